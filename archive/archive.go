@@ -0,0 +1,163 @@
+// Package archive reads zip and tar archives as read-only file systems.
+//
+// An opened archive is an fs.FS, so it can be walked and read with the
+// standard io/fs functions, and its files can be extracted to disk, for
+// example to hand them to a viewer:
+//
+//	a, err := archive.Open("release.tar.gz")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer a.Close()
+//
+//	entries, _ := a.ReadDir("bin")
+//	for _, e := range entries {
+//		fmt.Println(e.Name())
+//	}
+//	path, err := a.ExtractTemp("README.md")
+//
+// Supported formats are .zip, .tar, and gzip-compressed tar (.tar.gz and
+// .tgz), chosen by file name.
+package archive
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnsupported is returned by Open for files that aren't a supported
+// archive format.
+var ErrUnsupported = errors.New("archive: unsupported format")
+
+type format int
+
+const (
+	formatNone format = iota
+	formatZip
+	formatTar
+	formatTarGz
+)
+
+func formatOf(name string) format {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return formatZip
+	case strings.HasSuffix(name, ".tar"):
+		return formatTar
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return formatTarGz
+	}
+	return formatNone
+}
+
+// IsArchive reports whether name has the extension of a supported archive
+// format.
+func IsArchive(name string) bool {
+	return formatOf(name) != formatNone
+}
+
+// FS is an open archive. Paths inside it are slash-separated and relative
+// to the archive root, which is ".".
+type FS struct {
+	fsys   fs.FS
+	closer io.Closer
+}
+
+// Open opens the archive at path.
+func Open(path string) (*FS, error) {
+	switch formatOf(path) {
+	case formatZip:
+		r, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, err
+		}
+		return &FS{fsys: r, closer: r}, nil
+	case formatTar:
+		t, err := openTar(path, false)
+		if err != nil {
+			return nil, err
+		}
+		return &FS{fsys: t}, nil
+	case formatTarGz:
+		t, err := openTar(path, true)
+		if err != nil {
+			return nil, err
+		}
+		return &FS{fsys: t}, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: path, Err: ErrUnsupported}
+}
+
+// Open opens the named file or directory in the archive.
+func (a *FS) Open(name string) (fs.File, error) {
+	return a.fsys.Open(name)
+}
+
+// ReadDir returns the entries of the named directory, sorted by name.
+func (a *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(a.fsys, name)
+}
+
+// Stat returns information about the named file or directory.
+func (a *FS) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(a.fsys, name)
+}
+
+// Close releases the archive file.
+func (a *FS) Close() error {
+	if a.closer != nil {
+		return a.closer.Close()
+	}
+	return nil
+}
+
+// Extract copies the named file into dir, keeping its base name, and
+// returns the path of the copy.
+func (a *FS) Extract(name, dir string) (string, error) {
+	src, err := a.fsys.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return "", &fs.PathError{Op: "extract", Path: name, Err: errors.New("is a directory")}
+	}
+
+	dst := filepath.Join(dir, path.Base(name))
+	f, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm()|0o600)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(f, src); err != nil {
+		f.Close()
+		return "", err
+	}
+	return dst, f.Close()
+}
+
+// ExtractTemp extracts the named file into a new temporary directory and
+// returns the path of the copy. The caller should remove the directory,
+// filepath.Dir of the result, when done with it.
+func (a *FS) ExtractTemp(name string) (string, error) {
+	dir, err := os.MkdirTemp("", "archive-*")
+	if err != nil {
+		return "", err
+	}
+	p, err := a.Extract(name, dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return p, nil
+}
@@ -0,0 +1,124 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+var testFiles = map[string]string{
+	"README.md":       "# hello\n",
+	"bin/tool":        "#!/bin/sh\n",
+	"src/lib/util.go": "package lib\n",
+}
+
+func writeZip(t *testing.T, path string) {
+	t.Helper()
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	zw := zip.NewWriter(f)
+	for name, content := range testFiles {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = io.WriteString(w, content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	assert.NoError(t, f.Close())
+}
+
+func writeTar(t *testing.T, path string, gzipped bool) {
+	t.Helper()
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	var w io.Writer = f
+	var gz *gzip.Writer
+	if gzipped {
+		gz = gzip.NewWriter(f)
+		w = gz
+	}
+	tw := tar.NewWriter(w)
+	// Only some directories have their own entries, and names vary in form
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "./bin/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	for name, content := range testFiles {
+		if name == "README.md" {
+			name = "/" + name
+		}
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}))
+		_, err := io.WriteString(tw, content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "../escape", Typeflag: tar.TypeReg}))
+	assert.NoError(t, tw.Close())
+	if gz != nil {
+		assert.NoError(t, gz.Close())
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+	paths := map[string]func(string){
+		"a.zip":    func(p string) { writeZip(t, p) },
+		"a.tar":    func(p string) { writeTar(t, p, false) },
+		"a.tar.gz": func(p string) { writeTar(t, p, true) },
+		"a.tgz":    func(p string) { writeTar(t, p, true) },
+	}
+	for name, write := range paths {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			write(path)
+			assert.True(t, IsArchive(path))
+
+			a, err := Open(path)
+			assert.NoError(t, err)
+			defer a.Close()
+			assert.NoError(t, fstest.TestFS(a, "README.md", "bin/tool", "src/lib/util.go"))
+
+			entries, err := a.ReadDir(".")
+			assert.NoError(t, err)
+			var names []string
+			for _, e := range entries {
+				names = append(names, e.Name())
+			}
+			assert.Equal(t, []string{"README.md", "bin", "src"}, names)
+
+			data, err := fs.ReadFile(a, "src/lib/util.go")
+			assert.NoError(t, err)
+			assert.Equal(t, "package lib\n", string(data))
+		})
+	}
+}
+
+func TestOpen_Unsupported(t *testing.T) {
+	assert.False(t, IsArchive("notes.txt"))
+	_, err := Open("notes.txt")
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
+
+func TestExtractTemp(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.tar.gz")
+	writeTar(t, path, true)
+	a, err := Open(path)
+	assert.NoError(t, err)
+	defer a.Close()
+
+	extracted, err := a.ExtractTemp("bin/tool")
+	assert.NoError(t, err)
+	defer os.RemoveAll(filepath.Dir(extracted))
+	assert.Equal(t, "tool", filepath.Base(extracted))
+	data, err := os.ReadFile(extracted)
+	assert.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\n", string(data))
+
+	_, err = a.ExtractTemp("bin")
+	assert.Error(t, err)
+}
@@ -0,0 +1,207 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// tarFS is a tar archive indexed by path. The index is built once; files
+// are read by streaming the archive up to their header, since a compressed
+// stream can't be seeked.
+type tarFS struct {
+	path    string
+	gzipped bool
+	entries map[string]*tarEntry
+}
+
+type tarEntry struct {
+	info     fs.FileInfo
+	index    int      // position of the header in the archive; -1 for directories
+	children []string // names of the entries in a directory
+}
+
+func openTar(file string, gzipped bool) (*tarFS, error) {
+	t := &tarFS{
+		path:    file,
+		gzipped: gzipped,
+		entries: map[string]*tarEntry{".": {info: dirInfo("."), index: -1}},
+	}
+	err := t.scan(func(i int, hdr *tar.Header, _ *tar.Reader) bool {
+		name := cleanName(hdr.Name)
+		if name == "" {
+			return true
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			t.addDir(name)
+		case tar.TypeReg, tar.TypeRegA:
+			t.addDir(path.Dir(name))
+			if e, ok := t.entries[name]; !ok {
+				t.addChild(name)
+			} else if e.index < 0 {
+				return true // a directory has the same name
+			}
+			// A later entry with the same name replaces an earlier one
+			t.entries[name] = &tarEntry{info: hdr.FileInfo(), index: i}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// scan calls fn for each header in the archive until fn returns false.
+func (t *tarFS) scan(fn func(i int, hdr *tar.Header, tr *tar.Reader) bool) error {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if t.gzipped {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	for i := 0; ; i++ {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !fn(i, hdr, tr) {
+			return nil
+		}
+	}
+}
+
+// addDir adds a directory and its missing parents.
+func (t *tarFS) addDir(name string) {
+	if e, ok := t.entries[name]; ok {
+		if e.index < 0 {
+			return
+		}
+		// A file and a directory have the same name; the directory wins
+		e.info, e.index = dirInfo(name), -1
+		return
+	}
+	t.addDir(path.Dir(name))
+	t.addChild(name)
+	t.entries[name] = &tarEntry{info: dirInfo(name), index: -1}
+}
+
+func (t *tarFS) addChild(name string) {
+	parent := t.entries[path.Dir(name)]
+	parent.children = append(parent.children, name)
+}
+
+// cleanName turns a header name into an fs path, or "" if it can't be one.
+func cleanName(name string) string {
+	name = path.Clean(strings.TrimLeft(name, "/"))
+	if name == "." || !fs.ValidPath(name) {
+		return ""
+	}
+	return name
+}
+
+func (t *tarFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	e, ok := t.entries[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if e.index < 0 {
+		return &tarDir{fsys: t, entry: e}, nil
+	}
+
+	// Stream the archive up to the file's header in the background, then
+	// hand its contents to the returned file through a pipe
+	pr, pw := io.Pipe()
+	go func() {
+		err := t.scan(func(i int, _ *tar.Header, tr *tar.Reader) bool {
+			if i != e.index {
+				return true
+			}
+			_, err := io.Copy(pw, tr)
+			pw.CloseWithError(err)
+			return false
+		})
+		pw.CloseWithError(err)
+	}()
+	return &tarFile{info: e.info, r: pr}, nil
+}
+
+// tarFile is an open file in a tar archive.
+type tarFile struct {
+	info fs.FileInfo
+	r    *io.PipeReader
+}
+
+func (f *tarFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *tarFile) Read(p []byte) (int, error) { return f.r.Read(p) }
+func (f *tarFile) Close() error               { return f.r.Close() }
+
+// tarDir is an open directory in a tar archive.
+type tarDir struct {
+	fsys    *tarFS
+	entry   *tarEntry
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *tarDir) Stat() (fs.FileInfo, error) { return d.entry.info, nil }
+func (d *tarDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.entry.info.Name(), Err: errors.New("is a directory")}
+}
+func (d *tarDir) Close() error { return nil }
+
+func (d *tarDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if d.entries == nil {
+		d.entries = make([]fs.DirEntry, 0, len(d.entry.children))
+		for _, name := range d.entry.children {
+			d.entries = append(d.entries, fs.FileInfoToDirEntry(d.fsys.entries[name].info))
+		}
+		sort.Slice(d.entries, func(i, j int) bool { return d.entries[i].Name() < d.entries[j].Name() })
+	}
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(rest))
+	d.offset += n
+	return rest[:n], nil
+}
+
+// dirInfo describes a directory, including ones implied by file paths.
+type dirInfo string
+
+func (d dirInfo) Name() string       { return path.Base(string(d)) }
+func (d dirInfo) Size() int64        { return 0 }
+func (d dirInfo) Mode() fs.FileMode  { return fs.ModeDir | 0o555 }
+func (d dirInfo) ModTime() time.Time { return time.Time{} }
+func (d dirInfo) IsDir() bool        { return true }
+func (d dirInfo) Sys() any           { return nil }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"time"
 
 	"github.com/deepnoodle-ai/wonton/color"
 )
@@ -171,6 +172,21 @@ func (c *Context) Float64(name string) float64 {
 	return 0
 }
 
+// Duration returns a flag value as a time.Duration.
+func (c *Context) Duration(name string) time.Duration {
+	if v, ok := c.flags[name]; ok {
+		switch val := v.(type) {
+		case time.Duration:
+			return val
+		case string:
+			if d, err := time.ParseDuration(val); err == nil {
+				return d
+			}
+		}
+	}
+	return 0
+}
+
 // Bool returns a flag value as a bool.
 func (c *Context) Bool(name string) bool {
 	if v, ok := c.flags[name]; ok {
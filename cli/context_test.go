@@ -3,8 +3,10 @@ package cli
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/secrets"
 )
 
 func newTestContext(flags map[string]any, args ...string) *Context {
@@ -33,6 +35,19 @@ func TestContextIntParsesString(t *testing.T) {
 	assert.Equal(t, 42, ctx.Int("count"))
 }
 
+func TestContextDurationParsesString(t *testing.T) {
+	ctx := newTestContext(map[string]any{
+		"fromString":   "1m30s",
+		"fromDuration": 5 * time.Second,
+		"invalid":      "soon",
+	})
+
+	assert.Equal(t, 90*time.Second, ctx.Duration("fromString"))
+	assert.Equal(t, 5*time.Second, ctx.Duration("fromDuration"))
+	assert.Equal(t, time.Duration(0), ctx.Duration("invalid"))
+	assert.Equal(t, time.Duration(0), ctx.Duration("missing"))
+}
+
 func TestContextFloat64ParsesVariousTypes(t *testing.T) {
 	ctx := newTestContext(map[string]any{
 		"fromInt":   5,
@@ -217,3 +232,18 @@ func TestContextIsSetWithNilSetFlags(t *testing.T) {
 	}
 	assert.False(t, ctx.IsSet("test"))
 }
+
+func TestContextSecret(t *testing.T) {
+	store := secrets.NewMemoryStore()
+	store.Set("tool", "token", "s3cret")
+	ctx := newTestContext(nil)
+
+	value, err := ctx.Secret(store, "tool", "token", "Token: ")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+
+	// Without a terminal to prompt on, a missing secret is an error
+	_, err = ctx.Secret(store, "tool", "api-key", "API key: ")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no api-key secret is stored for tool")
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/deepnoodle-ai/wonton/tui"
+	"golang.org/x/term"
+)
+
+// Page writes content to stdout through the user's pager, for output that
+// may run past a screenful, like a long table or list. The content can be a
+// string, a []byte, or a tui.View, which is rendered at the terminal width
+// (in plain text when color is disabled). Anything else is formatted with
+// fmt.Sprint.
+//
+// The pager is $PAGER, or "less" when it isn't set. Content is written
+// straight to stdout instead when stdout is not a terminal, when the
+// context is not interactive, when NO_PAGER is set, when it fits on the
+// screen, or when the pager can't be started.
+//
+//	app.Command("links").Run(func(ctx *cli.Context) error {
+//	    return ctx.Page(tui.Table(columns, nil).Rows(rows))
+//	})
+func (c *Context) Page(content any) error {
+	width, height, isTTY := c.stdoutSize()
+	text := c.pageText(content, width)
+	if !isTTY || !c.interactive || os.Getenv("NO_PAGER") != "" || strings.Count(text, "\n") < height {
+		_, err := io.WriteString(c.stdout, text)
+		return err
+	}
+	name, args := pagerCommand(os.Getenv("PAGER"))
+	ctx := c.context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdout = c.stdout
+	cmd.Stderr = c.stderr
+	cmd.Env = pagerEnv(os.Environ())
+	if err := cmd.Start(); err != nil {
+		_, err := io.WriteString(c.stdout, text)
+		return err
+	}
+	return cmd.Wait()
+}
+
+// stdoutSize returns the size of the terminal stdout writes to, and false
+// if stdout is not a terminal.
+func (c *Context) stdoutSize() (width, height int, ok bool) {
+	f, isFile := c.stdout.(*os.File)
+	if !isFile || !isTerminal(f) {
+		return 0, 0, false
+	}
+	width, height, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0, 0, false
+	}
+	return width, height, true
+}
+
+// pageText returns content as text ending in a newline. A width of 0
+// renders views at the default width.
+func (c *Context) pageText(content any, width int) string {
+	var text string
+	switch v := content.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	case tui.View:
+		if c.app != nil && c.app.colorEnabled {
+			text = tui.Sprint(v, tui.PrintConfig{Width: width})
+		} else {
+			text = tui.RenderPlain(v, width)
+		}
+	default:
+		text = fmt.Sprint(v)
+	}
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return text
+}
+
+// pagerCommand returns the command to run for a $PAGER value.
+func pagerCommand(pager string) (string, []string) {
+	fields := strings.Fields(pager)
+	if len(fields) == 0 {
+		return "less", nil
+	}
+	return fields[0], fields[1:]
+}
+
+// pagerEnv returns the environment for the pager. Unless the user has
+// configured less, it passes colors through (-R), quits if the content
+// fits after all (-F), and leaves the content on screen on exit (-X).
+func pagerEnv(environ []string) []string {
+	for _, kv := range environ {
+		if strings.HasPrefix(kv, "LESS=") {
+			return environ
+		}
+	}
+	return append(environ, "LESS=FRX")
+}
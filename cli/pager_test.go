@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+func TestPageWritesDirectlyWhenNotTerminal(t *testing.T) {
+	ctx := newTestContext(nil)
+	ctx.interactive = true
+	assert.NoError(t, ctx.Page("line 1\nline 2"))
+	assert.Equal(t, ctx.stdout.(*bytes.Buffer).String(), "line 1\nline 2\n")
+}
+
+func TestPageRendersViewsPlain(t *testing.T) {
+	ctx := newTestContext(nil)
+	ctx.app = New("test")
+	ctx.app.colorEnabled = false
+	view := tui.Stack(tui.Text("first").Bold(), tui.Text("second"))
+	assert.NoError(t, ctx.Page(view))
+	assert.Equal(t, ctx.stdout.(*bytes.Buffer).String(), "first\nsecond\n")
+}
+
+func TestPageFormatsOtherValues(t *testing.T) {
+	ctx := newTestContext(nil)
+	assert.NoError(t, ctx.Page(42))
+	assert.Equal(t, ctx.stdout.(*bytes.Buffer).String(), "42\n")
+}
+
+func TestPagerCommand(t *testing.T) {
+	name, args := pagerCommand("")
+	assert.Equal(t, name, "less")
+	assert.Equal(t, len(args), 0)
+
+	name, args = pagerCommand("  less -S -R ")
+	assert.Equal(t, name, "less")
+	assert.Equal(t, args, []string{"-S", "-R"})
+}
+
+func TestPagerEnv(t *testing.T) {
+	assert.Equal(t, pagerEnv([]string{"HOME=/root"}), []string{"HOME=/root", "LESS=FRX"})
+	assert.Equal(t, pagerEnv([]string{"LESS=-S"}), []string{"LESS=-S"})
+}
@@ -1,6 +1,9 @@
 package cli
 
 import (
+	"errors"
+
+	"github.com/deepnoodle-ai/wonton/secrets"
 	"github.com/deepnoodle-ai/wonton/tui"
 )
 
@@ -70,6 +73,18 @@ func (c *Context) SelectString(title string, options ...string) (string, error)
 //	    return err
 //	}
 func (c *Context) Input(prompt string) (string, error) {
+	return c.input(prompt, 0)
+}
+
+// Password displays a text input prompt that masks what is typed, for
+// passphrases and tokens.
+//
+//	passphrase, err := ctx.Password("Passphrase: ")
+func (c *Context) Password(prompt string) (string, error) {
+	return c.input(prompt, '•')
+}
+
+func (c *Context) input(prompt string, mask rune) (string, error) {
 	if !c.Interactive() {
 		return "", Error("interactive terminal required for input prompts")
 	}
@@ -79,6 +94,7 @@ func (c *Context) Input(prompt string) (string, error) {
 
 	app := &inputPrompt{
 		prompt: prompt,
+		mask:   mask,
 		value:  &value,
 		done:   &done,
 	}
@@ -113,6 +129,45 @@ func (c *Context) Confirm(message string) (bool, error) {
 	return idx == 0, nil
 }
 
+// Secret returns the secret stored under service and key. The first time,
+// when there is none, it prompts for the secret with a masked input and
+// stores the answer, so tools ask for a token once and never keep it in a
+// plaintext file:
+//
+//	store, err := secrets.Open("mytool", secrets.Options{Passphrase: func() (string, error) {
+//	    return ctx.Password("Passphrase for the secrets file: ")
+//	}})
+//	if err != nil {
+//	    return err
+//	}
+//	token, err := ctx.Secret(store, "mytool", "github-token", "GitHub token: ")
+//
+// Without an interactive terminal, a missing secret is an error.
+func (c *Context) Secret(store secrets.Store, service, key, prompt string) (string, error) {
+	value, err := store.Get(service, key)
+	if err == nil {
+		return value, nil
+	}
+	if !errors.Is(err, secrets.ErrNotFound) {
+		return "", err
+	}
+	if !c.Interactive() {
+		return "", Errorf("no %s secret is stored for %s", key, service).
+			Hint("run the command in an interactive terminal to enter it")
+	}
+	value, err = c.Password(prompt)
+	if err != nil {
+		return "", err
+	}
+	if value == "" {
+		return "", Errorf("no %s entered", key)
+	}
+	if err := store.Set(service, key, value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
 // selectPrompt implements tui.Application for selection prompts
 type selectPrompt struct {
 	title    string
@@ -179,6 +234,7 @@ func (p *selectPrompt) HandleEvent(event tui.Event) []tui.Cmd {
 // inputPrompt implements tui.Application for text input prompts
 type inputPrompt struct {
 	prompt string
+	mask   rune
 	value  *string
 	done   *bool
 }
@@ -187,7 +243,7 @@ func (p *inputPrompt) View() tui.View {
 	return tui.Stack(
 		tui.Text("%s", p.prompt).Bold(),
 		tui.Spacer().MinHeight(1),
-		tui.Input(p.value).Width(40),
+		tui.Input(p.value).Mask(p.mask).Width(40),
 		tui.Spacer().MinHeight(1),
 		tui.Text("Enter to submit, Esc to cancel").Dim(),
 	)
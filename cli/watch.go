@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+// WatchFlag returns the --watch (-w) flag read by Watch. Customize it like
+// any other duration flag.
+func WatchFlag() *durationBuilder {
+	return Duration("watch", "w").Help("Re-run every interval, highlighting changes (e.g. 2s)")
+}
+
+// Watch returns a handler for commands whose output is worth watching. fn
+// builds the output. Without --watch, the handler prints it once. With
+// --watch set to an interval, it runs fn again every interval and redraws
+// the output in place, highlighting the cells that changed since the
+// previous run, like watch -d. Watching stops on Ctrl+C, when the context
+// is canceled, or when fn returns an error.
+//
+// When stdout is not a terminal, each run's output is printed in turn
+// instead.
+//
+//	app.Command("status").
+//	    Flags(cli.WatchFlag()).
+//	    Run(cli.Watch(func(ctx *cli.Context) (tui.View, error) {
+//	        return tui.Text("%d jobs queued", queue.Len()), nil
+//	    }))
+func Watch(fn func(*Context) (tui.View, error)) Handler {
+	return func(ctx *Context) error {
+		interval := ctx.Duration("watch")
+		if ctx.IsSet("watch") && interval <= 0 {
+			return fmt.Errorf("invalid value for --watch: %s", ctx.String("watch"))
+		}
+		if interval <= 0 {
+			view, err := fn(ctx)
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(ctx.stdout, ctx.pageText(view, 0))
+			return err
+		}
+		return ctx.watch(interval, fn)
+	}
+}
+
+// watchHighlight is the style merged into cells that changed between runs.
+var watchHighlight = tui.NewStyle().WithReverse()
+
+func (c *Context) watch(interval time.Duration, fn func(*Context) (tui.View, error)) error {
+	parent := c.context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt)
+	defer stop()
+
+	width, _, isTTY := c.stdoutSize()
+	var live *tui.LivePrinter
+	if isTTY {
+		live = tui.NewLivePrinter(tui.PrintConfig{Width: width, Output: c.stdout})
+		live.HighlightChanges(watchHighlight)
+		defer live.Stop()
+	}
+	header := tui.Text("Every %s · Ctrl+C to stop", interval).Dim()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		view, err := fn(c)
+		if err != nil {
+			return err
+		}
+		if live != nil {
+			if err := live.Update(tui.Stack(header, tui.Text(""), view)); err != nil {
+				return err
+			}
+		} else if _, err := io.WriteString(c.stdout, c.pageText(view, 0)+"\n"); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+func TestWatchPrintsOnceWithoutFlag(t *testing.T) {
+	runs := 0
+	app := New("test")
+	app.Command("status").
+		Flags(WatchFlag()).
+		Run(Watch(func(ctx *Context) (tui.View, error) {
+			runs++
+			return tui.Text("run %d", runs), nil
+		}))
+
+	result := app.Test(t, TestArgs("status"))
+	assert.True(t, result.Success())
+	assert.Equal(t, 1, runs)
+	assert.Equal(t, "run 1\n", result.Stdout)
+}
+
+func TestWatchRerunsUntilCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runs := 0
+	var stdout bytes.Buffer
+	app := New("test").SetStdout(&stdout)
+	app.Command("status").
+		Flags(WatchFlag()).
+		Run(Watch(func(*Context) (tui.View, error) {
+			runs++
+			if runs == 3 {
+				cancel()
+			}
+			return tui.Text("run %d", runs), nil
+		}))
+
+	start := time.Now()
+	assert.NoError(t, app.ExecuteContext(ctx, []string{"status", "--watch", "10ms"}))
+	assert.Equal(t, 3, runs)
+	assert.True(t, time.Since(start) >= 20*time.Millisecond)
+	// Output that isn't to a terminal is printed run after run
+	assert.Equal(t, "run 1\n\nrun 2\n\nrun 3\n\n", stdout.String())
+}
+
+func TestWatchRejectsInvalidInterval(t *testing.T) {
+	app := New("test")
+	app.Command("status").
+		Flags(WatchFlag()).
+		Run(Watch(func(*Context) (tui.View, error) {
+			return tui.Text("status"), nil
+		}))
+
+	result := app.Test(t, TestArgs("status", "--watch", "soon"))
+	assert.False(t, result.Success())
+	assert.Contains(t, result.Err.Error(), "invalid value for --watch")
+}
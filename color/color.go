@@ -34,6 +34,7 @@ package color
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 )
 
@@ -178,6 +179,26 @@ func (rgb RGB) Background() string {
 	return rgb.BackgroundSeq()
 }
 
+// Luminance returns the color's relative luminance as defined by WCAG, from
+// 0 for black to 1 for white.
+func (rgb RGB) Luminance() float64 {
+	linear := func(c uint8) float64 {
+		v := float64(c) / 255
+		if v <= 0.04045 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(rgb.R) + 0.7152*linear(rgb.G) + 0.0722*linear(rgb.B)
+}
+
+// IsDark reports whether the color is dark, so that light text reads better
+// on it than dark text.
+func (rgb RGB) IsDark() bool {
+	// Black and white text contrast equally at a luminance of about 0.18
+	return rgb.Luminance() < 0.18
+}
+
 // Apply applies RGB color to text and automatically appends a reset sequence.
 // If background is true, the color is applied as background; otherwise as foreground.
 //
@@ -103,6 +103,15 @@ func TestRGB_Background(t *testing.T) {
 	assert.Equal(t, "\033[48;2;127;0;255m", output)
 }
 
+func TestRGB_IsDark(t *testing.T) {
+	assert.Equal(t, 0.0, color.NewRGB(0, 0, 0).Luminance())
+	assert.Equal(t, 1.0, color.NewRGB(255, 255, 255).Luminance())
+	assert.True(t, color.NewRGB(30, 30, 46).IsDark())
+	assert.True(t, color.NewRGB(0, 0, 255).IsDark())
+	assert.False(t, color.NewRGB(253, 246, 227).IsDark())
+	assert.False(t, color.NewRGB(255, 255, 0).IsDark())
+}
+
 func TestRGB_Apply_Foreground(t *testing.T) {
 	rgb := color.NewRGB(255, 128, 0)
 	text := rgb.Apply("Test", false)
@@ -0,0 +1,170 @@
+package color
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// Profile is the range of colors a terminal can show.
+type Profile int
+
+const (
+	TrueColor Profile = iota // 24-bit RGB colors
+	ANSI256                  // The 256-color palette
+	ANSI16                   // The 16 standard and bright colors
+)
+
+// String returns the profile's name.
+func (p Profile) String() string {
+	switch p {
+	case ANSI256:
+		return "256"
+	case ANSI16:
+		return "16"
+	default:
+		return "truecolor"
+	}
+}
+
+// DetectProfile guesses the terminal's color profile from the environment:
+// COLORTERM=truecolor or 24bit, terminals known to support 24-bit color,
+// and TERM names ending in -direct mean TrueColor; other TERM names with
+// 256color mean ANSI256; anything else gets ANSI16.
+func DetectProfile() Profile {
+	return detectProfile(os.Getenv)
+}
+
+// truecolorTerminals are TERM_PROGRAM values and TERM prefixes of terminals
+// that support 24-bit color without setting COLORTERM.
+var truecolorTerminals = []string{
+	"iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper", "Tabby",
+	"xterm-kitty", "xterm-ghostty", "alacritty", "foot", "wezterm", "contour",
+}
+
+func detectProfile(getenv func(string) string) Profile {
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+	term := getenv("TERM")
+	if getenv("WT_SESSION") != "" || (term == "" && runtime.GOOS == "windows") {
+		return TrueColor // Windows Terminal and the Windows 10+ console
+	}
+	if strings.HasSuffix(term, "-direct") {
+		return TrueColor
+	}
+	for _, name := range truecolorTerminals {
+		if getenv("TERM_PROGRAM") == name || strings.HasPrefix(term, name) {
+			return TrueColor
+		}
+	}
+	if strings.Contains(term, "256color") {
+		return ANSI256
+	}
+	return ANSI16
+}
+
+// Convert returns the color of the profile closest to rgb, and false for
+// TrueColor, which shows rgb as it is.
+func (p Profile) Convert(rgb RGB) (Color, bool) {
+	switch p {
+	case ANSI256:
+		return rgb.ToANSI256(), true
+	case ANSI16:
+		return rgb.ToANSI16(), true
+	}
+	return NoColor, false
+}
+
+// Fit returns the color of the profile closest to c, converting colors of
+// the 256-color palette for ANSI16.
+func (p Profile) Fit(c Color) Color {
+	if p == ANSI16 && c >= 16 {
+		return c.RGB().ToANSI16()
+	}
+	return c
+}
+
+// ansi16 holds the colors xterm shows for the 16 standard and bright colors.
+// Terminals vary, but all keep them close enough to match against.
+var ansi16 = [16]RGB{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the channel values of the 6x6x6 color cube (16-231).
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// RGB returns the color the terminal shows for c, as for xterm, or black for
+// NoColor.
+func (c Color) RGB() RGB {
+	switch {
+	case c < 0:
+		return RGB{}
+	case c < 16:
+		return ansi16[c]
+	case c < 232:
+		n := int(c) - 16
+		return RGB{cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]}
+	case c < 256:
+		v := uint8(8 + 10*(int(c)-232))
+		return RGB{v, v, v}
+	}
+	return RGB{}
+}
+
+// ToANSI256 returns the closest color of the 256-color palette, from its
+// color cube or its grays. The first 16 colors are left out, as terminals
+// change them.
+func (rgb RGB) ToANSI256() Color {
+	// The closest level of the cube for each channel
+	level := func(v uint8) int {
+		best := 0
+		for i, l := range cubeLevels {
+			if absDiff(v, l) < absDiff(v, cubeLevels[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+	r, g, b := level(rgb.R), level(rgb.G), level(rgb.B)
+	cube := Color(16 + 36*r + 6*g + b)
+
+	// The closest of the 24 grays, 8 to 238 in steps of 10
+	avg := (int(rgb.R) + int(rgb.G) + int(rgb.B)) / 3
+	step := min(max((avg-3)/10, 0), 23)
+	gray := Color(232 + step)
+
+	if distance(rgb, gray.RGB()) < distance(rgb, cube.RGB()) {
+		return gray
+	}
+	return cube
+}
+
+// ToANSI16 returns the closest of the 16 standard and bright colors.
+func (rgb RGB) ToANSI16() Color {
+	best := 0
+	for i, c := range ansi16 {
+		if distance(rgb, c) < distance(rgb, ansi16[best]) {
+			best = i
+		}
+	}
+	return Color(best)
+}
+
+func absDiff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
+
+// distance returns how different two colors look, as a squared distance
+// weighted for the eye's sensitivity to each channel.
+func distance(a, b RGB) int {
+	dr, dg, db := absDiff(a.R, b.R), absDiff(a.G, b.G), absDiff(a.B, b.B)
+	return 2*dr*dr + 4*dg*dg + 3*db*db
+}
@@ -0,0 +1,49 @@
+package color_test
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/color"
+)
+
+func TestDetectProfile(t *testing.T) {
+	detect := func(vars ...string) color.Profile {
+		for _, name := range []string{"COLORTERM", "TERM", "TERM_PROGRAM", "WT_SESSION"} {
+			t.Setenv(name, "")
+		}
+		for i := 0; i < len(vars); i += 2 {
+			t.Setenv(vars[i], vars[i+1])
+		}
+		return color.DetectProfile()
+	}
+	assert.Equal(t, color.TrueColor, detect("COLORTERM", "truecolor", "TERM", "xterm"))
+	assert.Equal(t, color.TrueColor, detect("TERM", "xterm-kitty"))
+	assert.Equal(t, color.TrueColor, detect("TERM", "xterm-256color", "TERM_PROGRAM", "iTerm.app"))
+	assert.Equal(t, color.ANSI256, detect("TERM", "screen-256color"))
+	assert.Equal(t, color.ANSI256, detect("TERM", "xterm-256color", "TERM_PROGRAM", "Apple_Terminal"))
+	assert.Equal(t, color.ANSI16, detect("TERM", "linux"))
+}
+
+func TestRGB_ToANSI256(t *testing.T) {
+	assert.Equal(t, color.Palette(196), color.NewRGB(255, 0, 0).ToANSI256())
+	assert.Equal(t, color.Palette(16), color.NewRGB(0, 0, 0).ToANSI256())
+	assert.Equal(t, color.Palette(231), color.NewRGB(255, 255, 255).ToANSI256())
+	// Grays go to the gray ramp, which is finer than the cube's
+	assert.Equal(t, color.Palette(244), color.NewRGB(128, 128, 128).ToANSI256())
+	assert.Equal(t, color.Palette(75), color.NewRGB(97, 175, 239).ToANSI256())
+}
+
+func TestRGB_ToANSI16(t *testing.T) {
+	assert.Equal(t, color.BrightRed, color.NewRGB(250, 20, 20).ToANSI16())
+	assert.Equal(t, color.Red, color.NewRGB(180, 10, 10).ToANSI16())
+	assert.Equal(t, color.BrightBlack, color.NewRGB(128, 128, 128).ToANSI16())
+	assert.Equal(t, color.White, color.NewRGB(220, 223, 228).ToANSI16())
+
+	// Palette colors are converted too
+	assert.Equal(t, color.BrightRed, color.ANSI16.Fit(color.Palette(196)))
+	assert.Equal(t, color.Palette(196), color.ANSI256.Fit(color.Palette(196)))
+	c, ok := color.TrueColor.Convert(color.NewRGB(1, 2, 3))
+	assert.False(t, ok)
+	assert.Equal(t, color.NoColor, c)
+}
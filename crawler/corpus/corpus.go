@@ -0,0 +1,330 @@
+// Package corpus saves crawled pages as a Markdown corpus: one file per page
+// with its metadata in front matter, under a directory per host, and a
+// manifest listing every page saved. It is meant to feed documentation
+// mirrors and LLM ingestion pipelines straight from a crawl.
+//
+// A Writer skips pages it has already saved and pages whose Markdown is
+// identical to one it has saved, and it can resume an interrupted crawl: its
+// KnownURLs keep the crawler from fetching saved pages again, and its
+// Frontier lists the links found on them that were never saved.
+//
+// Basic usage:
+//
+//	w, err := corpus.Open("docs-mirror")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer w.Close()
+//
+//	c, err := crawler.New(crawler.Options{
+//		DefaultFetcher: fetch.NewHTTPFetcher(fetch.HTTPFetcherOptions{}),
+//		KnownURLs:      w.KnownURLs(),
+//	})
+//	seeds := append([]string{"https://example.com/docs"}, w.Frontier()...)
+//	err = c.Crawl(ctx, seeds, func(ctx context.Context, result *crawler.Result) {
+//		if _, err := w.Save(result); err != nil {
+//			log.Print(err)
+//		}
+//	})
+//
+// The directory looks like this:
+//
+//	docs-mirror/
+//	    manifest.jsonl             One Entry per line, in the order saved
+//	    example.com/index.md
+//	    example.com/docs/intro.md
+package corpus
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/crawler"
+	"github.com/deepnoodle-ai/wonton/htmltomd"
+)
+
+// ManifestFile is the name of the manifest in a corpus directory.
+const ManifestFile = "manifest.jsonl"
+
+// ErrSkipped is returned by Save for results it doesn't save: failed
+// fetches and pages without content.
+var ErrSkipped = errors.New("corpus: nothing to save")
+
+// Entry is a page in the manifest.
+type Entry struct {
+	// URL is the page's URL, as the crawler normalized it
+	URL string `json:"url"`
+
+	// Path is the page's file, relative to the corpus directory, and empty
+	// for duplicates
+	Path string `json:"path,omitempty"`
+
+	// Title and Description come from the page's metadata
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Hash is the SHA-256 of the page's Markdown, in hex
+	Hash string `json:"hash"`
+
+	// DuplicateOf is the URL of an earlier page with the same Markdown, whose
+	// file this page shares
+	DuplicateOf string `json:"duplicate_of,omitempty"`
+
+	// Links are the links found on the page, for resuming
+	Links []string `json:"links,omitempty"`
+
+	// SavedAt is when the page was saved
+	SavedAt time.Time `json:"saved_at"`
+}
+
+// Writer saves crawled pages to a corpus directory. All methods are
+// thread-safe, so Save can be called from the crawler's callback directly.
+type Writer struct {
+	dir      string
+	mu       sync.Mutex
+	manifest *os.File
+	entries  []Entry
+	byURL    map[string]int    // Index into entries
+	byHash   map[string]string // URL of the first page with each hash
+	paths    map[string]bool   // Files in use
+}
+
+// Open opens the corpus in dir, creating the directory if needed. If it
+// holds a manifest from an earlier crawl, the pages listed count as saved.
+func Open(dir string) (*Writer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	w := &Writer{
+		dir:    dir,
+		byURL:  map[string]int{},
+		byHash: map[string]string{},
+		paths:  map[string]bool{},
+	}
+	if err := w.load(); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, ManifestFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	w.manifest = f
+	return w, nil
+}
+
+// load reads the entries of an existing manifest. A last line cut short by
+// an interrupted crawl is ignored.
+func (w *Writer) load() error {
+	f, err := os.Open(filepath.Join(w.dir, ManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil || e.URL == "" {
+			continue
+		}
+		w.add(e)
+	}
+	return scanner.Err()
+}
+
+// add records e as saved.
+func (w *Writer) add(e Entry) {
+	w.byURL[e.URL] = len(w.entries)
+	w.entries = append(w.entries, e)
+	if _, ok := w.byHash[e.Hash]; !ok && e.DuplicateOf == "" {
+		w.byHash[e.Hash] = e.URL
+	}
+	if e.Path != "" {
+		w.paths[e.Path] = true
+	}
+}
+
+// Save writes the page of a crawl result to the corpus and adds it to the
+// manifest, returning its entry. The page's Markdown is the response's, or
+// is converted from its HTML. A page already saved is left as it is and its
+// earlier entry returned; a page with the same Markdown as an earlier one
+// is listed with DuplicateOf set instead of written again. Failed results
+// and pages without content return ErrSkipped.
+func (w *Writer) Save(result *crawler.Result) (Entry, error) {
+	if result == nil || result.URL == nil || result.Response == nil {
+		return Entry{}, ErrSkipped
+	}
+	if result.Response.HTML == "" && result.Response.Markdown == "" {
+		return Entry{}, ErrSkipped
+	}
+	rawURL := result.URL.String()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if i, ok := w.byURL[rawURL]; ok {
+		return w.entries[i], nil
+	}
+
+	markdown := result.Response.Markdown
+	if markdown == "" {
+		markdown = htmltomd.Convert(result.Response.HTML)
+	}
+	sum := sha256.Sum256([]byte(markdown))
+	meta := result.Response.Metadata
+	e := Entry{
+		URL:         rawURL,
+		Title:       meta.Title,
+		Description: meta.Description,
+		Hash:        hex.EncodeToString(sum[:]),
+		Links:       result.Links,
+		SavedAt:     time.Now().UTC(),
+	}
+
+	if first, ok := w.byHash[e.Hash]; ok {
+		e.DuplicateOf = first
+	} else {
+		e.Path = w.pathFor(result.URL, e.Hash)
+		file := filepath.Join(w.dir, filepath.FromSlash(e.Path))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return Entry{}, err
+		}
+		if err := os.WriteFile(file, []byte(frontMatter(e)+markdown), 0o644); err != nil {
+			return Entry{}, err
+		}
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return Entry{}, err
+	}
+	if _, err := w.manifest.Write(append(line, '\n')); err != nil {
+		return Entry{}, err
+	}
+	w.add(e)
+	return e, nil
+}
+
+// pathFor returns an unused file path for u: the host, then the URL path
+// with ".md" in place of any page extension like ".html", or "index.md" for
+// directories. Characters that aren't safe in file names become
+// underscores. A URL with a query, or whose path is taken, gets part of hash
+// added.
+func (w *Writer) pathFor(u *url.URL, hash string) string {
+	p := strings.Trim(u.Path, "/")
+	if p == "" || strings.HasSuffix(u.Path, "/") {
+		p = path.Join(p, "index")
+	}
+	switch path.Ext(p) {
+	case ".html", ".htm", ".php", ".asp", ".aspx":
+		p = strings.TrimSuffix(p, path.Ext(p))
+	}
+	if u.RawQuery != "" {
+		p += "-" + hash[:8]
+	}
+	p = path.Join(sanitize(u.Host), sanitize(p)) + ".md"
+	if w.paths[p] {
+		p = strings.TrimSuffix(p, ".md") + "-" + hash[:8] + ".md"
+	}
+	return p
+}
+
+// sanitize replaces characters that aren't safe in file names, keeping
+// slashes, and removes "." and ".." elements.
+func sanitize(p string) string {
+	mapped := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '-', r == '.', r == '_', r == '/':
+			return r
+		}
+		return '_'
+	}, p)
+	var parts []string
+	for _, part := range strings.Split(mapped, "/") {
+		if part != "" && part != "." && part != ".." {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return "_"
+	}
+	return strings.Join(parts, "/")
+}
+
+// frontMatter returns the YAML front matter of a page file.
+func frontMatter(e Entry) string {
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	fmt.Fprintf(&sb, "url: %s\n", strconv.Quote(e.URL))
+	if e.Title != "" {
+		fmt.Fprintf(&sb, "title: %s\n", strconv.Quote(e.Title))
+	}
+	if e.Description != "" {
+		fmt.Fprintf(&sb, "description: %s\n", strconv.Quote(e.Description))
+	}
+	fmt.Fprintf(&sb, "saved_at: %s\n", e.SavedAt.Format(time.RFC3339))
+	sb.WriteString("---\n\n")
+	return sb.String()
+}
+
+// Entries returns the pages saved, including those of earlier crawls, in
+// the order they were saved.
+func (w *Writer) Entries() []Entry {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]Entry(nil), w.entries...)
+}
+
+// KnownURLs returns the URLs of the pages saved, for crawler.Options so a
+// resumed crawl doesn't fetch them again.
+func (w *Writer) KnownURLs() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	urls := make([]string, len(w.entries))
+	for i, e := range w.entries {
+		urls[i] = e.URL
+	}
+	return urls
+}
+
+// Frontier returns the links found on saved pages that weren't saved
+// themselves, in the order found, to pass to Crawl when resuming. The
+// crawler still decides which of them to follow.
+func (w *Writer) Frontier() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	seen := map[string]bool{}
+	var frontier []string
+	for _, e := range w.entries {
+		for _, link := range e.Links {
+			if _, saved := w.byURL[strings.TrimSuffix(link, "/")]; saved || seen[link] {
+				continue
+			}
+			seen[link] = true
+			frontier = append(frontier, link)
+		}
+	}
+	return frontier
+}
+
+// Close closes the manifest.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.manifest.Close()
+}
@@ -0,0 +1,102 @@
+package corpus
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/crawler"
+	"github.com/deepnoodle-ai/wonton/fetch"
+)
+
+func result(rawURL, html string, links ...string) *crawler.Result {
+	u, _ := url.Parse(rawURL)
+	resp := &fetch.Response{URL: rawURL, HTML: html}
+	resp.Metadata.Title = "Title of " + u.Path
+	return &crawler.Result{URL: u, Response: resp, Links: links}
+}
+
+func TestWriter_Save(t *testing.T) {
+	dir := t.TempDir()
+	w, err := Open(dir)
+	assert.NoError(t, err)
+
+	e, err := w.Save(result("https://example.com", "<h1>Home</h1>", "https://example.com/docs/intro.html"))
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/index.md", e.Path)
+	data, err := os.ReadFile(filepath.Join(dir, "example.com", "index.md"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "url: \"https://example.com\"\ntitle: \"Title of \"\n")
+	assert.Contains(t, string(data), "---\n\n# Home")
+
+	e, err = w.Save(result("https://example.com/docs/intro.html", "<p>Intro</p>"))
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/docs/intro.md", e.Path)
+
+	// The same content under another URL is listed, not written again
+	e, err = w.Save(result("https://example.com/docs/intro?ref=nav", "<p>Intro</p>"))
+	assert.NoError(t, err)
+	assert.Equal(t, "", e.Path)
+	assert.Equal(t, "https://example.com/docs/intro.html", e.DuplicateOf)
+
+	// Saving a URL again keeps the first entry
+	again, err := w.Save(result("https://example.com", "<h1>Changed</h1>"))
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/index.md", again.Path)
+	assert.Equal(t, 3, len(w.Entries()))
+
+	_, err = w.Save(&crawler.Result{URL: &url.URL{Host: "x"}, Error: errors.New("timeout")})
+	assert.True(t, errors.Is(err, ErrSkipped))
+	assert.NoError(t, w.Close())
+}
+
+func TestWriter_Resume(t *testing.T) {
+	dir := t.TempDir()
+	w, err := Open(dir)
+	assert.NoError(t, err)
+	_, err = w.Save(result("https://example.com", "<p>Home</p>",
+		"https://example.com/a", "https://example.com/b"))
+	assert.NoError(t, err)
+	_, err = w.Save(result("https://example.com/a", "<p>A</p>", "https://example.com"))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	// A crawl cut off mid-write leaves a partial line, which is ignored
+	f, err := os.OpenFile(filepath.Join(dir, ManifestFile), os.O_APPEND|os.O_WRONLY, 0)
+	assert.NoError(t, err)
+	_, err = f.WriteString(`{"url":"https://exa`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	w, err = Open(dir)
+	assert.NoError(t, err)
+	defer w.Close()
+	assert.Equal(t, []string{"https://example.com", "https://example.com/a"}, w.KnownURLs())
+	assert.Equal(t, []string{"https://example.com/b"}, w.Frontier())
+
+	// The crawler skips the saved pages and picks up the frontier
+	mock := fetch.NewMockFetcher()
+	mock.AddResponse("https://example.com/b", &fetch.Response{HTML: "<p>B</p>"})
+	c, err := crawler.New(crawler.Options{
+		Workers:          1,
+		DefaultFetcher:   mock,
+		FollowBehavior:   crawler.FollowSameDomain,
+		RespectRobotsTxt: crawler.BoolPtr(false),
+		KnownURLs:        w.KnownURLs(),
+	})
+	assert.NoError(t, err)
+	var crawled []string
+	seeds := append([]string{"https://example.com"}, w.Frontier()...)
+	err = c.Crawl(context.Background(), seeds, func(ctx context.Context, r *crawler.Result) {
+		crawled = append(crawled, r.URL.String())
+		_, err := w.Save(r)
+		assert.NoError(t, err)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/b"}, crawled)
+	assert.Equal(t, 3, len(w.Entries()))
+}
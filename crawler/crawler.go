@@ -134,8 +134,10 @@ type Options struct {
 	// Use this to be respectful of target servers and avoid overwhelming them.
 	RequestDelay time.Duration
 
-	// KnownURLs is a list of URLs that are already known and should not be processed again.
-	// This is useful for resuming interrupted crawls.
+	// KnownURLs is a list of URLs that are already known and should not be processed again,
+	// even when given to Crawl or discovered as links. This is useful for resuming
+	// interrupted crawls: pass the URLs already saved here, and the links found on
+	// them but not yet crawled to Crawl.
 	KnownURLs []string
 
 	// ParserRules defines domain-specific parsers. When a URL matches a rule's pattern,
@@ -195,6 +197,16 @@ type Options struct {
 	// RobotsTxtUserAgent is the user agent string used when checking robots.txt rules.
 	// Defaults to "*" if not specified.
 	RobotsTxtUserAgent string
+
+	// MaxConcurrentPerHost limits how many URLs of one host are processed at
+	// once, so a crawl with many workers stays polite to each host. Workers
+	// wait for a free slot, and the waiting URLs count as throttled in the
+	// host's stats. Set to 0 for no limit.
+	MaxConcurrentPerHost int
+
+	// OnHostStats is called with a host's stats each time they change, for
+	// live per-host displays. See also GetHostStats.
+	OnHostStats HostStatsHook
 }
 
 // RetryOptions configures retry behavior for failed fetch requests.
@@ -262,6 +274,9 @@ type Crawler struct {
 	respectRobotsTxt   bool
 	robotsTxtUserAgent string
 	robotsCache        sync.Map // map[string]*robotsTxtData
+
+	// Per-host stats and concurrency limits
+	hosts *hostTracker
 }
 
 // New creates a new Crawler with the specified options. It validates and sets
@@ -321,6 +336,13 @@ func New(opts Options) (*Crawler, error) {
 		retryOptions:         opts.RetryOptions,
 		respectRobotsTxt:     respectRobotsTxt,
 		robotsTxtUserAgent:   opts.RobotsTxtUserAgent,
+		hosts:                newHostTracker(opts.MaxConcurrentPerHost, opts.OnHostStats),
+	}
+	// Known URLs count as seen, so they are never queued
+	for _, rawURL := range opts.KnownURLs {
+		if u, err := c.normalizeURL(rawURL); err == nil {
+			c.processedURLs.Store(strings.TrimSuffix(u.String(), "/"), true)
+		}
 	}
 	if err := c.AddParserRules(opts.ParserRules...); err != nil {
 		return nil, err
@@ -502,6 +524,7 @@ func (c *Crawler) enqueue(ctx context.Context, urls []string) (int, error) {
 		case c.queue <- value:
 			// Successfully queued, now mark as processed to prevent re-queueing
 			c.processedURLs.Store(value, true)
+			c.hosts.update(normalizedURL.Hostname(), func(s *HostStats) { s.Queued++ })
 			queued++
 		case <-ctx.Done():
 			return queued, ctx.Err()
@@ -525,7 +548,7 @@ func (c *Crawler) worker(ctx context.Context, wg *sync.WaitGroup, callback Callb
 				return
 			}
 			c.incrementActiveWorkers()
-			c.processURL(ctx, rawURL, callback)
+			c.processHostURL(ctx, rawURL, callback)
 			c.decrementActiveWorkers()
 			if c.requestDelay > 0 {
 				time.Sleep(c.requestDelay)
@@ -534,7 +557,40 @@ func (c *Crawler) worker(ctx context.Context, wg *sync.WaitGroup, callback Callb
 	}
 }
 
-func (c *Crawler) processURL(ctx context.Context, rawURL string, callback Callback) {
+// processHostURL processes a URL taken from the queue once a slot of its host
+// is free, recording the outcome in the host's stats.
+func (c *Crawler) processHostURL(ctx context.Context, rawURL string, callback Callback) {
+	var host string
+	if u, err := url.Parse(rawURL); err == nil {
+		host = u.Hostname()
+	}
+	c.hosts.update(host, func(s *HostStats) { s.Queued-- })
+	release, ok := c.hosts.acquire(ctx, host)
+	if !ok {
+		return
+	}
+	defer release()
+
+	c.hosts.update(host, func(s *HostStats) { s.InFlight++ })
+	start := time.Now()
+	err := c.processURL(ctx, rawURL, callback)
+	elapsed := time.Since(start)
+	c.hosts.update(host, func(s *HostStats) {
+		s.InFlight--
+		s.TotalTime += elapsed
+		if err != nil {
+			s.Errors++
+			s.LastError = err.Error()
+		} else {
+			s.Succeeded++
+		}
+	})
+}
+
+// processURL fetches, parses, and follows the links of a URL. It returns the
+// error that failed it, or nil if it succeeded; parse errors are reported to
+// the callback but don't fail the URL.
+func (c *Crawler) processURL(ctx context.Context, rawURL string, callback Callback) error {
 	c.stats.IncrementProcessed()
 
 	// Parse the url to get its domain
@@ -543,7 +599,7 @@ func (c *Crawler) processURL(ctx context.Context, rawURL string, callback Callba
 		c.logger.Warn("invalid url",
 			slog.String("url", rawURL),
 			slog.String("error", err.Error()))
-		return
+		return err
 	}
 	domain := parsedURL.Hostname()
 
@@ -565,18 +621,20 @@ func (c *Crawler) processURL(ctx context.Context, rawURL string, callback Callba
 		c.logger.Error("no fetcher configured",
 			slog.String("url", rawURL),
 			slog.String("domain", domain))
-		callback(ctx, &Result{URL: parsedURL, Error: errors.New("no fetcher configured for domain")})
+		err := errors.New("no fetcher configured for domain")
+		callback(ctx, &Result{URL: parsedURL, Error: err})
 		c.stats.IncrementFailed()
-		return
+		return err
 	}
 
 	// Check robots.txt if enabled
 	if c.respectRobotsTxt && !c.isAllowedByRobots(ctx, parsedURL) {
 		c.logger.Debug("blocked by robots.txt",
 			slog.String("url", rawURL))
-		callback(ctx, &Result{URL: parsedURL, Error: errors.New("blocked by robots.txt")})
+		err := errors.New("blocked by robots.txt")
+		callback(ctx, &Result{URL: parsedURL, Error: err})
 		c.stats.IncrementFailed()
-		return
+		return err
 	}
 
 	// Create fetch request
@@ -612,6 +670,7 @@ func (c *Crawler) processURL(ctx context.Context, rawURL string, callback Callba
 				retry.WithMaxAttempts(maxAttempts),
 				retry.WithBackoff(initialBackoff, maxBackoff),
 				retry.WithOnRetry(func(attempt int, err error, delay time.Duration) {
+					c.hosts.update(domain, func(s *HostStats) { s.Retries++ })
 					c.logger.Warn("retrying fetch",
 						slog.String("url", rawURL),
 						slog.Int("attempt", attempt),
@@ -626,7 +685,7 @@ func (c *Crawler) processURL(ctx context.Context, rawURL string, callback Callba
 		if err != nil {
 			callback(ctx, &Result{URL: parsedURL, Error: err})
 			c.stats.IncrementFailed()
-			return
+			return err
 		}
 		if c.cache != nil && response.HTML != "" {
 			if err := c.cache.Set(ctx, rawURL, []byte(response.HTML)); err != nil {
@@ -673,6 +732,7 @@ func (c *Crawler) processURL(ctx context.Context, rawURL string, callback Callba
 			slog.String("url", rawURL),
 			slog.String("error", err.Error()))
 	}
+	return nil
 }
 
 func (c *Crawler) getParser(domain string) (Parser, bool) {
@@ -771,6 +831,15 @@ func (c *Crawler) GetStats() *CrawlerStats {
 	return c.stats
 }
 
+// GetHostStats returns a snapshot of the stats of each host seen so far,
+// sorted by host: its URLs in flight, queued, and throttled, and its
+// successes, errors, retries, and processing time. Like GetStats, they
+// accumulate across calls to Crawl. Use Options.OnHostStats to be told of
+// changes as they happen.
+func (c *Crawler) GetHostStats() []HostStats {
+	return c.hosts.snapshot()
+}
+
 func (c *Crawler) idleMonitor(ctx context.Context, cancel context.CancelFunc) {
 	// Check every second for idle state
 	ticker := time.NewTicker(1 * time.Second)
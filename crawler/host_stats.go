@@ -0,0 +1,146 @@
+package crawler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HostStats is a snapshot of the crawl of one host. In-flight, queued, and
+// throttled are current counts; the rest accumulate over the crawl.
+type HostStats struct {
+	// Host is the hostname, without port
+	Host string
+
+	// InFlight is the number of URLs of the host being processed
+	InFlight int
+
+	// Queued is the number of URLs of the host waiting in the queue
+	Queued int
+
+	// Throttled is the number of URLs of the host taken from the queue but
+	// waiting for one of the host's MaxConcurrentPerHost slots
+	Throttled int
+
+	// Succeeded is the number of URLs of the host processed successfully
+	Succeeded int64
+
+	// Errors is the number of URLs of the host that failed
+	Errors int64
+
+	// Retries is the number of fetches of the host that were retried
+	Retries int64
+
+	// LastError is the message of the host's most recent error
+	LastError string
+
+	// TotalTime is the time spent processing the host's URLs
+	TotalTime time.Duration
+}
+
+// Done returns the number of URLs of the host processed so far.
+func (s HostStats) Done() int64 {
+	return s.Succeeded + s.Errors
+}
+
+// AvgTime returns the average time taken to process one of the host's URLs,
+// or zero if none are done.
+func (s HostStats) AvgTime() time.Duration {
+	if done := s.Done(); done > 0 {
+		return s.TotalTime / time.Duration(done)
+	}
+	return 0
+}
+
+// ErrorRate returns the fraction of the host's processed URLs that failed.
+func (s HostStats) ErrorRate() float64 {
+	if done := s.Done(); done > 0 {
+		return float64(s.Errors) / float64(done)
+	}
+	return 0
+}
+
+// HostStatsHook is called with a host's stats each time they change. It is
+// called by the goroutine that made the change, so it should return quickly.
+type HostStatsHook func(stats HostStats)
+
+// hostTracker keeps per-host stats and concurrency slots. All methods are
+// thread-safe.
+type hostTracker struct {
+	mu      sync.Mutex
+	hosts   map[string]*HostStats
+	slots   map[string]chan struct{}
+	perHost int
+	hook    HostStatsHook
+}
+
+func newHostTracker(perHost int, hook HostStatsHook) *hostTracker {
+	return &hostTracker{
+		hosts:   map[string]*HostStats{},
+		slots:   map[string]chan struct{}{},
+		perHost: perHost,
+		hook:    hook,
+	}
+}
+
+// update applies fn to the stats of host and passes a copy to the hook.
+func (t *hostTracker) update(host string, fn func(s *HostStats)) {
+	t.mu.Lock()
+	s, ok := t.hosts[host]
+	if !ok {
+		s = &HostStats{Host: host}
+		t.hosts[host] = s
+	}
+	fn(s)
+	snapshot := *s
+	t.mu.Unlock()
+	if t.hook != nil {
+		t.hook(snapshot)
+	}
+}
+
+// acquire takes one of the host's slots, waiting while all are in use, and
+// returns a function that releases it. It returns false if ctx is canceled
+// first.
+func (t *hostTracker) acquire(ctx context.Context, host string) (func(), bool) {
+	if t.perHost <= 0 {
+		return func() {}, true
+	}
+	t.mu.Lock()
+	slots, ok := t.slots[host]
+	if !ok {
+		slots = make(chan struct{}, t.perHost)
+		t.slots[host] = slots
+	}
+	t.mu.Unlock()
+	release := func() { <-slots }
+
+	select {
+	case slots <- struct{}{}:
+		return release, true
+	default:
+	}
+	t.update(host, func(s *HostStats) { s.Throttled++ })
+	defer t.update(host, func(s *HostStats) { s.Throttled-- })
+	select {
+	case slots <- struct{}{}:
+		return release, true
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+// snapshot returns the stats of every host, sorted by host.
+func (t *hostTracker) snapshot() []HostStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats := make([]HostStats, 0, len(t.hosts))
+	for _, s := range t.hosts {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Host < stats[j].Host
+	})
+	return stats
+}
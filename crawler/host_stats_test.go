@@ -0,0 +1,105 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/fetch"
+)
+
+func TestCrawler_HostStats(t *testing.T) {
+	mockFetcher := fetch.NewMockFetcher()
+	mockFetcher.AddResponse("https://a.example.com", &fetch.Response{HTML: "<html>a</html>"})
+	mockFetcher.AddResponse("https://a.example.com/page", &fetch.Response{HTML: "<html>a</html>"})
+	mockFetcher.AddError("https://b.example.com", errors.New("connection refused"))
+
+	var mu sync.Mutex
+	var updates int
+	c, err := New(Options{
+		Workers:        2,
+		DefaultFetcher: mockFetcher,
+		FollowBehavior: FollowNone,
+		OnHostStats: func(stats HostStats) {
+			mu.Lock()
+			updates++
+			mu.Unlock()
+		},
+	})
+	assert.NoError(t, err)
+
+	err = c.Crawl(context.Background(), []string{
+		"https://a.example.com", "https://a.example.com/page", "https://b.example.com",
+	}, func(ctx context.Context, result *Result) {})
+	assert.NoError(t, err)
+
+	hosts := c.GetHostStats()
+	assert.Equal(t, 2, len(hosts))
+	a, b := hosts[0], hosts[1]
+	assert.Equal(t, "a.example.com", a.Host)
+	assert.Equal(t, int64(2), a.Succeeded)
+	assert.Equal(t, int64(0), a.Errors)
+	assert.Equal(t, 0, a.Queued)
+	assert.Equal(t, 0, a.InFlight)
+	assert.Equal(t, "b.example.com", b.Host)
+	assert.Equal(t, int64(1), b.Errors)
+	assert.Equal(t, "connection refused", b.LastError)
+	assert.Equal(t, 1.0, b.ErrorRate())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.True(t, updates > 0)
+}
+
+// slowFetcher takes a while to fetch and records how many fetches overlap.
+type slowFetcher struct {
+	active, peak int64
+}
+
+func (f *slowFetcher) Fetch(ctx context.Context, req *fetch.Request) (*fetch.Response, error) {
+	n := atomic.AddInt64(&f.active, 1)
+	defer atomic.AddInt64(&f.active, -1)
+	for {
+		peak := atomic.LoadInt64(&f.peak)
+		if n <= peak || atomic.CompareAndSwapInt64(&f.peak, peak, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return &fetch.Response{URL: req.URL, HTML: "<html></html>"}, nil
+}
+
+func TestCrawler_MaxConcurrentPerHost(t *testing.T) {
+	fetcher := &slowFetcher{}
+	var throttled atomic.Bool
+	c, err := New(Options{
+		Workers:              4,
+		DefaultFetcher:       fetcher,
+		FollowBehavior:       FollowNone,
+		RespectRobotsTxt:     BoolPtr(false),
+		MaxConcurrentPerHost: 1,
+		OnHostStats: func(stats HostStats) {
+			if stats.Throttled > 0 {
+				throttled.Store(true)
+			}
+		},
+	})
+	assert.NoError(t, err)
+
+	err = c.Crawl(context.Background(), []string{
+		"https://example.com/1", "https://example.com/2",
+		"https://example.com/3", "https://example.com/4",
+	}, func(ctx context.Context, result *Result) {})
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(1), atomic.LoadInt64(&fetcher.peak))
+	assert.True(t, throttled.Load())
+	hosts := c.GetHostStats()
+	assert.Equal(t, 1, len(hosts))
+	assert.Equal(t, int64(4), hosts[0].Succeeded)
+	assert.Equal(t, 0, hosts[0].Throttled)
+}
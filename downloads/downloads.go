@@ -0,0 +1,755 @@
+// Package downloads provides a download manager with a queue, concurrent
+// transfers, pause/resume via HTTP range requests, and checksum verification.
+//
+// Files are streamed to "<path>.part" and renamed into place once the
+// transfer completes and its checksum (if any) verifies. A paused or failed
+// download keeps its partial file, and resuming continues from where it left
+// off when the server supports range requests.
+//
+// Basic usage:
+//
+//	m := downloads.New(downloads.Options{
+//		Dir:         "downloads",
+//		Concurrency: 3,
+//	})
+//	defer m.Close()
+//
+//	id, err := m.Add(downloads.Request{
+//		URL:      "https://example.com/file.tar.gz",
+//		Checksum: "sha256:9f86d08...",
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	m.Pause(id)
+//	m.Resume(id)
+//
+//	if err := m.Wait(ctx); err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, d := range m.List() {
+//		fmt.Println(d.Path, d.State, d.Err)
+//	}
+//
+// Progress is available by polling List or Get (for example from a TUI tick
+// handler with tui.DownloadsView), or by setting Options.OnUpdate.
+package downloads
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/fetch"
+)
+
+// PartialSuffix is appended to a download's path while it is in progress.
+const PartialSuffix = ".part"
+
+var (
+	// ErrNotFound is returned when a download ID is unknown.
+	ErrNotFound = errors.New("download not found")
+
+	// ErrInvalidState is returned when an operation doesn't apply to the
+	// download's current state, such as resuming a completed download.
+	ErrInvalidState = errors.New("invalid download state")
+
+	// ErrChecksumMismatch is returned when a completed download's content
+	// doesn't match Request.Checksum.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+
+	// ErrClosed is returned when adding to a closed Manager.
+	ErrClosed = errors.New("download manager closed")
+)
+
+// State is the lifecycle state of a download.
+type State int
+
+const (
+	// Queued downloads are waiting for a free transfer slot.
+	Queued State = iota
+	// Active downloads are transferring.
+	Active
+	// Paused downloads keep their partial file and can be resumed.
+	Paused
+	// Completed downloads are saved at their final path.
+	Completed
+	// Failed downloads stopped with an error. They can be resumed (retried).
+	Failed
+	// Canceled downloads were stopped by the user and their partial file removed.
+	Canceled
+)
+
+// String returns the lowercase state name.
+func (s State) String() string {
+	switch s {
+	case Queued:
+		return "queued"
+	case Active:
+		return "active"
+	case Paused:
+		return "paused"
+	case Completed:
+		return "completed"
+	case Failed:
+		return "failed"
+	case Canceled:
+		return "canceled"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// Finished reports whether the state is terminal (completed, failed, or canceled).
+func (s State) Finished() bool {
+	return s == Completed || s == Failed || s == Canceled
+}
+
+// Request describes a file to download.
+type Request struct {
+	// URL to download (required).
+	URL string
+
+	// Path is the destination file. If empty, the file is saved in
+	// Options.Dir using the last element of the URL path.
+	Path string
+
+	// Headers are additional HTTP headers sent with the request.
+	Headers map[string]string
+
+	// Checksum optionally verifies the completed file, in the form
+	// "algorithm:hex" where algorithm is md5, sha1, sha256, or sha512.
+	Checksum string
+}
+
+// Download is a snapshot of a download's progress.
+type Download struct {
+	ID   int
+	URL  string
+	Path string
+
+	State State
+
+	// Downloaded is the number of bytes saved so far.
+	Downloaded int64
+
+	// Total is the full size in bytes, or -1 if the server didn't report it.
+	Total int64
+
+	// BytesPerSecond is the average transfer rate of the current (or last)
+	// transfer session.
+	BytesPerSecond float64
+
+	// Err is set when State is Failed.
+	Err error
+
+	// Added, Started, and Finished record when the download was queued, when
+	// its latest transfer began, and when it reached a terminal state.
+	Added    time.Time
+	Started  time.Time
+	Finished time.Time
+}
+
+// Progress returns the completed fraction in the range [0, 1], or 0 when
+// the total size is unknown.
+func (d Download) Progress() float64 {
+	if d.State == Completed {
+		return 1
+	}
+	if d.Total <= 0 {
+		return 0
+	}
+	p := float64(d.Downloaded) / float64(d.Total)
+	if p > 1 {
+		p = 1
+	}
+	return p
+}
+
+// Remaining estimates the time left at the current rate. It returns 0 when
+// the estimate isn't available.
+func (d Download) Remaining() time.Duration {
+	if d.Total <= 0 || d.BytesPerSecond <= 0 || d.Downloaded >= d.Total {
+		return 0
+	}
+	return time.Duration(float64(d.Total-d.Downloaded) / d.BytesPerSecond * float64(time.Second))
+}
+
+// Options configures a Manager.
+type Options struct {
+	// Concurrency is the maximum number of simultaneous transfers.
+	// Defaults to 3.
+	Concurrency int
+
+	// Dir is where downloads without an explicit Request.Path are saved.
+	// Defaults to the current directory.
+	Dir string
+
+	// Streamer opens the HTTP streams. Defaults to a fetch.HTTPFetcher whose
+	// client has no overall timeout, so large files aren't cut off.
+	Streamer fetch.Streamer
+
+	// OnUpdate, if set, is called with a snapshot whenever a download changes
+	// state or makes progress (throttled by ProgressInterval). It is called
+	// from transfer goroutines and must not block.
+	OnUpdate func(Download)
+
+	// ProgressInterval limits how often OnUpdate reports byte progress for
+	// each download. Defaults to 100ms. State changes are always reported.
+	ProgressInterval time.Duration
+}
+
+// Manager queues and runs downloads. All methods are safe for concurrent use.
+type Manager struct {
+	opts Options
+
+	mu      sync.Mutex
+	items   map[int]*item
+	order   []int
+	nextID  int
+	closed  bool
+	changed chan struct{}
+	wg      sync.WaitGroup
+}
+
+type item struct {
+	dl       Download
+	req      Request
+	cancel   context.CancelFunc
+	stopping State // Paused or Canceled when a stop was requested mid-transfer
+	etag     string
+}
+
+// New creates a download manager.
+func New(opts Options) *Manager {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 3
+	}
+	if opts.Dir == "" {
+		opts.Dir = "."
+	}
+	if opts.Streamer == nil {
+		opts.Streamer = fetch.NewHTTPFetcher(fetch.HTTPFetcherOptions{
+			Client: &http.Client{},
+		})
+	}
+	if opts.ProgressInterval <= 0 {
+		opts.ProgressInterval = 100 * time.Millisecond
+	}
+	return &Manager{
+		opts:    opts,
+		items:   make(map[int]*item),
+		nextID:  1,
+		changed: make(chan struct{}),
+	}
+}
+
+// Add queues a download and returns its ID. The download starts as soon as
+// a transfer slot is free.
+func (m *Manager) Add(req Request) (int, error) {
+	if req.URL == "" {
+		return 0, errors.New("download URL is required")
+	}
+	if req.Checksum != "" {
+		if _, _, err := parseChecksum(req.Checksum); err != nil {
+			return 0, err
+		}
+	}
+	if req.Path == "" {
+		name, err := filenameFromURL(req.URL)
+		if err != nil {
+			return 0, err
+		}
+		req.Path = filepath.Join(m.opts.Dir, name)
+	}
+
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return 0, ErrClosed
+	}
+	id := m.nextID
+	m.nextID++
+	it := &item{
+		req: req,
+		dl: Download{
+			ID:    id,
+			URL:   req.URL,
+			Path:  req.Path,
+			State: Queued,
+			Total: -1,
+			Added: time.Now(),
+		},
+	}
+	m.items[id] = it
+	m.order = append(m.order, id)
+	snap := it.dl
+	m.scheduleLocked()
+	m.notifyLocked()
+	m.mu.Unlock()
+
+	m.report(snap)
+	return id, nil
+}
+
+// Get returns a snapshot of the download with the given ID.
+func (m *Manager) Get(id int) (Download, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	it, ok := m.items[id]
+	if !ok {
+		return Download{}, false
+	}
+	return it.dl, true
+}
+
+// List returns snapshots of all downloads in the order they were added.
+func (m *Manager) List() []Download {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := make([]Download, 0, len(m.order))
+	for _, id := range m.order {
+		list = append(list, m.items[id].dl)
+	}
+	return list
+}
+
+// Pause stops a queued or active download, keeping its partial file.
+func (m *Manager) Pause(id int) error {
+	return m.stop(id, Paused)
+}
+
+// Cancel stops a download and removes its partial file. Completed
+// downloads cannot be canceled.
+func (m *Manager) Cancel(id int) error {
+	return m.stop(id, Canceled)
+}
+
+func (m *Manager) stop(id int, target State) error {
+	m.mu.Lock()
+	it, ok := m.items[id]
+	if !ok {
+		m.mu.Unlock()
+		return ErrNotFound
+	}
+	switch it.dl.State {
+	case Active:
+		// The transfer goroutine finalizes the state when it exits
+		it.stopping = target
+		it.cancel()
+		m.mu.Unlock()
+		return nil
+	case Queued, Paused, Failed:
+		if target == Paused && it.dl.State != Queued {
+			m.mu.Unlock()
+			return ErrInvalidState
+		}
+		it.dl.State = target
+		if target == Canceled {
+			it.dl.Finished = time.Now()
+			os.Remove(it.req.Path + PartialSuffix)
+		}
+	default:
+		m.mu.Unlock()
+		return ErrInvalidState
+	}
+	snap := it.dl
+	m.notifyLocked()
+	m.mu.Unlock()
+
+	m.report(snap)
+	return nil
+}
+
+// Resume re-queues a paused or failed download. It continues from its
+// partial file when the server supports range requests.
+func (m *Manager) Resume(id int) error {
+	m.mu.Lock()
+	it, ok := m.items[id]
+	if !ok {
+		m.mu.Unlock()
+		return ErrNotFound
+	}
+	if it.dl.State != Paused && it.dl.State != Failed {
+		m.mu.Unlock()
+		return ErrInvalidState
+	}
+	it.dl.State = Queued
+	it.dl.Err = nil
+	it.dl.Finished = time.Time{}
+	snap := it.dl
+	m.scheduleLocked()
+	m.notifyLocked()
+	m.mu.Unlock()
+
+	m.report(snap)
+	return nil
+}
+
+// Remove forgets a download that is not active. The downloaded file (or
+// partial file) is left on disk.
+func (m *Manager) Remove(id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	it, ok := m.items[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if it.dl.State == Active {
+		return ErrInvalidState
+	}
+	delete(m.items, id)
+	for i, oid := range m.order {
+		if oid == id {
+			m.order = append(m.order[:i], m.order[i+1:]...)
+			break
+		}
+	}
+	m.notifyLocked()
+	return nil
+}
+
+// Wait blocks until no downloads are queued or active, or ctx is done.
+// Paused downloads don't keep Wait blocked.
+func (m *Manager) Wait(ctx context.Context) error {
+	for {
+		m.mu.Lock()
+		busy := false
+		for _, it := range m.items {
+			if it.dl.State == Queued || it.dl.State == Active {
+				busy = true
+				break
+			}
+		}
+		changed := m.changed
+		m.mu.Unlock()
+
+		if !busy {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// Close pauses all active and queued downloads, keeping their partial files,
+// and waits for transfers to stop. Further calls to Add fail with ErrClosed.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	m.closed = true
+	for _, it := range m.items {
+		switch it.dl.State {
+		case Active:
+			it.stopping = Paused
+			it.cancel()
+		case Queued:
+			it.dl.State = Paused
+		}
+	}
+	m.notifyLocked()
+	m.mu.Unlock()
+
+	m.wg.Wait()
+	return nil
+}
+
+// scheduleLocked starts queued downloads while transfer slots are free.
+// The caller must hold m.mu.
+func (m *Manager) scheduleLocked() {
+	if m.closed {
+		return
+	}
+	active := 0
+	for _, it := range m.items {
+		if it.dl.State == Active {
+			active++
+		}
+	}
+	for _, id := range m.order {
+		if active >= m.opts.Concurrency {
+			return
+		}
+		it := m.items[id]
+		if it.dl.State != Queued {
+			continue
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		it.cancel = cancel
+		it.stopping = Queued
+		it.dl.State = Active
+		it.dl.Started = time.Now()
+		it.dl.BytesPerSecond = 0
+		active++
+		m.wg.Add(1)
+		go m.run(ctx, it)
+	}
+}
+
+// notifyLocked wakes goroutines blocked in Wait. The caller must hold m.mu.
+func (m *Manager) notifyLocked() {
+	close(m.changed)
+	m.changed = make(chan struct{})
+}
+
+func (m *Manager) report(d Download) {
+	if m.opts.OnUpdate != nil {
+		m.opts.OnUpdate(d)
+	}
+}
+
+// run performs one transfer session and records its outcome.
+func (m *Manager) run(ctx context.Context, it *item) {
+	defer m.wg.Done()
+	err := m.transfer(ctx, it)
+
+	m.mu.Lock()
+	it.cancel()
+	switch {
+	case err == nil:
+		it.dl.State = Completed
+		it.dl.Finished = time.Now()
+	case it.stopping == Paused:
+		it.dl.State = Paused
+	case it.stopping == Canceled:
+		it.dl.State = Canceled
+		it.dl.Finished = time.Now()
+		os.Remove(it.req.Path + PartialSuffix)
+	default:
+		it.dl.State = Failed
+		it.dl.Err = err
+		it.dl.Finished = time.Now()
+	}
+	snap := it.dl
+	m.scheduleLocked()
+	m.notifyLocked()
+	m.mu.Unlock()
+
+	m.report(snap)
+}
+
+// transfer downloads (or resumes) it into its partial file, verifies the
+// checksum, and moves the file into place.
+func (m *Manager) transfer(ctx context.Context, it *item) error {
+	req := it.req
+	partPath := req.Path + PartialSuffix
+	if dir := filepath.Dir(req.Path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(partPath, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	offset := info.Size()
+
+	m.mu.Lock()
+	etag := it.etag
+	m.mu.Unlock()
+
+	resp, err := m.opts.Streamer.Stream(ctx, &fetch.StreamRequest{
+		URL:     req.URL,
+		Headers: req.Headers,
+		Offset:  offset,
+		IfRange: etag,
+	})
+	var reqErr *fetch.RequestError
+	if err != nil && offset > 0 && errors.As(err, &reqErr) &&
+		reqErr.StatusCode() == http.StatusRequestedRangeNotSatisfiable {
+		// The partial file already holds the whole resource
+		m.mu.Lock()
+		it.dl.Downloaded = offset
+		it.dl.Total = offset
+		m.mu.Unlock()
+		return m.finish(file, partPath, req)
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// The server ignored the range (or the resource changed): start over
+	if resp.Offset != offset {
+		if resp.Offset != 0 {
+			return fmt.Errorf("server resumed at byte %d, expected %d", resp.Offset, offset)
+		}
+		if err := file.Truncate(0); err != nil {
+			return err
+		}
+		offset = 0
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	it.etag = resp.ETag
+	it.dl.Downloaded = offset
+	it.dl.Total = resp.TotalSize
+	snap := it.dl
+	m.mu.Unlock()
+	m.report(snap)
+
+	start := time.Now()
+	lastReport := start
+	var session int64
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, err := file.Write(buf[:n]); err != nil {
+				return err
+			}
+			session += int64(n)
+
+			now := time.Now()
+			m.mu.Lock()
+			it.dl.Downloaded = offset + session
+			if elapsed := now.Sub(start).Seconds(); elapsed > 0 {
+				it.dl.BytesPerSecond = float64(session) / elapsed
+			}
+			snap := it.dl
+			m.mu.Unlock()
+			if now.Sub(lastReport) >= m.opts.ProgressInterval {
+				lastReport = now
+				m.report(snap)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if resp.TotalSize >= 0 && offset+session < resp.TotalSize {
+		return fmt.Errorf("transfer ended early: got %d of %d bytes", offset+session, resp.TotalSize)
+	}
+	if resp.TotalSize < 0 {
+		m.mu.Lock()
+		it.dl.Total = offset + session
+		m.mu.Unlock()
+	}
+	return m.finish(file, partPath, req)
+}
+
+// finish verifies the partial file and renames it to its final path.
+func (m *Manager) finish(file *os.File, partPath string, req Request) error {
+	if err := file.Sync(); err != nil {
+		return err
+	}
+	if req.Checksum != "" {
+		if err := verifyChecksum(partPath, req.Checksum); err != nil {
+			// The content is corrupt; remove it so a retry starts fresh
+			file.Close()
+			os.Remove(partPath)
+			return err
+		}
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(partPath, req.Path)
+}
+
+// parseChecksum splits an "algorithm:hex" checksum.
+func parseChecksum(checksum string) (hash.Hash, []byte, error) {
+	algo, digest, ok := strings.Cut(checksum, ":")
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid checksum %q: expected algorithm:hex", checksum)
+	}
+	want, err := hex.DecodeString(strings.TrimSpace(digest))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid checksum %q: %w", checksum, err)
+	}
+	var h hash.Hash
+	switch strings.ToLower(algo) {
+	case "md5":
+		h = md5.New()
+	case "sha1":
+		h = sha1.New()
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return nil, nil, fmt.Errorf("unsupported checksum algorithm %q", algo)
+	}
+	if len(want) != h.Size() {
+		return nil, nil, fmt.Errorf("invalid checksum %q: wrong digest length", checksum)
+	}
+	return h, want, nil
+}
+
+// verifyChecksum hashes the file at path and compares it with checksum.
+func verifyChecksum(path, checksum string) error {
+	h, want, err := parseChecksum(checksum)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if got := h.Sum(nil); !bytes.Equal(got, want) {
+		return fmt.Errorf("%w: got %s", ErrChecksumMismatch, hex.EncodeToString(got))
+	}
+	return nil
+}
+
+// filenameFromURL derives a safe file name from the last URL path element.
+func filenameFromURL(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	name := path.Base(u.Path)
+	if name == "." || name == "/" || name == "" || name == ".." {
+		name = u.Hostname()
+	}
+	name = strings.Map(func(r rune) rune {
+		if r < 32 || r == 127 || r == '/' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "" {
+		return "", fmt.Errorf("cannot derive a file name from %q", rawURL)
+	}
+	return name, nil
+}
@@ -0,0 +1,323 @@
+package downloads
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func sha256Checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func serveContent(content string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "file.bin", time.Time{}, strings.NewReader(content))
+	}))
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestManager_Download(t *testing.T) {
+	content := strings.Repeat("wonton ", 1000)
+	server := serveContent(content)
+	defer server.Close()
+
+	dir := t.TempDir()
+	m := New(Options{Dir: dir})
+	defer m.Close()
+
+	id, err := m.Add(Request{URL: server.URL + "/files/data.bin", Checksum: sha256Checksum(content)})
+	assert.NoError(t, err)
+	assert.NoError(t, m.Wait(context.Background()))
+
+	d, ok := m.Get(id)
+	assert.True(t, ok)
+	assert.Equal(t, Completed, d.State)
+	assert.NoError(t, d.Err)
+	assert.Equal(t, filepath.Join(dir, "data.bin"), d.Path)
+	assert.Equal(t, int64(len(content)), d.Downloaded)
+	assert.Equal(t, int64(len(content)), d.Total)
+	assert.Equal(t, 1.0, d.Progress())
+
+	data, err := os.ReadFile(d.Path)
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(data))
+	_, err = os.Stat(d.Path + PartialSuffix)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestManager_ChecksumMismatch(t *testing.T) {
+	server := serveContent("actual content")
+	defer server.Close()
+
+	m := New(Options{Dir: t.TempDir()})
+	defer m.Close()
+
+	id, err := m.Add(Request{URL: server.URL + "/a.txt", Checksum: sha256Checksum("expected content")})
+	assert.NoError(t, err)
+	assert.NoError(t, m.Wait(context.Background()))
+
+	d, _ := m.Get(id)
+	assert.Equal(t, Failed, d.State)
+	assert.True(t, errors.Is(d.Err, ErrChecksumMismatch))
+	_, err = os.Stat(d.Path)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(d.Path + PartialSuffix)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestManager_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	m := New(Options{Dir: t.TempDir()})
+	defer m.Close()
+
+	id, _ := m.Add(Request{URL: server.URL + "/missing"})
+	assert.NoError(t, m.Wait(context.Background()))
+
+	d, _ := m.Get(id)
+	assert.Equal(t, Failed, d.State)
+	assert.Error(t, d.Err)
+	assert.True(t, d.State.Finished())
+}
+
+// stallServer serves the first half of content and then stalls until the
+// client disconnects. Once release is closed it serves content normally,
+// honoring range requests.
+type stallServer struct {
+	content string
+	release chan struct{}
+
+	mu     sync.Mutex
+	ranges []string
+}
+
+func (s *stallServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.ranges = append(s.ranges, r.Header.Get("Range"))
+	s.mu.Unlock()
+
+	select {
+	case <-s.release:
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "file.bin", time.Time{}, strings.NewReader(s.content))
+		return
+	default:
+	}
+
+	w.Header().Set("ETag", `"v1"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(s.content)))
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(s.content[:len(s.content)/2]))
+	w.(http.Flusher).Flush()
+	<-r.Context().Done()
+}
+
+func TestManager_PauseResume(t *testing.T) {
+	content := strings.Repeat("0123456789", 2000)
+	stall := &stallServer{content: content, release: make(chan struct{})}
+	server := httptest.NewServer(stall)
+	defer server.Close()
+
+	m := New(Options{Dir: t.TempDir()})
+	defer m.Close()
+
+	id, err := m.Add(Request{URL: server.URL + "/big.bin", Checksum: sha256Checksum(content)})
+	assert.NoError(t, err)
+
+	half := int64(len(content) / 2)
+	waitFor(t, func() bool {
+		d, _ := m.Get(id)
+		return d.Downloaded >= half
+	})
+
+	assert.NoError(t, m.Pause(id))
+	waitFor(t, func() bool {
+		d, _ := m.Get(id)
+		return d.State == Paused
+	})
+	d, _ := m.Get(id)
+	info, err := os.Stat(d.Path + PartialSuffix)
+	assert.NoError(t, err)
+	assert.Equal(t, half, info.Size())
+
+	// Resuming continues from the partial file with a range request
+	close(stall.release)
+	assert.NoError(t, m.Resume(id))
+	assert.NoError(t, m.Wait(context.Background()))
+
+	d, _ = m.Get(id)
+	assert.Equal(t, Completed, d.State)
+	data, err := os.ReadFile(d.Path)
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(data))
+
+	stall.mu.Lock()
+	defer stall.mu.Unlock()
+	assert.Equal(t, []string{"", "bytes=" + strconv.Itoa(int(half)) + "-"}, stall.ranges)
+}
+
+func TestManager_Cancel(t *testing.T) {
+	content := strings.Repeat("x", 10000)
+	stall := &stallServer{content: content, release: make(chan struct{})}
+	server := httptest.NewServer(stall)
+	defer server.Close()
+
+	m := New(Options{Dir: t.TempDir()})
+	defer m.Close()
+
+	id, _ := m.Add(Request{URL: server.URL + "/file"})
+	waitFor(t, func() bool {
+		d, _ := m.Get(id)
+		return d.Downloaded > 0
+	})
+
+	assert.NoError(t, m.Cancel(id))
+	waitFor(t, func() bool {
+		d, _ := m.Get(id)
+		return d.State == Canceled
+	})
+	d, _ := m.Get(id)
+	_, err := os.Stat(d.Path + PartialSuffix)
+	assert.True(t, os.IsNotExist(err))
+
+	assert.True(t, errors.Is(m.Resume(id), ErrInvalidState))
+	assert.True(t, errors.Is(m.Cancel(id), ErrInvalidState))
+	assert.True(t, errors.Is(m.Pause(99), ErrNotFound))
+}
+
+func TestManager_Concurrency(t *testing.T) {
+	stall := &stallServer{content: strings.Repeat("y", 1000), release: make(chan struct{})}
+	server := httptest.NewServer(stall)
+	defer server.Close()
+
+	m := New(Options{Dir: t.TempDir(), Concurrency: 2})
+
+	for i := 0; i < 4; i++ {
+		_, err := m.Add(Request{URL: server.URL + "/f" + strconv.Itoa(i)})
+		assert.NoError(t, err)
+	}
+
+	waitFor(t, func() bool {
+		active := 0
+		for _, d := range m.List() {
+			if d.State == Active && d.Downloaded > 0 {
+				active++
+			}
+		}
+		return active == 2
+	})
+	states := map[State]int{}
+	for _, d := range m.List() {
+		states[d.State]++
+	}
+	assert.Equal(t, 2, states[Active])
+	assert.Equal(t, 2, states[Queued])
+
+	// Close pauses everything, keeping partial files
+	assert.NoError(t, m.Close())
+	for _, d := range m.List() {
+		assert.Equal(t, Paused, d.State)
+	}
+	_, err := m.Add(Request{URL: server.URL + "/late"})
+	assert.True(t, errors.Is(err, ErrClosed))
+}
+
+func TestManager_OnUpdate(t *testing.T) {
+	server := serveContent("hello")
+	defer server.Close()
+
+	var mu sync.Mutex
+	var states []State
+	m := New(Options{
+		Dir: t.TempDir(),
+		OnUpdate: func(d Download) {
+			mu.Lock()
+			states = append(states, d.State)
+			mu.Unlock()
+		},
+	})
+	defer m.Close()
+
+	m.Add(Request{URL: server.URL + "/hello.txt"})
+	assert.NoError(t, m.Wait(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Contains(t, states, Queued)
+	assert.Equal(t, Completed, states[len(states)-1])
+}
+
+func TestManager_AddValidation(t *testing.T) {
+	m := New(Options{Dir: t.TempDir()})
+	defer m.Close()
+
+	_, err := m.Add(Request{})
+	assert.Error(t, err)
+	_, err = m.Add(Request{URL: "https://example.com/a", Checksum: "sha256"})
+	assert.Error(t, err)
+	_, err = m.Add(Request{URL: "https://example.com/a", Checksum: "crc32:00"})
+	assert.Error(t, err)
+	_, err = m.Add(Request{URL: "https://example.com/a", Checksum: "md5:abcd"})
+	assert.Error(t, err)
+}
+
+func TestFilenameFromURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://example.com/files/report.pdf", "report.pdf"},
+		{"https://example.com/files/report.pdf?x=1", "report.pdf"},
+		{"https://example.com/", "example.com"},
+		{"https://example.com", "example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := filenameFromURL(tt.url)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDownload_ProgressAndRemaining(t *testing.T) {
+	d := Download{State: Active, Downloaded: 25, Total: 100, BytesPerSecond: 25}
+	assert.Equal(t, 0.25, d.Progress())
+	assert.Equal(t, 3*time.Second, d.Remaining())
+
+	d.Total = -1
+	assert.Equal(t, 0.0, d.Progress())
+	assert.Equal(t, time.Duration(0), d.Remaining())
+}
+
+func TestState_String(t *testing.T) {
+	assert.Equal(t, "queued", Queued.String())
+	assert.Equal(t, "canceled", Canceled.String())
+	assert.Equal(t, "State(42)", State(42).String())
+}
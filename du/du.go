@@ -0,0 +1,307 @@
+// Package du measures disk usage of directory trees, like the du and ncdu
+// tools. Directories are read concurrently, progress can be reported while
+// a scan runs, and hard-linked files are counted once.
+//
+// # Basic Usage
+//
+//	root, err := du.Scan(ctx, "/var/log", du.Options{})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, e := range root.Children { // largest first
+//		fmt.Printf("%10d  %s\n", e.Usage, e.Name)
+//	}
+//
+// # Progress
+//
+// Set Options.OnProgress to follow a long scan, for example to drive a
+// progress display:
+//
+//	du.Scan(ctx, home, du.Options{
+//		OnProgress: func(p du.Progress) {
+//			fmt.Printf("\r%d files, %d bytes", p.Files, p.Bytes)
+//		},
+//	})
+//
+// Directories that can't be read don't stop the scan: their Err is set and
+// their size counts only what could be read.
+package du
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Entry is a file or directory in a scanned tree. The sizes of a directory
+// include everything below it.
+type Entry struct {
+	Name    string // Base name; the root entry has the path passed to Scan
+	IsDir   bool
+	Size    int64 // Apparent size in bytes
+	Usage   int64 // Disk space used in bytes; equal to Size where unknown
+	Files   int64 // Number of files, 1 for a file
+	Dirs    int64 // Number of directories below a directory
+	ModTime time.Time
+	Err     error // Error reading a directory, if any
+
+	Parent   *Entry
+	Children []*Entry // Sorted by Usage, largest first
+}
+
+// Path returns the path of the entry, starting with the scanned root.
+func (e *Entry) Path() string {
+	if e.Parent == nil {
+		return e.Name
+	}
+	return filepath.Join(e.Parent.Path(), e.Name)
+}
+
+// Share returns the entry's usage as a fraction of its parent's, or 1 for
+// the root.
+func (e *Entry) Share() float64 {
+	if e.Parent == nil {
+		return 1
+	}
+	if e.Parent.Usage <= 0 {
+		return 0
+	}
+	return float64(e.Usage) / float64(e.Parent.Usage)
+}
+
+// Remove deletes the entry from disk and from the tree, subtracting its
+// sizes from its ancestors.
+func (e *Entry) Remove() error {
+	if err := os.RemoveAll(e.Path()); err != nil {
+		return err
+	}
+	if e.Parent == nil {
+		return nil
+	}
+	parent := e.Parent
+	for i, child := range parent.Children {
+		if child == e {
+			parent.Children = append(parent.Children[:i], parent.Children[i+1:]...)
+			break
+		}
+	}
+	dirs := e.Dirs
+	if e.IsDir {
+		dirs++
+	}
+	for p := parent; p != nil; p = p.Parent {
+		p.Size -= e.Size
+		p.Usage -= e.Usage
+		p.Files -= e.Files
+		p.Dirs -= dirs
+	}
+	e.Parent = nil
+	return nil
+}
+
+// Progress reports how far a scan has come.
+type Progress struct {
+	Files int64  // Files found so far
+	Dirs  int64  // Directories read so far
+	Bytes int64  // Disk usage found so far
+	Path  string // A directory being read
+	Done  bool   // Set on the final report
+}
+
+// Options configures a scan.
+type Options struct {
+	// Concurrency is the maximum number of directories read at once.
+	// Defaults to 8.
+	Concurrency int
+
+	// OneFileSystem skips directories on other file systems than the root,
+	// like du -x. It has no effect where devices can't be told apart.
+	OneFileSystem bool
+
+	// Exclude, if set, skips paths for which it returns true.
+	Exclude func(path string, isDir bool) bool
+
+	// OnProgress, if set, is called while the scan runs (throttled by
+	// ProgressInterval) and once when it ends. It is called from scanning
+	// goroutines and must not block.
+	OnProgress func(Progress)
+
+	// ProgressInterval limits how often OnProgress is called. Defaults to
+	// 100ms.
+	ProgressInterval time.Duration
+}
+
+// scanner holds the state shared by the goroutines of a scan.
+type scanner struct {
+	opts Options
+	ctx  context.Context
+	sem  chan struct{}
+	dev  uint64
+
+	files, dirs, bytes atomic.Int64
+
+	mu           sync.Mutex
+	seen         map[fileID]bool // hard-linked files already counted
+	lastProgress time.Time
+}
+
+// Scan measures the tree rooted at root. It returns an error if root can't
+// be read or ctx is canceled.
+func Scan(ctx context.Context, root string, opts Options) (*Entry, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 8
+	}
+	if opts.ProgressInterval <= 0 {
+		opts.ProgressInterval = 100 * time.Millisecond
+	}
+	info, err := os.Lstat(root)
+	if err != nil {
+		return nil, err
+	}
+	s := &scanner{
+		opts: opts,
+		ctx:  ctx,
+		sem:  make(chan struct{}, opts.Concurrency),
+		seen: make(map[fileID]bool),
+	}
+	s.dev, _ = device(info)
+
+	entry := s.entry(root, info)
+	if entry.IsDir {
+		s.scanDir(entry, root)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if opts.OnProgress != nil {
+		opts.OnProgress(s.progress(root, true))
+	}
+	return entry, nil
+}
+
+// entry creates the entry for a file or directory, counting the file.
+func (s *scanner) entry(name string, info os.FileInfo) *Entry {
+	e := &Entry{
+		Name:    name,
+		IsDir:   info.IsDir(),
+		ModTime: info.ModTime(),
+	}
+	if e.IsDir {
+		return e
+	}
+	e.Files = 1
+	if id, ok := hardLink(info); ok {
+		s.mu.Lock()
+		counted := s.seen[id]
+		s.seen[id] = true
+		s.mu.Unlock()
+		if counted {
+			return e
+		}
+	}
+	e.Size = info.Size()
+	e.Usage = usage(info)
+	s.files.Add(1)
+	s.bytes.Add(e.Usage)
+	return e
+}
+
+// scanDir reads dir and its subdirectories, then totals its sizes.
+func (s *scanner) scanDir(dir *Entry, path string) {
+	if s.ctx.Err() != nil {
+		return
+	}
+	s.dirs.Add(1)
+	s.reportProgress(path)
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		dir.Err = err
+	}
+
+	var wg sync.WaitGroup
+	for _, de := range entries {
+		childPath := filepath.Join(path, de.Name())
+		if s.opts.Exclude != nil && s.opts.Exclude(childPath, de.IsDir()) {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			continue // removed since the directory was read
+		}
+		child := s.entry(de.Name(), info)
+		child.Parent = dir
+		dir.Children = append(dir.Children, child)
+		if !child.IsDir {
+			continue
+		}
+		if s.opts.OneFileSystem {
+			if dev, ok := device(info); ok && dev != s.dev {
+				continue
+			}
+		}
+
+		// Read subdirectories in parallel while there is capacity, and
+		// inline otherwise so a deep tree can't exhaust the semaphore
+		select {
+		case s.sem <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-s.sem }()
+				s.scanDir(child, childPath)
+			}()
+		default:
+			s.scanDir(child, childPath)
+		}
+	}
+	wg.Wait()
+
+	for _, child := range dir.Children {
+		dir.Size += child.Size
+		dir.Usage += child.Usage
+		dir.Files += child.Files
+		dir.Dirs += child.Dirs
+		if child.IsDir {
+			dir.Dirs++
+		}
+	}
+	sort.SliceStable(dir.Children, func(i, j int) bool {
+		a, b := dir.Children[i], dir.Children[j]
+		if a.Usage != b.Usage {
+			return a.Usage > b.Usage
+		}
+		return a.Name < b.Name
+	})
+}
+
+// reportProgress calls OnProgress if the progress interval has passed.
+func (s *scanner) reportProgress(path string) {
+	if s.opts.OnProgress == nil {
+		return
+	}
+	s.mu.Lock()
+	now := time.Now()
+	due := now.Sub(s.lastProgress) >= s.opts.ProgressInterval
+	if due {
+		s.lastProgress = now
+	}
+	s.mu.Unlock()
+	if due {
+		s.opts.OnProgress(s.progress(path, false))
+	}
+}
+
+func (s *scanner) progress(path string, done bool) Progress {
+	return Progress{
+		Files: s.files.Load(),
+		Dirs:  s.dirs.Load(),
+		Bytes: s.bytes.Load(),
+		Path:  path,
+		Done:  done,
+	}
+}
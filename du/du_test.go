@@ -0,0 +1,120 @@
+package du
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+// makeTree creates files with the given sizes under a temporary directory.
+func makeTree(t *testing.T, files map[string]int) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, size := range files {
+		path := filepath.Join(root, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(strings.Repeat("x", size)), 0o644))
+	}
+	return root
+}
+
+func TestScan(t *testing.T) {
+	root := makeTree(t, map[string]int{
+		"a.txt":         100,
+		"big/one.bin":   5000,
+		"big/two.bin":   3000,
+		"small/x.txt":   10,
+		"small/deep/y":  20,
+		"empty/.keep":   0,
+		"big/sub/z.bin": 1000,
+	})
+
+	var mu sync.Mutex
+	var last Progress
+	entry, err := Scan(context.Background(), root, Options{
+		Concurrency: 2,
+		OnProgress: func(p Progress) {
+			mu.Lock()
+			last = p
+			mu.Unlock()
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, entry.IsDir)
+	assert.Equal(t, root, entry.Path())
+	assert.Equal(t, int64(9130), entry.Size)
+	assert.Equal(t, int64(7), entry.Files)
+	assert.Equal(t, int64(5), entry.Dirs)
+
+	// Children are sorted largest first
+	assert.Equal(t, "big", entry.Children[0].Name)
+	assert.Equal(t, int64(9000), entry.Children[0].Size)
+	assert.Equal(t, "one.bin", entry.Children[0].Children[0].Name)
+	assert.Equal(t, filepath.Join(root, "big", "one.bin"), entry.Children[0].Children[0].Path())
+
+	assert.True(t, last.Done)
+	assert.Equal(t, int64(7), last.Files)
+	assert.Equal(t, int64(6), last.Dirs)
+}
+
+func TestScan_Exclude(t *testing.T) {
+	root := makeTree(t, map[string]int{"keep/a": 10, "node_modules/b": 1000})
+	entry, err := Scan(context.Background(), root, Options{
+		Exclude: func(path string, isDir bool) bool {
+			return isDir && filepath.Base(path) == "node_modules"
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entry.Children))
+	assert.Equal(t, int64(10), entry.Size)
+}
+
+func TestScan_HardLinksCountedOnce(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hard links aren't detected on Windows")
+	}
+	root := makeTree(t, map[string]int{"a": 4096})
+	assert.NoError(t, os.Link(filepath.Join(root, "a"), filepath.Join(root, "b")))
+
+	entry, err := Scan(context.Background(), root, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4096), entry.Size)
+	assert.Equal(t, int64(2), entry.Files)
+}
+
+func TestScan_Errors(t *testing.T) {
+	_, err := Scan(context.Background(), filepath.Join(t.TempDir(), "missing"), Options{})
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Scan(ctx, makeTree(t, map[string]int{"a/b": 1}), Options{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestEntry_Remove(t *testing.T) {
+	root := makeTree(t, map[string]int{"a/b/c": 100, "a/d": 50, "e": 10})
+	entry, err := Scan(context.Background(), root, Options{})
+	assert.NoError(t, err)
+
+	a := entry.Children[0]
+	b := a.Children[0]
+	assert.Equal(t, "b", b.Name)
+	assert.Equal(t, float64(b.Usage)/float64(a.Usage), b.Share())
+	assert.Equal(t, 1.0, entry.Share())
+
+	assert.NoError(t, b.Remove())
+	assert.Equal(t, int64(50), a.Size)
+	assert.Equal(t, int64(60), entry.Size)
+	assert.Equal(t, int64(2), entry.Files)
+	assert.Equal(t, int64(1), entry.Dirs)
+	_, err = os.Stat(filepath.Join(root, "a", "b"))
+	assert.True(t, os.IsNotExist(err))
+}
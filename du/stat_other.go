@@ -0,0 +1,23 @@
+//go:build !unix
+
+package du
+
+import "os"
+
+// fileID identifies a file across hard links.
+type fileID struct{}
+
+// hardLink reports no hard links, since they can't be detected here.
+func hardLink(info os.FileInfo) (fileID, bool) {
+	return fileID{}, false
+}
+
+// usage returns the size of a file, since allocated space isn't known here.
+func usage(info os.FileInfo) int64 {
+	return info.Size()
+}
+
+// device reports that the device of a file isn't known here.
+func device(info os.FileInfo) (uint64, bool) {
+	return 0, false
+}
@@ -0,0 +1,40 @@
+//go:build unix
+
+package du
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileID identifies a file across hard links.
+type fileID struct {
+	dev, ino uint64
+}
+
+// hardLink returns the identity of a file with more than one link.
+func hardLink(info os.FileInfo) (fileID, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || st.Nlink <= 1 {
+		return fileID{}, false
+	}
+	return fileID{dev: uint64(st.Dev), ino: uint64(st.Ino)}, true
+}
+
+// usage returns the disk space allocated to a file, which is smaller than
+// its size for sparse files and larger for small files.
+func usage(info os.FileInfo) int64 {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return int64(st.Blocks) * 512
+	}
+	return info.Size()
+}
+
+// device returns the device a file is on.
+func device(info os.FileInfo) (uint64, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return uint64(st.Dev), true
+}
@@ -8,12 +8,21 @@
 //   - Enter: Follow selected link / submit URL / open link from panel
 //   - j/k or Up/Down: Scroll content (or navigate links when Links focused)
 //   - b/f or Left/Right: Back/forward in history
+//   - R: Toggle reader mode (centered column, focus dimming)
+//   - /: Search the page; n/N jump to next/previous match, Esc clears
+//   - t: New tab, w: Close tab, [/]: Previous/next tab, Alt+1-9: Jump to tab
+//   - T: Open the selected link in a background tab
+//   - d: Download the selected link, D: Show/hide the downloads panel
+//     (in the panel: p pause, r resume, x cancel)
 //   - Escape: Return to content area
 //
 // Run with:
 //
 //	go run ./examples/browser https://example.com
 //	go run ./examples/browser https://golang.org/doc/
+//
+// Add --replay auto to record pages to ./cassettes and replay them on later
+// runs, or --replay replay to browse the recorded pages offline.
 package main
 
 import (
@@ -29,7 +38,9 @@ import (
 
 	"github.com/deepnoodle-ai/wonton/cli"
 	"github.com/deepnoodle-ai/wonton/clipboard"
+	"github.com/deepnoodle-ai/wonton/downloads"
 	"github.com/deepnoodle-ai/wonton/fetch"
+	"github.com/deepnoodle-ai/wonton/htmlparse"
 	"github.com/deepnoodle-ai/wonton/tui"
 )
 
@@ -60,6 +71,13 @@ type PageMetadata struct {
 	Canonical   string
 	WordCount   int
 	LinkCount   int
+	ReadingTime time.Duration
+
+	// From the page's icon links and structured data
+	Icon        string
+	Published   string
+	Breadcrumbs []string
+	Product     string
 }
 
 // FocusArea represents which UI area has focus
@@ -71,36 +89,42 @@ const (
 	FocusLinks
 )
 
+// browserPage is the content of one loaded page. Each tab keeps a history
+// of these, so back/forward restore pages without refetching them.
+type browserPage struct {
+	URL          string
+	Title        string
+	Markdown     string
+	Links        []PageLink
+	Metadata     PageMetadata
+	SelectedLink int // -1 means no link selected
+	LinkScroll   int // Scroll offset in link panel
+}
+
 // BrowserApp is the TUI application state
 type BrowserApp struct {
 	mu sync.Mutex
 
-	// Current page
-	currentURL  string
-	pageTitle   string
-	markdown    string
-	links       []PageLink
-	metadata    PageMetadata
-	loading     bool
-	errorMsg    string
+	// Open tabs, each with its own page, history, scroll, and loading state
+	tabs        *tui.TabbedPages[*browserPage]
 	statusMsg   string
 	loadingTime time.Time
 
-	// Navigation
-	history      []HistoryEntry
-	historyIndex int
-
 	// Display
-	scrollY int // Scroll position for markdown view
-	width   int
-	height  int
+	readerMode bool // Centered reading column with focus dimming
+	search     tui.SearchController
+	width      int
+	height     int
 
 	// Focus and input
-	focus        FocusArea
-	urlInput     string // URL being edited
-	urlCursor    int    // Cursor position in URL
-	selectedLink int    // -1 means no link selected
-	linkScroll   int    // Scroll offset in link panel
+	focus     FocusArea
+	urlInput  string // URL being edited
+	urlCursor int    // Cursor position in URL
+
+	// Downloads (shown in place of the links panel when showDownloads is set)
+	downloads        *downloads.Manager
+	showDownloads    bool
+	selectedDownload int
 
 	// Fetcher
 	fetcher *fetch.HTTPFetcher
@@ -117,6 +141,16 @@ func main() {
 			cli.Int("timeout", "t").
 				Default(30).
 				Help("Request timeout in seconds"),
+			cli.String("download-dir", "d").
+				Default(".").
+				Help("Directory for downloaded files"),
+			cli.String("replay", "").
+				Default("off").
+				Enum("off", "auto", "record", "replay").
+				Help("Record pages to cassettes or replay them"),
+			cli.String("cassettes", "").
+				Default("cassettes").
+				Help("Directory for recorded cassettes"),
 		).
 		Run(func(ctx *cli.Context) error {
 			initialURL := ctx.Arg(0)
@@ -130,23 +164,33 @@ func main() {
 				initialURL = "https://" + initialURL
 			}
 
+			replay, err := fetch.ParseReplayMode(ctx.String("replay"))
+			if err != nil {
+				return err
+			}
+
 			tuiApp := &BrowserApp{
 				fetcher: fetch.NewHTTPFetcher(fetch.HTTPFetcherOptions{
 					Timeout: time.Duration(ctx.Int("timeout")) * time.Second,
 					Headers: map[string]string{
 						"User-Agent": "WontonBrowser/1.0 (terminal)",
 					},
+					Replay:      replay,
+					CassetteDir: ctx.String("cassettes"),
+				}),
+				downloads: downloads.New(downloads.Options{
+					Dir: ctx.String("download-dir"),
 				}),
-				historyIndex: -1,
-				selectedLink: -1,
-				focus:        FocusContent,
-				urlInput:     initialURL,
+				tabs:     tui.NewTabbedPages[*browserPage](),
+				focus:    FocusContent,
+				urlInput: initialURL,
 			}
 
-			// Start loading the initial page
-			go tuiApp.loadPage(initialURL)
+			// Start loading the initial page in the first tab
+			tuiApp.openTab(initialURL, true)
 
-			// Run TUI
+			// Run TUI; unfinished downloads are paused on exit
+			defer tuiApp.downloads.Close()
 			return tui.Run(tuiApp)
 		})
 
@@ -159,11 +203,13 @@ func main() {
 	}
 }
 
-func (app *BrowserApp) loadPage(pageURL string) {
+// loadPage fetches pageURL into the tab with the given ID. The tab is marked
+// as loading by the caller (under the lock) before this runs in a goroutine.
+// When replace is set (reload), the tab's current history entry is updated
+// instead of a new one being pushed.
+func (app *BrowserApp) loadPage(tabID int, pageURL string, replace bool) {
 	app.mu.Lock()
-	app.loading = true
 	app.loadingTime = time.Now()
-	app.errorMsg = ""
 	app.statusMsg = "Loading..."
 	app.mu.Unlock()
 
@@ -179,82 +225,178 @@ func (app *BrowserApp) loadPage(pageURL string) {
 	app.mu.Lock()
 	defer app.mu.Unlock()
 
-	app.loading = false
+	// The tab may have been closed while the page was loading
+	tab := app.tabs.Page(tabID)
+	if tab == nil {
+		return
+	}
+	tab.Loading = false
 
 	if err != nil {
-		app.errorMsg = fmt.Sprintf("Error loading page: %v", err)
+		tab.Err = err
 		app.statusMsg = "Error"
 		return
 	}
 
-	// Update current page
-	app.currentURL = resp.URL
-	app.urlInput = resp.URL
-	app.urlCursor = len(resp.URL)
-	app.pageTitle = resp.Metadata.Title
-	if app.pageTitle == "" {
-		app.pageTitle = resp.URL
+	page := &browserPage{
+		URL:          resp.URL,
+		Title:        resp.Metadata.Title,
+		Markdown:     resp.Markdown,
+		SelectedLink: -1,
+	}
+	if page.Title == "" {
+		page.Title = resp.URL
 	}
-	app.markdown = resp.Markdown
 
 	// Extract metadata
-	app.metadata = PageMetadata{
+	page.Metadata = PageMetadata{
 		Title:       resp.Metadata.Title,
 		Description: resp.Metadata.Description,
 		Author:      resp.Metadata.Author,
 		Canonical:   resp.Metadata.Canonical,
 		WordCount:   countWords(resp.Markdown),
+		ReadingTime: resp.ReadingTime,
 	}
 	// Extract OpenGraph metadata if available
 	if resp.Metadata.OpenGraph != nil {
-		if app.metadata.SiteName == "" {
-			app.metadata.SiteName = resp.Metadata.OpenGraph.SiteName
+		if page.Metadata.SiteName == "" {
+			page.Metadata.SiteName = resp.Metadata.OpenGraph.SiteName
 		}
-		if app.metadata.PageType == "" {
-			app.metadata.PageType = resp.Metadata.OpenGraph.Type
+		if page.Metadata.PageType == "" {
+			page.Metadata.PageType = resp.Metadata.OpenGraph.Type
 		}
 	}
 
-	// Extract links for the links panel
-	app.extractLinks(resp)
+	// Extract the icon and structured data
+	if icon, ok := resp.Metadata.Favicon(32); ok {
+		page.Metadata.Icon = icon.URL
+		if base, err := url.Parse(resp.URL); err == nil {
+			if resolved, err := base.Parse(icon.URL); err == nil {
+				page.Metadata.Icon = resolved.String()
+			}
+		}
+	}
+	if sd := resp.Metadata.StructuredData; sd != nil {
+		if len(sd.Articles) > 0 {
+			a := sd.Articles[0]
+			if page.Metadata.Author == "" && len(a.Authors) > 0 {
+				page.Metadata.Author = strings.Join(a.Authors, ", ")
+			}
+			page.Metadata.Published, _, _ = strings.Cut(a.DatePublished, "T")
+		}
+		if len(sd.Breadcrumbs) > 0 {
+			for _, crumb := range sd.Breadcrumbs[0] {
+				page.Metadata.Breadcrumbs = append(page.Metadata.Breadcrumbs, crumb.Name)
+			}
+		}
+		if len(sd.Products) > 0 {
+			page.Metadata.Product = describeProduct(sd.Products[0])
+		}
+	}
 
-	app.scrollY = 0
-	app.selectedLink = -1
-	app.linkScroll = 0
-	app.focus = FocusContent
+	// Extract links for the links panel
+	page.Links = extractLinks(resp)
+	page.Metadata.LinkCount = len(page.Links)
 
 	// Auto-select first link if available
-	if len(app.links) > 0 {
-		app.selectedLink = 0
+	if len(page.Links) > 0 {
+		page.SelectedLink = 0
 	}
 
-	app.metadata.LinkCount = len(app.links)
+	// Add to the tab's history (reloads replace the current entry)
+	tab.Title = page.Title
+	if tab.HistoryIndex() >= 0 && (replace || tab.State.URL == page.URL) {
+		tab.Replace(page)
+		tab.ScrollY = 0
+	} else {
+		tab.Navigate(page)
+	}
+	tab.Err = nil
 
-	// Add to history
-	entry := HistoryEntry{URL: resp.URL, Title: app.pageTitle}
-	if app.historyIndex < 0 || app.history[app.historyIndex].URL != resp.URL {
-		// Truncate forward history if we navigated from middle
-		if app.historyIndex < len(app.history)-1 {
-			app.history = app.history[:app.historyIndex+1]
-		}
-		app.history = append(app.history, entry)
-		app.historyIndex = len(app.history) - 1
+	if tab == app.tabs.Active() {
+		app.urlInput = page.URL
+		app.urlCursor = len(page.URL)
+		app.focus = FocusContent
 	}
 
 	elapsed := time.Since(app.loadingTime)
 	app.statusMsg = fmt.Sprintf("Loaded in %dms", elapsed.Milliseconds())
 }
 
+// navigate starts loading pageURL in the active tab. The lock must be held.
+func (app *BrowserApp) navigate(pageURL string) {
+	tab := app.tabs.Active()
+	if tab == nil {
+		return
+	}
+	tab.Loading = true
+	tab.Err = nil
+	app.urlInput = pageURL
+	app.urlCursor = len(pageURL)
+	app.search.Clear()
+	go app.loadPage(tab.ID, pageURL, false)
+}
+
+// openTab opens pageURL in a new tab, in the background unless foreground is
+// set. The lock must be held.
+func (app *BrowserApp) openTab(pageURL string, foreground bool) {
+	placeholder := &browserPage{URL: pageURL, SelectedLink: -1}
+	var tab *tui.TabPage[*browserPage]
+	if foreground {
+		tab = app.tabs.Open(pageURL, placeholder)
+		app.tabSwitched()
+	} else {
+		tab = app.tabs.OpenBackground(pageURL, placeholder)
+	}
+	tab.Loading = true
+	go app.loadPage(tab.ID, pageURL, false)
+}
+
+// tabSwitched syncs the URL bar and search with the newly active tab.
+func (app *BrowserApp) tabSwitched() {
+	app.search.Clear()
+	app.urlInput = app.page().URL
+	app.urlCursor = len(app.urlInput)
+}
+
+// page returns the active tab's current page. It is never nil.
+func (app *BrowserApp) page() *browserPage {
+	if tab := app.tabs.Active(); tab != nil && tab.State != nil {
+		return tab.State
+	}
+	return &browserPage{SelectedLink: -1}
+}
+
 // extractLinks extracts links from the markdown for the links panel
-func (app *BrowserApp) extractLinks(resp *fetch.Response) {
+// describeProduct summarizes a product for the Page Info panel, like
+// "Desk Lamp · 25.00 EUR · InStock · ★ 4.5".
+func describeProduct(p htmlparse.Product) string {
+	parts := []string{p.Name}
+	if len(p.Offers) > 0 {
+		o := p.Offers[0]
+		parts = append(parts, strings.TrimSpace(o.Price+" "+o.Currency), o.Availability)
+	}
+	if p.Rating != nil && p.Rating.Value > 0 {
+		parts = append(parts, fmt.Sprintf("★ %g", p.Rating.Value))
+	}
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, " · ")
+}
+
+func extractLinks(resp *fetch.Response) []PageLink {
 	baseURL, _ := url.Parse(resp.URL)
-	app.links = nil
+	var links []PageLink
 
 	// Regex to find markdown links
 	linkRegex := regexp.MustCompile(`\[([^\]]*)\]\(([^)]+)\)`)
 	linkIndex := 1
 
-	matches := linkRegex.FindAllStringSubmatch(app.markdown, -1)
+	matches := linkRegex.FindAllStringSubmatch(resp.Markdown, -1)
 	for _, match := range matches {
 		if len(match) < 3 {
 			continue
@@ -277,13 +419,14 @@ func (app *BrowserApp) extractLinks(resp *fetch.Response) {
 			continue
 		}
 
-		app.links = append(app.links, PageLink{
+		links = append(links, PageLink{
 			URL:   linkURL,
 			Text:  linkText,
 			Index: linkIndex,
 		})
 		linkIndex++
 	}
+	return links
 }
 
 func countWords(text string) int {
@@ -332,6 +475,11 @@ func (app *BrowserApp) HandleEvent(event tui.Event) []tui.Cmd {
 }
 
 func (app *BrowserApp) handleContentInput(e tui.KeyEvent) []tui.Cmd {
+	// Search keys (/, n, N, and typing a query) take precedence
+	if app.search.HandleKey(e) {
+		return nil
+	}
+
 	// Quit
 	if e.Rune == 'q' || e.Rune == 'Q' {
 		return []tui.Cmd{tui.Quit()}
@@ -348,30 +496,32 @@ func (app *BrowserApp) handleContentInput(e tui.KeyEvent) []tui.Cmd {
 		return nil
 	}
 
+	tab := app.tabs.Active()
+	page := app.page()
+
 	// Page size for scrolling
 	pageSize := app.contentHeight()
 
-	// Key handling - markdown view clamps scrollY automatically
+	// Key handling - markdown view clamps ScrollY automatically
 	switch e.Key {
 	case tui.KeyEnter:
-		if app.selectedLink >= 0 && app.selectedLink < len(app.links) {
-			linkURL := app.links[app.selectedLink].URL
-			go app.loadPage(linkURL)
+		if page.SelectedLink >= 0 && page.SelectedLink < len(page.Links) {
+			app.navigate(page.Links[page.SelectedLink].URL)
 		}
 		return nil
 
 	case tui.KeyArrowUp:
-		app.scrollY--
+		tab.ScrollY--
 	case tui.KeyArrowDown:
-		app.scrollY++
+		tab.ScrollY++
 	case tui.KeyPageUp, tui.KeyCtrlB:
-		app.scrollY -= pageSize
+		tab.ScrollY -= pageSize
 	case tui.KeyPageDown, tui.KeyCtrlF:
-		app.scrollY += pageSize
+		tab.ScrollY += pageSize
 	case tui.KeyHome:
-		app.scrollY = 0
+		tab.ScrollY = 0
 	case tui.KeyEnd:
-		app.scrollY = 999999 // Will be clamped by markdown view
+		tab.ScrollY = 999999 // Will be clamped by markdown view
 	case tui.KeyArrowLeft:
 		app.goBack()
 	case tui.KeyArrowRight:
@@ -381,45 +531,87 @@ func (app *BrowserApp) handleContentInput(e tui.KeyEvent) []tui.Cmd {
 	// Rune keys
 	switch e.Rune {
 	case 'j':
-		app.scrollY++
+		tab.ScrollY++
 	case 'k':
-		app.scrollY--
+		tab.ScrollY--
 	case ' ':
-		app.scrollY += pageSize
+		tab.ScrollY += pageSize
 	case 'G':
-		app.scrollY = 999999 // Will be clamped
+		tab.ScrollY = 999999 // Will be clamped
 	case 'g':
-		app.scrollY = 0
+		tab.ScrollY = 0
 	case 'b':
 		app.goBack()
 	case 'f':
 		app.goForward()
 	case 'r':
-		if app.currentURL != "" {
-			go app.loadPage(app.currentURL)
+		if page.URL != "" && !tab.Loading {
+			tab.Loading = true
+			go app.loadPage(tab.ID, page.URL, true)
+		}
+	case 't':
+		// New tab: start by typing its URL
+		app.tabs.Open("New Tab", &browserPage{SelectedLink: -1})
+		app.tabSwitched()
+		app.focus = FocusURLBar
+	case 'T':
+		if page.SelectedLink >= 0 && page.SelectedLink < len(page.Links) {
+			app.openTab(page.Links[page.SelectedLink].URL, false)
+			app.statusMsg = "Opened link in new tab"
+		}
+	case 'w':
+		if app.tabs.Len() == 1 {
+			return []tui.Cmd{tui.Quit()}
+		}
+		app.tabs.CloseActive()
+		app.tabSwitched()
+	case ']':
+		app.tabs.Next()
+		app.tabSwitched()
+	case '[':
+		app.tabs.Prev()
+		app.tabSwitched()
+	case 'R':
+		app.readerMode = !app.readerMode
+		if app.readerMode {
+			app.statusMsg = "Reader mode on"
+		} else {
+			app.statusMsg = "Reader mode off"
 		}
 	case 'c':
-		clipboard.Write(app.currentURL)
+		clipboard.Write(page.URL)
 		app.statusMsg = "URL copied"
 	case 'C':
-		if app.selectedLink >= 0 && app.selectedLink < len(app.links) {
-			clipboard.Write(app.links[app.selectedLink].URL)
+		if page.SelectedLink >= 0 && page.SelectedLink < len(page.Links) {
+			clipboard.Write(page.Links[page.SelectedLink].URL)
 			app.statusMsg = "Link URL copied"
 		}
+	case 'd':
+		app.downloadSelectedLink()
+	case 'D':
+		app.showDownloads = !app.showDownloads
 	case 'l':
 		// Quick switch to links panel
+		app.showDownloads = false
 		app.focus = FocusLinks
 	case '1', '2', '3', '4', '5', '6', '7', '8', '9':
 		linkNum := int(e.Rune - '0')
-		for i, link := range app.links {
+		for i, link := range page.Links {
 			if link.Index == linkNum {
-				app.selectedLink = i
+				page.SelectedLink = i
 				app.updateLinkScroll()
 				break
 			}
 		}
 	}
 
+	// Alt+1..9 jumps straight to a tab
+	if e.Alt && e.Rune >= '1' && e.Rune <= '9' {
+		if app.tabs.Switch(int(e.Rune - '1')) {
+			app.tabSwitched()
+		}
+	}
+
 	return nil
 }
 
@@ -427,7 +619,7 @@ func (app *BrowserApp) handleURLBarInput(e tui.KeyEvent) []tui.Cmd {
 	switch e.Key {
 	case tui.KeyEscape:
 		app.focus = FocusContent
-		app.urlInput = app.currentURL
+		app.urlInput = app.page().URL
 		return nil
 
 	case tui.KeyTab:
@@ -444,7 +636,7 @@ func (app *BrowserApp) handleURLBarInput(e tui.KeyEvent) []tui.Cmd {
 		if !strings.HasPrefix(urlToLoad, "http://") && !strings.HasPrefix(urlToLoad, "https://") {
 			urlToLoad = "https://" + urlToLoad
 		}
-		go app.loadPage(urlToLoad)
+		app.navigate(urlToLoad)
 		return nil
 
 	case tui.KeyBackspace:
@@ -500,6 +692,12 @@ func (app *BrowserApp) handleURLBarInput(e tui.KeyEvent) []tui.Cmd {
 }
 
 func (app *BrowserApp) handleLinksInput(e tui.KeyEvent) []tui.Cmd {
+	if app.showDownloads {
+		return app.handleDownloadsInput(e)
+	}
+
+	page := app.page()
+
 	switch e.Key {
 	case tui.KeyEscape:
 		app.focus = FocusContent
@@ -515,9 +713,8 @@ func (app *BrowserApp) handleLinksInput(e tui.KeyEvent) []tui.Cmd {
 		return nil
 
 	case tui.KeyEnter:
-		if app.selectedLink >= 0 && app.selectedLink < len(app.links) {
-			linkURL := app.links[app.selectedLink].URL
-			go app.loadPage(linkURL)
+		if page.SelectedLink >= 0 && page.SelectedLink < len(page.Links) {
+			app.navigate(page.Links[page.SelectedLink].URL)
 		}
 		return nil
 
@@ -544,15 +741,15 @@ func (app *BrowserApp) handleLinksInput(e tui.KeyEvent) []tui.Cmd {
 		return nil
 
 	case tui.KeyHome:
-		if len(app.links) > 0 {
-			app.selectedLink = 0
-			app.linkScroll = 0
+		if len(page.Links) > 0 {
+			page.SelectedLink = 0
+			page.LinkScroll = 0
 		}
 		return nil
 
 	case tui.KeyEnd:
-		if len(app.links) > 0 {
-			app.selectedLink = len(app.links) - 1
+		if len(page.Links) > 0 {
+			page.SelectedLink = len(page.Links) - 1
 			app.updateLinkScroll()
 		}
 		return nil
@@ -566,9 +763,18 @@ func (app *BrowserApp) handleLinksInput(e tui.KeyEvent) []tui.Cmd {
 		app.prevLink()
 	case 'q', 'Q':
 		return []tui.Cmd{tui.Quit()}
+	case 'T':
+		if page.SelectedLink >= 0 && page.SelectedLink < len(page.Links) {
+			app.openTab(page.Links[page.SelectedLink].URL, false)
+			app.statusMsg = "Opened link in new tab"
+		}
+	case 'd':
+		app.downloadSelectedLink()
+	case 'D':
+		app.showDownloads = true
 	case 'c':
-		if app.selectedLink >= 0 && app.selectedLink < len(app.links) {
-			clipboard.Write(app.links[app.selectedLink].URL)
+		if page.SelectedLink >= 0 && page.SelectedLink < len(page.Links) {
+			clipboard.Write(page.Links[page.SelectedLink].URL)
 			app.statusMsg = "Link URL copied"
 		}
 	}
@@ -576,10 +782,75 @@ func (app *BrowserApp) handleLinksInput(e tui.KeyEvent) []tui.Cmd {
 	return nil
 }
 
+// handleDownloadsInput handles keys while the downloads panel has focus
+func (app *BrowserApp) handleDownloadsInput(e tui.KeyEvent) []tui.Cmd {
+	switch e.Key {
+	case tui.KeyEscape:
+		app.focus = FocusContent
+		return nil
+	case tui.KeyTab:
+		if e.Shift {
+			app.focus = FocusContent
+		} else {
+			app.focus = FocusURLBar
+			app.urlCursor = len(app.urlInput)
+		}
+		return nil
+	case tui.KeyArrowUp:
+		app.selectedDownload--
+		return nil
+	case tui.KeyArrowDown:
+		app.selectedDownload++
+		return nil
+	}
+
+	// The view clamps selectedDownload, so it may briefly be out of range
+	list := app.downloads.List()
+	id := 0
+	if app.selectedDownload >= 0 && app.selectedDownload < len(list) {
+		id = list[app.selectedDownload].ID
+	}
+
+	var err error
+	switch e.Rune {
+	case 'j':
+		app.selectedDownload++
+	case 'k':
+		app.selectedDownload--
+	case 'p':
+		err = app.downloads.Pause(id)
+	case 'r':
+		err = app.downloads.Resume(id)
+	case 'x':
+		err = app.downloads.Cancel(id)
+	case 'D':
+		app.showDownloads = false
+	case 'q', 'Q':
+		return []tui.Cmd{tui.Quit()}
+	}
+	if err != nil {
+		app.statusMsg = "Download: " + err.Error()
+	}
+	return nil
+}
+
+// downloadSelectedLink queues the selected link for download
+func (app *BrowserApp) downloadSelectedLink() {
+	page := app.page()
+	if page.SelectedLink < 0 || page.SelectedLink >= len(page.Links) {
+		return
+	}
+	if _, err := app.downloads.Add(downloads.Request{URL: page.Links[page.SelectedLink].URL}); err != nil {
+		app.statusMsg = "Download: " + err.Error()
+		return
+	}
+	app.statusMsg = "Download started (D: show downloads)"
+}
+
 // contentHeight returns available height for content
 func (app *BrowserApp) contentHeight() int {
-	// Reserve: header(3) + url bar(3) + metadata(5) + content border(2) + link panel(linksToShow+4) + footer(2)
-	h := app.height - (3 + 3 + 5 + 2 + linksToShow + 4 + 2)
+	// Reserve: header(3) + tabs(1) + url bar(3) + metadata(5) + content border(2) + link panel(linksToShow+4) + footer(2)
+	h := app.height - (3 + 1 + 3 + 5 + 2 + linksToShow + 4 + 2)
 	if h < 5 {
 		h = 5
 	}
@@ -598,48 +869,54 @@ func (app *BrowserApp) sectionWidth() int {
 }
 
 func (app *BrowserApp) nextLink() {
-	if len(app.links) == 0 {
+	page := app.page()
+
+	if len(page.Links) == 0 {
 		return
 	}
-	app.selectedLink++
-	if app.selectedLink >= len(app.links) {
-		app.selectedLink = 0
+	page.SelectedLink++
+	if page.SelectedLink >= len(page.Links) {
+		page.SelectedLink = 0
 	}
 	app.updateLinkScroll()
 }
 
 func (app *BrowserApp) prevLink() {
-	if len(app.links) == 0 {
+	page := app.page()
+
+	if len(page.Links) == 0 {
 		return
 	}
-	app.selectedLink--
-	if app.selectedLink < 0 {
-		app.selectedLink = len(app.links) - 1
+	page.SelectedLink--
+	if page.SelectedLink < 0 {
+		page.SelectedLink = len(page.Links) - 1
 	}
 	app.updateLinkScroll()
 }
 
 func (app *BrowserApp) updateLinkScroll() {
+	page := app.page()
+
 	// Keep selected link visible in link panel
-	if app.selectedLink < app.linkScroll {
-		app.linkScroll = app.selectedLink
+	if page.SelectedLink < page.LinkScroll {
+		page.LinkScroll = page.SelectedLink
 	}
-	if app.selectedLink >= app.linkScroll+linksToShow {
-		app.linkScroll = app.selectedLink - linksToShow + 1
+	if page.SelectedLink >= page.LinkScroll+linksToShow {
+		page.LinkScroll = page.SelectedLink - linksToShow + 1
 	}
 }
 
 func (app *BrowserApp) goBack() {
-	if app.historyIndex > 0 {
-		app.historyIndex--
-		go app.loadPage(app.history[app.historyIndex].URL)
+	if tab := app.tabs.Active(); tab != nil && !tab.Loading && tab.Back() {
+		tab.Err = nil
+		app.tabSwitched()
 	}
 }
 
 func (app *BrowserApp) goForward() {
-	if app.historyIndex < len(app.history)-1 {
-		app.historyIndex++
-		go app.loadPage(app.history[app.historyIndex].URL)
+	if tab := app.tabs.Active(); tab != nil && !tab.Loading && tab.Forward() {
+		tab.Err = nil
+		app.tabSwitched()
 	}
 }
 
@@ -650,6 +927,9 @@ func (app *BrowserApp) View() tui.View {
 	// === HEADER ===
 	header := app.buildHeader()
 
+	// === TABS ===
+	tabStrip := app.buildTabStrip()
+
 	// === URL BAR ===
 	urlBar := app.buildURLBar()
 
@@ -667,6 +947,7 @@ func (app *BrowserApp) View() tui.View {
 
 	return tui.Stack(
 		header,
+		tabStrip,
 		urlBar,
 		metadataView,
 		contentView,
@@ -690,15 +971,16 @@ func (app *BrowserApp) buildHeader() tui.View {
 
 	// Navigation indicator
 	var navText string
-	if len(app.history) > 1 {
-		navText = fmt.Sprintf(" [%d/%d] ", app.historyIndex+1, len(app.history))
+	tab := app.tabs.Active()
+	if tab != nil && len(tab.History()) > 1 {
+		navText = fmt.Sprintf(" [%d/%d] ", tab.HistoryIndex()+1, len(tab.History()))
 	}
 
 	// Status indicator
 	var statusIndicator tui.View
-	if app.loading {
+	if tab != nil && tab.Loading {
 		statusIndicator = tui.Text(" Loading... ").FgRGB(255, 200, 100)
-	} else if app.errorMsg != "" {
+	} else if tab != nil && tab.Err != nil {
 		statusIndicator = tui.Text(" Error ").FgRGB(255, 100, 100)
 	} else {
 		statusIndicator = tui.Text(" Ready ").FgRGB(100, 255, 150)
@@ -724,6 +1006,19 @@ func (app *BrowserApp) buildHeader() tui.View {
 	))
 }
 
+func (app *BrowserApp) buildTabStrip() tui.View {
+	w := app.sectionWidth()
+
+	return tui.Width(w, tui.Group(
+		tui.TabStrip(app.tabs).
+			Numbered(true).
+			MaxTabWidth(28).
+			ActiveStyle(tui.NewStyle().WithBgRGB(tui.NewRGB(60, 80, 100)).WithFgRGB(tui.NewRGB(220, 230, 255)).WithBold()).
+			InactiveStyle(tui.NewStyle().WithFgRGB(tui.NewRGB(120, 140, 160))),
+		tui.Spacer(),
+	))
+}
+
 func (app *BrowserApp) buildURLBar() tui.View {
 	w := app.sectionWidth()
 
@@ -763,15 +1058,18 @@ func (app *BrowserApp) buildURLBar() tui.View {
 func (app *BrowserApp) buildMetadataView() tui.View {
 	w := app.sectionWidth()
 
-	if app.loading {
+	tab := app.tabs.Active()
+	page := app.page()
+
+	if tab.Loading {
 		return tui.Width(w, tui.Bordered(
-			tui.Text(" Loading %s...", app.currentURL).Fg(tui.ColorYellow),
+			tui.Text(" Loading %s...", app.urlInput).Fg(tui.ColorYellow),
 		).Border(&tui.RoundedBorder).Title("Page").BorderFg(tui.ColorYellow))
 	}
 
-	if app.errorMsg != "" {
+	if tab.Err != nil {
 		return tui.Width(w, tui.Bordered(
-			tui.Text(" %s", app.errorMsg).Fg(tui.ColorRed),
+			tui.Text(" Error loading page: %v", tab.Err).Fg(tui.ColorRed),
 		).Border(&tui.RoundedBorder).Title("Error").BorderFg(tui.ColorRed))
 	}
 
@@ -799,7 +1097,7 @@ func (app *BrowserApp) buildMetadataView() tui.View {
 	}
 
 	// Title
-	title := app.metadata.Title
+	title := page.Metadata.Title
 	if title == "" {
 		title = "(untitled)"
 	}
@@ -809,12 +1107,15 @@ func (app *BrowserApp) buildMetadataView() tui.View {
 	rows = append(rows, tui.Text(" %s", title).Bold().FgRGB(220, 230, 255))
 
 	// Site name
-	if app.metadata.SiteName != "" {
-		addRow("Site", app.metadata.SiteName, maxValLen)
+	if page.Metadata.SiteName != "" {
+		addRow("Site", page.Metadata.SiteName, maxValLen)
 	}
+	addRow("Path", strings.Join(page.Metadata.Breadcrumbs, " › "), maxValLen)
+	addRow("Product", page.Metadata.Product, maxValLen)
+	addRow("Icon", page.Metadata.Icon, maxValLen)
 
 	// Description
-	desc := app.metadata.Description
+	desc := page.Metadata.Description
 	if desc != "" {
 		if len(desc) > maxValLen {
 			desc = desc[:maxValLen-3] + "..."
@@ -824,13 +1125,19 @@ func (app *BrowserApp) buildMetadataView() tui.View {
 
 	// Stats line
 	var statParts []string
-	statParts = append(statParts, fmt.Sprintf("%d words", app.metadata.WordCount))
-	statParts = append(statParts, fmt.Sprintf("%d links", app.metadata.LinkCount))
-	if app.metadata.Author != "" {
-		statParts = append(statParts, fmt.Sprintf("by %s", app.metadata.Author))
+	statParts = append(statParts, fmt.Sprintf("%d words", page.Metadata.WordCount))
+	statParts = append(statParts, fmt.Sprintf("%d links", page.Metadata.LinkCount))
+	if page.Metadata.ReadingTime > 0 {
+		statParts = append(statParts, fmt.Sprintf("%d min read", int(page.Metadata.ReadingTime.Minutes())))
+	}
+	if page.Metadata.Author != "" {
+		statParts = append(statParts, fmt.Sprintf("by %s", page.Metadata.Author))
+	}
+	if page.Metadata.Published != "" {
+		statParts = append(statParts, page.Metadata.Published)
 	}
-	if app.metadata.PageType != "" {
-		statParts = append(statParts, app.metadata.PageType)
+	if page.Metadata.PageType != "" {
+		statParts = append(statParts, page.Metadata.PageType)
 	}
 	rows = append(rows, tui.Text(" %s", strings.Join(statParts, " • ")).FgRGB(120, 140, 160))
 
@@ -850,30 +1157,44 @@ func (app *BrowserApp) buildContentView() tui.View {
 	}
 
 	title := "Content"
+	if app.readerMode {
+		title = "Reader"
+	}
 	if app.focus == FocusContent {
-		title = "Content (focused)"
+		title += " (focused)"
 	}
 
 	var content tui.View
 
-	if app.loading {
+	tab := app.tabs.Active()
+	page := app.page()
+
+	if tab.Loading {
 		content = tui.Stack(
 			tui.Spacer().MinHeight(2),
 			tui.Text("Loading...").FgRGB(255, 200, 100),
 		)
-	} else if app.errorMsg != "" {
+	} else if tab.Err != nil {
 		content = tui.Stack(
 			tui.Spacer().MinHeight(2),
 			tui.Text("Error").Fg(tui.ColorRed).Bold(),
-			tui.Text("%s", app.errorMsg).Fg(tui.ColorWhite),
+			tui.Text("Error loading page: %v", tab.Err).Fg(tui.ColorWhite),
 		)
-	} else if app.markdown == "" {
+	} else if page.Markdown == "" {
 		content = tui.Text("No content").Fg(tui.ColorBrightBlack)
 	} else {
 		// Use the markdown view component with proper rendering
-		content = tui.Markdown(app.markdown, &app.scrollY).
+		md := tui.Markdown(page.Markdown, &tab.ScrollY).
 			MaxWidth(w - 4). // Account for border and padding
-			Height(h)
+			Height(h).
+			SearchWith(&app.search)
+		if app.readerMode {
+			md = md.Theme(tui.ReaderMarkdownTheme()).
+				ReadingWidth(72).
+				ParagraphSpacing(1).
+				FocusMode(true)
+		}
+		content = md
 	}
 
 	return tui.Width(w, tui.Bordered(content).
@@ -883,7 +1204,12 @@ func (app *BrowserApp) buildContentView() tui.View {
 }
 
 func (app *BrowserApp) buildLinkPanel() tui.View {
+	if app.showDownloads {
+		return app.buildDownloadsPanel()
+	}
+
 	w := app.sectionWidth()
+	page := app.page()
 
 	// Border color based on focus (cyan for all focused sections)
 	borderColor := tui.ColorBrightBlack
@@ -891,7 +1217,7 @@ func (app *BrowserApp) buildLinkPanel() tui.View {
 		borderColor = tui.ColorCyan
 	}
 
-	if len(app.links) == 0 {
+	if len(page.Links) == 0 {
 		return tui.Width(w, tui.Bordered(
 			tui.Text(" No links on this page").Fg(tui.ColorBrightBlack),
 		).Border(&tui.RoundedBorder).Title("Links").BorderFg(borderColor))
@@ -900,10 +1226,10 @@ func (app *BrowserApp) buildLinkPanel() tui.View {
 	var linkViews []tui.View
 
 	// Show links around the selected one
-	start := app.linkScroll
+	start := page.LinkScroll
 	end := start + linksToShow
-	if end > len(app.links) {
-		end = len(app.links)
+	if end > len(page.Links) {
+		end = len(page.Links)
 	}
 
 	// Calculate widths for two-column layout
@@ -918,7 +1244,7 @@ func (app *BrowserApp) buildLinkPanel() tui.View {
 	}
 
 	for i := start; i < end; i++ {
-		link := app.links[i]
+		link := page.Links[i]
 
 		// Prepare link text
 		text := link.Text
@@ -940,7 +1266,7 @@ func (app *BrowserApp) buildLinkPanel() tui.View {
 		}
 
 		var leftView, rightView tui.View
-		if i == app.selectedLink {
+		if i == page.SelectedLink {
 			// Highlighted selected link
 			leftView = tui.Text(" > [%d] %s", link.Index, text).FgRGB(255, 220, 100).Bold()
 			rightView = tui.Text("%s", linkURL).FgRGB(180, 160, 80)
@@ -959,9 +1285,9 @@ func (app *BrowserApp) buildLinkPanel() tui.View {
 	// Navigation hint with highlighted numbers
 	linkViews = append(linkViews, tui.Group(
 		tui.Text(" Link ").FgRGB(80, 100, 120),
-		tui.Text("%d", app.selectedLink+1).FgRGB(255, 200, 100).Bold(),
+		tui.Text("%d", page.SelectedLink+1).FgRGB(255, 200, 100).Bold(),
 		tui.Text(" of ").FgRGB(80, 100, 120),
-		tui.Text("%d", len(app.links)).FgRGB(200, 220, 255).Bold(),
+		tui.Text("%d", len(page.Links)).FgRGB(200, 220, 255).Bold(),
 	))
 
 	title := "Links"
@@ -974,6 +1300,26 @@ func (app *BrowserApp) buildLinkPanel() tui.View {
 	).Border(&tui.RoundedBorder).Title(title).BorderFg(borderColor))
 }
 
+func (app *BrowserApp) buildDownloadsPanel() tui.View {
+	w := app.sectionWidth()
+
+	borderColor := tui.ColorBrightBlack
+	title := "Downloads"
+	if app.focus == FocusLinks {
+		borderColor = tui.ColorCyan
+		title = "Downloads (focused - j/k to select, p/r/x to pause/resume/cancel)"
+	}
+
+	// Same height as the links panel: linksToShow rows plus the hint line
+	return tui.Width(w, tui.Bordered(
+		tui.Height(linksToShow+1,
+			tui.DownloadsView(app.downloads.List(), &app.selectedDownload).
+				Height(linksToShow+1).
+				EmptyText(" No downloads yet - press d on a link"),
+		),
+	).Border(&tui.RoundedBorder).Title(title).BorderFg(borderColor))
+}
+
 func (app *BrowserApp) buildFooter() tui.View {
 	w := app.sectionWidth()
 
@@ -983,9 +1329,12 @@ func (app *BrowserApp) buildFooter() tui.View {
 	case FocusURLBar:
 		helpText = "Enter: Navigate | Esc: Cancel | Tab: Next area"
 	case FocusLinks:
-		helpText = "j/k: Navigate | Enter: Follow | c: Copy URL | Tab: Next area | Esc: Content"
+		helpText = "j/k: Navigate | Enter: Follow | T: New tab | d: Download | c: Copy URL | Tab: Next area | Esc: Content"
+		if app.showDownloads {
+			helpText = "j/k: Select | p: Pause | r: Resume | x: Cancel | D: Links | Esc: Content"
+		}
 	default:
-		helpText = "Tab: Switch focus | j/k: Scroll | Enter: Follow link | l: Links | b/f: History | q: Quit"
+		helpText = "Tab: Switch focus | j/k: Scroll | /: Search | t/w/[/]: Tabs | b/f: History | R: Reader | q: Quit"
 	}
 
 	// Focus indicator
@@ -995,12 +1344,20 @@ func (app *BrowserApp) buildFooter() tui.View {
 		focusIndicator = "URL"
 	case FocusLinks:
 		focusIndicator = "LINKS"
+		if app.showDownloads {
+			focusIndicator = "DOWNLOADS"
+		}
 	default:
 		focusIndicator = "CONTENT"
 	}
 
-	// Status message
+	// Status message (the search prompt/match counter takes precedence)
 	statusText := app.statusMsg
+	if app.search.Typing() {
+		statusText = "/" + app.search.Input() + "█"
+	} else if app.search.Query != "" {
+		statusText = fmt.Sprintf("/%s  %s", app.search.Query, app.search.Status())
+	}
 
 	return tui.Width(w, tui.Stack(
 		tui.Background(' ', tui.NewStyle().WithBgRGB(tui.NewRGB(25, 35, 45)),
@@ -3,13 +3,20 @@
 // Usage:
 //
 //	crawl [options] <urls...>      Crawl websites starting from seed URLs
+//	crawl --out <dir> <urls...>    Save each page as Markdown, resuming a crawl into dir
 //	crawl fetch <url>              Fetch and display a single URL
 //	crawl links <url>              Extract and display links from a URL
 //	crawl meta <url>               Extract and display metadata from a URL
+//
+// All commands accept --replay auto|record|replay to record responses to
+// cassette files (in --cassettes, default ./cassettes) and replay them, so
+// runs can be repeated offline.
 package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"sort"
@@ -19,6 +26,7 @@ import (
 
 	"github.com/deepnoodle-ai/wonton/cli"
 	"github.com/deepnoodle-ai/wonton/crawler"
+	"github.com/deepnoodle-ai/wonton/crawler/corpus"
 	"github.com/deepnoodle-ai/wonton/fetch"
 	"github.com/deepnoodle-ai/wonton/htmltomd"
 	"github.com/deepnoodle-ai/wonton/tui"
@@ -29,6 +37,15 @@ func main() {
 		Description("Web crawler CLI with rich terminal display").
 		Version("1.0.0")
 
+	app.GlobalFlags(
+		cli.String("replay", "").Default("off").
+			Enum("off", "auto", "record", "replay").
+			Env("CRAWL_REPLAY").
+			Help("Record responses to cassettes or replay them"),
+		cli.String("cassettes", "").Default("cassettes").
+			Help("Directory for recorded cassettes"),
+	)
+
 	// Default action - crawl a website
 	app.Main().
 		Args("urls...").
@@ -36,10 +53,12 @@ func main() {
 			cli.Int("workers", "w").Default(4).Help("Number of concurrent workers"),
 			cli.Int("max", "m").Default(100).Help("Maximum URLs to crawl"),
 			cli.String("delay", "d").Default("100ms").Help("Delay between requests"),
+			cli.Int("per-host", "p").Default(2).Help("Maximum concurrent requests per host (0 for no limit)"),
 			cli.String("follow", "f").Default("same-domain").
 				Enum("none", "same-domain", "subdomains", "any").
 				Help("Link following behavior"),
 			cli.Bool("interactive", "i").Help("Show interactive TUI display"),
+			cli.String("out", "o").Help("Save pages as a Markdown corpus in this directory, resuming if it has one"),
 		).
 		Run(runCrawl)
 
@@ -107,28 +126,73 @@ func runCrawl(ctx *cli.Context) error {
 		follow = crawler.FollowAny
 	}
 
-	fetcher := fetch.NewHTTPFetcher(fetch.HTTPFetcherOptions{})
+	fetcher, err := newFetcher(ctx, fetch.HTTPFetcherOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Save pages to a corpus, skipping those saved by an earlier crawl and
+	// picking up the links they lead to
+	var knownURLs []string
+	var out *corpus.Writer
+	if dir := ctx.String("out"); dir != "" {
+		out, err = corpus.Open(dir)
+		if err != nil {
+			return fmt.Errorf("failed to open corpus: %w", err)
+		}
+		defer out.Close()
+		knownURLs = out.KnownURLs()
+		if len(knownURLs) > 0 {
+			urls = append(urls, out.Frontier()...)
+			ctx.Info("Resuming: %d pages already saved in %s", len(knownURLs), dir)
+		}
+	}
 
 	c, err := crawler.New(crawler.Options{
-		Workers:        workers,
-		MaxURLs:        maxURLs,
-		RequestDelay:   delay,
-		DefaultFetcher: fetcher,
-		FollowBehavior: follow,
+		KnownURLs:            knownURLs,
+		Workers:              workers,
+		MaxURLs:              maxURLs,
+		RequestDelay:         delay,
+		DefaultFetcher:       fetcher,
+		FollowBehavior:       follow,
+		MaxConcurrentPerHost: ctx.Int("per-host"),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create crawler: %w", err)
 	}
 
 	if interactive && ctx.Interactive() {
-		return runCrawlTUI(ctx.Context(), c, urls)
+		return runCrawlTUI(ctx.Context(), c, urls, out)
 	}
 
-	return runCrawlSimple(ctx, c, urls)
+	return runCrawlSimple(ctx, c, urls, out)
+}
+
+// newFetcher creates the fetcher for a command, recording or replaying
+// cassettes as selected by the global flags
+func newFetcher(ctx *cli.Context, opts fetch.HTTPFetcherOptions) (*fetch.HTTPFetcher, error) {
+	replay, err := fetch.ParseReplayMode(ctx.String("replay"))
+	if err != nil {
+		return nil, err
+	}
+	opts.Replay = replay
+	opts.CassetteDir = ctx.String("cassettes")
+	return fetch.NewHTTPFetcher(opts), nil
+}
+
+// savePage saves a crawled page to the corpus, if there is one
+func savePage(out *corpus.Writer, result *crawler.Result) error {
+	if out == nil {
+		return nil
+	}
+	if _, err := out.Save(result); err != nil && !errors.Is(err, corpus.ErrSkipped) {
+		return err
+	}
+	return nil
 }
 
 // runCrawlSimple runs the crawler with simple text output
-func runCrawlSimple(ctx *cli.Context, c *crawler.Crawler, urls []string) error {
+func runCrawlSimple(ctx *cli.Context, c *crawler.Crawler, urls []string, out *corpus.Writer) error {
 	var mu sync.Mutex
 	var succeeded, failed int
 
@@ -150,6 +214,9 @@ func runCrawlSimple(ctx *cli.Context, c *crawler.Crawler, urls []string) error {
 			} else {
 				ctx.Success("  %s", result.URL)
 			}
+			if err := savePage(out, result); err != nil {
+				ctx.Fail("  %s: not saved: %v", result.URL, err)
+			}
 		}
 	})
 	if err != nil {
@@ -157,6 +224,9 @@ func runCrawlSimple(ctx *cli.Context, c *crawler.Crawler, urls []string) error {
 	}
 
 	ctx.Info("\nCrawl complete: %d succeeded, %d failed", succeeded, failed)
+	if out != nil {
+		ctx.Info("Corpus: %d pages in %s", len(out.Entries()), ctx.String("out"))
+	}
 	return nil
 }
 
@@ -165,10 +235,44 @@ type CrawlApp struct {
 	crawler    *crawler.Crawler
 	urls       []string
 	results    []crawlResult
+	selected   int // Selected row of the results
 	mu         sync.Mutex
 	done       bool
 	startTime  time.Time
 	cancelFunc context.CancelFunc
+
+	// The hosts panel, shown instead of the results with Tab
+	showHosts    bool
+	hostSort     int // Index into hostColumns
+	hostSortDesc bool
+	hostSelected int
+}
+
+// hostColumn is a sortable column of the hosts panel
+type hostColumn struct {
+	title string
+	value func(s crawler.HostStats) string
+	less  func(a, b crawler.HostStats) bool
+}
+
+// hostColumns are the columns of the hosts panel, sorted by pressing 1-8
+var hostColumns = []hostColumn{
+	{"Host", func(s crawler.HostStats) string { return s.Host },
+		func(a, b crawler.HostStats) bool { return a.Host < b.Host }},
+	{"In-flight", func(s crawler.HostStats) string { return fmt.Sprint(s.InFlight) },
+		func(a, b crawler.HostStats) bool { return a.InFlight < b.InFlight }},
+	{"Queued", func(s crawler.HostStats) string { return fmt.Sprint(s.Queued) },
+		func(a, b crawler.HostStats) bool { return a.Queued < b.Queued }},
+	{"Throttled", func(s crawler.HostStats) string { return fmt.Sprint(s.Throttled) },
+		func(a, b crawler.HostStats) bool { return a.Throttled < b.Throttled }},
+	{"Done", func(s crawler.HostStats) string { return fmt.Sprint(s.Done()) },
+		func(a, b crawler.HostStats) bool { return a.Done() < b.Done() }},
+	{"Errors", func(s crawler.HostStats) string { return fmt.Sprint(s.Errors) },
+		func(a, b crawler.HostStats) bool { return a.Errors < b.Errors }},
+	{"Error %", func(s crawler.HostStats) string { return fmt.Sprintf("%.0f%%", 100*s.ErrorRate()) },
+		func(a, b crawler.HostStats) bool { return a.ErrorRate() < b.ErrorRate() }},
+	{"Avg time", func(s crawler.HostStats) string { return s.AvgTime().Round(time.Millisecond).String() },
+		func(a, b crawler.HostStats) bool { return a.AvgTime() < b.AvgTime() }},
 }
 
 type crawlResult struct {
@@ -211,7 +315,16 @@ func (app *CrawlApp) View() tui.View {
 		rows[i] = []string{statusIcon, r.url, title, fmt.Sprintf("%d", r.links)}
 	}
 
-	var selected int
+	var table tui.View = tui.Table(
+		[]tui.TableColumn{{Title: ""}, {Title: "URL"}, {Title: "Title"}, {Title: "Links"}},
+		&app.selected,
+	).Rows(rows).Height(20).ShowHeader(true).Detail(app.resultDetail)
+	help := "Press Enter for details, 'q' to quit, 's' to stop crawling, Tab for hosts"
+	if app.showHosts {
+		table = app.hostsTable()
+		help = "Press 1-8 to sort by a column (again to reverse), Tab for pages, 'q' to quit"
+	}
+
 	return tui.Stack(
 		tui.Group(
 			tui.Text("Web Crawler").Bold().Fg(tui.ColorCyan),
@@ -229,29 +342,90 @@ func (app *CrawlApp) View() tui.View {
 			tui.Text("Elapsed: %s", elapsed).Fg(tui.ColorBlue),
 		),
 		tui.Text(""),
-		tui.Table(
-			[]tui.TableColumn{{Title: ""}, {Title: "URL"}, {Title: "Title"}, {Title: "Links"}},
-			&selected,
-		).Rows(rows).Height(20).ShowHeader(true),
+		table,
 		tui.Text(""),
-		tui.Text("Press 'q' to quit, 's' to stop crawling").Dim(),
+		tui.Text("%s", help).Dim(),
 	).Padding(1)
 }
 
+// resultDetail shows everything known about a crawled page, beneath its
+// row in the results table.
+func (app *CrawlApp) resultDetail(row int) tui.View {
+	r := app.results[row]
+	field := func(label, value string) tui.View {
+		return tui.Group(tui.Text("%-8s", label).Dim(), tui.Text("%s", value))
+	}
+	views := []tui.View{
+		field("URL", r.url),
+		field("Title", r.title),
+		field("Status", r.status),
+		field("Links", fmt.Sprint(r.links)),
+		field("Fetched", r.fetched.Format("15:04:05")),
+	}
+	if r.errMsg != "" {
+		views = append(views, tui.Group(tui.Text("%-8s", "Error").Dim(), tui.Text("%s", r.errMsg).Fg(tui.ColorRed)))
+	}
+	return tui.Stack(views...)
+}
+
+// hostsTable returns the hosts panel: the live stats of each host, sorted
+// by the chosen column, and the last error of the selected host.
+func (app *CrawlApp) hostsTable() tui.View {
+	hosts := app.crawler.GetHostStats()
+	col := hostColumns[app.hostSort]
+	sort.SliceStable(hosts, func(i, j int) bool {
+		if app.hostSortDesc {
+			return col.less(hosts[j], hosts[i])
+		}
+		return col.less(hosts[i], hosts[j])
+	})
+
+	columns := make([]tui.TableColumn, len(hostColumns))
+	for i, c := range hostColumns {
+		title := fmt.Sprintf("%d %s", i+1, c.title)
+		if i == app.hostSort {
+			title += map[bool]string{false: " ▲", true: " ▼"}[app.hostSortDesc]
+		}
+		columns[i] = tui.TableColumn{Title: title}
+	}
+	rows := make([][]string, len(hosts))
+	for i, h := range hosts {
+		rows[i] = make([]string, len(hostColumns))
+		for j, c := range hostColumns {
+			rows[i][j] = c.value(h)
+		}
+	}
+	table := tui.Table(columns, &app.hostSelected).Rows(rows).Height(20).ShowHeader(true)
+	if app.hostSelected >= 0 && app.hostSelected < len(hosts) && hosts[app.hostSelected].LastError != "" {
+		return tui.Stack(table, tui.Text("Last error: %s", hosts[app.hostSelected].LastError).Fg(tui.ColorRed))
+	}
+	return table
+}
+
 func (app *CrawlApp) HandleEvent(event tui.Event) []tui.Cmd {
 	if key, ok := event.(tui.KeyEvent); ok {
-		switch key.Rune {
-		case 'q':
+		switch {
+		case key.Rune == 'q':
 			app.cancelFunc()
 			return []tui.Cmd{tui.Quit()}
-		case 's':
+		case key.Rune == 's':
 			app.crawler.Stop()
+		case key.Key == tui.KeyTab:
+			app.showHosts = !app.showHosts
+		case app.showHosts && key.Rune >= '1' && int(key.Rune-'1') < len(hostColumns):
+			col := int(key.Rune - '1')
+			if col == app.hostSort {
+				app.hostSortDesc = !app.hostSortDesc
+			} else {
+				// Numbers are most useful largest first
+				app.hostSort, app.hostSortDesc = col, col > 0
+			}
 		}
 	}
 	return nil
 }
 
-func runCrawlTUI(ctx context.Context, c *crawler.Crawler, urls []string) error {
+func runCrawlTUI(ctx context.Context, c *crawler.Crawler, urls []string, out *corpus.Writer) error {
 	ctx, cancel := context.WithCancel(ctx)
 
 	app := &CrawlApp{
@@ -280,6 +454,9 @@ func runCrawlTUI(ctx context.Context, c *crawler.Crawler, urls []string) error {
 				if result.Response != nil {
 					r.title = result.Response.Metadata.Title
 				}
+				if err := savePage(out, result); err != nil {
+					r.errMsg = "not saved: " + err.Error()
+				}
 			}
 			app.results = append(app.results, r)
 		})
@@ -315,9 +492,12 @@ func runFetch(ctx *cli.Context) error {
 		return fmt.Errorf("invalid timeout: %w", err)
 	}
 
-	fetcher := fetch.NewHTTPFetcher(fetch.HTTPFetcherOptions{
+	fetcher, err := newFetcher(ctx, fetch.HTTPFetcherOptions{
 		Timeout: timeout,
 	})
+	if err != nil {
+		return err
+	}
 
 	formats := []string{"html", "links"}
 	if showMarkdown {
@@ -382,7 +562,10 @@ func runLinks(ctx *cli.Context) error {
 	externalOnly := ctx.Bool("external")
 	interactive := ctx.Bool("interactive")
 
-	fetcher := fetch.NewHTTPFetcher(fetch.HTTPFetcherOptions{})
+	fetcher, err := newFetcher(ctx, fetch.HTTPFetcherOptions{})
+	if err != nil {
+		return err
+	}
 
 	req := &fetch.Request{
 		URL:     rawURL,
@@ -441,19 +624,19 @@ func runLinks(ctx *cli.Context) error {
 		return runLinksTUI(links, baseURL)
 	}
 
-	// Simple output
+	// Simple output, paged when it runs past the screen
 	ctx.Success("Found %d links on %s", len(links), rawURL)
 	fmt.Println()
 
+	var out strings.Builder
 	for _, link := range links {
 		if link.Text != "" {
-			fmt.Printf("  %s\n    %s\n", link.Text, link.URL)
+			fmt.Fprintf(&out, "  %s\n    %s\n", link.Text, link.URL)
 		} else {
-			fmt.Printf("  %s\n", link.URL)
+			fmt.Fprintf(&out, "  %s\n", link.URL)
 		}
 	}
-
-	return nil
+	return ctx.Page(out.String())
 }
 
 // LinksApp is the TUI application for displaying links
@@ -533,7 +716,10 @@ func runMeta(ctx *cli.Context) error {
 
 	outputJSON := ctx.Bool("json")
 
-	fetcher := fetch.NewHTTPFetcher(fetch.HTTPFetcherOptions{})
+	fetcher, err := newFetcher(ctx, fetch.HTTPFetcherOptions{})
+	if err != nil {
+		return err
+	}
 
 	req := &fetch.Request{
 		URL:     rawURL,
@@ -573,6 +759,14 @@ func runMeta(ctx *cli.Context) error {
 			fmt.Printf("    \"image\": %q\n", meta.Twitter.Image)
 			fmt.Printf("  }")
 		}
+		if len(meta.Icons) > 0 {
+			data, _ := json.Marshal(meta.Icons)
+			fmt.Printf(",\n  \"icons\": %s", data)
+		}
+		if meta.StructuredData != nil {
+			data, _ := json.MarshalIndent(meta.StructuredData, "  ", "  ")
+			fmt.Printf(",\n  \"structuredData\": %s", data)
+		}
 		fmt.Printf("\n}\n")
 		return nil
 	}
@@ -594,6 +788,9 @@ func runMeta(ctx *cli.Context) error {
 	printMeta("Charset", meta.Charset)
 	printMeta("Viewport", meta.Viewport)
 	printMeta("Robots", meta.Robots)
+	if icon, ok := meta.Favicon(32); ok {
+		printMeta("Icon", icon.URL)
+	}
 
 	if len(meta.Keywords) > 0 {
 		printMeta("Keywords", strings.Join(meta.Keywords, ", "))
@@ -621,6 +818,39 @@ func runMeta(ctx *cli.Context) error {
 		printMeta("  Creator", meta.Twitter.Creator)
 	}
 
+	if sd := meta.StructuredData; sd != nil {
+		fmt.Println()
+		ctx.Info("Structured Data:")
+		var types []string
+		for _, item := range sd.Items {
+			types = append(types, item.Type+" ("+item.Source+")")
+		}
+		printMeta("  Items", strings.Join(types, ", "))
+		for _, a := range sd.Articles {
+			printMeta("  "+a.Type, a.Headline)
+			printMeta("    Authors", strings.Join(a.Authors, ", "))
+			printMeta("    Published", a.DatePublished)
+			printMeta("    Publisher", a.Publisher)
+		}
+		for _, p := range sd.Products {
+			printMeta("  Product", p.Name)
+			printMeta("    Brand", p.Brand)
+			for _, o := range p.Offers {
+				printMeta("    Offer", strings.TrimSpace(o.Price+" "+o.Currency+" "+o.Availability))
+			}
+			if p.Rating != nil {
+				printMeta("    Rating", fmt.Sprintf("%g (%d ratings)", p.Rating.Value, p.Rating.Count))
+			}
+		}
+		for _, crumbs := range sd.Breadcrumbs {
+			var names []string
+			for _, c := range crumbs {
+				names = append(names, c.Name)
+			}
+			printMeta("  Breadcrumbs", strings.Join(names, " › "))
+		}
+	}
+
 	if resp.Branding != nil {
 		fmt.Println()
 		ctx.Info("Branding:")
@@ -0,0 +1,170 @@
+// Example: downloader - Concurrent file downloader with live progress
+//
+// Downloads one or more URLs using the downloads package and shows progress
+// with tui.DownloadsView. Interrupted downloads keep a .part file and resume
+// where they left off on the next run.
+//
+// Keys:
+//   - j/k or Up/Down: Select a download
+//   - p: Pause, r: Resume/retry, x: Cancel
+//   - q: Quit (active downloads are paused and can be resumed later)
+//
+// Run with:
+//
+//	go run ./examples/downloader https://example.com/a.zip https://example.com/b.iso
+//	go run ./examples/downloader --dir /tmp --concurrency 2 https://example.com/a.zip
+//	go run ./examples/downloader --checksum sha256:abc123... https://example.com/a.zip
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/deepnoodle-ai/wonton/cli"
+	"github.com/deepnoodle-ai/wonton/downloads"
+	"github.com/deepnoodle-ai/wonton/humanize"
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+// DownloaderApp shows the state of a downloads.Manager
+type DownloaderApp struct {
+	manager  *downloads.Manager
+	selected int
+	scrollY  int
+	status   string
+}
+
+func main() {
+	app := cli.New("downloader").
+		Description("Download files concurrently with pause/resume").
+		Version("1.0.0")
+
+	app.Main().
+		ArgsRange(1, -1).
+		Flags(
+			cli.String("dir", "d").
+				Default(".").
+				Help("Directory to save files in"),
+			cli.Int("concurrency", "c").
+				Default(3).
+				Help("Maximum simultaneous downloads"),
+			cli.String("checksum", "").
+				Help("Expected checksum (algorithm:hex) when downloading a single file"),
+		).
+		Run(func(ctx *cli.Context) error {
+			manager := downloads.New(downloads.Options{
+				Dir:         ctx.String("dir"),
+				Concurrency: ctx.Int("concurrency"),
+			})
+
+			checksum := ctx.String("checksum")
+			if checksum != "" && ctx.NArg() > 1 {
+				return cli.Error("--checksum requires a single URL")
+			}
+			for _, u := range ctx.Args() {
+				if _, err := manager.Add(downloads.Request{URL: u, Checksum: checksum}); err != nil {
+					return cli.Errorf("%s: %v", u, err)
+				}
+			}
+
+			tuiApp := &DownloaderApp{manager: manager}
+			err := tui.Run(tuiApp)
+			manager.Close()
+			if err != nil {
+				return err
+			}
+
+			// Print a summary once the UI exits
+			failed := 0
+			for _, d := range manager.List() {
+				fmt.Printf("%-10s %s (%s)\n", d.State, d.Path, humanize.Bytes(d.Downloaded))
+				if d.State == downloads.Failed {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return cli.Errorf("%d download(s) failed", failed)
+			}
+			return nil
+		})
+
+	if err := app.Execute(); err != nil {
+		if cli.IsHelpRequested(err) {
+			os.Exit(0)
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(cli.GetExitCode(err))
+	}
+}
+
+// selectedID returns the ID of the selected download, or 0 if there is none
+func (app *DownloaderApp) selectedID() int {
+	list := app.manager.List()
+	if app.selected < 0 || app.selected >= len(list) {
+		return 0
+	}
+	return list[app.selected].ID
+}
+
+func (app *DownloaderApp) HandleEvent(event tui.Event) []tui.Cmd {
+	e, ok := event.(tui.KeyEvent)
+	if !ok {
+		return nil
+	}
+
+	if e.Key == tui.KeyCtrlC || e.Rune == 'q' {
+		return []tui.Cmd{tui.Quit()}
+	}
+
+	switch e.Key {
+	case tui.KeyArrowUp:
+		app.selected--
+	case tui.KeyArrowDown:
+		app.selected++
+	}
+
+	var err error
+	switch e.Rune {
+	case 'k':
+		app.selected--
+	case 'j':
+		app.selected++
+	case 'p':
+		err = app.manager.Pause(app.selectedID())
+	case 'r':
+		err = app.manager.Resume(app.selectedID())
+	case 'x':
+		err = app.manager.Cancel(app.selectedID())
+	}
+	if err != nil {
+		app.status = err.Error()
+	} else if e.Rune == 'p' || e.Rune == 'r' || e.Rune == 'x' {
+		app.status = ""
+	}
+	return nil
+}
+
+func (app *DownloaderApp) View() tui.View {
+	list := app.manager.List()
+
+	var active, done int
+	for _, d := range list {
+		if d.State == downloads.Active {
+			active++
+		}
+		if d.State.Finished() {
+			done++
+		}
+	}
+
+	return tui.Stack(
+		tui.Bordered(
+			tui.DownloadsView(list, &app.selected).ScrollY(&app.scrollY),
+		).Border(&tui.RoundedBorder).Title(fmt.Sprintf("Downloads (%d active, %d/%d done)", active, done, len(list))),
+		tui.Group(
+			tui.Text(" j/k: Select | p: Pause | r: Resume | x: Cancel | q: Quit").Fg(tui.ColorBrightBlack),
+			tui.Spacer(),
+			tui.Text("%s ", app.status).Fg(tui.ColorRed),
+		),
+	)
+}
@@ -0,0 +1,297 @@
+// Example: du - Disk usage browser in the style of ncdu
+//
+// Scans a directory tree with the du package, showing progress while it
+// runs, then lets you browse the tree: each directory's contents are listed
+// largest first, next to a treemap of the same sizes.
+//
+// Keys:
+//   - Up/Down: Select an entry
+//   - Enter or Right: Open the selected directory
+//   - Left or Backspace: Go to the parent directory
+//   - t: Show or hide the treemap
+//   - d: Delete the selected entry (asks for confirmation)
+//   - q: Quit
+//
+// Run with:
+//
+//	go run ./examples/du
+//	go run ./examples/du -x /
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/cli"
+	"github.com/deepnoodle-ai/wonton/du"
+	"github.com/deepnoodle-ai/wonton/humanize"
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+// DiskUsageApp scans a directory and browses the result
+type DiskUsageApp struct {
+	root    string
+	options du.Options
+	started bool
+
+	mu       sync.Mutex // guards progress, written by the scan
+	progress du.Progress
+
+	tree     *du.Entry // nil while scanning
+	dir      *du.Entry // directory being shown
+	err      error
+	selected int
+	split    tui.SplitState
+	treemap  bool
+	confirm  bool // waiting for y/n to delete the selected entry
+	status   string
+}
+
+// scanDoneEvent delivers the result of the scan
+type scanDoneEvent struct {
+	tree *du.Entry
+	err  error
+	time time.Time
+}
+
+func (e scanDoneEvent) Timestamp() time.Time { return e.time }
+
+func main() {
+	app := cli.New("du").
+		Description("Browse disk usage of a directory tree").
+		Version("1.0.0")
+
+	app.Main().
+		ArgsRange(0, 1).
+		Flags(
+			cli.Bool("one-file-system", "x").
+				Help("Skip directories on other file systems"),
+			cli.Int("concurrency", "c").
+				Default(8).
+				Help("Directories to read at once"),
+		).
+		Run(func(ctx *cli.Context) error {
+			root := "."
+			if ctx.NArg() > 0 {
+				root = ctx.Arg(0)
+			}
+			diskApp := &DiskUsageApp{
+				root:    root,
+				treemap: true,
+				split:   tui.SplitState{Ratio: 0.6},
+			}
+			diskApp.options = du.Options{
+				Concurrency:   ctx.Int("concurrency"),
+				OneFileSystem: ctx.Bool("one-file-system"),
+				OnProgress:    diskApp.setProgress,
+			}
+			return tui.Run(diskApp)
+		})
+
+	if err := app.Execute(); err != nil {
+		if cli.IsHelpRequested(err) {
+			os.Exit(0)
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(cli.GetExitCode(err))
+	}
+}
+
+func (app *DiskUsageApp) setProgress(p du.Progress) {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	app.progress = p
+}
+
+// scan runs the scan in the background and reports the result
+func (app *DiskUsageApp) scan() tui.Event {
+	tree, err := du.Scan(context.Background(), app.root, app.options)
+	return scanDoneEvent{tree: tree, err: err, time: time.Now()}
+}
+
+func (app *DiskUsageApp) HandleEvent(event tui.Event) []tui.Cmd {
+	if !app.started {
+		app.started = true
+		return []tui.Cmd{app.scan}
+	}
+
+	switch e := event.(type) {
+	case scanDoneEvent:
+		app.tree, app.dir, app.err = e.tree, e.tree, e.err
+	case tui.KeyEvent:
+		return app.handleKey(e)
+	}
+	return nil
+}
+
+func (app *DiskUsageApp) handleKey(e tui.KeyEvent) []tui.Cmd {
+	if e.Key == tui.KeyCtrlC || e.Rune == 'q' {
+		return []tui.Cmd{tui.Quit()}
+	}
+	if app.dir == nil {
+		return nil
+	}
+
+	if app.confirm {
+		app.confirm = false
+		app.status = ""
+		if e.Rune == 'y' {
+			app.deleteSelected()
+		}
+		return nil
+	}
+
+	switch {
+	case e.Key == tui.KeyArrowRight:
+		app.open(app.selected)
+	case e.Key == tui.KeyArrowLeft || e.Key == tui.KeyBackspace:
+		app.up()
+	case e.Rune == 't':
+		app.treemap = !app.treemap
+	case e.Rune == 'd':
+		if entry := app.selectedEntry(); entry != nil {
+			app.confirm = true
+			app.status = fmt.Sprintf("Delete %s? (y/n)", entry.Path())
+		}
+	}
+	return nil
+}
+
+func (app *DiskUsageApp) selectedEntry() *du.Entry {
+	if app.selected < 0 || app.selected >= len(app.dir.Children) {
+		return nil
+	}
+	return app.dir.Children[app.selected]
+}
+
+// open shows the contents of the child directory at index
+func (app *DiskUsageApp) open(index int) {
+	if index < 0 || index >= len(app.dir.Children) || !app.dir.Children[index].IsDir {
+		return
+	}
+	app.dir = app.dir.Children[index]
+	app.selected = 0
+}
+
+// up goes to the parent directory, selecting the one we came from
+func (app *DiskUsageApp) up() {
+	if app.dir.Parent == nil {
+		return
+	}
+	child := app.dir
+	app.dir = app.dir.Parent
+	for i, e := range app.dir.Children {
+		if e == child {
+			app.selected = i
+		}
+	}
+}
+
+func (app *DiskUsageApp) deleteSelected() {
+	entry := app.selectedEntry()
+	if entry == nil {
+		return
+	}
+	if err := entry.Remove(); err != nil {
+		app.status = err.Error()
+		return
+	}
+	app.selected = min(app.selected, len(app.dir.Children)-1)
+}
+
+func (app *DiskUsageApp) View() tui.View {
+	if app.err != nil {
+		return tui.Text("du: %v", app.err).Fg(tui.ColorRed)
+	}
+	if app.tree == nil {
+		return app.scanningView()
+	}
+
+	dir := app.dir
+	var body tui.View = app.table()
+	if app.treemap {
+		body = tui.SplitPane(&app.split, body, app.treeMap()).MinSize(20)
+	}
+
+	help := " ↑↓ select  → open  ← up  t treemap  d delete  q quit"
+	footer := tui.Text("%s", help).Fg(tui.ColorBrightBlack)
+	if app.status != "" {
+		footer = tui.Text(" %s", app.status).Fg(tui.ColorYellow)
+	}
+
+	return tui.Stack(
+		tui.Group(
+			tui.Text(" %s ", dir.Path()).Bold().Bg(tui.ColorBlue).Fg(tui.ColorWhite),
+			tui.Text(" %s in %d files", humanize.Bytes(dir.Usage), dir.Files).Dim(),
+		),
+		tui.Divider(),
+		body,
+		tui.Divider(),
+		footer,
+	)
+}
+
+func (app *DiskUsageApp) scanningView() tui.View {
+	app.mu.Lock()
+	p := app.progress
+	app.mu.Unlock()
+
+	return tui.Stack(
+		tui.Group(tui.Text("Scanning %s ", app.root).Bold(), tui.Spinner(tui.SpinnerDots)),
+		tui.Spacer().MinHeight(1),
+		tui.Text("%d files in %d directories, %s", p.Files, p.Dirs, humanize.Bytes(p.Bytes)),
+		tui.Text("%s", p.Path).Dim(),
+	).Padding(1)
+}
+
+var columns = []tui.TableColumn{
+	{Title: "Size", Width: 10},
+	{Title: "Share", Width: 18, Render: shareBar},
+	{Title: "Files", Width: 8},
+	{Title: "Name"},
+}
+
+// table lists the current directory, largest first
+func (app *DiskUsageApp) table() tui.View {
+	rows := make([][]string, len(app.dir.Children))
+	for i, e := range app.dir.Children {
+		name := e.Name
+		if e.IsDir {
+			name += "/"
+		}
+		if e.Err != nil {
+			name += " (unreadable)"
+		}
+		rows[i] = []string{
+			humanize.Bytes(e.Usage),
+			strconv.FormatFloat(e.Share()*100, 'f', 1, 64),
+			strconv.FormatInt(e.Files, 10),
+			name,
+		}
+	}
+	return tui.Table(columns, &app.selected).
+		Rows(rows).
+		HeaderBottomBorder(false).
+		OnSelect(app.open)
+}
+
+// shareBar draws an entry's share of its directory as a bar
+func shareBar(row int, value string) tui.View {
+	share, _ := strconv.ParseFloat(value, 64)
+	return tui.Progress(int(share+0.5), 100).Width(12)
+}
+
+// treeMap draws the current directory's entries by size
+func (app *DiskUsageApp) treeMap() tui.View {
+	items := make([]tui.TreeMapItem, len(app.dir.Children))
+	for i, e := range app.dir.Children {
+		items[i] = tui.TreeMapItem{Label: e.Name, Value: float64(e.Usage)}
+	}
+	return tui.TreeMap(items).
+		Highlight(app.selected).
+		OnSelect(func(index int) { app.selected = index })
+}
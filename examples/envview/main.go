@@ -463,15 +463,6 @@ func (app *EnvViewApp) View() tui.View {
 }
 
 func (app *EnvViewApp) formatVar(v EnvVar, selected bool) tui.View {
-	var bg, fg tui.Color
-	if selected {
-		bg = tui.ColorCyan
-		fg = tui.ColorBlack
-	} else {
-		bg = tui.ColorDefault
-		fg = tui.ColorWhite
-	}
-
 	// Truncate key if needed
 	key := v.Key
 	maxKeyLen := 30
@@ -498,11 +489,11 @@ func (app *EnvViewApp) formatVar(v EnvVar, selected bool) tui.View {
 		sourceIcon = "E"
 	}
 
-	return tui.Group(
-		tui.Text(" %s ", sourceIcon).Fg(tui.ColorYellow).Bg(bg),
-		tui.Text(" %-30s ", key).Fg(fg).Bg(bg).Bold(),
-		tui.Text(" %s", valueDisplay).Fg(tui.ColorBrightBlack).Bg(bg),
-	)
+	return tui.ListRow(tui.Group(
+		tui.Text(" %s ", sourceIcon).Fg(tui.ColorYellow),
+		tui.Text(" %-30s ", key).Fg(tui.ColorWhite).Bold(),
+		tui.Text(" %s", valueDisplay).Fg(tui.ColorBrightBlack),
+	)).Selected(selected)
 }
 
 func (app *EnvViewApp) formatDetail(v EnvVar) []tui.View {
@@ -62,6 +62,9 @@ type GitScanApp struct {
 	selectedFile int
 	fileScroll   int
 
+	// Divider between the commit list and details
+	split tui.SplitState
+
 	// View state
 	mode      ViewMode
 	width     int
@@ -108,7 +111,7 @@ func main() {
 			tuiApp := &GitScanApp{
 				repo:      repo,
 				repoPath:  repo.Path,
-				statusMsg: "↑↓/jk navigate | Space/b page | Enter diff | f files | c copy | q quit",
+				statusMsg: "↑↓/jk navigate | Space/b page | Enter diff | f files | c copy | </> resize | q quit",
 			}
 
 			// Load initial data
@@ -275,6 +278,10 @@ func (app *GitScanApp) handleCommitsKey(e tui.KeyEvent) []tui.Cmd {
 				app.statusMsg = fmt.Sprintf("✓ Copied %s", hash)
 			}
 		}
+	case '<':
+		app.split.Resize(-4)
+	case '>':
+		app.split.Resize(4)
 	}
 
 	return nil
@@ -285,7 +292,7 @@ func (app *GitScanApp) handleDiffKey(e tui.KeyEvent) []tui.Cmd {
 	if e.Rune == 'q' || e.Rune == 'Q' || e.Key == tui.KeyEscape {
 		app.mode = ViewCommits
 		app.statusMsg = "↑↓/jk navigate | Space/b page | f files | c copy hash | q quit"
-		return nil
+		return []tui.Cmd{tui.Unzoom()}
 	}
 
 	// Calculate page size
@@ -360,8 +367,12 @@ func (app *GitScanApp) handleDiffKey(e tui.KeyEvent) []tui.Cmd {
 	}
 
 	switch e.Rune {
+	case 'z', 'Z':
+		// Maximize the diff, hiding the commit header and status bar
+		return []tui.Cmd{tui.ToggleZoom("diff")}
 	case 'f', 'F':
 		app.mode = ViewFiles
+		return []tui.Cmd{tui.Unzoom()}
 	case 'c', 'C':
 		// Copy selected line
 		if app.selectedLine >= 0 && app.selectedLine < len(app.diffLines) {
@@ -575,7 +586,7 @@ func (app *GitScanApp) loadDiff() {
 		}
 	}
 
-	app.statusMsg = "↑↓ scroll | f files | c copy | q back"
+	app.statusMsg = "↑↓ scroll | z zoom | f files | c copy | q back"
 }
 
 func (app *GitScanApp) View() tui.View {
@@ -693,7 +704,7 @@ func (app *GitScanApp) viewCommits() tui.View {
 		}
 	}
 
-	return tui.Group(
+	return tui.SplitPane(&app.split,
 		// Commit list
 		tui.Stack(
 			tui.Bordered(
@@ -706,7 +717,7 @@ func (app *GitScanApp) viewCommits() tui.View {
 				tui.Stack(detailViews...).Padding(1),
 			).Title("Details").BorderFg(tui.ColorYellow),
 		),
-	)
+	).MinSize(20)
 }
 
 func (app *GitScanApp) formatCommit(commit git.Commit, selected bool) tui.View {
@@ -726,26 +737,12 @@ func (app *GitScanApp) formatCommit(commit git.Commit, selected bool) tui.View {
 		subject = subject[:maxLen-3] + "..."
 	}
 
-	var bg tui.Color
-	if selected {
-		bg = tui.ColorCyan
-	} else {
-		bg = tui.ColorDefault
-	}
-
-	hashFg := tui.ColorYellow
-	subjectFg := tui.ColorWhite
-	if selected {
-		hashFg = tui.ColorBlack
-		subjectFg = tui.ColorBlack
-	}
-
-	return tui.Group(
-		tui.Text(" %s ", commit.ShortHash).Fg(hashFg).Bg(bg).Bold(),
-		tui.Text("%s", subject).Fg(subjectFg).Bg(bg),
+	return tui.ListRow(tui.Group(
+		tui.Text(" %s ", commit.ShortHash).Fg(tui.ColorYellow).Bold(),
+		tui.Text("%s", subject).Fg(tui.ColorWhite),
 		tui.Spacer(),
-		tui.Text("%s ", timeAgo).Fg(tui.ColorBrightBlack).Bg(bg),
-	)
+		tui.Text("%s ", timeAgo).Fg(tui.ColorBrightBlack),
+	)).Selected(selected)
 }
 
 func (app *GitScanApp) viewDiff() tui.View {
@@ -795,7 +792,7 @@ func (app *GitScanApp) viewDiff() tui.View {
 		selected := i == app.selectedLine
 
 		var fg tui.Color
-		var bg tui.Color
+		bg := tui.ColorDefault
 		prefix := " "
 
 		switch line.Type {
@@ -839,9 +836,9 @@ func (app *GitScanApp) viewDiff() tui.View {
 		}
 
 		if line.Type == "header" || line.Type == "hunk" {
-			diffViews = append(diffViews, tui.Text(" %s", text).Fg(fg).Bg(bg))
+			diffViews = append(diffViews, tui.FillBg(bg, tui.Text(" %s", text).Fg(fg)))
 		} else {
-			diffViews = append(diffViews, tui.Text(" %s%s", prefix, text).Fg(fg).Bg(bg))
+			diffViews = append(diffViews, tui.FillBg(bg, tui.Text(" %s%s", prefix, text).Fg(fg)))
 		}
 	}
 
@@ -857,9 +854,9 @@ func (app *GitScanApp) viewDiff() tui.View {
 
 	return tui.Stack(
 		tui.Text(" %s", commitInfo).Fg(tui.ColorYellow).Bold(),
-		tui.Bordered(
+		tui.Zoomable("diff", tui.Bordered(
 			tui.Stack(diffViews...),
-		).Title(fmt.Sprintf("Diff (%d/%d)", app.selectedLine+1, len(app.diffLines))).BorderFg(tui.ColorGreen),
+		).Title(fmt.Sprintf("Diff (%d/%d)", app.selectedLine+1, len(app.diffLines))).BorderFg(tui.ColorGreen)),
 	)
 }
 
@@ -933,24 +930,18 @@ func (app *GitScanApp) viewFiles() tui.View {
 		// Stats
 		stats := fmt.Sprintf("+%d -%d", file.Additions, file.Deletions)
 
-		var bg tui.Color
-		if selected {
-			bg = tui.ColorCyan
-			iconColor = tui.ColorBlack
-		}
-
 		path := file.Path
 		maxLen := app.width - 20
 		if len(path) > maxLen {
 			path = "..." + path[len(path)-maxLen+3:]
 		}
 
-		fileViews = append(fileViews, tui.Group(
-			tui.Text(" %s ", icon).Fg(iconColor).Bg(bg).Bold(),
-			tui.Text("%s", path).Fg(tui.ColorWhite).Bg(bg),
+		fileViews = append(fileViews, tui.ListRow(tui.Group(
+			tui.Text(" %s ", icon).Fg(iconColor).Bold(),
+			tui.Text("%s", path).Fg(tui.ColorWhite),
 			tui.Spacer(),
-			tui.Text("%s ", stats).Fg(tui.ColorBrightBlack).Bg(bg),
-		))
+			tui.Text("%s ", stats).Fg(tui.ColorBrightBlack),
+		)).Selected(selected))
 	}
 
 	// Summary
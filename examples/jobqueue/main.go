@@ -0,0 +1,200 @@
+// Example: jobqueue - Persistent job queue with a live view
+//
+// Runs a simulated crawl-and-export pipeline on the jobs package and shows it
+// with tui.JobsView. Each "crawl" job takes a few seconds, sometimes fails
+// and is retried, and queues an "export" job when it succeeds. The queue is
+// saved to a state file, so quitting (or killing the process) and running
+// again resumes the unfinished jobs.
+//
+// Keys:
+//   - j/k or Up/Down: Select a job
+//   - a: Add a crawl job
+//   - x: Cancel, r: Retry, d: Remove
+//   - c: Clear completed jobs
+//   - q: Quit (running jobs resume on the next run)
+//
+// Run with:
+//
+//	go run ./examples/jobqueue
+//	go run ./examples/jobqueue --state /tmp/jobs.json --concurrency 3 https://example.com
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/cli"
+	"github.com/deepnoodle-ai/wonton/jobs"
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+// JobQueueApp shows the state of a jobs.Queue
+type JobQueueApp struct {
+	queue    *jobs.Queue
+	selected int
+	scrollY  int
+	added    int
+	status   string
+}
+
+func main() {
+	app := cli.New("jobqueue").
+		Description("Run a resumable job pipeline").
+		Version("1.0.0")
+
+	app.Main().
+		ArgsRange(0, -1).
+		Flags(
+			cli.String("state", "s").
+				Default("jobqueue.json").
+				Help("File the queue is saved to"),
+			cli.Int("concurrency", "c").
+				Default(2).
+				Help("Maximum jobs running at once"),
+		).
+		Run(func(ctx *cli.Context) error {
+			queue, err := jobs.New(jobs.Options{
+				Path:        ctx.String("state"),
+				Concurrency: ctx.Int("concurrency"),
+				MaxAttempts: 3,
+			})
+			if err != nil {
+				return err
+			}
+			queue.Register("crawl", func(ctx context.Context, job jobs.Job) error {
+				var url string
+				if err := job.Decode(&url); err != nil {
+					return err
+				}
+				if err := work(ctx, 2*time.Second); err != nil {
+					return err
+				}
+				if rand.IntN(4) == 0 {
+					return errors.New("connection reset")
+				}
+				_, err := queue.Enqueue("export", url)
+				return err
+			})
+			queue.Register("export", func(ctx context.Context, job jobs.Job) error {
+				return work(ctx, time.Second)
+			})
+
+			// Seed the queue on the first run
+			urls := ctx.Args()
+			if len(urls) == 0 && len(queue.List()) == 0 {
+				urls = []string{"https://example.com", "https://example.org", "https://example.net"}
+			}
+			for _, u := range urls {
+				if _, err := queue.Enqueue("crawl", u); err != nil {
+					return err
+				}
+			}
+
+			err = tui.Run(&JobQueueApp{queue: queue}, tui.WithFPS(4))
+			if closeErr := queue.Close(); err == nil {
+				err = closeErr
+			}
+			return err
+		})
+
+	if err := app.Execute(); err != nil {
+		if cli.IsHelpRequested(err) {
+			os.Exit(0)
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(cli.GetExitCode(err))
+	}
+}
+
+// work simulates a job that takes about d, stopping early if ctx is canceled
+func work(ctx context.Context, d time.Duration) error {
+	d += time.Duration(rand.Int64N(int64(d)))
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// selectedID returns the ID of the selected job, or 0 if there is none
+func (app *JobQueueApp) selectedID() int {
+	list := app.queue.List()
+	jobs.Sort(list)
+	if app.selected < 0 || app.selected >= len(list) {
+		return 0
+	}
+	return list[app.selected].ID
+}
+
+func (app *JobQueueApp) HandleEvent(event tui.Event) []tui.Cmd {
+	e, ok := event.(tui.KeyEvent)
+	if !ok {
+		return nil
+	}
+
+	if e.Key == tui.KeyCtrlC || e.Rune == 'q' {
+		return []tui.Cmd{tui.Quit()}
+	}
+
+	switch e.Key {
+	case tui.KeyArrowUp:
+		app.selected--
+	case tui.KeyArrowDown:
+		app.selected++
+	}
+
+	var err error
+	switch e.Rune {
+	case 'k':
+		app.selected--
+	case 'j':
+		app.selected++
+	case 'a':
+		app.added++
+		_, err = app.queue.Enqueue("crawl", fmt.Sprintf("https://example.com/page/%d", app.added))
+	case 'x':
+		err = app.queue.Cancel(app.selectedID())
+	case 'r':
+		err = app.queue.Retry(app.selectedID())
+	case 'd':
+		err = app.queue.Remove(app.selectedID())
+	case 'c':
+		app.queue.Prune()
+	}
+	if err != nil {
+		app.status = err.Error()
+	} else if e.Rune != 0 {
+		app.status = ""
+	}
+	return nil
+}
+
+func (app *JobQueueApp) View() tui.View {
+	list := app.queue.List()
+
+	var running, done int
+	for _, j := range list {
+		if j.State == jobs.Running {
+			running++
+		}
+		if j.State.Finished() {
+			done++
+		}
+	}
+
+	return tui.Stack(
+		tui.Bordered(
+			tui.JobsView(list, &app.selected).ScrollY(&app.scrollY),
+		).Border(&tui.RoundedBorder).Title(fmt.Sprintf("Jobs (%d running, %d/%d finished)", running, done, len(list))),
+		tui.Group(
+			tui.Text(" j/k: Select | a: Add | x: Cancel | r: Retry | d: Remove | c: Clear done | q: Quit").Fg(tui.ColorBrightBlack),
+			tui.Spacer(),
+			tui.Text("%s ", app.status).Fg(tui.ColorRed),
+		),
+	)
+}
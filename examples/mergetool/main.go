@@ -0,0 +1,176 @@
+// Example: mergetool - Resolve merge conflicts side by side
+//
+// Shows our version, the common ancestor, and their version of a file with a
+// merge conflict in three panes, built on the git package and tui.MergeView.
+// Changes made on one side are merged automatically; pick a side or edit the
+// text for each conflict, then write the result.
+//
+// Keys:
+//   - n/N: Next/previous conflict
+//   - o, t, b: Take ours, theirs, or both
+//   - x: Mark the conflict unresolved again
+//   - e: Edit the conflict (Enter saves, Shift+Enter adds a line, Esc cancels)
+//   - w: Write the merged file and quit
+//   - q: Quit without writing
+//
+// Run with:
+//
+//	go run ./examples/mergetool path/to/conflicted/file
+//	go run ./examples/mergetool BASE LOCAL REMOTE MERGED
+//
+// The second form matches git's mergetool convention:
+//
+//	git config mergetool.wonton.cmd 'go run ./examples/mergetool "$BASE" "$LOCAL" "$REMOTE" "$MERGED"'
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/deepnoodle-ai/wonton/cli"
+	"github.com/deepnoodle-ai/wonton/git"
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+// MergeApp resolves the conflicts in one file
+type MergeApp struct {
+	merge   *tui.MergeState
+	output  string
+	editing bool
+	draft   string
+	written bool
+	err     error
+}
+
+func main() {
+	app := cli.New("mergetool").
+		Description("Resolve merge conflicts in a three-pane view").
+		Version("1.0.0")
+
+	app.Main().
+		ArgsRange(1, 4).
+		Run(func(ctx *cli.Context) error {
+			var base, ours, theirs string
+			var labels [3]string
+			output := ctx.Arg(0)
+
+			switch ctx.NArg() {
+			case 1:
+				repo, err := git.Open(".")
+				if err != nil {
+					return err
+				}
+				c, err := repo.Conflict(context.Background(), output)
+				if err != nil {
+					return cli.Errorf("%s: %v", output, err)
+				}
+				base, ours, theirs = c.Base, c.Ours, c.Theirs
+				labels = [3]string{"ours", "base", "theirs"}
+			case 4:
+				var versions [3]string
+				for i := range versions {
+					data, err := os.ReadFile(ctx.Arg(i))
+					if err != nil {
+						return err
+					}
+					versions[i] = string(data)
+				}
+				base, ours, theirs = versions[0], versions[1], versions[2]
+				labels = [3]string{ctx.Arg(1), ctx.Arg(0), ctx.Arg(2)}
+				output = ctx.Arg(3)
+			default:
+				return cli.Error("expected a conflicted file, or BASE LOCAL REMOTE MERGED")
+			}
+
+			merge := tui.NewMergeState(base, ours, theirs)
+			merge.OursLabel, merge.BaseLabel, merge.TheirsLabel = labels[0], labels[1], labels[2]
+
+			tuiApp := &MergeApp{merge: merge, output: output}
+			if err := tui.Run(tuiApp); err != nil {
+				return err
+			}
+			if !tuiApp.written {
+				return cli.Errorf("%s: not written", output)
+			}
+			fmt.Printf("Wrote %s (%d unresolved)\n", output, merge.Unresolved())
+			return nil
+		})
+
+	if err := app.Execute(); err != nil {
+		if cli.IsHelpRequested(err) {
+			os.Exit(0)
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(cli.GetExitCode(err))
+	}
+}
+
+func (app *MergeApp) HandleEvent(event tui.Event) []tui.Cmd {
+	e, ok := event.(tui.KeyEvent)
+	if !ok {
+		return nil
+	}
+	if e.Key == tui.KeyCtrlC {
+		return []tui.Cmd{tui.Quit()}
+	}
+
+	// The input handles keys while editing; Escape abandons the edit
+	if app.editing {
+		if e.Key == tui.KeyEscape {
+			app.editing = false
+		}
+		return nil
+	}
+
+	if app.merge.HandleKey(e) {
+		return nil
+	}
+	switch e.Rune {
+	case 'e':
+		if app.merge.Conflicts() > 0 {
+			app.draft = app.merge.Draft()
+			app.editing = true
+			return []tui.Cmd{tui.Focus("draft")}
+		}
+	case 'w':
+		if err := os.WriteFile(app.output, []byte(app.merge.Output()), 0644); err != nil {
+			app.err = err
+			return nil
+		}
+		app.written = true
+		return []tui.Cmd{tui.Quit()}
+	case 'q':
+		return []tui.Cmd{tui.Quit()}
+	}
+	return nil
+}
+
+func (app *MergeApp) View() tui.View {
+	help := "n/N next/prev  o ours  t theirs  b both  x unresolve  e edit  w write  q quit"
+	var editor tui.View = tui.Empty()
+	if app.editing {
+		help = "Enter save  Shift+Enter new line  Esc cancel"
+		editor = tui.InputField(&app.draft).
+			ID("draft").
+			Multiline(true).
+			MaxHeight(10).
+			Bordered().
+			OnSubmit(func(text string) {
+				app.merge.Edit(text)
+				app.editing = false
+			})
+	}
+
+	status := tui.StatusBar(fmt.Sprintf("%s | %s", app.merge.Status(), help))
+	if app.err != nil {
+		status = tui.StatusBar(app.err.Error()).Fg(tui.ColorRed)
+	}
+
+	return tui.Stack(
+		tui.HeaderBar(fmt.Sprintf("Merging %s", app.output)).Bold(),
+		tui.MergeView(app.merge),
+		editor,
+		status,
+	)
+}
@@ -0,0 +1,166 @@
+// Example: regex - Regular expression playground
+//
+// Type a pattern to highlight its matches in a file, with each capture group
+// in its own color. Mistakes in the pattern are reported as you type.
+//
+// Keys:
+//   - Type to edit the pattern
+//   - Ctrl+F: Show only matching lines
+//   - Up/Down, PageUp/PageDown: Scroll
+//   - Esc or Ctrl+C: Quit
+//
+// Run with:
+//
+//	go run ./examples/regex
+//	go run ./examples/regex /var/log/syslog
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/deepnoodle-ai/wonton/cli"
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+const sample = `2024-05-01T12:00:01Z INFO  server started addr=:8080 pid=4121
+2024-05-01T12:00:04Z DEBUG cache warmed entries=1532 took=212ms
+2024-05-01T12:01:17Z WARN  slow request path=/api/users took=1.8s
+2024-05-01T12:02:45Z ERROR upstream failed host=db-2 err="connection refused"
+2024-05-01T12:02:46Z INFO  retrying host=db-2 attempt=2
+2024-05-01T12:02:48Z INFO  upstream recovered host=db-2 took=3.1s
+2024-05-01T12:05:00Z INFO  stats requests=10442 errors=3 p99=245ms`
+
+// RegexApp highlights the matches of a pattern in some text
+type RegexApp struct {
+	lines   []string
+	pattern string
+	re      *tui.RegexHighlighter
+	filter  bool
+	scrollY int
+}
+
+func main() {
+	app := cli.New("regex").
+		Description("Try out regular expressions against a file").
+		Version("1.0.0")
+
+	app.Main().
+		ArgsRange(0, 1).
+		Run(func(ctx *cli.Context) error {
+			text := sample
+			if ctx.NArg() == 1 {
+				data, err := os.ReadFile(ctx.Arg(0))
+				if err != nil {
+					return err
+				}
+				text = string(data)
+			}
+
+			pattern := `(?P<level>WARN|ERROR)\s+(.*?)\s+host=(\S+)`
+			return tui.Run(&RegexApp{
+				lines:   strings.Split(strings.TrimRight(text, "\n"), "\n"),
+				pattern: pattern,
+				re:      tui.NewRegexHighlighter(pattern),
+			})
+		})
+
+	if err := app.Execute(); err != nil {
+		if cli.IsHelpRequested(err) {
+			os.Exit(0)
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(cli.GetExitCode(err))
+	}
+}
+
+func (app *RegexApp) HandleEvent(event tui.Event) []tui.Cmd {
+	e, ok := event.(tui.KeyEvent)
+	if !ok {
+		return nil
+	}
+	switch e.Key {
+	case tui.KeyEscape, tui.KeyCtrlC:
+		return []tui.Cmd{tui.Quit()}
+	case tui.KeyCtrlF:
+		app.filter = !app.filter
+		app.scrollY = 0
+	case tui.KeyArrowUp:
+		app.scrollY = max(0, app.scrollY-1)
+	case tui.KeyArrowDown:
+		app.scrollY++
+	case tui.KeyPageUp:
+		app.scrollY = max(0, app.scrollY-10)
+	case tui.KeyPageDown:
+		app.scrollY += 10
+	}
+	return nil
+}
+
+// status describes the pattern's error or how much it matched
+func (app *RegexApp) status(matched, matches int) tui.View {
+	if err := app.re.Err(); err != nil {
+		return tui.Text("%v", err).Fg(tui.ColorRed)
+	}
+	if !app.re.Active() {
+		return tui.Text("Type a pattern").Dim()
+	}
+	return tui.Text("%d matches on %d of %d lines", matches, matched, len(app.lines)).Dim()
+}
+
+// legend shows the color of each capture group
+func (app *RegexApp) legend() tui.View {
+	re := app.re.Regexp()
+	if re == nil || re.NumSubexp() == 0 {
+		return tui.Empty()
+	}
+	spans := []tui.StyledSegment{tui.Span("Groups: ", tui.NewStyle().WithDim())}
+	for i, name := range re.SubexpNames()[1:] {
+		label := fmt.Sprintf(" %d ", i+1)
+		if name != "" {
+			label = fmt.Sprintf(" %s ", name)
+		}
+		spans = append(spans, tui.Span(label, app.re.GroupStyle(i+1)), tui.Span(" ", tui.NewStyle()))
+	}
+	return tui.Paragraph(spans...)
+}
+
+func (app *RegexApp) View() tui.View {
+	app.re.SetPattern(app.pattern)
+
+	var rows []tui.View
+	matched, matches := 0, 0
+	for _, line := range app.lines {
+		found := 0
+		for _, r := range app.re.Find(line) {
+			if r.Group == 0 {
+				found++
+			}
+		}
+		if found > 0 {
+			matched++
+			matches += found
+		}
+		if app.filter && !app.re.MatchString(line) {
+			continue
+		}
+		rows = append(rows, tui.Paragraph(app.re.HighlightText(line, tui.NewStyle())...))
+	}
+
+	mode := "all lines"
+	if app.filter {
+		mode = "matching lines"
+	}
+	return tui.Stack(
+		tui.InputField(&app.pattern).
+			ID("pattern").
+			Prompt("/").
+			Placeholder("pattern").
+			Bordered(),
+		app.status(matched, matches),
+		app.legend(),
+		tui.Bordered(tui.Scroll(tui.Stack(rows...), &app.scrollY)).
+			Title(fmt.Sprintf("%s (Ctrl+F to toggle)", mode)),
+	)
+}
@@ -410,7 +410,7 @@ func (app *SSEViewApp) View() tui.View {
 
 	if len(app.events) == 0 {
 		eventViews = append(eventViews,
-			tui.Text("Waiting for events...").Fg(tui.ColorBrightBlack))
+			tui.LoadingText("Waiting for events..."))
 	} else {
 		// Calculate visible range
 		listHeight := app.height - 10
@@ -582,15 +582,9 @@ func (app *SSEViewApp) formatEventDetail(evt SSEEvent) []tui.View {
 	views = append(views, tui.Text("Data:").Bold())
 
 	data := evt.Event.Data
-	if app.prettyJSON && !app.showRaw {
-		// Try to pretty-print JSON
-		var jsonData any
-		if err := json.Unmarshal([]byte(data), &jsonData); err == nil {
-			pretty, err := json.MarshalIndent(jsonData, "", "  ")
-			if err == nil {
-				data = string(pretty)
-			}
-		}
+	if app.prettyJSON && !app.showRaw && json.Valid([]byte(data)) {
+		// Pretty-print JSON with syntax coloring
+		return append(views, tui.JSONView([]byte(data)))
 	}
 
 	// Split data into lines
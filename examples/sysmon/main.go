@@ -0,0 +1,171 @@
+// Command sysmon is a small system monitor: CPU, memory, load, network
+// traffic, and the busiest processes, read with the sysinfo package.
+//
+// Run it with:
+//
+//	go run ./examples/sysmon
+//
+// Use the arrow keys to move through the process list and q to quit.
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/humanize"
+	"github.com/deepnoodle-ai/wonton/sysinfo"
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+// maxProcesses is the number of processes listed.
+const maxProcesses = 50
+
+// historySize is the number of CPU samples kept for the history chart.
+const historySize = 120
+
+type SysmonApp struct {
+	monitor  *sysinfo.Monitor
+	sample   *sysinfo.Sample
+	history  []float64 // overall CPU usage, oldest first
+	err      error
+	last     time.Time
+	selected int
+}
+
+func (app *SysmonApp) refresh() {
+	app.sample, app.err = app.monitor.Sample()
+	app.last = time.Now()
+	if app.err == nil {
+		app.history = append(app.history, app.sample.CPU)
+		if len(app.history) > historySize {
+			app.history = app.history[len(app.history)-historySize:]
+		}
+	}
+}
+
+func (app *SysmonApp) HandleEvent(event tui.Event) []tui.Cmd {
+	switch e := event.(type) {
+	case tui.TickEvent:
+		if time.Since(app.last) >= time.Second {
+			app.refresh()
+		}
+	case tui.KeyEvent:
+		if e.Rune == 'q' || e.Key == tui.KeyCtrlC {
+			return []tui.Cmd{tui.Quit()}
+		}
+	}
+	return nil
+}
+
+func (app *SysmonApp) View() tui.View {
+	if app.err != nil {
+		return tui.Text("sysmon: %v", app.err).Fg(tui.ColorRed)
+	}
+	s := app.sample
+	if s == nil {
+		return tui.Text("Reading system metrics...").Dim()
+	}
+
+	return tui.Stack(
+		tui.Group(
+			tui.Text(" sysmon ").Bold().Bg(tui.ColorBlue).Fg(tui.ColorWhite),
+			tui.Text(" up %s  load %.2f %.2f %.2f", humanize.Duration(s.Uptime),
+				s.Load.Load1, s.Load.Load5, s.Load.Load15).Dim(),
+		),
+		tui.Spacer().MinHeight(1),
+		app.meters(s),
+		tui.Spacer().MinHeight(1),
+		tui.Text("CPU history").Dim(),
+		tui.Sparkline(app.history).Range(0, 100).Height(3).Axis().Thresholds(cpuThresholds...),
+		tui.Spacer().MinHeight(1),
+		tui.Text("%s", app.network(s)).Dim(),
+		tui.Spacer().MinHeight(1),
+		tui.Table(processColumns, &app.selected).Rows(app.processRows(s)),
+	)
+}
+
+// meters shows overall CPU and memory usage and a bar per core.
+func (app *SysmonApp) meters(s *sysinfo.Sample) tui.View {
+	views := []tui.View{
+		meter("CPU ", s.CPU, tui.ColorGreen),
+		tui.Group(
+			meter("Mem ", s.Memory.UsedPercent(), tui.ColorYellow),
+			tui.Text(" %s / %s", humanize.Bytes(int64(s.Memory.Used())),
+				humanize.Bytes(int64(s.Memory.Total))).Dim(),
+		),
+	}
+	for i, usage := range s.Cores {
+		views = append(views, meter(fmt.Sprintf("%-4d", i), usage, tui.ColorCyan))
+	}
+	return tui.Stack(views...)
+}
+
+// cpuThresholds turn CPU charts yellow and then red as usage rises.
+var cpuThresholds = []tui.Threshold{
+	{At: 70, Color: tui.ColorYellow},
+	{At: 90, Color: tui.ColorRed},
+}
+
+func meter(label string, percent float64, color tui.Color) tui.View {
+	return tui.Gauge(percent, 100).Label(label).Width(30).Fg(color).Thresholds(cpuThresholds...)
+}
+
+// network summarizes traffic on all interfaces except loopback.
+func (app *SysmonApp) network(s *sysinfo.Sample) string {
+	var parts []string
+	for _, iface := range s.Network {
+		if iface.Name == "lo" || iface.RxBytes+iface.TxBytes == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s ↓%s/s ↑%s/s", iface.Name,
+			humanize.Bytes(int64(iface.RxRate)), humanize.Bytes(int64(iface.TxRate))))
+	}
+	if len(parts) == 0 {
+		return "no network traffic"
+	}
+	return strings.Join(parts, "   ")
+}
+
+var processColumns = []tui.TableColumn{
+	{Title: "PID", Width: 8},
+	{Title: "User", Width: 10},
+	{Title: "CPU", Width: 18, Render: cpuBar},
+	{Title: "Mem", Width: 10},
+	{Title: "Command"},
+}
+
+func (app *SysmonApp) processRows(s *sysinfo.Sample) [][]string {
+	procs := s.Processes[:min(len(s.Processes), maxProcesses)]
+	rows := make([][]string, len(procs))
+	for i, p := range procs {
+		command := p.Name
+		if len(p.Command) > 0 {
+			command = strings.Join(p.Command, " ")
+		}
+		rows[i] = []string{
+			strconv.Itoa(p.PID),
+			p.User,
+			strconv.FormatFloat(p.CPU, 'f', 1, 64),
+			humanize.Bytes(int64(p.RSS)),
+			command,
+		}
+	}
+	return rows
+}
+
+// cpuBar draws a process's CPU usage as a bar.
+func cpuBar(row int, value string) tui.View {
+	cpu, _ := strconv.ParseFloat(value, 64)
+	return tui.Progress(int(cpu+0.5), 100).Width(12)
+}
+
+func main() {
+	app := &SysmonApp{monitor: sysinfo.NewMonitor()}
+	app.refresh()
+	if err := tui.Run(app); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,78 @@
+// Example: banner - Large-letter text for splash screens
+//
+// Draws the text you type with Banner in each built-in font. Make the
+// window narrower to see a banner shrink to a smaller font, wrap, and
+// finally fall back to plain text.
+//
+// Keys:
+//   - Type to change the text
+//   - Tab: Next font
+//   - Ctrl+G: Next gradient
+//   - Esc or Ctrl+C: Quit
+//
+// Run with:
+//
+//	go run ./examples/tui/banner
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+var fonts = []*tui.BannerFont{tui.BannerBlock, tui.BannerSlim, tui.BannerSmall, tui.BannerASCII}
+
+var gradients = [][]tui.RGB{
+	{tui.NewRGB(255, 0, 128), tui.NewRGB(0, 128, 255)},
+	{tui.NewRGB(255, 200, 0), tui.NewRGB(255, 60, 0)},
+	{tui.NewRGB(0, 255, 160), tui.NewRGB(0, 120, 255), tui.NewRGB(180, 0, 255)},
+	nil,
+}
+
+// BannerApp shows one banner
+type BannerApp struct {
+	text     string
+	font     int
+	gradient int
+}
+
+func (app *BannerApp) HandleEvent(event tui.Event) []tui.Cmd {
+	e, ok := event.(tui.KeyEvent)
+	if !ok {
+		return nil
+	}
+	switch e.Key {
+	case tui.KeyCtrlC, tui.KeyEscape:
+		return []tui.Cmd{tui.Quit()}
+	case tui.KeyTab:
+		app.font = (app.font + 1) % len(fonts)
+	case tui.KeyCtrlG:
+		app.gradient = (app.gradient + 1) % len(gradients)
+	}
+	return nil
+}
+
+func (app *BannerApp) View() tui.View {
+	banner := tui.Banner(app.text, fonts[app.font]).Center()
+	if stops := gradients[app.gradient]; stops != nil {
+		banner.Gradient(stops...)
+	} else {
+		banner.Fg(tui.ColorCyan)
+	}
+	return tui.Stack(
+		tui.Spacer(),
+		tui.Stack(banner).Align(tui.AlignCenter),
+		tui.Spacer(),
+		tui.InputField(&app.text).ID("text").Label("Text:"),
+		tui.StatusBar(fmt.Sprintf("font %s | Tab font  Ctrl+G gradient  Esc quit", fonts[app.font].Name())),
+	)
+}
+
+func main() {
+	app := &BannerApp{text: "DEPLOY"}
+	if err := tui.Run(app); err != nil {
+		log.Fatal(err)
+	}
+}
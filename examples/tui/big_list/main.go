@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+// BigListApp filters 200,000 generated file paths with an AsyncFilter, so
+// the list stays responsive while matching runs in the background.
+type BigListApp struct {
+	items    []tui.ListItem
+	filter   *tui.AsyncFilter
+	query    string
+	selected int
+	chosen   string
+	width    int
+	height   int
+}
+
+// Init generates the paths and sets up the filter.
+func (app *BigListApp) Init() error {
+	dirs := []string{"cmd", "internal/api", "internal/store", "pkg/render", "pkg/fuzzy", "web/static", "docs"}
+	kinds := []string{"handler", "service", "model", "view", "util", "config", "test"}
+	exts := []string{".go", ".ts", ".md", ".json"}
+	app.items = make([]tui.ListItem, 200_000)
+	for i := range app.items {
+		path := fmt.Sprintf("%s/%s_%d%s", dirs[i%len(dirs)], kinds[(i/7)%len(kinds)], i, exts[(i/49)%len(exts)])
+		app.items[i] = tui.ListItem{Label: path, Value: path}
+	}
+	app.filter = tui.NewAsyncFilter(app.items)
+	return nil
+}
+
+// HandleEvent quits on Escape, tracks the window size, and passes every
+// event to the filter.
+func (app *BigListApp) HandleEvent(event tui.Event) []tui.Cmd {
+	switch e := event.(type) {
+	case tui.KeyEvent:
+		if e.Key == tui.KeyEscape || e.Key == tui.KeyCtrlC {
+			return []tui.Cmd{tui.Quit()}
+		}
+	case tui.ResizeEvent:
+		app.width, app.height = e.Width, e.Height
+	}
+	return app.filter.Update(app.query, event)
+}
+
+// View shows the list and how many items match.
+func (app *BigListApp) View() tui.View {
+	status := fmt.Sprintf("%d of %d items", len(app.filter.Matches()), len(app.items))
+	if app.chosen != "" {
+		status += " • chose " + app.chosen
+	}
+	return tui.Stack(
+		tui.HeaderBar("Async filtering").Bg(tui.ColorBlue).Fg(tui.ColorWhite),
+		tui.FilterableList(app.items, &app.selected).
+			ID("files").
+			Filter(&app.query).
+			AsyncFilter(app.filter).
+			OnSelect(func(item tui.ListItem, _ int) { app.chosen = item.Label }).
+			Width(max(20, app.width)).
+			Height(max(5, app.height-3)),
+		tui.Text("%s", status).Dim(),
+		tui.Text("Type to filter • Arrows to navigate • Enter to choose • Esc to quit").Dim(),
+	)
+}
+
+func main() {
+	if err := tui.Run(&BigListApp{}); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -10,11 +10,10 @@ import (
 
 // BracketedPasteDemoApp demonstrates bracketed paste mode using Runtime.
 type BracketedPasteDemoApp struct {
-	terminal *tui.Terminal
-	buffer   []rune
-	cursor   int
-	pastes   []PasteRecord // History of pastes
-	message  string        // Status message
+	buffer  []rune
+	cursor  int
+	pastes  []PasteRecord // History of pastes
+	message string        // Status message
 }
 
 // PasteRecord stores information about a paste event
@@ -24,25 +23,16 @@ type PasteRecord struct {
 	CharCount int
 }
 
-func (app *BracketedPasteDemoApp) Init() error {
-	// Enable bracketed paste mode
-	app.terminal.EnableBracketedPaste()
-	return nil
-}
-
-func (app *BracketedPasteDemoApp) Destroy() {
-	app.terminal.DisableBracketedPaste()
-}
-
 func (app *BracketedPasteDemoApp) HandleEvent(event tui.Event) []tui.Cmd {
 	switch e := event.(type) {
+	case tui.PasteEvent:
+		// The whole paste arrives as one event
+		return app.handlePaste(e.Text)
 	case tui.KeyEvent:
-		// Check if this is a paste event
+		// Pastes are also delivered as a KeyEvent before their PasteEvent
 		if e.Paste != "" {
-			return app.handlePaste(e.Paste)
+			return nil
 		}
-
-		// Handle regular key events
 		return app.handleKey(e)
 	}
 
@@ -354,31 +344,12 @@ func splitLines(text string) []string {
 }
 
 func main() {
-	// Note: This example uses bracketed paste mode which requires direct terminal access.
-	// It cannot use the simplified tui.Run() API until WithBracketedPaste option is added.
-	terminal, err := tui.NewTerminal()
-	if err != nil {
-		log.Fatalf("Failed to create terminal: %v\n", err)
-	}
-	defer terminal.Close()
-
-	// Create the application
 	app := &BracketedPasteDemoApp{
-		terminal: terminal,
-		buffer:   []rune{},
-		cursor:   0,
-		pastes:   nil,
-		message:  "Ready! Paste something to see bracketed paste in action.",
+		message: "Ready! Paste something to see bracketed paste in action.",
 	}
 
-	// Create and run the runtime
-	runtime := tui.NewRuntime(terminal, app, 30)
-
-	// Convert tabs to 2 spaces in pasted content
-	runtime.SetPasteTabWidth(2)
-
-	// Run blocks until the application quits
-	if err := runtime.Run(); err != nil {
+	// Bracketed paste is on by default; convert tabs to 2 spaces in pastes
+	if err := tui.Run(app, tui.WithPasteTabWidth(2)); err != nil {
 		log.Fatalf("Runtime error: %v\n", err)
 	}
 }
@@ -9,12 +9,8 @@ import (
 	"github.com/deepnoodle-ai/wonton/tui"
 )
 
-// Message represents a chat message
-type Message struct {
-	Role    string // "user" or "assistant"
-	Content string
-	Time    time.Time
-}
+// Message represents a chat message: Role is "user" or "assistant"
+type Message = tui.ChatMessage
 
 // ClaudeStyleDemo implements a Claude Code-style interface with fixed input at the bottom
 // using the Runtime message-driven architecture.
@@ -24,9 +20,11 @@ type Message struct {
 // - Message history above
 // - Clean, modern design similar to Claude Code
 // - Proper keyboard input handling (Shift+Enter for newlines)
+// - Saving the transcript as Markdown with Ctrl+S, with a toast for the path
 type ClaudeStyleDemo struct {
 	messages []Message
 	input    string
+	notes    *tui.NotificationStore
 
 	// Command history
 	history      []string // Past commands
@@ -41,7 +39,8 @@ type ClaudeStyleDemo struct {
 // Init implements the Initializable interface
 func (d *ClaudeStyleDemo) Init() error {
 	d.historyIndex = -1 // Not browsing history
-	d.scrollY = 999999  // Start at bottom (gets clamped to maxScroll)
+	d.notes = tui.NewNotificationStore()
+	d.scrollY = 999999 // Start at bottom (gets clamped to maxScroll)
 	d.messages = []Message{
 		{
 			Role:    "assistant",
@@ -58,15 +57,18 @@ func (d *ClaudeStyleDemo) View() tui.View {
 	inputLines := strings.Count(d.input, "\n") + 1
 	footerHeight := 1 + inputLines + 1
 
-	return tui.Stack(
-		// Scrollable message area, anchored to bottom
-		tui.Scroll(d.renderMessages(), &d.scrollY).Bottom(),
-
-		// Fixed footer: separator + input area
-		tui.Height(footerHeight, tui.Stack(
-			tui.Divider().Fg(tui.ColorCyan),
-			d.renderInputArea(),
-		)),
+	return tui.ZStack(
+		tui.Stack(
+			// Scrollable message area, anchored to bottom
+			tui.Scroll(d.renderMessages(), &d.scrollY).Bottom(),
+
+			// Fixed footer: separator + input area
+			tui.Height(footerHeight, tui.Stack(
+				tui.Divider().Fg(tui.ColorCyan),
+				d.renderInputArea(),
+			)),
+		),
+		tui.Toasts(d.notes),
 	)
 }
 
@@ -77,6 +79,12 @@ func (d *ClaudeStyleDemo) HandleEvent(event tui.Event) []tui.Cmd {
 		return d.handleKeyEvent(e)
 	case tui.MouseEvent:
 		return d.handleMouseEvent(e)
+	case tui.TranscriptSavedEvent:
+		if e.Err != nil {
+			d.notes.Error("Export failed", e.Err.Error())
+		} else {
+			d.notes.Info("Transcript saved", e.Path)
+		}
 	}
 
 	return nil
@@ -104,6 +112,10 @@ func (d *ClaudeStyleDemo) handleKeyEvent(event tui.KeyEvent) []tui.Cmd {
 	case event.Key == tui.KeyCtrlC:
 		return []tui.Cmd{tui.Quit()}
 
+	case event.Key == tui.KeyCtrlS:
+		// Save the conversation as Markdown in the current directory
+		return []tui.Cmd{tui.SaveTranscript("", d.messages, tui.TranscriptMarkdown)}
+
 	case event.Key == tui.KeyEnter:
 		if event.Shift {
 			// Shift+Enter adds a new line
@@ -210,21 +222,9 @@ func (d *ClaudeStyleDemo) renderMessage(msg Message) tui.View {
 		headerColor = tui.ColorWhite
 	}
 
-	// Split content into lines for wrapped text
-	contentLines := strings.Split(msg.Content, "\n")
-	var contentViews []tui.View
-
-	for _, line := range contentLines {
-		if line == "" {
-			contentViews = append(contentViews, tui.Text(""))
-		} else {
-			contentViews = append(contentViews, tui.Text("%s", line).Wrap().Flex(1).Fg(headerColor))
-		}
-	}
-
 	return tui.Stack(
 		tui.Text("%s", header).Bold().Fg(headerColor),
-		tui.PaddingHV(2, 0, tui.Stack(contentViews...)),
+		tui.PaddingHV(2, 0, tui.WrappedText("%s", msg.Content).Flex(1).Fg(headerColor)),
 	)
 }
 
@@ -259,7 +259,7 @@ func (d *ClaudeStyleDemo) renderInputArea() tui.View {
 	}
 
 	// Help text at the bottom, right-aligned
-	helpText := "Ctrl+C: exit | Enter: send | \\+Enter: newline | ↑↓: history | PgUp/PgDn: scroll"
+	helpText := "Ctrl+C: exit | Enter: send | \\+Enter: newline | ↑↓: history | PgUp/PgDn: scroll | Ctrl+S: save"
 
 	return tui.Stack(
 		tui.Stack(inputViews...),
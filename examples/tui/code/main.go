@@ -2,6 +2,12 @@
 //
 // Displays a Go source file with syntax highlighting.
 //
+// Keys:
+//   - Up/Down or j/k, PgUp/PgDn: Scroll
+//   - /: Search; n/N next/previous match
+//   - Mouse drag: Select and copy text
+//   - q: Quit (Esc clears the search first)
+//
 // Run with: go run ./examples/tui/code main.go
 package main
 
@@ -9,6 +15,7 @@ import (
 	"os"
 
 	"github.com/deepnoodle-ai/wonton/cli"
+	"github.com/deepnoodle-ai/wonton/clipboard"
 	"github.com/deepnoodle-ai/wonton/tui"
 )
 
@@ -21,7 +28,7 @@ func main() {
 			if err != nil {
 				return err
 			}
-			return tui.Run(&codeApp{code: string(data)})
+			return tui.Run(&codeApp{code: string(data)}, tui.WithMouseTracking(true))
 		}).
 		Execute()
 }
@@ -29,21 +36,36 @@ func main() {
 type codeApp struct {
 	code    string
 	scrollY int
+	search  tui.SearchController
 }
 
 func (app *codeApp) HandleEvent(event tui.Event) []tui.Cmd {
-	switch e := event.(type) {
-	case tui.KeyEvent:
-		if e.Rune == 'q' || e.Key == tui.KeyEscape {
-			return []tui.Cmd{tui.Quit()}
-		}
+	if sel, ok := event.(tui.SelectionEvent); ok {
+		clipboard.Write(sel.Text)
+		return nil
+	}
+	e, ok := event.(tui.KeyEvent)
+	if !ok || app.search.HandleKey(e) {
+		return nil
+	}
+	switch {
+	case e.Rune == 'q' || e.Key == tui.KeyEscape || e.Key == tui.KeyCtrlC:
+		return []tui.Cmd{tui.Quit()}
+	case e.Rune == 'j' || e.Key == tui.KeyArrowDown:
+		app.scrollY++
+	case e.Rune == 'k' || e.Key == tui.KeyArrowUp:
+		app.scrollY--
+	case e.Key == tui.KeyPageDown:
+		app.scrollY += 20
+	case e.Key == tui.KeyPageUp:
+		app.scrollY -= 20
 	}
 	return nil
 }
 
 func (app *codeApp) View() tui.View {
-	return tui.Scroll(
-		tui.Code(app.code, "go"),
-		&app.scrollY,
+	return tui.Stack(
+		tui.Scroll(tui.Code(app.code, "go"), &app.scrollY).SearchWith(&app.search).Selectable(),
+		tui.SearchBar(&app.search),
 	)
 }
@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/deepnoodle-ai/wonton/tui"
+	"github.com/deepnoodle-ai/wonton/unidiff"
 )
 
 const sampleDiff = `diff --git a/server.go b/server.go
@@ -39,21 +40,32 @@ const sampleDiff = `diff --git a/server.go b/server.go
 +	if err := http.ListenAndServe(":"+port, nil); err != nil {
 +		log.Fatal(err)
 +	}
- }`
+ }
+diff --git a/README.md b/README.md
+--- a/README.md
++++ b/README.md
+@@ -1,5 +1,5 @@
+ # Server
+
+-Serves a greeting on port 8080.
++Serves a greeting on $PORT (default 8080).
+
+ Run with go run .`
 
 // DiffDemoApp demonstrates the declarative DiffView.
 type DiffDemoApp struct {
-	diff    *tui.Diff
-	scrollY int
+	diff  *unidiff.Diff
+	state *tui.DiffState
 }
 
 // Init initializes the application by parsing the diff.
 func (app *DiffDemoApp) Init() error {
-	diff, err := tui.ParseUnifiedDiff(sampleDiff)
+	diff, err := unidiff.Parse(sampleDiff)
 	if err != nil {
 		return fmt.Errorf("failed to parse diff: %w", err)
 	}
 	app.diff = diff
+	app.state = tui.NewDiffState()
 	return nil
 }
 
@@ -64,6 +76,7 @@ func (app *DiffDemoApp) HandleEvent(event tui.Event) []tui.Cmd {
 		if e.Rune == 'q' || e.Rune == 'Q' || e.Key == tui.KeyEscape || e.Key == tui.KeyCtrlC {
 			return []tui.Cmd{tui.Quit()}
 		}
+		app.state.HandleKey(e)
 	}
 
 	return nil
@@ -77,12 +90,9 @@ func (app *DiffDemoApp) View() tui.View {
 
 	return tui.Stack(
 		tui.Bordered(
-			tui.Scroll(
-				tui.DiffView(app.diff, "go", nil).ShowLineNumbers(true),
-				&app.scrollY,
-			),
+			tui.DiffView(app.diff, "go", nil).State(app.state),
 		).BorderFg(tui.ColorCyan).Title("Diff Viewer"),
-		tui.Text(" Press q to quit | ↑↓ to scroll | PgUp/PgDn for pages | Home/End to jump ").
+		tui.Text(" q quit | ↑↓ scroll | n/N next/prev hunk | ]/[ next/prev file | z fold | Z fold all | s side by side ").
 			Style(statusStyle),
 	)
 }
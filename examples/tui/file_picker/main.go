@@ -1,25 +1,28 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"log"
 	"os"
-	"path/filepath"
 	"strings"
 
+	"github.com/deepnoodle-ai/wonton/humanize"
 	"github.com/deepnoodle-ai/wonton/tui"
 )
 
-// FilePickerDemoApp demonstrates the declarative FilePicker view.
+// FilePickerDemoApp demonstrates the declarative FilePicker view, browsing
+// with a FileBrowser so zip and tar archives open like directories.
 type FilePickerDemoApp struct {
-	currentDir string
-	files      []tui.ListItem
-	filter     string
-	selected   int
-	showHidden bool
-	statusMsg  string
-	width      int
-	height     int
+	browser   *tui.FileBrowser
+	files     []tui.ListItem
+	filter    string
+	selected  int
+	statusMsg string
+	preview   []string
+	width     int
+	height    int
 }
 
 // Init initializes the application.
@@ -28,61 +31,27 @@ func (app *FilePickerDemoApp) Init() error {
 	if err != nil {
 		pwd = "/"
 	}
-	app.currentDir = pwd
+	app.browser = tui.NewFileBrowser(pwd)
 	app.refreshFiles()
-	app.statusMsg = "Type to filter, arrows to navigate, Enter to select, F2 toggle hidden, Esc quit"
+	app.statusMsg = "Type to filter, arrows to navigate, Enter to open, F2 toggle hidden, Esc quit"
 	return nil
 }
 
-// refreshFiles reads the current directory and updates the file list.
+// Destroy removes files extracted from archives for previews.
+func (app *FilePickerDemoApp) Destroy() {
+	app.browser.Close()
+}
+
+// refreshFiles lists the current location and updates the file list.
 func (app *FilePickerDemoApp) refreshFiles() {
 	app.filter = "" // Reset filter on dir change
 	app.selected = 0
 
-	files, err := os.ReadDir(app.currentDir)
+	items, err := app.browser.Items()
 	if err != nil {
 		app.files = []tui.ListItem{{Label: fmt.Sprintf("Error: %v", err)}}
 		return
 	}
-
-	var items []tui.ListItem
-
-	// Add ".." if not at root
-	parent := filepath.Dir(app.currentDir)
-	if parent != app.currentDir {
-		items = append(items, tui.ListItem{Label: "..", Value: parent, Icon: "[DIR]"})
-	}
-
-	// Sort: Directories first, then files
-	var dirs []os.DirEntry
-	var regular []os.DirEntry
-
-	for _, f := range files {
-		if !app.showHidden && strings.HasPrefix(f.Name(), ".") {
-			continue
-		}
-		if f.IsDir() {
-			dirs = append(dirs, f)
-		} else {
-			regular = append(regular, f)
-		}
-	}
-
-	for _, d := range dirs {
-		items = append(items, tui.ListItem{
-			Label: "[DIR] " + d.Name(),
-			Value: filepath.Join(app.currentDir, d.Name()),
-			Icon:  "[DIR]",
-		})
-	}
-
-	for _, f := range regular {
-		items = append(items, tui.ListItem{
-			Label: f.Name(),
-			Value: filepath.Join(app.currentDir, f.Name()),
-		})
-	}
-
 	app.files = items
 }
 
@@ -97,9 +66,9 @@ func (app *FilePickerDemoApp) HandleEvent(event tui.Event) []tui.Cmd {
 
 		// Toggle hidden files on F2
 		if e.Key == tui.KeyF2 {
-			app.showHidden = !app.showHidden
+			app.browser.ShowHidden = !app.browser.ShowHidden
 			app.refreshFiles()
-			if app.showHidden {
+			if app.browser.ShowHidden {
 				app.statusMsg = "Hidden files: ON"
 			} else {
 				app.statusMsg = "Hidden files: OFF"
@@ -115,60 +84,77 @@ func (app *FilePickerDemoApp) HandleEvent(event tui.Event) []tui.Cmd {
 	return nil
 }
 
-// handleSelect handles item selection.
-func (app *FilePickerDemoApp) handleSelect() {
-	if app.selected >= len(app.files) {
+// handleSelect opens directories and archives, and previews files.
+func (app *FilePickerDemoApp) handleSelect(item tui.ListItem) {
+	entry, opened, err := app.browser.Select(item)
+	if err != nil {
+		app.statusMsg = fmt.Sprintf("Error: %v", err)
 		return
 	}
-
-	item := app.files[app.selected]
-	path, ok := item.Value.(string)
-	if !ok {
+	if opened {
+		app.refreshFiles()
+		app.preview = nil
+		app.statusMsg = fmt.Sprintf("Opened: %s", app.browser.Path())
+		return
+	}
+	if entry.Path == "" {
 		return
 	}
 
-	info, err := os.Stat(path)
+	// Files inside archives are extracted to a temporary directory first
+	path, err := app.browser.Extract(entry)
 	if err != nil {
 		app.statusMsg = fmt.Sprintf("Error: %v", err)
 		return
 	}
+	app.statusMsg = fmt.Sprintf("Selected: %s (%s)", entry.Name, humanize.Bytes(entry.Size))
+	if entry.InArchive {
+		app.statusMsg += " extracted to " + path
+	}
+	app.preview = previewLines(path, 8)
+}
 
-	if info.IsDir() {
-		app.currentDir = path
-		app.refreshFiles()
-		app.statusMsg = fmt.Sprintf("Opened: %s", path)
-	} else {
-		size := info.Size()
-		var sizeStr string
-		if size < 1024 {
-			sizeStr = fmt.Sprintf("%d B", size)
-		} else if size < 1024*1024 {
-			sizeStr = fmt.Sprintf("%.1f KB", float64(size)/1024)
-		} else {
-			sizeStr = fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
-		}
-		app.statusMsg = fmt.Sprintf("Selected: %s (%s)", filepath.Base(path), sizeStr)
+// previewLines returns the first lines of a text file.
+func previewLines(path string, n int) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return []string{err.Error()}
 	}
+	defer f.Close()
+
+	buf := make([]byte, 4096)
+	size, _ := io.ReadFull(f, buf)
+	if bytes.IndexByte(buf[:size], 0) >= 0 {
+		return []string{"(binary file)"}
+	}
+	lines := strings.Split(string(buf[:size]), "\n")
+	return lines[:min(len(lines), n)]
 }
 
 // View returns the declarative view structure.
 func (app *FilePickerDemoApp) View() tui.View {
-	pickerHeight := app.height - 6
+	pickerHeight := app.height - 7 - len(app.preview)
 	if pickerHeight < 5 {
 		pickerHeight = 5
 	}
 
+	previewViews := make([]tui.View, len(app.preview))
+	for i, line := range app.preview {
+		previewViews[i] = tui.Text("  %s", line).Dim()
+	}
+
 	return tui.Stack(
 		tui.Text("FILE PICKER DEMO").Bold().Fg(tui.ColorCyan),
 		tui.Spacer().MinHeight(1),
 		tui.FilePicker(app.files, &app.filter, &app.selected).
-			CurrentPath(app.currentDir).
+			CurrentPath(app.browser.Path()).
 			Height(pickerHeight).
 			OnSelect(func(item tui.ListItem, index int) {
-				app.handleSelect()
+				app.handleSelect(item)
 			}),
 		tui.Spacer().MinHeight(1),
 		tui.Text("%s", app.statusMsg).Fg(tui.ColorGreen),
+		tui.Stack(previewViews...),
 	)
 }
 
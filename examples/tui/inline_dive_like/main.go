@@ -10,8 +10,6 @@ import (
 type diveLikeApp struct {
 	runner *tui.InlineApp
 
-	frame uint64
-
 	processing bool
 	showTodos  bool
 	showDialog bool
@@ -143,8 +141,6 @@ func (a *diveLikeApp) HandleEvent(event tui.Event) []tui.Cmd {
 		case 'x':
 			a.expandedMsg = !a.expandedMsg
 		}
-	case tui.TickEvent:
-		a.frame = e.Frame
 	}
 	return nil
 }
@@ -164,7 +160,7 @@ func (a *diveLikeApp) buildLiveView() tui.View {
 	}
 
 	views = append(views, tui.Group(
-		tui.Loading(a.frame).CharSet(tui.SpinnerBounce.Frames).Speed(6).Fg(tui.ColorCyan),
+		tui.Spinner(tui.SpinnerBounce).Speed(6).Fg(tui.ColorCyan),
 		tui.Text(" thinking").Animate(tui.Slide(3, tui.NewRGB(80, 80, 80), tui.NewRGB(80, 200, 220))),
 		tui.Text(" (%s)", formatDuration(time.Second*23)).Hint(),
 		tui.Text("  ").Hint(),
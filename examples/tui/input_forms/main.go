@@ -15,12 +15,15 @@ type InputFormsApp struct {
 	name     string
 	email    string
 	password string
+	role     string
 
 	// Form state
 	submitted bool
 	errors    []string
 }
 
+var roles = []string{"Developer", "Designer", "Product Manager", "Data Scientist", "Support Engineer", "Technical Writer"}
+
 func (app *InputFormsApp) View() tui.View {
 	if app.submitted {
 		// Show success screen
@@ -33,6 +36,7 @@ func (app *InputFormsApp) View() tui.View {
 			tui.Text("Name:     %s", app.name),
 			tui.Text("Email:    %s", app.email),
 			tui.Text("Password: %s", strings.Repeat("*", len(app.password))),
+			tui.Text("Role:     %s", app.role),
 			tui.Spacer().MinHeight(1),
 			tui.Text("Press Enter to exit").Dim(),
 		).Padding(2)
@@ -79,6 +83,15 @@ func (app *InputFormsApp) View() tui.View {
 		tui.Spacer().MinHeight(1),
 	)
 
+	// Role dropdown: Enter opens it, typing filters the roles
+	children = append(children,
+		tui.Group(
+			tui.Text("Role:     "),
+			tui.Select(&app.role, roles).ID("role").Placeholder("Choose a role").Width(40),
+		),
+		tui.Spacer().MinHeight(1),
+	)
+
 	// Show errors if any
 	if len(app.errors) > 0 {
 		for _, err := range app.errors {
@@ -100,7 +113,7 @@ func (app *InputFormsApp) View() tui.View {
 
 	// Help text
 	children = append(children,
-		tui.Text("Tab/Shift+Tab: Navigate | Enter/Space: Activate | Esc: Quit").Dim(),
+		tui.Text("Tab/Shift+Tab: Navigate | Enter/Space: Activate | Ctrl+C: Quit").Dim(),
 	)
 
 	return tui.Stack(children...).Padding(2)
@@ -116,8 +129,8 @@ func (app *InputFormsApp) HandleEvent(event tui.Event) []tui.Cmd {
 			}
 		}
 
-		// Handle quit keys
-		if e.Key == tui.KeyEscape || e.Key == tui.KeyCtrlC {
+		// Handle quit keys. Escape is left to close the role dropdown.
+		if e.Key == tui.KeyCtrlC {
 			return []tui.Cmd{tui.Quit()}
 		}
 	}
@@ -146,6 +159,10 @@ func (app *InputFormsApp) validateAndSubmit() {
 		app.errors = append(app.errors, "Password must be at least 4 characters")
 	}
 
+	if app.role == "" {
+		app.errors = append(app.errors, "Role is required")
+	}
+
 	if len(app.errors) == 0 {
 		app.submitted = true
 	}
@@ -13,6 +13,7 @@ type InputStylesApp struct {
 	input3 string
 	input4 string
 	input5 string
+	input6 string
 }
 
 func (app *InputStylesApp) Init() error {
@@ -96,6 +97,16 @@ func (app *InputStylesApp) View() tui.View {
 			CursorBlink(true).
 			Width(60),
 
+		tui.Spacer().MinHeight(1),
+
+		// 6. The terminal's own cursor, placed by the runtime
+		tui.InputField(&app.input6).
+			ID("input6").
+			Label("Terminal Cursor:").
+			Placeholder("Uses your terminal's cursor settings").
+			CursorShape(tui.InputCursorTerminal).
+			Width(60),
+
 		// Help
 		tui.Spacer().MinHeight(2),
 		tui.Text("Tab/Shift+Tab: navigate | ESC: quit").Fg(tui.ColorBrightBlack),
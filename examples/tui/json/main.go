@@ -0,0 +1,89 @@
+// Example: json - Explore a JSON document
+//
+// Shows a JSON file as a collapsible, colored tree with search.
+//
+// Keys:
+//   - Up/Down or j/k: Move
+//   - Right/l, Left/h: Expand or collapse, or step in and out
+//   - Enter or Space: Toggle the node
+//   - /: Search; n/N next/previous match
+//   - y: Copy the selected path to the clipboard
+//   - q or Ctrl+C: Quit
+//
+// Run with:
+//
+//	go run ./examples/tui/json data.json
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/deepnoodle-ai/wonton/clipboard"
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+// JSONApp browses one JSON document
+type JSONApp struct {
+	data   []byte
+	state  *tui.JSONState
+	search tui.SearchController
+	status string
+}
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: json FILE")
+		os.Exit(2)
+	}
+	data, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	app := &JSONApp{data: data, state: tui.NewJSONState()}
+	app.state.OnCopy = func(path string) {
+		if err := clipboard.Write(path); err != nil {
+			app.status = fmt.Sprintf("Copy failed: %v", err)
+			return
+		}
+		app.status = "Copied " + path
+	}
+	if err := tui.Run(app); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func (app *JSONApp) HandleEvent(event tui.Event) []tui.Cmd {
+	e, ok := event.(tui.KeyEvent)
+	if !ok {
+		return nil
+	}
+	if e.Key == tui.KeyCtrlC {
+		return []tui.Cmd{tui.Quit()}
+	}
+	app.status = ""
+	if app.search.HandleKey(e) || app.state.HandleKey(e) {
+		return nil
+	}
+	if e.Rune == 'q' {
+		return []tui.Cmd{tui.Quit()}
+	}
+	return nil
+}
+
+func (app *JSONApp) View() tui.View {
+	status := app.status
+	if status == "" {
+		status = app.state.SelectedPath()
+	}
+	return tui.Stack(
+		tui.JSONView(app.data).
+			State(app.state).
+			SearchWith(&app.search).
+			ExpandDepth(2),
+		tui.SearchBar(&app.search),
+		tui.StatusBar(fmt.Sprintf("%s | / search  y copy path  q quit", status)),
+	)
+}
@@ -0,0 +1,129 @@
+// Example: logs - Follow a live log
+//
+// Shows a log as it is written with LogView: lines are colored by level and
+// the view follows the end of the log until you scroll up. Without arguments
+// it logs a simulated service through log/slog; with a command, it shows the
+// command's output.
+//
+// Keys:
+//   - Up/Down or j/k, PgUp/PgDn: Scroll (scrolling up pauses following)
+//   - g/G or Home/End: Oldest line / end of the log
+//   - f: Pause or resume following
+//   - /: Search; n/N next/previous match
+//   - q or Ctrl+C: Quit
+//
+// Run with:
+//
+//	go run ./examples/tui/logs
+//	go run ./examples/tui/logs ping -c 100 localhost
+package main
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+// LogsApp follows one log
+type LogsApp struct {
+	logs   *tui.LogBuffer
+	state  *tui.LogState
+	search tui.SearchController
+}
+
+func main() {
+	app := &LogsApp{
+		logs:  tui.NewLogBuffer(5000),
+		state: tui.NewLogState(),
+	}
+
+	if len(os.Args) > 1 {
+		cmd := exec.Command(os.Args[1], os.Args[2:]...)
+		cmd.Stdout = app.logs
+		cmd.Stderr = app.logs
+		if err := cmd.Start(); err != nil {
+			log.Fatal(err)
+		}
+		go func() {
+			if err := cmd.Wait(); err != nil {
+				app.logs.Appendf("ERROR %s: %v", os.Args[1], err)
+			} else {
+				app.logs.Appendf("INFO %s exited", os.Args[1])
+			}
+		}()
+	} else {
+		// The view shows each line's time, so leave it out of the text
+		handler := slog.NewTextHandler(app.logs, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey && len(groups) == 0 {
+					return slog.Attr{}
+				}
+				return a
+			},
+		})
+		go simulate(slog.New(handler))
+	}
+
+	if err := tui.Run(app, tui.WithFPS(10)); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// simulate logs requests to a made-up service
+func simulate(logger *slog.Logger) {
+	paths := []string{"/", "/api/users", "/api/orders", "/static/app.js", "/healthz"}
+	for i := 0; ; i++ {
+		path := paths[rand.IntN(len(paths))]
+		latency := time.Duration(rand.IntN(300)) * time.Millisecond
+		switch n := rand.IntN(20); {
+		case n == 0:
+			logger.Error("request failed", "path", path, "err", "upstream timeout")
+		case n < 3:
+			logger.Warn("slow request", "path", path, "latency", latency*5)
+		case n < 6:
+			logger.Debug("cache miss", "key", fmt.Sprintf("user:%d", rand.IntN(1000)))
+		default:
+			logger.Info("request", "method", "GET", "path", path, "status", 200, "latency", latency)
+		}
+		time.Sleep(time.Duration(50+rand.IntN(250)) * time.Millisecond)
+	}
+}
+
+func (app *LogsApp) HandleEvent(event tui.Event) []tui.Cmd {
+	e, ok := event.(tui.KeyEvent)
+	if !ok {
+		return nil
+	}
+	if e.Key == tui.KeyCtrlC {
+		return []tui.Cmd{tui.Quit()}
+	}
+	if app.search.HandleKey(e) || app.state.HandleKey(e) {
+		return nil
+	}
+	if e.Rune == 'q' {
+		return []tui.Cmd{tui.Quit()}
+	}
+	return nil
+}
+
+func (app *LogsApp) View() tui.View {
+	mode := "following"
+	if !app.state.Following() {
+		mode = "paused"
+	}
+	return tui.Stack(
+		tui.LogView(app.logs).
+			State(app.state).
+			SearchWith(&app.search).
+			Timestamps("15:04:05.000"),
+		tui.SearchBar(&app.search),
+		tui.StatusBar(fmt.Sprintf("%d lines, %s | / search  f follow  g/G top/end  q quit", app.logs.Total(), mode)),
+	)
+}
@@ -15,6 +15,8 @@ type MouseDemoApp struct {
 	clickCount   int
 	scrollOffset int
 	lastAction   string
+	barWidth     int
+	dragFrom     int // barWidth when the current drag started
 }
 
 // HandleEvent processes events
@@ -33,25 +35,40 @@ func (app *MouseDemoApp) HandleEvent(event tui.Event) []tui.Cmd {
 
 // View returns the declarative view structure using TUI components
 func (app *MouseDemoApp) View() tui.View {
-	// Action buttons using Clickable components
+	hover := tui.NewStyle().WithReverse()
+
+	// Action buttons using Clickable components, highlighted under the pointer
 	buttonRow := tui.Group(
 		tui.Clickable("[ Increment ]", func() {
 			app.clickCount++
 			app.lastAction = fmt.Sprintf("Incremented! Count: %d", app.clickCount)
-		}).Fg(tui.ColorBlue),
+		}).Fg(tui.ColorBlue).HoverStyle(hover.WithForeground(tui.ColorBlue)),
 
 		tui.Spacer().MinWidth(2),
 
 		tui.Clickable("[ Reset ]", func() {
 			app.clickCount = 0
 			app.lastAction = "Counter reset to 0"
-		}).Fg(tui.ColorMagenta),
+		}).Fg(tui.ColorMagenta).HoverStyle(hover.WithForeground(tui.ColorMagenta)),
 
 		tui.Spacer().MinWidth(2),
 
 		tui.Clickable("[ Info ]", func() {
 			app.lastAction = "Info: This demo showcases mouse interactions with TUI components!"
-		}).Fg(tui.ColorGreen),
+		}).Fg(tui.ColorGreen).HoverStyle(hover.WithForeground(tui.ColorGreen)),
+	)
+
+	// A bar resized by dragging its handle
+	resizableBar := tui.Group(
+		tui.Text("%s", strings.Repeat("█", app.barWidth)).Fg(tui.ColorGreen),
+		tui.Draggable(tui.Text("▐◀▶").Fg(tui.ColorYellow), func(e tui.DragEvent) {
+			if e.Phase == tui.DragStart {
+				app.dragFrom = app.barWidth
+			}
+			dx, _ := e.Delta()
+			app.barWidth = max(1, min(60, app.dragFrom+dx))
+			app.lastAction = fmt.Sprintf("Bar width: %d", app.barWidth)
+		}),
 	)
 
 	// Scrollable content area using Scroll component
@@ -101,7 +118,7 @@ func (app *MouseDemoApp) View() tui.View {
 	return tui.Stack(
 		tui.Spacer().MinHeight(1),
 		tui.Text("Wonton Mouse Demo").Bold().Fg(tui.ColorCyan),
-		tui.Text("Click buttons and scroll content with mouse or keyboard").Dim(),
+		tui.Text("Click buttons, drag the bar's handle, and scroll content with mouse or keyboard").Dim(),
 		tui.Text("Press 'q', Esc, or Ctrl+C to exit").Dim(),
 		tui.Spacer().MinHeight(1),
 
@@ -110,6 +127,11 @@ func (app *MouseDemoApp) View() tui.View {
 
 		tui.Spacer().MinHeight(1),
 
+		// Drag the handle to resize the bar
+		resizableBar,
+
+		tui.Spacer().MinHeight(1),
+
 		// Scroll area
 		scrollArea,
 
@@ -124,6 +146,7 @@ func main() {
 	// Mouse tracking is enabled via WithMouseTracking option
 	app := &MouseDemoApp{
 		lastAction: "Ready - click buttons or scroll the content area",
+		barWidth:   20,
 	}
 
 	if err := tui.Run(app, tui.WithMouseTracking(true)); err != nil {
@@ -0,0 +1,113 @@
+// Example: notifications - Toasts and a notification center
+//
+// Notifications pop up as toasts that time out, and stay in a notification
+// center that can be opened beside the content.
+//
+// Keys:
+//   - i, w, e: Send an info, warning, or error notification
+//   - x: Delete a file, undoable until its toast goes (hover to hold it)
+//   - n: Open or close the notification center
+//   - In the center: j/k select, Enter or 1-9 run an action, d remove,
+//     f filter by severity, c clear, Esc close
+//   - q or Ctrl+C: Quit
+//
+// Run with:
+//
+//	go run ./examples/tui/notifications
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+// NotificationsApp sends notifications on request
+type NotificationsApp struct {
+	notes *tui.NotificationStore
+	sent  int
+	log   []string
+}
+
+func main() {
+	app := &NotificationsApp{notes: tui.NewNotificationStore()}
+	app.notes.MaxToasts = 3
+	if err := tui.Run(app, tui.WithFPS(10), tui.WithMouseTracking(true)); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func (app *NotificationsApp) HandleEvent(event tui.Event) []tui.Cmd {
+	e, ok := event.(tui.KeyEvent)
+	if !ok {
+		return nil
+	}
+	if e.Key == tui.KeyCtrlC {
+		return []tui.Cmd{tui.Quit()}
+	}
+	if app.notes.HandleKey(e) {
+		return nil
+	}
+
+	switch e.Rune {
+	case 'i':
+		app.sent++
+		app.notes.Info("Build finished", fmt.Sprintf("Build #%d passed in 42s", app.sent))
+	case 'w':
+		app.sent++
+		app.notes.Warn("Disk almost full", "Less than 10% free on /home")
+	case 'e':
+		app.sent++
+		job := app.sent
+		app.notes.Notify(tui.SeverityError, "Upload failed",
+			fmt.Sprintf("Upload #%d: connection refused", job),
+			tui.NotificationAction{Label: "Retry", Run: func() {
+				app.log = append(app.log, fmt.Sprintf("Retried upload #%d", job))
+			}},
+			tui.NotificationAction{Label: "Details", Run: func() {
+				app.log = append(app.log, fmt.Sprintf("Showed details of upload #%d", job))
+			}},
+		)
+	case 'x':
+		app.sent++
+		file := fmt.Sprintf("draft-%d.txt", app.sent)
+		app.notes.Perform(tui.UndoableAction{
+			Title:   "Deleted " + file,
+			Timeout: 8 * time.Second,
+			Do:      func() { app.log = append(app.log, "Moved "+file+" to the trash") },
+			Undo:    func() { app.log = append(app.log, "Restored "+file) },
+			Commit:  func() { app.log = append(app.log, "Emptied "+file+" from the trash") },
+		})
+	case 'n':
+		app.notes.Toggle()
+	case 'q':
+		return []tui.Cmd{tui.Quit()}
+	}
+	return nil
+}
+
+func (app *NotificationsApp) View() tui.View {
+	bell := "n notifications"
+	if unread := app.notes.Unread(); unread > 0 {
+		bell = fmt.Sprintf("n notifications (%d new)", unread)
+	}
+
+	var lines []tui.View
+	for _, line := range app.log {
+		lines = append(lines, tui.Text("%s", line))
+	}
+	content := tui.Stack(
+		tui.HeaderBar("Notifications demo").Bold(),
+		tui.Text("Press i, w, or e to send a notification.").Dim(),
+		tui.Stack(lines...),
+		tui.Spacer(),
+		tui.StatusBar(fmt.Sprintf("i info  w warning  e error  x delete  %s  q quit", bell)),
+	).Flex(1)
+
+	return tui.ZStack(
+		tui.Group(content, tui.NotificationCenter(app.notes)),
+		tui.Toasts(app.notes),
+	)
+}
@@ -0,0 +1,28 @@
+// Command pager shows a file a screen at a time, like less.
+//
+//	go run ./examples/tui/pager README.md
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: pager FILE")
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	data, err := os.ReadFile(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := tui.Page(tui.Pager(string(data), nil).Title(name).LineNumbers()); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -134,7 +134,7 @@ func (app *ProgressDemoApp) View() tui.View {
 
 		// Progress items using declarative views with ForEach
 		tui.ForEach(app.items, func(item *ProgressItem, i int) tui.View {
-			spinner := tui.Loading(app.frame).Label(item.Message).Fg(item.Color)
+			spinner := tui.Spinner(tui.SpinnerDots).Label(item.Message).Fg(item.Color)
 
 			// If not spinner-only, add progress bar below using Progress component
 			if !item.SpinnerOnly {
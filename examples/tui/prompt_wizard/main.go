@@ -124,14 +124,17 @@ func (app *App) summaryView() tui.View {
 		tui.Text(" Your selections:").Dim(),
 	}
 
+	// Line the answers up in a column after the step titles
+	guide := tui.NewAlignmentGuide()
 	for i, step := range steps {
 		answer := "skipped"
 		if i < len(app.answers) {
 			answer = app.answers[i]
 		}
-		items = append(items,
-			tui.Text("   • %s: %s", step.Title, answer),
-		)
+		items = append(items, tui.Group(
+			guide.Column("title", tui.Text("   • %s:", step.Title)),
+			tui.Text(" %s", answer),
+		))
 	}
 
 	items = append(items,
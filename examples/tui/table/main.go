@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"strconv"
 
 	"github.com/deepnoodle-ai/wonton/tui"
 )
@@ -41,13 +42,37 @@ func (app *TableDemoApp) View() tui.View {
 			}),
 		tui.Spacer().MinHeight(1),
 		tui.Text("Selected Row: %d", app.selected+1).Fg(tui.ColorGreen),
-		tui.Text("Features: Uppercase headers, max column width, color inversion, header border").Dim(),
+		tui.Text("Features: Uppercase headers, max column width, color inversion, header border, cell views").Dim(),
+		tui.Text("Click a title or press s/S to sort, drag the gap after Name to resize.").Dim(),
 		tui.Text("Press Arrows to move, q to quit.").Dim(),
 		tui.Spacer(),
 		tui.Text(" Press 'q' to quit ").Bg(tui.ColorBrightBlack).Fg(tui.ColorWhite),
 	)
 }
 
+// statusBadge renders the Status column as a colored badge.
+func statusBadge(row int, value string) tui.View {
+	bg := tui.ColorGreen
+	if value != "Active" {
+		bg = tui.ColorYellow
+	}
+	return tui.Text(" %s ", value).Bg(bg).Fg(tui.ColorBlack)
+}
+
+// loadStyle colors high loads red.
+func loadStyle(row int, value string) tui.Style {
+	if load, _ := strconv.Atoi(value); load > 80 {
+		return tui.NewStyle().WithForeground(tui.ColorRed)
+	}
+	return tui.NewStyle()
+}
+
+// loadBar renders the Load column as a progress bar.
+func loadBar(row int, value string) tui.View {
+	load, _ := strconv.Atoi(value)
+	return tui.Progress(load, 100)
+}
+
 // HandleEvent processes events from the runtime.
 func (app *TableDemoApp) HandleEvent(event tui.Event) []tui.Cmd {
 	switch e := event.(type) {
@@ -68,20 +93,28 @@ func (app *TableDemoApp) HandleEvent(event tui.Event) []tui.Cmd {
 func main() {
 	// Define columns
 	columns := []tui.TableColumn{
-		{Title: "ID", Width: 5},
-		{Title: "Name", Width: 20},
+		{Title: "ID", Width: 5, Align: tui.AlignRight, Sortable: true},
+		{Title: "Name", Width: 20, Sortable: true, Resizable: true},
 		{Title: "Role", Width: 15},
-		{Title: "Status", Width: 10},
+		{Title: "Status", Width: 10, Render: statusBadge, Sortable: true},
+		{Title: "Load", Width: 16, Render: loadBar, Sortable: true},
+		{Title: "%", Width: 4, Align: tui.AlignRight, CellStyle: loadStyle},
 	}
 
 	// Generate sample data
 	rows := make([][]string, 50)
 	for i := 0; i < 50; i++ {
+		status := "Active"
+		if i%7 == 3 {
+			status = "Away"
+		}
 		rows[i] = []string{
 			fmt.Sprintf("%d", i+1),
 			fmt.Sprintf("User %d", i+1),
 			"Developer",
-			"Active",
+			status,
+			strconv.Itoa(i * 37 % 101),
+			strconv.Itoa(i * 37 % 101),
 		}
 	}
 
@@ -92,7 +125,7 @@ func main() {
 	}
 
 	// Run the application
-	if err := tui.Run(app); err != nil {
+	if err := tui.Run(app, tui.WithMouseTracking(true)); err != nil {
 		log.Fatal(err)
 	}
 }
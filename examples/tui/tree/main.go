@@ -0,0 +1,122 @@
+// Example: tree - Browse a directory tree
+//
+// Directories load their entries the first time they are expanded, so even a
+// large tree opens instantly.
+//
+// Keys:
+//   - Up/Down or j/k: Move
+//   - Right/l: Expand, or step into a directory
+//   - Left/h: Collapse, or step out to the parent
+//   - Space: Show details of the selected entry
+//   - q or Ctrl+C: Quit
+//
+// Run with:
+//
+//	go run ./examples/tui/tree [dir]
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/deepnoodle-ai/wonton/humanize"
+	"github.com/deepnoodle-ai/wonton/tui"
+)
+
+// TreeApp browses the files under a directory
+type TreeApp struct {
+	root     *tui.TreeNode
+	selected *tui.TreeNode
+	scrollY  int
+	status   string
+	height   int
+}
+
+func main() {
+	dir := "."
+	if len(os.Args) > 1 {
+		dir = os.Args[1]
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	root := tui.NewTreeNode(abs).SetData(abs).SetLazy(true)
+	app := &TreeApp{root: root, selected: root, status: "Space for details, q to quit"}
+	if err := tui.Run(app); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadDir lists a directory as tree nodes, directories first
+func loadDir(node *tui.TreeNode) ([]*tui.TreeNode, error) {
+	dir := node.Data.(string)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].IsDir() && !entries[j].IsDir()
+	})
+	children := make([]*tui.TreeNode, len(entries))
+	for i, e := range entries {
+		children[i] = tui.NewTreeNode(e.Name()).
+			SetData(filepath.Join(dir, e.Name())).
+			SetLazy(e.IsDir())
+	}
+	return children, nil
+}
+
+// icon picks an icon from the entry's kind and state
+func icon(node *tui.TreeNode) string {
+	switch {
+	case node.Expanded:
+		return "📂"
+	case !node.IsLeaf():
+		return "📁"
+	default:
+		return "📄"
+	}
+}
+
+func (app *TreeApp) HandleEvent(event tui.Event) []tui.Cmd {
+	switch e := event.(type) {
+	case tui.KeyEvent:
+		if e.Key == tui.KeyCtrlC || e.Rune == 'q' {
+			return []tui.Cmd{tui.Quit()}
+		}
+	case tui.ResizeEvent:
+		app.height = e.Height
+	}
+	return nil
+}
+
+func (app *TreeApp) View() tui.View {
+	return tui.Stack(
+		tui.HeaderBar("Tree").Bold(),
+		tui.Tree(app.root).
+			ID("tree").
+			Selection(&app.selected).
+			ScrollY(&app.scrollY).
+			Height(max(1, app.height-2)).
+			LoadChildren(loadDir).
+			Icons(icon).
+			OnSelect(app.describe),
+		tui.Spacer(),
+		tui.StatusBar(app.status),
+	)
+}
+
+// describe shows the size and mode of the entry in the status bar
+func (app *TreeApp) describe(node *tui.TreeNode) {
+	info, err := os.Stat(node.Data.(string))
+	if err != nil {
+		app.status = err.Error()
+		return
+	}
+	app.status = fmt.Sprintf("%s  %s  %s", info.Mode(), humanize.Bytes(info.Size()), node.Data)
+}
@@ -0,0 +1,39 @@
+package fetch
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+)
+
+// DecodeHTML converts an HTML body to UTF-8 and returns it with the name of
+// the character encoding it was in, such as "utf-8", "windows-1252", or
+// "gbk".
+//
+// The encoding is taken from the first of these that names one: a byte
+// order mark, the charset parameter of contentType, or a <meta> tag in the
+// first 1024 bytes of the body. A body that declares nothing is UTF-8 if it
+// is valid UTF-8, and windows-1252 otherwise, as browsers assume. Bytes
+// that aren't valid in the encoding become U+FFFD.
+//
+// Example:
+//
+//	text, name := fetch.DecodeHTML(body, resp.Header.Get("Content-Type"))
+func DecodeHTML(body []byte, contentType string) (string, string) {
+	enc, name, certain := charset.DetermineEncoding(body, contentType)
+	if !certain && name == "windows-1252" && utf8.Valid(body) {
+		enc, name = encoding.Nop, "utf-8"
+	}
+	if name == "utf-8" && utf8.Valid(body) {
+		return strings.TrimPrefix(string(body), "\uFEFF"), name
+	}
+	decoded, err := enc.NewDecoder().Bytes(body)
+	if err != nil {
+		// The decoders replace invalid bytes rather than fail, but fall back
+		// to the bytes as they are just in case
+		return strings.ToValidUTF8(string(body), "\uFFFD"), name
+	}
+	return strings.TrimPrefix(string(decoded), "\uFEFF"), name
+}
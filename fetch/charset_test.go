@@ -0,0 +1,49 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestDecodeHTML(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        []byte
+		contentType string
+		want        string
+		charset     string
+	}{
+		{"header", []byte("<p>caf\xe9</p>"), "text/html; charset=ISO-8859-1", "<p>café</p>", "windows-1252"},
+		{"meta", []byte(`<meta charset="gbk"><p>` + "\xc4\xe3\xba\xc3</p>"), "text/html", `<meta charset="gbk"><p>你好</p>`, "gbk"},
+		{"bom", []byte("\xff\xfe<\x00p\x00>\x00"), "text/html; charset=iso-8859-1", "<p>", "utf-16le"},
+		{"utf-8 bom", []byte("\xef\xbb\xbf<p>ü</p>"), "", "<p>ü</p>", "utf-8"},
+		{"undeclared utf-8", []byte("<p>naïve</p>"), "text/html", "<p>naïve</p>", "utf-8"},
+		{"undeclared ascii", []byte("<p>plain</p>"), "text/html", "<p>plain</p>", "utf-8"},
+		{"undeclared latin-1", []byte("<p>na\xefve</p>"), "text/html", "<p>naïve</p>", "windows-1252"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, charset := DecodeHTML(tt.body, tt.contentType)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.charset, charset)
+		})
+	}
+}
+
+func TestHTTPFetcher_Fetch_Charset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=iso-8859-1")
+		w.Write([]byte("<html><head><title>Caf\xe9</title></head><body>Cr\xe8me br\xfbl\xe9e</body></html>"))
+	}))
+	defer server.Close()
+
+	resp, err := NewHTTPFetcher(HTTPFetcherOptions{}).Fetch(context.Background(), &Request{URL: server.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, "Café", resp.Metadata.Title)
+	assert.Contains(t, resp.HTML, "Crème brûlée")
+	assert.Equal(t, "windows-1252", resp.Metadata.Charset)
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -339,6 +341,8 @@ func TestProcessRequest_WithMarkdown(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, resp.Markdown)
 	assert.Contains(t, resp.Markdown, "Hello")
+	assert.Equal(t, []Heading{{Level: 1, Text: "Hello", Anchor: "hello"}}, resp.Outline)
+	assert.Equal(t, time.Minute, resp.ReadingTime)
 }
 
 func TestProcessRequest_WithHTML(t *testing.T) {
@@ -436,6 +440,104 @@ func TestProcessRequest_DefaultFormats(t *testing.T) {
 	assert.Empty(t, resp.RawHTML)
 }
 
+// Test stream.go
+
+func newRangeServer(content string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "data.bin", time.Time{}, strings.NewReader(content))
+	}))
+}
+
+func TestHTTPFetcher_Stream(t *testing.T) {
+	server := newRangeServer("hello, world")
+	defer server.Close()
+
+	fetcher := NewHTTPFetcher(HTTPFetcherOptions{})
+	resp, err := fetcher.Stream(context.Background(), &StreamRequest{URL: server.URL})
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello, world", string(body))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(0), resp.Offset)
+	assert.Equal(t, int64(12), resp.TotalSize)
+	assert.Equal(t, `"v1"`, resp.ETag)
+}
+
+func TestHTTPFetcher_Stream_Range(t *testing.T) {
+	server := newRangeServer("hello, world")
+	defer server.Close()
+
+	fetcher := NewHTTPFetcher(HTTPFetcherOptions{})
+	resp, err := fetcher.Stream(context.Background(), &StreamRequest{URL: server.URL, Offset: 7, IfRange: `"v1"`})
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "world", string(body))
+	assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	assert.Equal(t, int64(7), resp.Offset)
+	assert.Equal(t, int64(12), resp.TotalSize)
+	assert.Equal(t, int64(5), resp.ContentLength)
+}
+
+func TestHTTPFetcher_Stream_RangeIgnored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("full body"))
+	}))
+	defer server.Close()
+
+	fetcher := NewHTTPFetcher(HTTPFetcherOptions{})
+	resp, err := fetcher.Stream(context.Background(), &StreamRequest{URL: server.URL, Offset: 5})
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, int64(0), resp.Offset)
+}
+
+func TestHTTPFetcher_Stream_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	fetcher := NewHTTPFetcher(HTTPFetcherOptions{})
+	_, err := fetcher.Stream(context.Background(), &StreamRequest{URL: server.URL})
+	assert.Error(t, err)
+	var reqErr *RequestError
+	assert.True(t, errors.As(err, &reqErr))
+	assert.Equal(t, http.StatusNotFound, reqErr.StatusCode())
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		value string
+		start int64
+		total int64
+		ok    bool
+	}{
+		{"bytes 0-99/200", 0, 200, true},
+		{"bytes 100-199/*", 100, -1, true},
+		{"bytes */200", 0, 0, false},
+		{"items 0-1/2", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			start, total, ok := parseContentRange(tt.value)
+			assert.Equal(t, tt.ok, ok)
+			if ok {
+				assert.Equal(t, tt.start, start)
+				assert.Equal(t, tt.total, total)
+			}
+		})
+	}
+}
+
 // Example demonstrates basic usage of HTTPFetcher to fetch a web page.
 func Example() {
 	// Create a new HTTP fetcher with default options
@@ -51,6 +51,7 @@ import (
 	"time"
 
 	"github.com/deepnoodle-ai/wonton/htmlparse"
+	"github.com/deepnoodle-ai/wonton/htmltomd"
 )
 
 // Link represents a hyperlink found on a page.
@@ -62,6 +63,9 @@ type Image = htmlparse.Image
 // Metadata represents page metadata.
 type Metadata = htmlparse.Metadata
 
+// Heading represents a heading in a page's outline.
+type Heading = htmltomd.Heading
+
 // ElementFilter defines criteria for matching HTML elements.
 type ElementFilter = htmlparse.ElementFilter
 
@@ -158,6 +162,14 @@ type Response struct {
 	// Populated when "markdown" format is requested.
 	Markdown string `json:"markdown,omitempty"`
 
+	// Outline lists the headings of the Markdown content with their levels and
+	// anchors. Populated when "markdown" format is requested.
+	Outline []Heading `json:"outline,omitempty"`
+
+	// ReadingTime estimates how long the Markdown content takes to read, in
+	// whole minutes. Populated when "markdown" format is requested.
+	ReadingTime time.Duration `json:"reading_time,omitempty"`
+
 	// Summary is an AI-generated summary of the page content.
 	// Populated when "summary" format is requested (requires special fetcher support).
 	Summary string `json:"summary,omitempty"`
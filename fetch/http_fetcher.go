@@ -48,6 +48,15 @@ type HTTPFetcherOptions struct {
 	// MaxBodySize is the maximum response body size in bytes.
 	// Responses larger than this are rejected. Defaults to DefaultMaxBodySize (10 MB).
 	MaxBodySize int64
+
+	// Replay records responses to cassette files and replays them, for
+	// running offline and deterministically. See ReplayFetcher. Defaults to
+	// ReplayOff.
+	Replay ReplayMode
+
+	// CassetteDir is the directory cassettes are kept in when Replay is set.
+	// Defaults to DefaultCassetteDir.
+	CassetteDir string
 }
 
 // HTTPFetcher implements the Fetcher interface using Go's standard HTTP client.
@@ -107,7 +116,17 @@ func (f *HTTPFetcher) validateRequest(req *Request) error {
 //			"User-Agent": "MyApp/1.0",
 //		},
 //	})
+//
+// When options.Replay is set, the fetcher records and replays responses as
+// described on ReplayFetcher.
 func NewHTTPFetcher(options HTTPFetcherOptions) *HTTPFetcher {
+	if options.Replay != ReplayOff {
+		return NewReplayFetcher(options).HTTPFetcher
+	}
+	return newHTTPFetcher(options)
+}
+
+func newHTTPFetcher(options HTTPFetcherOptions) *HTTPFetcher {
 	if options.Timeout == 0 {
 		options.Timeout = DefaultTimeout
 	}
@@ -211,11 +230,13 @@ func (f *HTTPFetcher) Fetch(ctx context.Context, req *Request) (*Response, error
 		}
 	}
 
-	// Apply processing options
-	response, err := ProcessRequest(req, string(body))
+	// Transcode to UTF-8, then apply processing options
+	htmlContent, charsetName := DecodeHTML(body, contentType)
+	response, err := ProcessRequest(req, htmlContent)
 	if err != nil {
 		return nil, err
 	}
+	response.Metadata.Charset = charsetName
 
 	// Set other response fields
 	// Use the final URL after any redirects
@@ -293,9 +314,9 @@ func ProcessRequest(request *Request, htmlContent string) (*Response, error) {
 	}
 
 	// Generate markdown if requested
-	var markdownContent string
+	var markdown htmltomd.Result
 	if includeMarkdown {
-		markdownContent = htmltomd.Convert(renderedHTML)
+		markdown = htmltomd.ConvertDetailed(renderedHTML, nil)
 	}
 
 	// Get links from document if requested
@@ -337,15 +358,17 @@ func ProcessRequest(request *Request, htmlContent string) (*Response, error) {
 
 	// Build response
 	resp := &Response{
-		URL:        request.URL,
-		StatusCode: 200,
-		Headers:    map[string]string{},
-		Markdown:   markdownContent,
-		Metadata:   metadata,
-		Links:      links,
-		Images:     images,
-		Branding:   branding,
-		Timestamp:  time.Now().UTC(),
+		URL:         request.URL,
+		StatusCode:  200,
+		Headers:     map[string]string{},
+		Markdown:    markdown.Markdown,
+		Outline:     markdown.Outline,
+		ReadingTime: markdown.ReadingTime,
+		Metadata:    metadata,
+		Links:       links,
+		Images:      images,
+		Branding:    branding,
+		Timestamp:   time.Now().UTC(),
 	}
 
 	// Include HTML formats as requested
@@ -0,0 +1,383 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/deepnoodle-ai/wonton/retry"
+)
+
+// JSONRequest describes a call to a JSON API.
+//
+// Create one with NewJSONRequest or NewGraphQLRequest, or construct it
+// directly, and send it with HTTPFetcher.DoJSON, FetchJSON, or QueryGraphQL.
+type JSONRequest struct {
+	// Method is the HTTP method. Defaults to GET, or POST when Body is set.
+	Method string
+
+	// URL is the API endpoint (required).
+	URL string
+
+	// Headers are additional HTTP headers for this request. They override
+	// the fetcher's default headers.
+	Headers map[string]string
+
+	// Body is encoded as the JSON request body. nil sends no body.
+	Body any
+
+	// Retry retries the request with these options when it fails with a
+	// network error, a 429, or a 5xx status. Other failures, such as 4xx
+	// responses and undecodable bodies, are returned immediately. nil
+	// disables retries.
+	Retry []retry.Option
+}
+
+// NewJSONRequest creates a request with body encoded as JSON.
+//
+// Example:
+//
+//	req := fetch.NewJSONRequest(http.MethodPost, "https://api.example.com/items", item)
+func NewJSONRequest(method, url string, body any) *JSONRequest {
+	return &JSONRequest{Method: method, URL: url, Body: body}
+}
+
+// GraphQLQuery is the body of a GraphQL request.
+type GraphQLQuery struct {
+	// Query is the GraphQL document (required).
+	Query string `json:"query"`
+
+	// OperationName selects the operation to run when Query contains more
+	// than one.
+	OperationName string `json:"operationName,omitempty"`
+
+	// Variables are the values of the query's variables.
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// NewGraphQLRequest creates a POST request for a GraphQL query. Send it with
+// QueryGraphQL to decode the "data" field and handle the "errors" field of
+// the response.
+//
+// Example:
+//
+//	req := fetch.NewGraphQLRequest("https://api.example.com/graphql", fetch.GraphQLQuery{
+//		Query:     `query($id: ID!) { repo(id: $id) { name stars } }`,
+//		Variables: map[string]any{"id": "42"},
+//	})
+func NewGraphQLRequest(url string, query GraphQLQuery) *JSONRequest {
+	return &JSONRequest{Method: http.MethodPost, URL: url, Body: query}
+}
+
+// JSONResponse describes the HTTP response to a JSONRequest.
+type JSONResponse struct {
+	// URL is the final URL after any redirects.
+	URL string
+
+	// StatusCode is the HTTP status code.
+	StatusCode int
+
+	// Headers are the response headers, first value per name.
+	Headers map[string]string
+
+	// Body is the raw response body.
+	Body []byte
+}
+
+// APIError is the error reported by a JSON API in a response with a status
+// code of 400 or above. DoJSON returns it wrapped in a *RequestError carrying
+// the status code and URL.
+type APIError struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int
+
+	// Message is the error message found in the response body's error
+	// envelope, or the status text if there was none.
+	Message string
+
+	// Body is the raw response body.
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error %d: %s", e.StatusCode, e.Message)
+}
+
+// JSONDoer sends JSON requests. HTTPFetcher and ReplayFetcher implement it.
+type JSONDoer interface {
+	DoJSON(ctx context.Context, req *JSONRequest, out any) (*JSONResponse, error)
+}
+
+// DoJSON sends req and decodes the JSON response body into out, if out is
+// not nil and the body is not empty. The response is returned along with
+// any error once the server has answered, and is nil otherwise.
+//
+// The fetcher's default headers, Timeout (per attempt), and MaxBodySize
+// apply. Responses with a status code of 400 or above return a *RequestError
+// wrapping an *APIError, whose message is taken from the common error
+// envelopes: {"error": "..."}, {"error": {"message": "..."}},
+// {"message": "..."}, {"detail": "..."}, and {"errors": [{"message": "..."}]}.
+//
+// Example:
+//
+//	var items []Item
+//	_, err := fetcher.DoJSON(ctx, &fetch.JSONRequest{URL: url}, &items)
+//	var apiErr *fetch.APIError
+//	if errors.As(err, &apiErr) {
+//		fmt.Println(apiErr.StatusCode, apiErr.Message)
+//	}
+func (f *HTTPFetcher) DoJSON(ctx context.Context, req *JSONRequest, out any) (*JSONResponse, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = json.Marshal(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+	}
+	if len(req.Retry) == 0 {
+		return f.doJSON(ctx, req, body, out)
+	}
+	var last *JSONResponse
+	opts := append([]retry.Option{retry.WithRetryIf(IsRetryable)}, req.Retry...)
+	err := retry.DoSimple(ctx, func() error {
+		resp, err := f.doJSON(ctx, req, body, out)
+		last = resp
+		return err
+	}, opts...)
+	return last, err
+}
+
+func (f *HTTPFetcher) doJSON(ctx context.Context, req *JSONRequest, body []byte, out any) (*JSONResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+		if body != nil {
+			method = http.MethodPost
+		}
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, req.URL, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply default headers
+	for key, value := range f.headers {
+		if httpReq.Header.Get(key) == "" {
+			httpReq.Header.Set(key, value)
+		}
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	// Apply custom headers
+	for key, value := range req.Headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	resp, err := f.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > int(f.maxBodySize) {
+		return nil, NewRequestErrorf("response size exceeds limit of %d bytes", f.maxBodySize).
+			WithStatusCode(resp.StatusCode).
+			WithRawURL(req.URL)
+	}
+
+	headers := make(map[string]string)
+	for name, values := range resp.Header {
+		if len(values) > 0 {
+			headers[name] = values[0] // Use first value if multiple
+		}
+	}
+	result := &JSONResponse{
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Headers:    headers,
+		Body:       data,
+	}
+
+	if resp.StatusCode >= 400 {
+		apiErr := &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    errorMessage(data),
+			Body:       data,
+		}
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return result, NewRequestError(apiErr).
+			WithStatusCode(resp.StatusCode).
+			WithRawURL(req.URL)
+	}
+
+	if out != nil && len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return result, NewRequestErrorf("invalid JSON response: %w", err).
+				WithStatusCode(resp.StatusCode).
+				WithRawURL(req.URL)
+		}
+	}
+	return result, nil
+}
+
+// FetchJSON sends req with f and decodes the response into a T.
+//
+// Example:
+//
+//	repos, err := fetch.FetchJSON[[]Repo](ctx, fetcher, &fetch.JSONRequest{
+//		URL:   "https://api.example.com/repos",
+//		Retry: []retry.Option{retry.WithMaxAttempts(3)},
+//	})
+func FetchJSON[T any](ctx context.Context, f JSONDoer, req *JSONRequest) (T, error) {
+	var out T
+	_, err := f.DoJSON(ctx, req, &out)
+	return out, err
+}
+
+// GraphQLError is one entry of the "errors" field of a GraphQL response.
+type GraphQLError struct {
+	Message    string            `json:"message"`
+	Path       []any             `json:"path,omitempty"`
+	Locations  []GraphQLLocation `json:"locations,omitempty"`
+	Extensions map[string]any    `json:"extensions,omitempty"`
+}
+
+// GraphQLLocation is a position in a GraphQL query.
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLErrors is the "errors" field of a GraphQL response, returned as an
+// error by QueryGraphQL.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	if len(e) == 0 {
+		return "graphql: no errors"
+	}
+	msg := "graphql: " + e[0].Message
+	if len(e) > 1 {
+		msg += fmt.Sprintf(" (and %d more)", len(e)-1)
+	}
+	return msg
+}
+
+// QueryGraphQL sends a GraphQL request with f and decodes the "data" field
+// of the response into a T.
+//
+// If the response has an "errors" field, the error is a GraphQLErrors and
+// the result holds whatever partial data was returned.
+//
+// Example:
+//
+//	type result struct {
+//		Repo struct{ Name string; Stars int } `json:"repo"`
+//	}
+//	res, err := fetch.QueryGraphQL[result](ctx, fetcher, req)
+//	var gqlErrs fetch.GraphQLErrors
+//	if errors.As(err, &gqlErrs) {
+//		// The query ran but reported errors
+//	}
+func QueryGraphQL[T any](ctx context.Context, f JSONDoer, req *JSONRequest) (T, error) {
+	var envelope struct {
+		Data   *T            `json:"data"`
+		Errors GraphQLErrors `json:"errors"`
+	}
+	var out T
+	envelope.Data = &out
+	_, err := f.DoJSON(ctx, req, &envelope)
+	if err != nil {
+		// Servers may also report query errors with a 4xx status
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			if json.Unmarshal(apiErr.Body, &envelope) == nil && len(envelope.Errors) > 0 {
+				return out, envelope.Errors
+			}
+		}
+		return out, err
+	}
+	if len(envelope.Errors) > 0 {
+		return out, envelope.Errors
+	}
+	return out, nil
+}
+
+// IsRetryable reports whether a request that failed with err may succeed if
+// sent again: network errors, timeouts, and responses with a 429 or 5xx
+// status are retryable; other statuses, undecodable responses, unsupported
+// options, and errors marked with retry.MarkPermanent are not.
+//
+// It is the default retry condition of JSONRequest.Retry.
+func IsRetryable(err error) bool {
+	if err == nil || retry.IsPermanent(err) || errors.Is(err, ErrUnsupported) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) && reqErr.StatusCode() != 0 {
+		code := reqErr.StatusCode()
+		return code == http.StatusTooManyRequests || code >= 500
+	}
+	return true
+}
+
+// errorMessage extracts the message from a JSON error envelope, or returns
+// the body itself if it is short plain text.
+func errorMessage(body []byte) string {
+	var envelope map[string]any
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		text := strings.TrimSpace(string(body))
+		if text == "" || strings.HasPrefix(text, "<") || len(text) > 200 {
+			return ""
+		}
+		return text
+	}
+	message := func(v any) string {
+		switch v := v.(type) {
+		case string:
+			return v
+		case map[string]any:
+			if s, ok := v["message"].(string); ok {
+				return s
+			}
+		}
+		return ""
+	}
+	for _, key := range []string{"error", "message", "detail", "error_description"} {
+		if s := message(envelope[key]); s != "" {
+			// {"error": "invalid_grant", "error_description": "..."} (OAuth)
+			if desc, ok := envelope["error_description"].(string); ok && key == "error" && desc != "" {
+				return s + ": " + desc
+			}
+			return s
+		}
+	}
+	if list, ok := envelope["errors"].([]any); ok && len(list) > 0 {
+		if s := message(list[0]); s != "" {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,254 @@
+package fetch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/retry"
+)
+
+type testItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestDoJSON_Post(t *testing.T) {
+	var method, contentType, accept string
+	var received testItem
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		contentType = r.Header.Get("Content-Type")
+		accept = r.Header.Get("Accept")
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": 7, "name": "widget"}`))
+	}))
+	defer server.Close()
+
+	fetcher := NewHTTPFetcher(HTTPFetcherOptions{})
+	var created testItem
+	resp, err := fetcher.DoJSON(context.Background(), NewJSONRequest("", server.URL, testItem{Name: "widget"}), &created)
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, "application/json", accept)
+	assert.Equal(t, "widget", received.Name)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, testItem{ID: 7, Name: "widget"}, created)
+}
+
+func TestFetchJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.Write([]byte(`[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]`))
+	}))
+	defer server.Close()
+
+	items, err := FetchJSON[[]testItem](context.Background(), NewHTTPFetcher(HTTPFetcherOptions{}), &JSONRequest{URL: server.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, []testItem{{1, "a"}, {2, "b"}}, items)
+}
+
+func TestFetchJSON_InvalidBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html>not json</html>`))
+	}))
+	defer server.Close()
+
+	_, err := FetchJSON[testItem](context.Background(), NewHTTPFetcher(HTTPFetcherOptions{}), &JSONRequest{URL: server.URL})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid JSON response")
+	assert.False(t, IsRetryable(err))
+}
+
+func TestDoJSON_ErrorEnvelope(t *testing.T) {
+	tests := []struct {
+		body    string
+		message string
+	}{
+		{`{"error": "bad token"}`, "bad token"},
+		{`{"error": {"code": 3, "message": "quota exceeded"}}`, "quota exceeded"},
+		{`{"message": "not found"}`, "not found"},
+		{`{"detail": "invalid page"}`, "invalid page"},
+		{`{"errors": [{"message": "first"}, {"message": "second"}]}`, "first"},
+		{`{"error": "invalid_grant", "error_description": "expired"}`, "invalid_grant: expired"},
+		{`rate limited`, "rate limited"},
+		{`<html><body>Oops</body></html>`, "Bad Request"},
+		{``, "Bad Request"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			_, err := NewHTTPFetcher(HTTPFetcherOptions{}).DoJSON(context.Background(), &JSONRequest{URL: server.URL}, nil)
+			var apiErr *APIError
+			assert.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+			assert.Equal(t, tt.message, apiErr.Message)
+
+			var reqErr *RequestError
+			assert.True(t, errors.As(err, &reqErr))
+			assert.Equal(t, http.StatusBadRequest, reqErr.StatusCode())
+			assert.Equal(t, server.URL, reqErr.RawURL())
+		})
+	}
+}
+
+func TestDoJSON_Retry(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"id":1,"name":"x"}`, string(body))
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id": 1, "name": "ok"}`))
+	}))
+	defer server.Close()
+
+	req := NewJSONRequest(http.MethodPut, server.URL, testItem{ID: 1, Name: "x"})
+	req.Retry = []retry.Option{retry.WithMaxAttempts(5), retry.WithConstantBackoff(time.Millisecond)}
+	item, err := FetchJSON[testItem](context.Background(), NewHTTPFetcher(HTTPFetcherOptions{}), req)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, "ok", item.Name)
+}
+
+func TestDoJSON_RetrySkipsClientErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	req := &JSONRequest{
+		URL:   server.URL,
+		Retry: []retry.Option{retry.WithMaxAttempts(5), retry.WithConstantBackoff(time.Millisecond)},
+	}
+	resp, err := NewHTTPFetcher(HTTPFetcherOptions{}).DoJSON(context.Background(), req, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.False(t, IsRetryable(nil))
+	assert.True(t, IsRetryable(errors.New("connection reset")))
+	assert.True(t, IsRetryable(context.DeadlineExceeded))
+	assert.False(t, IsRetryable(context.Canceled))
+	assert.True(t, IsRetryable(NewRequestErrorf("x").WithStatusCode(429)))
+	assert.True(t, IsRetryable(NewRequestErrorf("x").WithStatusCode(502)))
+	assert.False(t, IsRetryable(NewRequestErrorf("x").WithStatusCode(401)))
+	assert.False(t, IsRetryable(ErrUnsupportedOption("Mobile")))
+	assert.False(t, IsRetryable(retry.MarkPermanent(errors.New("bad input"))))
+}
+
+func TestQueryGraphQL(t *testing.T) {
+	var query GraphQLQuery
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&query)
+		w.Write([]byte(`{"data": {"repo": {"name": "wonton", "stars": 42}}}`))
+	}))
+	defer server.Close()
+
+	type result struct {
+		Repo struct {
+			Name  string `json:"name"`
+			Stars int    `json:"stars"`
+		} `json:"repo"`
+	}
+	req := NewGraphQLRequest(server.URL, GraphQLQuery{
+		Query:         `query Repo($id: ID!) { repo(id: $id) { name stars } }`,
+		OperationName: "Repo",
+		Variables:     map[string]any{"id": "1"},
+	})
+	res, err := QueryGraphQL[result](context.Background(), NewHTTPFetcher(HTTPFetcherOptions{}), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "wonton", res.Repo.Name)
+	assert.Equal(t, 42, res.Repo.Stars)
+	assert.Equal(t, "Repo", query.OperationName)
+	assert.Equal(t, "1", query.Variables["id"])
+}
+
+func TestQueryGraphQL_Errors(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(`{
+			"data": {"viewer": {"login": "ada"}, "repo": null},
+			"errors": [
+				{"message": "repo not found", "path": ["repo"], "locations": [{"line": 1, "column": 20}]},
+				{"message": "rate limit"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	type result struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}
+	fetcher := NewHTTPFetcher(HTTPFetcherOptions{})
+	req := NewGraphQLRequest(server.URL, GraphQLQuery{Query: `{ viewer { login } repo(id: 1) { name } }`})
+
+	res, err := QueryGraphQL[result](context.Background(), fetcher, req)
+	var gqlErrs GraphQLErrors
+	assert.True(t, errors.As(err, &gqlErrs))
+	assert.Equal(t, 2, len(gqlErrs))
+	assert.Equal(t, "graphql: repo not found (and 1 more)", err.Error())
+	assert.Equal(t, []any{"repo"}, gqlErrs[0].Path)
+	assert.Equal(t, GraphQLLocation{Line: 1, Column: 20}, gqlErrs[0].Locations[0])
+	assert.Equal(t, "ada", res.Viewer.Login) // partial data
+
+	// Errors reported with a 4xx status are returned the same way
+	status = http.StatusBadRequest
+	_, err = QueryGraphQL[result](context.Background(), fetcher, req)
+	assert.True(t, errors.As(err, &gqlErrs))
+}
+
+func TestQueryGraphQL_Replay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var q GraphQLQuery
+		json.NewDecoder(r.Body).Decode(&q)
+		w.Write([]byte(`{"data": "` + q.Variables["name"].(string) + `"}`))
+	}))
+	dir := t.TempDir()
+	ctx := context.Background()
+	query := func(f JSONDoer, name string) (string, error) {
+		return QueryGraphQL[string](ctx, f, NewGraphQLRequest(server.URL, GraphQLQuery{
+			Query:     `query($name: String) { echo(name: $name) }`,
+			Variables: map[string]any{"name": name},
+		}))
+	}
+
+	recorder := NewReplayFetcher(HTTPFetcherOptions{CassetteDir: dir})
+	for _, name := range []string{"a", "b"} {
+		_, err := query(recorder, name)
+		assert.NoError(t, err)
+	}
+	server.Close()
+
+	// Queries to the same endpoint are recorded separately
+	player := NewReplayFetcher(HTTPFetcherOptions{Replay: ReplayOnly, CassetteDir: dir})
+	for _, name := range []string{"a", "b"} {
+		res, err := query(player, name)
+		assert.NoError(t, err)
+		assert.Equal(t, name, res)
+	}
+}
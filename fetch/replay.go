@@ -0,0 +1,313 @@
+package fetch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultCassetteDir is the directory cassettes are kept in when
+// HTTPFetcherOptions.CassetteDir is empty.
+const DefaultCassetteDir = "testdata/cassettes"
+
+// ErrNoCassette is returned in ReplayOnly mode when no cassette has been
+// recorded for a request.
+var ErrNoCassette = errors.New("no cassette recorded")
+
+// ReplayMode selects whether a fetcher records HTTP responses to cassettes,
+// replays them, or neither.
+type ReplayMode int
+
+const (
+	// ReplayOff makes requests over the network without recording them.
+	ReplayOff ReplayMode = iota
+
+	// ReplayAuto replays a request's cassette if one exists, and otherwise
+	// makes the request and records it.
+	ReplayAuto
+
+	// ReplayRecord always makes requests over the network, replacing any
+	// existing cassettes.
+	ReplayRecord
+
+	// ReplayOnly never touches the network. Requests without a cassette fail
+	// with ErrNoCassette.
+	ReplayOnly
+)
+
+var replayModeNames = []string{"off", "auto", "record", "replay"}
+
+// String returns the mode's name as accepted by ParseReplayMode.
+func (m ReplayMode) String() string {
+	if m < 0 || int(m) >= len(replayModeNames) {
+		return fmt.Sprintf("ReplayMode(%d)", int(m))
+	}
+	return replayModeNames[m]
+}
+
+// ParseReplayMode parses a mode name: "off", "auto", "record", or "replay".
+// An empty string is ReplayOff.
+func ParseReplayMode(s string) (ReplayMode, error) {
+	if s == "" {
+		return ReplayOff, nil
+	}
+	for i, name := range replayModeNames {
+		if strings.EqualFold(s, name) {
+			return ReplayMode(i), nil
+		}
+	}
+	return ReplayOff, fmt.Errorf("invalid replay mode %q", s)
+}
+
+// ReplayFetcher is an HTTPFetcher that records the HTTP responses it receives
+// to cassette files on disk and replays them later, so code that fetches
+// pages can run offline and deterministically in demos and tests.
+//
+// Recording happens at the HTTP transport level, so both Fetch and Stream
+// are covered, and each redirect hop is its own cassette. Cassettes are JSON
+// files named after the request's method, URL, Range header, and body.
+// Response bodies are stored as text when they are valid UTF-8, so cassettes
+// can be reviewed and edited by hand.
+//
+// ReplayFetcher also implements http.RoundTripper, and can be used as the
+// Transport of any http.Client.
+//
+// Example:
+//
+//	fetcher := fetch.NewReplayFetcher(fetch.HTTPFetcherOptions{
+//		Replay:      fetch.ReplayAuto,
+//		CassetteDir: "testdata/cassettes",
+//	})
+//	resp, err := fetcher.Fetch(ctx, &fetch.Request{URL: "https://example.com"})
+type ReplayFetcher struct {
+	*HTTPFetcher
+	dir       string
+	mode      ReplayMode
+	transport http.RoundTripper
+}
+
+// NewReplayFetcher creates a ReplayFetcher. options.Replay defaults to
+// ReplayAuto and options.CassetteDir to DefaultCassetteDir. Requests that
+// reach the network use the transport of options.Client.
+//
+// NewHTTPFetcher returns a replaying fetcher too when options.Replay is set,
+// so existing callers can opt in without changing types.
+func NewReplayFetcher(options HTTPFetcherOptions) *ReplayFetcher {
+	if options.Replay == ReplayOff {
+		options.Replay = ReplayAuto
+	}
+	if options.CassetteDir == "" {
+		options.CassetteDir = DefaultCassetteDir
+	}
+	if options.Client == nil {
+		options.Client = DefaultHTTPClient
+	}
+	transport := options.Client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	f := &ReplayFetcher{
+		dir:       options.CassetteDir,
+		mode:      options.Replay,
+		transport: transport,
+	}
+	client := *options.Client
+	client.Transport = f
+	options.Client = &client
+	f.HTTPFetcher = newHTTPFetcher(options)
+	return f
+}
+
+// Mode returns the fetcher's replay mode.
+func (f *ReplayFetcher) Mode() ReplayMode {
+	return f.mode
+}
+
+// Dir returns the directory cassettes are read from and written to.
+func (f *ReplayFetcher) Dir() string {
+	return f.dir
+}
+
+// CassettePath returns the file a request's cassette is stored in.
+func (f *ReplayFetcher) CassettePath(req *http.Request) string {
+	return filepath.Join(f.dir, cassetteName(req))
+}
+
+// RoundTrip implements http.RoundTripper, replaying or recording req
+// according to the fetcher's mode.
+func (f *ReplayFetcher) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := f.CassettePath(req)
+
+	if f.mode == ReplayAuto || f.mode == ReplayOnly {
+		c, err := loadCassette(path)
+		if err == nil {
+			return c.response(req), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if f.mode == ReplayOnly {
+			return nil, fmt.Errorf("%w for %s %s", ErrNoCassette, req.Method, req.URL)
+		}
+	}
+
+	resp, err := f.transport.RoundTrip(req)
+	if err != nil || f.mode == ReplayOff {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	c := &cassette{
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Range:    req.Header.Get("Range"),
+		Status:   resp.StatusCode,
+		Headers:  resp.Header,
+		Recorded: time.Now().UTC(),
+	}
+	c.setBody(body)
+	if err := c.save(path); err != nil {
+		return nil, err
+	}
+	return c.response(req), nil
+}
+
+// cassette is one recorded HTTP exchange.
+type cassette struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Range      string      `json:"range,omitempty"`
+	Status     int         `json:"status"`
+	Headers    http.Header `json:"headers"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 []byte      `json:"body_base64,omitempty"`
+	Recorded   time.Time   `json:"recorded,omitzero"`
+}
+
+func (c *cassette) setBody(body []byte) {
+	if utf8.Valid(body) {
+		c.Body = string(body)
+	} else {
+		c.BodyBase64 = body
+	}
+}
+
+func (c *cassette) body() []byte {
+	if c.BodyBase64 != nil {
+		return c.BodyBase64
+	}
+	return []byte(c.Body)
+}
+
+// response builds the http.Response the cassette recorded, as an answer to
+// req.
+func (c *cassette) response(req *http.Request) *http.Response {
+	body := c.body()
+	header := c.Headers.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.Status, http.StatusText(c.Status)),
+		StatusCode:    c.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func loadCassette(path string) (*cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// save writes the cassette to path, replacing it atomically so concurrent
+// readers never see a partial file.
+func (c *cassette) save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".cassette-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// cassetteName returns a readable file name for a request: its host and path,
+// followed by a hash of everything that distinguishes it from other requests.
+func cassetteName(req *http.Request) string {
+	h := sha256.New()
+	io.WriteString(h, req.Method+" "+req.URL.String()+" "+req.Header.Get("Range"))
+	// Requests to one endpoint differ by body, as with GraphQL
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			h.Write([]byte{0})
+			io.Copy(h, body)
+			body.Close()
+		}
+	}
+	hash := hex.EncodeToString(h.Sum(nil))[:12]
+	return cassetteSlug(req.URL) + "-" + hash + ".json"
+}
+
+func cassetteSlug(u *url.URL) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range u.Host + u.Path {
+		if r < 128 && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+		if b.Len() >= 60 {
+			break
+		}
+	}
+	return strings.Trim(b.String(), "-.")
+}
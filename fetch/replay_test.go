@@ -0,0 +1,154 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestReplayFetcher_RecordAndReplay(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html><head><title>Recorded</title></head><body>Hello</body></html>"))
+	}))
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	recorder := NewHTTPFetcher(HTTPFetcherOptions{Replay: ReplayAuto, CassetteDir: dir})
+	resp, err := recorder.Fetch(ctx, &Request{URL: server.URL + "/page"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Recorded", resp.Metadata.Title)
+
+	// A second fetch is served from the cassette
+	_, err = recorder.Fetch(ctx, &Request{URL: server.URL + "/page"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, hits)
+	server.Close()
+
+	player := NewReplayFetcher(HTTPFetcherOptions{Replay: ReplayOnly, CassetteDir: dir})
+	resp, err = player.Fetch(ctx, &Request{URL: server.URL + "/page"})
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, server.URL+"/page", resp.URL)
+	assert.Equal(t, "text/html", resp.Headers["Content-Type"])
+	assert.Contains(t, resp.HTML, "Hello")
+
+	_, err = player.Fetch(ctx, &Request{URL: server.URL + "/other"})
+	assert.True(t, errors.Is(err, ErrNoCassette))
+}
+
+func TestReplayFetcher_Record(t *testing.T) {
+	body := "first"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	fetcher := NewReplayFetcher(HTTPFetcherOptions{Replay: ReplayRecord, CassetteDir: dir})
+	assert.Equal(t, ReplayRecord, fetcher.Mode())
+	_, err := fetcher.Fetch(ctx, &Request{URL: server.URL})
+	assert.NoError(t, err)
+
+	// Record mode refreshes the cassette instead of replaying it
+	body = "second"
+	resp, err := fetcher.Fetch(ctx, &Request{URL: server.URL, Formats: []string{"raw_html"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "second", resp.RawHTML)
+
+	files, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(files))
+	assert.Equal(t, ".json", filepath.Ext(files[0].Name()))
+}
+
+func TestReplayFetcher_Stream(t *testing.T) {
+	server := newRangeServer("\x00\x01binary\xff")
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	fetcher := NewReplayFetcher(HTTPFetcherOptions{CassetteDir: dir})
+	assert.Equal(t, ReplayAuto, fetcher.Mode())
+	resp, err := fetcher.Stream(ctx, &StreamRequest{URL: server.URL})
+	assert.NoError(t, err)
+	resp.Body.Close()
+	resp, err = fetcher.Stream(ctx, &StreamRequest{URL: server.URL, Offset: 2})
+	assert.NoError(t, err)
+	resp.Body.Close()
+	server.Close()
+
+	player := NewReplayFetcher(HTTPFetcherOptions{Replay: ReplayOnly, CassetteDir: dir})
+	resp, err = player.Stream(ctx, &StreamRequest{URL: server.URL})
+	assert.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, "\x00\x01binary\xff", string(body))
+
+	// Range requests have their own cassettes
+	resp, err = player.Stream(ctx, &StreamRequest{URL: server.URL, Offset: 2})
+	assert.NoError(t, err)
+	body, err = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	assert.Equal(t, int64(2), resp.Offset)
+	assert.Equal(t, "binary\xff", string(body))
+}
+
+func TestReplayFetcher_Redirect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html><body>moved</body></html>"))
+	}))
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	_, err := NewReplayFetcher(HTTPFetcherOptions{CassetteDir: dir}).Fetch(ctx, &Request{URL: server.URL + "/old"})
+	assert.NoError(t, err)
+	server.Close()
+
+	player := NewReplayFetcher(HTTPFetcherOptions{Replay: ReplayOnly, CassetteDir: dir})
+	resp, err := player.Fetch(ctx, &Request{URL: server.URL + "/old"})
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/new", resp.URL)
+}
+
+func TestCassetteName(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/docs/intro?x=1", nil)
+	name := cassetteName(req)
+	assert.Equal(t, "example.com-docs-intro-", name[:len("example.com-docs-intro-")])
+
+	ranged, _ := http.NewRequest(http.MethodGet, "https://example.com/docs/intro?x=1", nil)
+	ranged.Header.Set("Range", "bytes=10-")
+	assert.NotEqual(t, name, cassetteName(ranged))
+}
+
+func TestParseReplayMode(t *testing.T) {
+	for _, mode := range []ReplayMode{ReplayOff, ReplayAuto, ReplayRecord, ReplayOnly} {
+		parsed, err := ParseReplayMode(mode.String())
+		assert.NoError(t, err)
+		assert.Equal(t, mode, parsed)
+	}
+	mode, err := ParseReplayMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, ReplayOff, mode)
+
+	_, err = ParseReplayMode("rewind")
+	assert.Error(t, err)
+}
@@ -0,0 +1,190 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// StreamRequest describes a raw download. Unlike Request, the response body
+// is not parsed or buffered, so it can be used for large or binary content.
+type StreamRequest struct {
+	// URL to download (required).
+	URL string
+
+	// Headers are additional HTTP headers for this request. They override the
+	// fetcher's default headers.
+	Headers map[string]string
+
+	// Offset requests the content starting at this byte offset using an HTTP
+	// Range request. Servers that don't support ranges return the full body;
+	// check StreamResponse.Offset to see where the body actually starts.
+	Offset int64
+
+	// IfRange, when set along with Offset, is sent as the If-Range header
+	// (typically a previously seen ETag or Last-Modified value). If the
+	// resource changed, the server returns the full body instead of a range.
+	IfRange string
+}
+
+// StreamResponse is an open HTTP response whose body has not been read.
+// The caller must close Body.
+type StreamResponse struct {
+	// URL is the final URL after any redirects.
+	URL string
+
+	// StatusCode is the HTTP status code (200 or 206).
+	StatusCode int
+
+	// Headers are the response headers, first value per name.
+	Headers map[string]string
+
+	// ContentType is the Content-Type header value.
+	ContentType string
+
+	// ContentLength is the number of bytes in Body, or -1 if unknown.
+	ContentLength int64
+
+	// Offset is the byte offset of the first byte of Body within the full
+	// resource. It is zero unless the server honored a range request.
+	Offset int64
+
+	// TotalSize is the size of the full resource, or -1 if unknown.
+	TotalSize int64
+
+	// ETag and LastModified identify the resource version, for use with
+	// StreamRequest.IfRange when resuming.
+	ETag         string
+	LastModified string
+
+	// Body streams the response content.
+	Body io.ReadCloser
+}
+
+// Streamer opens raw HTTP response streams. HTTPFetcher implements it.
+type Streamer interface {
+	Stream(ctx context.Context, req *StreamRequest) (*StreamResponse, error)
+}
+
+// Stream opens a GET request for req.URL and returns the response without
+// reading its body, for downloads and other large transfers.
+//
+// The fetcher's default headers are applied, but its Timeout and MaxBodySize
+// are not: a download may legitimately take longer and be larger than a web
+// page. Cancel ctx to abort a transfer. Note that a Timeout configured on the
+// underlying http.Client still applies to the whole transfer, so pass a client
+// without one when streaming large files.
+//
+// Responses with a status code of 400 or above are returned as a
+// *RequestError carrying the status code.
+//
+// Example:
+//
+//	resp, err := fetcher.Stream(ctx, &fetch.StreamRequest{URL: url, Offset: 1024})
+//	if err != nil {
+//		return err
+//	}
+//	defer resp.Body.Close()
+//	_, err = io.Copy(w, resp.Body)
+func (f *HTTPFetcher) Stream(ctx context.Context, req *StreamRequest) (*StreamResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, req.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply default headers
+	for key, value := range f.headers {
+		if httpReq.Header.Get(key) == "" {
+			httpReq.Header.Set(key, value)
+		}
+	}
+
+	// Apply custom headers
+	for key, value := range req.Headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	if req.Offset > 0 {
+		httpReq.Header.Set("Range", fmt.Sprintf("bytes=%d-", req.Offset))
+		if req.IfRange != "" {
+			httpReq.Header.Set("If-Range", req.IfRange)
+		}
+	}
+
+	resp, err := f.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		resp.Body.Close()
+		return nil, NewRequestErrorf("unexpected status: %s", resp.Status).
+			WithStatusCode(resp.StatusCode).
+			WithRawURL(req.URL)
+	}
+
+	headers := make(map[string]string)
+	for name, values := range resp.Header {
+		if len(values) > 0 {
+			headers[name] = values[0] // Use first value if multiple
+		}
+	}
+
+	result := &StreamResponse{
+		URL:           resp.Request.URL.String(),
+		StatusCode:    resp.StatusCode,
+		Headers:       headers,
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: resp.ContentLength,
+		TotalSize:     resp.ContentLength,
+		ETag:          resp.Header.Get("ETag"),
+		LastModified:  resp.Header.Get("Last-Modified"),
+		Body:          resp.Body,
+	}
+
+	if resp.StatusCode == http.StatusPartialContent {
+		start, total, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok {
+			resp.Body.Close()
+			return nil, NewRequestErrorf("invalid Content-Range: %q", resp.Header.Get("Content-Range")).
+				WithStatusCode(resp.StatusCode).
+				WithRawURL(req.URL)
+		}
+		result.Offset = start
+		result.TotalSize = total
+	}
+
+	return result, nil
+}
+
+// parseContentRange parses a "bytes start-end/total" header. total is -1
+// when the server reports it as "*".
+func parseContentRange(value string) (start, total int64, ok bool) {
+	value, found := strings.CutPrefix(strings.TrimSpace(value), "bytes ")
+	if !found {
+		return 0, 0, false
+	}
+	rangePart, totalPart, found := strings.Cut(value, "/")
+	if !found {
+		return 0, 0, false
+	}
+	startPart, _, found := strings.Cut(rangePart, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(startPart, 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, false
+	}
+	if totalPart == "*" {
+		return start, -1, true
+	}
+	total, err = strconv.ParseInt(totalPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, total, true
+}
@@ -0,0 +1,349 @@
+// Package fuzzy implements fuzzy matching and ranking in the style of fzf.
+//
+// A pattern matches a text when the pattern's characters appear in the text
+// in order, not necessarily next to each other: "fb" matches "FooBar" and
+// "file_browser.go". Matches are scored so the best ones can be listed first:
+// characters at the start of words, after separators, and at camelCase humps
+// score higher, consecutive characters score higher, and gaps cost points.
+// The positions of the matched characters are reported for highlighting.
+//
+// Matching ignores case and diacritics, so "cafe" matches "Café".
+//
+// # Basic Usage
+//
+//	if m, ok := fuzzy.Match("fb", "file_browser.go"); ok {
+//		fmt.Println(m.Score, m.Positions) // positions of 'f' and 'b'
+//	}
+//
+//	for _, r := range fuzzy.Rank("fb", names) {
+//		fmt.Println(names[r.Index], r.Score)
+//	}
+package fuzzy
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Scoring constants, following fzf.
+const (
+	scoreMatch        = 16
+	scoreGapStart     = -3
+	scoreGapExtension = -1
+
+	// Bonus for matching the first character of a word
+	bonusBoundary          = scoreMatch / 2
+	bonusBoundaryWhite     = bonusBoundary + 2
+	bonusBoundaryDelimiter = bonusBoundary + 1
+
+	// Bonus for matching a non-word character, such as "." or "-"
+	bonusNonWord = scoreMatch / 2
+
+	// Bonus for a camelCase hump or the start of a number
+	bonusCamel123 = bonusBoundary + scoreGapExtension
+
+	// Minimum bonus for each character after the first of a consecutive run
+	bonusConsecutive = -(scoreGapStart + scoreGapExtension)
+
+	// The bonus of the first pattern character counts this many times
+	bonusFirstCharMultiplier = 2
+)
+
+// Result describes how a pattern matched a text.
+type Result struct {
+	// Score is higher for better matches. It is only comparable between
+	// results for the same pattern.
+	Score int
+
+	// Positions holds the rune indices in the text of the matched
+	// characters, in increasing order.
+	Positions []int
+}
+
+// Ranked is a matching candidate returned by Rank.
+type Ranked struct {
+	Index int // Index of the candidate in the slice passed to Rank
+	Result
+}
+
+// Match reports whether pattern matches text and, if so, how well. An empty
+// pattern matches everything with a score of 0.
+func Match(pattern, text string) (Result, bool) {
+	if pattern == "" {
+		return Result{}, true
+	}
+	return newMatcher(pattern).match(text)
+}
+
+// Rank matches pattern against each candidate and returns the matches,
+// best first. Ties go to the shorter candidate, then to the earlier one.
+// With an empty pattern, every candidate is returned in its original order.
+func Rank(pattern string, candidates []string) []Ranked {
+	return RankFunc(pattern, candidates, nil)
+}
+
+// RankFunc is like Rank, but orders matches with the same score and length
+// by compare, such as a locale-aware collation, before their place in
+// candidates. A nil compare ranks like Rank.
+func RankFunc(pattern string, candidates []string, compare func(a, b string) int) []Ranked {
+	ranked := make([]Ranked, 0, len(candidates))
+	if pattern == "" {
+		for i := range candidates {
+			ranked = append(ranked, Ranked{Index: i})
+		}
+		return ranked
+	}
+	m := newMatcher(pattern)
+	for i, c := range candidates {
+		if r, ok := m.match(c); ok {
+			ranked = append(ranked, Ranked{Index: i, Result: r})
+		}
+	}
+	sort.SliceStable(ranked, func(a, b int) bool {
+		ra, rb := ranked[a], ranked[b]
+		if ra.Score != rb.Score {
+			return ra.Score > rb.Score
+		}
+		ca, cb := candidates[ra.Index], candidates[rb.Index]
+		if len(ca) != len(cb) || compare == nil {
+			return len(ca) < len(cb)
+		}
+		return compare(ca, cb) < 0
+	})
+	return ranked
+}
+
+// matcher matches one pattern against many texts, reusing its buffers.
+type matcher struct {
+	pattern []rune
+
+	// The text being matched: folded runes, the rune index of each in the
+	// original text, and the bonus for matching each
+	runes   []rune
+	indexes []int
+	bonuses []int
+
+	// Scoring matrices, see match
+	score, from, chunkBonus []int
+}
+
+// newMatcher normalizes a pattern.
+func newMatcher(pattern string) *matcher {
+	m := &matcher{}
+	for _, r := range pattern {
+		if r, ok := fold(r); ok {
+			m.pattern = append(m.pattern, r)
+		}
+	}
+	return m
+}
+
+// prepare folds text into the matcher's buffers.
+func (m *matcher) prepare(text string) {
+	m.runes, m.indexes, m.bonuses = m.runes[:0], m.indexes[:0], m.bonuses[:0]
+	prev := charWhite
+	i := 0
+	for _, r := range text {
+		if f, ok := fold(r); ok {
+			class := classOf(r)
+			m.runes = append(m.runes, f)
+			m.indexes = append(m.indexes, i)
+			m.bonuses = append(m.bonuses, bonusFor(prev, class))
+			prev = class
+		}
+		i++
+	}
+}
+
+// fold removes diacritics from r and lowercases it. It returns false for
+// combining marks, which are dropped.
+func fold(r rune) (rune, bool) {
+	if r < 0x80 {
+		if 'A' <= r && r <= 'Z' {
+			r += 'a' - 'A'
+		}
+		return r, true
+	}
+	if unicode.Is(unicode.Mn, r) {
+		return 0, false
+	}
+	if d := norm.NFD.String(string(r)); d != "" {
+		r, _ = utf8.DecodeRuneInString(d)
+	}
+	return unicode.ToLower(r), true
+}
+
+type charClass int
+
+const (
+	charWhite charClass = iota
+	charNonWord
+	charDelimiter
+	charLower
+	charUpper
+	charLetter
+	charNumber
+)
+
+func classOf(r rune) charClass {
+	switch {
+	case r >= 'a' && r <= 'z':
+		return charLower
+	case r >= 'A' && r <= 'Z':
+		return charUpper
+	case r >= '0' && r <= '9':
+		return charNumber
+	case unicode.IsSpace(r):
+		return charWhite
+	case r == '/' || r == ',' || r == ':' || r == ';' || r == '|':
+		return charDelimiter
+	case unicode.IsLower(r):
+		return charLower
+	case unicode.IsUpper(r):
+		return charUpper
+	case unicode.IsLetter(r):
+		return charLetter
+	case unicode.IsNumber(r):
+		return charNumber
+	}
+	return charNonWord
+}
+
+// bonusFor returns the bonus for matching a character of class, preceded by
+// one of class prev.
+func bonusFor(prev, class charClass) int {
+	if class > charDelimiter {
+		switch prev {
+		case charWhite:
+			return bonusBoundaryWhite
+		case charDelimiter:
+			return bonusBoundaryDelimiter
+		case charNonWord:
+			return bonusBoundary
+		}
+	}
+	if prev == charLower && class == charUpper || prev != charNumber && class == charNumber {
+		return bonusCamel123
+	}
+	switch class {
+	case charNonWord, charDelimiter:
+		return bonusNonWord
+	case charWhite:
+		return bonusBoundaryWhite
+	}
+	return 0
+}
+
+// match finds the best-scoring alignment of the pattern in text,
+// Smith-Waterman style: every pattern character must match, and the score
+// adds up match bonuses and gap penalties.
+func (mt *matcher) match(text string) (Result, bool) {
+	p := mt.pattern
+	m := len(p)
+	if m == 0 {
+		return Result{}, true
+	}
+	mt.prepare(text)
+	runes, bonuses, indexes := mt.runes, mt.bonuses, mt.indexes
+	n := len(runes)
+
+	// The pattern must be a subsequence of the text. Alignments start no
+	// earlier than the first match of p[0] and end no later than the last
+	// match of p[m-1], so the rest of the text can be skipped.
+	first, pi := -1, 0
+	for j := 0; j < n && pi < m; j++ {
+		if runes[j] == p[pi] {
+			if pi == 0 {
+				first = j
+			}
+			pi++
+		}
+	}
+	if pi < m {
+		return Result{}, false
+	}
+	last := n - 1
+	for runes[last] != p[m-1] {
+		last--
+	}
+	runes, bonuses, indexes = runes[first:last+1], bonuses[first:last+1], indexes[first:last+1]
+	n = len(runes)
+
+	const none = -1 << 30
+	// score[i*n+j] is the best score of p[:i+1] with p[i] matched at t[j];
+	// from[i*n+j] is where p[i-1] was matched in that alignment
+	// chunkBonus[i*n+j] is the bonus of the first match in the run ending at j
+	if cap(mt.score) < m*n {
+		mt.score, mt.from, mt.chunkBonus = make([]int, m*n), make([]int, m*n), make([]int, m*n)
+	}
+	score, from, chunkBonus := mt.score[:m*n], mt.from[:m*n], mt.chunkBonus[:m*n]
+
+	for i := 0; i < m; i++ {
+		row := i * n
+		prevRow := row - n
+		gap, gapFrom := none, -1 // best score of p[i-1] matched before j-1, with gap penalties
+		for j := 0; j < n; j++ {
+			if i > 0 && j >= 2 && score[prevRow+j-2] > none {
+				if s := score[prevRow+j-2] + scoreGapStart; s > gap+scoreGapExtension {
+					gap, gapFrom = s, j-2
+				} else {
+					gap += scoreGapExtension
+				}
+			} else if gap > none {
+				gap += scoreGapExtension
+			}
+
+			score[row+j] = none
+			if runes[j] != p[i] {
+				continue
+			}
+			bonus := bonuses[j]
+
+			if i == 0 {
+				score[row+j] = scoreMatch + bonus*bonusFirstCharMultiplier
+				chunkBonus[row+j], from[row+j] = bonus, -1
+				continue
+			}
+
+			// Continue a run of consecutive matches. The run keeps the bonus
+			// of its first character, unless it crosses into a new word.
+			if j > 0 && score[prevRow+j-1] > none {
+				first := chunkBonus[prevRow+j-1]
+				if bonus >= bonusBoundary && bonus > first {
+					first = bonus
+				}
+				score[row+j] = score[prevRow+j-1] + scoreMatch + max(bonus, first, bonusConsecutive)
+				chunkBonus[row+j], from[row+j] = first, j-1
+			}
+
+			// Or jump over a gap
+			if gap > none {
+				if s := gap + scoreMatch + bonus; s > score[row+j] {
+					score[row+j] = s
+					chunkBonus[row+j], from[row+j] = bonus, gapFrom
+				}
+			}
+		}
+	}
+
+	best, end := none, -1
+	lastRow := (m - 1) * n
+	for j := 0; j < n; j++ {
+		if score[lastRow+j] > best {
+			best, end = score[lastRow+j], j
+		}
+	}
+	if end < 0 {
+		return Result{}, false
+	}
+
+	positions := make([]int, m)
+	for i, j := m-1, end; i >= 0; i-- {
+		positions[i] = indexes[j]
+		j = from[i*n+j]
+	}
+	return Result{Score: best, Positions: positions}, true
+}
@@ -0,0 +1,111 @@
+package fuzzy
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestMatch(t *testing.T) {
+	m, ok := Match("fb", "file_browser.go")
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 5}, m.Positions)
+
+	_, ok = Match("bf", "file_browser.go")
+	assert.False(t, ok)
+
+	m, ok = Match("", "anything")
+	assert.True(t, ok)
+	assert.Equal(t, 0, m.Score)
+	assert.Nil(t, m.Positions)
+}
+
+func TestMatch_PrefersBoundaries(t *testing.T) {
+	// "FooBar" starts a word and has a hump, so it beats "fabc"
+	m, _ := Match("fb", "fabc FooBar")
+	assert.Equal(t, []int{5, 8}, m.Positions)
+
+	// camelCase humps count as word starts
+	m, _ = Match("gfb", "getFileBrowser")
+	assert.Equal(t, []int{0, 3, 7}, m.Positions)
+
+	// A consecutive run beats scattered characters
+	m, _ = Match("open", "o_p_e_n open")
+	assert.Equal(t, []int{8, 9, 10, 11}, m.Positions)
+}
+
+func TestMatch_IgnoresCase(t *testing.T) {
+	m, ok := Match("FOO", "a foo")
+	assert.True(t, ok)
+	assert.Equal(t, []int{2, 3, 4}, m.Positions)
+}
+
+func TestMatch_Unicode(t *testing.T) {
+	m, ok := Match("cafe", "Café")
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 1, 2, 3}, m.Positions)
+
+	// Decomposed input: the combining accent is skipped, and positions
+	// are rune indexes in the original text
+	m, ok = Match("ee", "éte")
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 3}, m.Positions)
+
+	m, ok = Match("日語", "日本語")
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 2}, m.Positions)
+}
+
+func TestRank(t *testing.T) {
+	names := []string{"Toggle Sidebar", "Reset View", "Open Settings", "Save File", "Go to Symbol", "settings.json"}
+
+	var got []string
+	for _, r := range Rank("set", names) {
+		got = append(got, names[r.Index])
+	}
+	assert.Equal(t, []string{"Open Settings", "settings.json", "Reset View"}, got)
+
+	// Ties keep the shorter candidate first
+	ranked := Rank("s", []string{"sb", "sa", "s"})
+	assert.Equal(t, 2, ranked[0].Index)
+	assert.Equal(t, 0, ranked[1].Index)
+	assert.Equal(t, 1, ranked[2].Index)
+
+	// RankFunc breaks those ties with a comparison instead of by index
+	ranked = RankFunc("s", []string{"sb", "sa", "s"}, strings.Compare)
+	assert.Equal(t, 2, ranked[0].Index)
+	assert.Equal(t, 1, ranked[1].Index)
+	assert.Equal(t, 0, ranked[2].Index)
+
+	// An empty pattern keeps every candidate in order
+	ranked = Rank("", names)
+	assert.Len(t, ranked, len(names))
+	assert.Equal(t, 3, ranked[3].Index)
+}
+
+func BenchmarkMatch(b *testing.B) {
+	text := "internal/component/file_browser/file_browser_view.go"
+	for i := 0; i < b.N; i++ {
+		Match("fbview", text)
+	}
+}
+
+func BenchmarkMatch_NoMatch(b *testing.B) {
+	text := "internal/component/file_browser/file_browser_view.go"
+	for i := 0; i < b.N; i++ {
+		Match("xyz", text)
+	}
+}
+
+func BenchmarkRank(b *testing.B) {
+	candidates := make([]string, 10000)
+	for i := range candidates {
+		candidates[i] = fmt.Sprintf("pkg%d/module_%d/handler_%d.go", i%37, i%101, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Rank("modhand", candidates)
+	}
+}
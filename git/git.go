@@ -13,6 +13,7 @@
 //   - Diff operations: staged, unstaged, between refs
 //   - File operations: tracked files, untracked files, file contents at refs
 //   - Reference operations: resolve refs, check ancestry, merge base
+//   - Merge conflicts: conflicted files and the versions being merged
 //   - Configuration access: read git config values
 //
 // # Basic Usage
@@ -78,6 +79,7 @@ import (
 var (
 	ErrNotRepository = errors.New("not a git repository")
 	ErrNoCommits     = errors.New("no commits yet")
+	ErrNoConflict    = errors.New("file has no merge conflict")
 )
 
 // Repository represents a git repository and provides methods for
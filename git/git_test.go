@@ -1750,6 +1750,55 @@ func TestDiffDeletedFile(t *testing.T) {
 	}
 }
 
+func TestConflict(t *testing.T) {
+	repo, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	addFile(t, repo, "file.txt", "one\ntwo\n")
+	commit(t, repo, "Initial commit")
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo.Path
+		cmd.Run()
+	}
+	run("checkout", "-b", "feature")
+	addFile(t, repo, "file.txt", "one\nTWO\n")
+	commit(t, repo, "Feature change")
+	run("checkout", "-")
+	addFile(t, repo, "file.txt", "one\n2\n")
+	commit(t, repo, "Main change")
+
+	// The merge fails with a conflict
+	run("merge", "feature")
+
+	files, err := repo.ConflictedFiles(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0] != "file.txt" {
+		t.Fatalf("expected [file.txt], got %v", files)
+	}
+
+	c, err := repo.Conflict(ctx, "file.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.HasBase || !c.HasOurs || !c.HasTheirs {
+		t.Errorf("expected all three versions, got %+v", c)
+	}
+	if c.Base != "one\ntwo\n" || c.Ours != "one\n2\n" || c.Theirs != "one\nTWO\n" {
+		t.Errorf("unexpected versions: %+v", c)
+	}
+
+	if _, err := repo.Conflict(ctx, "missing.txt"); err != git.ErrNoConflict {
+		t.Errorf("expected ErrNoConflict, got %v", err)
+	}
+}
+
 // Example demonstrates opening a repository and getting basic information.
 func Example() {
 	// Open the current directory as a git repository
@@ -0,0 +1,71 @@
+package git
+
+import (
+	"context"
+	"strings"
+)
+
+// Conflict holds the versions of a file with a merge conflict, as recorded
+// in the index during a merge, rebase, cherry-pick, or stash pop.
+type Conflict struct {
+	Path   string `json:"path"`
+	Base   string `json:"base"`   // Common ancestor's version (stage 1)
+	Ours   string `json:"ours"`   // Version on the current branch (stage 2)
+	Theirs string `json:"theirs"` // Version being merged in (stage 3)
+
+	// A side is missing when the file didn't exist there: there is no
+	// base when both sides added the file, and no ours or theirs when one
+	// side deleted it.
+	HasBase   bool `json:"has_base"`
+	HasOurs   bool `json:"has_ours"`
+	HasTheirs bool `json:"has_theirs"`
+}
+
+// ConflictedFiles returns the paths of files with unresolved merge
+// conflicts.
+func (r *Repository) ConflictedFiles(ctx context.Context) ([]string, error) {
+	return r.runLines(ctx, "diff", "--name-only", "--diff-filter=U")
+}
+
+// Conflict returns the base, ours, and theirs versions of a file with an
+// unresolved merge conflict. Returns ErrNoConflict if the file has none.
+//
+// Example:
+//
+//	c, err := repo.Conflict(ctx, "main.go")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	merged := resolve(c.Base, c.Ours, c.Theirs)
+func (r *Repository) Conflict(ctx context.Context, path string) (*Conflict, error) {
+	// Each line is "<mode> <object> <stage>\t<path>"
+	lines, err := r.runLines(ctx, "ls-files", "--unmerged", "--", path)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, ErrNoConflict
+	}
+
+	c := &Conflict{Path: path}
+	for _, line := range lines {
+		info, _, ok := strings.Cut(line, "\t")
+		fields := strings.Fields(info)
+		if !ok || len(fields) != 3 {
+			continue
+		}
+		content, err := r.FileAtRef(ctx, ":"+fields[2], path)
+		if err != nil {
+			return nil, err
+		}
+		switch fields[2] {
+		case "1":
+			c.Base, c.HasBase = content, true
+		case "2":
+			c.Ours, c.HasOurs = content, true
+		case "3":
+			c.Theirs, c.HasTheirs = content, true
+		}
+	}
+	return c, nil
+}
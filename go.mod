@@ -9,15 +9,13 @@ require (
 	github.com/creack/pty v1.1.24
 	github.com/google/go-cmp v0.7.0
 	github.com/mattn/go-runewidth v0.0.17
+	github.com/rivo/uniseg v0.2.0
 	github.com/yuin/goldmark v1.7.13
 	golang.org/x/image v0.34.0
 	golang.org/x/net v0.48.0
 	golang.org/x/sys v0.39.0
 	golang.org/x/term v0.38.0
+	golang.org/x/text v0.32.0
 )
 
-require (
-	github.com/dlclark/regexp2 v1.11.5 // indirect
-	github.com/rivo/uniseg v0.2.0 // indirect
-	golang.org/x/text v0.32.0 // indirect
-)
+require github.com/dlclark/regexp2 v1.11.5 // indirect
@@ -128,8 +128,9 @@ func ParseReader(r io.Reader) (*Document, error) {
 
 // Metadata contains extracted page metadata from HTML <head> elements.
 //
-// This includes standard meta tags, Open Graph protocol data, and
-// Twitter Card data. Use Document.Metadata() to extract this information.
+// This includes standard meta tags, Open Graph protocol data, Twitter Card
+// data, icons, and schema.org structured data. Use Document.Metadata() to
+// extract this information.
 //
 // Fields are omitted from JSON if empty (omitempty tags).
 type Metadata struct {
@@ -143,6 +144,9 @@ type Metadata struct {
 	Robots      string     `json:"robots,omitempty"`      // Robot indexing directives
 	OpenGraph   *OpenGraph `json:"opengraph,omitempty"`   // Open Graph protocol metadata
 	Twitter     *Twitter   `json:"twitter,omitempty"`     // Twitter Card metadata
+
+	Icons          []Icon          `json:"icons,omitempty"`          // Icons from <link rel="icon"> and similar; see Favicon
+	StructuredData *StructuredData `json:"structuredData,omitempty"` // JSON-LD and microdata
 }
 
 // OpenGraph contains Open Graph protocol metadata.
@@ -174,9 +178,10 @@ type Twitter struct {
 
 // Metadata extracts page metadata from the document.
 //
-// This method extracts standard meta tags, Open Graph data, and Twitter Card
-// data from the HTML <head> section. It returns a Metadata struct containing
-// all discovered values.
+// This method extracts standard meta tags, Open Graph data, Twitter Card
+// data, and icon links from the HTML <head> section, and structured data
+// from JSON-LD scripts and microdata anywhere in the page. It returns a
+// Metadata struct containing all discovered values.
 //
 // Example:
 //
@@ -257,6 +262,9 @@ func (d *Document) Metadata() Metadata {
 			if rel == "canonical" {
 				m.Canonical = getAttr(n, "href")
 			}
+			if icon, ok := parseIcon(n); ok {
+				m.Icons = append(m.Icons, icon)
+			}
 		}
 		return true
 	})
@@ -267,6 +275,7 @@ func (d *Document) Metadata() Metadata {
 	if tw != (Twitter{}) {
 		m.Twitter = &tw
 	}
+	m.StructuredData = d.structuredData()
 
 	return m
 }
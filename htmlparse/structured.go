@@ -0,0 +1,587 @@
+package htmlparse
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Icon is an icon the page links to with <link rel="icon"> or a related rel
+// like "apple-touch-icon". The URL is as written in the page.
+type Icon struct {
+	URL      string `json:"url"`                // The href attribute value
+	Rel      string `json:"rel"`                // e.g. "icon", "apple-touch-icon", "mask-icon"
+	Type     string `json:"type,omitempty"`     // MIME type, e.g. "image/png"
+	Sizes    []int  `json:"sizes,omitempty"`    // Widths from the sizes attribute, e.g. 32 for "32x32"
+	Scalable bool   `json:"scalable,omitempty"` // sizes="any", as for SVG icons
+}
+
+// iconRels are the rel values of icon links.
+var iconRels = map[string]bool{
+	"icon":                         true,
+	"shortcut icon":                true,
+	"apple-touch-icon":             true,
+	"apple-touch-icon-precomposed": true,
+	"mask-icon":                    true,
+}
+
+// parseIcon returns the icon a <link> element declares, if it is one.
+func parseIcon(n *html.Node) (Icon, bool) {
+	rel := strings.Join(strings.Fields(strings.ToLower(getAttr(n, "rel"))), " ")
+	href := strings.TrimSpace(getAttr(n, "href"))
+	if !iconRels[rel] || href == "" {
+		return Icon{}, false
+	}
+	icon := Icon{URL: href, Rel: rel, Type: getAttr(n, "type")}
+	for _, size := range strings.Fields(strings.ToLower(getAttr(n, "sizes"))) {
+		if size == "any" {
+			icon.Scalable = true
+			continue
+		}
+		w, _, _ := strings.Cut(size, "x")
+		if width, err := strconv.Atoi(w); err == nil && width > 0 {
+			icon.Sizes = append(icon.Sizes, width)
+		}
+	}
+	return icon, true
+}
+
+// fit returns the size of the icon that suits size pixels best: the
+// smallest at least that big, or else the largest. It returns 0 if the
+// icon's sizes aren't known.
+func (i Icon) fit(size int) int {
+	best := 0
+	for _, s := range i.Sizes {
+		switch {
+		case best == 0:
+			best = s
+		case s >= size && (best < size || s < best):
+			best = s
+		case s < size && best < size && s > best:
+			best = s
+		}
+	}
+	return best
+}
+
+// Favicon returns the page's icon that best suits showing at size pixels:
+// a scalable icon, else the smallest at least that big, else the largest,
+// else one whose size isn't given. Monochrome mask icons are skipped. It
+// returns false if the page links to no icons, in which case browsers try
+// /favicon.ico.
+//
+// Example:
+//
+//	if icon, ok := meta.Favicon(32); ok {
+//	    fmt.Println(icon.URL)
+//	}
+func (m Metadata) Favicon(size int) (Icon, bool) {
+	// rank orders icons: scalable, then big enough, then too small, then unknown
+	rank := func(i Icon) (int, int) {
+		fit := i.fit(size)
+		switch {
+		case i.Scalable:
+			return 3, 0
+		case fit >= size && fit > 0:
+			return 2, -fit
+		case fit > 0:
+			return 1, fit
+		}
+		return 0, 0
+	}
+	var best Icon
+	found := false
+	for _, icon := range m.Icons {
+		if icon.Rel == "mask-icon" {
+			continue
+		}
+		if !found {
+			best, found = icon, true
+			continue
+		}
+		r, s := rank(icon)
+		br, bs := rank(best)
+		if r > br || (r == br && s > bs) {
+			best = icon
+		}
+	}
+	return best, found
+}
+
+// StructuredData is the schema.org data a page describes itself with, from
+// JSON-LD scripts and microdata. Items holds every top-level item as
+// parsed; Articles, Products, and Breadcrumbs hold the items of those kinds
+// found anywhere, nested ones included, as typed structs.
+type StructuredData struct {
+	Items       []StructuredItem `json:"items,omitempty"`
+	Articles    []Article        `json:"articles,omitempty"`
+	Products    []Product        `json:"products,omitempty"`
+	Breadcrumbs [][]Breadcrumb   `json:"breadcrumbs,omitempty"`
+}
+
+// StructuredItem is a structured data item as parsed.
+type StructuredItem struct {
+	Type       string         `json:"type"`       // schema.org type, e.g. "Article"; the first if several
+	Source     string         `json:"source"`     // "json-ld" or "microdata"
+	Properties map[string]any `json:"properties"` // As JSON-LD, microdata included: "@type", then properties by name
+}
+
+// Article is a schema.org Article, or a kind of one like NewsArticle or
+// BlogPosting.
+type Article struct {
+	Type          string   `json:"type"`                    // e.g. "NewsArticle"
+	Headline      string   `json:"headline,omitempty"`      // headline, or name
+	Description   string   `json:"description,omitempty"`   // description
+	Authors       []string `json:"authors,omitempty"`       // Names of the authors
+	Publisher     string   `json:"publisher,omitempty"`     // Name of the publisher
+	DatePublished string   `json:"datePublished,omitempty"` // As written, usually ISO 8601
+	DateModified  string   `json:"dateModified,omitempty"`  // As written, usually ISO 8601
+	Images        []string `json:"images,omitempty"`        // Image URLs
+	URL           string   `json:"url,omitempty"`           // url
+}
+
+// Product is a schema.org Product.
+type Product struct {
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Brand       string   `json:"brand,omitempty"` // Name of the brand
+	SKU         string   `json:"sku,omitempty"`
+	Images      []string `json:"images,omitempty"` // Image URLs
+	URL         string   `json:"url,omitempty"`
+	Offers      []Offer  `json:"offers,omitempty"`
+	Rating      *Rating  `json:"rating,omitempty"` // From aggregateRating
+}
+
+// Offer is a price a Product is offered at.
+type Offer struct {
+	Price        string `json:"price,omitempty"`        // As written, e.g. "19.99"; lowPrice for a range
+	Currency     string `json:"currency,omitempty"`     // ISO 4217 code, e.g. "USD"
+	Availability string `json:"availability,omitempty"` // e.g. "InStock"
+	URL          string `json:"url,omitempty"`
+}
+
+// Rating is the aggregate rating of a Product.
+type Rating struct {
+	Value float64 `json:"value"`
+	Best  float64 `json:"best,omitempty"`  // Highest possible rating, if given
+	Count int     `json:"count,omitempty"` // Number of ratings or reviews
+}
+
+// Breadcrumb is one step of a BreadcrumbList, from the site's root down to
+// the page.
+type Breadcrumb struct {
+	Name     string `json:"name"`
+	URL      string `json:"url,omitempty"`
+	Position int    `json:"position,omitempty"`
+}
+
+// structuredData returns the JSON-LD and microdata items of the document,
+// or nil if it has none.
+func (d *Document) structuredData() *StructuredData {
+	var sd StructuredData
+	d.walkNodes(d.root, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		if strings.EqualFold(n.Data, "script") {
+			if strings.EqualFold(strings.TrimSpace(getAttr(n, "type")), "application/ld+json") {
+				var v any
+				if json.Unmarshal([]byte(getTextContent(n)), &v) == nil {
+					sd.addJSONLD(v)
+				}
+			}
+			return false
+		}
+		if hasAttr(n, "itemscope") && !hasAttr(n, "itemprop") {
+			sd.add("microdata", microdataItem(n))
+		}
+		return true
+	})
+	if len(sd.Items) == 0 {
+		return nil
+	}
+	return &sd
+}
+
+// addJSONLD adds the items of a JSON-LD script: an object, an array of
+// them, or an object whose @graph lists them.
+func (sd *StructuredData) addJSONLD(v any) {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			sd.addJSONLD(item)
+		}
+	case map[string]any:
+		if graph, ok := v["@graph"]; ok {
+			sd.addJSONLD(graph)
+		}
+		if _, ok := v["@type"]; ok {
+			sd.add("json-ld", v)
+		}
+	}
+}
+
+// add adds a top-level item and the typed items found in it.
+func (sd *StructuredData) add(source string, item map[string]any) {
+	types := itemTypes(item)
+	if len(types) == 0 {
+		return
+	}
+	sd.Items = append(sd.Items, StructuredItem{Type: types[0], Source: source, Properties: item})
+	sd.extract(item)
+}
+
+// extract adds v to the typed items if it is one, and does the same for
+// the items nested in it.
+func (sd *StructuredData) extract(v any) {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			sd.extract(item)
+		}
+	case map[string]any:
+		sd.extractItem(v)
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			if !strings.HasPrefix(key, "@") {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys) // So nested items are found in the same order every time
+		for _, key := range keys {
+			sd.extract(v[key])
+		}
+	}
+}
+
+// extractItem adds item to the typed items by the first of its types that
+// has one.
+func (sd *StructuredData) extractItem(item map[string]any) {
+	for _, t := range itemTypes(item) {
+		switch {
+		case articleTypes[t]:
+			sd.Articles = append(sd.Articles, parseArticle(t, item))
+			return
+		case t == "Product":
+			sd.Products = append(sd.Products, parseProduct(item))
+			return
+		case t == "BreadcrumbList":
+			if crumbs := parseBreadcrumbs(item); len(crumbs) > 0 {
+				sd.Breadcrumbs = append(sd.Breadcrumbs, crumbs)
+			}
+			return
+		}
+	}
+}
+
+// articleTypes are the schema.org types parsed as an Article.
+var articleTypes = map[string]bool{
+	"Article":             true,
+	"NewsArticle":         true,
+	"BlogPosting":         true,
+	"TechArticle":         true,
+	"ScholarlyArticle":    true,
+	"Report":              true,
+	"LiveBlogPosting":     true,
+	"AnalysisNewsArticle": true,
+	"OpinionNewsArticle":  true,
+	"SocialMediaPosting":  true,
+}
+
+func parseArticle(t string, item map[string]any) Article {
+	a := Article{
+		Type:          t,
+		Headline:      textValue(item["headline"]),
+		Description:   textValue(item["description"]),
+		Authors:       textValues(item["author"]),
+		Publisher:     textValue(item["publisher"]),
+		DatePublished: textValue(item["datePublished"]),
+		DateModified:  textValue(item["dateModified"]),
+		Images:        urlValues(item["image"]),
+		URL:           urlValue(item["url"]),
+	}
+	if a.Headline == "" {
+		a.Headline = textValue(item["name"])
+	}
+	return a
+}
+
+func parseProduct(item map[string]any) Product {
+	p := Product{
+		Name:        textValue(item["name"]),
+		Description: textValue(item["description"]),
+		Brand:       textValue(item["brand"]),
+		SKU:         textValue(item["sku"]),
+		Images:      urlValues(item["image"]),
+		URL:         urlValue(item["url"]),
+	}
+	for _, offer := range objects(item["offers"]) {
+		o := Offer{
+			Price:        textValue(offer["price"]),
+			Currency:     textValue(offer["priceCurrency"]),
+			Availability: shortType(textValue(offer["availability"])),
+			URL:          urlValue(offer["url"]),
+		}
+		if o.Price == "" {
+			o.Price = textValue(offer["lowPrice"])
+		}
+		p.Offers = append(p.Offers, o)
+	}
+	if ratings := objects(item["aggregateRating"]); len(ratings) > 0 {
+		r := ratings[0]
+		count := numberValue(r["ratingCount"])
+		if count == 0 {
+			count = numberValue(r["reviewCount"])
+		}
+		p.Rating = &Rating{
+			Value: numberValue(r["ratingValue"]),
+			Best:  numberValue(r["bestRating"]),
+			Count: int(count),
+		}
+	}
+	return p
+}
+
+// parseBreadcrumbs returns the steps of a BreadcrumbList in order.
+func parseBreadcrumbs(item map[string]any) []Breadcrumb {
+	var crumbs []Breadcrumb
+	for _, element := range objects(item["itemListElement"]) {
+		c := Breadcrumb{
+			Name:     textValue(element["name"]),
+			URL:      urlValue(element["item"]),
+			Position: int(numberValue(element["position"])),
+		}
+		if c.Name == "" {
+			c.Name = textValue(element["item"])
+		}
+		if c.URL == "" {
+			c.URL = urlValue(element["url"])
+		}
+		if c.Name != "" || c.URL != "" {
+			crumbs = append(crumbs, c)
+		}
+	}
+	sort.SliceStable(crumbs, func(i, j int) bool { return crumbs[i].Position < crumbs[j].Position })
+	return crumbs
+}
+
+// microdataItem returns the microdata item of an itemscope element in the
+// shape JSON-LD has.
+func microdataItem(n *html.Node) map[string]any {
+	item := map[string]any{}
+	var types []any
+	for _, t := range strings.Fields(getAttr(n, "itemtype")) {
+		types = append(types, t)
+	}
+	switch len(types) {
+	case 0:
+	case 1:
+		item["@type"] = types[0]
+	default:
+		item["@type"] = types
+	}
+	if id := getAttr(n, "itemid"); id != "" {
+		item["@id"] = id
+	}
+
+	var walk func(*html.Node)
+	walk = func(parent *html.Node) {
+		for c := parent.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			for _, name := range strings.Fields(getAttr(c, "itemprop")) {
+				value := microdataValue(c)
+				switch existing := item[name].(type) {
+				case nil:
+					item[name] = value
+				case []any:
+					item[name] = append(existing, value)
+				default:
+					item[name] = []any{existing, value}
+				}
+			}
+			// The properties of a nested item are its own
+			if !hasAttr(c, "itemscope") {
+				walk(c)
+			}
+		}
+	}
+	walk(n)
+	return item
+}
+
+// microdataValue returns the value of an itemprop element.
+func microdataValue(n *html.Node) any {
+	if hasAttr(n, "itemscope") {
+		return microdataItem(n)
+	}
+	switch strings.ToLower(n.Data) {
+	case "meta":
+		return getAttr(n, "content")
+	case "audio", "embed", "iframe", "img", "source", "track", "video":
+		return getAttr(n, "src")
+	case "a", "area", "link":
+		return getAttr(n, "href")
+	case "object":
+		return getAttr(n, "data")
+	case "data", "meter":
+		return getAttr(n, "value")
+	case "time":
+		if hasAttr(n, "datetime") {
+			return getAttr(n, "datetime")
+		}
+	}
+	if hasAttr(n, "content") {
+		return getAttr(n, "content")
+	}
+	return strings.Join(strings.Fields(getTextContent(n)), " ")
+}
+
+// itemTypes returns the schema.org types of an item, without their
+// "https://schema.org/" or "schema:" prefix.
+func itemTypes(item map[string]any) []string {
+	var types []string
+	switch t := item["@type"].(type) {
+	case string:
+		types = append(types, shortType(t))
+	case []any:
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				types = append(types, shortType(s))
+			}
+		}
+	}
+	return types
+}
+
+// shortType returns a schema.org type or enumeration value without its
+// prefix: "https://schema.org/InStock" becomes "InStock".
+func shortType(t string) string {
+	t = strings.TrimSpace(t)
+	if i := strings.LastIndexAny(t, "/:"); i >= 0 {
+		return t[i+1:]
+	}
+	return t
+}
+
+// hasAttr reports whether n has the attribute key, even if it is empty.
+func hasAttr(n *html.Node, key string) bool {
+	for _, attr := range n.Attr {
+		if strings.EqualFold(attr.Key, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// textValue returns a property as text: a string or number as it is, an
+// item's name, or the first of a list.
+func textValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]any:
+		for _, key := range []string{"name", "@value", "headline"} {
+			if s := textValue(v[key]); s != "" {
+				return s
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if s := textValue(item); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// textValues returns each value of a property that may be a list as text.
+func textValues(v any) []string {
+	list, ok := v.([]any)
+	if !ok {
+		list = []any{v}
+	}
+	var values []string
+	for _, item := range list {
+		if s := textValue(item); s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// urlValue returns a property as a URL: a string, or an item's url or @id.
+func urlValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]any:
+		for _, key := range []string{"url", "contentUrl", "@id"} {
+			if s := urlValue(v[key]); s != "" {
+				return s
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if s := urlValue(item); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// urlValues returns each value of a property that may be a list as a URL.
+func urlValues(v any) []string {
+	list, ok := v.([]any)
+	if !ok {
+		list = []any{v}
+	}
+	var values []string
+	for _, item := range list {
+		if s := urlValue(item); s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// numberValue returns a property as a number, parsing strings.
+func numberValue(v any) float64 {
+	switch v := v.(type) {
+	case float64:
+		return v
+	case string:
+		f, _ := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f
+	case []any:
+		if len(v) > 0 {
+			return numberValue(v[0])
+		}
+	}
+	return 0
+}
+
+// objects returns a property that may be one item or a list as items.
+func objects(v any) []map[string]any {
+	switch v := v.(type) {
+	case map[string]any:
+		return []map[string]any{v}
+	case []any:
+		var items []map[string]any
+		for _, item := range v {
+			if m, ok := item.(map[string]any); ok {
+				items = append(items, m)
+			}
+		}
+		return items
+	}
+	return nil
+}
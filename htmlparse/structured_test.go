@@ -0,0 +1,125 @@
+package htmlparse
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestMetadata_Favicon(t *testing.T) {
+	doc, _ := Parse(`<html><head>
+		<link rel="shortcut icon" href="/favicon.ico">
+		<link rel="icon" type="image/png" sizes="16x16" href="/16.png">
+		<link rel="icon" type="image/png" sizes="32x32 48x48" href="/32.png">
+		<link rel="apple-touch-icon" sizes="180x180" href="/180.png">
+		<link rel="mask-icon" href="/mask.svg">
+	</head></html>`)
+	m := doc.Metadata()
+	assert.Equal(t, 5, len(m.Icons))
+	assert.Equal(t, Icon{URL: "/32.png", Rel: "icon", Type: "image/png", Sizes: []int{32, 48}}, m.Icons[2])
+
+	icon, ok := m.Favicon(16)
+	assert.True(t, ok)
+	assert.Equal(t, "/16.png", icon.URL)
+	icon, _ = m.Favicon(40)
+	assert.Equal(t, "/32.png", icon.URL)
+	icon, _ = m.Favicon(512)
+	assert.Equal(t, "/180.png", icon.URL)
+
+	doc, _ = Parse(`<link rel="icon" href="/favicon.ico"><link rel="icon" sizes="any" href="/icon.svg">`)
+	icon, _ = doc.Metadata().Favicon(64)
+	assert.Equal(t, "/icon.svg", icon.URL)
+
+	doc, _ = Parse(`<title>No icons</title>`)
+	_, ok = doc.Metadata().Favicon(16)
+	assert.False(t, ok)
+}
+
+func TestMetadata_JSONLD(t *testing.T) {
+	doc, _ := Parse(`<html><head>
+		<script type="application/ld+json">{
+			"@context": "https://schema.org",
+			"@graph": [
+				{"@type": "WebPage", "name": "Post", "breadcrumb": {
+					"@type": "BreadcrumbList",
+					"itemListElement": [
+						{"@type": "ListItem", "position": 2, "name": "Blog", "item": "https://example.com/blog"},
+						{"@type": "ListItem", "position": 1, "name": "Home", "item": {"@id": "https://example.com/"}}
+					]
+				}},
+				{"@type": ["BlogPosting", "CreativeWork"], "headline": "Hello",
+				 "author": [{"@type": "Person", "name": "Ada"}, "Grace"],
+				 "publisher": {"@type": "Organization", "name": "Example"},
+				 "datePublished": "2024-05-01", "image": {"@type": "ImageObject", "url": "/a.png"}}
+			]
+		}</script>
+		<script type="application/ld+json">{"@type": "Product", "name": "Widget", "brand": {"name": "Acme"},
+			"offers": {"@type": "Offer", "price": 19.99, "priceCurrency": "USD", "availability": "https://schema.org/InStock"},
+			"aggregateRating": {"ratingValue": "4.5", "reviewCount": 12}}</script>
+		<script type="application/ld+json">{not json</script>
+	</head></html>`)
+	sd := doc.Metadata().StructuredData
+	assert.NotNil(t, sd)
+	assert.Equal(t, 3, len(sd.Items))
+	assert.Equal(t, "WebPage", sd.Items[0].Type)
+	assert.Equal(t, "json-ld", sd.Items[0].Source)
+	assert.Equal(t, "BlogPosting", sd.Items[1].Type)
+
+	assert.Equal(t, []Article{{
+		Type:          "BlogPosting",
+		Headline:      "Hello",
+		Authors:       []string{"Ada", "Grace"},
+		Publisher:     "Example",
+		DatePublished: "2024-05-01",
+		Images:        []string{"/a.png"},
+	}}, sd.Articles)
+	assert.Equal(t, []Product{{
+		Name:   "Widget",
+		Brand:  "Acme",
+		Offers: []Offer{{Price: "19.99", Currency: "USD", Availability: "InStock"}},
+		Rating: &Rating{Value: 4.5, Count: 12},
+	}}, sd.Products)
+	assert.Equal(t, [][]Breadcrumb{{
+		{Name: "Home", URL: "https://example.com/", Position: 1},
+		{Name: "Blog", URL: "https://example.com/blog", Position: 2},
+	}}, sd.Breadcrumbs)
+}
+
+func TestMetadata_Microdata(t *testing.T) {
+	doc, _ := Parse(`<html><body>
+		<div itemscope itemtype="https://schema.org/Product">
+			<h1 itemprop="name">Lamp</h1>
+			<img itemprop="image" src="/lamp.jpg">
+			<div itemprop="offers" itemscope itemtype="https://schema.org/Offer">
+				<span itemprop="price" content="25.00">$25</span>
+				<meta itemprop="priceCurrency" content="EUR">
+				<link itemprop="availability" href="https://schema.org/OutOfStock">
+			</div>
+		</div>
+		<article itemscope itemtype="https://schema.org/NewsArticle">
+			<h2 itemprop="headline">Big
+				news</h2>
+			<time itemprop="datePublished" datetime="2024-01-02">Jan 2</time>
+			<span itemprop="author" itemscope itemtype="https://schema.org/Person"><span itemprop="name">Lin</span></span>
+		</article>
+	</body></html>`)
+	sd := doc.Metadata().StructuredData
+	assert.NotNil(t, sd)
+	assert.Equal(t, 2, len(sd.Items))
+	assert.Equal(t, "microdata", sd.Items[0].Source)
+	assert.Equal(t, "Lamp", sd.Items[0].Properties["name"])
+	assert.Equal(t, []Product{{
+		Name:   "Lamp",
+		Images: []string{"/lamp.jpg"},
+		Offers: []Offer{{Price: "25.00", Currency: "EUR", Availability: "OutOfStock"}},
+	}}, sd.Products)
+	assert.Equal(t, []Article{{
+		Type:          "NewsArticle",
+		Headline:      "Big news",
+		Authors:       []string{"Lin"},
+		DatePublished: "2024-01-02",
+	}}, sd.Articles)
+
+	doc, _ = Parse(`<p>Plain page</p>`)
+	assert.Nil(t, doc.Metadata().StructuredData)
+}
@@ -206,6 +206,13 @@ func Convert(htmlContent string) string {
 //	opts := &htmltomd.Options{HeadingStyle: htmltomd.HeadingStyleSetext}
 //	md := htmltomd.ConvertWithOptions(html, opts)
 func ConvertWithOptions(htmlContent string, opts *Options) string {
+	markdown, _, _ := convert(htmlContent, opts)
+	return markdown
+}
+
+// convert converts HTML to Markdown, also returning the parsed document and
+// the tags skipped. The document is nil if the HTML couldn't be parsed.
+func convert(htmlContent string, opts *Options) (string, *html.Node, map[string]bool) {
 	if opts == nil {
 		opts = DefaultOptions()
 	}
@@ -227,7 +234,7 @@ func ConvertWithOptions(htmlContent string, opts *Options) string {
 	doc, err := html.Parse(strings.NewReader(htmlContent))
 	if err != nil {
 		// If parsing fails completely, return cleaned text
-		return cleanText(htmlContent)
+		return cleanText(htmlContent), nil, skipTags
 	}
 
 	c := &converter{
@@ -244,7 +251,7 @@ func ConvertWithOptions(htmlContent string, opts *Options) string {
 		result = result + "\n\n" + strings.Join(c.linkRefs, "\n")
 	}
 
-	return result
+	return result, doc, skipTags
 }
 
 type context struct {
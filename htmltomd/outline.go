@@ -0,0 +1,150 @@
+package htmltomd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// WordsPerMinute is the reading speed ReadingTime assumes for prose.
+const WordsPerMinute = 230
+
+// Heading is a heading of the converted document, for building a table of
+// contents.
+type Heading struct {
+	Level  int    `json:"level"`  // 1 for h1 through 6 for h6
+	Text   string `json:"text"`   // Plain text, whitespace collapsed
+	Anchor string `json:"anchor"` // Fragment that links to it, without the "#"
+}
+
+// Result is the Markdown of a document along with what ConvertDetailed
+// learns about it while converting.
+type Result struct {
+	Markdown    string        `json:"markdown"`
+	Outline     []Heading     `json:"outline,omitempty"` // Headings in document order
+	WordCount   int           `json:"word_count"`        // Words of text, code included
+	ReadingTime time.Duration `json:"reading_time"`      // From WordCount; see ReadingTime
+}
+
+// ConvertDetailed converts HTML to Markdown like ConvertWithOptions, and
+// also returns the document's outline and an estimate of how long it takes
+// to read. Content the options skip is left out of both.
+//
+// A heading's anchor is its id, or the id or name of an anchor element
+// inside it, so links into the original page still work. Headings without
+// one get a slug of their text like GitHub's: "Getting Started" becomes
+// "getting-started", and repeats get "-1", "-2", and so on.
+//
+// Example:
+//
+//	result := htmltomd.ConvertDetailed(html, nil)
+//	fmt.Printf("%d min read\n", int(result.ReadingTime.Minutes()))
+//	for _, h := range result.Outline {
+//	    fmt.Printf("%s- [%s](#%s)\n", strings.Repeat("  ", h.Level-1), h.Text, h.Anchor)
+//	}
+func ConvertDetailed(htmlContent string, opts *Options) Result {
+	markdown, doc, skipTags := convert(htmlContent, opts)
+	result := Result{Markdown: markdown}
+	if doc == nil {
+		result.WordCount = len(strings.Fields(markdown))
+		result.ReadingTime = ReadingTime(result.WordCount)
+		return result
+	}
+
+	slugs := map[string]int{}
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			result.WordCount += len(strings.Fields(n.Data))
+			return
+		case html.ElementNode:
+			tag := strings.ToLower(n.Data)
+			if skipTags[tag] {
+				return
+			}
+			if len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6' {
+				if heading, ok := outlineHeading(n, slugs); ok {
+					result.Outline = append(result.Outline, heading)
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	result.ReadingTime = ReadingTime(result.WordCount)
+	return result
+}
+
+// outlineHeading returns the outline entry of a heading element, or false
+// if it has no text. slugs counts the slugs given so far.
+func outlineHeading(n *html.Node, slugs map[string]int) (Heading, bool) {
+	text := strings.Join(strings.Fields(textContent(n)), " ")
+	if text == "" {
+		return Heading{}, false
+	}
+	h := Heading{Level: int(n.Data[1] - '0'), Text: text, Anchor: getAttr(n, "id")}
+	for c := n.FirstChild; c != nil && h.Anchor == ""; c = c.NextSibling {
+		if c.Type == html.ElementNode && strings.ToLower(c.Data) == "a" {
+			if h.Anchor = getAttr(c, "id"); h.Anchor == "" {
+				h.Anchor = getAttr(c, "name")
+			}
+		}
+	}
+	if h.Anchor == "" {
+		slug := Slug(text)
+		h.Anchor = slug
+		if n := slugs[slug]; n > 0 {
+			h.Anchor = slug + "-" + strconv.Itoa(n)
+		}
+		slugs[slug]++
+	}
+	return h, true
+}
+
+// textContent returns the text inside n.
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(textContent(c))
+	}
+	return sb.String()
+}
+
+// Slug returns the anchor GitHub gives a heading with this text: lower
+// case, spaces as hyphens, and punctuation other than hyphens and
+// underscores removed.
+//
+// Example:
+//
+//	htmltomd.Slug("What's New in v2.0?") // "whats-new-in-v20"
+func Slug(text string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case r == ' ' || r == '-':
+			sb.WriteRune('-')
+		case r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r):
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// ReadingTime estimates how long words take to read at WordsPerMinute,
+// rounded up to a whole minute. It is 0 only for no words.
+func ReadingTime(words int) time.Duration {
+	if words <= 0 {
+		return 0
+	}
+	minutes := (words + WordsPerMinute - 1) / WordsPerMinute
+	return time.Duration(minutes) * time.Minute
+}
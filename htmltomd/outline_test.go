@@ -0,0 +1,48 @@
+package htmltomd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestConvertDetailed(t *testing.T) {
+	html := `<nav><h2>Menu</h2></nav>
+		<h1 id="intro">Intro <em>guide</em></h1>
+		<p>One two three four.</p>
+		<h2><a name="setup"></a>Setup</h2>
+		<h2>Usage &amp; Tips</h2>
+		<h3>Usage &amp; Tips</h3>
+		<h2></h2>
+		<script>var ignored = "words here";</script>`
+	result := ConvertDetailed(html, &Options{SkipTags: []string{"nav"}})
+
+	assert.NotContains(t, result.Markdown, "Menu")
+	assert.Contains(t, result.Markdown, "# Intro *guide*")
+	assert.Equal(t, []Heading{
+		{Level: 1, Text: "Intro guide", Anchor: "intro"},
+		{Level: 2, Text: "Setup", Anchor: "setup"},
+		{Level: 2, Text: "Usage & Tips", Anchor: "usage--tips"},
+		{Level: 3, Text: "Usage & Tips", Anchor: "usage--tips-1"},
+	}, result.Outline)
+	assert.Equal(t, 2+4+1+3+3, result.WordCount)
+	assert.Equal(t, time.Minute, result.ReadingTime)
+}
+
+func TestReadingTime(t *testing.T) {
+	assert.Equal(t, time.Duration(0), ReadingTime(0))
+	assert.Equal(t, time.Minute, ReadingTime(1))
+	assert.Equal(t, time.Minute, ReadingTime(WordsPerMinute))
+	assert.Equal(t, 2*time.Minute, ReadingTime(WordsPerMinute+1))
+
+	long := "<p>" + strings.Repeat("word ", 5*WordsPerMinute) + "</p>"
+	assert.Equal(t, 5*time.Minute, ConvertDetailed(long, nil).ReadingTime)
+}
+
+func TestSlug(t *testing.T) {
+	assert.Equal(t, "whats-new-in-v20", Slug("What's New in v2.0?"))
+	assert.Equal(t, "snake_case-and-kebab-case", Slug(" snake_case and kebab-case "))
+	assert.Equal(t, "café-über", Slug("Café Über"))
+}
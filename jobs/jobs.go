@@ -0,0 +1,652 @@
+// Package jobs provides a persistent job queue for long-running CLI
+// workloads such as crawls and exports.
+//
+// Jobs are named units of work with a JSON payload. Handlers are registered
+// by name and run on a pool of workers. When Options.Path is set, the queue
+// is saved to that file on every change, so a process that crashes or is
+// interrupted picks up where it left off: jobs that were running are queued
+// again when the file is loaded.
+//
+// Basic usage:
+//
+//	q, err := jobs.New(jobs.Options{
+//		Path:        "jobs.json",
+//		Concurrency: 4,
+//		MaxAttempts: 3,
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer q.Close()
+//
+//	q.Register("fetch", func(ctx context.Context, job jobs.Job) error {
+//		var url string
+//		if err := job.Decode(&url); err != nil {
+//			return err
+//		}
+//		return fetchPage(ctx, url)
+//	})
+//
+//	for _, u := range urls {
+//		if _, err := q.Enqueue("fetch", u); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+//
+//	if err := q.Wait(ctx); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Progress is available by polling List or Get (for example from a TUI tick
+// handler with tui.JobsView), or by setting Options.OnUpdate.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when a job ID is unknown.
+	ErrNotFound = errors.New("job not found")
+
+	// ErrInvalidState is returned when an operation doesn't apply to the
+	// job's current state, such as retrying a running job.
+	ErrInvalidState = errors.New("invalid job state")
+
+	// ErrClosed is returned when enqueuing to a closed Queue.
+	ErrClosed = errors.New("job queue closed")
+)
+
+// State is the lifecycle state of a job.
+type State int
+
+const (
+	// Queued jobs are waiting for a free worker.
+	Queued State = iota
+	// Running jobs are being processed by their handler.
+	Running
+	// Completed jobs finished without error.
+	Completed
+	// Failed jobs returned an error on their last attempt. They can be retried.
+	Failed
+	// Canceled jobs were stopped by the user. They can be retried.
+	Canceled
+)
+
+// String returns the lowercase state name.
+func (s State) String() string {
+	switch s {
+	case Queued:
+		return "queued"
+	case Running:
+		return "running"
+	case Completed:
+		return "completed"
+	case Failed:
+		return "failed"
+	case Canceled:
+		return "canceled"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// Finished reports whether the state is terminal (completed, failed, or canceled).
+func (s State) Finished() bool {
+	return s == Completed || s == Failed || s == Canceled
+}
+
+// MarshalText encodes the state by name, keeping state files readable.
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a state name written by MarshalText.
+func (s *State) UnmarshalText(text []byte) error {
+	for _, st := range []State{Queued, Running, Completed, Failed, Canceled} {
+		if st.String() == string(text) {
+			*s = st
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown job state %q", text)
+}
+
+// Job is a snapshot of a job.
+type Job struct {
+	ID      int             `json:"id"`
+	Name    string          `json:"name"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+
+	State State `json:"state"`
+
+	// Attempts counts how many times the handler has been started.
+	Attempts int `json:"attempts"`
+
+	// Err is the error message of the last failed attempt.
+	Err string `json:"error,omitempty"`
+
+	// Added, Started, and Finished record when the job was enqueued, when
+	// its latest attempt began, and when it reached a terminal state.
+	Added    time.Time `json:"added"`
+	Started  time.Time `json:"started,omitzero"`
+	Finished time.Time `json:"finished,omitzero"`
+}
+
+// Decode unmarshals the job's payload into v.
+func (j Job) Decode(v any) error {
+	if len(j.Payload) == 0 {
+		return errors.New("job has no payload")
+	}
+	return json.Unmarshal(j.Payload, v)
+}
+
+// Elapsed returns how long the latest attempt ran, or has been running.
+func (j Job) Elapsed() time.Duration {
+	switch {
+	case j.Started.IsZero():
+		return 0
+	case j.State == Running:
+		return time.Since(j.Started)
+	case j.Finished.IsZero():
+		return 0
+	default:
+		return j.Finished.Sub(j.Started)
+	}
+}
+
+// Sort orders jobs for display: running jobs first, then queued jobs in the
+// order they will run, then finished jobs, most recently finished first.
+func Sort(list []Job) {
+	group := func(s State) int {
+		switch s {
+		case Running:
+			return 0
+		case Queued:
+			return 1
+		default:
+			return 2
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		gi, gj := group(list[i].State), group(list[j].State)
+		if gi != gj {
+			return gi < gj
+		}
+		if gi == 2 {
+			return list[i].Finished.After(list[j].Finished)
+		}
+		return false
+	})
+}
+
+// Handler processes a job. It should return promptly once ctx is canceled,
+// which happens when the job is canceled or the queue is closed.
+type Handler func(ctx context.Context, job Job) error
+
+// Options configures a Queue.
+type Options struct {
+	// Concurrency is the maximum number of jobs running at once.
+	// Defaults to 1.
+	Concurrency int
+
+	// MaxAttempts is how many times a failing job is run before it is
+	// marked Failed. Defaults to 1 (no retries).
+	MaxAttempts int
+
+	// Path is the file the queue is saved to. If it exists when the queue
+	// is created, its jobs are loaded and unfinished ones are resumed.
+	// If empty, the queue is kept in memory only.
+	Path string
+
+	// OnUpdate, if set, is called with a snapshot whenever a job changes
+	// state. It is called from worker goroutines and must not block.
+	OnUpdate func(Job)
+}
+
+// Queue stores and runs jobs. All methods are safe for concurrent use.
+type Queue struct {
+	opts Options
+
+	mu       sync.Mutex
+	items    map[int]*item
+	order    []int
+	handlers map[string]Handler
+	nextID   int
+	closed   bool
+	saveErr  error
+	changed  chan struct{}
+	wg       sync.WaitGroup
+}
+
+type item struct {
+	job      Job
+	cancel   context.CancelFunc
+	stopping bool // set when Cancel was called mid-run
+}
+
+// stateFile is the on-disk form of a Queue.
+type stateFile struct {
+	NextID int   `json:"next_id"`
+	Jobs   []Job `json:"jobs"`
+}
+
+// New creates a job queue. When opts.Path names an existing state file, its
+// jobs are loaded; jobs that were running when the file was last saved are
+// queued again. Jobs only start once a handler for their name is registered.
+func New(opts Options) (*Queue, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 1
+	}
+	q := &Queue{
+		opts:     opts,
+		items:    make(map[int]*item),
+		handlers: make(map[string]Handler),
+		nextID:   1,
+		changed:  make(chan struct{}),
+	}
+	if opts.Path == "" {
+		return q, nil
+	}
+
+	data, err := os.ReadFile(opts.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state stateFile
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("jobs: reading %s: %w", opts.Path, err)
+	}
+	for _, job := range state.Jobs {
+		if job.State == Running {
+			job.State = Queued
+		}
+		q.items[job.ID] = &item{job: job}
+		q.order = append(q.order, job.ID)
+		if job.ID >= q.nextID {
+			q.nextID = job.ID + 1
+		}
+	}
+	if state.NextID > q.nextID {
+		q.nextID = state.NextID
+	}
+	return q, nil
+}
+
+// Register sets the handler for jobs with the given name and starts any
+// that are queued.
+func (q *Queue) Register(name string, h Handler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[name] = h
+	q.scheduleLocked()
+}
+
+// Enqueue adds a job with the given name and payload and returns its ID.
+// The payload is encoded as JSON so it can be saved; pass a
+// json.RawMessage to store encoded JSON as-is. The job starts as soon as a
+// worker is free and a handler for name is registered. The error reports
+// an invalid payload or a failure to save the queue; in the latter case the
+// job is still queued.
+func (q *Queue) Enqueue(name string, payload any) (int, error) {
+	if name == "" {
+		return 0, errors.New("job name is required")
+	}
+	var raw json.RawMessage
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return 0, fmt.Errorf("jobs: encoding payload: %w", err)
+		}
+		raw = data
+	}
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return 0, ErrClosed
+	}
+	id := q.nextID
+	q.nextID++
+	it := &item{job: Job{
+		ID:      id,
+		Name:    name,
+		Payload: raw,
+		State:   Queued,
+		Added:   time.Now(),
+	}}
+	q.items[id] = it
+	q.order = append(q.order, id)
+	snap := it.job
+	q.scheduleLocked()
+	err := q.saveLocked()
+	q.notifyLocked()
+	q.mu.Unlock()
+
+	q.report(snap)
+	return id, err
+}
+
+// Get returns a snapshot of the job with the given ID.
+func (q *Queue) Get(id int) (Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	it, ok := q.items[id]
+	if !ok {
+		return Job{}, false
+	}
+	return it.job, true
+}
+
+// List returns snapshots of all jobs in the order they were enqueued.
+func (q *Queue) List() []Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	list := make([]Job, 0, len(q.order))
+	for _, id := range q.order {
+		list = append(list, q.items[id].job)
+	}
+	return list
+}
+
+// Cancel stops a queued or running job. A running job's context is
+// canceled and the job is marked Canceled once its handler returns.
+func (q *Queue) Cancel(id int) error {
+	q.mu.Lock()
+	it, ok := q.items[id]
+	if !ok {
+		q.mu.Unlock()
+		return ErrNotFound
+	}
+	switch it.job.State {
+	case Running:
+		// The worker finalizes the state when the handler returns
+		it.stopping = true
+		it.cancel()
+		q.mu.Unlock()
+		return nil
+	case Queued:
+		it.job.State = Canceled
+		it.job.Finished = time.Now()
+	default:
+		q.mu.Unlock()
+		return ErrInvalidState
+	}
+	snap := it.job
+	q.saveLocked()
+	q.notifyLocked()
+	q.mu.Unlock()
+
+	q.report(snap)
+	return nil
+}
+
+// Retry queues a failed or canceled job again with a fresh set of attempts.
+func (q *Queue) Retry(id int) error {
+	q.mu.Lock()
+	it, ok := q.items[id]
+	if !ok {
+		q.mu.Unlock()
+		return ErrNotFound
+	}
+	if it.job.State != Failed && it.job.State != Canceled {
+		q.mu.Unlock()
+		return ErrInvalidState
+	}
+	it.job.State = Queued
+	it.job.Attempts = 0
+	it.job.Err = ""
+	it.job.Finished = time.Time{}
+	snap := it.job
+	q.scheduleLocked()
+	q.saveLocked()
+	q.notifyLocked()
+	q.mu.Unlock()
+
+	q.report(snap)
+	return nil
+}
+
+// Remove forgets a job that is not running.
+func (q *Queue) Remove(id int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	it, ok := q.items[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if it.job.State == Running {
+		return ErrInvalidState
+	}
+	delete(q.items, id)
+	for i, oid := range q.order {
+		if oid == id {
+			q.order = append(q.order[:i], q.order[i+1:]...)
+			break
+		}
+	}
+	q.saveLocked()
+	q.notifyLocked()
+	return nil
+}
+
+// Prune removes all completed jobs and returns how many were removed.
+func (q *Queue) Prune() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	kept := q.order[:0]
+	removed := 0
+	for _, id := range q.order {
+		if q.items[id].job.State == Completed {
+			delete(q.items, id)
+			removed++
+			continue
+		}
+		kept = append(kept, id)
+	}
+	q.order = kept
+	if removed > 0 {
+		q.saveLocked()
+		q.notifyLocked()
+	}
+	return removed
+}
+
+// Wait blocks until no jobs are queued or running, or ctx is done. Queued
+// jobs whose name has no registered handler keep Wait blocked.
+func (q *Queue) Wait(ctx context.Context) error {
+	for {
+		q.mu.Lock()
+		busy := false
+		for _, it := range q.items {
+			if it.job.State == Queued || it.job.State == Running {
+				busy = true
+				break
+			}
+		}
+		changed := q.changed
+		q.mu.Unlock()
+
+		if !busy {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// Close stops running jobs, waits for their handlers to return, and saves
+// them as queued so they run again when the queue is next loaded. Further
+// calls to Enqueue fail with ErrClosed. It returns the last error from
+// saving the queue, if any.
+func (q *Queue) Close() error {
+	q.mu.Lock()
+	q.closed = true
+	for _, it := range q.items {
+		if it.job.State == Running {
+			it.cancel()
+		}
+	}
+	q.notifyLocked()
+	q.mu.Unlock()
+
+	q.wg.Wait()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.saveLocked()
+	return q.saveErr
+}
+
+// scheduleLocked starts queued jobs while workers are free. The caller must
+// hold q.mu.
+func (q *Queue) scheduleLocked() {
+	if q.closed {
+		return
+	}
+	running := 0
+	for _, it := range q.items {
+		if it.job.State == Running {
+			running++
+		}
+	}
+	for _, id := range q.order {
+		if running >= q.opts.Concurrency {
+			return
+		}
+		it := q.items[id]
+		h, ok := q.handlers[it.job.Name]
+		if it.job.State != Queued || !ok {
+			continue
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		it.cancel = cancel
+		it.stopping = false
+		it.job.State = Running
+		it.job.Attempts++
+		it.job.Started = time.Now()
+		running++
+		q.wg.Add(1)
+		go q.run(ctx, it, h)
+	}
+}
+
+// saveLocked writes the queue to Options.Path, replacing the file
+// atomically so a crash never leaves it half written. The caller must hold
+// q.mu.
+func (q *Queue) saveLocked() error {
+	if q.opts.Path == "" {
+		return nil
+	}
+	state := stateFile{NextID: q.nextID, Jobs: make([]Job, 0, len(q.order))}
+	for _, id := range q.order {
+		state.Jobs = append(state.Jobs, q.items[id].job)
+	}
+	err := writeFileAtomic(q.opts.Path, state)
+	if err != nil {
+		q.saveErr = err
+	}
+	return err
+}
+
+func writeFileAtomic(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// notifyLocked wakes goroutines blocked in Wait. The caller must hold q.mu.
+func (q *Queue) notifyLocked() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+func (q *Queue) report(j Job) {
+	if q.opts.OnUpdate != nil {
+		q.opts.OnUpdate(j)
+	}
+}
+
+// run runs one attempt of a job and records its outcome.
+func (q *Queue) run(ctx context.Context, it *item, h Handler) {
+	defer q.wg.Done()
+	q.mu.Lock()
+	job := it.job
+	q.mu.Unlock()
+	q.report(job)
+
+	err := safeRun(ctx, h, job)
+
+	q.mu.Lock()
+	it.cancel()
+	switch {
+	case it.stopping:
+		it.job.State = Canceled
+		it.job.Finished = time.Now()
+	case q.closed:
+		// Interrupted by Close: run again next time the queue is loaded
+		it.job.State = Queued
+		it.job.Attempts--
+	case err == nil:
+		it.job.State = Completed
+		it.job.Err = ""
+		it.job.Finished = time.Now()
+	case it.job.Attempts < q.opts.MaxAttempts:
+		it.job.State = Queued
+		it.job.Err = err.Error()
+	default:
+		it.job.State = Failed
+		it.job.Err = err.Error()
+		it.job.Finished = time.Now()
+	}
+	snap := it.job
+	q.scheduleLocked()
+	q.saveLocked()
+	q.notifyLocked()
+	q.mu.Unlock()
+
+	q.report(snap)
+}
+
+// safeRun calls h, turning a panic into an error so one bad job doesn't
+// take down the process.
+func safeRun(ctx context.Context, h Handler, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return h(ctx, job)
+}
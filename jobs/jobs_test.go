@@ -0,0 +1,263 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestQueue_Run(t *testing.T) {
+	q, err := New(Options{Concurrency: 2})
+	assert.NoError(t, err)
+	defer q.Close()
+
+	var mu sync.Mutex
+	var got []string
+	q.Register("echo", func(ctx context.Context, job Job) error {
+		var s string
+		if err := job.Decode(&s); err != nil {
+			return err
+		}
+		mu.Lock()
+		got = append(got, s)
+		mu.Unlock()
+		return nil
+	})
+
+	id, err := q.Enqueue("echo", "hello")
+	assert.NoError(t, err)
+	_, err = q.Enqueue("echo", "world")
+	assert.NoError(t, err)
+	assert.NoError(t, q.Wait(context.Background()))
+
+	job, ok := q.Get(id)
+	assert.True(t, ok)
+	assert.Equal(t, Completed, job.State)
+	assert.Equal(t, 1, job.Attempts)
+	assert.Equal(t, `"hello"`, string(job.Payload))
+	assert.False(t, job.Finished.IsZero())
+	sort.Strings(got)
+	assert.Equal(t, []string{"hello", "world"}, got)
+}
+
+func TestQueue_WaitsForHandler(t *testing.T) {
+	q, err := New(Options{})
+	assert.NoError(t, err)
+	defer q.Close()
+
+	id, err := q.Enqueue("later", nil)
+	assert.NoError(t, err)
+	job, _ := q.Get(id)
+	assert.Equal(t, Queued, job.State)
+
+	q.Register("later", func(ctx context.Context, job Job) error { return nil })
+	assert.NoError(t, q.Wait(context.Background()))
+	job, _ = q.Get(id)
+	assert.Equal(t, Completed, job.State)
+}
+
+func TestQueue_Retries(t *testing.T) {
+	q, err := New(Options{MaxAttempts: 3})
+	assert.NoError(t, err)
+	defer q.Close()
+
+	calls := 0
+	q.Register("flaky", func(ctx context.Context, job Job) error {
+		calls++
+		if calls < 2 {
+			return errors.New("try again")
+		}
+		return nil
+	})
+	q.Register("broken", func(ctx context.Context, job Job) error {
+		panic("boom")
+	})
+
+	flaky, _ := q.Enqueue("flaky", nil)
+	broken, _ := q.Enqueue("broken", nil)
+	assert.NoError(t, q.Wait(context.Background()))
+
+	job, _ := q.Get(flaky)
+	assert.Equal(t, Completed, job.State)
+	assert.Equal(t, 2, job.Attempts)
+	assert.Equal(t, "", job.Err)
+
+	job, _ = q.Get(broken)
+	assert.Equal(t, Failed, job.State)
+	assert.Equal(t, 3, job.Attempts)
+	assert.Equal(t, "panic: boom", job.Err)
+
+	assert.Equal(t, ErrInvalidState, q.Retry(flaky))
+	assert.NoError(t, q.Retry(broken))
+	assert.NoError(t, q.Wait(context.Background()))
+	job, _ = q.Get(broken)
+	assert.Equal(t, Failed, job.State)
+	assert.Equal(t, 3, job.Attempts)
+}
+
+func TestQueue_Cancel(t *testing.T) {
+	q, err := New(Options{})
+	assert.NoError(t, err)
+	defer q.Close()
+
+	started := make(chan struct{})
+	q.Register("block", func(ctx context.Context, job Job) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	q.Register("other", func(ctx context.Context, job Job) error { return nil })
+
+	running, _ := q.Enqueue("block", nil)
+	queued, _ := q.Enqueue("other", nil)
+	<-started
+
+	assert.NoError(t, q.Cancel(queued))
+	assert.NoError(t, q.Cancel(running))
+	assert.NoError(t, q.Wait(context.Background()))
+
+	job, _ := q.Get(running)
+	assert.Equal(t, Canceled, job.State)
+	job, _ = q.Get(queued)
+	assert.Equal(t, Canceled, job.State)
+	assert.Equal(t, 0, job.Attempts)
+
+	assert.Equal(t, ErrInvalidState, q.Cancel(queued))
+	assert.Equal(t, ErrNotFound, q.Cancel(99))
+	assert.NoError(t, q.Remove(queued))
+	_, ok := q.Get(queued)
+	assert.False(t, ok)
+}
+
+func TestQueue_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "jobs.json")
+	q, err := New(Options{Path: path})
+	assert.NoError(t, err)
+
+	started := make(chan struct{})
+	q.Register("crawl", func(ctx context.Context, job Job) error {
+		if job.ID == 2 {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	})
+	done, _ := q.Enqueue("crawl", map[string]string{"url": "https://example.com"})
+	assert.NoError(t, q.Wait(context.Background()))
+	interrupted, _ := q.Enqueue("crawl", map[string]string{"url": "https://example.org"})
+	<-started
+	assert.NoError(t, q.Close())
+
+	_, err = q.Enqueue("crawl", nil)
+	assert.Equal(t, ErrClosed, err)
+
+	// A new process picks up the interrupted job
+	q2, err := New(Options{Path: path})
+	assert.NoError(t, err)
+	defer q2.Close()
+
+	jobs := q2.List()
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, done, jobs[0].ID)
+	assert.Equal(t, Completed, jobs[0].State)
+	assert.Equal(t, interrupted, jobs[1].ID)
+	assert.Equal(t, Queued, jobs[1].State)
+	assert.Equal(t, 0, jobs[1].Attempts)
+
+	var payload struct{ URL string }
+	assert.NoError(t, jobs[1].Decode(&payload))
+	assert.Equal(t, "https://example.org", payload.URL)
+
+	id, err := q2.Enqueue("crawl", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, id)
+
+	q2.Register("crawl", func(ctx context.Context, job Job) error { return nil })
+	assert.NoError(t, q2.Wait(context.Background()))
+	job, _ := q2.Get(interrupted)
+	assert.Equal(t, Completed, job.State)
+	assert.Equal(t, 3, q2.Prune())
+	assert.Len(t, q2.List(), 0)
+}
+
+func TestQueue_ResumesRunningJobs(t *testing.T) {
+	// A crashed process leaves running jobs in the file
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	assert.NoError(t, writeFileAtomic(path, stateFile{
+		NextID: 8,
+		Jobs:   []Job{{ID: 7, Name: "export", State: Running, Attempts: 1}},
+	}))
+
+	q, err := New(Options{Path: path, MaxAttempts: 2})
+	assert.NoError(t, err)
+	defer q.Close()
+	job, _ := q.Get(7)
+	assert.Equal(t, Queued, job.State)
+
+	var attempts int
+	q.Register("export", func(ctx context.Context, job Job) error {
+		attempts = job.Attempts
+		return nil
+	})
+	assert.NoError(t, q.Wait(context.Background()))
+	assert.Equal(t, 2, attempts)
+}
+
+func TestSort(t *testing.T) {
+	now := time.Now()
+	list := []Job{
+		{ID: 1, State: Completed, Finished: now.Add(-time.Minute)},
+		{ID: 2, State: Queued},
+		{ID: 3, State: Failed, Finished: now},
+		{ID: 4, State: Running},
+		{ID: 5, State: Queued},
+	}
+	Sort(list)
+	var ids []int
+	for _, j := range list {
+		ids = append(ids, j.ID)
+	}
+	assert.Equal(t, []int{4, 2, 5, 3, 1}, ids)
+}
+
+func TestOnUpdate(t *testing.T) {
+	var mu sync.Mutex
+	var states []State
+	q, err := New(Options{OnUpdate: func(j Job) {
+		mu.Lock()
+		states = append(states, j.State)
+		mu.Unlock()
+	}})
+	assert.NoError(t, err)
+	defer q.Close()
+
+	q.Register("noop", func(ctx context.Context, job Job) error { return nil })
+	_, err = q.Enqueue("noop", nil)
+	assert.NoError(t, err)
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(states) == 3
+	})
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Contains(t, states, Completed)
+}
@@ -0,0 +1,229 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// defaultIterations is the PBKDF2 iteration count for new files, following
+// the OWASP recommendation for PBKDF2-HMAC-SHA256.
+const defaultIterations = 600_000
+
+// FileStore keeps secrets in a file encrypted with AES-256-GCM, under a key
+// derived from a passphrase with PBKDF2-HMAC-SHA256. It is the fallback for
+// systems without a keyring.
+//
+// The whole file is decrypted to read a secret and re-encrypted to change
+// one, so it suits the handful of credentials a tool needs. The file is
+// written atomically with 0600 permissions.
+type FileStore struct {
+	path       string
+	passphrase func() (string, error)
+	iterations int
+
+	mu   sync.Mutex
+	salt []byte // salt the key was derived with
+	key  []byte
+}
+
+// fileFormat is the JSON layout of a FileStore's file. Data is the
+// encrypted JSON of the secrets, as map[service]map[key]value.
+type fileFormat struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+// NewFileStore creates a store backed by the file at path, which is created
+// when the first secret is set. passphrase is called once, the first time
+// the file is read or written.
+func NewFileStore(path string, passphrase func() (string, error)) *FileStore {
+	return &FileStore{path: path, passphrase: passphrase, iterations: defaultIterations}
+}
+
+// Path returns the path of the store's file.
+func (s *FileStore) Path() string {
+	return s.path
+}
+
+// Get returns the secret, or ErrNotFound.
+func (s *FileStore) Get(service, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	value, ok := all[service][key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+// Set stores the secret, replacing any previous value.
+func (s *FileStore) Set(service, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	if all[service] == nil {
+		all[service] = map[string]string{}
+	}
+	all[service][key] = value
+	return s.save(all)
+}
+
+// Delete removes the secret, or returns ErrNotFound.
+func (s *FileStore) Delete(service, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[service][key]; !ok {
+		return ErrNotFound
+	}
+	delete(all[service], key)
+	if len(all[service]) == 0 {
+		delete(all, service)
+	}
+	return s.save(all)
+}
+
+// load decrypts the file. A missing file holds no secrets.
+func (s *FileStore) load() (map[string]map[string]string, error) {
+	all := map[string]map[string]string{}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f fileFormat
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("secrets: invalid file %s: %w", s.path, err)
+	}
+	if f.Version != 1 || f.KDF != "pbkdf2-sha256" {
+		return nil, fmt.Errorf("secrets: unsupported file %s (version %d, kdf %q)", s.path, f.Version, f.KDF)
+	}
+	gcm, err := s.cipher(f.Salt, f.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, f.Nonce, f.Data, f.Salt)
+	if err != nil {
+		s.key = nil // let the next call ask again
+		return nil, ErrBadPassphrase
+	}
+	if err := json.Unmarshal(plain, &all); err != nil {
+		return nil, fmt.Errorf("secrets: invalid file %s: %w", s.path, err)
+	}
+	return all, nil
+}
+
+// save encrypts all with a fresh nonce and replaces the file.
+func (s *FileStore) save(all map[string]map[string]string) error {
+	salt := s.salt
+	if s.key == nil {
+		salt = make([]byte, 16)
+		rand.Read(salt)
+	}
+	gcm, err := s.cipher(salt, s.iterations)
+	if err != nil {
+		return err
+	}
+	plain, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	rand.Read(nonce)
+	data, err := json.MarshalIndent(fileFormat{
+		Version:    1,
+		KDF:        "pbkdf2-sha256",
+		Iterations: s.iterations,
+		Salt:       salt,
+		Nonce:      nonce,
+		Data:       gcm.Seal(nil, nonce, plain, salt),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, append(data, '\n'))
+}
+
+// cipher returns the AES-GCM cipher for a file with the given salt,
+// deriving the key unless it was derived for that salt already.
+func (s *FileStore) cipher(salt []byte, iterations int) (cipher.AEAD, error) {
+	if s.key == nil || string(salt) != string(s.salt) {
+		if s.passphrase == nil {
+			return nil, errors.New("secrets: no passphrase")
+		}
+		passphrase, err := s.passphrase()
+		if err != nil {
+			return nil, err
+		}
+		if passphrase == "" {
+			return nil, errors.New("secrets: empty passphrase")
+		}
+		key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+		if err != nil {
+			return nil, err
+		}
+		s.key, s.salt = key, salt
+		s.iterations = iterations
+	}
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// writeFileAtomic replaces path with data, readable by the owner only.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".secrets-*")
+	if err != nil {
+		return err
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
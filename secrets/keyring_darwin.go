@@ -0,0 +1,55 @@
+//go:build darwin
+
+package secrets
+
+import (
+	"encoding/hex"
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// errSecItemNotFound is the exit code of the security command when no
+// keychain item matches.
+const errSecItemNotFound = 44
+
+func keyringAvailable() bool {
+	_, err := exec.LookPath("security")
+	return err == nil
+}
+
+// keyringGet reads a generic password from the login keychain.
+func keyringGet(service, key string) (string, error) {
+	out, err := runCommand("", "security", "find-generic-password", "-s", service, "-a", key, "-w")
+	if err != nil {
+		return "", keychainError(err)
+	}
+	return strings.TrimSuffix(out, "\n"), nil
+}
+
+// keyringSet adds or updates a generic password. The command is passed on
+// stdin, with the secret hex-encoded, so the secret never appears in the
+// process list.
+func keyringSet(service, key, value string) error {
+	cmd := "add-generic-password -U -s " + quote(service) + " -a " + quote(key) + " -X " + hex.EncodeToString([]byte(value)) + "\n"
+	_, err := runCommand(cmd, "security", "-i")
+	return err
+}
+
+func keyringDelete(service, key string) error {
+	_, err := runCommand("", "security", "delete-generic-password", "-s", service, "-a", key)
+	return keychainError(err)
+}
+
+func keychainError(err error) error {
+	var cmdErr *commandError
+	if errors.As(err, &cmdErr) && cmdErr.code == errSecItemNotFound {
+		return ErrNotFound
+	}
+	return err
+}
+
+// quote quotes an argument for the security command's interactive mode.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,51 @@
+//go:build darwin || linux
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// commandError is a keyring helper's failure, with its exit code and what it
+// wrote to stderr.
+type commandError struct {
+	name   string
+	code   int
+	stderr string
+}
+
+func (e *commandError) Error() string {
+	if e.stderr != "" {
+		return "secrets: " + e.name + ": " + e.stderr
+	}
+	return "secrets: " + e.name + " failed"
+}
+
+// runCommand runs a keyring helper with stdin as its input and returns its
+// output.
+func runCommand(stdin string, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), keyringTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", errors.New("secrets: " + name + " timed out")
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return "", &commandError{name: name, code: exitErr.ExitCode(), stderr: strings.TrimSpace(stderr.String())}
+	}
+	if err != nil {
+		return "", err
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,48 @@
+//go:build linux
+
+package secrets
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// keyringAvailable reports whether secret-tool is installed and there is a
+// D-Bus session to reach the Secret Service through.
+func keyringAvailable() bool {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return false
+	}
+	return os.Getenv("DBUS_SESSION_BUS_ADDRESS") != ""
+}
+
+// keyringGet looks up a secret in the Secret Service.
+func keyringGet(service, key string) (string, error) {
+	out, err := runCommand("", "secret-tool", "lookup", "service", service, "account", key)
+	var cmdErr *commandError
+	// secret-tool exits with 1 and says nothing when there is no match
+	if errors.As(err, &cmdErr) && cmdErr.code == 1 && cmdErr.stderr == "" {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+// keyringSet stores a secret, which secret-tool reads from stdin.
+func keyringSet(service, key, value string) error {
+	_, err := runCommand(value, "secret-tool", "store", "--label", service+" ("+key+")", "service", service, "account", key)
+	return err
+}
+
+// keyringDelete clears a secret. secret-tool succeeds whether or not there
+// was one, so look it up first.
+func keyringDelete(service, key string) error {
+	if _, err := keyringGet(service, key); err != nil {
+		return err
+	}
+	_, err := runCommand("", "secret-tool", "clear", "service", service, "account", key)
+	return err
+}
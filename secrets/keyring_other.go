@@ -0,0 +1,19 @@
+//go:build !darwin && !linux && !windows
+
+package secrets
+
+func keyringAvailable() bool {
+	return false
+}
+
+func keyringGet(service, key string) (string, error) {
+	return "", ErrUnavailable
+}
+
+func keyringSet(service, key, value string) error {
+	return ErrUnavailable
+}
+
+func keyringDelete(service, key string) error {
+	return ErrUnavailable
+}
@@ -0,0 +1,48 @@
+package secrets
+
+import "sync"
+
+// MemoryStore keeps secrets in memory, for tests of code that takes a Store.
+// The zero value is ready to use.
+type MemoryStore struct {
+	mu      sync.Mutex
+	secrets map[[2]string]string
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Get returns the secret, or ErrNotFound.
+func (s *MemoryStore) Get(service, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.secrets[[2]string{service, key}]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+// Set stores the secret, replacing any previous value.
+func (s *MemoryStore) Set(service, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.secrets == nil {
+		s.secrets = map[[2]string]string{}
+	}
+	s.secrets[[2]string{service, key}] = value
+	return nil
+}
+
+// Delete removes the secret, or returns ErrNotFound.
+func (s *MemoryStore) Delete(service, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.secrets[[2]string{service, key}]; !ok {
+		return ErrNotFound
+	}
+	delete(s.secrets, [2]string{service, key})
+	return nil
+}
@@ -0,0 +1,164 @@
+// Package secrets stores API tokens and other credentials in the operating
+// system's keyring, so tools never have to keep them in plaintext files.
+//
+// # Basic Usage
+//
+// Open returns the system keyring when one is available, and an encrypted
+// file otherwise:
+//
+//	store, err := secrets.Open("mytool", secrets.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	if err := store.Set("mytool", "github-token", token); err != nil {
+//		return err
+//	}
+//	token, err := store.Get("mytool", "github-token")
+//	if errors.Is(err, secrets.ErrNotFound) {
+//		// First run: ask for the token
+//	}
+//
+// Secrets are addressed by a service (usually the tool's name) and a key
+// within it.
+//
+// # Platform Support
+//
+//   - macOS: the login Keychain, through the security command
+//   - Linux: the Secret Service (GNOME Keyring, KWallet), through secret-tool
+//   - Windows: the Credential Manager
+//   - Elsewhere, or when the keyring isn't reachable (such as over SSH on a
+//     headless Linux machine): an AES-GCM encrypted file, see FileStore
+//
+// # First-Time Setup
+//
+// The cli package's Context.Secret returns a stored secret, or prompts for
+// it and stores it the first time:
+//
+//	token, err := ctx.Secret(store, "mytool", "github-token", "GitHub token: ")
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrNotFound is returned when no secret is stored for a service and key.
+var ErrNotFound = errors.New("secrets: secret not found")
+
+// ErrUnavailable is returned by the keyring when the system has none, or it
+// can't be reached.
+var ErrUnavailable = errors.New("secrets: keyring not available on this system")
+
+// ErrBadPassphrase is returned by a FileStore when its file can't be
+// decrypted with the passphrase it was given.
+var ErrBadPassphrase = errors.New("secrets: wrong passphrase or corrupt file")
+
+// PassphraseEnv is the environment variable a FileStore opened by Open reads
+// its passphrase from when Options.Passphrase is nil.
+const PassphraseEnv = "WONTON_SECRETS_PASSPHRASE"
+
+// keyringTimeout bounds each call to a keyring helper command.
+const keyringTimeout = 10 * time.Second
+
+// Store gets and sets secrets by service and key. Implementations are safe
+// for concurrent use.
+type Store interface {
+	// Get returns the secret, or ErrNotFound.
+	Get(service, key string) (string, error)
+	// Set stores the secret, replacing any previous value.
+	Set(service, key, value string) error
+	// Delete removes the secret, or returns ErrNotFound.
+	Delete(service, key string) error
+}
+
+// Keyring returns the operating system's keyring. Its methods return
+// ErrUnavailable when KeyringAvailable is false.
+func Keyring() Store {
+	return keyring{}
+}
+
+// KeyringAvailable reports whether the system keyring can be used.
+func KeyringAvailable() bool {
+	return keyringAvailable()
+}
+
+type keyring struct{}
+
+func (keyring) Get(service, key string) (string, error) {
+	if !keyringAvailable() {
+		return "", ErrUnavailable
+	}
+	return keyringGet(service, key)
+}
+
+func (keyring) Set(service, key, value string) error {
+	if !keyringAvailable() {
+		return ErrUnavailable
+	}
+	return keyringSet(service, key, value)
+}
+
+func (keyring) Delete(service, key string) error {
+	if !keyringAvailable() {
+		return ErrUnavailable
+	}
+	return keyringDelete(service, key)
+}
+
+// Options configures Open.
+type Options struct {
+	// FilePath is the encrypted file used when there is no keyring.
+	// Defaults to DefaultFilePath(app).
+	FilePath string
+
+	// Passphrase returns the passphrase for the encrypted file. It is called
+	// once, the first time the file is read or written. Defaults to reading
+	// PassphraseEnv.
+	Passphrase func() (string, error)
+
+	// NoKeyring uses the encrypted file even when a keyring is available.
+	NoKeyring bool
+}
+
+// Open returns the system keyring if it is available, and otherwise a
+// FileStore for app.
+func Open(app string, opts Options) (Store, error) {
+	if !opts.NoKeyring && KeyringAvailable() {
+		return Keyring(), nil
+	}
+	path := opts.FilePath
+	if path == "" {
+		var err error
+		if path, err = DefaultFilePath(app); err != nil {
+			return nil, err
+		}
+	}
+	passphrase := opts.Passphrase
+	if passphrase == nil {
+		passphrase = EnvPassphrase(PassphraseEnv)
+	}
+	return NewFileStore(path, passphrase), nil
+}
+
+// DefaultFilePath returns where app's encrypted secrets file is kept:
+// secrets.enc in app's directory under the user's configuration directory.
+func DefaultFilePath(app string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, app, "secrets.enc"), nil
+}
+
+// EnvPassphrase returns a passphrase function that reads the environment
+// variable name.
+func EnvPassphrase(name string) func() (string, error) {
+	return func() (string, error) {
+		if p := os.Getenv(name); p != "" {
+			return p, nil
+		}
+		return "", errors.New("secrets: no keyring available; set " + name + " to encrypt secrets in a file")
+	}
+}
@@ -0,0 +1,116 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+// newTestFileStore creates a FileStore with a cheap key derivation.
+func newTestFileStore(path, passphrase string) (*FileStore, *int) {
+	calls := 0
+	s := NewFileStore(path, func() (string, error) {
+		calls++
+		return passphrase, nil
+	})
+	s.iterations = 1000
+	return s, &calls
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app", "secrets.enc")
+	store, calls := newTestFileStore(path, "hunter2")
+
+	// A missing file holds nothing, and doesn't need the passphrase
+	_, err := store.Get("app", "token")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Equal(t, 0, *calls)
+
+	assert.NoError(t, store.Set("app", "token", "s3cret"))
+	assert.NoError(t, store.Set("app", "user", "ada"))
+	assert.NoError(t, store.Set("other", "token", "x"))
+	assert.Equal(t, 1, *calls)
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	data, _ := os.ReadFile(path)
+	assert.False(t, strings.Contains(string(data), "s3cret"))
+
+	// A new store reads what the first one wrote
+	reopened, calls := newTestFileStore(path, "hunter2")
+	value, err := reopened.Get("app", "token")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+	value, _ = reopened.Get("other", "token")
+	assert.Equal(t, "x", value)
+	assert.Equal(t, 1, *calls)
+
+	assert.NoError(t, reopened.Delete("app", "token"))
+	assert.True(t, errors.Is(reopened.Delete("app", "token"), ErrNotFound))
+	_, err = reopened.Get("app", "token")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	value, _ = reopened.Get("app", "user")
+	assert.Equal(t, "ada", value)
+}
+
+func TestFileStore_WrongPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	store, _ := newTestFileStore(path, "right")
+	assert.NoError(t, store.Set("app", "token", "s3cret"))
+
+	wrong, _ := newTestFileStore(path, "wrong")
+	_, err := wrong.Get("app", "token")
+	assert.True(t, errors.Is(err, ErrBadPassphrase))
+	assert.True(t, errors.Is(wrong.Set("app", "token", "other"), ErrBadPassphrase))
+
+	value, err := store.Get("app", "token")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+}
+
+func TestFileStore_NoPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	store, _ := newTestFileStore(path, "")
+	assert.Error(t, store.Set("app", "token", "s3cret"))
+	_, err := os.Stat(path)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestOpen_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	t.Setenv("TEST_SECRETS_PASSPHRASE", "")
+	store, err := Open("app", Options{
+		FilePath:   path,
+		Passphrase: EnvPassphrase("TEST_SECRETS_PASSPHRASE"),
+		NoKeyring:  true,
+	})
+	assert.NoError(t, err)
+	file, ok := store.(*FileStore)
+	assert.True(t, ok)
+	assert.Equal(t, path, file.Path())
+	file.iterations = 1000
+
+	err = store.Set("app", "token", "s3cret")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "TEST_SECRETS_PASSPHRASE")
+
+	t.Setenv("TEST_SECRETS_PASSPHRASE", "hunter2")
+	assert.NoError(t, store.Set("app", "token", "s3cret"))
+}
+
+func TestMemoryStore(t *testing.T) {
+	var store MemoryStore
+	_, err := store.Get("app", "token")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.NoError(t, store.Set("app", "token", "s3cret"))
+	value, err := store.Get("app", "token")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+	assert.NoError(t, store.Delete("app", "token"))
+	assert.True(t, errors.Is(store.Delete("app", "token"), ErrNotFound))
+}
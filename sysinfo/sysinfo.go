@@ -0,0 +1,277 @@
+// Package sysinfo reads system metrics: CPU time, memory, disks, network
+// interfaces, load, and the process list. It parses the kernel's /proc
+// files directly instead of shelling out or using cgo.
+//
+// # Snapshots
+//
+// The functions CPUTimes, MemoryStats, Disks, NetInterfaces, LoadAverage, and
+// Processes each read the current values once. Counters such as CPU time and
+// bytes received only grow, so most dashboards want rates instead; a Monitor
+// keeps the previous sample and fills them in:
+//
+//	mon := sysinfo.NewMonitor()
+//	for range time.Tick(time.Second) {
+//		s, err := mon.Sample()
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Printf("cpu %.1f%%  mem %.1f%%\n", s.CPU, s.Memory.UsedPercent())
+//	}
+//
+// The first sample has no previous one to compare with, so its rates are
+// zero.
+//
+// # Platform Support
+//
+// Linux is supported. On other platforms every function returns
+// ErrUnsupported.
+package sysinfo
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrUnsupported is returned on platforms where system metrics can't be read.
+var ErrUnsupported = errors.New("sysinfo: unsupported platform")
+
+// CPUTime holds the time a CPU has spent in each state since boot.
+type CPUTime struct {
+	Name    string // "cpu" for the total of all CPUs, or "cpu0", "cpu1", ...
+	User    time.Duration
+	Nice    time.Duration
+	System  time.Duration
+	Idle    time.Duration
+	IOWait  time.Duration
+	IRQ     time.Duration
+	SoftIRQ time.Duration
+	Steal   time.Duration
+}
+
+// Total returns the time spent in all states.
+func (c CPUTime) Total() time.Duration {
+	return c.User + c.Nice + c.System + c.Idle + c.IOWait + c.IRQ + c.SoftIRQ + c.Steal
+}
+
+// Busy returns the time spent doing work, excluding idle and I/O wait.
+func (c CPUTime) Busy() time.Duration {
+	return c.Total() - c.Idle - c.IOWait
+}
+
+// CPUUsage returns the percentage of time a CPU was busy between two
+// readings of its CPUTime.
+func CPUUsage(prev, cur CPUTime) float64 {
+	total := cur.Total() - prev.Total()
+	if total <= 0 {
+		return 0
+	}
+	return percent(float64(cur.Busy()-prev.Busy()), float64(total))
+}
+
+// Memory holds memory and swap sizes in bytes.
+type Memory struct {
+	Total     uint64
+	Available uint64 // Memory available for new programs without swapping
+	Free      uint64 // Memory not used at all, not counting reclaimable caches
+	Cached    uint64 // Page cache and buffers
+	SwapTotal uint64
+	SwapFree  uint64
+}
+
+// Used returns the memory in use, excluding reclaimable caches.
+func (m Memory) Used() uint64 {
+	if m.Available > m.Total {
+		return 0
+	}
+	return m.Total - m.Available
+}
+
+// UsedPercent returns the memory in use as a percentage of the total.
+func (m Memory) UsedPercent() float64 {
+	return percent(float64(m.Used()), float64(m.Total))
+}
+
+// SwapUsed returns the swap space in use.
+func (m Memory) SwapUsed() uint64 {
+	if m.SwapFree > m.SwapTotal {
+		return 0
+	}
+	return m.SwapTotal - m.SwapFree
+}
+
+// Disk describes a mounted filesystem. Sizes are in bytes.
+type Disk struct {
+	Device string // Device path, like "/dev/sda1"
+	Mount  string // Mount point, like "/"
+	FSType string // Filesystem type, like "ext4"
+	Total  uint64
+	Free   uint64 // Space available to unprivileged users
+	Used   uint64
+}
+
+// UsedPercent returns the space in use as a percentage of the space usable
+// by unprivileged users.
+func (d Disk) UsedPercent() float64 {
+	return percent(float64(d.Used), float64(d.Used+d.Free))
+}
+
+// NetInterface holds the traffic counters of a network interface. The rates
+// are filled in by a Monitor.
+type NetInterface struct {
+	Name      string
+	RxBytes   uint64
+	TxBytes   uint64
+	RxPackets uint64
+	TxPackets uint64
+	RxErrors  uint64
+	TxErrors  uint64
+
+	RxRate float64 // Bytes received per second since the previous sample
+	TxRate float64 // Bytes sent per second since the previous sample
+}
+
+// Load holds the system load averages.
+type Load struct {
+	Load1  float64 // Over the last minute
+	Load5  float64 // Over the last 5 minutes
+	Load15 float64 // Over the last 15 minutes
+}
+
+// Process describes a running process.
+type Process struct {
+	PID      int
+	PPID     int
+	Name     string   // Executable name, possibly truncated by the kernel
+	Command  []string // Command line arguments; empty for kernel threads
+	State    string   // Single-letter state, like "R" (running) or "S" (sleeping)
+	UID      int
+	User     string // Name of the user, or the UID if it has none
+	Threads  int
+	RSS      uint64        // Resident memory in bytes
+	VMS      uint64        // Virtual memory in bytes
+	CPUTime  time.Duration // User and system CPU time used so far
+	Started  time.Time
+	CPU      float64 // Percentage of one CPU used since the previous sample, filled in by a Monitor
+	MemShare float64 // RSS as a percentage of total memory, filled in by a Monitor
+}
+
+// Sample holds system metrics read at one time by a Monitor.
+type Sample struct {
+	Time      time.Time
+	CPU       float64   // Busy percentage of all CPUs together
+	Cores     []float64 // Busy percentage of each CPU
+	Memory    Memory
+	Load      Load
+	Uptime    time.Duration
+	Disks     []Disk
+	Network   []NetInterface
+	Processes []Process // Sorted by CPU usage, highest first
+}
+
+// Monitor reads samples of system metrics and computes rates since the
+// previous sample. A Monitor is not safe for concurrent use.
+type Monitor struct {
+	prevTime time.Time
+	prevCPU  []CPUTime
+	prevNet  map[string]NetInterface
+	prevProc map[int]time.Duration
+}
+
+// NewMonitor creates a Monitor.
+func NewMonitor() *Monitor {
+	return &Monitor{}
+}
+
+// Sample reads all metrics. Disks that can't be read are left out; other
+// errors end the sample.
+func (m *Monitor) Sample() (*Sample, error) {
+	now := time.Now()
+	s := &Sample{Time: now}
+
+	cpus, err := CPUTimes()
+	if err != nil {
+		return nil, err
+	}
+	if s.Memory, err = MemoryStats(); err != nil {
+		return nil, err
+	}
+	if s.Load, err = LoadAverage(); err != nil {
+		return nil, err
+	}
+	if s.Uptime, err = Uptime(); err != nil {
+		return nil, err
+	}
+	s.Disks, _ = Disks()
+	if s.Network, err = NetInterfaces(); err != nil {
+		return nil, err
+	}
+	if s.Processes, err = Processes(); err != nil {
+		return nil, err
+	}
+
+	m.fillRates(s, cpus)
+	return s, nil
+}
+
+// fillRates computes the rates of s from the previous sample and keeps the
+// counters of s for the next one.
+func (m *Monitor) fillRates(s *Sample, cpus []CPUTime) {
+	elapsed := s.Time.Sub(m.prevTime).Seconds()
+	first := m.prevTime.IsZero() || elapsed <= 0
+
+	for i, cpu := range cpus {
+		usage := 0.0
+		if !first && i < len(m.prevCPU) && m.prevCPU[i].Name == cpu.Name {
+			usage = CPUUsage(m.prevCPU[i], cpu)
+		}
+		if cpu.Name == "cpu" {
+			s.CPU = usage
+		} else {
+			s.Cores = append(s.Cores, usage)
+		}
+	}
+
+	for i, iface := range s.Network {
+		if prev, ok := m.prevNet[iface.Name]; ok && !first {
+			s.Network[i].RxRate = rate(prev.RxBytes, iface.RxBytes, elapsed)
+			s.Network[i].TxRate = rate(prev.TxBytes, iface.TxBytes, elapsed)
+		}
+	}
+
+	for i, p := range s.Processes {
+		if prev, ok := m.prevProc[p.PID]; ok && !first && p.CPUTime >= prev {
+			s.Processes[i].CPU = percent((p.CPUTime - prev).Seconds(), elapsed)
+		}
+		s.Processes[i].MemShare = percent(float64(p.RSS), float64(s.Memory.Total))
+	}
+	sort.SliceStable(s.Processes, func(i, j int) bool {
+		return s.Processes[i].CPU > s.Processes[j].CPU
+	})
+
+	m.prevTime = s.Time
+	m.prevCPU = cpus
+	m.prevNet = make(map[string]NetInterface, len(s.Network))
+	for _, iface := range s.Network {
+		m.prevNet[iface.Name] = iface
+	}
+	m.prevProc = make(map[int]time.Duration, len(s.Processes))
+	for _, p := range s.Processes {
+		m.prevProc[p.PID] = p.CPUTime
+	}
+}
+
+// rate returns the per-second change of a counter, or 0 if it was reset.
+func rate(prev, cur uint64, seconds float64) float64 {
+	if cur < prev || seconds <= 0 {
+		return 0
+	}
+	return float64(cur-prev) / seconds
+}
+
+func percent(part, whole float64) float64 {
+	if whole <= 0 {
+		return 0
+	}
+	return part / whole * 100
+}
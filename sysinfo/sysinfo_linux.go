@@ -0,0 +1,340 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// procRoot is where the proc filesystem is mounted. Tests point it at
+// fixtures.
+var procRoot = "/proc"
+
+// clockTick is the unit of CPU times in /proc. The kernel reports them in
+// USER_HZ, which is 100 on every supported architecture.
+const clockTick = time.Second / 100
+
+func procPath(parts ...string) string {
+	return filepath.Join(append([]string{procRoot}, parts...)...)
+}
+
+// CPUTimes returns the time spent in each state by all CPUs together,
+// named "cpu", followed by each CPU.
+func CPUTimes() ([]CPUTime, error) {
+	data, err := os.ReadFile(procPath("stat"))
+	if err != nil {
+		return nil, err
+	}
+	var cpus []CPUTime
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+		cpu := CPUTime{Name: fields[0]}
+		states := []*time.Duration{
+			&cpu.User, &cpu.Nice, &cpu.System, &cpu.Idle,
+			&cpu.IOWait, &cpu.IRQ, &cpu.SoftIRQ, &cpu.Steal,
+		}
+		for i, state := range states {
+			if i+1 >= len(fields) {
+				break
+			}
+			ticks, err := strconv.ParseUint(fields[i+1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("sysinfo: parse %s: %w", procPath("stat"), err)
+			}
+			*state = time.Duration(ticks) * clockTick
+		}
+		cpus = append(cpus, cpu)
+	}
+	if len(cpus) == 0 {
+		return nil, fmt.Errorf("sysinfo: no cpu lines in %s", procPath("stat"))
+	}
+	return cpus, nil
+}
+
+// MemoryStats returns memory and swap sizes.
+func MemoryStats() (Memory, error) {
+	values, err := readKeyValues(procPath("meminfo"))
+	if err != nil {
+		return Memory{}, err
+	}
+	m := Memory{
+		Total:     values["MemTotal"],
+		Free:      values["MemFree"],
+		Cached:    values["Cached"] + values["Buffers"],
+		SwapTotal: values["SwapTotal"],
+		SwapFree:  values["SwapFree"],
+	}
+	if avail, ok := values["MemAvailable"]; ok {
+		m.Available = avail
+	} else {
+		// Kernels before 3.14 don't report it
+		m.Available = m.Free + m.Cached
+	}
+	return m, nil
+}
+
+// readKeyValues parses lines like "MemTotal:  16318480 kB" into bytes.
+func readKeyValues(path string) (map[string]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		n, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		if len(fields) > 1 && fields[1] == "kB" {
+			n *= 1024
+		}
+		values[key] = n
+	}
+	return values, scanner.Err()
+}
+
+// LoadAverage returns the system load averages.
+func LoadAverage() (Load, error) {
+	data, err := os.ReadFile(procPath("loadavg"))
+	if err != nil {
+		return Load{}, err
+	}
+	var l Load
+	if _, err := fmt.Sscan(string(data), &l.Load1, &l.Load5, &l.Load15); err != nil {
+		return Load{}, fmt.Errorf("sysinfo: parse %s: %w", procPath("loadavg"), err)
+	}
+	return l, nil
+}
+
+// Uptime returns the time since the system booted.
+func Uptime() (time.Duration, error) {
+	data, err := os.ReadFile(procPath("uptime"))
+	if err != nil {
+		return 0, err
+	}
+	var seconds float64
+	if _, err := fmt.Sscan(string(data), &seconds); err != nil {
+		return 0, fmt.Errorf("sysinfo: parse %s: %w", procPath("uptime"), err)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// Disks returns the mounted filesystems backed by a device, with their
+// sizes. Filesystems whose sizes can't be read are left out.
+func Disks() ([]Disk, error) {
+	data, err := os.ReadFile(procPath("self", "mounts"))
+	if err != nil {
+		return nil, err
+	}
+	var disks []Disk
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || !strings.HasPrefix(fields[0], "/") || seen[fields[0]] {
+			continue
+		}
+		d := Disk{
+			Device: unescapeMount(fields[0]),
+			Mount:  unescapeMount(fields[1]),
+			FSType: fields[2],
+		}
+		var st unix.Statfs_t
+		if err := unix.Statfs(d.Mount, &st); err != nil {
+			continue
+		}
+		bsize := uint64(st.Bsize)
+		d.Total = st.Blocks * bsize
+		d.Free = st.Bavail * bsize
+		d.Used = (st.Blocks - st.Bfree) * bsize
+		seen[fields[0]] = true
+		disks = append(disks, d)
+	}
+	return disks, nil
+}
+
+// unescapeMount decodes the octal escapes (like \040 for a space) in
+// /proc/mounts fields.
+func unescapeMount(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if n, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// NetInterfaces returns the traffic counters of each network interface.
+func NetInterfaces() ([]NetInterface, error) {
+	data, err := os.ReadFile(procPath("net", "dev"))
+	if err != nil {
+		return nil, err
+	}
+	var ifaces []NetInterface
+	for _, line := range strings.Split(string(data), "\n") {
+		name, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) < 16 {
+			continue
+		}
+		n := make([]uint64, 16)
+		for i := range n {
+			n[i], _ = strconv.ParseUint(fields[i], 10, 64)
+		}
+		ifaces = append(ifaces, NetInterface{
+			Name:      strings.TrimSpace(name),
+			RxBytes:   n[0],
+			RxPackets: n[1],
+			RxErrors:  n[2],
+			TxBytes:   n[8],
+			TxPackets: n[9],
+			TxErrors:  n[10],
+		})
+	}
+	return ifaces, nil
+}
+
+// Processes returns the running processes. Processes that exit while the
+// list is read are left out.
+func Processes() ([]Process, error) {
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, err
+	}
+	boot, err := bootTime()
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[int]string)
+	var procs []Process
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || !e.IsDir() {
+			continue
+		}
+		p, err := readProcess(pid, boot)
+		if err != nil {
+			continue
+		}
+		name, ok := users[p.UID]
+		if !ok {
+			name = strconv.Itoa(p.UID)
+			if u, err := user.LookupId(name); err == nil {
+				name = u.Username
+			}
+			users[p.UID] = name
+		}
+		p.User = name
+		procs = append(procs, p)
+	}
+	return procs, nil
+}
+
+// bootTime returns when the system booted, from the btime line of
+// /proc/stat.
+func bootTime() (time.Time, error) {
+	values, err := readFields(procPath("stat"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	btime, err := strconv.ParseInt(values["btime"], 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("sysinfo: no btime in %s", procPath("stat"))
+	}
+	return time.Unix(btime, 0), nil
+}
+
+// readFields maps the first field of each line of a file to the second.
+func readFields(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		if fields := strings.Fields(line); len(fields) >= 2 {
+			values[strings.TrimSuffix(fields[0], ":")] = fields[1]
+		}
+	}
+	return values, nil
+}
+
+// readProcess reads a process from /proc/<pid>.
+func readProcess(pid int, boot time.Time) (Process, error) {
+	dir := strconv.Itoa(pid)
+	stat, err := os.ReadFile(procPath(dir, "stat"))
+	if err != nil {
+		return Process{}, err
+	}
+	// The name is in parentheses and may itself contain spaces and
+	// parentheses, so split at the last closing one
+	open := bytes.IndexByte(stat, '(')
+	closing := bytes.LastIndexByte(stat, ')')
+	if open < 0 || closing < open {
+		return Process{}, fmt.Errorf("sysinfo: parse %s", procPath(dir, "stat"))
+	}
+	// Fields from the state onwards; rest[n] is field n+3 in proc(5)
+	rest := strings.Fields(string(stat[closing+1:]))
+	if len(rest) < 22 {
+		return Process{}, fmt.Errorf("sysinfo: parse %s", procPath(dir, "stat"))
+	}
+	num := func(i int) uint64 {
+		n, _ := strconv.ParseUint(rest[i], 10, 64)
+		return n
+	}
+
+	p := Process{
+		PID:     pid,
+		Name:    string(stat[open+1 : closing]),
+		State:   rest[0],
+		PPID:    int(num(1)),
+		CPUTime: time.Duration(num(11)+num(12)) * clockTick,
+		Threads: int(num(17)),
+		Started: boot.Add(time.Duration(num(19)) * clockTick),
+		VMS:     num(20),
+		RSS:     num(21) * uint64(os.Getpagesize()),
+	}
+
+	if status, err := readFields(procPath(dir, "status")); err == nil {
+		p.UID, _ = strconv.Atoi(status["Uid"])
+	}
+	if cmdline, err := os.ReadFile(procPath(dir, "cmdline")); err == nil && len(cmdline) > 0 {
+		p.Command = strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+	}
+	return p, nil
+}
@@ -0,0 +1,153 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func useFixtures(t *testing.T) {
+	t.Helper()
+	prev := procRoot
+	procRoot = "testdata/proc"
+	t.Cleanup(func() { procRoot = prev })
+}
+
+func TestCPUTimes(t *testing.T) {
+	useFixtures(t)
+	cpus, err := CPUTimes()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(cpus))
+	assert.Equal(t, "cpu", cpus[0].Name)
+	assert.Equal(t, 10*time.Second, cpus[0].User)
+	assert.Equal(t, 80*time.Second, cpus[0].Idle)
+	assert.Equal(t, 95800*time.Millisecond, cpus[0].Total())
+	assert.Equal(t, "cpu1", cpus[2].Name)
+}
+
+func TestCPUUsage(t *testing.T) {
+	prev := CPUTime{User: time.Second, Idle: 3 * time.Second}
+	cur := CPUTime{User: 2 * time.Second, Idle: 6 * time.Second}
+	assert.Equal(t, 25.0, CPUUsage(prev, cur))
+	assert.Equal(t, 0.0, CPUUsage(cur, cur))
+}
+
+func TestMemoryStats(t *testing.T) {
+	useFixtures(t)
+	m, err := MemoryStats()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8000000*1024), m.Total)
+	assert.Equal(t, uint64(2000000*1024), m.Used())
+	assert.Equal(t, 25.0, m.UsedPercent())
+	assert.Equal(t, uint64(3200000*1024), m.Cached)
+	assert.Equal(t, uint64(500000*1024), m.SwapUsed())
+}
+
+func TestLoadAndUptime(t *testing.T) {
+	useFixtures(t)
+	l, err := LoadAverage()
+	assert.NoError(t, err)
+	assert.Equal(t, Load{Load1: 0.52, Load5: 0.58, Load15: 0.59}, l)
+
+	up, err := Uptime()
+	assert.NoError(t, err)
+	assert.Equal(t, 3600500*time.Millisecond, up)
+}
+
+func TestNetInterfaces(t *testing.T) {
+	useFixtures(t)
+	ifaces, err := NetInterfaces()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(ifaces))
+	assert.Equal(t, NetInterface{
+		Name: "eth0", RxBytes: 1048576, RxPackets: 900, RxErrors: 2,
+		TxBytes: 524288, TxPackets: 400, TxErrors: 1,
+	}, ifaces[1])
+}
+
+func TestDisks(t *testing.T) {
+	useFixtures(t)
+	disks, err := Disks()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(disks))
+	assert.Equal(t, "/dev/root", disks[0].Device)
+	assert.Equal(t, "/", disks[0].Mount)
+	assert.True(t, disks[0].Total > 0)
+}
+
+func TestUnescapeMount(t *testing.T) {
+	assert.Equal(t, "/mnt/my disk", unescapeMount(`/mnt/my\040disk`))
+	assert.Equal(t, "/plain", unescapeMount("/plain"))
+}
+
+func TestProcesses(t *testing.T) {
+	useFixtures(t)
+	procs, err := Processes()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(procs))
+
+	init := procs[0]
+	assert.Equal(t, 1, init.PID)
+	assert.Equal(t, "init", init.Name)
+	assert.Equal(t, []string{"/sbin/init", "splash"}, init.Command)
+	assert.Equal(t, 0, init.UID)
+	assert.Equal(t, 2*time.Second, init.CPUTime)
+	assert.Equal(t, uint64(2000*os.Getpagesize()), init.RSS)
+	assert.Equal(t, time.Unix(1700000000, 100*int64(time.Millisecond)), init.Started)
+
+	app := procs[1]
+	assert.Equal(t, "my (odd) app", app.Name)
+	assert.Equal(t, "R", app.State)
+	assert.Equal(t, 1, app.PPID)
+	assert.Equal(t, 4, app.Threads)
+	assert.Equal(t, 1000, app.UID)
+	assert.Equal(t, 0, len(app.Command))
+}
+
+func TestMonitor_FillRates(t *testing.T) {
+	m := NewMonitor()
+	start := time.Unix(1000, 0)
+	first := &Sample{
+		Time:      start,
+		Memory:    Memory{Total: 1000},
+		Network:   []NetInterface{{Name: "eth0", RxBytes: 1000, TxBytes: 100}},
+		Processes: []Process{{PID: 1, CPUTime: time.Second, RSS: 100}, {PID: 2, CPUTime: time.Second}},
+	}
+	m.fillRates(first, []CPUTime{{Name: "cpu", Idle: time.Second}, {Name: "cpu0", Idle: time.Second}})
+	assert.Equal(t, 0.0, first.CPU)
+	assert.Equal(t, []float64{0}, first.Cores)
+	assert.Equal(t, 0.0, first.Network[0].RxRate)
+	assert.Equal(t, 10.0, first.Processes[0].MemShare)
+
+	second := &Sample{
+		Time:      start.Add(2 * time.Second),
+		Network:   []NetInterface{{Name: "eth0", RxBytes: 3000, TxBytes: 100}},
+		Processes: []Process{{PID: 1, CPUTime: 1500 * time.Millisecond}, {PID: 2, CPUTime: 2 * time.Second}},
+	}
+	m.fillRates(second, []CPUTime{
+		{Name: "cpu", User: time.Second, Idle: 2 * time.Second},
+		{Name: "cpu0", User: time.Second, Idle: time.Second},
+	})
+	assert.Equal(t, 50.0, second.CPU)
+	assert.Equal(t, []float64{100}, second.Cores)
+	assert.Equal(t, 1000.0, second.Network[0].RxRate)
+	assert.Equal(t, 0.0, second.Network[0].TxRate)
+
+	// Processes are sorted by CPU usage
+	assert.Equal(t, 2, second.Processes[0].PID)
+	assert.Equal(t, 50.0, second.Processes[0].CPU)
+	assert.Equal(t, 25.0, second.Processes[1].CPU)
+}
+
+func TestMonitor_Sample(t *testing.T) {
+	m := NewMonitor()
+	s, err := m.Sample()
+	assert.NoError(t, err)
+	assert.True(t, s.Memory.Total > 0)
+	assert.True(t, len(s.Cores) > 0)
+	assert.True(t, len(s.Processes) > 0)
+}
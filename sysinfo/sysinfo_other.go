@@ -0,0 +1,41 @@
+//go:build !linux
+
+package sysinfo
+
+import "time"
+
+// CPUTimes is not supported on this platform and returns ErrUnsupported.
+func CPUTimes() ([]CPUTime, error) {
+	return nil, ErrUnsupported
+}
+
+// MemoryStats is not supported on this platform and returns ErrUnsupported.
+func MemoryStats() (Memory, error) {
+	return Memory{}, ErrUnsupported
+}
+
+// LoadAverage is not supported on this platform and returns ErrUnsupported.
+func LoadAverage() (Load, error) {
+	return Load{}, ErrUnsupported
+}
+
+// Uptime is not supported on this platform and returns ErrUnsupported.
+func Uptime() (time.Duration, error) {
+	return 0, ErrUnsupported
+}
+
+// Disks is not supported on this platform and returns ErrUnsupported.
+func Disks() ([]Disk, error) {
+	return nil, ErrUnsupported
+}
+
+// NetInterfaces is not supported on this platform and returns
+// ErrUnsupported.
+func NetInterfaces() ([]NetInterface, error) {
+	return nil, ErrUnsupported
+}
+
+// Processes is not supported on this platform and returns ErrUnsupported.
+func Processes() ([]Process, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,90 @@
+package terminal
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// backgroundResponseRe matches a terminal's answer to the OSC 11 background
+// color query: ESC ] 11 ; rgb:RRRR/GGGG/BBBB, ended by BEL or ST. Each
+// channel has one to four hex digits.
+var backgroundResponseRe = regexp.MustCompile(`\x1b\]11;rgba?:([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})(?:/[0-9a-fA-F]{1,4})?(?:\x07|\x1b\\)`)
+
+// DetectBackground asks the terminal for its background color with the OSC
+// 11 query, and returns it. Terminals that don't answer, or aren't
+// terminals, fall back to the COLORFGBG environment variable set by some
+// terminals, which gives black or white. ok is false if neither says.
+//
+// Like DetectKittyProtocol, call it once at startup before enabling raw
+// mode. The result is kept and returned by Background.
+//
+// Example:
+//
+//	if bg, ok := term.DetectBackground(); ok && !bg.IsDark() {
+//	    // Use darker colors on a light terminal
+//	}
+func (t *Terminal) DetectBackground() (RGB, bool) {
+	if t.fd != -1 {
+		if bg, ok := parseBackgroundResponse(t.probe("\x1b]11;?\x1b\\")); ok {
+			t.background = &bg
+			return bg, true
+		}
+	}
+	if bg, ok := parseColorFGBG(os.Getenv("COLORFGBG")); ok {
+		t.background = &bg
+		return bg, true
+	}
+	return RGB{}, false
+}
+
+// Background returns the background color found by DetectBackground, or
+// false if it hasn't been called or found nothing.
+func (t *Terminal) Background() (RGB, bool) {
+	if t.background == nil {
+		return RGB{}, false
+	}
+	return *t.background, true
+}
+
+// SetBackground sets the background color Background returns, for tests and
+// for applications that know it some other way.
+func (t *Terminal) SetBackground(bg RGB) {
+	t.background = &bg
+}
+
+// parseBackgroundResponse reads the color from an answer to the OSC 11 query.
+func parseBackgroundResponse(response string) (RGB, bool) {
+	m := backgroundResponseRe.FindStringSubmatch(response)
+	if m == nil {
+		return RGB{}, false
+	}
+	var channels [3]uint8
+	for i, hex := range m[1:4] {
+		v, _ := strconv.ParseUint(hex, 16, 16)
+		// Scale to 8 bits from however many the terminal sent
+		maxValue := uint64(1)<<(4*len(hex)) - 1
+		channels[i] = uint8(v * 255 / maxValue)
+	}
+	return NewRGB(channels[0], channels[1], channels[2]), true
+}
+
+// parseColorFGBG reads the background from a COLORFGBG value such as "15;0"
+// or "0;default;15", whose last field is the ANSI color number of the
+// background.
+func parseColorFGBG(value string) (RGB, bool) {
+	fields := strings.Split(value, ";")
+	if len(fields) < 2 {
+		return RGB{}, false
+	}
+	n, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || n < 0 || n > 15 {
+		return RGB{}, false
+	}
+	// White (7) and the bright colors other than bright black are light
+	if n == 7 || n > 8 {
+		return NewRGB(255, 255, 255), true
+	}
+	return NewRGB(0, 0, 0), true
+}
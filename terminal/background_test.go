@@ -0,0 +1,38 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestParseBackgroundResponse(t *testing.T) {
+	bg, ok := parseBackgroundResponse("\x1b]11;rgb:fdfd/f6f6/e3e3\x1b\\\x1b[?62;22c")
+	assert.True(t, ok)
+	assert.Equal(t, NewRGB(253, 246, 227), bg)
+
+	// Two hex digits per channel, ended by BEL
+	bg, ok = parseBackgroundResponse("\x1b]11;rgb:1e/1e/2e\x07")
+	assert.True(t, ok)
+	assert.Equal(t, NewRGB(30, 30, 46), bg)
+
+	// Terminals that don't support the query only answer the device
+	// attributes query
+	_, ok = parseBackgroundResponse("\x1b[?1;2c")
+	assert.False(t, ok)
+}
+
+func TestParseColorFGBG(t *testing.T) {
+	bg, ok := parseColorFGBG("15;0")
+	assert.True(t, ok)
+	assert.True(t, bg.IsDark())
+
+	bg, ok = parseColorFGBG("0;default;15")
+	assert.True(t, ok)
+	assert.False(t, bg.IsDark())
+
+	_, ok = parseColorFGBG("")
+	assert.False(t, ok)
+	_, ok = parseColorFGBG("15;default")
+	assert.False(t, ok)
+}
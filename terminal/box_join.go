@@ -0,0 +1,207 @@
+package terminal
+
+// boxArms holds the weight of a box-drawing character's four arms, in the
+// order up, right, down, left: 0 for none, 1 for light, 2 for heavy, and 3
+// for double.
+type boxArms [4]int
+
+// boxChars maps the box-drawing characters (U+2500 to U+257F, except the
+// dashed and diagonal lines) to their arms. The rounded corners have the
+// same arms as the square ones.
+var boxChars = map[rune]boxArms{
+	'─': {0, 1, 0, 1},
+	'━': {0, 2, 0, 2},
+	'│': {1, 0, 1, 0},
+	'┃': {2, 0, 2, 0},
+	'┌': {0, 1, 1, 0},
+	'┍': {0, 2, 1, 0},
+	'┎': {0, 1, 2, 0},
+	'┏': {0, 2, 2, 0},
+	'┐': {0, 0, 1, 1},
+	'┑': {0, 0, 1, 2},
+	'┒': {0, 0, 2, 1},
+	'┓': {0, 0, 2, 2},
+	'└': {1, 1, 0, 0},
+	'┕': {1, 2, 0, 0},
+	'┖': {2, 1, 0, 0},
+	'┗': {2, 2, 0, 0},
+	'┘': {1, 0, 0, 1},
+	'┙': {1, 0, 0, 2},
+	'┚': {2, 0, 0, 1},
+	'┛': {2, 0, 0, 2},
+	'├': {1, 1, 1, 0},
+	'┝': {1, 2, 1, 0},
+	'┞': {2, 1, 1, 0},
+	'┟': {1, 1, 2, 0},
+	'┠': {2, 1, 2, 0},
+	'┡': {2, 2, 1, 0},
+	'┢': {1, 2, 2, 0},
+	'┣': {2, 2, 2, 0},
+	'┤': {1, 0, 1, 1},
+	'┥': {1, 0, 1, 2},
+	'┦': {2, 0, 1, 1},
+	'┧': {1, 0, 2, 1},
+	'┨': {2, 0, 2, 1},
+	'┩': {2, 0, 1, 2},
+	'┪': {1, 0, 2, 2},
+	'┫': {2, 0, 2, 2},
+	'┬': {0, 1, 1, 1},
+	'┭': {0, 1, 1, 2},
+	'┮': {0, 2, 1, 1},
+	'┯': {0, 2, 1, 2},
+	'┰': {0, 1, 2, 1},
+	'┱': {0, 1, 2, 2},
+	'┲': {0, 2, 2, 1},
+	'┳': {0, 2, 2, 2},
+	'┴': {1, 1, 0, 1},
+	'┵': {1, 1, 0, 2},
+	'┶': {1, 2, 0, 1},
+	'┷': {1, 2, 0, 2},
+	'┸': {2, 1, 0, 1},
+	'┹': {2, 1, 0, 2},
+	'┺': {2, 2, 0, 1},
+	'┻': {2, 2, 0, 2},
+	'┼': {1, 1, 1, 1},
+	'┽': {1, 1, 1, 2},
+	'┾': {1, 2, 1, 1},
+	'┿': {1, 2, 1, 2},
+	'╀': {2, 1, 1, 1},
+	'╁': {1, 1, 2, 1},
+	'╂': {2, 1, 2, 1},
+	'╃': {2, 1, 1, 2},
+	'╄': {2, 2, 1, 1},
+	'╅': {1, 1, 2, 2},
+	'╆': {1, 2, 2, 1},
+	'╇': {2, 2, 1, 2},
+	'╈': {1, 2, 2, 2},
+	'╉': {2, 1, 2, 2},
+	'╊': {2, 2, 2, 1},
+	'╋': {2, 2, 2, 2},
+	'═': {0, 3, 0, 3},
+	'║': {3, 0, 3, 0},
+	'╒': {0, 3, 1, 0},
+	'╓': {0, 1, 3, 0},
+	'╔': {0, 3, 3, 0},
+	'╕': {0, 0, 1, 3},
+	'╖': {0, 0, 3, 1},
+	'╗': {0, 0, 3, 3},
+	'╘': {1, 3, 0, 0},
+	'╙': {3, 1, 0, 0},
+	'╚': {3, 3, 0, 0},
+	'╛': {1, 0, 0, 3},
+	'╜': {3, 0, 0, 1},
+	'╝': {3, 0, 0, 3},
+	'╞': {1, 3, 1, 0},
+	'╟': {3, 1, 3, 0},
+	'╠': {3, 3, 3, 0},
+	'╡': {1, 0, 1, 3},
+	'╢': {3, 0, 3, 1},
+	'╣': {3, 0, 3, 3},
+	'╤': {0, 3, 1, 3},
+	'╥': {0, 1, 3, 1},
+	'╦': {0, 3, 3, 3},
+	'╧': {1, 3, 0, 3},
+	'╨': {3, 1, 0, 1},
+	'╩': {3, 3, 0, 3},
+	'╪': {1, 3, 1, 3},
+	'╫': {3, 1, 3, 1},
+	'╬': {3, 3, 3, 3},
+	'╭': {0, 1, 1, 0},
+	'╮': {0, 0, 1, 1},
+	'╯': {1, 0, 0, 1},
+	'╰': {1, 1, 0, 0},
+	'╴': {0, 0, 0, 1},
+	'╵': {1, 0, 0, 0},
+	'╶': {0, 1, 0, 0},
+	'╷': {0, 0, 1, 0},
+	'╸': {0, 0, 0, 2},
+	'╹': {2, 0, 0, 0},
+	'╺': {0, 2, 0, 0},
+	'╻': {0, 0, 2, 0},
+	'╼': {0, 2, 0, 1},
+	'╽': {1, 0, 2, 0},
+	'╾': {0, 1, 0, 2},
+	'╿': {2, 0, 1, 0},
+}
+
+// boxByArms maps arms back to a character. Rounded corners are left out, so
+// joins draw square corners.
+var boxByArms = func() map[boxArms]rune {
+	m := make(map[boxArms]rune, len(boxChars))
+	for r, arms := range boxChars {
+		if r < '╭' || r > '╰' {
+			m[arms] = r
+		}
+	}
+	return m
+}()
+
+// JoinBoxRunes returns the character that draws both box-drawing characters
+// in one cell, for borders that meet or overlap: '┐' and '┌' join as '┬',
+// '│' and '─' as '┼'. Each arm keeps the heavier of the two weights. Where
+// Unicode has no character for the mix of weights, such as heavy with
+// double, the arms take the weight of above. ASCII borders join as '+'.
+//
+// It returns false, and above, if either rune isn't a box-drawing character.
+func JoinBoxRunes(below, above rune) (rune, bool) {
+	if isASCIIBox(below) && isASCIIBox(above) {
+		if below == above {
+			return above, true
+		}
+		return '+', true
+	}
+	a, ok := boxChars[below]
+	if !ok {
+		return above, false
+	}
+	b, ok := boxChars[above]
+	if !ok {
+		return above, false
+	}
+
+	var joined boxArms
+	for i := range joined {
+		joined[i] = max(a[i], b[i])
+	}
+	switch joined {
+	case b:
+		return above, true
+	case a:
+		return below, true
+	}
+	if r, ok := boxByArms[joined]; ok {
+		return r, true
+	}
+
+	// Draw the arms from below in above's weight, and failing that, draw
+	// every arm light
+	weight := 0
+	for _, w := range b {
+		weight = max(weight, w)
+	}
+	for i := range joined {
+		if b[i] == 0 && a[i] != 0 {
+			joined[i] = weight
+		} else {
+			joined[i] = b[i]
+		}
+	}
+	if r, ok := boxByArms[joined]; ok {
+		return r, true
+	}
+	for i := range joined {
+		joined[i] = min(joined[i], 1)
+	}
+	return boxByArms[joined], true
+}
+
+// IsBoxRune reports whether r is a box-drawing character that JoinBoxRunes
+// can join.
+func IsBoxRune(r rune) bool {
+	_, ok := boxChars[r]
+	return ok || isASCIIBox(r)
+}
+
+func isASCIIBox(r rune) bool {
+	return r == '+' || r == '-' || r == '|'
+}
@@ -0,0 +1,46 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestJoinBoxRunes(t *testing.T) {
+	tests := []struct {
+		below, above, want rune
+	}{
+		{'┐', '┌', '┬'},
+		{'┘', '└', '┴'},
+		{'│', '─', '┼'},
+		{'┤', '├', '┼'},
+		{'┬', '─', '┬'},
+		{'╮', '╭', '┬'},
+		{'╭', '╭', '╭'},
+		{'╗', '╔', '╦'},
+		{'━', '│', '┿'},
+		{'║', '─', '╫'},
+		{'┓', '┌', '┱'},
+		{'╗', '┌', '┬'}, // no character mixes double left with single right and down
+		{'-', '|', '+'},
+		{'+', '-', '+'},
+		{'|', '|', '|'},
+	}
+	for _, tt := range tests {
+		got, ok := JoinBoxRunes(tt.below, tt.above)
+		assert.True(t, ok, "%c %c", tt.below, tt.above)
+		assert.Equal(t, got, tt.want, "%c %c", tt.below, tt.above)
+	}
+
+	got, ok := JoinBoxRunes('a', '│')
+	assert.False(t, ok)
+	assert.Equal(t, got, '│')
+}
+
+func TestIsBoxRune(t *testing.T) {
+	assert.True(t, IsBoxRune('┼'))
+	assert.True(t, IsBoxRune('╭'))
+	assert.True(t, IsBoxRune('+'))
+	assert.False(t, IsBoxRune('a'))
+	assert.False(t, IsBoxRune('┄'))
+}
@@ -0,0 +1,33 @@
+package terminal
+
+// ColorProfile returns the range of colors the terminal renders with.
+// NewTerminal detects it from the environment with color.DetectProfile;
+// test terminals use ProfileTrueColor.
+func (t *Terminal) ColorProfile() ColorProfile {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.colorProfile
+}
+
+// SetColorProfile sets the range of colors the terminal renders with, for
+// example from a --color flag. RGB colors are converted to the nearest
+// colors of the profile when they are written, so views can use RGB colors
+// on any terminal.
+func (t *Terminal) SetColorProfile(profile ColorProfile) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.colorProfile = profile
+	// Redraw everything in the new colors
+	for y := range t.frontBuffer {
+		for x := range t.frontBuffer[y] {
+			t.frontBuffer[y][x] = Cell{Char: 0, Style: NewStyle()}
+		}
+	}
+	t.dirtyRegion.MarkRect(0, 0, t.width, t.height)
+}
+
+// StyleSequence returns the escape sequence that sets style, with its colors
+// converted for the terminal's color profile.
+func (t *Terminal) StyleSequence(style Style) string {
+	return style.Downsample(t.colorProfile).String()
+}
@@ -0,0 +1,47 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestStyle_Downsample(t *testing.T) {
+	style := NewStyle().WithFgRGB(NewRGB(255, 0, 0)).WithBackground(Color(196)).WithBold()
+	assert.Equal(t, style, style.Downsample(ProfileTrueColor))
+
+	s256 := style.Downsample(ProfileANSI256)
+	assert.Nil(t, s256.FgRGB)
+	assert.Equal(t, Color(196), s256.Foreground)
+	assert.Equal(t, Color(196), s256.Background)
+	assert.True(t, s256.Bold)
+
+	s16 := style.Downsample(ProfileANSI16)
+	assert.Equal(t, ColorBrightRed, s16.Foreground)
+	assert.Equal(t, ColorBrightRed, s16.Background)
+	assert.Equal(t, "\033[0;1;91;101m", s16.String())
+}
+
+func TestTerminal_ColorProfile(t *testing.T) {
+	var out strings.Builder
+	term := NewTestTerminal(4, 1, &out)
+	assert.Equal(t, ProfileTrueColor, term.ColorProfile())
+	style := NewStyle().WithFgRGB(NewRGB(97, 175, 239))
+
+	term.SetColorProfile(ProfileANSI256)
+	frame, _ := term.BeginFrame()
+	frame.PrintStyled(0, 0, "hi", style)
+	term.EndFrame(frame)
+	assert.Contains(t, out.String(), "38;5;75m")
+	assert.NotContains(t, out.String(), "38;2;")
+
+	// Changing the profile redraws in the new colors
+	out.Reset()
+	term.SetColorProfile(ProfileANSI16)
+	frame, _ = term.BeginFrame()
+	frame.PrintStyled(0, 0, "hi", style)
+	term.EndFrame(frame)
+	assert.Contains(t, out.String(), "hi")
+	assert.NotContains(t, out.String(), "38;5;")
+}
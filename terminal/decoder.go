@@ -202,6 +202,9 @@ func (kd *KeyDecoder) handleEscapeEvent() (Event, error) {
 	case 0x0D, 0x0A:
 		// ESC + Enter (CR or LF) = Shift+Enter (iTerm2 and similar terminals)
 		return KeyEvent{Key: KeyEnter, Shift: true}, nil
+	case 0x7F, 0x08:
+		// ESC + Backspace = Alt+Backspace (delete word in most terminals)
+		return KeyEvent{Key: KeyBackspace, Alt: true}, nil
 	default:
 		// Alt+key combination or unknown
 		if nextByte >= 0x20 && nextByte < 0x7F {
@@ -282,7 +285,7 @@ func (kd *KeyDecoder) decodeCSIEvent() (Event, error) {
 				keyEvent, err := kd.decodeCSIu(string(num))
 				return keyEvent, err
 			}
-			if b >= '0' && b <= '9' {
+			if b >= '0' && b <= '9' || b == ':' {
 				num = append(num, b)
 			} else {
 				// Unexpected character
@@ -461,6 +464,9 @@ func (kd *KeyDecoder) handleEscape() (KeyEvent, error) {
 	case 0x0D, 0x0A:
 		// ESC + Enter (CR or LF) = Shift+Enter (iTerm2 and similar terminals)
 		return KeyEvent{Key: KeyEnter, Shift: true}, nil
+	case 0x7F, 0x08:
+		// ESC + Backspace = Alt+Backspace (delete word in most terminals)
+		return KeyEvent{Key: KeyBackspace, Alt: true}, nil
 	default:
 		// Alt+key combination or unknown
 		if nextByte >= 0x20 && nextByte < 0x7F {
@@ -524,7 +530,11 @@ func (kd *KeyDecoder) decodeCSI() (KeyEvent, error) {
 				// Modified key sequence
 				return kd.decodeCSIModified(string(num))
 			}
-			if b >= '0' && b <= '9' {
+			if b == 'u' {
+				// CSI u sequence without modifiers (Kitty keyboard protocol)
+				return kd.decodeCSIu(string(num))
+			}
+			if b >= '0' && b <= '9' || b == ':' {
 				num = append(num, b)
 			} else {
 				// Unexpected character
@@ -583,37 +593,61 @@ func (kd *KeyDecoder) decodeCSINumber(num string) (KeyEvent, error) {
 // decodeCSIu decodes CSI u sequences without modifiers (Kitty keyboard protocol)
 // Format: ESC [ codepoint u
 func (kd *KeyDecoder) decodeCSIu(codepoint string) (KeyEvent, error) {
-	return kd.decodeCSIuWithModifier(codepoint, '1') // modifier 1 = no modifiers
+	return kd.decodeCSIuWithModifier(codepoint, keyModifiers{})
 }
 
-// decodeCSIuWithModifier decodes CSI u sequences with modifiers (Kitty keyboard protocol)
-// Format: ESC [ codepoint ; modifier u
-// Codepoint is the Unicode code point of the key
-// Modifier: 1=none, 2=Shift, 3=Alt, 4=Shift+Alt, 5=Ctrl, 6=Shift+Ctrl, 7=Alt+Ctrl, 8=Shift+Alt+Ctrl
-func (kd *KeyDecoder) decodeCSIuWithModifier(codepoint string, modByte byte) (KeyEvent, error) {
-	// Decode modifiers
-	var shift, alt, ctrl bool
-	if modByte == '2' || modByte == '4' || modByte == '6' || modByte == '8' {
-		shift = true
-	}
-	if modByte == '3' || modByte == '4' || modByte == '7' || modByte == '8' {
-		alt = true
-	}
-	if modByte == '5' || modByte == '6' || modByte == '7' || modByte == '8' {
-		ctrl = true
+// keyModifiers are the modifiers of a CSI key sequence.
+type keyModifiers struct {
+	shift, alt, ctrl, super bool
+}
+
+// parseKeyModifiers parses a CSI modifier parameter, which is 1 plus a bit
+// mask: 1=Shift, 2=Alt, 4=Ctrl, 8=Super, 16=Hyper, 32=Meta, 64=Caps Lock,
+// 128=Num Lock. The kitty protocol may follow it with ":" and an event type.
+// Meta is reported as Alt, and the lock keys are ignored.
+func parseKeyModifiers(param string) keyModifiers {
+	param, _, _ = strings.Cut(param, ":")
+	n, err := strconv.Atoi(param)
+	if err != nil || n < 1 {
+		return keyModifiers{}
+	}
+	bits := n - 1
+	return keyModifiers{
+		shift: bits&1 != 0,
+		alt:   bits&(2|32) != 0,
+		ctrl:  bits&4 != 0,
+		super: bits&8 != 0,
 	}
+}
+
+// apply sets the modifiers on event.
+func (m keyModifiers) apply(event KeyEvent) KeyEvent {
+	event.Shift = event.Shift || m.shift
+	event.Alt = event.Alt || m.alt
+	event.Ctrl = event.Ctrl || m.ctrl
+	event.Super = event.Super || m.super
+	return event
+}
+
+// decodeCSIuWithModifier decodes a key reported by its Unicode codepoint, as
+// in the kitty protocol's ESC [ codepoint ; modifier u and xterm's
+// modifyOtherKeys ESC [ 27 ; modifier ; codepoint ~. A kitty codepoint may
+// be followed by ":" and alternate keys, which are ignored.
+func (kd *KeyDecoder) decodeCSIuWithModifier(codepoint string, mods keyModifiers) (KeyEvent, error) {
+	codepoint, _, _ = strings.Cut(codepoint, ":")
+	event, err := kd.decodeCodepoint(codepoint, mods.shift, mods.alt, mods.ctrl)
+	event.Super = mods.super
+	return event, err
+}
 
+// decodeCodepoint returns the key for a codepoint with the given modifiers.
+func (kd *KeyDecoder) decodeCodepoint(codepoint string, shift, alt, ctrl bool) (KeyEvent, error) {
 	// Handle special keys by codepoint
 	switch codepoint {
 	case "9":
 		return KeyEvent{Key: KeyTab, Shift: shift, Alt: alt, Ctrl: ctrl}, nil
 	case "13":
-		event := KeyEvent{Key: KeyEnter, Shift: shift, Alt: alt, Ctrl: ctrl}
-		// Normalize Ctrl+Enter and Alt+Enter to also set Shift
-		if ctrl || alt {
-			event.Shift = true
-		}
-		return event, nil
+		return KeyEvent{Key: KeyEnter, Shift: shift, Alt: alt, Ctrl: ctrl}, nil
 	case "27":
 		return KeyEvent{Key: KeyEscape, Shift: shift, Alt: alt, Ctrl: ctrl}, nil
 	case "127":
@@ -749,70 +783,75 @@ func (kd *KeyDecoder) ctrlLetterEvent(letter rune, shift, alt bool) (KeyEvent, e
 	return KeyEvent{Key: key, Ctrl: true, Shift: shift, Alt: alt}, nil
 }
 
-// decodeCSIModified decodes CSI sequences with modifiers (e.g., ESC [ 1 ; 5 A for Ctrl+Up)
+// decodeCSIModified decodes CSI sequences with parameters after the first,
+// which is already read up to its ';'. These are modified keys such as
+// ESC [ 1 ; 5 A (Ctrl+Up), ESC [ 3 ; 5 ~ (Ctrl+Delete), kitty keyboard
+// protocol keys such as ESC [ 13 ; 2 u (Shift+Enter), and xterm
+// modifyOtherKeys keys such as ESC [ 27 ; 5 ; 13 ~ (Ctrl+Enter).
 func (kd *KeyDecoder) decodeCSIModified(num string) (KeyEvent, error) {
-	// Read modifier number
-	modByte, err := kd.reader.ReadByte()
-	if err != nil {
-		return KeyEvent{Key: KeyUnknown}, err
-	}
-
-	// Read the key code
-	keyByte, err := kd.reader.ReadByte()
-	if err != nil {
-		return KeyEvent{Key: KeyUnknown}, err
+	// Read the remaining parameters up to the final byte
+	params := []string{num}
+	var param []byte
+	var final byte
+	for {
+		b, err := kd.reader.ReadByte()
+		if err != nil {
+			return KeyEvent{Key: KeyUnknown}, err
+		}
+		if b >= 0x40 && b <= 0x7E {
+			final = b
+			break
+		}
+		if b == ';' {
+			params = append(params, string(param))
+			param = param[:0]
+			continue
+		}
+		param = append(param, b)
 	}
+	params = append(params, string(param))
+	mods := parseKeyModifiers(params[1])
 
-	// Decode the key
-	var key Key
-	switch keyByte {
+	var event KeyEvent
+	switch final {
 	case 'A':
-		key = KeyArrowUp
+		event.Key = KeyArrowUp
 	case 'B':
-		key = KeyArrowDown
+		event.Key = KeyArrowDown
 	case 'C':
-		key = KeyArrowRight
+		event.Key = KeyArrowRight
 	case 'D':
-		key = KeyArrowLeft
+		event.Key = KeyArrowLeft
+	case 'H':
+		event.Key = KeyHome
+	case 'F':
+		event.Key = KeyEnd
+	case 'P':
+		event.Key = KeyF1
+	case 'Q':
+		event.Key = KeyF2
+	case 'R':
+		event.Key = KeyF3
+	case 'S':
+		event.Key = KeyF4
 	case 'u':
 		// CSI u sequence (Kitty keyboard protocol)
 		// num contains the Unicode codepoint
-		return kd.decodeCSIuWithModifier(num, modByte)
+		return kd.decodeCSIuWithModifier(num, mods)
 	case '~':
+		if num == "27" && len(params) > 2 {
+			// xterm modifyOtherKeys: ESC [ 27 ; modifier ; codepoint ~
+			return kd.decodeCSIuWithModifier(params[2], mods)
+		}
 		// Modified key ending with ~ (e.g., ESC[3;5~ for Ctrl+Delete)
-		switch num {
-		case "3":
-			key = KeyDelete
-		case "5":
-			key = KeyPageUp
-		case "6":
-			key = KeyPageDown
-		default:
-			return KeyEvent{Key: KeyUnknown}, nil
+		event, _ = kd.decodeCSINumber(num)
+		if event.Key == KeyUnknown {
+			return event, nil
 		}
 	default:
 		return KeyEvent{Key: KeyUnknown}, nil
 	}
-
-	event := KeyEvent{Key: key}
-
-	// Decode modifiers (2=Shift, 3=Alt, 4=Shift+Alt, 5=Ctrl, 6=Shift+Ctrl, 7=Alt+Ctrl, 8=Shift+Alt+Ctrl)
-	if modByte == '2' || modByte == '4' || modByte == '6' || modByte == '8' {
-		event.Shift = true
-	}
-	if modByte == '3' || modByte == '4' || modByte == '7' || modByte == '8' {
-		event.Alt = true
-	}
-	if modByte == '5' || modByte == '6' || modByte == '7' || modByte == '8' {
-		event.Ctrl = true
-	}
-
-	// Normalize Ctrl+Enter and Alt+Enter to also set Shift, so apps can just check Shift for "soft newline".
-	if key == KeyEnter && (event.Ctrl || event.Alt) {
-		event.Shift = true
-	}
-
-	return event, nil
+	return mods.apply(event), nil
 }
 
 // decodeSS3 decodes ANSI SS3 sequences (ESC O ...)
@@ -258,6 +258,19 @@ func TestKeyDecoder_AltModifier(t *testing.T) {
 	}
 }
 
+// TestKeyDecoder_AltBackspace tests that ESC followed by DEL or BS decodes
+// as Alt+Backspace
+func TestKeyDecoder_AltBackspace(t *testing.T) {
+	for _, b := range []byte{0x7F, 0x08} {
+		decoder := NewKeyDecoder(bytes.NewReader([]byte{0x1B, b}))
+		event, err := decoder.ReadKeyEvent()
+
+		assert.NoError(t, err)
+		assert.Equal(t, event.Key, KeyBackspace)
+		assert.True(t, event.Alt)
+	}
+}
+
 // TestKeyDecoder_ModifiedArrowKeys tests arrow keys with modifiers
 func TestKeyDecoder_ModifiedArrowKeys(t *testing.T) {
 	tests := []struct {
@@ -508,22 +521,92 @@ func TestKeyDecoder_ReadEvent_KittyCSIuModifiers(t *testing.T) {
 		name        string
 		input       []byte
 		expectedKey Key
+		expectRune  rune
 		expectCtrl  bool
+		expectAlt   bool
 		expectShift bool
+		expectSuper bool
 	}{
 		{
 			name:        "Ctrl+Enter via CSI u",
 			input:       []byte{0x1B, '[', '1', '3', ';', '5', 'u'},
 			expectedKey: KeyEnter,
 			expectCtrl:  true,
-			expectShift: true,
 		},
 		{
 			name:        "Ctrl+C via CSI u",
 			input:       []byte{0x1B, '[', '9', '9', ';', '5', 'u'},
 			expectedKey: KeyCtrlC,
 			expectCtrl:  true,
-			expectShift: false,
+		},
+		{
+			name:        "Shift+Enter via CSI u",
+			input:       []byte("\x1b[13;2u"),
+			expectedKey: KeyEnter,
+			expectShift: true,
+		},
+		{
+			name:        "Ctrl+Shift+Enter via CSI u",
+			input:       []byte("\x1b[13;6u"),
+			expectedKey: KeyEnter,
+			expectCtrl:  true,
+			expectShift: true,
+		},
+		{
+			name:        "Alt+Enter via CSI u",
+			input:       []byte("\x1b[13;3u"),
+			expectedKey: KeyEnter,
+			expectAlt:   true,
+		},
+		{
+			name:        "Super+a via CSI u",
+			input:       []byte("\x1b[97;9u"),
+			expectedKey: KeyUnknown,
+			expectRune:  'a',
+			expectSuper: true,
+		},
+		{
+			name:        "Caps Lock is ignored",
+			input:       []byte("\x1b[13;66u"),
+			expectedKey: KeyEnter,
+			expectShift: true,
+		},
+		{
+			name:        "Event type suffix",
+			input:       []byte("\x1b[13;2:1u"),
+			expectedKey: KeyEnter,
+			expectShift: true,
+		},
+		{
+			name:        "Ctrl+Enter via modifyOtherKeys",
+			input:       []byte("\x1b[27;5;13~"),
+			expectedKey: KeyEnter,
+			expectCtrl:  true,
+		},
+		{
+			name:        "Shift+Enter via modifyOtherKeys",
+			input:       []byte("\x1b[27;2;13~"),
+			expectedKey: KeyEnter,
+			expectShift: true,
+		},
+		{
+			name:        "Ctrl+F1",
+			input:       []byte("\x1b[1;5P"),
+			expectedKey: KeyF1,
+			expectCtrl:  true,
+		},
+		{
+			name:        "Ctrl+Delete",
+			input:       []byte("\x1b[3;5~"),
+			expectedKey: KeyDelete,
+			expectCtrl:  true,
+		},
+		{
+			name:        "Ctrl+Shift+Up",
+			input:       []byte("\x1b[1;6A"),
+			expectedKey: KeyArrowUp,
+			expectCtrl:  true,
+			expectShift: true,
 		},
 	}
 
@@ -537,12 +620,23 @@ func TestKeyDecoder_ReadEvent_KittyCSIuModifiers(t *testing.T) {
 			assert.True(t, ok)
 
 			assert.Equal(t, keyEvent.Key, tt.expectedKey)
+			assert.Equal(t, keyEvent.Rune, tt.expectRune)
 			assert.Equal(t, keyEvent.Ctrl, tt.expectCtrl)
+			assert.Equal(t, keyEvent.Alt, tt.expectAlt)
 			assert.Equal(t, keyEvent.Shift, tt.expectShift)
+			assert.Equal(t, keyEvent.Super, tt.expectSuper)
 		})
 	}
 }
 
+func TestKeyEvent_IsModifiedEnter(t *testing.T) {
+	assert.False(t, KeyEvent{Key: KeyEnter}.IsModifiedEnter())
+	assert.True(t, KeyEvent{Key: KeyEnter, Shift: true}.IsModifiedEnter())
+	assert.True(t, KeyEvent{Key: KeyEnter, Ctrl: true}.IsModifiedEnter())
+	assert.True(t, KeyEvent{Key: KeyEnter, Alt: true}.IsModifiedEnter())
+	assert.False(t, KeyEvent{Key: KeyTab, Shift: true}.IsModifiedEnter())
+}
+
 func TestKeyDecoder_ReadEvent_EscapeStandalone(t *testing.T) {
 	decoder := NewKeyDecoder(bytes.NewReader([]byte{0x1B}))
 	event, err := decoder.ReadEvent()
@@ -110,10 +110,19 @@ type KeyEvent struct {
 	Alt   bool      // True if Alt/Option modifier was held
 	Ctrl  bool      // True if Ctrl/Command modifier was held
 	Shift bool      // True if Shift modifier was held
+	Super bool      // True if Super/Command was held (reported by the kitty keyboard protocol only)
 	Paste string    // If non-empty, this event represents a paste operation (bracketed paste mode)
 	Time  time.Time // When the event occurred
 }
 
+// IsModifiedEnter reports whether the event is Enter with Shift, Alt, or Ctrl
+// held. Multi-line inputs insert a newline for these instead of submitting.
+// Which of them a terminal can report depends on its keyboard protocol; see
+// Terminal.KeyboardProtocol.
+func (e KeyEvent) IsModifiedEnter() bool {
+	return e.Key == KeyEnter && (e.Shift || e.Alt || e.Ctrl)
+}
+
 // Timestamp implements the Event interface
 func (e KeyEvent) Timestamp() time.Time {
 	if e.Time.IsZero() {
@@ -32,6 +32,17 @@ const (
 	ColorBrightWhite   = color.BrightWhite
 )
 
+// ColorProfile is the range of colors a terminal can show. The Terminal
+// converts RGB colors to the nearest colors of its profile as it renders.
+type ColorProfile = color.Profile
+
+// Color profiles
+const (
+	ProfileTrueColor = color.TrueColor
+	ProfileANSI256   = color.ANSI256
+	ProfileANSI16    = color.ANSI16
+)
+
 // Re-export color functions for backward compatibility
 var (
 	NewRGB          = color.NewRGB
@@ -39,6 +50,10 @@ var (
 	RainbowGradient = color.RainbowGradient
 	SmoothRainbow   = color.SmoothRainbow
 	MultiGradient   = color.MultiGradient
+
+	// DetectColorProfile guesses the terminal's color profile from
+	// COLORTERM, TERM, and TERM_PROGRAM.
+	DetectColorProfile = color.DetectProfile
 )
 
 // Style represents text styling attributes including colors, text attributes, and hyperlinks.
@@ -239,6 +254,28 @@ func (s Style) String() string {
 	return fmt.Sprintf("\033[%sm", strings.Join(codes, ";"))
 }
 
+// Downsample returns the style with its colors replaced by the nearest
+// colors profile can show: RGB colors become palette colors, and for
+// ProfileANSI16, colors of the 256-color palette become one of the 16.
+func (s Style) Downsample(profile ColorProfile) Style {
+	if profile == ProfileTrueColor {
+		return s
+	}
+	if s.FgRGB != nil {
+		s.Foreground, _ = profile.Convert(*s.FgRGB)
+		s.FgRGB = nil
+	} else if s.Foreground != ColorDefault {
+		s.Foreground = profile.Fit(s.Foreground)
+	}
+	if s.BgRGB != nil {
+		s.Background, _ = profile.Convert(*s.BgRGB)
+		s.BgRGB = nil
+	} else if s.Background != ColorDefault {
+		s.Background = profile.Fit(s.Background)
+	}
+	return s
+}
+
 // Apply applies the style to the given text by wrapping it with ANSI escape codes.
 // The text is prefixed with the style's ANSI sequence and suffixed with a reset.
 //
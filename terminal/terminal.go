@@ -100,10 +100,12 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/deepnoodle-ai/wonton/color"
 	"github.com/mattn/go-runewidth"
 	"golang.org/x/term"
 )
@@ -454,11 +456,19 @@ type Terminal struct {
 	recorder *Recorder
 
 	// Kitty keyboard protocol support
-	kittySupported bool
-	kittyEnabled   bool
+	kittySupported  bool
+	kittyEnabled    bool
+	modifyOtherKeys bool
+
+	// Background color found by DetectBackground, or nil
+	background *RGB
+
+	// Colors the terminal can show; RGB colors are converted to fit
+	colorProfile ColorProfile
 
 	// Cursor visibility state
 	cursorHidden bool
+	placedCursor *Position // set by PlaceCursor; flushes leave the cursor here
 
 	// Mode tracking for cleanup
 	mouseEnabled   bool // Mouse tracking is enabled
@@ -480,6 +490,21 @@ func (t *Terminal) EndFrame(f RenderFrame) error {
 	return t.flushInternal()
 }
 
+// AbortFrame releases a frame without flushing it, for when rendering fails
+// partway through. The partial changes stay in the back buffer and are drawn
+// by the next EndFrame unless overwritten.
+//
+// Errors:
+//   - Returns ErrInvalidFrame if the frame doesn't match this terminal
+func (t *Terminal) AbortFrame(f RenderFrame) error {
+	tf, ok := f.(*terminalRenderFrame)
+	if !ok || tf.t != t {
+		return ErrInvalidFrame
+	}
+	t.mu.Unlock()
+	return nil
+}
+
 // Position represents a cursor position
 type Position struct {
 	X int
@@ -533,12 +558,13 @@ func NewTerminal() (*Terminal, error) {
 	}
 
 	t := &Terminal{
-		fd:       fd,
-		width:    width,
-		height:   height,
-		buffered: true,
-		out:      os.Stdout,
-		metrics:  NewRenderMetrics(),
+		fd:           fd,
+		width:        width,
+		height:       height,
+		buffered:     true,
+		out:          os.Stdout,
+		metrics:      NewRenderMetrics(),
+		colorProfile: color.DetectProfile(),
 	}
 
 	t.initBuffers(width, height)
@@ -834,6 +860,7 @@ func (t *Terminal) MoveCursor(x, y int) {
 }
 
 func (t *Terminal) moveCursorInternal(x, y int) {
+	t.placedCursor = nil
 	if !t.buffered {
 		fmt.Fprintf(t.out, "\033[%d;%dH", y+1, x+1)
 		return
@@ -954,6 +981,25 @@ func (t *Terminal) ShowCursor() {
 	fmt.Fprint(t.out, "\033[?25h")
 }
 
+// PlaceCursor moves the cursor to (x, y) and shows it, or hides it if
+// visible is false. Unlike MoveCursor, the change is written immediately
+// rather than at the next flush, and later flushes return the cursor to the
+// placed position, so it stays put while frames are drawn. A later
+// MoveCursor cancels the placement. Call it between frames, not while a
+// frame is active.
+func (t *Terminal) PlaceCursor(x, y int, visible bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cursorHidden = !visible
+	if !visible {
+		t.placedCursor = nil
+		fmt.Fprint(t.out, "\033[?25l")
+		return
+	}
+	t.placedCursor = &Position{X: x, Y: y}
+	fmt.Fprintf(t.out, "\033[%d;%dH\033[?25h", y+1, x+1)
+}
+
 // EnableAlternateScreen switches to the alternate screen buffer
 func (t *Terminal) EnableAlternateScreen() {
 	if !t.altScreen {
@@ -987,6 +1033,14 @@ func (t *Terminal) DisableBracketedPaste() {
 	t.bracketedPaste = false
 }
 
+// Responses to the keyboard protocol query: the kitty protocol's current
+// flags (CSI ? flags u), and the primary device attributes (CSI ? ... c),
+// which every terminal answers.
+var (
+	kittyResponseRe    = regexp.MustCompile(`\x1b\[\?\d*u`)
+	deviceAttributesRe = regexp.MustCompile(`\x1b\[\?[\d;]*c`)
+)
+
 // DetectKittyProtocol probes the terminal to detect Kitty keyboard protocol support.
 // This should be called once at startup before enabling raw mode.
 // Returns true if the terminal supports the protocol.
@@ -1009,15 +1063,26 @@ func (t *Terminal) DetectKittyProtocol() bool {
 		return false // Test mode
 	}
 
+	// Send query: progressive enhancement query + device attributes query
+	response := t.probe("\x1b[?u")
+	t.kittySupported = kittyResponseRe.MatchString(response) && deviceAttributesRe.MatchString(response)
+
+	return t.kittySupported
+}
+
+// probe sends query followed by a device attributes query, and returns what
+// the terminal answered within 200ms. Terminals answer in order and all of
+// them answer the device attributes query, so its answer ends the wait
+// without a timeout on terminals that ignore query.
+func (t *Terminal) probe(query string) string {
 	// Need raw mode for detection
 	oldState, err := term.MakeRaw(t.fd)
 	if err != nil {
-		return false
+		return ""
 	}
 	defer term.Restore(t.fd, oldState)
 
-	// Send query: progressive enhancement query + device attributes query
-	fmt.Fprint(t.out, "\x1b[?u\x1b[c")
+	fmt.Fprint(t.out, query+"\x1b[c")
 
 	// Read response with timeout
 	responseChan := make(chan string, 1)
@@ -1032,8 +1097,7 @@ func (t *Terminal) DetectKittyProtocol() bool {
 				break
 			}
 			response += string(buf[:n])
-			// Check if we have both responses
-			if strings.Contains(response, "\x1b[?") && strings.Contains(response, "c") {
+			if deviceAttributesRe.MatchString(response) {
 				break
 			}
 		}
@@ -1043,15 +1107,10 @@ func (t *Terminal) DetectKittyProtocol() bool {
 
 	select {
 	case response := <-responseChan:
-		// Check for progressive enhancement response (CSI ? <flags> u) AND device attributes (CSI ? ... c)
-		hasProgressiveEnhancement := strings.Contains(response, "\x1b[?") && strings.Contains(response, "u")
-		hasDeviceAttributes := strings.Contains(response, "\x1b[?") && strings.Contains(response, "c")
-		t.kittySupported = hasProgressiveEnhancement && hasDeviceAttributes
+		return response
 	case <-time.After(250 * time.Millisecond):
-		t.kittySupported = false
+		return ""
 	}
-
-	return t.kittySupported
 }
 
 // EnableEnhancedKeyboard enables enhanced keyboard mode (CSI u / kitty keyboard protocol).
@@ -1090,6 +1149,86 @@ func (t *Terminal) DisableEnhancedKeyboard() {
 	t.kittyEnabled = false
 }
 
+// EnableModifyOtherKeys enables xterm's modifyOtherKeys mode (level 1), in
+// which the terminal reports keys such as Shift+Enter and Ctrl+Enter as
+// ESC [ 27 ; modifier ; key ~ instead of sending plain Enter. It is the
+// fallback for terminals without the kitty keyboard protocol, supported by
+// xterm, and by tmux with extended-keys on. Other terminals ignore it.
+//
+// Call DisableModifyOtherKeys() before exiting to restore normal keyboard mode.
+func (t *Terminal) EnableModifyOtherKeys() {
+	if t.modifyOtherKeys {
+		return
+	}
+	fmt.Fprint(t.out, "\033[>4;1m")
+	t.modifyOtherKeys = true
+}
+
+// DisableModifyOtherKeys disables modifyOtherKeys mode.
+func (t *Terminal) DisableModifyOtherKeys() {
+	if !t.modifyOtherKeys {
+		return
+	}
+	fmt.Fprint(t.out, "\033[>4m")
+	t.modifyOtherKeys = false
+}
+
+// KeyboardProtocol identifies how a terminal reports modified keys.
+type KeyboardProtocol int
+
+const (
+	// KeyboardLegacy is the traditional encoding, in which keys such as
+	// Enter, Shift+Enter, and Ctrl+Enter can't be told apart.
+	KeyboardLegacy KeyboardProtocol = iota
+
+	// KeyboardKitty is the kitty keyboard protocol, which reports every
+	// modifier of special keys.
+	KeyboardKitty
+
+	// KeyboardModifyOtherKeys is xterm's modifyOtherKeys mode, which reports
+	// modifiers with keys like Enter and Tab.
+	KeyboardModifyOtherKeys
+)
+
+// String returns the protocol's name.
+func (p KeyboardProtocol) String() string {
+	switch p {
+	case KeyboardKitty:
+		return "kitty"
+	case KeyboardModifyOtherKeys:
+		return "modifyOtherKeys"
+	default:
+		return "legacy"
+	}
+}
+
+// NegotiateKeyboard enables the best keyboard protocol the terminal supports:
+// the kitty protocol if DetectKittyProtocol found it, and modifyOtherKeys
+// otherwise. It returns the protocol enabled. KeyboardModifyOtherKeys only
+// means the mode was requested, since terminals don't confirm it.
+//
+// Call DetectKittyProtocol first, before enabling raw mode.
+func (t *Terminal) NegotiateKeyboard() KeyboardProtocol {
+	if t.kittySupported {
+		t.EnableEnhancedKeyboard()
+	} else {
+		t.EnableModifyOtherKeys()
+	}
+	return t.KeyboardProtocol()
+}
+
+// KeyboardProtocol returns the keyboard protocol currently enabled.
+func (t *Terminal) KeyboardProtocol() KeyboardProtocol {
+	switch {
+	case t.kittyEnabled:
+		return KeyboardKitty
+	case t.modifyOtherKeys:
+		return KeyboardModifyOtherKeys
+	default:
+		return KeyboardLegacy
+	}
+}
+
 // IsKittyProtocolSupported returns true if Kitty keyboard protocol is supported.
 // Only valid after DetectKittyProtocol() has been called.
 func (t *Terminal) IsKittyProtocolSupported() bool {
@@ -1243,7 +1382,7 @@ func (t *Terminal) printInternal(startX, startY int, text string, style Style, c
 		var output strings.Builder
 		output.WriteString(fmt.Sprintf("\033[%d;%dH", startY+1, startX+1))
 		if !style.IsEmpty() {
-			output.WriteString(style.String())
+			output.WriteString(t.StyleSequence(style))
 		}
 		output.WriteString(text)
 		if !style.IsEmpty() {
@@ -1266,7 +1405,7 @@ func (t *Terminal) printInternal(startX, startY int, text string, style Style, c
 		var output strings.Builder
 		output.WriteString(fmt.Sprintf("\033[%d;%dH", startY+1, startX+1))
 		if !style.IsEmpty() {
-			output.WriteString(style.String())
+			output.WriteString(t.StyleSequence(style))
 		}
 		output.WriteString(text)
 		if !style.IsEmpty() {
@@ -1413,7 +1552,7 @@ func (t *Terminal) SetCell(x, y int, char rune, style Style) error {
 func (t *Terminal) setCellInternal(x, y int, char rune, style Style) error {
 	if !t.buffered {
 		// Fallback for non-buffered
-		fmt.Fprintf(t.out, "\033[%d;%dH%s%c\033[0m", y+1, x+1, style.String(), char)
+		fmt.Fprintf(t.out, "\033[%d;%dH%s%c\033[0m", y+1, x+1, t.StyleSequence(style), char)
 		return nil
 	}
 
@@ -1491,7 +1630,7 @@ func (t *Terminal) fillInternal(x, y, width, height int, char rune, style Style)
 	if !t.buffered {
 		line := strings.Repeat(string(char), width)
 		if !style.IsEmpty() {
-			fmt.Fprint(t.out, style.String())
+			fmt.Fprint(t.out, t.StyleSequence(style))
 		}
 		for i := 0; i < height; i++ {
 			fmt.Fprintf(t.out, "\033[%d;%dH%s", y+i+1, x+1, line)
@@ -1722,7 +1861,7 @@ func (t *Terminal) flushInternal() error {
 
 				// Update style if needed
 				if cell.Style != currentStyle {
-					output.WriteString(cell.Style.String())
+					output.WriteString(t.StyleSequence(cell.Style))
 					currentStyle = cell.Style
 					if t.metricsEnabled {
 						ansiCodes++
@@ -1773,9 +1912,13 @@ func (t *Terminal) flushInternal() error {
 		}
 	}
 
-	// Restore cursor to virtual position
-	if currentX != t.virtualX || currentY != t.virtualY {
-		output.WriteString(fmt.Sprintf("\033[%d;%dH", t.virtualY+1, t.virtualX+1))
+	// Restore cursor to virtual position, or to where PlaceCursor put it
+	cursorX, cursorY := t.virtualX, t.virtualY
+	if t.placedCursor != nil {
+		cursorX, cursorY = t.placedCursor.X, t.placedCursor.Y
+	}
+	if currentX != cursorX || currentY != cursorY {
+		output.WriteString(fmt.Sprintf("\033[%d;%dH", cursorY+1, cursorX+1))
 		if t.metricsEnabled {
 			ansiCodes++
 		}
@@ -1989,6 +2132,7 @@ func (t *Terminal) Close() error {
 	t.DisableMouseTracking()
 	t.DisableBracketedPaste()
 	t.DisableEnhancedKeyboard()
+	t.DisableModifyOtherKeys()
 
 	// Restore cursor and screen
 	t.ShowCursor()
@@ -2056,6 +2200,31 @@ func (t *Terminal) WriteRaw(data []byte) error {
 	return nil
 }
 
+// ScreenText returns the text currently displayed on the terminal, as of
+// the last flush, without styling. Rows are separated by newlines and
+// trailing spaces are trimmed.
+func (t *Terminal) ScreenText() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	lines := make([]string, len(t.frontBuffer))
+	for y, row := range t.frontBuffer {
+		var line strings.Builder
+		for _, cell := range row {
+			if cell.Continuation {
+				continue
+			}
+			if cell.Char == 0 {
+				line.WriteByte(' ')
+			} else {
+				line.WriteRune(cell.Char)
+			}
+		}
+		lines[y] = strings.TrimRight(line.String(), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
 // GetCell returns the cell at the given position from the back buffer.
 // Returns an empty cell if the position is out of bounds.
 // This is primarily useful for testing.
@@ -83,6 +83,36 @@ func TestTerminal_EnableDisableEnhancedKeyboard(t *testing.T) {
 	assert.True(t, !term.IsKittyProtocolEnabled())
 }
 
+func TestTerminal_EnableDisableModifyOtherKeys(t *testing.T) {
+	buf := &bytes.Buffer{}
+	term := NewTestTerminal(10, 5, buf)
+
+	term.EnableModifyOtherKeys()
+	term.EnableModifyOtherKeys()
+	assert.Equal(t, buf.String(), "\033[>4;1m")
+	assert.Equal(t, term.KeyboardProtocol(), KeyboardModifyOtherKeys)
+
+	buf.Reset()
+	term.DisableModifyOtherKeys()
+	term.DisableModifyOtherKeys()
+	assert.Equal(t, buf.String(), "\033[>4m")
+	assert.Equal(t, term.KeyboardProtocol(), KeyboardLegacy)
+}
+
+func TestTerminal_NegotiateKeyboard(t *testing.T) {
+	buf := &bytes.Buffer{}
+	term := NewTestTerminal(10, 5, buf)
+	assert.Equal(t, term.NegotiateKeyboard(), KeyboardModifyOtherKeys)
+	assert.Equal(t, buf.String(), "\033[>4;1m")
+
+	buf.Reset()
+	term = NewTestTerminal(10, 5, buf)
+	term.kittySupported = true
+	assert.Equal(t, term.NegotiateKeyboard(), KeyboardKitty)
+	assert.Equal(t, buf.String(), "\033[>1u")
+	assert.Equal(t, term.KeyboardProtocol().String(), "kitty")
+}
+
 func TestTerminal_KittySupportAccessors(t *testing.T) {
 	term := NewTestTerminal(10, 5, &bytes.Buffer{})
 	assert.True(t, !term.IsKittyProtocolSupported())
@@ -334,6 +334,26 @@ func TestTerminal_HideCursor_ShowCursor(t *testing.T) {
 	})
 }
 
+func TestTerminal_PlaceCursor(t *testing.T) {
+	buf := &bytes.Buffer{}
+	term := NewTestTerminal(80, 24, buf)
+
+	term.PlaceCursor(4, 2, true)
+	assert.Equal(t, "\033[3;5H\033[?25h", buf.String())
+
+	// Flushes restore the cursor to the placed position and keep it visible
+	buf.Reset()
+	frame, _ := term.BeginFrame()
+	frame.PrintStyled(0, 0, "hi", NewStyle())
+	term.EndFrame(frame)
+	assert.Contains(t, buf.String(), "\033[3;5H")
+	assert.Regexp(t, `\x1b\[\?25h$`, buf.String())
+
+	buf.Reset()
+	term.PlaceCursor(0, 0, false)
+	assert.Equal(t, "\033[?25l", buf.String())
+}
+
 func TestTerminal_BufferInitialization(t *testing.T) {
 	term := NewTestTerminal(80, 24, &bytes.Buffer{})
 
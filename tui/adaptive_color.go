@@ -0,0 +1,66 @@
+package tui
+
+// AdaptiveColor is a color with one value for terminals with a light
+// background and one for terminals with a dark background. The Runtime
+// asks the terminal for its background at startup (see
+// Terminal.DetectBackground), and the value that reads on it is used when
+// the view is drawn. Terminals that don't say get Dark.
+//
+// Example:
+//
+//	subtle := tui.AdaptiveColor{Light: tui.NewRGB(90, 90, 90), Dark: tui.NewRGB(160, 160, 160)}
+//	tui.Text("last synced 2m ago").FgAdaptive(subtle)
+type AdaptiveColor struct {
+	Light RGB
+	Dark  RGB
+}
+
+// Resolve returns Dark if dark is true, and Light otherwise.
+func (c AdaptiveColor) Resolve(dark bool) RGB {
+	if dark {
+		return c.Dark
+	}
+	return c.Light
+}
+
+// DarkBackground reports whether views in this context are drawn on a dark
+// terminal background. It is true when the background is unknown, as most
+// terminals are dark.
+func (c *RenderContext) DarkBackground() bool {
+	return c.background == nil || c.background.IsDark()
+}
+
+// WithBackground returns a new context for drawing on a terminal with
+// background color bg.
+func (c *RenderContext) WithBackground(bg RGB) *RenderContext {
+	sub := *c
+	sub.background = &bg
+	return &sub
+}
+
+// FgAdaptive sets the foreground to the color of c that reads on the
+// terminal's background.
+func (t *textView) FgAdaptive(c AdaptiveColor) *textView {
+	t.fgAdaptive = &c
+	return t
+}
+
+// BgAdaptive sets the background to the color of c for the terminal's
+// background.
+func (t *textView) BgAdaptive(c AdaptiveColor) *textView {
+	t.bgAdaptive = &c
+	return t
+}
+
+// adaptStyle sets the colors of style from fg and bg, either of which may be
+// nil, for the context's background.
+func adaptStyle(ctx *RenderContext, style Style, fg, bg *AdaptiveColor) Style {
+	dark := ctx.DarkBackground()
+	if fg != nil {
+		style = style.WithFgRGB(fg.Resolve(dark))
+	}
+	if bg != nil {
+		style = style.WithBgRGB(bg.Resolve(dark))
+	}
+	return style
+}
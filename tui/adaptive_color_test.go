@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestAdaptiveColor_FollowsTerminalBackground(t *testing.T) {
+	subtle := AdaptiveColor{Light: NewRGB(90, 90, 90), Dark: NewRGB(160, 160, 160)}
+	terminal := NewTestTerminal(10, 2, &bytes.Buffer{})
+	app := &simpleApp{
+		renderFunc: func() View {
+			return Stack(
+				Text("a").FgAdaptive(subtle),
+				Text("b").Role(RoleText),
+			)
+		},
+		handleFunc: func(Event) []Cmd { return nil },
+	}
+	runtime := NewRuntime(terminal, app, 30)
+
+	// Unknown backgrounds are taken as dark
+	runtime.render()
+	assert.Equal(t, &subtle.Dark, terminal.GetCell(0, 0).Style.FgRGB)
+	assert.Equal(t, &DarkTheme.Text, terminal.GetCell(0, 1).Style.FgRGB)
+
+	// On a light background the light colors and theme are used
+	terminal.SetBackground(NewRGB(253, 246, 227))
+	runtime.render()
+	assert.Equal(t, &subtle.Light, terminal.GetCell(0, 0).Style.FgRGB)
+	assert.Equal(t, &LightTheme.Text, terminal.GetCell(0, 1).Style.FgRGB)
+
+	// A theme set by the application wins
+	runtime.SetTheme(HighContrastTheme)
+	runtime.render()
+	assert.Equal(t, &HighContrastTheme.Text, terminal.GetCell(0, 1).Style.FgRGB)
+}
@@ -0,0 +1,114 @@
+package tui
+
+import "image"
+
+// AlignmentGuide gives views in different containers a shared column width,
+// like SwiftUI alignment guides. Views tagged with the same column ID are
+// all laid out at the width of the widest one, so labels in separate rows
+// line up without hand-computed padding.
+//
+// Views are rebuilt every frame, so create a new guide in View() rather than
+// keeping one in application state; a guide accumulates every view tagged
+// through it.
+//
+// Example:
+//
+//	guide := tui.NewAlignmentGuide().ColumnAlign("label", tui.AlignRight)
+//	tui.Stack(
+//	    tui.Group(guide.Column("label", tui.Text("Name:")), tui.Text(" %s", name)),
+//	    tui.Group(guide.Column("label", tui.Text("Email address:")), tui.Text(" %s", email)),
+//	)
+type AlignmentGuide struct {
+	columns map[string]*alignmentColumn
+}
+
+// alignmentColumn is the set of views sharing one column width.
+type alignmentColumn struct {
+	members   []*alignedView
+	alignment Alignment
+}
+
+// NewAlignmentGuide creates an empty alignment guide.
+func NewAlignmentGuide() *AlignmentGuide {
+	return &AlignmentGuide{columns: make(map[string]*alignmentColumn)}
+}
+
+func (g *AlignmentGuide) column(id string) *alignmentColumn {
+	col, ok := g.columns[id]
+	if !ok {
+		col = &alignmentColumn{alignment: AlignLeft}
+		g.columns[id] = col
+	}
+	return col
+}
+
+// Column tags a view as a member of the column with the given ID. The
+// returned view is as wide as the widest member of the column.
+func (g *AlignmentGuide) Column(id string, view View) View {
+	col := g.column(id)
+	v := &alignedView{inner: view, column: col}
+	col.members = append(col.members, v)
+	return v
+}
+
+// ColumnAlign sets how narrower members are positioned within the column's
+// width: AlignLeft (default), AlignCenter, or AlignRight.
+func (g *AlignmentGuide) ColumnAlign(id string, align Alignment) *AlignmentGuide {
+	g.column(id).alignment = align
+	return g
+}
+
+// Width returns the shared width of a column given the available width,
+// or 0 if the column has no members.
+func (g *AlignmentGuide) Width(id string, maxWidth int) int {
+	col, ok := g.columns[id]
+	if !ok {
+		return 0
+	}
+	return col.width(maxWidth)
+}
+
+// width returns the widest member's width, capped at maxWidth.
+func (c *alignmentColumn) width(maxWidth int) int {
+	w := 0
+	for _, m := range c.members {
+		if mw, _ := m.inner.size(maxWidth, 0); mw > w {
+			w = mw
+		}
+	}
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	return w
+}
+
+// alignedView is a view whose width is shared with the rest of its column.
+type alignedView struct {
+	inner  View
+	column *alignmentColumn
+}
+
+func (a *alignedView) size(maxWidth, maxHeight int) (int, int) {
+	w := a.column.width(maxWidth)
+	_, h := a.inner.size(w, maxHeight)
+	return w, h
+}
+
+func (a *alignedView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	innerW, _ := a.inner.size(width, height)
+	if innerW > width {
+		innerW = width
+	}
+	x := 0
+	switch a.column.alignment {
+	case AlignCenter:
+		x = (width - innerW) / 2
+	case AlignRight:
+		x = width - innerW
+	}
+	a.inner.render(ctx.SubContext(image.Rect(x, 0, x+innerW, height)))
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestAlignmentGuide_SharedWidth(t *testing.T) {
+	guide := NewAlignmentGuide()
+	view := Stack(
+		Group(guide.Column("label", Text("Name:")), Text("Ada")),
+		Group(guide.Column("label", Text("Email address:")), Text("ada@example.com")),
+	)
+
+	screen := SprintScreen(view, PrintConfig{Width: 40})
+	assert.Equal(t, "Name:         Ada", screen.Row(0))
+	assert.Equal(t, "Email address:ada@example.com", screen.Row(1))
+	assert.Equal(t, 14, guide.Width("label", 40))
+	assert.Equal(t, 0, guide.Width("missing", 40))
+}
+
+func TestAlignmentGuide_ColumnAlign(t *testing.T) {
+	guide := NewAlignmentGuide().ColumnAlign("label", AlignRight)
+	view := Stack(
+		Group(guide.Column("label", Text("Id:")), Text(" 7")),
+		Group(guide.Column("label", Text("Title:")), Text(" Dune")),
+	)
+
+	screen := SprintScreen(view, PrintConfig{Width: 40})
+	assert.Equal(t, "   Id: 7", screen.Row(0))
+	assert.Equal(t, "Title: Dune", screen.Row(1))
+
+	guide.ColumnAlign("label", AlignCenter)
+	screen = SprintScreen(view, PrintConfig{Width: 40})
+	assert.Equal(t, " Id:   7", screen.Row(0))
+}
+
+func TestAlignmentGuide_IndependentColumns(t *testing.T) {
+	guide := NewAlignmentGuide()
+	view := Stack(
+		Group(guide.Column("a", Text("x")), Text("|"), guide.Column("b", Text("long value")), Text("|")),
+		Group(guide.Column("a", Text("xyz")), Text("|"), guide.Column("b", Text("v")), Text("|")),
+	)
+
+	screen := SprintScreen(view, PrintConfig{Width: 40})
+	assert.Equal(t, "x  |long value|", screen.Row(0))
+	assert.Equal(t, "xyz|v         |", screen.Row(1))
+}
+
+func TestAlignmentGuide_CapsAtMaxWidth(t *testing.T) {
+	guide := NewAlignmentGuide()
+	guide.Column("c", Text("short"))
+	wide := guide.Column("c", Text("a much longer label"))
+
+	w, h := wide.size(10, 0)
+	assert.Equal(t, 10, w)
+	assert.Equal(t, 1, h)
+}
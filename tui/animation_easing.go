@@ -219,8 +219,44 @@ func EaseInOutBounce(t float64) float64 {
 	return (1 + EaseOutBounce(2*t-1)) / 2
 }
 
+// EaseSpring moves like a bouncy spring: it overshoots the end and settles
+// with a few shrinking oscillations. See EaseSpringDamped to choose how
+// bouncy.
+func EaseSpring(t float64) float64 {
+	return springAt(t, 0.35)
+}
+
 // Custom easing function builders
 
+// EaseSpringDamped returns a spring easing with the given damping ratio,
+// from just above 0 (oscillates many times) to 1 (critically damped: the
+// fastest approach with no overshoot). Values outside that range are
+// clamped.
+func EaseSpringDamped(damping float64) Easing {
+	damping = max(0.05, min(1, damping))
+	return func(t float64) float64 {
+		return springAt(t, damping)
+	}
+}
+
+// springAt returns the position of a damped spring released at 0 toward 1,
+// with time scaled so that it has settled to within 0.1% at t=1.
+func springAt(t, damping float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	if t >= 1 {
+		return 1
+	}
+	// With a natural frequency of 1, the envelope decays as e^(-damping*x)
+	x := t * math.Log(1000) / damping
+	if damping >= 1 {
+		return 1 - math.Exp(-x)*(1+x)
+	}
+	freq := math.Sqrt(1 - damping*damping)
+	return 1 - math.Exp(-damping*x)*(math.Cos(freq*x)+damping/freq*math.Sin(freq*x))
+}
+
 // EaseChain chains multiple easing functions sequentially.
 // Each function is applied to an equal portion of the total time.
 func EaseChain(easings ...Easing) Easing {
@@ -0,0 +1,238 @@
+package tui
+
+import "time"
+
+// Animator is a clock for animated values. Create values with Float, Color,
+// or Animate, start them moving with To or Keyframes, and read them with
+// Value when building views; the Animator works out where each value is on
+// its timeline from the current frame, so apps don't do frame arithmetic
+// themselves.
+//
+// Advance the Animator with Tick (or Update) from TickEvents. Create values once, with
+// the rest of the application's state; like that state, they should only be
+// used from HandleEvent and View.
+//
+// Example:
+//
+//	type App struct {
+//	    anim   *tui.Animator
+//	    width  *tui.Animated[float64]
+//	    accent *tui.Animated[tui.RGB]
+//	}
+//
+//	func NewApp() *App {
+//	    anim := tui.NewAnimator()
+//	    return &App{
+//	        anim:   anim,
+//	        width:  anim.Float(20),
+//	        accent: anim.Color(tui.NewRGB(80, 80, 80)),
+//	    }
+//	}
+//
+//	func (app *App) HandleEvent(e tui.Event) []tui.Cmd {
+//	    switch e := e.(type) {
+//	    case tui.TickEvent:
+//	        app.anim.Tick(e)
+//	    case tui.KeyEvent:
+//	        app.width.To(40, 15, tui.EaseSpring)
+//	        app.accent.To(tui.NewRGB(255, 180, 0), 10, tui.EaseOutQuad)
+//	    }
+//	    return nil
+//	}
+//
+//	func (app *App) View() tui.View {
+//	    accent := app.accent.Value()
+//	    return tui.Width(int(app.width.Value()),
+//	        tui.Text("Sidebar").FgRGB(accent.R, accent.G, accent.B))
+//	}
+type Animator struct {
+	frame  uint64
+	now    time.Time // time of the current frame, zero if only Update is used
+	values []interface{ Running() bool }
+}
+
+// NewAnimator creates an Animator at frame 0.
+func NewAnimator() *Animator {
+	return &Animator{}
+}
+
+// Update sets the current frame, normally TickEvent.Frame.
+func (a *Animator) Update(frame uint64) {
+	a.frame = frame
+}
+
+// Tick advances the Animator to a TickEvent's frame and time. Values with a
+// FrameRate need the time, so use Tick rather than Update for them.
+func (a *Animator) Tick(e TickEvent) {
+	a.frame, a.now = e.Frame, e.Time
+}
+
+// Frame returns the current frame.
+func (a *Animator) Frame() uint64 {
+	return a.frame
+}
+
+// Running reports whether any of the Animator's values is still moving,
+// for apps that only tick while something is animating.
+func (a *Animator) Running() bool {
+	for _, v := range a.values {
+		if v.Running() {
+			return true
+		}
+	}
+	return false
+}
+
+// Float creates an animated number starting at value.
+func (a *Animator) Float(value float64) *Animated[float64] {
+	return Animate(a, value, LerpFloat)
+}
+
+// Color creates an animated color starting at value. Colors move in a
+// straight line through RGB space, without overshooting.
+func (a *Animator) Color(value RGB) *Animated[RGB] {
+	return Animate(a, value, func(from, to RGB, t float64) RGB {
+		return blendRGB(from, to, max(0, min(1, t)))
+	})
+}
+
+// Animate creates an animated value of any type, starting at value, that
+// moves between keyframes with lerp. lerp returns from at t=0 and to at t=1;
+// with overshooting easings such as EaseSpring, t also goes a little below 0
+// and above 1.
+func Animate[T any](a *Animator, value T, lerp func(from, to T, t float64) T) *Animated[T] {
+	v := &Animated[T]{animator: a, lerp: lerp, from: value, value: value}
+	a.values = append(a.values, v)
+	return v
+}
+
+// LerpFloat returns from at t=0 and to at t=1, and the values in between
+// (or beyond) for other t.
+func LerpFloat(from, to, t float64) float64 {
+	return from + (to-from)*t
+}
+
+// Keyframe is a point on an animated value's timeline.
+type Keyframe[T any] struct {
+	At     int    // Frames after the animation starts
+	Value  T      // The value at that frame
+	Easing Easing // How the value moves from the previous keyframe; nil is linear
+}
+
+// Animated is a value that moves over time on an Animator's timeline. Read
+// it with Value.
+type Animated[T any] struct {
+	animator *Animator
+	lerp     func(from, to T, t float64) T
+	from     T // value the keyframes start from
+	keys     []Keyframe[T]
+	start    uint64    // frame the keyframes started on
+	started  time.Time // time the keyframes started, for a frame rate
+	fps      int       // frame rate the keyframes count in, or 0 for ticks
+	loop     bool
+	value    T // value once the keyframes have finished
+}
+
+// Value returns the value at the Animator's current frame.
+func (v *Animated[T]) Value() T {
+	if len(v.keys) == 0 {
+		return v.value
+	}
+	elapsed := v.elapsed()
+	total := v.keys[len(v.keys)-1].At
+	if v.loop && total > 0 {
+		elapsed %= total
+	} else if elapsed >= total {
+		// Finished; keep the last value
+		v.value = v.keys[len(v.keys)-1].Value
+		v.keys = nil
+		return v.value
+	}
+
+	prevAt, prev := 0, v.from
+	for _, k := range v.keys {
+		if elapsed < k.At {
+			t := float64(elapsed-prevAt) / float64(k.At-prevAt)
+			if k.Easing != nil {
+				t = k.Easing(t)
+			}
+			return v.lerp(prev, k.Value, t)
+		}
+		prevAt, prev = k.At, k.Value
+	}
+	return prev
+}
+
+// elapsed returns the frames since the keyframes started.
+func (v *Animated[T]) elapsed() int {
+	a := v.animator
+	if v.fps > 0 && !a.now.IsZero() && !v.started.IsZero() {
+		if d := a.now.Sub(v.started); d > 0 {
+			return int(d * time.Duration(v.fps) / time.Second)
+		}
+		return 0
+	}
+	if a.frame > v.start {
+		return int(a.frame - v.start)
+	}
+	return 0
+}
+
+// Running reports whether the value is still moving. Looping values are
+// always running.
+func (v *Animated[T]) Running() bool {
+	if len(v.keys) == 0 {
+		return false
+	}
+	v.Value() // clears finished keyframes
+	return len(v.keys) > 0
+}
+
+// Set stops any animation and sets the value.
+func (v *Animated[T]) Set(value T) *Animated[T] {
+	v.keys = nil
+	v.value = value
+	return v
+}
+
+// To animates the value from where it is now to target over the given
+// number of frames.
+func (v *Animated[T]) To(target T, frames int, easing Easing) *Animated[T] {
+	return v.Keyframes(Keyframe[T]{At: frames, Value: target, Easing: easing})
+}
+
+// Keyframes animates the value through keyframes, starting now from where
+// it is. A keyframe at frame 0 sets the starting value instead. Keyframes
+// must be in order of At.
+func (v *Animated[T]) Keyframes(keys ...Keyframe[T]) *Animated[T] {
+	from := v.Value()
+	if len(keys) > 0 && keys[0].At <= 0 {
+		from = keys[0].Value
+		keys = keys[1:]
+	}
+	v.from = from
+	v.start = v.animator.frame
+	v.started = v.animator.now
+	v.keys = keys
+	v.value = from
+	if len(keys) == 0 {
+		v.keys = nil
+	}
+	return v
+}
+
+// FrameRate makes the value's keyframes count frames at fps per second of
+// real time rather than the app's ticks, so an animation takes the same time
+// whatever FPS the app runs at, for example 60 for a motion designed at 60
+// FPS in an app ticking at 30. The Animator must be advanced with Tick.
+func (v *Animated[T]) FrameRate(fps int) *Animated[T] {
+	v.fps = fps
+	return v
+}
+
+// Loop sets whether the keyframes repeat from the start once they finish,
+// for effects like pulsing and breathing.
+func (v *Animated[T]) Loop(loop bool) *Animated[T] {
+	v.loop = loop
+	return v
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestAnimator_To(t *testing.T) {
+	anim := NewAnimator()
+	x := anim.Float(10)
+	assert.Equal(t, x.Value(), 10.0)
+	assert.False(t, anim.Running())
+
+	anim.Update(100)
+	x.To(20, 10, nil)
+	assert.True(t, anim.Running())
+	assert.Equal(t, x.Value(), 10.0)
+	anim.Update(105)
+	assert.Equal(t, x.Value(), 15.0)
+
+	// Retargeting starts from where the value is now
+	x.To(0, 5, EaseLinear)
+	anim.Update(106)
+	assert.Equal(t, x.Value(), 12.0)
+	anim.Update(110)
+	assert.Equal(t, x.Value(), 0.0)
+	assert.False(t, anim.Running())
+	anim.Update(200)
+	assert.Equal(t, x.Value(), 0.0)
+}
+
+func TestAnimator_KeyframesAndLoop(t *testing.T) {
+	anim := NewAnimator()
+	x := anim.Float(0).Keyframes(
+		Keyframe[float64]{At: 0, Value: 1},
+		Keyframe[float64]{At: 4, Value: 5},
+		Keyframe[float64]{At: 6, Value: 1, Easing: EaseInQuad},
+	).Loop(true)
+
+	values := []float64{}
+	for frame := uint64(0); frame <= 8; frame++ {
+		anim.Update(frame)
+		values = append(values, x.Value())
+	}
+	assert.Equal(t, values, []float64{1, 2, 3, 4, 5, 4, 1, 2, 3})
+	assert.True(t, anim.Running())
+
+	x.Set(7)
+	assert.Equal(t, x.Value(), 7.0)
+	assert.False(t, anim.Running())
+}
+
+func TestAnimator_Color(t *testing.T) {
+	anim := NewAnimator()
+	c := anim.Color(NewRGB(0, 0, 0)).To(NewRGB(200, 100, 0), 4, nil)
+	anim.Update(2)
+	assert.Equal(t, c.Value(), NewRGB(100, 50, 0))
+}
+
+func TestEaseSpring(t *testing.T) {
+	assert.Equal(t, EaseSpring(0), 0.0)
+	assert.Equal(t, EaseSpring(1), 1.0)
+
+	// It overshoots, then settles
+	peak := 0.0
+	for i := 0; i <= 100; i++ {
+		peak = max(peak, EaseSpring(float64(i)/100))
+	}
+	assert.True(t, peak > 1.1)
+	assert.InDelta(t, EaseSpring(0.99), 1.0, 0.01)
+
+	// Critically damped springs don't overshoot
+	critical := EaseSpringDamped(1)
+	for i := 0; i <= 100; i++ {
+		assert.True(t, critical(float64(i)/100) <= 1)
+	}
+}
+
+func TestAnimator_FrameRate(t *testing.T) {
+	anim := NewAnimator()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	anim.Tick(TickEvent{Time: start, Frame: 1})
+
+	// 60 frames at 60 FPS take a second, however often the app ticks
+	x := anim.Float(0).FrameRate(60).To(60, 60, nil)
+	anim.Tick(TickEvent{Time: start.Add(250 * time.Millisecond), Frame: 2})
+	assert.Equal(t, x.Value(), 15.0)
+	anim.Tick(TickEvent{Time: start.Add(time.Second), Frame: 3})
+	assert.Equal(t, x.Value(), 60.0)
+	assert.False(t, anim.Running())
+
+	// Without one, keyframes count ticks
+	y := anim.Float(0).To(10, 10, nil)
+	anim.Tick(TickEvent{Time: start.Add(time.Hour), Frame: 8})
+	assert.Equal(t, y.Value(), 5.0)
+}
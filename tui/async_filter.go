@@ -0,0 +1,257 @@
+package tui
+
+import (
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/fuzzy"
+)
+
+// defaultFilterChunk is the number of items an AsyncFilter matches per
+// command.
+const defaultFilterChunk = 10000
+
+// AsyncFilter fuzzy-filters a large set of list items in the background,
+// so typing in a FilterableList stays responsive with hundreds of thousands
+// of items. Matching runs in commands of ChunkSize items each; the list shows
+// the matches found so far, ranked, while the rest are filtered. A new query
+// cancels the run in progress. When the new query extends a finished one,
+// only the previous matches are filtered again.
+//
+// Pass every event to Update along with the current query:
+//
+//	app.filter = tui.NewAsyncFilter(items)
+//
+//	func (app *App) HandleEvent(event tui.Event) []tui.Cmd {
+//	    return app.filter.Update(app.query, event)
+//	}
+//
+//	func (app *App) View() tui.View {
+//	    return tui.FilterableList(app.items, &app.selected).
+//	        Filter(&app.query).
+//	        AsyncFilter(app.filter)
+//	}
+type AsyncFilter struct {
+	// ChunkSize is the number of items matched per command. Defaults to
+	// 10,000.
+	ChunkSize int
+
+	// Collator orders equally good matches with labels of the same length,
+	// such as LocaleCollator("de"). Without one they keep the items'
+	// order. A FilterableList with a Collator sets it if it is nil.
+	Collator Collator
+
+	items  []ListItem
+	labels []string
+
+	started  bool
+	query    string         // query of the current run
+	gen      atomic.Int64   // incremented to cancel the current run
+	pool     []int          // items the run filters; nil for all of them
+	next     int            // position in pool (or items) of the next chunk
+	pending  bool           // whether a chunk command is running
+	finished bool           // whether the run has filtered every item
+	results  []fuzzy.Ranked // matches so far, best first; Index is the item index
+	indexes  []int          // item indexes of results, built by Matches
+}
+
+// AsyncFilterEvent carries the matches found in one chunk of an
+// AsyncFilter run. Pass it to AsyncFilter.Update.
+type AsyncFilterEvent struct {
+	filter  *AsyncFilter
+	gen     int64
+	end     int            // position after the chunk
+	matches []fuzzy.Ranked // ranked matches in the chunk
+	Time    time.Time
+}
+
+// Timestamp implements Event.
+func (e AsyncFilterEvent) Timestamp() time.Time { return e.Time }
+
+// NewAsyncFilter creates a filter over items.
+func NewAsyncFilter(items []ListItem) *AsyncFilter {
+	f := &AsyncFilter{}
+	f.SetItems(items)
+	return f
+}
+
+// SetItems replaces the items being filtered. The next call to Update
+// filters them from scratch.
+func (f *AsyncFilter) SetItems(items []ListItem) {
+	f.items = items
+	f.labels = make([]string, len(items))
+	for i, item := range items {
+		f.labels[i] = item.Label
+	}
+	f.gen.Add(1)
+	f.started = false
+}
+
+// Update starts filtering when query has changed and continues the run
+// with each AsyncFilterEvent it produces. Call it from HandleEvent with
+// every event and return the commands it returns.
+func (f *AsyncFilter) Update(query string, event Event) []Cmd {
+	if e, ok := event.(AsyncFilterEvent); ok && e.filter == f && e.gen == f.gen.Load() {
+		f.results, f.indexes = mergeRanked(f.results, e.matches, f.labels, f.Collator), nil
+		f.next, f.pending = e.end, false
+		f.finished = f.next >= f.size()
+	}
+	if !f.started || query != f.query {
+		f.start(query)
+	}
+	if f.finished || f.pending {
+		return nil
+	}
+	f.pending = true
+	return []Cmd{f.chunk()}
+}
+
+// start begins a run for query, cancelling any run in progress.
+func (f *AsyncFilter) start(query string) {
+	prev, prevFinished := f.query, f.started && f.finished
+	f.gen.Add(1)
+	f.started, f.query, f.next, f.pending = true, query, 0, false
+
+	switch {
+	case query == "":
+		f.pool, f.indexes = nil, nil
+		f.results = make([]fuzzy.Ranked, len(f.items))
+		for i := range f.items {
+			f.results[i].Index = i
+		}
+		f.finished = true
+		return
+	case prevFinished && prev != "" && len(query) > len(prev) && query[:len(prev)] == prev:
+		// Every match of the new query also matches the previous one
+		pool := make([]int, len(f.results))
+		for i, r := range f.results {
+			pool[i] = r.Index
+		}
+		slices.Sort(pool)
+		f.pool = pool
+	default:
+		f.pool = nil
+	}
+	f.results, f.indexes = nil, nil
+	f.finished = f.size() == 0
+}
+
+// size returns the number of items the run filters.
+func (f *AsyncFilter) size() int {
+	if f.pool != nil {
+		return len(f.pool)
+	}
+	return len(f.items)
+}
+
+// chunk returns a command that filters the next chunk of the run, stopping
+// early if the run is cancelled.
+func (f *AsyncFilter) chunk() Cmd {
+	gen, query, pool, labels, collator := f.gen.Load(), f.query, f.pool, f.labels, f.Collator
+	start := f.next
+	size := f.ChunkSize
+	if size <= 0 {
+		size = defaultFilterChunk
+	}
+	end := min(start+size, f.size())
+
+	return func() Event {
+		event := AsyncFilterEvent{filter: f, gen: gen, end: end}
+		candidates := make([]string, 0, end-start)
+		for i := start; i < end; i++ {
+			if pool != nil {
+				candidates = append(candidates, labels[pool[i]])
+			} else {
+				candidates = append(candidates, labels[i])
+			}
+		}
+		if f.gen.Load() == gen {
+			event.matches = fuzzy.RankFunc(query, candidates, collator)
+			for i := range event.matches {
+				if pool != nil {
+					event.matches[i].Index = pool[start+event.matches[i].Index]
+				} else {
+					event.matches[i].Index += start
+				}
+			}
+		}
+		event.Time = time.Now()
+		return event
+	}
+}
+
+// Query returns the query of the current run.
+func (f *AsyncFilter) Query() string {
+	return f.query
+}
+
+// Busy reports whether a run is still in progress.
+func (f *AsyncFilter) Busy() bool {
+	return f.started && !f.finished
+}
+
+// Progress returns how many of the items the current run filters have been
+// filtered so far, and how many there are.
+func (f *AsyncFilter) Progress() (done, total int) {
+	if f.finished {
+		return f.size(), f.size()
+	}
+	return f.next, f.size()
+}
+
+// Matches returns the indexes of the matching items found so far, best
+// match first. The slice is shared and must not be modified.
+func (f *AsyncFilter) Matches() []int {
+	if f.indexes == nil {
+		f.indexes = make([]int, len(f.results))
+		for i, r := range f.results {
+			f.indexes[i] = r.Index
+		}
+	}
+	return f.indexes
+}
+
+// positions returns the matched label positions of the i-th match.
+func (f *AsyncFilter) positions(i int) []int {
+	if i < 0 || i >= len(f.results) {
+		return nil
+	}
+	return f.results[i].Positions
+}
+
+// mergeRanked merges two ranked lists of matches, keeping the order of
+// fuzzy.RankFunc: best score first, then shortest label, then by collator,
+// then first item.
+func mergeRanked(a, b []fuzzy.Ranked, labels []string, collator Collator) []fuzzy.Ranked {
+	if len(b) == 0 {
+		return a
+	}
+	less := func(x, y fuzzy.Ranked) bool {
+		if x.Score != y.Score {
+			return x.Score > y.Score
+		}
+		if lx, ly := len(labels[x.Index]), len(labels[y.Index]); lx != ly {
+			return lx < ly
+		}
+		if collator != nil {
+			if c := collator(labels[x.Index], labels[y.Index]); c != 0 {
+				return c < 0
+			}
+		}
+		return x.Index < y.Index
+	}
+	merged := make([]fuzzy.Ranked, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if less(b[j], a[i]) {
+			merged = append(merged, b[j])
+			j++
+		} else {
+			merged = append(merged, a[i])
+			i++
+		}
+	}
+	merged = append(merged, a[i:]...)
+	return append(merged, b[j:]...)
+}
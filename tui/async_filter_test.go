@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/fuzzy"
+)
+
+// runAsyncFilter runs the filter's commands until it finishes.
+func runAsyncFilter(f *AsyncFilter, query string) {
+	cmds := f.Update(query, KeyEvent{})
+	for len(cmds) > 0 {
+		cmds = f.Update(query, cmds[0]())
+	}
+}
+
+func asyncFilterItems(n int) []ListItem {
+	items := make([]ListItem, n)
+	for i := range items {
+		items[i] = ListItem{Label: fmt.Sprintf("item-%d/%s", i, []string{"alpha", "beta", "gamma"}[i%3])}
+	}
+	return items
+}
+
+func TestAsyncFilter_MatchesRank(t *testing.T) {
+	items := asyncFilterItems(95)
+	f := NewAsyncFilter(items)
+	f.ChunkSize = 10
+
+	runAsyncFilter(f, "1bet")
+	assert.False(t, f.Busy())
+
+	labels := make([]string, len(items))
+	for i, item := range items {
+		labels[i] = item.Label
+	}
+	var want []int
+	for _, r := range fuzzy.Rank("1bet", labels) {
+		want = append(want, r.Index)
+	}
+	assert.Equal(t, want, f.Matches())
+
+	// Narrowing the query only filters the previous matches
+	cmds := f.Update("1beta", KeyEvent{})
+	_, total := f.Progress()
+	assert.Equal(t, len(want), total)
+	for len(cmds) > 0 {
+		cmds = f.Update("1beta", cmds[0]())
+	}
+	for _, r := range fuzzy.Rank("1beta", labels) {
+		assert.Contains(t, f.Matches(), r.Index)
+	}
+
+	// An empty query lists everything at once
+	assert.Empty(t, f.Update("", KeyEvent{}))
+	assert.Len(t, f.Matches(), len(items))
+}
+
+func TestAsyncFilter_Chunks(t *testing.T) {
+	f := NewAsyncFilter(asyncFilterItems(30))
+	f.ChunkSize = 10
+
+	cmds := f.Update("alpha", KeyEvent{})
+	assert.Len(t, cmds, 1)
+	assert.True(t, f.Busy())
+
+	// Other events don't start more commands while one is running
+	assert.Empty(t, f.Update("alpha", KeyEvent{}))
+
+	// Matches arrive chunk by chunk
+	cmds = f.Update("alpha", cmds[0]())
+	done, total := f.Progress()
+	assert.Equal(t, 10, done)
+	assert.Equal(t, 30, total)
+	assert.Len(t, f.Matches(), 4)
+
+	// A new query cancels the run; its stale results are dropped
+	stale := cmds[0]
+	cmds = f.Update("beta", KeyEvent{})
+	assert.Len(t, cmds, 1)
+	assert.Empty(t, f.Update("beta", stale()))
+	assert.Empty(t, f.Matches())
+
+	for len(cmds) > 0 {
+		cmds = f.Update("beta", cmds[0]())
+	}
+	assert.Len(t, f.Matches(), 10)
+	assert.False(t, f.Busy())
+}
+
+func TestFilterableList_AsyncFilter(t *testing.T) {
+	items := asyncFilterItems(30)
+	f := NewAsyncFilter(items)
+	f.ChunkSize = 20
+	selected := 0
+	query := "gamma"
+	view := func() View {
+		return FilterableList(items, &selected).Filter(&query).AsyncFilter(f).Height(4)
+	}
+
+	cmds := f.Update(query, KeyEvent{})
+	f.Update(query, cmds[0]())
+	screen := SprintScreen(view(), PrintConfig{Width: 20, Height: 4})
+	assert.Equal(t, "Filter: gamma    66%", screen.Row(0))
+	assert.Equal(t, "item-2/gamma", screen.Row(2))
+	assert.True(t, screen.Cell(7, 2).Style.Bold)
+}
+
+func TestAsyncFilter_CollatesTies(t *testing.T) {
+	// Equally good matches of the same length
+	items := []ListItem{{Label: "Zoo-item"}, {Label: "Äp-item"}, {Label: "Bar-item"}}
+	labels := []string{"Zoo-item", "Äp-item", "Bar-item"}
+	collator := LocaleCollator("de")
+
+	var want []int
+	for _, r := range fuzzy.RankFunc("item", labels, collator) {
+		want = append(want, r.Index)
+	}
+	assert.Equal(t, []int{1, 2, 0}, want)
+
+	// Ties across chunks are merged in the same order
+	f := NewAsyncFilter(items)
+	f.ChunkSize = 1
+	f.Collator = collator
+	runAsyncFilter(f, "item")
+	assert.Equal(t, want, f.Matches())
+
+	// A list passes its collator to the filter
+	f = NewAsyncFilter(items)
+	f.ChunkSize = 1
+	selected := 0
+	query := "item"
+	view := func() View {
+		return FilterableList(items, &selected).Filter(&query).AsyncFilter(f).Collator(collator).Height(5)
+	}
+	SprintScreen(view(), PrintConfig{Width: 20, Height: 5})
+	runAsyncFilter(f, query)
+	screen := SprintScreen(view(), PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, "Äp-item", screen.Row(2))
+	assert.Equal(t, "Bar-item", screen.Row(3))
+	assert.Equal(t, "Zoo-item", screen.Row(4))
+}
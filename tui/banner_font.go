@@ -0,0 +1,307 @@
+package tui
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//go:embed fonts/*.flf
+var bannerFontFiles embed.FS
+
+// Built-in banner fonts. All four draw the same 5-pixel-tall letters, and
+// uppercase and lowercase letters look the same.
+var (
+	// BannerBlock draws each pixel as two full blocks, for square pixels.
+	// It is the widest font: about 10 columns per letter.
+	BannerBlock = mustLoadBannerFont("block")
+
+	// BannerSlim draws each pixel as one full block, about 5 columns per
+	// letter.
+	BannerSlim = mustLoadBannerFont("slim")
+
+	// BannerSmall packs two rows of pixels into each line with half blocks,
+	// so it is 3 lines tall.
+	BannerSmall = mustLoadBannerFont("small")
+
+	// BannerASCII draws each pixel as '#', for terminals without Unicode.
+	BannerASCII = mustLoadBannerFont("ascii")
+)
+
+// BannerFont is a FIGlet font for Banner. Use one of the built-in fonts, or
+// load any FIGlet (.flf) font with ParseBannerFont.
+type BannerFont struct {
+	name      string
+	height    int
+	hardblank rune
+	fitting   bool
+	glyphs    map[rune][][]rune
+}
+
+func mustLoadBannerFont(name string) *BannerFont {
+	f, err := bannerFontFiles.Open("fonts/" + name + ".flf")
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+	font, err := ParseBannerFont(name, f)
+	if err != nil {
+		panic(err)
+	}
+	return font
+}
+
+// figletDeutsch are the characters that follow ASCII 32-126 in a FIGlet font.
+var figletDeutsch = []rune{'Ä', 'Ö', 'Ü', 'ä', 'ö', 'ü', 'ß'}
+
+// ParseBannerFont reads a FIGlet font in the .flf format, as distributed
+// with figlet and toilet. Fonts that smush letters together are drawn with
+// the letters just touching instead.
+//
+// Example:
+//
+//	f, err := os.Open("fonts/standard.flf")
+//	if err != nil {
+//	    return err
+//	}
+//	defer f.Close()
+//	font, err := tui.ParseBannerFont("standard", f)
+func ParseBannerFont(name string, r io.Reader) (*BannerFont, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), 1<<20)
+	line := 0
+	next := func() (string, bool) {
+		if !scanner.Scan() {
+			return "", false
+		}
+		line++
+		return strings.TrimRight(scanner.Text(), "\r"), true
+	}
+
+	header, ok := next()
+	if !ok || !strings.HasPrefix(header, "flf2a") || len(header) < 6 {
+		return nil, fmt.Errorf("banner font %s: not a FIGlet font", name)
+	}
+	hardblank := []rune(header[5:])[0]
+	fields := strings.Fields(header[5+len(string(hardblank)):])
+	if len(fields) < 5 {
+		return nil, fmt.Errorf("banner font %s: invalid header %q", name, header)
+	}
+	nums := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("banner font %s: invalid header %q", name, header)
+		}
+		nums[i] = n
+	}
+	height, oldLayout, comments := nums[0], nums[3], nums[4]
+	if height <= 0 {
+		return nil, fmt.Errorf("banner font %s: invalid height %d", name, height)
+	}
+	fitting := oldLayout >= 0
+	if len(nums) > 6 {
+		// The full layout's horizontal bits take precedence: 64 is fitting,
+		// 128 smushing, and neither means full width
+		fitting = nums[6]&(64|128) != 0
+	}
+	for range comments {
+		if _, ok := next(); !ok {
+			return nil, fmt.Errorf("banner font %s: truncated comments", name)
+		}
+	}
+
+	font := &BannerFont{
+		name:      name,
+		height:    height,
+		hardblank: hardblank,
+		fitting:   fitting,
+		glyphs:    make(map[rune][][]rune),
+	}
+	readGlyph := func() ([][]rune, error) {
+		rows := make([][]rune, height)
+		for i := range rows {
+			text, ok := next()
+			if !ok {
+				return nil, io.ErrUnexpectedEOF
+			}
+			rows[i] = []rune(trimEndmark(text))
+		}
+		return rows, nil
+	}
+
+	// ASCII 32-126 and the Deutsch characters come in order, then any
+	// number of characters tagged with their code
+	for i := 0; i < 95+len(figletDeutsch); i++ {
+		rows, err := readGlyph()
+		if err != nil {
+			if i < 95 {
+				return nil, fmt.Errorf("banner font %s: line %d: %w", name, line, err)
+			}
+			return font, nil
+		}
+		r := rune(32 + i)
+		if i >= 95 {
+			r = figletDeutsch[i-95]
+		}
+		font.addGlyph(r, rows)
+	}
+	for {
+		tag, ok := next()
+		if !ok {
+			break
+		}
+		fields := strings.Fields(tag)
+		if len(fields) == 0 {
+			continue
+		}
+		code, err := strconv.ParseInt(fields[0], 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("banner font %s: line %d: invalid character code %q", name, line, fields[0])
+		}
+		rows, err := readGlyph()
+		if err != nil {
+			return nil, fmt.Errorf("banner font %s: line %d: %w", name, line, err)
+		}
+		if code >= 0 {
+			font.addGlyph(rune(code), rows)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("banner font %s: %w", name, err)
+	}
+	return font, nil
+}
+
+// trimEndmark removes the endmark character that ends each line of a glyph,
+// doubled on the glyph's last line.
+func trimEndmark(text string) string {
+	text = strings.TrimRight(text, " ")
+	if text == "" {
+		return text
+	}
+	mark := text[len(text)-1]
+	return strings.TrimRight(text, string(mark))
+}
+
+// addGlyph adds a glyph, skipping empty ones so the character falls back.
+func (f *BannerFont) addGlyph(r rune, rows [][]rune) {
+	for _, row := range rows {
+		if len(row) > 0 {
+			f.glyphs[r] = rows
+			return
+		}
+	}
+}
+
+// Name returns the name the font was loaded with.
+func (f *BannerFont) Name() string {
+	return f.name
+}
+
+// Height returns the number of lines the font's letters take.
+func (f *BannerFont) Height() int {
+	return f.height
+}
+
+// Render returns text drawn in the font, one string per line, for printing
+// a banner outside a TUI. Newlines in text start a new banner below.
+//
+// Example:
+//
+//	for _, line := range tui.BannerSlim.Render("DEPLOY") {
+//	    fmt.Println(line)
+//	}
+func (f *BannerFont) Render(text string) []string {
+	var lines []string
+	for i, part := range strings.Split(text, "\n") {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		for _, row := range f.layout(part) {
+			lines = append(lines, strings.TrimRight(string(row), " "))
+		}
+	}
+	return lines
+}
+
+// glyph returns the glyph for r, falling back to the other case and then
+// to '?'.
+func (f *BannerFont) glyph(r rune) [][]rune {
+	if g, ok := f.glyphs[r]; ok {
+		return g
+	}
+	for _, alt := range []rune{unicode.ToUpper(r), unicode.ToLower(r), '?'} {
+		if g, ok := f.glyphs[alt]; ok {
+			return g
+		}
+	}
+	return nil
+}
+
+// layout draws one line of text, returning the font's rows with hardblanks
+// replaced by spaces. Every row has the same width.
+func (f *BannerFont) layout(text string) [][]rune {
+	rows := make([][]rune, f.height)
+	for _, r := range text {
+		g := f.glyph(r)
+		if g == nil {
+			continue
+		}
+		overlap := 0
+		if f.fitting && len(rows[0]) > 0 {
+			overlap = f.fit(rows, g)
+		}
+		for i := range rows {
+			// Take the overlap from the blanks ending the row first, then
+			// from the blanks starting the glyph
+			trailing := min(trailingBlanks(rows[i]), overlap)
+			rows[i] = append(rows[i][:len(rows[i])-trailing], g[i][overlap-trailing:]...)
+		}
+		width := 0
+		for _, row := range rows {
+			width = max(width, len(row))
+		}
+		for i := range rows {
+			for len(rows[i]) < width {
+				rows[i] = append(rows[i], ' ')
+			}
+		}
+	}
+	for _, row := range rows {
+		for i, r := range row {
+			if r == f.hardblank {
+				row[i] = ' '
+			}
+		}
+	}
+	return rows
+}
+
+// fit returns how many columns the glyph can move left over the rows
+// without any of its characters touching theirs.
+func (f *BannerFont) fit(rows, g [][]rune) int {
+	overlap := -1
+	for i, row := range rows {
+		leading := 0
+		for leading < len(g[i]) && g[i][leading] == ' ' {
+			leading++
+		}
+		if n := trailingBlanks(row) + leading; overlap < 0 || n < overlap {
+			overlap = n
+		}
+	}
+	return max(0, overlap)
+}
+
+func trailingBlanks(row []rune) int {
+	n := 0
+	for n < len(row) && row[len(row)-1-n] == ' ' {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/deepnoodle-ai/wonton/color"
+	"github.com/mattn/go-runewidth"
+)
+
+// bannerView displays text in large letters drawn with a FIGlet font
+type bannerView struct {
+	text     string
+	font     *BannerFont
+	fallback []*BannerFont
+	style    Style
+	gradient []RGB
+	vertical bool
+	align    Alignment
+	lastSize [2]int
+	last     []string
+}
+
+// Banner creates a view that draws text in large letters, for splash
+// screens and headers. A nil font uses BannerBlock.
+//
+// When the banner is wider than the space it's given, it shrinks: it first
+// tries the smaller fallback fonts (BannerSlim and BannerSmall by default),
+// then wraps words onto more lines, and finally shows the text plainly.
+//
+// Example:
+//
+//	Banner("DEPLOY", tui.BannerBlock).Gradient(tui.NewRGB(255, 0, 128), tui.NewRGB(0, 128, 255))
+func Banner(text string, font *BannerFont) *bannerView {
+	if font == nil {
+		font = BannerBlock
+	}
+	return &bannerView{
+		text:     text,
+		font:     font,
+		fallback: []*BannerFont{BannerSlim, BannerSmall},
+		style:    NewStyle().WithBold(),
+	}
+}
+
+// Fallback sets the fonts tried, in order, when the banner doesn't fit in
+// its own font. With no fonts, a banner that doesn't fit wraps and then
+// shows the text plainly.
+func (b *bannerView) Fallback(fonts ...*BannerFont) *bannerView {
+	b.fallback = fonts
+	return b
+}
+
+// Fg sets the foreground color.
+func (b *bannerView) Fg(c Color) *bannerView {
+	b.style = b.style.WithForeground(c)
+	return b
+}
+
+// Style sets the complete style.
+func (b *bannerView) Style(s Style) *bannerView {
+	b.style = s
+	return b
+}
+
+// Gradient colors the letters with a gradient through the given stops,
+// from the left edge of the banner to the right.
+//
+// Example:
+//
+//	Banner("wonton", nil).Gradient(tui.NewRGB(255, 0, 0), tui.NewRGB(0, 0, 255))
+func (b *bannerView) Gradient(stops ...RGB) *bannerView {
+	b.gradient = stops
+	b.vertical = false
+	return b
+}
+
+// VerticalGradient colors the letters with a gradient through the given
+// stops, from the top of the banner to the bottom.
+func (b *bannerView) VerticalGradient(stops ...RGB) *bannerView {
+	b.gradient = stops
+	b.vertical = true
+	return b
+}
+
+// Align sets the alignment of lines when the banner wraps.
+func (b *bannerView) Align(a Alignment) *bannerView {
+	b.align = a
+	return b
+}
+
+// Center is a shorthand for Align(AlignCenter).
+func (b *bannerView) Center() *bannerView {
+	b.align = AlignCenter
+	return b
+}
+
+func (b *bannerView) size(maxWidth, maxHeight int) (int, int) {
+	lines := b.lines(maxWidth, maxHeight)
+	return linesWidth(lines), len(lines)
+}
+
+func (b *bannerView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	lines := b.lines(width, height)
+	bannerWidth, _ := b.size(width, height)
+
+	var colors []RGB
+	if len(b.gradient) > 0 {
+		if b.vertical {
+			colors = color.MultiGradient(b.gradient, len(lines))
+		} else {
+			colors = color.MultiGradient(b.gradient, bannerWidth)
+		}
+	}
+	for y, line := range lines {
+		if y >= height {
+			break
+		}
+		x := 0
+		if lineWidth := runewidth.StringWidth(line); b.align == AlignCenter {
+			x = (bannerWidth - lineWidth) / 2
+		} else if b.align == AlignRight {
+			x = bannerWidth - lineWidth
+		}
+		for _, r := range line {
+			style := b.style
+			if colors != nil {
+				if b.vertical {
+					style = style.WithFgRGB(colors[y])
+				} else if x < len(colors) {
+					style = style.WithFgRGB(colors[x])
+				}
+			}
+			if r != ' ' {
+				ctx.SetCell(x, y, r, style)
+			}
+			x += runewidth.RuneWidth(r)
+		}
+	}
+}
+
+// lines returns the banner drawn to fit in the given size, where 0 means
+// unlimited.
+func (b *bannerView) lines(maxWidth, maxHeight int) []string {
+	if b.last != nil && b.lastSize == [2]int{maxWidth, maxHeight} {
+		return b.last
+	}
+	b.lastSize = [2]int{maxWidth, maxHeight}
+	b.last = b.fit(maxWidth, maxHeight)
+	return b.last
+}
+
+func (b *bannerView) fit(maxWidth, maxHeight int) []string {
+	fits := func(lines []string) bool {
+		if maxHeight > 0 && len(lines) > maxHeight {
+			return false
+		}
+		return maxWidth <= 0 || linesWidth(lines) <= maxWidth
+	}
+	fonts := append([]*BannerFont{b.font}, b.fallback...)
+	for _, font := range fonts {
+		if lines := font.Render(b.text); fits(lines) {
+			return lines
+		}
+	}
+	if maxWidth > 0 {
+		for _, font := range fonts {
+			if lines, ok := b.wrap(font, maxWidth); ok && fits(lines) {
+				return lines
+			}
+		}
+	}
+	return strings.Split(WrapText(b.text, maxWidth), "\n")
+}
+
+// wrap draws the text with as many words on each banner line as fit in
+// maxWidth, or returns false if a word is too wide on its own.
+func (b *bannerView) wrap(font *BannerFont, maxWidth int) ([]string, bool) {
+	var lines []string
+	for _, paragraph := range strings.Split(b.text, "\n") {
+		words := strings.Fields(paragraph)
+		for len(words) > 0 {
+			n := 1
+			for n < len(words) && linesWidth(font.Render(strings.Join(words[:n+1], " "))) <= maxWidth {
+				n++
+			}
+			banner := font.Render(strings.Join(words[:n], " "))
+			if linesWidth(banner) > maxWidth {
+				return nil, false
+			}
+			if len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, banner...)
+			words = words[n:]
+		}
+	}
+	return lines, true
+}
+
+func linesWidth(lines []string) int {
+	w := 0
+	for _, line := range lines {
+		w = max(w, runewidth.StringWidth(line))
+	}
+	return w
+}
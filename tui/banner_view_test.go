@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/termtest"
+)
+
+func TestBannerFont_Render(t *testing.T) {
+	assert.Equal(t, []string{
+		"#  #  #",
+		"#  # ##",
+		"####  #",
+		"#  #  #",
+		"#  # ###",
+	}, BannerASCII.Render("H1"))
+
+	// Lowercase letters and unknown characters fall back
+	assert.Equal(t, BannerASCII.Render("H"), BannerASCII.Render("h"))
+	assert.Equal(t, BannerASCII.Render("?"), BannerASCII.Render("€"))
+	assert.Equal(t, 3, len(BannerSmall.Render("Hi")))
+}
+
+// testFont has two-line glyphs for 'a' and 'b', the hardblank '$', and a
+// tagged character, and fits letters together.
+var testFont = `flf2a$ 2 2 4 0 1
+A test font
+` + "$@\n$@@\n" + strings.Repeat("@\n@@\n", 64) + "a @\naa@@\n  b@\n bb@@\n" + strings.Repeat("@\n@@\n", 35) + `0x263A smiley
+:)@
+  @@
+`
+
+func TestParseBannerFont(t *testing.T) {
+	font, err := ParseBannerFont("test", strings.NewReader(testFont))
+	assert.NoError(t, err)
+	assert.Equal(t, "test", font.Name())
+	assert.Equal(t, 2, font.Height())
+
+	// Letters move left until they touch; hardblanks keep their space
+	assert.Equal(t, []string{"a  b", "aabb"}, font.Render("ab"))
+	assert.Equal(t, []string{"a   b", "aa bb"}, font.Render("a b"))
+	assert.Equal(t, []string{":)", ""}, font.Render("☺"))
+
+	_, err = ParseBannerFont("bad", strings.NewReader("not a font"))
+	assert.Error(t, err)
+	_, err = ParseBannerFont("short", strings.NewReader("flf2a$ 2 2 4 0 0\n@\n@@\n"))
+	assert.Error(t, err)
+}
+
+func TestBanner_Shrink(t *testing.T) {
+	// Wide enough for the font
+	screen := SprintScreen(Banner("HI", BannerASCII), PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, "#  # ###", screen.Row(0))
+
+	// Too narrow: the first fallback font that fits is used
+	w, h := Banner("HI", BannerBlock).size(10, 10)
+	assert.Equal(t, 8, w)
+	assert.Equal(t, 5, h)
+
+	// Too short for five lines
+	w, h = Banner("HI", BannerBlock).size(10, 3)
+	assert.Equal(t, 3, h)
+	assert.Equal(t, 8, w)
+
+	// Words wrap onto more banner lines
+	screen = SprintScreen(Banner("HI HI", BannerASCII).Fallback(), PrintConfig{Width: 10, Height: 11})
+	assert.Equal(t, "#  # ###", screen.Row(0))
+	assert.Equal(t, "", screen.Row(5))
+	assert.Equal(t, "#  # ###", screen.Row(6))
+
+	// And the text is shown plainly when nothing fits
+	screen = SprintScreen(Banner("HI HI", BannerASCII), PrintConfig{Width: 6, Height: 11})
+	assert.Equal(t, "HI HI", screen.Row(0))
+}
+
+func TestBanner_Gradient(t *testing.T) {
+	red, blue := NewRGB(255, 0, 0), NewRGB(0, 0, 255)
+	screen := SprintScreen(Banner("HI", BannerASCII).Gradient(red, blue), PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, termtest.Color{Type: termtest.ColorRGB, R: 255}, screen.Cell(0, 0).Style.Foreground)
+	assert.Equal(t, termtest.Color{Type: termtest.ColorRGB, B: 255}, screen.Cell(7, 0).Style.Foreground)
+
+	screen = SprintScreen(Banner("HI", BannerASCII).VerticalGradient(red, blue), PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, termtest.Color{Type: termtest.ColorRGB, R: 255}, screen.Cell(3, 0).Style.Foreground)
+	assert.Equal(t, termtest.Color{Type: termtest.ColorRGB, B: 255}, screen.Cell(0, 4).Style.Foreground)
+}
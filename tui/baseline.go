@@ -0,0 +1,131 @@
+package tui
+
+// Baseline selects which line of text horizontal containers align on.
+type Baseline int
+
+const (
+	// BaselineNone disables baseline alignment; children are positioned by
+	// the container's Align setting.
+	BaselineNone Baseline = iota
+	// BaselineFirst aligns the first line of text in each child.
+	BaselineFirst
+	// BaselineLast aligns the last line of text in each child.
+	BaselineLast
+)
+
+// baselineView is implemented by views whose text doesn't start on their
+// first row and end on their last, such as borders, padding, and stacks.
+type baselineView interface {
+	// baselines returns the rows of the first and last lines of text when
+	// the view is rendered at the given size.
+	baselines(width, height int) (first, last int)
+}
+
+// Baselines returns the rows, relative to the top of the view, of its first
+// and last lines of text when rendered at the given size. Views that don't
+// report their text metrics are assumed to start on their first row and end
+// on their last.
+//
+// Horizontal containers use these metrics for baseline alignment; custom
+// layouts can use them to line up composite headers.
+func Baselines(v View, width, height int) (first, last int) {
+	if height <= 0 {
+		return 0, 0
+	}
+	if b, ok := v.(baselineView); ok {
+		first, last = b.baselines(width, height)
+	} else {
+		first, last = 0, height-1
+	}
+	return clampRow(first, height), clampRow(last, height)
+}
+
+// baselineOf returns the first or last baseline of v.
+func baselineOf(v View, width, height int, which Baseline) int {
+	first, last := Baselines(v, width, height)
+	if which == BaselineLast {
+		return last
+	}
+	return first
+}
+
+func clampRow(row, height int) int {
+	if row < 0 {
+		return 0
+	}
+	if row >= height {
+		return height - 1
+	}
+	return row
+}
+
+func (p *paddingView) baselines(width, height int) (int, int) {
+	first, last := Baselines(p.inner, width-p.left-p.right, height-p.top-p.bottom)
+	return p.top + first, p.top + last
+}
+
+func (f *borderedView) baselines(width, height int) (int, int) {
+	if f.border == nil {
+		return Baselines(f.inner, width, height)
+	}
+	first, last := Baselines(f.inner, width-2, height-2)
+	return 1 + first, 1 + last
+}
+
+func (h *hiddenView) baselines(width, height int) (int, int) {
+	return Baselines(h.inner, width, height)
+}
+
+func (o *opacityView) baselines(width, height int) (int, int) {
+	return Baselines(o.inner, width, height)
+}
+
+func (s *stack) baselines(width, height int) (int, int) {
+	s.size(width, height)
+	first, last := -1, 0
+	y := 0
+	renderedVisible := false
+	for i, child := range s.children {
+		size := s.childSizes[i]
+		if size.X == 0 && size.Y == 0 {
+			continue
+		}
+		if renderedVisible {
+			y += s.gap
+		}
+		childFirst, childLast := Baselines(child, size.X, size.Y)
+		if first < 0 {
+			first = y + childFirst
+		}
+		last = y + childLast
+		y += size.Y
+		renderedVisible = true
+	}
+	if first < 0 {
+		first = 0
+	}
+	return first, last
+}
+
+func (g *group) baselines(width, height int) (int, int) {
+	g.size(width, height)
+	offsets := g.childOffsets(height)
+	first, last := -1, 0
+	for i, child := range g.children {
+		size := g.childSizes[i]
+		if size.X == 0 && size.Y == 0 {
+			continue
+		}
+		childFirst, childLast := Baselines(child, size.X, size.Y)
+		if first < 0 || offsets[i]+childFirst < first {
+			first = offsets[i] + childFirst
+		}
+		if offsets[i]+childLast > last {
+			last = offsets[i] + childLast
+		}
+	}
+	if first < 0 {
+		first = 0
+	}
+	return first, last
+}
@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestBaselines_Metrics(t *testing.T) {
+	first, last := Baselines(Text("one\ntwo\nthree"), 10, 3)
+	assert.Equal(t, 0, first)
+	assert.Equal(t, 2, last)
+
+	first, last = Baselines(Bordered(Text("x")).Border(&SingleBorder), 3, 3)
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 1, last)
+
+	first, last = Baselines(PaddingLTRB(0, 2, 0, 1, Text("a\nb")), 1, 5)
+	assert.Equal(t, 2, first)
+	assert.Equal(t, 3, last)
+
+	// A stack reports the first line of its first child and the last line
+	// of its last child
+	first, last = Baselines(Stack(Bordered(Text("title")).Border(&SingleBorder), Text("a\nb")).Gap(1), 7, 6)
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 5, last)
+}
+
+func TestGroup_BaselineFirst(t *testing.T) {
+	view := Group(
+		Bordered(Text("Title")).Border(&SingleBorder),
+		Text(" hint"),
+	).Baseline(BaselineFirst)
+
+	_, h := view.size(40, 0)
+	assert.Equal(t, 3, h)
+
+	screen := SprintScreen(view, PrintConfig{Width: 40})
+	assert.Equal(t, "┌─────┐", screen.Row(0))
+	assert.Equal(t, "│Title│ hint", screen.Row(1))
+	assert.Equal(t, "└─────┘", screen.Row(2))
+}
+
+func TestGroup_BaselineLast(t *testing.T) {
+	view := Group(
+		Text("big\nheader"),
+		Text(" (3 items)"),
+	).Baseline(BaselineLast)
+
+	screen := SprintScreen(view, PrintConfig{Width: 40})
+	assert.Equal(t, "big", screen.Row(0))
+	assert.Equal(t, "header (3 items)", screen.Row(1))
+}
+
+func TestGroup_BaselineGrowsHeight(t *testing.T) {
+	// Shifting the short child down to the tall child's baseline makes the
+	// group taller than either child
+	view := Group(
+		PaddingLTRB(0, 2, 0, 0, Text("a")),
+		Text("b\nc\nd"),
+	).Baseline(BaselineFirst)
+
+	_, h := view.size(40, 0)
+	assert.Equal(t, 5, h)
+
+	screen := SprintScreen(view, PrintConfig{Width: 40})
+	assert.Equal(t, "", screen.Row(0))
+	assert.Equal(t, "ab", screen.Row(2))
+	assert.Equal(t, " d", screen.Row(4))
+}
+
+func TestGroup_NestedBaseline(t *testing.T) {
+	inner := Group(Bordered(Text("A")).Border(&SingleBorder), Text("x")).Baseline(BaselineFirst)
+	first, _ := Baselines(inner, 4, 3)
+	assert.Equal(t, 1, first)
+
+	screen := SprintScreen(Group(inner, Text("y")).Baseline(BaselineFirst), PrintConfig{Width: 20})
+	assert.Equal(t, "│A│xy", screen.Row(1))
+}
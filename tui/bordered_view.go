@@ -15,6 +15,9 @@ type borderedView struct {
 	focusBorderFg   Color  // Border color when focused
 	hasFocusBorder  bool   // true if focusBorderFg was set
 	focusTitleStyle *Style // Title style when focused
+
+	borderRole Role // theme colors applied over the styles when drawn
+	titleRole  Role
 }
 
 // Bordered wraps a view with a border and optional title.
@@ -64,6 +67,19 @@ func (f *borderedView) BorderFg(c Color) *borderedView {
 	return f
 }
 
+// BorderRole colors the border with the current theme's color for role,
+// such as RoleBorder, so it follows theme switches.
+func (f *borderedView) BorderRole(role Role) *borderedView {
+	f.borderRole = role
+	return f
+}
+
+// TitleRole colors the title with the current theme's color for role.
+func (f *borderedView) TitleRole(role Role) *borderedView {
+	f.titleRole = role
+	return f
+}
+
 // FocusID sets the focus ID to watch for styling changes.
 // When the element with this ID is focused, focus styles will be applied.
 func (f *borderedView) FocusID(id string) *borderedView {
@@ -136,54 +152,55 @@ func (f *borderedView) render(ctx *RenderContext) {
 	isFocused := f.focusID != "" && fm != nil && fm.GetFocusedID() == f.focusID
 
 	// Choose border style based on focus
-	borderStyle := f.borderStyle
+	theme := ctx.Theme()
+	borderStyle := theme.Apply(f.borderStyle, f.borderRole, RoleNone)
 	if isFocused && f.hasFocusBorder {
 		borderStyle = NewStyle().WithForeground(f.focusBorderFg)
 	}
 
 	// Choose title style based on focus
-	titleStyle := f.titleStyle
+	titleStyle := theme.Apply(f.titleStyle, f.titleRole, RoleNone)
 	if isFocused && f.focusTitleStyle != nil {
 		titleStyle = *f.focusTitleStyle
 	}
 
+	// With the theme's focus indicators, focus also shows without color
+	border, title := f.border, f.title
+	if isFocused {
+		border, title = focusedBorder(ctx, border), focusedTitle(ctx, title)
+	}
+
 	// Draw border
 	// Top border
-	ctx.PrintTruncated(0, 0, f.border.TopLeft, borderStyle)
+	ctx.PrintTruncated(0, 0, border.TopLeft, borderStyle)
 	for x := 1; x < w-1; x++ {
-		ctx.PrintTruncated(x, 0, f.border.Horizontal, borderStyle)
+		ctx.PrintTruncated(x, 0, border.Horizontal, borderStyle)
 	}
 	if w > 1 {
-		ctx.PrintTruncated(w-1, 0, f.border.TopRight, borderStyle)
+		ctx.PrintTruncated(w-1, 0, border.TopRight, borderStyle)
 	}
 
 	// Title in top border
-	if f.title != "" && w > 4 {
-		titleW, _ := MeasureText(f.title)
-		maxTitleW := w - 4
-		if titleW > maxTitleW {
-			titleW = maxTitleW
-		}
-		titleX := 2
-		ctx.PrintTruncated(titleX, 0, f.title[:min(len(f.title), maxTitleW)], titleStyle)
+	if title != "" && w > 4 {
+		ctx.PrintTruncated(2, 0, truncateToWidth(title, w-4), titleStyle)
 	}
 
 	// Side borders
 	for y := 1; y < h-1; y++ {
-		ctx.PrintTruncated(0, y, f.border.Vertical, borderStyle)
+		ctx.PrintTruncated(0, y, border.Vertical, borderStyle)
 		if w > 1 {
-			ctx.PrintTruncated(w-1, y, f.border.Vertical, borderStyle)
+			ctx.PrintTruncated(w-1, y, border.Vertical, borderStyle)
 		}
 	}
 
 	// Bottom border
 	if h > 1 {
-		ctx.PrintTruncated(0, h-1, f.border.BottomLeft, borderStyle)
+		ctx.PrintTruncated(0, h-1, border.BottomLeft, borderStyle)
 		for x := 1; x < w-1; x++ {
-			ctx.PrintTruncated(x, h-1, f.border.Horizontal, borderStyle)
+			ctx.PrintTruncated(x, h-1, border.Horizontal, borderStyle)
 		}
 		if w > 1 {
-			ctx.PrintTruncated(w-1, h-1, f.border.BottomRight, borderStyle)
+			ctx.PrintTruncated(w-1, h-1, border.BottomRight, borderStyle)
 		}
 	}
 
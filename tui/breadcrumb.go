@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"image"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// breadcrumbView shows the path to the current place in a hierarchy.
+type breadcrumbView struct {
+	parts          []string
+	separator      string
+	style          Style
+	currentStyle   Style
+	separatorStyle Style
+	onSelect       func(index int)
+}
+
+// crumb is a part of a breadcrumb as drawn; index is -1 for the "…" that
+// stands for hidden parts.
+type crumb struct {
+	text  string
+	index int
+}
+
+// Breadcrumb shows the path to the current place in a hierarchy, such as
+// the folders above a file, with the last part as the current place. When
+// there isn't room, the parts after the first are hidden behind "…", the
+// highest first, so the root and the current place stay visible.
+//
+// Example:
+//
+//	tui.Breadcrumb(app.path...).OnSelect(func(i int) {
+//	    app.path = app.path[:i+1]
+//	})
+func Breadcrumb(parts ...string) *breadcrumbView {
+	return &breadcrumbView{
+		parts:          parts,
+		separator:      " › ",
+		style:          NewStyle(),
+		currentStyle:   NewStyle().WithBold(),
+		separatorStyle: NewStyle().WithDim(),
+	}
+}
+
+// Separator sets the text between parts. Default is " › ".
+func (b *breadcrumbView) Separator(sep string) *breadcrumbView {
+	b.separator = sep
+	return b
+}
+
+// Style sets the style of the parts above the current one.
+func (b *breadcrumbView) Style(s Style) *breadcrumbView {
+	b.style = s
+	return b
+}
+
+// CurrentStyle sets the style of the last part. Default is bold.
+func (b *breadcrumbView) CurrentStyle(s Style) *breadcrumbView {
+	b.currentStyle = s
+	return b
+}
+
+// SeparatorStyle sets the style of the separators. Default is dim.
+func (b *breadcrumbView) SeparatorStyle(s Style) *breadcrumbView {
+	b.separatorStyle = s
+	return b
+}
+
+// OnSelect makes the parts clickable, calling fn with the index of the
+// part clicked.
+func (b *breadcrumbView) OnSelect(fn func(index int)) *breadcrumbView {
+	b.onSelect = fn
+	return b
+}
+
+// crumbsWidth returns the width of crumbs drawn with separators.
+func (b *breadcrumbView) crumbsWidth(crumbs []crumb) int {
+	if len(crumbs) == 0 {
+		return 0
+	}
+	w := runewidth.StringWidth(b.separator) * (len(crumbs) - 1)
+	for _, c := range crumbs {
+		w += runewidth.StringWidth(c.text)
+	}
+	return w
+}
+
+// layout returns the crumbs to draw in width (0 for no limit), hiding
+// parts behind "…" until they fit.
+func (b *breadcrumbView) layout(width int) []crumb {
+	n := len(b.parts)
+	all := make([]crumb, n)
+	for i, part := range b.parts {
+		all[i] = crumb{part, i}
+	}
+	if width == 0 || n < 2 || b.crumbsWidth(all) <= width {
+		return all
+	}
+	ellipsis := crumb{"…", -1}
+	// Hide parts 1 to hidden, then the first as well
+	for hidden := 1; hidden < n-1; hidden++ {
+		crumbs := append([]crumb{all[0], ellipsis}, all[hidden+1:]...)
+		if b.crumbsWidth(crumbs) <= width {
+			return crumbs
+		}
+	}
+	return []crumb{ellipsis, all[n-1]}
+}
+
+func (b *breadcrumbView) size(maxWidth, maxHeight int) (int, int) {
+	if len(b.parts) == 0 {
+		return 0, 0
+	}
+	w := b.crumbsWidth(b.layout(maxWidth))
+	if maxWidth > 0 {
+		w = min(w, maxWidth)
+	}
+	return w, 1
+}
+
+func (b *breadcrumbView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	abs := ctx.AbsoluteBounds()
+	x := 0
+	for i, c := range b.layout(width) {
+		if i > 0 {
+			ctx.PrintTruncated(x, 0, b.separator, b.separatorStyle)
+			x += runewidth.StringWidth(b.separator)
+		}
+		if x >= width {
+			break
+		}
+		style := b.style
+		if c.index == len(b.parts)-1 {
+			style = b.currentStyle
+		}
+		text := runewidth.Truncate(c.text, width-x, "…")
+		ctx.PrintTruncated(x, 0, text, style)
+		w := runewidth.StringWidth(text)
+		if b.onSelect != nil && c.index >= 0 {
+			index := c.index
+			interactiveRegistry.RegisterButton(image.Rect(x, 0, x+w, 1).Add(abs.Min), func() {
+				b.onSelect(index)
+			})
+		}
+		x += w
+	}
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestBreadcrumb_Render(t *testing.T) {
+	view := Breadcrumb("home", "docs", "notes.txt")
+	screen := SprintScreen(view, PrintConfig{Width: 40, Height: 1})
+	assert.Equal(t, "home › docs › notes.txt", screen.Row(0))
+
+	screen = SprintScreen(Breadcrumb("a", "b").Separator("/"), PrintConfig{Width: 40, Height: 1})
+	assert.Equal(t, "a/b", screen.Row(0))
+}
+
+func TestBreadcrumb_HidesMiddleWhenNarrow(t *testing.T) {
+	view := Breadcrumb("home", "projects", "wonton", "tui", "README.md")
+	tests := []struct {
+		width int
+		want  string
+	}{
+		{50, "home › projects › wonton › tui › README.md"},
+		{36, "home › … › wonton › tui › README.md"},
+		{24, "home › … › README.md"},
+		{14, "… › README.md"},
+		{8, "… › REA…"},
+	}
+	for _, tt := range tests {
+		screen := SprintScreen(view, PrintConfig{Width: tt.width, Height: 1})
+		assert.Equal(t, tt.want, screen.Row(0), "width %d", tt.width)
+	}
+}
+
+func TestBreadcrumb_OnSelect(t *testing.T) {
+	selected := -1
+	terminal := NewTestTerminal(30, 1, &bytes.Buffer{})
+	app := &simpleApp{
+		renderFunc: func() View {
+			return Breadcrumb("home", "docs", "notes.txt").OnSelect(func(i int) { selected = i })
+		},
+		handleFunc: func(Event) []Cmd { return nil },
+	}
+	runtime := NewRuntime(terminal, app, 30)
+	runtime.render()
+	defer interactiveRegistry.HandleHover(MouseEvent{Type: MouseLeave})
+
+	runtime.processEvent(MouseEvent{Type: MouseClick, Button: MouseButtonLeft, X: 8, Y: 0})
+	assert.Equal(t, 1, selected)
+	runtime.processEvent(MouseEvent{Type: MouseClick, Button: MouseButtonLeft, X: 5, Y: 0})
+	assert.Equal(t, 1, selected, "separators aren't clickable")
+	runtime.processEvent(MouseEvent{Type: MouseClick, Button: MouseButtonLeft, X: 0, Y: 0})
+	assert.Equal(t, 0, selected)
+}
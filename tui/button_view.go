@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"image"
+	"slices"
 	"sync"
 )
 
@@ -96,8 +97,23 @@ var interactiveRegistry = &interactiveRegistryImpl{
 }
 
 type interactiveRegistryImpl struct {
-	mu      sync.Mutex
-	regions []interactiveRegion
+	mu            sync.Mutex
+	regions       []interactiveRegion
+	scrollRegions []scrollRegion
+	dragRegions   []dragRegion
+	hoverRegions  []hoverRegion
+	rightClicks   []rightClickRegion
+	keyHandlers   []func(KeyEvent) bool
+	keyCaptures   []func(KeyEvent) bool
+
+	// The drag in progress. It outlives Clear so a drag continues across
+	// frames until the button is released.
+	activeDrag func(MouseEvent)
+
+	// The last known mouse position, for hover. Like activeDrag, it
+	// outlives Clear.
+	pointer      image.Point
+	pointerKnown bool
 }
 
 type interactiveRegion struct {
@@ -105,12 +121,38 @@ type interactiveRegion struct {
 	callback func()
 }
 
+type scrollRegion struct {
+	bounds   image.Rectangle
+	callback func(delta int)
+}
+
+type dragRegion struct {
+	bounds   image.Rectangle
+	callback func(MouseEvent)
+}
+
+type hoverRegion struct {
+	bounds   image.Rectangle
+	callback func(hovered bool)
+}
+
+type rightClickRegion struct {
+	bounds   image.Rectangle
+	callback func(x, y int)
+}
+
 // Clear clears all registered interactive regions.
 // Called by the runtime before each render.
 func (r *interactiveRegistryImpl) Clear() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.regions = r.regions[:0]
+	r.scrollRegions = r.scrollRegions[:0]
+	r.dragRegions = r.dragRegions[:0]
+	r.hoverRegions = r.hoverRegions[:0]
+	r.rightClicks = r.rightClicks[:0]
+	r.keyHandlers = r.keyHandlers[:0]
+	r.keyCaptures = r.keyCaptures[:0]
 }
 
 // RegisterRegion adds a clickable region (for non-focusable clickables).
@@ -120,6 +162,116 @@ func (r *interactiveRegistryImpl) RegisterRegion(bounds image.Rectangle, callbac
 	r.regions = append(r.regions, interactiveRegion{bounds: bounds, callback: callback})
 }
 
+// RegisterScroll adds a region that responds to the mouse wheel. The
+// callback receives -1 for wheel up and 1 for wheel down.
+func (r *interactiveRegistryImpl) RegisterScroll(bounds image.Rectangle, callback func(delta int)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scrollRegions = append(r.scrollRegions, scrollRegion{bounds: bounds, callback: callback})
+}
+
+// RegisterDrag adds a region that can be dragged with the left mouse button.
+// The callback receives the press, every drag event while the button is
+// held (wherever the pointer is), and the release. Drag events require
+// mouse tracking (WithMouseTracking).
+func (r *interactiveRegistryImpl) RegisterDrag(bounds image.Rectangle, callback func(MouseEvent)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dragRegions = append(r.dragRegions, dragRegion{bounds: bounds, callback: callback})
+}
+
+// RegisterHover adds a region whose callback is told when the mouse pointer
+// enters (true) or leaves (false) it. Hover requires mouse tracking
+// (WithMouseTracking).
+func (r *interactiveRegistryImpl) RegisterHover(bounds image.Rectangle, callback func(hovered bool)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hoverRegions = append(r.hoverRegions, hoverRegion{bounds: bounds, callback: callback})
+}
+
+// Hovered reports whether the mouse pointer was last seen inside bounds, for
+// views that draw differently under the pointer.
+func (r *interactiveRegistryImpl) Hovered(bounds image.Rectangle) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.pointerKnown && r.pointer.In(bounds)
+}
+
+// RegisterRightClick adds a region that responds to a right click. The
+// callback receives the screen position of the click.
+func (r *interactiveRegistryImpl) RegisterRightClick(bounds image.Rectangle, callback func(x, y int)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rightClicks = append(r.rightClicks, rightClickRegion{bounds: bounds, callback: callback})
+}
+
+// RegisterKeys adds a handler for keys that the focused element doesn't
+// consume, for views with shortcuts of their own. The handler returns true
+// if it used the key.
+func (r *interactiveRegistryImpl) RegisterKeys(handler func(KeyEvent) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keyHandlers = append(r.keyHandlers, handler)
+}
+
+// RegisterKeyCapture adds a handler that sees keys before the focused
+// element, for popups that take the keyboard while they are open. The
+// handler returns true if it used the key.
+func (r *interactiveRegistryImpl) RegisterKeyCapture(handler func(KeyEvent) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keyCaptures = append(r.keyCaptures, handler)
+}
+
+// regionCount returns the number of clickable regions registered so far.
+func (r *interactiveRegistryImpl) regionCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.regions)
+}
+
+// raise moves the clickable regions registered after the first n ahead of
+// all others, for overlays drawn on top of the views that registered them.
+func (r *interactiveRegistryImpl) raise(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n >= len(r.regions) {
+		return
+	}
+	top := slices.Clone(r.regions[n:])
+	copy(r.regions[len(top):], r.regions[:n])
+	copy(r.regions, top)
+}
+
+// registryMark records how much has been registered, so that a render
+// whose output is thrown away can undo its registrations.
+type registryMark struct {
+	regions, scrollRegions, dragRegions, hoverRegions, rightClicks, keyHandlers, keyCaptures int
+}
+
+// mark returns the current registration counts.
+func (r *interactiveRegistryImpl) mark() registryMark {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return registryMark{
+		len(r.regions), len(r.scrollRegions), len(r.dragRegions), len(r.hoverRegions),
+		len(r.rightClicks), len(r.keyHandlers), len(r.keyCaptures),
+	}
+}
+
+// reset drops everything registered since m was taken.
+func (r *interactiveRegistryImpl) reset(m registryMark) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.regions = r.regions[:min(m.regions, len(r.regions))]
+	r.scrollRegions = r.scrollRegions[:min(m.scrollRegions, len(r.scrollRegions))]
+	r.dragRegions = r.dragRegions[:min(m.dragRegions, len(r.dragRegions))]
+	r.hoverRegions = r.hoverRegions[:min(m.hoverRegions, len(r.hoverRegions))]
+	r.rightClicks = r.rightClicks[:min(m.rightClicks, len(r.rightClicks))]
+	r.keyHandlers = r.keyHandlers[:min(m.keyHandlers, len(r.keyHandlers))]
+	r.keyCaptures = r.keyCaptures[:min(m.keyCaptures, len(r.keyCaptures))]
+}
+
 // RegisterButton is an alias for RegisterRegion for backward compatibility.
 func (r *interactiveRegistryImpl) RegisterButton(bounds image.Rectangle, callback func()) {
 	r.RegisterRegion(bounds, callback)
@@ -144,6 +296,155 @@ func (r *interactiveRegistryImpl) HandleClick(x, y int) bool {
 	return false
 }
 
+// HandleScroll checks if a wheel event hit any scroll region and invokes its
+// callback. Regions registered later are drawn on top, so the innermost
+// region wins. Returns true if a region was scrolled.
+func (r *interactiveRegistryImpl) HandleScroll(x, y, delta int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pt := image.Pt(x, y)
+	for i := len(r.scrollRegions) - 1; i >= 0; i-- {
+		if region := r.scrollRegions[i]; pt.In(region.bounds) {
+			r.mu.Unlock()
+			region.callback(delta)
+			r.mu.Lock()
+			return true
+		}
+	}
+	return false
+}
+
+// HandleRightClick checks if a right click hit any right click region and
+// invokes its callback, innermost region first. Returns true if a region was
+// clicked.
+func (r *interactiveRegistryImpl) HandleRightClick(x, y int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pt := image.Pt(x, y)
+	for i := len(r.rightClicks) - 1; i >= 0; i-- {
+		if region := r.rightClicks[i]; pt.In(region.bounds) {
+			r.mu.Unlock()
+			region.callback(x, y)
+			r.mu.Lock()
+			return true
+		}
+	}
+	return false
+}
+
+// HandleKeyCapture offers a key to the registered key captures, innermost
+// first, until one uses it. Returns true if a capture used the key.
+func (r *interactiveRegistryImpl) HandleKeyCapture(e KeyEvent) bool {
+	r.mu.Lock()
+	handlers := slices.Clone(r.keyCaptures)
+	r.mu.Unlock()
+
+	for i := len(handlers) - 1; i >= 0; i-- {
+		if handlers[i](e) {
+			return true
+		}
+	}
+	return false
+}
+
+// HandleKey offers a key to the registered key handlers, innermost first,
+// until one uses it. Returns true if a handler used the key.
+func (r *interactiveRegistryImpl) HandleKey(e KeyEvent) bool {
+	r.mu.Lock()
+	handlers := slices.Clone(r.keyHandlers)
+	r.mu.Unlock()
+
+	for i := len(handlers) - 1; i >= 0; i-- {
+		if handlers[i](e) {
+			return true
+		}
+	}
+	return false
+}
+
+// HandleDrag starts a drag when the left button is pressed in a drag region
+// and routes drag and release events to it. Returns true if the event was
+// part of a drag.
+func (r *interactiveRegistryImpl) HandleDrag(e MouseEvent) bool {
+	r.mu.Lock()
+	callback := r.activeDrag
+	switch e.Type {
+	case MousePress:
+		callback = nil
+		if e.Button == MouseButtonLeft {
+			pt := image.Pt(e.X, e.Y)
+			for i := len(r.dragRegions) - 1; i >= 0; i-- {
+				if pt.In(r.dragRegions[i].bounds) {
+					callback = r.dragRegions[i].callback
+					break
+				}
+			}
+		}
+		r.activeDrag = callback
+	case MouseRelease:
+		r.activeDrag = nil
+	case MouseDrag:
+	default:
+		callback = nil
+	}
+	r.mu.Unlock()
+
+	if callback == nil {
+		return false
+	}
+	callback(e)
+	return true
+}
+
+// HandleHover records the pointer position of a mouse event and tells the
+// hover regions the pointer entered or left. A MouseLeave event means the
+// pointer left the screen.
+func (r *interactiveRegistryImpl) HandleHover(e MouseEvent) {
+	r.mu.Lock()
+	old, oldKnown := r.pointer, r.pointerKnown
+	if e.Type == MouseLeave {
+		r.pointerKnown = false
+	} else {
+		r.pointer, r.pointerKnown = image.Pt(e.X, e.Y), true
+	}
+	var left, entered []func(bool)
+	for _, region := range r.hoverRegions {
+		was := oldKnown && old.In(region.bounds)
+		is := r.pointerKnown && r.pointer.In(region.bounds)
+		if was && !is {
+			left = append(left, region.callback)
+		} else if is && !was {
+			entered = append(entered, region.callback)
+		}
+	}
+	r.mu.Unlock()
+
+	// Leaves come first, so moving between neighbors is a leave then an enter
+	for _, callback := range left {
+		callback(false)
+	}
+	for _, callback := range entered {
+		callback(true)
+	}
+}
+
+// wheelDelta returns -1 for wheel up, 1 for wheel down, and 0 for any other
+// mouse event.
+func wheelDelta(e MouseEvent) int {
+	if e.Type != MouseScroll {
+		return 0
+	}
+	switch e.Button {
+	case MouseButtonWheelUp:
+		return -1
+	case MouseButtonWheelDown:
+		return 1
+	}
+	return 0
+}
+
 // buttonView displays an interactive button that can be focused and activated
 type buttonView struct {
 	id         string
@@ -151,6 +452,7 @@ type buttonView struct {
 	callback   func()
 	style      Style
 	focusStyle Style
+	hoverStyle Style
 	width      int
 }
 
@@ -215,6 +517,14 @@ func (b *buttonView) FocusStyle(s Style) *buttonView {
 	return b
 }
 
+// HoverStyle sets the style applied while the mouse pointer is over this
+// button and it isn't focused. Hover requires mouse tracking
+// (WithMouseTracking).
+func (b *buttonView) HoverStyle(s Style) *buttonView {
+	b.hoverStyle = s
+	return b
+}
+
 // Width sets a fixed width for the button.
 func (b *buttonView) Width(w int) *buttonView {
 	b.width = w
@@ -248,7 +558,9 @@ func (b *buttonView) render(ctx *RenderContext) {
 	// Choose style based on focus state
 	style := b.style
 	if state.focused {
-		style = b.focusStyle
+		style = focusedStyle(ctx, b.style, b.focusStyle)
+	} else if !b.hoverStyle.IsEmpty() && interactiveRegistry.Hovered(bounds) {
+		style = b.hoverStyle
 	}
 
 	// Render the label
@@ -257,10 +569,11 @@ func (b *buttonView) render(ctx *RenderContext) {
 
 // clickableView displays an interactive clickable element (mouse-only, not focusable)
 type clickableView struct {
-	label    string
-	callback func()
-	style    Style
-	width    int
+	label      string
+	callback   func()
+	style      Style
+	hoverStyle Style
+	width      int
 }
 
 // Clickable creates a mouse-only clickable element (not keyboard focusable).
@@ -308,6 +621,13 @@ func (c *clickableView) Style(s Style) *clickableView {
 	return c
 }
 
+// HoverStyle sets the style applied while the mouse pointer is over this
+// clickable. Hover requires mouse tracking (WithMouseTracking).
+func (c *clickableView) HoverStyle(s Style) *clickableView {
+	c.hoverStyle = s
+	return c
+}
+
 // Width sets a fixed width for the clickable.
 func (c *clickableView) Width(w int) *clickableView {
 	c.width = w
@@ -335,10 +655,16 @@ func (c *clickableView) render(ctx *RenderContext) {
 	}
 
 	// Register this clickable for click handling (mouse only)
+	bounds := ctx.AbsoluteBounds()
 	if c.callback != nil {
-		interactiveRegistry.RegisterRegion(ctx.AbsoluteBounds(), c.callback)
+		interactiveRegistry.RegisterRegion(bounds, c.callback)
+	}
+
+	style := c.style
+	if !c.hoverStyle.IsEmpty() && interactiveRegistry.Hovered(bounds) {
+		style = c.hoverStyle
 	}
 
 	// Render the label
-	ctx.PrintTruncated(0, 0, c.label, c.style)
+	ctx.PrintTruncated(0, 0, c.label, style)
 }
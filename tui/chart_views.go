@@ -0,0 +1,568 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// sparkBlocks are the block characters a sparkline column is built from,
+// indexed by eighths of a cell.
+var sparkBlocks = []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// Threshold colors chart values at or above a level. When several apply,
+// the one with the highest level wins.
+type Threshold struct {
+	At    float64
+	Color Color
+}
+
+// thresholdStyle returns style with the color of the highest threshold v
+// reaches, or style unchanged if it reaches none.
+func thresholdStyle(thresholds []Threshold, v float64, style Style) Style {
+	best := math.Inf(-1)
+	for _, t := range thresholds {
+		if v >= t.At && t.At >= best {
+			best = t.At
+			style = style.WithForeground(t.Color)
+		}
+	}
+	return style
+}
+
+// formatChartValue is the default format for chart values: whole numbers
+// without decimals, others with one.
+func formatChartValue(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+// downsample reduces values to at most n points by averaging equal runs of
+// neighbors.
+func downsample(values []float64, n int) []float64 {
+	if n <= 0 || len(values) <= n {
+		return values
+	}
+	out := make([]float64, n)
+	for i := range out {
+		start, end := i*len(values)/n, (i+1)*len(values)/n
+		sum := 0.0
+		for _, v := range values[start:end] {
+			sum += v
+		}
+		out[i] = sum / float64(end-start)
+	}
+	return out
+}
+
+// valueRange returns the smallest and largest of values.
+func valueRange(values []float64) (lo, hi float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	lo, hi = values[0], values[0]
+	for _, v := range values[1:] {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	return lo, hi
+}
+
+// sparklineView draws a series of values as a compact column chart.
+type sparklineView struct {
+	values     []float64
+	width      int
+	height     int
+	lo, hi     float64
+	fixedRange bool
+	style      Style
+	thresholds []Threshold
+	axis       bool
+	axisStyle  Style
+	format     func(float64) string
+}
+
+// Sparkline creates a small chart of values, one column per value, scaled
+// so the smallest value shows as the lowest block and the largest fills the
+// chart. When there are more values than columns, neighboring values are
+// averaged to fit.
+//
+// Example:
+//
+//	Sparkline(app.cpuHistory).Height(2).Thresholds(
+//	    Threshold{At: 50, Color: ColorYellow},
+//	    Threshold{At: 90, Color: ColorRed},
+//	)
+func Sparkline(values []float64) *sparklineView {
+	return &sparklineView{
+		values:    values,
+		height:    1,
+		style:     NewStyle().WithForeground(ColorGreen),
+		axisStyle: NewStyle().WithForeground(ColorBrightBlack),
+		format:    formatChartValue,
+	}
+}
+
+// Width sets the number of columns. By default there is one per value.
+func (s *sparklineView) Width(w int) *sparklineView {
+	s.width = w
+	return s
+}
+
+// Height sets the number of rows (default 1). Each row adds eight levels.
+func (s *sparklineView) Height(h int) *sparklineView {
+	if h > 0 {
+		s.height = h
+	}
+	return s
+}
+
+// Range fixes the values at the bottom and top of the chart instead of
+// scaling to the data, e.g. Range(0, 100) for percentages.
+func (s *sparklineView) Range(lo, hi float64) *sparklineView {
+	s.lo, s.hi = lo, hi
+	s.fixedRange = true
+	return s
+}
+
+// Fg sets the color of the columns.
+func (s *sparklineView) Fg(c Color) *sparklineView {
+	s.style = s.style.WithForeground(c)
+	return s
+}
+
+// Style sets the style of the columns.
+func (s *sparklineView) Style(style Style) *sparklineView {
+	s.style = style
+	return s
+}
+
+// Thresholds colors each column by its value.
+func (s *sparklineView) Thresholds(thresholds ...Threshold) *sparklineView {
+	s.thresholds = thresholds
+	return s
+}
+
+// Axis labels the top and bottom of the chart with its range, on the left.
+func (s *sparklineView) Axis() *sparklineView {
+	s.axis = true
+	return s
+}
+
+// Format sets how axis labels are formatted.
+func (s *sparklineView) Format(format func(float64) string) *sparklineView {
+	if format != nil {
+		s.format = format
+	}
+	return s
+}
+
+// chartRange returns the values at the bottom and top of the chart.
+func (s *sparklineView) chartRange() (lo, hi float64) {
+	if s.fixedRange {
+		return s.lo, s.hi
+	}
+	return valueRange(s.values)
+}
+
+// axisWidth returns the width of the axis labels and the space after them.
+func (s *sparklineView) axisWidth() int {
+	if !s.axis {
+		return 0
+	}
+	lo, hi := s.chartRange()
+	return max(runewidth.StringWidth(s.format(lo)), runewidth.StringWidth(s.format(hi))) + 1
+}
+
+func (s *sparklineView) size(maxWidth, maxHeight int) (int, int) {
+	w := s.width
+	if w == 0 {
+		w = len(s.values)
+	}
+	w += s.axisWidth()
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	h := s.height
+	if maxHeight > 0 && h > maxHeight {
+		h = maxHeight
+	}
+	return w, h
+}
+
+func (s *sparklineView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+
+	lo, hi := s.chartRange()
+	x := 0
+	if s.axis {
+		axisW := s.axisWidth()
+		top, bottom := s.format(hi), s.format(lo)
+		ctx.PrintTruncated(axisW-1-runewidth.StringWidth(top), 0, top, s.axisStyle)
+		if height > 1 {
+			ctx.PrintTruncated(axisW-1-runewidth.StringWidth(bottom), height-1, bottom, s.axisStyle)
+		}
+		x = axisW
+	}
+
+	values := downsample(s.values, width-x)
+	levels := height * 8
+	for i, v := range values {
+		// The lowest value still shows as one level, so the line is unbroken
+		level := 1
+		if hi > lo {
+			frac := min(max((v-lo)/(hi-lo), 0), 1)
+			level = 1 + int(math.Round(frac*float64(levels-1)))
+		}
+		style := thresholdStyle(s.thresholds, v, s.style)
+		for row := 0; row < height; row++ {
+			cell := min(max(level-row*8, 0), 8)
+			if cell > 0 {
+				ctx.SetCell(x+i, height-1-row, sparkBlocks[cell], style)
+			}
+		}
+	}
+}
+
+// Bar is one bar of a BarChart.
+type Bar struct {
+	Label string
+	Value float64
+
+	// Style overrides the chart's style and thresholds for this bar.
+	Style *Style
+}
+
+// barChartView draws labeled horizontal bars.
+type barChartView struct {
+	bars       []Bar
+	width      int
+	max        float64
+	style      Style
+	labelStyle Style
+	valueStyle Style
+	thresholds []Threshold
+	showValues bool
+	axis       bool
+	format     func(float64) string
+}
+
+// BarChart creates a chart of labeled horizontal bars, scaled so the
+// largest value fills the chart. Bar ends use partial block characters for
+// precision finer than a cell.
+//
+// Example:
+//
+//	BarChart([]Bar{
+//	    {Label: "GET", Value: 1204},
+//	    {Label: "POST", Value: 311},
+//	}).Width(30).Axis()
+func BarChart(bars []Bar) *barChartView {
+	return &barChartView{
+		bars:       bars,
+		width:      30,
+		style:      NewStyle().WithForeground(ColorCyan),
+		labelStyle: NewStyle(),
+		valueStyle: NewStyle().WithForeground(ColorBrightBlack),
+		showValues: true,
+		format:     formatChartValue,
+	}
+}
+
+// Width sets the width of the longest bar.
+func (b *barChartView) Width(w int) *barChartView {
+	b.width = w
+	return b
+}
+
+// Max fixes the value of a full-width bar instead of scaling to the data.
+func (b *barChartView) Max(v float64) *barChartView {
+	b.max = v
+	return b
+}
+
+// Fg sets the color of the bars.
+func (b *barChartView) Fg(c Color) *barChartView {
+	b.style = b.style.WithForeground(c)
+	return b
+}
+
+// Style sets the style of the bars.
+func (b *barChartView) Style(style Style) *barChartView {
+	b.style = style
+	return b
+}
+
+// LabelStyle sets the style of the bar labels.
+func (b *barChartView) LabelStyle(style Style) *barChartView {
+	b.labelStyle = style
+	return b
+}
+
+// Thresholds colors each bar by its value.
+func (b *barChartView) Thresholds(thresholds ...Threshold) *barChartView {
+	b.thresholds = thresholds
+	return b
+}
+
+// ShowValues shows or hides the value after each bar (shown by default).
+func (b *barChartView) ShowValues(show bool) *barChartView {
+	b.showValues = show
+	return b
+}
+
+// Axis adds a scale below the bars, labeled with 0 and the full-width value.
+func (b *barChartView) Axis() *barChartView {
+	b.axis = true
+	return b
+}
+
+// Format sets how values and axis labels are formatted.
+func (b *barChartView) Format(format func(float64) string) *barChartView {
+	if format != nil {
+		b.format = format
+	}
+	return b
+}
+
+// scale returns the value of a full-width bar.
+func (b *barChartView) scale() float64 {
+	if b.max > 0 {
+		return b.max
+	}
+	top := 0.0
+	for _, bar := range b.bars {
+		top = max(top, bar.Value)
+	}
+	return top
+}
+
+// columns returns the widths of the label and value columns.
+func (b *barChartView) columns() (labelW, valueW int) {
+	for _, bar := range b.bars {
+		labelW = max(labelW, runewidth.StringWidth(bar.Label))
+		if b.showValues {
+			valueW = max(valueW, runewidth.StringWidth(b.format(bar.Value)))
+		}
+	}
+	if labelW > 0 {
+		labelW++
+	}
+	if valueW > 0 {
+		valueW++
+	}
+	return labelW, valueW
+}
+
+func (b *barChartView) size(maxWidth, maxHeight int) (int, int) {
+	labelW, valueW := b.columns()
+	w := labelW + b.width + valueW
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	h := len(b.bars)
+	if b.axis {
+		h += 2
+	}
+	if maxHeight > 0 && h > maxHeight {
+		h = maxHeight
+	}
+	return w, h
+}
+
+func (b *barChartView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+
+	labelW, valueW := b.columns()
+	barW := max(1, min(b.width, width-labelW-valueW))
+	scale := b.scale()
+
+	for y, bar := range b.bars {
+		if y >= height {
+			return
+		}
+		ctx.PrintTruncated(0, y, bar.Label, b.labelStyle)
+
+		style := thresholdStyle(b.thresholds, bar.Value, b.style)
+		if bar.Style != nil {
+			style = *bar.Style
+		}
+		eighths := 0
+		if scale > 0 && bar.Value > 0 {
+			eighths = int(math.Round(min(bar.Value/scale, 1) * float64(barW*8)))
+		}
+		cells := eighths / 8
+		for i := 0; i < cells; i++ {
+			ctx.SetCell(labelW+i, y, '█', style)
+		}
+		if eighths%8 > 0 {
+			ctx.SetCell(labelW+cells, y, progressEighths[eighths%8], style)
+		}
+
+		if b.showValues {
+			end := labelW + cells
+			if eighths%8 > 0 {
+				end++
+			}
+			ctx.PrintTruncated(end+1, y, b.format(bar.Value), b.valueStyle)
+		}
+	}
+
+	// Scale below the bars
+	if y := len(b.bars); b.axis && y+1 < height {
+		axisStyle := NewStyle().WithForeground(ColorBrightBlack)
+		ctx.PrintTruncated(labelW, y, "└", axisStyle)
+		for i := 1; i < barW-1; i++ {
+			ctx.SetCell(labelW+i, y, '─', axisStyle)
+		}
+		if barW > 1 {
+			ctx.PrintTruncated(labelW+barW-1, y, "┘", axisStyle)
+		}
+		top := b.format(scale)
+		ctx.PrintTruncated(labelW, y+1, "0", axisStyle)
+		ctx.PrintTruncated(labelW+max(1, barW-runewidth.StringWidth(top)), y+1, top, axisStyle)
+	}
+}
+
+// gaugeView shows a value as a filled bar with its percentage.
+type gaugeView struct {
+	label      string
+	value      float64
+	max        float64
+	width      int
+	style      Style
+	emptyStyle Style
+	labelStyle Style
+	thresholds []Threshold
+	showValue  bool
+}
+
+// Gauge creates a one-line gauge of value out of max. The bar fills with
+// partial block characters, and Thresholds change its color as the value
+// rises.
+//
+// Example:
+//
+//	Gauge(app.cpu, 100).Label("CPU").Thresholds(
+//	    Threshold{At: 70, Color: ColorYellow},
+//	    Threshold{At: 90, Color: ColorRed},
+//	)
+func Gauge(value, max float64) *gaugeView {
+	return &gaugeView{
+		value:      value,
+		max:        max,
+		width:      20,
+		style:      NewStyle().WithForeground(ColorGreen),
+		emptyStyle: NewStyle().WithForeground(ColorBrightBlack),
+		labelStyle: NewStyle(),
+		showValue:  true,
+	}
+}
+
+// Label sets a label shown before the gauge.
+func (g *gaugeView) Label(label string) *gaugeView {
+	g.label = label
+	return g
+}
+
+// Width sets the width of the bar.
+func (g *gaugeView) Width(w int) *gaugeView {
+	g.width = w
+	return g
+}
+
+// Fg sets the color of the filled portion.
+func (g *gaugeView) Fg(c Color) *gaugeView {
+	g.style = g.style.WithForeground(c)
+	return g
+}
+
+// Style sets the style of the filled portion.
+func (g *gaugeView) Style(style Style) *gaugeView {
+	g.style = style
+	return g
+}
+
+// LabelStyle sets the style of the label.
+func (g *gaugeView) LabelStyle(style Style) *gaugeView {
+	g.labelStyle = style
+	return g
+}
+
+// Thresholds colors the gauge by its value.
+func (g *gaugeView) Thresholds(thresholds ...Threshold) *gaugeView {
+	g.thresholds = thresholds
+	return g
+}
+
+// ShowValue shows or hides the percentage after the bar (shown by default).
+func (g *gaugeView) ShowValue(show bool) *gaugeView {
+	g.showValue = show
+	return g
+}
+
+// fraction returns how full the gauge is, from 0 to 1.
+func (g *gaugeView) fraction() float64 {
+	if g.max <= 0 {
+		return 0
+	}
+	return min(max(g.value/g.max, 0), 1)
+}
+
+func (g *gaugeView) size(maxWidth, maxHeight int) (int, int) {
+	w := g.width
+	if g.label != "" {
+		w += runewidth.StringWidth(g.label) + 1
+	}
+	if g.showValue {
+		w += 5 // " 100%"
+	}
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	return w, 1
+}
+
+func (g *gaugeView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+
+	x := 0
+	if g.label != "" {
+		ctx.PrintTruncated(0, 0, g.label, g.labelStyle)
+		x = runewidth.StringWidth(g.label) + 1
+	}
+	barW := g.width
+	if g.showValue {
+		barW = min(barW, width-x-5)
+	}
+	barW = max(1, min(barW, width-x))
+
+	style := thresholdStyle(g.thresholds, g.value, g.style)
+	eighths := int(math.Round(g.fraction() * float64(barW*8)))
+	for i := 0; i < barW; i++ {
+		switch fill := eighths - i*8; {
+		case fill >= 8:
+			ctx.SetCell(x+i, 0, '█', style)
+		case fill > 0:
+			ctx.SetCell(x+i, 0, progressEighths[fill], style)
+		default:
+			ctx.SetCell(x+i, 0, '░', g.emptyStyle)
+		}
+	}
+
+	if g.showValue {
+		percent := int(math.Round(g.fraction() * 100))
+		ctx.PrintTruncated(x+barW, 0, fmt.Sprintf(" %3d%%", percent), style)
+	}
+}
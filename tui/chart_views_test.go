@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/termtest"
+)
+
+func TestSparkline(t *testing.T) {
+	screen := SprintScreen(Sparkline([]float64{0, 1, 2, 3, 4, 5, 6, 7}), PrintConfig{Width: 20, Height: 1})
+	assert.Equal(t, "▁▂▃▄▅▆▇█", screen.Row(0))
+
+	// Two rows give sixteen levels
+	screen = SprintScreen(Sparkline([]float64{0, 15}).Height(2), PrintConfig{Width: 20, Height: 2})
+	assert.Equal(t, " █", screen.Row(0))
+	assert.Equal(t, "▁█", screen.Row(1))
+
+	// A fixed range doesn't stretch the data
+	screen = SprintScreen(Sparkline([]float64{50, 50}).Range(0, 100), PrintConfig{Width: 20, Height: 1})
+	assert.Equal(t, "▅▅", screen.Row(0))
+}
+
+func TestSparkline_Downsample(t *testing.T) {
+	values := make([]float64, 100)
+	for i := 50; i < 100; i++ {
+		values[i] = 8
+	}
+	screen := SprintScreen(Sparkline(values), PrintConfig{Width: 4, Height: 1})
+	assert.Equal(t, "▁▁██", screen.Row(0))
+
+	assert.Equal(t, []float64{1.5, 3.5}, downsample([]float64{1, 2, 3, 4}, 2))
+	assert.Equal(t, []float64{1, 2}, downsample([]float64{1, 2}, 5))
+}
+
+func TestSparkline_AxisAndThresholds(t *testing.T) {
+	view := Sparkline([]float64{10, 95}).Height(2).Axis().
+		Thresholds(Threshold{At: 50, Color: ColorYellow}, Threshold{At: 90, Color: ColorRed})
+	w, h := view.size(0, 0)
+	assert.Equal(t, 5, w)
+	assert.Equal(t, 2, h)
+
+	screen := SprintScreen(view, PrintConfig{Width: 10, Height: 2})
+	assert.Equal(t, "95  █", screen.Row(0))
+	assert.Equal(t, "10 ▁█", screen.Row(1))
+	assert.Equal(t, termtest.Color{Type: termtest.ColorBasic, Value: uint8(ColorGreen)}, screen.Cell(3, 1).Style.Foreground)
+	assert.Equal(t, termtest.Color{Type: termtest.ColorBasic, Value: uint8(ColorRed)}, screen.Cell(4, 1).Style.Foreground)
+}
+
+func TestBarChart(t *testing.T) {
+	highlight := NewStyle().WithForeground(ColorMagenta)
+	view := BarChart([]Bar{
+		{Label: "GET", Value: 8},
+		{Label: "POST", Value: 3},
+		{Label: "PUT", Value: 1, Style: &highlight},
+	}).Width(8).Axis()
+
+	w, h := view.size(0, 0)
+	assert.Equal(t, 15, w)
+	assert.Equal(t, 5, h)
+
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, "GET  ████████ 8", screen.Row(0))
+	assert.Equal(t, "POST ███ 3", screen.Row(1))
+	assert.Equal(t, "PUT  █ 1", screen.Row(2))
+	assert.Equal(t, "     └──────┘", screen.Row(3))
+	assert.Equal(t, "     0      8", screen.Row(4))
+	assert.Equal(t, termtest.Color{Type: termtest.ColorBasic, Value: uint8(ColorCyan)}, screen.Cell(5, 0).Style.Foreground)
+	assert.Equal(t, termtest.Color{Type: termtest.ColorBasic, Value: uint8(ColorMagenta)}, screen.Cell(5, 2).Style.Foreground)
+
+	// Fractions of a cell use partial blocks
+	screen = SprintScreen(BarChart([]Bar{{Value: 2}}).Max(16).Width(4).ShowValues(false), PrintConfig{Width: 10, Height: 1})
+	assert.Equal(t, "▌", screen.Row(0))
+}
+
+func TestGauge(t *testing.T) {
+	view := Gauge(75, 100).Label("CPU").Width(10).
+		Thresholds(Threshold{At: 70, Color: ColorYellow}, Threshold{At: 90, Color: ColorRed})
+	w, _ := view.size(0, 0)
+	assert.Equal(t, 19, w)
+
+	screen := SprintScreen(view, PrintConfig{Width: 30, Height: 1})
+	assert.Equal(t, "CPU ███████▌░░  75%", screen.Row(0))
+	assert.Equal(t, termtest.Color{Type: termtest.ColorBasic, Value: uint8(ColorYellow)}, screen.Cell(4, 0).Style.Foreground)
+
+	screen = SprintScreen(Gauge(150, 100).Width(4), PrintConfig{Width: 30, Height: 1})
+	assert.Equal(t, "████ 100%", screen.Row(0))
+}
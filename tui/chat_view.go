@@ -0,0 +1,367 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// ChatState holds the scroll position and read marker of a ChatView. It
+// lives in application state; pass keys to HandleKey to scroll. The zero
+// value follows the newest message.
+type ChatState struct {
+	paused bool // Showing older lines instead of following the newest
+	top    int  // First line shown while paused
+	height int  // Rows shown at the last render
+	total  int  // Lines at the last render
+
+	unread    int // Index of the first unread message, if hasUnread
+	hasUnread bool
+
+	jump     bool      // Scroll to jumpTo or jumpTime on the next render
+	jumpTo   int       // Message index to show at the top
+	jumpTime time.Time // If set, the first message at or after it instead
+}
+
+// NewChatState creates a ChatState that follows the newest message.
+func NewChatState() *ChatState {
+	return &ChatState{}
+}
+
+// MarkUnread marks the messages from index on as unread, such as those that
+// arrived while the window was in the background. The view draws a divider
+// above the first unread message. An earlier marker is kept.
+func (s *ChatState) MarkUnread(index int) {
+	if !s.hasUnread || index < s.unread {
+		s.unread, s.hasUnread = index, true
+	}
+}
+
+// MarkRead removes the unread marker.
+func (s *ChatState) MarkRead() {
+	s.hasUnread = false
+}
+
+// FirstUnread returns the index of the first unread message, if any.
+func (s *ChatState) FirstUnread() (int, bool) {
+	return s.unread, s.hasUnread
+}
+
+// JumpToUnread scrolls to the first unread message on the next render. It
+// returns false if there are no unread messages.
+func (s *ChatState) JumpToUnread() bool {
+	if !s.hasUnread {
+		return false
+	}
+	s.JumpToMessage(s.unread)
+	return true
+}
+
+// JumpToMessage scrolls to the message at index on the next render.
+func (s *ChatState) JumpToMessage(index int) {
+	s.jump, s.jumpTo, s.jumpTime = true, index, time.Time{}
+}
+
+// JumpToTime scrolls to the first message sent at or after t on the next
+// render, or to the end if there is none.
+func (s *ChatState) JumpToTime(t time.Time) {
+	s.jump, s.jumpTime = true, t
+}
+
+// Following reports whether the view follows the newest message.
+func (s *ChatState) Following() bool {
+	return !s.paused
+}
+
+// scrollTo shows line i at the top, following when that is the bottom.
+func (s *ChatState) scrollTo(i int) {
+	bottom := max(0, s.total-s.height)
+	s.paused = i < bottom
+	s.top = max(0, min(i, bottom))
+}
+
+// scrollY returns the first line shown.
+func (s *ChatState) scrollY() int {
+	bottom := max(0, s.total-s.height)
+	if !s.paused {
+		return bottom
+	}
+	return min(s.top, bottom)
+}
+
+// HandleKey handles a key for the view and reports whether it used it:
+//
+//   - Up/Down or k/j: Scroll by a line
+//   - PgUp/PgDn: Scroll by a page
+//   - Home or g: Go to the first message
+//   - End or G: Go to the newest message and follow
+//   - u: Go to the first unread message
+func (s *ChatState) HandleKey(event KeyEvent) bool {
+	page := max(1, s.height-1)
+	top := s.scrollY()
+	switch {
+	case event.Key == KeyArrowUp || event.Rune == 'k':
+		s.scrollTo(top - 1)
+	case event.Key == KeyArrowDown || event.Rune == 'j':
+		s.scrollTo(top + 1)
+	case event.Key == KeyPageUp:
+		s.scrollTo(top - page)
+	case event.Key == KeyPageDown:
+		s.scrollTo(top + page)
+	case event.Key == KeyHome || event.Rune == 'g':
+		s.scrollTo(0)
+	case event.Key == KeyEnd || event.Rune == 'G':
+		s.paused = false
+	case event.Rune == 'u':
+		return s.JumpToUnread()
+	default:
+		return false
+	}
+	return true
+}
+
+// chatView renders a conversation.
+type chatView struct {
+	messages   []ChatMessage
+	state      *ChatState
+	search     *SearchController
+	timeLayout string
+	roleStyles map[string]Style
+	timeStyle  Style
+	newStyle   Style
+}
+
+// ChatView renders a conversation: each message under a header with its
+// role and time, its text wrapped to the width. It fills the space it is
+// given, follows new messages while scrolled to the bottom, and draws a
+// scrollbar down the right edge.
+//
+// Bind a SearchController with SearchWith to search the transcript: matches
+// are highlighted, the current one is scrolled into view, and every match
+// is marked on the scrollbar, so the scrollbar works as a minimap of where
+// they are. Mark messages unread on the state to show a divider above them
+// and jump there with "u" or ChatState.JumpToUnread.
+//
+// Example:
+//
+//	tui.Stack(
+//	    tui.ChatView(app.messages, app.chat).SearchWith(&app.search),
+//	    tui.SearchBar(&app.search),
+//	)
+func ChatView(messages []ChatMessage, state *ChatState) *chatView {
+	return &chatView{
+		messages:   messages,
+		state:      state,
+		timeLayout: "15:04",
+		roleStyles: map[string]Style{
+			"user":      NewStyle().WithForeground(ColorCyan).WithBold(),
+			"assistant": NewStyle().WithForeground(ColorGreen).WithBold(),
+			"system":    NewStyle().WithForeground(ColorYellow).WithBold(),
+		},
+		timeStyle: NewStyle().WithForeground(ColorBrightBlack),
+		newStyle:  NewStyle().WithForeground(ColorRed),
+	}
+}
+
+// SearchWith binds a SearchController. Matches are highlighted and marked
+// on the scrollbar, and moving to a match scrolls it into view.
+func (v *chatView) SearchWith(ctrl *SearchController) *chatView {
+	v.search = ctrl
+	return v
+}
+
+// RoleStyle sets the style of the headers of messages with role. Other roles
+// are bold.
+func (v *chatView) RoleStyle(role string, s Style) *chatView {
+	v.roleStyles[role] = s
+	return v
+}
+
+// TimeFormat sets the time.Format layout of message times. Default "15:04";
+// empty hides them.
+func (v *chatView) TimeFormat(layout string) *chatView {
+	v.timeLayout = layout
+	return v
+}
+
+func (v *chatView) flex() int {
+	return 1
+}
+
+func (v *chatView) size(maxWidth, maxHeight int) (int, int) {
+	h := maxHeight
+	if h <= 0 {
+		h = len(v.layout(max(1, maxWidth-1)))
+	}
+	return maxWidth, h
+}
+
+// chatLine is one row of the laid out conversation.
+type chatLine struct {
+	segments []StyledSegment
+	indent   int
+	message  int  // Index of the message the line belongs to
+	header   bool // The message's header
+	divider  bool // The unread divider, which isn't searched
+}
+
+// text returns the line's text, for searching.
+func (l chatLine) text() string {
+	if l.divider {
+		return ""
+	}
+	var sb strings.Builder
+	for _, seg := range l.segments {
+		sb.WriteString(seg.Text)
+	}
+	return sb.String()
+}
+
+// layout lays the conversation out in width columns.
+func (v *chatView) layout(width int) []chatLine {
+	var lines []chatLine
+	for i, m := range v.messages {
+		if i > 0 {
+			lines = append(lines, chatLine{message: i})
+		}
+		if v.state != nil && v.state.hasUnread && v.state.unread == i {
+			label := " new "
+			side := max(0, width-runewidth.StringWidth(label)) / 2
+			text := strings.Repeat("─", side) + label + strings.Repeat("─", max(0, width-side-len(label)))
+			lines = append(lines, chatLine{
+				segments: []StyledSegment{{Text: text, Style: v.newStyle}},
+				message:  i,
+				divider:  true,
+			})
+		}
+
+		style, ok := v.roleStyles[m.Role]
+		if !ok {
+			style = NewStyle().WithBold()
+		}
+		header := []StyledSegment{{Text: transcriptHeader(ChatMessage{Role: m.Role}), Style: style}}
+		if v.timeLayout != "" && !m.Time.IsZero() {
+			header = append(header, StyledSegment{Text: "  " + m.Time.Format(v.timeLayout), Style: v.timeStyle})
+		}
+		lines = append(lines, chatLine{segments: header, message: i, header: true})
+
+		for _, text := range strings.Split(WrapText(strings.TrimRight(m.Content, "\n"), max(1, width-2)), "\n") {
+			lines = append(lines, chatLine{
+				segments: []StyledSegment{{Text: text, Style: NewStyle()}},
+				indent:   2,
+				message:  i,
+			})
+		}
+	}
+	return lines
+}
+
+// jumpLine returns the line to scroll to for the state's pending jump.
+func (v *chatView) jumpLine(lines []chatLine) int {
+	target := v.state.jumpTo
+	if !v.state.jumpTime.IsZero() {
+		target = len(v.messages)
+		for i, m := range v.messages {
+			if !m.Time.Before(v.state.jumpTime) {
+				target = i
+				break
+			}
+		}
+	}
+	for i, line := range lines {
+		// Show the unread divider above the message too
+		if line.message == target && (line.header || line.divider) {
+			return i
+		}
+	}
+	return len(lines)
+}
+
+func (v *chatView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width < 2 || height == 0 {
+		return
+	}
+	width-- // The scrollbar
+	lines := v.layout(width)
+
+	state := v.state
+	if state == nil {
+		state = &ChatState{}
+	}
+	state.height, state.total = height, len(lines)
+	if state.jump {
+		state.jump = false
+		state.scrollTo(v.jumpLine(lines))
+	}
+
+	if v.search != nil {
+		texts := make([]string, len(lines))
+		for i, line := range lines {
+			texts[i] = line.text()
+		}
+		v.search.setMatches(findMatches(texts, v.search.Query, v.search.CaseSensitive))
+		if target, ok := v.search.revealLine(state.scrollY(), height); ok {
+			state.scrollTo(target)
+		}
+	}
+
+	scrollY := state.scrollY()
+	for y := 0; y < height && scrollY+y < len(lines); y++ {
+		line := lines[scrollY+y]
+		segments := line.segments
+		if v.search != nil && !line.divider {
+			segments = v.search.highlightSegments(segments, scrollY+y)
+		}
+		x := line.indent
+		for _, seg := range segments {
+			if x >= width {
+				break
+			}
+			text := truncateToWidth(seg.Text, width-x)
+			ctx.PrintStyled(x, y, text, seg.Style)
+			x += runewidth.StringWidth(text)
+		}
+	}
+	v.renderScrollbar(ctx, width, height, scrollY, len(lines))
+}
+
+// renderScrollbar draws the scrollbar in column x: a thumb for the lines in
+// view, and a mark on each row standing for lines with search matches.
+func (v *chatView) renderScrollbar(ctx *RenderContext, x, height, scrollY, total int) {
+	if total <= height {
+		return
+	}
+	track := NewStyle().WithForeground(ColorBrightBlack)
+	thumb := NewStyle().WithForeground(ColorWhite)
+	var matches []SearchMatch
+	current := -1
+	if v.search != nil {
+		matches = v.search.Matches()
+		current = v.search.Index()
+	}
+
+	for y := 0; y < height; y++ {
+		// The lines row y stands for
+		lo := y * total / height
+		hi := max(lo+1, (y+1)*total/height)
+
+		char, style := '│', track
+		if lo < scrollY+height && hi > scrollY {
+			char, style = '┃', thumb
+		}
+		for i, m := range matches {
+			if m.Line < lo || m.Line >= hi {
+				continue
+			}
+			char = '━'
+			if i == current {
+				style = v.search.currentStyle()
+				break
+			}
+			style = v.search.matchStyle()
+		}
+		ctx.SetCell(x, y, char, style)
+	}
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/termtest"
+)
+
+func chatMessages(n int) []ChatMessage {
+	start := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	var messages []ChatMessage
+	for i := 0; i < n; i++ {
+		role := "user"
+		if i%2 == 1 {
+			role = "assistant"
+		}
+		messages = append(messages, ChatMessage{
+			Role:    role,
+			Content: fmt.Sprintf("message %d", i),
+			Time:    start.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	return messages
+}
+
+func TestChatView_FollowsAndScrolls(t *testing.T) {
+	messages := chatMessages(4)
+	state := NewChatState()
+	screen := SprintScreen(ChatView(messages, state), PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, "User  11:00        │", screen.Row(0))
+	assert.Equal(t, "  message 2        │", screen.Row(1))
+	assert.Equal(t, "Assistant  12:00", screen.Row(3)[:16])
+	assert.True(t, state.Following())
+
+	state.HandleKey(KeyEvent{Key: KeyHome})
+	screen = SprintScreen(ChatView(messages, state), PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, "User  09:00", screen.Row(0)[:11])
+	assert.False(t, state.Following())
+
+	// Jump to the first message sent at 11:00 or later
+	state.JumpToTime(time.Date(2026, 5, 1, 10, 30, 0, 0, time.UTC))
+	screen = SprintScreen(ChatView(messages, state), PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, "User  11:00", screen.Row(0)[:11])
+}
+
+func TestChatView_Unread(t *testing.T) {
+	messages := chatMessages(6)
+	state := NewChatState()
+	state.MarkUnread(3)
+	state.MarkUnread(4) // The earlier marker is kept
+	index, ok := state.FirstUnread()
+	assert.True(t, ok)
+	assert.Equal(t, 3, index)
+
+	assert.True(t, state.HandleKey(KeyEvent{Rune: 'u'}))
+	screen := SprintScreen(ChatView(messages, state), PrintConfig{Width: 20, Height: 4})
+	assert.Equal(t, "─────── new ───────│", screen.Row(0))
+	assert.Equal(t, "Assistant  12:00", screen.Row(1)[:16])
+
+	state.MarkRead()
+	assert.False(t, state.JumpToUnread())
+}
+
+func TestChatView_SearchMarksScrollbar(t *testing.T) {
+	messages := chatMessages(10)
+	search := NewSearchController()
+	search.SetQuery("message 1")
+	state := NewChatState()
+	screen := SprintScreen(ChatView(messages, state).SearchWith(search), PrintConfig{Width: 20, Height: 6})
+
+	// The first match is brought into view and highlighted
+	assert.Equal(t, 1, search.Count())
+	assert.Equal(t, "  message 1", screen.Row(2)[:11])
+	assert.Equal(t, termtest.Color{Type: termtest.ColorBasic, Value: uint8(ColorBrightYellow)}, screen.Cell(2, 2).Style.Background)
+
+	// Its place in the conversation is marked on the scrollbar
+	marks := 0
+	for y := 0; y < 6; y++ {
+		if screen.Cell(19, y).Char == '━' {
+			marks++
+		}
+	}
+	assert.Equal(t, 1, marks)
+}
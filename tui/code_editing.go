@@ -0,0 +1,276 @@
+package tui
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// CodeEditing turns on editing helpers for code in a TextInput, for small
+// embedded code editors. The zero value turns them all off.
+//
+// Example:
+//
+//	tui.InputField(&app.query).
+//	    Multiline(true).
+//	    CodeEditing(tui.CodeEditingFor("sql"))
+type CodeEditing struct {
+	// AutoPair closes brackets and quotes as they are typed. Typing the
+	// closing character just before the one inserted steps over it, and
+	// Backspace between an empty pair deletes both.
+	AutoPair bool
+
+	// AutoIndent starts new lines with the indentation of the line before,
+	// indented a level further after an opening bracket.
+	AutoIndent bool
+
+	// TabWidth, if non-zero, makes Tab insert spaces up to the next
+	// multiple of TabWidth columns, and Shift+Tab remove a level of
+	// indentation from the line. A level of indentation is TabWidth spaces,
+	// or a tab if TabWidth is zero, in which case Tab is left to move focus.
+	TabWidth int
+
+	// LineComment is the prefix, like "//" or "#", that Ctrl+/ adds to or
+	// removes from the start of the lines with cursors. Empty disables it.
+	LineComment string
+}
+
+// lineComments maps language names, as used by Code, to their line comment
+// prefix.
+var lineComments = map[string]string{
+	"go": "//", "golang": "//", "c": "//", "cpp": "//", "c++": "//", "java": "//",
+	"javascript": "//", "js": "//", "jsx": "//", "typescript": "//", "ts": "//", "tsx": "//",
+	"rust": "//", "rs": "//", "swift": "//", "kotlin": "//", "scala": "//",
+	"csharp": "//", "c#": "//", "php": "//", "dart": "//", "zig": "//", "protobuf": "//", "proto": "//",
+	"python": "#", "py": "#", "ruby": "#", "rb": "#", "bash": "#", "sh": "#", "shell": "#",
+	"zsh": "#", "fish": "#", "yaml": "#", "yml": "#", "toml": "#", "perl": "#", "r": "#",
+	"dockerfile": "#", "nix": "#", "elixir": "#", "powershell": "#", "graphql": "#", "hcl": "#", "terraform": "#",
+	"sql": "--", "lua": "--", "haskell": "--", "hs": "--", "elm": "--",
+	"lisp": ";", "clojure": ";", "scheme": ";", "ini": ";", "nasm": ";",
+	"erlang": "%", "tex": "%", "latex": "%", "matlab": "%",
+	"vim": "\"",
+}
+
+// CodeEditingFor returns a CodeEditing with brackets and quotes paired,
+// indentation kept, Tab inserting four spaces, and the line comment of
+// language, named as for Code (like "go", "python", or "sql"). Languages it
+// doesn't know get no comment toggle.
+func CodeEditingFor(language string) CodeEditing {
+	return CodeEditing{
+		AutoPair:    true,
+		AutoIndent:  true,
+		TabWidth:    4,
+		LineComment: lineComments[strings.ToLower(language)],
+	}
+}
+
+// indentUnit returns one level of indentation.
+func (c CodeEditing) indentUnit() string {
+	if c.TabWidth > 0 {
+		return strings.Repeat(" ", c.TabWidth)
+	}
+	return "\t"
+}
+
+// closers maps the characters AutoPair pairs to their closing characters.
+var closers = map[rune]rune{'(': ')', '[': ']', '{': '}', '"': '"', '\'': '\'', '`': '`'}
+
+// WithCodeEditing turns on editing helpers for code.
+func (t *TextInput) WithCodeEditing(c CodeEditing) *TextInput {
+	t.CodeEditing = c
+	return t
+}
+
+// handleCodeKey handles the keys of the CodeEditing helpers. With several
+// cursors only the comment toggle applies.
+func (t *TextInput) handleCodeKey(event KeyEvent) bool {
+	c := t.CodeEditing
+	if c.LineComment != "" && (event.Ctrl && event.Rune == '/' || event.Rune == 0x1f) {
+		t.toggleComment()
+		return true
+	}
+	if t.cursors != nil {
+		return false
+	}
+
+	text := t.DisplayText()
+	prev, _ := utf8.DecodeLastRuneInString(text[:t.CursorPos])
+	next, _ := utf8.DecodeRuneInString(text[t.CursorPos:])
+	switch {
+	case c.TabWidth > 0 && event.Key == KeyTab && !event.Ctrl:
+		if event.Shift {
+			t.dedentLine()
+			return true
+		}
+		starts := lineStarts(text)
+		col := runewidth.StringWidth(text[starts[lineOf(starts, t.CursorPos)]:t.CursorPos])
+		t.insertAtCursor(strings.Repeat(" ", c.TabWidth-col%c.TabWidth))
+		t.codeEdited()
+		return true
+
+	case c.AutoPair && event.Key == KeyBackspace && !event.Ctrl && !event.Alt:
+		if closer, ok := closers[prev]; ok && next == closer && t.CursorPos < len(text) {
+			t.deleteForward()
+			t.deleteBackward()
+			t.codeEdited()
+			return true
+		}
+
+	case c.AutoPair && event.Rune != 0 && !event.Ctrl && !event.Alt:
+		r := event.Rune
+		if r == next && strings.ContainsRune(")]}\"'`", r) {
+			// Step over the closing character
+			t.CursorPos += utf8.RuneLen(r)
+			t.MarkDirty()
+			return true
+		}
+		closer, ok := closers[r]
+		if !ok || (next != utf8.RuneError && !strings.ContainsRune(" \t\n)]},;:", next)) {
+			return false
+		}
+		if closer == r && isWordChar(prev) {
+			return false // An apostrophe, as in "don't"
+		}
+		if t.MaxLength > 0 && utf8.RuneCountInString(t.Value())+2 > t.MaxLength {
+			return false
+		}
+		t.insertAtCursor(string(r) + string(closer))
+		t.CursorPos -= utf8.RuneLen(closer)
+		t.codeEdited()
+		return true
+	}
+	return false
+}
+
+// insertLineBreak inserts a newline at the cursor, indenting the new line
+// if AutoIndent is on.
+func (t *TextInput) insertLineBreak() {
+	t.insertNewline()
+	if !t.CodeEditing.AutoIndent {
+		return
+	}
+	text := t.DisplayText()
+	starts := lineStarts(text)
+	line := lineOf(starts, t.CursorPos) - 1
+	prevLine := text[starts[line] : starts[line+1]-1]
+	indent := prevLine[:len(prevLine)-len(strings.TrimLeft(prevLine, " \t"))]
+	t.insertAtCursor(indent)
+
+	last, _ := utf8.DecodeLastRuneInString(strings.TrimRight(prevLine, " \t"))
+	if closer, ok := closers[last]; ok && strings.ContainsRune("([{", last) {
+		t.insertAtCursor(t.CodeEditing.indentUnit())
+		// Move a closing bracket after the cursor onto its own line
+		next, _ := utf8.DecodeRuneInString(t.DisplayText()[t.CursorPos:])
+		if next == closer {
+			pos := t.CursorPos
+			t.insertNewline()
+			t.insertAtCursor(indent)
+			t.CursorPos = pos
+		}
+	}
+}
+
+// toggleComment comments the lines with cursors that aren't commented, or
+// uncomments them all if they all are. Blank lines are left alone.
+func (t *TextInput) toggleComment() {
+	prefix := t.CodeEditing.LineComment
+	text := t.DisplayText()
+	starts := lineStarts(text)
+
+	var lines []int
+	for _, pos := range t.Cursors() {
+		if line := lineOf(starts, pos); len(lines) == 0 || lines[len(lines)-1] != line {
+			lines = append(lines, line)
+		}
+	}
+	// indentEnd returns the offset of the first non-blank character of a
+	// line, or -1 if it is blank
+	indentEnd := func(line int) int {
+		start, end := starts[line], lineEnd(text, starts, line)
+		body := strings.TrimLeft(text[start:end], " \t")
+		if body == "" {
+			return -1
+		}
+		return end - len(body)
+	}
+	commented := true
+	for _, line := range lines {
+		if at := indentEnd(line); at >= 0 && !strings.HasPrefix(text[at:], prefix) {
+			commented = false
+		}
+	}
+
+	// From the last line up, so offsets before each edit stay valid
+	for i := len(lines) - 1; i >= 0; i-- {
+		at := indentEnd(lines[i])
+		if at < 0 {
+			continue
+		}
+		if !commented {
+			if !strings.HasPrefix(text[at:], prefix) {
+				t.replaceAt(at, 0, prefix+" ")
+			}
+			continue
+		}
+		n := len(prefix)
+		if strings.HasPrefix(text[at+n:], " ") {
+			n++
+		}
+		t.replaceAt(at, n, "")
+	}
+	t.codeEdited()
+}
+
+// dedentLine removes a level of indentation from the cursor's line.
+func (t *TextInput) dedentLine() {
+	text := t.DisplayText()
+	starts := lineStarts(text)
+	start := starts[lineOf(starts, t.CursorPos)]
+	n := 0
+	if strings.HasPrefix(text[start:], "\t") {
+		n = 1
+	} else {
+		for n < t.CodeEditing.TabWidth && start+n < len(text) && text[start+n] == ' ' {
+			n++
+		}
+	}
+	if n > 0 {
+		t.replaceAt(start, n, "")
+		t.codeEdited()
+	}
+}
+
+// replaceAt replaces n bytes of the display text at offset at with text,
+// keeping the cursors on the same text: cursors at or after the edit move
+// with the text after it, and cursors inside removed text move to its
+// start.
+func (t *TextInput) replaceAt(at, n int, text string) {
+	shift := func(pos int) int {
+		switch {
+		case pos >= at+n:
+			return pos + len(text) - n
+		case pos > at:
+			return at
+		}
+		return pos
+	}
+	cursor := shift(t.CursorPos)
+	for i, c := range t.cursors {
+		t.cursors[i] = inputCursor{pos: shift(c.pos), anchor: shift(c.anchor)}
+	}
+
+	t.CursorPos = at + n
+	for t.CursorPos > at && t.deleteBackward() {
+	}
+	t.insertAtCursor(text)
+	t.CursorPos = cursor
+}
+
+// codeEdited reports an edit made by a CodeEditing helper.
+func (t *TextInput) codeEdited() {
+	if t.OnChange != nil {
+		t.OnChange(t.Value())
+	}
+	t.MarkDirty()
+}
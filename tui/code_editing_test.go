@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func newCodeInput(language string) *TextInput {
+	input := NewTextInput().WithMultilineMode(true).WithCodeEditing(CodeEditingFor(language))
+	input.SetFocused(true)
+	return input
+}
+
+func typeKeys(input *TextInput, text string) {
+	for _, r := range text {
+		input.HandleKey(KeyEvent{Rune: r})
+	}
+}
+
+func TestCodeEditing_AutoPair(t *testing.T) {
+	input := newCodeInput("go")
+	typeKeys(input, `f(`)
+	assert.Equal(t, "f()", input.Value())
+	assert.Equal(t, 2, input.CursorPos)
+
+	typeKeys(input, `"a`)
+	assert.Equal(t, `f("a")`, input.Value())
+	typeKeys(input, `")`)
+	assert.Equal(t, `f("a")`, input.Value())
+	assert.Equal(t, 6, input.CursorPos)
+
+	// Not before a word, and not as an apostrophe
+	input.SetValue("x")
+	input.CursorPos = 0
+	typeKeys(input, "(")
+	assert.Equal(t, "(x", input.Value())
+	input.SetValue("don")
+	typeKeys(input, "'")
+	assert.Equal(t, "don'", input.Value())
+
+	// Backspace in an empty pair deletes both
+	input.SetValue("")
+	typeKeys(input, "[")
+	input.HandleKey(KeyEvent{Key: KeyBackspace})
+	assert.Equal(t, "", input.Value())
+}
+
+func TestCodeEditing_AutoIndent(t *testing.T) {
+	input := newCodeInput("go")
+	input.SetValue("    if x ")
+	typeKeys(input, "{")
+	input.HandleKey(KeyEvent{Key: KeyEnter, Shift: true})
+	assert.Equal(t, "    if x {\n        \n    }", input.Value())
+	assert.Equal(t, 19, input.CursorPos)
+
+	typeKeys(input, "y()")
+	input.HandleKey(KeyEvent{Key: KeyEnter, Shift: true})
+	typeKeys(input, "z")
+	assert.Equal(t, "    if x {\n        y()\n        z\n    }", input.Value())
+}
+
+func TestCodeEditing_Tabs(t *testing.T) {
+	input := newCodeInput("python")
+	typeKeys(input, "ab")
+	input.HandleKey(KeyEvent{Key: KeyTab})
+	assert.Equal(t, "ab  ", input.Value())
+	input.HandleKey(KeyEvent{Key: KeyTab})
+	assert.Equal(t, "ab      ", input.Value())
+
+	input.SetValue("      x")
+	input.HandleKey(KeyEvent{Key: KeyTab, Shift: true})
+	assert.Equal(t, "  x", input.Value())
+	assert.Equal(t, 3, input.CursorPos)
+	input.HandleKey(KeyEvent{Key: KeyTab, Shift: true})
+	assert.Equal(t, "x", input.Value())
+}
+
+func TestCodeEditing_ToggleComment(t *testing.T) {
+	input := newCodeInput("sql")
+	input.SetValue("select *\n  from t\n\nwhere x")
+	input.CursorPos = 12 // in "from"
+	input.HandleKey(KeyEvent{Rune: '/', Ctrl: true})
+	assert.Equal(t, "select *\n  -- from t\n\nwhere x", input.Value())
+	assert.Equal(t, 15, input.CursorPos)
+
+	// Lines with cursors are commented together, and uncommented when all
+	// of them are
+	input.AddCursor(0)
+	input.HandleKey(KeyEvent{Rune: 0x1f})
+	assert.Equal(t, "-- select *\n  -- from t\n\nwhere x", input.Value())
+	assert.Equal(t, []int{3, 18}, input.Cursors())
+	input.HandleKey(KeyEvent{Rune: 0x1f})
+	assert.Equal(t, "select *\n  from t\n\nwhere x", input.Value())
+	assert.Equal(t, []int{0, 12}, input.Cursors())
+
+	assert.Equal(t, "", CodeEditingFor("brainfuck").LineComment)
+	assert.Equal(t, "#", CodeEditingFor("Python").LineComment)
+}
+
+func TestInputField_CodeEditingTakesTab(t *testing.T) {
+	var code string
+	terminal := NewTestTerminal(30, 3, &bytes.Buffer{})
+	app := &simpleApp{
+		renderFunc: func() View {
+			return Stack(
+				InputField(&code).ID("code").Multiline(true).CodeEditing(CodeEditingFor("go")),
+				InputField(nil).ID("other"),
+			)
+		},
+		handleFunc: func(Event) []Cmd { return nil },
+	}
+	runtime := NewRuntime(terminal, app, 30)
+	runtime.render()
+	runtime.processEvent(FocusSetEvent{ID: "code"})
+	runtime.render()
+
+	runtime.processEvent(KeyEvent{Key: KeyTab})
+	runtime.processEvent(KeyEvent{Rune: 'x'})
+	assert.Equal(t, "    x", code)
+	assert.Equal(t, "code", runtime.focusMgr.GetFocusedID())
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/alecthomas/chroma/v2"
@@ -21,6 +22,10 @@ type codeView struct {
 	height      int
 	tabWidth    int
 	highlighted [][]StyledSegment
+	diagnostics []Diagnostic
+	activeLine  int
+	source      *LineSource // lines read on demand, instead of code
+	search      *SearchController
 }
 
 // Code creates a code view with syntax highlighting.
@@ -38,9 +43,28 @@ func Code(code string, language string) *codeView {
 		showNumbers: true,
 		startLine:   1,
 		tabWidth:    4,
+		activeLine:  -1,
 	}
 }
 
+// CodeSource creates a code view that reads its lines from src on demand,
+// for files too large to load. Only the visible lines are read and
+// highlighted, each on its own, so constructs spanning lines such as block
+// comments aren't recognized. The view fills the space it is given.
+//
+// Example:
+//
+//	tui.CodeSource(app.src, "log").ScrollY(&app.scrollY)
+func CodeSource(src *LineSource, language string) *codeView {
+	c := Code("", language)
+	c.source = src
+	return c
+}
+
+// sourceHeight is the height of a CodeSource view that has no fixed height
+// and no height limit.
+const sourceHeight = 24
+
 // Language sets the programming language for syntax highlighting.
 // If not set or unknown, falls back to plain text.
 func (c *codeView) Language(lang string) *codeView {
@@ -103,27 +127,38 @@ func (c *codeView) TabWidth(w int) *codeView {
 	return c
 }
 
+// Diagnostics attaches diagnostics to the code. Their ranges are underlined
+// and their lines get a gutter marker before the line numbers. Use
+// ActiveLine to show a line's messages in a popover.
+func (c *codeView) Diagnostics(diags []Diagnostic) *codeView {
+	c.diagnostics = diags
+	return c
+}
+
+// ActiveLine sets the 0-based line whose diagnostics are shown in a popover
+// below it, such as the line under the application's cursor. Pass -1 (the
+// default) to show none.
+func (c *codeView) ActiveLine(line int) *codeView {
+	c.activeLine = line
+	return c
+}
+
+// SearchWith binds a SearchController. Matches are highlighted, and moving
+// to a match scrolls it into view (requires a ScrollY pointer). A CodeSource
+// view only searches the lines on screen, since searching the whole file
+// would read all of it.
+func (c *codeView) SearchWith(ctrl *SearchController) *codeView {
+	c.search = ctrl
+	return c
+}
+
 // highlight performs syntax highlighting and caches the result.
 func (c *codeView) highlight() {
 	if c.highlighted != nil {
 		return
 	}
 
-	// Get lexer
-	lexer := lexers.Get(c.language)
-	if lexer == nil {
-		lexer = lexers.Analyse(c.code)
-	}
-	if lexer == nil {
-		lexer = lexers.Fallback
-	}
-	lexer = chroma.Coalesce(lexer)
-
-	// Get style
-	style := styles.Get(c.theme)
-	if style == nil {
-		style = styles.Fallback
-	}
+	lexer, style := c.lexerAndStyle()
 
 	// Tokenize
 	iterator, err := lexer.Tokenise(nil, c.code)
@@ -167,6 +202,92 @@ func (c *codeView) highlight() {
 	}
 }
 
+// lexerAndStyle returns the lexer for the view's language and the chroma
+// style for its theme, falling back to plain text.
+func (c *codeView) lexerAndStyle() (chroma.Lexer, *chroma.Style) {
+	// Get lexer
+	lexer := lexers.Get(c.language)
+	if lexer == nil && c.source == nil {
+		lexer = lexers.Analyse(c.code)
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	// Get style
+	style := styles.Get(c.theme)
+	if style == nil {
+		style = styles.Fallback
+	}
+	return lexer, style
+}
+
+// highlightLine highlights a single line read from the view's source.
+func (c *codeView) highlightLine(lexer chroma.Lexer, style *chroma.Style, text string) []StyledSegment {
+	iterator, err := lexer.Tokenise(nil, text)
+	if err != nil {
+		return []StyledSegment{{Text: c.expandTabs(text, 0), Style: NewStyle()}}
+	}
+	segments := []StyledSegment{}
+	col := 0
+	for _, token := range iterator.Tokens() {
+		part := strings.TrimRight(token.Value, "\n")
+		if part == "" {
+			continue
+		}
+		expanded := c.expandTabs(part, col)
+		segments = append(segments, StyledSegment{
+			Text:  expanded,
+			Style: c.chromaToStyle(style.Get(token.Type)),
+		})
+		col += runewidth.StringWidth(expanded)
+	}
+	return segments
+}
+
+// visibleLines returns the highlighted lines from line from on, up to
+// count of them, along with their text before highlighting. It indexes a
+// source as far as needed, but no further.
+func (c *codeView) visibleLines(from, count int) (texts []string, lines [][]StyledSegment) {
+	if c.source != nil {
+		lexer, style := c.lexerAndStyle()
+		key := fmt.Sprintf("%s/%s/%d", c.language, c.theme, c.tabWidth)
+		return c.source.visibleLines(from, count, key, func(text string) []StyledSegment {
+			return c.highlightLine(lexer, style, text)
+		})
+	}
+	c.highlight()
+	end := min(from+count, len(c.highlighted))
+	if from >= end {
+		return nil, nil
+	}
+	if len(c.diagnostics) > 0 {
+		texts = strings.Split(c.code, "\n")[from:]
+	}
+	return texts, c.highlighted[from:end]
+}
+
+// lineCount returns the number of lines, or for a source, the number indexed
+// so far.
+func (c *codeView) lineCount() int {
+	if c.source != nil {
+		n, _ := c.source.LineCount()
+		return n
+	}
+	c.highlight()
+	return len(c.highlighted)
+}
+
+// segmentLines returns the text of each line of segments.
+func segmentLines(lines [][]StyledSegment) []string {
+	texts := make([]string, len(lines))
+	for i, line := range lines {
+		texts[i] = plainText(line)
+	}
+	return texts
+}
+
 // plainLines creates unhighlighted lines for fallback.
 func (c *codeView) plainLines() [][]StyledSegment {
 	lines := strings.Split(c.code, "\n")
@@ -228,13 +349,41 @@ func (c *codeView) expandTabs(s string, startCol int) string {
 	return result.String()
 }
 
+// expandedIndex converts a rune column of a source line to a rune index into
+// the line after tab expansion.
+func (c *codeView) expandedIndex(line string, col int) int {
+	index, width := 0, 0
+	for _, r := range line {
+		if col <= 0 {
+			return index
+		}
+		if r == '\t' {
+			spaces := c.tabWidth - (width % c.tabWidth)
+			index += spaces
+			width += spaces
+		} else {
+			index++
+			width += runewidth.RuneWidth(r)
+		}
+		col--
+	}
+	return index + col
+}
+
+// gutterWidth returns the width of the diagnostic marker column.
+func (c *codeView) gutterWidth() int {
+	if len(c.diagnostics) == 0 {
+		return 0
+	}
+	return diagnosticWidth
+}
+
 // lineNumberWidth calculates the width needed for line numbers.
 func (c *codeView) lineNumberWidth() int {
 	if !c.showNumbers {
 		return 0
 	}
-	c.highlight()
-	maxLine := c.startLine + len(c.highlighted) - 1
+	maxLine := c.startLine + c.lineCount() - 1
 	width := 1
 	for maxLine >= 10 {
 		maxLine /= 10
@@ -244,12 +393,15 @@ func (c *codeView) lineNumberWidth() int {
 }
 
 func (c *codeView) size(maxWidth, maxHeight int) (int, int) {
+	if c.source != nil {
+		return c.sourceSize(maxWidth, maxHeight)
+	}
 	c.highlight()
 
 	// Calculate width
 	w := c.width
 	if w == 0 {
-		lnWidth := c.lineNumberWidth()
+		lnWidth := c.gutterWidth() + c.lineNumberWidth()
 		maxCodeWidth := 0
 		for _, line := range c.highlighted {
 			lineWidth := 0
@@ -278,26 +430,62 @@ func (c *codeView) size(maxWidth, maxHeight int) (int, int) {
 	return w, h
 }
 
+// sourceSize measures a CodeSource view, which fills the space it is given
+// since its lines can't all be measured.
+func (c *codeView) sourceSize(maxWidth, maxHeight int) (int, int) {
+	w := c.width
+	if w == 0 {
+		w = maxWidth
+	}
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+
+	h := c.height
+	if h == 0 {
+		h = maxHeight
+	}
+	if h == 0 {
+		h = sourceHeight
+	}
+	if maxHeight > 0 && h > maxHeight {
+		h = maxHeight
+	}
+	// Short files only need as many rows as they have lines
+	if n, done := c.source.indexed(h); done {
+		h = min(h, n)
+	}
+	return w, h
+}
+
 func (c *codeView) render(ctx *RenderContext) {
 	width, height := ctx.Size()
 	if width == 0 || height == 0 {
 		return
 	}
 
-	c.highlight()
-
-	lnWidth := c.lineNumberWidth()
-	lnStyle := NewStyle().WithForeground(ColorBrightBlack)
-	separatorStyle := NewStyle().WithForeground(ColorBrightBlack)
-
 	// Get scroll position
 	scrollY := 0
 	if c.scrollY != nil {
 		scrollY = *c.scrollY
 	}
 
+	// Index a source far enough to clamp the scroll position and number
+	// the visible lines
+	total := 0
+	if c.source != nil {
+		total, _ = c.source.indexed(max(scrollY, 0) + height)
+	} else {
+		c.highlight()
+		total = len(c.highlighted)
+	}
+
+	lnWidth := c.lineNumberWidth()
+	lnStyle := NewStyle().WithForeground(ColorBrightBlack)
+	separatorStyle := NewStyle().WithForeground(ColorBrightBlack)
+
 	// Clamp scroll
-	maxScroll := len(c.highlighted) - height
+	maxScroll := total - height
 	if maxScroll < 0 {
 		maxScroll = 0
 	}
@@ -308,18 +496,53 @@ func (c *codeView) render(ctx *RenderContext) {
 		scrollY = 0
 	}
 
+	// Update search matches and follow the current match
+	if c.search != nil && c.source == nil {
+		c.search.setMatches(findMatches(segmentLines(c.highlighted), c.search.Query, c.search.CaseSensitive))
+		if target, ok := c.search.revealLine(scrollY, height); ok {
+			scrollY = min(target, maxScroll)
+		}
+	}
+
 	// Update scroll pointer
 	if c.scrollY != nil && *c.scrollY != scrollY {
 		*c.scrollY = scrollY
 	}
 
+	byLine := diagnosticsByLine(c.diagnostics)
+	texts, lines := c.visibleLines(scrollY, height)
+	if c.search != nil && c.source != nil {
+		matches := findMatches(segmentLines(lines), c.search.Query, c.search.CaseSensitive)
+		for i := range matches {
+			matches[i].Line += scrollY
+		}
+		c.search.setMatches(matches)
+	}
+
 	// Render visible lines
-	for y := 0; y < height && scrollY+y < len(c.highlighted); y++ {
+	for y, line := range lines {
 		lineIdx := scrollY + y
-		line := c.highlighted[lineIdx]
 
 		x := 0
 
+		// Render diagnostic marker and underlines
+		if byLine != nil {
+			marker, markerStyle := diagnosticMarker(byLine[lineIdx])
+			ctx.PrintTruncated(x, y, marker, markerStyle)
+			x += diagnosticWidth
+
+			if diags := byLine[lineIdx]; len(diags) > 0 && y < len(texts) {
+				src := texts[y]
+				line = underlineDiagnostics(line, diags, func(col int) int {
+					return c.expandedIndex(src, col)
+				})
+			}
+		}
+
+		if c.search != nil {
+			line = c.search.highlightSegments(line, lineIdx)
+		}
+
 		// Render line number
 		if c.showNumbers {
 			lineNum := c.startLine + lineIdx
@@ -345,10 +568,19 @@ func (c *codeView) render(ctx *RenderContext) {
 			x += runewidth.StringWidth(text)
 		}
 	}
+
+	// Show the active line's diagnostics
+	if row := c.activeLine - scrollY; row >= 0 && row < height {
+		renderDiagnosticPopover(ctx, c.gutterWidth()+lnWidth, row, byLine[c.activeLine])
+	}
 }
 
-// GetLineCount returns the total number of lines.
+// GetLineCount returns the total number of lines. For a CodeSource view
+// this indexes the whole file.
 func (c *codeView) GetLineCount() int {
+	if c.source != nil {
+		return c.source.IndexAll()
+	}
 	c.highlight()
 	return len(c.highlighted)
 }
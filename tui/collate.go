@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"sync"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// Collator orders text for people: it returns a negative number when a
+// sorts before b, a positive number when after, and zero when they sort
+// the same. Table and FilterableList take one with their Collator method;
+// without one, text is compared by its bytes, ignoring case.
+type Collator func(a, b string) int
+
+// LocaleCollator returns a Collator that orders text by the rules of a
+// language, given as a BCP 47 tag such as "de" or "sv-SE". Accented letters
+// sort where the language puts them, so "Ärger" comes before "Zebra" in
+// German but after it in Swedish, and runs of digits compare by value, so
+// "file2" comes before "file10". An empty or unknown locale uses the
+// default Unicode order. The Collator is safe for concurrent use.
+//
+// Example:
+//
+//	tui.Table(columns, &app.selected).Rows(app.rows).Collator(tui.LocaleCollator("de"))
+func LocaleCollator(locale string) Collator {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		tag = language.Und
+	}
+	c := collate.New(tag, collate.Numeric)
+	var mu sync.Mutex // the collator reuses its buffers
+	return func(a, b string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return c.CompareString(a, b)
+	}
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestLocaleCollator(t *testing.T) {
+	sorted := func(c Collator, words ...string) []string {
+		slices.SortFunc(words, c)
+		return words
+	}
+	// Byte order puts Ärger after Zebra
+	assert.Equal(t, []string{"Apfel", "Zebra", "Ärger"}, sorted(func(a, b string) int { return compareTableCells(a, b, nil) }, "Zebra", "Ärger", "Apfel"))
+
+	de := LocaleCollator("de")
+	assert.Equal(t, []string{"Apfel", "Ärger", "Zebra"}, sorted(de, "Zebra", "Ärger", "Apfel"))
+	assert.Equal(t, []string{"Apfel", "Zebra", "Ärger"}, sorted(LocaleCollator("sv"), "Zebra", "Ärger", "Apfel"))
+
+	// Digits compare by value, and case only breaks ties
+	assert.Equal(t, []string{"file2", "File2", "file10"}, sorted(de, "file10", "File2", "file2"))
+
+	// Unknown locales use the default order
+	assert.Equal(t, []string{"Ärger", "Zebra"}, sorted(LocaleCollator("not a locale"), "Zebra", "Ärger"))
+}
+
+func TestTable_Collator(t *testing.T) {
+	defer resetTableLayouts()
+
+	selected := 0
+	view := Table([]TableColumn{{Title: "Name"}}, &selected).
+		Rows([][]string{{"Zebra"}, {"Ärger"}, {"file10"}, {"file2"}, {"7"}}).
+		Layout(&TableLayout{SortColumn: "Name"}).
+		Collator(LocaleCollator("de")).
+		ShowHeader(false)
+
+	screen := SprintScreen(view, PrintConfig{Width: 10, Height: 5})
+	var rows []string
+	for i := range 5 {
+		rows = append(rows, screen.Row(i))
+	}
+	assert.Equal(t, []string{"7", "Ärger", "file2", "file10", "Zebra"}, rows)
+}
+
+func TestFilterableList_Collator(t *testing.T) {
+	selected := 0
+	filter := ""
+	var chosen string
+	items := []string{"Zebra", "Ärger", "file10", "file2"}
+	list := func() *listView {
+		return FilterableListStrings(items, &selected).Filter(&filter).Height(6).
+			Collator(LocaleCollator("de")).
+			OnSelect(func(item ListItem, index int) { chosen = item.Label })
+	}
+
+	screen := SprintScreen(list(), PrintConfig{Width: 20, Height: 6})
+	assert.Equal(t, "Ärger", screen.Row(2))
+	assert.Equal(t, "file2", screen.Row(3))
+	assert.Equal(t, "file10", screen.Row(4))
+	assert.Equal(t, "Zebra", screen.Row(5))
+
+	// The first row shown is the one selected
+	view := list()
+	view.size(20, 6)
+	view.HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, "Ärger", chosen)
+
+	// Equally good matches keep the collator's order
+	filter = "file"
+	screen = SprintScreen(list(), PrintConfig{Width: 20, Height: 6})
+	assert.Equal(t, "file2", screen.Row(2))
+	assert.Equal(t, "file10", screen.Row(3))
+}
@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"image"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/fuzzy"
+	"github.com/mattn/go-runewidth"
+)
+
+// Action is a command offered in the command palette.
+type Action struct {
+	Name       string       // Shown in the palette and matched against the query
+	Keybinding string       // Optional shortcut hint shown next to the name, like "Ctrl+S"
+	Handler    func() []Cmd // Called when the action is run; returned commands are executed
+}
+
+// maxPaletteRows is the number of matching actions shown at once.
+const maxPaletteRows = 10
+
+// commandPalette is the state of the Runtime's command palette.
+type commandPalette struct {
+	hotkey  KeyEvent
+	actions []Action
+
+	open      bool
+	input     *TextInput
+	matches   []Action
+	positions [][]int // matched rune positions in each match's name
+	cursor    int
+}
+
+func newCommandPalette(hotkey KeyEvent, actions []Action) *commandPalette {
+	return &commandPalette{hotkey: hotkey, actions: actions}
+}
+
+// show opens the palette with an empty query.
+func (p *commandPalette) show() {
+	p.open = true
+	p.input = NewTextInput()
+	p.input.SetFocused(true)
+	p.filter()
+}
+
+// filter updates the matching actions for the current query, ranked by
+// the fuzzy package, best match first; an empty query lists every action in
+// its original order.
+func (p *commandPalette) filter() {
+	names := make([]string, len(p.actions))
+	for i, a := range p.actions {
+		names[i] = a.Name
+	}
+	p.matches, p.positions = p.matches[:0], p.positions[:0]
+	for _, r := range fuzzy.Rank(p.input.Value(), names) {
+		p.matches = append(p.matches, p.actions[r.Index])
+		p.positions = append(p.positions, r.Positions)
+	}
+	p.cursor = 0
+}
+
+// handleKey processes a key while the palette is open and returns the
+// commands of the action run, if any.
+func (p *commandPalette) handleKey(event KeyEvent) []Cmd {
+	switch {
+	case event.Key == KeyEscape || matchKey(event, p.hotkey):
+		p.open = false
+	case event.Key == KeyArrowUp || event.Key == KeyCtrlP:
+		p.cursor = max(0, p.cursor-1)
+	case event.Key == KeyArrowDown || event.Key == KeyCtrlN:
+		p.cursor = max(0, min(len(p.matches)-1, p.cursor+1))
+	case event.Key == KeyEnter:
+		if p.cursor >= len(p.matches) {
+			return nil
+		}
+		p.open = false
+		if handler := p.matches[p.cursor].Handler; handler != nil {
+			return handler()
+		}
+	default:
+		before := p.input.Value()
+		p.input.HandleKey(event)
+		if p.input.Value() != before {
+			p.filter()
+		}
+	}
+	return nil
+}
+
+// render draws the palette centered near the top of ctx.
+func (p *commandPalette) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	w := min(60, width-4)
+	rows := min(len(p.matches), maxPaletteRows)
+	h := min(rows+3, height-2) // borders and the query line
+	if w < 10 || h < 3 {
+		return
+	}
+	x := (width - w) / 2
+	y := min(2, height-h)
+
+	box := ctx.SubContext(image.Rect(x, y, x+w, y+h))
+	box.Fill(' ', NewStyle())
+	border := Bordered(Empty()).Border(&RoundedBorder).Title("Commands")
+	border.size(w, h)
+	border.render(box)
+
+	inner := box.SubContext(image.Rect(1, 1, w-1, h-1))
+	innerW, innerH := inner.Size()
+	inner.PrintTruncated(0, 0, "> ", NewStyle().WithForeground(ColorCyan))
+	inputCtx := inner.SubContext(image.Rect(2, 0, innerW, 1))
+	p.input.SetBounds(inputCtx.AbsoluteBounds())
+	drawInput(inputCtx, p.input)
+
+	// Scroll the list so the cursor stays visible
+	visible := innerH - 1
+	start := max(0, p.cursor-visible+1)
+	hintStyle := NewStyle().WithForeground(ColorBrightBlack)
+	matchStyle := NewStyle().WithForeground(ColorYellow).WithBold()
+	for i := 0; i < visible && start+i < len(p.matches); i++ {
+		a := p.matches[start+i]
+		rowStyle := NewStyle()
+		if start+i == p.cursor {
+			rowStyle = rowStyle.WithReverse()
+			inner.FillStyled(0, i+1, innerW, 1, ' ', rowStyle)
+		}
+		hintW := runewidth.StringWidth(a.Keybinding)
+		nameW := innerW - 1
+		if a.Keybinding != "" && hintW+2 < innerW {
+			nameW = innerW - hintW - 3
+			inner.PrintTruncated(innerW-hintW-1, i+1, a.Keybinding, hintStyle.Merge(rowStyle))
+		}
+		name := runewidth.Truncate(a.Name, nameW, "…")
+		inner.PrintTruncated(1, i+1, name, rowStyle)
+		limit := nameW
+		if name != a.Name {
+			limit = nameW - 1
+		}
+		printMatched(inner, 1, i+1, a.Name, p.positions[start+i], limit, rowStyle.Merge(matchStyle))
+	}
+}
+
+// CommandPaletteEvent is produced by the OpenCommandPalette command and
+// processed by the Runtime to open its command palette.
+type CommandPaletteEvent struct {
+	Time time.Time
+}
+
+// Timestamp implements Event.
+func (e CommandPaletteEvent) Timestamp() time.Time { return e.Time }
+
+// OpenCommandPalette returns a command that opens the command palette set up
+// with WithCommandPalette, for example from a menu or button.
+func OpenCommandPalette() Cmd {
+	return func() Event {
+		return CommandPaletteEvent{Time: time.Now()}
+	}
+}
+
+// SetCommandPalette sets up a command palette listing actions that opens
+// when hotkey is pressed. See WithCommandPalette. Must be called before
+// Run().
+func (r *Runtime) SetCommandPalette(hotkey KeyEvent, actions []Action) {
+	r.palette = newCommandPalette(hotkey, actions)
+}
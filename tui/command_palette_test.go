@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+// screenRow returns row y of the test terminal's back buffer.
+func screenRow(terminal *Terminal, y int) string {
+	w, _ := terminal.Size()
+	var sb strings.Builder
+	for x := 0; x < w; x++ {
+		if ch := terminal.GetCell(x, y).Char; ch != 0 {
+			sb.WriteRune(ch)
+		}
+	}
+	return strings.TrimRight(sb.String(), " ")
+}
+
+func TestCommandPalette(t *testing.T) {
+	terminal := NewTestTerminal(40, 10, &bytes.Buffer{})
+	var appKeys int
+	app := &simpleApp{
+		handleFunc: func(e Event) []Cmd {
+			if _, ok := e.(KeyEvent); ok {
+				appKeys++
+			}
+			return nil
+		},
+		renderFunc: func() View { return Text("main") },
+	}
+	ran := ""
+	action := func(name string) Action {
+		return Action{Name: name, Handler: func() []Cmd {
+			ran = name
+			return []Cmd{Quit()}
+		}}
+	}
+	hotkey := KeyEvent{Key: KeyCtrlP, Ctrl: true}
+	runtime := NewRuntime(terminal, app, 30)
+	runtime.SetCommandPalette(hotkey, []Action{
+		action("Open file"),
+		{Name: "Toggle sidebar", Keybinding: "Ctrl+B"},
+		action("Quit"),
+	})
+
+	runtime.processEvent(KeyEvent{Rune: 'x'})
+	assert.Equal(t, 1, appKeys)
+
+	runtime.processEvent(hotkey)
+	runtime.render()
+	assert.Equal(t, "main", screenRow(terminal, 0))
+	assert.Contains(t, screenRow(terminal, 2), "Commands")
+	assert.Contains(t, screenRow(terminal, 4), "Open file")
+	assert.Contains(t, screenRow(terminal, 5), "Toggle sidebar")
+	assert.Contains(t, screenRow(terminal, 5), "Ctrl+B")
+
+	// Typing filters the actions and the app doesn't see the keys
+	runtime.processEvent(KeyEvent{Rune: 'q'})
+	runtime.processEvent(KeyEvent{Rune: 't'})
+	runtime.render()
+	assert.Contains(t, screenRow(terminal, 3), "> qt")
+	assert.Contains(t, screenRow(terminal, 4), "Quit")
+	assert.Equal(t, 1, appKeys)
+
+	runtime.processEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, "Quit", ran)
+	assert.Equal(t, 1, len(runtime.cmds))
+	assert.False(t, runtime.palette.open)
+
+	// The OpenCommandPalette command reopens it with an empty query
+	runtime.processEvent(OpenCommandPalette()())
+	assert.True(t, runtime.palette.open)
+	assert.Equal(t, 3, len(runtime.palette.matches))
+	runtime.processEvent(KeyEvent{Key: KeyEscape})
+	assert.False(t, runtime.palette.open)
+}
+
+func TestCommandPalette_Navigation(t *testing.T) {
+	p := newCommandPalette(KeyEvent{Key: KeyCtrlP, Ctrl: true}, []Action{
+		{Name: "Open file"}, {Name: "Save"}, {Name: "Save all"},
+	})
+	p.show()
+	p.handleKey(KeyEvent{Key: KeyArrowUp})
+	assert.Equal(t, 0, p.cursor)
+	p.handleKey(KeyEvent{Key: KeyArrowDown})
+	p.handleKey(KeyEvent{Key: KeyCtrlN, Ctrl: true})
+	p.handleKey(KeyEvent{Key: KeyArrowDown})
+	assert.Equal(t, 2, p.cursor)
+
+	// Shorter matches rank first
+	p.handleKey(KeyEvent{Rune: 's'})
+	p.handleKey(KeyEvent{Rune: 'a'})
+	assert.Equal(t, 0, p.cursor)
+	assert.Equal(t, "Save", p.matches[0].Name)
+	assert.Equal(t, "Save all", p.matches[1].Name)
+
+	// Actions without a handler just close the palette
+	assert.Nil(t, p.handleKey(KeyEvent{Key: KeyEnter}))
+	assert.False(t, p.open)
+}
+
+func TestCommandPalette_Ranking(t *testing.T) {
+	p := newCommandPalette(KeyEvent{Key: KeyCtrlP, Ctrl: true}, []Action{
+		{Name: "Reset view"},
+		{Name: "Toggle sidebar"},
+		{Name: "Open settings"},
+	})
+	p.show()
+	for _, r := range "set" {
+		p.handleKey(KeyEvent{Rune: r})
+	}
+	// Word starts rank above matches inside words
+	assert.Equal(t, 2, len(p.matches))
+	assert.Equal(t, "Open settings", p.matches[0].Name)
+	assert.Equal(t, "Reset view", p.matches[1].Name)
+	assert.Equal(t, []int{5, 6, 7}, p.positions[0])
+}
@@ -15,6 +15,28 @@ type RenderContext struct {
 	frameCount uint64
 	bounds     image.Rectangle
 	focusMgr   *FocusManager
+	zoom       *zoomState
+	cursor     *cursorRequest
+	overlays   *overlayLayer
+	env        *envNode // values set with Env, nil if none
+	background *RGB     // terminal background, nil if unknown
+}
+
+// overlayLayer holds drawing deferred until the whole view tree has been
+// rendered, for popups such as an open Select dropdown that must appear on
+// top of views drawn after them and must not be clipped by their parents.
+// It is shared by every context derived from the root context.
+type overlayLayer struct {
+	frame   RenderFrame // the root frame
+	draws   []func()
+	anchors map[string]image.Rectangle // screen bounds of Anchor views, by ID
+}
+
+// cursorRequest records where a view asked for the terminal cursor during a
+// render. It is shared by every context derived from the root context.
+type cursorRequest struct {
+	x, y    int
+	visible bool
 }
 
 // NewRenderContext creates a new render context.
@@ -25,6 +47,7 @@ func NewRenderContext(frame RenderFrame, frameCount uint64) *RenderContext {
 		frame:      frame,
 		frameCount: frameCount,
 		bounds:     image.Rect(0, 0, w, h),
+		overlays:   &overlayLayer{frame: frame},
 	}
 }
 
@@ -35,9 +58,50 @@ func (c *RenderContext) WithFocusManager(fm *FocusManager) *RenderContext {
 		frameCount: c.frameCount,
 		bounds:     c.bounds,
 		focusMgr:   fm,
+		zoom:       c.zoom,
+		cursor:     c.cursor,
+		overlays:   c.overlays,
+		env:        c.env,
+		background: c.background,
 	}
 }
 
+// withZoom returns a new context that carries the zoom target.
+func (c *RenderContext) withZoom(zoom *zoomState) *RenderContext {
+	sub := *c
+	sub.zoom = zoom
+	return &sub
+}
+
+// withCursor returns a new context that collects a cursor position set with
+// SetCursor, for runtimes that can place the terminal cursor.
+func (c *RenderContext) withCursor(cursor *cursorRequest) *RenderContext {
+	sub := *c
+	sub.cursor = cursor
+	return &sub
+}
+
+// SetCursor asks for the terminal cursor to be shown at (x, y), relative to
+// this context, once the frame is drawn. The last call in a frame wins, and
+// when no view sets the cursor it stays hidden. Positions outside the
+// context are ignored.
+//
+// It returns false if the renderer can't place the cursor (such as when
+// printing or in an InlineApp), so views can draw their own cursor instead.
+func (c *RenderContext) SetCursor(x, y int) bool {
+	if c.cursor == nil {
+		return false
+	}
+	if !image.Pt(x, y).In(c.bounds) {
+		return true
+	}
+	abs := c.AbsoluteBounds().Min
+	c.cursor.x = abs.X + x
+	c.cursor.y = abs.Y + y
+	c.cursor.visible = true
+	return true
+}
+
 // FocusManager returns the focus manager for this context, or nil if none.
 func (c *RenderContext) FocusManager() *FocusManager {
 	return c.focusMgr
@@ -79,7 +143,107 @@ func (c *RenderContext) SubContext(bounds image.Rectangle) *RenderContext {
 		frameCount: c.frameCount,
 		bounds:     image.Rect(0, 0, clippedBounds.Dx(), clippedBounds.Dy()),
 		focusMgr:   c.focusMgr,
+		zoom:       c.zoom,
+		cursor:     c.cursor,
+		overlays:   c.overlays,
+		env:        c.env,
+		background: c.background,
+	}
+}
+
+// screenBounds returns the bounds of the whole frame relative to this
+// context, for views that place an overlay where there is room.
+func (c *RenderContext) screenBounds() image.Rectangle {
+	if c.overlays == nil {
+		return c.bounds
+	}
+	w, h := c.overlays.frame.Size()
+	return image.Rect(0, 0, w, h).Sub(c.AbsoluteBounds().Min)
+}
+
+// overlay draws with draw once the rest of the view tree has been rendered,
+// over whatever is there, in bounds relative to this context. Unlike
+// SubContext, the bounds are only clipped to the frame, not to this context.
+// Regions that draw registers for clicks take priority over those beneath.
+func (c *RenderContext) overlay(bounds image.Rectangle, draw func(ctx *RenderContext)) {
+	if c.overlays == nil {
+		draw(c.SubContext(bounds))
+		return
+	}
+	abs := bounds.Add(c.AbsoluteBounds().Min)
+	c.overlayPlaced(func(image.Rectangle) image.Rectangle { return abs }, draw)
+}
+
+// overlayPlaced is like overlay, but the bounds are given by place once the
+// rest of the view tree has been rendered, so they can depend on where other
+// views were drawn. place is passed the bounds of the frame and returns
+// bounds in the same coordinates; empty bounds draw nothing.
+func (c *RenderContext) overlayPlaced(place func(screen image.Rectangle) image.Rectangle, draw func(ctx *RenderContext)) {
+	if c.overlays == nil {
+		if bounds := place(c.bounds); !bounds.Empty() {
+			draw(c.SubContext(bounds))
+		}
+		return
+	}
+	layer := c.overlays
+	root := &RenderContext{
+		frameCount: c.frameCount,
+		focusMgr:   c.focusMgr,
+		cursor:     c.cursor,
+		overlays:   layer,
+		env:        c.env,
+		background: c.background,
+	}
+	layer.draws = append(layer.draws, func() {
+		w, h := layer.frame.Size()
+		root.frame = layer.frame
+		root.bounds = image.Rect(0, 0, w, h)
+		if bounds := place(root.bounds); !bounds.Empty() {
+			draw(root.SubContext(bounds))
+		}
+	})
+}
+
+// setAnchor records the screen bounds of the Anchor view with the given ID
+// for this render.
+func (c *RenderContext) setAnchor(id string) {
+	if c.overlays == nil {
+		return
+	}
+	if c.overlays.anchors == nil {
+		c.overlays.anchors = make(map[string]image.Rectangle)
+	}
+	c.overlays.anchors[id] = c.AbsoluteBounds()
+}
+
+// anchorBounds returns the screen bounds of the Anchor view or focusable
+// element with the given ID, as rendered this frame.
+func (c *RenderContext) anchorBounds(id string) (image.Rectangle, bool) {
+	if c.overlays != nil {
+		if bounds, ok := c.overlays.anchors[id]; ok {
+			return bounds, true
+		}
+	}
+	if c.focusMgr != nil {
+		return c.focusMgr.boundsOf(id)
+	}
+	return image.Rectangle{}, false
+}
+
+// drawOverlays draws the overlays queued during rendering, in order, so the
+// last one ends up on top. Called on the root context after the view tree
+// has been rendered.
+func (c *RenderContext) drawOverlays() {
+	if c.overlays == nil {
+		return
+	}
+	// Overlays may queue more overlays
+	for i := 0; i < len(c.overlays.draws); i++ {
+		n := interactiveRegistry.regionCount()
+		c.overlays.draws[i]()
+		interactiveRegistry.raise(n)
 	}
+	c.overlays.draws = nil
 }
 
 // Drawing methods - delegate to the underlying RenderFrame
@@ -136,5 +300,10 @@ func (c *RenderContext) WithFrame(frame RenderFrame) *RenderContext {
 		frameCount: c.frameCount,
 		bounds:     image.Rect(0, 0, w, h),
 		focusMgr:   c.focusMgr,
+		zoom:       c.zoom,
+		cursor:     c.cursor,
+		overlays:   c.overlays,
+		env:        c.env,
+		background: c.background,
 	}
 }
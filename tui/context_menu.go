@@ -0,0 +1,361 @@
+package tui
+
+import (
+	"image"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// MenuItem is an action in a ContextMenu. An item with no Label is drawn as
+// a separator line.
+type MenuItem struct {
+	Label    string // Shown in the menu
+	Hint     string // Optional text shown at the right, like a shortcut "Ctrl+C"
+	Disabled bool   // Shown dimmed and can't be chosen
+	OnSelect func() // Called when the item is chosen
+}
+
+// selectable reports whether the item can be moved to and chosen.
+func (m MenuItem) selectable() bool {
+	return m.Label != "" && !m.Disabled
+}
+
+// contextMenuRegistry keeps whether each context menu is open, keyed by ID,
+// since views are rebuilt every frame.
+var contextMenuRegistry = &contextMenuRegistryImpl{
+	states: make(map[string]*contextMenuState),
+	active: make(map[string]bool),
+}
+
+type contextMenuRegistryImpl struct {
+	mu     sync.Mutex
+	states map[string]*contextMenuState
+	active map[string]bool // tracks which IDs were accessed this frame
+}
+
+type contextMenuState struct {
+	open   bool
+	anchor image.Point // screen position of the menu's top-left corner
+	cursor int         // index into the items
+	menu   int         // index of the open menu, for a MenuBar
+}
+
+// Clear marks all entries as inactive. Called at the start of each frame.
+func (r *contextMenuRegistryImpl) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.active = make(map[string]bool)
+}
+
+// Prune removes entries that weren't accessed since the last Clear().
+func (r *contextMenuRegistryImpl) Prune() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id := range r.states {
+		if !r.active[id] {
+			delete(r.states, id)
+		}
+	}
+}
+
+func (r *contextMenuRegistryImpl) Get(id string) *contextMenuState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.active[id] = true
+	if state, exists := r.states[id]; exists {
+		return state
+	}
+	state := &contextMenuState{}
+	r.states[id] = state
+	return state
+}
+
+// contextMenuView opens a menu of actions over its inner view.
+type contextMenuView struct {
+	inner         View
+	items         []MenuItem
+	id            string
+	key           KeyEvent
+	style         Style
+	selectedStyle Style
+	disabledStyle Style
+}
+
+// ContextMenu attaches a menu of actions to the inner view. A right click
+// on the view opens the menu at the mouse pointer, and Shift+F10 opens it
+// at the view's focused element (or its top-left corner) when the focused
+// element is inside the view or nothing is focused.
+//
+// While the menu is open it takes the keyboard: the arrow keys move through
+// the items, typing a letter jumps to the next item starting with it, Enter
+// or Space chooses an item, and Escape closes the menu. Items can also be
+// clicked, and a click anywhere else closes the menu. Right clicks require
+// mouse tracking (WithMouseTracking).
+//
+// The open state is kept by ID, which defaults to one made from the item
+// labels; set ID when several views have menus with the same labels.
+//
+// Example:
+//
+//	tui.ContextMenu(tui.Text(file.Name),
+//	    tui.MenuItem{Label: "Open", Hint: "Enter", OnSelect: func() { app.open(file) }},
+//	    tui.MenuItem{Label: "Rename", OnSelect: func() { app.rename(file) }},
+//	    tui.MenuItem{},
+//	    tui.MenuItem{Label: "Delete", OnSelect: func() { app.delete(file) }},
+//	).ID("menu-" + file.Name)
+func ContextMenu(inner View, items ...MenuItem) *contextMenuView {
+	labels := make([]string, len(items))
+	for i, item := range items {
+		labels[i] = item.Label
+	}
+	return &contextMenuView{
+		inner:         inner,
+		items:         items,
+		id:            "context_menu:" + strings.Join(labels, "|"),
+		key:           KeyEvent{Key: KeyF10, Shift: true},
+		style:         NewStyle(),
+		selectedStyle: NewStyle().WithReverse(),
+		disabledStyle: NewStyle().WithDim(),
+	}
+}
+
+// ID sets the ID the menu's open state is kept under.
+func (m *contextMenuView) ID(id string) *contextMenuView {
+	m.id = id
+	return m
+}
+
+// Key sets the key that opens the menu (default Shift+F10). A zero KeyEvent
+// disables opening the menu from the keyboard.
+func (m *contextMenuView) Key(key KeyEvent) *contextMenuView {
+	m.key = key
+	return m
+}
+
+// Style sets the style of the menu.
+func (m *contextMenuView) Style(s Style) *contextMenuView {
+	m.style = s
+	return m
+}
+
+// SelectedStyle sets the style of the item under the cursor.
+func (m *contextMenuView) SelectedStyle(s Style) *contextMenuView {
+	m.selectedStyle = s
+	return m
+}
+
+// DisabledStyle sets the style of disabled items.
+func (m *contextMenuView) DisabledStyle(s Style) *contextMenuView {
+	m.disabledStyle = s
+	return m
+}
+
+func (m *contextMenuView) size(maxWidth, maxHeight int) (int, int) {
+	return m.inner.size(maxWidth, maxHeight)
+}
+
+// flex implements the Flexible interface by delegating to the inner view.
+func (m *contextMenuView) flex() int {
+	if flex, ok := m.inner.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (m *contextMenuView) render(ctx *RenderContext) {
+	state := contextMenuRegistry.Get(m.id)
+	bounds := ctx.AbsoluteBounds()
+	fm := ctx.FocusManager()
+
+	// Register before drawing so that menus of views inside take priority
+	interactiveRegistry.RegisterRightClick(bounds, func(x, y int) {
+		m.open(state, image.Pt(x, y))
+	})
+	if m.key.Key != KeyUnknown || m.key.Rune != 0 {
+		interactiveRegistry.RegisterKeys(func(e KeyEvent) bool {
+			if !matchKey(e, m.key) {
+				return false
+			}
+			anchor := bounds.Min
+			if fm != nil {
+				if focused := fm.GetFocused(); focused != nil {
+					if !focused.FocusBounds().Min.In(bounds) {
+						return false
+					}
+					anchor = focused.FocusBounds().Min
+				}
+			}
+			m.open(state, anchor)
+			return true
+		})
+	}
+
+	m.inner.render(ctx)
+
+	if state.open {
+		interactiveRegistry.RegisterKeyCapture(func(e KeyEvent) bool {
+			m.handleKey(state, e)
+			return true
+		})
+		m.renderMenu(ctx, state)
+	}
+}
+
+// open shows the menu at anchor with the cursor on the first item that can
+// be chosen.
+func (m *contextMenuView) open(state *contextMenuState, anchor image.Point) {
+	state.open = true
+	state.anchor = anchor
+	state.cursor = -1
+	m.moveCursor(state, 1)
+}
+
+// handleKey processes a key while the menu is open.
+func (m *contextMenuView) handleKey(state *contextMenuState, e KeyEvent) {
+	switch {
+	case e.Key == KeyEscape || e.Key == KeyTab || matchKey(e, m.key):
+		state.open = false
+	case e.Key == KeyArrowUp || e.Key == KeyCtrlP:
+		m.moveCursor(state, -1)
+	case e.Key == KeyArrowDown || e.Key == KeyCtrlN:
+		m.moveCursor(state, 1)
+	case e.Key == KeyHome:
+		state.cursor = -1
+		m.moveCursor(state, 1)
+	case e.Key == KeyEnd:
+		state.cursor = len(m.items)
+		m.moveCursor(state, -1)
+	case e.Key == KeyEnter || e.Rune == ' ':
+		m.choose(state, state.cursor)
+	case e.Rune != 0 && !e.Ctrl && !e.Alt:
+		m.jumpTo(state, e.Rune)
+	}
+}
+
+// moveCursor moves the cursor to the next item that can be chosen in the
+// direction of delta, wrapping around at the ends.
+func (m *contextMenuView) moveCursor(state *contextMenuState, delta int) {
+	n := len(m.items)
+	for step := 1; step <= n; step++ {
+		i := ((state.cursor+delta*step)%n + n) % n
+		if m.items[i].selectable() {
+			state.cursor = i
+			return
+		}
+	}
+}
+
+// jumpTo moves the cursor to the next item that can be chosen whose label
+// starts with r, ignoring case.
+func (m *contextMenuView) jumpTo(state *contextMenuState, r rune) {
+	n := len(m.items)
+	for step := 1; step <= n; step++ {
+		i := (state.cursor + step + n) % n
+		item := m.items[i]
+		if item.selectable() && unicode.ToLower([]rune(item.Label)[0]) == unicode.ToLower(r) {
+			state.cursor = i
+			return
+		}
+	}
+}
+
+// choose closes the menu and runs the item at index, if it can be chosen.
+func (m *contextMenuView) choose(state *contextMenuState, index int) {
+	if index < 0 || index >= len(m.items) || !m.items[index].selectable() {
+		return
+	}
+	state.open = false
+	if fn := m.items[index].OnSelect; fn != nil {
+		fn()
+	}
+}
+
+// renderMenu queues the open menu as an overlay at its anchor, moved to
+// stay on screen. Items that don't fit on the screen are left out.
+func (m *contextMenuView) renderMenu(ctx *RenderContext, state *contextMenuState) {
+	labelW, hintW := 0, 0
+	for _, item := range m.items {
+		labelW = max(labelW, runewidth.StringWidth(item.Label))
+		hintW = max(hintW, runewidth.StringWidth(item.Hint))
+	}
+	innerW := labelW + 2 // a space either side
+	if hintW > 0 {
+		innerW += hintW + 2
+	}
+	w, h := innerW+2, len(m.items)+2
+
+	screen := ctx.screenBounds()
+	w, h = min(w, screen.Dx()), min(h, screen.Dy())
+	if w < 3 || h < 3 {
+		return
+	}
+	at := state.anchor.Sub(ctx.AbsoluteBounds().Min)
+	x, y := at.X, at.Y
+	if x+w > screen.Max.X {
+		x = max(screen.Min.X, screen.Max.X-w)
+	}
+	if y+h > screen.Max.Y {
+		// Open upward from the anchor if there's room, else as low as fits
+		if at.Y+1-h >= screen.Min.Y {
+			y = at.Y + 1 - h
+		} else {
+			y = max(screen.Min.Y, screen.Max.Y-h)
+		}
+	}
+	screenAbs := screen.Add(ctx.AbsoluteBounds().Min)
+
+	ctx.overlay(image.Rect(x, y, x+w, y+h), func(box *RenderContext) {
+		box.Fill(' ', m.style)
+		border := Bordered(Empty()).Border(&RoundedBorder)
+		border.size(w, h)
+		border.render(box)
+
+		for i := 0; i < h-2 && i < len(m.items); i++ {
+			item := m.items[i]
+			if item.Label == "" {
+				box.SetCell(0, i+1, '├', m.style)
+				for cx := 1; cx < w-1; cx++ {
+					box.SetCell(cx, i+1, '─', m.style)
+				}
+				box.SetCell(w-1, i+1, '┤', m.style)
+				continue
+			}
+			row := box.SubContext(image.Rect(1, i+1, w-1, i+2))
+			rowW, _ := row.Size()
+			style := m.style
+			if item.Disabled {
+				style = style.Merge(m.disabledStyle)
+			} else if i == state.cursor {
+				style = style.Merge(m.selectedStyle)
+			}
+			row.FillStyled(0, 0, rowW, 1, ' ', style)
+			row.PrintTruncated(1, 0, runewidth.Truncate(item.Label, max(0, rowW-2), "…"), style)
+			if item.Hint != "" && labelW+4 <= rowW {
+				hint := runewidth.Truncate(item.Hint, rowW-labelW-4, "…")
+				row.PrintTruncated(rowW-1-runewidth.StringWidth(hint), 0, hint, style.WithDim())
+			}
+
+			if item.selectable() {
+				index := i
+				interactiveRegistry.RegisterButton(row.AbsoluteBounds(), func() {
+					m.choose(state, index)
+				})
+				interactiveRegistry.RegisterHover(row.AbsoluteBounds(), func(hovered bool) {
+					if hovered {
+						state.cursor = index
+					}
+				})
+			}
+		}
+
+		// Clicks elsewhere in the menu do nothing, and clicks outside it
+		// close it without reaching the views beneath
+		interactiveRegistry.RegisterButton(box.AbsoluteBounds(), func() {})
+		interactiveRegistry.RegisterButton(screenAbs, func() { state.open = false })
+		interactiveRegistry.RegisterRightClick(screenAbs, func(x, y int) { state.open = false })
+	})
+}
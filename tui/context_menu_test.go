@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func newContextMenuApp(chosen *[]string) *simpleApp {
+	choose := func(name string) func() {
+		return func() { *chosen = append(*chosen, name) }
+	}
+	return &simpleApp{
+		renderFunc: func() View {
+			return ContextMenu(Text("file.txt"),
+				MenuItem{Label: "Open", Hint: "Enter", OnSelect: choose("open")},
+				MenuItem{Label: "Copy", Disabled: true, OnSelect: choose("copy")},
+				MenuItem{},
+				MenuItem{Label: "Delete", OnSelect: choose("delete")},
+			)
+		},
+		handleFunc: func(Event) []Cmd { return nil },
+	}
+}
+
+// screenText returns all rows of the test terminal's back buffer.
+func screenText(terminal *Terminal) string {
+	_, h := terminal.Size()
+	rows := make([]string, h)
+	for y := range rows {
+		rows[y] = screenRow(terminal, y)
+	}
+	return strings.Join(rows, "\n")
+}
+
+func TestContextMenu_RightClickOpensAtPointer(t *testing.T) {
+	terminal := NewTestTerminal(30, 10, &bytes.Buffer{})
+	var chosen []string
+	runtime := NewRuntime(terminal, newContextMenuApp(&chosen), 30)
+	runtime.render()
+	assert.NotContains(t, screenText(terminal), "Delete")
+
+	runtime.processEvent(MouseEvent{Type: MouseClick, Button: MouseButtonRight, X: 2, Y: 0})
+	runtime.render()
+	assert.Equal(t, "fi╭───────────────╮", screenRow(terminal, 0))
+	assert.Equal(t, "  │ Open    Enter │", screenRow(terminal, 1))
+	assert.Equal(t, "  │ Copy          │", screenRow(terminal, 2))
+	assert.Equal(t, "  ├───────────────┤", screenRow(terminal, 3))
+	assert.Equal(t, "  │ Delete        │", screenRow(terminal, 4))
+
+	// Clicking an item chooses it and closes the menu
+	runtime.processEvent(MouseEvent{Type: MouseClick, Button: MouseButtonLeft, X: 5, Y: 4})
+	runtime.render()
+	assert.Equal(t, []string{"delete"}, chosen)
+	assert.NotContains(t, screenText(terminal), "Delete")
+}
+
+func TestContextMenu_KeyboardNavigation(t *testing.T) {
+	terminal := NewTestTerminal(30, 10, &bytes.Buffer{})
+	var chosen []string
+	runtime := NewRuntime(terminal, newContextMenuApp(&chosen), 30)
+	runtime.render()
+
+	runtime.processEvent(KeyEvent{Key: KeyF10, Shift: true})
+	runtime.render()
+	assert.Contains(t, screenRow(terminal, 1), "Open")
+
+	// Down skips the disabled item and the separator
+	runtime.processEvent(KeyEvent{Key: KeyArrowDown})
+	runtime.processEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, []string{"delete"}, chosen)
+
+	runtime.render()
+	runtime.processEvent(KeyEvent{Key: KeyF10, Shift: true})
+	runtime.render()
+	runtime.processEvent(KeyEvent{Rune: 'd'})
+	runtime.processEvent(KeyEvent{Key: KeyArrowDown}) // wraps around to Open
+	runtime.processEvent(KeyEvent{Rune: ' '})
+	assert.Equal(t, []string{"delete", "open"}, chosen)
+
+	runtime.render()
+	runtime.processEvent(KeyEvent{Key: KeyF10, Shift: true})
+	runtime.render()
+	runtime.processEvent(KeyEvent{Key: KeyEscape})
+	runtime.render()
+	assert.NotContains(t, screenText(terminal), "Delete")
+	assert.Equal(t, 2, len(chosen))
+}
+
+func TestContextMenu_StaysOnScreen(t *testing.T) {
+	terminal := NewTestTerminal(20, 6, &bytes.Buffer{})
+	var chosen []string
+	runtime := NewRuntime(terminal, newContextMenuApp(&chosen), 30)
+	runtime.render()
+
+	// Near the bottom right corner, the menu opens up and to the left
+	runtime.processEvent(MouseEvent{Type: MouseClick, Button: MouseButtonRight, X: 18, Y: 5})
+	runtime.render()
+	assert.Equal(t, "fil╭───────────────╮", screenRow(terminal, 0))
+	assert.Equal(t, "   ╰───────────────╯", screenRow(terminal, 5))
+
+	// A click outside closes it without choosing anything
+	runtime.processEvent(MouseEvent{Type: MouseClick, Button: MouseButtonLeft, X: 0, Y: 3})
+	runtime.render()
+	assert.NotContains(t, screenText(terminal), "Delete")
+	assert.Equal(t, 0, len(chosen))
+}
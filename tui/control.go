@@ -0,0 +1,264 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// errStopped is returned by control commands sent after the runtime stops.
+var errStopped = errors.New("application has stopped")
+
+// controlEvent runs a control command in the event loop. It is handled by
+// the Runtime and never reaches the application.
+type controlEvent struct {
+	run      func() error
+	result   chan error // receives run's result once the next frame is drawn
+	received time.Time
+}
+
+// Timestamp implements Event.
+func (e controlEvent) Timestamp() time.Time { return e.received }
+
+// controlResult is the result of a control command waiting to be sent.
+type controlResult struct {
+	ch  chan error
+	err error
+}
+
+// sendControlResults sends the results of the control commands run so far.
+// Called once a frame has been drawn.
+func (r *Runtime) sendControlResults() {
+	for _, res := range r.controlResults {
+		res.ch <- res.err
+	}
+	r.controlResults = nil
+}
+
+// control runs fn in the event loop and waits for the frame drawn after it,
+// so a following screen query sees its effect.
+func (r *Runtime) control(fn func() error) error {
+	result := make(chan error, 1)
+	select {
+	case r.events <- controlEvent{run: fn, result: result, received: time.Now()}:
+	case <-r.done:
+		return errStopped
+	}
+	select {
+	case err := <-result:
+		return err
+	case <-r.done:
+		return errStopped
+	}
+}
+
+// serveControl listens for control connections on the Unix socket at path
+// and returns a function that stops listening and removes the socket. See
+// WithControlSocket for the commands clients can send.
+func serveControl(r *Runtime, path string) (stop func(), err error) {
+	// Replace a socket left behind by an earlier run, but nothing else
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		os.Remove(path)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("control socket: %w", err)
+	}
+	// Only the user running the application may control it
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("control socket: %w", err)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go r.serveControlConn(conn)
+		}
+	}()
+	return func() { listener.Close() }, nil
+}
+
+// serveControlConn answers the commands sent on one control connection.
+func (r *Runtime) serveControlConn(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	w := bufio.NewWriter(conn)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		reply, err := r.runControlCommand(line)
+		if err != nil {
+			fmt.Fprintf(w, "error: %v\n", err)
+		} else if reply != "" {
+			fmt.Fprintf(w, "ok %d\n%s\n", strings.Count(reply, "\n")+1, reply)
+		} else {
+			fmt.Fprintln(w, "ok")
+		}
+		if w.Flush() != nil {
+			return
+		}
+	}
+}
+
+// runControlCommand runs one control command line and returns the text to
+// send back with the reply, if any.
+func (r *Runtime) runControlCommand(line string) (string, error) {
+	name, arg, _ := strings.Cut(line, " ")
+	switch name {
+	case "key":
+		var keys []KeyEvent
+		for _, field := range strings.Fields(arg) {
+			key, err := parseKeyName(field)
+			if err != nil {
+				return "", err
+			}
+			keys = append(keys, key)
+		}
+		if len(keys) == 0 {
+			return "", errors.New("key: no keys given")
+		}
+		return "", r.control(func() error {
+			for _, key := range keys {
+				key.Time = time.Now()
+				r.processEvent(key)
+			}
+			return nil
+		})
+
+	case "type":
+		return "", r.control(func() error {
+			for _, ch := range arg {
+				r.processEvent(KeyEvent{Rune: ch, Time: time.Now()})
+			}
+			return nil
+		})
+
+	case "paste":
+		return "", r.control(func() error {
+			r.processEvent(KeyEvent{Paste: arg, Time: time.Now()})
+			return nil
+		})
+
+	case "click":
+		var x, y int
+		if _, err := fmt.Sscan(arg, &x, &y); err != nil {
+			return "", fmt.Errorf("click: want x and y: %w", err)
+		}
+		return "", r.control(func() error {
+			now := time.Now()
+			for _, t := range []MouseEventType{MousePress, MouseClick, MouseRelease} {
+				r.processEvent(MouseEvent{Type: t, Button: MouseButtonLeft, X: x, Y: y, Time: now})
+			}
+			return nil
+		})
+
+	case "action":
+		return "", r.control(func() error {
+			if r.palette == nil {
+				return errors.New("action: the application has no command palette")
+			}
+			for _, a := range r.palette.actions {
+				if strings.EqualFold(a.Name, arg) {
+					if a.Handler != nil {
+						r.queueCmds(a.Handler())
+					}
+					return nil
+				}
+			}
+			return fmt.Errorf("action: no action named %q", arg)
+		})
+
+	case "screen":
+		// Wait for any pending events to be drawn first
+		if err := r.control(func() error { return nil }); err != nil {
+			return "", err
+		}
+		lines := strings.Split(r.terminal.ScreenText(), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight(line, " ")
+		}
+		return strings.Join(lines, "\n"), nil
+
+	case "quit":
+		r.Stop()
+		return "", nil
+	}
+	return "", fmt.Errorf("unknown command %q", name)
+}
+
+// keyNames maps the names accepted by parseKeyName to keys.
+var keyNames = map[string]Key{
+	"enter": KeyEnter, "return": KeyEnter, "tab": KeyTab, "backspace": KeyBackspace,
+	"esc": KeyEscape, "escape": KeyEscape,
+	"up": KeyArrowUp, "down": KeyArrowDown, "left": KeyArrowLeft, "right": KeyArrowRight,
+	"home": KeyHome, "end": KeyEnd, "pgup": KeyPageUp, "pageup": KeyPageUp,
+	"pgdn": KeyPageDown, "pagedown": KeyPageDown,
+	"delete": KeyDelete, "del": KeyDelete, "insert": KeyInsert, "ins": KeyInsert,
+	"f1": KeyF1, "f2": KeyF2, "f3": KeyF3, "f4": KeyF4, "f5": KeyF5, "f6": KeyF6,
+	"f7": KeyF7, "f8": KeyF8, "f9": KeyF9, "f10": KeyF10, "f11": KeyF11, "f12": KeyF12,
+}
+
+// parseKeyName parses a key written like "j", "J", "enter", "space",
+// "ctrl+c", or "shift+tab" into the KeyEvent a terminal reports for it.
+// Modifier and key names are not case sensitive, except for single
+// characters.
+func parseKeyName(name string) (KeyEvent, error) {
+	var key KeyEvent
+	rest := name
+	for {
+		mod, after, found := strings.Cut(rest, "+")
+		if !found || after == "" {
+			break
+		}
+		switch strings.ToLower(mod) {
+		case "ctrl", "control":
+			key.Ctrl = true
+		case "alt", "meta", "option":
+			key.Alt = true
+		case "shift":
+			key.Shift = true
+		case "super", "cmd":
+			key.Super = true
+		default:
+			return KeyEvent{}, fmt.Errorf("key %q: unknown modifier %q", name, mod)
+		}
+		rest = after
+	}
+
+	if utf8.RuneCountInString(rest) == 1 {
+		r, _ := utf8.DecodeRuneInString(rest)
+		if key.Ctrl {
+			// Terminals report Ctrl with a letter as a control character
+			lower := r | 0x20
+			if lower < 'a' || lower > 'z' {
+				return KeyEvent{}, fmt.Errorf("key %q: ctrl only combines with letters", name)
+			}
+			key.Key = KeyCtrlA + Key(lower-'a')
+			return key, nil
+		}
+		key.Rune = r
+		return key, nil
+	}
+	lower := strings.ToLower(rest)
+	if lower == "space" {
+		key.Rune = ' '
+		return key, nil
+	}
+	k, ok := keyNames[lower]
+	if !ok {
+		return KeyEvent{}, fmt.Errorf("key %q: unknown key %q", name, rest)
+	}
+	key.Key = k
+	return key, nil
+}
@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestParseKeyName(t *testing.T) {
+	tests := []struct {
+		name string
+		want KeyEvent
+	}{
+		{"j", KeyEvent{Rune: 'j'}},
+		{"J", KeyEvent{Rune: 'J'}},
+		{"+", KeyEvent{Rune: '+'}},
+		{"space", KeyEvent{Rune: ' '}},
+		{"Enter", KeyEvent{Key: KeyEnter}},
+		{"ctrl+c", KeyEvent{Key: KeyCtrlC, Ctrl: true}},
+		{"Ctrl+P", KeyEvent{Key: KeyCtrlP, Ctrl: true}},
+		{"shift+tab", KeyEvent{Key: KeyTab, Shift: true}},
+		{"alt+f", KeyEvent{Rune: 'f', Alt: true}},
+		{"shift+f10", KeyEvent{Key: KeyF10, Shift: true}},
+		{"pgdn", KeyEvent{Key: KeyPageDown}},
+	}
+	for _, tt := range tests {
+		got, err := parseKeyName(tt.name)
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, got, tt.want, tt.name)
+	}
+
+	for _, bad := range []string{"hyper+j", "ctrl+1", "bogus"} {
+		_, err := parseKeyName(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+// controlApp counts key presses and shows them.
+type controlApp struct {
+	keys []string
+}
+
+func (a *controlApp) View() View {
+	return Stack(
+		Text("keys: %s", strings.Join(a.keys, ",")),
+		Clickable("reset", func() { a.keys = nil }),
+	)
+}
+
+func (a *controlApp) HandleEvent(e Event) []Cmd {
+	if key, ok := e.(KeyEvent); ok {
+		switch {
+		case key.Paste != "":
+			a.keys = append(a.keys, "paste:"+key.Paste)
+		case key.Rune != 0:
+			a.keys = append(a.keys, string(key.Rune))
+		default:
+			a.keys = append(a.keys, fmt.Sprint(key.Key))
+		}
+	}
+	return nil
+}
+
+func TestControlSocket(t *testing.T) {
+	terminal := NewTestTerminal(30, 3, &bytes.Buffer{})
+	app := &controlApp{}
+	runtime := NewRuntime(terminal, app, 30)
+	runtime.SetCommandPalette(KeyEvent{Key: KeyCtrlK, Ctrl: true}, []Action{
+		{Name: "Clear keys", Handler: func() []Cmd { app.keys = []string{"cleared"}; return nil }},
+	})
+	runtime.ticker = time.NewTicker(time.Hour)
+	defer runtime.ticker.Stop()
+	go runtime.eventLoop()
+	defer runtime.Stop()
+
+	path := filepath.Join(t.TempDir(), "control.sock")
+	stop, err := serveControl(runtime, path)
+	assert.NoError(t, err)
+	defer stop()
+
+	conn, err := net.Dial("unix", path)
+	assert.NoError(t, err)
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	send := func(line string) string {
+		fmt.Fprintln(conn, line)
+		reply, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		return strings.TrimSuffix(reply, "\n")
+	}
+	screen := func() []string {
+		assert.Equal(t, send("screen"), "ok 3")
+		lines := make([]string, 3)
+		for i := range lines {
+			line, err := reader.ReadString('\n')
+			assert.NoError(t, err)
+			lines[i] = strings.TrimSuffix(line, "\n")
+		}
+		return lines
+	}
+
+	assert.Equal(t, send("key j J"), "ok")
+	assert.Equal(t, send("type hi"), "ok")
+	assert.Equal(t, send("paste xy"), "ok")
+	assert.Equal(t, screen()[0], "keys: j,J,h,i,paste:xy")
+
+	assert.Equal(t, send("click 1 1"), "ok")
+	assert.Equal(t, screen()[0], "keys:")
+
+	assert.Equal(t, send("action clear keys"), "ok")
+	assert.Equal(t, screen()[0], "keys: cleared")
+
+	assert.Equal(t, send("action Nope"), `error: action: no action named "Nope"`)
+	assert.Equal(t, send("key hyper+j"), `error: key "hyper+j": unknown modifier "hyper"`)
+	assert.Equal(t, send("dance"), `error: unknown command "dance"`)
+
+	assert.Equal(t, send("quit"), "ok")
+	select {
+	case <-runtime.done:
+	case <-time.After(time.Second):
+		t.Fatal("the application didn't quit")
+	}
+	assert.Equal(t, send("key j"), "error: application has stopped")
+}
@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// crashHistorySize is the number of recent events kept for crash reports.
+const crashHistorySize = 50
+
+// PanicError is returned by Run when the application panics. The terminal
+// is restored before Run returns, and unless crash reports are disabled a
+// report with the stack trace, recent events, and last screen contents is
+// written to a temporary file for attaching to bug reports.
+//
+// Crash reports include recent key presses, without the characters typed,
+// and everything that was on screen, so review them before sharing.
+type PanicError struct {
+	Value      any    // The value passed to panic
+	Stack      []byte // Stack trace of the panicking goroutine
+	ReportPath string // Path of the crash report, or "" if none was written
+}
+
+func (e *PanicError) Error() string {
+	if e.ReportPath != "" {
+		return fmt.Sprintf("panic: %v (crash report: %s)", e.Value, e.ReportPath)
+	}
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// eventHistory is a ring buffer of recent events for crash reports.
+type eventHistory struct {
+	entries []string
+	next    int
+	full    bool
+}
+
+func newEventHistory(size int) *eventHistory {
+	return &eventHistory{entries: make([]string, size)}
+}
+
+// add records an event. Ticks are skipped so they don't push out the
+// events that led to the crash.
+func (h *eventHistory) add(event Event) {
+	if _, ok := event.(TickEvent); ok {
+		return
+	}
+	h.entries[h.next] = event.Timestamp().Format("15:04:05.000") + " " + describeEvent(event)
+	h.next = (h.next + 1) % len(h.entries)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// list returns the recorded events, oldest first.
+func (h *eventHistory) list() []string {
+	if !h.full {
+		return append([]string(nil), h.entries[:h.next]...)
+	}
+	return append(append([]string(nil), h.entries[h.next:]...), h.entries[:h.next]...)
+}
+
+// describeEvent formats an event for a crash report. Typed characters and
+// pasted text are reduced to their length, so passwords entered into
+// masked inputs don't end up in the report.
+func describeEvent(event Event) string {
+	switch e := event.(type) {
+	case KeyEvent:
+		var b strings.Builder
+		fmt.Fprintf(&b, "KeyEvent key=%d", e.Key)
+		switch {
+		case unicode.IsPrint(e.Rune):
+			b.WriteString(" rune=(1)")
+		case e.Rune != 0:
+			fmt.Fprintf(&b, " rune=%q", e.Rune)
+		}
+		for _, mod := range []struct {
+			set  bool
+			name string
+		}{{e.Ctrl, "ctrl"}, {e.Alt, "alt"}, {e.Shift, "shift"}, {e.Super, "super"}} {
+			if mod.set {
+				b.WriteString(" " + mod.name)
+			}
+		}
+		if e.Paste != "" {
+			fmt.Fprintf(&b, " paste=(%d bytes)", len(e.Paste))
+		}
+		return b.String()
+	case MouseEvent:
+		return fmt.Sprintf("MouseEvent type=%d button=%d x=%d y=%d", e.Type, e.Button, e.X, e.Y)
+	case ResizeEvent:
+		return fmt.Sprintf("ResizeEvent %dx%d", e.Width, e.Height)
+	}
+	return fmt.Sprintf("%T", event)
+}
+
+// recordPanic records the first panic in the runtime's goroutines. It must
+// be called from a deferred function with the result of recover.
+func (r *Runtime) recordPanic(value any) {
+	stack := debug.Stack()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.panicErr == nil {
+		r.panicErr = &PanicError{Value: value, Stack: stack}
+	}
+}
+
+// writeCrashReport writes a crash report for a recorded panic to a
+// temporary file and returns its path.
+func (r *Runtime) writeCrashReport(p *PanicError, screen string, events []string) (string, error) {
+	f, err := os.CreateTemp("", "wonton-crash-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	width, height := r.terminal.Size()
+	var b strings.Builder
+	fmt.Fprintf(&b, "wonton crash report\n")
+	fmt.Fprintf(&b, "time: %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&b, "panic: %v\n\n", p.Value)
+
+	b.WriteString("== environment ==\n")
+	fmt.Fprintf(&b, "go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	if info, ok := debug.ReadBuildInfo(); ok {
+		fmt.Fprintf(&b, "main: %s %s\n", info.Main.Path, info.Main.Version)
+	}
+	for _, name := range []string{"TERM", "COLORTERM", "TERM_PROGRAM", "TERM_PROGRAM_VERSION"} {
+		fmt.Fprintf(&b, "%s=%s\n", name, os.Getenv(name))
+	}
+	fmt.Fprintf(&b, "size: %dx%d\n", width, height)
+	fmt.Fprintf(&b, "keyboard: %s\n", r.terminal.KeyboardProtocol())
+	fmt.Fprintf(&b, "fps: %d\n\n", r.fps)
+
+	b.WriteString("== stack ==\n")
+	b.Write(p.Stack)
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "== last %d events (oldest first) ==\n", len(events))
+	for _, e := range events {
+		b.WriteString(e + "\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString("== screen ==\n")
+	b.WriteString(screen)
+	b.WriteString("\n")
+
+	if _, err := f.WriteString(b.String()); err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,55 @@
+package tui
+
+// cursorView places the terminal cursor within its inner view.
+type cursorView struct {
+	inner   View
+	x, y    int
+	visible bool
+}
+
+// Cursor shows the terminal cursor at (x, y) within the inner view, for
+// custom editors and prompts that track a caret themselves. When visible is
+// false the view only draws inner, so the cursor can follow focus:
+//
+//	tui.Cursor(app.col, app.row, app.editing, editorView)
+//
+// The Runtime places the cursor after each frame and hides it again when no
+// view asks for it; the last view drawn wins. Printing and InlineApp don't
+// move the terminal cursor, so Cursor has no effect there. Views that draw
+// themselves call RenderContext.SetCursor instead.
+func Cursor(x, y int, visible bool, inner View) View {
+	return &cursorView{inner: inner, x: x, y: y, visible: visible}
+}
+
+func (c *cursorView) size(maxWidth, maxHeight int) (int, int) {
+	return c.inner.size(maxWidth, maxHeight)
+}
+
+// flex implements the Flexible interface by delegating to the inner view.
+func (c *cursorView) flex() int {
+	if flex, ok := c.inner.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (c *cursorView) render(ctx *RenderContext) {
+	c.inner.render(ctx)
+	if c.visible {
+		ctx.SetCursor(c.x, c.y)
+	}
+}
+
+// drawInput draws a TextInput into ctx and places the terminal cursor for
+// InputCursorTerminal, drawing a block cursor instead where the renderer
+// can't place it.
+func drawInput(ctx *RenderContext, input *TextInput) {
+	if input.CursorShape == InputCursorTerminal && ctx.cursor == nil {
+		input.CursorShape = InputCursorBlock
+		defer func() { input.CursorShape = InputCursorTerminal }()
+	}
+	input.Draw(ctx.frame)
+	if x, y, ok := input.CursorPosition(); ok {
+		ctx.SetCursor(x, y)
+	}
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestRenderContext_SetCursor(t *testing.T) {
+	terminal := NewTestTerminal(20, 5, &bytes.Buffer{})
+	frame, err := terminal.BeginFrame()
+	assert.NoError(t, err)
+	defer terminal.EndFrame(frame)
+
+	ctx := NewRenderContext(frame, 0)
+	assert.False(t, ctx.SetCursor(1, 1))
+
+	var cursor cursorRequest
+	ctx = ctx.withCursor(&cursor)
+	sub := ctx.SubContext(image.Rect(4, 2, 10, 3))
+	assert.True(t, sub.SetCursor(3, 0))
+	assert.Equal(t, cursorRequest{x: 7, y: 2, visible: true}, cursor)
+
+	// Positions outside the context are ignored
+	sub.SetCursor(0, 1)
+	assert.Equal(t, cursorRequest{x: 7, y: 2, visible: true}, cursor)
+}
+
+func TestRuntime_PlacesCursor(t *testing.T) {
+	var buf bytes.Buffer
+	terminal := NewTestTerminal(40, 5, &buf)
+	show := true
+	app := &simpleApp{
+		handleFunc: func(Event) []Cmd { return nil },
+		renderFunc: func() View {
+			return Stack(Text("name:"), Cursor(2, 0, show, Text("abc")))
+		},
+	}
+	runtime := NewRuntime(terminal, app, 30)
+
+	runtime.render()
+	assert.Contains(t, buf.String(), "\033[2;3H\033[?25h")
+
+	// Without a request, the cursor is hidden again
+	buf.Reset()
+	show = false
+	runtime.render()
+	assert.Regexp(t, `\x1b\[\?25l$`, buf.String())
+}
+
+func TestInputField_TerminalCursor(t *testing.T) {
+	value := "hi"
+	field := func() View {
+		return InputField(&value).ID("name").CursorShape(InputCursorTerminal)
+	}
+
+	// Without a runtime to place the cursor, a block cursor is drawn instead
+	terminal := NewTestTerminal(20, 3, &bytes.Buffer{})
+	frame, err := terminal.BeginFrame()
+	assert.NoError(t, err)
+	view := field()
+	view.size(20, 3)
+	view.render(NewRenderContext(frame, 0).WithFocusManager(NewFocusManager()))
+	terminal.EndFrame(frame)
+	assert.Equal(t, ColorWhite, terminal.GetCell(2, 0).Style.Background)
+
+	var buf bytes.Buffer
+	terminal = NewTestTerminal(20, 3, &buf)
+	runtime := NewRuntime(terminal, &simpleApp{handleFunc: func(Event) []Cmd { return nil }, renderFunc: field}, 30)
+	runtime.render()
+	assert.Contains(t, buf.String(), "\033[1;3H\033[?25h")
+	assert.Equal(t, terminal.GetCell(3, 0).Style, terminal.GetCell(2, 0).Style)
+}
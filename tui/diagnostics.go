@@ -0,0 +1,216 @@
+package tui
+
+import (
+	"image"
+	"sort"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// DiagnosticSeverity ranks a Diagnostic. Lower values are more severe.
+type DiagnosticSeverity int
+
+const (
+	SeverityError DiagnosticSeverity = iota
+	SeverityWarning
+	SeverityInfo
+	SeverityHint
+)
+
+// String returns the lowercase name of the severity.
+func (s DiagnosticSeverity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	default:
+		return "hint"
+	}
+}
+
+// color returns the color used for the severity's underline and marker.
+func (s DiagnosticSeverity) color() Color {
+	switch s {
+	case SeverityError:
+		return ColorRed
+	case SeverityWarning:
+		return ColorYellow
+	case SeverityInfo:
+		return ColorBlue
+	default:
+		return ColorBrightBlack
+	}
+}
+
+// marker returns the gutter marker for the severity.
+func (s DiagnosticSeverity) marker() string {
+	switch s {
+	case SeverityError:
+		return "●"
+	case SeverityWarning:
+		return "▲"
+	case SeverityInfo:
+		return "■"
+	default:
+		return "·"
+	}
+}
+
+// Diagnostic is a message attached to a range of text, such as a spelling
+// mistake or a linter finding. Text views that accept diagnostics underline
+// the range in the severity's color, mark the line in a gutter, and show the
+// messages in a popover for the active line.
+//
+// Lines and columns are 0-based. Columns count runes of the line as given,
+// before any tab expansion.
+type Diagnostic struct {
+	Line     int
+	StartCol int
+	EndCol   int // Column just past the range; <= StartCol runs to the end of the line
+	Severity DiagnosticSeverity
+	Message  string
+	Source   string // Optional origin shown after the message, e.g. "vet"
+}
+
+// diagnosticWidth is the width of the gutter column that holds markers.
+const diagnosticWidth = 2
+
+// diagnosticsByLine groups diagnostics by line, most severe first.
+func diagnosticsByLine(diags []Diagnostic) map[int][]Diagnostic {
+	if len(diags) == 0 {
+		return nil
+	}
+	byLine := make(map[int][]Diagnostic)
+	for _, d := range diags {
+		byLine[d.Line] = append(byLine[d.Line], d)
+	}
+	for _, line := range byLine {
+		sort.SliceStable(line, func(i, j int) bool {
+			return line[i].Severity < line[j].Severity
+		})
+	}
+	return byLine
+}
+
+// diagnosticMarker returns the gutter text for a line's diagnostics, which
+// are sorted most severe first.
+func diagnosticMarker(diags []Diagnostic) (string, Style) {
+	if len(diags) == 0 {
+		return "  ", NewStyle()
+	}
+	sev := diags[0].Severity
+	return sev.marker() + " ", NewStyle().WithForeground(sev.color())
+}
+
+// underlineDiagnostics splits the segments of a line so that the ranges of
+// diags are underlined in their severity's color. toIndex maps a diagnostic
+// column to a rune index into the segments' text.
+func underlineDiagnostics(segments []StyledSegment, diags []Diagnostic, toIndex func(col int) int) []StyledSegment {
+	if len(diags) == 0 {
+		return segments
+	}
+
+	// severityAt returns the most severe diagnostic covering rune i
+	severityAt := func(i int) (DiagnosticSeverity, bool) {
+		for _, d := range diags {
+			start := toIndex(d.StartCol)
+			if i < start {
+				continue
+			}
+			if d.EndCol > d.StartCol && i >= toIndex(d.EndCol) {
+				continue
+			}
+			return d.Severity, true
+		}
+		return 0, false
+	}
+
+	var result []StyledSegment
+	i := 0
+	for _, seg := range segments {
+		var piece []rune
+		pieceSev, pieceHit := severityAt(i)
+		flush := func() {
+			if len(piece) == 0 {
+				return
+			}
+			out := seg
+			out.Text = string(piece)
+			if pieceHit {
+				out.Style = out.Style.WithForeground(pieceSev.color()).WithUnderline()
+			}
+			result = append(result, out)
+			piece = piece[:0]
+		}
+		for _, r := range seg.Text {
+			sev, hit := severityAt(i)
+			if hit != pieceHit || sev != pieceSev {
+				flush()
+				pieceSev, pieceHit = sev, hit
+			}
+			piece = append(piece, r)
+			i++
+		}
+		flush()
+	}
+	return result
+}
+
+// segmentsView lays out styled segments on a single line.
+func segmentsView(segments []StyledSegment) View {
+	views := make([]View, len(segments))
+	for i, seg := range segments {
+		views[i] = Text("%s", seg.Text).Style(seg.Style)
+	}
+	return Group(views...)
+}
+
+// renderDiagnosticPopover queues an overlay listing the messages of diags
+// next to the line at row y of ctx, starting at column x. The popover opens
+// below the line, or above it if there is more room there.
+func renderDiagnosticPopover(ctx *RenderContext, x, y int, diags []Diagnostic) {
+	if len(diags) == 0 {
+		return
+	}
+	lines := make([]string, len(diags))
+	w := 0
+	for i, d := range diags {
+		text := d.Severity.marker() + " " + d.Message
+		if d.Source != "" {
+			text += " (" + d.Source + ")"
+		}
+		lines[i] = text
+		w = max(w, runewidth.StringWidth(text)+4)
+	}
+	h := len(diags) + 2
+
+	screen := ctx.screenBounds()
+	w = min(w, screen.Dx())
+	below, above := screen.Max.Y-y-1, y-screen.Min.Y
+	top := y + 1
+	if below < h && above > below {
+		h = min(h, above)
+		top = y - h
+	} else {
+		h = min(h, below)
+	}
+	x = min(x, screen.Max.X-w)
+	if h < 3 || w < 5 {
+		return
+	}
+
+	ctx.overlay(image.Rect(x, top, x+w, top+h), func(box *RenderContext) {
+		box.Fill(' ', NewStyle())
+		border := Bordered(Empty()).Border(&RoundedBorder).BorderFg(diags[0].Severity.color())
+		border.size(w, h)
+		border.render(box)
+
+		for i, text := range lines[:h-2] {
+			style := NewStyle().WithForeground(diags[i].Severity.color())
+			box.PrintTruncated(2, i+1, runewidth.Truncate(text, w-4, "…"), style)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestUnderlineDiagnostics(t *testing.T) {
+	segments := []StyledSegment{{Text: "hello "}, {Text: "wrold"}}
+	diags := []Diagnostic{
+		{StartCol: 6, EndCol: 11, Severity: SeverityWarning},
+		{StartCol: 8, Severity: SeverityError}, // runs to the end of the line
+	}
+	sorted := diagnosticsByLine(diags)[0]
+	got := underlineDiagnostics(segments, sorted, func(col int) int { return col })
+
+	texts := make([]string, len(got))
+	for i, seg := range got {
+		texts[i] = seg.Text
+	}
+	assert.Equal(t, []string{"hello ", "wr", "old"}, texts)
+	assert.False(t, got[0].Style.Underline)
+	assert.True(t, got[1].Style.Underline)
+	assert.Equal(t, ColorYellow, got[1].Style.Foreground)
+	assert.Equal(t, ColorRed, got[2].Style.Foreground)
+}
+
+func TestTextArea_Diagnostics(t *testing.T) {
+	content := "one\ntwo tpyo\nthree"
+	cursor := 1
+	view := TextArea(nil).Content(content).ID("diag").Size(30, 8).
+		HighlightCurrentLine(true).CursorLine(&cursor).
+		Diagnostics([]Diagnostic{{Line: 1, StartCol: 4, EndCol: 8, Severity: SeverityWarning, Message: "unknown word", Source: "spell"}})
+
+	var buf bytes.Buffer
+	terminal := NewTestTerminal(30, 8, &buf)
+	fm := NewFocusManager()
+	for range 2 { // the first render registers the text area for focus
+		frame, err := terminal.BeginFrame()
+		assert.NoError(t, err)
+		ctx := NewRenderContext(frame, 0).WithFocusManager(fm)
+		view.render(ctx)
+		ctx.drawOverlays()
+		assert.NoError(t, terminal.EndFrame(frame))
+	}
+
+	assert.Equal(t, "  one", screenRow(terminal, 0))
+	assert.Equal(t, "▲ two tpyo", screenRow(terminal, 1))
+	assert.False(t, terminal.GetCell(5, 1).Style.Underline)
+	assert.True(t, terminal.GetCell(6, 1).Style.Underline)
+	assert.Equal(t, ColorYellow, terminal.GetCell(6, 1).Style.Foreground)
+
+	// The focused text area shows the current line's messages below it
+	assert.Equal(t, "  ╭────────────────────────╮", screenRow(terminal, 2))
+	assert.Equal(t, "  │ ▲ unknown word (spell) │", screenRow(terminal, 3))
+}
+
+func TestCode_Diagnostics(t *testing.T) {
+	code := "x :=\t1\ny := 2"
+	view := Code(code, "text").LineNumbers(false).ActiveLine(0).
+		Diagnostics([]Diagnostic{{Line: 0, StartCol: 5, EndCol: 6, Severity: SeverityError, Message: "bad"}})
+
+	w, _ := view.size(0, 0)
+	assert.Equal(t, 11, w)
+
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, "● x :=    1", screen.Row(0))
+	assert.Equal(t, "  ╭───────╮", screen.Row(1))
+	assert.Equal(t, "  │ ● bad │", screen.Row(2))
+
+	// The tab expands to four spaces, so column 5 lands on "1"
+	assert.False(t, screen.Cell(9, 0).Style.Underline)
+	assert.True(t, screen.Cell(10, 0).Style.Underline)
+}
@@ -10,29 +10,33 @@ import (
 
 // DiffTheme defines colors and styles for diff rendering
 type DiffTheme struct {
-	AddedBg      RGB    // Background for added lines
-	AddedFg      RGB    // Foreground for added lines
-	RemovedBg    RGB    // Background for removed lines
-	RemovedFg    RGB    // Foreground for removed lines
-	ContextStyle Style  // Style for context lines
-	HeaderStyle  Style  // Style for file headers
-	HunkStyle    Style  // Style for hunk headers
-	LineNumStyle Style  // Style for line numbers
-	SyntaxTheme  string // Chroma theme for syntax highlighting
+	AddedBg       RGB    // Background for added lines
+	AddedFg       RGB    // Foreground for added lines
+	AddedWordBg   RGB    // Background for changed words in added lines (zero: brightened AddedBg)
+	RemovedBg     RGB    // Background for removed lines
+	RemovedFg     RGB    // Foreground for removed lines
+	RemovedWordBg RGB    // Background for changed words in removed lines (zero: brightened RemovedBg)
+	ContextStyle  Style  // Style for context lines
+	HeaderStyle   Style  // Style for file headers
+	HunkStyle     Style  // Style for hunk headers
+	LineNumStyle  Style  // Style for line numbers
+	SyntaxTheme   string // Chroma theme for syntax highlighting
 }
 
 // DefaultDiffTheme returns a default diff theme
 func DefaultDiffTheme() DiffTheme {
 	return DiffTheme{
-		AddedBg:      RGB{R: 0, G: 64, B: 0},      // Dark green background
-		AddedFg:      RGB{R: 100, G: 255, B: 100}, // Light green foreground
-		RemovedBg:    RGB{R: 64, G: 0, B: 0},      // Dark red background
-		RemovedFg:    RGB{R: 255, G: 100, B: 100}, // Light red foreground
-		ContextStyle: NewStyle().WithForeground(ColorWhite),
-		HeaderStyle:  NewStyle().WithForeground(ColorCyan).WithBold(),
-		HunkStyle:    NewStyle().WithForeground(ColorBlue).WithBold(),
-		LineNumStyle: NewStyle().WithForeground(ColorBrightBlack),
-		SyntaxTheme:  "monokai",
+		AddedBg:       RGB{R: 0, G: 64, B: 0},      // Dark green background
+		AddedFg:       RGB{R: 100, G: 255, B: 100}, // Light green foreground
+		AddedWordBg:   RGB{R: 0, G: 120, B: 0},     // Brighter green for changed words
+		RemovedBg:     RGB{R: 64, G: 0, B: 0},      // Dark red background
+		RemovedFg:     RGB{R: 255, G: 100, B: 100}, // Light red foreground
+		RemovedWordBg: RGB{R: 120, G: 0, B: 0},     // Brighter red for changed words
+		ContextStyle:  NewStyle().WithForeground(ColorWhite),
+		HeaderStyle:   NewStyle().WithForeground(ColorCyan).WithBold(),
+		HunkStyle:     NewStyle().WithForeground(ColorBlue).WithBold(),
+		LineNumStyle:  NewStyle().WithForeground(ColorBrightBlack),
+		SyntaxTheme:   "monokai",
 	}
 }
 
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/termtest"
+	"github.com/deepnoodle-ai/wonton/unidiff"
 )
 
 const sampleDiff = `diff --git a/main.go b/main.go
@@ -190,7 +192,7 @@ func TestDiffView_Scrolling(t *testing.T) {
 	view := DiffView(diff, "go", &scrollY).Height(10)
 
 	// Render to initialize
-	view.renderContent()
+	SprintScreen(view, PrintConfig{Width: 80})
 
 	// Initial position
 	assert.Equal(t, 0, scrollY)
@@ -398,3 +400,134 @@ func TestDiffRenderer_TabsInDiff(t *testing.T) {
 
 	assert.True(t, foundExpanded, "Should find tab-expanded content with 4 spaces")
 }
+
+const twoFileDiff = `diff --git a/one.txt b/one.txt
+--- a/one.txt
++++ b/one.txt
+@@ -1,3 +1,3 @@
+ alpha
+-beta gamma
++beta delta
+ omega
+@@ -10,2 +10,3 @@
+ ten
++eleven
+ twelve
+diff --git a/two.txt b/two.txt
+--- a/two.txt
++++ b/two.txt
+@@ -1 +1 @@
+-old
++new
+`
+
+func TestDiffView_UnidiffInput(t *testing.T) {
+	diff, err := unidiff.Parse(twoFileDiff)
+	assert.NoError(t, err)
+	screen := SprintScreen(DiffView(diff, "", nil), PrintConfig{Width: 40})
+	assert.Equal(t, "--- one.txt", screen.Row(0))
+	assert.Equal(t, "+++ one.txt", screen.Row(1))
+	assert.Equal(t, "@@ -1,3 +1,3 @@", screen.Row(3))
+	assert.Equal(t, "    1     1 │ alpha", screen.Row(4))
+	assert.Equal(t, "    2       │ beta gamma", screen.Row(5))
+	assert.Equal(t, "          2 │ beta delta", screen.Row(6))
+}
+
+func TestDiffView_IntraLineHighlight(t *testing.T) {
+	diff, err := unidiff.Parse(twoFileDiff)
+	assert.NoError(t, err)
+	theme := DefaultDiffTheme()
+	view := DiffView(diff, "", nil).ShowLineNumbers(false)
+	screen := SprintScreen(view, PrintConfig{Width: 40})
+
+	bg := func(x, y int) termtest.Color { return screen.Cell(x, y).Style.Background }
+	rgb := func(c RGB) termtest.Color {
+		return termtest.Color{Type: termtest.ColorRGB, R: c.R, G: c.G, B: c.B}
+	}
+	// "beta " is unchanged, "gamma"/"delta" changed
+	assert.Equal(t, rgb(theme.RemovedBg), bg(0, 5))
+	assert.Equal(t, rgb(theme.RemovedWordBg), bg(5, 5))
+	assert.Equal(t, rgb(theme.AddedBg), bg(0, 6))
+	assert.Equal(t, rgb(theme.AddedWordBg), bg(5, 6))
+
+	// Lines with nothing in common aren't highlighted word by word
+	assert.Equal(t, "old", screen.Row(18))
+	assert.Equal(t, rgb(theme.RemovedBg), bg(1, 18))
+
+	screen = SprintScreen(DiffView(diff, "", nil).ShowLineNumbers(false).IntraLine(false), PrintConfig{Width: 40})
+	assert.Equal(t, rgb(theme.AddedBg), screen.Cell(5, 6).Style.Background)
+}
+
+func TestDiffView_SideBySide(t *testing.T) {
+	diff, err := unidiff.Parse(twoFileDiff)
+	assert.NoError(t, err)
+	screen := SprintScreen(DiffView(diff, "", nil).SideBySide(true), PrintConfig{Width: 41})
+	assert.Equal(t, "one.txt", screen.Row(0))
+	assert.Equal(t, "@@ -1,3 +1,3 @@", screen.Row(2))
+	assert.Equal(t, "    1 alpha         │    1 alpha", screen.Row(3))
+	assert.Equal(t, "    2 beta gamma    │    2 beta delta", screen.Row(4))
+	assert.Equal(t, "                    │   11 eleven", screen.Row(9))
+}
+
+func TestDiffState_Navigation(t *testing.T) {
+	diff, err := unidiff.Parse(twoFileDiff)
+	assert.NoError(t, err)
+	state := NewDiffState()
+	render := func() *termtest.Screen {
+		return SprintScreen(DiffView(diff, "", nil).State(state), PrintConfig{Width: 40, Height: 4})
+	}
+	render()
+
+	file, hunk := state.CurrentHunk()
+	assert.Equal(t, 0, file)
+	assert.Equal(t, 0, hunk)
+
+	assert.True(t, state.HandleKey(KeyEvent{Rune: 'n'}))
+	screen := render()
+	assert.Equal(t, "@@ -1,3 +1,3 @@", screen.Row(0))
+	assert.True(t, state.HandleKey(KeyEvent{Rune: 'n'}))
+	screen = render()
+	assert.Equal(t, "@@ -10,2 +10,3 @@", screen.Row(0))
+
+	assert.True(t, state.HandleKey(KeyEvent{Rune: ']'}))
+	screen = render()
+	assert.Equal(t, "--- two.txt", screen.Row(0))
+	assert.False(t, state.NextFile())
+
+	assert.True(t, state.HandleKey(KeyEvent{Rune: '['}))
+	screen = render()
+	assert.Equal(t, "--- one.txt", screen.Row(0))
+	assert.False(t, state.HandleKey(KeyEvent{Rune: 'N'}))
+}
+
+func TestDiffState_Folding(t *testing.T) {
+	diff, err := unidiff.Parse(twoFileDiff)
+	assert.NoError(t, err)
+	state := NewDiffState()
+	render := func() *termtest.Screen {
+		return SprintScreen(DiffView(diff, "", nil).State(state), PrintConfig{Width: 40, Height: 6})
+	}
+	render()
+	state.NextHunk()
+	render()
+
+	// Folding keeps the hunk at the top and hides its lines
+	assert.True(t, state.HandleKey(KeyEvent{Key: KeyEnter}))
+	screen := render()
+	assert.True(t, state.Folded(0, 0))
+	assert.Equal(t, "@@ -1,3 +1,3 @@  ⋯ 4 lines", screen.Row(0))
+	assert.Equal(t, "@@ -10,2 +10,3 @@", screen.Row(2))
+
+	assert.True(t, state.HandleKey(KeyEvent{Rune: 'Z'}))
+	render()
+	assert.True(t, state.Folded(1, 0))
+	assert.True(t, state.HandleKey(KeyEvent{Rune: 'Z'}))
+	render()
+	assert.False(t, state.Folded(0, 0))
+
+	// Switching layouts keeps the current hunk at the top
+	assert.True(t, state.HandleKey(KeyEvent{Rune: 's'}))
+	screen = render()
+	assert.True(t, state.SideBySide)
+	assert.Equal(t, "@@ -1,3 +1,3 @@", screen.Row(0))
+}
@@ -1,29 +1,43 @@
 package tui
 
 import (
+	"fmt"
+	"image"
+	"strings"
+
+	"github.com/deepnoodle-ai/wonton/unidiff"
 	"github.com/mattn/go-runewidth"
 )
 
 // diffView displays a file diff with syntax highlighting.
 type diffView struct {
-	diff      *Diff
-	scrollY   *int
-	language  string
-	theme     DiffTheme
-	renderer  *DiffRenderer
-	rendered  []RenderedDiffLine
-	lastDiff  *Diff // for cache invalidation
-	height    int
-	showLines bool
-}
-
-// DiffView creates a diff view from a parsed Diff.
-// scrollY should be a pointer to the scroll position (optional, can be nil).
+	diff       *Diff
+	scrollY    *int
+	language   string
+	theme      DiffTheme
+	renderer   *DiffRenderer
+	height     int
+	state      *DiffState
+	sideBySide bool
+	intraLine  bool
+}
+
+// DiffView creates a diff view from a parsed Diff, such as one returned by
+// unidiff.Parse or ParseUnifiedDiff. scrollY should be a pointer to the
+// scroll position (optional, can be nil).
+//
+// The view shows the diff in unified form with line numbers, and highlights
+// the words that changed between each removed line and the added line that
+// replaced it. Bind a DiffState to switch to side-by-side columns, fold
+// hunks, and jump between hunks and files.
 //
 // Example:
 //
-//	diff, _ := tui.ParseUnifiedDiff(diffText)
+//	diff, _ := unidiff.Parse(diffText)
 //	DiffView(diff, "go", &app.scrollY)
+//
+//	// Interactive, with keys routed to app.diffState.HandleKey:
+//	DiffView(diff, "go", nil).State(app.diffState)
 func DiffView(diff *Diff, language string, scrollY *int) *diffView {
 	return &diffView{
 		diff:      diff,
@@ -31,7 +45,7 @@ func DiffView(diff *Diff, language string, scrollY *int) *diffView {
 		language:  language,
 		theme:     DefaultDiffTheme(),
 		renderer:  NewDiffRenderer(),
-		showLines: true,
+		intraLine: true,
 	}
 }
 
@@ -49,61 +63,182 @@ func DiffViewFromText(diffText, language string, scrollY *int) (*diffView, error
 func (d *diffView) Theme(theme DiffTheme) *diffView {
 	d.theme = theme
 	d.renderer.Theme = theme
-	d.rendered = nil // invalidate cache
 	return d
 }
 
 // Language sets the programming language for syntax highlighting.
 func (d *diffView) Language(lang string) *diffView {
 	d.language = lang
-	d.rendered = nil // invalidate cache
 	return d
 }
 
 // ShowLineNumbers enables or disables line numbers.
 func (d *diffView) ShowLineNumbers(show bool) *diffView {
-	d.showLines = show
 	d.renderer.ShowLineNums = show
-	d.rendered = nil // invalidate cache
 	return d
 }
 
 // SyntaxHighlight enables or disables syntax highlighting.
 func (d *diffView) SyntaxHighlight(enable bool) *diffView {
 	d.renderer.SyntaxHighlight = enable
-	d.rendered = nil // invalidate cache
 	return d
 }
 
-// Height sets a fixed height for the view.
+// IntraLine enables or disables highlighting of the changed words within
+// modified lines. Enabled by default.
+func (d *diffView) IntraLine(enable bool) *diffView {
+	d.intraLine = enable
+	return d
+}
+
+// SideBySide shows the old and new versions in two columns. A bound
+// DiffState's SideBySide field takes precedence.
+func (d *diffView) SideBySide(enable bool) *diffView {
+	d.sideBySide = enable
+	return d
+}
+
+// State binds the interactive state: layout mode, folded hunks, and scroll
+// position. The scrollY passed to DiffView is ignored.
+func (d *diffView) State(state *DiffState) *diffView {
+	d.state = state
+	return d
+}
+
+// Height sets a fixed height for the view. By default a view with state
+// fills the available height, and one without shows every line.
 func (d *diffView) Height(h int) *diffView {
 	d.height = h
 	return d
 }
 
-// renderContent renders the diff if needed.
-func (d *diffView) renderContent() {
-	if d.rendered != nil && d.lastDiff == d.diff {
-		return // use cached render
+// diffRowKind is the kind of a row in the laid out diff.
+type diffRowKind int
+
+const (
+	diffRowOldPath diffRowKind = iota // "--- path", or the path when side by side
+	diffRowNewPath                    // "+++ path"
+	diffRowHunk                       // the @@ header
+	diffRowLine                       // a line of a hunk
+	diffRowBlank                      // spacing after headers and hunks
+)
+
+// diffRow is one row of the laid out diff. For lines in unified form, left
+// is the line and right the line it pairs with for word highlighting, or -1.
+// Side by side, left and right are the lines shown in each column, or -1.
+type diffRow struct {
+	kind        diffRowKind
+	file, hunk  int // hunk is -1 for file headers
+	left, right int
+}
+
+// diffHunkRef identifies a hunk by file and hunk index.
+type diffHunkRef struct {
+	file, hunk int
+}
+
+// layout lays out the diff as rows, in unified or side-by-side form,
+// skipping the lines of folded hunks.
+func (d *diffView) layout() []diffRow {
+	if d.diff == nil {
+		return nil
 	}
+	sideBySide := d.isSideBySide()
+	var rows []diffRow
+	for f, file := range d.diff.Files {
+		rows = append(rows, diffRow{kind: diffRowOldPath, file: f, hunk: -1})
+		if !sideBySide {
+			rows = append(rows, diffRow{kind: diffRowNewPath, file: f, hunk: -1})
+		}
+		rows = append(rows, diffRow{kind: diffRowBlank, file: f, hunk: -1})
 
-	d.renderer.ShowLineNums = d.showLines
-	d.rendered = d.renderer.RenderDiff(d.diff, d.language)
-	d.lastDiff = d.diff
+		for h, hunk := range file.Hunks {
+			rows = append(rows, diffRow{kind: diffRowHunk, file: f, hunk: h})
+			if d.state == nil || !d.state.Folded(f, h) {
+				rows = appendHunkRows(rows, f, h, hunk.Lines, sideBySide)
+			}
+			rows = append(rows, diffRow{kind: diffRowBlank, file: f, hunk: h})
+		}
+	}
+	return rows
 }
 
-func (d *diffView) size(maxWidth, maxHeight int) (int, int) {
-	// Render to get line count
-	d.renderContent()
+// isSideBySide reports whether the diff is shown in two columns.
+func (d *diffView) isSideBySide() bool {
+	if d.state != nil {
+		return d.state.SideBySide
+	}
+	return d.sideBySide
+}
+
+// appendHunkRows appends the rows for a hunk's lines. Each run of removed
+// lines is paired, line by line, with the run of added lines after it.
+func appendHunkRows(rows []diffRow, f, h int, lines []DiffLine, sideBySide bool) []diffRow {
+	line := func(left, right int) diffRow {
+		return diffRow{kind: diffRowLine, file: f, hunk: h, left: left, right: right}
+	}
+	for i := 0; i < len(lines); {
+		if lines[i].Type != DiffLineRemoved && lines[i].Type != DiffLineAdded {
+			if sideBySide {
+				rows = append(rows, line(i, i))
+			} else {
+				rows = append(rows, line(i, -1))
+			}
+			i++
+			continue
+		}
+
+		removedStart := i
+		for i < len(lines) && lines[i].Type == DiffLineRemoved {
+			i++
+		}
+		addedStart := i
+		for i < len(lines) && lines[i].Type == DiffLineAdded {
+			i++
+		}
+		removed, added := addedStart-removedStart, i-addedStart
+		pair := func(k, count, start int) int {
+			if k < count {
+				return start + k
+			}
+			return -1
+		}
+
+		if sideBySide {
+			for k := 0; k < max(removed, added); k++ {
+				rows = append(rows, line(pair(k, removed, removedStart), pair(k, added, addedStart)))
+			}
+			continue
+		}
+		for k := 0; k < removed; k++ {
+			rows = append(rows, line(removedStart+k, pair(k, added, addedStart)))
+		}
+		for k := 0; k < added; k++ {
+			rows = append(rows, line(addedStart+k, pair(k, removed, removedStart)))
+		}
+	}
+	return rows
+}
 
+func (d *diffView) flex() int {
+	if d.state != nil && d.height == 0 {
+		return 1
+	}
+	return 0
+}
+
+func (d *diffView) size(maxWidth, maxHeight int) (int, int) {
 	w := maxWidth
 	if w == 0 {
 		w = 80
 	}
 
 	h := d.height
-	if h == 0 && d.rendered != nil {
-		h = len(d.rendered)
+	if h == 0 {
+		h = len(d.layout())
+		if d.state != nil && maxHeight > 0 {
+			h = maxHeight
+		}
 	}
 
 	if maxHeight > 0 && h > maxHeight {
@@ -118,21 +253,29 @@ func (d *diffView) render(ctx *RenderContext) {
 		return
 	}
 
-	// Render diff content
-	d.renderContent()
-
-	if len(d.rendered) == 0 {
+	rows := d.layout()
+	if len(rows) == 0 {
 		return
 	}
 
 	// Get scroll position
 	scrollY := 0
-	if d.scrollY != nil {
+	if d.state != nil {
+		scrollY = d.state.scrollY
+		if a := d.state.anchor; a != nil {
+			for i, row := range rows {
+				if row.kind == diffRowHunk && row.file == a.file && row.hunk == a.hunk {
+					scrollY = i
+				}
+			}
+			d.state.anchor = nil
+		}
+	} else if d.scrollY != nil {
 		scrollY = *d.scrollY
 	}
 
 	// Clamp scroll position
-	maxScroll := len(d.rendered) - height
+	maxScroll := len(rows) - height
 	if maxScroll < 0 {
 		maxScroll = 0
 	}
@@ -144,76 +287,381 @@ func (d *diffView) render(ctx *RenderContext) {
 	}
 
 	// Update scroll pointer if clamped
-	if d.scrollY != nil && *d.scrollY != scrollY {
+	if d.state != nil {
+		d.state.scrollY, d.state.height, d.state.rows = scrollY, height, rows
+	} else if d.scrollY != nil && *d.scrollY != scrollY {
 		*d.scrollY = scrollY
 	}
 
 	// Render visible lines
-	endLine := scrollY + height
-	if endLine > len(d.rendered) {
-		endLine = len(d.rendered)
-	}
-
-	y := 0
-	for i := scrollY; i < endLine && y < height; i++ {
-		line := d.rendered[i]
-		x := 0
-
-		// Draw background if specified
-		if line.BgColor != nil {
-			bgStyle := NewStyle().WithBgRGB(*line.BgColor)
-			// Fill the entire line width with background
-			for col := 0; col < width; col++ {
-				ctx.SetCell(col, y, ' ', bgStyle)
-			}
+	for y := 0; y < height && scrollY+y < len(rows); y++ {
+		d.renderRow(ctx.SubContext(image.Rect(0, y, width, y+1)), rows[scrollY+y])
+	}
+}
+
+// renderRow draws one row in a one-line context.
+func (d *diffView) renderRow(ctx *RenderContext, row diffRow) {
+	width, _ := ctx.Size()
+	theme := d.renderer.Theme
+	file := d.diff.Files[row.file]
+
+	switch row.kind {
+	case diffRowOldPath:
+		text := "--- " + file.OldPath
+		if d.isSideBySide() {
+			text = diffFileTitle(file)
+		}
+		ctx.PrintStyled(0, 0, text, theme.HeaderStyle)
+	case diffRowNewPath:
+		ctx.PrintStyled(0, 0, "+++ "+file.NewPath, theme.HeaderStyle)
+	case diffRowHunk:
+		hunk := file.Hunks[row.hunk]
+		ctx.PrintStyled(0, 0, hunk.Header, theme.HunkStyle)
+		if d.state != nil && d.state.Folded(row.file, row.hunk) {
+			x := runewidth.StringWidth(hunk.Header)
+			ctx.PrintStyled(x, 0, fmt.Sprintf("  ⋯ %d lines", len(hunk.Lines)), theme.LineNumStyle)
+		}
+	case diffRowLine:
+		lines := file.Hunks[row.hunk].Lines
+		if !d.isSideBySide() {
+			d.renderLine(ctx, lines, row.left, row.right, true, true)
+			return
 		}
+		half := (width - 1) / 2
+		d.renderLine(ctx.SubContext(image.Rect(0, 0, half, 1)), lines, row.left, row.right, true, false)
+		ctx.PrintStyled(half, 0, "│", theme.LineNumStyle)
+		d.renderLine(ctx.SubContext(image.Rect(half+1, 0, width, 1)), lines, row.right, row.left, false, true)
+	}
+}
 
-		// Draw line numbers if enabled
-		if d.renderer.ShowLineNums {
-			lineNumStyle := d.renderer.Theme.LineNumStyle
-			if line.BgColor != nil {
-				lineNumStyle = lineNumStyle.WithBgRGB(*line.BgColor)
-			}
+// diffFileTitle names a file for a one-line header: its path, or both paths
+// when it was renamed.
+func diffFileTitle(file DiffFile) string {
+	switch {
+	case file.OldPath == file.NewPath || file.OldPath == "/dev/null":
+		return file.NewPath
+	case file.NewPath == "/dev/null":
+		return file.OldPath
+	default:
+		return file.OldPath + " → " + file.NewPath
+	}
+}
+
+// renderLine draws lines[index] with the line numbers selected by oldNum
+// and newNum, highlighting the words that differ from lines[pair]. An index
+// of -1 leaves the space blank.
+func (d *diffView) renderLine(ctx *RenderContext, lines []DiffLine, index, pair int, oldNum, newNum bool) {
+	if index < 0 {
+		return
+	}
+	width, _ := ctx.Size()
+	theme := d.renderer.Theme
+	line := lines[index]
+	rendered := d.renderer.renderDiffLine(line, d.language)
+
+	if d.intraLine && pair >= 0 && (line.Type == DiffLineAdded || line.Type == DiffLineRemoved) {
+		removed, added := lines[index], lines[pair]
+		if line.Type == DiffLineAdded {
+			removed, added = added, removed
+		}
+		oldSpans, newSpans := unidiff.WordDiff(d.renderer.expandTabs(removed.Content), d.renderer.expandTabs(added.Content))
+		if line.Type == DiffLineAdded {
+			rendered.Segments = emphasizeSegments(rendered.Segments, newSpans, wordBg(theme.AddedWordBg, theme.AddedBg))
+		} else {
+			rendered.Segments = emphasizeSegments(rendered.Segments, oldSpans, wordBg(theme.RemovedWordBg, theme.RemovedBg))
+		}
+	}
 
-			// Old line number
-			ctx.PrintStyled(x, y, line.LineNumOld, lineNumStyle)
-			x += runewidth.StringWidth(line.LineNumOld)
+	// Draw background if specified
+	if rendered.BgColor != nil {
+		ctx.FillStyled(0, 0, width, 1, ' ', NewStyle().WithBgRGB(*rendered.BgColor))
+	}
 
-			// Separator
-			ctx.PrintStyled(x, y, " ", lineNumStyle)
-			x++
+	// Draw line numbers if enabled
+	x := 0
+	if d.renderer.ShowLineNums {
+		lineNumStyle := theme.LineNumStyle
+		if rendered.BgColor != nil {
+			lineNumStyle = lineNumStyle.WithBgRGB(*rendered.BgColor)
+		}
+		var nums []string
+		if oldNum {
+			nums = append(nums, rendered.LineNumOld)
+		}
+		if newNum {
+			nums = append(nums, rendered.LineNumNew)
+		}
+		text := strings.Join(nums, " ")
+		if oldNum && newNum {
+			text += " │ "
+		} else {
+			text += " "
+		}
+		ctx.PrintStyled(x, 0, text, lineNumStyle)
+		x += runewidth.StringWidth(text)
+	}
 
-			// New line number
-			ctx.PrintStyled(x, y, line.LineNumNew, lineNumStyle)
-			x += runewidth.StringWidth(line.LineNumNew)
+	// Draw content segments
+	for _, seg := range rendered.Segments {
+		if x >= width {
+			break
+		}
 
-			// Separator
-			ctx.PrintStyled(x, y, " │ ", lineNumStyle)
-			x += 3
+		// Apply the line's background unless the segment has its own
+		style := seg.Style
+		if rendered.BgColor != nil && style.BgRGB == nil {
+			style = style.WithBgRGB(*rendered.BgColor)
 		}
 
-		// Draw content segments
-		for _, seg := range line.Segments {
-			if x >= width {
-				break
-			}
+		ctx.PrintStyled(x, 0, seg.Text, style)
+		x += runewidth.StringWidth(seg.Text)
+	}
+}
 
-			// Apply background color if specified
+// wordBg returns the background for changed words: the theme's color, or a
+// brightened line background when the theme leaves it unset.
+func wordBg(word, line RGB) RGB {
+	if word != (RGB{}) {
+		return word
+	}
+	brighten := func(c uint8) uint8 { return uint8(min(255, int(c)*2+32)) }
+	return RGB{R: brighten(line.R), G: brighten(line.G), B: brighten(line.B)}
+}
+
+// emphasizeSegments splits segments at the edges of spans, byte ranges of
+// their concatenated text, and gives the text inside spans the background bg.
+func emphasizeSegments(segments []StyledSegment, spans []unidiff.Span, bg RGB) []StyledSegment {
+	if len(spans) == 0 {
+		return segments
+	}
+	var result []StyledSegment
+	pos := 0
+	for _, seg := range segments {
+		start, end := pos, pos+len(seg.Text)
+		for cur := start; cur < end; {
+			next, inside := end, false
+			for _, s := range spans {
+				if s.Start <= cur && cur < s.End {
+					next, inside = min(end, s.End), true
+					break
+				}
+				if s.Start > cur {
+					next = min(end, s.Start)
+					break
+				}
+			}
 			style := seg.Style
-			if line.BgColor != nil {
-				style = style.WithBgRGB(*line.BgColor)
+			if inside {
+				style = style.WithBgRGB(bg)
 			}
-
-			ctx.PrintStyled(x, y, seg.Text, style)
-			x += runewidth.StringWidth(seg.Text)
+			result = append(result, StyledSegment{Text: seg.Text[cur-pos : next-pos], Style: style})
+			cur = next
 		}
-
-		y++
+		pos = end
 	}
+	return result
 }
 
 // GetLineCount returns the total number of rendered lines.
 func (d *diffView) GetLineCount() int {
-	d.renderContent()
-	return len(d.rendered)
+	return len(d.layout())
+}
+
+// DiffState holds the interactive state of a DiffView: the layout mode,
+// folded hunks, and scroll position. Keep one per diff in the application
+// and route keys to HandleKey.
+//
+// Example:
+//
+//	app.diffState = tui.NewDiffState()
+//
+//	// In HandleEvent:
+//	if e, ok := event.(tui.KeyEvent); ok && app.diffState.HandleKey(e) {
+//	    return nil
+//	}
+//
+//	// In View:
+//	tui.DiffView(app.diff, "go", nil).State(app.diffState)
+type DiffState struct {
+	// SideBySide shows the old and new versions in two columns.
+	SideBySide bool
+
+	foldAll bool
+	folds   map[diffHunkRef]bool // overrides foldAll per hunk
+	anchor  *diffHunkRef         // hunk to scroll to the top at the next render
+	scrollY int
+	height  int
+	rows    []diffRow // layout at the last render
+}
+
+// NewDiffState creates the state for an interactive DiffView.
+func NewDiffState() *DiffState {
+	return &DiffState{folds: make(map[diffHunkRef]bool)}
+}
+
+// Folded reports whether a hunk is folded to its header.
+func (s *DiffState) Folded(file, hunk int) bool {
+	if folded, ok := s.folds[diffHunkRef{file, hunk}]; ok {
+		return folded
+	}
+	return s.foldAll
+}
+
+// SetFolded folds or unfolds a hunk.
+func (s *DiffState) SetFolded(file, hunk int, folded bool) {
+	if s.folds == nil {
+		s.folds = make(map[diffHunkRef]bool)
+	}
+	s.folds[diffHunkRef{file, hunk}] = folded
+}
+
+// FoldAll folds every hunk, keeping the current hunk at the top.
+func (s *DiffState) FoldAll() {
+	s.setAllFolded(true)
+}
+
+// UnfoldAll unfolds every hunk, keeping the current hunk at the top.
+func (s *DiffState) UnfoldAll() {
+	s.setAllFolded(false)
+}
+
+func (s *DiffState) setAllFolded(folded bool) {
+	s.keepCurrentHunk()
+	s.foldAll = folded
+	s.folds = make(map[diffHunkRef]bool)
+}
+
+// ToggleFold folds or unfolds the current hunk and reports whether there
+// was one.
+func (s *DiffState) ToggleFold() bool {
+	file, hunk := s.CurrentHunk()
+	if hunk < 0 {
+		return false
+	}
+	s.SetFolded(file, hunk, !s.Folded(file, hunk))
+	s.keepCurrentHunk()
+	return true
+}
+
+// ToggleSideBySide switches between unified and side-by-side layouts,
+// keeping the current hunk at the top.
+func (s *DiffState) ToggleSideBySide() {
+	s.keepCurrentHunk()
+	s.SideBySide = !s.SideBySide
+}
+
+// keepCurrentHunk scrolls the current hunk to the top at the next render,
+// after the layout changes.
+func (s *DiffState) keepCurrentHunk() {
+	if file, hunk := s.CurrentHunk(); hunk >= 0 {
+		s.anchor = &diffHunkRef{file, hunk}
+	}
+}
+
+// CurrentHunk returns the file and hunk at the top of the view as of the
+// last render. hunk is -1 if there is none, such as before the first render.
+func (s *DiffState) CurrentHunk() (file, hunk int) {
+	if len(s.rows) == 0 {
+		return -1, -1
+	}
+	top := s.rows[min(s.scrollY, len(s.rows)-1)]
+	if top.hunk >= 0 {
+		return top.file, top.hunk
+	}
+	// A file header belongs with the file's first hunk
+	for _, row := range s.rows {
+		if row.file == top.file && row.hunk >= 0 {
+			return row.file, row.hunk
+		}
+	}
+	return top.file, -1
+}
+
+// ScrollY returns the scroll offset as of the last render.
+func (s *DiffState) ScrollY() int {
+	return s.scrollY
+}
+
+// NextHunk scrolls the next hunk's header to the top and reports whether
+// there was one.
+func (s *DiffState) NextHunk() bool {
+	return s.seek(1, diffRowHunk)
+}
+
+// PrevHunk scrolls the previous hunk's header to the top and reports whether
+// there was one.
+func (s *DiffState) PrevHunk() bool {
+	return s.seek(-1, diffRowHunk)
+}
+
+// NextFile scrolls the next file's header to the top and reports whether
+// there was one.
+func (s *DiffState) NextFile() bool {
+	return s.seek(1, diffRowOldPath)
+}
+
+// PrevFile scrolls the previous file's header to the top and reports whether
+// there was one.
+func (s *DiffState) PrevFile() bool {
+	return s.seek(-1, diffRowOldPath)
+}
+
+// seek scrolls to the nearest row of the given kind before or after the top.
+func (s *DiffState) seek(dir int, kind diffRowKind) bool {
+	for i := s.scrollY + dir; i >= 0 && i < len(s.rows); i += dir {
+		if s.rows[i].kind == kind {
+			s.scrollY = i
+			return true
+		}
+	}
+	return false
+}
+
+// HandleKey handles a key for the view and reports whether it used it:
+//
+//   - Up/Down or k/j: Scroll by a line
+//   - PgUp/PgDn: Scroll by a page
+//   - Home/End or g/G: Go to the top or bottom
+//   - n/N: Next/previous hunk
+//   - ]/[: Next/previous file
+//   - Enter or z: Fold or unfold the current hunk
+//   - Z: Fold or unfold all hunks
+//   - s: Switch between unified and side-by-side layouts
+func (s *DiffState) HandleKey(event KeyEvent) bool {
+	page := max(1, s.height-1)
+	switch {
+	case event.Key == KeyArrowUp || event.Rune == 'k':
+		s.scrollY = max(0, s.scrollY-1)
+	case event.Key == KeyArrowDown || event.Rune == 'j':
+		s.scrollY++
+	case event.Key == KeyPageUp:
+		s.scrollY = max(0, s.scrollY-page)
+	case event.Key == KeyPageDown:
+		s.scrollY += page
+	case event.Key == KeyHome || event.Rune == 'g':
+		s.scrollY = 0
+	case event.Key == KeyEnd || event.Rune == 'G':
+		s.scrollY = max(0, len(s.rows)-s.height)
+	case event.Rune == 'n':
+		return s.NextHunk()
+	case event.Rune == 'N':
+		return s.PrevHunk()
+	case event.Rune == ']':
+		return s.NextFile()
+	case event.Rune == '[':
+		return s.PrevFile()
+	case event.Key == KeyEnter || event.Rune == 'z':
+		return s.ToggleFold()
+	case event.Rune == 'Z':
+		if s.foldAll {
+			s.UnfoldAll()
+		} else {
+			s.FoldAll()
+		}
+	case event.Rune == 's':
+		s.ToggleSideBySide()
+	default:
+		return false
+	}
+	return true
 }
@@ -192,11 +192,3 @@ func (h *headerBarView) render(ctx *RenderContext) {
 	// Draw centered text
 	ctx.PrintStyled(startX, 0, h.text, h.style)
 }
-
-// StatusBar creates a full-width status bar (same as HeaderBar but defaults to bottom style).
-func StatusBar(text string) *headerBarView {
-	return &headerBarView{
-		text:  text,
-		style: NewStyle().WithBackground(ColorBrightBlack).WithForeground(ColorWhite),
-	}
-}
@@ -360,5 +360,5 @@ func TestStatusBar_NotFlexible(t *testing.T) {
 	// Status bars should NOT implement Flexible - they have fixed height (1 row)
 	// and fill width via size(), not flex distribution.
 	_, ok := interface{}(s).(Flexible)
-	assert.False(t, ok, "statusBar (headerBarView) should not implement Flexible")
+	assert.False(t, ok, "statusBarView should not implement Flexible")
 }
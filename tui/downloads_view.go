@@ -0,0 +1,261 @@
+package tui
+
+import (
+	"fmt"
+	"image"
+	"path/filepath"
+	"strings"
+
+	"github.com/deepnoodle-ai/wonton/downloads"
+	"github.com/deepnoodle-ai/wonton/humanize"
+	"github.com/mattn/go-runewidth"
+)
+
+// downloadsView lists downloads with a progress bar, size, rate, and state.
+type downloadsView struct {
+	items         []downloads.Download
+	selected      *int
+	scrollY       *int
+	height        int
+	barWidth      int
+	style         Style
+	selectedStyle Style
+	barStyle      Style
+	emptyStyle    Style
+	errorStyle    Style
+	doneStyle     Style
+	emptyText     string
+}
+
+// DownloadsView creates a list of downloads, one per line, showing each
+// file's name, progress bar, percentage, transferred size, rate, remaining
+// time, and state. Pass snapshots from downloads.Manager.List; re-render
+// periodically (for example on TickEvent) to animate progress.
+//
+// selected is optional. When set, the selected row is highlighted and
+// clicking a row selects it; the application handles keys such as p (pause),
+// r (resume), and x (cancel) by calling the manager with the selected ID.
+//
+// Example:
+//
+//	tui.DownloadsView(app.manager.List(), &app.selected).Height(10)
+func DownloadsView(items []downloads.Download, selected *int) *downloadsView {
+	return &downloadsView{
+		items:         items,
+		selected:      selected,
+		barWidth:      20,
+		style:         NewStyle(),
+		selectedStyle: NewStyle().WithReverse(),
+		barStyle:      NewStyle().WithForeground(ColorCyan),
+		emptyStyle:    NewStyle().WithForeground(ColorBrightBlack),
+		errorStyle:    NewStyle().WithForeground(ColorRed),
+		doneStyle:     NewStyle().WithForeground(ColorGreen),
+		emptyText:     "No downloads",
+	}
+}
+
+// Height limits the number of visible rows. The list scrolls to keep the
+// selected row visible. Zero shows all rows.
+func (v *downloadsView) Height(h int) *downloadsView {
+	v.height = h
+	return v
+}
+
+// ScrollY binds the scroll offset so it persists between frames.
+func (v *downloadsView) ScrollY(scrollY *int) *downloadsView {
+	v.scrollY = scrollY
+	return v
+}
+
+// BarWidth sets the width of each progress bar.
+func (v *downloadsView) BarWidth(w int) *downloadsView {
+	v.barWidth = w
+	return v
+}
+
+// Style sets the base text style.
+func (v *downloadsView) Style(s Style) *downloadsView {
+	v.style = s
+	return v
+}
+
+// SelectedStyle sets the style of the selected row.
+func (v *downloadsView) SelectedStyle(s Style) *downloadsView {
+	v.selectedStyle = s
+	return v
+}
+
+// BarStyle sets the style of the filled portion of progress bars.
+func (v *downloadsView) BarStyle(s Style) *downloadsView {
+	v.barStyle = s
+	return v
+}
+
+// EmptyText sets the text shown when there are no downloads.
+func (v *downloadsView) EmptyText(text string) *downloadsView {
+	v.emptyText = text
+	return v
+}
+
+func (v *downloadsView) visibleRows(maxHeight int) int {
+	rows := len(v.items)
+	if rows == 0 {
+		return 1
+	}
+	if v.height > 0 && rows > v.height {
+		rows = v.height
+	}
+	if maxHeight > 0 && rows > maxHeight {
+		rows = maxHeight
+	}
+	return rows
+}
+
+func (v *downloadsView) size(maxWidth, maxHeight int) (int, int) {
+	w := maxWidth
+	if w <= 0 {
+		w = 80
+	}
+	return w, v.visibleRows(maxHeight)
+}
+
+// downloadDetails returns the text after the bar: percent, size, rate,
+// remaining time, and state (or error).
+func downloadDetails(d downloads.Download) string {
+	var parts []string
+	if d.Total > 0 {
+		parts = append(parts, fmt.Sprintf("%3.0f%%", d.Progress()*100))
+		parts = append(parts, humanize.Bytes(d.Downloaded)+"/"+humanize.Bytes(d.Total))
+	} else {
+		parts = append(parts, humanize.Bytes(d.Downloaded))
+	}
+	if d.State == downloads.Active {
+		if d.BytesPerSecond > 0 {
+			parts = append(parts, humanize.Bytes(int64(d.BytesPerSecond))+"/s")
+		}
+		if eta := d.Remaining(); eta > 0 {
+			parts = append(parts, humanize.DurationShort(eta))
+		}
+	}
+	if d.State == downloads.Failed && d.Err != nil {
+		parts = append(parts, "failed: "+d.Err.Error())
+	} else {
+		parts = append(parts, d.State.String())
+	}
+	return strings.Join(parts, "  ")
+}
+
+func (v *downloadsView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	if len(v.items) == 0 {
+		ctx.PrintTruncated(0, 0, v.emptyText, v.emptyStyle)
+		return
+	}
+
+	selected := -1
+	if v.selected != nil {
+		if *v.selected >= len(v.items) {
+			*v.selected = len(v.items) - 1
+		}
+		if *v.selected < 0 {
+			*v.selected = 0
+		}
+		selected = *v.selected
+	}
+
+	// Scroll to keep the selected row visible
+	rows := v.visibleRows(height)
+	scroll := 0
+	if v.scrollY != nil {
+		scroll = *v.scrollY
+	}
+	if selected >= 0 {
+		if selected < scroll {
+			scroll = selected
+		}
+		if selected >= scroll+rows {
+			scroll = selected - rows + 1
+		}
+	}
+	if scroll > len(v.items)-rows {
+		scroll = len(v.items) - rows
+	}
+	if scroll < 0 {
+		scroll = 0
+	}
+	if v.scrollY != nil {
+		*v.scrollY = scroll
+	}
+
+	// Name column gets a third of the width, at least 12 cells
+	nameWidth := width / 3
+	if nameWidth < 12 {
+		nameWidth = 12
+	}
+	barWidth := v.barWidth
+	if nameWidth+1+barWidth+2 > width {
+		barWidth = width - nameWidth - 3
+	}
+
+	bounds := ctx.AbsoluteBounds()
+	for row := 0; row < rows; row++ {
+		idx := scroll + row
+		d := v.items[idx]
+		isSelected := idx == selected
+
+		base := v.style
+		if isSelected {
+			base = v.selectedStyle
+			ctx.FillStyled(0, row, width, 1, ' ', base)
+		}
+
+		x := 0
+		name := filepath.Base(d.Path)
+		if name == "." || name == "" {
+			name = d.URL
+		}
+		ctx.PrintTruncated(x, row, runewidth.Truncate(name, nameWidth, "…"), base)
+		x += nameWidth + 1
+
+		if barWidth > 0 {
+			filled := int(d.Progress() * float64(barWidth))
+			barStyle := v.barStyle
+			switch d.State {
+			case downloads.Completed:
+				barStyle = v.doneStyle
+			case downloads.Failed:
+				barStyle = v.errorStyle
+			}
+			if isSelected {
+				barStyle = barStyle.WithReverse()
+			}
+			ctx.PrintStyled(x, row, strings.Repeat("█", filled), barStyle)
+			ctx.PrintStyled(x+filled, row, strings.Repeat("░", barWidth-filled), v.emptyStyle)
+			x += barWidth + 2
+		}
+
+		detailStyle := base
+		if d.State == downloads.Failed && !isSelected {
+			detailStyle = v.errorStyle
+		}
+		if x < width {
+			ctx.PrintTruncated(x, row, downloadDetails(d), detailStyle)
+		}
+
+		if v.selected != nil {
+			i := idx // capture for closure
+			sel := v.selected
+			interactiveRegistry.RegisterButton(image.Rect(
+				bounds.Min.X,
+				bounds.Min.Y+row,
+				bounds.Min.X+width,
+				bounds.Min.Y+row+1,
+			), func() {
+				*sel = i
+			})
+		}
+	}
+}
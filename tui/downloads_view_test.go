@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/downloads"
+)
+
+func TestDownloadsView_Empty(t *testing.T) {
+	screen := SprintScreen(DownloadsView(nil, nil), PrintConfig{Width: 40})
+	assert.Contains(t, screen.Row(0), "No downloads")
+}
+
+func TestDownloadsView_Rows(t *testing.T) {
+	items := []downloads.Download{
+		{ID: 1, Path: "/tmp/report.pdf", State: downloads.Active, Downloaded: 512, Total: 1024, BytesPerSecond: 256},
+		{ID: 2, Path: "/tmp/archive.zip", State: downloads.Completed, Downloaded: 2048, Total: 2048},
+		{ID: 3, Path: "/tmp/broken.iso", State: downloads.Failed, Total: -1, Err: errors.New("boom")},
+	}
+	screen := SprintScreen(DownloadsView(items, nil).BarWidth(10), PrintConfig{Width: 100})
+
+	assert.Contains(t, screen.Row(0), "report.pdf")
+	assert.Contains(t, screen.Row(0), "█████░░░░░")
+	assert.Contains(t, screen.Row(0), "50%")
+	assert.Contains(t, screen.Row(0), "/s")
+	assert.Contains(t, screen.Row(0), "active")
+
+	assert.Contains(t, screen.Row(1), "██████████")
+	assert.Contains(t, screen.Row(1), "100%")
+	assert.Contains(t, screen.Row(1), "completed")
+
+	assert.Contains(t, screen.Row(2), "failed: boom")
+}
+
+func TestDownloadsView_HeightScrollsToSelection(t *testing.T) {
+	var items []downloads.Download
+	for i, name := range []string{"a.bin", "b.bin", "c.bin", "d.bin"} {
+		items = append(items, downloads.Download{ID: i + 1, Path: name, State: downloads.Queued, Total: -1})
+	}
+	selected := 3
+	scroll := 0
+	view := DownloadsView(items, &selected).ScrollY(&scroll).Height(2)
+
+	w, h := view.size(60, 10)
+	assert.Equal(t, 60, w)
+	assert.Equal(t, 2, h)
+
+	screen := SprintScreen(view, PrintConfig{Width: 60})
+	assert.Contains(t, screen.Row(0), "c.bin")
+	assert.Contains(t, screen.Row(1), "d.bin")
+	assert.Equal(t, 2, scroll)
+}
+
+func TestDownloadsView_ClampsSelection(t *testing.T) {
+	items := []downloads.Download{{ID: 1, Path: "only.bin", Total: -1}}
+	selected := 5
+	SprintScreen(DownloadsView(items, &selected), PrintConfig{Width: 40})
+	assert.Equal(t, 0, selected)
+}
@@ -0,0 +1,171 @@
+package tui
+
+// EnvKey identifies a value in the environment: values a view sets for its
+// subtree with Env, which every view beneath it can read without each
+// helper function in between passing them along. The key carries the type
+// of its values and the value views get where no parent sets one.
+//
+// Example:
+//
+//	var DensityKey = tui.NewEnvKey("density", 1)
+//
+//	tui.Env(DensityKey, 2, sidebar())
+//
+//	// Deep inside sidebar():
+//	tui.CanvasContext(func(ctx *tui.RenderContext) {
+//	    density := tui.EnvValue(ctx, DensityKey)
+//	    ...
+//	})
+type EnvKey[T any] struct {
+	name string
+	def  T
+}
+
+// NewEnvKey creates an environment key named name, for debugging, whose
+// value is def where no parent sets it. Each call makes a distinct key, so
+// create keys once, in package variables.
+func NewEnvKey[T any](name string, def T) *EnvKey[T] {
+	return &EnvKey[T]{name: name, def: def}
+}
+
+// String returns the key's name.
+func (k *EnvKey[T]) String() string {
+	return k.name
+}
+
+// Default returns the value views get where no parent sets one.
+func (k *EnvKey[T]) Default() T {
+	return k.def
+}
+
+// envNode is a value set in the environment. Nodes form a list from the
+// innermost value out, shared by every context beneath the view that set
+// it, so setting a value never copies the values around it.
+type envNode struct {
+	key    any
+	value  any
+	parent *envNode
+}
+
+// StyleKey is the environment key of the default style of text beneath a
+// view. Text views take its foreground and background where they set none
+// of their own, and its attributes, such as bold, in addition to their own.
+// Use DefaultForeground and DefaultBackground to set just the colors.
+var StyleKey = NewEnvKey("style", NewStyle())
+
+// EnvValue returns the value of key set by the nearest view above, or the
+// key's default.
+func EnvValue[T any](ctx *RenderContext, key *EnvKey[T]) T {
+	if value, ok := lookupEnv(ctx, key); ok {
+		return value
+	}
+	return key.def
+}
+
+// lookupEnv returns the value of key set by the nearest view above, and
+// false if none sets it.
+func lookupEnv[T any](ctx *RenderContext, key *EnvKey[T]) (T, bool) {
+	for n := ctx.env; n != nil; n = n.parent {
+		if n.key == any(key) {
+			return n.value.(T), true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// WithEnv returns a new context in which key has value, for views that
+// draw their children themselves, such as in a CanvasContext.
+func WithEnv[T any](ctx *RenderContext, key *EnvKey[T], value T) *RenderContext {
+	sub := *ctx
+	sub.env = &envNode{key: key, value: value, parent: ctx.env}
+	return &sub
+}
+
+// envView draws its inner view with a value set in the environment.
+type envView struct {
+	with  func(ctx *RenderContext) *RenderContext
+	inner View
+}
+
+// Env draws inner with key set to value. Views beneath it read the value
+// with EnvValue, unless a view closer to them sets it again.
+//
+// Example:
+//
+//	tui.Env(tui.ThemeKey, tui.LightTheme, preview)
+func Env[T any](key *EnvKey[T], value T, inner View) View {
+	return &envView{
+		with:  func(ctx *RenderContext) *RenderContext { return WithEnv(ctx, key, value) },
+		inner: inner,
+	}
+}
+
+// DefaultForeground draws inner with c as the default foreground of its text,
+// keeping the rest of the inherited style.
+//
+// Example:
+//
+//	tui.DefaultForeground(tui.ColorBrightBlack, tui.Stack(
+//	    tui.Text("Last saved 2m ago"),
+//	    tui.Text("3 warnings").Fg(tui.ColorYellow), // Its own color wins
+//	))
+func DefaultForeground(c Color, inner View) View {
+	return &envView{
+		with: func(ctx *RenderContext) *RenderContext {
+			style := EnvValue(ctx, StyleKey)
+			style.Foreground, style.FgRGB = c, nil
+			return WithEnv(ctx, StyleKey, style)
+		},
+		inner: inner,
+	}
+}
+
+// DefaultBackground draws inner with c as the default background of its text,
+// keeping the rest of the inherited style.
+func DefaultBackground(c Color, inner View) View {
+	return &envView{
+		with: func(ctx *RenderContext) *RenderContext {
+			style := EnvValue(ctx, StyleKey)
+			style.Background, style.BgRGB = c, nil
+			return WithEnv(ctx, StyleKey, style)
+		},
+		inner: inner,
+	}
+}
+
+func (v *envView) size(maxWidth, maxHeight int) (int, int) {
+	return v.inner.size(maxWidth, maxHeight)
+}
+
+func (v *envView) flex() int {
+	if flex, ok := v.inner.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (v *envView) render(ctx *RenderContext) {
+	v.inner.render(v.with(ctx))
+}
+
+// InheritStyle returns style with the colors it leaves unset taken from the
+// environment's StyleKey, and the environment's attributes added.
+func (c *RenderContext) InheritStyle(style Style) Style {
+	env, ok := lookupEnv(c, StyleKey)
+	if !ok {
+		return style
+	}
+	if style.Foreground == ColorDefault && style.FgRGB == nil {
+		style.Foreground, style.FgRGB = env.Foreground, env.FgRGB
+	}
+	if style.Background == ColorDefault && style.BgRGB == nil {
+		style.Background, style.BgRGB = env.Background, env.BgRGB
+	}
+	style.Bold = style.Bold || env.Bold
+	style.Italic = style.Italic || env.Italic
+	style.Underline = style.Underline || env.Underline
+	style.Strikethrough = style.Strikethrough || env.Strikethrough
+	style.Dim = style.Dim || env.Dim
+	return style
+}
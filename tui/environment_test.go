@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+var testDensityKey = NewEnvKey("density", 1)
+
+func TestEnv_Values(t *testing.T) {
+	var seen []int
+	probe := CanvasContext(func(ctx *RenderContext) {
+		seen = append(seen, EnvValue(ctx, testDensityKey))
+	})
+	view := Stack(
+		probe,
+		Env(testDensityKey, 2, Stack(
+			probe,
+			Bordered(probe),
+			Env(testDensityKey, 3, probe),
+		)),
+	)
+	SprintScreen(view, PrintConfig{Width: 10, Height: 10})
+	assert.Equal(t, []int{1, 2, 2, 3}, seen)
+	assert.Equal(t, "density", testDensityKey.String())
+}
+
+func TestEnv_DefaultColors(t *testing.T) {
+	view := DefaultForeground(ColorRed, DefaultBackground(ColorBlue, Stack(
+		Text("a"),
+		Text("b").Fg(ColorGreen),
+		Env(StyleKey, NewStyle().WithBold(), Text("c")),
+	)))
+	terminal := NewTestTerminal(3, 3, &bytes.Buffer{})
+	runtime := NewRuntime(terminal, &simpleApp{
+		renderFunc: func() View { return view },
+		handleFunc: func(Event) []Cmd { return nil },
+	}, 30)
+	runtime.render()
+
+	a := terminal.GetCell(0, 0).Style
+	assert.Equal(t, ColorRed, a.Foreground)
+	assert.Equal(t, ColorBlue, a.Background)
+	b := terminal.GetCell(0, 1).Style
+	assert.Equal(t, ColorGreen, b.Foreground)
+	assert.Equal(t, ColorBlue, b.Background)
+	c := terminal.GetCell(0, 2).Style
+	assert.True(t, c.Bold)
+	assert.Equal(t, ColorDefault, c.Foreground)
+}
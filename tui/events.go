@@ -12,6 +12,7 @@ import "time"
 //   - KeyEvent: Keyboard input
 //   - MouseEvent: Mouse clicks, movement, scrolling
 //   - TickEvent: Periodic timer for animations
+//   - PasteEvent: Text pasted into the terminal
 //   - ResizeEvent: Terminal size changed
 //   - QuitEvent: Application shutdown request
 //   - ErrorEvent: Error from async command
@@ -34,7 +35,11 @@ type Event interface {
 // Use this for animations and periodic updates.
 //
 // The Frame counter increments with each tick, starting from 1. This is
-// useful for frame-based animations that need consistent timing.
+// useful for frame-based animations that need consistent timing. Frames are
+// numbered by the time since the first tick, so when handling events or
+// drawing takes longer than a frame, the ticks that were missed are skipped:
+// Frame jumps ahead by Skipped+1 and animations keep to time rather than
+// slowing down. Delta is the time since the previous tick.
 //
 // Example:
 //
@@ -45,8 +50,10 @@ type Event interface {
 //	    return nil
 //	}
 type TickEvent struct {
-	Time  time.Time
-	Frame uint64 // Frame counter, increments with each tick
+	Time    time.Time
+	Frame   uint64        // Frame counter, increments with each tick
+	Delta   time.Duration // Time since the previous tick
+	Skipped int           // Frames skipped since the previous tick
 }
 
 func (e TickEvent) Timestamp() time.Time {
@@ -76,6 +83,33 @@ func (e ResizeEvent) Timestamp() time.Time {
 	return e.Time
 }
 
+// PasteEvent is emitted when text is pasted into the terminal, with the whole
+// paste in one event rather than a KeyEvent per character. Line endings are
+// normalized to "\n", and tabs are expanded if WithPasteTabWidth is set.
+// Pastes are only recognized with bracketed paste mode on, which Run enables
+// by default (see WithBracketedPaste).
+//
+// A focused input inserts the paste itself before the application sees it.
+// The paste is also delivered just before this event as a KeyEvent with
+// Paste set; handle one or the other.
+//
+// Example:
+//
+//	func (a *App) HandleEvent(event Event) []Cmd {
+//	    if paste, ok := event.(PasteEvent); ok {
+//	        a.editor.Insert(paste.Text)
+//	    }
+//	    return nil
+//	}
+type PasteEvent struct {
+	Time time.Time
+	Text string // The pasted text
+}
+
+func (e PasteEvent) Timestamp() time.Time {
+	return e.Time
+}
+
 // QuitEvent signals that the application should shut down.
 // The Runtime will exit the event loop, call Destroy() if implemented,
 // and return from Run().
@@ -95,3 +95,29 @@ type bufferEvent struct {
 func (e bufferEvent) Timestamp() time.Time {
 	return time.Now()
 }
+
+func TestRuntime_PasteEvent(t *testing.T) {
+	var value string
+	var events []Event
+	terminal := NewTestTerminal(30, 3, &bytes.Buffer{})
+	app := &simpleApp{
+		renderFunc: func() View { return InputField(&value).ID("name") },
+		handleFunc: func(e Event) []Cmd {
+			events = append(events, e)
+			return nil
+		},
+	}
+	runtime := NewRuntime(terminal, app, 30)
+	runtime.render()
+	runtime.processEvent(FocusSetEvent{ID: "name"})
+
+	at := time.Now()
+	runtime.processEvent(KeyEvent{Paste: "hello\nworld", Time: at})
+	assert.Equal(t, "hello world", value)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, "hello\nworld", events[0].(KeyEvent).Paste)
+	assert.Equal(t, PasteEvent{Time: at, Text: "hello\nworld"}, events[1])
+
+	runtime.render()
+	assert.Equal(t, "hello world", screenRow(terminal, 0))
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// FallbackMode controls when Run prints snapshots of the screen as plain
+// text instead of drawing on the terminal. See WithFallbackRenderer.
+type FallbackMode int
+
+const (
+	// FallbackNever always draws on the terminal. This is the default.
+	FallbackNever FallbackMode = iota
+	// FallbackAuto prints snapshots when TERM is "dumb" or stdout isn't a
+	// terminal, as when output is piped or in CI.
+	FallbackAuto
+	// FallbackAlways prints snapshots even on a capable terminal.
+	FallbackAlways
+)
+
+// active reports whether snapshots should be printed instead of drawing.
+func (m FallbackMode) active() bool {
+	switch m {
+	case FallbackAlways:
+		return true
+	case FallbackAuto:
+		return os.Getenv("TERM") == "dumb" || !term.IsTerminal(int(os.Stdout.Fd()))
+	}
+	return false
+}
+
+// fallbackSize returns the size to lay out the screen at in fallback mode:
+// COLUMNS and LINES if they are set, as shells do, and 80x24 otherwise.
+func fallbackSize() (int, int) {
+	size := func(name string, def int) int {
+		if n, err := strconv.Atoi(os.Getenv(name)); err == nil && n > 0 {
+			return n
+		}
+		return def
+	}
+	return size("COLUMNS", 80), size("LINES", 24)
+}
+
+// snapshotPrinter writes the text on the screen each time it changes, at
+// most once an interval, for the fallback renderer. Lines are written whole,
+// without cursor movement or styles, and snapshots are separated by "---".
+type snapshotPrinter struct {
+	out      io.Writer
+	interval time.Duration
+
+	printed   string // the screen last written
+	pending   string // the latest screen, if not yet written
+	lastPrint time.Time
+	count     int
+
+	now func() time.Time // For tests
+}
+
+// newSnapshotPrinter returns a snapshotPrinter that writes to out.
+func newSnapshotPrinter(out io.Writer, interval time.Duration) *snapshotPrinter {
+	return &snapshotPrinter{out: out, interval: interval, now: time.Now}
+}
+
+// update records the screen after a frame, and writes it if it has changed
+// and the interval has passed since the last snapshot.
+func (s *snapshotPrinter) update(screen string) {
+	if s == nil {
+		return
+	}
+	screen = strings.TrimRight(screen, " \n")
+	if screen == s.printed {
+		s.pending = ""
+		return
+	}
+	s.pending = screen
+	if now := s.now(); s.count == 0 || now.Sub(s.lastPrint) >= s.interval {
+		s.write(now)
+	}
+}
+
+// flush writes the latest screen if it hasn't been written, so the output
+// ends with the application's final state.
+func (s *snapshotPrinter) flush() {
+	if s != nil && s.pending != "" {
+		s.write(s.now())
+	}
+}
+
+func (s *snapshotPrinter) write(now time.Time) {
+	if s.count > 0 {
+		fmt.Fprintln(s.out, "---")
+	}
+	fmt.Fprintln(s.out, s.pending)
+	s.printed, s.pending = s.pending, ""
+	s.lastPrint = now
+	s.count++
+}
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+type fallbackTestApp struct {
+	status string
+}
+
+func (a *fallbackTestApp) View() View {
+	return Stack(Text("Deploy").Bold().Fg(ColorGreen), Text("status: %s", a.status))
+}
+
+func TestFallbackRenderer_PrintsChangedScreens(t *testing.T) {
+	var out bytes.Buffer
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	app := &fallbackTestApp{status: "starting"}
+	runtime := NewRuntime(NewTestTerminal(20, 5, &bytes.Buffer{}), app, 30)
+	runtime.snapshots = newSnapshotPrinter(&out, time.Second)
+	runtime.snapshots.now = func() time.Time { return clock }
+
+	runtime.render()
+	runtime.render() // Unchanged
+
+	clock = clock.Add(100 * time.Millisecond)
+	app.status = "building"
+	runtime.render() // Too soon; held back
+
+	clock = clock.Add(time.Second)
+	app.status = "pushing"
+	runtime.render()
+
+	clock = clock.Add(100 * time.Millisecond)
+	app.status = "done"
+	runtime.render()
+	runtime.snapshots.flush()
+	runtime.snapshots.flush()
+
+	assert.Equal(t, strings.Join([]string{
+		"Deploy",
+		"status: starting",
+		"---",
+		"Deploy",
+		"status: pushing",
+		"---",
+		"Deploy",
+		"status: done",
+		"",
+	}, "\n"), out.String())
+	assert.False(t, strings.Contains(out.String(), "\x1b"))
+}
+
+func TestFallbackMode(t *testing.T) {
+	assert.False(t, FallbackNever.active())
+	assert.True(t, FallbackAlways.active())
+
+	t.Setenv("TERM", "dumb")
+	assert.True(t, FallbackAuto.active())
+
+	t.Setenv("COLUMNS", "120")
+	t.Setenv("LINES", "")
+	width, height := fallbackSize()
+	assert.Equal(t, 120, width)
+	assert.Equal(t, 24, height)
+}
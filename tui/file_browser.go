@@ -0,0 +1,202 @@
+package tui
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/deepnoodle-ai/wonton/archive"
+)
+
+// FileEntry is the Value of each ListItem listed by a FileBrowser.
+type FileEntry struct {
+	Path      string // Display path; inside an archive, the archive path followed by the inner path
+	Name      string
+	IsDir     bool
+	Size      int64
+	InArchive bool // The entry is inside an archive and must be extracted to be opened
+	IsArchive bool // The entry is an archive the browser can descend into
+
+	inner string // path inside the archive
+}
+
+// FileBrowser tracks the location shown by a FilePicker and lists it,
+// descending into zip and tar archives as if they were directories.
+// Archives are read-only; use Extract to get a file out of one, for example
+// to preview it.
+//
+// Example:
+//
+//	browser := tui.NewFileBrowser(dir)
+//	defer browser.Close()
+//	app.files, _ = browser.Items()
+//
+//	tui.FilePicker(app.files, &app.filter, &app.selected).
+//	    CurrentPath(browser.Path()).
+//	    OnSelect(func(item tui.ListItem, _ int) {
+//	        entry, opened, err := browser.Select(item)
+//	        if opened {
+//	            app.files, err = browser.Items()
+//	        } else if err == nil {
+//	            app.preview(browser.Extract(entry))
+//	        }
+//	    })
+type FileBrowser struct {
+	// ShowHidden lists entries whose names start with a dot.
+	ShowHidden bool
+
+	dir         string      // directory on disk, or the directory holding the archive
+	archive     *archive.FS // open archive, if inside one
+	archivePath string      // path of the open archive
+	inner       string      // directory inside the archive
+	tempDirs    []string    // directories created by Extract
+}
+
+// NewFileBrowser creates a browser showing dir.
+func NewFileBrowser(dir string) *FileBrowser {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	return &FileBrowser{dir: dir}
+}
+
+// Path returns the location being shown.
+func (b *FileBrowser) Path() string {
+	if b.archive == nil {
+		return b.dir
+	}
+	if b.inner == "." {
+		return b.archivePath
+	}
+	return filepath.Join(b.archivePath, filepath.FromSlash(b.inner))
+}
+
+// Items lists the current location for a FilePicker: ".." unless at the
+// root, then directories, archives, and files, each group sorted by name.
+// Each item's Value is a FileEntry.
+func (b *FileBrowser) Items() ([]ListItem, error) {
+	var entries []fs.DirEntry
+	var err error
+	if b.archive != nil {
+		entries, err = b.archive.ReadDir(b.inner)
+	} else {
+		entries, err = os.ReadDir(b.dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var items, archives, files []ListItem
+	if b.archive != nil || filepath.Dir(b.dir) != b.dir {
+		items = append(items, ListItem{Label: "..", Icon: "[DIR]", Value: FileEntry{Name: "..", IsDir: true}})
+	}
+	for _, e := range entries {
+		if !b.ShowHidden && strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		entry := b.entry(e)
+		switch {
+		case entry.IsDir:
+			items = append(items, ListItem{Label: "[DIR] " + entry.Name, Icon: "[DIR]", Value: entry})
+		case entry.IsArchive:
+			archives = append(archives, ListItem{Label: "[ARC] " + entry.Name, Icon: "[ARC]", Value: entry})
+		default:
+			files = append(files, ListItem{Label: entry.Name, Value: entry})
+		}
+	}
+	items = append(items, archives...)
+	return append(items, files...), nil
+}
+
+func (b *FileBrowser) entry(e fs.DirEntry) FileEntry {
+	entry := FileEntry{Name: e.Name(), IsDir: e.IsDir()}
+	if info, err := e.Info(); err == nil && !e.IsDir() {
+		entry.Size = info.Size()
+	}
+	if b.archive != nil {
+		entry.InArchive = true
+		entry.inner = path.Join(b.inner, e.Name())
+		entry.Path = filepath.Join(b.archivePath, filepath.FromSlash(entry.inner))
+	} else {
+		entry.Path = filepath.Join(b.dir, e.Name())
+		entry.IsArchive = !e.IsDir() && archive.IsArchive(e.Name())
+	}
+	return entry
+}
+
+// Select acts on an item from Items. Directories, archives, and ".." are
+// opened, and opened is true; call Items to list the new location. Other
+// entries are returned for the caller to handle.
+func (b *FileBrowser) Select(item ListItem) (entry FileEntry, opened bool, err error) {
+	entry, ok := item.Value.(FileEntry)
+	if !ok {
+		return entry, false, nil
+	}
+	switch {
+	case entry.Name == "..":
+		b.Up()
+		return entry, true, nil
+	case entry.IsArchive:
+		a, err := archive.Open(entry.Path)
+		if err != nil {
+			return entry, false, err
+		}
+		b.archive, b.archivePath, b.inner = a, entry.Path, "."
+		return entry, true, nil
+	case entry.IsDir && entry.InArchive:
+		b.inner = entry.inner
+		return entry, true, nil
+	case entry.IsDir:
+		b.dir = entry.Path
+		return entry, true, nil
+	}
+	return entry, false, nil
+}
+
+// Up goes to the parent of the current location, leaving an archive at its
+// root.
+func (b *FileBrowser) Up() {
+	switch {
+	case b.archive != nil && b.inner != ".":
+		b.inner = path.Dir(b.inner)
+	case b.archive != nil:
+		b.archive.Close()
+		b.archive, b.archivePath, b.inner = nil, "", ""
+	default:
+		b.dir = filepath.Dir(b.dir)
+	}
+}
+
+// Extract returns a path on disk for a file entry. Files inside an archive
+// are extracted to a temporary directory that Close removes; others are
+// returned as is.
+func (b *FileBrowser) Extract(entry FileEntry) (string, error) {
+	if !entry.InArchive {
+		return entry.Path, nil
+	}
+	if b.archive == nil || !strings.HasPrefix(entry.Path, b.archivePath) {
+		return "", &fs.PathError{Op: "extract", Path: entry.Path, Err: fs.ErrClosed}
+	}
+	p, err := b.archive.ExtractTemp(entry.inner)
+	if err != nil {
+		return "", err
+	}
+	b.tempDirs = append(b.tempDirs, filepath.Dir(p))
+	return p, nil
+}
+
+// Close closes any open archive and removes extracted files.
+func (b *FileBrowser) Close() error {
+	var err error
+	if b.archive != nil {
+		err = b.archive.Close()
+		b.archive = nil
+	}
+	for _, dir := range b.tempDirs {
+		os.RemoveAll(dir)
+	}
+	b.tempDirs = nil
+	return err
+}
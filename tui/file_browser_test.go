@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func itemLabels(items []ListItem) []string {
+	labels := make([]string, len(items))
+	for i, item := range items {
+		labels[i] = item.Label
+	}
+	return labels
+}
+
+func TestFileBrowser_Archive(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), nil, 0o644))
+
+	f, err := os.Create(filepath.Join(dir, "build.zip"))
+	assert.NoError(t, err)
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("docs/guide.md")
+	assert.NoError(t, err)
+	_, err = w.Write([]byte("# Guide"))
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+	assert.NoError(t, f.Close())
+
+	b := NewFileBrowser(dir)
+	defer b.Close()
+	items, err := b.Items()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"..", "[DIR] sub", "[ARC] build.zip", "notes.txt"}, itemLabels(items))
+
+	// Descend into the archive and one of its directories
+	_, opened, err := b.Select(items[2])
+	assert.NoError(t, err)
+	assert.True(t, opened)
+	items, _ = b.Items()
+	assert.Equal(t, []string{"..", "[DIR] docs"}, itemLabels(items))
+	_, opened, _ = b.Select(items[1])
+	assert.True(t, opened)
+	assert.Equal(t, filepath.Join(dir, "build.zip", "docs"), b.Path())
+
+	items, _ = b.Items()
+	entry, opened, err := b.Select(items[1])
+	assert.NoError(t, err)
+	assert.False(t, opened)
+	assert.True(t, entry.InArchive)
+	path, err := b.Extract(entry)
+	assert.NoError(t, err)
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "# Guide", string(data))
+
+	// Going up leaves the archive; Close removes extracted files
+	b.Up()
+	b.Up()
+	assert.Equal(t, dir, b.Path())
+	assert.NoError(t, b.Close())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -61,3 +61,67 @@ func (f *fillView) size(maxWidth, maxHeight int) (int, int) {
 func (f *fillView) flex() int {
 	return 1
 }
+
+// fillBgView draws its inner view over a background color that spans the
+// width it is given.
+type fillBgView struct {
+	inner View
+	style Style
+}
+
+// FillBg draws inner over background color c, extended across all the width
+// inner is given, so a highlighted row is one solid bar rather than a
+// background behind each piece of text: padding, the space a Spacer leaves,
+// and the space after content narrower than the row are all filled. Cells
+// inner draws without a background color of their own get c as well. The
+// height is inner's own.
+//
+// Example:
+//
+//	tui.FillBg(rowBg, tui.PaddingHV(1, 0, tui.Group(
+//	    tui.Text(file.Name),
+//	    tui.Spacer(),
+//	    tui.Text(file.Size).Dim(),
+//	)))
+func FillBg(c Color, inner View) *fillBgView {
+	return &fillBgView{inner: inner, style: NewStyle().WithBackground(c)}
+}
+
+// FillBgRGB is like FillBg with a background color given by RGB values.
+func FillBgRGB(r, g, b uint8, inner View) *fillBgView {
+	return &fillBgView{inner: inner, style: NewStyle().WithBgRGB(RGB{R: r, G: g, B: b})}
+}
+
+func (f *fillBgView) size(maxWidth, maxHeight int) (int, int) {
+	w, h := f.inner.size(maxWidth, maxHeight)
+	if maxWidth > 0 {
+		w = maxWidth
+	}
+	return w, h
+}
+
+// flex implements the Flexible interface by delegating to the inner view.
+func (f *fillBgView) flex() int {
+	if flex, ok := f.inner.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (f *fillBgView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	ctx.FillStyled(0, 0, width, height, ' ', f.style)
+	frame := &styleRenderFrame{
+		RenderFrame: ctx.RenderFrame(),
+		transform: func(s Style) Style {
+			if s.Background == ColorDefault && s.BgRGB == nil {
+				s.Background, s.BgRGB = f.style.Background, f.style.BgRGB
+			}
+			return s
+		},
+	}
+	f.inner.render(ctx.WithFrame(frame))
+}
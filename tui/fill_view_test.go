@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"bytes"
 	"strings"
 	"testing"
 
@@ -350,3 +351,58 @@ func TestFill_SingleCell(t *testing.T) {
 	output := buf.String()
 	assert.True(t, strings.Contains(output, "X"), "output should contain fill character")
 }
+
+// renderToTerminal renders view on a test terminal of the given size.
+func renderToTerminal(t *testing.T, view View, width, height int) *Terminal {
+	t.Helper()
+	terminal := NewTestTerminal(width, height, &bytes.Buffer{})
+	frame, err := terminal.BeginFrame()
+	assert.NoError(t, err)
+	view.size(width, height)
+	ctx := NewRenderContext(frame, 0)
+	view.render(ctx)
+	ctx.drawOverlays()
+	terminal.EndFrame(frame)
+	return terminal
+}
+
+func TestFillBg_SpansRow(t *testing.T) {
+	view := Stack(
+		Group(Text("a"), Spacer(), Text("b")).FillBg(ColorBlue),
+		Text("next"),
+	)
+	terminal := renderToTerminal(t, view, 10, 3)
+
+	// The whole first row is blue, including the Spacer and the texts
+	for x := 0; x < 10; x++ {
+		assert.Equal(t, ColorBlue, terminal.GetCell(x, 0).Style.Background, "cell %d", x)
+	}
+	assert.Equal(t, 'b', terminal.GetCell(9, 0).Char)
+	// and the row is only as tall as the Group
+	assert.Equal(t, 'n', terminal.GetCell(0, 1).Char)
+	assert.Equal(t, ColorDefault, terminal.GetCell(0, 1).Style.Background)
+}
+
+func TestFillBg_PaddingAndOwnBackground(t *testing.T) {
+	view := Stack(
+		FillBg(ColorBlue, PaddingHV(1, 0, Group(Text("a"), Text("b").Bg(ColorRed)))),
+	)
+	terminal := renderToTerminal(t, view, 6, 1)
+
+	assert.Equal(t, ' ', terminal.GetCell(0, 0).Char)
+	assert.Equal(t, ColorBlue, terminal.GetCell(0, 0).Style.Background)
+	assert.Equal(t, ColorBlue, terminal.GetCell(1, 0).Style.Background)
+	// Cells with a background of their own keep it
+	assert.Equal(t, ColorRed, terminal.GetCell(2, 0).Style.Background)
+	assert.Equal(t, ColorBlue, terminal.GetCell(5, 0).Style.Background)
+}
+
+func TestFillBg_Size(t *testing.T) {
+	view := FillBg(ColorBlue, Text("abc"))
+	w, h := view.size(20, 10)
+	assert.Equal(t, 20, w)
+	assert.Equal(t, 1, h)
+	w, h = view.size(0, 0)
+	assert.Equal(t, 3, w)
+	assert.Equal(t, 1, h)
+}
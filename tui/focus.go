@@ -88,6 +88,18 @@ func (fm *FocusManager) GetFocused() Focusable {
 	return fm.focusables[fm.focusedID]
 }
 
+// boundsOf returns the screen bounds of the focusable element with the
+// given ID, if one is registered.
+func (fm *FocusManager) boundsOf(id string) (image.Rectangle, bool) {
+	fm.mu.Lock()
+	f, ok := fm.focusables[id]
+	fm.mu.Unlock()
+	if !ok {
+		return image.Rectangle{}, false
+	}
+	return f.FocusBounds(), true
+}
+
 // GetFocusedID returns the ID of the currently focused element.
 func (fm *FocusManager) GetFocusedID() string {
 	fm.mu.Lock()
@@ -184,8 +196,9 @@ func (fm *FocusManager) findCurrentIndex() int {
 // HandleKey routes a key event to the focused element.
 // Returns true if the event was handled.
 func (fm *FocusManager) HandleKey(event KeyEvent) bool {
-	// Handle Tab/Shift+Tab for navigation (before delegating to focused element)
-	if event.Key == KeyTab {
+	// Handle Tab/Shift+Tab for navigation (before delegating to focused element).
+	// Ctrl+Tab is left for shortcuts such as switching tabs.
+	if event.Key == KeyTab && !event.Ctrl {
 		if event.Shift {
 			fm.FocusPrev()
 		} else {
@@ -212,6 +225,11 @@ func (fm *FocusManager) HandleClick(x, y int) bool {
 	defer fm.mu.Unlock()
 
 	pt := image.Pt(x, y)
+	// The focused element may have a popup over the others, so it's
+	// checked first
+	if f, ok := fm.focusables[fm.focusedID]; ok && pt.In(f.FocusBounds()) {
+		return true
+	}
 	for id, f := range fm.focusables {
 		if pt.In(f.FocusBounds()) {
 			// Unfocus previous
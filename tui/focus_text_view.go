@@ -84,7 +84,7 @@ func (f *focusTextView) render(ctx *RenderContext) {
 	// Choose style based on focus state
 	style := f.style
 	if isFocused && f.focusStyle != nil {
-		style = *f.focusStyle
+		style = focusedStyle(ctx, f.style, *f.focusStyle)
 	}
 
 	ctx.PrintTruncated(0, 0, f.content, style)
@@ -0,0 +1,743 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator checks a field value. It returns an error describing the problem
+// when the value is invalid, or nil when it is acceptable. The error message
+// is shown next to the field and in the form's validation summary, so keep
+// it short and lowercase (e.g. "is required").
+type Validator func(value string) error
+
+// Required rejects empty or whitespace-only values.
+func Required() Validator {
+	return func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return errors.New("is required")
+		}
+		return nil
+	}
+}
+
+// MinLength rejects values shorter than n characters.
+func MinLength(n int) Validator {
+	return func(value string) error {
+		if utf8.RuneCountInString(value) < n {
+			return fmt.Errorf("must be at least %d characters", n)
+		}
+		return nil
+	}
+}
+
+// MaxLength rejects values longer than n characters.
+func MaxLength(n int) Validator {
+	return func(value string) error {
+		if utf8.RuneCountInString(value) > n {
+			return fmt.Errorf("must be at most %d characters", n)
+		}
+		return nil
+	}
+}
+
+// Pattern rejects non-empty values that do not match the regular expression.
+// Combine with Required to also reject empty values. Panics if expr is not a
+// valid regular expression.
+func Pattern(expr, message string) Validator {
+	re := regexp.MustCompile(expr)
+	return func(value string) error {
+		if value != "" && !re.MatchString(value) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// FormField describes one input of a Form. A field is a text input unless
+// Options or Checked is set.
+type FormField struct {
+	ID          string      // Focus ID of the field's input; must be unique
+	Label       string      // Label shown to the left of the input and in error messages
+	Value       *string     // Binding for the input's value, or the chosen option
+	Placeholder string      // Optional placeholder text
+	Mask        rune        // Optional mask character (e.g. '*' for passwords)
+	Validators  []Validator // Checks run in order; the first failure is reported
+
+	// Options makes the field a choice between fixed values, cycled with
+	// Left/Right or Space. Value holds the chosen option.
+	Options []string
+
+	// Checked makes the field a checkbox, toggled with Space. Validators
+	// see "true" when it is checked and "" otherwise, so Required means it
+	// must be checked.
+	Checked *bool
+}
+
+// value returns the field's value as seen by validators.
+func (field *FormField) value() string {
+	switch {
+	case field.Checked != nil:
+		if *field.Checked {
+			return "true"
+		}
+		return ""
+	case field.Value != nil:
+		return *field.Value
+	}
+	return ""
+}
+
+// FieldError is a validation failure for a single form field.
+type FieldError struct {
+	FieldID string
+	Label   string
+	Err     error
+}
+
+// Error implements error.
+func (e FieldError) Error() string {
+	return e.Label + " " + e.Err.Error()
+}
+
+// Unwrap returns the underlying validator error.
+func (e FieldError) Unwrap() error { return e.Err }
+
+// Form holds the fields, validation state, and focus/scroll coordination for
+// a form rendered with FormView. Like SearchController, a Form is owned by
+// the application and persists across frames; views are rebuilt from it on
+// every render.
+//
+// When Submit fails, FormView shows a summary of all errors above the fields
+// and moves focus to it. Up/Down select an error and Enter (or a click) jumps
+// to the offending field, scrolling it into view.
+//
+// Example:
+//
+//	app.form = tui.NewForm("signup").
+//	    Field("name", "Name", &app.name, tui.Required()).
+//	    Field("email", "Email", &app.email, tui.Required(), tui.Pattern(`^\S+@\S+$`, "must be an email address")).
+//	    OnSubmit(app.save)
+//
+//	func (app *App) View() tui.View {
+//	    return tui.FormView(app.form).SubmitLabel("[ Sign up ]")
+//	}
+type Form struct {
+	id        string
+	fields    []*FormField
+	errors    []FieldError
+	submitted bool
+	accepted  bool // the last Submit passed validation
+	onSubmit  func()
+
+	// Summary selection and focus/scroll requests applied on the next render
+	summarySelected int
+	pendingFocus    string
+	reveal          string
+	lastFocused     string
+	scrollY         int
+}
+
+// NewForm creates an empty form. The id prefixes the focus IDs of the
+// form's own elements (the validation summary and submit button).
+func NewForm(id string) *Form {
+	return &Form{id: id}
+}
+
+// Field adds a field with the given focus ID, label, value binding, and
+// validators.
+func (f *Form) Field(id, label string, value *string, validators ...Validator) *Form {
+	return f.AddField(FormField{ID: id, Label: label, Value: value, Validators: validators})
+}
+
+// Select adds a field that chooses one of options, stored in value.
+func (f *Form) Select(id, label string, value *string, options []string, validators ...Validator) *Form {
+	return f.AddField(FormField{ID: id, Label: label, Value: value, Options: options, Validators: validators})
+}
+
+// Checkbox adds a checkbox field bound to checked.
+func (f *Form) Checkbox(id, label string, checked *bool, validators ...Validator) *Form {
+	return f.AddField(FormField{ID: id, Label: label, Checked: checked, Validators: validators})
+}
+
+// AddField adds a fully configured field.
+func (f *Form) AddField(field FormField) *Form {
+	f.fields = append(f.fields, &field)
+	return f
+}
+
+// OnSubmit sets the callback invoked when Submit succeeds.
+func (f *Form) OnSubmit(fn func()) *Form {
+	f.onSubmit = fn
+	return f
+}
+
+// Fields returns the form's fields in display order.
+func (f *Form) Fields() []*FormField {
+	return f.fields
+}
+
+// SummaryID returns the focus ID of the validation summary.
+func (f *Form) SummaryID() string {
+	return f.id + "-summary"
+}
+
+// SubmitID returns the focus ID of the submit button.
+func (f *Form) SubmitID() string {
+	return f.id + "-submit"
+}
+
+// Validate runs every field's validators and records the failures, in field
+// order. It returns true if all fields are valid.
+func (f *Form) Validate() bool {
+	f.errors = f.errors[:0]
+	for _, field := range f.fields {
+		value := field.value()
+		for _, validate := range field.Validators {
+			if err := validate(value); err != nil {
+				f.errors = append(f.errors, FieldError{FieldID: field.ID, Label: field.Label, Err: err})
+				break
+			}
+		}
+	}
+	if f.summarySelected >= len(f.errors) {
+		f.summarySelected = len(f.errors) - 1
+	}
+	if f.summarySelected < 0 {
+		f.summarySelected = 0
+	}
+	return len(f.errors) == 0
+}
+
+// Submit validates the form. If it is valid, the OnSubmit callback runs and
+// Submit returns true. Otherwise the validation summary is shown and focused
+// on the next render, and Submit returns false.
+func (f *Form) Submit() bool {
+	f.submitted = true
+	f.accepted = f.Validate()
+	if !f.accepted {
+		f.summarySelected = 0
+		f.pendingFocus = f.SummaryID()
+		f.reveal = f.SummaryID()
+		return false
+	}
+	if f.onSubmit != nil {
+		f.onSubmit()
+	}
+	return true
+}
+
+// FormResult is a snapshot of a form's values and validation state.
+type FormResult struct {
+	Valid   bool              // The last Submit passed validation
+	Values  map[string]string // Text and choice field values by field ID
+	Checked map[string]bool   // Checkbox states by field ID
+	Errors  []FieldError      // Failures from the last validation
+}
+
+// Result returns the current field values together with the outcome of the
+// last Submit, for handling a submission in one place:
+//
+//	if app.form.Submit() {
+//	    res := app.form.Result()
+//	    app.createProject(res.Values["name"], res.Checked["git"])
+//	}
+func (f *Form) Result() FormResult {
+	res := FormResult{
+		Valid:   f.accepted,
+		Values:  make(map[string]string),
+		Checked: make(map[string]bool),
+		Errors:  append([]FieldError(nil), f.errors...),
+	}
+	for _, field := range f.fields {
+		switch {
+		case field.Checked != nil:
+			res.Checked[field.ID] = *field.Checked
+		case field.Value != nil:
+			res.Values[field.ID] = *field.Value
+		}
+	}
+	return res
+}
+
+// Errors returns the failures from the last validation.
+func (f *Form) Errors() []FieldError {
+	return f.errors
+}
+
+// FieldErr returns the validation error for the field, or nil.
+func (f *Form) FieldErr(id string) error {
+	for _, e := range f.errors {
+		if e.FieldID == id {
+			return e.Err
+		}
+	}
+	return nil
+}
+
+// Submitted reports whether Submit has been called since the last Reset.
+// Errors are only displayed once the form has been submitted.
+func (f *Form) Submitted() bool {
+	return f.submitted
+}
+
+// Reset clears validation errors and hides the summary. Field values are
+// left untouched.
+func (f *Form) Reset() {
+	f.errors = nil
+	f.submitted = false
+	f.accepted = false
+	f.summarySelected = 0
+}
+
+// FocusField moves focus to the field with the given ID and scrolls it into
+// view on the next render.
+func (f *Form) FocusField(id string) {
+	f.pendingFocus = id
+	f.reveal = id
+}
+
+// SelectedError returns the index of the selected item in the validation
+// summary.
+func (f *Form) SelectedError() int {
+	return f.summarySelected
+}
+
+// showSummary reports whether the validation summary should be displayed.
+func (f *Form) showSummary() bool {
+	return f.submitted && len(f.errors) > 0
+}
+
+// formSummary is the focusable validation summary. A new one is registered
+// every frame; selection state lives in the Form.
+type formSummary struct {
+	form    *Form
+	fm      *FocusManager
+	bounds  image.Rectangle
+	focused bool
+}
+
+func (s *formSummary) FocusID() string              { return s.form.SummaryID() }
+func (s *formSummary) IsFocused() bool              { return s.focused }
+func (s *formSummary) SetFocused(focused bool)      { s.focused = focused }
+func (s *formSummary) FocusBounds() image.Rectangle { return s.bounds }
+
+func (s *formSummary) HandleKeyEvent(event KeyEvent) bool {
+	f := s.form
+	if len(f.errors) == 0 {
+		return false
+	}
+	switch {
+	case event.Key == KeyArrowUp || event.Rune == 'k':
+		if f.summarySelected > 0 {
+			f.summarySelected--
+		}
+		return true
+	case event.Key == KeyArrowDown || event.Rune == 'j':
+		if f.summarySelected < len(f.errors)-1 {
+			f.summarySelected++
+		}
+		return true
+	case event.Key == KeyEnter:
+		s.jump(f.summarySelected)
+		return true
+	}
+	return false
+}
+
+// jump moves focus to the field of the i-th error.
+func (s *formSummary) jump(i int) {
+	f := s.form
+	if i < 0 || i >= len(f.errors) {
+		return
+	}
+	f.summarySelected = i
+	f.FocusField(f.errors[i].FieldID)
+	if s.fm != nil {
+		// Focus immediately so the next key goes to the field even before
+		// the form is re-rendered.
+		s.fm.SetFocus(f.pendingFocus)
+	}
+}
+
+// formControl is a focusable choice or checkbox field. A new one is
+// registered every frame; its value lives in the field's binding.
+type formControl struct {
+	form    *Form
+	field   *FormField
+	bounds  image.Rectangle
+	focused bool
+}
+
+func (c *formControl) FocusID() string              { return c.field.ID }
+func (c *formControl) IsFocused() bool              { return c.focused }
+func (c *formControl) SetFocused(focused bool)      { c.focused = focused }
+func (c *formControl) FocusBounds() image.Rectangle { return c.bounds }
+
+func (c *formControl) HandleKeyEvent(event KeyEvent) bool {
+	switch {
+	case event.Key == KeyEnter:
+		c.form.Submit()
+	case event.Rune == ' ':
+		c.change(1)
+	case c.field.Checked != nil:
+		return false
+	case event.Key == KeyArrowLeft || event.Rune == 'h':
+		c.change(-1)
+	case event.Key == KeyArrowRight || event.Rune == 'l':
+		c.change(1)
+	default:
+		return false
+	}
+	return true
+}
+
+// change toggles a checkbox or moves a choice by delta options, wrapping.
+func (c *formControl) change(delta int) {
+	field := c.field
+	if field.Checked != nil {
+		*field.Checked = !*field.Checked
+	} else if field.Value != nil && len(field.Options) > 0 {
+		i := -1
+		for j, option := range field.Options {
+			if option == *field.Value {
+				i = j
+			}
+		}
+		n := len(field.Options)
+		if i < 0 && delta < 0 {
+			i = 0
+		}
+		*field.Value = field.Options[((i+delta)%n+n)%n]
+	}
+	if c.form.submitted {
+		c.form.Validate()
+	}
+}
+
+// text returns how the control's value is drawn.
+func (c *formControl) text() string {
+	field := c.field
+	if field.Checked != nil {
+		if *field.Checked {
+			return "[x]"
+		}
+		return "[ ]"
+	}
+	value := field.Placeholder
+	if field.Value != nil && *field.Value != "" {
+		value = *field.Value
+	}
+	return "‹ " + value + " ›"
+}
+
+// offscreenFocus keeps a block that is scrolled out of view in the Tab
+// order. Focusing it scrolls the block into view on the next render, where
+// the real element takes over.
+type offscreenFocus struct {
+	id      string
+	focused bool
+}
+
+func (o *offscreenFocus) FocusID() string                    { return o.id }
+func (o *offscreenFocus) IsFocused() bool                    { return o.focused }
+func (o *offscreenFocus) SetFocused(focused bool)            { o.focused = focused }
+func (o *offscreenFocus) FocusBounds() image.Rectangle       { return image.Rectangle{} }
+func (o *offscreenFocus) HandleKeyEvent(event KeyEvent) bool { return false }
+
+// formView renders a Form: validation summary, labeled inputs with inline
+// errors, and an optional submit button, inside its own scroll viewport.
+type formView struct {
+	form          *Form
+	submitLabel   string
+	labelStyle    Style
+	errorStyle    Style
+	summaryStyle  Style
+	selectedStyle Style
+}
+
+// FormView renders the form's fields as labeled inputs. Once the form has
+// been submitted, each invalid field shows its error underneath and a
+// summary of all errors is shown at the top. The view scrolls when the
+// fields do not fit, keeping the focused field visible.
+//
+// Tab and Shift+Tab move through the fields, the summary, and the submit
+// button in order, including ones scrolled out of view. Pressing Enter in a
+// field submits the form.
+//
+// Example:
+//
+//	tui.FormView(app.form).SubmitLabel("[ Save ]")
+func FormView(form *Form) *formView {
+	return &formView{
+		form:          form,
+		labelStyle:    NewStyle().WithForeground(ColorBrightBlack),
+		errorStyle:    NewStyle().WithForeground(ColorRed),
+		summaryStyle:  NewStyle().WithForeground(ColorRed).WithBold(),
+		selectedStyle: NewStyle().WithForeground(ColorRed).WithReverse(),
+	}
+}
+
+// SubmitLabel adds a submit button with the given label below the fields.
+func (v *formView) SubmitLabel(label string) *formView {
+	v.submitLabel = label
+	return v
+}
+
+// LabelStyle sets the style of field labels.
+func (v *formView) LabelStyle(s Style) *formView {
+	v.labelStyle = s
+	return v
+}
+
+// ErrorStyle sets the style of inline field errors and summary items.
+func (v *formView) ErrorStyle(s Style) *formView {
+	v.errorStyle = s
+	return v
+}
+
+// SummaryStyle sets the style of the summary heading.
+func (v *formView) SummaryStyle(s Style) *formView {
+	v.summaryStyle = s
+	return v
+}
+
+// SelectedStyle sets the style of the selected summary item while the
+// summary is focused.
+func (v *formView) SelectedStyle(s Style) *formView {
+	v.selectedStyle = s
+	return v
+}
+
+func (v *formView) flex() int {
+	return 1
+}
+
+// formBlock is a vertical slice of the form's layout.
+type formBlock struct {
+	y, h   int
+	id     string // focus ID this block belongs to, if any
+	render func(ctx *RenderContext)
+}
+
+// layout computes the form's blocks for the given width.
+func (v *formView) layout(width int) ([]formBlock, int) {
+	f := v.form
+	var blocks []formBlock
+	y := 0
+	add := func(h int, id string, render func(ctx *RenderContext)) {
+		blocks = append(blocks, formBlock{y: y, h: h, id: id, render: render})
+		y += h
+	}
+
+	if f.showSummary() {
+		n := len(f.errors)
+		heading := fmt.Sprintf("✗ %d fields need attention:", n)
+		if n == 1 {
+			heading = "✗ 1 field needs attention:"
+		}
+		add(n+2, f.SummaryID(), func(ctx *RenderContext) {
+			v.renderSummary(ctx, heading)
+		})
+	}
+
+	labelWidth := 0
+	for _, field := range f.fields {
+		if w, _ := MeasureText(field.Label); w > labelWidth {
+			labelWidth = w
+		}
+	}
+
+	for _, field := range f.fields {
+		var control func(ctx *RenderContext)
+		h := 1
+		if field.Checked != nil || len(field.Options) > 0 {
+			control = func(ctx *RenderContext) { v.renderControl(ctx, field, labelWidth) }
+		} else {
+			input := v.input(field, labelWidth)
+			_, h = input.size(width, 0)
+			h = max(h, 1)
+			control = input.render
+		}
+		var err error
+		if f.submitted {
+			err = f.FieldErr(field.ID)
+		}
+		fieldH := h
+		if err != nil {
+			fieldH++
+		}
+		add(fieldH, field.ID, func(ctx *RenderContext) {
+			w, _ := ctx.Size()
+			control(ctx.SubContext(image.Rect(0, 0, w, h)))
+			if err != nil {
+				ctx.PrintTruncated(labelWidth+1, h, "✗ "+err.Error(), v.errorStyle)
+			}
+		})
+	}
+
+	if v.submitLabel != "" {
+		button := Button(v.submitLabel, func() { f.Submit() }).ID(f.SubmitID())
+		add(1, "", func(ctx *RenderContext) {})
+		add(1, f.SubmitID(), button.render)
+	}
+	return blocks, y
+}
+
+// input builds the InputField for a form field, with the label padded so
+// all inputs line up.
+func (v *formView) input(field *FormField, labelWidth int) *inputFieldView {
+	f := v.form
+	label := field.Label
+	if w, _ := MeasureText(label); w < labelWidth+1 {
+		label += strings.Repeat(" ", labelWidth+1-w)
+	}
+	input := InputField(field.Value).
+		ID(field.ID).
+		Label(label).
+		LabelStyle(v.labelStyle).
+		Placeholder(field.Placeholder).
+		OnSubmit(func(string) { f.Submit() })
+	if f.submitted {
+		// Re-validate as the user edits so fixed errors disappear
+		input.OnChange(func(string) { f.Validate() })
+	}
+	if field.Mask != 0 {
+		input.Mask(field.Mask)
+	}
+	return input
+}
+
+// renderControl draws a choice or checkbox field on one line, aligned with
+// the text inputs.
+func (v *formView) renderControl(ctx *RenderContext, field *FormField, labelWidth int) {
+	w, _ := ctx.Size()
+	bounds := ctx.AbsoluteBounds()
+	control := &formControl{form: v.form, field: field, bounds: bounds}
+	if fm := ctx.FocusManager(); fm != nil {
+		fm.Register(control)
+	}
+
+	ctx.PrintTruncated(0, 0, field.Label, v.labelStyle)
+	style := NewStyle()
+	if control.focused {
+		style = style.WithReverse()
+	}
+	ctx.PrintTruncated(labelWidth+1, 0, control.text(), style)
+
+	interactiveRegistry.RegisterButton(image.Rect(
+		bounds.Min.X+labelWidth+1, bounds.Min.Y, bounds.Min.X+w, bounds.Min.Y+1,
+	), func() { control.change(1) })
+}
+
+func (v *formView) renderSummary(ctx *RenderContext, heading string) {
+	f := v.form
+	w, _ := ctx.Size()
+	summary := &formSummary{form: f, fm: ctx.FocusManager(), bounds: ctx.AbsoluteBounds()}
+	if summary.fm != nil {
+		summary.fm.Register(summary)
+	}
+
+	ctx.PrintTruncated(0, 0, heading, v.summaryStyle)
+	bounds := ctx.AbsoluteBounds()
+	for i, e := range f.errors {
+		style := v.errorStyle
+		prefix := "  • "
+		if summary.focused && i == f.summarySelected {
+			style = v.selectedStyle
+			prefix = "  › "
+		}
+		ctx.PrintTruncated(0, i+1, prefix+e.Error(), style)
+
+		i := i // capture for closure
+		interactiveRegistry.RegisterButton(image.Rect(
+			bounds.Min.X,
+			bounds.Min.Y+i+1,
+			bounds.Min.X+w,
+			bounds.Min.Y+i+2,
+		), func() {
+			summary.jump(i)
+		})
+	}
+}
+
+func (v *formView) size(maxWidth, maxHeight int) (int, int) {
+	w := maxWidth
+	if w <= 0 {
+		w = 80
+	}
+	_, h := v.layout(w)
+	if maxHeight > 0 && h > maxHeight {
+		h = maxHeight
+	}
+	return w, h
+}
+
+func (v *formView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	f := v.form
+
+	// Apply focus requests before fields register so the right one is
+	// marked focused this frame.
+	fm := ctx.FocusManager()
+	if fm != nil && f.pendingFocus != "" {
+		fm.SetFocus(f.pendingFocus)
+	}
+	f.pendingFocus = ""
+
+	blocks, total := v.layout(width)
+
+	// Scroll an explicitly revealed block, or a newly focused one, into view
+	target := f.reveal
+	if fm != nil {
+		if focused := fm.GetFocusedID(); focused != f.lastFocused {
+			f.lastFocused = focused
+			if target == "" {
+				target = focused
+			}
+		}
+	}
+	f.reveal = ""
+	if target != "" {
+		for _, b := range blocks {
+			if b.id != target {
+				continue
+			}
+			if b.y < f.scrollY {
+				f.scrollY = b.y
+			}
+			if b.y+b.h > f.scrollY+height {
+				f.scrollY = b.y + b.h - height
+			}
+			break
+		}
+	}
+	if f.scrollY > total-height {
+		f.scrollY = total - height
+	}
+	if f.scrollY < 0 {
+		f.scrollY = 0
+	}
+
+	for _, b := range blocks {
+		top := b.y - f.scrollY
+		if top < 0 || top >= height {
+			if fm != nil && b.id != "" {
+				fm.Register(&offscreenFocus{id: b.id})
+			}
+			continue
+		}
+		bottom := top + b.h
+		if bottom > height {
+			bottom = height
+		}
+		b.render(ctx.SubContext(image.Rect(0, top, width, bottom)))
+	}
+}
@@ -0,0 +1,247 @@
+package tui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestValidators(t *testing.T) {
+	assert.Error(t, Required()(""))
+	assert.Error(t, Required()("   "))
+	assert.NoError(t, Required()("x"))
+
+	assert.Error(t, MinLength(3)("ab"))
+	assert.NoError(t, MinLength(3)("abc"))
+	assert.Error(t, MaxLength(2)("abc"))
+	assert.NoError(t, MaxLength(2)("日本"))
+
+	email := Pattern(`^\S+@\S+$`, "must be an email address")
+	assert.NoError(t, email(""))
+	assert.NoError(t, email("a@b"))
+	assert.Equal(t, "must be an email address", email("nope").Error())
+}
+
+func TestForm_SubmitInvalid(t *testing.T) {
+	var name, email string
+	submitted := false
+	form := NewForm("signup").
+		Field("name", "Name", &name, Required()).
+		Field("email", "Email", &email, Required(), Pattern(`@`, "must be an email address")).
+		OnSubmit(func() { submitted = true })
+
+	// Errors are hidden until the first submit
+	screen := SprintScreen(FormView(form), PrintConfig{Width: 60})
+	assert.NotContains(t, screen.Text(), "is required")
+
+	assert.False(t, form.Submit())
+	assert.False(t, submitted)
+	assert.True(t, form.Submitted())
+	assert.Equal(t, 2, len(form.Errors()))
+	assert.Equal(t, "Name is required", form.Errors()[0].Error())
+	assert.Equal(t, "email", form.Errors()[1].FieldID)
+
+	screen = SprintScreen(FormView(form), PrintConfig{Width: 60})
+	assert.Contains(t, screen.Row(0), "2 fields need attention")
+	assert.Contains(t, screen.Row(1), "Name is required")
+	assert.Contains(t, screen.Row(2), "Email is required")
+	assert.True(t, strings.HasPrefix(screen.Row(4), "Name"))
+	assert.Contains(t, screen.Row(5), "✗ is required")
+
+	// The first failing validator is the one reported
+	email = "nope"
+	form.Validate()
+	assert.Equal(t, "must be an email address", form.FieldErr("email").Error())
+	assert.Nil(t, form.FieldErr("missing"))
+}
+
+func TestForm_SubmitValid(t *testing.T) {
+	name := "Ada"
+	submitted := false
+	form := NewForm("f").Field("name", "Name", &name, Required()).OnSubmit(func() { submitted = true })
+
+	assert.True(t, form.Submit())
+	assert.True(t, submitted)
+	assert.Equal(t, 0, len(form.Errors()))
+
+	screen := SprintScreen(FormView(form), PrintConfig{Width: 40})
+	assert.True(t, strings.HasPrefix(screen.Row(0), "Name"))
+}
+
+func TestForm_ResetHidesErrors(t *testing.T) {
+	var name string
+	form := NewForm("f").Field("name", "Name", &name, Required())
+	form.Submit()
+	form.Reset()
+	assert.False(t, form.Submitted())
+	assert.Equal(t, 0, len(form.Errors()))
+}
+
+// renderForm renders the form into a test frame of the given size using fm.
+func renderForm(t *testing.T, view View, fm *FocusManager, w, h int) {
+	t.Helper()
+	var buf bytes.Buffer
+	terminal := NewTestTerminal(w, h, &buf)
+	frame, err := terminal.BeginFrame()
+	assert.NoError(t, err)
+	defer terminal.EndFrame(frame)
+
+	fm.Clear()
+	interactiveRegistry.Clear()
+	ctx := NewRenderContext(frame, 0).WithFocusManager(fm)
+	view.render(ctx.SubContext(image.Rect(0, 0, w, h)))
+}
+
+func TestForm_SummaryJumpsToField(t *testing.T) {
+	var name, email string
+	form := NewForm("signup").
+		Field("name", "Name", &name, Required()).
+		Field("email", "Email", &email, Required())
+	fm := NewFocusManager()
+
+	assert.False(t, form.Submit())
+	renderForm(t, FormView(form), fm, 60, 10)
+	assert.Equal(t, form.SummaryID(), fm.GetFocusedID())
+
+	// Down selects the second error; Enter focuses its field
+	assert.True(t, fm.HandleKey(KeyEvent{Key: KeyArrowDown}))
+	assert.Equal(t, 1, form.SelectedError())
+	assert.True(t, fm.HandleKey(KeyEvent{Key: KeyEnter}))
+	assert.Equal(t, "email", fm.GetFocusedID())
+
+	renderForm(t, FormView(form), fm, 60, 10)
+	assert.Equal(t, "email", fm.GetFocusedID())
+	assert.True(t, fm.GetFocused().IsFocused())
+}
+
+func TestForm_SummaryClickJumpsToField(t *testing.T) {
+	var a, b string
+	form := NewForm("f").Field("a", "A", &a, Required()).Field("b", "B", &b, Required())
+	fm := NewFocusManager()
+
+	form.Submit()
+	renderForm(t, FormView(form), fm, 40, 10)
+	assert.True(t, interactiveRegistry.HandleClick(2, 2))
+	assert.Equal(t, "b", fm.GetFocusedID())
+	assert.Equal(t, 1, form.SelectedError())
+}
+
+func TestForm_JumpScrollsFieldIntoView(t *testing.T) {
+	values := make([]string, 10)
+	form := NewForm("long")
+	for i := range values {
+		id := fmt.Sprintf("field%d", i)
+		var validators []Validator
+		if i == 9 {
+			validators = append(validators, Required())
+		} else {
+			values[i] = "ok"
+		}
+		form.Field(id, "Field "+id, &values[i], validators...)
+	}
+	fm := NewFocusManager()
+
+	form.Submit()
+	renderForm(t, FormView(form), fm, 40, 5)
+	assert.Equal(t, 0, form.scrollY)
+
+	assert.True(t, fm.HandleKey(KeyEvent{Key: KeyEnter}))
+	renderForm(t, FormView(form), fm, 40, 5)
+
+	// Summary (3 rows) + 9 fields + last field with its error (2 rows) = 14
+	assert.Equal(t, 14-5, form.scrollY)
+	assert.Equal(t, "field9", fm.GetFocusedID())
+	assert.True(t, fm.GetFocused().IsFocused())
+
+	// Returning focus to the summary scrolls back to the top
+	form.FocusField(form.SummaryID())
+	renderForm(t, FormView(form), fm, 40, 5)
+	assert.Equal(t, 0, form.scrollY)
+}
+
+func TestForm_RevalidatesAfterSubmit(t *testing.T) {
+	var name string
+	form := NewForm("f").Field("name", "Name", &name, Required())
+	form.Submit()
+	assert.Equal(t, 1, len(form.Errors()))
+
+	name = "fixed"
+	input := FormView(form).input(form.Fields()[0], 4)
+	input.onChange(name)
+	assert.Equal(t, 0, len(form.Errors()))
+}
+
+func TestFieldError_Unwrap(t *testing.T) {
+	errTaken := errors.New("is taken")
+	form := NewForm("f").Field("user", "Username", new(string), func(string) error { return errTaken })
+	form.Validate()
+	assert.True(t, errors.Is(form.Errors()[0], errTaken))
+	assert.Equal(t, "Username is taken", form.Errors()[0].Error())
+}
+
+func TestForm_SelectAndCheckbox(t *testing.T) {
+	name, db := "app", ""
+	var terms bool
+	form := NewForm("setup").
+		Field("name", "Name", &name, Required()).
+		Select("db", "Database", &db, []string{"Postgres", "SQLite"}, Required()).
+		Checkbox("terms", "Accept terms", &terms, Required())
+	fm := NewFocusManager()
+
+	assert.False(t, form.Submit())
+	assert.Equal(t, "Database is required", form.Errors()[0].Error())
+	assert.Equal(t, "Accept terms is required", form.Errors()[1].Error())
+
+	renderForm(t, FormView(form), fm, 40, 10)
+	fm.SetFocus("db")
+	assert.True(t, fm.HandleKey(KeyEvent{Key: KeyArrowRight}))
+	assert.Equal(t, "Postgres", db)
+	assert.True(t, fm.HandleKey(KeyEvent{Key: KeyArrowRight}))
+	assert.Equal(t, "SQLite", db)
+	assert.True(t, fm.HandleKey(KeyEvent{Key: KeyArrowRight}))
+	assert.Equal(t, "Postgres", db)
+	assert.True(t, fm.HandleKey(KeyEvent{Key: KeyArrowLeft}))
+	assert.Equal(t, "SQLite", db)
+
+	// Errors update as fields change after a submit
+	assert.Equal(t, 1, len(form.Errors()))
+	fm.SetFocus("terms")
+	assert.True(t, fm.HandleKey(KeyEvent{Rune: ' '}))
+	assert.True(t, terms)
+	assert.Equal(t, 0, len(form.Errors()))
+
+	screen := SprintScreen(FormView(form), PrintConfig{Width: 40})
+	assert.Equal(t, "Database     ‹ SQLite ›", screen.Row(1))
+	assert.Equal(t, "Accept terms [x]", screen.Row(2))
+
+	assert.False(t, form.Result().Valid)
+	assert.True(t, form.Submit())
+	res := form.Result()
+	assert.True(t, res.Valid)
+	assert.Equal(t, map[string]string{"name": "app", "db": "SQLite"}, res.Values)
+	assert.Equal(t, map[string]bool{"terms": true}, res.Checked)
+}
+
+func TestForm_TabReachesScrolledFields(t *testing.T) {
+	values := make([]string, 8)
+	form := NewForm("long")
+	for i := range values {
+		form.Field(fmt.Sprintf("field%d", i), "Field", &values[i])
+	}
+	fm := NewFocusManager()
+
+	// Only three fields fit, but Tab visits all of them in order
+	for i := range values {
+		renderForm(t, FormView(form), fm, 40, 3)
+		assert.Equal(t, fmt.Sprintf("field%d", i), fm.GetFocusedID())
+		assert.True(t, fm.HandleKey(KeyEvent{Key: KeyTab}))
+	}
+	renderForm(t, FormView(form), fm, 40, 3)
+	assert.Equal(t, "field0", fm.GetFocusedID())
+	assert.Equal(t, 0, form.scrollY)
+}
@@ -0,0 +1,54 @@
+package tui
+
+import "time"
+
+// SlowFrame describes a tick that took longer to handle and draw than the
+// time between frames. See WithSlowFrameHandler.
+type SlowFrame struct {
+	Frame    uint64        // The TickEvent's frame
+	Duration time.Duration // Time spent handling the tick and drawing the frame
+	Budget   time.Duration // Time between frames at the configured FPS
+	Skipped  int           // Frames the tick skipped, from earlier slow frames
+}
+
+// frameClock numbers ticks by the time since the first one, so when the
+// event loop falls behind, frames are skipped rather than animations slowing
+// down.
+type frameClock struct {
+	interval time.Duration
+	start    time.Time // when frame 0 would have been
+	last     time.Time // the previous tick, or zero before the first
+	frame    uint64
+}
+
+// newFrameClock returns a clock for ticks at fps frames per second.
+func newFrameClock(fps int) *frameClock {
+	return &frameClock{interval: time.Second / time.Duration(fps)}
+}
+
+// tick returns the TickEvent for a tick at now. Frame advances by one, or
+// by more when ticks are late.
+func (c *frameClock) tick(now time.Time) TickEvent {
+	if c.last.IsZero() {
+		c.start, c.last = now.Add(-c.interval), now.Add(-c.interval)
+	}
+	frame := uint64(now.Sub(c.start) / c.interval)
+	skipped := 0
+	if frame > c.frame+1 {
+		skipped = int(frame - c.frame - 1)
+	} else {
+		frame = c.frame + 1
+	}
+	event := TickEvent{Time: now, Frame: frame, Delta: now.Sub(c.last), Skipped: skipped}
+	c.frame, c.last = frame, now
+	return event
+}
+
+// slowFrame returns the SlowFrame for tick if handling and drawing it took
+// longer than the budget.
+func (c *frameClock) slowFrame(tick TickEvent, took time.Duration) (SlowFrame, bool) {
+	if took <= c.interval {
+		return SlowFrame{}, false
+	}
+	return SlowFrame{Frame: tick.Frame, Duration: took, Budget: c.interval, Skipped: tick.Skipped}, true
+}
@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestFrameClock_SkipsLateFrames(t *testing.T) {
+	clock := newFrameClock(10) // 100ms frames
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tick := clock.tick(start)
+	assert.Equal(t, tick.Frame, uint64(1))
+	assert.Equal(t, tick.Skipped, 0)
+
+	tick = clock.tick(start.Add(100 * time.Millisecond))
+	assert.Equal(t, tick.Frame, uint64(2))
+	assert.Equal(t, tick.Delta, 100*time.Millisecond)
+
+	// The loop was busy for 350ms, so frames 3 and 4 were missed
+	tick = clock.tick(start.Add(450 * time.Millisecond))
+	assert.Equal(t, tick.Frame, uint64(5))
+	assert.Equal(t, tick.Skipped, 2)
+	assert.Equal(t, tick.Delta, 350*time.Millisecond)
+
+	// Early ticks still advance one frame
+	tick = clock.tick(start.Add(460 * time.Millisecond))
+	assert.Equal(t, tick.Frame, uint64(6))
+	assert.Equal(t, tick.Skipped, 0)
+}
+
+func TestFrameClock_SlowFrame(t *testing.T) {
+	clock := newFrameClock(20)
+	tick := TickEvent{Frame: 7, Skipped: 1}
+	_, slow := clock.slowFrame(tick, 40*time.Millisecond)
+	assert.False(t, slow)
+
+	frame, slow := clock.slowFrame(tick, 80*time.Millisecond)
+	assert.True(t, slow)
+	assert.Equal(t, frame, SlowFrame{Frame: 7, Duration: 80 * time.Millisecond, Budget: 50 * time.Millisecond, Skipped: 1})
+}
@@ -1,45 +1,53 @@
 package tui
 
 import (
-	"sort"
-	"strings"
+	"github.com/deepnoodle-ai/wonton/fuzzy"
+	"github.com/mattn/go-runewidth"
 )
 
-// FuzzyMatch checks if pattern matches text (subsequence match)
+// FuzzyMatch checks if pattern matches text: the pattern's characters appear
+// in text in order. Matching is case-insensitive unless the pattern has an
+// uppercase letter. See the fuzzy package for scoring.
 func FuzzyMatch(pattern, text string) bool {
-	pattern = strings.ToLower(pattern)
-	text = strings.ToLower(text)
-
-	pIdx := 0
-	for i := 0; i < len(text); i++ {
-		if pIdx >= len(pattern) {
-			return true
-		}
-		if text[i] == pattern[pIdx] {
-			pIdx++
-		}
-	}
-	return pIdx == len(pattern)
+	_, ok := fuzzy.Match(pattern, text)
+	return ok
 }
 
-// FuzzySearch returns the top matches for pattern in candidates
+// FuzzySearch returns the candidates matching pattern, best match first.
+// It ranks the same way as FilterableList and the command palette.
 func FuzzySearch(pattern string, candidates []string) []string {
 	if pattern == "" {
 		return candidates
 	}
-
 	var matches []string
-	for _, c := range candidates {
-		if FuzzyMatch(pattern, c) {
-			matches = append(matches, c)
-		}
+	for _, r := range fuzzy.Rank(pattern, candidates) {
+		matches = append(matches, candidates[r.Index])
 	}
-
-	// Simple sort by length for now (shorter matches are usually better)
-	// A better scoring system would be ideal but this is a start
-	sort.Slice(matches, func(i, j int) bool {
-		return len(matches[i]) < len(matches[j])
-	})
-
 	return matches
 }
+
+// printMatched restyles the runes of text at positions, as reported by the
+// fuzzy package, assuming text was already printed at (x, y). Runes ending
+// past limit columns are left alone, so a truncation marker isn't covered.
+func printMatched(ctx *RenderContext, x, y int, text string, positions []int, limit int, style Style) {
+	if len(positions) == 0 {
+		return
+	}
+	col, p := 0, 0
+	for i, r := range []rune(text) {
+		if p == len(positions) {
+			return
+		}
+		w := runewidth.RuneWidth(r)
+		if col+w > limit {
+			return
+		}
+		if positions[p] == i {
+			if w > 0 {
+				ctx.SetCell(x+col, y, r, style)
+			}
+			p++
+		}
+		col += w
+	}
+}
@@ -81,3 +81,34 @@ func TestFuzzySearch(t *testing.T) {
 		assert.Contains(t, results, "hello world")
 	})
 }
+
+func TestFuzzySearch_Ranking(t *testing.T) {
+	// Word starts beat scattered matches, then shorter candidates win
+	results := FuzzySearch("fb", []string{"fabric", "foo_bar.go", "FooBar"})
+	assert.Equal(t, []string{"FooBar", "foo_bar.go", "fabric"}, results)
+}
+
+func TestFilterableList_FuzzyFilter(t *testing.T) {
+	selected := 0
+	filter := "ob"
+	items := []string{"Job queue", "Open buffers", "Toolbar"}
+	view := FilterableListStrings(items, &selected).Filter(&filter).Height(5)
+
+	// Below the filter input and separator, best match first
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, "Open buffers", screen.Row(2))
+	assert.Equal(t, "Job queue", screen.Row(3))
+	assert.Equal(t, "Toolbar", screen.Row(4))
+
+	// Matched characters are highlighted
+	assert.True(t, screen.Cell(0, 2).Style.Bold)
+	assert.False(t, screen.Cell(1, 2).Style.Bold)
+	assert.True(t, screen.Cell(5, 2).Style.Bold)
+
+	// A custom filter keeps the item order and highlights nothing
+	view = FilterableListStrings(items, &selected).Filter(&filter).Height(5).
+		FilterFunc(func(item ListItem, q string) bool { return item.Label != "Toolbar" })
+	screen = SprintScreen(view, PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, "Job queue", screen.Row(2))
+	assert.False(t, screen.Cell(1, 2).Style.Bold)
+}
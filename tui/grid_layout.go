@@ -0,0 +1,314 @@
+package tui
+
+import "image"
+
+// GridColumn sizes one column of a Grid. Create one with GridFixed, GridFr,
+// or GridAuto.
+type GridColumn struct {
+	width    int // Fixed width in cells, if fraction is 0 and not auto
+	fraction int // Share of the width left over by the other columns
+	auto     bool
+}
+
+// GridFixed returns a column width cells wide.
+func GridFixed(width int) GridColumn {
+	return GridColumn{width: max(0, width)}
+}
+
+// GridFr returns a column that takes fraction shares of the width left
+// over once fixed and auto columns are sized, like CSS's fr unit. Without a
+// width limit it is sized like an auto column.
+func GridFr(fraction int) GridColumn {
+	return GridColumn{fraction: max(1, fraction)}
+}
+
+// GridAuto returns a column as wide as its widest item. Items spanning
+// several columns don't widen it.
+func GridAuto() GridColumn {
+	return GridColumn{auto: true}
+}
+
+// gridItem is a child of a Grid with its span and alignment.
+type gridItem struct {
+	view      View
+	span      int
+	align     Alignment
+	hasAlign  bool
+	valign    Alignment
+	hasVAlign bool
+}
+
+// GridCell wraps a child of a Grid to set how many columns it spans and
+// how it is aligned in its cell. Children that aren't wrapped span one
+// column and use the grid's alignment.
+func GridCell(view View) *gridItem {
+	return &gridItem{view: view, span: 1}
+}
+
+// Span sets how many columns the item spans. It is cut to the number of
+// columns.
+func (g *gridItem) Span(columns int) *gridItem {
+	g.span = max(1, columns)
+	return g
+}
+
+// Align sets the horizontal alignment of the item within its cell.
+func (g *gridItem) Align(a Alignment) *gridItem {
+	g.align, g.hasAlign = a, true
+	return g
+}
+
+// VAlign sets the vertical alignment of the item within its row: AlignLeft
+// for the top, AlignCenter, or AlignRight for the bottom.
+func (g *gridItem) VAlign(a Alignment) *gridItem {
+	g.valign, g.hasVAlign = a, true
+	return g
+}
+
+func (g *gridItem) size(maxWidth, maxHeight int) (int, int) {
+	return g.view.size(maxWidth, maxHeight)
+}
+
+func (g *gridItem) render(ctx *RenderContext) {
+	g.view.render(ctx)
+}
+
+// gridLayoutView arranges children in rows of columns.
+type gridLayoutView struct {
+	columns   []GridColumn
+	items     []*gridItem
+	columnGap int
+	rowGap    int
+	align     Alignment
+	valign    Alignment
+}
+
+// Grid arranges children in rows across columns, filling each row from
+// left to right before starting the next, so dashboards line up without
+// nesting Stacks and Groups. Each column is fixed, a fraction of the free
+// width, or as wide as its content; wrap a child in GridCell to span
+// several columns or align it differently. A child that doesn't fit in
+// what is left of a row starts the next row. Each row is as tall as its
+// tallest item.
+//
+// Example:
+//
+//	tui.Grid(
+//	    []tui.GridColumn{tui.GridFixed(12), tui.GridFr(1), tui.GridFr(2)},
+//	    tui.Text("CPU"), cpuChart, cpuLog,
+//	    tui.Text("Memory"), memChart, memLog,
+//	    tui.GridCell(statusBar).Span(3),
+//	).Gap(1)
+func Grid(columns []GridColumn, children ...View) *gridLayoutView {
+	if len(columns) == 0 {
+		columns = []GridColumn{GridFr(1)}
+	}
+	items := make([]*gridItem, len(children))
+	for i, child := range children {
+		if item, ok := child.(*gridItem); ok {
+			items[i] = item
+		} else {
+			items[i] = GridCell(child)
+		}
+	}
+	return &gridLayoutView{columns: columns, items: items}
+}
+
+// Gap sets the space between both columns and rows.
+func (g *gridLayoutView) Gap(n int) *gridLayoutView {
+	g.columnGap, g.rowGap = n, n
+	return g
+}
+
+// ColumnGap sets the space between columns.
+func (g *gridLayoutView) ColumnGap(n int) *gridLayoutView {
+	g.columnGap = n
+	return g
+}
+
+// RowGap sets the space between rows.
+func (g *gridLayoutView) RowGap(n int) *gridLayoutView {
+	g.rowGap = n
+	return g
+}
+
+// Align sets the horizontal alignment of items within their cells:
+// AlignLeft (default), AlignCenter, or AlignRight.
+func (g *gridLayoutView) Align(a Alignment) *gridLayoutView {
+	g.align = a
+	return g
+}
+
+// VAlign sets the vertical alignment of items within their rows: AlignLeft
+// for the top (default), AlignCenter, or AlignRight for the bottom.
+func (g *gridLayoutView) VAlign(a Alignment) *gridLayoutView {
+	g.valign = a
+	return g
+}
+
+// gridPlacement is where an item goes in the grid.
+type gridPlacement struct {
+	row, col, span int
+	size           image.Point // The item's size in its cell
+}
+
+// gridLayout is the grid laid out for a width.
+type gridLayout struct {
+	widths  []int // Of each column
+	heights []int // Of each row
+	items   []gridPlacement
+}
+
+// place assigns each item a row and column.
+func (g *gridLayoutView) place() []gridPlacement {
+	placements := make([]gridPlacement, len(g.items))
+	row, col := 0, 0
+	for i, item := range g.items {
+		span := min(item.span, len(g.columns))
+		if col+span > len(g.columns) {
+			row, col = row+1, 0
+		}
+		placements[i] = gridPlacement{row: row, col: col, span: span}
+		col += span
+		if col == len(g.columns) {
+			row, col = row+1, 0
+		}
+	}
+	return placements
+}
+
+// spanWidth returns the width of span columns from col, with the gaps
+// between them.
+func (l *gridLayout) spanWidth(col, span, gap int) int {
+	w := gap * (span - 1)
+	for _, cw := range l.widths[col : col+span] {
+		w += cw
+	}
+	return w
+}
+
+// layout sizes the columns for maxWidth, 0 for no limit, and the rows.
+func (g *gridLayoutView) layout(maxWidth int) *gridLayout {
+	l := &gridLayout{widths: make([]int, len(g.columns)), items: g.place()}
+	gaps := g.columnGap * (len(g.columns) - 1)
+
+	// Fixed and auto columns first; without a limit, fractions are auto
+	used, totalFr := gaps, 0
+	for c, col := range g.columns {
+		switch {
+		case col.fraction > 0 && maxWidth > 0:
+			totalFr += col.fraction
+			continue
+		case col.auto || col.fraction > 0:
+			limit := 0
+			if maxWidth > 0 {
+				limit = max(0, maxWidth-used)
+			}
+			for i, p := range l.items {
+				if p.col == c && p.span == 1 {
+					w, _ := g.items[i].size(limit, 0)
+					l.widths[c] = max(l.widths[c], w)
+				}
+			}
+		default:
+			l.widths[c] = col.width
+		}
+		used += l.widths[c]
+	}
+
+	// Fractions share what is left, the remainder going to the last
+	if totalFr > 0 {
+		free := max(0, maxWidth-used)
+		given, last := 0, 0
+		for c, col := range g.columns {
+			if col.fraction > 0 {
+				l.widths[c] = free * col.fraction / totalFr
+				given += l.widths[c]
+				last = c
+			}
+		}
+		l.widths[last] += free - given
+	}
+
+	// Rows are as tall as their tallest item
+	for i, p := range l.items {
+		cellW := l.spanWidth(p.col, p.span, g.columnGap)
+		w, h := g.items[i].size(cellW, 0)
+		l.items[i].size = image.Point{X: min(w, cellW), Y: h}
+		for len(l.heights) <= p.row {
+			l.heights = append(l.heights, 0)
+		}
+		l.heights[p.row] = max(l.heights[p.row], h)
+	}
+	return l
+}
+
+func (g *gridLayoutView) size(maxWidth, maxHeight int) (int, int) {
+	if len(g.items) == 0 {
+		return 0, 0
+	}
+	l := g.layout(maxWidth)
+	w := l.spanWidth(0, len(l.widths), g.columnGap)
+	h := g.rowGap * (len(l.heights) - 1)
+	for _, rh := range l.heights {
+		h += rh
+	}
+	return w, h
+}
+
+func (g *gridLayoutView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 || len(g.items) == 0 {
+		return
+	}
+	l := g.layout(width)
+
+	// Where each column and row starts
+	xs := make([]int, len(l.widths))
+	for c := 1; c < len(xs); c++ {
+		xs[c] = xs[c-1] + l.widths[c-1] + g.columnGap
+	}
+	ys := make([]int, len(l.heights))
+	for r := 1; r < len(ys); r++ {
+		ys[r] = ys[r-1] + l.heights[r-1] + g.rowGap
+	}
+
+	for i, p := range l.items {
+		item := g.items[i]
+		cellW := l.spanWidth(p.col, p.span, g.columnGap)
+		cellH := l.heights[p.row]
+		size := p.size
+		if flex, ok := item.view.(Flexible); ok && flex.flex() > 0 {
+			size = image.Point{X: cellW, Y: cellH} // Flexible items fill their cell
+		}
+		if size.X == 0 || size.Y == 0 {
+			continue
+		}
+
+		align, valign := g.align, g.valign
+		if item.hasAlign {
+			align = item.align
+		}
+		if item.hasVAlign {
+			valign = item.valign
+		}
+		x, y := xs[p.col]+alignOffset(align, cellW, size.X), ys[p.row]+alignOffset(valign, cellH, size.Y)
+		if y >= height {
+			break
+		}
+		item.render(ctx.SubContext(image.Rect(x, y, x+size.X, y+size.Y)))
+	}
+}
+
+// alignOffset returns the offset of something size long aligned within
+// space: nothing for AlignLeft, half the free space for AlignCenter, and
+// all of it for AlignRight.
+func alignOffset(a Alignment, space, size int) int {
+	switch a {
+	case AlignCenter:
+		return max(0, space-size) / 2
+	case AlignRight:
+		return max(0, space-size)
+	}
+	return 0
+}
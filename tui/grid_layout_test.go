@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestGrid_ColumnSizing(t *testing.T) {
+	view := Grid(
+		[]GridColumn{GridFixed(4), GridAuto(), GridFr(1), GridFr(2)},
+		Text("a"), Text("bbb"), Text("c"), Text("d"),
+		Text("e"), Text("f"), Text("g"), Text("h"),
+	).ColumnGap(1)
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 2})
+	// 4, then 3 for "bbb"; the fractions share the 10 left
+	assert.Equal(t, "a    bbb c   d", screen.Row(0))
+	assert.Equal(t, "e    f   g   h", screen.Row(1))
+
+	w, h := view.size(20, 0)
+	assert.Equal(t, 20, w)
+	assert.Equal(t, 2, h)
+
+	// Without a width limit, fractions are as wide as their content
+	w, _ = view.size(0, 0)
+	assert.Equal(t, 4+1+3+1+1+1+1, w)
+}
+
+func TestGrid_SpanGapAlign(t *testing.T) {
+	view := Grid(
+		[]GridColumn{GridFixed(5), GridFixed(5)},
+		Text("ab").Wrap(), Stack(Text("x"), Text("y")),
+		GridCell(Text("wide")).Span(2).Align(AlignCenter),
+		Text("c"),
+		GridCell(Text("too wide")).Span(2),
+	).ColumnGap(1).RowGap(1).Align(AlignRight).VAlign(AlignRight)
+	screen := SprintScreen(view, PrintConfig{Width: 11, Height: 9})
+	assert.Equal(t, "          x", screen.Row(0))
+	assert.Equal(t, "   ab     y", screen.Row(1))
+	assert.Equal(t, "", screen.Row(2))
+	assert.Equal(t, "   wide", screen.Row(3))
+	assert.Equal(t, "", screen.Row(4))
+	// The span doesn't fit after "c", so it starts the next row
+	assert.Equal(t, "    c", screen.Row(5))
+	assert.Equal(t, "", screen.Row(6))
+	assert.Equal(t, "   too wide", screen.Row(7))
+}
@@ -4,11 +4,13 @@ import "image"
 
 // group arranges children horizontally
 type group struct {
-	children   []View
-	gap        int
-	alignment  Alignment
-	flexFactor int
-	childSizes []image.Point
+	children    []View
+	gap         int
+	joinBorders bool
+	alignment   Alignment
+	baseline    Baseline
+	flexFactor  int
+	childSizes  []image.Point
 }
 
 // Group creates a group that arranges children left-to-right (horizontal layout).
@@ -69,6 +71,31 @@ func (g *group) Gap(n int) *group {
 	return g
 }
 
+// JoinBorders overlaps neighboring children by one column and joins the
+// box-drawing characters where they meet, so side by side Bordered views
+// share one border with '┬' and '┴' junctions instead of drawing two. It
+// replaces the gap.
+//
+// Example:
+//
+//	Group(
+//	    Bordered(left).Border(&SingleBorder),
+//	    Bordered(right).Border(&SingleBorder),
+//	).JoinBorders()
+func (g *group) JoinBorders() *group {
+	g.joinBorders = true
+	return g
+}
+
+// spacing returns the space between visible children: the gap, or -1 when
+// the children overlap to join their borders.
+func (g *group) spacing() int {
+	if g.joinBorders {
+		return -1
+	}
+	return g.gap
+}
+
 // Align sets the vertical alignment of children within the group.
 // Options: AlignLeft (top), AlignCenter (middle), AlignRight (bottom).
 func (g *group) Align(a Alignment) *group {
@@ -76,6 +103,21 @@ func (g *group) Align(a Alignment) *group {
 	return g
 }
 
+// Baseline aligns children on their first or last line of text instead of
+// by Align, so a multi-line or bordered header lines up with one-line hints
+// next to it. The group grows as needed to fit children shifted down.
+//
+// Example:
+//
+//	Group(
+//	    Bordered(Text("Results")).Border(&RoundedBorder),
+//	    Text(" 42 matches").Dim(),
+//	).Baseline(BaselineFirst)
+func (g *group) Baseline(b Baseline) *group {
+	g.baseline = b
+	return g
+}
+
 func (g *group) size(maxWidth, maxHeight int) (int, int) {
 	if len(g.children) == 0 {
 		return 0, 0
@@ -120,7 +162,7 @@ func (g *group) size(maxWidth, maxHeight int) (int, int) {
 		// Estimate spacing for now (will be recalculated after measuring flex children)
 		estimatedSpacing := 0
 		if visibleCount+len(flexChildren) > 1 {
-			estimatedSpacing = g.gap * (visibleCount + len(flexChildren) - 1)
+			estimatedSpacing = g.spacing() * (visibleCount + len(flexChildren) - 1)
 		}
 
 		remainingWidth := maxWidth - totalFixedWidth - estimatedSpacing
@@ -133,6 +175,9 @@ func (g *group) size(maxWidth, maxHeight int) (int, int) {
 		totalMinWidth := 0
 		for i, idx := range flexChildren {
 			minW, _ := g.children[idx].size(0, maxHeight)
+			if weighted(g.children[idx]) {
+				minW = 0 // Sized by weight alone
+			}
 			minWidths[i] = minW
 			totalMinWidth += minW
 		}
@@ -187,17 +232,66 @@ func (g *group) size(maxWidth, maxHeight int) (int, int) {
 		totalWidth += size.X
 	}
 	if visibleCount > 1 {
-		totalWidth += g.gap * (visibleCount - 1)
+		totalWidth += g.spacing() * (visibleCount - 1)
+	}
+
+	if g.baseline != BaselineNone {
+		// Tall enough for the most text above and below the shared baseline
+		ascent, descent := g.baselineExtents()
+		maxChildHeight = ascent + descent
+		if maxHeight > 0 && maxChildHeight > maxHeight {
+			maxChildHeight = maxHeight
+		}
 	}
 
 	return totalWidth, maxChildHeight
 }
 
+// baselineExtents returns the most rows any child has above its baseline
+// (including the baseline row) and below it.
+func (g *group) baselineExtents() (ascent, descent int) {
+	for i, child := range g.children {
+		size := g.childSizes[i]
+		if size.X == 0 && size.Y == 0 {
+			continue
+		}
+		b := baselineOf(child, size.X, size.Y, g.baseline)
+		ascent = max(ascent, b+1)
+		descent = max(descent, size.Y-b-1)
+	}
+	return ascent, descent
+}
+
+// childOffsets returns the Y position of each child for the given height,
+// based on the group's alignment or baseline.
+func (g *group) childOffsets(height int) []int {
+	offsets := make([]int, len(g.children))
+	ascent := 0
+	if g.baseline != BaselineNone {
+		ascent, _ = g.baselineExtents()
+	}
+	for i, child := range g.children {
+		size := g.childSizes[i]
+		switch {
+		case g.baseline != BaselineNone:
+			offsets[i] = ascent - 1 - baselineOf(child, size.X, size.Y, g.baseline)
+		case itemAlignment(child, g.alignment) == AlignCenter:
+			offsets[i] = (height - size.Y) / 2
+		case itemAlignment(child, g.alignment) == AlignRight:
+			offsets[i] = height - size.Y
+		}
+	}
+	return offsets
+}
+
 func (g *group) render(ctx *RenderContext) {
 	width, height := ctx.Size()
 	if width == 0 || height == 0 || len(g.children) == 0 {
 		return
 	}
+	if g.joinBorders {
+		ctx = ctx.joiningBorders()
+	}
 
 	// Re-measure with actual bounds
 	g.size(width, height)
@@ -212,7 +306,7 @@ func (g *group) render(ctx *RenderContext) {
 		}
 	}
 	if visibleCount > 1 {
-		totalWidth += g.gap * (visibleCount - 1)
+		totalWidth += g.spacing() * (visibleCount - 1)
 	}
 
 	// If total exceeds available width, shrink children proportionally
@@ -221,7 +315,7 @@ func (g *group) render(ctx *RenderContext) {
 		// Calculate scale factor, reserving space for gaps
 		gapSpace := 0
 		if visibleCount > 1 {
-			gapSpace = g.gap * (visibleCount - 1)
+			gapSpace = g.spacing() * (visibleCount - 1)
 		}
 		availableForChildren := width - gapSpace
 		if availableForChildren < 0 {
@@ -258,6 +352,7 @@ func (g *group) render(ctx *RenderContext) {
 		}
 	}
 
+	offsets := g.childOffsets(height)
 	currentX := 0
 	renderedVisible := false
 
@@ -265,22 +360,17 @@ func (g *group) render(ctx *RenderContext) {
 		size := g.childSizes[i]
 		// Skip empty children (both dimensions zero)
 		if size.X == 0 && size.Y == 0 {
+			renderFloating(ctx, child)
 			continue
 		}
 
 		// Add gap before this child if we've already rendered a visible child
-		if renderedVisible && g.gap > 0 {
-			currentX += g.gap
+		if renderedVisible && g.spacing() != 0 {
+			currentX += g.spacing()
 		}
 
-		// Calculate Y position based on alignment
-		y := 0
-		switch g.alignment {
-		case AlignCenter:
-			y = (height - size.Y) / 2
-		case AlignRight:
-			y = height - size.Y
-		}
+		// Y position from alignment or baseline
+		y := offsets[i]
 
 		// Clip to bounds
 		if currentX >= width {
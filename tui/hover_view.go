@@ -0,0 +1,145 @@
+package tui
+
+import "image"
+
+// hoverableView tells a callback when the mouse pointer enters or leaves its
+// inner view.
+type hoverableView struct {
+	inner   View
+	onHover func(hovered bool)
+}
+
+// Hoverable calls onHover with true when the mouse pointer enters the inner
+// view and with false when it leaves, for hover effects on any view. Keep
+// the hover state in the app and choose styles from it in View. Hover
+// requires mouse tracking (WithMouseTracking).
+//
+// Example:
+//
+//	Hoverable(
+//	    Text("Open").Style(style),
+//	    func(hovered bool) { app.openHovered = hovered },
+//	)
+func Hoverable(inner View, onHover func(hovered bool)) View {
+	return &hoverableView{inner: inner, onHover: onHover}
+}
+
+func (h *hoverableView) size(maxWidth, maxHeight int) (int, int) {
+	return h.inner.size(maxWidth, maxHeight)
+}
+
+// flex implements the Flexible interface by delegating to the inner view.
+func (h *hoverableView) flex() int {
+	if flex, ok := h.inner.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (h *hoverableView) render(ctx *RenderContext) {
+	if h.onHover != nil {
+		interactiveRegistry.RegisterHover(ctx.AbsoluteBounds(), h.onHover)
+	}
+	h.inner.render(ctx)
+}
+
+// DragPhase is the stage of a drag reported by a DragEvent.
+type DragPhase int
+
+const (
+	// DragStart is reported when the pointer first moves with the button held.
+	DragStart DragPhase = iota
+	// DragMove is reported each time the pointer moves after that.
+	DragMove
+	// DragEnd is reported when the button is released.
+	DragEnd
+)
+
+// DragEvent describes a drag of a Draggable view. Positions are screen
+// coordinates.
+type DragEvent struct {
+	Phase          DragPhase
+	StartX, StartY int             // Where the button was pressed
+	X, Y           int             // Where the pointer is now
+	Bounds         image.Rectangle // The view's screen bounds when the drag started
+}
+
+// Delta returns how far the pointer has moved since the drag started.
+func (e DragEvent) Delta() (dx, dy int) {
+	return e.X - e.StartX, e.Y - e.StartY
+}
+
+// draggableView reports drags that start on its inner view.
+type draggableView struct {
+	inner  View
+	onDrag func(DragEvent)
+}
+
+// Draggable calls onDrag as the inner view is dragged with the left mouse
+// button, for drag-to-resize and drag-to-reorder interactions. A press that
+// is released without moving is not a drag, so it can still be a click. The
+// drag continues wherever the pointer goes until the button is released.
+// Dragging requires mouse tracking (WithMouseTracking).
+//
+// Example:
+//
+//	// Resize a sidebar by dragging its edge
+//	Draggable(Text("┃"), func(e tui.DragEvent) {
+//	    if e.Phase == tui.DragStart {
+//	        app.startWidth = app.sidebarWidth
+//	    }
+//	    dx, _ := e.Delta()
+//	    app.sidebarWidth = app.startWidth + dx
+//	})
+func Draggable(inner View, onDrag func(DragEvent)) View {
+	return &draggableView{inner: inner, onDrag: onDrag}
+}
+
+func (d *draggableView) size(maxWidth, maxHeight int) (int, int) {
+	return d.inner.size(maxWidth, maxHeight)
+}
+
+// flex implements the Flexible interface by delegating to the inner view.
+func (d *draggableView) flex() int {
+	if flex, ok := d.inner.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (d *draggableView) render(ctx *RenderContext) {
+	if d.onDrag != nil {
+		// Register before drawing so that draggable views inside take priority
+		interactiveRegistry.RegisterDrag(ctx.AbsoluteBounds(), newDragTracker(ctx.AbsoluteBounds(), d.onDrag))
+	}
+	d.inner.render(ctx)
+}
+
+// newDragTracker returns a drag region callback that turns the press, drag,
+// and release events of one drag into DragEvents.
+func newDragTracker(bounds image.Rectangle, onDrag func(DragEvent)) func(MouseEvent) {
+	var event DragEvent
+	dragging := false
+	return func(e MouseEvent) {
+		switch e.Type {
+		case MousePress:
+			event = DragEvent{StartX: e.X, StartY: e.Y, X: e.X, Y: e.Y, Bounds: bounds}
+			dragging = false
+		case MouseDrag:
+			event.X, event.Y = e.X, e.Y
+			event.Phase = DragMove
+			if !dragging {
+				event.Phase = DragStart
+				dragging = true
+			}
+			onDrag(event)
+		case MouseRelease:
+			if dragging {
+				event.X, event.Y = e.X, e.Y
+				event.Phase = DragEnd
+				dragging = false
+				onDrag(event)
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestHoverable_EnterLeave(t *testing.T) {
+	interactiveRegistry.Clear()
+	defer interactiveRegistry.Clear()
+	interactiveRegistry.HandleHover(MouseEvent{Type: MouseLeave})
+
+	var got []string
+	view := Stack(
+		Hoverable(Text("first"), func(hovered bool) { got = append(got, "first", boolString(hovered)) }),
+		Hoverable(Text("second"), func(hovered bool) { got = append(got, "second", boolString(hovered)) }),
+	)
+	SprintScreen(view, PrintConfig{Width: 10, Height: 2})
+
+	interactiveRegistry.HandleHover(MouseEvent{Type: MouseMove, X: 1, Y: 0})
+	interactiveRegistry.HandleHover(MouseEvent{Type: MouseMove, X: 2, Y: 0})
+	interactiveRegistry.HandleHover(MouseEvent{Type: MouseMove, X: 1, Y: 1})
+	interactiveRegistry.HandleHover(MouseEvent{Type: MouseLeave})
+	assert.Equal(t, got, []string{"first", "in", "first", "out", "second", "in", "second", "out"})
+}
+
+func boolString(hovered bool) string {
+	if hovered {
+		return "in"
+	}
+	return "out"
+}
+
+func TestButton_HoverStyle(t *testing.T) {
+	buf := &bytes.Buffer{}
+	term := NewTestTerminal(20, 3, buf)
+	defer interactiveRegistry.HandleHover(MouseEvent{Type: MouseLeave})
+	// The first button takes focus, so hover the second
+	hover := NewStyle().WithForeground(ColorRed)
+	app := &simpleApp{
+		renderFunc: func() View {
+			return Stack(
+				Button("Yes", func() {}).ID("yes").HoverStyle(hover),
+				Button("No", func() {}).ID("no").HoverStyle(hover),
+			)
+		},
+		handleFunc: func(Event) []Cmd { return nil },
+	}
+	runtime := NewRuntime(term, app, 30)
+	runtime.render()
+	assert.Equal(t, term.GetCell(0, 1).Style, NewStyle())
+
+	runtime.processEvent(MouseEvent{Type: MouseMove, X: 1, Y: 1})
+	runtime.render()
+	assert.Equal(t, term.GetCell(0, 1).Style, hover)
+
+	runtime.processEvent(MouseEvent{Type: MouseMove, X: 10, Y: 1})
+	runtime.render()
+	assert.Equal(t, term.GetCell(0, 1).Style, NewStyle())
+}
+
+func TestDraggable_Events(t *testing.T) {
+	interactiveRegistry.Clear()
+	defer interactiveRegistry.Clear()
+
+	var events []DragEvent
+	SprintScreen(Draggable(Text("handle"), func(e DragEvent) {
+		events = append(events, e)
+	}), PrintConfig{Width: 10, Height: 1})
+
+	// A press and release without moving is a click, not a drag
+	assert.True(t, interactiveRegistry.HandleDrag(MouseEvent{Type: MousePress, Button: MouseButtonLeft, X: 2, Y: 0}))
+	assert.True(t, interactiveRegistry.HandleDrag(MouseEvent{Type: MouseRelease, X: 2, Y: 0}))
+	assert.Equal(t, len(events), 0)
+
+	assert.True(t, interactiveRegistry.HandleDrag(MouseEvent{Type: MousePress, Button: MouseButtonLeft, X: 2, Y: 0}))
+	interactiveRegistry.Clear() // a new frame doesn't end the drag
+	assert.True(t, interactiveRegistry.HandleDrag(MouseEvent{Type: MouseDrag, Button: MouseButtonLeft, X: 5, Y: 0}))
+	assert.True(t, interactiveRegistry.HandleDrag(MouseEvent{Type: MouseDrag, Button: MouseButtonLeft, X: 15, Y: 3}))
+	assert.True(t, interactiveRegistry.HandleDrag(MouseEvent{Type: MouseRelease, X: 14, Y: 3}))
+
+	assert.Equal(t, len(events), 3)
+	assert.Equal(t, events[0].Phase, DragStart)
+	assert.Equal(t, events[1].Phase, DragMove)
+	assert.Equal(t, events[2].Phase, DragEnd)
+	dx, dy := events[2].Delta()
+	assert.Equal(t, dx, 12)
+	assert.Equal(t, dy, 3)
+	assert.Equal(t, events[2].Bounds, image.Rect(0, 0, 10, 1))
+}
@@ -126,6 +126,10 @@ type InlineAppConfig struct {
 	BracketedPaste bool      // Enable bracketed paste mode.
 	PasteTabWidth  int       // 0 = preserve tabs. Convert tabs to N spaces in pastes.
 	KittyKeyboard  bool      // Enable Kitty keyboard protocol.
+
+	// OnSlowFrame is called on the event loop with each tick that takes
+	// longer to handle and draw than the time between frames. nil = no hook.
+	OnSlowFrame func(SlowFrame)
 }
 
 func (c InlineAppConfig) withDefaults() InlineAppConfig {
@@ -168,6 +172,7 @@ type InlineApp struct {
 	done   chan struct{}
 	ticker *time.Ticker
 	frame  uint64
+	clock  *frameClock
 
 	// Focus management
 	focusMgr *FocusManager
@@ -295,6 +300,7 @@ func (r *InlineApp) Run(app any) error {
 	// Start ticker if FPS > 0
 	if r.config.FPS > 0 {
 		r.ticker = time.NewTicker(time.Second / time.Duration(r.config.FPS))
+		r.clock = newFrameClock(r.config.FPS)
 	}
 
 	// Initialize resize watcher (platform-specific)
@@ -414,13 +420,15 @@ func (r *InlineApp) eventLoop() {
 			return nil
 		}():
 			// Send tick event for animations
-			r.frame++
-			tickEvent := TickEvent{
-				Time:  time.Now(),
-				Frame: r.frame,
-			}
+			tickEvent := r.clock.tick(time.Now())
+			r.frame = tickEvent.Frame
 			r.processEvent(tickEvent)
 			r.render()
+			if r.config.OnSlowFrame != nil {
+				if slow, ok := r.clock.slowFrame(tickEvent, time.Since(tickEvent.Time)); ok {
+					r.config.OnSlowFrame(slow)
+				}
+			}
 
 		case <-r.done:
 			return
@@ -476,12 +484,21 @@ func (r *InlineApp) processEvent(event Event) {
 	// Route events to interactive elements via focus manager
 	switch e := event.(type) {
 	case MouseEvent:
-		if e.Type == MouseClick {
+		interactiveRegistry.HandleHover(e)
+		rightClicked := e.Type == MouseClick && e.Button == MouseButtonRight &&
+			interactiveRegistry.HandleRightClick(e.X, e.Y)
+		if e.Type == MouseClick && !rightClicked {
 			r.focusMgr.HandleClick(e.X, e.Y)
 			interactiveRegistry.HandleClick(e.X, e.Y)
 		}
+		if delta := wheelDelta(e); delta != 0 {
+			interactiveRegistry.HandleScroll(e.X, e.Y, delta)
+		}
+		interactiveRegistry.HandleDrag(e)
 	case KeyEvent:
-		r.focusMgr.HandleKey(e)
+		if !interactiveRegistry.HandleKeyCapture(e) && !r.focusMgr.HandleKey(e) {
+			interactiveRegistry.HandleKey(e)
+		}
 	}
 
 	// Call user's event handler
@@ -500,6 +517,10 @@ func (r *InlineApp) processEvent(event Event) {
 			}
 		}
 	}
+
+	if key, ok := event.(KeyEvent); ok && key.Paste != "" {
+		r.processEvent(PasteEvent{Time: key.Timestamp(), Text: key.Paste})
+	}
 }
 
 // render calls the application's LiveView() and updates the live region.
@@ -514,6 +535,13 @@ func (r *InlineApp) render() {
 		interactiveRegistry.Clear()
 		inputRegistry.Clear()
 		textAreaRegistry.Clear()
+		tableEditRegistry.Clear()
+		tableScrollRegistry.Clear()
+		tableLayoutRegistry.Clear()
+		tableDetailRegistry.Clear()
+		selectRegistry.Clear()
+		contextMenuRegistry.Clear()
+		transitionRegistry.Clear()
 
 		view := app.LiveView()
 
@@ -522,6 +550,13 @@ func (r *InlineApp) render() {
 
 		// Prune TextArea state for IDs that weren't rendered
 		textAreaRegistry.Prune()
+		tableEditRegistry.Prune()
+		tableScrollRegistry.Prune()
+		tableLayoutRegistry.Prune()
+		tableDetailRegistry.Prune()
+		selectRegistry.Prune()
+		contextMenuRegistry.Prune()
+		transitionRegistry.Prune()
 	}
 }
 
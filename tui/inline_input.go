@@ -277,8 +277,8 @@ func (s *promptState) handleKey(event terminal.KeyEvent, prompt string) (string,
 	// Handle special keys
 	switch event.Key {
 	case terminal.KeyEnter:
-		// Shift+Enter for newline in multi-line mode
-		if s.config.multiLine && event.Shift {
+		// Shift/Alt/Ctrl+Enter for newline in multi-line mode
+		if s.config.multiLine && event.IsModifiedEnter() {
 			s.insertRune('\n')
 			return "", false, nil
 		}
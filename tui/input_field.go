@@ -24,6 +24,9 @@ type inputFieldView struct {
 	cursorShape      InputCursorStyle
 	cursorColor      *Color
 	multiline        bool
+	history          *InputHistory
+	keyHandler       InputKeyHandler
+	codeEditing      CodeEditing
 
 	// Label configuration
 	label           string
@@ -158,6 +161,28 @@ func (f *inputFieldView) Multiline(enabled bool) *inputFieldView {
 	return f
 }
 
+// History enables recalling submitted values with Up and Down. Values are
+// added to the history when Enter is pressed.
+func (f *inputFieldView) History(h *InputHistory) *inputFieldView {
+	f.history = h
+	return f
+}
+
+// KeyHandler sets a handler that sees keys before the input's own editing
+// keys, such as a ViMode for vi-style modal editing.
+func (f *inputFieldView) KeyHandler(h InputKeyHandler) *inputFieldView {
+	f.keyHandler = h
+	return f
+}
+
+// CodeEditing turns on editing helpers for code, such as bracket pairing,
+// automatic indentation, and comment toggling. When its TabWidth is set, Tab
+// indents instead of moving focus while the input is focused.
+func (f *inputFieldView) CodeEditing(c CodeEditing) *inputFieldView {
+	f.codeEditing = c
+	return f
+}
+
 // Bordered enables a border around the input.
 func (f *inputFieldView) Bordered() *inputFieldView {
 	f.bordered = true
@@ -211,7 +236,8 @@ func (f *inputFieldView) PromptStyle(s Style) *inputFieldView {
 }
 
 // CursorShape sets the cursor shape/style for the input.
-// Options are: InputCursorBlock (default), InputCursorUnderline, InputCursorBar.
+// Options are: InputCursorBlock (default), InputCursorUnderline, InputCursorBar,
+// and InputCursorTerminal to use the terminal's own cursor.
 func (f *inputFieldView) CursorShape(shape InputCursorStyle) *inputFieldView {
 	f.cursorShape = shape
 	return f
@@ -392,18 +418,25 @@ func (f *inputFieldView) renderBorderedInput(ctx *RenderContext, x, w, h int, is
 		}
 	}
 
+	// Strip a trailing colon from the label for a cleaner look. With the
+	// theme's focus indicators, focus also shows without color.
+	border := f.border
+	label := strings.TrimSuffix(strings.TrimSuffix(f.label, ": "), ":")
+	if isFocused {
+		border, label = focusedBorder(ctx, border), focusedTitle(ctx, label)
+	}
+
 	// Draw top border with embedded label
 	// Format: ╭─ Label ─────────────╮
-	ctx.PrintTruncated(x, 0, f.border.TopLeft, borderStyle)
+	ctx.PrintTruncated(x, 0, border.TopLeft, borderStyle)
 	bx := x + 1
 
-	if f.label != "" && w > 4 {
+	if label != "" && w > 4 {
 		// Draw horizontal line before label
-		ctx.PrintTruncated(bx, 0, f.border.Horizontal, borderStyle)
+		ctx.PrintTruncated(bx, 0, border.Horizontal, borderStyle)
 		bx++
 
-		// Draw label with space padding, stripping trailing colon for cleaner look
-		label := strings.TrimSuffix(strings.TrimSuffix(f.label, ": "), ":")
+		// Draw label with space padding
 		labelText := " " + label + " "
 		labelW, _ := MeasureText(labelText)
 		maxLabelW := w - 4 // Leave room for corners and some border
@@ -416,28 +449,28 @@ func (f *inputFieldView) renderBorderedInput(ctx *RenderContext, x, w, h int, is
 
 	// Fill rest of top border
 	for ; bx < x+w-1; bx++ {
-		ctx.PrintTruncated(bx, 0, f.border.Horizontal, borderStyle)
+		ctx.PrintTruncated(bx, 0, border.Horizontal, borderStyle)
 	}
 	if w > 1 {
-		ctx.PrintTruncated(x+w-1, 0, f.border.TopRight, borderStyle)
+		ctx.PrintTruncated(x+w-1, 0, border.TopRight, borderStyle)
 	}
 
 	// Side borders
 	for y := 1; y < h-1; y++ {
-		ctx.PrintTruncated(x, y, f.border.Vertical, borderStyle)
+		ctx.PrintTruncated(x, y, border.Vertical, borderStyle)
 		if w > 1 {
-			ctx.PrintTruncated(x+w-1, y, f.border.Vertical, borderStyle)
+			ctx.PrintTruncated(x+w-1, y, border.Vertical, borderStyle)
 		}
 	}
 
 	// Bottom border
 	if h > 1 {
-		ctx.PrintTruncated(x, h-1, f.border.BottomLeft, borderStyle)
+		ctx.PrintTruncated(x, h-1, border.BottomLeft, borderStyle)
 		for bx := x + 1; bx < x+w-1; bx++ {
-			ctx.PrintTruncated(bx, h-1, f.border.Horizontal, borderStyle)
+			ctx.PrintTruncated(bx, h-1, border.Horizontal, borderStyle)
 		}
 		if w > 1 {
-			ctx.PrintTruncated(x+w-1, h-1, f.border.BottomRight, borderStyle)
+			ctx.PrintTruncated(x+w-1, h-1, border.BottomRight, borderStyle)
 		}
 	}
 
@@ -472,15 +505,22 @@ func (f *inputFieldView) renderHorizontalBarInput(ctx *RenderContext, x, w, h in
 		}
 	}
 
-	// Get border character (use horizontal from border style, or default to simple line)
-	borderChar := "─"
-	if f.border != nil {
-		borderChar = f.border.Horizontal
+	// Get border character (use horizontal from border style, or default to
+	// simple line). With the theme's focus indicators, focus also shows
+	// without color.
+	border := f.border
+	if border == nil {
+		border = &SingleBorder
 	}
+	label := strings.TrimSuffix(strings.TrimSuffix(f.label, ": "), ":")
+	if isFocused {
+		border, label = focusedBorder(ctx, border), focusedTitle(ctx, label)
+	}
+	borderChar := border.Horizontal
 
 	// Draw top border
 	topY := 0
-	if f.label != "" && w > 4 {
+	if label != "" && w > 4 {
 		// Draw a few border characters before the label
 		bx := x
 		prefixChars := 2 // Number of border chars before label
@@ -490,7 +530,6 @@ func (f *inputFieldView) renderHorizontalBarInput(ctx *RenderContext, x, w, h in
 		}
 
 		// Draw label
-		label := strings.TrimSuffix(strings.TrimSuffix(f.label, ": "), ":")
 		labelText := " " + label + " "
 		labelW, _ := MeasureText(labelText)
 		maxLabelW := w - prefixChars - 2
@@ -554,10 +593,22 @@ func (f *inputFieldView) renderInput(ctx *RenderContext, isFocused bool) {
 	if f.cursorColor != nil {
 		state.input.CursorColor = f.cursorColor
 	}
+	state.input.History = f.history
+	state.input.KeyHandler = f.keyHandler
+	state.input.CodeEditing = f.codeEditing
+	if isFocused && f.codeEditing.TabWidth > 0 {
+		// Take Tab before the focus manager moves focus with it
+		interactiveRegistry.RegisterKeyCapture(func(e KeyEvent) bool {
+			if e.Key != KeyTab || e.Ctrl {
+				return false
+			}
+			return state.HandleKeyEvent(e)
+		})
+	}
 
 	// Update TextInput bounds
 	state.input.SetBounds(inputBounds)
 
 	// Draw the TextInput
-	state.input.Draw(ctx.frame)
+	drawInput(ctx, state.input)
 }
@@ -0,0 +1,85 @@
+package tui
+
+// InputHistory records submitted values of a text input so they can be
+// recalled with the Up and Down keys, like a shell or chat prompt. Attach it
+// with InputField.History or TextInput.WithHistory; submitted values are
+// added automatically.
+//
+// A history can be shared by several inputs, and kept across sessions by
+// saving Entries and restoring them with Add.
+type InputHistory struct {
+	entries []string
+	max     int
+
+	// Navigation state: index into entries while browsing (len(entries)
+	// when not browsing), and the text that was being edited before
+	// browsing started.
+	index int
+	draft string
+}
+
+// NewInputHistory creates a history that keeps at most max entries. A max of
+// 0 keeps every entry.
+func NewInputHistory(max int) *InputHistory {
+	return &InputHistory{max: max}
+}
+
+// Add appends an entry, ending any browsing in progress. Empty entries and
+// repeats of the most recent entry are ignored.
+func (h *InputHistory) Add(entry string) {
+	h.index = len(h.entries)
+	if entry == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry) {
+		return
+	}
+	h.entries = append(h.entries, entry)
+	if h.max > 0 && len(h.entries) > h.max {
+		h.entries = h.entries[len(h.entries)-h.max:]
+	}
+	h.index = len(h.entries)
+}
+
+// Entries returns the entries, oldest first. The slice must not be modified.
+func (h *InputHistory) Entries() []string {
+	return h.entries
+}
+
+// Len returns the number of entries.
+func (h *InputHistory) Len() int {
+	return len(h.entries)
+}
+
+// Prev returns the entry before the one being shown. current is the input's
+// text, saved when browsing starts so Next can restore it. It returns false
+// when there is no earlier entry.
+func (h *InputHistory) Prev(current string) (string, bool) {
+	if h.index > len(h.entries) {
+		h.index = len(h.entries)
+	}
+	if h.index == 0 {
+		return "", false
+	}
+	if h.index == len(h.entries) {
+		h.draft = current
+	}
+	h.index--
+	return h.entries[h.index], true
+}
+
+// Next returns the entry after the one being shown, or the saved draft after
+// the newest entry. It returns false when not browsing.
+func (h *InputHistory) Next() (string, bool) {
+	if h.index >= len(h.entries) {
+		return "", false
+	}
+	h.index++
+	if h.index == len(h.entries) {
+		return h.draft, true
+	}
+	return h.entries[h.index], true
+}
+
+// Reset ends browsing so the next Prev starts from the newest entry.
+func (h *InputHistory) Reset() {
+	h.index = len(h.entries)
+	h.draft = ""
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestInputHistory_Browse(t *testing.T) {
+	h := NewInputHistory(0)
+	h.Add("one")
+	h.Add("two")
+
+	_, ok := h.Next()
+	assert.False(t, ok)
+
+	entry, ok := h.Prev("draft")
+	assert.True(t, ok)
+	assert.Equal(t, "two", entry)
+	entry, _ = h.Prev("two")
+	assert.Equal(t, "one", entry)
+	_, ok = h.Prev("one")
+	assert.False(t, ok)
+
+	entry, _ = h.Next()
+	assert.Equal(t, "two", entry)
+	entry, ok = h.Next()
+	assert.True(t, ok)
+	assert.Equal(t, "draft", entry)
+	_, ok = h.Next()
+	assert.False(t, ok)
+}
+
+func TestInputHistory_Add(t *testing.T) {
+	h := NewInputHistory(2)
+	h.Add("one")
+	h.Add("")
+	h.Add("two")
+	h.Add("two")
+	h.Add("three")
+	assert.Equal(t, []string{"two", "three"}, h.Entries())
+
+	h.Prev("")
+	h.Add("four")
+	entry, _ := h.Prev("")
+	assert.Equal(t, "four", entry)
+}
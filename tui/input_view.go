@@ -68,7 +68,8 @@ func (s *inputState) HandleKeyEvent(event KeyEvent) bool {
 	}
 
 	// Handle Enter for submit (unless Shift is pressed for multiline newlines)
-	if event.Key == KeyEnter && !event.Shift {
+	if event.Key == KeyEnter && !event.IsModifiedEnter() {
+		s.input.recordSubmit(s.input.Value())
 		if s.onSubmit != nil {
 			s.onSubmit(s.input.Value())
 		}
@@ -89,11 +90,15 @@ func (s *inputState) HandleKeyEvent(event KeyEvent) bool {
 	return handled
 }
 
-// Clear clears input tracking (called before each render).
+// Clear clears input tracking (called before each render). Inputs keep
+// their text and cursor, but lose their bounds until they are rendered
+// again, so an input that isn't shown can't be hit.
 func (r *inputRegistryImpl) Clear() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	// Note: we don't clear the inputs map, just let focus manager handle order
+	for _, state := range r.inputs {
+		state.bounds = image.Rectangle{}
+	}
 }
 
 // Register adds or updates an input.
@@ -393,5 +398,5 @@ func (i *inputView) render(ctx *RenderContext) {
 	state.input.SetBounds(inputBounds)
 
 	// Draw the TextInput - pass the underlying frame
-	state.input.Draw(ctx.frame)
+	drawInput(ctx, state.input)
 }
@@ -0,0 +1,368 @@
+package tui
+
+import (
+	"fmt"
+	"image"
+)
+
+// ListState holds the selection and scroll position of an ItemList. Views
+// are rebuilt every frame, so keep a ListState in your application model and
+// pass it to ItemList each time.
+type ListState struct {
+	Selected int // Index of the selected item
+	Offset   int // Index of the first visible item
+
+	// From the last render, for key handling and wheel scrolling
+	count    int
+	pageSize int
+}
+
+// Select selects the item at index, clamped to the items from the last
+// render. The list scrolls to show it on the next render.
+func (s *ListState) Select(index int) {
+	s.Selected = index
+	s.clamp()
+}
+
+// HandleKey moves the selection for the list navigation keys: Up/Down and
+// k/j move by one item, PageUp/PageDown by a page, and Home/End or g/G jump
+// to the first or last item. It returns true if the key was a navigation
+// key, so applications that route keys themselves can call it from
+// HandleEvent. Keys with Ctrl or Alt held are ignored.
+func (s *ListState) HandleKey(event KeyEvent) bool {
+	if event.Ctrl || event.Alt || s.count == 0 {
+		return false
+	}
+	page := s.pageSize
+	if page < 1 {
+		page = 1
+	}
+
+	switch {
+	case event.Key == KeyArrowUp || event.Rune == 'k':
+		s.Select(s.Selected - 1)
+	case event.Key == KeyArrowDown || event.Rune == 'j':
+		s.Select(s.Selected + 1)
+	case event.Key == KeyPageUp:
+		s.Select(s.Selected - page)
+	case event.Key == KeyPageDown:
+		s.Select(s.Selected + page)
+	case event.Key == KeyHome || event.Rune == 'g':
+		s.Select(0)
+	case event.Key == KeyEnd || event.Rune == 'G':
+		s.Select(s.count - 1)
+	default:
+		return false
+	}
+	return true
+}
+
+// Scroll moves the visible window by delta items, dragging the selection
+// along so it stays visible.
+func (s *ListState) Scroll(delta int) {
+	s.Offset += delta
+	s.clamp()
+	if s.Selected < s.Offset {
+		s.Selected = s.Offset
+	}
+	if s.pageSize > 0 && s.Selected >= s.Offset+s.pageSize {
+		s.Selected = s.Offset + s.pageSize - 1
+	}
+}
+
+// clamp keeps the selection within the items and the offset within the
+// scrollable range.
+func (s *ListState) clamp() {
+	if s.Selected >= s.count {
+		s.Selected = s.count - 1
+	}
+	if s.Selected < 0 {
+		s.Selected = 0
+	}
+	maxOffset := s.count - s.pageSize
+	if s.Offset > maxOffset {
+		s.Offset = maxOffset
+	}
+	if s.Offset < 0 {
+		s.Offset = 0
+	}
+}
+
+// layout records the item count and page size of a render and scrolls the
+// selection into view.
+func (s *ListState) layout(count, pageSize int) {
+	s.count = count
+	s.pageSize = pageSize
+	s.clamp()
+	if s.Selected < s.Offset {
+		s.Offset = s.Selected
+	}
+	if s.Selected >= s.Offset+pageSize {
+		s.Offset = s.Selected - pageSize + 1
+	}
+}
+
+// itemListView displays a scrolling list of items with a selection.
+type itemListView[T any] struct {
+	id       string
+	items    []T
+	state    *ListState
+	renderer func(item T, index int, selected bool) View
+	bounds   image.Rectangle
+	focused  bool
+
+	style         Style
+	selectedStyle Style
+	itemHeight    int
+	width         int
+	height        int
+	loading       bool
+
+	onSelect   func(item T, index int)
+	onActivate func(item T, index int)
+}
+
+// ItemList creates a scrolling list of items with a selection. The list
+// keeps the selected item visible and handles list navigation keys (see
+// ListState.HandleKey), the mouse wheel, and click-to-select. Enter
+// activates the selected item.
+//
+// Items are drawn with fmt.Sprint (or the label of a ListItem) unless a
+// renderer is set with Render. Keys reach the list when it has focus, which
+// requires an ID; applications that route keys themselves can call
+// state.HandleKey instead. With a nil state the list starts at the first
+// item each frame.
+//
+// Example:
+//
+//	tui.ItemList(app.files, &app.fileList).
+//	    ID("files").
+//	    Render(func(f File, i int, selected bool) tui.View {
+//	        return tui.Text("%s", f.Name).Bold()
+//	    }).
+//	    OnSelect(func(f File, i int) { app.preview = f }).
+//	    OnActivate(func(f File, i int) { app.open(f) })
+func ItemList[T any](items []T, state *ListState) *itemListView[T] {
+	if state == nil {
+		state = &ListState{}
+	}
+	return &itemListView[T]{
+		items:         items,
+		state:         state,
+		style:         NewStyle(),
+		selectedStyle: NewStyle().WithReverse(),
+		itemHeight:    1,
+	}
+}
+
+// ID sets the focus ID, making the list focusable with Tab and by clicking.
+func (l *itemListView[T]) ID(id string) *itemListView[T] {
+	l.id = id
+	return l
+}
+
+// Render sets the function that builds the view for each item. It is drawn
+// into a row of ItemHeight lines.
+func (l *itemListView[T]) Render(fn func(item T, index int, selected bool) View) *itemListView[T] {
+	l.renderer = fn
+	return l
+}
+
+// OnSelect sets a callback invoked when the selection moves to another item.
+func (l *itemListView[T]) OnSelect(fn func(item T, index int)) *itemListView[T] {
+	l.onSelect = fn
+	return l
+}
+
+// OnActivate sets a callback invoked when Enter is pressed on the selected
+// item.
+func (l *itemListView[T]) OnActivate(fn func(item T, index int)) *itemListView[T] {
+	l.onActivate = fn
+	return l
+}
+
+// Style sets the style of unselected items drawn by the default renderer.
+func (l *itemListView[T]) Style(s Style) *itemListView[T] {
+	l.style = s
+	return l
+}
+
+// SelectedStyle sets the style of the selected item drawn by the default
+// renderer. The default is reverse video.
+func (l *itemListView[T]) SelectedStyle(s Style) *itemListView[T] {
+	l.selectedStyle = s
+	return l
+}
+
+// ItemHeight sets the number of lines per item (default 1).
+func (l *itemListView[T]) ItemHeight(h int) *itemListView[T] {
+	if h > 0 {
+		l.itemHeight = h
+	}
+	return l
+}
+
+// Loading shows animated skeleton rows in place of the items while they
+// load.
+func (l *itemListView[T]) Loading(loading bool) *itemListView[T] {
+	l.loading = loading
+	return l
+}
+
+// Width sets a fixed width. By default the list fills the available width.
+func (l *itemListView[T]) Width(w int) *itemListView[T] {
+	l.width = w
+	return l
+}
+
+// Height sets a fixed height. By default the list fills the available
+// height.
+func (l *itemListView[T]) Height(h int) *itemListView[T] {
+	l.height = h
+	return l
+}
+
+// Focusable interface implementation
+func (l *itemListView[T]) FocusID() string {
+	return l.id
+}
+
+func (l *itemListView[T]) IsFocused() bool {
+	return l.focused
+}
+
+func (l *itemListView[T]) SetFocused(focused bool) {
+	l.focused = focused
+}
+
+func (l *itemListView[T]) FocusBounds() image.Rectangle {
+	return l.bounds
+}
+
+func (l *itemListView[T]) HandleKeyEvent(event KeyEvent) bool {
+	if event.Key == KeyEnter && !event.IsModifiedEnter() {
+		if l.onActivate != nil && l.state.Selected < len(l.items) {
+			l.onActivate(l.items[l.state.Selected], l.state.Selected)
+		}
+		return true
+	}
+	previous := l.state.Selected
+	if !l.state.HandleKey(event) {
+		return false
+	}
+	l.selectionChanged(previous)
+	return true
+}
+
+// selectionChanged fires OnSelect if the selection moved from previous.
+func (l *itemListView[T]) selectionChanged(previous int) {
+	selected := l.state.Selected
+	if selected != previous && l.onSelect != nil && selected < len(l.items) {
+		l.onSelect(l.items[selected], selected)
+	}
+}
+
+func (l *itemListView[T]) flex() int {
+	if l.height > 0 {
+		return 0
+	}
+	return 1
+}
+
+func (l *itemListView[T]) size(maxWidth, maxHeight int) (int, int) {
+	w, h := l.width, l.height
+	if w == 0 {
+		w = maxWidth
+	}
+	if h == 0 {
+		h = maxHeight
+		if h == 0 {
+			h = len(l.items) * l.itemHeight
+		}
+	}
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	if maxHeight > 0 && h > maxHeight {
+		h = maxHeight
+	}
+	return w, h
+}
+
+func (l *itemListView[T]) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+
+	if l.loading {
+		Skeleton(height / l.itemHeight).Gap(l.itemHeight - 1).render(ctx)
+		return
+	}
+
+	l.bounds = ctx.AbsoluteBounds()
+	if fm := ctx.FocusManager(); fm != nil && l.id != "" {
+		fm.Register(l)
+	}
+
+	pageSize := height / l.itemHeight
+	if pageSize < 1 {
+		pageSize = 1
+	}
+	state := l.state
+	state.layout(len(l.items), pageSize)
+
+	for row := 0; row < pageSize; row++ {
+		index := state.Offset + row
+		if index >= len(l.items) {
+			break
+		}
+		y := row * l.itemHeight
+		itemCtx := ctx.SubContext(image.Rect(0, y, width, y+l.itemHeight))
+		l.renderItem(itemCtx, index, index == state.Selected)
+
+		interactiveRegistry.RegisterRegion(itemCtx.AbsoluteBounds(), func() {
+			previous := state.Selected
+			state.Select(index)
+			l.selectionChanged(previous)
+		})
+	}
+
+	interactiveRegistry.RegisterScroll(l.bounds, func(delta int) {
+		previous := state.Selected
+		state.Scroll(delta)
+		l.selectionChanged(previous)
+	})
+}
+
+func (l *itemListView[T]) renderItem(ctx *RenderContext, index int, selected bool) {
+	item := l.items[index]
+	if l.renderer != nil {
+		view := l.renderer(item, index, selected)
+		width, height := ctx.Size()
+		view.size(width, height)
+		view.render(ctx)
+		return
+	}
+
+	var label string
+	switch v := any(item).(type) {
+	case ListItem:
+		label = v.Label
+		if v.Icon != "" {
+			label = v.Icon + "  " + v.Label
+		}
+	default:
+		label = fmt.Sprint(item)
+	}
+
+	style := l.style
+	if selected {
+		style = l.selectedStyle
+		width, _ := ctx.Size()
+		for x := 0; x < width; x++ {
+			ctx.SetCell(x, 0, ' ', style)
+		}
+	}
+	ctx.PrintTruncated(0, 0, label, style)
+}
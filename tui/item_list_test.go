@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func testListItems(n int) []string {
+	items := make([]string, n)
+	for i := range items {
+		items[i] = fmt.Sprintf("item %d", i)
+	}
+	return items
+}
+
+func TestItemList_ScrollsToSelection(t *testing.T) {
+	state := &ListState{Selected: 7}
+	screen := SprintScreen(ItemList(testListItems(10), state), PrintConfig{Width: 10, Height: 3})
+
+	assert.Equal(t, 5, state.Offset)
+	assert.Equal(t, "item 5", screen.Row(0))
+	assert.Equal(t, "item 7", screen.Row(2))
+	assert.True(t, screen.Cell(8, 2).Style.Reverse)
+}
+
+func TestItemList_NilState(t *testing.T) {
+	for width := 1; width <= 40; width++ {
+		view := ItemList(testListItems(3), nil).ID("nil-state")
+		screen := SprintScreen(view, PrintConfig{Width: width, Height: 2})
+		assert.True(t, screen.Cell(0, 0).Style.Reverse)
+		view.HandleKeyEvent(KeyEvent{Key: KeyArrowDown})
+		view.HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	}
+}
+
+func TestListState_HandleKey(t *testing.T) {
+	state := &ListState{}
+	SprintScreen(ItemList(testListItems(10), state), PrintConfig{Width: 10, Height: 4})
+
+	assert.True(t, state.HandleKey(KeyEvent{Rune: 'j'}))
+	assert.True(t, state.HandleKey(KeyEvent{Key: KeyArrowDown}))
+	assert.Equal(t, 2, state.Selected)
+	state.HandleKey(KeyEvent{Key: KeyPageDown})
+	assert.Equal(t, 6, state.Selected)
+	state.HandleKey(KeyEvent{Rune: 'G'})
+	assert.Equal(t, 9, state.Selected)
+	state.HandleKey(KeyEvent{Key: KeyArrowDown})
+	assert.Equal(t, 9, state.Selected)
+	state.HandleKey(KeyEvent{Key: KeyHome})
+	assert.Equal(t, 0, state.Selected)
+
+	assert.False(t, state.HandleKey(KeyEvent{Rune: 'x'}))
+	assert.False(t, state.HandleKey(KeyEvent{Key: KeyArrowUp, Alt: true}))
+}
+
+func TestItemList_Mouse(t *testing.T) {
+	interactiveRegistry.Clear()
+	defer interactiveRegistry.Clear()
+
+	state := &ListState{}
+	var selected []int
+	view := ItemList(testListItems(10), state).
+		OnSelect(func(item string, index int) { selected = append(selected, index) })
+	SprintScreen(view, PrintConfig{Width: 10, Height: 4})
+
+	assert.True(t, interactiveRegistry.HandleClick(2, 2))
+	assert.Equal(t, 2, state.Selected)
+
+	// Scrolling drags the selection along once it leaves the window
+	assert.True(t, interactiveRegistry.HandleScroll(2, 2, 1))
+	assert.True(t, interactiveRegistry.HandleScroll(2, 2, 1))
+	assert.True(t, interactiveRegistry.HandleScroll(2, 2, 1))
+	assert.Equal(t, 3, state.Offset)
+	assert.Equal(t, 3, state.Selected)
+	assert.Equal(t, []int{2, 3}, selected)
+}
+
+func TestItemList_Activate(t *testing.T) {
+	state := &ListState{}
+	var activated string
+	view := ItemList([]ListItem{{Label: "one"}, {Label: "two", Icon: "*"}}, state).
+		ID("list").
+		OnActivate(func(item ListItem, index int) { activated = item.Label })
+	screen := SprintScreen(view, PrintConfig{Width: 10, Height: 2})
+	assert.Equal(t, "*  two", screen.Row(1))
+
+	assert.True(t, view.HandleKeyEvent(KeyEvent{Key: KeyArrowDown}))
+	assert.True(t, view.HandleKeyEvent(KeyEvent{Key: KeyEnter}))
+	assert.Equal(t, "two", activated)
+}
@@ -0,0 +1,296 @@
+package tui
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/humanize"
+	"github.com/deepnoodle-ai/wonton/jobs"
+)
+
+// jobsView lists jobs grouped into running, queued, and finished sections.
+type jobsView struct {
+	items         []jobs.Job
+	selected      *int
+	scrollY       *int
+	height        int
+	style         Style
+	headerStyle   Style
+	selectedStyle Style
+	dimStyle      Style
+	runningStyle  Style
+	errorStyle    Style
+	doneStyle     Style
+	emptyText     string
+}
+
+// JobsView creates a list of jobs under "Running", "Queued", and "Finished"
+// headings, showing each job's ID, name, state, timing, and payload. Pass
+// snapshots from jobs.Queue.List; re-render periodically (for example on
+// TickEvent) to follow progress.
+//
+// Jobs are shown in jobs.Sort order. selected is optional; it indexes the
+// jobs in that order, so sort the same list to find the selected job's ID:
+//
+//	list := app.queue.List()
+//	jobs.Sort(list)
+//	app.queue.Cancel(list[app.selected].ID)
+//
+// Example:
+//
+//	tui.JobsView(app.queue.List(), &app.selected).Height(10)
+func JobsView(items []jobs.Job, selected *int) *jobsView {
+	sorted := append([]jobs.Job(nil), items...)
+	jobs.Sort(sorted)
+	return &jobsView{
+		items:         sorted,
+		selected:      selected,
+		style:         NewStyle(),
+		headerStyle:   NewStyle().WithBold(),
+		selectedStyle: NewStyle().WithReverse(),
+		dimStyle:      NewStyle().WithForeground(ColorBrightBlack),
+		runningStyle:  NewStyle().WithForeground(ColorCyan),
+		errorStyle:    NewStyle().WithForeground(ColorRed),
+		doneStyle:     NewStyle().WithForeground(ColorGreen),
+		emptyText:     "No jobs",
+	}
+}
+
+// Height limits the number of visible rows, including headings. The list
+// scrolls to keep the selected job visible. Zero shows all rows.
+func (v *jobsView) Height(h int) *jobsView {
+	v.height = h
+	return v
+}
+
+// ScrollY binds the scroll offset so it persists between frames.
+func (v *jobsView) ScrollY(scrollY *int) *jobsView {
+	v.scrollY = scrollY
+	return v
+}
+
+// Style sets the base text style.
+func (v *jobsView) Style(s Style) *jobsView {
+	v.style = s
+	return v
+}
+
+// HeaderStyle sets the style of the section headings.
+func (v *jobsView) HeaderStyle(s Style) *jobsView {
+	v.headerStyle = s
+	return v
+}
+
+// SelectedStyle sets the style of the selected row.
+func (v *jobsView) SelectedStyle(s Style) *jobsView {
+	v.selectedStyle = s
+	return v
+}
+
+// EmptyText sets the text shown when there are no jobs.
+func (v *jobsView) EmptyText(text string) *jobsView {
+	v.emptyText = text
+	return v
+}
+
+// jobsRow is a heading (job < 0) or the index of a job in v.items.
+type jobsRow struct {
+	heading string
+	job     int
+}
+
+// rows lays out the section headings and jobs.
+func (v *jobsView) rows() []jobsRow {
+	section := func(s jobs.State) string {
+		switch s {
+		case jobs.Running:
+			return "Running"
+		case jobs.Queued:
+			return "Queued"
+		default:
+			return "Finished"
+		}
+	}
+	counts := make(map[string]int)
+	for _, j := range v.items {
+		counts[section(j.State)]++
+	}
+	var rows []jobsRow
+	current := ""
+	for i, j := range v.items {
+		if s := section(j.State); s != current {
+			current = s
+			rows = append(rows, jobsRow{heading: fmt.Sprintf("%s (%d)", s, counts[s]), job: -1})
+		}
+		rows = append(rows, jobsRow{job: i})
+	}
+	return rows
+}
+
+func (v *jobsView) visibleRows(total, maxHeight int) int {
+	rows := total
+	if rows == 0 {
+		return 1
+	}
+	if v.height > 0 && rows > v.height {
+		rows = v.height
+	}
+	if maxHeight > 0 && rows > maxHeight {
+		rows = maxHeight
+	}
+	return rows
+}
+
+func (v *jobsView) size(maxWidth, maxHeight int) (int, int) {
+	w := maxWidth
+	if w <= 0 {
+		w = 80
+	}
+	return w, v.visibleRows(len(v.rows()), maxHeight)
+}
+
+// jobDetails describes a job's state and timing.
+func jobDetails(j jobs.Job) string {
+	switch j.State {
+	case jobs.Running:
+		text := "running " + humanize.DurationShort(j.Elapsed().Truncate(time.Second))
+		if j.Attempts > 1 {
+			text += fmt.Sprintf(" (attempt %d)", j.Attempts)
+		}
+		return text
+	case jobs.Queued:
+		if j.Err != "" {
+			return "retrying: " + j.Err
+		}
+		return "queued"
+	case jobs.Completed:
+		return "done in " + humanize.DurationShort(j.Elapsed())
+	case jobs.Failed:
+		return "failed: " + j.Err
+	default:
+		return j.State.String()
+	}
+}
+
+func (v *jobsView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	if len(v.items) == 0 {
+		ctx.PrintTruncated(0, 0, v.emptyText, v.dimStyle)
+		return
+	}
+
+	selected := -1
+	if v.selected != nil {
+		if *v.selected >= len(v.items) {
+			*v.selected = len(v.items) - 1
+		}
+		if *v.selected < 0 {
+			*v.selected = 0
+		}
+		selected = *v.selected
+	}
+
+	// Scroll to keep the selected job, and its heading when possible, visible
+	rows := v.rows()
+	visible := v.visibleRows(len(rows), height)
+	scroll := 0
+	if v.scrollY != nil {
+		scroll = *v.scrollY
+	}
+	if selected >= 0 {
+		for r, row := range rows {
+			if row.job != selected {
+				continue
+			}
+			top := r
+			if r > 0 && rows[r-1].job < 0 {
+				top = r - 1
+			}
+			if top < scroll {
+				scroll = top
+			}
+			if r >= scroll+visible {
+				scroll = r - visible + 1
+			}
+		}
+	}
+	if scroll > len(rows)-visible {
+		scroll = len(rows) - visible
+	}
+	if scroll < 0 {
+		scroll = 0
+	}
+	if v.scrollY != nil {
+		*v.scrollY = scroll
+	}
+
+	// Name and details each get a third of the width; the payload the rest
+	nameWidth := max(12, width/3)
+	detailWidth := max(12, width/3)
+
+	bounds := ctx.AbsoluteBounds()
+	for y := 0; y < visible; y++ {
+		row := rows[scroll+y]
+		if row.job < 0 {
+			ctx.PrintTruncated(0, y, row.heading, v.headerStyle)
+			continue
+		}
+		j := v.items[row.job]
+		isSelected := row.job == selected
+
+		base := v.style
+		if isSelected {
+			base = v.selectedStyle
+			ctx.FillStyled(0, y, width, 1, ' ', base)
+		}
+
+		x := 2
+		ctx.PrintTruncated(x, y, truncateToWidth(fmt.Sprintf("#%d %s", j.ID, j.Name), nameWidth-1), base)
+		x += nameWidth
+
+		detailStyle := base
+		if !isSelected {
+			switch j.State {
+			case jobs.Running:
+				detailStyle = v.runningStyle
+			case jobs.Completed:
+				detailStyle = v.doneStyle
+			case jobs.Failed:
+				detailStyle = v.errorStyle
+			case jobs.Canceled:
+				detailStyle = v.dimStyle
+			}
+		}
+		if x < width {
+			ctx.PrintTruncated(x, y, truncateToWidth(jobDetails(j), detailWidth-1), detailStyle)
+		}
+		x += detailWidth
+
+		payloadStyle := v.dimStyle
+		if isSelected {
+			payloadStyle = base
+		}
+		if x < width && len(j.Payload) > 0 {
+			payload := strings.Join(strings.Fields(string(j.Payload)), " ")
+			ctx.PrintTruncated(x, y, payload, payloadStyle)
+		}
+
+		if v.selected != nil {
+			i := row.job // capture for closure
+			sel := v.selected
+			interactiveRegistry.RegisterButton(image.Rect(
+				bounds.Min.X,
+				bounds.Min.Y+y,
+				bounds.Min.X+width,
+				bounds.Min.Y+y+1,
+			), func() {
+				*sel = i
+			})
+		}
+	}
+}
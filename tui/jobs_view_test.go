@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/jobs"
+)
+
+func TestJobsView_Empty(t *testing.T) {
+	screen := SprintScreen(JobsView(nil, nil), PrintConfig{Width: 40})
+	assert.Contains(t, screen.Row(0), "No jobs")
+}
+
+func TestJobsView_Sections(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	items := []jobs.Job{
+		{ID: 1, Name: "export", State: jobs.Completed, Started: start, Finished: start.Add(3 * time.Second)},
+		{ID: 2, Name: "crawl", State: jobs.Queued, Payload: json.RawMessage(`{"url": "https://example.com"}`)},
+		{ID: 3, Name: "crawl", State: jobs.Running, Started: time.Now()},
+		{ID: 4, Name: "index", State: jobs.Failed, Err: "disk full", Finished: start},
+	}
+	screen := SprintScreen(JobsView(items, nil), PrintConfig{Width: 100})
+
+	assert.Equal(t, "Running (1)", screen.Row(0))
+	assert.Contains(t, screen.Row(1), "#3 crawl")
+	assert.Contains(t, screen.Row(1), "running")
+	assert.Equal(t, "Queued (1)", screen.Row(2))
+	assert.Contains(t, screen.Row(3), "#2 crawl")
+	assert.Contains(t, screen.Row(3), `{"url": "https://example.com"}`)
+	assert.Equal(t, "Finished (2)", screen.Row(4))
+	assert.Contains(t, screen.Row(5), "#1 export")
+	assert.Contains(t, screen.Row(5), "done in 3s")
+	assert.Contains(t, screen.Row(6), "failed: disk full")
+}
+
+func TestJobsView_HeightScrollsToSelection(t *testing.T) {
+	var items []jobs.Job
+	for i := 1; i <= 4; i++ {
+		items = append(items, jobs.Job{ID: i, Name: "job", State: jobs.Queued})
+	}
+	selected := 3
+	scroll := 0
+	view := JobsView(items, &selected).ScrollY(&scroll).Height(2)
+
+	w, h := view.size(60, 10)
+	assert.Equal(t, 60, w)
+	assert.Equal(t, 2, h)
+
+	screen := SprintScreen(view, PrintConfig{Width: 60})
+	assert.Contains(t, screen.Row(0), "#3 job")
+	assert.Contains(t, screen.Row(1), "#4 job")
+	assert.Equal(t, 3, scroll)
+
+	// Selecting the first job brings its heading into view
+	selected = 0
+	screen = SprintScreen(view, PrintConfig{Width: 60})
+	assert.Equal(t, "Queued (4)", screen.Row(0))
+	assert.Contains(t, screen.Row(1), "#1 job")
+}
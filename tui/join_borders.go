@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"image"
+
+	"github.com/deepnoodle-ai/wonton/terminal"
+	"github.com/mattn/go-runewidth"
+)
+
+// joinBordersView draws its inner view with box-drawing characters joined.
+type joinBordersView struct {
+	inner View
+}
+
+// JoinBorders draws the inner view so that box-drawing characters drawn on
+// top of each other join: where two borders cross or touch, '┐' drawn over
+// '┌' becomes '┬', and '─' over '│' becomes '┼'. Stack and Group do this for
+// their children with their own JoinBorders method, which also overlaps the
+// children's edges; use this function for views placed over each other in
+// other ways, such as with ZStack.
+func JoinBorders(inner View) View {
+	return &joinBordersView{inner: inner}
+}
+
+func (j *joinBordersView) size(maxWidth, maxHeight int) (int, int) {
+	return j.inner.size(maxWidth, maxHeight)
+}
+
+// flex implements the Flexible interface by delegating to the inner view.
+func (j *joinBordersView) flex() int {
+	if flex, ok := j.inner.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (j *joinBordersView) render(ctx *RenderContext) {
+	j.inner.render(ctx.joiningBorders())
+}
+
+// joiningBorders returns a context whose drawing joins box-drawing
+// characters drawn over each other. Inside another joining context it
+// shares that context's record of what was drawn.
+func (c *RenderContext) joiningBorders() *RenderContext {
+	if _, ok := c.frame.(*joinFrame); ok {
+		return c
+	}
+	return c.WithFrame(&joinFrame{RenderFrame: c.frame, drawn: make(map[image.Point]rune)})
+}
+
+// joinFrame draws to a frame, joining each box-drawing character with the
+// one drawn before it in the same cell.
+type joinFrame struct {
+	RenderFrame
+	drawn map[image.Point]rune // box-drawing characters drawn, by screen position
+}
+
+// join returns the character to draw at (x, y) in place of r, and records
+// it.
+func (f *joinFrame) join(x, y int, r rune) rune {
+	w, h := f.Size()
+	if x < 0 || y < 0 || x >= w || y >= h {
+		return r
+	}
+	pt := f.GetBounds().Min.Add(image.Pt(x, y))
+	if !terminal.IsBoxRune(r) {
+		delete(f.drawn, pt)
+		return r
+	}
+	if below, ok := f.drawn[pt]; ok {
+		r, _ = terminal.JoinBoxRunes(below, r)
+	}
+	f.drawn[pt] = r
+	return r
+}
+
+func (f *joinFrame) SetCell(x, y int, char rune, style Style) error {
+	return f.RenderFrame.SetCell(x, y, f.join(x, y, char), style)
+}
+
+func (f *joinFrame) PrintStyled(x, y int, text string, style Style) error {
+	return f.RenderFrame.PrintStyled(x, y, f.joinText(x, y, text), style)
+}
+
+func (f *joinFrame) PrintTruncated(x, y int, text string, style Style) error {
+	return f.RenderFrame.PrintTruncated(x, y, f.joinText(x, y, text), style)
+}
+
+// joinText returns text with its box-drawing characters joined with those
+// already drawn where it will be printed.
+func (f *joinFrame) joinText(x, y int, text string) string {
+	runes := []rune(text)
+	changed := false
+	cx, cy := x, y
+	for i, r := range runes {
+		if r == '\n' {
+			cx, cy = x, cy+1
+			continue
+		}
+		if joined := f.join(cx, cy, r); joined != r {
+			runes[i] = joined
+			changed = true
+		}
+		cx += runewidth.RuneWidth(r)
+	}
+	if !changed {
+		return text
+	}
+	return string(runes)
+}
+
+func (f *joinFrame) FillStyled(x, y, width, height int, char rune, style Style) error {
+	for cy := y; cy < y+height; cy++ {
+		for cx := x; cx < x+width; cx++ {
+			pt := f.GetBounds().Min.Add(image.Pt(cx, cy))
+			delete(f.drawn, pt)
+		}
+	}
+	return f.RenderFrame.FillStyled(x, y, width, height, char, style)
+}
+
+func (f *joinFrame) Fill(char rune, style Style) error {
+	w, h := f.Size()
+	return f.FillStyled(0, 0, w, h, char, style)
+}
+
+func (f *joinFrame) SubFrame(rect image.Rectangle) RenderFrame {
+	return &joinFrame{RenderFrame: f.RenderFrame.SubFrame(rect), drawn: f.drawn}
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestGroup_JoinBorders(t *testing.T) {
+	view := Group(
+		Bordered(Text("a")).Border(&SingleBorder),
+		Bordered(Text("b")).Border(&SingleBorder),
+	).JoinBorders()
+	w, h := view.size(0, 0)
+	assert.Equal(t, w, 5)
+	assert.Equal(t, h, 3)
+
+	screen := SprintScreen(view, PrintConfig{Width: 5, Height: 3})
+	assert.Equal(t, screen.Row(0), "┌─┬─┐")
+	assert.Equal(t, screen.Row(1), "│a│b│")
+	assert.Equal(t, screen.Row(2), "└─┴─┘")
+}
+
+func TestStack_JoinBordersGrid(t *testing.T) {
+	cell := func(s string) View { return Bordered(Text("%s", s)).Border(&RoundedBorder) }
+	view := Stack(
+		Group(cell("a"), cell("b")).JoinBorders(),
+		Group(cell("c"), cell("d")).JoinBorders(),
+	).JoinBorders()
+
+	screen := SprintScreen(view, PrintConfig{Width: 5, Height: 5})
+	assert.Equal(t, screen.Row(0), "╭─┬─╮")
+	assert.Equal(t, screen.Row(1), "│a│b│")
+	assert.Equal(t, screen.Row(2), "├─┼─┤")
+	assert.Equal(t, screen.Row(3), "│c│d│")
+	assert.Equal(t, screen.Row(4), "╰─┴─╯")
+}
+
+func TestJoinBorders_ContentIsNotJoined(t *testing.T) {
+	// Text drawn over a border replaces it, even ASCII lines
+	view := JoinBorders(ZStack(
+		Bordered(Text(" ")).Border(&SingleBorder),
+		Text("-+-"),
+	))
+	screen := SprintScreen(view, PrintConfig{Width: 3, Height: 3})
+	assert.Equal(t, screen.Row(1), "-+-")
+
+	// Without joining, borders drawn over each other replace each other
+	screen = SprintScreen(Group(
+		Bordered(Text("a")).Border(&SingleBorder),
+		Bordered(Text("b")).Border(&SingleBorder),
+	), PrintConfig{Width: 6, Height: 3})
+	assert.Equal(t, screen.Row(0), "┌─┐┌─┐")
+}
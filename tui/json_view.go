@@ -0,0 +1,644 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// jsonKind is the type of a JSON value.
+type jsonKind int
+
+const (
+	jsonNull jsonKind = iota
+	jsonBool
+	jsonNumber
+	jsonString
+	jsonObject
+	jsonArray
+)
+
+// jsonNode is a parsed JSON value. Objects keep their keys in document
+// order.
+type jsonNode struct {
+	kind     jsonKind
+	key      string // Key within the parent object, quoted JSON
+	value    string // JSON text of a scalar
+	path     string // jq-style path, such as .users[0].name
+	depth    int
+	children []*jsonNode
+}
+
+// parseJSON decodes JSON text into nodes.
+func parseJSON(data []byte) (*jsonNode, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	root, err := decodeJSONNode(dec, ".", 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err == nil {
+		return nil, fmt.Errorf("unexpected data after top-level value")
+	}
+	return root, nil
+}
+
+// decodeJSONNode decodes the next value from dec.
+func decodeJSONNode(dec *json.Decoder, path string, depth int) (*jsonNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	n := &jsonNode{path: path, depth: depth}
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			// Keys of the root read .a, not ..a
+			base := path
+			if base == "." {
+				base = ""
+			}
+			n.kind = jsonObject
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key := keyTok.(string)
+				child, err := decodeJSONNode(dec, base+jsonPathKey(key), depth+1)
+				if err != nil {
+					return nil, err
+				}
+				child.key = strconv.Quote(key)
+				n.children = append(n.children, child)
+			}
+		} else {
+			n.kind = jsonArray
+			for i := 0; dec.More(); i++ {
+				child, err := decodeJSONNode(dec, fmt.Sprintf("%s[%d]", path, i), depth+1)
+				if err != nil {
+					return nil, err
+				}
+				n.children = append(n.children, child)
+			}
+		}
+		if _, err := dec.Token(); err != nil { // closing delimiter
+			return nil, err
+		}
+	case string:
+		n.kind, n.value = jsonString, strconv.Quote(t)
+	case json.Number:
+		n.kind, n.value = jsonNumber, t.String()
+	case bool:
+		n.kind, n.value = jsonBool, strconv.FormatBool(t)
+	case nil:
+		n.kind, n.value = jsonNull, "null"
+	}
+	return n, nil
+}
+
+// jsonPathKey returns the path step for an object key: .name for keys that
+// are identifiers, ["key"] otherwise.
+func jsonPathKey(key string) string {
+	ident := key != ""
+	for i, r := range key {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9') {
+			ident = false
+			break
+		}
+	}
+	if ident {
+		return "." + key
+	}
+	return "[" + strconv.Quote(key) + "]"
+}
+
+// JSONState holds the interactive state of a JSONView: which nodes are
+// collapsed, the selected line, and the scroll position. It lives in
+// application state, which forwards keys to HandleKey.
+type JSONState struct {
+	// OnCopy is called with the path of the selected node, such as
+	// .users[0].name, when the user presses y. Use it to put the path on the
+	// clipboard.
+	OnCopy func(path string)
+
+	open     map[string]bool // explicit expand/collapse by path
+	selected string          // path of the selected node
+	closing  bool            // the closing bracket line of selected is selected
+	cursor   int             // index of the selected line at the last render
+	scrollY  int
+	height   int
+	lines    []jsonLine // visible lines at the last render
+	searched string     // query whose matches were last revealed
+}
+
+// NewJSONState creates the state for an interactive JSONView.
+func NewJSONState() *JSONState {
+	return &JSONState{open: make(map[string]bool)}
+}
+
+// SelectedPath returns the path of the selected node, or "" before the view
+// has rendered.
+func (s *JSONState) SelectedPath() string {
+	return s.selected
+}
+
+// Select selects the node at path, as shown by SelectedPath.
+func (s *JSONState) Select(path string) {
+	s.selected, s.closing = path, false
+}
+
+// SetExpanded expands or collapses the node at path.
+func (s *JSONState) SetExpanded(path string, expanded bool) {
+	if s.open == nil {
+		s.open = make(map[string]bool)
+	}
+	s.open[path] = expanded
+}
+
+// ExpandAll clears every collapse, including those from ExpandDepth.
+func (s *JSONState) ExpandAll() {
+	s.open = map[string]bool{"*": true}
+}
+
+// CollapseAll collapses every node but the root.
+func (s *JSONState) CollapseAll() {
+	s.open = map[string]bool{"*": false, ".": true}
+}
+
+// HandleKey handles a key for the view and reports whether it used it:
+//
+//   - Up/Down or k/j: Select the previous/next line
+//   - PgUp/PgDn, Home/End: Move by a page, or to the top/bottom
+//   - Right or l: Expand the node, or step into it
+//   - Left or h: Collapse the node, or step out to its parent
+//   - Enter or Space: Toggle the node
+//   - y: Copy the selected path with OnCopy
+func (s *JSONState) HandleKey(event KeyEvent) bool {
+	if len(s.lines) == 0 {
+		return false
+	}
+	moveTo := func(i int) bool {
+		i = max(0, min(i, len(s.lines)-1))
+		s.cursor = i
+		s.selected, s.closing = s.lines[i].node.path, s.lines[i].closing
+		return true
+	}
+	cur := s.lines[min(s.cursor, len(s.lines)-1)]
+	node := cur.node
+	container := node.kind == jsonObject || node.kind == jsonArray
+	page := max(1, s.height-1)
+
+	switch {
+	case event.Key == KeyArrowUp || event.Rune == 'k':
+		return moveTo(s.cursor - 1)
+	case event.Key == KeyArrowDown || event.Rune == 'j':
+		return moveTo(s.cursor + 1)
+	case event.Key == KeyPageUp:
+		return moveTo(s.cursor - page)
+	case event.Key == KeyPageDown:
+		return moveTo(s.cursor + page)
+	case event.Key == KeyHome:
+		return moveTo(0)
+	case event.Key == KeyEnd:
+		return moveTo(len(s.lines) - 1)
+	case event.Key == KeyArrowRight || event.Rune == 'l':
+		if !container || len(node.children) == 0 {
+			return false
+		}
+		if cur.collapsed {
+			s.SetExpanded(node.path, true)
+		} else if !cur.closing {
+			return moveTo(s.cursor + 1)
+		}
+		return true
+	case event.Key == KeyArrowLeft || event.Rune == 'h':
+		if container && len(node.children) > 0 && !cur.collapsed {
+			s.SetExpanded(node.path, false)
+			s.selected, s.closing = node.path, false
+			return true
+		}
+		for i := s.cursor - 1; i >= 0; i-- {
+			if s.lines[i].node.depth < node.depth && !s.lines[i].closing {
+				return moveTo(i)
+			}
+		}
+		return true
+	case event.Key == KeyEnter || event.Rune == ' ':
+		if container && len(node.children) > 0 {
+			s.SetExpanded(node.path, cur.collapsed)
+			s.selected, s.closing = node.path, false
+		}
+		return true
+	case event.Rune == 'y':
+		if s.OnCopy != nil {
+			s.OnCopy(node.path)
+		}
+		return true
+	}
+	return false
+}
+
+// jsonLine is one line of a rendered JSON document.
+type jsonLine struct {
+	node      *jsonNode
+	closing   bool // the closing bracket of an expanded container
+	collapsed bool // a container shown on one line
+	last      bool // the node is the last in its parent, so has no comma
+}
+
+// jsonView renders JSON as a collapsible, colored tree.
+type jsonView struct {
+	root        *jsonNode
+	err         error
+	state       *JSONState
+	search      *SearchController
+	height      int
+	expandDepth int
+
+	keyStyle    Style
+	stringStyle Style
+	numberStyle Style
+	boolStyle   Style
+	nullStyle   Style
+	punctStyle  Style
+	dimStyle    Style
+	cursorStyle Style
+}
+
+// JSONView renders JSON as a syntax-colored tree whose objects and arrays can
+// be collapsed. data is JSON text as []byte or json.RawMessage, or any other
+// Go value, which is shown as encoding/json would marshal it. Object keys
+// stay in document order. Data that can't be decoded or marshaled is shown
+// as an error.
+//
+// On its own the view is a static, pretty-printed document. Bind a
+// JSONState to browse it: select lines, collapse and expand nodes, and copy
+// the selected node's path. SearchWith highlights matches, expanding
+// collapsed nodes that contain them.
+//
+// Example:
+//
+//	tui.Stack(
+//	    tui.JSONView(app.body).State(app.json).SearchWith(&app.search),
+//	    tui.SearchBar(&app.search),
+//	)
+func JSONView(data any) *jsonView {
+	v := &jsonView{
+		keyStyle:    NewStyle().WithForeground(ColorCyan),
+		stringStyle: NewStyle().WithForeground(ColorGreen),
+		numberStyle: NewStyle().WithForeground(ColorMagenta),
+		boolStyle:   NewStyle().WithForeground(ColorYellow),
+		nullStyle:   NewStyle().WithForeground(ColorBrightBlack),
+		punctStyle:  NewStyle(),
+		dimStyle:    NewStyle().WithForeground(ColorBrightBlack),
+		cursorStyle: NewStyle().WithReverse(),
+	}
+	var text []byte
+	switch d := data.(type) {
+	case []byte:
+		text = d
+	case json.RawMessage:
+		text = d
+	default:
+		text, v.err = json.Marshal(d)
+	}
+	if v.err == nil {
+		v.root, v.err = parseJSON(text)
+	}
+	return v
+}
+
+// State binds the interactive state.
+func (v *jsonView) State(state *JSONState) *jsonView {
+	v.state = state
+	return v
+}
+
+// SearchWith binds a SearchController. Matches are highlighted, and when a
+// new query is committed the nodes containing matches are expanded.
+func (v *jsonView) SearchWith(ctrl *SearchController) *jsonView {
+	v.search = ctrl
+	return v
+}
+
+// Height sets a fixed height. By default a view with state fills the space
+// it is given, and one without is as tall as its content.
+func (v *jsonView) Height(h int) *jsonView {
+	v.height = h
+	return v
+}
+
+// ExpandDepth collapses containers nested deeper than depth levels until the
+// user expands them; 1 shows only the top-level keys. Zero, the default,
+// expands everything.
+func (v *jsonView) ExpandDepth(depth int) *jsonView {
+	v.expandDepth = depth
+	return v
+}
+
+// KeyStyle sets the style of object keys.
+func (v *jsonView) KeyStyle(s Style) *jsonView {
+	v.keyStyle = s
+	return v
+}
+
+// StringStyle sets the style of string values.
+func (v *jsonView) StringStyle(s Style) *jsonView {
+	v.stringStyle = s
+	return v
+}
+
+// NumberStyle sets the style of numbers.
+func (v *jsonView) NumberStyle(s Style) *jsonView {
+	v.numberStyle = s
+	return v
+}
+
+// CursorStyle sets the style of the selected line.
+func (v *jsonView) CursorStyle(s Style) *jsonView {
+	v.cursorStyle = s
+	return v
+}
+
+// expanded reports whether the container n is shown expanded.
+func (v *jsonView) expanded(n *jsonNode) bool {
+	if v.state != nil {
+		if open, ok := v.state.open[n.path]; ok {
+			return open
+		}
+		if open, ok := v.state.open["*"]; ok {
+			return open
+		}
+	}
+	return v.expandDepth <= 0 || n.depth < v.expandDepth
+}
+
+// lines lays out the visible lines of the document. With all set,
+// collapsed nodes are laid out as if expanded.
+func (v *jsonView) lines(all bool) []jsonLine {
+	var lines []jsonLine
+	var walk func(n *jsonNode, last bool)
+	walk = func(n *jsonNode, last bool) {
+		container := n.kind == jsonObject || n.kind == jsonArray
+		if !container || len(n.children) == 0 {
+			lines = append(lines, jsonLine{node: n, last: last})
+			return
+		}
+		if !all && !v.expanded(n) {
+			lines = append(lines, jsonLine{node: n, last: last, collapsed: true})
+			return
+		}
+		lines = append(lines, jsonLine{node: n, last: last})
+		for i, child := range n.children {
+			walk(child, i == len(n.children)-1)
+		}
+		lines = append(lines, jsonLine{node: n, last: last, closing: true})
+	}
+	if v.root != nil {
+		walk(v.root, true)
+	}
+	return lines
+}
+
+// segments returns the styled text of a line, without indentation.
+func (v *jsonView) segments(line jsonLine) []StyledSegment {
+	n := line.node
+	var segs []StyledSegment
+	open, close := "{", "}"
+	if n.kind == jsonArray {
+		open, close = "[", "]"
+	}
+	if line.closing {
+		segs = append(segs, StyledSegment{Text: close, Style: v.punctStyle})
+	} else {
+		if n.key != "" {
+			segs = append(segs,
+				StyledSegment{Text: n.key, Style: v.keyStyle},
+				StyledSegment{Text: ": ", Style: v.punctStyle})
+		}
+		switch n.kind {
+		case jsonObject, jsonArray:
+			switch {
+			case len(n.children) == 0:
+				segs = append(segs, StyledSegment{Text: open + close, Style: v.punctStyle})
+			case line.collapsed:
+				segs = append(segs, StyledSegment{Text: open + "…" + close, Style: v.punctStyle})
+			default:
+				segs = append(segs, StyledSegment{Text: open, Style: v.punctStyle})
+			}
+		case jsonString:
+			segs = append(segs, StyledSegment{Text: n.value, Style: v.stringStyle})
+		case jsonNumber:
+			segs = append(segs, StyledSegment{Text: n.value, Style: v.numberStyle})
+		case jsonBool:
+			segs = append(segs, StyledSegment{Text: n.value, Style: v.boolStyle})
+		default:
+			segs = append(segs, StyledSegment{Text: n.value, Style: v.nullStyle})
+		}
+	}
+	if !line.last && (line.closing || line.collapsed || len(n.children) == 0) {
+		segs = append(segs, StyledSegment{Text: ",", Style: v.punctStyle})
+	}
+	if line.collapsed {
+		count := fmt.Sprintf(" %d items", len(n.children))
+		if n.kind == jsonObject {
+			count = fmt.Sprintf(" %d keys", len(n.children))
+		}
+		segs = append(segs, StyledSegment{Text: count, Style: v.dimStyle})
+	}
+	return segs
+}
+
+// plainText returns the unstyled text of a line.
+func plainText(segs []StyledSegment) string {
+	var b strings.Builder
+	for _, s := range segs {
+		b.WriteString(s.Text)
+	}
+	return b.String()
+}
+
+// jsonGutter is the width of the expand/collapse marker before each line's
+// text.
+const jsonGutter = 2
+
+func (v *jsonView) flex() int {
+	if v.state != nil && v.height == 0 {
+		return 1
+	}
+	return 0
+}
+
+func (v *jsonView) size(maxWidth, maxHeight int) (int, int) {
+	if v.err != nil {
+		w := runewidth.StringWidth(v.err.Error())
+		if maxWidth > 0 && w > maxWidth {
+			w = maxWidth
+		}
+		return w, 1
+	}
+	lines := v.lines(false)
+	w := 0
+	for _, line := range lines {
+		lw := jsonGutter + line.node.depth*2 + runewidth.StringWidth(plainText(v.segments(line)))
+		w = max(w, lw)
+	}
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	h := v.height
+	if h == 0 {
+		h = len(lines)
+		if v.state != nil && maxHeight > 0 {
+			h = maxHeight
+		}
+	}
+	if maxHeight > 0 && h > maxHeight {
+		h = maxHeight
+	}
+	return w, h
+}
+
+func (v *jsonView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	if v.err != nil {
+		ctx.PrintTruncated(0, 0, v.err.Error(), NewStyle().WithForeground(ColorRed))
+		return
+	}
+
+	// A new query expands the nodes holding its matches
+	s := v.state
+	if s != nil && v.search != nil && v.search.Query != s.searched {
+		s.searched = v.search.Query
+		if s.searched != "" {
+			all := v.lines(true)
+			texts := make([]string, len(all))
+			for i, line := range all {
+				texts[i] = plainText(v.segments(line))
+			}
+			for _, m := range findMatches(texts, s.searched, v.search.CaseSensitive) {
+				v.revealPath(all, m.Line)
+			}
+		}
+	}
+
+	lines := v.lines(false)
+	texts := make([]string, len(lines))
+	for i, line := range lines {
+		texts[i] = plainText(v.segments(line))
+	}
+
+	scrollY, cursor := 0, -1
+	if s != nil {
+		s.lines, s.height = lines, height
+		// The selected node's opening line, or its closing line if that
+		// was selected and is still shown
+		s.cursor = 0
+		found := false
+		for i, line := range lines {
+			if line.node.path != s.selected {
+				continue
+			}
+			if !found || line.closing == s.closing {
+				s.cursor, found = i, true
+			}
+			if line.closing == s.closing {
+				break
+			}
+		}
+		if len(lines) > 0 {
+			s.selected, s.closing = lines[s.cursor].node.path, lines[s.cursor].closing
+		}
+		cursor = s.cursor
+		if cursor < s.scrollY {
+			s.scrollY = cursor
+		}
+		if cursor >= s.scrollY+height {
+			s.scrollY = cursor - height + 1
+		}
+		scrollY = s.scrollY
+	}
+	if v.search != nil {
+		v.search.setMatches(findMatches(texts, v.search.Query, v.search.CaseSensitive))
+		if target, ok := v.search.revealLine(scrollY, height); ok {
+			scrollY = target
+			if s != nil {
+				s.scrollY = target
+			}
+		}
+	}
+	scrollY = max(0, min(scrollY, len(lines)-height))
+	if s != nil {
+		s.scrollY = scrollY
+	}
+
+	bounds := ctx.AbsoluteBounds()
+	for y := 0; y < height && scrollY+y < len(lines); y++ {
+		i := scrollY + y
+		line := lines[i]
+		n := line.node
+		if i == cursor {
+			ctx.FillStyled(0, y, width, 1, ' ', v.cursorStyle)
+		}
+		if (n.kind == jsonObject || n.kind == jsonArray) && len(n.children) > 0 && !line.closing {
+			marker := "▾"
+			if line.collapsed {
+				marker = "▸"
+			}
+			ctx.PrintStyled(n.depth*2, y, marker, v.dimStyle)
+		}
+
+		segs := v.segments(line)
+		if v.search != nil {
+			segs = v.search.highlightSegments(segs, i)
+		}
+		x := jsonGutter + n.depth*2
+		for _, seg := range segs {
+			if x >= width {
+				break
+			}
+			style := seg.Style
+			if i == cursor {
+				style = style.WithReverse()
+			}
+			text := truncateToWidth(seg.Text, width-x)
+			ctx.PrintStyled(x, y, text, style)
+			x += runewidth.StringWidth(text)
+		}
+
+		if s != nil {
+			index := i
+			interactiveRegistry.RegisterButton(
+				image.Rect(bounds.Min.X, bounds.Min.Y+y, bounds.Max.X, bounds.Min.Y+y+1),
+				func() {
+					s.cursor = index
+					s.selected, s.closing = lines[index].node.path, lines[index].closing
+					if index == cursor {
+						s.HandleKey(KeyEvent{Key: KeyEnter})
+					}
+				},
+			)
+		}
+	}
+}
+
+// revealPath expands the containers enclosing line i of the fully expanded
+// layout all.
+func (v *jsonView) revealPath(all []jsonLine, i int) {
+	depth := all[i].node.depth
+	for j := i - 1; j >= 0 && depth > 0; j-- {
+		if n := all[j].node; !all[j].closing && n.depth < depth {
+			v.state.SetExpanded(n.path, true)
+			depth = n.depth
+		}
+	}
+}
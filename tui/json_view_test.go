@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/termtest"
+)
+
+const testJSON = `{"name":"wonton","tags":["tui","cli"],"meta":{"stars":42,"ok":true,"x":null},"a b":{}}`
+
+func TestJSONView(t *testing.T) {
+	screen := SprintScreen(JSONView([]byte(testJSON)), PrintConfig{Width: 30, Height: 13})
+	assert.Equal(t, "▾ {", screen.Row(0))
+	assert.Equal(t, `    "name": "wonton",`, screen.Row(1))
+	assert.Equal(t, `  ▾ "tags": [`, screen.Row(2))
+	assert.Equal(t, `      "cli"`, screen.Row(4))
+	assert.Equal(t, "    ],", screen.Row(5))
+	assert.Equal(t, `      "stars": 42,`, screen.Row(7))
+	assert.Equal(t, `      "x": null`, screen.Row(9))
+	assert.Equal(t, `    "a b": {}`, screen.Row(11))
+	assert.Equal(t, "  }", screen.Row(12))
+}
+
+func TestJSONView_GoValues(t *testing.T) {
+	type user struct {
+		Name  string   `json:"name"`
+		Admin bool     `json:"admin,omitempty"`
+		Tags  []string `json:"tags"`
+	}
+	screen := SprintScreen(JSONView([]user{{Name: "ada", Tags: []string{}}}), PrintConfig{Width: 20, Height: 6})
+	assert.Equal(t, "▾ [", screen.Row(0))
+	assert.Equal(t, `      "name": "ada",`, screen.Row(2))
+	assert.Equal(t, `      "tags": []`, screen.Row(3))
+
+	screen = SprintScreen(JSONView(json.RawMessage(`{"a": [1, 2.50]}`)).ExpandDepth(1), PrintConfig{Width: 20, Height: 3})
+	assert.Equal(t, `  ▸ "a": […] 2 items`, screen.Row(1))
+
+	// Errors are shown in place of the document
+	screen = SprintScreen(JSONView([]byte(`{"a": }`)), PrintConfig{Width: 40, Height: 1})
+	assert.Contains(t, screen.Row(0), "missing value")
+	screen = SprintScreen(JSONView(make(chan int)), PrintConfig{Width: 40, Height: 1})
+	assert.Contains(t, screen.Row(0), "unsupported type")
+}
+
+func TestJSONView_Paths(t *testing.T) {
+	root, err := parseJSON([]byte(`{"users":[{"name":"a","first name":"b"}],"_x1":0}`))
+	assert.NoError(t, err)
+	assert.Equal(t, ".", root.path)
+	users := root.children[0]
+	assert.Equal(t, ".users", users.path)
+	assert.Equal(t, ".users[0]", users.children[0].path)
+	assert.Equal(t, ".users[0].name", users.children[0].children[0].path)
+	assert.Equal(t, `.users[0]["first name"]`, users.children[0].children[1].path)
+	assert.Equal(t, "._x1", root.children[1].path)
+
+	top, err := parseJSON([]byte(`[1]`))
+	assert.NoError(t, err)
+	assert.Equal(t, ".[0]", top.children[0].path)
+}
+
+func TestJSONState_Keys(t *testing.T) {
+	state := NewJSONState()
+	var copied string
+	state.OnCopy = func(path string) { copied = path }
+	render := func() *termtest.Screen {
+		return SprintScreen(JSONView([]byte(testJSON)).State(state), PrintConfig{Width: 30, Height: 13})
+	}
+	keys := func(runes string) {
+		for _, r := range runes {
+			render()
+			state.HandleKey(KeyEvent{Rune: r})
+		}
+		render()
+	}
+
+	keys("jj")
+	assert.Equal(t, ".tags", state.SelectedPath())
+
+	// h collapses, l expands and then steps in
+	keys("h")
+	screen := render()
+	assert.Equal(t, `  ▸ "tags": […], 2 items`, screen.Row(2))
+	assert.Equal(t, `  ▾ "meta": {`, screen.Row(3))
+	keys("ll")
+	assert.Equal(t, ".tags[0]", state.SelectedPath())
+
+	// h on a leaf steps out to its parent; y copies the path
+	keys("jh")
+	assert.Equal(t, ".tags", state.SelectedPath())
+	keys("y")
+	assert.Equal(t, ".tags", copied)
+
+	// The closing bracket line can be selected and collapses its node
+	state.Select(".meta")
+	keys("jjjj")
+	assert.Equal(t, ".meta", state.SelectedPath())
+	keys("h")
+	screen = render()
+	assert.Equal(t, `  ▸ "meta": {…}, 3 keys`, screen.Row(6))
+
+	state.CollapseAll()
+	screen = render()
+	assert.Equal(t, `  ▸ "tags": […], 2 items`, screen.Row(2))
+	assert.Equal(t, `    "a b": {}`, screen.Row(4))
+	state.ExpandAll()
+	screen = render()
+	assert.Equal(t, `      "stars": 42,`, screen.Row(7))
+}
+
+func TestJSONView_Search(t *testing.T) {
+	state := NewJSONState()
+	state.CollapseAll()
+	search := NewSearchController()
+	view := func() View {
+		return JSONView([]byte(testJSON)).State(state).SearchWith(search)
+	}
+	SprintScreen(view(), PrintConfig{Width: 30, Height: 13})
+
+	// A new query expands the nodes holding matches
+	search.SetQuery("stars")
+	screen := SprintScreen(view(), PrintConfig{Width: 30, Height: 13})
+	assert.Equal(t, `      "stars": 42,`, screen.Row(4))
+	assert.Equal(t, `  ▸ "tags": […], 2 items`, screen.Row(2))
+	assert.Equal(t, 1, search.Count())
+
+	// Collapsing it again sticks until the query changes
+	state.SetExpanded(".meta", false)
+	SprintScreen(view(), PrintConfig{Width: 30, Height: 13})
+	assert.Equal(t, 0, search.Count())
+}
@@ -0,0 +1,87 @@
+package tui
+
+// layoutItem sets how a child of a Stack or Group is sized along the
+// container and aligned across it.
+type layoutItem struct {
+	view     View
+	weight   int
+	align    Alignment
+	hasAlign bool
+}
+
+// Flex makes view take weight shares of the space its Stack or Group has
+// left once the other children are sized, whatever the size of its
+// content, so panels split in proportion without working out widths:
+//
+//	Group(
+//	    Flex(2, Bordered(editor)),
+//	    Flex(1, Bordered(preview)),
+//	)
+//
+// gives the editor two thirds of the width and the preview one third. A
+// view's own Flex method, such as Stack's or Text's, instead adds its share
+// to the size of its content.
+func Flex(weight int, view View) *layoutItem {
+	return &layoutItem{view: view, weight: max(0, weight)}
+}
+
+// AlignSelf aligns view across its container instead of by the container's
+// Align: horizontally in a Stack (AlignLeft, AlignCenter, or AlignRight),
+// and vertically in a Group (AlignLeft for the top, AlignCenter, or
+// AlignRight for the bottom).
+//
+// Example:
+//
+//	Stack(
+//	    Text("Title"),
+//	    AlignSelf(AlignRight, Text("v1.2")),
+//	)
+func AlignSelf(a Alignment, view View) *layoutItem {
+	return &layoutItem{view: view, align: a, hasAlign: true}
+}
+
+// Align sets the item's alignment across its container, as AlignSelf does.
+func (l *layoutItem) Align(a Alignment) *layoutItem {
+	l.align, l.hasAlign = a, true
+	return l
+}
+
+// Flex sets the item's weight, as the Flex function does.
+func (l *layoutItem) Flex(weight int) *layoutItem {
+	l.weight = max(0, weight)
+	return l
+}
+
+func (l *layoutItem) flex() int {
+	if l.weight > 0 {
+		return l.weight
+	}
+	if flex, ok := l.view.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (l *layoutItem) size(maxWidth, maxHeight int) (int, int) {
+	return l.view.size(maxWidth, maxHeight)
+}
+
+func (l *layoutItem) render(ctx *RenderContext) {
+	l.view.render(ctx)
+}
+
+// weighted reports whether child is sized by its Flex weight alone, with
+// none of the container's space set aside for its content.
+func weighted(child View) bool {
+	item, ok := child.(*layoutItem)
+	return ok && item.weight > 0
+}
+
+// itemAlignment returns how child is aligned across its container: by
+// AlignSelf, or else by the container's alignment def.
+func itemAlignment(child View, def Alignment) Alignment {
+	if item, ok := child.(*layoutItem); ok && item.hasAlign {
+		return item.align
+	}
+	return def
+}
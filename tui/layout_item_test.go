@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestFlex_SplitsByWeight(t *testing.T) {
+	view := Group(
+		Flex(2, Fill('a')),
+		Flex(1, Text("longer content")),
+	)
+	screen := SprintScreen(view, PrintConfig{Width: 12, Height: 1})
+	assert.Equal(t, "aaaaaaaalong", screen.Row(0))
+
+	// Against a fixed child, the weights share what is left
+	view = Group(
+		Text("|"),
+		Flex(1, Fill('x')),
+		Flex(3, Fill('y')),
+	)
+	screen = SprintScreen(view, PrintConfig{Width: 9, Height: 1})
+	assert.Equal(t, "|xxyyyyyy", screen.Row(0))
+
+	column := Stack(
+		Flex(1, Fill('a')),
+		Flex(2, Stack(Text("b"), Text("b"), Text("b"), Text("b"))),
+	)
+	screen = SprintScreen(column, PrintConfig{Width: 1, Height: 3})
+	assert.Equal(t, "a\nb\nb", screen.Row(0)+"\n"+screen.Row(1)+"\n"+screen.Row(2))
+}
+
+func TestAlignSelf(t *testing.T) {
+	view := Stack(
+		Text("left"),
+		AlignSelf(AlignRight, Text("r")),
+		AlignSelf(AlignCenter, Text("c")),
+	).Align(AlignLeft)
+	w, _ := view.size(0, 0)
+	screen := SprintScreen(view, PrintConfig{Width: w, Height: 3})
+	assert.Equal(t, "left", screen.Row(0))
+	assert.Equal(t, "   r", screen.Row(1))
+	assert.Equal(t, " c", screen.Row(2))
+
+	view2 := Group(
+		Stack(Text("a"), Text("a"), Text("a")),
+		AlignSelf(AlignRight, Text("b")),
+		Flex(1, Text("c")).Align(AlignCenter),
+	)
+	screen = SprintScreen(view2, PrintConfig{Width: 3, Height: 3})
+	assert.Equal(t, "a", screen.Row(0))
+	assert.Equal(t, "a c", screen.Row(1))
+	assert.Equal(t, "ab", screen.Row(2))
+}
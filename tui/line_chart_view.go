@@ -0,0 +1,384 @@
+package tui
+
+import (
+	"math"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// brailleDots maps a dot's position within a braille cell, [row][column],
+// to its bit in the character's offset from U+2800.
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// Series is one line of a LineChart. NaN and infinite values leave a gap in
+// the line.
+type Series struct {
+	Name   string
+	Values []float64
+
+	// Style overrides the palette color for this series.
+	Style *Style
+}
+
+// lineChartView plots series as lines of braille dots.
+type lineChartView struct {
+	series     []Series
+	width      int
+	height     int
+	lo, hi     float64
+	fixedRange bool
+	window     int
+	palette    []Style
+	axisStyle  Style
+	showAxis   bool
+	showLegend bool
+	format     func(float64) string
+}
+
+// LineChart creates a chart that plots each series as a line. Lines are
+// drawn with braille dots, two across and four down per cell, so they
+// are much smoother than block characters allow. The Y axis scales to the
+// data unless Range fixes it, and a legend names the series that have a
+// Name.
+//
+// For a live chart, append to the series' values as samples arrive and set
+// Window so the chart scrolls once it fills.
+//
+// Example:
+//
+//	LineChart(
+//	    Series{Name: "cpu", Values: app.cpu},
+//	    Series{Name: "mem", Values: app.mem},
+//	).Range(0, 100).Window(120).Height(10)
+func LineChart(series ...Series) *lineChartView {
+	return &lineChartView{
+		series: series,
+		palette: []Style{
+			NewStyle().WithForeground(ColorGreen),
+			NewStyle().WithForeground(ColorCyan),
+			NewStyle().WithForeground(ColorYellow),
+			NewStyle().WithForeground(ColorMagenta),
+			NewStyle().WithForeground(ColorBlue),
+			NewStyle().WithForeground(ColorRed),
+		},
+		axisStyle:  NewStyle().WithForeground(ColorBrightBlack),
+		showAxis:   true,
+		showLegend: true,
+		format:     formatChartValue,
+	}
+}
+
+// Size sets the width and the height of the plot, not counting the legend.
+// Without a size the chart fills the space it is given.
+func (c *lineChartView) Size(w, h int) *lineChartView {
+	c.width, c.height = w, h
+	return c
+}
+
+// Width sets the width of the chart, including the axis.
+func (c *lineChartView) Width(w int) *lineChartView {
+	c.width = w
+	return c
+}
+
+// Height sets the height of the plot, not counting the legend.
+func (c *lineChartView) Height(h int) *lineChartView {
+	c.height = h
+	return c
+}
+
+// Range fixes the values at the bottom and top of the chart instead of
+// scaling to the data. Values outside the range are clamped to its edges.
+func (c *lineChartView) Range(lo, hi float64) *lineChartView {
+	c.lo, c.hi = lo, hi
+	c.fixedRange = true
+	return c
+}
+
+// Window shows only the last n values of each series across the full width
+// of the chart. The chart fills from the left and then scrolls as values
+// are appended. Zero shows all values.
+func (c *lineChartView) Window(n int) *lineChartView {
+	c.window = n
+	return c
+}
+
+// Palette sets the styles that series cycle through, in order.
+func (c *lineChartView) Palette(styles ...Style) *lineChartView {
+	if len(styles) > 0 {
+		c.palette = styles
+	}
+	return c
+}
+
+// ShowAxis shows or hides the Y axis labels (shown by default).
+func (c *lineChartView) ShowAxis(show bool) *lineChartView {
+	c.showAxis = show
+	return c
+}
+
+// ShowLegend shows or hides the legend (shown by default when a series has
+// a name).
+func (c *lineChartView) ShowLegend(show bool) *lineChartView {
+	c.showLegend = show
+	return c
+}
+
+// Format sets how axis labels are formatted.
+func (c *lineChartView) Format(format func(float64) string) *lineChartView {
+	if format != nil {
+		c.format = format
+	}
+	return c
+}
+
+// visible returns the values of a series that are in the window.
+func (c *lineChartView) visible(s Series) []float64 {
+	if c.window > 0 && len(s.Values) > c.window {
+		return s.Values[len(s.Values)-c.window:]
+	}
+	return s.Values
+}
+
+// slots returns the number of positions along the X axis.
+func (c *lineChartView) slots() int {
+	if c.window > 0 {
+		return c.window
+	}
+	n := 0
+	for _, s := range c.series {
+		n = max(n, len(s.Values))
+	}
+	return n
+}
+
+// chartRange returns the values at the bottom and top of the chart.
+func (c *lineChartView) chartRange() (lo, hi float64) {
+	if c.fixedRange {
+		return c.lo, c.hi
+	}
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, s := range c.series {
+		for _, v := range c.visible(s) {
+			if finite(v) {
+				lo, hi = min(lo, v), max(hi, v)
+			}
+		}
+	}
+	if lo > hi {
+		return 0, 0
+	}
+	return lo, hi
+}
+
+// finite reports whether v can be plotted.
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// axisWidth returns the width of the axis labels and the line after them.
+func (c *lineChartView) axisWidth(lo, hi float64) int {
+	if !c.showAxis {
+		return 0
+	}
+	return max(runewidth.StringWidth(c.format(lo)), runewidth.StringWidth(c.format(hi))) + 1
+}
+
+// hasLegend reports whether the legend is drawn.
+func (c *lineChartView) hasLegend() bool {
+	if !c.showLegend {
+		return false
+	}
+	for _, s := range c.series {
+		if s.Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// seriesStyle returns the style of the series at index i.
+func (c *lineChartView) seriesStyle(i int) Style {
+	if c.series[i].Style != nil {
+		return *c.series[i].Style
+	}
+	return c.palette[i%len(c.palette)]
+}
+
+func (c *lineChartView) flex() int {
+	if c.width == 0 && c.height == 0 {
+		return 1
+	}
+	return 0
+}
+
+func (c *lineChartView) size(maxWidth, maxHeight int) (int, int) {
+	w, h := c.width, c.height
+	if w == 0 {
+		w = maxWidth
+		if w <= 0 {
+			w = 40
+		}
+	}
+	if h == 0 {
+		h = 8
+		if maxHeight > 0 {
+			h = maxHeight
+			if c.hasLegend() {
+				h--
+			}
+		}
+	}
+	if c.hasLegend() {
+		h++
+	}
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	if maxHeight > 0 && h > maxHeight {
+		h = maxHeight
+	}
+	return w, h
+}
+
+func (c *lineChartView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	plotH := height
+	if c.hasLegend() {
+		plotH--
+		c.renderLegend(ctx, plotH)
+	}
+	if width == 0 || plotH <= 0 {
+		return
+	}
+
+	lo, hi := c.chartRange()
+	x0 := c.axisWidth(lo, hi)
+	if c.showAxis {
+		c.renderAxis(ctx, x0, plotH, lo, hi)
+	}
+	plotW := width - x0
+	if plotW <= 0 {
+		return
+	}
+
+	// Plot every series onto a grid of dots, remembering which series last
+	// touched each cell so it sets the cell's color
+	pxW, pxH := plotW*2, plotH*4
+	dots := make([]rune, plotW*plotH)
+	owner := make([]int, plotW*plotH)
+	set := func(x, y, series int) {
+		if x < 0 || x >= pxW || y < 0 || y >= pxH {
+			return
+		}
+		cell := y/4*plotW + x/2
+		dots[cell] |= brailleDots[y%4][x%2]
+		owner[cell] = series
+	}
+
+	slots := c.slots()
+	toX := func(i int) int {
+		if slots <= 1 {
+			return 0
+		}
+		return int(math.Round(float64(i) * float64(pxW-1) / float64(slots-1)))
+	}
+	toY := func(v float64) int {
+		if hi <= lo {
+			return (pxH - 1) / 2
+		}
+		y := math.Round((1 - (v-lo)/(hi-lo)) * float64(pxH-1))
+		if math.IsNaN(y) {
+			// An infinite fixed range
+			return (pxH - 1) / 2
+		}
+		return int(min(max(y, 0), float64(pxH-1)))
+	}
+
+	for si, s := range c.series {
+		values := c.visible(s)
+		for i, v := range values {
+			if !finite(v) {
+				continue
+			}
+			x, y := toX(i), toY(v)
+			if i == 0 || !finite(values[i-1]) {
+				set(x, y, si)
+				continue
+			}
+			drawDotLine(toX(i-1), toY(values[i-1]), x, y, func(x, y int) { set(x, y, si) })
+		}
+	}
+
+	for cell, bits := range dots {
+		if bits != 0 {
+			ctx.SetCell(x0+cell%plotW, cell/plotW, 0x2800+bits, c.seriesStyle(owner[cell]))
+		}
+	}
+}
+
+// renderAxis draws the Y axis labels and the line between them and the
+// plot. The top, bottom, and (given room) middle rows are labeled.
+func (c *lineChartView) renderAxis(ctx *RenderContext, axisW, plotH int, lo, hi float64) {
+	labels := map[int]float64{0: hi, plotH - 1: lo}
+	if plotH >= 5 {
+		labels[(plotH-1)/2] = hi - (hi-lo)*float64((plotH-1)/2)/float64(plotH-1)
+	}
+	for y := 0; y < plotH; y++ {
+		v, ok := labels[y]
+		if !ok {
+			ctx.SetCell(axisW-1, y, '│', c.axisStyle)
+			continue
+		}
+		text := c.format(v)
+		ctx.PrintTruncated(max(0, axisW-1-runewidth.StringWidth(text)), y, text, c.axisStyle)
+		ctx.SetCell(axisW-1, y, '┤', c.axisStyle)
+	}
+}
+
+// renderLegend draws each named series' color and name on row y.
+func (c *lineChartView) renderLegend(ctx *RenderContext, y int) {
+	x := c.axisWidth(c.chartRange())
+	for i, s := range c.series {
+		if s.Name == "" {
+			continue
+		}
+		ctx.PrintTruncated(x, y, "━━", c.seriesStyle(i))
+		ctx.PrintTruncated(x+3, y, s.Name, NewStyle())
+		x += 3 + runewidth.StringWidth(s.Name) + 2
+	}
+}
+
+// drawDotLine calls set for each dot on the line from (x0, y0) to (x1, y1),
+// using Bresenham's algorithm.
+func drawDotLine(x0, y0, x1, y1 int, set func(x, y int)) {
+	dx, dy := x1-x0, y0-y1
+	sx, sy := 1, 1
+	if dx < 0 {
+		dx, sx = -dx, -1
+	}
+	if dy > 0 {
+		dy, sy = -dy, -1
+	}
+	err := dx + dy
+	for {
+		set(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"math"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/termtest"
+)
+
+func TestLineChart_Dots(t *testing.T) {
+	// A rising line crosses one dot per row and column
+	view := LineChart(Series{Values: []float64{0, 1, 2, 3}}).ShowAxis(false).Size(2, 1)
+	screen := SprintScreen(view, PrintConfig{Width: 2, Height: 1})
+	assert.Equal(t, "⡠⠊", screen.Row(0))
+
+	// A flat line sits in the middle
+	screen = SprintScreen(LineChart(Series{Values: []float64{5, 5}}).ShowAxis(false).Size(1, 1), PrintConfig{Width: 1, Height: 1})
+	assert.Equal(t, "⠒", screen.Row(0))
+}
+
+func TestLineChart_Gaps(t *testing.T) {
+	view := LineChart(Series{Values: []float64{0, math.NaN(), 0, 0}}).ShowAxis(false).Range(0, 1).Size(2, 1)
+	screen := SprintScreen(view, PrintConfig{Width: 2, Height: 1})
+	assert.Equal(t, "⡀⣀", screen.Row(0))
+}
+
+func TestLineChart_InfiniteValues(t *testing.T) {
+	// Infinite values leave gaps and don't stretch the range
+	view := LineChart(Series{Values: []float64{0, math.Inf(1), 0, math.Inf(-1)}}).ShowAxis(false).Range(0, 1).Size(2, 1)
+	screen := SprintScreen(view, PrintConfig{Width: 2, Height: 1})
+	assert.Equal(t, "⡀⡀", screen.Row(0))
+
+	screen = SprintScreen(LineChart(Series{Values: []float64{1, math.Inf(1), 3, math.Inf(-1)}}), PrintConfig{Width: 20, Height: 4})
+	assert.Contains(t, screen.Row(0), "3")
+
+	// Finite values are at the bottom of an infinite fixed range
+	view = LineChart(Series{Values: []float64{1, 2}}).ShowAxis(false).Range(0, math.Inf(1)).Size(1, 1)
+	screen = SprintScreen(view, PrintConfig{Width: 1, Height: 1})
+	assert.Equal(t, "⣀", screen.Row(0))
+	view = LineChart(Series{Values: []float64{1, 2}}).ShowAxis(false).Range(math.Inf(-1), math.Inf(1)).Size(1, 1)
+	screen = SprintScreen(view, PrintConfig{Width: 1, Height: 1})
+	assert.Equal(t, "⠒", screen.Row(0))
+}
+
+func TestLineChart_AxisAndLegend(t *testing.T) {
+	view := LineChart(
+		Series{Name: "cpu", Values: []float64{0, 100}},
+		Series{Name: "mem", Values: []float64{100, 0}},
+	).Size(18, 5)
+
+	w, h := view.size(20, 10)
+	assert.Equal(t, 18, w)
+	assert.Equal(t, 6, h)
+
+	screen := SprintScreen(view, PrintConfig{Width: 18, Height: 6})
+	assert.Equal(t, "100┤", screen.Row(0)[:len("100┤")])
+	assert.Equal(t, " 50┤", screen.Row(2)[:len(" 50┤")])
+	assert.Equal(t, "  0┤", screen.Row(4)[:len("  0┤")])
+	assert.Equal(t, "    ━━ cpu  ━━ mem", screen.Row(5))
+	assert.Equal(t, termtest.Color{Type: termtest.ColorBasic, Value: uint8(ColorGreen)}, screen.Cell(4, 5).Style.Foreground)
+	assert.Equal(t, termtest.Color{Type: termtest.ColorBasic, Value: uint8(ColorCyan)}, screen.Cell(12, 5).Style.Foreground)
+}
+
+func TestLineChart_Window(t *testing.T) {
+	// Only the last four values are plotted, across the full width
+	values := []float64{0, 0, 1, 1, 0, 1}
+	view := LineChart(Series{Values: values}).ShowAxis(false).Range(0, 1).Window(4).Size(4, 1)
+	screen := SprintScreen(view, PrintConfig{Width: 4, Height: 1})
+	assert.Equal(t, "⠉⠑⢄⠎", screen.Row(0))
+
+	// Appending scrolls the chart
+	values = append(values, 1)
+	view = LineChart(Series{Values: values}).ShowAxis(false).Range(0, 1).Window(4).Size(4, 1)
+	screen = SprintScreen(view, PrintConfig{Width: 4, Height: 1})
+	assert.Equal(t, "⠱⡠⠊⠉", screen.Row(0))
+}
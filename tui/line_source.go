@@ -0,0 +1,267 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"io"
+	"sync"
+)
+
+const (
+	// lineCheckpoint is how many lines apart the index records offsets.
+	lineCheckpoint = 256
+	// lineScanChunk is how many bytes are read at a time while indexing.
+	lineScanChunk = 64 * 1024
+
+	defaultMaxCachedLines = 1000
+	defaultMaxLineLength  = 4096
+)
+
+// LineSource reads the lines of a large file on demand, so a view can
+// display a file of any size without loading it. It keeps a sparse index of
+// line offsets, built only as far as the lines that have been asked for, and
+// a bounded cache of recently read lines.
+//
+// Create a LineSource once and keep it in application state; views such as
+// CodeSource read through it each frame. It is safe for concurrent use.
+//
+// Example:
+//
+//	f, _ := os.Open("huge.log")
+//	info, _ := f.Stat()
+//	app.src = tui.NewLineSource(f, info.Size())
+//	...
+//	tui.CodeSource(app.src, "log").ScrollY(&app.scrollY)
+type LineSource struct {
+	// MaxCachedLines bounds how many lines are kept in memory.
+	// Zero uses 1000.
+	MaxCachedLines int
+	// MaxLineLength truncates longer lines, in bytes. Zero uses 4096.
+	MaxLineLength int
+
+	mu          sync.Mutex
+	r           io.ReaderAt
+	size        int64
+	checkpoints []int64 // offset of every lineCheckpoint-th line
+	lines       int     // lines found so far
+	scanned     int64   // bytes indexed so far
+	done        bool
+	err         error
+
+	cache   map[int]*list.Element
+	recency *list.List // of *cachedLine, most recent first
+}
+
+// cachedLine is a line held in a LineSource's cache, along with the styled
+// segments a view last rendered for it.
+type cachedLine struct {
+	n        int
+	text     string
+	styleKey string
+	segments []StyledSegment
+}
+
+// NewLineSource creates a LineSource reading size bytes from r.
+func NewLineSource(r io.ReaderAt, size int64) *LineSource {
+	return &LineSource{
+		r:           r,
+		size:        size,
+		checkpoints: []int64{0},
+		lines:       1,
+		cache:       make(map[int]*list.Element),
+		recency:     list.New(),
+	}
+}
+
+// LineCount returns the number of lines found so far, and whether that is
+// all of them. Lines are indexed as views ask for them; use IndexAll to
+// count the whole file.
+func (s *LineSource) LineCount() (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lines, s.done
+}
+
+// IndexAll indexes the whole file and returns its number of lines.
+func (s *LineSource) IndexAll() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.indexTo(-1)
+	return s.lines
+}
+
+// indexed indexes the file until at least n lines are known and returns
+// the number of lines found, and whether that is all of them.
+func (s *LineSource) indexed(n int) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.indexTo(n)
+	return s.lines, s.done
+}
+
+// Err returns the first error reading the file, if any.
+func (s *LineSource) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Line returns line n (0-based), without its line ending. It returns false
+// if the file has fewer lines.
+func (s *LineSource) Line(n int) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.load(n, 1) {
+		return "", false
+	}
+	return s.get(n).text, true
+}
+
+// load makes sure lines [from, from+count) that exist are cached, reading
+// them in one pass. It reports whether line from exists.
+func (s *LineSource) load(from, count int) bool {
+	if from < 0 {
+		return false
+	}
+	s.indexTo(from + count)
+	if from >= s.lines {
+		return false
+	}
+	count = min(count, s.lines-from, s.cacheLimit())
+
+	missing := false
+	for n := from; n < from+count; n++ {
+		if _, ok := s.cache[n]; !ok {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return true
+	}
+
+	// Seek to the nearest checkpoint and read forward
+	k := from / lineCheckpoint
+	offset := s.checkpoints[k]
+	reader := bufio.NewReaderSize(io.NewSectionReader(s.r, offset, s.size-offset), lineScanChunk)
+	for n := k * lineCheckpoint; n < from+count; n++ {
+		text, err := s.readLine(reader, n >= from)
+		if n >= from {
+			if _, ok := s.cache[n]; !ok {
+				s.put(&cachedLine{n: n, text: text})
+			}
+		}
+		if err != nil {
+			if err != io.EOF && s.err == nil {
+				s.err = err
+			}
+			break
+		}
+	}
+	return true
+}
+
+// readLine reads the next line from reader, truncated to MaxLineLength. If
+// keep is false the line is skipped and an empty string returned.
+func (s *LineSource) readLine(reader *bufio.Reader, keep bool) (string, error) {
+	limit := s.MaxLineLength
+	if limit <= 0 {
+		limit = defaultMaxLineLength
+	}
+	var buf []byte
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if keep && len(buf) < limit {
+			buf = append(buf, chunk[:min(len(chunk), limit-len(buf))]...)
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		buf = bytes.TrimSuffix(buf, []byte("\n"))
+		buf = bytes.TrimSuffix(buf, []byte("\r"))
+		return string(buf), err
+	}
+}
+
+// indexTo scans the file until at least n lines are known, or to the end
+// if n is negative.
+func (s *LineSource) indexTo(n int) {
+	buf := make([]byte, lineScanChunk)
+	for !s.done && (n < 0 || s.lines < n) {
+		read, err := s.r.ReadAt(buf[:min(int64(len(buf)), s.size-s.scanned)], s.scanned)
+		for i, b := range buf[:read] {
+			if b != '\n' {
+				continue
+			}
+			if s.lines%lineCheckpoint == 0 {
+				s.checkpoints = append(s.checkpoints, s.scanned+int64(i)+1)
+			}
+			s.lines++
+		}
+		s.scanned += int64(read)
+		if err != nil && err != io.EOF {
+			s.err = err
+			s.done = true
+		} else if s.scanned >= s.size || read == 0 {
+			s.done = true
+			if read > 0 && buf[read-1] == '\n' {
+				// A trailing newline ends the last line rather than
+				// starting one
+				s.lines--
+				if s.lines%lineCheckpoint == 0 {
+					s.checkpoints = s.checkpoints[:len(s.checkpoints)-1]
+				}
+			}
+		}
+	}
+}
+
+// cacheLimit returns the number of lines the cache holds.
+func (s *LineSource) cacheLimit() int {
+	if s.MaxCachedLines <= 0 {
+		return defaultMaxCachedLines
+	}
+	return s.MaxCachedLines
+}
+
+// get returns cached line n, marking it recently used. The line must be
+// cached.
+func (s *LineSource) get(n int) *cachedLine {
+	el := s.cache[n]
+	s.recency.MoveToFront(el)
+	return el.Value.(*cachedLine)
+}
+
+// put adds a line to the cache, evicting the least recently used lines
+// beyond MaxCachedLines.
+func (s *LineSource) put(line *cachedLine) {
+	s.cache[line.n] = s.recency.PushFront(line)
+	for s.recency.Len() > s.cacheLimit() {
+		oldest := s.recency.Back()
+		s.recency.Remove(oldest)
+		delete(s.cache, oldest.Value.(*cachedLine).n)
+	}
+}
+
+// visibleLines loads lines [from, from+count) and returns the ones that
+// exist, highlighting each with highlight unless it was last highlighted
+// under the same styleKey.
+func (s *LineSource) visibleLines(from, count int, styleKey string, highlight func(text string) []StyledSegment) (texts []string, segments [][]StyledSegment) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.load(from, count) {
+		return nil, nil
+	}
+	count = min(count, s.lines-from, s.cacheLimit())
+	for n := from; n < from+count; n++ {
+		line := s.get(n)
+		if line.segments == nil || line.styleKey != styleKey {
+			line.segments = highlight(line.text)
+			line.styleKey = styleKey
+		}
+		texts = append(texts, line.text)
+		segments = append(segments, line.segments)
+	}
+	return texts, segments
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+// countingReader records the furthest byte read.
+type countingReader struct {
+	*bytes.Reader
+	furthest int64
+}
+
+func (r *countingReader) ReadAt(p []byte, off int64) (int, error) {
+	n, err := r.Reader.ReadAt(p, off)
+	r.furthest = max(r.furthest, off+int64(n))
+	return n, err
+}
+
+func numberedLines(n int) string {
+	var b strings.Builder
+	for i := range n {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	return b.String()
+}
+
+func TestLineSource_Lines(t *testing.T) {
+	data := numberedLines(1000)
+	src := NewLineSource(strings.NewReader(data), int64(len(data)))
+
+	text, ok := src.Line(700)
+	assert.True(t, ok)
+	assert.Equal(t, "line 700", text)
+	text, ok = src.Line(0)
+	assert.True(t, ok)
+	assert.Equal(t, "line 0", text)
+	text, _ = src.Line(256)
+	assert.Equal(t, "line 256", text)
+
+	// The trailing newline doesn't start another line
+	assert.Equal(t, 1000, src.IndexAll())
+	_, ok = src.Line(1000)
+	assert.False(t, ok)
+	assert.NoError(t, src.Err())
+}
+
+func TestLineSource_LineEndings(t *testing.T) {
+	data := "one\r\ntwo\r\n\r\nfour"
+	src := NewLineSource(strings.NewReader(data), int64(len(data)))
+	assert.Equal(t, 4, src.IndexAll())
+	for i, want := range []string{"one", "two", "", "four"} {
+		text, ok := src.Line(i)
+		assert.True(t, ok)
+		assert.Equal(t, want, text)
+	}
+
+	empty := NewLineSource(strings.NewReader(""), 0)
+	assert.Equal(t, 1, empty.IndexAll())
+}
+
+func TestLineSource_Lazy(t *testing.T) {
+	data := numberedLines(100_000)
+	r := &countingReader{Reader: bytes.NewReader([]byte(data))}
+	src := NewLineSource(r, int64(len(data)))
+
+	text, _ := src.Line(10)
+	assert.Equal(t, "line 10", text)
+	n, done := src.LineCount()
+	assert.False(t, done)
+	assert.True(t, n < 100_000)
+	assert.True(t, r.furthest <= lineScanChunk, "read %d bytes", r.furthest)
+}
+
+func TestLineSource_Bounds(t *testing.T) {
+	data := strings.Repeat("x", 100) + "\n" + numberedLines(50)
+	src := NewLineSource(strings.NewReader(data), int64(len(data)))
+	src.MaxCachedLines = 10
+	src.MaxLineLength = 8
+
+	text, _ := src.Line(0)
+	assert.Equal(t, "xxxxxxxx", text)
+
+	texts, _ := src.visibleLines(1, 30, "", func(string) []StyledSegment { return nil })
+	assert.Equal(t, 10, len(texts))
+	assert.Equal(t, "line 0", texts[0])
+	assert.Equal(t, 10, src.recency.Len())
+	assert.Equal(t, 10, len(src.cache))
+}
+
+func TestCodeSource_Render(t *testing.T) {
+	data := numberedLines(1000)
+	src := NewLineSource(strings.NewReader(data), int64(len(data)))
+	scrollY := 500
+
+	screen := SprintScreen(CodeSource(src, "text").ScrollY(&scrollY).Height(3), PrintConfig{Width: 20, Height: 3})
+	assert.Equal(t, " 501 line 500", screen.Row(0))
+	assert.Equal(t, " 503 line 502", screen.Row(2))
+
+	// Scrolling past the end clamps to the last page
+	scrollY = 5000
+	screen = SprintScreen(CodeSource(src, "text").ScrollY(&scrollY).Height(3), PrintConfig{Width: 20, Height: 3})
+	assert.Equal(t, 997, scrollY)
+	assert.Equal(t, "1000 line 999", screen.Row(2))
+
+	// Short files take only the rows they need
+	short := NewLineSource(strings.NewReader("a\nb\n"), 4)
+	_, h := CodeSource(short, "text").size(20, 10)
+	assert.Equal(t, 2, h)
+	assert.Equal(t, 2, CodeSource(short, "text").GetLineCount())
+}
@@ -0,0 +1,151 @@
+package tui
+
+// listRowView draws a row of a list with its selected, hover, or zebra
+// background across the full width it is given.
+type listRowView struct {
+	inner    View
+	selected bool
+	striped  bool
+	onClick  func()
+
+	// The zero Style takes the color from the theme
+	selectedStyle Style
+	hoverStyle    Style
+	zebraStyle    Style
+}
+
+// ListRow draws content as a row of a list, with the background of a
+// selected row, the row under the mouse pointer, or every other row across
+// all the width it is given, as FillBg does. Colors come from the theme, so
+// rows look the same in every list and follow theme switches:
+//
+//   - Selected rows have the primary color behind them, and their text
+//     takes the theme's background color, except where content draws a
+//     background of its own, such as a badge
+//   - The row under the pointer is tinted toward the primary color; hover
+//     requires mouse tracking (WithMouseTracking)
+//   - With Zebra, odd rows are tinted toward the text color
+//
+// Example:
+//
+//	tui.ForEach(app.files, func(f File, i int) tui.View {
+//	    return tui.ListRow(tui.Group(tui.Text(f.Name), tui.Spacer(), tui.Text(f.Size).Dim())).
+//	        Selected(i == app.cursor).
+//	        Zebra(i).
+//	        OnClick(func() { app.cursor = i })
+//	})
+func ListRow(content View) *listRowView {
+	return &listRowView{inner: content}
+}
+
+// Selected sets whether the row is the selected one.
+func (r *listRowView) Selected(selected bool) *listRowView {
+	r.selected = selected
+	return r
+}
+
+// Zebra stripes the row when index, its position in the list, is odd, so
+// the rows of long lists are easier to follow across wide screens.
+func (r *listRowView) Zebra(index int) *listRowView {
+	r.striped = index%2 == 1
+	return r
+}
+
+// OnClick makes the row clickable. Clickable views inside the row, such as
+// buttons, still get their own clicks.
+func (r *listRowView) OnClick(fn func()) *listRowView {
+	r.onClick = fn
+	return r
+}
+
+// SelectedStyle sets the style of a selected row in place of the theme's
+// colors. Its background fills the row, and it is merged over the content's
+// style, so a foreground replaces the text's and attributes like Reverse
+// are added.
+func (r *listRowView) SelectedStyle(s Style) *listRowView {
+	r.selectedStyle = s
+	return r
+}
+
+// HoverStyle sets the style of the row under the mouse pointer in place of
+// the theme's colors, used like SelectedStyle's.
+func (r *listRowView) HoverStyle(s Style) *listRowView {
+	r.hoverStyle = s
+	return r
+}
+
+// ZebraStyle sets the style of striped rows in place of the theme's colors,
+// used like SelectedStyle's.
+func (r *listRowView) ZebraStyle(s Style) *listRowView {
+	r.zebraStyle = s
+	return r
+}
+
+func (r *listRowView) size(maxWidth, maxHeight int) (int, int) {
+	w, h := r.inner.size(maxWidth, maxHeight)
+	if maxWidth > 0 {
+		w = maxWidth
+	}
+	return w, h
+}
+
+// flex implements the Flexible interface by delegating to the inner view.
+func (r *listRowView) flex() int {
+	if flex, ok := r.inner.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+// rowStyle returns the style of the row's state, and false for a plain
+// row.
+func (r *listRowView) rowStyle(ctx *RenderContext) (Style, bool) {
+	theme := ctx.Theme()
+	switch {
+	case r.selected:
+		if r.selectedStyle != (Style{}) {
+			return r.selectedStyle, true
+		}
+		return NewStyle().WithBgRGB(theme.Primary).WithFgRGB(theme.Background), true
+	case interactiveRegistry.Hovered(ctx.AbsoluteBounds()):
+		if r.hoverStyle != (Style{}) {
+			return r.hoverStyle, true
+		}
+		return NewStyle().WithBgRGB(blendRGB(theme.Background, theme.Primary, 0.2)), true
+	case r.striped:
+		if r.zebraStyle != (Style{}) {
+			return r.zebraStyle, true
+		}
+		return NewStyle().WithBgRGB(blendRGB(theme.Background, theme.Text, 0.06)), true
+	}
+	return Style{}, false
+}
+
+func (r *listRowView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+
+	style, ok := r.rowStyle(ctx)
+	if !ok {
+		r.inner.render(ctx)
+	} else {
+		ctx.FillStyled(0, 0, width, height, ' ', style)
+		frame := &styleRenderFrame{
+			RenderFrame: ctx.RenderFrame(),
+			transform: func(s Style) Style {
+				if s.Background != ColorDefault || s.BgRGB != nil {
+					return s // content with a background of its own
+				}
+				return s.Merge(style)
+			},
+		}
+		r.inner.render(ctx.WithFrame(frame))
+	}
+
+	// Registered after the content, so clickables inside the row win
+	if r.onClick != nil {
+		interactiveRegistry.RegisterRegion(ctx.AbsoluteBounds(), r.onClick)
+	}
+}
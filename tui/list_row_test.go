@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestListRow_SelectedUsesTheme(t *testing.T) {
+	theme := DarkTheme
+	view := Themed(theme, Stack(
+		ListRow(Group(Text("a"), Text("ok").Bg(ColorRed))).Selected(true),
+		ListRow(Text("b")),
+	))
+	terminal := renderToTerminal(t, view, 10, 2)
+
+	// The selected row is filled across the width with the primary color,
+	// and its text takes the background color
+	for x := 0; x < 10; x++ {
+		if x == 1 || x == 2 {
+			continue
+		}
+		assert.Equal(t, theme.Primary, *terminal.GetCell(x, 0).Style.BgRGB, "cell %d", x)
+	}
+	assert.Equal(t, theme.Background, *terminal.GetCell(0, 0).Style.FgRGB)
+	// Content with a background of its own keeps it
+	assert.Equal(t, ColorRed, terminal.GetCell(1, 0).Style.Background)
+	assert.Nil(t, terminal.GetCell(1, 0).Style.BgRGB)
+	// Plain rows are left as they are
+	assert.Equal(t, NewStyle(), terminal.GetCell(0, 1).Style)
+}
+
+func TestListRow_Zebra(t *testing.T) {
+	var rows []View
+	for i := range 3 {
+		rows = append(rows, ListRow(Text("row")).Zebra(i))
+	}
+	terminal := renderToTerminal(t, Themed(LightTheme, Stack(rows...)), 6, 3)
+
+	assert.Nil(t, terminal.GetCell(5, 0).Style.BgRGB)
+	assert.Equal(t, blendRGB(LightTheme.Background, LightTheme.Text, 0.06), *terminal.GetCell(5, 1).Style.BgRGB)
+	assert.Nil(t, terminal.GetCell(5, 2).Style.BgRGB)
+}
+
+func TestListRow_CustomStyles(t *testing.T) {
+	view := Stack(
+		ListRow(Text("a").Fg(ColorGreen)).Selected(true).SelectedStyle(NewStyle().WithReverse()),
+		ListRow(Text("b")).Zebra(1).ZebraStyle(NewStyle().WithBackground(ColorBlue)),
+	)
+	terminal := renderToTerminal(t, view, 4, 2)
+
+	// Attributes are added to the content's style
+	assert.Equal(t, NewStyle().WithForeground(ColorGreen).WithReverse(), terminal.GetCell(0, 0).Style)
+	assert.True(t, terminal.GetCell(3, 0).Style.Reverse)
+	assert.Equal(t, ColorBlue, terminal.GetCell(3, 1).Style.Background)
+}
+
+func TestListRow_HoverAndClick(t *testing.T) {
+	interactiveRegistry.Clear()
+	defer interactiveRegistry.Clear()
+	defer interactiveRegistry.HandleHover(MouseEvent{Type: MouseLeave})
+
+	clicked := -1
+	hover := NewStyle().WithBackground(ColorYellow)
+	view := func() View {
+		return Stack(
+			ListRow(Text("first")).HoverStyle(hover).OnClick(func() { clicked = 0 }),
+			ListRow(Group(Text("second "), Clickable("x", func() { clicked = 9 }))).HoverStyle(hover).OnClick(func() { clicked = 1 }),
+		)
+	}
+	interactiveRegistry.HandleHover(MouseEvent{Type: MouseMove, X: 8, Y: 0})
+	terminal := renderToTerminal(t, view(), 12, 2)
+
+	assert.Equal(t, ColorYellow, terminal.GetCell(11, 0).Style.Background)
+	assert.Equal(t, ColorDefault, terminal.GetCell(11, 1).Style.Background)
+
+	assert.True(t, interactiveRegistry.HandleClick(10, 1))
+	assert.Equal(t, 1, clicked)
+	// Clickables in the row get their own clicks
+	assert.True(t, interactiveRegistry.HandleClick(7, 1))
+	assert.Equal(t, 9, clicked)
+}
+
+func TestListRow_Size(t *testing.T) {
+	row := ListRow(Text("abc"))
+	w, h := row.size(20, 10)
+	assert.Equal(t, 20, w)
+	assert.Equal(t, 1, h)
+	w, _ = row.size(0, 0)
+	assert.Equal(t, 3, w)
+}
@@ -3,7 +3,9 @@ package tui
 import (
 	"fmt"
 	"image"
-	"strings"
+	"slices"
+
+	"github.com/deepnoodle-ai/wonton/fuzzy"
 )
 
 // ListItemRenderer is a function that renders a list item.
@@ -33,6 +35,9 @@ type listView struct {
 	// Filtering
 	filterText        *string // pointer to filter text binding
 	filterFunc        func(item ListItem, query string) bool
+	matches           map[int][]int // matched label rune positions by item index, with the default filter
+	async             *AsyncFilter
+	collator          Collator
 	showFilter        bool
 	filterPlaceholder string
 
@@ -42,6 +47,7 @@ type listView struct {
 	style         Style
 	selectedStyle Style // style for active/cursor item
 	chosenStyle   Style // style for chosen items
+	matchStyle    Style // style for characters matching the filter
 	filterStyle   Style
 	width         int
 	height        int
@@ -94,6 +100,7 @@ func FilterableList(items []ListItem, selected *int) *listView {
 		style:             NewStyle(),
 		selectedStyle:     NewStyle().WithReverse(),
 		chosenStyle:       NewStyle().WithForeground(ColorGreen),
+		matchStyle:        NewStyle().WithForeground(ColorYellow).WithBold(),
 		filterStyle:       NewStyle().WithForeground(ColorBrightBlack),
 		filterPlaceholder: "Filter...",
 	}
@@ -259,6 +266,13 @@ func (l *listView) ChosenFg(c Color) *listView {
 	return l
 }
 
+// MatchStyle sets the style of the label characters matching the filter.
+// Only the default filter reports matches.
+func (l *listView) MatchStyle(s Style) *listView {
+	l.matchStyle = s
+	return l
+}
+
 // ChosenBg sets the background color for chosen items (items confirmed with Enter).
 func (l *listView) ChosenBg(c Color) *listView {
 	l.chosenStyle = l.chosenStyle.WithBackground(c)
@@ -370,13 +384,33 @@ func (l *listView) FilterPlaceholder(text string) *listView {
 	return l
 }
 
-// FilterFunc sets a custom filter function.
-// Default is case-insensitive substring match on Label.
+// FilterFunc sets a custom filter function, which keeps the items' order.
+// By default, items whose Label fuzzy-matches the query are kept, best match
+// first, with the matching characters highlighted (see the fuzzy package).
 func (l *listView) FilterFunc(fn func(item ListItem, query string) bool) *listView {
 	l.filterFunc = fn
 	return l
 }
 
+// AsyncFilter filters the items in the background with f, for item sets
+// too large to filter on every keystroke. The app passes its events to
+// f.Update; see AsyncFilter. The items given to the list must be the ones
+// given to f.
+func (l *listView) AsyncFilter(f *AsyncFilter) *listView {
+	l.async = f
+	return l
+}
+
+// Collator lists the items in the order of their labels, such as with
+// LocaleCollator("de"), and orders equally good matches of the default
+// filter the same way, including an AsyncFilter without a Collator of its
+// own. Without one, items keep their order. Selection callbacks still
+// report the items' original indices.
+func (l *listView) Collator(c Collator) *listView {
+	l.collator = c
+	return l
+}
+
 // ScrollY binds an external scroll position for programmatic control.
 func (l *listView) ScrollY(scrollY *int) *listView {
 	l.scrollOffset = scrollY
@@ -394,27 +428,42 @@ func (l *listView) ScrollOffset(offset *int) *listView {
 func (l *listView) applyFilter() {
 	if l.filterText == nil || *l.filterText == "" {
 		// No filter - show all items
+		clear(l.matches)
 		l.filteredIdxs = make([]int, len(l.items))
 		for i := range l.items {
 			l.filteredIdxs[i] = i
 		}
+		l.collate()
 		return
 	}
 
 	query := *l.filterText
 	l.filteredIdxs = l.filteredIdxs[:0]
+	clear(l.matches)
 
-	filterFn := l.filterFunc
-	if filterFn == nil {
-		// Default: case-insensitive substring match on label
-		filterFn = func(item ListItem, q string) bool {
-			return strings.Contains(strings.ToLower(item.Label), strings.ToLower(q))
+	if l.async != nil {
+		if l.async.Collator == nil {
+			l.async.Collator = l.collator
 		}
-	}
-
-	for i, item := range l.items {
-		if filterFn(item, query) {
-			l.filteredIdxs = append(l.filteredIdxs, i)
+		l.filteredIdxs = l.async.Matches()
+	} else if l.filterFunc != nil {
+		for i, item := range l.items {
+			if l.filterFunc(item, query) {
+				l.filteredIdxs = append(l.filteredIdxs, i)
+			}
+		}
+		l.collate()
+	} else {
+		labels := make([]string, len(l.items))
+		for i, item := range l.items {
+			labels[i] = item.Label
+		}
+		if l.matches == nil {
+			l.matches = make(map[int][]int)
+		}
+		for _, r := range fuzzy.RankFunc(query, labels, l.collator) {
+			l.filteredIdxs = append(l.filteredIdxs, r.Index)
+			l.matches[r.Index] = r.Positions
 		}
 	}
 
@@ -428,6 +477,16 @@ func (l *listView) applyFilter() {
 	}
 }
 
+// collate puts the filtered items in the order of the collator, if any.
+func (l *listView) collate() {
+	if l.collator == nil {
+		return
+	}
+	slices.SortStableFunc(l.filteredIdxs, func(a, b int) int {
+		return l.collator(l.items[a].Label, l.items[b].Label)
+	})
+}
+
 func (l *listView) size(maxWidth, maxHeight int) (int, int) {
 	l.applyFilter()
 
@@ -529,6 +588,15 @@ func (l *listView) renderFilter(ctx *RenderContext) {
 
 	ctx.PrintStyled(0, 0, prefix, l.filterStyle)
 
+	// Show how far a background filter has got
+	if l.async != nil && l.async.Busy() {
+		done, total := l.async.Progress()
+		progress := fmt.Sprintf(" %d%%", done*100/max(1, total))
+		w, _ := ctx.Size()
+		pw, _ := MeasureText(progress)
+		ctx.PrintTruncated(w-pw, 0, progress, l.filterStyle)
+	}
+
 	// Show filter text or placeholder
 	displayText := *l.filterText
 	if displayText == "" {
@@ -714,12 +782,11 @@ func (l *listView) renderItem(ctx *RenderContext, item ListItem, selected bool,
 	}
 
 	// Build the full text to render (without checkbox - that goes on right)
-	var fullText string
+	var fullText, prefix string
 	if item.Icon != "" {
-		fullText = item.Icon + "  " + item.Label // Double space after icon for safety
-	} else {
-		fullText = item.Label
+		prefix = item.Icon + "  " // Double space after icon for safety
 	}
+	fullText = prefix + item.Label
 
 	// Fill background for selected or chosen items
 	if (selected || chosen) && height > 0 {
@@ -743,6 +810,14 @@ func (l *listView) renderItem(ctx *RenderContext, item ListItem, selected bool,
 	maxTextWidth := width - markerWidth
 	if maxTextWidth > 0 {
 		ctx.PrintTruncated(0, 0, fullText, style)
+		positions := l.matches[origIdx]
+		if l.async != nil {
+			positions = l.async.positions(index)
+		}
+		if len(positions) > 0 {
+			prefixW, _ := MeasureText(prefix)
+			printMatched(ctx, prefixW, 0, item.Label, positions, width-prefixW, style.Merge(l.matchStyle))
+		}
 	}
 
 	// Render marker on the right side if present
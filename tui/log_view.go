@@ -0,0 +1,564 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// LogLevel is the severity of a log line, used to color it.
+type LogLevel int
+
+const (
+	LogLevelNone LogLevel = iota // No level found
+	LogLevelTrace
+	LogLevelDebug
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+	LogLevelFatal
+)
+
+// String returns the level's name, such as "WARN".
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelTrace:
+		return "TRACE"
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	case LogLevelFatal:
+		return "FATAL"
+	}
+	return ""
+}
+
+// logLevelWords maps the words that name a level to the level.
+var logLevelWords = map[string]LogLevel{
+	"trace": LogLevelTrace, "trc": LogLevelTrace,
+	"debug": LogLevelDebug, "dbg": LogLevelDebug,
+	"info": LogLevelInfo, "inf": LogLevelInfo, "notice": LogLevelInfo,
+	"warn": LogLevelWarn, "warning": LogLevelWarn, "wrn": LogLevelWarn,
+	"error": LogLevelError, "err": LogLevelError, "erro": LogLevelError,
+	"fatal": LogLevelFatal, "panic": LogLevelFatal, "critical": LogLevelFatal, "crit": LogLevelFatal,
+}
+
+// DetectLogLevel finds the level of a log line from its first few words,
+// matching names such as "INFO", "[warn]", "error:", or slog's
+// "level=ERROR". A lowercase name only counts when it is marked off like
+// these, so "GET /info" and "no error" have no level. It returns
+// LogLevelNone when no level is named.
+func DetectLogLevel(text string) LogLevel {
+	words := 0
+	for i := 0; i < len(text) && words < 6; {
+		if !isASCIILetter(text[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(text) && isASCIILetter(text[j]) {
+			j++
+		}
+		word := text[i:j]
+		words++
+		// The characters around the word, skipping spaces
+		var before, after byte = '^', '$'
+		for k := i - 1; k >= 0; k-- {
+			if text[k] != ' ' {
+				before = text[k]
+				break
+			}
+		}
+		for k := j; k < len(text); k++ {
+			if text[k] != ' ' {
+				after = text[k]
+				break
+			}
+		}
+		level, ok := logLevelWords[strings.ToLower(word)]
+		marked := strings.IndexByte("^[(<=|", before) >= 0 || strings.IndexByte("]):|>", after) >= 0
+		if ok && before != '/' && before != '.' && (marked || word == strings.ToUpper(word)) {
+			return level
+		}
+		i = j
+	}
+	return LogLevelNone
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// LogLine is one line of a LogBuffer.
+type LogLine struct {
+	Time  time.Time
+	Level LogLevel
+	Text  string
+}
+
+// DefaultLogCapacity is the number of lines a LogBuffer keeps by default.
+const DefaultLogCapacity = 10000
+
+// LogBuffer holds the most recent lines of a log in a ring buffer, for
+// display with LogView. Appending is cheap and never grows the buffer past
+// its capacity: the oldest lines are dropped instead.
+//
+// A LogBuffer is an io.Writer, so it can receive the output of the log and
+// log/slog packages or of a subprocess directly. It is safe for concurrent
+// use; append from any goroutine and render with LogView.
+//
+// Example:
+//
+//	app.logs = tui.NewLogBuffer(5000)
+//	logger := slog.New(slog.NewTextHandler(app.logs, nil))
+//	cmd.Stdout = app.logs
+type LogBuffer struct {
+	mu      sync.Mutex
+	lines   []LogLine
+	start   int    // index of the oldest line in lines
+	count   int    // lines held
+	total   int64  // lines ever appended
+	pending []byte // partial line written without a newline
+	now     func() time.Time
+}
+
+// NewLogBuffer creates a buffer that keeps the last capacity lines. Zero
+// uses DefaultLogCapacity, as does the zero LogBuffer.
+func NewLogBuffer(capacity int) *LogBuffer {
+	if capacity <= 0 {
+		capacity = DefaultLogCapacity
+	}
+	return &LogBuffer{lines: make([]LogLine, capacity), now: time.Now}
+}
+
+// Append adds text as one or more lines, split on newlines, stamped with
+// the current time and with levels found by DetectLogLevel.
+func (b *LogBuffer) Append(text string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.timeNow()
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		b.appendLocked(newLogLine(now, line))
+	}
+}
+
+// Appendf adds a formatted line, as Append.
+func (b *LogBuffer) Appendf(format string, args ...any) {
+	b.Append(fmt.Sprintf(format, args...))
+}
+
+// AppendLine adds a line as is.
+func (b *LogBuffer) AppendLine(line LogLine) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.appendLocked(line)
+}
+
+// Write implements io.Writer. Each complete line written is appended as
+// Append would; a trailing partial line waits for the rest of its text.
+func (b *LogBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.timeNow()
+	data := p
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		line := string(append(b.pending, data[:i]...))
+		b.pending = b.pending[:0]
+		b.appendLocked(newLogLine(now, line))
+		data = data[i+1:]
+	}
+	b.pending = append(b.pending, data...)
+	return len(p), nil
+}
+
+func (b *LogBuffer) timeNow() time.Time {
+	if b.now == nil {
+		return time.Now()
+	}
+	return b.now()
+}
+
+func newLogLine(t time.Time, text string) LogLine {
+	text = strings.TrimSuffix(text, "\r")
+	return LogLine{Time: t, Level: DetectLogLevel(text), Text: text}
+}
+
+func (b *LogBuffer) appendLocked(line LogLine) {
+	if b.lines == nil {
+		b.lines = make([]LogLine, DefaultLogCapacity)
+	}
+	capacity := len(b.lines)
+	if b.count < capacity {
+		b.lines[(b.start+b.count)%capacity] = line
+		b.count++
+	} else {
+		b.lines[b.start] = line
+		b.start = (b.start + 1) % capacity
+	}
+	b.total++
+}
+
+// Len returns the number of lines held.
+func (b *LogBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.count
+}
+
+// Total returns the number of lines ever appended, including those dropped
+// to make room.
+func (b *LogBuffer) Total() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.total
+}
+
+// Line returns the i-th line held, oldest first.
+func (b *LogBuffer) Line(i int) (LogLine, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if i < 0 || i >= b.count {
+		return LogLine{}, false
+	}
+	return b.lines[(b.start+i)%len(b.lines)], true
+}
+
+// Lines returns a copy of the lines held, oldest first.
+func (b *LogBuffer) Lines() []LogLine {
+	lines, _ := b.snapshot()
+	return lines
+}
+
+// snapshot returns the lines held and the sequence number of the first,
+// counting from the first line ever appended.
+func (b *LogBuffer) snapshot() ([]LogLine, int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	lines := make([]LogLine, b.count)
+	for i := range lines {
+		lines[i] = b.lines[(b.start+i)%len(b.lines)]
+	}
+	return lines, b.total - int64(b.count)
+}
+
+// Clear removes all lines.
+func (b *LogBuffer) Clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	clear(b.lines)
+	b.start, b.count = 0, 0
+	b.pending = b.pending[:0]
+}
+
+// LogState holds the scroll position of an interactive LogView. The view
+// follows the end of the log until the user scrolls up, and follows again
+// once they scroll back to the bottom. It lives in application state, which
+// forwards keys to HandleKey.
+type LogState struct {
+	paused bool
+	top    int64 // sequence number of the top line while paused
+	seen   int64 // lines appended when the view last reached the end
+
+	// From the last render
+	first  int64
+	count  int
+	height int
+}
+
+// NewLogState creates the state for an interactive LogView.
+func NewLogState() *LogState {
+	return &LogState{}
+}
+
+// Following reports whether the view is following the end of the log.
+func (s *LogState) Following() bool {
+	return !s.paused
+}
+
+// Follow resumes following the end of the log.
+func (s *LogState) Follow() {
+	s.paused = false
+}
+
+// NewLines returns the number of lines appended since the view stopped
+// following, as of the last render.
+func (s *LogState) NewLines() int {
+	if !s.paused {
+		return 0
+	}
+	return int(s.first + int64(s.count) - s.seen)
+}
+
+// topIndex returns the index of the top line among the count held at the
+// last render.
+func (s *LogState) topIndex() int {
+	bottom := max(0, s.count-s.height)
+	if !s.paused {
+		return bottom
+	}
+	return int(max(0, min(s.top-s.first, int64(bottom))))
+}
+
+// scrollTo shows the line at index i at the top, following when that is
+// the bottom.
+func (s *LogState) scrollTo(i int) {
+	bottom := max(0, s.count-s.height)
+	if i >= bottom {
+		s.paused = false
+		return
+	}
+	if !s.paused {
+		s.seen = s.first + int64(s.count)
+	}
+	s.paused = true
+	s.top = s.first + int64(max(0, i))
+}
+
+// HandleKey handles a key for the view and reports whether it used it:
+//
+//   - Up/Down or k/j: Scroll by a line
+//   - PgUp/PgDn: Scroll by a page
+//   - Home or g: Go to the oldest line
+//   - End or G: Go to the end and follow
+//   - f: Toggle following
+func (s *LogState) HandleKey(event KeyEvent) bool {
+	page := max(1, s.height-1)
+	top := s.topIndex()
+	switch {
+	case event.Key == KeyArrowUp || event.Rune == 'k':
+		s.scrollTo(top - 1)
+	case event.Key == KeyArrowDown || event.Rune == 'j':
+		s.scrollTo(top + 1)
+	case event.Key == KeyPageUp:
+		s.scrollTo(top - page)
+	case event.Key == KeyPageDown:
+		s.scrollTo(top + page)
+	case event.Key == KeyHome || event.Rune == 'g':
+		s.scrollTo(0)
+	case event.Key == KeyEnd || event.Rune == 'G':
+		s.paused = false
+	case event.Rune == 'f':
+		if s.paused {
+			s.paused = false
+		} else {
+			s.paused = true
+			s.top = s.first + int64(top)
+			s.seen = s.first + int64(s.count)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// logView renders the lines of a LogBuffer.
+type logView struct {
+	buf         *LogBuffer
+	state       *LogState
+	search      *SearchController
+	height      int
+	timeLayout  string
+	levelStyles map[LogLevel]Style
+	timeStyle   Style
+	badgeStyle  Style
+}
+
+// LogView renders the tail of a LogBuffer, one line per row, colored by
+// level. Lines longer than the view are truncated.
+//
+// On its own the view always shows the newest lines. Bind a LogState to let
+// the user scroll back: the view pauses while they read older lines, shows
+// how many lines arrived since, and follows again at the bottom. SearchWith
+// highlights matches and scrolls to the current one.
+//
+// Example:
+//
+//	tui.Stack(
+//	    tui.LogView(app.logs).State(app.logState).Timestamps("15:04:05").SearchWith(&app.search),
+//	    tui.SearchBar(&app.search),
+//	)
+func LogView(buf *LogBuffer) *logView {
+	return &logView{
+		buf: buf,
+		levelStyles: map[LogLevel]Style{
+			LogLevelTrace: NewStyle().WithForeground(ColorBrightBlack),
+			LogLevelDebug: NewStyle().WithForeground(ColorBrightBlack),
+			LogLevelInfo:  NewStyle(),
+			LogLevelWarn:  NewStyle().WithForeground(ColorYellow),
+			LogLevelError: NewStyle().WithForeground(ColorRed),
+			LogLevelFatal: NewStyle().WithForeground(ColorRed).WithBold(),
+		},
+		timeStyle:  NewStyle().WithForeground(ColorBrightBlack),
+		badgeStyle: NewStyle().WithReverse(),
+	}
+}
+
+// State binds the scroll state.
+func (v *logView) State(state *LogState) *logView {
+	v.state = state
+	return v
+}
+
+// SearchWith binds a SearchController. Matches are highlighted, and moving
+// to a match stops following so it stays in view.
+func (v *logView) SearchWith(ctrl *SearchController) *logView {
+	v.search = ctrl
+	return v
+}
+
+// Height sets a fixed height. By default a view with state fills the space
+// it is given, and one without is as tall as the log.
+func (v *logView) Height(h int) *logView {
+	v.height = h
+	return v
+}
+
+// Timestamps shows each line's time in a column formatted with layout, such
+// as "15:04:05" or time.DateTime. Empty hides the column, the default.
+func (v *logView) Timestamps(layout string) *logView {
+	v.timeLayout = layout
+	return v
+}
+
+// LevelStyle sets the style of lines with the given level.
+func (v *logView) LevelStyle(level LogLevel, s Style) *logView {
+	v.levelStyles[level] = s
+	return v
+}
+
+// TimeStyle sets the style of the timestamp column.
+func (v *logView) TimeStyle(s Style) *logView {
+	v.timeStyle = s
+	return v
+}
+
+func (v *logView) flex() int {
+	if v.state != nil && v.height == 0 {
+		return 1
+	}
+	return 0
+}
+
+func (v *logView) gutter() int {
+	if v.timeLayout == "" {
+		return 0
+	}
+	return runewidth.StringWidth(time.Time{}.Format(v.timeLayout)) + 1
+}
+
+func (v *logView) size(maxWidth, maxHeight int) (int, int) {
+	var lines []LogLine
+	if v.buf != nil {
+		lines, _ = v.buf.snapshot()
+	}
+	w := 0
+	for _, line := range lines {
+		w = max(w, runewidth.StringWidth(line.Text))
+	}
+	if len(lines) > 0 {
+		w += v.gutter()
+	}
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	h := v.height
+	if h == 0 {
+		h = len(lines)
+		if v.state != nil && maxHeight > 0 {
+			h = maxHeight
+		}
+	}
+	if maxHeight > 0 && h > maxHeight {
+		h = maxHeight
+	}
+	return w, h
+}
+
+func (v *logView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 || v.buf == nil {
+		return
+	}
+	lines, first := v.buf.snapshot()
+
+	s := v.state
+	if s == nil {
+		s = &LogState{}
+	}
+	s.first, s.count, s.height = first, len(lines), height
+	if !s.paused {
+		s.seen = first + int64(len(lines))
+	}
+	top := s.topIndex()
+
+	if v.search != nil {
+		texts := make([]string, len(lines))
+		for i, line := range lines {
+			texts[i] = line.Text
+		}
+		v.search.setMatches(findMatches(texts, v.search.Query, v.search.CaseSensitive))
+		if target, ok := v.search.revealLine(top, height); ok {
+			s.scrollTo(target)
+			top = s.topIndex()
+		}
+	}
+	if s.paused {
+		s.top = first + int64(top)
+	}
+
+	gutter := v.gutter()
+	for y := 0; y < height && top+y < len(lines); y++ {
+		i := top + y
+		line := lines[i]
+		if gutter > 0 && !line.Time.IsZero() {
+			ctx.PrintTruncated(0, y, line.Time.Format(v.timeLayout), v.timeStyle)
+		}
+		segs := []StyledSegment{{Text: line.Text, Style: v.levelStyles[line.Level]}}
+		if v.search != nil {
+			segs = v.search.highlightSegments(segs, i)
+		}
+		x := gutter
+		for _, seg := range segs {
+			if x >= width {
+				break
+			}
+			text := truncateToWidth(seg.Text, width-x)
+			ctx.PrintStyled(x, y, text, seg.Style)
+			x += runewidth.StringWidth(text)
+		}
+	}
+
+	if v.state == nil || !s.paused {
+		return
+	}
+	// While paused, a badge counts the lines that arrived since and
+	// resumes following when clicked
+	badge := " ⏸ paused "
+	if n := s.NewLines(); n > 0 {
+		badge = fmt.Sprintf(" ↓ %d new ", n)
+	}
+	bw := runewidth.StringWidth(badge)
+	if bw > width {
+		return
+	}
+	ctx.PrintStyled(width-bw, height-1, badge, v.badgeStyle)
+	bounds := ctx.AbsoluteBounds()
+	interactiveRegistry.RegisterButton(
+		image.Rect(bounds.Max.X-bw, bounds.Min.Y+height-1, bounds.Max.X, bounds.Min.Y+height),
+		s.Follow,
+	)
+}
@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/termtest"
+)
+
+func TestDetectLogLevel(t *testing.T) {
+	tests := []struct {
+		text  string
+		level LogLevel
+	}{
+		{"INFO starting server", LogLevelInfo},
+		{"2024-05-01T10:00:00Z WARN disk almost full", LogLevelWarn},
+		{"[error] connection refused", LogLevelError},
+		{"error: file not found", LogLevelError},
+		{`time=2024-05-01T10:00:00Z level=DEBUG msg="cache miss"`, LogLevelDebug},
+		{"10:00:01 | fatal | out of memory", LogLevelFatal},
+		{"E0501 ERR something", LogLevelError},
+		{"GET /api/info 200", LogLevelNone},
+		{"retrying after an error occurred", LogLevelNone},
+		{"", LogLevelNone},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.level, DetectLogLevel(tt.text), tt.text)
+	}
+}
+
+func TestLogBuffer_Ring(t *testing.T) {
+	buf := NewLogBuffer(3)
+	for i := 1; i <= 5; i++ {
+		buf.Appendf("line %d", i)
+	}
+	assert.Equal(t, 3, buf.Len())
+	assert.Equal(t, int64(5), buf.Total())
+	line, ok := buf.Line(0)
+	assert.True(t, ok)
+	assert.Equal(t, "line 3", line.Text)
+
+	var texts []string
+	for _, l := range buf.Lines() {
+		texts = append(texts, l.Text)
+	}
+	assert.Equal(t, []string{"line 3", "line 4", "line 5"}, texts)
+
+	buf.Clear()
+	assert.Equal(t, 0, buf.Len())
+	_, ok = buf.Line(0)
+	assert.False(t, ok)
+}
+
+func TestLogBuffer_Write(t *testing.T) {
+	var buf LogBuffer
+	fmt.Fprint(&buf, "first\r\nsec")
+	assert.Equal(t, 1, buf.Len())
+	fmt.Fprint(&buf, "ond\nWARN third\n")
+	lines := buf.Lines()
+	assert.Equal(t, 3, len(lines))
+	assert.Equal(t, "first", lines[0].Text)
+	assert.Equal(t, "second", lines[1].Text)
+	assert.Equal(t, LogLevelWarn, lines[2].Level)
+	assert.False(t, lines[0].Time.IsZero())
+
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Error("boom", "code", 7)
+	line, _ := buf.Line(3)
+	assert.Equal(t, LogLevelError, line.Level)
+	assert.Contains(t, line.Text, `msg=boom code=7`)
+}
+
+func newTestLog(n int) *LogBuffer {
+	buf := NewLogBuffer(100)
+	for i := 1; i <= n; i++ {
+		buf.Appendf("line %d", i)
+	}
+	return buf
+}
+
+func TestLogView_Tail(t *testing.T) {
+	screen := SprintScreen(LogView(newTestLog(10)).Height(3), PrintConfig{Width: 20, Height: 3})
+	assert.Equal(t, "line 8", screen.Row(0))
+	assert.Equal(t, "line 10", screen.Row(2))
+
+	// Without a fixed height, the view is as tall as the log
+	screen = SprintScreen(LogView(newTestLog(2)), PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, "line 1", screen.Row(0))
+	assert.Equal(t, "line 2", screen.Row(1))
+}
+
+func TestLogView_LevelsAndTimestamps(t *testing.T) {
+	buf := NewLogBuffer(10)
+	at := time.Date(2024, 5, 1, 9, 30, 15, 0, time.UTC)
+	buf.AppendLine(LogLine{Time: at, Level: LogLevelError, Text: "failed"})
+	buf.AppendLine(LogLine{Time: at, Level: LogLevelInfo, Text: "ok"})
+
+	screen := SprintScreen(LogView(buf).Timestamps("15:04:05"), PrintConfig{Width: 30, Height: 2})
+	assert.Equal(t, "09:30:15 failed", screen.Row(0))
+	assert.Equal(t, "09:30:15 ok", screen.Row(1))
+	assert.Equal(t, termtest.Color{Type: termtest.ColorBasic, Value: uint8(ColorRed)}, screen.Cell(9, 0).Style.Foreground)
+	assert.Equal(t, termtest.Color{Type: termtest.ColorBasic, Value: uint8(ColorBrightBlack)}, screen.Cell(0, 0).Style.Foreground)
+	assert.Equal(t, termtest.Color{}, screen.Cell(9, 1).Style.Foreground)
+}
+
+func TestLogState_Follow(t *testing.T) {
+	buf := newTestLog(10)
+	state := NewLogState()
+	view := func() *termtest.Screen {
+		return SprintScreen(LogView(buf).State(state).Height(3), PrintConfig{Width: 20, Height: 3})
+	}
+	assert.Equal(t, "line 8", view().Row(0))
+	assert.True(t, state.Following())
+
+	// Scrolling up pauses
+	state.HandleKey(KeyEvent{Rune: 'k'})
+	state.HandleKey(KeyEvent{Rune: 'k'})
+	assert.False(t, state.Following())
+	assert.Equal(t, "line 6", view().Row(0))
+
+	// New lines don't move a paused view, and are counted
+	buf.Append("line 11\nline 12")
+	screen := view()
+	assert.Equal(t, "line 6", screen.Row(0))
+	assert.Contains(t, screen.Row(2), "↓ 2 new")
+	assert.Equal(t, 2, state.NewLines())
+
+	// Scrolling back to the bottom follows again
+	state.HandleKey(KeyEvent{Key: KeyPageDown})
+	state.HandleKey(KeyEvent{Key: KeyPageDown})
+	assert.True(t, state.Following())
+	assert.Equal(t, "line 10", view().Row(0))
+
+	state.HandleKey(KeyEvent{Rune: 'g'})
+	assert.Equal(t, "line 1", view().Row(0))
+	state.HandleKey(KeyEvent{Rune: 'G'})
+	assert.Equal(t, "line 12", view().Row(2))
+
+	state.HandleKey(KeyEvent{Rune: 'f'})
+	assert.False(t, state.Following())
+	buf.Append("line 13")
+	assert.Equal(t, "line 10", view().Row(0))
+	state.HandleKey(KeyEvent{Rune: 'f'})
+	assert.Equal(t, "line 13", view().Row(2))
+}
+
+func TestLogState_PausedWhileDropping(t *testing.T) {
+	buf := NewLogBuffer(5)
+	for i := 1; i <= 5; i++ {
+		buf.Appendf("line %d", i)
+	}
+	state := NewLogState()
+	view := func() *termtest.Screen {
+		return SprintScreen(LogView(buf).State(state).Height(2), PrintConfig{Width: 20, Height: 2})
+	}
+	view()
+	state.HandleKey(KeyEvent{Rune: 'k'})
+	assert.Equal(t, "line 3", view().Row(0))
+
+	// The paused lines stay on screen as older ones are dropped
+	buf.Append("line 6")
+	assert.Equal(t, "line 3", view().Row(0))
+
+	// Until they are dropped too
+	buf.Append("line 7\nline 8\nline 9")
+	assert.Equal(t, "line 5", view().Row(0))
+}
+
+func TestLogView_Search(t *testing.T) {
+	buf := newTestLog(20)
+	state := NewLogState()
+	search := NewSearchController()
+	search.SetQuery("line 4")
+
+	screen := SprintScreen(LogView(buf).State(state).SearchWith(search).Height(3), PrintConfig{Width: 20, Height: 3})
+	assert.Equal(t, 1, search.Count())
+	assert.False(t, state.Following())
+	assert.Equal(t, "line 3", screen.Row(0))
+	assert.Equal(t, "line 4", screen.Row(1))
+	assert.Equal(t, termtest.Color{Type: termtest.ColorBasic, Value: uint8(ColorBrightYellow)}, screen.Cell(0, 1).Style.Background)
+}
@@ -50,6 +50,19 @@ type MarkdownTheme struct {
 	TableBorderStyle Style
 	TableHeaderStyle Style
 	TableCellStyle   Style
+
+	// Reader typography. Empty values disable the decoration.
+	H1Rule        string                     // Repeated beneath level-1 headings, e.g. "═"
+	H2Rule        string                     // Repeated beneath level-2 headings, e.g. "─"
+	BlockQuoteBar string                     // Prefix drawn on every blockquote line, e.g. "│ "
+	Admonitions   map[string]AdmonitionStyle // GitHub-style "> [!NOTE]" callouts, keyed by upper-case kind
+}
+
+// AdmonitionStyle describes how a "> [!KIND]" callout blockquote is rendered.
+type AdmonitionStyle struct {
+	Label string // Title shown on the first line, e.g. "Note"
+	Icon  string // Optional icon shown before the label
+	Style Style  // Style for the label and the quote bar
 }
 
 // DefaultMarkdownTheme returns a default markdown theme with good contrast
@@ -84,21 +97,43 @@ func DefaultMarkdownTheme() MarkdownTheme {
 	}
 }
 
+// ReaderMarkdownTheme returns a theme tuned for long-form reading. It extends
+// the default theme with underlined heading scales, a bar in front of
+// blockquotes, and styled admonitions (NOTE, TIP, IMPORTANT, WARNING, CAUTION).
+func ReaderMarkdownTheme() MarkdownTheme {
+	theme := DefaultMarkdownTheme()
+	theme.H1Style = NewStyle().WithBold().WithForeground(ColorBrightCyan)
+	theme.H1Rule = "═"
+	theme.H2Rule = "─"
+	theme.BlockQuoteBar = "│ "
+	theme.BlockQuoteStyle = NewStyle().WithForeground(ColorBrightBlack).WithItalic()
+	theme.Admonitions = map[string]AdmonitionStyle{
+		"NOTE":      {Label: "Note", Icon: "ℹ", Style: NewStyle().WithForeground(ColorBlue)},
+		"TIP":       {Label: "Tip", Icon: "✓", Style: NewStyle().WithForeground(ColorGreen)},
+		"IMPORTANT": {Label: "Important", Icon: "!", Style: NewStyle().WithForeground(ColorMagenta)},
+		"WARNING":   {Label: "Warning", Icon: "⚠", Style: NewStyle().WithForeground(ColorYellow)},
+		"CAUTION":   {Label: "Caution", Icon: "✖", Style: NewStyle().WithForeground(ColorRed)},
+	}
+	return theme
+}
+
 // MarkdownRenderer renders markdown content to styled terminal output
 type MarkdownRenderer struct {
-	Theme    MarkdownTheme
-	MaxWidth int // Maximum width for text wrapping (0 = no limit)
-	TabWidth int // Width of tab character in spaces
-	parser   goldmark.Markdown
+	Theme            MarkdownTheme
+	MaxWidth         int // Maximum width for text wrapping (0 = no limit)
+	TabWidth         int // Width of tab character in spaces
+	ParagraphSpacing int // Blank lines emitted after each block (default 1)
+	parser           goldmark.Markdown
 }
 
 // NewMarkdownRenderer creates a new markdown renderer with the default theme
 func NewMarkdownRenderer() *MarkdownRenderer {
 	return &MarkdownRenderer{
-		Theme:    DefaultMarkdownTheme(),
-		MaxWidth: 80,
-		TabWidth: 4,
-		parser:   goldmark.New(goldmark.WithExtensions(extension.Table)),
+		Theme:            DefaultMarkdownTheme(),
+		MaxWidth:         80,
+		TabWidth:         4,
+		ParagraphSpacing: 1,
+		parser:           goldmark.New(goldmark.WithExtensions(extension.Table)),
 	}
 }
 
@@ -114,10 +149,17 @@ func (mr *MarkdownRenderer) WithMaxWidth(width int) *MarkdownRenderer {
 	return mr
 }
 
+// WithParagraphSpacing sets the number of blank lines emitted after each block
+func (mr *MarkdownRenderer) WithParagraphSpacing(lines int) *MarkdownRenderer {
+	mr.ParagraphSpacing = lines
+	return mr
+}
+
 // StyledLine represents a single line of rendered markdown with styled segments
 type StyledLine struct {
 	Segments []StyledSegment
 	Indent   int // Indentation level in spaces
+	Block    int // Source block (paragraph, heading, list item...) this line belongs to; 0 for spacing
 }
 
 // StyledSegment represents a portion of text with a specific style
@@ -129,7 +171,15 @@ type StyledSegment struct {
 
 // RenderedMarkdown contains the fully rendered markdown content
 type RenderedMarkdown struct {
-	Lines []StyledLine
+	Lines    []StyledLine
+	Headings []MarkdownHeading // In document order
+}
+
+// MarkdownHeading is a heading of rendered markdown
+type MarkdownHeading struct {
+	Level int    // 1 to 6
+	Text  string // Plain text
+	Line  int    // Index in Lines of the heading's first line
 }
 
 // Render parses and renders markdown content
@@ -184,7 +234,9 @@ type renderContext struct {
 	result       *RenderedMarkdown
 	indent       int
 	listLevel    int
-	listCounters []int // Stack of list counters for nested numbered lists
+	listCounters []int  // Stack of list counters for nested numbered lists
+	blockCount   int    // Last block ID assigned by markBlock
+	stripPrefix  string // Raw text to drop from the next paragraph (admonition markers)
 }
 
 func (mr *MarkdownRenderer) renderNode(node ast.Node, ctx *renderContext) {
@@ -256,17 +308,59 @@ func (mr *MarkdownRenderer) renderHeading(node *ast.Heading, ctx *renderContext)
 		segments[i].Style = mr.mergeStyles(segments[i].Style, style)
 	}
 
+	start := len(ctx.result.Lines)
 	ctx.result.Lines = append(ctx.result.Lines, StyledLine{
 		Segments: segments,
 		Indent:   ctx.indent,
 	})
+	var text strings.Builder
+	for _, seg := range segments {
+		text.WriteString(seg.Text)
+	}
+	ctx.result.Headings = append(ctx.result.Headings, MarkdownHeading{
+		Level: node.Level,
+		Text:  strings.TrimSpace(text.String()),
+		Line:  start,
+	})
+
+	// Underline the heading to convey its scale
+	rule := ""
+	switch node.Level {
+	case 1:
+		rule = mr.Theme.H1Rule
+	case 2:
+		rule = mr.Theme.H2Rule
+	}
+	if rule != "" {
+		width := mr.segmentsWidth(segments)
+		if mr.MaxWidth > 0 && width > mr.MaxWidth-ctx.indent {
+			width = mr.MaxWidth - ctx.indent
+		}
+		if width > 0 {
+			ruleStyle := style
+			ruleStyle.Underline = false
+			ctx.result.Lines = append(ctx.result.Lines, StyledLine{
+				Segments: []StyledSegment{{Text: strings.Repeat(rule, width), Style: ruleStyle}},
+				Indent:   ctx.indent,
+			})
+		}
+	}
+	mr.markBlock(ctx, start)
 
 	// Add blank line after heading
-	ctx.result.Lines = append(ctx.result.Lines, StyledLine{})
+	mr.appendBlockGap(ctx)
 }
 
 func (mr *MarkdownRenderer) renderParagraph(node *ast.Paragraph, ctx *renderContext) {
 	segments := mr.extractInlineSegments(node, ctx)
+	if ctx.stripPrefix != "" {
+		segments = trimLeadingText(segments, ctx.stripPrefix)
+		ctx.stripPrefix = ""
+		if len(segments) == 0 {
+			return
+		}
+	}
+	start := len(ctx.result.Lines)
 
 	// Word wrap if needed
 	if mr.MaxWidth > 0 {
@@ -283,9 +377,10 @@ func (mr *MarkdownRenderer) renderParagraph(node *ast.Paragraph, ctx *renderCont
 			Indent:   ctx.indent,
 		})
 	}
+	mr.markBlock(ctx, start)
 
 	// Add blank line after paragraph
-	ctx.result.Lines = append(ctx.result.Lines, StyledLine{})
+	mr.appendBlockGap(ctx)
 }
 
 func (mr *MarkdownRenderer) renderList(node *ast.List, ctx *renderContext) {
@@ -303,7 +398,7 @@ func (mr *MarkdownRenderer) renderList(node *ast.List, ctx *renderContext) {
 
 	// Add blank line after list
 	if ctx.listLevel == 0 {
-		ctx.result.Lines = append(ctx.result.Lines, StyledLine{})
+		mr.appendBlockGap(ctx)
 	}
 }
 
@@ -326,6 +421,8 @@ func (mr *MarkdownRenderer) renderListItem(node *ast.ListItem, ctx *renderContex
 	// Render first line with marker
 	firstChild := true
 	savedIndent := ctx.indent
+	start := len(ctx.result.Lines)
+	defer mr.markBlock(ctx, start)
 
 	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
 		if firstChild {
@@ -402,6 +499,7 @@ func (mr *MarkdownRenderer) renderListItem(node *ast.ListItem, ctx *renderContex
 }
 
 func (mr *MarkdownRenderer) renderCodeBlock(node *ast.CodeBlock, ctx *renderContext) {
+	start := len(ctx.result.Lines)
 	lines := node.Lines()
 	for i := 0; i < lines.Len(); i++ {
 		line := lines.At(i)
@@ -415,14 +513,16 @@ func (mr *MarkdownRenderer) renderCodeBlock(node *ast.CodeBlock, ctx *renderCont
 			Indent: ctx.indent + mr.TabWidth,
 		})
 	}
+	mr.markBlock(ctx, start)
 
 	// Add blank line after code block
-	ctx.result.Lines = append(ctx.result.Lines, StyledLine{})
+	mr.appendBlockGap(ctx)
 }
 
 func (mr *MarkdownRenderer) renderFencedCodeBlock(node *ast.FencedCodeBlock, ctx *renderContext) {
 	// Get language for syntax highlighting
 	language := string(node.Language(ctx.source))
+	start := len(ctx.result.Lines)
 
 	// Collect code lines
 	var code strings.Builder
@@ -441,7 +541,8 @@ func (mr *MarkdownRenderer) renderFencedCodeBlock(node *ast.FencedCodeBlock, ctx
 					Indent:   ctx.indent + mr.TabWidth,
 				})
 			}
-			ctx.result.Lines = append(ctx.result.Lines, StyledLine{})
+			mr.markBlock(ctx, start)
+			mr.appendBlockGap(ctx)
 			return
 		}
 	}
@@ -459,21 +560,145 @@ func (mr *MarkdownRenderer) renderFencedCodeBlock(node *ast.FencedCodeBlock, ctx
 			Indent: ctx.indent + mr.TabWidth,
 		})
 	}
+	mr.markBlock(ctx, start)
 
 	// Add blank line after code block
-	ctx.result.Lines = append(ctx.result.Lines, StyledLine{})
+	mr.appendBlockGap(ctx)
 }
 
 func (mr *MarkdownRenderer) renderBlockquote(node *ast.Blockquote, ctx *renderContext) {
 	savedIndent := ctx.indent
-	ctx.indent += 2
+	start := len(ctx.result.Lines)
 
-	mr.renderChildren(node, ctx)
+	bar := mr.Theme.BlockQuoteBar
+	barStyle := mr.Theme.BlockQuoteStyle
+	contentStyle := mr.Theme.BlockQuoteStyle
+
+	kind, marker := mr.admonitionKind(node, ctx)
+	if kind != "" {
+		admonition := mr.Theme.Admonitions[kind]
+		if bar == "" {
+			bar = "│ "
+		}
+		barStyle = admonition.Style
+		contentStyle = NewStyle()
+		ctx.stripPrefix = marker
+
+		label := admonition.Label
+		if admonition.Icon != "" {
+			label = admonition.Icon + " " + label
+		}
+		ctx.result.Lines = append(ctx.result.Lines, StyledLine{
+			Segments: []StyledSegment{{Text: label, Style: admonition.Style.WithBold()}},
+			Indent:   savedIndent + runewidth.StringWidth(bar),
+		})
+	}
 
-	// Apply blockquote style to all lines in the blockquote
-	// Note: This is simplified - in practice we'd track which lines belong to the blockquote
+	if bar == "" {
+		ctx.indent += 2
+		mr.renderChildren(node, ctx)
+		ctx.indent = savedIndent
+		return
+	}
 
+	barWidth := runewidth.StringWidth(bar)
+	ctx.indent += barWidth
+	mr.renderChildren(node, ctx)
 	ctx.indent = savedIndent
+	ctx.stripPrefix = ""
+
+	// Prefix every line of the quote (excluding trailing spacing) with the bar
+	// and treat the whole quote as a single block.
+	end := len(ctx.result.Lines)
+	for end > start && isBlankLine(ctx.result.Lines[end-1]) {
+		end--
+	}
+	ctx.blockCount++
+	for i := start; i < end; i++ {
+		line := &ctx.result.Lines[i]
+		pad := line.Indent - savedIndent - barWidth
+		if pad < 0 {
+			pad = 0
+		}
+		segments := make([]StyledSegment, 0, len(line.Segments)+1)
+		segments = append(segments, StyledSegment{Text: bar + strings.Repeat(" ", pad), Style: barStyle})
+		for _, seg := range line.Segments {
+			seg.Style = mr.mergeStyles(contentStyle, seg.Style)
+			segments = append(segments, seg)
+		}
+		line.Segments = segments
+		line.Indent = savedIndent
+		line.Block = ctx.blockCount
+	}
+}
+
+// admonitionKind reports whether a blockquote starts with a "[!KIND]" marker
+// for a kind configured in the theme. It returns the kind and the raw marker.
+func (mr *MarkdownRenderer) admonitionKind(node *ast.Blockquote, ctx *renderContext) (kind, marker string) {
+	if len(mr.Theme.Admonitions) == 0 {
+		return "", ""
+	}
+	para, ok := node.FirstChild().(*ast.Paragraph)
+	if !ok || para.Lines().Len() == 0 {
+		return "", ""
+	}
+	line := para.Lines().At(0)
+	first := strings.TrimSpace(string(line.Value(ctx.source)))
+	if !strings.HasPrefix(first, "[!") {
+		return "", ""
+	}
+	end := strings.IndexByte(first, ']')
+	if end < 0 {
+		return "", ""
+	}
+	kind = strings.ToUpper(first[2:end])
+	if _, ok := mr.Theme.Admonitions[kind]; !ok {
+		return "", ""
+	}
+	return kind, first[:end+1]
+}
+
+// trimLeadingText removes prefix (and any whitespace following it) from the
+// start of the segments, which may split the prefix across several segments.
+func trimLeadingText(segments []StyledSegment, prefix string) []StyledSegment {
+	remaining := len(prefix)
+	for len(segments) > 0 && remaining > 0 {
+		n := len(segments[0].Text)
+		if n > remaining {
+			segments[0].Text = segments[0].Text[remaining:]
+			remaining = 0
+			break
+		}
+		remaining -= n
+		segments = segments[1:]
+	}
+	for len(segments) > 0 {
+		segments[0].Text = strings.TrimLeft(segments[0].Text, " \t\n")
+		if segments[0].Text != "" {
+			break
+		}
+		segments = segments[1:]
+	}
+	return segments
+}
+
+// markBlock assigns a new block ID to every line from start onward that has
+// content and has not already been claimed by a nested block.
+func (mr *MarkdownRenderer) markBlock(ctx *renderContext, start int) {
+	ctx.blockCount++
+	for i := start; i < len(ctx.result.Lines); i++ {
+		line := &ctx.result.Lines[i]
+		if line.Block == 0 && !isBlankLine(*line) {
+			line.Block = ctx.blockCount
+		}
+	}
+}
+
+// appendBlockGap appends the configured paragraph spacing after a block.
+func (mr *MarkdownRenderer) appendBlockGap(ctx *renderContext) {
+	for i := 0; i < mr.ParagraphSpacing; i++ {
+		ctx.result.Lines = append(ctx.result.Lines, StyledLine{})
+	}
 }
 
 func (mr *MarkdownRenderer) renderHorizontalRule(ctx *renderContext) {
@@ -484,6 +709,7 @@ func (mr *MarkdownRenderer) renderHorizontalRule(ctx *renderContext) {
 
 	rule := strings.Repeat(mr.Theme.HorizontalRuleChar, width-ctx.indent)
 
+	start := len(ctx.result.Lines)
 	ctx.result.Lines = append(ctx.result.Lines, StyledLine{
 		Segments: []StyledSegment{{
 			Text:  rule,
@@ -491,9 +717,10 @@ func (mr *MarkdownRenderer) renderHorizontalRule(ctx *renderContext) {
 		}},
 		Indent: ctx.indent,
 	})
+	mr.markBlock(ctx, start)
 
 	// Add blank line after rule
-	ctx.result.Lines = append(ctx.result.Lines, StyledLine{})
+	mr.appendBlockGap(ctx)
 }
 
 func (mr *MarkdownRenderer) extractInlineSegments(node ast.Node, ctx *renderContext) []StyledSegment {
@@ -973,6 +1200,7 @@ func (mr *MarkdownRenderer) renderTable(node *east.Table, ctx *renderContext) {
 	}
 
 	// Render top border: ┌──────┬──────┐
+	start := len(ctx.result.Lines)
 	mr.renderTableBorder(ctx, colWidths, "┌", "─", "┬", "┐")
 
 	// Render rows
@@ -987,9 +1215,10 @@ func (mr *MarkdownRenderer) renderTable(node *east.Table, ctx *renderContext) {
 
 	// Render bottom border: └──────┴──────┘
 	mr.renderTableBorder(ctx, colWidths, "└", "─", "┴", "┘")
+	mr.markBlock(ctx, start)
 
 	// Add blank line after table
-	ctx.result.Lines = append(ctx.result.Lines, StyledLine{})
+	mr.appendBlockGap(ctx)
 }
 
 // segmentsWidth calculates the total display width of segments
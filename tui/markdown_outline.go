@@ -0,0 +1,251 @@
+package tui
+
+import (
+	"image"
+	"strings"
+)
+
+// OutlineController connects a Markdown view to an outline of its headings,
+// so a table of contents can follow the reader and jump to a section.
+//
+// The controller lives in application state. A Markdown view bound to it
+// with OutlineWith records its headings and the one being read each render;
+// MarkdownOutline shows them as a sidebar that highlights the current
+// section and scrolls the view to a heading when one is clicked. Call Next,
+// Prev, or JumpTo from key handling to move between sections.
+//
+// Example:
+//
+//	type App struct {
+//	    scrollY int
+//	    outline tui.OutlineController
+//	}
+//
+//	func (a *App) View() tui.View {
+//	    return tui.Group(
+//	        tui.Width(28, tui.MarkdownOutline(&a.outline)),
+//	        tui.Markdown(a.doc, &a.scrollY).OutlineWith(&a.outline),
+//	    )
+//	}
+type OutlineController struct {
+	headings []MarkdownHeading
+	current  int
+
+	// A heading to scroll to on the next render
+	jump        int
+	jumpPending bool
+
+	// The heading last jumped to, which stays current until the view is
+	// scrolled, even when it is too near the end to reach the top
+	pinned       int
+	pinnedScroll int
+	pinnedValid  bool
+}
+
+// NewOutlineController creates an outline controller.
+func NewOutlineController() *OutlineController {
+	return &OutlineController{}
+}
+
+// Headings returns the headings of the bound view, as of its last render.
+func (o *OutlineController) Headings() []MarkdownHeading {
+	return o.headings
+}
+
+// Current returns the index of the heading of the section being read, or
+// -1 before the first heading or when there are none.
+func (o *OutlineController) Current() int {
+	if len(o.headings) == 0 {
+		return -1
+	}
+	return o.current
+}
+
+// JumpTo scrolls the bound view to heading i on its next render, and makes
+// it the current heading.
+func (o *OutlineController) JumpTo(i int) {
+	if i < 0 || i >= len(o.headings) {
+		return
+	}
+	o.jump, o.jumpPending = i, true
+	o.current = i
+}
+
+// Next jumps to the heading after the current one.
+func (o *OutlineController) Next() {
+	o.JumpTo(o.Current() + 1)
+}
+
+// Prev jumps to the heading before the current one.
+func (o *OutlineController) Prev() {
+	o.JumpTo(max(0, o.Current()-1))
+}
+
+// sync is called by the bound view each render with its headings and
+// scroll offset, and returns the offset to scroll to if a jump is pending.
+func (o *OutlineController) sync(headings []MarkdownHeading, scrollY int) (int, bool) {
+	o.headings = headings
+	if !o.jumpPending {
+		return scrollY, false
+	}
+	o.jumpPending = false
+	if o.jump >= len(headings) {
+		return scrollY, false
+	}
+	o.pinned, o.pinnedValid = o.jump, true
+	o.pinnedScroll = -1 // Set by setReading once the offset is clamped
+	return headings[o.jump].Line, true
+}
+
+// setReading records the section being read from the line being read and
+// the view's final scroll offset.
+func (o *OutlineController) setReading(line, scrollY int) {
+	if o.pinnedValid {
+		if o.pinnedScroll == -1 {
+			o.pinnedScroll = scrollY
+		}
+		if scrollY == o.pinnedScroll {
+			o.current = o.pinned
+			return
+		}
+		o.pinnedValid = false
+	}
+	o.current = -1
+	for i, h := range o.headings {
+		if h.Line > line {
+			break
+		}
+		o.current = i
+	}
+}
+
+// markdownOutlineView lists the headings of an OutlineController.
+type markdownOutlineView struct {
+	ctrl         *OutlineController
+	maxLevel     int
+	style        Style
+	currentStyle Style
+	hasStyle     bool
+	hasCurrent   bool
+}
+
+// MarkdownOutline returns a sidebar listing the headings of the Markdown
+// view bound to ctrl, indented by level. The section being read is
+// highlighted and kept in view, and clicking a heading scrolls the Markdown
+// view to it (clicks need WithMouseTracking).
+func MarkdownOutline(ctrl *OutlineController) *markdownOutlineView {
+	return &markdownOutlineView{ctrl: ctrl}
+}
+
+// MaxLevel hides headings deeper than level, such as 2 to list only h1
+// and h2 headings. Zero shows them all.
+func (v *markdownOutlineView) MaxLevel(level int) *markdownOutlineView {
+	v.maxLevel = level
+	return v
+}
+
+// Style sets the style of headings. The default is the theme's text color,
+// and its muted color below the second level.
+func (v *markdownOutlineView) Style(s Style) *markdownOutlineView {
+	v.style, v.hasStyle = s, true
+	return v
+}
+
+// CurrentStyle sets the style of the heading being read. The default is
+// the theme's primary color in bold.
+func (v *markdownOutlineView) CurrentStyle(s Style) *markdownOutlineView {
+	v.currentStyle, v.hasCurrent = s, true
+	return v
+}
+
+// outlineEntry is a heading shown in the outline.
+type outlineEntry struct {
+	index int // In the controller's headings
+	text  string
+}
+
+// entries returns the headings shown, indented relative to the shallowest.
+func (v *markdownOutlineView) entries() []outlineEntry {
+	if v.ctrl == nil {
+		return nil
+	}
+	top := 6
+	for _, h := range v.ctrl.headings {
+		if v.maxLevel == 0 || h.Level <= v.maxLevel {
+			top = min(top, h.Level)
+		}
+	}
+	var entries []outlineEntry
+	for i, h := range v.ctrl.headings {
+		if v.maxLevel > 0 && h.Level > v.maxLevel {
+			continue
+		}
+		entries = append(entries, outlineEntry{index: i, text: strings.Repeat("  ", h.Level-top) + h.Text})
+	}
+	return entries
+}
+
+func (v *markdownOutlineView) size(maxWidth, maxHeight int) (int, int) {
+	entries := v.entries()
+	w := 0
+	for _, e := range entries {
+		tw, _ := MeasureText(e.text)
+		w = max(w, tw+2)
+	}
+	h := len(entries)
+	if maxWidth > 0 {
+		w = min(w, maxWidth)
+	}
+	if maxHeight > 0 {
+		h = min(h, maxHeight)
+	}
+	return w, h
+}
+
+func (v *markdownOutlineView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	entries := v.entries()
+	if width == 0 || height == 0 || len(entries) == 0 {
+		return
+	}
+
+	// The entry of the current section: the last shown at or before it
+	current := -1
+	for i, e := range entries {
+		if e.index <= v.ctrl.Current() {
+			current = i
+		}
+	}
+
+	// Keep the current entry in view, a third of the way down
+	first := 0
+	if len(entries) > height && current >= height {
+		first = min(current-height/3, len(entries)-height)
+	}
+
+	theme := ctx.Theme()
+	bounds := ctx.AbsoluteBounds()
+	for y := 0; y < height && first+y < len(entries); y++ {
+		i := first + y
+		e := entries[i]
+		style, marker := v.style, "  "
+		if !v.hasStyle {
+			style = theme.Style(RoleText)
+			if v.ctrl.headings[e.index].Level > 2 {
+				style = theme.Style(RoleMuted)
+			}
+		}
+		if i == current {
+			marker = "▸ "
+			style = v.currentStyle
+			if !v.hasCurrent {
+				style = theme.Style(RolePrimary).WithBold()
+			}
+		}
+		ctx.PrintTruncated(0, y, marker+e.text, style)
+
+		index := e.index
+		row := image.Rect(bounds.Min.X, bounds.Min.Y+y, bounds.Max.X, bounds.Min.Y+y+1)
+		interactiveRegistry.RegisterRegion(row, func() { v.ctrl.JumpTo(index) })
+	}
+}
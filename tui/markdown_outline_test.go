@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestMarkdownOutline(t *testing.T) {
+	var doc strings.Builder
+	for _, section := range []string{"Intro", "Setup", "Usage", "Outro"} {
+		doc.WriteString("## " + section + "\n\n")
+		if section == "Setup" {
+			doc.WriteString("### Install\n\n")
+		}
+		for i := 0; i < 4; i++ {
+			doc.WriteString(section + " text.\n\n")
+		}
+	}
+
+	scrollY := 0
+	outline := NewOutlineController()
+	view := func() View {
+		return Group(
+			Width(12, MarkdownOutline(outline)),
+			Markdown(doc.String(), &scrollY).MaxWidth(28).OutlineWith(outline),
+		)
+	}
+
+	screen := SprintScreen(view(), PrintConfig{Width: 40, Height: 6})
+	assert.Equal(t, 5, len(outline.Headings()))
+	assert.Equal(t, 0, outline.Current())
+	assert.True(t, strings.HasPrefix(screen.Row(0), "▸ Intro"))
+	assert.True(t, strings.HasPrefix(screen.Row(2), "    Install"))
+
+	// Jumping scrolls the view to the heading
+	outline.JumpTo(2)
+	screen = SprintScreen(view(), PrintConfig{Width: 40, Height: 6})
+	assert.Equal(t, outline.Headings()[2].Line, scrollY)
+	assert.Equal(t, 2, outline.Current())
+	assert.True(t, strings.HasPrefix(screen.Row(2), "▸   Install"))
+	assert.Contains(t, screen.Row(0), "Install")
+
+	// The last heading stays current though it can't reach the top
+	outline.JumpTo(4)
+	SprintScreen(view(), PrintConfig{Width: 40, Height: 12})
+	assert.True(t, scrollY < outline.Headings()[4].Line)
+	assert.Equal(t, 4, outline.Current())
+
+	// Scrolling moves the current section with the reading line
+	scrollY = outline.Headings()[1].Line
+	SprintScreen(view(), PrintConfig{Width: 40, Height: 6})
+	assert.Equal(t, 1, outline.Current())
+	outline.Next()
+	SprintScreen(view(), PrintConfig{Width: 40, Height: 6})
+	assert.Equal(t, 2, outline.Current())
+
+	// MaxLevel hides deeper headings
+	screen = SprintScreen(Stack(MarkdownOutline(outline).MaxLevel(2)), PrintConfig{Width: 20, Height: 6})
+	assert.Equal(t, "  Usage", screen.Row(2))
+}
@@ -1299,3 +1299,105 @@ func TestMarkdownRenderer_WrappedLinkSegmentText(t *testing.T) {
 	}
 	assert.Equal(t, "Get in Touch", combinedText.String())
 }
+
+func TestMarkdownRenderer_ParagraphSpacing(t *testing.T) {
+	renderer := NewMarkdownRenderer().WithParagraphSpacing(2)
+
+	result, err := renderer.Render("First paragraph.\n\nSecond paragraph.")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(result.Lines), "Should have para, 2 blanks, para")
+	assert.True(t, isBlankLine(result.Lines[1]))
+	assert.True(t, isBlankLine(result.Lines[2]))
+
+	renderer.ParagraphSpacing = 0
+	result, err = renderer.Render("First paragraph.\n\nSecond paragraph.")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(result.Lines))
+}
+
+func TestMarkdownRenderer_BlockIDs(t *testing.T) {
+	renderer := NewMarkdownRenderer()
+
+	result, err := renderer.Render("# Title\n\nSome text.\n\n- one\n- two")
+	assert.NoError(t, err)
+
+	var blocks []int
+	for _, line := range result.Lines {
+		if isBlankLine(line) {
+			assert.Equal(t, 0, line.Block, "spacing lines belong to no block")
+			continue
+		}
+		assert.True(t, line.Block > 0)
+		blocks = append(blocks, line.Block)
+	}
+	// heading, paragraph, and two list items are distinct blocks
+	assert.Equal(t, 4, len(blocks))
+	seen := map[int]bool{}
+	for _, b := range blocks {
+		assert.False(t, seen[b], "block IDs should be unique")
+		seen[b] = true
+	}
+}
+
+func TestMarkdownRenderer_ReaderTheme(t *testing.T) {
+	renderer := NewMarkdownRenderer().WithTheme(ReaderMarkdownTheme())
+
+	t.Run("heading rules", func(t *testing.T) {
+		result, err := renderer.Render("# Title\n\n## Sub")
+		assert.NoError(t, err)
+		output := renderToPlainText(result)
+		assert.Contains(t, output, "Title\n═════\n")
+		assert.Contains(t, output, "Sub\n───")
+	})
+
+	t.Run("blockquote bar", func(t *testing.T) {
+		result, err := renderer.Render("> quoted line\n>\n> second")
+		assert.NoError(t, err)
+		output := renderToPlainText(result)
+		assert.Contains(t, output, "│ quoted line\n│ \n│ second")
+		assert.Equal(t, result.Lines[0].Block, result.Lines[2].Block, "a quote is a single block")
+	})
+
+	t.Run("admonition", func(t *testing.T) {
+		result, err := renderer.Render("> [!WARNING]\n> Mind the gap.")
+		assert.NoError(t, err)
+		output := renderToPlainText(result)
+		assert.Contains(t, output, "│ ⚠ Warning\n│ Mind the gap.")
+		assert.NotContains(t, output, "[!WARNING]")
+		assert.Equal(t, ColorYellow, result.Lines[0].Segments[0].Style.Foreground)
+	})
+
+	t.Run("unknown admonition stays a quote", func(t *testing.T) {
+		result, err := renderer.Render("> [!FOO] text")
+		assert.NoError(t, err)
+		assert.Contains(t, renderToPlainText(result), "[!FOO] text")
+	})
+}
+
+func TestMarkdownView_ReadingWidthCentersColumn(t *testing.T) {
+	view := Markdown("word word word word word word", nil).ReadingWidth(10)
+	screen := SprintScreen(view, PrintConfig{Width: 30})
+
+	// Text wraps at 10 columns and the column is offset by (30-10)/2
+	assert.Equal(t, "          word word", strings.TrimRight(screen.Row(0), " "))
+	assert.Equal(t, "          word word", strings.TrimRight(screen.Row(1), " "))
+}
+
+func TestMarkdownView_FocusMode(t *testing.T) {
+	content := "Alpha.\n\nBravo.\n\nCharlie.\n\nDelta."
+	scrollY := 0
+	view := Markdown(content, &scrollY).Height(3).FocusMode(true)
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 3})
+
+	assert.Equal(t, "Alpha.", strings.TrimSpace(screen.Row(0)))
+	assert.False(t, screen.Cell(0, 0).Style.Dim, "focused block is not dimmed")
+	assert.True(t, screen.Cell(0, 2).Style.Dim, "other blocks are dimmed")
+
+	// At the end of the document the reading position reaches the last block
+	scrollY = 100
+	view = Markdown(content, &scrollY).Height(3).FocusMode(true)
+	screen = SprintScreen(view, PrintConfig{Width: 20, Height: 3})
+	assert.Equal(t, "Delta.", strings.TrimSpace(screen.Row(2)))
+	assert.False(t, screen.Cell(0, 2).Style.Dim)
+	assert.True(t, screen.Cell(0, 0).Style.Dim)
+}
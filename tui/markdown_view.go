@@ -1,19 +1,25 @@
 package tui
 
 import (
+	"strings"
+
 	"github.com/mattn/go-runewidth"
 )
 
 // markdownView displays rendered markdown content.
 type markdownView struct {
-	content   string
-	scrollY   *int
-	theme     MarkdownTheme
-	maxWidth  int
-	height    int
-	renderer  *MarkdownRenderer
-	rendered  *RenderedMarkdown
-	lastWidth int // track last render width for cache invalidation
+	content      string
+	scrollY      *int
+	theme        MarkdownTheme
+	maxWidth     int
+	height       int
+	readingWidth int  // 0 = text fills the view; >0 = centered column of this width
+	focusMode    bool // dim everything except the block being read
+	search       *SearchController
+	outline      *OutlineController
+	renderer     *MarkdownRenderer
+	rendered     *RenderedMarkdown
+	lastWidth    int // track last render width for cache invalidation
 }
 
 // Markdown creates a markdown view with the given content.
@@ -54,6 +60,85 @@ func (m *markdownView) Height(h int) *markdownView {
 	return m
 }
 
+// ReadingWidth clamps the line length to w columns and centers the text
+// column within the space allocated to the view. Comfortable values for
+// prose are between 60 and 80 columns. Zero disables clamping.
+//
+// Example:
+//
+//	Markdown(article, &scrollY).ReadingWidth(72).Theme(tui.ReaderMarkdownTheme())
+func (m *markdownView) ReadingWidth(w int) *markdownView {
+	m.readingWidth = w
+	m.rendered = nil // invalidate cache
+	return m
+}
+
+// ParagraphSpacing sets the number of blank lines between blocks (default 1).
+func (m *markdownView) ParagraphSpacing(lines int) *markdownView {
+	m.renderer.ParagraphSpacing = lines
+	m.rendered = nil // invalidate cache
+	return m
+}
+
+// FocusMode dims everything except the block (paragraph, heading, list item,
+// quote...) currently being read. The reading position follows the scroll
+// offset, moving from the top of the viewport toward the bottom as the
+// document approaches its end so that every block can receive focus.
+func (m *markdownView) FocusMode(enabled bool) *markdownView {
+	m.focusMode = enabled
+	return m
+}
+
+// SearchWith binds a SearchController to the view. Each render the view
+// searches its rendered text for the controller's query, highlights every
+// match, and scrolls the current match into view when the controller moves
+// to a new match (requires a scrollY pointer).
+func (m *markdownView) SearchWith(ctrl *SearchController) *markdownView {
+	m.search = ctrl
+	return m
+}
+
+// OutlineWith binds an OutlineController to the view. Each render the view
+// records its headings and the section being read in the controller, and
+// scrolls to a heading when the controller jumps to one (requires a scrollY
+// pointer). Show the outline with MarkdownOutline.
+func (m *markdownView) OutlineWith(ctrl *OutlineController) *markdownView {
+	m.outline = ctrl
+	return m
+}
+
+// Search returns the positions of all case-insensitive matches of query in
+// the rendered content. Line indexes correspond to scroll offsets, so
+// setting scrollY to a match's Line brings it to the top of the view.
+// If the view has not been laid out yet, its MaxWidth is used for wrapping.
+func (m *markdownView) Search(query string) []SearchMatch {
+	if m.rendered == nil {
+		m.renderContent(m.textWidth(m.maxWidth))
+	}
+	return findMatches(m.plainLines(), query, false)
+}
+
+// plainLines returns the rendered lines as unstyled text.
+func (m *markdownView) plainLines() []string {
+	lines := make([]string, len(m.rendered.Lines))
+	for i, line := range m.rendered.Lines {
+		var b strings.Builder
+		for _, seg := range line.Segments {
+			b.WriteString(seg.Text)
+		}
+		lines[i] = b.String()
+	}
+	return lines
+}
+
+// textWidth returns the wrapping width for a view of the given width.
+func (m *markdownView) textWidth(width int) int {
+	if m.readingWidth > 0 && m.readingWidth < width {
+		return m.readingWidth
+	}
+	return width
+}
+
 // renderContent renders the markdown if needed.
 func (m *markdownView) renderContent(width int) {
 	if m.rendered != nil && m.lastWidth == width {
@@ -83,12 +168,23 @@ func (m *markdownView) renderContent(width int) {
 
 func (m *markdownView) size(maxWidth, maxHeight int) (int, int) {
 	w := m.maxWidth
+	if m.readingWidth > 0 {
+		// A centered column takes all the space it is offered
+		w = m.readingWidth
+		if maxWidth > 0 {
+			w = maxWidth
+		}
+	}
 	if maxWidth > 0 && w > maxWidth {
 		w = maxWidth
 	}
 
 	// Render to get line count
-	m.renderContent(w)
+	m.renderContent(m.textWidth(w))
+	if m.outline != nil && m.rendered != nil {
+		// So an outline drawn before the view lists the headings this frame
+		m.outline.headings = m.rendered.Headings
+	}
 
 	h := m.height
 	if h == 0 && m.rendered != nil {
@@ -108,7 +204,9 @@ func (m *markdownView) render(ctx *RenderContext) {
 	}
 
 	// Render markdown content
-	m.renderContent(width)
+	textWidth := m.textWidth(width)
+	m.renderContent(textWidth)
+	offsetX := (width - textWidth) / 2
 
 	if m.rendered == nil {
 		return
@@ -120,6 +218,19 @@ func (m *markdownView) render(ctx *RenderContext) {
 		scrollY = *m.scrollY
 	}
 
+	// Update search matches and follow the current match
+	if m.search != nil {
+		m.search.setMatches(findMatches(m.plainLines(), m.search.Query, m.search.CaseSensitive))
+		if target, ok := m.search.revealLine(scrollY, height); ok {
+			scrollY = target
+		}
+	}
+	if m.outline != nil {
+		if target, ok := m.outline.sync(m.rendered.Headings, scrollY); ok {
+			scrollY = target
+		}
+	}
+
 	// Clamp scroll position
 	maxScroll := len(m.rendered.Lines) - height
 	if maxScroll < 0 {
@@ -143,24 +254,44 @@ func (m *markdownView) render(ctx *RenderContext) {
 		endLine = len(m.rendered.Lines)
 	}
 
+	if m.outline != nil {
+		m.outline.setReading(readingLine(scrollY, maxScroll, height), scrollY)
+	}
+
+	focusBlock := -1
+	if m.focusMode {
+		focusBlock = m.focusedBlock(scrollY, maxScroll, height)
+	}
+
 	y := 0
 	for i := scrollY; i < endLine && y < height; i++ {
 		line := m.rendered.Lines[i]
+		dimmed := focusBlock >= 0 && line.Block != focusBlock
 
 		// Apply indentation
-		x := line.Indent
+		x := offsetX + line.Indent
+
+		segments := line.Segments
+		if m.search != nil {
+			segments = m.search.highlightSegments(segments, i)
+		}
 
 		// Render segments
-		for _, seg := range line.Segments {
+		for _, seg := range segments {
+			style := seg.Style
+			if dimmed {
+				style = style.WithDim()
+			}
 			if seg.Hyperlink != nil {
 				// Render as hyperlink using segment's text (which may be a
 				// single word after wrapping), not the full hyperlink text
 				link := *seg.Hyperlink
 				link.Text = seg.Text
+				link.Style = style
 				ctx.PrintHyperlink(x, y, link)
 			} else {
 				// Render as styled text
-				ctx.PrintStyled(x, y, seg.Text, seg.Style)
+				ctx.PrintStyled(x, y, seg.Text, style)
 			}
 
 			x += runewidth.StringWidth(seg.Text)
@@ -175,6 +306,43 @@ func (m *markdownView) render(ctx *RenderContext) {
 	}
 }
 
+// focusedBlock returns the block ID at the reading position for focus mode.
+// The reading line starts at the top of the viewport and slides down to the
+// last visible line as scrollY approaches maxScroll. If the reading line is
+// spacing, the nearest following (then preceding) block is used. Returns -1
+// (no dimming) when the content fits without scrolling.
+func (m *markdownView) focusedBlock(scrollY, maxScroll, height int) int {
+	lines := m.rendered.Lines
+	if len(lines) == 0 || maxScroll == 0 {
+		// Everything fits on screen; there is nothing to scroll through
+		return -1
+	}
+	reading := readingLine(scrollY, maxScroll, height)
+	if reading >= len(lines) {
+		reading = len(lines) - 1
+	}
+	for i := reading; i < len(lines); i++ {
+		if lines[i].Block != 0 {
+			return lines[i].Block
+		}
+	}
+	for i := reading - 1; i >= 0; i-- {
+		if lines[i].Block != 0 {
+			return lines[i].Block
+		}
+	}
+	return -1
+}
+
+// readingLine returns the line being read: the top line of the viewport,
+// sliding down to the last visible line as scrollY approaches maxScroll.
+func readingLine(scrollY, maxScroll, height int) int {
+	if maxScroll <= 0 {
+		return scrollY
+	}
+	return scrollY + (height-1)*scrollY/maxScroll
+}
+
 // GetLineCount returns the total number of rendered lines.
 // This is useful for scroll calculations in HandleEvent.
 func (m *markdownView) GetLineCount() int {
@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// ParseMarkup parses text containing inline style tags into styled
+// segments. A tag is a ParseStyle description in square brackets, starting
+// with a letter, that applies until the matching [/]:
+//
+//	"[bold red]error:[/] file not found"
+//	"[fg=#888888]took [bold]3.2s[/][/]"
+//
+// Tags nest, with inner tags layered over outer ones. Brackets that don't
+// contain a valid style are kept as literal text, and "[[" produces a
+// literal "[". Use EscapeMarkup on untrusted text before embedding it.
+// Text outside any tag uses base.
+func ParseMarkup(markup string, base Style) []StyledSegment {
+	var segments []StyledSegment
+	stack := []Style{base}
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() > 0 {
+			segments = append(segments, StyledSegment{Text: text.String(), Style: stack[len(stack)-1]})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(markup); {
+		if markup[i] != '[' {
+			j := strings.IndexByte(markup[i:], '[')
+			if j < 0 {
+				j = len(markup) - i
+			}
+			text.WriteString(markup[i : i+j])
+			i += j
+			continue
+		}
+		if strings.HasPrefix(markup[i:], "[[") {
+			text.WriteByte('[')
+			i += 2
+			continue
+		}
+		end := strings.IndexByte(markup[i:], ']')
+		if end < 0 {
+			text.WriteString(markup[i:])
+			break
+		}
+		tag := markup[i+1 : i+end]
+
+		// Closing tag: [/] or [/style], so paths like "[/usr/bin]" stay text
+		if tag == "/" || (strings.HasPrefix(tag, "/") && isStyleTag(tag[1:])) {
+			flush()
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+			i += end + 1
+			continue
+		}
+
+		// Opening tag. Tags must start with a letter so that text like
+		// "array[0]" isn't taken for a palette color.
+		if isStyleTag(tag) {
+			flush()
+			stack = append(stack, layerStyle(stack[len(stack)-1], MustParseStyle(tag)))
+			i += end + 1
+			continue
+		}
+
+		// Not a tag; keep the bracket as text
+		text.WriteByte('[')
+		i++
+	}
+	flush()
+	return segments
+}
+
+// layerStyle applies the attributes and colors set in top over base. Unlike
+// Style.Merge, a basic color in top replaces an RGB color in base.
+func layerStyle(base, top Style) Style {
+	result := base.Merge(top)
+	if top.Foreground != ColorDefault && top.FgRGB == nil {
+		result.FgRGB = nil
+	}
+	if top.Background != ColorDefault && top.BgRGB == nil {
+		result.BgRGB = nil
+	}
+	return result
+}
+
+// StripMarkup returns the text of markup with all style tags removed.
+func StripMarkup(markup string) string {
+	var b strings.Builder
+	for _, seg := range ParseMarkup(markup, NewStyle()) {
+		b.WriteString(seg.Text)
+	}
+	return b.String()
+}
+
+// EscapeMarkup escapes square brackets so text is displayed literally when
+// embedded in markup.
+func EscapeMarkup(text string) string {
+	return strings.ReplaceAll(text, "[", "[[")
+}
+
+// styledRunes is markup flattened to plain runes with one style per rune.
+type styledRunes struct {
+	runes  []rune
+	styles []Style
+}
+
+func newStyledRunes(segments []StyledSegment) styledRunes {
+	var sr styledRunes
+	for _, seg := range segments {
+		for _, r := range seg.Text {
+			sr.runes = append(sr.runes, r)
+			sr.styles = append(sr.styles, seg.Style)
+		}
+	}
+	return sr
+}
+
+// restyle assigns styles to display, a copy of the plain text that may have
+// been wrapped or aligned. Wrapping and alignment only insert or remove
+// whitespace, so runes are matched in order: unmatched whitespace in the
+// plain text was dropped, and unmatched display runes were inserted and get
+// the base style.
+func (sr styledRunes) restyle(display string, base Style) ([]rune, []Style) {
+	runes := []rune(display)
+	styles := make([]Style, len(runes))
+	i := 0
+	for j, r := range runes {
+		for i < len(sr.runes) && sr.runes[i] != r && isMarkupSpace(sr.runes[i]) {
+			i++
+		}
+		if i < len(sr.runes) && sr.runes[i] == r {
+			styles[j] = sr.styles[i]
+			i++
+		} else {
+			styles[j] = base
+		}
+	}
+	return runes, styles
+}
+
+// isStyleTag reports whether tag is a valid ParseStyle description that
+// starts with a letter.
+func isStyleTag(tag string) bool {
+	if tag == "" || !((tag[0] >= 'a' && tag[0] <= 'z') || (tag[0] >= 'A' && tag[0] <= 'Z')) {
+		return false
+	}
+	_, err := ParseStyle(tag)
+	return err == nil
+}
+
+func isMarkupSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n'
+}
+
+// printStyledRunes prints one line of runes, grouping runs of equal style.
+func printStyledRunes(ctx *RenderContext, y int, runes []rune, styles []Style) {
+	x := 0
+	for start := 0; start < len(runes); {
+		end := start + 1
+		for end < len(runes) && styles[end] == styles[start] {
+			end++
+		}
+		run := string(runes[start:end])
+		ctx.PrintTruncated(x, y, run, styles[start])
+		x += runewidth.StringWidth(run)
+		start = end
+	}
+}
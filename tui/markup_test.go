@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestParseMarkup(t *testing.T) {
+	base := NewStyle()
+	segments := ParseMarkup("[red]error[/] occurred", base)
+	assert.Equal(t, []StyledSegment{
+		{Text: "error", Style: NewStyle().WithForeground(ColorRed)},
+		{Text: " occurred", Style: base},
+	}, segments)
+}
+
+func TestParseMarkup_Nesting(t *testing.T) {
+	segments := ParseMarkup("[red]a[bold]b[/]c[/]d", NewStyle())
+	assert.Equal(t, 4, len(segments))
+	assert.Equal(t, NewStyle().WithForeground(ColorRed), segments[0].Style)
+	assert.Equal(t, NewStyle().WithForeground(ColorRed).WithBold(), segments[1].Style)
+	assert.Equal(t, NewStyle().WithForeground(ColorRed), segments[2].Style)
+	assert.Equal(t, NewStyle(), segments[3].Style)
+
+	// A basic color inside an RGB color replaces it
+	segments = ParseMarkup("[fg=#ff0000]a[blue]b[/][/]", NewStyle())
+	assert.Nil(t, segments[1].Style.FgRGB)
+	assert.Equal(t, ColorBlue, segments[1].Style.Foreground)
+}
+
+func TestParseMarkup_Literals(t *testing.T) {
+	assert.Equal(t, "[1/3] array[0] [[x]] [", StripMarkup("[[1/3] array[0] [[[x]] ["))
+	assert.Equal(t, "a] b", StripMarkup("a] b[/]"))
+	assert.Equal(t, "[/usr/bin] x", StripMarkup("[red][/usr/bin][/red] x"))
+	assert.Equal(t, "[nope] ok", StripMarkup("[nope] [green]ok"))
+	assert.Equal(t, "a[b]", StripMarkup(EscapeMarkup("a[b]")))
+}
+
+func TestText_Markup(t *testing.T) {
+	view := Text("[bold red]error:[/] not found").Markup()
+	w, h := view.size(80, 0)
+	assert.Equal(t, 16, w)
+	assert.Equal(t, 1, h)
+
+	screen := SprintScreen(view, PrintConfig{Width: 40})
+	assert.Equal(t, "error: not found", screen.Row(0))
+	assert.True(t, screen.Cell(0, 0).Style.Bold)
+	assert.False(t, screen.Cell(7, 0).Style.Bold)
+}
+
+func TestText_MarkupWrapAndAlign(t *testing.T) {
+	view := Text("one [bold]two three[/] four").Markup().Wrap()
+	screen := SprintScreen(view, PrintConfig{Width: 9})
+	assert.Equal(t, "one two", screen.Row(0))
+	assert.Equal(t, "three", screen.Row(1))
+	assert.Equal(t, "four", screen.Row(2))
+	assert.False(t, screen.Cell(0, 0).Style.Bold)
+	assert.True(t, screen.Cell(4, 0).Style.Bold)
+	assert.True(t, screen.Cell(0, 1).Style.Bold)
+	assert.False(t, screen.Cell(0, 2).Style.Bold)
+
+	screen = SprintScreen(Text("[bold]ab[/]").Markup().Right().Width(6), PrintConfig{Width: 6})
+	assert.Equal(t, "    ab", screen.Row(0))
+	assert.True(t, screen.Cell(4, 0).Style.Bold)
+}
@@ -0,0 +1,241 @@
+package tui
+
+import (
+	"image"
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Menu is a titled list of actions in a MenuBar.
+type Menu struct {
+	Label string     // Shown in the bar
+	Items []MenuItem // Shown in the dropdown, as in a ContextMenu
+}
+
+// menuBarView is a row of menu titles that open dropdowns of actions.
+type menuBarView struct {
+	menus         []Menu
+	id            string
+	key           KeyEvent
+	style         Style
+	menuStyle     Style
+	selectedStyle Style
+	disabledStyle Style
+}
+
+// MenuBar creates a one-line bar of menus, like File, Edit, and View, each
+// opening a dropdown of actions below its title. The dropdowns are drawn
+// over the rest of the screen and behave like a ContextMenu.
+//
+// Clicking a title opens its menu, and F10 opens the first menu, as does
+// Alt with the first letter of a menu's label. While a menu is open, Left
+// and Right move to the neighboring menus, and clicking another title opens
+// that menu instead.
+//
+// The open state is kept by ID, which defaults to one made from the menu
+// labels; set ID when an app has several menu bars with the same labels.
+//
+// Example:
+//
+//	tui.MenuBar(
+//	    tui.Menu{Label: "File", Items: []tui.MenuItem{
+//	        {Label: "Open…", Hint: "Ctrl+O", OnSelect: app.open},
+//	        {Label: "Save", Hint: "Ctrl+S", OnSelect: app.save, Disabled: !app.modified},
+//	        {},
+//	        {Label: "Quit", OnSelect: app.quit},
+//	    }},
+//	    tui.Menu{Label: "View", Items: []tui.MenuItem{
+//	        {Label: "Zoom In", OnSelect: app.zoomIn},
+//	    }},
+//	)
+func MenuBar(menus ...Menu) *menuBarView {
+	labels := make([]string, len(menus))
+	for i, menu := range menus {
+		labels[i] = menu.Label
+	}
+	return &menuBarView{
+		menus:         menus,
+		id:            "menu_bar:" + strings.Join(labels, "|"),
+		key:           KeyEvent{Key: KeyF10},
+		style:         NewStyle().WithBackground(ColorBrightBlack).WithForeground(ColorWhite),
+		menuStyle:     NewStyle(),
+		selectedStyle: NewStyle().WithReverse(),
+		disabledStyle: NewStyle().WithDim(),
+	}
+}
+
+// ID sets the ID the bar's open state is kept under.
+func (m *menuBarView) ID(id string) *menuBarView {
+	m.id = id
+	return m
+}
+
+// Key sets the key that opens the first menu (default F10). A zero KeyEvent
+// disables it.
+func (m *menuBarView) Key(key KeyEvent) *menuBarView {
+	m.key = key
+	return m
+}
+
+// Style sets the style of the bar.
+func (m *menuBarView) Style(s Style) *menuBarView {
+	m.style = s
+	return m
+}
+
+// MenuStyle sets the style of the dropdowns.
+func (m *menuBarView) MenuStyle(s Style) *menuBarView {
+	m.menuStyle = s
+	return m
+}
+
+// SelectedStyle sets the style of the open menu's title and the item under
+// the cursor.
+func (m *menuBarView) SelectedStyle(s Style) *menuBarView {
+	m.selectedStyle = s
+	return m
+}
+
+// DisabledStyle sets the style of disabled items.
+func (m *menuBarView) DisabledStyle(s Style) *menuBarView {
+	m.disabledStyle = s
+	return m
+}
+
+// titleSpans returns the x offset and width of each menu's title, which
+// has a space either side.
+func (m *menuBarView) titleSpans() [][2]int {
+	spans := make([][2]int, len(m.menus))
+	x := 0
+	for i, menu := range m.menus {
+		w := runewidth.StringWidth(menu.Label) + 2
+		spans[i] = [2]int{x, w}
+		x += w
+	}
+	return spans
+}
+
+func (m *menuBarView) size(maxWidth, maxHeight int) (int, int) {
+	w := maxWidth
+	if w == 0 {
+		for _, span := range m.titleSpans() {
+			w += span[1]
+		}
+	}
+	return w, 1
+}
+
+func (m *menuBarView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	state := contextMenuRegistry.Get(m.id)
+	if state.menu >= len(m.menus) {
+		state.open = false
+	}
+	spans := m.titleSpans()
+	abs := ctx.AbsoluteBounds()
+	titleBounds := func(i int) image.Rectangle {
+		return image.Rect(spans[i][0], 0, spans[i][0]+spans[i][1], 1).Add(abs.Min).Intersect(abs)
+	}
+
+	ctx.FillStyled(0, 0, width, 1, ' ', m.style)
+	for i, menu := range m.menus {
+		x, w := spans[i][0], spans[i][1]
+		if x >= width {
+			break
+		}
+		style := m.style
+		if state.open && state.menu == i {
+			style = style.Merge(m.selectedStyle)
+		}
+		ctx.FillStyled(x, 0, min(w, width-x), 1, ' ', style)
+		ctx.PrintTruncated(x+1, 0, menu.Label, style)
+
+		index := i
+		interactiveRegistry.RegisterButton(titleBounds(i), func() { m.toggle(state, index) })
+	}
+
+	if len(m.menus) > 0 {
+		interactiveRegistry.RegisterKeys(func(e KeyEvent) bool {
+			if (m.key.Key != KeyUnknown || m.key.Rune != 0) && matchKey(e, m.key) {
+				m.open(state, 0)
+				return true
+			}
+			if e.Alt && !e.Ctrl && e.Rune != 0 {
+				for i, menu := range m.menus {
+					if menu.Label != "" && unicode.ToLower([]rune(menu.Label)[0]) == unicode.ToLower(e.Rune) {
+						m.open(state, i)
+						return true
+					}
+				}
+			}
+			return false
+		})
+	}
+
+	if !state.open {
+		return
+	}
+	dropdown := m.dropdown(state.menu)
+	interactiveRegistry.RegisterKeyCapture(func(e KeyEvent) bool {
+		n := len(m.menus)
+		switch e.Key {
+		case KeyArrowLeft:
+			m.open(state, (state.menu+n-1)%n)
+		case KeyArrowRight:
+			m.open(state, (state.menu+1)%n)
+		default:
+			dropdown.handleKey(state, e)
+		}
+		return true
+	})
+	state.anchor = abs.Min.Add(image.Pt(spans[state.menu][0], 1))
+	dropdown.renderMenu(ctx, state)
+
+	// The titles stay clickable over the dropdown's region for clicks
+	// outside it
+	ctx.overlay(image.Rect(0, 0, width, 1), func(*RenderContext) {
+		for i := range m.menus {
+			index := i
+			bounds := titleBounds(i)
+			if bounds.Empty() {
+				break
+			}
+			interactiveRegistry.RegisterButton(bounds, func() { m.toggle(state, index) })
+		}
+	})
+}
+
+// dropdown returns the context menu that draws and handles the keys of the
+// menu at index.
+func (m *menuBarView) dropdown(index int) *contextMenuView {
+	return &contextMenuView{
+		items:         m.menus[index].Items,
+		key:           m.key,
+		style:         m.menuStyle,
+		selectedStyle: m.selectedStyle,
+		disabledStyle: m.disabledStyle,
+	}
+}
+
+// open shows the menu at index with the cursor on its first item that can
+// be chosen.
+func (m *menuBarView) open(state *contextMenuState, index int) {
+	state.open = true
+	state.menu = index
+	state.cursor = -1
+	m.dropdown(index).moveCursor(state, 1)
+}
+
+// toggle opens the menu at index, or closes it if it is open.
+func (m *menuBarView) toggle(state *contextMenuState, index int) {
+	if state.open && state.menu == index {
+		state.open = false
+		return
+	}
+	m.open(state, index)
+}
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func newMenuBarApp(id string, chosen *[]string) *simpleApp {
+	choose := func(name string) func() {
+		return func() { *chosen = append(*chosen, name) }
+	}
+	return &simpleApp{
+		renderFunc: func() View {
+			return Stack(
+				MenuBar(
+					Menu{Label: "File", Items: []MenuItem{
+						{Label: "Open", Hint: "^O", OnSelect: choose("open")},
+						{Label: "Save", Disabled: true, OnSelect: choose("save")},
+						{},
+						{Label: "Quit", OnSelect: choose("quit")},
+					}},
+					Menu{Label: "Edit", Items: []MenuItem{
+						{Label: "Undo", OnSelect: choose("undo")},
+					}},
+				).ID(id),
+				Text("content"),
+			)
+		},
+		handleFunc: func(Event) []Cmd { return nil },
+	}
+}
+
+func TestMenuBar_ClickOpensDropdown(t *testing.T) {
+	terminal := NewTestTerminal(30, 8, &bytes.Buffer{})
+	var chosen []string
+	runtime := NewRuntime(terminal, newMenuBarApp(t.Name(), &chosen), 30)
+	runtime.render()
+	defer interactiveRegistry.HandleHover(MouseEvent{Type: MouseLeave})
+	assert.Equal(t, " File  Edit", screenRow(terminal, 0))
+	assert.Equal(t, "content", screenRow(terminal, 1))
+
+	runtime.processEvent(MouseEvent{Type: MouseClick, Button: MouseButtonLeft, X: 1, Y: 0})
+	runtime.render()
+	assert.Equal(t, "╭──────────╮", screenRow(terminal, 1))
+	assert.Equal(t, "│ Open  ^O │", screenRow(terminal, 2))
+	assert.Equal(t, "│ Save     │", screenRow(terminal, 3))
+	assert.Equal(t, "├──────────┤", screenRow(terminal, 4))
+	assert.Equal(t, "│ Quit     │", screenRow(terminal, 5))
+
+	// Clicking another title switches menus, and clicking it again closes it
+	runtime.processEvent(MouseEvent{Type: MouseClick, Button: MouseButtonLeft, X: 7, Y: 0})
+	runtime.render()
+	assert.Equal(t, "conten╭──────╮", screenRow(terminal, 1))
+	assert.Equal(t, "      │ Undo │", screenRow(terminal, 2))
+	runtime.processEvent(MouseEvent{Type: MouseClick, Button: MouseButtonLeft, X: 7, Y: 0})
+	runtime.render()
+	assert.Equal(t, "content", screenRow(terminal, 1))
+
+	// Clicking an item chooses it
+	runtime.processEvent(MouseEvent{Type: MouseClick, Button: MouseButtonLeft, X: 1, Y: 0})
+	runtime.render()
+	runtime.processEvent(MouseEvent{Type: MouseClick, Button: MouseButtonLeft, X: 3, Y: 5})
+	runtime.render()
+	assert.Equal(t, []string{"quit"}, chosen)
+	assert.Equal(t, "content", screenRow(terminal, 1))
+}
+
+func TestMenuBar_Keyboard(t *testing.T) {
+	terminal := NewTestTerminal(30, 8, &bytes.Buffer{})
+	var chosen []string
+	runtime := NewRuntime(terminal, newMenuBarApp(t.Name(), &chosen), 30)
+	runtime.render()
+
+	runtime.processEvent(KeyEvent{Key: KeyF10})
+	runtime.render()
+	assert.Contains(t, screenRow(terminal, 2), "Open")
+
+	// Right moves to the next menu, wrapping around
+	runtime.processEvent(KeyEvent{Key: KeyArrowRight})
+	runtime.render()
+	assert.Contains(t, screenRow(terminal, 2), "Undo")
+	runtime.processEvent(KeyEvent{Key: KeyArrowRight})
+	runtime.render()
+	assert.Contains(t, screenRow(terminal, 2), "Open")
+
+	// Down skips the disabled item and the separator
+	runtime.processEvent(KeyEvent{Key: KeyArrowDown})
+	runtime.processEvent(KeyEvent{Key: KeyEnter})
+	runtime.render()
+	assert.Equal(t, []string{"quit"}, chosen)
+	assert.Equal(t, "content", screenRow(terminal, 1))
+
+	// Alt and a menu's first letter opens it; Escape closes it
+	runtime.processEvent(KeyEvent{Rune: 'e', Alt: true})
+	runtime.render()
+	assert.Contains(t, screenRow(terminal, 2), "Undo")
+	runtime.processEvent(KeyEvent{Key: KeyEscape})
+	runtime.render()
+	assert.Equal(t, "content", screenRow(terminal, 1))
+}
@@ -0,0 +1,510 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/deepnoodle-ai/wonton/unidiff"
+	"github.com/mattn/go-runewidth"
+)
+
+// MergeResolution says how a conflict in a MergeState has been resolved.
+type MergeResolution int
+
+const (
+	MergeUnresolved MergeResolution = iota
+	MergeOurs                       // Take our side
+	MergeTheirs                     // Take their side
+	MergeBoth                       // Take our side followed by theirs
+	MergeEdited                     // Take text supplied with Edit
+)
+
+// String returns "unresolved", "ours", "theirs", "both", or "edited".
+func (r MergeResolution) String() string {
+	switch r {
+	case MergeOurs:
+		return "ours"
+	case MergeTheirs:
+		return "theirs"
+	case MergeBoth:
+		return "both"
+	case MergeEdited:
+		return "edited"
+	default:
+		return "unresolved"
+	}
+}
+
+// MergeState holds a three-way merge being resolved in a MergeView: the
+// merged chunks, how each conflict has been resolved, and which conflict is
+// current. It lives in application state.
+//
+// Changes made on only one side are merged automatically; conflicts are
+// resolved by picking a side or supplying edited text. The application
+// forwards key events to HandleKey, which implements:
+//
+//   - n / N (or ] / [) move to the next / previous conflict
+//   - o, t, b take ours, theirs, or both for the current conflict
+//   - x marks the current conflict unresolved again
+//   - Up/Down, j/k, PageUp/PageDown scroll
+//
+// Example:
+//
+//	c, _ := repo.Conflict(ctx, path)
+//	app.merge = tui.NewMergeState(c.Base, c.Ours, c.Theirs)
+//	...
+//	if app.merge.Unresolved() == 0 {
+//	    os.WriteFile(path, []byte(app.merge.Output()), 0644)
+//	}
+type MergeState struct {
+	// OursLabel, BaseLabel, and TheirsLabel name the three versions in the
+	// view's headers and in conflict markers. Empty uses "ours", "base",
+	// and "theirs".
+	OursLabel   string
+	BaseLabel   string
+	TheirsLabel string
+
+	merge       *unidiff.Merge
+	conflicts   []int // indexes into merge.Chunks
+	resolutions []MergeResolution
+	edits       [][]string
+	current     int
+
+	scrollY       int
+	scrollPending bool
+}
+
+// NewMergeState merges ours and theirs, two versions of a text changed
+// from base, and returns the state for resolving the conflicts.
+func NewMergeState(base, ours, theirs string) *MergeState {
+	s := &MergeState{merge: unidiff.Merge3(base, ours, theirs), scrollPending: true}
+	for i, c := range s.merge.Chunks {
+		if c.Kind == unidiff.ChunkConflict {
+			s.conflicts = append(s.conflicts, i)
+		}
+	}
+	s.resolutions = make([]MergeResolution, len(s.conflicts))
+	s.edits = make([][]string, len(s.conflicts))
+	return s
+}
+
+// Chunks returns the chunks of the merge.
+func (s *MergeState) Chunks() []unidiff.MergeChunk {
+	return s.merge.Chunks
+}
+
+// Conflicts returns the number of conflicts.
+func (s *MergeState) Conflicts() int {
+	return len(s.conflicts)
+}
+
+// Unresolved returns the number of conflicts not yet resolved.
+func (s *MergeState) Unresolved() int {
+	n := 0
+	for _, r := range s.resolutions {
+		if r == MergeUnresolved {
+			n++
+		}
+	}
+	return n
+}
+
+// Current returns the index of the current conflict, or -1 if there are
+// no conflicts.
+func (s *MergeState) Current() int {
+	if len(s.conflicts) == 0 {
+		return -1
+	}
+	return s.current
+}
+
+// SetCurrent makes conflict i current and scrolls it into view.
+func (s *MergeState) SetCurrent(i int) {
+	if i >= 0 && i < len(s.conflicts) {
+		s.current = i
+		s.scrollPending = true
+	}
+}
+
+// Next moves to the next conflict, wrapping around to the first.
+func (s *MergeState) Next() {
+	if len(s.conflicts) > 0 {
+		s.SetCurrent((s.current + 1) % len(s.conflicts))
+	}
+}
+
+// Prev moves to the previous conflict, wrapping around to the last.
+func (s *MergeState) Prev() {
+	if len(s.conflicts) > 0 {
+		s.SetCurrent((s.current - 1 + len(s.conflicts)) % len(s.conflicts))
+	}
+}
+
+// Resolution returns how conflict i has been resolved.
+func (s *MergeState) Resolution(i int) MergeResolution {
+	if i < 0 || i >= len(s.resolutions) {
+		return MergeUnresolved
+	}
+	return s.resolutions[i]
+}
+
+// Resolve resolves the current conflict by taking one or both sides, or
+// marks it unresolved again. Use Edit to resolve it with other text.
+func (s *MergeState) Resolve(r MergeResolution) {
+	if len(s.conflicts) == 0 || r == MergeEdited {
+		return
+	}
+	s.resolutions[s.current] = r
+	s.edits[s.current] = nil
+}
+
+// Edit resolves the current conflict with text, such as the result of
+// editing Draft.
+func (s *MergeState) Edit(text string) {
+	if len(s.conflicts) == 0 {
+		return
+	}
+	s.resolutions[s.current] = MergeEdited
+	s.edits[s.current] = strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if text == "" {
+		s.edits[s.current] = []string{}
+	}
+}
+
+// Draft returns text for editing the current conflict: its resolved lines,
+// or the conflict between diff3-style markers if it is unresolved.
+func (s *MergeState) Draft() string {
+	if len(s.conflicts) == 0 {
+		return ""
+	}
+	if s.resolutions[s.current] == MergeUnresolved {
+		ours, base, theirs := s.labels()
+		return s.merge.Chunks[s.conflicts[s.current]].Markers(ours, base, theirs)
+	}
+	lines := s.resolvedLines(s.current)
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Output returns the merged text. Conflicts that are still unresolved are
+// written between diff3-style conflict markers.
+func (s *MergeState) Output() string {
+	var b strings.Builder
+	ours, base, theirs := s.labels()
+	conflict := 0
+	for _, c := range s.merge.Chunks {
+		lines := c.Merged()
+		if c.Kind == unidiff.ChunkConflict {
+			if s.resolutions[conflict] == MergeUnresolved {
+				b.WriteString(c.Markers(ours, base, theirs))
+				conflict++
+				continue
+			}
+			lines = s.resolvedLines(conflict)
+			conflict++
+		}
+		for _, line := range lines {
+			b.WriteString(line)
+			b.WriteByte('\n')
+		}
+	}
+	text := b.String()
+	if !s.merge.Newline {
+		text = strings.TrimSuffix(text, "\n")
+	}
+	return text
+}
+
+// Status returns a short summary such as "conflict 2/5, 3 unresolved".
+func (s *MergeState) Status() string {
+	switch {
+	case len(s.conflicts) == 0:
+		return "no conflicts"
+	case s.Unresolved() == 0:
+		return fmt.Sprintf("conflict %d/%d, all resolved", s.current+1, len(s.conflicts))
+	default:
+		return fmt.Sprintf("conflict %d/%d, %d unresolved", s.current+1, len(s.conflicts), s.Unresolved())
+	}
+}
+
+// HandleKey processes merge key bindings. It returns true if the event was
+// consumed.
+func (s *MergeState) HandleKey(event KeyEvent) bool {
+	switch event.Key {
+	case KeyArrowUp:
+		s.scrollY--
+		return true
+	case KeyArrowDown:
+		s.scrollY++
+		return true
+	case KeyPageUp:
+		s.scrollY -= 10
+		return true
+	case KeyPageDown:
+		s.scrollY += 10
+		return true
+	}
+	if event.Alt || event.Ctrl {
+		return false
+	}
+	switch event.Rune {
+	case 'n', ']':
+		s.Next()
+	case 'N', '[':
+		s.Prev()
+	case 'o':
+		s.Resolve(MergeOurs)
+	case 't':
+		s.Resolve(MergeTheirs)
+	case 'b':
+		s.Resolve(MergeBoth)
+	case 'x':
+		s.Resolve(MergeUnresolved)
+	case 'k':
+		s.scrollY--
+	case 'j':
+		s.scrollY++
+	default:
+		return false
+	}
+	return true
+}
+
+// labels returns the names of the three versions.
+func (s *MergeState) labels() (ours, base, theirs string) {
+	ours, base, theirs = s.OursLabel, s.BaseLabel, s.TheirsLabel
+	if ours == "" {
+		ours = "ours"
+	}
+	if base == "" {
+		base = "base"
+	}
+	if theirs == "" {
+		theirs = "theirs"
+	}
+	return ours, base, theirs
+}
+
+// resolvedLines returns the lines taken for resolved conflict i.
+func (s *MergeState) resolvedLines(i int) []string {
+	c := s.merge.Chunks[s.conflicts[i]]
+	switch s.resolutions[i] {
+	case MergeOurs:
+		return c.Ours
+	case MergeTheirs:
+		return c.Theirs
+	case MergeBoth:
+		return append(append([]string(nil), c.Ours...), c.Theirs...)
+	case MergeEdited:
+		return s.edits[i]
+	}
+	return nil
+}
+
+// mergeRow is one row of a MergeView: a line, or a blank, from each version.
+type mergeRow struct {
+	cells    [3]string
+	styles   [3]Style
+	conflict int // index of the conflict the row belongs to, or -1
+}
+
+// mergeView shows a MergeState as three side-by-side panes.
+type mergeView struct {
+	state       *MergeState
+	height      int
+	tabWidth    int
+	headerStyle Style
+	changed     Style
+	conflict    Style
+	dropped     Style
+	marker      Style
+}
+
+// MergeView displays a three-way merge as side-by-side panes of our
+// version, the base, and theirs, with chunks aligned across the panes.
+// Changes merged automatically are shown in green and conflicts in red;
+// once a conflict is resolved, the sides taken stay green and the rest are
+// dimmed. A marker flags the current conflict, which is scrolled into view
+// when it changes.
+//
+// Example:
+//
+//	tui.Stack(
+//	    tui.MergeView(app.merge),
+//	    tui.Text("%s", app.merge.Status()).Dim(),
+//	)
+func MergeView(state *MergeState) *mergeView {
+	return &mergeView{
+		state:       state,
+		tabWidth:    4,
+		headerStyle: NewStyle().WithBold(),
+		changed:     NewStyle().WithForeground(ColorGreen),
+		conflict:    NewStyle().WithForeground(ColorRed),
+		dropped:     NewStyle().WithForeground(ColorBrightBlack).WithStrikethrough(),
+		marker:      NewStyle().WithForeground(ColorYellow).WithBold(),
+	}
+}
+
+// Height sets a fixed height for the view. By default it fills the space
+// it is given.
+func (v *mergeView) Height(h int) *mergeView {
+	v.height = h
+	return v
+}
+
+// rows lays out the chunks of the merge as aligned rows.
+func (v *mergeView) rows() []mergeRow {
+	s := v.state
+	var rows []mergeRow
+	conflict := 0
+	for _, c := range s.merge.Chunks {
+		sides := [3][]string{c.Ours, c.Base, c.Theirs}
+		var styles [3]Style
+		index := -1
+		switch c.Kind {
+		case unidiff.ChunkOurs:
+			styles[0] = v.changed
+		case unidiff.ChunkTheirs:
+			styles[2] = v.changed
+		case unidiff.ChunkBoth:
+			styles[0], styles[2] = v.changed, v.changed
+		case unidiff.ChunkConflict:
+			index = conflict
+			conflict++
+			styles = [3]Style{v.conflict, v.conflict, v.conflict}
+			switch s.resolutions[index] {
+			case MergeOurs:
+				styles = [3]Style{v.changed, v.dropped, v.dropped}
+			case MergeTheirs:
+				styles = [3]Style{v.dropped, v.dropped, v.changed}
+			case MergeBoth:
+				styles = [3]Style{v.changed, v.dropped, v.changed}
+			case MergeEdited:
+				styles = [3]Style{v.dropped, v.dropped, v.dropped}
+			}
+		}
+
+		n := max(len(c.Ours), len(c.Base), len(c.Theirs))
+		if c.Kind == unidiff.ChunkConflict && n == 0 {
+			n = 1 // keep the conflict visible so it can be selected
+		}
+		for i := 0; i < n; i++ {
+			row := mergeRow{styles: styles, conflict: index}
+			for side, lines := range sides {
+				if i < len(lines) {
+					row.cells[side] = lines[i]
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func (v *mergeView) flex() int {
+	if v.height == 0 {
+		return 1
+	}
+	return 0
+}
+
+func (v *mergeView) size(maxWidth, maxHeight int) (int, int) {
+	w := maxWidth
+	if w <= 0 {
+		w = 120
+	}
+	h := v.height
+	if h == 0 {
+		h = len(v.rows()) + 1
+		if maxHeight > 0 {
+			h = maxHeight
+		}
+	}
+	if maxHeight > 0 && h > maxHeight {
+		h = maxHeight
+	}
+	return w, h
+}
+
+func (v *mergeView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	s := v.state
+
+	// Two columns of gutter, then three panes with " │ " between them
+	const gutter = 2
+	paneW := max(1, (width-gutter-6)/3)
+	paneX := [3]int{gutter, gutter + paneW + 3, gutter + 2*(paneW+3)}
+	sepStyle := NewStyle().WithForeground(ColorBrightBlack)
+
+	ours, base, theirs := s.labels()
+	for i, label := range []string{ours, base, theirs} {
+		ctx.PrintTruncated(paneX[i], 0, runewidth.Truncate(label, paneW, "…"), v.headerStyle)
+	}
+	for i := 1; i < 3; i++ {
+		ctx.PrintTruncated(paneX[i]-2, 0, "│", sepStyle)
+	}
+
+	rows := v.rows()
+	bodyH := height - 1
+	if bodyH <= 0 {
+		return
+	}
+
+	// Scroll the current conflict into view when it changes
+	if s.scrollPending {
+		s.scrollPending = false
+		first, last := -1, -1
+		for i, row := range rows {
+			if row.conflict == s.current && len(s.conflicts) > 0 {
+				if first < 0 {
+					first = i
+				}
+				last = i
+			}
+		}
+		if first >= 0 && (first < s.scrollY || last >= s.scrollY+bodyH) {
+			s.scrollY = first - bodyH/3
+		}
+	}
+	s.scrollY = max(0, min(s.scrollY, len(rows)-bodyH))
+
+	for y := 0; y < bodyH && s.scrollY+y < len(rows); y++ {
+		row := rows[s.scrollY+y]
+		if row.conflict >= 0 && row.conflict == s.current {
+			ctx.PrintTruncated(0, y+1, "▶", v.marker)
+		}
+		for i, text := range row.cells {
+			text = runewidth.Truncate(expandTabs(text, v.tabWidth), paneW, "…")
+			ctx.PrintTruncated(paneX[i], y+1, text, row.styles[i])
+			if i > 0 {
+				ctx.PrintTruncated(paneX[i]-2, y+1, "│", sepStyle)
+			}
+		}
+	}
+}
+
+// expandTabs replaces tabs in s with spaces up to the next multiple of
+// width columns.
+func expandTabs(s string, width int) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	var b strings.Builder
+	col := 0
+	for _, r := range s {
+		if r == '\t' {
+			spaces := width - col%width
+			b.WriteString(strings.Repeat(" ", spaces))
+			col += spaces
+			continue
+		}
+		b.WriteRune(r)
+		col += runewidth.RuneWidth(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+const (
+	mergeBase   = "a\nb\nc\nd\ne\n"
+	mergeOurs   = "a\nB1\nc\nd\nE1\n"
+	mergeTheirs = "a\nB2\nc\nd\nE2\n"
+)
+
+func TestMergeState_Resolve(t *testing.T) {
+	s := NewMergeState(mergeBase, mergeOurs, mergeTheirs)
+	assert.Equal(t, 2, s.Conflicts())
+	assert.Equal(t, 2, s.Unresolved())
+	assert.Equal(t, "conflict 1/2, 2 unresolved", s.Status())
+
+	s.HandleKey(KeyEvent{Rune: 'o'})
+	assert.Equal(t, MergeOurs, s.Resolution(0))
+	s.HandleKey(KeyEvent{Rune: 'n'})
+	assert.Equal(t, 1, s.Current())
+	s.HandleKey(KeyEvent{Rune: 'b'})
+	assert.Equal(t, 0, s.Unresolved())
+	assert.Equal(t, "a\nB1\nc\nd\nE1\nE2\n", s.Output())
+
+	// Wraps around to the first conflict
+	s.HandleKey(KeyEvent{Rune: 'n'})
+	assert.Equal(t, 0, s.Current())
+	s.HandleKey(KeyEvent{Rune: 'x'})
+	assert.Equal(t, "a\n<<<<<<< ours\nB1\n||||||| base\nb\n=======\nB2\n>>>>>>> theirs\nc\nd\nE1\nE2\n", s.Output())
+}
+
+func TestMergeState_Edit(t *testing.T) {
+	s := NewMergeState(mergeBase, mergeOurs, mergeTheirs)
+	s.OursLabel, s.TheirsLabel = "HEAD", "feature"
+	assert.Equal(t, "<<<<<<< HEAD\nB1\n||||||| base\nb\n=======\nB2\n>>>>>>> feature\n", s.Draft())
+
+	s.Edit("B1 and B2\n")
+	assert.Equal(t, MergeEdited, s.Resolution(0))
+	assert.Equal(t, "B1 and B2\n", s.Draft())
+
+	s.Next()
+	s.Edit("")
+	assert.Equal(t, "a\nB1 and B2\nc\nd\n", s.Output())
+}
+
+func TestMergeView_Render(t *testing.T) {
+	s := NewMergeState(mergeBase, mergeOurs, mergeTheirs)
+	s.Resolve(MergeTheirs)
+	s.Next()
+
+	screen := SprintScreen(MergeView(s), PrintConfig{Width: 20, Height: 6})
+	assert.Equal(t, "  ours │ base │ the…", screen.Row(0))
+	assert.Equal(t, "  a    │ a    │ a", screen.Row(1))
+	assert.Equal(t, "  B1   │ b    │ B2", screen.Row(2))
+	assert.Equal(t, "▶ E1   │ e    │ E2", screen.Row(5))
+
+	// The first conflict took theirs, so our side is struck out
+	assert.True(t, screen.Cell(2, 2).Style.Strike)
+	assert.False(t, screen.Cell(16, 2).Style.Strike)
+}
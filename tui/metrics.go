@@ -0,0 +1,314 @@
+package tui
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AppMetrics collects runtime measurements of a running application (frame
+// rate, render time, event latency, and commands) along with counters and
+// gauges of the application's own, for long-running TUIs such as monitors
+// and dashboards. Pass it to Run with WithMetrics to collect and serve it.
+//
+// Its methods are safe to call from any goroutine.
+//
+// Example:
+//
+//	metrics := tui.NewAppMetrics()
+//	app := &Monitor{metrics: metrics}
+//	tui.Run(app, tui.WithMetrics(metrics, "localhost:9090"))
+//
+//	// In the app
+//	app.metrics.Add("pages_crawled_total", 1)
+//	app.metrics.Set("queue_depth", float64(len(app.queue)))
+type AppMetrics struct {
+	mu    sync.Mutex
+	start time.Time
+
+	frames       uint64
+	frameTime    time.Duration // total time spent rendering
+	fps          float64
+	windowStart  time.Time // start of the current frame rate window
+	windowFrames int       // frames rendered in it
+
+	events       uint64
+	eventLatency time.Duration // total time from events occurring to being handled
+
+	cmds        uint64
+	cmdsRunning int
+	cmdsQueued  func() int // length of the runtime's command queue, once attached
+
+	render func() MetricsSnapshot // the terminal's render metrics, once attached
+
+	counters map[string]float64
+	gauges   map[string]float64
+}
+
+// AppMetricsSnapshot is the state of an AppMetrics at one moment. It is what the
+// expvar variable published by AppMetrics.Publish reports, as JSON, with
+// durations in nanoseconds.
+type AppMetricsSnapshot struct {
+	Uptime           time.Duration      // Time since the AppMetrics was created
+	FPS              float64            // Frames rendered per second, over the last second or so
+	Frames           uint64             // Frames rendered
+	AvgFrameTime     time.Duration      // Average time to render a frame
+	Events           uint64             // Events handled
+	AvgEventLatency  time.Duration      // Average time from an event occurring to it being handled
+	Cmds             uint64             // Commands started
+	CmdsRunning      int                // Commands running now
+	CmdsQueued       int                // Commands waiting to start
+	Render           MetricsSnapshot    // The terminal's drawing: cells updated, bytes written, and so on
+	Counters, Gauges map[string]float64 // The application's own metrics
+}
+
+// NewAppMetrics creates an empty Metrics.
+func NewAppMetrics() *AppMetrics {
+	now := time.Now()
+	return &AppMetrics{
+		start:       now,
+		windowStart: now,
+		counters:    make(map[string]float64),
+		gauges:      make(map[string]float64),
+	}
+}
+
+// Add adds delta to the application counter name, creating it at zero. Use
+// counters for totals that only go up, like requests made. Names should be
+// valid Prometheus metric names; other characters are replaced with '_' when
+// served.
+func (m *AppMetrics) Add(name string, delta float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counters[name] += delta
+}
+
+// Set sets the application gauge name to value. Use gauges for values that
+// go up and down, like a queue's length.
+func (m *AppMetrics) Set(name string, value float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gauges[name] = value
+}
+
+// Snapshot returns the current state of the metrics.
+func (m *AppMetrics) Snapshot() AppMetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := AppMetricsSnapshot{
+		Uptime:      time.Since(m.start),
+		FPS:         m.fps,
+		Frames:      m.frames,
+		Events:      m.events,
+		Cmds:        m.cmds,
+		CmdsRunning: m.cmdsRunning,
+		Counters:    make(map[string]float64, len(m.counters)),
+		Gauges:      make(map[string]float64, len(m.gauges)),
+	}
+	if m.frames > 0 {
+		s.AvgFrameTime = m.frameTime / time.Duration(m.frames)
+	}
+	if m.events > 0 {
+		s.AvgEventLatency = m.eventLatency / time.Duration(m.events)
+	}
+	if m.cmdsQueued != nil {
+		s.CmdsQueued = m.cmdsQueued()
+	}
+	if m.render != nil {
+		s.Render = m.render()
+	}
+	for name, v := range m.counters {
+		s.Counters[name] = v
+	}
+	for name, v := range m.gauges {
+		s.Gauges[name] = v
+	}
+	return s
+}
+
+// Publish publishes the metrics as the expvar variable name, served as JSON
+// by expvar.Handler (at /debug/vars when the expvar package's handler is
+// registered). Like expvar.Publish, it panics if name is already in use.
+func (m *AppMetrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() any { return m.Snapshot() }))
+}
+
+// Handler returns an HTTP handler that serves the metrics in the Prometheus
+// text format. Runtime metrics are prefixed "wonton_"; the application's
+// counters and gauges are served under their own names.
+func (m *AppMetrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		m.WritePrometheus(w)
+	})
+}
+
+// WritePrometheus writes the metrics to w in the Prometheus text format.
+func (m *AppMetrics) WritePrometheus(w io.Writer) error {
+	s := m.Snapshot()
+	m.mu.Lock()
+	frameTime, eventLatency := m.frameTime, m.eventLatency
+	m.mu.Unlock()
+
+	var b strings.Builder
+	metric := func(name, kind, help string, value float64) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n%s %g\n", name, help, name, kind, name, value)
+	}
+	summary := func(name, help string, sum time.Duration, count uint64) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s summary\n%s_sum %g\n%s_count %d\n",
+			name, help, name, name, sum.Seconds(), name, count)
+	}
+	metric("wonton_uptime_seconds", "gauge", "Time since the metrics were created.", s.Uptime.Seconds())
+	metric("wonton_frames_per_second", "gauge", "Frames rendered per second.", s.FPS)
+	summary("wonton_frame_render_seconds", "Time spent rendering frames.", frameTime, s.Frames)
+	summary("wonton_event_latency_seconds", "Time from events occurring to being handled.", eventLatency, s.Events)
+	metric("wonton_cmds_total", "counter", "Commands started.", float64(s.Cmds))
+	metric("wonton_cmds_running", "gauge", "Commands running.", float64(s.CmdsRunning))
+	metric("wonton_cmds_queued", "gauge", "Commands waiting to start.", float64(s.CmdsQueued))
+	metric("wonton_terminal_cells_updated_total", "counter", "Cells drawn to the terminal.", float64(s.Render.CellsUpdated))
+	metric("wonton_terminal_bytes_written_total", "counter", "Bytes written to the terminal.", float64(s.Render.BytesWritten))
+	metric("wonton_terminal_frames_skipped_total", "counter", "Frames with nothing to draw.", float64(s.Render.SkippedFrames))
+	for _, name := range sortedKeys(s.Counters) {
+		metric(prometheusName(name), "counter", "Application counter.", s.Counters[name])
+	}
+	for _, name := range sortedKeys(s.Gauges) {
+		metric(prometheusName(name), "gauge", "Application gauge.", s.Gauges[name])
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// prometheusName returns name with the characters Prometheus doesn't allow
+// in metric names replaced with '_'.
+func prometheusName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r == ':':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9' && i > 0:
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// metricsVarOnce guards publishing the "wonton" expvar variable, which can
+// only be published once per process.
+var (
+	metricsVarOnce sync.Once
+	metricsServed  struct {
+		sync.Mutex
+		m *AppMetrics
+	}
+)
+
+// serveMetrics serves m over HTTP on addr and returns a function that stops
+// the server.
+func serveMetrics(m *AppMetrics, addr string) (stop func(), err error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("metrics: %w", err)
+	}
+	metricsServed.Lock()
+	metricsServed.m = m
+	metricsServed.Unlock()
+	metricsVarOnce.Do(func() {
+		expvar.Publish("wonton", expvar.Func(func() any {
+			metricsServed.Lock()
+			defer metricsServed.Unlock()
+			return metricsServed.m.Snapshot()
+		}))
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	mux.Handle("/debug/vars", expvar.Handler())
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go server.Serve(listener)
+	return func() { server.Close() }, nil
+}
+
+// The recording methods below are called by the Runtime and do nothing on
+// a nil AppMetrics.
+
+// recordFrame records a frame whose rendering began at start.
+func (m *AppMetrics) recordFrame(start time.Time) {
+	if m == nil {
+		return
+	}
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.frames++
+	m.frameTime += now.Sub(start)
+	m.windowFrames++
+	if elapsed := now.Sub(m.windowStart); elapsed >= time.Second {
+		m.fps = float64(m.windowFrames) / elapsed.Seconds()
+		m.windowStart, m.windowFrames = now, 0
+	}
+}
+
+// recordEvent records that event has been handled.
+func (m *AppMetrics) recordEvent(event Event) {
+	if m == nil {
+		return
+	}
+	var latency time.Duration
+	if t := event.Timestamp(); !t.IsZero() {
+		latency = max(0, time.Since(t))
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events++
+	m.eventLatency += latency
+}
+
+// cmdStarted and cmdDone record a command starting and finishing.
+func (m *AppMetrics) cmdStarted() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cmds++
+	m.cmdsRunning++
+}
+
+func (m *AppMetrics) cmdDone() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cmdsRunning--
+}
+
+// attach makes the metrics report the length of queue as the commands
+// waiting to start, and terminal's render metrics, which it enables.
+func (m *AppMetrics) attach(queue chan Cmd, terminal *Terminal) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cmdsQueued = func() int { return len(queue) }
+	if terminal != nil {
+		terminal.EnableMetrics()
+		m.render = terminal.GetMetrics
+	}
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestAppMetrics_CountersAndGauges(t *testing.T) {
+	m := NewAppMetrics()
+	m.Add("pages_total", 2)
+	m.Add("pages_total", 3)
+	m.Set("queue depth", 7)
+	m.Set("queue depth", 4)
+
+	s := m.Snapshot()
+	assert.Equal(t, s.Counters["pages_total"], 5.0)
+	assert.Equal(t, s.Gauges["queue depth"], 4.0)
+
+	var buf bytes.Buffer
+	assert.NoError(t, m.WritePrometheus(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "# TYPE wonton_frames_per_second gauge\n")
+	assert.Contains(t, out, "# TYPE pages_total counter\npages_total 5\n")
+	assert.Contains(t, out, "# TYPE queue_depth gauge\nqueue_depth 4\n")
+}
+
+func TestAppMetrics_Runtime(t *testing.T) {
+	term := NewTestTerminal(10, 2, &bytes.Buffer{})
+	app := &simpleApp{
+		renderFunc: func() View { return Text("hello") },
+		handleFunc: func(Event) []Cmd { return nil },
+	}
+	runtime := NewRuntime(term, app, 30)
+	m := NewAppMetrics()
+	runtime.SetMetrics(m)
+
+	runtime.render()
+	runtime.processEventWithQuitCheck(KeyEvent{Rune: 'a', Time: time.Now().Add(-time.Millisecond)})
+	runtime.render()
+	runtime.cmds <- func() Event { return nil }
+
+	s := m.Snapshot()
+	assert.Equal(t, s.Frames, uint64(2))
+	assert.Equal(t, s.Events, uint64(1))
+	assert.True(t, s.AvgEventLatency >= time.Millisecond)
+	assert.Equal(t, s.CmdsQueued, 1)
+	assert.True(t, s.Render.CellsUpdated > 0)
+}
+
+func TestAppMetrics_Handler(t *testing.T) {
+	m := NewAppMetrics()
+	m.Add("errors_total", 1)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain"))
+	assert.Contains(t, rec.Body.String(), "errors_total 1\n")
+	assert.Contains(t, rec.Body.String(), "wonton_event_latency_seconds_count 0\n")
+}
+
+func TestPrometheusName(t *testing.T) {
+	assert.Equal(t, prometheusName("http.requests-total"), "http_requests_total")
+	assert.Equal(t, prometheusName("9lives"), "_lives")
+	assert.Equal(t, prometheusName("ok:name_2"), "ok:name_2")
+}
@@ -99,3 +99,47 @@ func (v *stack) Bg(c Color) View {
 func (h *group) Bg(c Color) View {
 	return Background(' ', NewStyle().WithBackground(c), h)
 }
+
+// FillBg extends background color c across the full width given to a
+// Stack, for a highlighted block. See FillBg.
+func (v *stack) FillBg(c Color) View {
+	return FillBg(c, v)
+}
+
+// FillBg extends background color c across the full width given to a
+// Group, for a highlighted row. See FillBg.
+func (h *group) FillBg(c Color) View {
+	return FillBg(c, h)
+}
+
+// Visibility modifiers
+
+// Hidden keeps the Stack's space in the layout but draws nothing when hidden is true.
+func (v *stack) Hidden(hidden bool) View {
+	return Hidden(hidden, v)
+}
+
+// Hidden keeps the Group's space in the layout but draws nothing when hidden is true.
+func (h *group) Hidden(hidden bool) View {
+	return Hidden(hidden, h)
+}
+
+// Hidden keeps the ZStack's space in the layout but draws nothing when hidden is true.
+func (z *zStack) Hidden(hidden bool) View {
+	return Hidden(hidden, z)
+}
+
+// Opacity fades a Stack, from 0 (invisible) to 1 (unchanged).
+func (v *stack) Opacity(opacity float64) View {
+	return Opacity(opacity, v)
+}
+
+// Opacity fades a Group, from 0 (invisible) to 1 (unchanged).
+func (h *group) Opacity(opacity float64) View {
+	return Opacity(opacity, h)
+}
+
+// Opacity fades a ZStack, from 0 (invisible) to 1 (unchanged).
+func (z *zStack) Opacity(opacity float64) View {
+	return Opacity(opacity, z)
+}
@@ -179,6 +179,31 @@ func TestSize_Render(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAspectRatio_Modifier(t *testing.T) {
+	view := AspectRatio(2, Text("Hi"))
+
+	// As big as fits: limited by width, then by height
+	w, h := view.size(20, 20)
+	assert.Equal(t, 20, w)
+	assert.Equal(t, 10, h)
+	w, h = view.size(20, 4)
+	assert.Equal(t, 8, w)
+	assert.Equal(t, 4, h)
+	w, h = view.size(0, 3)
+	assert.Equal(t, 6, w)
+	assert.Equal(t, 3, h)
+
+	// Without limits, grows from the content's size
+	w, h = AspectRatio(2, Stack(Text("a"), Text("b"), Text("c"))).size(0, 0)
+	assert.Equal(t, 6, w)
+	assert.Equal(t, 3, h)
+
+	// The inner view gets only the ratio's space
+	screen := SprintScreen(Group(AspectRatio(2, Fill('#')), Text("|")), PrintConfig{Width: 10, Height: 2})
+	assert.Equal(t, "####|", screen.Row(0))
+	assert.Equal(t, "####", screen.Row(1))
+}
+
 // Bordered view tests
 
 func TestBordered_Basic(t *testing.T) {
@@ -0,0 +1,338 @@
+package tui
+
+import (
+	"fmt"
+	"image"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// notificationCenterView is a side panel listing the notifications in a
+// NotificationStore.
+type notificationCenterView struct {
+	store      *NotificationStore
+	width      int
+	timeFormat string
+}
+
+// NotificationCenter creates a side panel listing the history of store,
+// newest first, with each notification's time, severity, message, and
+// actions. It takes no space while the center is closed, so place it beside
+// the main content and toggle it with store.Toggle:
+//
+//	tui.Group(content, tui.NotificationCenter(app.notes))
+//
+// Route keys to store.HandleKey while the center is open to select, filter,
+// remove, and act on notifications. Actions can also be clicked.
+func NotificationCenter(store *NotificationStore) *notificationCenterView {
+	return &notificationCenterView{store: store, width: 40, timeFormat: "15:04:05"}
+}
+
+// Width sets the width of the panel, including its left border. Default 40.
+func (v *notificationCenterView) Width(w int) *notificationCenterView {
+	v.width = w
+	return v
+}
+
+// TimeFormat sets the time.Format layout for timestamps. Default "15:04:05".
+func (v *notificationCenterView) TimeFormat(layout string) *notificationCenterView {
+	v.timeFormat = layout
+	return v
+}
+
+func (v *notificationCenterView) size(maxWidth, maxHeight int) (int, int) {
+	if v.store == nil || !v.store.IsOpen() {
+		return 0, 0
+	}
+	w := v.width
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	h := maxHeight
+	if h <= 0 {
+		h = 2
+		for _, n := range v.store.Filter(v.store.SeverityFilter()) {
+			h += len(v.entryLines(n, w-2))
+		}
+	}
+	return w, h
+}
+
+// notificationLine is one row of an entry in the center.
+type notificationLine struct {
+	kind notificationLineKind
+	text string
+}
+
+type notificationLineKind int
+
+const (
+	notificationTitle notificationLineKind = iota
+	notificationMessage
+	notificationActions
+	notificationGap
+)
+
+// entryLines lays out a notification in width columns.
+func (v *notificationCenterView) entryLines(n Notification, width int) []notificationLine {
+	lines := []notificationLine{{kind: notificationTitle, text: n.Title}}
+	if n.Message != "" {
+		for _, line := range strings.Split(WrapText(n.Message, max(1, width-2)), "\n") {
+			lines = append(lines, notificationLine{kind: notificationMessage, text: line})
+		}
+	}
+	if len(n.Actions) > 0 {
+		lines = append(lines, notificationLine{kind: notificationActions})
+	}
+	return append(lines, notificationLine{kind: notificationGap})
+}
+
+// filterLabel describes the store's severity filter.
+func filterLabel(s DiagnosticSeverity) string {
+	switch s {
+	case SeverityError:
+		return "errors"
+	case SeverityWarning:
+		return "warnings+"
+	case SeverityInfo:
+		return "info+"
+	default:
+		return "all"
+	}
+}
+
+func (v *notificationCenterView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 || v.store == nil || !v.store.IsOpen() {
+		return
+	}
+	border := NewStyle().WithForeground(ColorBrightBlack)
+	dim := NewStyle().WithDim()
+	for y := 0; y < height; y++ {
+		ctx.PrintStyled(0, y, "│", border)
+	}
+	x := 2
+	inner := width - x
+	if inner <= 0 {
+		return
+	}
+
+	// Header: title and filter
+	filter := v.store.SeverityFilter()
+	label := filterLabel(filter)
+	ctx.PrintStyled(x, 0, truncateToWidth("Notifications", inner), NewStyle().WithBold())
+	if lw := runewidth.StringWidth(label); x+14+lw <= width {
+		ctx.PrintStyled(width-lw, 0, label, dim)
+	}
+	if height > 1 {
+		ctx.PrintStyled(x, 1, strings.Repeat("─", inner), border)
+	}
+
+	shown := v.store.Filter(filter)
+	if len(shown) == 0 {
+		if height > 2 {
+			ctx.PrintStyled(x, 2, truncateToWidth("No notifications", inner), dim)
+		}
+		return
+	}
+
+	// Start far enough down that the selected entry fits
+	selected := 0
+	if sel, ok := v.store.Selected(); ok {
+		for i, n := range shown {
+			if n.ID == sel.ID {
+				selected = i
+			}
+		}
+	}
+	entries := make([][]notificationLine, len(shown))
+	for i, n := range shown {
+		entries[i] = v.entryLines(n, inner)
+	}
+	start, rows := 0, 0
+	for i := 0; i <= selected; i++ {
+		rows += len(entries[i])
+	}
+	for start < selected && rows > height-2 {
+		rows -= len(entries[start])
+		start++
+	}
+
+	bounds := ctx.AbsoluteBounds()
+	y := 2
+	for i := start; i < len(shown) && y < height; i++ {
+		n := shown[i]
+		for _, line := range entries[i] {
+			if y >= height {
+				break
+			}
+			switch line.kind {
+			case notificationTitle:
+				style := NewStyle().WithBold()
+				if i == selected {
+					style = style.WithReverse()
+				}
+				ctx.PrintStyled(x, y, n.Severity.marker(), NewStyle().WithForeground(n.Severity.color()))
+				stamp := n.Time.Format(v.timeFormat)
+				titleWidth := inner - 2 - runewidth.StringWidth(stamp) - 1
+				if titleWidth < 1 {
+					titleWidth, stamp = inner-2, ""
+				}
+				ctx.PrintStyled(x+2, y, truncateToWidth(line.text, titleWidth), style)
+				if stamp != "" {
+					ctx.PrintStyled(width-runewidth.StringWidth(stamp), y, stamp, dim)
+				}
+			case notificationMessage:
+				ctx.PrintStyled(x+2, y, truncateToWidth(line.text, inner-2), NewStyle())
+			case notificationActions:
+				ax := x + 2
+				for a, action := range n.Actions {
+					text := fmt.Sprintf("[%d %s]", a+1, action.Label)
+					w := runewidth.StringWidth(text)
+					if ax+w > width {
+						break
+					}
+					ctx.PrintStyled(ax, y, text, NewStyle().WithForeground(ColorCyan))
+					id, index := n.ID, a
+					interactiveRegistry.RegisterButton(
+						image.Rect(bounds.Min.X+ax, bounds.Min.Y+y, bounds.Min.X+ax+w, bounds.Min.Y+y+1),
+						func() { v.store.RunAction(id, index) },
+					)
+					ax += w + 1
+				}
+			}
+			y++
+		}
+	}
+}
+
+// toastsView shows a NotificationStore's current notifications as toasts.
+type toastsView struct {
+	store         *NotificationStore
+	width         int
+	hideCountdown bool
+}
+
+// Toasts shows the notifications of store that are not yet dismissed or
+// timed out as a column of boxes in its top right corner, newest first.
+// It fills the space it is given, so layer it over the content with ZStack:
+//
+//	tui.ZStack(content, tui.Toasts(app.notes))
+//
+// A toast's actions are shown as buttons; clicking one runs it, and
+// clicking elsewhere on the toast dismisses it. The bottom border counts
+// down the time the toast has left, and the countdown pauses while the
+// mouse pointer is over the toast (with WithMouseTracking), so there is
+// time to reach an "Undo". Toasts held back by the store's MaxToasts are
+// counted below the others as "+N more".
+//
+// Nothing is shown while the notification center is open. Toasts time out
+// as the UI re-renders, so run the app with ticks enabled.
+func Toasts(store *NotificationStore) *toastsView {
+	return &toastsView{store: store, width: 36}
+}
+
+// Width sets the width of each toast. Default 36.
+func (v *toastsView) Width(w int) *toastsView {
+	v.width = w
+	return v
+}
+
+// HideCountdown draws the bottom border of each toast whole, without the
+// time the toast has left.
+func (v *toastsView) HideCountdown() *toastsView {
+	v.hideCountdown = true
+	return v
+}
+
+func (v *toastsView) size(maxWidth, maxHeight int) (int, int) {
+	return maxWidth, maxHeight
+}
+
+func (v *toastsView) render(ctx *RenderContext) {
+	if v.store == nil || v.store.IsOpen() {
+		return
+	}
+	toasts := v.store.Toasts()
+	if len(toasts) == 0 {
+		return
+	}
+	width, height := ctx.Size()
+	w := min(v.width, width)
+	if w < 5 {
+		return
+	}
+
+	// Drawn as overlays so toasts stay on top of popups and take clicks
+	// before the content beneath them
+	origin := ctx.AbsoluteBounds().Min
+	y := 0
+	for i := len(toasts) - 1; i >= 0 && y < height; i-- {
+		n := toasts[i]
+		lines := []string{n.Title}
+		if n.Message != "" {
+			lines = append(lines, strings.Split(WrapText(n.Message, w-4), "\n")...)
+		}
+		h := len(lines) + 2
+		if len(n.Actions) > 0 {
+			h++
+		}
+		box := image.Rect(width-w, y, width, min(y+h, height))
+		id := n.ID
+
+		// Hold the toast while the pointer is over it
+		if interactiveRegistry.Hovered(box.Add(origin)) {
+			v.store.Pause(id)
+		} else {
+			v.store.Resume(id)
+		}
+
+		ctx.overlay(box, func(ctx *RenderContext) {
+			color := n.Severity.color()
+			ctx.Fill(' ', NewStyle())
+			frame := Bordered(Empty()).Border(&RoundedBorder).BorderFg(color)
+			frame.size(w, h)
+			frame.render(ctx)
+			if left, ok := v.store.toastProgress(id); ok && !v.hideCountdown {
+				// The part of the bottom border for the time gone is dimmed
+				inner := w - 2
+				gone := inner - int(left*float64(inner)+0.5)
+				ctx.PrintStyled(1+inner-gone, h-1, strings.Repeat(RoundedBorder.Horizontal, gone), NewStyle().WithForeground(ColorBrightBlack))
+			}
+			ctx.PrintStyled(2, 1, n.Severity.marker(), NewStyle().WithForeground(color))
+			ctx.PrintStyled(4, 1, truncateToWidth(lines[0], w-6), NewStyle().WithBold())
+			for j, line := range lines[1:] {
+				ctx.PrintStyled(2, 2+j, truncateToWidth(line, w-4), NewStyle())
+			}
+
+			// Actions are registered before the toast, so their clicks win
+			bounds := ctx.AbsoluteBounds()
+			ax, ay := 2, 1+len(lines)
+			for a, action := range n.Actions {
+				text := "[" + action.Label + "]"
+				aw := runewidth.StringWidth(text)
+				if ax+aw > w-2 {
+					break
+				}
+				ctx.PrintStyled(ax, ay, text, NewStyle().WithForeground(ColorCyan))
+				index := a
+				interactiveRegistry.RegisterButton(
+					image.Rect(bounds.Min.X+ax, bounds.Min.Y+ay, bounds.Min.X+ax+aw, bounds.Min.Y+ay+1),
+					func() { v.store.RunAction(id, index) },
+				)
+				ax += aw + 1
+			}
+			interactiveRegistry.RegisterButton(bounds, func() { v.store.Dismiss(id) })
+		})
+		y += h
+	}
+
+	if queued := v.store.QueuedToasts(); queued > 0 && y < height {
+		more := fmt.Sprintf("+%d more", queued)
+		mw := runewidth.StringWidth(more)
+		ctx.overlay(image.Rect(width-mw, y, width, y+1), func(ctx *RenderContext) {
+			ctx.PrintStyled(0, 0, more, NewStyle().WithDim())
+		})
+	}
+}
@@ -0,0 +1,530 @@
+package tui
+
+import (
+	"sync"
+	"time"
+)
+
+// Notification is a message for the user, shown briefly as a toast and then
+// kept in the history of a NotificationStore.
+type Notification struct {
+	ID       int
+	Severity DiagnosticSeverity
+	Title    string
+	Message  string
+	Time     time.Time
+	Actions  []NotificationAction
+
+	// Timeout is how long the toast stays up. Zero uses the store's
+	// Timeout, and a negative Timeout keeps the toast up until it is
+	// dismissed or one of its actions is run.
+	Timeout time.Duration
+
+	// Dismissed is set once the toast has timed out or been closed. The
+	// notification stays in the history.
+	Dismissed bool
+
+	// Read is set once the notification has been seen in the center.
+	Read bool
+
+	// The toast's timer, which starts once the toast is shown and stops
+	// while it is paused
+	shownAt  time.Time
+	queued   bool
+	pausedAt time.Time
+	paused   time.Duration
+
+	// Run when the toast goes without an action being run, for Perform
+	commit func()
+}
+
+// settle takes down n's toast and returns the commit of an undoable action,
+// to run once the lock is released. The change is then final, so the
+// notification's Undo goes. The caller holds the lock.
+func (n *Notification) settle() func() {
+	n.Dismissed = true
+	commit := n.commit
+	if commit != nil {
+		n.commit = nil
+		n.Actions = nil
+	}
+	return commit
+}
+
+// runCommits runs the commits returned by settle.
+func runCommits(commits []func()) {
+	for _, commit := range commits {
+		if commit != nil {
+			commit()
+		}
+	}
+}
+
+// NotificationAction is a button offered with a notification, such as
+// "Retry" or "Open".
+type NotificationAction struct {
+	Label string
+	Run   func()
+}
+
+// NotificationStore keeps notifications for toasts and the notification
+// center, and the center's panel state. It is safe to add notifications from
+// any goroutine; views read it when rendering.
+//
+// Example:
+//
+//	app.notes = tui.NewNotificationStore()
+//	app.notes.Notify(tui.SeverityError, "Upload failed", err.Error(),
+//	    tui.NotificationAction{Label: "Retry", Run: app.retry})
+//
+//	// In View:
+//	tui.ZStack(
+//	    tui.Group(content.Flex(1), tui.NotificationCenter(app.notes)),
+//	    tui.Toasts(app.notes),
+//	)
+type NotificationStore struct {
+	// Limit caps how many notifications the history keeps, dropping the
+	// oldest. Zero keeps 100.
+	Limit int
+
+	// Timeout is how long a toast stays up. Zero uses 5 seconds.
+	Timeout time.Duration
+
+	// MaxToasts caps how many toasts are up at once. Later notifications
+	// wait in a queue, their timers not yet running, until earlier toasts
+	// go; the Toasts view counts them as "+N more". Zero shows them all.
+	MaxToasts int
+
+	mu     sync.Mutex
+	items  []*Notification // oldest first
+	nextID int
+	now    func() time.Time
+
+	open     bool
+	filter   DiagnosticSeverity
+	selected int // ID of the selected notification in the center
+}
+
+// NewNotificationStore creates an empty store whose center shows every
+// severity.
+func NewNotificationStore() *NotificationStore {
+	return &NotificationStore{filter: SeverityHint, now: time.Now}
+}
+
+// Add adds a notification and returns its ID. A zero Time is set to now.
+func (s *NotificationStore) Add(n Notification) int {
+	s.mu.Lock()
+	s.nextID++
+	n.ID = s.nextID
+	if n.Time.IsZero() {
+		n.Time = s.now()
+	}
+	s.items = append(s.items, &n)
+
+	limit := s.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	var commits []func()
+	if len(s.items) > limit {
+		for _, dropped := range s.items[:len(s.items)-limit] {
+			commits = append(commits, dropped.settle())
+		}
+		s.items = append(s.items[:0], s.items[len(s.items)-limit:]...)
+	}
+	s.mu.Unlock()
+
+	runCommits(commits)
+	return n.ID
+}
+
+// Notify adds a notification with the given severity, title, message, and
+// actions, and returns its ID.
+func (s *NotificationStore) Notify(severity DiagnosticSeverity, title, message string, actions ...NotificationAction) int {
+	return s.Add(Notification{Severity: severity, Title: title, Message: message, Actions: actions})
+}
+
+// Info adds an informational notification and returns its ID.
+func (s *NotificationStore) Info(title, message string) int {
+	return s.Notify(SeverityInfo, title, message)
+}
+
+// Warn adds a warning notification and returns its ID.
+func (s *NotificationStore) Warn(title, message string) int {
+	return s.Notify(SeverityWarning, title, message)
+}
+
+// Error adds an error notification and returns its ID.
+func (s *NotificationStore) Error(title, message string) int {
+	return s.Notify(SeverityError, title, message)
+}
+
+// find returns the notification with the given ID, or nil. The caller holds
+// the lock.
+func (s *NotificationStore) find(id int) *Notification {
+	for _, n := range s.items {
+		if n.ID == id {
+			return n
+		}
+	}
+	return nil
+}
+
+// Get returns the notification with the given ID.
+func (s *NotificationStore) Get(id int) (Notification, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n := s.find(id); n != nil {
+		return *n, true
+	}
+	return Notification{}, false
+}
+
+// Dismiss takes down the notification's toast, keeping it in the history.
+func (s *NotificationStore) Dismiss(id int) {
+	s.mu.Lock()
+	var commit func()
+	if n := s.find(id); n != nil {
+		commit = n.settle()
+	}
+	s.mu.Unlock()
+	runCommits([]func(){commit})
+}
+
+// Remove deletes the notification from the history.
+func (s *NotificationStore) Remove(id int) {
+	s.mu.Lock()
+	var commit func()
+	for i, n := range s.items {
+		if n.ID == id {
+			commit = n.settle()
+			s.items = append(s.items[:i], s.items[i+1:]...)
+			break
+		}
+	}
+	s.mu.Unlock()
+	runCommits([]func(){commit})
+}
+
+// Clear deletes every notification.
+func (s *NotificationStore) Clear() {
+	s.mu.Lock()
+	var commits []func()
+	for _, n := range s.items {
+		commits = append(commits, n.settle())
+	}
+	s.items = nil
+	s.mu.Unlock()
+	runCommits(commits)
+}
+
+// All returns every notification, newest first.
+func (s *NotificationStore) All() []Notification {
+	return s.Filter(SeverityHint)
+}
+
+// Filter returns the notifications at least as severe as severity, newest
+// first.
+func (s *NotificationStore) Filter(severity DiagnosticSeverity) []Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []Notification
+	for i := len(s.items) - 1; i >= 0; i-- {
+		if s.items[i].Severity <= severity {
+			result = append(result, *s.items[i])
+		}
+	}
+	return result
+}
+
+// Toasts returns the notifications to show as toasts, oldest first: those
+// not yet dismissed or timed out, up to MaxToasts. Timed-out notifications
+// are dismissed.
+func (s *NotificationStore) Toasts() []Notification {
+	s.mu.Lock()
+	now := s.now()
+	var result []Notification
+	var commits []func()
+	for _, n := range s.items {
+		if n.Dismissed {
+			continue
+		}
+		if s.MaxToasts > 0 && len(result) >= s.MaxToasts {
+			n.queued = true
+			continue
+		}
+		if n.shownAt.IsZero() {
+			// A toast that waited in the queue gets its full time
+			n.shownAt = n.Time
+			if n.queued {
+				n.shownAt = now
+			}
+		}
+		if left, ok := s.remaining(n, now); ok && left <= 0 {
+			commits = append(commits, n.settle())
+			continue
+		}
+		result = append(result, *n)
+	}
+	s.mu.Unlock()
+
+	runCommits(commits)
+	return result
+}
+
+// QueuedToasts returns the number of toasts waiting for others to go
+// because of MaxToasts.
+func (s *NotificationStore) QueuedToasts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count, shown := 0, 0
+	for _, n := range s.items {
+		if n.Dismissed {
+			continue
+		}
+		if s.MaxToasts > 0 && shown >= s.MaxToasts {
+			count++
+		} else {
+			shown++
+		}
+	}
+	return count
+}
+
+// timeout returns how long n's toast stays up, and false if it stays until
+// dismissed.
+func (s *NotificationStore) timeout(n *Notification) (time.Duration, bool) {
+	switch {
+	case n.Timeout < 0:
+		return 0, false
+	case n.Timeout > 0:
+		return n.Timeout, true
+	case s.Timeout > 0:
+		return s.Timeout, true
+	}
+	return 5 * time.Second, true
+}
+
+// remaining returns how long n's toast has left, and false if it stays
+// until dismissed. The caller holds the lock.
+func (s *NotificationStore) remaining(n *Notification, now time.Time) (time.Duration, bool) {
+	timeout, ok := s.timeout(n)
+	if !ok {
+		return 0, false
+	}
+	if n.shownAt.IsZero() {
+		return timeout, true
+	}
+	elapsed := now.Sub(n.shownAt) - n.paused
+	if !n.pausedAt.IsZero() {
+		elapsed -= now.Sub(n.pausedAt)
+	}
+	return timeout - elapsed, true
+}
+
+// toastProgress returns the part of the toast's time still left, from 1
+// down to 0, and false if it stays until dismissed.
+func (s *NotificationStore) toastProgress(id int) (float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := s.find(id)
+	if n == nil {
+		return 0, false
+	}
+	timeout, ok := s.timeout(n)
+	if !ok {
+		return 0, false
+	}
+	left, _ := s.remaining(n, s.now())
+	return min(1, max(0, float64(left)/float64(timeout))), true
+}
+
+// Pause stops the timer of the notification's toast, so it stays up until
+// Resume. The Toasts view pauses a toast while the mouse pointer is over
+// it.
+func (s *NotificationStore) Pause(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n := s.find(id); n != nil && n.pausedAt.IsZero() {
+		n.pausedAt = s.now()
+	}
+}
+
+// Resume restarts the timer of a paused toast where it stopped.
+func (s *NotificationStore) Resume(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n := s.find(id); n != nil && !n.pausedAt.IsZero() {
+		n.paused += s.now().Sub(n.pausedAt)
+		n.pausedAt = time.Time{}
+	}
+}
+
+// Unread returns the number of notifications not yet seen in the center.
+func (s *NotificationStore) Unread() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, n := range s.items {
+		if !n.Read {
+			count++
+		}
+	}
+	return count
+}
+
+// MarkAllRead marks every notification as read.
+func (s *NotificationStore) MarkAllRead() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, n := range s.items {
+		n.Read = true
+	}
+}
+
+// RunAction runs the notification's action with the given index and
+// dismisses it. It reports whether there was such an action.
+func (s *NotificationStore) RunAction(id, index int) bool {
+	s.mu.Lock()
+	n := s.find(id)
+	if n == nil || index < 0 || index >= len(n.Actions) {
+		s.mu.Unlock()
+		return false
+	}
+	n.Dismissed = true
+	n.commit = nil // the user answered, so an undoable change isn't committed
+	run := n.Actions[index].Run
+	s.mu.Unlock()
+
+	// Run without the lock so the action can add notifications
+	if run != nil {
+		run()
+	}
+	return true
+}
+
+// IsOpen reports whether the notification center is shown.
+func (s *NotificationStore) IsOpen() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.open
+}
+
+// SetOpen shows or hides the notification center. Opening it marks every
+// notification as read and dismisses the toasts, since the center shows
+// them.
+func (s *NotificationStore) SetOpen(open bool) {
+	s.mu.Lock()
+	s.open = open
+	var commits []func()
+	if open {
+		for _, n := range s.items {
+			n.Read = true
+			if !n.Dismissed {
+				commits = append(commits, n.settle())
+			}
+		}
+	}
+	s.mu.Unlock()
+	runCommits(commits)
+}
+
+// Toggle shows the notification center if it is hidden, and hides it
+// otherwise.
+func (s *NotificationStore) Toggle() {
+	s.SetOpen(!s.IsOpen())
+}
+
+// SeverityFilter returns the least severe level the center shows.
+func (s *NotificationStore) SeverityFilter() DiagnosticSeverity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.filter
+}
+
+// SetSeverityFilter makes the center show only notifications at least as
+// severe as severity. SeverityHint shows everything.
+func (s *NotificationStore) SetSeverityFilter(severity DiagnosticSeverity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.filter = severity
+}
+
+// Selected returns the notification selected in the center.
+func (s *NotificationStore) Selected() (Notification, bool) {
+	s.mu.Lock()
+	id := s.selected
+	s.mu.Unlock()
+	return s.Get(id)
+}
+
+// HandleKey handles a key for the open notification center and reports
+// whether it used it:
+//
+//   - Up/Down or k/j: Select the previous/next notification
+//   - Enter or 1-9: Run the selected notification's first or nth action
+//   - d or Delete: Remove the selected notification
+//   - f: Cycle the severity filter: all, warnings and errors, errors
+//   - c: Clear the history
+//   - Escape: Close the center
+func (s *NotificationStore) HandleKey(e KeyEvent) bool {
+	if !s.IsOpen() {
+		return false
+	}
+	shown := s.Filter(s.SeverityFilter())
+	index := 0
+	if sel, ok := s.Selected(); ok {
+		for i, n := range shown {
+			if n.ID == sel.ID {
+				index = i
+			}
+		}
+	}
+	selectIndex := func(i int) {
+		if i >= 0 && i < len(shown) {
+			s.mu.Lock()
+			s.selected = shown[i].ID
+			s.mu.Unlock()
+		}
+	}
+
+	switch {
+	case e.Key == KeyArrowUp || e.Rune == 'k':
+		selectIndex(index - 1)
+	case e.Key == KeyArrowDown || e.Rune == 'j':
+		selectIndex(index + 1)
+	case e.Key == KeyEnter:
+		if len(shown) > 0 {
+			s.RunAction(shown[index].ID, 0)
+		}
+	case e.Rune >= '1' && e.Rune <= '9':
+		if len(shown) > 0 {
+			s.RunAction(shown[index].ID, int(e.Rune-'1'))
+		}
+	case e.Key == KeyDelete || e.Rune == 'd':
+		if len(shown) > 0 {
+			s.Remove(shown[index].ID)
+			if index+1 < len(shown) {
+				selectIndex(index + 1)
+			} else {
+				selectIndex(index - 1)
+			}
+		}
+	case e.Rune == 'f':
+		switch s.SeverityFilter() {
+		case SeverityError:
+			s.SetSeverityFilter(SeverityHint)
+		case SeverityWarning:
+			s.SetSeverityFilter(SeverityError)
+		default:
+			s.SetSeverityFilter(SeverityWarning)
+		}
+	case e.Rune == 'c':
+		s.Clear()
+	case e.Key == KeyEscape:
+		s.SetOpen(false)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,278 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+// newTestStore returns a store whose clock is set by the returned pointer.
+func newTestStore() (*NotificationStore, *time.Time) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := NewNotificationStore()
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestNotificationStore(t *testing.T) {
+	s, _ := newTestStore()
+	first := s.Info("Saved", "report.pdf")
+	second := s.Error("Upload failed", "connection refused")
+	s.Warn("Disk almost full", "")
+
+	all := s.All()
+	assert.Len(t, all, 3)
+	assert.Equal(t, "Disk almost full", all[0].Title) // newest first
+	assert.Equal(t, first, all[2].ID)
+	assert.Equal(t, 3, s.Unread())
+
+	errs := s.Filter(SeverityError)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, second, errs[0].ID)
+	assert.Len(t, s.Filter(SeverityWarning), 2)
+
+	s.Remove(second)
+	_, ok := s.Get(second)
+	assert.False(t, ok)
+	assert.Len(t, s.All(), 2)
+
+	s.MarkAllRead()
+	assert.Equal(t, 0, s.Unread())
+	s.Clear()
+	assert.Len(t, s.All(), 0)
+}
+
+func TestNotificationStore_Limit(t *testing.T) {
+	s, _ := newTestStore()
+	s.Limit = 2
+	s.Info("one", "")
+	s.Info("two", "")
+	s.Info("three", "")
+	all := s.All()
+	assert.Len(t, all, 2)
+	assert.Equal(t, "three", all[0].Title)
+	assert.Equal(t, "two", all[1].Title)
+}
+
+func TestNotificationStore_Toasts(t *testing.T) {
+	s, now := newTestStore()
+	s.Timeout = 5 * time.Second
+	first := s.Info("first", "")
+	*now = now.Add(3 * time.Second)
+	second := s.Info("second", "")
+	assert.Len(t, s.Toasts(), 2)
+
+	// Toasts time out but stay in the history
+	*now = now.Add(3 * time.Second)
+	toasts := s.Toasts()
+	assert.Len(t, toasts, 1)
+	assert.Equal(t, second, toasts[0].ID)
+	n, _ := s.Get(first)
+	assert.True(t, n.Dismissed)
+
+	s.Dismiss(second)
+	assert.Len(t, s.Toasts(), 0)
+	assert.Len(t, s.All(), 2)
+}
+
+func TestNotificationStore_Actions(t *testing.T) {
+	s, _ := newTestStore()
+	retried := false
+	id := s.Notify(SeverityError, "Upload failed", "",
+		NotificationAction{Label: "Retry", Run: func() {
+			retried = true
+			s.Info("Retrying", "") // actions may add notifications
+		}})
+
+	assert.False(t, s.RunAction(id, 1))
+	assert.True(t, s.RunAction(id, 0))
+	assert.True(t, retried)
+	n, _ := s.Get(id)
+	assert.True(t, n.Dismissed)
+	assert.Len(t, s.All(), 2)
+}
+
+func TestNotificationStore_HandleKey(t *testing.T) {
+	s, _ := newTestStore()
+	opened := ""
+	s.Notify(SeverityError, "error", "", NotificationAction{Label: "Open", Run: func() { opened = "error" }})
+	s.Warn("warning", "")
+	s.Info("info", "")
+
+	// Keys do nothing while the center is closed
+	assert.False(t, s.HandleKey(KeyEvent{Rune: 'j'}))
+
+	s.Toggle()
+	assert.True(t, s.IsOpen())
+	assert.Equal(t, 0, s.Unread())
+	assert.Len(t, s.Toasts(), 0)
+
+	// Cycle the filter to errors only
+	s.HandleKey(KeyEvent{Rune: 'f'})
+	assert.Equal(t, SeverityWarning, s.SeverityFilter())
+	s.HandleKey(KeyEvent{Rune: 'f'})
+	assert.Equal(t, SeverityError, s.SeverityFilter())
+	s.HandleKey(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, "error", opened)
+	s.HandleKey(KeyEvent{Rune: 'f'})
+	assert.Equal(t, SeverityHint, s.SeverityFilter())
+
+	// Select and remove
+	s.HandleKey(KeyEvent{Rune: 'j'})
+	sel, _ := s.Selected()
+	assert.Equal(t, "warning", sel.Title)
+	s.HandleKey(KeyEvent{Rune: 'd'})
+	sel, _ = s.Selected()
+	assert.Equal(t, "error", sel.Title)
+	assert.Len(t, s.All(), 2)
+
+	s.HandleKey(KeyEvent{Key: KeyEscape})
+	assert.False(t, s.IsOpen())
+}
+
+func TestNotificationCenter(t *testing.T) {
+	s, _ := newTestStore()
+	s.Error("Upload failed", "connection refused while sending report.pdf")
+	s.Notify(SeverityInfo, "Saved", "", NotificationAction{Label: "Open"}, NotificationAction{Label: "Undo"})
+
+	// Closed, the center takes no space
+	w, h := NotificationCenter(s).size(40, 10)
+	assert.Equal(t, 0, w)
+	assert.Equal(t, 0, h)
+
+	s.SetOpen(true)
+	screen := SprintScreen(NotificationCenter(s).Width(30), PrintConfig{Width: 30, Height: 9})
+	assert.Equal(t, "│ Notifications            all", screen.Row(0))
+	assert.Equal(t, "│ ■ Saved             12:00:00", screen.Row(2))
+	assert.Equal(t, "│   [1 Open] [2 Undo]", screen.Row(3))
+	assert.Equal(t, "│ ● Upload failed     12:00:00", screen.Row(5))
+	assert.Equal(t, "│   connection refused while", screen.Row(6))
+	assert.Equal(t, "│   sending report.pdf", screen.Row(7))
+
+	s.SetSeverityFilter(SeverityError)
+	screen = SprintScreen(NotificationCenter(s).Width(30), PrintConfig{Width: 30, Height: 4})
+	assert.Equal(t, "│ Notifications         errors", screen.Row(0))
+	assert.Equal(t, "│ ● Upload failed     12:00:00", screen.Row(2))
+}
+
+func TestToasts(t *testing.T) {
+	s, _ := newTestStore()
+	s.Error("Upload failed", "connection refused")
+	s.Info("Saved", "")
+
+	screen := SprintScreen(ZStack(Text("content"), Toasts(s).Width(24)), PrintConfig{Width: 30, Height: 8})
+	assert.Equal(t, "      ╭──────────────────────╮", screen.Row(0))
+	assert.Equal(t, "      │ ■ Saved              │", screen.Row(1))
+	assert.Equal(t, "      │ ● Upload failed      │", screen.Row(4))
+	assert.Equal(t, "      │ connection refused   │", screen.Row(5))
+
+	// The center replaces the toasts
+	s.SetOpen(true)
+	screen = SprintScreen(ZStack(Text("content"), Toasts(s)), PrintConfig{Width: 30, Height: 8})
+	assert.Equal(t, "", screen.Row(0))
+}
+
+func TestNotificationStore_ToastTimeouts(t *testing.T) {
+	s, now := newTestStore()
+	s.Timeout = 5 * time.Second
+	short := s.Add(Notification{Title: "short", Timeout: time.Second})
+	sticky := s.Add(Notification{Title: "sticky", Timeout: -1})
+	normal := s.Info("normal", "")
+
+	*now = now.Add(2 * time.Second)
+	toasts := s.Toasts()
+	assert.Len(t, toasts, 2)
+	assert.Equal(t, sticky, toasts[0].ID)
+
+	// Paused toasts keep the time they had left
+	s.Pause(normal)
+	*now = now.Add(time.Minute)
+	assert.Len(t, s.Toasts(), 2)
+	s.Resume(normal)
+	*now = now.Add(2 * time.Second)
+	assert.Len(t, s.Toasts(), 2)
+	*now = now.Add(time.Second)
+	toasts = s.Toasts()
+	assert.Len(t, toasts, 1)
+	assert.Equal(t, sticky, toasts[0].ID)
+
+	n, _ := s.Get(short)
+	assert.True(t, n.Dismissed)
+}
+
+func TestNotificationStore_MaxToasts(t *testing.T) {
+	s, now := newTestStore()
+	s.Timeout = 5 * time.Second
+	s.MaxToasts = 2
+	s.Info("one", "")
+	s.Info("two", "")
+	s.Info("three", "")
+	s.Info("four", "")
+
+	toasts := s.Toasts()
+	assert.Len(t, toasts, 2)
+	assert.Equal(t, "one", toasts[0].Title)
+	assert.Equal(t, 2, s.QueuedToasts())
+
+	// Queued toasts get their full time once shown
+	*now = now.Add(5 * time.Second)
+	toasts = s.Toasts()
+	assert.Len(t, toasts, 2)
+	assert.Equal(t, "three", toasts[0].Title)
+	assert.Equal(t, 0, s.QueuedToasts())
+	*now = now.Add(4 * time.Second)
+	assert.Len(t, s.Toasts(), 2)
+}
+
+func TestToasts_ActionsAndOverflow(t *testing.T) {
+	interactiveRegistry.Clear()
+	defer interactiveRegistry.Clear()
+	s, _ := newTestStore()
+	s.MaxToasts = 1
+	undone := false
+	id := s.Notify(SeverityInfo, "Deleted", "", NotificationAction{Label: "Undo", Run: func() { undone = true }})
+	s.Info("Saved", "")
+	s.Info("Sent", "")
+
+	screen := SprintScreen(ZStack(Text("content"), Toasts(s).Width(20).HideCountdown()), PrintConfig{Width: 30, Height: 8})
+	assert.Equal(t, "          ╭──────────────────╮", screen.Row(0))
+	assert.Equal(t, "          │ ■ Deleted        │", screen.Row(1))
+	assert.Equal(t, "          │ [Undo]           │", screen.Row(2))
+	assert.Equal(t, "          ╰──────────────────╯", screen.Row(3))
+	assert.Equal(t, "                       +2 more", screen.Row(4))
+
+	// The action's button takes its click before the toast
+	assert.True(t, interactiveRegistry.HandleClick(13, 2))
+	assert.True(t, undone)
+	n, _ := s.Get(id)
+	assert.True(t, n.Dismissed)
+}
+
+func TestToasts_Countdown(t *testing.T) {
+	interactiveRegistry.Clear()
+	defer interactiveRegistry.Clear()
+	defer interactiveRegistry.HandleHover(MouseEvent{Type: MouseLeave})
+	s, now := newTestStore()
+	s.Timeout = 4 * time.Second
+	id := s.Info("Saved", "")
+	view := ZStack(Text("content"), Toasts(s).Width(10))
+
+	*now = now.Add(time.Second)
+	terminal := renderToTerminal(t, view, 10, 3)
+	// A quarter of the time is gone, so the last 2 of 8 cells are dimmed
+	assert.Equal(t, ColorBlue, terminal.GetCell(6, 2).Style.Foreground)
+	assert.Equal(t, ColorBrightBlack, terminal.GetCell(7, 2).Style.Foreground)
+
+	// The pointer over the toast holds it
+	interactiveRegistry.HandleHover(MouseEvent{Type: MouseMove, X: 3, Y: 1})
+	renderToTerminal(t, view, 10, 3)
+	*now = now.Add(time.Minute)
+	assert.Len(t, s.Toasts(), 1)
+	interactiveRegistry.HandleHover(MouseEvent{Type: MouseLeave})
+	renderToTerminal(t, view, 10, 3)
+	*now = now.Add(3 * time.Second)
+	assert.Len(t, s.Toasts(), 0)
+	n, _ := s.Get(id)
+	assert.True(t, n.Dismissed)
+}
@@ -0,0 +1,304 @@
+package tui
+
+import "image"
+
+// floating is implemented by views that take no space in a layout and draw
+// over the rest of the screen instead, so containers render them even
+// though they measure as empty.
+type floating interface {
+	floats()
+}
+
+// renderFloating renders child if it is a floating view. Containers call it
+// for children they otherwise skip for being empty.
+func renderFloating(ctx *RenderContext, child View) {
+	if _, ok := child.(floating); ok {
+		child.render(ctx.SubContext(image.Rectangle{}))
+	}
+}
+
+// OverlayAlignment is the point of the screen an Overlay is placed at.
+type OverlayAlignment int
+
+const (
+	OverlayTopLeft OverlayAlignment = iota
+	OverlayTop
+	OverlayTopRight
+	OverlayLeft
+	OverlayCenter
+	OverlayRight
+	OverlayBottomLeft
+	OverlayBottom
+	OverlayBottomRight
+)
+
+// overlayView draws its content at a point of the screen.
+type overlayView struct {
+	alignment        OverlayAlignment
+	content          View
+	marginX, marginY int
+}
+
+// Overlay draws content over the rest of the screen at a corner, edge, or
+// the center, wherever it appears in the view tree. It takes no space in the
+// layout, and is drawn after everything else, so it isn't hidden by views
+// drawn later or clipped by its parents. Clicks on it go to its content
+// rather than the views beneath.
+//
+// Example:
+//
+//	tui.Stack(
+//	    header,
+//	    content,
+//	    tui.If(app.saved, tui.Overlay(tui.OverlayBottomRight, tui.Text(" Saved ").Reverse()).Margin(2, 1)),
+//	)
+func Overlay(alignment OverlayAlignment, content View) *overlayView {
+	return &overlayView{alignment: alignment, content: content}
+}
+
+// Margin keeps the content x columns from the left and right edges of the
+// screen and y rows from the top and bottom.
+func (o *overlayView) Margin(x, y int) *overlayView {
+	o.marginX, o.marginY = x, y
+	return o
+}
+
+func (o *overlayView) floats() {}
+
+func (o *overlayView) size(maxWidth, maxHeight int) (int, int) {
+	return 0, 0
+}
+
+func (o *overlayView) render(ctx *RenderContext) {
+	ctx.overlayPlaced(o.place, func(box *RenderContext) {
+		box.Fill(' ', NewStyle())
+		o.content.render(box)
+	})
+}
+
+// place returns the bounds of the content on a screen of the given bounds.
+func (o *overlayView) place(screen image.Rectangle) image.Rectangle {
+	area := screen
+	area.Min.X, area.Max.X = area.Min.X+o.marginX, area.Max.X-o.marginX
+	area.Min.Y, area.Max.Y = area.Min.Y+o.marginY, area.Max.Y-o.marginY
+	if area.Dx() <= 0 || area.Dy() <= 0 {
+		return image.Rectangle{}
+	}
+	w, h := o.content.size(area.Dx(), area.Dy())
+	w, h = min(w, area.Dx()), min(h, area.Dy())
+
+	x, y := area.Min.X, area.Min.Y
+	switch o.alignment {
+	case OverlayTop, OverlayCenter, OverlayBottom:
+		x += (area.Dx() - w) / 2
+	case OverlayTopRight, OverlayRight, OverlayBottomRight:
+		x = area.Max.X - w
+	}
+	switch o.alignment {
+	case OverlayLeft, OverlayCenter, OverlayRight:
+		y += (area.Dy() - h) / 2
+	case OverlayBottomLeft, OverlayBottom, OverlayBottomRight:
+		y = area.Max.Y - h
+	}
+	return image.Rect(x, y, x+w, y+h)
+}
+
+// anchorView records where its content is drawn for popovers.
+type anchorView struct {
+	id    string
+	inner View
+}
+
+// Anchor marks a view that popovers can be placed next to by its ID. Views
+// that can take focus don't need one; a Popover can name their focus ID.
+//
+// Example:
+//
+//	tui.Anchor("search", tui.InputField(&app.query))
+func Anchor(id string, inner View) View {
+	return &anchorView{id: id, inner: inner}
+}
+
+func (a *anchorView) size(maxWidth, maxHeight int) (int, int) {
+	return a.inner.size(maxWidth, maxHeight)
+}
+
+func (a *anchorView) flex() int {
+	if flex, ok := a.inner.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (a *anchorView) render(ctx *RenderContext) {
+	ctx.setAnchor(a.id)
+	a.inner.render(ctx)
+}
+
+// PopoverPlacement is the side of its anchor a Popover opens on.
+type PopoverPlacement int
+
+const (
+	PopoverBelow PopoverPlacement = iota
+	PopoverAbove
+	PopoverRight
+	PopoverLeft
+)
+
+// popoverView draws its content next to another view.
+type popoverView struct {
+	anchorID   string
+	content    View
+	placement  PopoverPlacement
+	alignment  Alignment
+	gap        int
+	matchWidth bool
+}
+
+// Popover draws content next to the view with the given ID: an Anchor, or a
+// focusable view such as an InputField, by its focus ID. It opens below the
+// anchor unless Placement says otherwise, and flips to the other side if
+// there is more room there, so tooltips, dropdowns, and completion menus
+// stay on screen. Like Overlay, it can appear anywhere in the view tree,
+// takes no space in the layout, and is drawn over everything else. Nothing
+// is drawn if the anchor isn't on screen.
+//
+// Example:
+//
+//	tui.Stack(
+//	    tui.InputField(&app.query).ID("query"),
+//	    results,
+//	    tui.If(len(app.completions) > 0,
+//	        tui.Popover("query", tui.Bordered(completionList)).MatchWidth()),
+//	)
+func Popover(anchorID string, content View) *popoverView {
+	return &popoverView{anchorID: anchorID, content: content, alignment: AlignLeft}
+}
+
+// Placement sets the side of the anchor the popover opens on.
+func (p *popoverView) Placement(placement PopoverPlacement) *popoverView {
+	p.placement = placement
+	return p
+}
+
+// Align lines the popover up with the anchor's start (AlignLeft, the
+// default), center, or end (AlignRight): its left, center, or right edge
+// when placed above or below, or its top, middle, or bottom when placed to
+// the side.
+func (p *popoverView) Align(a Alignment) *popoverView {
+	p.alignment = a
+	return p
+}
+
+// Gap leaves n cells between the anchor and the popover.
+func (p *popoverView) Gap(n int) *popoverView {
+	p.gap = n
+	return p
+}
+
+// MatchWidth makes a popover placed above or below as wide as its anchor,
+// as for a dropdown.
+func (p *popoverView) MatchWidth() *popoverView {
+	p.matchWidth = true
+	return p
+}
+
+func (p *popoverView) floats() {}
+
+func (p *popoverView) size(maxWidth, maxHeight int) (int, int) {
+	return 0, 0
+}
+
+func (p *popoverView) render(ctx *RenderContext) {
+	ctx.overlayPlaced(func(screen image.Rectangle) image.Rectangle {
+		anchor, ok := ctx.anchorBounds(p.anchorID)
+		if !ok || anchor.Empty() {
+			return image.Rectangle{}
+		}
+		return p.place(anchor, screen)
+	}, func(box *RenderContext) {
+		box.Fill(' ', NewStyle())
+		p.content.render(box)
+	})
+}
+
+// place returns the bounds of the content next to anchor, on a screen of the
+// given bounds.
+func (p *popoverView) place(anchor, screen image.Rectangle) image.Rectangle {
+	vertical := p.placement == PopoverBelow || p.placement == PopoverAbove
+	forward := p.placement == PopoverBelow || p.placement == PopoverRight
+
+	// The room before and after the anchor along the placement's axis
+	before, after := anchor.Min.Y-screen.Min.Y, screen.Max.Y-anchor.Max.Y
+	if !vertical {
+		before, after = anchor.Min.X-screen.Min.X, screen.Max.X-anchor.Max.X
+	}
+	before, after = before-p.gap, after-p.gap
+
+	// The content's size with room along the axis (0 for as much as it likes)
+	maxW := screen.Dx()
+	if vertical && p.matchWidth {
+		maxW = min(maxW, anchor.Dx())
+	}
+	measure := func(room int) (int, int) {
+		if vertical {
+			w, h := p.content.size(maxW, room)
+			if p.matchWidth {
+				w = maxW
+			}
+			return min(w, maxW), h
+		}
+		w, h := p.content.size(room, screen.Dy())
+		return w, min(h, screen.Dy())
+	}
+
+	// Flip to the other side if the content doesn't fit and there is more
+	// room there
+	w, h := measure(0)
+	length, room, other := h, before, after
+	if !vertical {
+		length = w
+	}
+	if forward {
+		room, other = after, before
+	}
+	if length > room && other > room {
+		forward, room = !forward, other
+	}
+	if room <= 0 {
+		return image.Rectangle{}
+	}
+	w, h = measure(room)
+
+	var x, y int
+	if vertical {
+		h = min(h, room)
+		y = anchor.Max.Y + p.gap
+		if !forward {
+			y = anchor.Min.Y - p.gap - h
+		}
+		x = alignedStart(p.alignment, anchor.Min.X, anchor.Max.X, w)
+		x = max(screen.Min.X, min(x, screen.Max.X-w))
+	} else {
+		w = min(w, room)
+		x = anchor.Max.X + p.gap
+		if !forward {
+			x = anchor.Min.X - p.gap - w
+		}
+		y = alignedStart(p.alignment, anchor.Min.Y, anchor.Max.Y, h)
+		y = max(screen.Min.Y, min(y, screen.Max.Y-h))
+	}
+	return image.Rect(x, y, x+w, y+h)
+}
+
+// alignedStart returns where something n long starts when aligned with the
+// span from start to end.
+func alignedStart(a Alignment, start, end, n int) int {
+	switch a {
+	case AlignCenter:
+		return start + (end-start-n)/2
+	case AlignRight:
+		return end - n
+	}
+	return start
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestOverlay_Alignment(t *testing.T) {
+	view := Stack(
+		Text("content"),
+		Overlay(OverlayBottomRight, Text("ok")).Margin(1, 0),
+		Overlay(OverlayCenter, Text("mid")),
+	)
+	screen := SprintScreen(view, PrintConfig{Width: 11, Height: 5})
+	assert.Equal(t, "content", screen.Row(0))
+	assert.Equal(t, "    mid", screen.Row(2))
+	assert.Equal(t, "        ok", screen.Row(4))
+}
+
+func TestPopover_BelowAnchor(t *testing.T) {
+	view := Stack(
+		// Declared before its anchor, and drawn over the views after it
+		Popover("field", Text("tip")).Align(AlignRight),
+		Text("title"),
+		Group(Text("  "), Anchor("field", Text("field"))),
+		Text("after"),
+	)
+	screen := SprintScreen(view, PrintConfig{Width: 10, Height: 5})
+	assert.Equal(t, "title", screen.Row(0))
+	assert.Equal(t, "  field", screen.Row(1))
+	assert.Equal(t, "aftetip", screen.Row(2))
+}
+
+func TestPopover_FlipsWhenNoRoom(t *testing.T) {
+	view := Stack(
+		Text("one"),
+		Text("two"),
+		Anchor("last", Text("last")),
+		Popover("last", Stack(Text("a"), Text("b"))),
+	)
+	screen := SprintScreen(view, PrintConfig{Width: 10, Height: 3})
+	assert.Equal(t, "ane", screen.Row(0))
+	assert.Equal(t, "bwo", screen.Row(1))
+	assert.Equal(t, "last", screen.Row(2))
+}
+
+func TestPopover_Side(t *testing.T) {
+	// Cut to the room on the right
+	screen := SprintScreen(Stack(
+		Anchor("btn", Text("[go]")),
+		Popover("btn", Text("a long tip")).Placement(PopoverRight).Gap(1),
+	), PrintConfig{Width: 12, Height: 2})
+	assert.Equal(t, "[go] a long", screen.Row(0))
+
+	// No room on the right, so it flips to the left
+	screen = SprintScreen(Group(
+		Text("some text "),
+		Anchor("btn", Text("[x]")),
+		Popover("btn", Text("tip")).Placement(PopoverRight),
+	), PrintConfig{Width: 13, Height: 1})
+	assert.Equal(t, "some tetip[x]", screen.Row(0))
+}
+
+func TestPopover_MissingAnchor(t *testing.T) {
+	view := Stack(Text("only"), Popover("nowhere", Text("tip")))
+	screen := SprintScreen(view, PrintConfig{Width: 10, Height: 3})
+	assert.Equal(t, "only", screen.Row(0))
+	assert.Equal(t, "", screen.Row(1))
+}
+
+func TestPopover_MatchWidth(t *testing.T) {
+	view := Stack(
+		Anchor("input", Text("[ input ]")),
+		Popover("input", Bordered(Text("x")).Border(&SingleBorder)).MatchWidth(),
+	)
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 4})
+	assert.Equal(t, "┌───────┐", screen.Row(1))
+	assert.Equal(t, "│x      │", screen.Row(2))
+}
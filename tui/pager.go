@@ -0,0 +1,530 @@
+package tui
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// PagerState holds the scroll position and search of a Pager, which lives
+// in application state so it survives between frames.
+type PagerState struct {
+	Top  int // Index of the first visible line
+	Left int // First visible column, for lines wider than the pager
+
+	// Search is the "/" search of the pager's content
+	Search SearchController
+
+	// From the last render, for key handling
+	count, pageSize, width int
+}
+
+// HandleKey scrolls and searches for the keys of less:
+//
+//   - j, k, Up, Down, Enter, y, Ctrl+E/Ctrl+Y, Ctrl+N/Ctrl+P: one line
+//   - Space, f, b, PageDown, PageUp, Ctrl+F/Ctrl+B, Ctrl+V/Alt+V: one page
+//   - d, u, Ctrl+D/Ctrl+U: half a page
+//   - g, G, <, >, Home, End: the top or bottom
+//   - Left, Right: half the width, for lines wider than the pager
+//   - /, n, N, Escape: search; see SearchController.HandleKey
+//
+// It returns true if the key was used, so applications that route keys
+// themselves can call it from HandleEvent. Quitting (q) is left to the
+// application.
+func (s *PagerState) HandleKey(event KeyEvent) bool {
+	if s.Search.HandleKey(event) {
+		return true
+	}
+	page := max(1, s.pageSize)
+	half := max(1, page/2)
+	if event.Alt {
+		if event.Rune == 'v' {
+			s.ScrollBy(-page)
+			return true
+		}
+		return false
+	}
+
+	switch event.Key {
+	case KeyArrowDown, KeyEnter, KeyCtrlE, KeyCtrlN:
+		s.ScrollBy(1)
+	case KeyArrowUp, KeyCtrlY, KeyCtrlP:
+		s.ScrollBy(-1)
+	case KeyPageDown, KeyCtrlF, KeyCtrlV:
+		s.ScrollBy(page)
+	case KeyPageUp, KeyCtrlB:
+		s.ScrollBy(-page)
+	case KeyCtrlD:
+		s.ScrollBy(half)
+	case KeyCtrlU:
+		s.ScrollBy(-half)
+	case KeyHome:
+		s.ScrollTo(0)
+	case KeyEnd:
+		s.ScrollTo(s.count)
+	case KeyArrowRight:
+		s.Left += max(1, s.width/2)
+	case KeyArrowLeft:
+		s.Left = max(0, s.Left-max(1, s.width/2))
+	case KeyUnknown:
+		if event.Ctrl {
+			return false
+		}
+		switch event.Rune {
+		case 'j':
+			s.ScrollBy(1)
+		case 'k', 'y':
+			s.ScrollBy(-1)
+		case ' ', 'f':
+			s.ScrollBy(page)
+		case 'b':
+			s.ScrollBy(-page)
+		case 'd':
+			s.ScrollBy(half)
+		case 'u':
+			s.ScrollBy(-half)
+		case 'g', '<':
+			s.ScrollTo(0)
+		case 'G', '>':
+			s.ScrollTo(s.count)
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// ScrollBy moves the first visible line by delta lines.
+func (s *PagerState) ScrollBy(delta int) {
+	s.ScrollTo(s.Top + delta)
+}
+
+// ScrollTo makes line the first visible line, as far as the content allows.
+func (s *PagerState) ScrollTo(line int) {
+	s.Top = line
+	s.clamp()
+}
+
+// Percent returns how far through the content the last visible line is, as
+// less shows it, from the last render.
+func (s *PagerState) Percent() int {
+	if s.count == 0 {
+		return 100
+	}
+	return min(100, (s.Top+s.pageSize)*100/s.count)
+}
+
+// AtEnd reports whether the last line was visible in the last render.
+func (s *PagerState) AtEnd() bool {
+	return s.Top+s.pageSize >= s.count
+}
+
+// clamp keeps the first visible line within the scrollable range.
+func (s *PagerState) clamp() {
+	if s.pageSize > 0 {
+		s.Top = min(s.Top, s.count-s.pageSize)
+	}
+	s.Top = max(0, s.Top)
+}
+
+// pagerView shows lines of text a screen at a time.
+type pagerView struct {
+	id          string
+	lines       [][]StyledSegment
+	texts       []string // plain text of lines, for search
+	state       *PagerState
+	title       string
+	lineNumbers bool
+	hideStatus  bool
+	statusStyle Style
+	bounds      image.Rectangle
+	focused     bool
+}
+
+// Pager shows content a screen at a time, like less: it scrolls with the
+// less keys (see PagerState.HandleKey), the mouse wheel, and sideways for
+// long lines, searches with "/", and shows a status line with the lines on
+// screen and how far through they are. Tabs are expanded to 8 columns.
+//
+// Keys reach the pager when it has focus, which requires an ID;
+// applications that route keys themselves can call state.HandleKey
+// instead. A nil state keeps the position only as long as the view. To
+// page content from a command line program, see Page.
+//
+// Example:
+//
+//	tui.Pager(app.log, &app.logPager).ID("log").Title("build.log").LineNumbers()
+func Pager(content string, state *PagerState) *pagerView {
+	content = strings.TrimSuffix(content, "\n")
+	texts := strings.Split(expandPagerTabs(content), "\n")
+	lines := make([][]StyledSegment, len(texts))
+	for i, text := range texts {
+		lines[i] = []StyledSegment{{Text: text, Style: NewStyle()}}
+	}
+	return newPagerView(lines, state)
+}
+
+func newPagerView(lines [][]StyledSegment, state *PagerState) *pagerView {
+	if state == nil {
+		state = &PagerState{}
+	}
+	return &pagerView{
+		lines:       lines,
+		texts:       segmentLines(lines),
+		state:       state,
+		statusStyle: NewStyle().WithReverse(),
+	}
+}
+
+// expandPagerTabs replaces tabs with spaces to the next multiple of 8
+// columns, as terminals show them.
+func expandPagerTabs(s string) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	var b strings.Builder
+	col := 0
+	for _, r := range s {
+		switch r {
+		case '\t':
+			n := 8 - col%8
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+		case '\n':
+			b.WriteRune(r)
+			col = 0
+		default:
+			b.WriteRune(r)
+			col += runewidth.RuneWidth(r)
+		}
+	}
+	return b.String()
+}
+
+// ID sets the focus ID, making the pager focusable with Tab and by
+// clicking.
+func (p *pagerView) ID(id string) *pagerView {
+	p.id = id
+	return p
+}
+
+// Title sets a name, like a file name, to show in the status line.
+func (p *pagerView) Title(title string) *pagerView {
+	p.title = title
+	return p
+}
+
+// LineNumbers shows the number of each line in a gutter.
+func (p *pagerView) LineNumbers() *pagerView {
+	p.lineNumbers = true
+	return p
+}
+
+// HideStatus hides the status line, leaving all the rows for content.
+func (p *pagerView) HideStatus() *pagerView {
+	p.hideStatus = true
+	return p
+}
+
+// StatusStyle sets the style of the status line. Default is reverse video.
+func (p *pagerView) StatusStyle(s Style) *pagerView {
+	p.statusStyle = s
+	return p
+}
+
+// Focusable interface implementation
+func (p *pagerView) FocusID() string {
+	return p.id
+}
+
+func (p *pagerView) IsFocused() bool {
+	return p.focused
+}
+
+func (p *pagerView) SetFocused(focused bool) {
+	p.focused = focused
+}
+
+func (p *pagerView) FocusBounds() image.Rectangle {
+	return p.bounds
+}
+
+func (p *pagerView) HandleKeyEvent(event KeyEvent) bool {
+	return p.state.HandleKey(event)
+}
+
+func (p *pagerView) flex() int {
+	return 1
+}
+
+func (p *pagerView) size(maxWidth, maxHeight int) (int, int) {
+	w, h := maxWidth, maxHeight
+	if w == 0 {
+		for _, text := range p.texts {
+			w = max(w, runewidth.StringWidth(text))
+		}
+		w += p.gutterWidth()
+	}
+	if h == 0 {
+		h = len(p.lines)
+		if !p.hideStatus {
+			h++
+		}
+	}
+	return w, h
+}
+
+// gutterWidth returns the width of the line numbers and the space after
+// them, or 0 when they are hidden.
+func (p *pagerView) gutterWidth() int {
+	if !p.lineNumbers {
+		return 0
+	}
+	return len(fmt.Sprint(len(p.lines))) + 1
+}
+
+func (p *pagerView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	p.bounds = ctx.AbsoluteBounds()
+	if fm := ctx.FocusManager(); fm != nil && p.id != "" {
+		fm.Register(p)
+	}
+
+	rows := height
+	if !p.hideStatus && height > 1 {
+		rows--
+	}
+	gutter := p.gutterWidth()
+	textW := max(1, width-gutter)
+
+	state := p.state
+	state.count, state.pageSize, state.width = len(p.lines), rows, textW
+	state.clamp()
+
+	search := &state.Search
+	if search.Query != "" {
+		pending := search.scrollPending
+		search.setMatches(findMatches(p.texts, search.Query, search.CaseSensitive))
+		if target, ok := search.revealLine(state.Top, rows); ok {
+			state.ScrollTo(target)
+		}
+		if m, ok := search.Current(); ok && pending && (m.Start < state.Left || m.End > state.Left+textW) {
+			state.Left = max(0, m.Start-textW/3)
+		}
+	} else {
+		search.setMatches(nil)
+	}
+
+	numberStyle := NewStyle().WithForeground(ColorBrightBlack)
+	for y := 0; y < rows; y++ {
+		index := state.Top + y
+		if index >= len(p.lines) {
+			ctx.PrintStyled(0, y, "~", numberStyle)
+			continue
+		}
+		if gutter > 0 {
+			ctx.PrintStyled(0, y, padLeft(index+1, gutter-1), numberStyle)
+		}
+		line := p.lines[index]
+		if search.Query != "" {
+			line = search.highlightSegments(line, index)
+		}
+		x := gutter
+		for _, seg := range skipColumns(line, state.Left) {
+			if x >= width {
+				break
+			}
+			text := truncateToWidth(seg.Text, width-x)
+			ctx.PrintStyled(x, y, text, seg.Style)
+			x += runewidth.StringWidth(text)
+		}
+	}
+
+	if rows < height {
+		ctx.FillStyled(0, rows, width, 1, ' ', p.statusStyle)
+		ctx.PrintTruncated(0, rows, p.statusText(), p.statusStyle)
+	}
+
+	interactiveRegistry.RegisterScroll(p.bounds, func(delta int) {
+		state.ScrollBy(delta * 3)
+	})
+}
+
+// statusText returns the status line: the search being typed, or the title,
+// the lines on screen, and how far through they are.
+func (p *pagerView) statusText() string {
+	state := p.state
+	if state.Search.Typing() {
+		return "/" + state.Search.Input() + "█"
+	}
+	var parts []string
+	if p.title != "" {
+		parts = append(parts, p.title)
+	}
+	if state.count > 0 {
+		last := min(state.count, state.Top+state.pageSize)
+		parts = append(parts, fmt.Sprintf("lines %d-%d/%d", state.Top+1, last, state.count))
+	}
+	if state.AtEnd() {
+		parts = append(parts, "(END)")
+	} else {
+		parts = append(parts, fmt.Sprintf("%d%%", state.Percent()))
+	}
+	if state.Left > 0 {
+		parts = append(parts, fmt.Sprintf("col %d", state.Left+1))
+	}
+	if state.Search.Query != "" {
+		parts = append(parts, "/"+state.Search.Query+" "+state.Search.Status())
+	}
+	return " " + strings.Join(parts, "  ")
+}
+
+// skipColumns returns segments without their first n display columns. A
+// wide character cut in half is replaced with a space.
+func skipColumns(segments []StyledSegment, n int) []StyledSegment {
+	if n <= 0 {
+		return segments
+	}
+	var result []StyledSegment
+	col := 0
+	for _, seg := range segments {
+		if col >= n {
+			result = append(result, seg)
+			continue
+		}
+		var b strings.Builder
+		for _, r := range seg.Text {
+			w := runewidth.RuneWidth(r)
+			switch {
+			case col >= n:
+				b.WriteRune(r)
+			case col+w > n:
+				b.WriteString(strings.Repeat(" ", col+w-n))
+			}
+			col += w
+		}
+		if b.Len() > 0 {
+			seg.Text = b.String()
+			result = append(result, seg)
+		}
+	}
+	return result
+}
+
+// viewLines renders view at the given width, as tall as it wants to be,
+// and returns its rows as styled text, for paging views that don't scroll
+// themselves.
+func viewLines(view View, width int) [][]StyledSegment {
+	_, height := view.size(width, 0)
+	if height <= 0 {
+		return nil
+	}
+	// Regions the view registers are offscreen, so they are dropped
+	mark := interactiveRegistry.mark()
+	defer interactiveRegistry.reset(mark)
+
+	terminal := NewTestTerminal(width, height, io.Discard)
+	frame, err := terminal.BeginFrame()
+	if err != nil {
+		return nil
+	}
+	view.size(width, height)
+	view.render(NewRenderContext(frame, 0))
+	terminal.EndFrame(frame)
+
+	lines := make([][]StyledSegment, height)
+	for y := range lines {
+		var segments []StyledSegment
+		var text strings.Builder
+		var style Style
+		flush := func() {
+			if text.Len() > 0 {
+				segments = append(segments, StyledSegment{Text: text.String(), Style: style})
+				text.Reset()
+			}
+		}
+		// Trailing blank cells are left out
+		end := width
+		for end > 0 {
+			if cell := terminal.GetCell(end-1, y); (cell.Char != ' ' && cell.Char != 0) || !cell.Style.IsEmpty() {
+				break
+			}
+			end--
+		}
+		for x := 0; x < end; x++ {
+			cell := terminal.GetCell(x, y)
+			if cell.Continuation {
+				continue
+			}
+			if cell.Style != style {
+				flush()
+				style = cell.Style
+			}
+			if cell.Char == 0 {
+				text.WriteRune(' ')
+			} else {
+				text.WriteRune(cell.Char)
+			}
+		}
+		flush()
+		lines[y] = segments
+	}
+	return lines
+}
+
+// pageApp runs a pager full screen for Page.
+type pageApp struct {
+	view  View // laid out at the screen width, unless it is a Pager
+	pager *pagerView
+}
+
+func (a *pageApp) View() View {
+	return a.pager
+}
+
+func (a *pageApp) HandleEvent(event Event) []Cmd {
+	switch e := event.(type) {
+	case ResizeEvent:
+		if a.view != nil {
+			a.pager.lines = viewLines(a.view, e.Width)
+			a.pager.texts = segmentLines(a.pager.lines)
+		}
+	case KeyEvent:
+		if !a.pager.state.Search.Typing() && (e.Rune == 'q' || e.Rune == 'Q' || e.Key == KeyCtrlC) && !e.Alt {
+			return []Cmd{Quit()}
+		}
+		a.pager.state.HandleKey(e)
+	}
+	return nil
+}
+
+// Page shows view full screen, a screen at a time, until the user presses
+// q, for a command line program's long output. It takes the terminal over
+// like Run, and takes the same options.
+//
+// A Pager is shown as it is, with sideways scrolling for long lines. Any
+// other view is laid out at the width of the terminal, as tall as it wants
+// to be, and paged a screen at a time with the same keys and search.
+//
+// Example:
+//
+//	err := tui.Page(tui.Pager(string(data), nil).Title(path).LineNumbers())
+//	err := tui.Page(tui.Markdown(readme, nil))
+func Page(view View, opts ...RunOption) error {
+	app := &pageApp{}
+	if pager, ok := view.(*pagerView); ok {
+		app.pager = pager
+	} else {
+		app.view = view
+		app.pager = newPagerView(nil, nil)
+	}
+	return Run(app, opts...)
+}
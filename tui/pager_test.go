@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestPagerState_HandleKey(t *testing.T) {
+	state := &PagerState{}
+	screen := SprintScreen(Pager(numberedLines(100), state), PrintConfig{Width: 20, Height: 11})
+	assert.Equal(t, "line 0", screen.Row(0))
+
+	tests := []struct {
+		key  KeyEvent
+		want int
+	}{
+		{KeyEvent{Rune: ' '}, 10},
+		{KeyEvent{Rune: 'j'}, 11},
+		{KeyEvent{Key: KeyArrowUp}, 10},
+		{KeyEvent{Rune: 'd'}, 15},
+		{KeyEvent{Key: KeyCtrlU, Ctrl: true}, 10},
+		{KeyEvent{Rune: 'b'}, 0},
+		{KeyEvent{Key: KeyCtrlF, Ctrl: true}, 10},
+		{KeyEvent{Rune: 'G'}, 90},
+		{KeyEvent{Rune: 'j'}, 90},
+		{KeyEvent{Rune: 'g'}, 0},
+		{KeyEvent{Rune: 'k'}, 0},
+		{KeyEvent{Key: KeyEnd}, 90},
+		{KeyEvent{Rune: 'v', Alt: true}, 80},
+	}
+	for _, tt := range tests {
+		assert.True(t, state.HandleKey(tt.key), "%+v", tt.key)
+		assert.Equal(t, tt.want, state.Top, "after %+v", tt.key)
+	}
+	assert.False(t, state.HandleKey(KeyEvent{Rune: 'q'}))
+}
+
+func TestPager_StatusAndLineNumbers(t *testing.T) {
+	state := &PagerState{}
+	view := Pager(numberedLines(100), state).Title("app.log").LineNumbers()
+	screen := SprintScreen(view, PrintConfig{Width: 40, Height: 5})
+	assert.Equal(t, "  1 line 0", screen.Row(0))
+	assert.Equal(t, "  4 line 3", screen.Row(3))
+	assert.Equal(t, " app.log  lines 1-4/100  4%", screen.Row(4))
+
+	state.ScrollTo(96)
+	screen = SprintScreen(view, PrintConfig{Width: 40, Height: 5})
+	assert.Equal(t, "100 line 99", screen.Row(3))
+	assert.Equal(t, " app.log  lines 97-100/100  (END)", screen.Row(4))
+}
+
+func TestPager_ShortContent(t *testing.T) {
+	screen := SprintScreen(Pager("one\ntwo\n", nil), PrintConfig{Width: 20, Height: 4})
+	assert.Equal(t, "one", screen.Row(0))
+	assert.Equal(t, "two", screen.Row(1))
+	assert.Equal(t, "~", screen.Row(2))
+	assert.Equal(t, " lines 1-2/2  (END)", screen.Row(3))
+}
+
+func TestPager_HorizontalScroll(t *testing.T) {
+	state := &PagerState{}
+	view := Pager("0123456789abcdefghij\n\tx", state).HideStatus()
+	screen := SprintScreen(view, PrintConfig{Width: 10, Height: 2})
+	assert.Equal(t, "0123456789", screen.Row(0))
+	assert.Equal(t, "        x", screen.Row(1))
+
+	state.HandleKey(KeyEvent{Key: KeyArrowRight})
+	screen = SprintScreen(view, PrintConfig{Width: 10, Height: 2})
+	assert.Equal(t, "56789abcde", screen.Row(0))
+	assert.Equal(t, "   x", screen.Row(1))
+
+	state.HandleKey(KeyEvent{Key: KeyArrowLeft})
+	state.HandleKey(KeyEvent{Key: KeyArrowLeft})
+	assert.Equal(t, 0, state.Left)
+}
+
+func TestPager_Search(t *testing.T) {
+	state := &PagerState{}
+	view := Pager(numberedLines(100), state)
+	SprintScreen(view, PrintConfig{Width: 30, Height: 6})
+
+	for _, key := range []KeyEvent{{Rune: '/'}, {Rune: '5'}, {Rune: '0'}} {
+		assert.True(t, state.HandleKey(key))
+	}
+	screen := SprintScreen(view, PrintConfig{Width: 30, Height: 6})
+	assert.Equal(t, "/50█", screen.Row(5))
+
+	// Enter jumps to the first match, and n to the next
+	state.HandleKey(KeyEvent{Key: KeyEnter})
+	screen = SprintScreen(view, PrintConfig{Width: 30, Height: 6})
+	assert.Contains(t, screen.Text(), "line 50")
+	assert.Contains(t, screen.Row(5), "/50 1/1")
+	assert.Equal(t, 1, state.Search.Count())
+}
+
+func TestPager_SkipColumnsWideCharacters(t *testing.T) {
+	segments := []StyledSegment{{Text: "ab"}, {Text: "世界"}}
+	assert.Equal(t, []StyledSegment{{Text: "b"}, {Text: "世界"}}, skipColumns(segments, 1))
+	assert.Equal(t, []StyledSegment{{Text: " 界"}}, skipColumns(segments, 3))
+}
+
+func TestPage_ViewLines(t *testing.T) {
+	view := Stack(Text("title").Bold(), Text("body"))
+	lines := viewLines(view, 20)
+	assert.Equal(t, 2, len(lines))
+	assert.Equal(t, "title", plainText(lines[0]))
+	assert.True(t, lines[0][0].Style.Bold)
+	assert.Equal(t, "body", plainText(lines[1]))
+}
+
+func TestPage_App(t *testing.T) {
+	app := &pageApp{view: Stack(Text("a"), Text("b")), pager: newPagerView(nil, nil)}
+	app.HandleEvent(ResizeEvent{Width: 20, Height: 10})
+	assert.Equal(t, []string{"a", "b"}, app.pager.texts)
+
+	assert.Equal(t, 0, len(app.HandleEvent(KeyEvent{Rune: 'j'})))
+	assert.Equal(t, 1, len(app.HandleEvent(KeyEvent{Rune: 'q'})))
+
+	// q is part of a search query while one is typed
+	app.HandleEvent(KeyEvent{Rune: '/'})
+	assert.Equal(t, 0, len(app.HandleEvent(KeyEvent{Rune: 'q'})))
+}
@@ -0,0 +1,606 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"unicode"
+)
+
+// ErrPaneNotFound is returned when a PaneManager operation names a pane that
+// doesn't exist.
+var ErrPaneNotFound = errors.New("pane not found")
+
+// PaneDirection is a direction within a pane layout, used to place new
+// splits and to move focus and swap panes.
+type PaneDirection int
+
+const (
+	PaneLeft PaneDirection = iota
+	PaneRight
+	PaneUp
+	PaneDown
+)
+
+// String returns the direction name.
+func (d PaneDirection) String() string {
+	switch d {
+	case PaneLeft:
+		return "left"
+	case PaneRight:
+		return "right"
+	case PaneUp:
+		return "up"
+	case PaneDown:
+		return "down"
+	}
+	return fmt.Sprintf("PaneDirection(%d)", int(d))
+}
+
+// paneNode is a node in the split tree: either a leaf holding a pane ID or a
+// split with exactly two children.
+type paneNode struct {
+	id       string
+	vertical bool    // split: children stacked top to bottom
+	ratio    float64 // split: share of the space given to the first child
+	children [2]*paneNode
+	parent   *paneNode
+}
+
+func (n *paneNode) isLeaf() bool {
+	return n.children[0] == nil
+}
+
+// PaneManager arranges an application's regions as a tree of splits, like
+// the windows of tmux or vim, and tracks which pane has keyboard focus.
+// Panes can be split, closed, swapped, and zoomed to temporarily fill the
+// whole area.
+//
+// PaneManager is plain application state identified by pane IDs: keep it in
+// your app struct, pass key events to HandleKey, and render it with PaneView,
+// which asks you for the view of each pane. Route the remaining key events
+// to the focused pane.
+//
+// Example:
+//
+//	panes := tui.NewPaneManager("files")
+//	panes.Split("files", "diff", tui.PaneRight)
+//	panes.Split("diff", "log", tui.PaneDown)
+//
+//	func (a *App) HandleEvent(e tui.Event) []tui.Cmd {
+//	    if key, ok := e.(tui.KeyEvent); ok && a.panes.HandleKey(key) {
+//	        return nil
+//	    }
+//	    // ... send the event to a.panes.Focused()
+//	}
+//
+//	func (a *App) View() tui.View {
+//	    return tui.PaneView(a.panes, func(id string) tui.View {
+//	        return a.views[id]
+//	    })
+//	}
+type PaneManager struct {
+	root    *paneNode
+	focused string
+	zoomed  bool
+	onFocus func(id string)
+
+	// rects are the pane rectangles from the last render, used to find
+	// neighbors for directional focus and swaps.
+	rects map[string]image.Rectangle
+}
+
+// NewPaneManager creates a layout with a single pane, which has focus.
+func NewPaneManager(id string) *PaneManager {
+	return &PaneManager{
+		root:    &paneNode{id: id},
+		focused: id,
+	}
+}
+
+// Split divides pane target in two, placing a new pane id on the given side
+// of it. The two panes share the space equally. Focus doesn't change.
+func (m *PaneManager) Split(target, id string, dir PaneDirection) error {
+	node := m.find(target)
+	if node == nil {
+		return fmt.Errorf("split %q: %w", target, ErrPaneNotFound)
+	}
+	if m.find(id) != nil {
+		return fmt.Errorf("split %q: pane %q already exists", target, id)
+	}
+
+	existing := &paneNode{id: node.id, parent: node}
+	added := &paneNode{id: id, parent: node}
+	node.id = ""
+	node.vertical = dir == PaneUp || dir == PaneDown
+	node.ratio = 0.5
+	if dir == PaneLeft || dir == PaneUp {
+		node.children = [2]*paneNode{added, existing}
+	} else {
+		node.children = [2]*paneNode{existing, added}
+	}
+	m.rects = nil
+	return nil
+}
+
+// Close removes a pane, giving its space to its sibling. If the pane had
+// focus, focus moves to the sibling. The last remaining pane can't be
+// closed; Close returns false for it and for unknown IDs.
+func (m *PaneManager) Close(id string) bool {
+	node := m.find(id)
+	if node == nil || node.parent == nil {
+		return false
+	}
+	parent := node.parent
+	sibling := parent.children[0]
+	if sibling == node {
+		sibling = parent.children[1]
+	}
+
+	// Replace the parent split with the sibling subtree
+	*parent = paneNode{
+		id:       sibling.id,
+		vertical: sibling.vertical,
+		ratio:    sibling.ratio,
+		children: sibling.children,
+		parent:   parent.parent,
+	}
+	for _, child := range parent.children {
+		if child != nil {
+			child.parent = parent
+		}
+	}
+
+	if m.focused == id {
+		m.zoomed = false
+		m.setFocus(firstLeaf(parent).id)
+	}
+	m.rects = nil
+	return true
+}
+
+// Panes returns the pane IDs in layout order: left to right and top to
+// bottom.
+func (m *PaneManager) Panes() []string {
+	var ids []string
+	var walk func(n *paneNode)
+	walk = func(n *paneNode) {
+		if n.isLeaf() {
+			ids = append(ids, n.id)
+			return
+		}
+		walk(n.children[0])
+		walk(n.children[1])
+	}
+	walk(m.root)
+	return ids
+}
+
+// Has reports whether a pane with the given ID exists.
+func (m *PaneManager) Has(id string) bool {
+	return m.find(id) != nil
+}
+
+// Focused returns the ID of the focused pane.
+func (m *PaneManager) Focused() string {
+	return m.focused
+}
+
+// Focus gives a pane keyboard focus. Focusing another pane ends zoom. It
+// returns false if no pane has that ID.
+func (m *PaneManager) Focus(id string) bool {
+	if m.find(id) == nil {
+		return false
+	}
+	if id != m.focused {
+		m.zoomed = false
+		m.setFocus(id)
+	}
+	return true
+}
+
+// OnFocusChange sets a callback invoked with the newly focused pane's ID
+// whenever focus moves, for example to move the FocusManager to a widget
+// inside that pane.
+func (m *PaneManager) OnFocusChange(fn func(id string)) {
+	m.onFocus = fn
+}
+
+// FocusDirection moves focus to the nearest pane on the given side of the
+// focused pane. It returns false if there is none.
+func (m *PaneManager) FocusDirection(dir PaneDirection) bool {
+	neighbor := m.neighbor(dir)
+	if neighbor == "" {
+		return false
+	}
+	return m.Focus(neighbor)
+}
+
+// FocusNext moves focus to the next pane in layout order, wrapping around.
+func (m *PaneManager) FocusNext() {
+	m.cycle(1)
+}
+
+// FocusPrev moves focus to the previous pane in layout order, wrapping
+// around.
+func (m *PaneManager) FocusPrev() {
+	m.cycle(-1)
+}
+
+func (m *PaneManager) cycle(delta int) {
+	ids := m.Panes()
+	for i, id := range ids {
+		if id == m.focused {
+			m.Focus(ids[(i+delta+len(ids))%len(ids)])
+			return
+		}
+	}
+}
+
+// Zoomed reports whether the focused pane is zoomed to fill the whole area.
+func (m *PaneManager) Zoomed() bool {
+	return m.zoomed
+}
+
+// SetZoomed zooms or restores the focused pane. Zooming a layout with a
+// single pane has no effect.
+func (m *PaneManager) SetZoomed(zoomed bool) {
+	m.zoomed = zoomed && !m.root.isLeaf()
+}
+
+// ToggleZoom zooms the focused pane, or restores the layout if it is zoomed.
+func (m *PaneManager) ToggleZoom() {
+	m.SetZoomed(!m.zoomed)
+}
+
+// Swap exchanges the positions of two panes. Focus stays with the same pane
+// ID, so it moves along with the pane.
+func (m *PaneManager) Swap(a, b string) error {
+	na, nb := m.find(a), m.find(b)
+	if na == nil {
+		return fmt.Errorf("swap %q: %w", a, ErrPaneNotFound)
+	}
+	if nb == nil {
+		return fmt.Errorf("swap %q: %w", b, ErrPaneNotFound)
+	}
+	na.id, nb.id = nb.id, na.id
+	if m.rects != nil {
+		m.rects[a], m.rects[b] = m.rects[b], m.rects[a]
+	}
+	return nil
+}
+
+// SwapDirection swaps the focused pane with its nearest neighbor on the
+// given side. It returns false if there is none.
+func (m *PaneManager) SwapDirection(dir PaneDirection) bool {
+	neighbor := m.neighbor(dir)
+	if neighbor == "" {
+		return false
+	}
+	m.zoomed = false
+	return m.Swap(m.focused, neighbor) == nil
+}
+
+// Resize grows (positive delta) or shrinks the pane's share of its split
+// along the given direction, by a fraction of the split's size. For
+// example Resize(id, PaneRight, 0.1) moves the edge between the pane and
+// its right-hand neighbor 10% to the right. It returns false if the pane has
+// no edge on that side.
+func (m *PaneManager) Resize(id string, dir PaneDirection, delta float64) bool {
+	node := m.find(id)
+	if node == nil {
+		return false
+	}
+	vertical := dir == PaneUp || dir == PaneDown
+	edgeAfter := dir == PaneRight || dir == PaneDown
+	for child := node; child.parent != nil; child = child.parent {
+		split := child.parent
+		isFirst := split.children[0] == child
+		if split.vertical != vertical || isFirst != edgeAfter {
+			continue
+		}
+		if !isFirst {
+			delta = -delta
+		}
+		split.ratio = min(max(split.ratio+delta, 0.1), 0.9)
+		m.rects = nil
+		return true
+	}
+	return false
+}
+
+// HandleKey handles the pane management key bindings and reports whether
+// the event was consumed:
+//
+//   - Ctrl+h/j/k/l or Alt+arrow keys move focus left, down, up, or right
+//   - Alt+Shift+arrow keys or Alt+H/J/K/L swap the focused pane with its
+//     neighbor in that direction
+//   - Alt+z toggles zoom of the focused pane
+//
+// Most terminals send Ctrl+h as Backspace and Ctrl+j as Enter unless they
+// report modifiers explicitly (the kitty keyboard protocol), so the Alt+arrow
+// bindings are the portable choice.
+func (m *PaneManager) HandleKey(event KeyEvent) bool {
+	if event.Alt && event.Key == KeyUnknown {
+		if event.Rune == 'z' {
+			m.ToggleZoom()
+			return true
+		}
+		dir, ok := vimDirection(unicode.ToLower(event.Rune))
+		if !ok || !unicode.IsUpper(event.Rune) {
+			return false
+		}
+		m.SwapDirection(dir)
+		return true
+	}
+
+	var dir PaneDirection
+	switch {
+	case event.Key == KeyCtrlH || (event.Key == KeyBackspace && event.Ctrl):
+		dir = PaneLeft
+	case event.Key == KeyCtrlJ || (event.Key == KeyEnter && event.Ctrl):
+		dir = PaneDown
+	case event.Key == KeyCtrlK:
+		dir = PaneUp
+	case event.Key == KeyCtrlL:
+		dir = PaneRight
+	case event.Alt && event.Key == KeyArrowLeft:
+		dir = PaneLeft
+	case event.Alt && event.Key == KeyArrowDown:
+		dir = PaneDown
+	case event.Alt && event.Key == KeyArrowUp:
+		dir = PaneUp
+	case event.Alt && event.Key == KeyArrowRight:
+		dir = PaneRight
+	default:
+		return false
+	}
+	if event.Alt && event.Shift {
+		m.SwapDirection(dir)
+	} else {
+		m.FocusDirection(dir)
+	}
+	return true
+}
+
+func vimDirection(r rune) (PaneDirection, bool) {
+	switch r {
+	case 'h':
+		return PaneLeft, true
+	case 'j':
+		return PaneDown, true
+	case 'k':
+		return PaneUp, true
+	case 'l':
+		return PaneRight, true
+	}
+	return 0, false
+}
+
+func (m *PaneManager) setFocus(id string) {
+	m.focused = id
+	if m.onFocus != nil {
+		m.onFocus(id)
+	}
+}
+
+func (m *PaneManager) find(id string) *paneNode {
+	var walk func(n *paneNode) *paneNode
+	walk = func(n *paneNode) *paneNode {
+		if n.isLeaf() {
+			if n.id == id {
+				return n
+			}
+			return nil
+		}
+		if found := walk(n.children[0]); found != nil {
+			return found
+		}
+		return walk(n.children[1])
+	}
+	return walk(m.root)
+}
+
+func firstLeaf(n *paneNode) *paneNode {
+	for !n.isLeaf() {
+		n = n.children[0]
+	}
+	return n
+}
+
+// Layout returns the rectangle of each pane when the layout fills bounds,
+// ignoring zoom.
+func (m *PaneManager) Layout(bounds image.Rectangle) map[string]image.Rectangle {
+	rects := make(map[string]image.Rectangle)
+	var walk func(n *paneNode, r image.Rectangle)
+	walk = func(n *paneNode, r image.Rectangle) {
+		if n.isLeaf() {
+			rects[n.id] = r
+			return
+		}
+		first, second := r, r
+		if n.vertical {
+			split := r.Min.Y + int(float64(r.Dy())*n.ratio+0.5)
+			first.Max.Y, second.Min.Y = split, split
+		} else {
+			split := r.Min.X + int(float64(r.Dx())*n.ratio+0.5)
+			first.Max.X, second.Min.X = split, split
+		}
+		walk(n.children[0], first)
+		walk(n.children[1], second)
+	}
+	walk(m.root, bounds)
+	return rects
+}
+
+// neighbor returns the nearest pane on the given side of the focused pane,
+// preferring the one that overlaps it most along the other axis.
+func (m *PaneManager) neighbor(dir PaneDirection) string {
+	rects := m.rects
+	if rects == nil {
+		// Not rendered yet; any size preserves the geometry
+		rects = m.Layout(image.Rect(0, 0, 1000, 1000))
+	}
+	cur, ok := rects[m.focused]
+	if !ok {
+		return ""
+	}
+
+	best := ""
+	bestDist, bestOverlap := 0, 0
+	for id, r := range rects {
+		if id == m.focused {
+			continue
+		}
+		var dist, overlap int
+		switch dir {
+		case PaneLeft:
+			dist = cur.Min.X - r.Max.X
+			overlap = min(cur.Max.Y, r.Max.Y) - max(cur.Min.Y, r.Min.Y)
+		case PaneRight:
+			dist = r.Min.X - cur.Max.X
+			overlap = min(cur.Max.Y, r.Max.Y) - max(cur.Min.Y, r.Min.Y)
+		case PaneUp:
+			dist = cur.Min.Y - r.Max.Y
+			overlap = min(cur.Max.X, r.Max.X) - max(cur.Min.X, r.Min.X)
+		case PaneDown:
+			dist = r.Min.Y - cur.Max.Y
+			overlap = min(cur.Max.X, r.Max.X) - max(cur.Min.X, r.Min.X)
+		}
+		if dist < 0 || overlap <= 0 {
+			continue
+		}
+		if best == "" || dist < bestDist || (dist == bestDist && overlap > bestOverlap) ||
+			(dist == bestDist && overlap == bestOverlap && id < best) {
+			best, bestDist, bestOverlap = id, dist, overlap
+		}
+	}
+	return best
+}
+
+// paneView renders a PaneManager layout.
+type paneView struct {
+	manager    *PaneManager
+	content    func(id string) View
+	border     *BorderStyle
+	borderFg   Color
+	focusFg    Color
+	titleFn    func(id string) string
+	clickFocus bool
+}
+
+// PaneView renders the panes of a PaneManager, filling the available space.
+// content returns the view for each pane ID. Each pane gets a border titled
+// with its ID, highlighted on the focused pane; when the manager is zoomed,
+// only the focused pane is drawn. Clicking a pane focuses it.
+//
+// Example:
+//
+//	tui.PaneView(app.panes, app.paneContent).
+//	    Title(func(id string) string { return strings.ToUpper(id) }).
+//	    FocusBorderFg(tui.ColorGreen)
+func PaneView(manager *PaneManager, content func(id string) View) *paneView {
+	return &paneView{
+		manager:    manager,
+		content:    content,
+		border:     &RoundedBorder,
+		borderFg:   ColorBrightBlack,
+		focusFg:    ColorCyan,
+		titleFn:    func(id string) string { return id },
+		clickFocus: true,
+	}
+}
+
+// Border sets the border drawn around each pane. Pass nil to draw panes
+// without borders.
+func (v *paneView) Border(border *BorderStyle) *paneView {
+	v.border = border
+	return v
+}
+
+// BorderFg sets the border color of unfocused panes.
+func (v *paneView) BorderFg(c Color) *paneView {
+	v.borderFg = c
+	return v
+}
+
+// FocusBorderFg sets the border color of the focused pane.
+func (v *paneView) FocusBorderFg(c Color) *paneView {
+	v.focusFg = c
+	return v
+}
+
+// Title sets the function that returns each pane's border title. Return an
+// empty string for no title.
+func (v *paneView) Title(fn func(id string) string) *paneView {
+	v.titleFn = fn
+	return v
+}
+
+// ClickToFocus sets whether clicking a pane focuses it. Defaults to true.
+func (v *paneView) ClickToFocus(enabled bool) *paneView {
+	v.clickFocus = enabled
+	return v
+}
+
+func (v *paneView) size(maxWidth, maxHeight int) (int, int) {
+	return maxWidth, maxHeight
+}
+
+func (v *paneView) flex() int {
+	return 1
+}
+
+func (v *paneView) render(ctx *RenderContext) {
+	w, h := ctx.Size()
+	if w == 0 || h == 0 {
+		return
+	}
+	m := v.manager
+	m.rects = m.Layout(image.Rect(0, 0, w, h))
+
+	rects := m.rects
+	if m.zoomed {
+		rects = map[string]image.Rectangle{m.focused: image.Rect(0, 0, w, h)}
+	}
+
+	for _, id := range m.Panes() {
+		r, ok := rects[id]
+		if !ok || r.Empty() {
+			continue
+		}
+		focused := id == m.focused
+		var view View = Empty()
+		if v.content != nil {
+			if content := v.content(id); content != nil {
+				view = content
+			}
+		}
+		if v.border != nil {
+			title := v.titleFn(id)
+			if focused && m.zoomed {
+				title += " [zoom]"
+			}
+			fg := v.borderFg
+			border := v.border
+			titleStyle := NewStyle().WithForeground(v.borderFg)
+			if focused {
+				fg = v.focusFg
+				titleStyle = NewStyle().WithForeground(v.focusFg).WithBold()
+				border, title = focusedBorder(ctx, border), focusedTitle(ctx, title)
+			}
+			view = Bordered(view).Border(border).BorderFg(fg).Title(title).TitleStyle(titleStyle)
+		}
+
+		paneCtx := ctx.SubContext(r)
+		view.render(paneCtx)
+
+		// Registered after the pane's content so clickable content wins
+		if v.clickFocus && !focused {
+			id := id
+			interactiveRegistry.RegisterRegion(paneCtx.AbsoluteBounds(), func() {
+				m.Focus(id)
+			})
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+// newTestPanes builds the layout
+//
+//	+-------+-------+
+//	|       | diff  |
+//	| files +-------+
+//	|       | log   |
+//	+-------+-------+
+func newTestPanes(t *testing.T) *PaneManager {
+	m := NewPaneManager("files")
+	assert.NoError(t, m.Split("files", "diff", PaneRight))
+	assert.NoError(t, m.Split("diff", "log", PaneDown))
+	return m
+}
+
+func TestPaneManager_Split(t *testing.T) {
+	m := newTestPanes(t)
+	assert.Equal(t, []string{"files", "diff", "log"}, m.Panes())
+	assert.Equal(t, "files", m.Focused())
+
+	rects := m.Layout(image.Rect(0, 0, 80, 20))
+	assert.Equal(t, image.Rect(0, 0, 40, 20), rects["files"])
+	assert.Equal(t, image.Rect(40, 0, 80, 10), rects["diff"])
+	assert.Equal(t, image.Rect(40, 10, 80, 20), rects["log"])
+
+	assert.NoError(t, m.Split("files", "tree", PaneLeft))
+	assert.Equal(t, []string{"tree", "files", "diff", "log"}, m.Panes())
+
+	err := m.Split("missing", "x", PaneRight)
+	assert.True(t, errors.Is(err, ErrPaneNotFound))
+	assert.Error(t, m.Split("files", "diff", PaneRight))
+}
+
+func TestPaneManager_FocusDirection(t *testing.T) {
+	m := newTestPanes(t)
+
+	assert.True(t, m.FocusDirection(PaneRight))
+	assert.Equal(t, "diff", m.Focused())
+	assert.True(t, m.FocusDirection(PaneDown))
+	assert.Equal(t, "log", m.Focused())
+	assert.False(t, m.FocusDirection(PaneDown))
+	assert.True(t, m.FocusDirection(PaneLeft))
+	assert.Equal(t, "files", m.Focused())
+	assert.False(t, m.FocusDirection(PaneLeft))
+}
+
+func TestPaneManager_HandleKey(t *testing.T) {
+	m := newTestPanes(t)
+	var changes []string
+	m.OnFocusChange(func(id string) { changes = append(changes, id) })
+
+	assert.True(t, m.HandleKey(KeyEvent{Key: KeyCtrlL, Ctrl: true}))
+	assert.True(t, m.HandleKey(KeyEvent{Key: KeyCtrlJ, Ctrl: true}))
+	assert.Equal(t, "log", m.Focused())
+	assert.True(t, m.HandleKey(KeyEvent{Key: KeyArrowUp, Alt: true}))
+	assert.Equal(t, "diff", m.Focused())
+	// Ctrl+h as reported by the kitty keyboard protocol
+	assert.True(t, m.HandleKey(KeyEvent{Key: KeyBackspace, Ctrl: true}))
+	assert.Equal(t, "files", m.Focused())
+	assert.Equal(t, []string{"diff", "log", "diff", "files"}, changes)
+
+	assert.False(t, m.HandleKey(KeyEvent{Key: KeyBackspace}))
+	assert.False(t, m.HandleKey(KeyEvent{Rune: 'h'}))
+	assert.False(t, m.HandleKey(KeyEvent{Rune: 'x', Alt: true}))
+}
+
+func TestPaneManager_Zoom(t *testing.T) {
+	m := newTestPanes(t)
+	m.Focus("diff")
+	assert.True(t, m.HandleKey(KeyEvent{Rune: 'z', Alt: true}))
+	assert.True(t, m.Zoomed())
+
+	screen := SprintScreen(PaneView(m, func(id string) View { return Text("%s body", id) }), PrintConfig{Width: 30, Height: 5})
+	assert.Equal(t, "╭─diff [zoom]────────────────╮", screen.Row(0))
+	assert.Equal(t, "│diff body                   │", screen.Row(1))
+
+	// Moving focus restores the layout
+	m.FocusDirection(PaneLeft)
+	assert.False(t, m.Zoomed())
+	assert.Equal(t, "files", m.Focused())
+
+	single := NewPaneManager("only")
+	single.ToggleZoom()
+	assert.False(t, single.Zoomed())
+}
+
+func TestPaneManager_Swap(t *testing.T) {
+	m := newTestPanes(t)
+	assert.True(t, m.HandleKey(KeyEvent{Rune: 'L', Alt: true}))
+	assert.Equal(t, []string{"diff", "files", "log"}, m.Panes())
+	assert.Equal(t, "files", m.Focused())
+
+	assert.True(t, m.HandleKey(KeyEvent{Key: KeyArrowDown, Alt: true, Shift: true}))
+	assert.Equal(t, []string{"diff", "log", "files"}, m.Panes())
+
+	err := m.Swap("files", "missing")
+	assert.True(t, errors.Is(err, ErrPaneNotFound))
+}
+
+func TestPaneManager_Close(t *testing.T) {
+	m := newTestPanes(t)
+	m.Focus("diff")
+	assert.True(t, m.Close("diff"))
+	assert.Equal(t, []string{"files", "log"}, m.Panes())
+	assert.Equal(t, "log", m.Focused())
+
+	rects := m.Layout(image.Rect(0, 0, 80, 20))
+	assert.Equal(t, image.Rect(40, 0, 80, 20), rects["log"])
+
+	assert.True(t, m.Close("files"))
+	assert.False(t, m.Close("log"))
+	assert.False(t, m.Close("missing"))
+	assert.Equal(t, []string{"log"}, m.Panes())
+}
+
+func TestPaneManager_Resize(t *testing.T) {
+	m := newTestPanes(t)
+	assert.True(t, m.Resize("files", PaneRight, 0.25))
+	rects := m.Layout(image.Rect(0, 0, 80, 20))
+	assert.Equal(t, 60, rects["files"].Dx())
+
+	// log's right edge is the screen edge
+	assert.False(t, m.Resize("log", PaneRight, 0.1))
+	assert.True(t, m.Resize("log", PaneUp, 0.2))
+	rects = m.Layout(image.Rect(0, 0, 80, 20))
+	assert.Equal(t, 14, rects["log"].Dy())
+}
+
+func TestPaneView_Render(t *testing.T) {
+	m := newTestPanes(t)
+	view := PaneView(m, func(id string) View { return Text("%s", id) })
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 6})
+
+	assert.Equal(t, "╭─files──╮╭─diff───╮", screen.Row(0))
+	assert.Equal(t, "│files   ││diff    │", screen.Row(1))
+	assert.Equal(t, "│        │╰────────╯", screen.Row(2))
+	assert.Equal(t, "│        │╭─log────╮", screen.Row(3))
+	assert.Equal(t, "│        ││log     │", screen.Row(4))
+	assert.Equal(t, uint8(ColorCyan), screen.Cell(0, 0).Style.Foreground.Value)
+	assert.Equal(t, uint8(ColorBrightBlack), screen.Cell(10, 0).Style.Foreground.Value)
+}
+
+func TestPaneView_ClickToFocus(t *testing.T) {
+	interactiveRegistry.Clear()
+	defer interactiveRegistry.Clear()
+
+	m := newTestPanes(t)
+	SprintScreen(PaneView(m, func(id string) View { return Text("%s", id) }), PrintConfig{Width: 20, Height: 6})
+
+	assert.True(t, interactiveRegistry.HandleClick(15, 4))
+	assert.Equal(t, "log", m.Focused())
+}
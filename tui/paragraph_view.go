@@ -0,0 +1,408 @@
+package tui
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Hyphenation controls how a Paragraph marks words broken across lines.
+type Hyphenation int
+
+const (
+	// HyphenManual breaks words at soft hyphens (U+00AD) in the text,
+	// showing a hyphen at the end of the line. This is the default.
+	HyphenManual Hyphenation = iota
+
+	// HyphenNone ignores soft hyphens. Words longer than a line are still
+	// broken, without a mark.
+	HyphenNone
+
+	// HyphenAuto also hyphenates words that don't fit in the space left on
+	// a line, filling lines more evenly at the cost of more broken words.
+	HyphenAuto
+)
+
+const softHyphen = "\u00ad"
+
+// paragraphView displays styled spans wrapped to the available width.
+type paragraphView struct {
+	spans      []StyledSegment
+	style      Style
+	align      Alignment
+	hyphens    Hyphenation
+	flexFactor int
+}
+
+// Paragraph creates a view that wraps styled spans of text to the available
+// width. Unlike Text(...).Wrap(), wrapping is Unicode-aware: it measures
+// grapheme clusters, so wide CJK characters and emoji take two cells and
+// combining marks none, and it can break between CJK characters, after
+// hyphens in words like "well-known", and at soft hyphens. Words longer than
+// a line are broken rather than overflowing. Newlines start new lines and
+// leading spaces are kept as indentation.
+//
+// Each span keeps its own style; a span's Hyperlink makes its text
+// clickable. Use Span to build spans, or ParseMarkup to get them from
+// markup.
+//
+// Example:
+//
+//	tui.Paragraph(
+//	    tui.Span("Warning: ", tui.NewStyle().WithBold().WithForeground(tui.ColorYellow)),
+//	    tui.Span(message, tui.NewStyle()),
+//	).Hyphenation(tui.HyphenAuto)
+func Paragraph(spans ...StyledSegment) *paragraphView {
+	return &paragraphView{
+		spans: spans,
+		style: NewStyle(),
+	}
+}
+
+// WrappedText creates a Paragraph of plain text with optional Printf-style
+// formatting, styled with the paragraph's own style methods.
+//
+// Example:
+//
+//	tui.WrappedText("%s", msg.Content).Fg(tui.ColorCyan)
+func WrappedText(format string, args ...any) *paragraphView {
+	content := format
+	if len(args) > 0 {
+		content = fmt.Sprintf(format, args...)
+	}
+	return Paragraph(StyledSegment{Text: content})
+}
+
+// Span returns a styled span for a Paragraph.
+func Span(text string, style Style) StyledSegment {
+	return StyledSegment{Text: text, Style: style}
+}
+
+// Fg sets the foreground color of text whose span doesn't set one.
+func (p *paragraphView) Fg(c Color) *paragraphView {
+	p.style = p.style.WithForeground(c)
+	return p
+}
+
+// Bg sets the background color of text whose span doesn't set one.
+func (p *paragraphView) Bg(c Color) *paragraphView {
+	p.style = p.style.WithBackground(c)
+	return p
+}
+
+// Bold makes all text bold.
+func (p *paragraphView) Bold() *paragraphView {
+	p.style = p.style.WithBold()
+	return p
+}
+
+// Italic makes all text italic.
+func (p *paragraphView) Italic() *paragraphView {
+	p.style = p.style.WithItalic()
+	return p
+}
+
+// Dim makes all text dim.
+func (p *paragraphView) Dim() *paragraphView {
+	p.style = p.style.WithDim()
+	return p
+}
+
+// Style sets the base style that span styles are layered over.
+func (p *paragraphView) Style(s Style) *paragraphView {
+	p.style = s
+	return p
+}
+
+// Align sets the alignment of each line (left, center, or right).
+func (p *paragraphView) Align(a Alignment) *paragraphView {
+	p.align = a
+	return p
+}
+
+// Center is a shorthand for Align(AlignCenter).
+func (p *paragraphView) Center() *paragraphView {
+	p.align = AlignCenter
+	return p
+}
+
+// Right is a shorthand for Align(AlignRight).
+func (p *paragraphView) Right() *paragraphView {
+	p.align = AlignRight
+	return p
+}
+
+// Hyphenation sets how words broken across lines are marked.
+func (p *paragraphView) Hyphenation(h Hyphenation) *paragraphView {
+	p.hyphens = h
+	return p
+}
+
+// Flex sets the flex factor for this view in flex layouts.
+func (p *paragraphView) Flex(factor int) *paragraphView {
+	p.flexFactor = factor
+	return p
+}
+
+func (p *paragraphView) flex() int {
+	return p.flexFactor
+}
+
+func (p *paragraphView) size(maxWidth, maxHeight int) (int, int) {
+	lines := p.layout(maxWidth)
+	w := 0
+	for _, line := range lines {
+		w = max(w, lineWidth(line))
+	}
+	h := len(lines)
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	if maxHeight > 0 && h > maxHeight {
+		h = maxHeight
+	}
+	return w, h
+}
+
+func (p *paragraphView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	for y, line := range p.layout(width) {
+		if y >= height {
+			break
+		}
+		x := 0
+		switch p.align {
+		case AlignCenter:
+			x = (width - lineWidth(line)) / 2
+		case AlignRight:
+			x = width - lineWidth(line)
+		}
+		for _, c := range line {
+			ctx.SetCell(x, y, c.base, c.style)
+			x += c.width
+		}
+	}
+}
+
+// cluster is a grapheme cluster: what the user sees as one character.
+type cluster struct {
+	text  string
+	base  rune // rune drawn in the cell
+	width int
+	style Style
+}
+
+// paraWord is a run of clusters that can't be broken at a line end, except
+// when it is longer than a line.
+type paraWord struct {
+	clusters []cluster
+	glue     []cluster // spaces after the word, dropped at a line break
+	hyphen   bool      // ends at a soft hyphen
+	newline  bool      // followed by a hard line break
+}
+
+// words splits the spans into words at break opportunities.
+func (p *paragraphView) words() []paraWord {
+	var words []paraWord
+	var cur paraWord
+	finish := func() {
+		words = append(words, cur)
+		cur = paraWord{}
+	}
+
+	for _, span := range p.spans {
+		style := layerStyle(p.style, span.Style)
+		if span.Hyperlink != nil {
+			style = style.WithURL(span.Hyperlink.URL)
+		}
+		g := uniseg.NewGraphemes(span.Text)
+		for g.Next() {
+			c := newCluster(g.Str(), style)
+			switch {
+			case c.text == "\n" || c.text == "\r\n":
+				cur.newline = true
+				finish()
+			case c.text == "\r":
+			case c.text == softHyphen:
+				if p.hyphens != HyphenNone && len(cur.clusters) > 0 && len(cur.glue) == 0 {
+					cur.hyphen = true
+					finish()
+				}
+			case c.text == " ":
+				cur.glue = append(cur.glue, c)
+			case c.text == "\t":
+				space := cluster{text: " ", base: ' ', width: 1, style: style}
+				cur.glue = append(cur.glue, space, space, space, space)
+			case c.width == 0:
+				// A stray zero-width character; it can't be drawn in a cell
+			default:
+				if len(cur.glue) > 0 {
+					finish()
+				}
+				if c.width == 2 && !isClosingPunct(c.base) && len(cur.clusters) > 0 {
+					// Lines can break before wide characters such as CJK
+					finish()
+				}
+				if isClosingPunct(c.base) && len(cur.clusters) == 0 && len(words) > 0 {
+					// Keep closing punctuation with the word before it
+					if last := words[len(words)-1]; len(last.glue) == 0 && !last.newline && !last.hyphen {
+						cur = last
+						words = words[:len(words)-1]
+					}
+				}
+				cur.clusters = append(cur.clusters, c)
+				if c.width == 2 || (c.text == "-" && len(cur.clusters) > 1 && isWordRune(cur.clusters[len(cur.clusters)-2].base)) {
+					// Lines can break after wide characters and after a
+					// hyphen inside a word
+					finish()
+				}
+			}
+		}
+	}
+	if len(cur.clusters) > 0 || len(cur.glue) > 0 {
+		finish()
+	}
+	return words
+}
+
+// layout breaks the paragraph into lines no wider than width. A width of 0
+// or less breaks only at newlines.
+func (p *paragraphView) layout(width int) [][]cluster {
+	var lines [][]cluster
+	var line, glue []cluster
+	lineHyphen := false // the line ends at a soft hyphen if broken here
+
+	breakLine := func() {
+		if lineHyphen {
+			line = append(line, hyphenCluster(line[len(line)-1].style))
+		}
+		lines = append(lines, line)
+		line, glue, lineHyphen = nil, nil, false
+	}
+
+	for _, w := range p.words() {
+		rest := w.clusters
+		hyphenW := 0
+		if w.hyphen {
+			hyphenW = 1
+		}
+
+		lineW := clustersWidth(line) + clustersWidth(glue)
+		if width > 0 && len(line) > 0 && lineW+clustersWidth(rest)+hyphenW > width {
+			if p.hyphens == HyphenAuto {
+				// Hyphenate the word into the space left on the line
+				if head, tail := splitWord(rest, width-lineW-1, 2); len(head) > 0 {
+					line = append(append(line, glue...), head...)
+					line = append(line, hyphenCluster(head[len(head)-1].style))
+					rest, lineHyphen = tail, false
+				}
+			}
+			breakLine()
+		} else {
+			// Glue before the first word of a paragraph is indentation
+			line = append(line, glue...)
+		}
+
+		// Break words longer than a line
+		for width > 0 && len(rest) > 1 && clustersWidth(line)+clustersWidth(rest)+hyphenW > width {
+			avail := width - clustersWidth(line)
+			mark := p.hyphens == HyphenAuto && avail > 2
+			if mark {
+				avail--
+			}
+			head, tail := splitWord(rest, avail, 1)
+			if len(head) == 0 {
+				if len(line) > 0 {
+					breakLine()
+					continue
+				}
+				head, tail = rest[:1], rest[1:] // a character wider than the line
+			}
+			line = append(line, head...)
+			if mark {
+				line = append(line, hyphenCluster(head[len(head)-1].style))
+			}
+			rest = tail
+			breakLine()
+		}
+
+		line = append(line, rest...)
+		glue, lineHyphen = w.glue, w.hyphen
+		if w.newline {
+			lineHyphen = false
+			breakLine()
+		}
+	}
+	if len(line) > 0 || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitWord splits clusters so the head fits in avail cells, keeping at
+// least minPart clusters on each side. The head is empty if it can't.
+func splitWord(clusters []cluster, avail, minPart int) (head, tail []cluster) {
+	n, w := 0, 0
+	for n < len(clusters) && w+clusters[n].width <= avail {
+		w += clusters[n].width
+		n++
+	}
+	n = min(n, len(clusters)-minPart)
+	if n < minPart {
+		return nil, clusters
+	}
+	return clusters[:n], clusters[n:]
+}
+
+func newCluster(text string, style Style) cluster {
+	c := cluster{text: text, style: style, width: runewidth.StringWidth(text)}
+	// Precompose combining marks where possible so they fit in one cell,
+	// otherwise draw the first visible rune
+	if composed := []rune(norm.NFC.String(text)); len(composed) == 1 {
+		c.base = composed[0]
+		return c
+	}
+	for _, r := range text {
+		if runewidth.RuneWidth(r) > 0 {
+			c.base = r
+			break
+		}
+	}
+	return c
+}
+
+func hyphenCluster(style Style) cluster {
+	return cluster{text: "-", base: '-', width: 1, style: style}
+}
+
+func clustersWidth(clusters []cluster) int {
+	w := 0
+	for _, c := range clusters {
+		w += c.width
+	}
+	return w
+}
+
+func lineWidth(line []cluster) int {
+	return clustersWidth(line)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isClosingPunct reports whether a line shouldn't start with r.
+func isClosingPunct(r rune) bool {
+	switch r {
+	case '.', ',', ';', ':', '!', '?', ')', ']', '}',
+		'、', '。', '，', '．', '：', '；', '！', '？', '）', '」', '』', '】', '〉', '》', 'ー':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+// paragraphLines returns the text of each laid out line.
+func paragraphLines(p *paragraphView, width int) []string {
+	var lines []string
+	for _, line := range p.layout(width) {
+		var b strings.Builder
+		for _, c := range line {
+			b.WriteString(c.text)
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
+}
+
+func TestParagraph_Wrap(t *testing.T) {
+	p := WrappedText("the quick brown fox jumps")
+	assert.Equal(t, []string{"the quick", "brown fox", "jumps"}, paragraphLines(p, 10))
+	assert.Equal(t, []string{"the quick brown fox jumps"}, paragraphLines(p, 0))
+
+	// Newlines and indentation are kept; spaces at a break are dropped
+	p = WrappedText("one two\n\n  three four")
+	assert.Equal(t, []string{"one two", "", "  three", "four"}, paragraphLines(p, 8))
+
+	// Words longer than a line are broken
+	assert.Equal(t, []string{"abcd", "efgh", "ij"}, paragraphLines(WrappedText("abcdefghij"), 4))
+
+	// Hyphenated words break after the hyphen, but options don't
+	assert.Equal(t, []string{"a well-", "known", "--flag"}, paragraphLines(WrappedText("a well-known --flag"), 10))
+}
+
+func TestParagraph_WideCharacters(t *testing.T) {
+	// CJK text breaks between characters, but not before closing punctuation
+	p := WrappedText("日本語のテキスト。")
+	assert.Equal(t, []string{"日本語", "のテキ", "スト。"}, paragraphLines(p, 6))
+
+	w, h := p.size(7, 0)
+	assert.Equal(t, 6, w)
+	assert.Equal(t, 3, h)
+
+	// Emoji and combining marks are measured as one grapheme each
+	p = WrappedText("café 👍🏽 ok")
+	assert.Equal(t, []string{"café 👍🏽", "ok"}, paragraphLines(p, 7))
+
+	screen := SprintScreen(p, PrintConfig{Width: 7, Height: 2})
+	assert.Equal(t, "café 👍", screen.Row(0))
+}
+
+func TestParagraph_Hyphenation(t *testing.T) {
+	text := "an extra­ordinary day"
+	assert.Equal(t, []string{"an extra-", "ordinary", "day"}, paragraphLines(WrappedText("%s", text), 10))
+	assert.Equal(t, []string{"an", "extraordi", "nary day"}, paragraphLines(WrappedText("%s", text).Hyphenation(HyphenNone), 9))
+	assert.Equal(t, []string{"an extra-", "ordinary", "day"}, paragraphLines(WrappedText("%s", text).Hyphenation(HyphenAuto), 10))
+
+	assert.Equal(t, []string{"see docum-", "entation"}, paragraphLines(WrappedText("see documentation").Hyphenation(HyphenAuto), 10))
+	assert.Equal(t, []string{"abc-", "def"}, paragraphLines(WrappedText("abcdef").Hyphenation(HyphenAuto), 4))
+}
+
+func TestParagraph_Spans(t *testing.T) {
+	bold := NewStyle().WithBold()
+	p := Paragraph(Span("plain ", NewStyle()), Span("bold text", bold)).Fg(ColorRed)
+	assert.Equal(t, []string{"plain bold", "text"}, paragraphLines(p, 10))
+
+	screen := SprintScreen(p.Right(), PrintConfig{Width: 10, Height: 2})
+	assert.Equal(t, "      text", screen.Row(1))
+	assert.True(t, screen.Cell(6, 1).Style.Bold)
+	assert.False(t, screen.Cell(0, 0).Style.Bold)
+	assert.Equal(t, uint8(ColorRed), screen.Cell(0, 0).Style.Foreground.Value)
+}
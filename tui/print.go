@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"image"
 	"io"
 	"os"
 	"strings"
@@ -34,6 +35,15 @@ func (c PrintConfig) withDefaults() PrintConfig {
 	return c
 }
 
+// colorProfile returns the colors to print with: those of the terminal if
+// Output is one, and true color otherwise, such as for files and buffers.
+func (c PrintConfig) colorProfile() ColorProfile {
+	if f, ok := c.Output.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		return DetectColorProfile()
+	}
+	return ProfileTrueColor
+}
+
 // Print renders a view to the terminal without taking over the screen.
 // This outputs the view inline, preserving scroll history and existing content.
 //
@@ -79,6 +89,7 @@ func Print(view View, cfgs ...PrintConfig) error {
 	// Create an in-memory terminal buffer
 	var buf strings.Builder
 	terminal := NewTestTerminal(cfg.Width, height, &buf)
+	terminal.SetColorProfile(cfg.colorProfile())
 
 	// Render the view to the buffer
 	frame, err := terminal.BeginFrame()
@@ -93,6 +104,7 @@ func Print(view View, cfgs ...PrintConfig) error {
 	ctx := NewRenderContext(frame, 0)
 	view.size(cfg.Width, height) // Measure phase
 	view.render(ctx)             // Render phase
+	ctx.drawOverlays()
 
 	// End the frame (this populates the buffer)
 	terminal.EndFrame(frame)
@@ -152,7 +164,7 @@ func renderToANSI(t *Terminal, width, height int, rawMode bool) string {
 					output.WriteString("\033[0m")
 				}
 				if cell.Style != NewStyle() {
-					output.WriteString(cell.Style.String())
+					output.WriteString(t.StyleSequence(cell.Style))
 				}
 				currentStyle = cell.Style
 				styleSet = true
@@ -210,6 +222,95 @@ func Sprint(view View, cfgs ...PrintConfig) string {
 	return buf.String()
 }
 
+// RenderPlain renders a view to plain text with all styling removed, for
+// writing the screen to a log file or copying it into a bug report. Box
+// drawing characters are kept; use RenderASCII to convert them. Trailing
+// spaces are trimmed from each line. A width of 0 uses the terminal width,
+// like PrintConfig.
+//
+// Example:
+//
+//	os.WriteFile("screen.txt", []byte(tui.RenderPlain(app.View(), 100)), 0o644)
+func RenderPlain(view View, width int) string {
+	return renderPlain(view, width, false)
+}
+
+// RenderASCII is like RenderPlain but converts box drawing and block
+// characters to ASCII (+, -, |, and #), for destinations that mangle
+// Unicode such as some issue trackers and log pipelines.
+func RenderASCII(view View, width int) string {
+	return renderPlain(view, width, true)
+}
+
+func renderPlain(view View, width int, ascii bool) string {
+	cfg := PrintConfig{Width: width}.withDefaults()
+
+	_, height := view.size(cfg.Width, 0)
+	if height == 0 {
+		height = 1
+	}
+
+	var buf strings.Builder
+	terminal := NewTestTerminal(cfg.Width, height, &buf)
+	frame, err := terminal.BeginFrame()
+	if err != nil {
+		return ""
+	}
+	frame.Fill(' ', NewStyle())
+	view.size(cfg.Width, height)
+	ctx := NewRenderContext(frame, 0)
+	view.render(ctx)
+	ctx.drawOverlays()
+	terminal.EndFrame(frame)
+
+	lines := make([]string, height)
+	for y := 0; y < height; y++ {
+		var line strings.Builder
+		for x := 0; x < cfg.Width; x++ {
+			cell := terminal.GetCell(x, y)
+			if cell.Continuation {
+				continue
+			}
+			char := cell.Char
+			if char == 0 || cell.Style.Hidden {
+				char = ' '
+			}
+			if ascii {
+				char = asciiRune(char)
+			}
+			line.WriteRune(char)
+		}
+		lines[y] = strings.TrimRight(line.String(), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// asciiRune returns an ASCII stand-in for box drawing and block characters,
+// and r unchanged otherwise.
+func asciiRune(r rune) rune {
+	switch {
+	case r >= 0x2500 && r <= 0x257F: // Box Drawing
+		switch r {
+		case '─', '━', '═', '┄', '┅', '┈', '┉', '╌', '╍', '╴', '╶', '╸', '╺', '╼', '╾':
+			return '-'
+		case '│', '┃', '║', '┆', '┇', '┊', '┋', '╎', '╏', '╵', '╷', '╹', '╻', '╽', '╿':
+			return '|'
+		case '╱':
+			return '/'
+		case '╲':
+			return '\\'
+		case '╳':
+			return 'X'
+		}
+		return '+'
+	case r == '░':
+		return '.'
+	case r >= 0x2580 && r <= 0x259F: // Block Elements
+		return '#'
+	}
+	return r
+}
+
 // SprintScreen renders a view and returns a termtest.Screen for assertions.
 // This is a convenience function for testing that combines Sprint with
 // termtest.Screen parsing, making it easy to write precise visual tests.
@@ -293,6 +394,9 @@ type LivePrinter struct {
 	frameCount   uint64
 	hiddenCursor bool
 	lastLines    []string // Previous frame's lines for diffing
+
+	highlight Style    // Style merged into cells that changed, if not empty
+	lastCells [][]Cell // Previous frame's cells, before highlighting
 }
 
 // NewLivePrinter creates a new LivePrinter for updating a region in place.
@@ -303,7 +407,8 @@ func NewLivePrinter(cfgs ...PrintConfig) *LivePrinter {
 	}
 	cfg = cfg.withDefaults()
 	return &LivePrinter{
-		config: cfg,
+		config:    cfg,
+		highlight: NewStyle(),
 	}
 }
 
@@ -314,6 +419,15 @@ func (lp *LivePrinter) SetWidth(w int) {
 	lp.config.Width = w
 }
 
+// HighlightChanges makes each Update highlight the cells that changed since
+// the previous update by merging style into them, like watch -d. Pass an
+// empty style to stop highlighting.
+//
+//	live.HighlightChanges(tui.NewStyle().WithReverse())
+func (lp *LivePrinter) HighlightChanges(style Style) {
+	lp.highlight = style
+}
+
 // Update renders a new view, replacing the previous content in place.
 // The cursor moves back to overwrite the previous output.
 //
@@ -378,6 +492,7 @@ func (lp *LivePrinter) update(view View, useSync bool, fm *FocusManager) error {
 	// Create terminal buffer and render
 	var buf strings.Builder
 	terminal := NewTestTerminal(lp.config.Width, height, &buf)
+	terminal.SetColorProfile(lp.config.colorProfile())
 
 	frame, err := terminal.BeginFrame()
 	if err != nil {
@@ -392,7 +507,11 @@ func (lp *LivePrinter) update(view View, useSync bool, fm *FocusManager) error {
 	lp.frameCount++
 	view.size(lp.config.Width, height)
 	view.render(ctx)
+	ctx.drawOverlays()
 	terminal.EndFrame(frame)
+	if err := lp.highlightChanges(terminal, height); err != nil {
+		return err
+	}
 
 	// Convert to individual lines for diffing
 	newLines := renderToLines(terminal, lp.config.Width, height)
@@ -459,6 +578,64 @@ func (lp *LivePrinter) update(view View, useSync bool, fm *FocusManager) error {
 	return err
 }
 
+// highlightChanges merges the highlight style into the cells of terminal
+// that differ from the previous update, and remembers the cells for the
+// next one. Cells outside the previous update count as blank.
+func (lp *LivePrinter) highlightChanges(terminal *Terminal, height int) error {
+	if lp.highlight.IsEmpty() {
+		lp.lastCells = nil
+		return nil
+	}
+	blank := Cell{Char: ' ', Style: NewStyle(), Width: 1}
+	cells := make([][]Cell, height)
+	var changed []image.Point
+	for y := range cells {
+		cells[y] = make([]Cell, lp.config.Width)
+		for x := range cells[y] {
+			cell := terminal.GetCell(x, y)
+			cells[y][x] = cell
+			old := blank
+			if y < len(lp.lastCells) && x < len(lp.lastCells[y]) {
+				old = lp.lastCells[y][x]
+			}
+			if lp.lastCells != nil && !cell.Continuation && !sameCell(cell, old) {
+				changed = append(changed, image.Pt(x, y))
+			}
+		}
+	}
+	lp.lastCells = cells
+	if len(changed) == 0 {
+		return nil
+	}
+
+	frame, err := terminal.BeginFrame()
+	if err != nil {
+		return fmt.Errorf("failed to begin frame: %w", err)
+	}
+	for _, pt := range changed {
+		cell := cells[pt.Y][pt.X]
+		frame.SetCell(pt.X, pt.Y, cell.Char, cell.Style.Merge(lp.highlight))
+	}
+	return terminal.EndFrame(frame)
+}
+
+// sameCell reports whether two cells look the same, comparing RGB colors
+// by value.
+func sameCell(a, b Cell) bool {
+	if a.Char != b.Char || a.Continuation != b.Continuation {
+		return false
+	}
+	sameRGB := func(a, b *RGB) bool {
+		return a == b || (a != nil && b != nil && *a == *b)
+	}
+	as, bs := a.Style, b.Style
+	if !sameRGB(as.FgRGB, bs.FgRGB) || !sameRGB(as.BgRGB, bs.BgRGB) {
+		return false
+	}
+	as.FgRGB, as.BgRGB, bs.FgRGB, bs.BgRGB = nil, nil, nil, nil
+	return as == bs
+}
+
 // Stop finalizes the live region, moving the cursor below the content
 // and restoring cursor visibility.
 func (lp *LivePrinter) Stop() {
@@ -484,6 +661,7 @@ func (lp *LivePrinter) Clear() {
 	}
 	lp.lastHeight = 0
 	lp.lastLines = nil // Reset diff state
+	lp.lastCells = nil
 	lp.started = false
 }
 
@@ -527,7 +705,7 @@ func renderToANSILive(t *Terminal, width, height int) string {
 					output.WriteString("\033[0m")
 				}
 				if cell.Style != NewStyle() {
-					output.WriteString(cell.Style.String())
+					output.WriteString(t.StyleSequence(cell.Style))
 				}
 				currentStyle = cell.Style
 				styleSet = true
@@ -603,7 +781,7 @@ func renderToLines(t *Terminal, width, height int) []string {
 					line.WriteString("\033[0m")
 				}
 				if cell.Style != NewStyle() {
-					line.WriteString(cell.Style.String())
+					line.WriteString(t.StyleSequence(cell.Style))
 				}
 				currentStyle = cell.Style
 				styleSet = true
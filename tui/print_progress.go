@@ -0,0 +1,231 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/humanize"
+	"golang.org/x/term"
+)
+
+// ProgressPrinter reports the progress of a long task from a CLI, with the
+// same options as the Progress view. On a terminal it draws a progress bar
+// that updates in place; when output is piped or redirected, as in CI logs,
+// it prints a plain line now and then instead, without carriage returns or
+// escape codes:
+//
+//	Uploading: 45% 123/270 files, eta 40s
+//
+// It is safe to update from several goroutines.
+//
+// Example:
+//
+//	progress := tui.PrintProgress(len(files)).Label("Uploading").Unit("files").ShowFraction().ShowETA()
+//	for _, f := range files {
+//	    upload(f)
+//	    progress.Add(1)
+//	}
+//	progress.Done()
+type ProgressPrinter struct {
+	mu     sync.Mutex
+	config PrintConfig
+	live   *LivePrinter // Set when Output is a terminal
+
+	current, total int
+	label, unit    string
+	hidePercent    bool
+	showFraction   bool
+	showETA        bool
+	interval       time.Duration
+
+	started   time.Time
+	lastPrint time.Time
+	printed   int // The value last printed, or -1
+	done      bool
+
+	now func() time.Time // For tests
+}
+
+// PrintProgress returns a ProgressPrinter for a task of total steps. A total
+// of 0 or less means the size isn't known, and only the count is shown.
+// Lines are printed at most every 2 seconds; see Interval.
+func PrintProgress(total int, cfgs ...PrintConfig) *ProgressPrinter {
+	cfg := PrintConfig{}
+	if len(cfgs) > 0 {
+		cfg = cfgs[0]
+	}
+	cfg = cfg.withDefaults()
+	p := &ProgressPrinter{
+		config:   cfg,
+		total:    total,
+		interval: 2 * time.Second,
+		printed:  -1,
+		now:      time.Now,
+	}
+	if f, ok := cfg.Output.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		p.live = NewLivePrinter(cfg)
+		p.interval = 100 * time.Millisecond
+	}
+	p.started = p.now()
+	return p
+}
+
+// Label sets a label to show before the progress.
+func (p *ProgressPrinter) Label(label string) *ProgressPrinter {
+	p.label = label
+	return p
+}
+
+// Unit names what is being counted, such as "files" or "bytes", and is
+// shown after the count in printed lines.
+func (p *ProgressPrinter) Unit(unit string) *ProgressPrinter {
+	p.unit = unit
+	return p
+}
+
+// ShowFraction shows the count, like "123/270". Printed lines show it after
+// the percentage; the progress bar shows it instead of the percentage.
+func (p *ProgressPrinter) ShowFraction() *ProgressPrinter {
+	p.showFraction = true
+	return p
+}
+
+// HidePercent hides the percentage.
+func (p *ProgressPrinter) HidePercent() *ProgressPrinter {
+	p.hidePercent = true
+	return p
+}
+
+// ShowETA shows the estimated time remaining, extrapolated from the time
+// since PrintProgress was called. The last line shows the total time taken
+// instead.
+func (p *ProgressPrinter) ShowETA() *ProgressPrinter {
+	p.showETA = true
+	return p
+}
+
+// Interval sets the least time between printed lines. The first and last
+// values are always printed. The default is 2 seconds, or a tenth of a
+// second on a terminal.
+func (p *ProgressPrinter) Interval(d time.Duration) *ProgressPrinter {
+	p.interval = d
+	return p
+}
+
+// Set records the number of steps completed.
+func (p *ProgressPrinter) Set(current int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.current = current
+	p.report(false)
+}
+
+// Add records n more steps completed.
+func (p *ProgressPrinter) Add(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.current += n
+	p.report(false)
+}
+
+// Done prints the final progress, if it hasn't been already, and ends the
+// progress bar on a terminal. Later updates are ignored.
+func (p *ProgressPrinter) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		return
+	}
+	p.report(true)
+	p.done = true
+	if p.live != nil {
+		p.live.Stop()
+	}
+}
+
+// View returns the Progress view showing the current progress, as drawn on
+// a terminal, for showing it in an app instead.
+func (p *ProgressPrinter) View() View {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.view()
+}
+
+// Line returns the line that would be printed for the current progress.
+func (p *ProgressPrinter) Line() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.line()
+}
+
+func (p *ProgressPrinter) view() *progressView {
+	v := Progress(p.current, p.total).Label(p.label)
+	if p.showFraction || p.hidePercent || p.total <= 0 {
+		v.ShowFraction()
+	}
+	if p.total <= 0 {
+		v.Indeterminate()
+	}
+	if p.showETA {
+		v.ShowETA(p.now().Sub(p.started))
+	}
+	return v
+}
+
+func (p *ProgressPrinter) line() string {
+	var parts []string
+	if p.total > 0 && !p.hidePercent {
+		percent := min(100, max(0, p.current*100/p.total))
+		parts = append(parts, fmt.Sprintf("%d%%", percent))
+	}
+	if p.showFraction || p.total <= 0 || p.hidePercent {
+		count := fmt.Sprint(p.current)
+		if p.total > 0 {
+			count += fmt.Sprintf("/%d", p.total)
+		}
+		if p.unit != "" {
+			count += " " + p.unit
+		}
+		parts = append(parts, count)
+	}
+	text := strings.Join(parts, " ")
+
+	if p.showETA {
+		var eta string
+		if p.total > 0 && p.current >= p.total {
+			eta = "took " + humanize.DurationShort(p.now().Sub(p.started))
+		} else if p.total > 0 {
+			eta = "eta " + p.view().etaText()
+		}
+		if eta != "" {
+			text += ", " + eta
+		}
+	}
+	if p.label != "" {
+		text = p.label + ": " + text
+	}
+	return text
+}
+
+// report prints the progress if it has changed and the interval has passed
+// since the last line, or final is set. The caller holds the lock.
+func (p *ProgressPrinter) report(final bool) {
+	if p.done || p.current == p.printed {
+		return
+	}
+	now := p.now()
+	first := p.printed == -1
+	complete := p.total > 0 && p.current >= p.total
+	if !final && !first && !complete && now.Sub(p.lastPrint) < p.interval {
+		return
+	}
+	p.printed, p.lastPrint = p.current, now
+	if p.live != nil {
+		p.live.Update(p.view())
+		return
+	}
+	fmt.Fprintln(p.config.Output, p.line())
+}
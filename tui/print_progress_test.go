@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestPrintProgress_Lines(t *testing.T) {
+	var buf strings.Builder
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := PrintProgress(270, PrintConfig{Output: &buf})
+	p.now = func() time.Time { return clock }
+	p.started = clock
+	p.Label("Uploading").Unit("items").ShowFraction().ShowETA()
+
+	p.Set(0)
+	clock = clock.Add(time.Second)
+	p.Set(10) // Within the interval
+	clock = clock.Add(32 * time.Second)
+	p.Set(123)
+	clock = clock.Add(time.Second)
+	p.Set(124) // Within the interval
+	clock = clock.Add(time.Minute)
+	p.Set(270)
+	p.Done()
+	p.Set(271) // After Done
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Equal(t, []string{
+		"Uploading: 0% 0/270 items, eta --",
+		"Uploading: 45% 123/270 items, eta 39s",
+		"Uploading: 100% 270/270 items, took 1m",
+	}, lines)
+	assert.False(t, strings.ContainsAny(buf.String(), "\r\x1b"))
+}
+
+func TestPrintProgress_DoneFlushes(t *testing.T) {
+	var buf strings.Builder
+	p := PrintProgress(10, PrintConfig{Output: &buf}).Interval(time.Hour)
+	p.Add(1)
+	p.Add(4)
+	p.Done()
+	p.Done()
+	assert.Equal(t, "10%\n50%\n", buf.String())
+}
+
+func TestPrintProgress_UnknownTotal(t *testing.T) {
+	p := PrintProgress(0, PrintConfig{Output: &strings.Builder{}}).Unit("pages").ShowETA()
+	p.Set(42)
+	assert.Equal(t, "42 pages", p.Line())
+
+	p = PrintProgress(8, PrintConfig{Output: &strings.Builder{}}).HidePercent()
+	p.Set(2)
+	assert.Equal(t, "2/8", p.Line())
+}
+
+func TestPrintProgress_View(t *testing.T) {
+	p := PrintProgress(4, PrintConfig{Output: &strings.Builder{}}).Label("Sync")
+	p.Set(2)
+	screen := SprintScreen(p.View(), PrintConfig{Width: 40})
+	assert.Equal(t, "Sync ██████████░░░░░░░░░░  50%", screen.Row(0))
+}
@@ -114,6 +114,26 @@ func TestPrint_Empty(t *testing.T) {
 	// Should not error on empty view
 }
 
+func TestRenderPlain(t *testing.T) {
+	view := Stack(
+		Bordered(Text("Status").Bold().Fg(ColorGreen)).Border(&RoundedBorder),
+		Text("done").Error(),
+	)
+
+	output := RenderPlain(view, 20)
+	assert.Equal(t, "╭──────╮\n│Status│\n╰──────╯\ndone", output)
+	assert.False(t, strings.Contains(output, "\033"), "output should not contain escape codes")
+}
+
+func TestRenderASCII(t *testing.T) {
+	view := Stack(
+		Bordered(Text("Box")).Border(&DoubleBorder),
+		Text("█▌░░ %d%%", 50),
+	)
+
+	assert.Equal(t, "+---+\n|Box|\n+---+\n##.. 50%", RenderASCII(view, 20))
+}
+
 func TestLivePrinter_Update(t *testing.T) {
 	var buf strings.Builder
 	lp := NewLivePrinter(PrintConfig{Width: 40, Output: &buf})
@@ -132,6 +152,27 @@ func TestLivePrinter_Update(t *testing.T) {
 	lp.Stop()
 }
 
+func TestLivePrinter_HighlightChanges(t *testing.T) {
+	var buf strings.Builder
+	lp := NewLivePrinter(PrintConfig{Width: 10, Output: &buf})
+	hl := NewStyle().WithReverse()
+	lp.HighlightChanges(hl)
+
+	// Nothing is highlighted the first time
+	assert.NoError(t, lp.Update(Stack(Text("abc"), Text("x").FgRGB(1, 2, 3))))
+	assert.Equal(t, []string{"abc", NewStyle().WithFgRGB(NewRGB(1, 2, 3)).String() + "x\033[0m"}, lp.lastLines)
+
+	// Only the changed cell is, and equal RGB colors aren't changes
+	assert.NoError(t, lp.Update(Stack(Text("abd"), Text("x").FgRGB(1, 2, 3))))
+	assert.Equal(t, "ab\033[0m"+hl.String()+"d\033[0m", lp.lastLines[0])
+	assert.NotContains(t, lp.lastLines[1], hl.String())
+
+	lp.HighlightChanges(NewStyle())
+	assert.NoError(t, lp.Update(Stack(Text("abe"), Text("x"))))
+	assert.Equal(t, "abe", lp.lastLines[0])
+	lp.Stop()
+}
+
 func TestLivePrinter_Clear(t *testing.T) {
 	var buf strings.Builder
 	lp := NewLivePrinter(PrintConfig{Width: 40, Output: &buf})
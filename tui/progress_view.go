@@ -3,6 +3,10 @@ package tui
 import (
 	"fmt"
 	"math"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/color"
+	"github.com/deepnoodle-ai/wonton/humanize"
 )
 
 // progressView displays a progress bar (declarative view)
@@ -19,6 +23,12 @@ type progressView struct {
 	showPercent  bool
 	showFraction bool
 	label        string
+
+	indeterminate bool
+	smooth        bool
+	gradient      []RGB
+	showETA       bool
+	elapsed       time.Duration // time spent so far, for the ETA
 }
 
 // Progress creates a declarative progress bar view.
@@ -139,6 +149,44 @@ func (p *progressView) Label(label string) *progressView {
 	return p
 }
 
+// Indeterminate shows a block bouncing along the bar instead of the current
+// progress, for work whose size isn't known. The block moves with the render
+// frame, so the app must tick. The percentage, fraction, and ETA are hidden.
+func (p *progressView) Indeterminate() *progressView {
+	p.indeterminate = true
+	return p
+}
+
+// Smooth draws the end of the fill with partial block characters, giving
+// the bar eight steps per cell instead of one.
+func (p *progressView) Smooth() *progressView {
+	p.smooth = true
+	return p
+}
+
+// Gradient colors the filled portion with a gradient through the given
+// stops, spread over the full width of the bar.
+//
+// Example:
+//
+//	Progress(70, 100).Gradient(NewRGB(255, 0, 0), NewRGB(0, 255, 0))
+func (p *progressView) Gradient(stops ...RGB) *progressView {
+	p.gradient = stops
+	return p
+}
+
+// ShowETA shows the estimated time remaining after the percentage,
+// extrapolated from elapsed, the time spent reaching the current value.
+//
+// Example:
+//
+//	Progress(done, total).ShowETA(time.Since(app.started))
+func (p *progressView) ShowETA(elapsed time.Duration) *progressView {
+	p.showETA = true
+	p.elapsed = elapsed
+	return p
+}
+
 // Shimmer returns an animated progress bar with a shimmer highlight effect.
 // Speed controls how fast the shimmer moves (lower = faster).
 // HighlightColor is the color of the shimmer highlight.
@@ -178,70 +226,10 @@ func (a *animatedProgressView) size(maxWidth, maxHeight int) (int, int) {
 }
 
 func (a *animatedProgressView) render(ctx *RenderContext) {
-	width, height := ctx.Size()
-	if width == 0 || height == 0 {
-		return
-	}
-
 	p := a.base
-	x := 0
-
-	// Draw label
-	if p.label != "" {
-		ctx.PrintStyled(x, 0, p.label, p.style)
-		labelW, _ := MeasureText(p.label)
-		x += labelW + 1
-	}
-
-	// Calculate available width for bar
-	barWidth := p.width
-	availableWidth := width - x
-	if p.showPercent {
-		availableWidth -= 5
-	}
-	if p.showFraction {
-		availableWidth -= len(fmt.Sprintf(" %d/%d", p.total, p.total)) + 1
-	}
-	if barWidth > availableWidth {
-		barWidth = availableWidth
-	}
-	if barWidth < 1 {
-		barWidth = 1
-	}
-
-	// Calculate filled width
-	fillWidth := 0
-	if p.total > 0 {
-		fillWidth = (p.current * barWidth) / p.total
-		if fillWidth > barWidth {
-			fillWidth = barWidth
-		}
-		if fillWidth < 0 {
-			fillWidth = 0
-		}
-	}
-
-	// Draw empty background
-	if p.emptyPattern != "" {
-		// Use repeating pattern for empty portion
-		patternRunes := []rune(p.emptyPattern)
-		for i := 0; i < barWidth; i++ {
-			char := patternRunes[i%len(patternRunes)]
-			ctx.SetCell(x+i, 0, char, p.emptyStyle)
-		}
-	} else {
-		// Use single character for empty portion
-		for i := 0; i < barWidth; i++ {
-			ctx.SetCell(x+i, 0, p.emptyChar, p.emptyStyle)
-		}
-	}
-
 	frame := ctx.Frame()
 
-	// Draw filled portion with animation
-	for i := 0; i < fillWidth; i++ {
-		style := p.style
-
+	p.renderWith(ctx, func(i, fillWidth int, style Style) Style {
 		if a.shimmer && fillWidth > 0 {
 			// Calculate shimmer position (moves across the bar)
 			shimmerPos := int(frame/uint64(a.shimmerSpeed)) % (fillWidth + 3)
@@ -271,28 +259,94 @@ func (a *animatedProgressView) render(ctx *RenderContext) {
 			b := uint8(float64(a.pulseColor.B) * brightness)
 			style = style.WithFgRGB(NewRGB(r, g, b))
 		}
+		return style
+	})
+}
 
-		ctx.SetCell(x+i, 0, p.filledChar, style)
+// progressEighths are the partial block characters Smooth draws at the end
+// of the fill, indexed by eighths of a cell.
+var progressEighths = []rune{' ', '▏', '▎', '▍', '▌', '▋', '▊', '▉'}
+
+// suffixWidth returns the width reserved after the bar for the percentage,
+// fraction, and ETA.
+func (p *progressView) suffixWidth() int {
+	if p.indeterminate {
+		return 0
 	}
-	x += barWidth
+	w := 0
+	if p.showPercent {
+		w += 5 // " 100%"
+	}
+	if p.showFraction {
+		// Estimate fraction width
+		w += len(fmt.Sprintf(" %d/%d", p.total, p.total)) + 1
+	}
+	if p.showETA {
+		w += 10 // " ETA 999ms"
+	}
+	return w
+}
 
-	// Draw percentage or fraction
+// suffix returns the text drawn after the bar.
+func (p *progressView) suffix() string {
+	if p.indeterminate {
+		return ""
+	}
+	var text string
 	if p.showPercent && p.total > 0 {
 		percent := (p.current * 100) / p.total
-		text := fmt.Sprintf(" %3d%%", percent)
-		percentStyle := p.style
-		if p.percentStyle != nil {
-			percentStyle = *p.percentStyle
-		}
-		ctx.PrintStyled(x, 0, text, percentStyle)
+		text = fmt.Sprintf(" %3d%%", percent)
 	} else if p.showFraction {
-		text := fmt.Sprintf(" %d/%d", p.current, p.total)
-		percentStyle := p.style
-		if p.percentStyle != nil {
-			percentStyle = *p.percentStyle
-		}
-		ctx.PrintStyled(x, 0, text, percentStyle)
+		text = fmt.Sprintf(" %d/%d", p.current, p.total)
 	}
+	if p.showETA {
+		text += " ETA " + p.etaText()
+	}
+	return text
+}
+
+// etaText extrapolates the time remaining from the elapsed time and the
+// fraction done so far.
+func (p *progressView) etaText() string {
+	switch {
+	case p.total <= 0 || p.current <= 0:
+		return "--"
+	case p.current >= p.total:
+		return "0s"
+	}
+	remaining := p.elapsed * time.Duration(p.total-p.current) / time.Duration(p.current)
+	return humanize.DurationShort(remaining)
+}
+
+// fill returns how many whole cells of a barWidth-wide bar are filled, and
+// the eighths of the following cell when Smooth is set.
+func (p *progressView) fill(barWidth int) (cells, eighths int) {
+	if p.total <= 0 || p.current <= 0 {
+		return 0, 0
+	}
+	if p.current >= p.total {
+		return barWidth, 0
+	}
+	if !p.smooth {
+		return (p.current * barWidth) / p.total, 0
+	}
+	n := (p.current * barWidth * 8) / p.total
+	return n / 8, n % 8
+}
+
+// marquee returns the start and length of the block an indeterminate bar
+// shows at the given frame. The block bounces between the ends of the bar.
+func (p *progressView) marquee(barWidth int, frame uint64) (start, n int) {
+	n = max(1, barWidth/4)
+	travel := barWidth - n
+	if travel <= 0 {
+		return 0, barWidth
+	}
+	pos := int(frame/2) % (2 * travel)
+	if pos > travel {
+		pos = 2*travel - pos
+	}
+	return pos, n
 }
 
 func (p *progressView) size(maxWidth, maxHeight int) (int, int) {
@@ -301,13 +355,7 @@ func (p *progressView) size(maxWidth, maxHeight int) (int, int) {
 		labelW, _ := MeasureText(p.label)
 		w += labelW + 1 // +1 for space
 	}
-	if p.showPercent {
-		w += 5 // " 100%"
-	}
-	if p.showFraction {
-		// Estimate fraction width
-		w += len(fmt.Sprintf(" %d/%d", p.total, p.total)) + 1
-	}
+	w += p.suffixWidth()
 	if maxWidth > 0 && w > maxWidth {
 		w = maxWidth
 	}
@@ -315,6 +363,12 @@ func (p *progressView) size(maxWidth, maxHeight int) (int, int) {
 }
 
 func (p *progressView) render(ctx *RenderContext) {
+	p.renderWith(ctx, func(i, fillWidth int, style Style) Style { return style })
+}
+
+// renderWith draws the bar, passing the style of each filled cell through
+// styleAt so animated bars can vary it.
+func (p *progressView) renderWith(ctx *RenderContext, styleAt func(i, fillWidth int, style Style) Style) {
 	width, height := ctx.Size()
 	if width == 0 || height == 0 {
 		return
@@ -330,33 +384,11 @@ func (p *progressView) render(ctx *RenderContext) {
 	}
 
 	// Calculate available width for bar
-	barWidth := p.width
-	availableWidth := width - x
-	if p.showPercent {
-		availableWidth -= 5
-	}
-	if p.showFraction {
-		availableWidth -= len(fmt.Sprintf(" %d/%d", p.total, p.total)) + 1
-	}
-	if barWidth > availableWidth {
-		barWidth = availableWidth
-	}
+	barWidth := min(p.width, width-x-p.suffixWidth())
 	if barWidth < 1 {
 		barWidth = 1
 	}
 
-	// Calculate filled width
-	fillWidth := 0
-	if p.total > 0 {
-		fillWidth = (p.current * barWidth) / p.total
-		if fillWidth > barWidth {
-			fillWidth = barWidth
-		}
-		if fillWidth < 0 {
-			fillWidth = 0
-		}
-	}
-
 	// Draw empty background
 	if p.emptyPattern != "" {
 		// Use repeating pattern for empty portion
@@ -372,23 +404,38 @@ func (p *progressView) render(ctx *RenderContext) {
 		}
 	}
 
+	// Gradient colors are spread over the whole bar, so a cell's color
+	// shows its position rather than how full the bar is.
+	var colors []RGB
+	if len(p.gradient) > 0 {
+		colors = color.MultiGradient(p.gradient, barWidth)
+	}
+	fillStyle := func(i int) Style {
+		if colors != nil {
+			return p.style.WithFgRGB(colors[i])
+		}
+		return p.style
+	}
+
 	// Draw filled portion
-	for i := 0; i < fillWidth; i++ {
-		ctx.SetCell(x+i, 0, p.filledChar, p.style)
+	if p.indeterminate {
+		start, n := p.marquee(barWidth, ctx.Frame())
+		for i := start; i < start+n; i++ {
+			ctx.SetCell(x+i, 0, p.filledChar, styleAt(i, barWidth, fillStyle(i)))
+		}
+	} else {
+		cells, eighths := p.fill(barWidth)
+		for i := 0; i < cells; i++ {
+			ctx.SetCell(x+i, 0, p.filledChar, styleAt(i, cells, fillStyle(i)))
+		}
+		if eighths > 0 {
+			ctx.SetCell(x+cells, 0, progressEighths[eighths], styleAt(cells, cells, fillStyle(cells)))
+		}
 	}
 	x += barWidth
 
-	// Draw percentage or fraction
-	if p.showPercent && p.total > 0 {
-		percent := (p.current * 100) / p.total
-		text := fmt.Sprintf(" %3d%%", percent)
-		percentStyle := p.style
-		if p.percentStyle != nil {
-			percentStyle = *p.percentStyle
-		}
-		ctx.PrintStyled(x, 0, text, percentStyle)
-	} else if p.showFraction {
-		text := fmt.Sprintf(" %d/%d", p.current, p.total)
+	// Draw percentage, fraction, and ETA
+	if text := p.suffix(); text != "" {
 		percentStyle := p.style
 		if p.percentStyle != nil {
 			percentStyle = *p.percentStyle
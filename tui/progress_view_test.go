@@ -1,7 +1,9 @@
 package tui
 
 import (
+	"bytes"
 	"testing"
+	"time"
 
 	"github.com/deepnoodle-ai/wonton/assert"
 )
@@ -91,6 +93,60 @@ func TestProgress_Pulse(t *testing.T) {
 	assert.Equal(t, 15, p.pulseSpeed)
 }
 
+func TestProgress_Smooth(t *testing.T) {
+	screen := SprintScreen(Progress(9, 16).Width(4).HidePercent().Smooth(), PrintConfig{Width: 10, Height: 1})
+	assert.Equal(t, "██▎░", screen.Row(0))
+
+	screen = SprintScreen(Progress(9, 16).Width(4).HidePercent(), PrintConfig{Width: 10, Height: 1})
+	assert.Equal(t, "██░░", screen.Row(0))
+}
+
+func TestProgress_Indeterminate(t *testing.T) {
+	var buf bytes.Buffer
+	terminal := NewTestTerminal(20, 1, &buf)
+	view := Progress(0, 0).Width(8).Indeterminate()
+
+	rows := make([]string, 0, 4)
+	for _, n := range []uint64{0, 2, 12, 14} {
+		frame, err := terminal.BeginFrame()
+		assert.NoError(t, err)
+		view.render(NewRenderContext(frame, n))
+		assert.NoError(t, terminal.EndFrame(frame))
+		rows = append(rows, screenRow(terminal, 0))
+	}
+
+	// The block is a quarter of the bar and bounces back from the end
+	assert.Equal(t, []string{"██░░░░░░", "░██░░░░░", "░░░░░░██", "░░░░░██░"}, rows)
+	w, _ := view.size(0, 0)
+	assert.Equal(t, 8, w)
+}
+
+func TestProgress_ShowETA(t *testing.T) {
+	p := Progress(25, 100).Width(4).ShowETA(time.Minute)
+	assert.Equal(t, "  25% ETA 3m", p.suffix())
+	w, _ := p.size(0, 0)
+	assert.Equal(t, 19, w)
+
+	assert.Equal(t, " 0/10 ETA --", Progress(0, 10).ShowFraction().ShowETA(time.Second).suffix())
+	assert.Equal(t, " 100% ETA 0s", Progress(10, 10).ShowETA(time.Second).suffix())
+}
+
+func TestProgress_Gradient(t *testing.T) {
+	var buf bytes.Buffer
+	terminal := NewTestTerminal(10, 1, &buf)
+	frame, err := terminal.BeginFrame()
+	assert.NoError(t, err)
+	Progress(1, 2).Width(3).HidePercent().
+		Gradient(NewRGB(0, 0, 0), NewRGB(200, 0, 0)).
+		render(NewRenderContext(frame, 0))
+	assert.NoError(t, terminal.EndFrame(frame))
+
+	cell := terminal.GetCell(0, 0)
+	assert.Equal(t, NewRGB(0, 0, 0), *cell.Style.FgRGB)
+	cell = terminal.GetCell(1, 0)
+	assert.Equal(t, '░', cell.Char)
+}
+
 func TestLoading_Basic(t *testing.T) {
 	l := Loading(0)
 	assert.Equal(t, uint64(0), l.frame)
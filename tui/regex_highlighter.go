@@ -0,0 +1,231 @@
+package tui
+
+import (
+	"regexp"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// RegexHighlight is a range of a line matched by a RegexHighlighter. Columns
+// are display columns, as in SearchMatch.
+type RegexHighlight struct {
+	Start int    // First display column of the range
+	End   int    // Display column just past the range
+	Group int    // 0 for the whole match, otherwise the capture group number
+	Name  string // Name of the capture group, if it has one
+}
+
+// RegexHighlighter finds the matches of a regular expression in lines of
+// text and colors them, with a different color for each capture group. It
+// is the engine behind a regex playground, and can filter lines for views
+// such as logs.
+//
+// The highlighter lives in application state. Set the pattern as the user
+// types it; an invalid pattern is reported by Err rather than panicking,
+// and highlights nothing until it is fixed.
+//
+// Example:
+//
+//	app.re.SetPattern(app.pattern)
+//	if err := app.re.Err(); err != nil {
+//	    status = tui.Text("%v", err).Fg(tui.ColorRed)
+//	}
+//	for _, line := range app.lines {
+//	    rows = append(rows, tui.Paragraph(app.re.HighlightText(line, tui.NewStyle())...))
+//	}
+type RegexHighlighter struct {
+	// MatchStyle highlights whole matches. Zero value uses a yellow background.
+	MatchStyle Style
+	// GroupStyles highlight capture groups, the first style for group 1 and
+	// so on, cycling for groups beyond the last. Nil uses a built-in set of
+	// colors.
+	GroupStyles []Style
+
+	pattern string
+	re      *regexp.Regexp
+	err     error
+}
+
+// defaultGroupStyles are the capture group colors used when GroupStyles is
+// nil.
+var defaultGroupStyles = []Style{
+	NewStyle().WithBackground(ColorCyan).WithForeground(ColorBlack),
+	NewStyle().WithBackground(ColorMagenta).WithForeground(ColorBlack),
+	NewStyle().WithBackground(ColorGreen).WithForeground(ColorBlack),
+	NewStyle().WithBackground(ColorBlue).WithForeground(ColorWhite),
+	NewStyle().WithBackground(ColorRed).WithForeground(ColorWhite),
+}
+
+// NewRegexHighlighter creates a highlighter for pattern. Check Err for a
+// problem with the pattern.
+func NewRegexHighlighter(pattern string) *RegexHighlighter {
+	h := &RegexHighlighter{}
+	h.SetPattern(pattern)
+	return h
+}
+
+// SetPattern compiles pattern and returns the error if it is invalid, which
+// Err also reports until the pattern changes. Setting the current pattern
+// again does nothing, so it is cheap to call on every render. An empty
+// pattern matches nothing.
+func (h *RegexHighlighter) SetPattern(pattern string) error {
+	if pattern == h.pattern {
+		return h.err
+	}
+	h.pattern = pattern
+	h.re, h.err = nil, nil
+	if pattern != "" {
+		h.re, h.err = regexp.Compile(pattern)
+	}
+	return h.err
+}
+
+// Pattern returns the current pattern.
+func (h *RegexHighlighter) Pattern() string {
+	return h.pattern
+}
+
+// Err returns the error compiling the current pattern, or nil if it is
+// valid.
+func (h *RegexHighlighter) Err() error {
+	return h.err
+}
+
+// Regexp returns the compiled pattern, or nil if it is empty or invalid.
+func (h *RegexHighlighter) Regexp() *regexp.Regexp {
+	return h.re
+}
+
+// Active reports whether there is a valid, non-empty pattern.
+func (h *RegexHighlighter) Active() bool {
+	return h.re != nil
+}
+
+// MatchString reports whether line should be shown by a filter: whether
+// the pattern matches it, or, without an active pattern, always. An
+// invalid pattern filters nothing, so the content doesn't vanish while the
+// user is partway through typing one.
+func (h *RegexHighlighter) MatchString(line string) bool {
+	return h.re == nil || h.re.MatchString(line)
+}
+
+// GroupStyle returns the style of capture group n, or of whole matches for
+// n == 0.
+func (h *RegexHighlighter) GroupStyle(n int) Style {
+	if n == 0 {
+		if h.MatchStyle.IsEmpty() {
+			return NewStyle().WithBackground(ColorYellow).WithForeground(ColorBlack)
+		}
+		return h.MatchStyle
+	}
+	styles := h.GroupStyles
+	if len(styles) == 0 {
+		styles = defaultGroupStyles
+	}
+	return styles[(n-1)%len(styles)]
+}
+
+// Find returns the ranges of line the pattern matches. Each match gives a
+// range for the whole match followed by one for each capture group that
+// took part in it, so a group's range lies within its match's. Empty
+// matches and groups are left out.
+func (h *RegexHighlighter) Find(line string) []RegexHighlight {
+	if h.re == nil {
+		return nil
+	}
+	matches := h.re.FindAllStringSubmatchIndex(line, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	// Display column of each byte offset that starts a rune
+	cols := make(map[int]int, len(line)+1)
+	col := 0
+	for i, r := range line {
+		cols[i] = col
+		col += runewidth.RuneWidth(r)
+	}
+	cols[len(line)] = col
+
+	names := h.re.SubexpNames()
+	var result []RegexHighlight
+	for _, m := range matches {
+		for g := 0; g < len(m)/2; g++ {
+			start, end := m[2*g], m[2*g+1]
+			if start < 0 || start == end {
+				continue
+			}
+			result = append(result, RegexHighlight{
+				Start: cols[start],
+				End:   cols[end],
+				Group: g,
+				Name:  names[g],
+			})
+		}
+	}
+	return result
+}
+
+// Highlight splits segments, the styled text of one line, so that matches
+// and their capture groups use the highlighter's styles. Where groups nest,
+// the innermost group's style wins.
+func (h *RegexHighlighter) Highlight(segments []StyledSegment) []StyledSegment {
+	if h.re == nil {
+		return segments
+	}
+	var line []byte
+	for _, seg := range segments {
+		line = append(line, seg.Text...)
+	}
+	ranges := h.Find(string(line))
+	if len(ranges) == 0 {
+		return segments
+	}
+
+	// Later ranges are nested within or follow earlier ones, so the last
+	// range covering a column is the innermost
+	styleAt := func(col int) (Style, bool) {
+		for i := len(ranges) - 1; i >= 0; i-- {
+			if r := ranges[i]; col >= r.Start && col < r.End {
+				return h.GroupStyle(r.Group), true
+			}
+		}
+		return Style{}, false
+	}
+
+	var result []StyledSegment
+	col := 0
+	for _, seg := range segments {
+		var piece []rune
+		pieceStyle, pieceHit := styleAt(col)
+		flush := func() {
+			if len(piece) == 0 {
+				return
+			}
+			out := seg
+			out.Text = string(piece)
+			if pieceHit {
+				out.Style = pieceStyle
+			}
+			result = append(result, out)
+			piece = piece[:0]
+		}
+		for _, r := range seg.Text {
+			st, hit := styleAt(col)
+			if hit != pieceHit || st != pieceStyle {
+				flush()
+				pieceStyle, pieceHit = st, hit
+			}
+			piece = append(piece, r)
+			col += runewidth.RuneWidth(r)
+		}
+		flush()
+	}
+	return result
+}
+
+// HighlightText highlights a line of plain text drawn in style. Pass the
+// result to Paragraph to display it.
+func (h *RegexHighlighter) HighlightText(text string, style Style) []StyledSegment {
+	return h.Highlight([]StyledSegment{{Text: text, Style: style}})
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestRegexHighlighter_Find(t *testing.T) {
+	h := NewRegexHighlighter(`(?P<key>[^\s=]+)=(\d+)`)
+	assert.NoError(t, h.Err())
+
+	got := h.Find("a=1 日本=22")
+	assert.Equal(t, []RegexHighlight{
+		{Start: 0, End: 3, Group: 0},
+		{Start: 0, End: 1, Group: 1, Name: "key"},
+		{Start: 2, End: 3, Group: 2},
+		{Start: 4, End: 11, Group: 0},
+		{Start: 4, End: 8, Group: 1, Name: "key"},
+		{Start: 9, End: 11, Group: 2},
+	}, got)
+
+	// Empty matches are left out
+	assert.Equal(t, 0, len(NewRegexHighlighter(`x*`).Find("abc")))
+}
+
+func TestRegexHighlighter_Highlight(t *testing.T) {
+	h := NewRegexHighlighter(`a(b)`)
+	base := NewStyle().WithForeground(ColorWhite)
+	got := h.Highlight([]StyledSegment{{Text: "xa", Style: base}, {Text: "bx", Style: base}})
+
+	texts := make([]string, len(got))
+	for i, seg := range got {
+		texts[i] = seg.Text
+	}
+	assert.Equal(t, []string{"x", "a", "b", "x"}, texts)
+	assert.Equal(t, base, got[0].Style)
+	assert.Equal(t, h.GroupStyle(0), got[1].Style)
+	assert.Equal(t, h.GroupStyle(1), got[2].Style)
+	assert.Equal(t, base, got[3].Style)
+}
+
+func TestRegexHighlighter_InvalidPattern(t *testing.T) {
+	h := NewRegexHighlighter("")
+	assert.False(t, h.Active())
+	assert.True(t, h.MatchString("anything"))
+
+	err := h.SetPattern(`(unclosed`)
+	assert.Error(t, err)
+	assert.Equal(t, err, h.Err())
+	assert.False(t, h.Active())
+	assert.True(t, h.MatchString("anything"))
+	assert.Equal(t, []StyledSegment{{Text: "(unclosed"}}, h.HighlightText("(unclosed", Style{}))
+
+	// Fixing the pattern clears the error
+	assert.NoError(t, h.SetPattern(`err(or)?`))
+	assert.NoError(t, h.Err())
+	assert.True(t, h.MatchString("an error"))
+	assert.False(t, h.MatchString("all good"))
+}
@@ -0,0 +1,103 @@
+package tui
+
+// responsiveView builds its content for the space it is given.
+type responsiveView struct {
+	build func(width, height int) View
+
+	// The view last built, and the size it was built for
+	built          View
+	builtW, builtH int
+}
+
+// Responsive calls build with the space the view is given and shows the
+// view it returns, so a layout can change with the terminal's size without
+// keeping track of resize events:
+//
+//	tui.Responsive(func(w, h int) tui.View {
+//	    if w < 80 {
+//	        return tui.Stack(sidebar, content)
+//	    }
+//	    return tui.Group(sidebar, content)
+//	})
+//
+// A width or height of 0 means there is no limit, as when a view is measured
+// inside a ScrollView. build is called again only when the space changes.
+func Responsive(build func(width, height int) View) View {
+	return &responsiveView{build: build}
+}
+
+// viewFor returns the view built for width and height.
+func (r *responsiveView) viewFor(width, height int) View {
+	if r.built == nil || width != r.builtW || height != r.builtH {
+		r.built, r.builtW, r.builtH = r.build(width, height), width, height
+		if r.built == nil {
+			r.built = Empty()
+		}
+	}
+	return r.built
+}
+
+func (r *responsiveView) size(maxWidth, maxHeight int) (int, int) {
+	return r.viewFor(maxWidth, maxHeight).size(maxWidth, maxHeight)
+}
+
+func (r *responsiveView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	r.viewFor(width, height).render(ctx)
+}
+
+// breakpoint is a view to show when the space is under a limit.
+type breakpoint struct {
+	width, height int
+	view          View
+}
+
+// breakpointView picks a view by the space it is given.
+type breakpointView struct {
+	view        View
+	breakpoints []breakpoint
+}
+
+// Breakpoints shows view unless the space it is given is under one of the
+// breakpoints added with Below or BelowHeight, in which case it shows that
+// breakpoint's view instead. Breakpoints are checked in the order added, so
+// add the tightest first:
+//
+//	tui.Breakpoints(tui.Group(nav, content, details)).
+//	    Below(60, tui.Stack(nav, content)).
+//	    Below(100, tui.Group(nav, content))
+func Breakpoints(view View) *breakpointView {
+	return &breakpointView{view: view}
+}
+
+// Below shows view when the width is less than width.
+func (b *breakpointView) Below(width int, view View) *breakpointView {
+	b.breakpoints = append(b.breakpoints, breakpoint{width: width, view: view})
+	return b
+}
+
+// BelowHeight shows view when the height is less than height.
+func (b *breakpointView) BelowHeight(height int, view View) *breakpointView {
+	b.breakpoints = append(b.breakpoints, breakpoint{height: height, view: view})
+	return b
+}
+
+// viewFor returns the view for width and height, where 0 means no limit.
+func (b *breakpointView) viewFor(width, height int) View {
+	for _, bp := range b.breakpoints {
+		if (bp.width > 0 && width > 0 && width < bp.width) ||
+			(bp.height > 0 && height > 0 && height < bp.height) {
+			return bp.view
+		}
+	}
+	return b.view
+}
+
+func (b *breakpointView) size(maxWidth, maxHeight int) (int, int) {
+	return b.viewFor(maxWidth, maxHeight).size(maxWidth, maxHeight)
+}
+
+func (b *breakpointView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	b.viewFor(width, height).render(ctx)
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestResponsive(t *testing.T) {
+	calls := 0
+	view := Responsive(func(w, h int) View {
+		calls++
+		if w < 10 {
+			return Stack(Text("left"), Text("right"))
+		}
+		return Group(Text("left"), Spacer(), Text("right"))
+	})
+
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 2})
+	assert.Equal(t, "left           right", screen.Row(0))
+
+	screen = SprintScreen(view, PrintConfig{Width: 8, Height: 2})
+	assert.Equal(t, "left", screen.Row(0))
+	assert.Equal(t, "right", screen.Row(1))
+
+	// The same space reuses the view built for it
+	calls = 0
+	view.size(12, 2)
+	view.size(12, 2)
+	assert.Equal(t, 1, calls)
+}
+
+func TestBreakpoints(t *testing.T) {
+	view := Breakpoints(Text("wide")).
+		Below(10, Text("narrow")).
+		Below(20, Text("medium")).
+		BelowHeight(2, Text("short"))
+
+	assert.Equal(t, "wide", SprintScreen(view, PrintConfig{Width: 30, Height: 3}).Row(0))
+	assert.Equal(t, "medium", SprintScreen(view, PrintConfig{Width: 15, Height: 3}).Row(0))
+	assert.Equal(t, "narrow", SprintScreen(view, PrintConfig{Width: 8, Height: 1}).Row(0))
+	assert.Equal(t, "short", SprintScreen(view, PrintConfig{Width: 30, Height: 1}).Row(0))
+
+	// No limit is never under a breakpoint
+	w, _ := view.size(0, 0)
+	assert.Equal(t, 4, w)
+}
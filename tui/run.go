@@ -1,6 +1,12 @@
 package tui
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
 
 // RunOption is a functional option for configuring Run.
 type RunOption func(*runConfig)
@@ -13,6 +19,16 @@ type runConfig struct {
 	bracketedPaste  bool
 	pasteTabWidth   int
 	inputSource     InputSource
+	crashReports    bool
+	paletteKey      *KeyEvent
+	paletteActions  []Action
+	metrics         *AppMetrics
+	metricsAddr     string
+	controlSocket   string
+	theme           *Theme
+	colorProfile    *ColorProfile
+	onSlowFrame     func(SlowFrame)
+	fallback        FallbackMode
 }
 
 func defaultRunConfig() runConfig {
@@ -21,7 +37,9 @@ func defaultRunConfig() runConfig {
 		alternateScreen: true,
 		hideCursor:      true,
 		mouseTracking:   false,
+		bracketedPaste:  true,
 		pasteTabWidth:   0,
+		crashReports:    true,
 	}
 }
 
@@ -43,6 +61,44 @@ func WithFPS(fps int) RunOption {
 	}
 }
 
+// WithSlowFrameHandler calls fn with each tick that takes longer to handle
+// and draw than the time between frames at the configured FPS, such as to
+// log where an app is slow. fn runs on the event loop, so it should return
+// quickly. Animations stay on time regardless: the frames missed are skipped
+// and reported in the next TickEvent's Skipped.
+//
+// Example:
+//
+//	tui.Run(app, tui.WithSlowFrameHandler(func(f tui.SlowFrame) {
+//	    log.Printf("frame %d took %v (budget %v)", f.Frame, f.Duration, f.Budget)
+//	}))
+func WithSlowFrameHandler(fn func(SlowFrame)) RunOption {
+	return func(c *runConfig) {
+		c.onSlowFrame = fn
+	}
+}
+
+// WithFallbackRenderer sets when the application is shown as plain-text
+// snapshots of the screen instead of being drawn on the terminal, so the same
+// binary behaves sanely on dumb terminals and in CI logs. With FallbackAuto,
+// that is when TERM is "dumb" or stdout isn't a terminal. Default is
+// FallbackNever.
+//
+// In fallback mode the application runs as usual, laid out at COLUMNS by
+// LINES (80x24 if unset), but nothing moves the cursor or sets colors: the
+// text on the screen is printed whenever it changes, at most once a second,
+// with "---" between snapshots, and once more when the application quits.
+// Keyboard input is read from stdin without raw mode.
+//
+// Example:
+//
+//	tui.Run(app, tui.WithFallbackRenderer(tui.FallbackAuto))
+func WithFallbackRenderer(mode FallbackMode) RunOption {
+	return func(c *runConfig) {
+		c.fallback = mode
+	}
+}
+
 // WithAlternateScreen controls whether to use the alternate screen buffer.
 // When enabled (default), the terminal switches to a separate buffer and
 // restores the original content on exit.
@@ -68,9 +124,10 @@ func WithMouseTracking(enabled bool) RunOption {
 	}
 }
 
-// WithBracketedPaste enables bracketed paste mode.
+// WithBracketedPaste controls bracketed paste mode. Default is true.
 // When enabled, the terminal can distinguish pasted text from typed text,
-// allowing proper handling of multi-line pastes.
+// so a paste arrives as one PasteEvent instead of a KeyEvent per character,
+// and newlines in it don't act as Enter.
 func WithBracketedPaste(enabled bool) RunOption {
 	return func(c *runConfig) {
 		c.bracketedPaste = enabled
@@ -86,6 +143,104 @@ func WithPasteTabWidth(width int) RunOption {
 	}
 }
 
+// WithCrashReports controls whether a crash report is written when the
+// application panics. Default is true. The report holds the stack trace, the
+// most recent events, the last screen contents, and terminal details, and
+// its path is printed once the terminal is restored. See PanicError.
+func WithCrashReports(enabled bool) RunOption {
+	return func(c *runConfig) {
+		c.crashReports = enabled
+	}
+}
+
+// WithCommandPalette adds a command palette that opens over the application
+// when hotkey is pressed, listing actions. Typing filters the actions by
+// fuzzy match, Up and Down (or Ctrl+P and Ctrl+N) select one, Enter runs it,
+// and Escape or the hotkey closes the palette. The application doesn't
+// receive keys while the palette is open. The OpenCommandPalette command
+// opens it too.
+//
+// Example:
+//
+//	tui.Run(app, tui.WithCommandPalette(tui.KeyEvent{Key: tui.KeyCtrlP, Ctrl: true},
+//	    tui.Action{Name: "Toggle sidebar", Keybinding: "Ctrl+B", Handler: app.toggleSidebar},
+//	    tui.Action{Name: "Quit", Keybinding: "q", Handler: func() []tui.Cmd { return []tui.Cmd{tui.Quit()} }},
+//	))
+func WithCommandPalette(hotkey KeyEvent, actions ...Action) RunOption {
+	return func(c *runConfig) {
+		c.paletteKey = &hotkey
+		c.paletteActions = actions
+	}
+}
+
+// WithMetrics records the application's frame rate, render time, event
+// latency, and commands in m, alongside the counters and gauges the
+// application adds to it. If addr is not empty, Run also serves the metrics
+// over HTTP on addr while the application runs: in the Prometheus text
+// format at /metrics, and as the expvar variable "wonton" at /debug/vars.
+//
+// Example:
+//
+//	metrics := tui.NewAppMetrics()
+//	tui.Run(app, tui.WithMetrics(metrics, "localhost:9090"))
+func WithMetrics(m *AppMetrics, addr string) RunOption {
+	return func(c *runConfig) {
+		c.metrics = m
+		c.metricsAddr = addr
+	}
+}
+
+// WithControlSocket lets other programs drive the running application
+// through a Unix socket at path: pressing keys, typing and pasting text,
+// clicking, running command palette actions, and reading the text on the
+// screen. Use it for end-to-end tests of an installed binary and for
+// accessibility tools. The socket is readable and writable only by the
+// user running the application, and removed when it exits.
+//
+// Clients send one command per line and get back "ok", or "error: " and a
+// reason. Each command waits for the application to handle it and redraw,
+// so a following "screen" shows its effect:
+//
+//	key <key>...     press keys: j, J, enter, ctrl+p, shift+tab, alt+f, f5, space, ...
+//	type <text>      type text, one key per character
+//	paste <text>     paste text
+//	click <x> <y>    click the left mouse button at screen position x, y
+//	action <name>    run the command palette action with that name
+//	screen           reply "ok <n>" and then the n lines of text on the screen
+//	quit             quit the application
+//
+// Example:
+//
+//	tui.Run(app, tui.WithControlSocket(os.Getenv("MYAPP_CONTROL")))
+//
+//	// From a shell
+//	printf 'key j j enter\nscreen\n' | nc -U "$MYAPP_CONTROL"
+//
+// An empty path disables the socket.
+func WithControlSocket(path string) RunOption {
+	return func(c *runConfig) {
+		c.controlSocket = path
+	}
+}
+
+// WithTheme sets the theme views with semantic color roles are drawn with.
+// Default is DarkTheme. The SwitchTheme command changes it while the
+// application runs.
+func WithTheme(theme Theme) RunOption {
+	return func(c *runConfig) {
+		c.theme = &theme
+	}
+}
+
+// WithColorProfile sets the range of colors to render with, instead of the
+// one detected from COLORTERM and TERM, for example from a --color flag. RGB
+// colors are converted to the nearest colors of the profile.
+func WithColorProfile(profile ColorProfile) RunOption {
+	return func(c *runConfig) {
+		c.colorProfile = &profile
+	}
+}
+
 // Run is the simplest way to start a Wonton application.
 // It creates a terminal, configures it, runs the application, and cleans up.
 //
@@ -136,10 +291,27 @@ func Run(app any, opts ...RunOption) error {
 		opt(&cfg)
 	}
 
-	// Create terminal
-	terminal, err := NewTerminal()
-	if err != nil {
-		return err
+	// Serve metrics first, so a bad address fails before the terminal changes
+	if cfg.metrics != nil && cfg.metricsAddr != "" {
+		stop, err := serveMetrics(cfg.metrics, cfg.metricsAddr)
+		if err != nil {
+			return err
+		}
+		defer stop()
+	}
+
+	// Create terminal, or a screen in memory to print snapshots of
+	var terminal *Terminal
+	var snapshots *snapshotPrinter
+	if cfg.fallback.active() {
+		width, height := fallbackSize()
+		terminal = NewTestTerminal(width, height, io.Discard)
+		snapshots = newSnapshotPrinter(os.Stdout, time.Second)
+	} else {
+		var err error
+		if terminal, err = NewTerminal(); err != nil {
+			return err
+		}
 	}
 	defer terminal.Close()
 
@@ -156,10 +328,30 @@ func Run(app any, opts ...RunOption) error {
 	if cfg.bracketedPaste {
 		terminal.EnableBracketedPaste()
 	}
+	if cfg.colorProfile != nil {
+		terminal.SetColorProfile(*cfg.colorProfile)
+	}
 
 	// Create and configure runtime
 	runtime := NewRuntime(terminal, app, cfg.fps)
 	runtime.SetPasteTabWidth(cfg.pasteTabWidth)
+	runtime.SetCrashReports(cfg.crashReports)
+	runtime.SetMetrics(cfg.metrics)
+	runtime.SetSlowFrameHandler(cfg.onSlowFrame)
+	runtime.snapshots = snapshots
+	if cfg.theme != nil {
+		runtime.SetTheme(*cfg.theme)
+	}
+	if cfg.paletteKey != nil {
+		runtime.SetCommandPalette(*cfg.paletteKey, cfg.paletteActions)
+	}
+	if cfg.controlSocket != "" {
+		stop, err := serveControl(runtime, cfg.controlSocket)
+		if err != nil {
+			return err
+		}
+		defer stop()
+	}
 
 	// Ensure these modes are disabled on cleanup (terminal.Close doesn't handle this)
 	if cfg.mouseTracking {
@@ -170,5 +362,15 @@ func Run(app any, opts ...RunOption) error {
 	}
 
 	// Run the application
-	return runtime.Run()
+	err := runtime.Run()
+	snapshots.flush()
+
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) && panicErr.ReportPath != "" {
+		// Restore the terminal first so the message isn't lost with the
+		// alternate screen
+		terminal.Close()
+		fmt.Fprintf(os.Stderr, "The application crashed. A crash report was written to:\n  %s\n", panicErr.ReportPath)
+	}
+	return err
 }
@@ -29,10 +29,41 @@ type Runtime struct {
 	ticker   *time.Ticker
 	fps      int
 	frame    uint64 // Frame counter for TickEvents
+	clock    *frameClock
+
+	// Called with ticks that take longer than a frame, or nil
+	onSlowFrame func(SlowFrame)
+
+	// Prints the screen as text after each frame in fallback mode, or nil
+	snapshots *snapshotPrinter
 
 	// Focus management
 	focusMgr *FocusManager
 
+	// ID of the Zoomable view drawn full-screen, or "" for none
+	zoomID string
+
+	// Terminal cursor placed by a view with RenderContext.SetCursor
+	cursor cursorRequest
+
+	// Command palette set up with SetCommandPalette, or nil
+	palette *commandPalette
+
+	// Crash reporting
+	history      *eventHistory // Recent events, touched only by the event loop
+	panicErr     *PanicError   // First panic recovered in the event loop or a command
+	crashReports bool          // Write a crash report file when the app panics
+
+	// Metrics set up with SetMetrics, or nil
+	metrics *AppMetrics
+
+	// Theme views are drawn with, or nil for the default theme
+	theme *Theme
+
+	// Results of control commands run since the last frame, sent once it
+	// is drawn
+	controlResults []controlResult
+
 	mu          sync.Mutex
 	running     bool
 	resizeUnsub func() // Unsubscribe function for resize callback
@@ -69,8 +100,11 @@ func NewRuntime(terminal *Terminal, app any, fps int) *Runtime {
 		done:          make(chan struct{}),
 		fps:           fps,
 		frame:         0,
+		clock:         newFrameClock(fps),
 		pasteTabWidth: 0, // Default: preserve tabs
 		focusMgr:      NewFocusManager(),
+		history:       newEventHistory(crashHistorySize),
+		crashReports:  true,
 	}
 }
 
@@ -82,6 +116,38 @@ func (r *Runtime) SetPasteTabWidth(width int) {
 	r.pasteTabWidth = width
 }
 
+// SetCrashReports controls whether a crash report file is written when the
+// application panics. Default is true. Either way, a panic in HandleEvent,
+// View, or a command restores the terminal and makes Run return a
+// *PanicError. Must be called before Run().
+func (r *Runtime) SetCrashReports(enabled bool) {
+	r.crashReports = enabled
+}
+
+// SetMetrics makes the runtime record its frame rate, render time, event
+// latency, and commands in m, along with the terminal's render metrics,
+// which it enables. Must be called before Run().
+func (r *Runtime) SetMetrics(m *AppMetrics) {
+	r.metrics = m
+	if m != nil {
+		m.attach(r.cmds, r.terminal)
+	}
+}
+
+// SetSlowFrameHandler sets a function called with each tick that takes
+// longer to handle and draw than the time between frames. Must be called
+// before Run(). See WithSlowFrameHandler.
+func (r *Runtime) SetSlowFrameHandler(fn func(SlowFrame)) {
+	r.onSlowFrame = fn
+}
+
+// SetTheme sets the theme views with semantic color roles are drawn with.
+// Default is DarkTheme. The SwitchTheme command changes it while the
+// application runs.
+func (r *Runtime) SetTheme(theme Theme) {
+	r.theme = &theme
+}
+
 // Run starts the runtime's event loop and blocks until the application quits.
 // This method is the main entry point for message-driven applications.
 //
@@ -92,7 +158,8 @@ func (r *Runtime) SetPasteTabWidth(width int) {
 //  4. Block until QuitEvent is received
 //  5. Clean up and call Destroy (if implemented)
 //
-// Returns error if initialization fails.
+// Returns error if initialization fails, or a *PanicError if the
+// application panics.
 func (r *Runtime) Run() error {
 	r.mu.Lock()
 	if r.running {
@@ -111,21 +178,21 @@ func (r *Runtime) Run() error {
 
 	// Enable raw mode for character-by-character input
 	// Only enable if stdin is actually a terminal (not piped or redirected)
-	if term.IsTerminal(int(os.Stdin.Fd())) {
+	if r.snapshots == nil && term.IsTerminal(int(os.Stdin.Fd())) {
 		// Detect Kitty keyboard protocol support before enabling raw mode
 		// This probes the terminal and enables the protocol if supported
 		r.terminal.DetectKittyProtocol()
+		r.terminal.DetectBackground()
 
 		if err := r.terminal.EnableRawMode(); err != nil {
 			return fmt.Errorf("failed to enable raw mode: %w", err)
 		}
 
-		// Enable Kitty keyboard protocol if the terminal supports it
-		// This allows detection of modifier keys (Shift+Enter, etc.)
-		// For terminals that don't support it, backslash+Enter fallback is used
-		if r.terminal.IsKittyProtocolSupported() {
-			r.terminal.EnableEnhancedKeyboard()
-		}
+		// Enable the kitty keyboard protocol, or modifyOtherKeys where it
+		// isn't supported, so modifiers are reported with keys like Enter
+		// (Shift+Enter, Ctrl+Enter, etc.). For terminals that support
+		// neither, backslash+Enter fallback is used
+		r.terminal.NegotiateKeyboard()
 	}
 
 	// Register resize handler
@@ -159,6 +226,12 @@ func (r *Runtime) Run() error {
 	// Goroutine 1: Main event loop
 	go func() {
 		defer wg.Done()
+		defer func() {
+			if p := recover(); p != nil {
+				r.recordPanic(p)
+				close(r.done)
+			}
+		}()
 		r.eventLoop()
 	}()
 
@@ -183,11 +256,20 @@ func (r *Runtime) Run() error {
 	if r.resizeUnsub != nil {
 		r.resizeUnsub()
 	}
-	if r.terminal.IsKittyProtocolEnabled() {
-		r.terminal.DisableEnhancedKeyboard()
-	}
+	r.terminal.DisableEnhancedKeyboard()
+	r.terminal.DisableModifyOtherKeys()
 	r.terminal.DisableRawMode()
 
+	r.mu.Lock()
+	panicErr := r.panicErr
+	r.mu.Unlock()
+	if panicErr != nil && r.crashReports {
+		path, err := r.writeCrashReport(panicErr, r.terminal.ScreenText(), r.history.list())
+		if err == nil {
+			panicErr.ReportPath = path
+		}
+	}
+
 	// Call Destroy if implemented
 	if destroy, ok := r.app.(Destroyable); ok {
 		destroy.Destroy()
@@ -197,6 +279,9 @@ func (r *Runtime) Run() error {
 	r.running = false
 	r.mu.Unlock()
 
+	if panicErr != nil {
+		return panicErr
+	}
 	return nil
 }
 
@@ -244,13 +329,15 @@ func (r *Runtime) eventLoop() {
 
 		case <-r.ticker.C:
 			// Send tick event for animations
-			r.frame++
-			tickEvent := TickEvent{
-				Time:  time.Now(),
-				Frame: r.frame,
-			}
+			tickEvent := r.clock.tick(time.Now())
+			r.frame = tickEvent.Frame
 			r.processEvent(tickEvent)
 			r.render()
+			if r.onSlowFrame != nil {
+				if slow, ok := r.clock.slowFrame(tickEvent, time.Since(tickEvent.Time)); ok {
+					r.onSlowFrame(slow)
+				}
+			}
 
 		case <-r.done:
 			return
@@ -272,9 +359,11 @@ func (r *Runtime) processEventWithQuitCheck(event Event) bool {
 				return true
 			}
 			r.processEvent(e)
+			r.metrics.recordEvent(e)
 		}
 	} else {
 		r.processEvent(event)
+		r.metrics.recordEvent(event)
 	}
 
 	return false
@@ -282,6 +371,8 @@ func (r *Runtime) processEventWithQuitCheck(event Event) bool {
 
 // processEvent calls the application's HandleEvent (if implemented) and queues any returned commands.
 func (r *Runtime) processEvent(event Event) {
+	r.history.add(event)
+
 	// Handle focus events from commands
 	switch e := event.(type) {
 	case FocusSetEvent:
@@ -293,21 +384,64 @@ func (r *Runtime) processEvent(event Event) {
 	case FocusPrevEvent:
 		r.focusMgr.FocusPrev()
 		return
+	case ZoomEvent:
+		r.zoomID = e.nextZoom(r.zoomID)
+		return
+	case ThemeEvent:
+		// The application sees it too, to remember the choice
+		r.SetTheme(e.Theme)
+	case CommandPaletteEvent:
+		if r.palette != nil {
+			r.palette.show()
+		}
+		return
+	case controlEvent:
+		r.controlResults = append(r.controlResults, controlResult{e.result, e.run()})
+		return
+	}
+
+	// The command palette takes all keys while it is open
+	if r.palette != nil {
+		if key, ok := event.(KeyEvent); ok {
+			if r.palette.open {
+				r.queueCmds(r.palette.handleKey(key))
+				return
+			}
+			if matchKey(key, r.palette.hotkey) {
+				r.palette.show()
+				return
+			}
+		}
 	}
 
 	// Route events to interactive elements via focus manager
 	switch e := event.(type) {
 	case MouseEvent:
-		if e.Type == MouseClick {
+		interactiveRegistry.HandleHover(e)
+		// A right click on a view with a context menu opens it instead of
+		// clicking what's beneath
+		rightClicked := e.Type == MouseClick && e.Button == MouseButtonRight &&
+			interactiveRegistry.HandleRightClick(e.X, e.Y)
+		if e.Type == MouseClick && !rightClicked {
 			// Check if the click hit a focusable element
 			r.focusMgr.HandleClick(e.X, e.Y)
 			// Check if the click hit a non-focusable interactive region
 			interactiveRegistry.HandleClick(e.X, e.Y)
 		}
+		if delta := wheelDelta(e); delta != 0 {
+			interactiveRegistry.HandleScroll(e.X, e.Y, delta)
+		}
+		// A press clears the selection, unless it starts a new one
+		if e.Type == MousePress && e.Button == MouseButtonLeft {
+			selection.clear()
+		}
+		interactiveRegistry.HandleDrag(e)
 	case KeyEvent:
-		// Route key events to focused element (handles Tab/Shift+Tab navigation)
-		if r.focusMgr.HandleKey(e) {
-			// Focused element consumed the event, but still pass to app
+		// Route key events to open popups, then the focused element (handles
+		// Tab/Shift+Tab navigation), then view shortcuts. The app sees the
+		// event either way.
+		if !interactiveRegistry.HandleKeyCapture(e) && !r.focusMgr.HandleKey(e) {
+			interactiveRegistry.HandleKey(e)
 		}
 	}
 
@@ -317,14 +451,23 @@ func (r *Runtime) processEvent(event Event) {
 		cmds = handler.HandleEvent(event)
 	}
 
-	// Queue commands for async execution
-	if len(cmds) > 0 {
-		for _, cmd := range cmds {
-			select {
-			case r.cmds <- cmd:
-			case <-r.done:
-				return
-			}
+	r.queueCmds(cmds)
+
+	if key, ok := event.(KeyEvent); ok && key.Paste != "" {
+		r.processEvent(PasteEvent{Time: key.Timestamp(), Text: key.Paste})
+	}
+	if text, ok := selection.takeFinished(); ok {
+		r.processEvent(SelectionEvent{Text: text, Time: time.Now()})
+	}
+}
+
+// queueCmds queues commands for async execution.
+func (r *Runtime) queueCmds(cmds []Cmd) {
+	for _, cmd := range cmds {
+		select {
+		case r.cmds <- cmd:
+		case <-r.done:
+			return
 		}
 	}
 }
@@ -336,6 +479,18 @@ func (r *Runtime) render() {
 		// Terminal not ready, skip this frame
 		return
 	}
+	defer r.metrics.recordFrame(time.Now())
+	defer r.sendControlResults()
+	flushed := false
+	defer func() {
+		// Release the terminal if View or a view's render panics
+		if !flushed {
+			r.terminal.AbortFrame(frame)
+		}
+	}()
+
+	cursor := r.cursor
+	cursor.visible = false
 
 	if app, ok := r.app.(Application); ok {
 		// Application interface - use declarative View() rendering
@@ -345,6 +500,13 @@ func (r *Runtime) render() {
 		interactiveRegistry.Clear()
 		inputRegistry.Clear()
 		textAreaRegistry.Clear()
+		tableEditRegistry.Clear()
+		tableScrollRegistry.Clear()
+		tableLayoutRegistry.Clear()
+		tableDetailRegistry.Clear()
+		selectRegistry.Clear()
+		contextMenuRegistry.Clear()
+		transitionRegistry.Clear()
 
 		// Clear the frame before rendering. This ensures that when views shrink,
 		// old content outside their new bounds is erased. The double-buffering
@@ -352,22 +514,44 @@ func (r *Runtime) render() {
 		frame.Fill(' ', NewStyle())
 
 		view := app.View()
-		width, height := frame.Size()
 
 		// Create render context with frame counter and focus manager for animations
-		ctx := NewRenderContext(frame, r.frame).WithFocusManager(r.focusMgr)
+		ctx := NewRenderContext(frame, r.frame).WithFocusManager(r.focusMgr).withCursor(&cursor)
+		if bg, ok := r.terminal.Background(); ok {
+			ctx = ctx.WithBackground(bg)
+		}
+		if r.theme != nil {
+			ctx = ctx.WithTheme(*r.theme)
+		}
 
-		// Measure phase (populates cached child sizes)
-		view.size(width, height)
-		// Render phase
-		view.render(ctx)
+		// Measure and render, drawing only the zoomed view if there is one
+		renderWithZoom(ctx, view, r.zoomID)
+		ctx.drawOverlays()
+		if r.palette != nil && r.palette.open {
+			r.palette.render(ctx)
+		}
 
 		// Prune TextArea state for IDs that weren't rendered this frame
 		textAreaRegistry.Prune()
+		tableEditRegistry.Prune()
+		tableScrollRegistry.Prune()
+		tableLayoutRegistry.Prune()
+		tableDetailRegistry.Prune()
+		selectRegistry.Prune()
+		contextMenuRegistry.Prune()
+		transitionRegistry.Prune()
 	}
 
 	// Flush to screen (diffs and sends only dirty regions)
+	flushed = true
 	r.terminal.EndFrame(frame)
+	r.snapshots.update(r.terminal.ScreenText())
+
+	// Place or hide the terminal cursor if a view moved it
+	if cursor != r.cursor {
+		r.terminal.PlaceCursor(cursor.x, cursor.y, cursor.visible)
+		r.cursor = cursor
+	}
 }
 
 // SetInputSource sets the input source for the runtime.
@@ -588,7 +772,16 @@ func (r *Runtime) commandExecutor() {
 		select {
 		case cmd := <-r.cmds:
 			// Execute command in a new goroutine
+			r.metrics.cmdStarted()
 			go func(c Cmd) {
+				defer r.metrics.cmdDone()
+				defer func() {
+					if p := recover(); p != nil {
+						r.recordPanic(p)
+						r.Stop()
+					}
+				}()
+
 				// Execute the command (may take time)
 				event := c()
 
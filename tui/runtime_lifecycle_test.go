@@ -2,6 +2,10 @@ package tui
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -103,32 +107,119 @@ func TestRuntimeSendEvent(t *testing.T) {
 	assert.Equal(t, 3, model.counter.Load())
 }
 
-// TestRuntimePanic tests that the runtime handles panics
-// Currently, panics in HandleEvent will crash the runtime (expected behavior)
-// This test documents this behavior and can be updated when panic recovery is added
+// TestRuntimePanic tests that a panic in HandleEvent is recovered, the
+// runtime shuts down, and a crash report is written
 func TestRuntimePanic(t *testing.T) {
-	t.Skip("Panics in HandleEvent currently crash the runtime - this is expected behavior")
-
-	// When panic handling is implemented, this test can be enabled:
-	// var buf bytes.Buffer
-	// terminal := NewTestTerminal(80, 24, &buf)
-	//
-	// model := &testRuntimeModel{}
-	// runtime := NewRuntime(terminal, model, 30)
-	//
-	// // Send a panic event
-	// go func() {
-	// 	for {
-	// 		time.Sleep(1 * time.Millisecond)
-	// 		if model.executed.Load() != nil {
-	// 			runtime.SendEvent(panicEvent{})
-	// 			return
-	// 		}
-	// 	}
-	// }()
-	//
-	// err := runtime.Run()
-	// assert.Error(t, err, "should return error on panic")
+	var buf bytes.Buffer
+	terminal := NewTestTerminal(80, 24, &buf)
+
+	model := &testRuntimeModel{}
+	runtime := NewRuntime(terminal, model, 30)
+
+	// Send a panic event
+	go func() {
+		for {
+			time.Sleep(1 * time.Millisecond)
+			if model.executed.Load() != nil {
+				runtime.SendEvent(KeyEvent{Rune: 'x'})
+				runtime.SendEvent(panicEvent{})
+				return
+			}
+		}
+	}()
+
+	err := runtime.Run()
+	var panicErr *PanicError
+	assert.True(t, errors.As(err, &panicErr), "should return a PanicError")
+	assert.Equal(t, "testing panic behavior", panicErr.Value)
+	assert.True(t, strings.Contains(string(panicErr.Stack), "HandleEvent"))
+
+	assert.NotEqual(t, "", panicErr.ReportPath)
+	defer os.Remove(panicErr.ReportPath)
+	report, readErr := os.ReadFile(panicErr.ReportPath)
+	assert.NoError(t, readErr)
+	assert.True(t, strings.Contains(string(report), "panic: testing panic behavior"))
+	assert.True(t, strings.Contains(string(report), "KeyEvent key=0 rune=(1)"))
+	assert.True(t, strings.Contains(string(report), "tui.panicEvent"))
+	assert.True(t, strings.Contains(string(report), "== screen ==\ntest"))
+}
+
+// TestCrashReportRedactsTyping tests that characters typed into a masked
+// input don't appear in a crash report
+func TestCrashReportRedactsTyping(t *testing.T) {
+	runtime := NewRuntime(NewTestTerminal(40, 5, &bytes.Buffer{}), &simpleApp{}, 30)
+	history := newEventHistory(crashHistorySize)
+	password := ""
+	for _, r := range "hunter2" {
+		history.add(KeyEvent{Rune: r, Time: time.Now()})
+		password += string(r)
+	}
+	history.add(KeyEvent{Key: KeyEnter, Time: time.Now()})
+	screen := SprintScreen(InputField(&password).ID("password").Mask('•'), PrintConfig{Width: 40, Height: 3})
+
+	path, err := runtime.writeCrashReport(&PanicError{Value: "boom"}, screen.Text(), history.list())
+	assert.NoError(t, err)
+	defer os.Remove(path)
+	report, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	assert.False(t, strings.Contains(string(report), "hunter2"))
+	for _, r := range "hunter2" {
+		assert.False(t, strings.Contains(string(report), fmt.Sprintf("%q", r)))
+	}
+	assert.Equal(t, 7, strings.Count(string(report), "KeyEvent key=0 rune=(1)"))
+	assert.True(t, strings.Contains(string(report), fmt.Sprintf("KeyEvent key=%d\n", KeyEnter)))
+}
+
+// TestRuntimePanicInView tests that a panic while rendering releases the
+// terminal so the runtime can shut down
+func TestRuntimePanicInView(t *testing.T) {
+	var buf bytes.Buffer
+	terminal := NewTestTerminal(80, 24, &buf)
+
+	var renders atomic.Int32
+	app := &simpleApp{
+		handleFunc: func(Event) []Cmd { return nil },
+		renderFunc: func() View {
+			if renders.Add(1) > 1 {
+				panic("bad view")
+			}
+			return Text("ok")
+		},
+	}
+	runtime := NewRuntime(terminal, app, 30)
+	runtime.SetCrashReports(false)
+
+	err := runtime.Run()
+	var panicErr *PanicError
+	assert.True(t, errors.As(err, &panicErr), "should return a PanicError")
+	assert.Equal(t, "bad view", panicErr.Value)
+	assert.Equal(t, "", panicErr.ReportPath)
+	assert.Equal(t, "ok", terminal.ScreenText()[:2])
+}
+
+// TestRuntimePanicInCommand tests that a panic in an async command is
+// recovered
+func TestRuntimePanicInCommand(t *testing.T) {
+	var buf bytes.Buffer
+	terminal := NewTestTerminal(80, 24, &buf)
+
+	app := &simpleApp{
+		renderFunc: func() View { return Text("ok") },
+		handleFunc: func(e Event) []Cmd {
+			if _, ok := e.(ResizeEvent); ok {
+				return []Cmd{func() Event { panic("bad command") }}
+			}
+			return nil
+		},
+	}
+	runtime := NewRuntime(terminal, app, 30)
+	runtime.SetCrashReports(false)
+
+	err := runtime.Run()
+	var panicErr *PanicError
+	assert.True(t, errors.As(err, &panicErr), "should return a PanicError")
+	assert.Equal(t, "bad command", panicErr.Value)
 }
 
 // TestRuntimeMultipleQuits tests that multiple quit commands don't cause issues
@@ -1,6 +1,9 @@
 package tui
 
-import "image"
+import (
+	"image"
+	"sort"
+)
 
 // ScrollAnchor determines which part of content to show when content exceeds viewport.
 type ScrollAnchor int
@@ -17,6 +20,9 @@ type scrollView struct {
 	inner   View
 	scrollY *int         // external scroll position (optional)
 	anchor  ScrollAnchor // where to anchor when content exceeds viewport
+	search  *SearchController
+	// selectable enables selecting text with the mouse
+	selectable bool
 }
 
 // Scroll creates a scrollable viewport around the inner view.
@@ -46,6 +52,25 @@ func (s *scrollView) Bottom() *scrollView {
 	return s
 }
 
+// SearchWith binds a SearchController. The view searches the text its
+// content draws for the controller's query, highlights every match, and
+// scrolls the current match into view. Since the content can be any view,
+// it is drawn twice while a query is active: once offscreen to find its
+// text, and once to show it.
+func (s *scrollView) SearchWith(ctrl *SearchController) *scrollView {
+	s.search = ctrl
+	return s
+}
+
+// Selectable lets the user select the content's text by dragging the mouse
+// over it. The selection follows the content as it scrolls, and the
+// application receives a SelectionEvent with the selected text when the drag
+// ends. Requires mouse tracking (WithMouseTracking).
+func (s *scrollView) Selectable() *scrollView {
+	s.selectable = true
+	return s
+}
+
 func (s *scrollView) flex() int {
 	return 1 // Scroll views are flexible to fill available space
 }
@@ -73,9 +98,15 @@ func (s *scrollView) render(ctx *RenderContext) {
 	// Measure inner content without height constraint to get full content height
 	_, contentHeight := s.inner.size(viewportWidth, 0)
 
+	text := s.findMatches(ctx, viewportWidth, contentHeight)
+
 	// If content fits in viewport, just render directly
 	if contentHeight <= viewportHeight {
-		s.inner.render(ctx)
+		if s.search != nil {
+			s.search.revealLine(0, viewportHeight) // everything is in view
+		}
+		s.draw(ctx, ctx.RenderFrame(), viewportHeight, 0)
+		s.highlightMatches(ctx, text, 0, viewportHeight)
 		return
 	}
 
@@ -93,6 +124,13 @@ func (s *scrollView) render(ctx *RenderContext) {
 		scrollY = maxScroll
 	}
 
+	// Follow the current search match
+	if s.search != nil {
+		if target, ok := s.search.revealLine(scrollY, viewportHeight); ok {
+			scrollY = target
+		}
+	}
+
 	// Clamp scroll position
 	if scrollY < 0 {
 		scrollY = 0
@@ -115,11 +153,196 @@ func (s *scrollView) render(ctx *RenderContext) {
 		contentHeight: contentHeight,
 	}
 
-	// Create a new context with the scroll frame, preserving the frame counter
-	scrollCtx := ctx.WithFrame(offsetFrame)
+	// Render inner content, collecting its sticky headers
+	headers := &stickyHeaders{scrollY: scrollY}
+	s.draw(WithEnv(ctx, stickyKey, headers), offsetFrame, contentHeight, scrollY)
+	s.highlightMatches(ctx, text, scrollY, viewportHeight)
+	headers.pin(ctx)
+}
+
+// draw renders the content to frame, which is as wide as the viewport and
+// contentHeight rows tall, and is shown scrolled down by scrollY. Content
+// drawn to a selectable view is kept for copying.
+func (s *scrollView) draw(ctx *RenderContext, frame RenderFrame, contentHeight, scrollY int) {
+	if !s.selectable {
+		s.inner.render(ctx.WithFrame(frame))
+		return
+	}
+	width, _ := ctx.Size()
+	renderSelectable(ctx, frame, width, contentHeight, scrollY, func(frame RenderFrame) {
+		s.inner.render(ctx.WithFrame(frame))
+	})
+}
+
+// findMatches draws the content offscreen and searches its text. It returns
+// the text, or nil when there is nothing to search for.
+func (s *scrollView) findMatches(ctx *RenderContext, width, height int) *textFrame {
+	if s.search == nil {
+		return nil
+	}
+	if s.search.Query == "" {
+		s.search.setMatches(nil)
+		return nil
+	}
+	text := newTextFrame(width, height)
+	// Without a focus manager or overlays, and with its clickable regions
+	// dropped, the offscreen render leaves no trace
+	mark := interactiveRegistry.mark()
+	s.inner.render(&RenderContext{
+		frame:      text,
+		frameCount: ctx.frameCount,
+		bounds:     image.Rect(0, 0, width, height),
+		zoom:       ctx.zoom,
+	})
+	interactiveRegistry.reset(mark)
+	s.search.setMatches(findMatches(text.lines(), s.search.Query, s.search.CaseSensitive))
+	return text
+}
+
+// highlightMatches redraws the visible matches in the search styles.
+func (s *scrollView) highlightMatches(ctx *RenderContext, text *textFrame, scrollY, height int) {
+	if text == nil {
+		return
+	}
+	for i, m := range s.search.matches {
+		row := m.Line - scrollY
+		if row < 0 || row >= height {
+			continue
+		}
+		style := s.search.matchStyle()
+		if i == s.search.current {
+			style = s.search.currentStyle()
+		}
+		for col := m.Start; col < m.End; col++ {
+			if r := text.cell(col, m.Line); r != 0 {
+				ctx.SetCell(col, row, r, style)
+			}
+		}
+	}
+}
+
+// stickyView marks its inner view as a sticky header.
+type stickyView struct {
+	inner View
+}
+
+// Sticky marks header as a sticky header of the Scroll it is in: once it
+// scrolls past the top of the viewport it stays pinned there, until the
+// next sticky header comes up and pushes it out. Use it for the section
+// headers of a long list, such as the dates of a commit log, so the section
+// being read is always labeled.
+//
+// Headers are found where the Scroll's content draws them, so they can be
+// nested anywhere in it. Outside a Scroll, Sticky draws header as it is.
+//
+// Example:
+//
+//	tui.Scroll(tui.ForEach(app.days, func(day Day, _ int) tui.View {
+//	    return tui.Stack(
+//	        tui.Sticky(tui.Text(day.Date).Bold().Bg(tui.ColorBrightBlack)),
+//	        tui.ForEach(day.Commits, commitRow),
+//	    )
+//	}), &app.scrollY)
+func Sticky(header View) *stickyView {
+	return &stickyView{inner: header}
+}
+
+func (v *stickyView) size(maxWidth, maxHeight int) (int, int) {
+	return v.inner.size(maxWidth, maxHeight)
+}
+
+func (v *stickyView) flex() int {
+	if flex, ok := v.inner.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (v *stickyView) render(ctx *RenderContext) {
+	if headers, ok := lookupEnv(ctx, stickyKey); ok {
+		if x, y, ok := scrollPosition(ctx.RenderFrame()); ok {
+			width, height := ctx.Size()
+			headers.list = append(headers.list, stickyHeader{
+				view:   v.inner,
+				bounds: image.Rect(x, headers.scrollY+y, x+width, headers.scrollY+y+height),
+			})
+		}
+	}
+	v.inner.render(ctx)
+}
 
-	// Render inner content
-	s.inner.render(scrollCtx)
+// stickyKey is the environment key of the sticky headers a Scroll's content
+// draws.
+var stickyKey = NewEnvKey[*stickyHeaders]("sticky", nil)
+
+// stickyHeaders collects the sticky headers drawn to a Scroll's content, in
+// content coordinates.
+type stickyHeaders struct {
+	scrollY int
+	list    []stickyHeader
+}
+
+type stickyHeader struct {
+	view   View
+	bounds image.Rectangle
+}
+
+// pin redraws the header whose section is at the top of the viewport over
+// the content, pushed up by the next header as it arrives.
+func (h *stickyHeaders) pin(ctx *RenderContext) {
+	sort.SliceStable(h.list, func(i, j int) bool {
+		return h.list[i].bounds.Min.Y < h.list[j].bounds.Min.Y
+	})
+	pinned := -1
+	for i, header := range h.list {
+		if header.bounds.Min.Y < h.scrollY {
+			pinned = i
+		}
+	}
+	if pinned < 0 {
+		return
+	}
+	header := h.list[pinned]
+	height := header.bounds.Dy()
+	row := 0
+	for _, next := range h.list[pinned+1:] {
+		if next.bounds.Min.Y > header.bounds.Min.Y {
+			row = min(0, next.bounds.Min.Y-h.scrollY-height)
+			break
+		}
+	}
+	if row+height <= 0 {
+		return
+	}
+
+	width, viewportHeight := ctx.Size()
+	ctx.FillStyled(header.bounds.Min.X, 0, header.bounds.Dx(), row+height, ' ', NewStyle())
+	frame := &scrollRenderFrame{
+		inner:         ctx.RenderFrame(),
+		offsetY:       -row,
+		clipH:         viewportHeight,
+		clipW:         width,
+		contentHeight: height,
+	}
+	header.view.render(ctx.WithFrame(frame).SubContext(image.Rect(header.bounds.Min.X, 0, header.bounds.Max.X, height)))
+}
+
+// scrollPosition returns where frame is in the content of the Scroll it
+// draws to, relative to the top of the viewport, and false if it isn't a
+// Scroll's frame.
+func scrollPosition(frame RenderFrame) (x, y int, ok bool) {
+	for {
+		switch f := frame.(type) {
+		case *scrollRenderFrame:
+			return f.offsetX, -f.offsetY, true
+		case *styleRenderFrame:
+			frame = f.RenderFrame
+		case *teeRenderFrame:
+			frame = f.RenderFrame
+		default:
+			return 0, 0, false
+		}
+	}
 }
 
 // scrollRenderFrame wraps a RenderFrame and applies a vertical offset,
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+// stickySections returns a list of sections, each a sticky header and rows.
+func stickySections(names ...string) View {
+	var sections []View
+	for _, name := range names {
+		rows := []View{Sticky(Text("== %s ==", name))}
+		for i := range 4 {
+			rows = append(rows, Text("%s%d", name, i))
+		}
+		sections = append(sections, Stack(rows...))
+	}
+	return Stack(sections...)
+}
+
+func TestSticky_PinsHeader(t *testing.T) {
+	scrollY := 0
+	view := func() View { return Scroll(stickySections("a", "b"), &scrollY) }
+
+	screen := SprintScreen(view(), PrintConfig{Width: 10, Height: 3})
+	assert.Equal(t, "== a ==", screen.Row(0))
+	assert.Equal(t, "a0", screen.Row(1))
+
+	// Scrolled into the section, its header stays at the top
+	scrollY = 2
+	screen = SprintScreen(view(), PrintConfig{Width: 10, Height: 3})
+	assert.Equal(t, "== a ==", screen.Row(0))
+	assert.Equal(t, "a2", screen.Row(1))
+	assert.Equal(t, "a3", screen.Row(2))
+
+	// until the next header comes up below it, and then pushes it out
+	scrollY = 4
+	screen = SprintScreen(view(), PrintConfig{Width: 10, Height: 3})
+	assert.Equal(t, "== a ==", screen.Row(0))
+	assert.Equal(t, "== b ==", screen.Row(1))
+	scrollY = 5
+	screen = SprintScreen(view(), PrintConfig{Width: 10, Height: 3})
+	assert.Equal(t, "== b ==", screen.Row(0))
+	assert.Equal(t, "b0", screen.Row(1))
+
+	scrollY = 7
+	screen = SprintScreen(view(), PrintConfig{Width: 10, Height: 3})
+	assert.Equal(t, "== b ==", screen.Row(0))
+	assert.Equal(t, "b2", screen.Row(1))
+}
+
+func TestSticky_PushedPartly(t *testing.T) {
+	scrollY := 2
+	view := Scroll(Stack(
+		Sticky(Stack(Text("top a"), Text("bottom a"))), Text("row"),
+		Sticky(Stack(Text("top b"), Text("bottom b"))), Text("row"), Text("row"),
+	), &scrollY)
+
+	// The second header is at the second row, pushing the first, two rows
+	// tall, up by one
+	screen := SprintScreen(view, PrintConfig{Width: 10, Height: 3})
+	assert.Equal(t, "bottom a", screen.Row(0))
+	assert.Equal(t, "top b", screen.Row(1))
+	assert.Equal(t, "bottom b", screen.Row(2))
+}
+
+func TestSticky_OutsideScroll(t *testing.T) {
+	screen := SprintScreen(Stack(Sticky(Text("header")), Text("body")), PrintConfig{Width: 10, Height: 2})
+	assert.Equal(t, "header", screen.Row(0))
+	assert.Equal(t, "body", screen.Row(1))
+
+	w, h := Sticky(Text("header")).size(20, 5)
+	assert.Equal(t, 6, w)
+	assert.Equal(t, 1, h)
+}
@@ -0,0 +1,427 @@
+package tui
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// SearchMatch is the location of a single search match in line-based content.
+// Columns are display columns (wide characters count as two) relative to the
+// start of the line's text, excluding any indentation the view adds.
+type SearchMatch struct {
+	Line  int // Line index within the view's content
+	Start int // First display column of the match
+	End   int // Display column just past the match
+}
+
+// SearchController holds "/"-style incremental search state that is shared
+// between a searchable view and the application's key handling.
+//
+// The controller lives in application state. Views bound to it (for example
+// Markdown(...).SearchWith(ctrl)) compute matches against their own content
+// each render, highlight them, and scroll the current match into view. The
+// application forwards key events to HandleKey, which implements:
+//
+//   - "/" starts typing a query; Enter commits it, Escape cancels
+//   - n / N jump to the next / previous match
+//   - Escape (outside of typing) clears the active search
+//
+// Example:
+//
+//	type App struct {
+//	    scrollY int
+//	    search  tui.SearchController
+//	}
+//
+//	func (a *App) HandleEvent(e tui.Event) []tui.Cmd {
+//	    if key, ok := e.(tui.KeyEvent); ok && a.search.HandleKey(key) {
+//	        return nil
+//	    }
+//	    ...
+//	}
+//
+//	func (a *App) View() tui.View {
+//	    return tui.Stack(
+//	        tui.Markdown(a.doc, &a.scrollY).SearchWith(&a.search),
+//	        tui.SearchBar(&a.search),
+//	    )
+//	}
+type SearchController struct {
+	// Query is the committed search text. Empty means no search.
+	Query string
+	// CaseSensitive enables case-sensitive matching (default is insensitive).
+	CaseSensitive bool
+	// MatchStyle highlights every match. Zero value uses a yellow background.
+	MatchStyle Style
+	// CurrentStyle highlights the current match. Zero value uses a bold, bright background.
+	CurrentStyle Style
+
+	typing        bool
+	input         string
+	matches       []SearchMatch
+	current       int
+	scrollPending bool
+}
+
+// NewSearchController creates a search controller with default styles.
+func NewSearchController() *SearchController {
+	return &SearchController{}
+}
+
+// Begin enters query-typing mode, as if the user pressed "/".
+func (s *SearchController) Begin() {
+	s.typing = true
+	s.input = ""
+}
+
+// Typing reports whether the user is currently typing a query.
+func (s *SearchController) Typing() bool {
+	return s.typing
+}
+
+// Input returns the query text being typed.
+func (s *SearchController) Input() string {
+	return s.input
+}
+
+// Active reports whether a query is committed or being typed.
+func (s *SearchController) Active() bool {
+	return s.typing || s.Query != ""
+}
+
+// SetQuery commits a new query and moves to its first match.
+func (s *SearchController) SetQuery(query string) {
+	s.Query = query
+	s.current = 0
+	s.scrollPending = query != ""
+}
+
+// Clear removes the active query and any typing state.
+func (s *SearchController) Clear() {
+	s.Query = ""
+	s.typing = false
+	s.input = ""
+	s.matches = nil
+	s.current = 0
+	s.scrollPending = false
+}
+
+// Next moves to the next match, wrapping around at the end.
+func (s *SearchController) Next() {
+	if len(s.matches) == 0 {
+		return
+	}
+	s.current = (s.current + 1) % len(s.matches)
+	s.scrollPending = true
+}
+
+// Prev moves to the previous match, wrapping around at the start.
+func (s *SearchController) Prev() {
+	if len(s.matches) == 0 {
+		return
+	}
+	s.current = (s.current - 1 + len(s.matches)) % len(s.matches)
+	s.scrollPending = true
+}
+
+// Matches returns the matches found during the last render.
+func (s *SearchController) Matches() []SearchMatch {
+	return s.matches
+}
+
+// Count returns the number of matches found during the last render.
+func (s *SearchController) Count() int {
+	return len(s.matches)
+}
+
+// Index returns the zero-based index of the current match, or -1 if there are no matches.
+func (s *SearchController) Index() int {
+	if len(s.matches) == 0 {
+		return -1
+	}
+	return s.current
+}
+
+// Current returns the current match, if any.
+func (s *SearchController) Current() (SearchMatch, bool) {
+	if len(s.matches) == 0 {
+		return SearchMatch{}, false
+	}
+	return s.matches[s.current], true
+}
+
+// Status returns a short match counter such as "3/12", "no matches", or "".
+func (s *SearchController) Status() string {
+	if s.Query == "" {
+		return ""
+	}
+	if len(s.matches) == 0 {
+		return "no matches"
+	}
+	return fmt.Sprintf("%d/%d", s.current+1, len(s.matches))
+}
+
+// HandleKey processes search key bindings. It returns true if the event was
+// consumed. While typing, all printable keys are consumed.
+func (s *SearchController) HandleKey(event KeyEvent) bool {
+	if s.typing {
+		switch event.Key {
+		case KeyEnter:
+			s.typing = false
+			s.SetQuery(s.input)
+		case KeyEscape:
+			s.typing = false
+			s.input = ""
+		case KeyBackspace:
+			if s.input == "" {
+				s.typing = false
+			} else {
+				r := []rune(s.input)
+				s.input = string(r[:len(r)-1])
+			}
+		case KeyCtrlU:
+			s.input = ""
+		default:
+			if event.Paste != "" {
+				s.input += event.Paste
+			} else if event.Key == KeyUnknown && event.Rune != 0 && !event.Ctrl && !event.Alt {
+				s.input += string(event.Rune)
+			}
+		}
+		return true
+	}
+
+	if event.Key == KeyUnknown && !event.Ctrl && !event.Alt {
+		switch event.Rune {
+		case '/':
+			s.Begin()
+			return true
+		case 'n':
+			if s.Query != "" {
+				s.Next()
+				return true
+			}
+		case 'N':
+			if s.Query != "" {
+				s.Prev()
+				return true
+			}
+		}
+	}
+	if event.Key == KeyEscape && s.Query != "" {
+		s.Clear()
+		return true
+	}
+	return false
+}
+
+// setMatches is called by bound views after searching their content.
+func (s *SearchController) setMatches(matches []SearchMatch) {
+	s.matches = matches
+	if s.current >= len(matches) {
+		s.current = 0
+	}
+}
+
+// revealLine returns the scroll offset that brings the current match into a
+// viewport of the given height, and consumes the pending scroll request.
+// ok is false when no scrolling is needed.
+func (s *SearchController) revealLine(scrollY, height int) (int, bool) {
+	if !s.scrollPending {
+		return scrollY, false
+	}
+	s.scrollPending = false
+	m, found := s.Current()
+	if !found {
+		return scrollY, false
+	}
+	if m.Line >= scrollY && m.Line < scrollY+height {
+		return scrollY, false
+	}
+	target := m.Line - height/3
+	if target < 0 {
+		target = 0
+	}
+	return target, true
+}
+
+func (s *SearchController) matchStyle() Style {
+	if s.MatchStyle == (Style{}) || s.MatchStyle.IsEmpty() {
+		return NewStyle().WithBackground(ColorYellow).WithForeground(ColorBlack)
+	}
+	return s.MatchStyle
+}
+
+func (s *SearchController) currentStyle() Style {
+	if s.CurrentStyle == (Style{}) || s.CurrentStyle.IsEmpty() {
+		return NewStyle().WithBackground(ColorBrightYellow).WithForeground(ColorBlack).WithBold()
+	}
+	return s.CurrentStyle
+}
+
+// highlightSegments splits segments on a single line so that matched columns
+// use the controller's highlight styles.
+func (s *SearchController) highlightSegments(segments []StyledSegment, line int) []StyledSegment {
+	var lineMatches []SearchMatch
+	currentIdx := -1
+	for i, m := range s.matches {
+		if m.Line == line {
+			if i == s.current {
+				currentIdx = len(lineMatches)
+			}
+			lineMatches = append(lineMatches, m)
+		}
+	}
+	if len(lineMatches) == 0 {
+		return segments
+	}
+
+	styleAt := func(col int) (Style, bool) {
+		for i, m := range lineMatches {
+			if col >= m.Start && col < m.End {
+				if i == currentIdx {
+					return s.currentStyle(), true
+				}
+				return s.matchStyle(), true
+			}
+		}
+		return Style{}, false
+	}
+
+	var result []StyledSegment
+	col := 0
+	for _, seg := range segments {
+		var piece []rune
+		pieceStyle, pieceHit := styleAt(col)
+		flush := func() {
+			if len(piece) == 0 {
+				return
+			}
+			out := seg
+			out.Text = string(piece)
+			if pieceHit {
+				out.Style = pieceStyle
+			}
+			result = append(result, out)
+			piece = piece[:0]
+		}
+		for _, r := range seg.Text {
+			st, hit := styleAt(col)
+			if hit != pieceHit || st != pieceStyle {
+				flush()
+				pieceStyle, pieceHit = st, hit
+			}
+			piece = append(piece, r)
+			col += runewidth.RuneWidth(r)
+		}
+		flush()
+	}
+	return result
+}
+
+// findMatches searches plain-text lines for query and returns every
+// non-overlapping match in document order.
+func findMatches(lines []string, query string, caseSensitive bool) []SearchMatch {
+	if query == "" {
+		return nil
+	}
+	needle := []rune(query)
+	if !caseSensitive {
+		needle = foldRunes(needle)
+	}
+
+	var matches []SearchMatch
+	for lineIdx, text := range lines {
+		hay := []rune(text)
+		if !caseSensitive {
+			hay = foldRunes(hay)
+		}
+		col := 0
+		for i := 0; i+len(needle) <= len(hay); {
+			if runesEqual(hay[i:i+len(needle)], needle) {
+				width := 0
+				for _, r := range hay[i : i+len(needle)] {
+					width += runewidth.RuneWidth(r)
+				}
+				matches = append(matches, SearchMatch{Line: lineIdx, Start: col, End: col + width})
+				col += width
+				i += len(needle)
+				continue
+			}
+			col += runewidth.RuneWidth(hay[i])
+			i++
+		}
+	}
+	return matches
+}
+
+func foldRunes(rs []rune) []rune {
+	out := make([]rune, len(rs))
+	for i, r := range rs {
+		out[i] = unicode.ToLower(r)
+	}
+	return out
+}
+
+func runesEqual(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// searchBarView renders the search prompt or match counter.
+type searchBarView struct {
+	ctrl  *SearchController
+	style Style
+}
+
+// SearchBar returns a one-line view showing the search prompt while typing
+// ("/query") or the match counter once a query is committed. It renders
+// nothing when no search is active.
+func SearchBar(ctrl *SearchController) *searchBarView {
+	return &searchBarView{ctrl: ctrl, style: NewStyle()}
+}
+
+// Style sets the text style of the search bar.
+func (v *searchBarView) Style(s Style) *searchBarView {
+	v.style = s
+	return v
+}
+
+func (v *searchBarView) text() string {
+	if v.ctrl == nil {
+		return ""
+	}
+	if v.ctrl.typing {
+		return "/" + v.ctrl.input + "█"
+	}
+	if v.ctrl.Query != "" {
+		return fmt.Sprintf("/%s  %s", v.ctrl.Query, v.ctrl.Status())
+	}
+	return ""
+}
+
+func (v *searchBarView) size(maxWidth, maxHeight int) (int, int) {
+	text := v.text()
+	if text == "" {
+		return 0, 0
+	}
+	w := runewidth.StringWidth(text)
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	return w, 1
+}
+
+func (v *searchBarView) render(ctx *RenderContext) {
+	text := v.text()
+	if text == "" {
+		return
+	}
+	ctx.PrintTruncated(0, 0, text, v.style)
+}
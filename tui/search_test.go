@@ -0,0 +1,181 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestFindMatches(t *testing.T) {
+	lines := []string{"Hello world", "no match here", "WORLD world"}
+
+	matches := findMatches(lines, "world", false)
+	assert.Equal(t, []SearchMatch{
+		{Line: 0, Start: 6, End: 11},
+		{Line: 2, Start: 0, End: 5},
+		{Line: 2, Start: 6, End: 11},
+	}, matches)
+
+	matches = findMatches(lines, "WORLD", true)
+	assert.Equal(t, []SearchMatch{{Line: 2, Start: 0, End: 5}}, matches)
+
+	assert.Equal(t, 0, len(findMatches(lines, "", false)))
+}
+
+func TestFindMatches_WideCharacters(t *testing.T) {
+	matches := findMatches([]string{"日本語 text"}, "text", false)
+	assert.Equal(t, []SearchMatch{{Line: 0, Start: 7, End: 11}}, matches)
+}
+
+func TestSearchController_HandleKey(t *testing.T) {
+	s := NewSearchController()
+
+	assert.False(t, s.HandleKey(KeyEvent{Rune: 'n'}), "n without a query is not consumed")
+	assert.True(t, s.HandleKey(KeyEvent{Rune: '/'}))
+	assert.True(t, s.Typing())
+
+	for _, r := range "fox" {
+		s.HandleKey(KeyEvent{Rune: r})
+	}
+	s.HandleKey(KeyEvent{Key: KeyBackspace})
+	assert.Equal(t, "fo", s.Input())
+	s.HandleKey(KeyEvent{Key: KeyEnter})
+	assert.False(t, s.Typing())
+	assert.Equal(t, "fo", s.Query)
+
+	s.setMatches([]SearchMatch{{Line: 1}, {Line: 5}, {Line: 9}})
+	assert.Equal(t, "1/3", s.Status())
+	assert.True(t, s.HandleKey(KeyEvent{Rune: 'n'}))
+	assert.Equal(t, 1, s.Index())
+	assert.True(t, s.HandleKey(KeyEvent{Rune: 'N'}))
+	assert.True(t, s.HandleKey(KeyEvent{Rune: 'N'}))
+	assert.Equal(t, 2, s.Index(), "Prev wraps to the last match")
+
+	assert.True(t, s.HandleKey(KeyEvent{Key: KeyEscape}))
+	assert.False(t, s.Active())
+}
+
+func TestSearchController_EscapeCancelsTyping(t *testing.T) {
+	s := NewSearchController()
+	s.SetQuery("keep")
+	s.HandleKey(KeyEvent{Rune: '/'})
+	s.HandleKey(KeyEvent{Rune: 'x'})
+	s.HandleKey(KeyEvent{Key: KeyEscape})
+	assert.False(t, s.Typing())
+	assert.Equal(t, "keep", s.Query, "cancelling keeps the previous query")
+}
+
+func TestMarkdownView_Search(t *testing.T) {
+	view := Markdown("alpha beta\n\ngamma alpha", nil)
+	matches := view.Search("alpha")
+	assert.Equal(t, 2, len(matches))
+	assert.Equal(t, 0, matches[0].Line)
+	assert.Equal(t, 2, matches[1].Line)
+	assert.Equal(t, 6, matches[1].Start)
+}
+
+func TestMarkdownView_SearchWithHighlightsAndScrolls(t *testing.T) {
+	content := "one\n\ntwo\n\nthree\n\nfour\n\nneedle here"
+	scrollY := 0
+	search := NewSearchController()
+	search.SetQuery("needle")
+
+	view := Markdown(content, &scrollY).Height(3).SearchWith(search)
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 3})
+
+	assert.Equal(t, 1, search.Count())
+	assert.True(t, scrollY > 0, "view scrolls to reveal the match")
+	assert.Contains(t, screen.Text(), "needle here")
+
+	// Locate the highlighted cell
+	found := false
+	for y := 0; y < 3; y++ {
+		if strings.HasPrefix(screen.Row(y), "needle") {
+			found = true
+			assert.True(t, screen.Cell(0, y).Style.Bold, "current match is highlighted")
+			assert.False(t, screen.Cell(7, y).Style.Bold, "text after the match is not")
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestCodeView_SearchWith(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, fmt.Sprintf("x := %d", i))
+	}
+	lines[14] = "return needle"
+	scrollY := 0
+	search := NewSearchController()
+	search.SetQuery("needle")
+
+	view := Code(strings.Join(lines, "\n"), "").ScrollY(&scrollY).Height(4).SearchWith(search)
+	screen := SprintScreen(view, PrintConfig{Width: 30, Height: 4})
+	assert.Equal(t, 1, search.Count())
+	assert.Equal(t, SearchMatch{Line: 14, Start: 7, End: 13}, search.Matches()[0])
+	assert.Equal(t, 14-4/3, scrollY)
+
+	// Columns are relative to the code, after the line numbers
+	row := 14 - scrollY
+	assert.Equal(t, "15 return needle", screen.Row(row))
+	assert.True(t, screen.Cell(10, row).Style.Bold)
+	assert.False(t, screen.Cell(9, row).Style.Bold)
+}
+
+func TestScrollView_SearchWith(t *testing.T) {
+	var rows []View
+	for i := 1; i <= 20; i++ {
+		rows = append(rows, Text("row %d", i))
+	}
+	rows[11] = Group(Text("row"), Text(" 12 "), Text("日本"))
+	scrollY := 0
+	search := NewSearchController()
+	search.SetQuery("12 日")
+
+	view := Scroll(Stack(rows...), &scrollY).SearchWith(search)
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, 1, search.Count())
+	assert.Equal(t, SearchMatch{Line: 11, Start: 4, End: 9}, search.Matches()[0])
+	assert.Equal(t, 11-5/3, scrollY)
+
+	row := 11 - scrollY
+	assert.Equal(t, "row 12 日本", screen.Row(row))
+	assert.False(t, screen.Cell(3, row).Style.Bold)
+	assert.True(t, screen.Cell(4, row).Style.Bold)
+	assert.True(t, screen.Cell(6, row).Style.Bold)
+	assert.False(t, screen.Cell(9, row).Style.Bold)
+
+	// Clearing the query removes the highlight
+	search.Clear()
+	screen = SprintScreen(view, PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, 0, search.Count())
+	assert.False(t, screen.Cell(4, row).Style.Bold)
+}
+
+func TestScrollView_SearchDropsOffscreenRegions(t *testing.T) {
+	interactiveRegistry.Clear()
+	search := NewSearchController()
+	search.SetQuery("go")
+	view := Scroll(Clickable("go", func() {}), nil).SearchWith(search)
+	SprintScreen(view, PrintConfig{Width: 10, Height: 2})
+	assert.Equal(t, 1, search.Count())
+	assert.Equal(t, 1, interactiveRegistry.regionCount())
+}
+
+func TestSearchBar(t *testing.T) {
+	s := NewSearchController()
+	w, h := SearchBar(s).size(40, 1)
+	assert.Equal(t, 0, w)
+	assert.Equal(t, 0, h)
+
+	s.Begin()
+	s.HandleKey(KeyEvent{Rune: 'a'})
+	screen := SprintScreen(SearchBar(s), PrintConfig{Width: 20})
+	assert.Contains(t, screen.Row(0), "/a█")
+
+	s.HandleKey(KeyEvent{Key: KeyEnter})
+	screen = SprintScreen(SearchBar(s), PrintConfig{Width: 20})
+	assert.Contains(t, screen.Row(0), "/a  no matches")
+}
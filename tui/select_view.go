@@ -0,0 +1,395 @@
+package tui
+
+import (
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/deepnoodle-ai/wonton/fuzzy"
+	"github.com/mattn/go-runewidth"
+)
+
+// selectRegistry keeps whether each Select is open, with its filter and
+// cursor, keyed by ID, since select views are rebuilt every frame.
+var selectRegistry = &selectRegistryImpl{
+	states: make(map[string]*selectState),
+	active: make(map[string]bool),
+}
+
+type selectRegistryImpl struct {
+	mu     sync.Mutex
+	states map[string]*selectState
+	active map[string]bool // tracks which IDs were accessed this frame
+}
+
+type selectState struct {
+	open    bool
+	filter  string
+	matches []fuzzy.Ranked // options matching the filter, best first
+	cursor  int            // index into matches
+	scroll  int            // first visible match
+}
+
+// Clear marks all entries as inactive. Called at the start of each frame.
+func (r *selectRegistryImpl) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.active = make(map[string]bool)
+}
+
+// Prune removes entries that weren't accessed since the last Clear().
+func (r *selectRegistryImpl) Prune() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id := range r.states {
+		if !r.active[id] {
+			delete(r.states, id)
+		}
+	}
+}
+
+func (r *selectRegistryImpl) Get(id string) *selectState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.active[id] = true
+	if state, exists := r.states[id]; exists {
+		return state
+	}
+	state := &selectState{}
+	r.states[id] = state
+	return state
+}
+
+// selectView is a dropdown: a field showing the chosen option that opens a
+// filterable list of options over the views below it.
+type selectView struct {
+	id          string
+	value       *string
+	options     []string
+	placeholder string
+	width       int
+	maxRows     int
+	style       Style
+	focusStyle  Style
+	matchStyle  Style
+	onChange    func(string)
+	bounds      image.Rectangle
+	listBounds  image.Rectangle // screen bounds of the open list
+	focused     bool
+	state       *selectState
+}
+
+// Select creates a dropdown for choosing one of options. value points to
+// the chosen option.
+//
+// Closed, it shows the chosen option. Enter, Space, or the down arrow when
+// focused, or a click, opens a list of the options over the views below it
+// (or above, if there is more room there). Typing filters the list with
+// fuzzy matching, the arrow keys and mouse wheel move through it, and Enter
+// or a click chooses an option. Escape, or moving focus away, closes it
+// without changing the value. Use Tab to focus the field.
+//
+// Example:
+//
+//	tui.Select(&app.language, []string{"Go", "Rust", "Zig"}).
+//	    Placeholder("Pick a language").
+//	    OnChange(func(lang string) { app.reload() })
+func Select(value *string, options []string) *selectView {
+	return &selectView{
+		id:         fmt.Sprintf("dropdown_%p", value),
+		value:      value,
+		options:    options,
+		maxRows:    8,
+		style:      NewStyle(),
+		focusStyle: NewStyle().WithReverse(),
+		matchStyle: NewStyle().WithForeground(ColorYellow).WithBold(),
+	}
+}
+
+// ID sets a custom ID for this select (for focus management).
+func (s *selectView) ID(id string) *selectView {
+	s.id = id
+	return s
+}
+
+// Placeholder sets the text shown when no option is chosen.
+func (s *selectView) Placeholder(text string) *selectView {
+	s.placeholder = text
+	return s
+}
+
+// Width sets the width of the field. By default it fits the widest option.
+func (s *selectView) Width(w int) *selectView {
+	s.width = w
+	return s
+}
+
+// MaxRows sets how many options the open list shows at once (default 8).
+func (s *selectView) MaxRows(n int) *selectView {
+	s.maxRows = max(1, n)
+	return s
+}
+
+// Style sets the style of the field.
+func (s *selectView) Style(style Style) *selectView {
+	s.style = style
+	return s
+}
+
+// FocusStyle sets the style of the field when focused, and of the option
+// under the cursor when open.
+func (s *selectView) FocusStyle(style Style) *selectView {
+	s.focusStyle = style
+	return s
+}
+
+// MatchStyle sets the style of the option characters matching the filter.
+func (s *selectView) MatchStyle(style Style) *selectView {
+	s.matchStyle = style
+	return s
+}
+
+// OnChange sets a callback invoked when an option is chosen.
+func (s *selectView) OnChange(fn func(string)) *selectView {
+	s.onChange = fn
+	return s
+}
+
+// Focusable interface implementation
+func (s *selectView) FocusID() string              { return s.id }
+func (s *selectView) IsFocused() bool              { return s.focused }
+func (s *selectView) SetFocused(focused bool)      { s.focused = focused }
+func (s *selectView) FocusBounds() image.Rectangle { return s.bounds.Union(s.listBounds) }
+
+func (s *selectView) HandleKeyEvent(event KeyEvent) bool {
+	state := s.getState()
+	if !state.open {
+		if event.Key == KeyEnter || event.Key == KeyArrowDown || event.Rune == ' ' {
+			s.open(state)
+			return true
+		}
+		return false
+	}
+
+	switch {
+	case event.Key == KeyEscape:
+		state.open = false
+	case event.Key == KeyEnter:
+		if state.cursor < len(state.matches) {
+			s.choose(state, state.matches[state.cursor].Index)
+		}
+	case event.Key == KeyArrowUp || event.Key == KeyCtrlP:
+		s.moveCursor(state, -1)
+	case event.Key == KeyArrowDown || event.Key == KeyCtrlN:
+		s.moveCursor(state, 1)
+	case event.Key == KeyPageUp:
+		s.moveCursor(state, -s.maxRows)
+	case event.Key == KeyPageDown:
+		s.moveCursor(state, s.maxRows)
+	case event.Key == KeyHome:
+		s.moveCursor(state, -len(state.matches))
+	case event.Key == KeyEnd:
+		s.moveCursor(state, len(state.matches))
+	case event.Key == KeyBackspace:
+		if state.filter != "" {
+			runes := []rune(state.filter)
+			s.setFilter(state, string(runes[:len(runes)-1]))
+		}
+	case event.Rune >= ' ' && !event.Ctrl && !event.Alt:
+		s.setFilter(state, state.filter+string(event.Rune))
+	default:
+		// Keep other keys, except Tab which the focus manager handles
+		// first, from reaching the views beneath the list
+	}
+	return true
+}
+
+func (s *selectView) getState() *selectState {
+	if s.state == nil {
+		s.state = selectRegistry.Get(s.id)
+	}
+	return s.state
+}
+
+// open shows every option with the cursor on the chosen one.
+func (s *selectView) open(state *selectState) {
+	state.open = true
+	s.setFilter(state, "")
+	for i, r := range state.matches {
+		if s.value != nil && s.options[r.Index] == *s.value {
+			state.cursor = i
+		}
+	}
+	state.scroll = max(0, state.cursor-s.maxRows+1)
+}
+
+func (s *selectView) setFilter(state *selectState, filter string) {
+	state.filter = filter
+	state.matches = fuzzy.Rank(filter, s.options)
+	state.cursor, state.scroll = 0, 0
+}
+
+func (s *selectView) moveCursor(state *selectState, delta int) {
+	if len(state.matches) == 0 {
+		return
+	}
+	state.cursor = max(0, min(len(state.matches)-1, state.cursor+delta))
+	if state.cursor < state.scroll {
+		state.scroll = state.cursor
+	} else if state.cursor >= state.scroll+s.maxRows {
+		state.scroll = state.cursor - s.maxRows + 1
+	}
+}
+
+func (s *selectView) choose(state *selectState, index int) {
+	state.open = false
+	if s.value != nil {
+		*s.value = s.options[index]
+	}
+	if s.onChange != nil {
+		s.onChange(s.options[index])
+	}
+}
+
+func (s *selectView) size(maxWidth, maxHeight int) (int, int) {
+	w := s.width
+	if w == 0 {
+		w = runewidth.StringWidth(s.placeholder)
+		for _, option := range s.options {
+			w = max(w, runewidth.StringWidth(option))
+		}
+		w += 4 // padding and the arrow
+	}
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	return w, 1
+}
+
+func (s *selectView) render(ctx *RenderContext) {
+	w, h := ctx.Size()
+	if w == 0 || h == 0 {
+		return
+	}
+	state := s.getState()
+
+	s.bounds = ctx.AbsoluteBounds()
+	if fm := ctx.FocusManager(); fm != nil {
+		fm.Register(s)
+		if !s.focused {
+			state.open = false
+		}
+	}
+
+	style := s.style
+	if s.focused {
+		style = focusedStyle(ctx, s.style, s.focusStyle)
+	}
+	text := s.placeholder
+	textStyle := style
+	if s.value != nil && *s.value != "" {
+		text = *s.value
+	} else {
+		textStyle = style.WithDim()
+	}
+	arrow := "▾"
+	if state.open {
+		arrow = "▴"
+	}
+	ctx.FillStyled(0, 0, w, 1, ' ', style)
+	ctx.PrintTruncated(1, 0, runewidth.Truncate(text, w-4, "…"), textStyle)
+	ctx.PrintTruncated(w-2, 0, arrow, style)
+
+	interactiveRegistry.RegisterButton(s.bounds, func() {
+		if state.open {
+			state.open = false
+		} else {
+			s.open(state)
+		}
+	})
+
+	if state.open {
+		s.renderList(ctx, state, w)
+	}
+}
+
+// renderList queues the open list of options as an overlay below the
+// field, or above it if there is more room there.
+func (s *selectView) renderList(ctx *RenderContext, state *selectState, fieldW int) {
+	w := fieldW
+	for _, option := range s.options {
+		w = max(w, runewidth.StringWidth(option)+4)
+	}
+	rows := max(1, min(len(state.matches), s.maxRows))
+	h := rows + 3 // borders and the filter line
+
+	screen := ctx.screenBounds()
+	w = min(w, screen.Dx())
+	below, above := screen.Max.Y-1, -screen.Min.Y
+	y := 1
+	if below < h && above > below {
+		h = min(h, above)
+		y = -h
+	} else {
+		h = min(h, below)
+	}
+	x := min(0, screen.Max.X-w)
+	if h < 3 {
+		return
+	}
+	rows = h - 3
+	if state.cursor >= state.scroll+rows {
+		state.scroll = state.cursor - rows + 1
+	}
+
+	ctx.overlay(image.Rect(x, y, x+w, y+h), func(box *RenderContext) {
+		s.listBounds = box.AbsoluteBounds()
+		box.Fill(' ', NewStyle())
+		border := Bordered(Empty()).Border(&RoundedBorder)
+		border.size(w, h)
+		border.render(box)
+
+		inner := box.SubContext(image.Rect(1, 1, w-1, h-1))
+		innerW, _ := inner.Size()
+		hintStyle := NewStyle().WithForeground(ColorBrightBlack)
+		inner.PrintTruncated(0, 0, "> ", NewStyle().WithForeground(ColorCyan))
+		if state.filter == "" {
+			inner.PrintTruncated(2, 0, "Type to filter", hintStyle)
+		} else {
+			inner.PrintTruncated(2, 0, state.filter, NewStyle())
+		}
+		if len(state.matches) == 0 {
+			inner.PrintTruncated(1, 1, "No matches", hintStyle)
+		}
+
+		for i := 0; i < rows && state.scroll+i < len(state.matches); i++ {
+			index := state.scroll + i
+			match := state.matches[index]
+			option := s.options[match.Index]
+			rowStyle := NewStyle()
+			if index == state.cursor {
+				rowStyle = s.focusStyle
+				inner.FillStyled(0, i+1, innerW, 1, ' ', rowStyle)
+			}
+			name := runewidth.Truncate(option, innerW-2, "…")
+			inner.PrintTruncated(1, i+1, name, rowStyle)
+			limit := innerW - 2
+			if name != option {
+				limit--
+			}
+			printMatched(inner, 1, i+1, option, match.Positions, limit, rowStyle.Merge(s.matchStyle))
+
+			row := inner.SubContext(image.Rect(0, i+1, innerW, i+2))
+			interactiveRegistry.RegisterButton(row.AbsoluteBounds(), func() {
+				s.choose(state, match.Index)
+			})
+		}
+
+		// Clicks elsewhere in the box shouldn't reach the views beneath
+		interactiveRegistry.RegisterButton(box.AbsoluteBounds(), func() {})
+		interactiveRegistry.RegisterScroll(box.AbsoluteBounds(), func(delta int) {
+			s.moveCursor(state, delta)
+		})
+	})
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestSelect_Closed(t *testing.T) {
+	value := "Go"
+	screen := SprintScreen(Select(&value, []string{"Go", "Rust", "Zig"}), PrintConfig{Width: 20, Height: 1})
+	assert.Equal(t, " Go               ▾", screen.Row(0))
+
+	empty := ""
+	screen = SprintScreen(Select(&empty, []string{"Go"}).Placeholder("Language"), PrintConfig{Width: 20, Height: 1})
+	assert.Equal(t, " Language         ▾", screen.Row(0))
+	assert.True(t, screen.Cell(1, 0).Style.Dim)
+}
+
+func TestSelect_Keyboard(t *testing.T) {
+	value := "Rust"
+	var changed []string
+	options := []string{"Go", "Rust", "Zig", "Ruby"}
+	view := func() *selectView {
+		return Select(&value, options).OnChange(func(v string) { changed = append(changed, v) })
+	}
+
+	// Opening puts the cursor on the chosen option; the list covers the
+	// views below the field
+	assert.True(t, view().HandleKeyEvent(KeyEvent{Key: KeyEnter}))
+	screen := SprintScreen(Stack(view(), Text("below")), PrintConfig{Width: 12, Height: 8})
+	assert.Equal(t, " Rust ▴", screen.Row(0))
+	assert.Equal(t, "╭──────╮", screen.Row(1))
+	assert.Equal(t, "│> Type│", screen.Row(2))
+	assert.Equal(t, "│ Go   │", screen.Row(3))
+	assert.Equal(t, "│ Rust │", screen.Row(4))
+	assert.True(t, screen.Cell(2, 4).Style.Reverse)
+	assert.Equal(t, "╰──────╯", screen.Row(7))
+
+	// Typing filters, best match first
+	view().HandleKeyEvent(KeyEvent{Rune: 'r'})
+	view().HandleKeyEvent(KeyEvent{Rune: 'b'})
+	screen = SprintScreen(Stack(view(), Text("below")), PrintConfig{Width: 12, Height: 8})
+	assert.Equal(t, "│> rb  │", screen.Row(2))
+	assert.Equal(t, "│ Ruby │", screen.Row(3))
+	assert.Equal(t, "╰──────╯", screen.Row(4))
+	assert.True(t, screen.Cell(2, 3).Style.Bold)
+
+	view().HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, "Ruby", value)
+	assert.Equal(t, []string{"Ruby"}, changed)
+	screen = SprintScreen(Stack(view(), Text("below")), PrintConfig{Width: 12, Height: 3})
+	assert.Equal(t, "below", screen.Row(1))
+
+	// Escape closes without changing the value
+	view().HandleKeyEvent(KeyEvent{Key: KeyArrowDown})
+	view().HandleKeyEvent(KeyEvent{Key: KeyArrowUp})
+	view().HandleKeyEvent(KeyEvent{Key: KeyEscape})
+	assert.Equal(t, "Ruby", value)
+	assert.False(t, view().HandleKeyEvent(KeyEvent{Key: KeyArrowUp}))
+}
+
+func TestSelect_Mouse(t *testing.T) {
+	terminal := NewTestTerminal(20, 8, &bytes.Buffer{})
+	value := "Rust"
+	pressed := false
+	app := &simpleApp{
+		handleFunc: func(e Event) []Cmd { return nil },
+		renderFunc: func() View {
+			return Stack(
+				Select(&value, []string{"Go", "Rust", "Zig"}),
+				Clickable("Below", func() { pressed = true }),
+				Clickable("Below", func() { pressed = true }),
+				Clickable("Below", func() { pressed = true }),
+			)
+		},
+	}
+	runtime := NewRuntime(terminal, app, 30)
+	runtime.render()
+
+	runtime.processEvent(MouseEvent{Type: MouseClick, X: 1, Y: 0})
+	runtime.render()
+	assert.Equal(t, "│ Go   │", screenRow(terminal, 3))
+
+	// The option is clicked, not the button beneath it
+	runtime.processEvent(MouseEvent{Type: MouseClick, X: 2, Y: 3})
+	assert.Equal(t, "Go", value)
+	assert.False(t, pressed)
+	runtime.render()
+	assert.Contains(t, screenRow(terminal, 1), "Below")
+}
@@ -0,0 +1,232 @@
+package tui
+
+import (
+	"image"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SelectionEvent is sent to the application when the user finishes
+// selecting text by dragging the mouse over a selectable view. Mouse
+// tracking takes over the terminal's own selection, so apps that want copy
+// to work pass the text on themselves:
+//
+//	case tui.SelectionEvent:
+//	    clipboard.Write(e.Text)
+type SelectionEvent struct {
+	Text string    // The selected text, lines separated by "\n"
+	Time time.Time // When the selection was made
+}
+
+// Timestamp implements Event.
+func (e SelectionEvent) Timestamp() time.Time { return e.Time }
+
+// SelectedText returns the text currently selected with the mouse in a
+// selectable view, or "" if there is none.
+func (r *Runtime) SelectedText() string {
+	return selection.selectedText()
+}
+
+// ClearSelection removes the mouse selection.
+func (r *Runtime) ClearSelection() {
+	selection.clear()
+}
+
+// selection is the text selected with the mouse. There is at most one, in
+// the selectable view whose region it was started in.
+var selection = &textSelection{}
+
+// selectionStyle highlights selected text.
+var selectionStyle = NewStyle().WithReverse()
+
+type textSelection struct {
+	mu       sync.Mutex
+	region   image.Rectangle // screen bounds of the view the selection is in
+	anchor   image.Point     // where the drag started, in content coordinates
+	head     image.Point     // where the drag is now, in content coordinates
+	active   bool            // the drag has moved, so something is selected
+	dragging bool
+	finished bool       // a drag ended and hasn't been reported yet
+	text     *textFrame // the view's content as of its last render
+}
+
+func (s *textSelection) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = false
+	s.dragging = false
+	s.finished = false
+	s.text = nil
+}
+
+// handleDrag updates the selection from a mouse event in a selectable view
+// with the given screen region, whose content is scrolled down by scrollY.
+func (s *textSelection) handleDrag(e MouseEvent, region image.Rectangle, scrollY int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Clamp the pointer to the view, so dragging past an edge selects up to it
+	x := min(max(e.X, region.Min.X), region.Max.X-1) - region.Min.X
+	y := min(max(e.Y, region.Min.Y), region.Max.Y-1) - region.Min.Y + scrollY
+	pt := image.Pt(x, y)
+	switch e.Type {
+	case MousePress:
+		s.region = region
+		s.anchor, s.head = pt, pt
+		s.active = false
+		s.dragging = true
+		s.finished = false
+		s.text = nil
+	case MouseDrag:
+		if s.dragging && s.region == region {
+			s.head = pt
+			s.active = s.active || pt != s.anchor
+		}
+	case MouseRelease:
+		if s.dragging {
+			s.dragging = false
+			s.finished = s.active
+		}
+	}
+}
+
+// takeFinished returns the text of a selection that was just completed, once.
+func (s *textSelection) takeFinished() (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.finished {
+		return "", false
+	}
+	s.finished = false
+	return s.textLocked(), true
+}
+
+func (s *textSelection) selectedText() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.textLocked()
+}
+
+// bounds returns the first and last selected cells in reading order.
+func (s *textSelection) bounds() (start, end image.Point) {
+	start, end = s.anchor, s.head
+	if end.Y < start.Y || end.Y == start.Y && end.X < start.X {
+		start, end = end, start
+	}
+	return start, end
+}
+
+// contains reports whether the content cell at x, y is selected. Like a
+// terminal's selection, it runs from the start cell to the end of its line,
+// over whole lines, and up to the end cell.
+func (s *textSelection) contains(x, y int) bool {
+	start, end := s.bounds()
+	if y < start.Y || y > end.Y {
+		return false
+	}
+	if y == start.Y && x < start.X {
+		return false
+	}
+	if y == end.Y && x > end.X {
+		return false
+	}
+	return true
+}
+
+func (s *textSelection) textLocked() string {
+	if !s.active || s.text == nil {
+		return ""
+	}
+	start, end := s.bounds()
+	var lines []string
+	for y := start.Y; y <= end.Y; y++ {
+		var b strings.Builder
+		for x := 0; x < s.text.bounds.Dx(); x++ {
+			if r := s.text.cell(x, y); r != 0 && s.contains(x, y) {
+				b.WriteRune(r)
+			}
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderSelectable draws a selectable view. draw renders the view's content
+// to frame, a frame of contentWidth by contentHeight that is shown in ctx
+// scrolled down by scrollY. The content is captured for copying, a drag
+// over ctx's area selects it, and the selected part is highlighted.
+func renderSelectable(ctx *RenderContext, frame RenderFrame, contentWidth, contentHeight, scrollY int, draw func(frame RenderFrame)) {
+	region := ctx.AbsoluteBounds()
+	// Register before drawing so that draggable views inside take priority
+	interactiveRegistry.RegisterDrag(region, func(e MouseEvent) {
+		selection.handleDrag(e, region, scrollY)
+	})
+
+	text := newTextFrame(contentWidth, contentHeight)
+	draw(&teeRenderFrame{RenderFrame: frame, copy: text})
+
+	s := selection
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.region != region || !s.active && !s.dragging {
+		return
+	}
+	s.text = text
+	if !s.active {
+		return
+	}
+	width, height := ctx.Size()
+	for row := 0; row < height; row++ {
+		y := row + scrollY
+		for x := 0; x < width; x++ {
+			if r := text.cell(x, y); r != 0 && s.contains(x, y) {
+				ctx.SetCell(x, row, r, selectionStyle)
+			}
+		}
+	}
+}
+
+// teeRenderFrame draws to a frame and records the same drawing in a copy.
+type teeRenderFrame struct {
+	RenderFrame
+	copy RenderFrame
+}
+
+func (f *teeRenderFrame) SetCell(x, y int, char rune, style Style) error {
+	f.copy.SetCell(x, y, char, style)
+	return f.RenderFrame.SetCell(x, y, char, style)
+}
+
+func (f *teeRenderFrame) PrintStyled(x, y int, text string, style Style) error {
+	f.copy.PrintStyled(x, y, text, style)
+	return f.RenderFrame.PrintStyled(x, y, text, style)
+}
+
+func (f *teeRenderFrame) PrintTruncated(x, y int, text string, style Style) error {
+	f.copy.PrintTruncated(x, y, text, style)
+	return f.RenderFrame.PrintTruncated(x, y, text, style)
+}
+
+func (f *teeRenderFrame) FillStyled(x, y, width, height int, char rune, style Style) error {
+	f.copy.FillStyled(x, y, width, height, char, style)
+	return f.RenderFrame.FillStyled(x, y, width, height, char, style)
+}
+
+func (f *teeRenderFrame) Fill(char rune, style Style) error {
+	f.copy.Fill(char, style)
+	return f.RenderFrame.Fill(char, style)
+}
+
+func (f *teeRenderFrame) PrintHyperlink(x, y int, link Hyperlink) error {
+	f.copy.PrintHyperlink(x, y, link)
+	return f.RenderFrame.PrintHyperlink(x, y, link)
+}
+
+func (f *teeRenderFrame) PrintHyperlinkFallback(x, y int, link Hyperlink) error {
+	f.copy.PrintHyperlinkFallback(x, y, link)
+	return f.RenderFrame.PrintHyperlinkFallback(x, y, link)
+}
+
+func (f *teeRenderFrame) SubFrame(rect image.Rectangle) RenderFrame {
+	return &teeRenderFrame{RenderFrame: f.RenderFrame.SubFrame(rect), copy: f.copy.SubFrame(rect)}
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func newSelectionTest(t *testing.T, view func() View) (*Runtime, *Terminal, *[]string) {
+	t.Cleanup(selection.clear)
+	terminal := NewTestTerminal(20, 4, &bytes.Buffer{})
+	var selected []string
+	app := &simpleApp{
+		handleFunc: func(e Event) []Cmd {
+			if sel, ok := e.(SelectionEvent); ok {
+				selected = append(selected, sel.Text)
+			}
+			return nil
+		},
+		renderFunc: view,
+	}
+	runtime := NewRuntime(terminal, app, 30)
+	runtime.render()
+	return runtime, terminal, &selected
+}
+
+func drag(r *Runtime, fromX, fromY, toX, toY int) {
+	r.processEvent(MouseEvent{Type: MousePress, Button: MouseButtonLeft, X: fromX, Y: fromY})
+	r.render()
+	r.processEvent(MouseEvent{Type: MouseDrag, Button: MouseButtonLeft, X: toX, Y: toY})
+	r.render()
+	r.processEvent(MouseEvent{Type: MouseRelease, Button: MouseButtonLeft, X: toX, Y: toY})
+	r.render()
+}
+
+func TestSelection_Text(t *testing.T) {
+	runtime, terminal, selected := newSelectionTest(t, func() View {
+		return Stack(Text("not selectable"), Text("alpha beta\ngamma delta").Selectable())
+	})
+
+	drag(runtime, 6, 1, 2, 2)
+	assert.Equal(t, []string{"beta\ngam"}, *selected)
+	assert.Equal(t, "beta\ngam", runtime.SelectedText())
+	assert.False(t, terminal.GetCell(5, 1).Style.Reverse)
+	assert.True(t, terminal.GetCell(6, 1).Style.Reverse)
+	assert.True(t, terminal.GetCell(0, 2).Style.Reverse)
+	assert.True(t, terminal.GetCell(2, 2).Style.Reverse)
+	assert.False(t, terminal.GetCell(3, 2).Style.Reverse)
+
+	// A click clears the selection
+	runtime.processEvent(MouseEvent{Type: MousePress, Button: MouseButtonLeft, X: 0, Y: 0})
+	runtime.processEvent(MouseEvent{Type: MouseRelease, Button: MouseButtonLeft, X: 0, Y: 0})
+	runtime.render()
+	assert.Equal(t, "", runtime.SelectedText())
+	assert.False(t, terminal.GetCell(6, 1).Style.Reverse)
+	assert.Equal(t, 1, len(*selected))
+
+	// Dragging outside a selectable view selects nothing
+	drag(runtime, 0, 0, 5, 0)
+	assert.Equal(t, "", runtime.SelectedText())
+}
+
+func TestSelection_Scroll(t *testing.T) {
+	scrollY := 0
+	runtime, terminal, selected := newSelectionTest(t, func() View {
+		var rows []View
+		for i := 1; i <= 10; i++ {
+			rows = append(rows, Text("row %d", i))
+		}
+		return Scroll(Stack(rows...), &scrollY).Selectable()
+	})
+
+	// Dragging past the bottom edge selects to the last visible row
+	drag(runtime, 0, 2, 3, 9)
+	assert.Equal(t, []string{"row 3\nrow"}, *selected)
+
+	// The selection stays with the content as it scrolls
+	scrollY = 2
+	runtime.render()
+	assert.Equal(t, "row 3", screenRow(terminal, 0))
+	assert.True(t, terminal.GetCell(0, 0).Style.Reverse)
+	assert.True(t, terminal.GetCell(3, 1).Style.Reverse)
+	assert.False(t, terminal.GetCell(4, 1).Style.Reverse)
+
+	// Content scrolled out of view is still copied
+	scrollY = 6
+	runtime.render()
+	assert.Equal(t, "row 3\nrow", runtime.SelectedText())
+}
@@ -1,6 +1,9 @@
 package tui
 
-import "image"
+import (
+	"image"
+	"math"
+)
 
 // sizeView wraps a view with fixed, minimum, or maximum size constraints
 type sizeView struct {
@@ -156,3 +159,60 @@ func (s *sizeView) render(ctx *RenderContext) {
 	innerCtx := ctx.SubContext(image.Rect(0, 0, constrainedW, constrainedH))
 	s.inner.render(innerCtx)
 }
+
+// aspectView sizes its inner view to a width-to-height ratio.
+type aspectView struct {
+	inner View
+	ratio float64
+}
+
+// AspectRatio wraps a view to keep its width ratio times its height, taking
+// the largest such size that fits. Terminal cells are about twice as tall
+// as they are wide, so a ratio of 2 looks square. Without a width or height
+// limit, the view grows from its content's size to the ratio.
+//
+// Example:
+//
+//	AspectRatio(4, chart)  // 4 columns per row, as big as will fit
+//	MaxWidth(60, AspectRatio(2, preview))
+func AspectRatio(ratio float64, inner View) View {
+	if ratio <= 0 {
+		ratio = 1
+	}
+	return &aspectView{inner: inner, ratio: ratio}
+}
+
+// fit returns the largest size with the view's ratio within maxWidth and
+// maxHeight, where 0 means no limit.
+func (a *aspectView) fit(maxWidth, maxHeight int) (int, int) {
+	heightFor := func(w int) int { return int(math.Round(float64(w) / a.ratio)) }
+	widthFor := func(h int) int { return int(math.Round(float64(h) * a.ratio)) }
+	switch {
+	case maxWidth > 0:
+		w, h := maxWidth, heightFor(maxWidth)
+		if maxHeight > 0 && h > maxHeight {
+			w, h = min(maxWidth, widthFor(maxHeight)), maxHeight
+		}
+		return w, h
+	case maxHeight > 0:
+		return widthFor(maxHeight), maxHeight
+	}
+	w, h := a.inner.size(0, 0)
+	if heightFor(w) >= h {
+		return w, heightFor(w)
+	}
+	return widthFor(h), h
+}
+
+func (a *aspectView) size(maxWidth, maxHeight int) (int, int) {
+	return a.fit(maxWidth, maxHeight)
+}
+
+func (a *aspectView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	w, h := a.fit(width, height)
+	a.inner.render(ctx.SubContext(image.Rect(0, 0, w, h)))
+}
@@ -0,0 +1,143 @@
+package tui
+
+// defaultSkeletonWidths is the line width pattern, in percent of the
+// available width, used when Skeleton is given no widths.
+var defaultSkeletonWidths = []int{100, 85, 95, 60}
+
+// skeletonStyle holds the colors and animation of skeleton bars.
+type skeletonStyle struct {
+	color     RGB
+	highlight RGB
+	animated  bool
+}
+
+func defaultSkeletonStyle() skeletonStyle {
+	return skeletonStyle{
+		color:     NewRGB(60, 60, 60),
+		highlight: NewRGB(110, 110, 110),
+		animated:  true,
+	}
+}
+
+// skeletonBandWidth is the width of the shimmer highlight in cells.
+const skeletonBandWidth = 6
+
+// drawBar draws a skeleton bar of width cells at (x, y). The shimmer sweeps
+// diagonally, so its position depends on the row as well as the column.
+func (s skeletonStyle) drawBar(ctx *RenderContext, x, y, width int) {
+	screenWidth, _ := ctx.Size()
+	cycle := screenWidth + 2*skeletonBandWidth
+	band := int(ctx.Frame()/2)%cycle - skeletonBandWidth
+	for i := 0; i < width; i++ {
+		color := s.color
+		if s.animated {
+			distance := x + i + y - band
+			if distance < 0 {
+				distance = -distance
+			}
+			if distance < skeletonBandWidth {
+				color = blendRGB(s.color, s.highlight, 1-float64(distance)/skeletonBandWidth)
+			}
+		}
+		ctx.SetCell(x+i, y, '█', NewStyle().WithFgRGB(color))
+	}
+}
+
+// skeletonView draws placeholder bars in the shape of the content to come.
+type skeletonView struct {
+	lines  int
+	widths []int
+	gap    int
+	width  int
+	style  skeletonStyle
+}
+
+// Skeleton creates a placeholder of gray bars that stands in for content
+// while it loads, with a highlight sweeping across it. widths gives each
+// line's length in percent of the available width and repeats if there are
+// more lines than widths; with no widths, lines have varied lengths like a
+// paragraph of text.
+//
+// Use it as the pending view of Suspense. Tables and item lists draw their
+// own skeleton rows with Loading.
+//
+// Example:
+//
+//	tui.Suspense(tui.Skeleton(3, 40, 100, 70), func() tui.View { ... })
+func Skeleton(lines int, widths ...int) *skeletonView {
+	if len(widths) == 0 {
+		widths = defaultSkeletonWidths
+	}
+	return &skeletonView{
+		lines:  lines,
+		widths: widths,
+		style:  defaultSkeletonStyle(),
+	}
+}
+
+// Color sets the bar color.
+func (s *skeletonView) Color(c RGB) *skeletonView {
+	s.style.color = c
+	return s
+}
+
+// HighlightColor sets the color at the center of the shimmer.
+func (s *skeletonView) HighlightColor(c RGB) *skeletonView {
+	s.style.highlight = c
+	return s
+}
+
+// Static disables the shimmer animation.
+func (s *skeletonView) Static() *skeletonView {
+	s.style.animated = false
+	return s
+}
+
+// Gap sets the number of blank lines between bars.
+func (s *skeletonView) Gap(lines int) *skeletonView {
+	s.gap = lines
+	return s
+}
+
+// Width sets a fixed width. By default the skeleton fills the available
+// width.
+func (s *skeletonView) Width(w int) *skeletonView {
+	s.width = w
+	return s
+}
+
+func (s *skeletonView) size(maxWidth, maxHeight int) (int, int) {
+	w := s.width
+	if w == 0 {
+		w = maxWidth
+	}
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	h := 0
+	if s.lines > 0 {
+		h = s.lines + (s.lines-1)*s.gap
+	}
+	if maxHeight > 0 && h > maxHeight {
+		h = maxHeight
+	}
+	return w, h
+}
+
+func (s *skeletonView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	for line := 0; line < s.lines; line++ {
+		y := line * (1 + s.gap)
+		if y >= height {
+			break
+		}
+		w := width * s.widths[line%len(s.widths)] / 100
+		if w < 1 {
+			w = 1
+		}
+		if w > width {
+			w = width
+		}
+		s.style.drawBar(ctx, 0, y, w)
+	}
+}
@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestSkeleton_LineWidths(t *testing.T) {
+	view := Skeleton(3, 50, 100).Gap(1).Static().Color(NewRGB(1, 2, 3))
+	screen := SprintScreen(view, PrintConfig{Width: 10, Height: 5})
+
+	assert.Equal(t, "█████", screen.Row(0))
+	assert.Equal(t, "", screen.Row(1))
+	assert.Equal(t, "██████████", screen.Row(2))
+	assert.Equal(t, "█████", screen.Row(4))
+	fg := screen.Cell(0, 0).Style.Foreground
+	assert.Equal(t, []uint8{1, 2, 3}, []uint8{fg.R, fg.G, fg.B})
+}
+
+func TestTable_Loading(t *testing.T) {
+	view := Table([]TableColumn{{Title: "Name", Width: 6}, {Title: "Size", Width: 4}}, nil).
+		Loading(true)
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 5})
+
+	assert.Equal(t, "Name    Size", screen.Row(0))
+	assert.Equal(t, "██████  ███", screen.Row(2))
+	assert.Equal(t, "█████   ███", screen.Row(3))
+}
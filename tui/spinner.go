@@ -6,17 +6,8 @@ import (
 	"github.com/mattn/go-runewidth"
 )
 
-// Spinner represents an animated loading spinner widget
-//
-// Deprecated: Use Loading() for declarative spinner.
-type Spinner struct {
-	frames       []string
-	interval     time.Duration // Time per frame
-	style        Style
-	message      string
-	currentFrame int
-	lastUpdate   time.Time
-}
+// spinnerFPS is the tick rate spinner intervals are converted to frames at.
+const spinnerFPS = 30
 
 // SpinnerStyle defines different spinner animations
 type SpinnerStyle struct {
@@ -56,6 +47,11 @@ var (
 		Interval: 80 * time.Millisecond,
 	}
 
+	SpinnerBraille = SpinnerStyle{
+		Frames:   []string{"⠋", "⠙", "⠚", "⠞", "⠖", "⠦", "⠴", "⠲", "⠳", "⠓"},
+		Interval: 80 * time.Millisecond,
+	}
+
 	SpinnerBar = SpinnerStyle{
 		Frames: []string{
 			"[    ]",
@@ -127,72 +123,122 @@ var (
 	}
 )
 
-// NewSpinner creates a new spinner widget
+// spinnerView is an animated spinner that advances with the render frame.
+type spinnerView struct {
+	frames     []string
+	speed      int // render frames per spinner frame
+	style      Style
+	label      string
+	labelStyle *Style
+}
+
+// Spinner creates a spinner that animates itself: each render shows the
+// frame for the current render frame, so the application doesn't track a
+// frame counter. The app must tick (Run with an FPS, or InlineApp.FPS) for
+// it to move. Frames change at the style's Interval at 30 FPS; use Speed to
+// change the pace.
+//
+// Example:
 //
-// Deprecated: Use Loading() for declarative spinner.
-func NewSpinner(style SpinnerStyle) *Spinner {
-	return &Spinner{
-		frames:     style.Frames,
-		interval:   style.Interval,
-		style:      NewStyle(),
-		lastUpdate: time.Now(),
+//	tui.Spinner(tui.SpinnerDots).Label("Loading...").Fg(tui.ColorCyan)
+func Spinner(style SpinnerStyle) *spinnerView {
+	frames := style.Frames
+	if len(frames) == 0 {
+		frames = SpinnerDots.Frames
+	}
+	speed := int(style.Interval * spinnerFPS / time.Second)
+	return &spinnerView{
+		frames: frames,
+		speed:  max(1, speed),
+		style:  NewStyle(),
+	}
+}
+
+// Speed sets how many render frames each spinner frame is shown for
+// (higher = slower).
+func (s *spinnerView) Speed(frames int) *spinnerView {
+	if frames > 0 {
+		s.speed = frames
 	}
+	return s
+}
+
+// Label sets text shown after the spinner.
+func (s *spinnerView) Label(label string) *spinnerView {
+	s.label = label
+	return s
+}
+
+// LabelStyle sets the style of the label. By default it uses the spinner's
+// style.
+func (s *spinnerView) LabelStyle(style Style) *spinnerView {
+	s.labelStyle = &style
+	return s
 }
 
-// WithStyle sets the spinner style
-func (s *Spinner) WithStyle(style Style) *Spinner {
+// Style sets the complete style.
+func (s *spinnerView) Style(style Style) *spinnerView {
 	s.style = style
 	return s
 }
 
-// Fg sets the foreground color for the spinner.
-func (s *Spinner) Fg(c Color) *Spinner {
+// Fg sets the foreground color.
+func (s *spinnerView) Fg(c Color) *spinnerView {
 	s.style = s.style.WithForeground(c)
 	return s
 }
 
-// Bg sets the background color for the spinner.
-func (s *Spinner) Bg(c Color) *Spinner {
+// Bg sets the background color.
+func (s *spinnerView) Bg(c Color) *spinnerView {
 	s.style = s.style.WithBackground(c)
 	return s
 }
 
 // Bold makes the spinner bold.
-func (s *Spinner) Bold() *Spinner {
+func (s *spinnerView) Bold() *spinnerView {
 	s.style = s.style.WithBold()
 	return s
 }
 
 // Dim makes the spinner dimmed.
-func (s *Spinner) Dim() *Spinner {
+func (s *spinnerView) Dim() *spinnerView {
 	s.style = s.style.WithDim()
 	return s
 }
 
-// WithMessage sets the spinner message
-func (s *Spinner) WithMessage(message string) *Spinner {
-	s.message = message
-	return s
+// frameWidth returns the width of the widest frame, so the label doesn't
+// move as the spinner turns.
+func (s *spinnerView) frameWidth() int {
+	w := 0
+	for _, f := range s.frames {
+		w = max(w, runewidth.StringWidth(f))
+	}
+	return w
 }
 
-// Update advances the spinner animation based on elapsed time.
-// Call this from HandleEvent on TickEvent.
-func (s *Spinner) Update(now time.Time) {
-	if now.Sub(s.lastUpdate) >= s.interval {
-		s.currentFrame = (s.currentFrame + 1) % len(s.frames)
-		s.lastUpdate = now
+func (s *spinnerView) size(maxWidth, maxHeight int) (int, int) {
+	w := s.frameWidth()
+	if s.label != "" {
+		w += 1 + runewidth.StringWidth(s.label)
 	}
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	return w, 1
 }
 
-// Draw renders the spinner at the specified position
-func (s *Spinner) Draw(frame RenderFrame, x, y int) {
-	// Draw spinner frame
-	frame.PrintStyled(x, y, s.frames[s.currentFrame], s.style)
-
-	// Draw message if present
-	if s.message != "" {
-		// Calculate offset based on spinner width
-		offset := runewidth.StringWidth(s.frames[s.currentFrame]) + 1
-		frame.PrintStyled(x+offset, y, s.message, NewStyle())
+func (s *spinnerView) render(ctx *RenderContext) {
+	w, h := ctx.Size()
+	if w == 0 || h == 0 {
+		return
+	}
+	frame := s.frames[int(ctx.Frame()/uint64(s.speed))%len(s.frames)]
+	ctx.PrintStyled(0, 0, frame, s.style)
+	if s.label != "" {
+		labelStyle := s.style
+		if s.labelStyle != nil {
+			labelStyle = *s.labelStyle
+		}
+		ctx.PrintTruncated(s.frameWidth()+1, 0, s.label, labelStyle)
 	}
 }
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -31,6 +32,7 @@ func TestSpinnerStyle_AllStylesHaveFrames(t *testing.T) {
 		SpinnerCircle,
 		SpinnerSquare,
 		SpinnerBounce,
+		SpinnerBraille,
 		SpinnerBar,
 		SpinnerStars,
 		SpinnerStarField,
@@ -43,3 +45,30 @@ func TestSpinnerStyle_AllStylesHaveFrames(t *testing.T) {
 		assert.Greater(t, style.Interval, time.Duration(0), "Spinner style should have positive interval")
 	}
 }
+
+func TestSpinner_AnimatesWithFrame(t *testing.T) {
+	var buf bytes.Buffer
+	terminal := NewTestTerminal(20, 1, &buf)
+	view := Spinner(SpinnerLine).Label("Working")
+
+	rows := make([]string, 0, 4)
+	for _, n := range []uint64{0, 2, 3, 9} {
+		frame, err := terminal.BeginFrame()
+		assert.NoError(t, err)
+		view.render(NewRenderContext(frame, n))
+		assert.NoError(t, terminal.EndFrame(frame))
+		rows = append(rows, screenRow(terminal, 0))
+	}
+
+	// 100ms frames at 30 FPS last 3 render frames
+	assert.Equal(t, []string{"- Working", "- Working", "\\ Working", "/ Working"}, rows)
+}
+
+func TestSpinner_Size(t *testing.T) {
+	w, h := Spinner(SpinnerBar).Label("Load").size(0, 0)
+	assert.Equal(t, 11, w)
+	assert.Equal(t, 1, h)
+
+	screen := SprintScreen(Stack(Spinner(SpinnerStarField).Speed(1)), PrintConfig{Width: 10, Height: 1})
+	assert.Equal(t, "·", screen.Row(0))
+}
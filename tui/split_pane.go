@@ -0,0 +1,278 @@
+package tui
+
+import (
+	"image"
+	"math"
+)
+
+// SplitState holds the divider position of a SplitPane. Keep it in your
+// application model so the position survives across frames and resizing by
+// the user sticks.
+type SplitState struct {
+	Ratio float64 // Share of the space given to the first pane, 0 to 1; 0 means 0.5
+	Size  int     // Fixed size of the first pane in cells; if set, Ratio is ignored
+
+	// From the last render, for resizing by cells
+	available int
+	minSize   int
+}
+
+// Resize moves the divider by delta cells (positive moves it right or
+// down), keeping both panes at least their minimum size.
+func (s *SplitState) Resize(delta int) {
+	s.setFirst(s.first(s.available) + delta)
+}
+
+// first returns the size of the first pane out of available cells.
+func (s *SplitState) first(available int) int {
+	size := s.Size
+	if size == 0 {
+		ratio := s.Ratio
+		if ratio <= 0 || ratio > 1 {
+			ratio = 0.5
+		}
+		size = int(math.Round(ratio * float64(available)))
+	}
+	return clampSplit(size, s.minSize, available)
+}
+
+// setFirst sets the size of the first pane, as a fixed size or a ratio
+// depending on how the split was configured.
+func (s *SplitState) setFirst(size int) {
+	if s.available <= 0 {
+		return
+	}
+	size = clampSplit(size, s.minSize, s.available)
+	if s.Size > 0 {
+		s.Size = size
+		return
+	}
+	s.Ratio = float64(size) / float64(s.available)
+	if s.Ratio == 0 {
+		// 0 means the default ratio
+		s.Ratio = math.SmallestNonzeroFloat64
+	}
+}
+
+// clampSplit keeps size within [min, available-min], or centers it if the
+// space is too small for both minimums.
+func clampSplit(size, min, available int) int {
+	if available < 2*min {
+		return available / 2
+	}
+	if size < min {
+		return min
+	}
+	if size > available-min {
+		return available - min
+	}
+	return size
+}
+
+// splitPaneView lays out two views side by side or stacked, separated by a
+// divider that can be moved.
+type splitPaneView struct {
+	first, second View
+	vertical      bool
+	state         *SplitState
+
+	id           string
+	minSize      int
+	dividerStyle Style
+	activeStyle  Style
+	bounds       image.Rectangle // absolute bounds of the divider
+	focused      bool
+}
+
+// SplitPane places left and right side by side, separated by a vertical
+// divider. state holds the divider position: set Ratio for a proportional
+// split or Size for a fixed-width left pane. With a nil state the panes
+// split evenly and can't be resized.
+//
+// The divider can be dragged with the mouse (with WithMouseTracking), and
+// with an ID it is focusable and moves with the Left and Right arrow keys
+// (Shift for 5 cells). Applications can also call state.Resize from their
+// own key bindings.
+//
+// Example:
+//
+//	tui.SplitPane(&app.split, fileList, preview).ID("split").MinSize(10)
+func SplitPane(state *SplitState, left, right View) *splitPaneView {
+	return newSplitPane(state, left, right, false)
+}
+
+// VSplitPane stacks top above bottom, separated by a horizontal divider that
+// moves with the Up and Down arrow keys. See SplitPane.
+func VSplitPane(state *SplitState, top, bottom View) *splitPaneView {
+	return newSplitPane(state, top, bottom, true)
+}
+
+func newSplitPane(state *SplitState, first, second View, vertical bool) *splitPaneView {
+	if state == nil {
+		state = &SplitState{}
+	}
+	return &splitPaneView{
+		first:        first,
+		second:       second,
+		vertical:     vertical,
+		state:        state,
+		minSize:      1,
+		dividerStyle: NewStyle().WithForeground(ColorBrightBlack),
+		activeStyle:  NewStyle().WithForeground(ColorCyan),
+	}
+}
+
+// ID sets the focus ID, making the divider focusable for keyboard resizing.
+func (s *splitPaneView) ID(id string) *splitPaneView {
+	s.id = id
+	return s
+}
+
+// MinSize sets the minimum size of each pane in cells (default 1).
+func (s *splitPaneView) MinSize(cells int) *splitPaneView {
+	if cells >= 0 {
+		s.minSize = cells
+	}
+	return s
+}
+
+// DividerStyle sets the style of the divider.
+func (s *splitPaneView) DividerStyle(style Style) *splitPaneView {
+	s.dividerStyle = style
+	return s
+}
+
+// FocusDividerStyle sets the style of the divider while it is focused.
+func (s *splitPaneView) FocusDividerStyle(style Style) *splitPaneView {
+	s.activeStyle = style
+	return s
+}
+
+// Focusable interface implementation
+func (s *splitPaneView) FocusID() string {
+	return s.id
+}
+
+func (s *splitPaneView) IsFocused() bool {
+	return s.focused
+}
+
+func (s *splitPaneView) SetFocused(focused bool) {
+	s.focused = focused
+}
+
+func (s *splitPaneView) FocusBounds() image.Rectangle {
+	return s.bounds
+}
+
+func (s *splitPaneView) HandleKeyEvent(event KeyEvent) bool {
+	step := 1
+	if event.Shift {
+		step = 5
+	}
+	back, forward := KeyArrowLeft, KeyArrowRight
+	if s.vertical {
+		back, forward = KeyArrowUp, KeyArrowDown
+	}
+	switch event.Key {
+	case back:
+		s.state.Resize(-step)
+	case forward:
+		s.state.Resize(step)
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *splitPaneView) flex() int {
+	return 1
+}
+
+func (s *splitPaneView) size(maxWidth, maxHeight int) (int, int) {
+	w1, h1 := s.first.size(maxWidth, maxHeight)
+	w2, h2 := s.second.size(maxWidth, maxHeight)
+	w, h := w1+w2+1, max(h1, h2)
+	if s.vertical {
+		w, h = max(w1, w2), h1+h2+1
+	}
+	if maxWidth > 0 {
+		w = maxWidth
+	}
+	if maxHeight > 0 {
+		h = maxHeight
+	}
+	return w, h
+}
+
+func (s *splitPaneView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+
+	total := width
+	if s.vertical {
+		total = height
+	}
+	available := total - 1 // one cell for the divider
+	if available < 0 {
+		available = 0
+	}
+	state := s.state
+	state.available = available
+	state.minSize = s.minSize
+	split := state.first(available)
+
+	var firstRect, dividerRect, secondRect image.Rectangle
+	if s.vertical {
+		firstRect = image.Rect(0, 0, width, split)
+		dividerRect = image.Rect(0, split, width, split+1)
+		secondRect = image.Rect(0, split+1, width, height)
+	} else {
+		firstRect = image.Rect(0, 0, split, height)
+		dividerRect = image.Rect(split, 0, split+1, height)
+		secondRect = image.Rect(split+1, 0, width, height)
+	}
+
+	for _, pane := range []struct {
+		view View
+		rect image.Rectangle
+	}{{s.first, firstRect}, {s.second, secondRect}} {
+		if pane.rect.Empty() {
+			continue
+		}
+		paneCtx := ctx.SubContext(pane.rect)
+		pane.view.size(pane.rect.Dx(), pane.rect.Dy())
+		pane.view.render(paneCtx)
+	}
+
+	origin := ctx.AbsoluteBounds().Min
+	s.bounds = dividerRect.Add(origin)
+	if fm := ctx.FocusManager(); fm != nil && s.id != "" {
+		fm.Register(s)
+	}
+
+	style := s.dividerStyle
+	if s.focused {
+		style = s.activeStyle
+	}
+	char := '│'
+	if s.vertical {
+		char = '─'
+	}
+	for y := dividerRect.Min.Y; y < dividerRect.Max.Y; y++ {
+		for x := dividerRect.Min.X; x < dividerRect.Max.X; x++ {
+			ctx.SetCell(x, y, char, style)
+		}
+	}
+
+	vertical := s.vertical
+	interactiveRegistry.RegisterDrag(s.bounds, func(e MouseEvent) {
+		if vertical {
+			state.setFirst(e.Y - origin.Y)
+		} else {
+			state.setFirst(e.X - origin.X)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestSplitPane_Layout(t *testing.T) {
+	state := &SplitState{Ratio: 0.25}
+	view := SplitPane(state, Text("left"), Text("right"))
+	screen := SprintScreen(view, PrintConfig{Width: 21, Height: 2})
+	assert.Equal(t, "left │right", screen.Row(0))
+	assert.Equal(t, "     │", screen.Row(1))
+
+	state = &SplitState{Size: 2}
+	screen = SprintScreen(VSplitPane(state, Text("top"), Text("bottom")), PrintConfig{Width: 6, Height: 5})
+	assert.Equal(t, "top", screen.Row(0))
+	assert.Equal(t, "──────", screen.Row(2))
+	assert.Equal(t, "bottom", screen.Row(3))
+}
+
+func TestSplitPane_Resize(t *testing.T) {
+	state := &SplitState{}
+	view := SplitPane(state, Text("a"), Text("b")).ID("split").MinSize(3)
+	SprintScreen(view, PrintConfig{Width: 21, Height: 2})
+
+	assert.True(t, view.HandleKeyEvent(KeyEvent{Key: KeyArrowRight, Shift: true}))
+	assert.Equal(t, 0.75, state.Ratio)
+	state.Resize(-100)
+	assert.Equal(t, 0.15, state.Ratio)
+	assert.False(t, view.HandleKeyEvent(KeyEvent{Key: KeyArrowUp}))
+
+	fixed := &SplitState{Size: 5}
+	SprintScreen(SplitPane(fixed, Text("a"), Text("b")), PrintConfig{Width: 21, Height: 2})
+	fixed.Resize(2)
+	assert.Equal(t, 7, fixed.Size)
+}
+
+func TestSplitPane_Drag(t *testing.T) {
+	interactiveRegistry.Clear()
+	defer interactiveRegistry.Clear()
+
+	state := &SplitState{}
+	SprintScreen(SplitPane(state, Text("a"), Text("b")), PrintConfig{Width: 21, Height: 2})
+
+	assert.True(t, interactiveRegistry.HandleDrag(MouseEvent{Type: MousePress, Button: MouseButtonLeft, X: 10, Y: 1}))
+	interactiveRegistry.Clear() // a new frame doesn't end the drag
+	assert.True(t, interactiveRegistry.HandleDrag(MouseEvent{Type: MouseDrag, Button: MouseButtonLeft, X: 4, Y: 1}))
+	assert.Equal(t, 0.2, state.Ratio)
+	assert.True(t, interactiveRegistry.HandleDrag(MouseEvent{Type: MouseRelease, X: 4, Y: 1}))
+	assert.False(t, interactiveRegistry.HandleDrag(MouseEvent{Type: MouseDrag, Button: MouseButtonLeft, X: 8, Y: 1}))
+
+	// Presses outside the divider don't start a drag
+	assert.False(t, interactiveRegistry.HandleDrag(MouseEvent{Type: MousePress, Button: MouseButtonLeft, X: 2, Y: 1}))
+}
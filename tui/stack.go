@@ -9,11 +9,12 @@ import "image"
 
 // stack arranges children vertically
 type stack struct {
-	children   []View
-	gap        int
-	alignment  Alignment
-	flexFactor int
-	childSizes []image.Point // cached during size() for use in render()
+	children    []View
+	gap         int
+	joinBorders bool
+	alignment   Alignment
+	flexFactor  int
+	childSizes  []image.Point // cached during size() for use in render()
 }
 
 // Stack creates a vertical stack that arranges children top-to-bottom.
@@ -75,6 +76,31 @@ func (s *stack) Gap(n int) *stack {
 	return s
 }
 
+// JoinBorders overlaps neighboring children by one row and joins the
+// box-drawing characters where they meet, so stacked Bordered views share
+// one border with '├' and '┤' junctions instead of drawing two. It
+// replaces the gap.
+//
+// Example:
+//
+//	Stack(
+//	    Bordered(left).Border(&SingleBorder),
+//	    Bordered(right).Border(&SingleBorder),
+//	).JoinBorders()
+func (s *stack) JoinBorders() *stack {
+	s.joinBorders = true
+	return s
+}
+
+// spacing returns the space between visible children: the gap, or -1 when
+// the children overlap to join their borders.
+func (s *stack) spacing() int {
+	if s.joinBorders {
+		return -1
+	}
+	return s.gap
+}
+
 // Align sets the horizontal alignment of children within the stack.
 // Options: AlignLeft (default), AlignCenter, AlignRight.
 func (s *stack) Align(a Alignment) *stack {
@@ -126,7 +152,7 @@ func (s *stack) size(maxWidth, maxHeight int) (int, int) {
 		// Estimate spacing for now (will be recalculated after measuring flex children)
 		estimatedSpacing := 0
 		if visibleCount+len(flexChildren) > 1 {
-			estimatedSpacing = s.gap * (visibleCount + len(flexChildren) - 1)
+			estimatedSpacing = s.spacing() * (visibleCount + len(flexChildren) - 1)
 		}
 
 		remainingHeight := maxHeight - totalFixedHeight - estimatedSpacing
@@ -139,6 +165,9 @@ func (s *stack) size(maxWidth, maxHeight int) (int, int) {
 		totalMinHeight := 0
 		for i, idx := range flexChildren {
 			_, minH := s.children[idx].size(maxWidth, 0)
+			if weighted(s.children[idx]) {
+				minH = 0 // Sized by weight alone
+			}
 			minHeights[i] = minH
 			totalMinHeight += minH
 		}
@@ -193,7 +222,7 @@ func (s *stack) size(maxWidth, maxHeight int) (int, int) {
 		totalHeight += size.Y
 	}
 	if visibleCount > 1 {
-		totalHeight += s.gap * (visibleCount - 1)
+		totalHeight += s.spacing() * (visibleCount - 1)
 	}
 
 	return maxChildWidth, totalHeight
@@ -204,6 +233,9 @@ func (s *stack) render(ctx *RenderContext) {
 	if width == 0 || height == 0 || len(s.children) == 0 {
 		return
 	}
+	if s.joinBorders {
+		ctx = ctx.joiningBorders()
+	}
 
 	// Re-measure with actual bounds to get correct sizes
 	s.size(width, height)
@@ -215,17 +247,18 @@ func (s *stack) render(ctx *RenderContext) {
 		size := s.childSizes[i]
 		// Skip empty children (both dimensions zero)
 		if size.X == 0 && size.Y == 0 {
+			renderFloating(ctx, child)
 			continue
 		}
 
 		// Add gap before this child if we've already rendered a visible child
-		if renderedVisible && s.gap > 0 {
-			currentY += s.gap
+		if renderedVisible && s.spacing() != 0 {
+			currentY += s.spacing()
 		}
 
 		// Calculate X position based on alignment
 		x := 0
-		switch s.alignment {
+		switch itemAlignment(child, s.alignment) {
 		case AlignCenter:
 			x = (width - size.X) / 2
 		case AlignRight:
@@ -0,0 +1,312 @@
+package tui
+
+import (
+	"sort"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// StatusSegment is a piece of text in a StatusBar.
+type StatusSegment struct {
+	Text string
+	Key  string // Shown before Text in the bar's key style, for key hints
+
+	// Style is merged over the bar's style for this segment; the zero
+	// Style leaves the bar's style as it is
+	Style Style
+
+	// When the bar is too narrow for all its segments, those with the
+	// lowest priority are dropped first, and among equals the last
+	Priority int
+}
+
+// Segment returns a status bar segment showing text.
+func Segment(text string) StatusSegment {
+	return StatusSegment{Text: text}
+}
+
+// KeyHint returns a status bar segment for a key binding, like "^S save".
+// Hints have priority -1, so they go before other segments when the bar is
+// narrow.
+func KeyHint(key, label string) StatusSegment {
+	return StatusSegment{Key: key, Text: label, Priority: -1}
+}
+
+// KeyHints returns a key hint for each action with a Keybinding, such as
+// those given to WithCommandPalette, so the status bar lists the same keys
+// the app handles. The last hints are dropped first when the bar is narrow.
+//
+// Example:
+//
+//	tui.StatusBar("").Right(tui.KeyHints(app.actions...)...)
+func KeyHints(actions ...Action) []StatusSegment {
+	var hints []StatusSegment
+	for _, a := range actions {
+		if a.Keybinding != "" {
+			hints = append(hints, KeyHint(a.Keybinding, a.Name))
+		}
+	}
+	return hints
+}
+
+// WithPriority returns the segment with priority p.
+func (s StatusSegment) WithPriority(p int) StatusSegment {
+	s.Priority = p
+	return s
+}
+
+// WithStyle returns the segment with style s merged over the bar's style.
+func (s StatusSegment) WithStyle(style Style) StatusSegment {
+	s.Style = style
+	return s
+}
+
+// width returns how wide the segment is drawn.
+func (s StatusSegment) width() int {
+	w := runewidth.StringWidth(s.Text)
+	if s.Key != "" {
+		w += runewidth.StringWidth(s.Key)
+		if s.Text != "" {
+			w++
+		}
+	}
+	return w
+}
+
+// statusBarView is a one-line bar with segments on the left, center, and
+// right.
+type statusBarView struct {
+	left, center, right []StatusSegment
+	style               Style
+	keyStyle            Style
+	separator           string
+}
+
+// StatusBar creates a full-width status bar, showing text in the center.
+// Add segments with Left, Center, and Right; each can have its own style
+// and a priority deciding which are dropped first when the bar is narrow.
+//
+// Example:
+//
+//	tui.StatusBar("").
+//	    Left(tui.Segment(" NORMAL ").WithStyle(modeStyle).WithPriority(2), tui.Segment(app.file)).
+//	    Center(tui.Segment(app.message)).
+//	    Right(tui.KeyHint("^S", "save"), tui.KeyHint("q", "quit"))
+func StatusBar(text string) *statusBarView {
+	s := &statusBarView{
+		style:     NewStyle().WithBackground(ColorBrightBlack).WithForeground(ColorWhite),
+		keyStyle:  NewStyle().WithBold(),
+		separator: "  ",
+	}
+	if text != "" {
+		s.center = []StatusSegment{Segment(text)}
+	}
+	return s
+}
+
+// Left adds segments at the left of the bar.
+func (s *statusBarView) Left(segments ...StatusSegment) *statusBarView {
+	s.left = append(s.left, segments...)
+	return s
+}
+
+// Center adds segments at the center of the bar.
+func (s *statusBarView) Center(segments ...StatusSegment) *statusBarView {
+	s.center = append(s.center, segments...)
+	return s
+}
+
+// Right adds segments at the right of the bar.
+func (s *statusBarView) Right(segments ...StatusSegment) *statusBarView {
+	s.right = append(s.right, segments...)
+	return s
+}
+
+// Separator sets the text between neighboring segments. Default is two
+// spaces.
+func (s *statusBarView) Separator(sep string) *statusBarView {
+	s.separator = sep
+	return s
+}
+
+// KeyStyle sets the style of key hints' keys, merged over the bar's style.
+// Default is bold.
+func (s *statusBarView) KeyStyle(style Style) *statusBarView {
+	s.keyStyle = style
+	return s
+}
+
+// Fg sets the foreground color.
+func (s *statusBarView) Fg(c Color) *statusBarView {
+	s.style = s.style.WithForeground(c)
+	return s
+}
+
+// Bg sets the background color.
+func (s *statusBarView) Bg(c Color) *statusBarView {
+	s.style = s.style.WithBackground(c)
+	return s
+}
+
+// Bold makes the text bold.
+func (s *statusBarView) Bold() *statusBarView {
+	s.style = s.style.WithBold()
+	return s
+}
+
+// Style sets the complete style of the bar.
+func (s *statusBarView) Style(style Style) *statusBarView {
+	s.style = style
+	return s
+}
+
+// groupWidth returns the width of segments drawn side by side.
+func (s *statusBarView) groupWidth(segments []StatusSegment) int {
+	if len(segments) == 0 {
+		return 0
+	}
+	w := runewidth.StringWidth(s.separator) * (len(segments) - 1)
+	for _, seg := range segments {
+		w += seg.width()
+	}
+	return w
+}
+
+// fitted returns the segments that fit in width, dropping those with the
+// lowest priority first.
+func (s *statusBarView) fitted(width int) (left, center, right []StatusSegment) {
+	type ref struct {
+		group, index, order, priority int
+	}
+	groups := [][]StatusSegment{s.left, s.center, s.right}
+	var refs []ref
+	for g, segments := range groups {
+		for i, seg := range segments {
+			refs = append(refs, ref{g, i, len(refs), seg.Priority})
+		}
+	}
+	// Drop order: lowest priority first, and the last of equals first
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].priority != refs[j].priority {
+			return refs[i].priority < refs[j].priority
+		}
+		return refs[i].order > refs[j].order
+	})
+
+	dropped := make([]map[int]bool, 3)
+	for g := range dropped {
+		dropped[g] = map[int]bool{}
+	}
+	keep := func() [3][]StatusSegment {
+		var kept [3][]StatusSegment
+		for g, segments := range groups {
+			for i, seg := range segments {
+				if !dropped[g][i] {
+					kept[g] = append(kept[g], seg)
+				}
+			}
+		}
+		return kept
+	}
+	kept := keep()
+	for _, r := range refs {
+		if s.needed(kept) <= width {
+			break
+		}
+		if len(refs)-len(dropped[0])-len(dropped[1])-len(dropped[2]) == 1 {
+			break // Keep the last segment and truncate it
+		}
+		dropped[r.group][r.index] = true
+		kept = keep()
+	}
+	return kept[0], kept[1], kept[2]
+}
+
+// needed returns the width the groups take, with a cell of padding at each
+// end and two cells between groups.
+func (s *statusBarView) needed(groups [3][]StatusSegment) int {
+	w, n := 2, 0
+	for _, g := range groups {
+		if gw := s.groupWidth(g); gw > 0 {
+			w += gw
+			n++
+		}
+	}
+	if n > 1 {
+		w += 2 * (n - 1)
+	}
+	return w
+}
+
+func (s *statusBarView) size(maxWidth, maxHeight int) (int, int) {
+	w := maxWidth
+	if w == 0 {
+		w = s.needed([3][]StatusSegment{s.left, s.center, s.right})
+	}
+	return w, 1
+}
+
+func (s *statusBarView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	ctx.FillStyled(0, 0, width, 1, ' ', s.style)
+
+	left, center, right := s.fitted(width)
+	leftW, centerW, rightW := s.groupWidth(left), s.groupWidth(center), s.groupWidth(right)
+
+	// Left from the start, right to the end, and the center in the middle
+	// of the bar, or of the space between the others if it overlaps them
+	leftEnd := 1 + leftW
+	rightStart := width - 1 - rightW
+	if leftW > 0 {
+		leftEnd += 2
+	}
+	if rightW > 0 {
+		rightStart -= 2
+	}
+	centerX := (width - centerW) / 2
+	if centerX < leftEnd || centerX+centerW > rightStart {
+		centerX = leftEnd + max(0, (rightStart-leftEnd-centerW)/2)
+	}
+
+	s.drawGroup(ctx, 1, width-1, left)
+	s.drawGroup(ctx, centerX, width-1, center)
+	s.drawGroup(ctx, max(1, width-1-rightW), width-1, right)
+}
+
+// drawGroup draws segments side by side from x, cutting them off at end.
+func (s *statusBarView) drawGroup(ctx *RenderContext, x, end int, segments []StatusSegment) {
+	for i, seg := range segments {
+		if i > 0 {
+			x = s.print(ctx, x, end, s.separator, s.style)
+		}
+		style := s.style
+		if seg.Style != (Style{}) {
+			style = style.Merge(seg.Style)
+		}
+		if seg.Key != "" {
+			x = s.print(ctx, x, end, seg.Key, style.Merge(s.keyStyle))
+			if seg.Text != "" {
+				x = s.print(ctx, x, end, " ", style)
+			}
+		}
+		x = s.print(ctx, x, end, seg.Text, style)
+	}
+}
+
+// print draws text at x, cut off with an ellipsis at end, and returns where
+// it ends.
+func (s *statusBarView) print(ctx *RenderContext, x, end int, text string, style Style) int {
+	if x >= end || text == "" {
+		return x
+	}
+	w := runewidth.StringWidth(text)
+	if x+w > end {
+		text = runewidth.Truncate(text, end-x, "…")
+		w = runewidth.StringWidth(text)
+	}
+	ctx.PrintStyled(x, 0, text, style)
+	return x + w
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestStatusBar_CenteredText(t *testing.T) {
+	screen := SprintScreen(StatusBar("Ready"), PrintConfig{Width: 11, Height: 1})
+	assert.Equal(t, "   Ready", screen.Row(0))
+}
+
+func TestStatusBar_Segments(t *testing.T) {
+	view := StatusBar("mid").
+		Left(Segment("a"), Segment("b")).
+		Right(KeyHint("q", "quit"))
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 1})
+	assert.Equal(t, " a  b   mid  q quit", screen.Row(0))
+}
+
+func TestStatusBar_DropsLowestPriority(t *testing.T) {
+	view := StatusBar("").
+		Left(Segment("main.go").WithPriority(2), Segment("utf-8")).
+		Right(KeyHint("^S", "save"), KeyHint("q", "quit"))
+
+	// Everything fits
+	screen := SprintScreen(view, PrintConfig{Width: 33, Height: 1})
+	assert.Equal(t, " main.go  utf-8  ^S save  q quit", screen.Row(0))
+
+	// Key hints go first, the last first
+	screen = SprintScreen(view, PrintConfig{Width: 25, Height: 1})
+	assert.Equal(t, " main.go  utf-8  ^S save", screen.Row(0))
+	screen = SprintScreen(view, PrintConfig{Width: 18, Height: 1})
+	assert.Equal(t, " main.go  utf-8", screen.Row(0))
+
+	// The highest priority segment is kept, and truncated if need be
+	screen = SprintScreen(view, PrintConfig{Width: 12, Height: 1})
+	assert.Equal(t, " main.go", screen.Row(0))
+	screen = SprintScreen(view, PrintConfig{Width: 6, Height: 1})
+	assert.Equal(t, " mai…", screen.Row(0))
+}
+
+func TestStatusBar_SegmentStyles(t *testing.T) {
+	mode := NewStyle().WithBackground(ColorGreen)
+	view := StatusBar("").Left(Segment("NORMAL").WithStyle(mode), KeyHint("q", "quit"))
+	terminal := NewTestTerminal(20, 1, &bytes.Buffer{})
+	frame, err := terminal.BeginFrame()
+	assert.NoError(t, err)
+	view.render(NewRenderContext(frame, 0))
+	terminal.EndFrame(frame)
+
+	n := terminal.GetCell(1, 0)
+	assert.Equal(t, 'N', n.Char)
+	assert.Equal(t, ColorGreen, n.Style.Background)
+	assert.Equal(t, ColorWhite, n.Style.Foreground)
+
+	q := terminal.GetCell(9, 0)
+	assert.Equal(t, 'q', q.Char)
+	assert.True(t, q.Style.Bold)
+	assert.Equal(t, ColorBrightBlack, q.Style.Background)
+	assert.False(t, terminal.GetCell(11, 0).Style.Bold)
+}
+
+func TestKeyHints(t *testing.T) {
+	hints := KeyHints(
+		Action{Name: "Save", Keybinding: "ctrl+s"},
+		Action{Name: "Reload"},
+		Action{Name: "Quit", Keybinding: "q"},
+	)
+	assert.Equal(t, []StatusSegment{
+		{Key: "ctrl+s", Text: "Save", Priority: -1},
+		{Key: "q", Text: "Quit", Priority: -1},
+	}, hints)
+}
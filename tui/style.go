@@ -42,6 +42,15 @@ type (
 	Box = terminal.Box
 	// MetricsSnapshot is a point-in-time snapshot of rendering metrics
 	MetricsSnapshot = terminal.MetricsSnapshot
+	// ColorProfile is the range of colors a terminal can show
+	ColorProfile = terminal.ColorProfile
+)
+
+// Re-export color profiles from terminal
+const (
+	ProfileTrueColor = terminal.ProfileTrueColor
+	ProfileANSI256   = terminal.ProfileANSI256
+	ProfileANSI16    = terminal.ProfileANSI16
 )
 
 // Re-export color constants from terminal
@@ -72,6 +81,8 @@ var (
 	RainbowGradient = terminal.RainbowGradient
 	SmoothRainbow   = terminal.SmoothRainbow
 	MultiGradient   = terminal.MultiGradient
+
+	DetectColorProfile = terminal.DetectColorProfile
 )
 
 // Re-export style constructor
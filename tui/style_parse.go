@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// namedColors maps color names accepted by ParseStyle and ParseColor to
+// ANSI colors.
+var namedColors = map[string]Color{
+	"default":       ColorDefault,
+	"black":         ColorBlack,
+	"red":           ColorRed,
+	"green":         ColorGreen,
+	"yellow":        ColorYellow,
+	"blue":          ColorBlue,
+	"magenta":       ColorMagenta,
+	"cyan":          ColorCyan,
+	"white":         ColorWhite,
+	"brightblack":   ColorBrightBlack,
+	"brightred":     ColorBrightRed,
+	"brightgreen":   ColorBrightGreen,
+	"brightyellow":  ColorBrightYellow,
+	"brightblue":    ColorBrightBlue,
+	"brightmagenta": ColorBrightMagenta,
+	"brightcyan":    ColorBrightCyan,
+	"brightwhite":   ColorBrightWhite,
+	"gray":          ColorBrightBlack,
+	"grey":          ColorBrightBlack,
+	"purple":        ColorMagenta,
+}
+
+// ParseColor parses a color name ("red", "bright-blue", "gray"), a
+// 256-color palette index ("208"), or a hex RGB value ("#ff8800" or
+// "#f80"). For hex values the returned RGB is non-nil and the Color is
+// ColorDefault.
+func ParseColor(s string) (Color, *RGB, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if strings.HasPrefix(name, "#") {
+		rgb, err := parseHexColor(name[1:])
+		if err != nil {
+			return ColorDefault, nil, fmt.Errorf("invalid color %q: %w", s, err)
+		}
+		return ColorDefault, &rgb, nil
+	}
+	if n, err := strconv.Atoi(name); err == nil {
+		if n < 0 || n > 255 {
+			return ColorDefault, nil, fmt.Errorf("invalid color %q: palette index must be 0-255", s)
+		}
+		return Color(n), nil, nil
+	}
+	name = strings.NewReplacer("-", "", "_", "", " ", "").Replace(name)
+	if c, ok := namedColors[name]; ok {
+		return c, nil, nil
+	}
+	return ColorDefault, nil, fmt.Errorf("unknown color %q", s)
+}
+
+func parseHexColor(hex string) (RGB, error) {
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return RGB{}, fmt.Errorf("expected 3 or 6 hex digits")
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return RGB{}, fmt.Errorf("expected 3 or 6 hex digits")
+	}
+	return NewRGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil
+}
+
+// ParseStyle parses a style description such as "bold red on black" or
+// "bold fg=red bg=#101820 underline", so styles can be defined in themes
+// and configuration files.
+//
+// The description is a whitespace-separated list of:
+//   - attributes: bold, italic, underline, strikethrough (or strike), blink,
+//     reverse, dim, hidden
+//   - a foreground color, either bare ("red") or as fg=COLOR
+//   - a background color, either as "on COLOR" or bg=COLOR
+//   - link=URL to make the text a hyperlink
+//
+// Colors are anything accepted by ParseColor. Matching is case-insensitive
+// and an empty description returns NewStyle().
+func ParseStyle(spec string) (Style, error) {
+	style := NewStyle()
+	fields := strings.Fields(spec)
+	for i := 0; i < len(fields); i++ {
+		token := fields[i]
+		lower := strings.ToLower(token)
+
+		switch lower {
+		case "bold":
+			style = style.WithBold()
+			continue
+		case "italic":
+			style = style.WithItalic()
+			continue
+		case "underline":
+			style = style.WithUnderline()
+			continue
+		case "strikethrough", "strike":
+			style = style.WithStrikethrough()
+			continue
+		case "blink":
+			style = style.WithBlink()
+			continue
+		case "reverse":
+			style = style.WithReverse()
+			continue
+		case "dim":
+			style = style.WithDim()
+			continue
+		case "hidden":
+			style.Hidden = true
+			continue
+		case "on":
+			if i+1 >= len(fields) {
+				return NewStyle(), fmt.Errorf("invalid style %q: \"on\" must be followed by a color", spec)
+			}
+			i++
+			var err error
+			if style, err = withColor(style, fields[i], true); err != nil {
+				return NewStyle(), fmt.Errorf("invalid style %q: %w", spec, err)
+			}
+			continue
+		}
+
+		key, value, hasValue := strings.Cut(token, "=")
+		var err error
+		switch {
+		case hasValue && strings.EqualFold(key, "fg"):
+			style, err = withColor(style, value, false)
+		case hasValue && strings.EqualFold(key, "bg"):
+			style, err = withColor(style, value, true)
+		case hasValue && strings.EqualFold(key, "link"):
+			style = style.WithURL(value)
+		case hasValue:
+			err = fmt.Errorf("unknown key %q", key)
+		default:
+			style, err = withColor(style, token, false)
+		}
+		if err != nil {
+			return NewStyle(), fmt.Errorf("invalid style %q: %w", spec, err)
+		}
+	}
+	return style, nil
+}
+
+// MustParseStyle is like ParseStyle but panics if the description is
+// invalid. Use it for styles defined in code.
+func MustParseStyle(spec string) Style {
+	style, err := ParseStyle(spec)
+	if err != nil {
+		panic(err)
+	}
+	return style
+}
+
+// withColor sets the foreground or background color of style from a color
+// description, replacing any previous color.
+func withColor(style Style, s string, background bool) (Style, error) {
+	c, rgb, err := ParseColor(s)
+	if err != nil {
+		return style, err
+	}
+	if background {
+		style.Background = c
+		style.BgRGB = rgb
+	} else {
+		style.Foreground = c
+		style.FgRGB = rgb
+	}
+	return style, nil
+}
@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Color
+	}{
+		{"red", ColorRed},
+		{"Bright-Blue", ColorBrightBlue},
+		{"bright_white", ColorBrightWhite},
+		{"gray", ColorBrightBlack},
+		{"default", ColorDefault},
+		{"208", Color(208)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			c, rgb, err := ParseColor(tt.in)
+			assert.NoError(t, err)
+			assert.Nil(t, rgb)
+			assert.Equal(t, tt.want, c)
+		})
+	}
+
+	_, rgb, err := ParseColor("#101820")
+	assert.NoError(t, err)
+	assert.Equal(t, NewRGB(0x10, 0x18, 0x20), *rgb)
+
+	_, rgb, err = ParseColor("#F80")
+	assert.NoError(t, err)
+	assert.Equal(t, NewRGB(0xff, 0x88, 0x00), *rgb)
+
+	for _, bad := range []string{"mauve", "#12", "#gggggg", "256", "-1"} {
+		_, _, err := ParseColor(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	style, err := ParseStyle("bold fg=red bg=#101820 underline")
+	assert.NoError(t, err)
+	assert.True(t, style.Bold)
+	assert.True(t, style.Underline)
+	assert.Equal(t, ColorRed, style.Foreground)
+	assert.Equal(t, NewRGB(0x10, 0x18, 0x20), *style.BgRGB)
+
+	style, err = ParseStyle("Bold red on black")
+	assert.NoError(t, err)
+	assert.Equal(t, NewStyle().WithBold().WithForeground(ColorRed).WithBackground(ColorBlack), style)
+
+	style, err = ParseStyle("italic strike dim reverse blink hidden link=https://example.com")
+	assert.NoError(t, err)
+	assert.True(t, style.Italic && style.Strikethrough && style.Dim && style.Reverse && style.Blink && style.Hidden)
+	assert.Equal(t, "https://example.com", style.URL)
+
+	// Later colors replace earlier ones, including RGB
+	style, err = ParseStyle("fg=#ffffff red")
+	assert.NoError(t, err)
+	assert.Nil(t, style.FgRGB)
+	assert.Equal(t, ColorRed, style.Foreground)
+
+	style, err = ParseStyle("  ")
+	assert.NoError(t, err)
+	assert.Equal(t, NewStyle(), style)
+}
+
+func TestParseStyle_Errors(t *testing.T) {
+	for _, bad := range []string{"bold on", "sparkly", "fg=nope", "size=3"} {
+		_, err := ParseStyle(bad)
+		assert.Error(t, err, bad)
+	}
+	assert.Panics(t, func() { MustParseStyle("sparkly") })
+	assert.Equal(t, NewStyle().WithItalic(), MustParseStyle("italic"))
+}
@@ -68,7 +68,8 @@ func (s *styledButtonView) Style(st Style) *styledButtonView {
 	return s
 }
 
-// HoverStyle sets the style when hovered (if hover tracking is enabled).
+// HoverStyle sets the style while the mouse pointer is over the button.
+// Hover requires mouse tracking (WithMouseTracking).
 func (s *styledButtonView) HoverStyle(st Style) *styledButtonView {
 	s.hoverStyle = st
 	return s
@@ -118,10 +119,15 @@ func (s *styledButtonView) render(ctx *RenderContext) {
 		return
 	}
 
+	style := s.style
+	if interactiveRegistry.Hovered(ctx.AbsoluteBounds()) {
+		style = s.hoverStyle
+	}
+
 	// Fill background
 	for y := 0; y < height; y++ {
 		for x := 0; x < width; x++ {
-			ctx.SetCell(x, y, ' ', s.style)
+			ctx.SetCell(x, y, ' ', style)
 		}
 	}
 
@@ -137,7 +143,7 @@ func (s *styledButtonView) render(ctx *RenderContext) {
 	}
 
 	// Draw label
-	ctx.PrintTruncated(textX, textY, s.label, s.style)
+	ctx.PrintTruncated(textX, textY, s.label, style)
 
 	// Register click region
 	if s.callback != nil {
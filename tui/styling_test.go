@@ -42,21 +42,21 @@ func TestCheckboxListStyling(t *testing.T) {
 	assert.NotNil(t, list.highlightStyle)
 }
 
-// TestSpinnerStyling tests the new styling methods for Spinner
+// TestSpinnerStyling tests the styling methods for Spinner
 func TestSpinnerStyling(t *testing.T) {
-	// Test that all new styling methods can be chained without panic
-	spinner := NewSpinner(SpinnerDots).
+	// Test that all styling methods can be chained without panic
+	spinner := Spinner(SpinnerDots).
 		Fg(ColorCyan).
 		Bg(ColorBlack).
 		Bold().
 		Dim().
-		WithStyle(NewStyle().WithForeground(ColorGreen)).
-		WithMessage("Loading...")
+		Style(NewStyle().WithForeground(ColorGreen)).
+		Label("Loading...")
 
 	// Verify the spinner was created
 	assert.NotNil(t, spinner)
-	assert.NotNil(t, spinner.style)
-	assert.Equal(t, "Loading...", spinner.message)
+	assert.Equal(t, NewStyle().WithForeground(ColorGreen), spinner.style)
+	assert.Equal(t, "Loading...", spinner.label)
 }
 
 // TestCheckboxListDefaultStyles tests that CheckboxList has sensible default styles
@@ -0,0 +1,81 @@
+package tui
+
+// suspenseView shows a placeholder until its content is ready.
+type suspenseView struct {
+	pending View
+	content func() View
+
+	resolved View // content or pending, chosen for the current frame
+}
+
+// Suspense shows pending until content returns a non-nil view, then shows
+// the content instead. content is called every frame, so it typically
+// checks data that a Cmd loads in the background:
+//
+//	func (app *App) View() tui.View {
+//	    return tui.Suspense(nil, func() tui.View {
+//	        if app.page == nil {
+//	            return nil // still loading
+//	        }
+//	        return tui.Markdown(app.page.Body, &app.scrollY)
+//	    })
+//	}
+//
+//	func (app *App) HandleEvent(e tui.Event) []tui.Cmd {
+//	    if loaded, ok := e.(PageLoadedEvent); ok {
+//	        app.page = loaded.Page
+//	    }
+//	    return nil
+//	}
+//
+// A nil pending view shows an animated "Loading..." line. Wrapping the root
+// view in Suspense gives the application a startup splash until its first
+// data arrives.
+func Suspense(pending View, content func() View) View {
+	if pending == nil {
+		pending = LoadingText("Loading...")
+	}
+	return &suspenseView{pending: pending, content: content}
+}
+
+// LoadingText returns a line of gray text with a highlight sweeping across
+// it, for use as a loading placeholder. Unlike Loading, it animates from the
+// render frame and needs no frame counter.
+//
+// Example:
+//
+//	tui.If(len(app.events) == 0, tui.LoadingText("Waiting for events..."))
+func LoadingText(label string) View {
+	return Text("%s", label).Animate(Slide(2, NewRGB(110, 110, 110), NewRGB(230, 230, 230)).WithWidth(4))
+}
+
+// resolve picks the view to show this frame.
+func (s *suspenseView) resolve() View {
+	if s.resolved == nil {
+		s.resolved = s.pending
+		if s.content != nil {
+			if content := s.content(); content != nil {
+				s.resolved = content
+			}
+		}
+	}
+	return s.resolved
+}
+
+func (s *suspenseView) size(maxWidth, maxHeight int) (int, int) {
+	return s.resolve().size(maxWidth, maxHeight)
+}
+
+// flex implements the Flexible interface by delegating to the view shown.
+func (s *suspenseView) flex() int {
+	if flex, ok := s.resolve().(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (s *suspenseView) render(ctx *RenderContext) {
+	view := s.resolve()
+	s.resolved = nil // check content again if the view is reused
+	view.render(ctx)
+}
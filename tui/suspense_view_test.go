@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestSuspense(t *testing.T) {
+	var data string
+	view := Suspense(Text("waiting"), func() View {
+		if data == "" {
+			return nil
+		}
+		return Text("%s", data)
+	})
+
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 1})
+	assert.Equal(t, "waiting", screen.Row(0))
+
+	data = "loaded"
+	screen = SprintScreen(view, PrintConfig{Width: 20, Height: 1})
+	assert.Equal(t, "loaded", screen.Row(0))
+}
+
+func TestSuspense_DefaultPending(t *testing.T) {
+	view := Suspense(nil, func() View { return nil })
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 1})
+	assert.Equal(t, "Loading...", screen.Row(0))
+}
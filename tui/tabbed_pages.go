@@ -0,0 +1,497 @@
+package tui
+
+import (
+	"fmt"
+	"image"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// TabPage is a single page managed by TabbedPages. Each page carries its own
+// application state, navigation history, scroll position, and loading flag so
+// that switching tabs never disturbs the others.
+//
+// State is the page's current application-defined state (a document, a
+// buffer, a REPL session). Navigate pushes a new state onto the page's
+// history; Back and Forward move through it.
+type TabPage[T any] struct {
+	// ID uniquely identifies the page for the lifetime of its TabbedPages.
+	// Unlike indices, IDs remain valid as tabs are opened and closed, so
+	// async work (like a page load) should refer to pages by ID.
+	ID int
+	// Title is shown in the tab strip.
+	Title string
+	// State is the page's current state.
+	State T
+	// ScrollY is the page's scroll offset, for use with scrollable views.
+	ScrollY int
+	// Loading marks the page as busy. The tab strip shows a spinner for it.
+	Loading bool
+	// Err records the last error for the page, if any.
+	Err error
+
+	history      []T
+	historyIndex int
+}
+
+// Navigate makes state the page's current state and records it in the page's
+// history. Any forward history is discarded and the scroll offset is reset.
+func (p *TabPage[T]) Navigate(state T) {
+	if p.historyIndex < len(p.history)-1 {
+		p.history = p.history[:p.historyIndex+1]
+	}
+	p.history = append(p.history, state)
+	p.historyIndex = len(p.history) - 1
+	p.State = state
+	p.ScrollY = 0
+	p.Err = nil
+}
+
+// Replace updates the current state, and the current history entry if there
+// is one, without adding to the history.
+func (p *TabPage[T]) Replace(state T) {
+	p.State = state
+	if p.historyIndex >= 0 && p.historyIndex < len(p.history) {
+		p.history[p.historyIndex] = state
+	}
+}
+
+// CanGoBack reports whether there is an earlier history entry.
+func (p *TabPage[T]) CanGoBack() bool {
+	return p.historyIndex > 0
+}
+
+// CanGoForward reports whether there is a later history entry.
+func (p *TabPage[T]) CanGoForward() bool {
+	return p.historyIndex < len(p.history)-1
+}
+
+// Back restores the previous history entry. It returns false if there is none.
+func (p *TabPage[T]) Back() bool {
+	if !p.CanGoBack() {
+		return false
+	}
+	p.historyIndex--
+	p.State = p.history[p.historyIndex]
+	p.ScrollY = 0
+	return true
+}
+
+// Forward restores the next history entry. It returns false if there is none.
+func (p *TabPage[T]) Forward() bool {
+	if !p.CanGoForward() {
+		return false
+	}
+	p.historyIndex++
+	p.State = p.history[p.historyIndex]
+	p.ScrollY = 0
+	return true
+}
+
+// History returns the page's history entries, oldest first.
+func (p *TabPage[T]) History() []T {
+	return p.history
+}
+
+// HistoryIndex returns the index of the current history entry, or -1 if the
+// page has no history yet.
+func (p *TabPage[T]) HistoryIndex() int {
+	return p.historyIndex
+}
+
+// TabbedPages manages an ordered set of independent pages with one active
+// page, the model behind document tabs in browsers, editors, and REPLs.
+//
+// TabbedPages is plain application state: keep it in your app struct, mutate
+// it from HandleEvent, and render it with TabStrip in View. It is not safe for
+// concurrent use; guard it with the same lock as the rest of your state when
+// background goroutines update pages.
+//
+// Example:
+//
+//	type App struct {
+//	    tabs *tui.TabbedPages[Document]
+//	}
+//
+//	func (a *App) HandleEvent(e tui.Event) []tui.Cmd {
+//	    if key, ok := e.(tui.KeyEvent); ok {
+//	        switch key.Rune {
+//	        case 't':
+//	            a.tabs.Open("untitled", Document{})
+//	        case 'w':
+//	            a.tabs.CloseActive()
+//	        case ']':
+//	            a.tabs.Next()
+//	        }
+//	    }
+//	    return nil
+//	}
+//
+//	func (a *App) View() tui.View {
+//	    page := a.tabs.Active()
+//	    return tui.Stack(
+//	        tui.TabStrip(a.tabs),
+//	        tui.Markdown(page.State.Text, &page.ScrollY),
+//	    )
+//	}
+type TabbedPages[T any] struct {
+	pages  []*TabPage[T]
+	active int
+	nextID int
+}
+
+// NewTabbedPages creates an empty set of pages.
+func NewTabbedPages[T any]() *TabbedPages[T] {
+	return &TabbedPages[T]{active: -1, nextID: 1}
+}
+
+// Open adds a new page after the last one and makes it active.
+func (t *TabbedPages[T]) Open(title string, state T) *TabPage[T] {
+	page := t.OpenBackground(title, state)
+	t.active = len(t.pages) - 1
+	return page
+}
+
+// OpenBackground adds a new page after the last one without changing the
+// active page (unless it is the first page).
+func (t *TabbedPages[T]) OpenBackground(title string, state T) *TabPage[T] {
+	page := &TabPage[T]{
+		ID:           t.nextID,
+		Title:        title,
+		State:        state,
+		historyIndex: -1,
+	}
+	t.nextID++
+	t.pages = append(t.pages, page)
+	if t.active < 0 {
+		t.active = 0
+	}
+	return page
+}
+
+// Close removes the page with the given ID. When the active page is closed,
+// the page to its right becomes active (or the one to its left if it was
+// last). It returns false if no page has that ID.
+func (t *TabbedPages[T]) Close(id int) bool {
+	idx := t.indexOf(id)
+	if idx < 0 {
+		return false
+	}
+	t.pages = append(t.pages[:idx], t.pages[idx+1:]...)
+	switch {
+	case len(t.pages) == 0:
+		t.active = -1
+	case idx < t.active:
+		t.active--
+	case t.active >= len(t.pages):
+		t.active = len(t.pages) - 1
+	}
+	return true
+}
+
+// CloseActive closes the active page. It returns false if there are no pages.
+func (t *TabbedPages[T]) CloseActive() bool {
+	page := t.Active()
+	if page == nil {
+		return false
+	}
+	return t.Close(page.ID)
+}
+
+// Switch makes the page at index active. It returns false if index is out
+// of range.
+func (t *TabbedPages[T]) Switch(index int) bool {
+	if index < 0 || index >= len(t.pages) {
+		return false
+	}
+	t.active = index
+	return true
+}
+
+// SwitchID makes the page with the given ID active. It returns false if no
+// page has that ID.
+func (t *TabbedPages[T]) SwitchID(id int) bool {
+	return t.Switch(t.indexOf(id))
+}
+
+// Next activates the page to the right of the active page, wrapping around.
+func (t *TabbedPages[T]) Next() {
+	if len(t.pages) > 0 {
+		t.active = (t.active + 1) % len(t.pages)
+	}
+}
+
+// Prev activates the page to the left of the active page, wrapping around.
+func (t *TabbedPages[T]) Prev() {
+	if len(t.pages) > 0 {
+		t.active = (t.active - 1 + len(t.pages)) % len(t.pages)
+	}
+}
+
+// Active returns the active page, or nil if there are no pages.
+func (t *TabbedPages[T]) Active() *TabPage[T] {
+	if t.active < 0 || t.active >= len(t.pages) {
+		return nil
+	}
+	return t.pages[t.active]
+}
+
+// ActiveIndex returns the index of the active page, or -1 if there are no pages.
+func (t *TabbedPages[T]) ActiveIndex() int {
+	return t.active
+}
+
+// Page returns the page with the given ID, or nil if it has been closed.
+func (t *TabbedPages[T]) Page(id int) *TabPage[T] {
+	if idx := t.indexOf(id); idx >= 0 {
+		return t.pages[idx]
+	}
+	return nil
+}
+
+// Pages returns the pages in tab order. The slice must not be modified.
+func (t *TabbedPages[T]) Pages() []*TabPage[T] {
+	return t.pages
+}
+
+// Len returns the number of open pages.
+func (t *TabbedPages[T]) Len() int {
+	return len(t.pages)
+}
+
+func (t *TabbedPages[T]) indexOf(id int) int {
+	for i, p := range t.pages {
+		if p.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// tabStripItem is the render-time snapshot of a single tab.
+type tabStripItem struct {
+	title   string
+	badge   string
+	loading bool
+}
+
+// tabStripView displays a one-line row of tabs.
+type tabStripView struct {
+	items         []tabStripItem
+	active        int
+	onSelect      func(index int)
+	activeStyle   Style
+	inactiveStyle Style
+	badgeStyle    Style
+	maxTabWidth   int
+	separator     string
+	numbered      bool
+}
+
+// TabStrip creates a one-line tab bar for a TabbedPages model. The active
+// tab is highlighted, loading pages show a spinner, and clicking a tab makes
+// it active. When the tabs don't fit, the strip scrolls to keep the active
+// tab visible.
+//
+// Example:
+//
+//	tui.TabStrip(app.tabs).MaxTabWidth(20).Numbered(true)
+func TabStrip[T any](tabs *TabbedPages[T]) *tabStripView {
+	v := newTabStrip(func(index int) { tabs.Switch(index) })
+	v.active = tabs.ActiveIndex()
+	for _, p := range tabs.Pages() {
+		v.items = append(v.items, tabStripItem{title: p.Title, loading: p.Loading})
+	}
+	return v
+}
+
+func newTabStrip(onSelect func(index int)) *tabStripView {
+	return &tabStripView{
+		onSelect:      onSelect,
+		activeStyle:   NewStyle().WithReverse().WithBold(),
+		inactiveStyle: NewStyle().WithForeground(ColorBrightBlack),
+		badgeStyle:    NewStyle().WithForeground(ColorYellow),
+		maxTabWidth:   24,
+		separator:     "│",
+	}
+}
+
+// ActiveStyle sets the style of the active tab.
+func (v *tabStripView) ActiveStyle(s Style) *tabStripView {
+	v.activeStyle = s
+	return v
+}
+
+// InactiveStyle sets the style of inactive tabs.
+func (v *tabStripView) InactiveStyle(s Style) *tabStripView {
+	v.inactiveStyle = s
+	return v
+}
+
+// BadgeStyle sets the style of tab badges, applied over the tab's style.
+func (v *tabStripView) BadgeStyle(s Style) *tabStripView {
+	v.badgeStyle = s
+	return v
+}
+
+// MaxTabWidth sets the maximum width of a tab label, including padding.
+// Longer titles are truncated with an ellipsis. Zero means unlimited.
+func (v *tabStripView) MaxTabWidth(w int) *tabStripView {
+	v.maxTabWidth = w
+	return v
+}
+
+// Separator sets the string drawn between tabs.
+func (v *tabStripView) Separator(s string) *tabStripView {
+	v.separator = s
+	return v
+}
+
+// Numbered prefixes each tab with its 1-based position, matching the common
+// Alt+1..9 / 1..9 switching shortcuts.
+func (v *tabStripView) Numbered(numbered bool) *tabStripView {
+	v.numbered = numbered
+	return v
+}
+
+// OnSelect overrides what happens when a tab is clicked. By default the
+// clicked tab becomes active.
+func (v *tabStripView) OnSelect(fn func(index int)) *tabStripView {
+	v.onSelect = fn
+	return v
+}
+
+// label returns the padded text of tab i. The spinner placeholder is a
+// single-cell space so that labels keep a stable width across frames.
+func (v *tabStripView) label(i int) string {
+	item := v.items[i]
+	title := item.title
+	if title == "" {
+		title = "untitled"
+	}
+	if v.numbered {
+		title = fmt.Sprintf("%d:%s", i+1, title)
+	}
+	prefix := " "
+	if item.loading {
+		prefix = "   "
+	}
+	text := prefix + title + " "
+	if item.badge != "" {
+		text += item.badge + " "
+	}
+	if v.maxTabWidth > 0 && runewidth.StringWidth(text) > v.maxTabWidth {
+		text = runewidth.Truncate(text, v.maxTabWidth-1, "…") + " "
+	}
+	return text
+}
+
+// visibleRange returns the first and last (exclusive) tab indices that fit
+// in width while keeping the active tab visible.
+func (v *tabStripView) visibleRange(widths []int, width int) (int, int) {
+	sepW := runewidth.StringWidth(v.separator)
+	fits := func(start, end int) bool {
+		total := 0
+		for i := start; i < end; i++ {
+			total += widths[i]
+			if i > start {
+				total += sepW
+			}
+		}
+		return total <= width
+	}
+
+	start := 0
+	if v.active > 0 {
+		for start < v.active && !fits(start, v.active+1) {
+			start++
+		}
+	}
+	end := start
+	for end < len(widths) && fits(start, end+1) {
+		end++
+	}
+	if end == start && start < len(widths) {
+		end = start + 1
+	}
+	return start, end
+}
+
+func (v *tabStripView) size(maxWidth, maxHeight int) (int, int) {
+	if len(v.items) == 0 {
+		return 0, 0
+	}
+	w := 0
+	for i := range v.items {
+		w += runewidth.StringWidth(v.label(i))
+		if i > 0 {
+			w += runewidth.StringWidth(v.separator)
+		}
+	}
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	return w, 1
+}
+
+func (v *tabStripView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 || len(v.items) == 0 {
+		return
+	}
+
+	widths := make([]int, len(v.items))
+	for i := range v.items {
+		widths[i] = runewidth.StringWidth(v.label(i))
+	}
+	start, end := v.visibleRange(widths, width)
+
+	spinner := SpinnerDots.Frames[int(ctx.Frame()/4)%len(SpinnerDots.Frames)]
+	bounds := ctx.AbsoluteBounds()
+	x := 0
+	for i := start; i < end && x < width; i++ {
+		if i > start {
+			ctx.PrintStyled(x, 0, v.separator, v.inactiveStyle)
+			x += runewidth.StringWidth(v.separator)
+		}
+		style := v.inactiveStyle
+		if i == v.active {
+			style = v.activeStyle
+		}
+		label := v.label(i)
+		ctx.PrintTruncated(x, 0, label, style)
+		if v.items[i].loading {
+			ctx.PrintStyled(x+1, 0, spinner, style)
+		}
+		if badge := v.items[i].badge; badge != "" && strings.HasSuffix(label, " "+badge+" ") {
+			badgeX := x + widths[i] - runewidth.StringWidth(badge) - 1
+			ctx.PrintTruncated(badgeX, 0, badge, style.Merge(v.badgeStyle))
+		}
+
+		if v.onSelect != nil {
+			tabW := widths[i]
+			if x+tabW > width {
+				tabW = width - x
+			}
+			idx := i // capture for closure
+			interactiveRegistry.RegisterButton(image.Rect(
+				bounds.Min.X+x,
+				bounds.Min.Y,
+				bounds.Min.X+x+tabW,
+				bounds.Min.Y+1,
+			), func() {
+				v.onSelect(idx)
+			})
+		}
+		x += widths[i]
+	}
+
+	// Indicate tabs hidden off either edge
+	if start > 0 {
+		ctx.PrintStyled(0, 0, "‹", v.inactiveStyle)
+	}
+	if end < len(v.items) {
+		ctx.PrintStyled(width-1, 0, "›", v.inactiveStyle)
+	}
+}
@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"bytes"
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestTabbedPages_OpenAndSwitch(t *testing.T) {
+	tabs := NewTabbedPages[string]()
+	assert.Nil(t, tabs.Active())
+	assert.Equal(t, -1, tabs.ActiveIndex())
+
+	a := tabs.Open("a", "A")
+	b := tabs.Open("b", "B")
+	assert.Equal(t, 2, tabs.Len())
+	assert.Equal(t, b, tabs.Active())
+	assert.NotEqual(t, a.ID, b.ID)
+
+	c := tabs.OpenBackground("c", "C")
+	assert.Equal(t, b, tabs.Active())
+	assert.Equal(t, c, tabs.Page(c.ID))
+
+	tabs.Next()
+	assert.Equal(t, c, tabs.Active())
+	tabs.Next()
+	assert.Equal(t, a, tabs.Active())
+	tabs.Prev()
+	assert.Equal(t, c, tabs.Active())
+
+	assert.True(t, tabs.SwitchID(b.ID))
+	assert.Equal(t, 1, tabs.ActiveIndex())
+	assert.False(t, tabs.Switch(5))
+	assert.False(t, tabs.SwitchID(99))
+}
+
+func TestTabbedPages_Close(t *testing.T) {
+	tabs := NewTabbedPages[int]()
+	a := tabs.Open("a", 1)
+	b := tabs.Open("b", 2)
+	c := tabs.Open("c", 3)
+
+	// Closing a tab before the active one keeps the same page active
+	assert.True(t, tabs.Close(a.ID))
+	assert.Equal(t, c, tabs.Active())
+	assert.Nil(t, tabs.Page(a.ID))
+
+	// Closing the last, active tab activates its left neighbour
+	assert.True(t, tabs.CloseActive())
+	assert.Equal(t, b, tabs.Active())
+
+	// Closing the active tab in the middle activates its right neighbour
+	d := tabs.Open("d", 4)
+	tabs.SwitchID(b.ID)
+	tabs.CloseActive()
+	assert.Equal(t, d, tabs.Active())
+
+	assert.True(t, tabs.CloseActive())
+	assert.Equal(t, 0, tabs.Len())
+	assert.Nil(t, tabs.Active())
+	assert.False(t, tabs.CloseActive())
+	assert.False(t, tabs.Close(b.ID))
+}
+
+func TestTabPage_History(t *testing.T) {
+	tabs := NewTabbedPages[string]()
+	page := tabs.Open("docs", "")
+	assert.False(t, page.CanGoBack())
+	assert.Equal(t, -1, page.HistoryIndex())
+
+	page.Navigate("one")
+	page.ScrollY = 10
+	page.Navigate("two")
+	assert.Equal(t, 0, page.ScrollY)
+	page.Navigate("three")
+
+	assert.True(t, page.Back())
+	assert.Equal(t, "two", page.State)
+	assert.True(t, page.Back())
+	assert.Equal(t, "one", page.State)
+	assert.False(t, page.Back())
+
+	assert.True(t, page.Forward())
+	assert.Equal(t, "two", page.State)
+
+	// Navigating from the middle discards forward history
+	page.Navigate("four")
+	assert.False(t, page.CanGoForward())
+	assert.Equal(t, []string{"one", "two", "four"}, page.History())
+
+	page.Replace("four!")
+	assert.Equal(t, "four!", page.History()[2])
+}
+
+func TestTabbedPages_IndependentState(t *testing.T) {
+	tabs := NewTabbedPages[string]()
+	a := tabs.Open("a", "")
+	b := tabs.Open("b", "")
+	a.Navigate("a1")
+	a.ScrollY = 5
+	b.Loading = true
+
+	assert.Equal(t, 5, a.ScrollY)
+	assert.Equal(t, 0, b.ScrollY)
+	assert.False(t, a.Loading)
+	assert.Equal(t, 0, len(b.History()))
+}
+
+func TestTabStrip_Render(t *testing.T) {
+	tabs := NewTabbedPages[string]()
+	tabs.Open("Home", "")
+	tabs.Open("Docs", "")
+
+	screen := SprintScreen(TabStrip(tabs).Numbered(true), PrintConfig{Width: 40})
+	row := screen.Row(0)
+	assert.Contains(t, row, "1:Home")
+	assert.Contains(t, row, "│")
+	assert.Contains(t, row, "2:Docs")
+
+	w, h := TabStrip(NewTabbedPages[string]()).size(40, 1)
+	assert.Equal(t, 0, w)
+	assert.Equal(t, 0, h)
+}
+
+func TestTabStrip_TruncatesAndScrollsToActive(t *testing.T) {
+	tabs := NewTabbedPages[string]()
+	tabs.Open("A very long tab title indeed", "")
+	for i := 0; i < 5; i++ {
+		tabs.Open("tab", "")
+	}
+	tabs.Open("Last", "")
+
+	screen := SprintScreen(TabStrip(tabs).MaxTabWidth(10), PrintConfig{Width: 20})
+	row := screen.Row(0)
+	assert.Contains(t, row, "Last")
+	assert.True(t, strings.HasPrefix(row, "‹"))
+
+	tabs.Switch(0)
+	screen = SprintScreen(TabStrip(tabs).MaxTabWidth(10), PrintConfig{Width: 20})
+	row = screen.Row(0)
+	assert.Contains(t, row, "A very …")
+	assert.Contains(t, row, "›")
+}
+
+func TestTabStrip_LoadingSpinner(t *testing.T) {
+	tabs := NewTabbedPages[string]()
+	page := tabs.Open("Loading", "")
+	page.Loading = true
+
+	screen := SprintScreen(TabStrip(tabs), PrintConfig{Width: 20})
+	assert.Contains(t, screen.Row(0), SpinnerDots.Frames[0]+" Loading")
+}
+
+func TestTabStrip_ClickSwitches(t *testing.T) {
+	tabs := NewTabbedPages[string]()
+	tabs.Open("one", "")
+	tabs.Open("two", "")
+	assert.Equal(t, 1, tabs.ActiveIndex())
+
+	interactiveRegistry.Clear()
+	defer interactiveRegistry.Clear()
+
+	var buf bytes.Buffer
+	terminal := NewTestTerminal(30, 1, &buf)
+	frame, err := terminal.BeginFrame()
+	assert.NoError(t, err)
+	defer terminal.EndFrame(frame)
+
+	ctx := NewRenderContext(frame, 0)
+	TabStrip(tabs).render(ctx.SubContext(image.Rect(0, 0, 30, 1)))
+
+	assert.True(t, interactiveRegistry.HandleClick(1, 0))
+	assert.Equal(t, 0, tabs.ActiveIndex())
+}
@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"image"
+	"slices"
+	"sync"
+)
+
+// tableDetailIndent is how far a detail view is indented under its row.
+const tableDetailIndent = 2
+
+// tableDetailRegistry keeps which row of each table with a Detail function
+// is expanded, keyed by table ID, since table views are rebuilt every
+// frame.
+var tableDetailRegistry = &tableDetailRegistryImpl{
+	rows:   make(map[string]int),
+	active: make(map[string]bool),
+}
+
+type tableDetailRegistryImpl struct {
+	mu     sync.Mutex
+	rows   map[string]int  // expanded row in Rows
+	active map[string]bool // tracks which IDs were accessed this frame
+}
+
+// Clear marks all entries as inactive. Called at the start of each frame.
+func (r *tableDetailRegistryImpl) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.active = make(map[string]bool)
+}
+
+// Prune removes entries that weren't accessed since the last Clear().
+func (r *tableDetailRegistryImpl) Prune() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id := range r.rows {
+		if !r.active[id] {
+			delete(r.rows, id)
+		}
+	}
+}
+
+// Get returns the expanded row of a table, or -1 if none is.
+func (r *tableDetailRegistryImpl) Get(id string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.active[id] = true
+	if row, exists := r.rows[id]; exists {
+		return row
+	}
+	return -1
+}
+
+// Set expands a row of a table, or collapses it with -1.
+func (r *tableDetailRegistryImpl) Set(id string, row int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.active[id] = true
+	if row < 0 {
+		delete(r.rows, id)
+		return
+	}
+	r.rows[id] = row
+}
+
+// Detail lets the user expand a row to show a view beneath it, such as the
+// full payload of a log entry. Enter or a click on a row shows its detail,
+// collapsing any other, and Enter again or Escape hides it. fn gets the
+// row's index in Rows, like OnSelect, and is only called for the expanded
+// row. The detail is indented under the row and keeps its natural height,
+// limited to what fits below the row; set DetailHeight to fix it.
+//
+// In a table with editable columns, Enter shows the detail and F2 or e
+// opens the cell editor (see OnEdit). While an edit is open, Enter and
+// Escape commit and cancel it.
+//
+// The expanded row is kept by the table's ID, so a table with details
+// needs an ID unless its selected pointer is unique.
+//
+// Example:
+//
+//	tui.Table(columns, &app.selected).Rows(app.rows()).
+//	    Detail(func(row int) tui.View {
+//	        return tui.Text("%s", app.requests[row].Body).Wrap()
+//	    })
+func (t *tableView) Detail(fn func(row int) View) *tableView {
+	t.detail = fn
+	return t
+}
+
+// DetailHeight sets the height of expanded detail views. The default, 0,
+// uses each view's natural height.
+func (t *tableView) DetailHeight(h int) *tableView {
+	t.detailHeight = h
+	return t
+}
+
+// expandedRow returns the displayed index of the row whose detail is shown
+// and its detail view, or -1 if no row is expanded.
+func (t *tableView) expandedRow() (int, View) {
+	if t.detail == nil {
+		return -1, nil
+	}
+	if t.detailView != nil {
+		return t.detailRow, t.detailView
+	}
+	src := tableDetailRegistry.Get(t.id)
+	row := src
+	if t.rowOrder != nil {
+		row = slices.Index(t.rowOrder, src)
+	}
+	if row < 0 || row >= len(t.rows) {
+		return -1, nil
+	}
+	if info, ok := t.rowInfoAt(row); ok && info.expandable() {
+		return -1, nil
+	}
+	t.detailRow, t.detailView = row, t.detail(src)
+	if t.detailView == nil {
+		return -1, nil
+	}
+	return t.detailRow, t.detailView
+}
+
+// detailLines returns the number of lines the expanded detail takes in a
+// table of the given width, with room for at most maxLines (0 for no
+// limit).
+func (t *tableView) detailLines(width, maxLines int) int {
+	_, view := t.expandedRow()
+	if view == nil {
+		return 0
+	}
+	h := t.detailHeight
+	if h <= 0 {
+		_, h = view.size(max(0, width-tableDetailIndent), maxLines)
+	}
+	if maxLines > 0 {
+		h = min(h, maxLines)
+	}
+	return max(0, h)
+}
+
+// toggleDetail expands the detail of a displayed row, or collapses it if
+// it is already expanded.
+func (t *tableView) toggleDetail(row int) {
+	if expanded, _ := t.expandedRow(); expanded == row {
+		tableDetailRegistry.Set(t.id, -1)
+	} else {
+		tableDetailRegistry.Set(t.id, t.sourceRow(row))
+	}
+	t.detailView = nil
+}
+
+// handleDetailKey expands the selected row with Enter, and collapses the
+// expanded row with Enter on it or Escape. It returns false for keys the
+// table should process normally.
+func (t *tableView) handleDetailKey(event KeyEvent) bool {
+	if t.detail == nil || t.selected == nil {
+		return false
+	}
+	switch event.Key {
+	case KeyEnter:
+		row := *t.selected
+		if row < 0 || row >= len(t.rows) {
+			return false
+		}
+		t.toggleDetail(row)
+		if expanded, _ := t.expandedRow(); expanded == row {
+			t.activate(row)
+		}
+		return true
+	case KeyEscape:
+		if expanded, _ := t.expandedRow(); expanded >= 0 {
+			tableDetailRegistry.Set(t.id, -1)
+			t.detailView = nil
+			return true
+		}
+	}
+	return false
+}
+
+// renderDetail draws the expanded detail view at line y, clipped to lines.
+func (t *tableView) renderDetail(ctx *RenderContext, view View, y, lines int) {
+	width, _ := ctx.Size()
+	detailCtx := ctx.SubContext(image.Rect(tableDetailIndent, y, width, y+lines))
+	w, h := detailCtx.Size()
+	if w == 0 || h == 0 {
+		return
+	}
+	view.size(w, h)
+	view.render(detailCtx)
+}
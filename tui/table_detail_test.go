@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func resetTableDetails() {
+	tableDetailRegistry.Clear()
+	tableDetailRegistry.Prune()
+}
+
+func TestTable_Detail(t *testing.T) {
+	defer resetTableDetails()
+
+	selected := 0
+	var selects []int
+	table := func() *tableView {
+		return Table([]TableColumn{{Title: "Method"}, {Title: "Path"}}, &selected).
+			ID("requests").
+			Rows([][]string{{"GET", "/"}, {"POST", "/login"}, {"GET", "/about"}}).
+			ShowHeader(false).
+			Detail(func(row int) View {
+				return Stack(Text("body %d", row), Text("status 200"))
+			}).
+			OnSelect(func(row int) { selects = append(selects, row) })
+	}
+	rows := func(view View, height int) []string {
+		screen := SprintScreen(view, PrintConfig{Width: 20, Height: height})
+		var lines []string
+		for i := range height {
+			lines = append(lines, screen.Row(i))
+		}
+		return lines
+	}
+
+	view := table()
+	view.HandleKeyEvent(KeyEvent{Key: KeyArrowDown})
+	assert.True(t, view.HandleKeyEvent(KeyEvent{Key: KeyEnter}))
+	assert.Equal(t, []int{1}, selects)
+	assert.Equal(t, []string{"GET       /", "POST      /login", "  body 1", "  status 200", "GET       /about"}, rows(table(), 5))
+
+	// The table grows to fit the detail
+	_, h := table().size(20, 0)
+	assert.Equal(t, 5, h)
+
+	// Enter on another row moves the detail, and Escape hides it
+	view = table()
+	view.HandleKeyEvent(KeyEvent{Key: KeyArrowDown})
+	view.HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, []string{"GET       /", "POST      /login", "GET       /about", "  body 2", "  status 200"}, rows(table(), 5))
+	assert.True(t, table().HandleKeyEvent(KeyEvent{Key: KeyEscape}))
+	assert.Equal(t, []string{"GET       /", "POST      /login", "GET       /about", "", ""}, rows(table(), 5))
+	assert.False(t, table().HandleKeyEvent(KeyEvent{Key: KeyEscape}))
+
+	// Enter on the expanded row hides it without selecting it again
+	table().HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	table().HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, []int{1, 2, 2}, selects)
+	_, h = table().size(20, 0)
+	assert.Equal(t, 3, h)
+}
+
+func TestTable_DetailScrollsIntoView(t *testing.T) {
+	defer resetTableDetails()
+
+	selected := 2
+	table := func() *tableView {
+		return Table([]TableColumn{{Title: "N"}}, &selected).
+			ID("scrolling").
+			Rows([][]string{{"a"}, {"b"}, {"c"}, {"d"}}).
+			ShowHeader(false).
+			Height(3).
+			Detail(func(row int) View { return Text("detail").Height(5) })
+	}
+
+	table().HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	screen := SprintScreen(table(), PrintConfig{Width: 10, Height: 3})
+	assert.Equal(t, "c", screen.Row(0))
+	assert.Equal(t, "  detail", screen.Row(1))
+
+	// With a fixed height, the detail is clipped to it
+	screen = SprintScreen(table().Height(4).DetailHeight(1), PrintConfig{Width: 10, Height: 4})
+	assert.Equal(t, "a", screen.Row(0))
+	assert.Equal(t, "c", screen.Row(2))
+	assert.Equal(t, "  detail", screen.Row(3))
+}
+
+func TestTable_DetailFollowsSort(t *testing.T) {
+	defer resetTableDetails()
+	defer resetTableLayouts()
+
+	selected := 0
+	layout := &TableLayout{}
+	var detailRow int
+	table := func() *tableView {
+		return Table([]TableColumn{{Title: "Name", Sortable: true}}, &selected).
+			ID("sorted").
+			Rows([][]string{{"b"}, {"a"}}).
+			Layout(layout).
+			ShowHeader(false).
+			Detail(func(row int) View {
+				detailRow = row
+				return Text("detail")
+			})
+	}
+
+	table().HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, 0, detailRow)
+
+	layout.SortBy("Name", false)
+	screen := SprintScreen(table(), PrintConfig{Width: 10, Height: 3})
+	assert.Equal(t, "a", screen.Row(0))
+	assert.Equal(t, "b", screen.Row(1))
+	assert.Equal(t, "  detail", screen.Row(2))
+	assert.Equal(t, 0, detailRow)
+}
+
+func TestTable_DetailWithEditableColumn(t *testing.T) {
+	defer resetTableDetails()
+	defer resetTableEdits()
+
+	selected := 0
+	var edits []string
+	table := func() *tableView {
+		return Table([]TableColumn{{Title: "Key"}, {Title: "Value", Editable: true}}, &selected).
+			ID("editable-detail").
+			Rows([][]string{{"name", "wonton"}}).
+			ShowHeader(false).
+			Detail(func(row int) View { return Text("detail") }).
+			OnEdit(func(row, col int, value string) { edits = append(edits, value) })
+	}
+
+	// Enter shows the detail rather than editing
+	assert.True(t, table().HandleKeyEvent(KeyEvent{Key: KeyEnter}))
+	screen := SprintScreen(table(), PrintConfig{Width: 20, Height: 2})
+	assert.Equal(t, "  detail", screen.Row(1))
+	assert.False(t, table().editState().editing)
+
+	// e opens the editor, and Enter commits the edit without hiding the detail
+	assert.True(t, table().HandleKeyEvent(KeyEvent{Rune: 'e'}))
+	assert.True(t, table().editState().editing)
+	table().HandleKeyEvent(KeyEvent{Rune: '!'})
+	table().HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, []string{"wonton!"}, edits)
+	screen = SprintScreen(table(), PrintConfig{Width: 20, Height: 2})
+	assert.Equal(t, "  detail", screen.Row(1))
+
+	// So does F2, and Escape cancels the edit before hiding the detail
+	table().HandleKeyEvent(KeyEvent{Key: KeyF2})
+	table().HandleKeyEvent(KeyEvent{Key: KeyEscape})
+	assert.False(t, table().editState().editing)
+	screen = SprintScreen(table(), PrintConfig{Width: 20, Height: 2})
+	assert.Equal(t, "  detail", screen.Row(1))
+	table().HandleKeyEvent(KeyEvent{Key: KeyEscape})
+	screen = SprintScreen(table(), PrintConfig{Width: 20, Height: 2})
+	assert.Equal(t, "", screen.Row(1))
+}
@@ -0,0 +1,218 @@
+package tui
+
+import (
+	"image"
+	"sync"
+)
+
+// tableEditRegistry keeps the cell cursor and any edit in progress for
+// tables with editable columns, keyed by table ID, since table views are
+// rebuilt every frame.
+var tableEditRegistry = &tableEditRegistryImpl{
+	states: make(map[string]*tableEditState),
+	active: make(map[string]bool),
+}
+
+type tableEditRegistryImpl struct {
+	mu     sync.Mutex
+	states map[string]*tableEditState
+	active map[string]bool // tracks which IDs were accessed this frame
+}
+
+type tableEditState struct {
+	col     int        // column of the cell cursor
+	editing bool       // whether an edit is in progress
+	row     int        // row being edited
+	input   *TextInput // editor for the cell being edited
+	err     string     // validation error for the current edit
+}
+
+// Clear marks all entries as inactive. Called at the start of each frame.
+func (r *tableEditRegistryImpl) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.active = make(map[string]bool)
+}
+
+// Prune removes entries that weren't accessed since the last Clear().
+func (r *tableEditRegistryImpl) Prune() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id := range r.states {
+		if !r.active[id] {
+			delete(r.states, id)
+		}
+	}
+}
+
+func (r *tableEditRegistryImpl) Get(id string) *tableEditState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.active[id] = true
+	if state, exists := r.states[id]; exists {
+		return state
+	}
+	state := &tableEditState{col: -1}
+	r.states[id] = state
+	return state
+}
+
+// OnEdit sets a callback invoked when an edit to a cell in an editable
+// column is committed with Enter. The table doesn't modify its rows, so
+// the callback should update the application's data.
+//
+// When a table has editable columns, Left and Right move a cell cursor
+// between them, Enter or F2 opens an editor in the cell, Enter again
+// validates and commits the edit, and Escape cancels it. In a table with a
+// Detail function, Enter shows the detail instead and F2 or e opens the
+// editor. A table with editable
+// columns needs an ID unless its selected pointer is unique.
+//
+// Example:
+//
+//	tui.Table([]tui.TableColumn{
+//	    {Title: "Key"},
+//	    {Title: "Value", Editable: true, Validate: validateValue},
+//	}, &app.selected).
+//	    Rows(app.rows()).
+//	    OnEdit(func(row, col int, value string) { app.config[row].Value = value })
+func (t *tableView) OnEdit(fn func(row, col int, value string)) *tableView {
+	t.onEdit = fn
+	return t
+}
+
+// EditCursorStyle sets the style of the cell cursor in editable tables.
+func (t *tableView) EditCursorStyle(s Style) *tableView {
+	t.editCursorStyle = s
+	return t
+}
+
+// editState returns the edit state for tables with editable columns, or
+// nil if no column is editable.
+func (t *tableView) editState() *tableEditState {
+	if t.edit != nil {
+		return t.edit
+	}
+	first := -1
+	for i, col := range t.columns {
+		if col.Editable {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return nil
+	}
+	t.edit = tableEditRegistry.Get(t.id)
+	if t.edit.col < 0 || t.edit.col >= len(t.columns) || !t.columns[t.edit.col].Editable {
+		t.edit.col = first
+	}
+	return t.edit
+}
+
+// moveEditCursor moves the cell cursor to the next editable column in
+// direction dir (-1 or 1). It returns false if there is none.
+func (t *tableView) moveEditCursor(edit *tableEditState, dir int) bool {
+	for col := edit.col + dir; col >= 0 && col < len(t.columns); col += dir {
+		if t.columns[col].Editable {
+			edit.col = col
+			return true
+		}
+	}
+	return false
+}
+
+// handleEditKey handles keys for tables with editable columns. It returns
+// false for keys the table should process normally.
+func (t *tableView) handleEditKey(edit *tableEditState, event KeyEvent) bool {
+	if edit.editing {
+		switch {
+		case event.Key == KeyEscape:
+			edit.editing = false
+		case event.Key == KeyEnter && !event.IsModifiedEnter():
+			t.commitEdit(edit)
+		default:
+			edit.input.SetFocused(true) // rendering unfocuses it while the table isn't focused
+			edit.input.HandleKey(event)
+			edit.err = ""
+		}
+		// Swallow every key so typing doesn't move the selection
+		return true
+	}
+
+	switch {
+	case event.Key == KeyArrowLeft:
+		return t.moveEditCursor(edit, -1)
+	case event.Key == KeyArrowRight:
+		return t.moveEditCursor(edit, 1)
+	case t.opensEditor(event):
+		if t.selected == nil || *t.selected < 0 || *t.selected >= len(t.rows) {
+			return false
+		}
+		row := *t.selected
+		value := ""
+		if edit.col < len(t.rows[row]) {
+			value = t.rows[row][edit.col]
+		}
+		edit.input = NewTextInput()
+		edit.input.SetValue(value)
+		edit.input.SetFocused(true)
+		edit.row = row
+		edit.err = ""
+		edit.editing = true
+		return true
+	}
+	return false
+}
+
+// opensEditor reports whether a key opens the editor: Enter or F2, or in
+// a table with a Detail function, where Enter shows the detail, F2 or e.
+func (t *tableView) opensEditor(event KeyEvent) bool {
+	if event.Key == KeyF2 {
+		return true
+	}
+	if t.detail != nil {
+		return event.Rune == 'e' && !event.Ctrl && !event.Alt
+	}
+	return event.Key == KeyEnter
+}
+
+// commitEdit validates the edited value and reports it with OnEdit.
+func (t *tableView) commitEdit(edit *tableEditState) {
+	value := edit.input.Value()
+	if validate := t.columns[edit.col].Validate; validate != nil {
+		if err := validate(value); err != nil {
+			edit.err = err.Error()
+			return
+		}
+	}
+	edit.editing = false
+	if t.onEdit != nil {
+		t.onEdit(t.sourceRow(edit.row), t.sourceColumn(edit.col), value)
+	}
+}
+
+// renderEditor draws the cell editor over the cell at (x, y) and any
+// validation error after it.
+func (t *tableView) renderEditor(ctx *RenderContext, edit *tableEditState, x, y int) {
+	width, _ := ctx.Size()
+	w := t.columnWidths[edit.col]
+	if x+w > width {
+		w = width - x
+	}
+	if w <= 0 {
+		return
+	}
+	cellCtx := ctx.SubContext(image.Rect(x, y, x+w, y+1))
+	edit.input.SetFocused(t.focused)
+	edit.input.SetBounds(cellCtx.AbsoluteBounds())
+	drawInput(cellCtx, edit.input)
+
+	if edit.err != "" {
+		errX := x + w + 1
+		if errX < width {
+			ctx.PrintTruncated(errX, y, "✗ "+edit.err, NewStyle().WithForeground(ColorRed))
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func resetTableEdits() {
+	tableEditRegistry.Clear()
+	tableEditRegistry.Prune()
+}
+
+func TestTable_InlineEdit(t *testing.T) {
+	defer resetTableEdits()
+
+	selected := 0
+	var edits []string
+	columns := []TableColumn{
+		{Title: "Key", Width: 6},
+		{Title: "Value", Width: 8, Editable: true, Validate: func(v string) error {
+			if v == "" {
+				return errors.New("required")
+			}
+			return nil
+		}},
+	}
+	table := func() *tableView {
+		return Table(columns, &selected).
+			ID("config").
+			Rows([][]string{{"name", "wonton"}, {"port", "80"}}).
+			OnEdit(func(row, col int, value string) { edits = append(edits, value) })
+	}
+
+	view := table()
+	assert.True(t, view.HandleKeyEvent(KeyEvent{Key: KeyEnter}))
+	assert.True(t, view.HandleKeyEvent(KeyEvent{Key: KeyCtrlU}))
+
+	// An invalid value keeps the editor open and shows the error
+	view = table()
+	assert.True(t, view.HandleKeyEvent(KeyEvent{Key: KeyEnter}))
+	screen := SprintScreen(view, PrintConfig{Width: 30, Height: 4})
+	assert.Equal(t, "name             ✗ required", screen.Row(2))
+
+	for _, r := range "dumpling" {
+		view.HandleKeyEvent(KeyEvent{Rune: r})
+	}
+	// Typing doesn't move the selection
+	view.HandleKeyEvent(KeyEvent{Rune: 'j'})
+	view.HandleKeyEvent(KeyEvent{Key: KeyArrowDown})
+	assert.Equal(t, 0, selected)
+
+	assert.True(t, table().HandleKeyEvent(KeyEvent{Key: KeyEnter}))
+	assert.Equal(t, []string{"dumplingj"}, edits)
+
+	// Escape cancels without reporting an edit
+	view = table()
+	view.HandleKeyEvent(KeyEvent{Key: KeyArrowDown})
+	view.HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	view.HandleKeyEvent(KeyEvent{Rune: '1'})
+	view.HandleKeyEvent(KeyEvent{Key: KeyEscape})
+	assert.Equal(t, 1, selected)
+	assert.Equal(t, 1, len(edits))
+	assert.False(t, table().HandleKeyEvent(KeyEvent{Key: KeyArrowLeft}))
+}
+
+func TestTable_EditCursorColumns(t *testing.T) {
+	defer resetTableEdits()
+
+	selected := 0
+	var editedCol int
+	view := Table([]TableColumn{{Title: "A", Editable: true}, {Title: "B"}, {Title: "C", Editable: true}}, &selected).
+		ID("cols").
+		Rows([][]string{{"1", "2", "3"}}).
+		OnEdit(func(row, col int, value string) { editedCol = col })
+
+	assert.True(t, view.HandleKeyEvent(KeyEvent{Key: KeyArrowRight}))
+	assert.False(t, view.HandleKeyEvent(KeyEvent{Key: KeyArrowRight}))
+	view.HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	view.HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, 2, editedCol)
+}
@@ -0,0 +1,344 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TableRowGroup is a set of table rows shown under a collapsible header row.
+// Keep groups in your application model so their Collapsed state survives
+// across frames.
+type TableRowGroup struct {
+	Title     string
+	Rows      [][]string
+	Collapsed bool
+
+	// Data holds arbitrary user data associated with this group.
+	Data any
+}
+
+// TableNode is a row of a tree table. Its children are shown indented
+// beneath it while it is expanded.
+type TableNode struct {
+	Cells    []string
+	Children []*TableNode
+	Expanded bool
+
+	// Data holds arbitrary user data associated with this node.
+	Data any
+}
+
+// AggregateCount is a TableColumn.Aggregate function that shows the number
+// of rows.
+func AggregateCount(values []string) string {
+	return strconv.Itoa(len(values))
+}
+
+// AggregateSum is a TableColumn.Aggregate function that adds up the numeric
+// values, ignoring thousands separators and cells that aren't numbers.
+func AggregateSum(values []string) string {
+	var sum float64
+	for _, v := range values {
+		if n, ok := parseTableNumber(v); ok {
+			sum += n
+		}
+	}
+	return strconv.FormatFloat(sum, 'f', -1, 64)
+}
+
+// tableRowInfo describes a displayed row of a grouped or tree table.
+type tableRowInfo struct {
+	group    *TableRowGroup // group of the row, or the group a header row heads
+	groupRow int            // index in group.Rows, or -1 for a header row
+	node     *TableNode
+	depth    int
+}
+
+// expandable reports whether the row can be expanded or collapsed.
+func (r tableRowInfo) expandable() bool {
+	if r.group != nil {
+		return r.groupRow < 0
+	}
+	return r.node != nil && len(r.node.Children) > 0
+}
+
+func (r tableRowInfo) expanded() bool {
+	if r.group != nil {
+		return !r.group.Collapsed
+	}
+	return r.node.Expanded
+}
+
+func (r tableRowInfo) setExpanded(expanded bool) {
+	if r.group != nil {
+		r.group.Collapsed = !expanded
+	} else {
+		r.node.Expanded = expanded
+	}
+}
+
+// Groups shows rows under collapsible group headers, replacing Rows. Header
+// rows show the group title in the first column and, in columns with an
+// Aggregate function, a summary of the group's rows.
+//
+// Right and Left expand and collapse the selected group, Space or Enter
+// toggles it, and clicking a header toggles it. Row indexes passed to
+// OnSelect and OnEdit count the displayed rows, headers included; use
+// OnSelectGroupRow to get the group and row instead.
+//
+// Example:
+//
+//	tui.Table([]tui.TableColumn{
+//	    {Title: "Name"},
+//	    {Title: "Size", Aggregate: tui.AggregateSum},
+//	}, &app.selected).Groups(app.groups)
+func (t *tableView) Groups(groups []*TableRowGroup) *tableView {
+	t.groups = groups
+	return t
+}
+
+// Tree shows a tree table, replacing Rows: the first column is indented to
+// show each node's depth, with a marker on nodes that have children. A node
+// with children fills its empty cells in columns with an Aggregate function
+// from its leaf descendants, so a directory can show the total size of its
+// files.
+//
+// Right and Left expand and collapse the selected node (Left on a leaf or
+// collapsed node selects its parent), and Space or Enter toggles it. Row
+// indexes passed to OnSelect and OnEdit count the displayed rows; use
+// OnSelectNode to get the node instead.
+//
+// Example:
+//
+//	tui.Table([]tui.TableColumn{
+//	    {Title: "Process"},
+//	    {Title: "CPU %", Aggregate: tui.AggregateSum},
+//	}, &app.selected).Tree(app.processes).OnSelectNode(app.inspect)
+func (t *tableView) Tree(roots []*TableNode) *tableView {
+	t.tree = roots
+	return t
+}
+
+// OnSelectGroupRow sets a callback invoked when a row of a grouped table is
+// selected with Enter or a click. row is the index in group.Rows.
+func (t *tableView) OnSelectGroupRow(fn func(group *TableRowGroup, row int)) *tableView {
+	t.onSelectGroupRow = fn
+	return t
+}
+
+// OnSelectNode sets a callback invoked when a leaf row of a tree table is
+// selected with Enter or a click.
+func (t *tableView) OnSelectNode(fn func(node *TableNode)) *tableView {
+	t.onSelectNode = fn
+	return t
+}
+
+// GroupStyle sets the style of group header rows. The default is bold.
+func (t *tableView) GroupStyle(s Style) *tableView {
+	t.groupStyle = s
+	return t
+}
+
+// prepare builds the displayed rows and columns from groups, the tree, and
+// the layout. It runs once, before the table is first measured.
+func (t *tableView) prepare() {
+	if t.prepared {
+		return
+	}
+	t.prepared = true
+	t.flattenRows()
+	t.applyLayout()
+	t.sortRows()
+	t.applyWidths()
+	t.decorateRows()
+}
+
+// flattenRows turns groups or the tree into displayed rows.
+func (t *tableView) flattenRows() {
+	switch {
+	case t.groups != nil:
+		t.rows, t.rowInfo = nil, nil
+		for _, g := range t.groups {
+			t.addRow(t.aggregateCells(make([]string, len(t.columns)), g.Rows), tableRowInfo{group: g, groupRow: -1})
+			if g.Collapsed {
+				continue
+			}
+			for _, i := range t.sortOrder(len(g.Rows), func(i int) []string { return g.Rows[i] }) {
+				t.addRow(g.Rows[i], tableRowInfo{group: g, groupRow: i})
+			}
+		}
+	case t.tree != nil:
+		t.rows, t.rowInfo = nil, nil
+		for _, node := range t.sortNodes(t.tree) {
+			t.addNode(node, 0)
+		}
+	}
+}
+
+// addRow appends a copy of cells as a displayed row.
+func (t *tableView) addRow(cells []string, info tableRowInfo) {
+	row := make([]string, max(len(cells), len(t.columns)))
+	copy(row, cells)
+	t.rows = append(t.rows, row)
+	t.rowInfo = append(t.rowInfo, info)
+}
+
+func (t *tableView) addNode(node *TableNode, depth int) {
+	cells := node.Cells
+	if len(node.Children) > 0 {
+		cells = t.aggregateCells(append([]string(nil), cells...), leafCells(node, nil))
+	}
+	t.addRow(cells, tableRowInfo{node: node, depth: depth})
+	if node.Expanded {
+		for _, child := range t.sortNodes(node.Children) {
+			t.addNode(child, depth+1)
+		}
+	}
+}
+
+// sortNodes returns nodes in the order they are shown.
+func (t *tableView) sortNodes(nodes []*TableNode) []*TableNode {
+	sorted := make([]*TableNode, len(nodes))
+	for i, n := range t.sortOrder(len(nodes), func(i int) []string { return nodes[i].Cells }) {
+		sorted[i] = nodes[n]
+	}
+	return sorted
+}
+
+// leafCells collects the cells of the leaf descendants of node.
+func leafCells(node *TableNode, rows [][]string) [][]string {
+	for _, child := range node.Children {
+		if len(child.Children) == 0 {
+			rows = append(rows, child.Cells)
+		} else {
+			rows = leafCells(child, rows)
+		}
+	}
+	return rows
+}
+
+// aggregateCells fills the empty cells of columns with an Aggregate
+// function from rows.
+func (t *tableView) aggregateCells(cells []string, rows [][]string) []string {
+	for len(cells) < len(t.columns) {
+		cells = append(cells, "")
+	}
+	for col, column := range t.columns {
+		if column.Aggregate == nil || cells[col] != "" {
+			continue
+		}
+		values := make([]string, 0, len(rows))
+		for _, row := range rows {
+			if col < len(row) {
+				values = append(values, row[col])
+			}
+		}
+		cells[col] = column.Aggregate(values)
+	}
+	return cells
+}
+
+// decorateRows adds group titles, indentation, and expand markers to the
+// first displayed column.
+func (t *tableView) decorateRows() {
+	for i, info := range t.rowInfo {
+		row := t.rows[i]
+		if len(row) == 0 {
+			continue
+		}
+		marker := "  "
+		if info.expandable() {
+			marker = t.expandMarker(info.expanded())
+		}
+		switch {
+		case info.group != nil && info.groupRow < 0:
+			row[0] = marker + info.group.Title
+		case info.group != nil:
+			row[0] = "  " + row[0]
+		default:
+			row[0] = strings.Repeat("  ", info.depth) + marker + row[0]
+		}
+	}
+}
+
+func (t *tableView) expandMarker(expanded bool) string {
+	if expanded {
+		return "▾ "
+	}
+	return "▸ "
+}
+
+// rowInfoAt returns the grouping information of a displayed row, if any.
+func (t *tableView) rowInfoAt(row int) (tableRowInfo, bool) {
+	if row < 0 || row >= len(t.rowInfo) {
+		return tableRowInfo{}, false
+	}
+	return t.rowInfo[row], true
+}
+
+// handleGroupKey expands and collapses groups and tree nodes. It returns
+// false for keys the table should process normally.
+func (t *tableView) handleGroupKey(event KeyEvent) bool {
+	if t.selected == nil {
+		return false
+	}
+	info, ok := t.rowInfoAt(*t.selected)
+	if !ok {
+		return false
+	}
+	switch {
+	case event.Key == KeyArrowRight:
+		if info.expandable() && !info.expanded() {
+			info.setExpanded(true)
+			return true
+		}
+	case event.Key == KeyArrowLeft:
+		if info.expandable() && info.expanded() {
+			info.setExpanded(false)
+			return true
+		}
+		if parent := t.parentRow(*t.selected); parent >= 0 {
+			*t.selected = parent
+			return true
+		}
+	case event.Rune == ' ' || event.Key == KeyEnter:
+		if info.expandable() {
+			info.setExpanded(!info.expanded())
+			return true
+		}
+	}
+	return false
+}
+
+// parentRow returns the displayed row of the group header or parent node
+// of row, or -1 if it has none.
+func (t *tableView) parentRow(row int) int {
+	info := t.rowInfo[row]
+	for i := row - 1; i >= 0; i-- {
+		above := t.rowInfo[i]
+		if info.group != nil && above.group == info.group && above.groupRow < 0 {
+			return i
+		}
+		if info.node != nil && above.node != nil && above.depth < info.depth {
+			return i
+		}
+	}
+	return -1
+}
+
+// activate reports the selection of a displayed row to the select callbacks.
+func (t *tableView) activate(row int) {
+	if t.onSelect != nil {
+		t.onSelect(t.sourceRow(row))
+	}
+	info, ok := t.rowInfoAt(row)
+	if !ok {
+		return
+	}
+	switch {
+	case info.group != nil && info.groupRow >= 0 && t.onSelectGroupRow != nil:
+		t.onSelectGroupRow(info.group, info.groupRow)
+	case info.node != nil && t.onSelectNode != nil:
+		t.onSelectNode(info.node)
+	}
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestAggregates(t *testing.T) {
+	assert.Equal(t, "3", AggregateCount([]string{"a", "b", ""}))
+	assert.Equal(t, "1012.5", AggregateSum([]string{"1,000", " 12.5", "n/a"}))
+}
+
+func TestTable_Groups(t *testing.T) {
+	groups := []*TableRowGroup{
+		{Title: "src", Rows: [][]string{{"main.go", "120"}, {"util.go", "30"}}},
+		{Title: "docs", Rows: [][]string{{"README", "40"}}, Collapsed: true},
+	}
+	columns := []TableColumn{{Title: "File"}, {Title: "Lines", Aggregate: AggregateSum}}
+	selected := 0
+	var picked []string
+	table := func() *tableView {
+		return Table(columns, &selected).
+			Groups(groups).
+			HeaderBottomBorder(false).
+			OnSelectGroupRow(func(g *TableRowGroup, row int) { picked = append(picked, g.Rows[row][0]) })
+	}
+
+	screen := SprintScreen(table(), PrintConfig{Width: 30, Height: 5})
+	assert.Equal(t, "File         Lines", screen.Row(0))
+	assert.Equal(t, "▾ src        150", screen.Row(1))
+	assert.Equal(t, "  main.go    120", screen.Row(2))
+	assert.Equal(t, "  util.go    30", screen.Row(3))
+	assert.Equal(t, "▸ docs       40", screen.Row(4))
+
+	// Left collapses the selected group and Right expands it
+	view := table()
+	assert.True(t, view.HandleKeyEvent(KeyEvent{Key: KeyArrowLeft}))
+	assert.True(t, groups[0].Collapsed)
+	view = table()
+	view.HandleKeyEvent(KeyEvent{Key: KeyArrowDown})
+	assert.True(t, view.HandleKeyEvent(KeyEvent{Key: KeyEnter}))
+	assert.False(t, groups[1].Collapsed)
+
+	// Enter on a row reports it, and Left moves to its header
+	view = table()
+	view.HandleKeyEvent(KeyEvent{Key: KeyArrowDown})
+	view.HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, []string{"README"}, picked)
+	view.HandleKeyEvent(KeyEvent{Key: KeyArrowLeft})
+	assert.Equal(t, 1, selected)
+}
+
+func TestTable_Tree(t *testing.T) {
+	roots := []*TableNode{{
+		Cells:    []string{"init", ""},
+		Expanded: true,
+		Children: []*TableNode{
+			{Cells: []string{"sshd", "1.5"}},
+			{Cells: []string{"bash", ""}, Children: []*TableNode{
+				{Cells: []string{"vim", "2"}},
+			}},
+		},
+	}}
+	columns := []TableColumn{{Title: "Process"}, {Title: "CPU", Aggregate: AggregateSum}}
+	selected := 2
+	var node *TableNode
+	table := func() *tableView {
+		return Table(columns, &selected).
+			Tree(roots).
+			HeaderBottomBorder(false).
+			OnSelectNode(func(n *TableNode) { node = n })
+	}
+
+	screen := SprintScreen(table(), PrintConfig{Width: 30, Height: 5})
+	assert.Equal(t, "▾ init      3.5", screen.Row(1))
+	assert.Equal(t, "    sshd    1.5", screen.Row(2))
+	assert.Equal(t, "  ▸ bash    2", screen.Row(3))
+	assert.Equal(t, "", screen.Row(4))
+
+	view := table()
+	assert.True(t, view.HandleKeyEvent(KeyEvent{Key: KeyArrowRight}))
+	assert.True(t, roots[0].Children[1].Expanded)
+
+	// Enter on a leaf reports the node; Left selects its parent
+	view = table()
+	view.HandleKeyEvent(KeyEvent{Key: KeyArrowDown})
+	view.HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, "vim", node.Cells[0])
+	view.HandleKeyEvent(KeyEvent{Key: KeyArrowLeft})
+	assert.Equal(t, 2, selected)
+}
@@ -0,0 +1,377 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// TableLayout holds the user's choice of visible table columns, their
+// order and widths, and the sort order. Columns are identified by title,
+// so a saved layout still applies after columns are added or removed:
+// unknown titles are ignored and new columns appear after the ordered
+// ones.
+//
+// Keep a TableLayout in your application model, pass it to Table with
+// Layout, and let the user change it with ColumnPicker. Save and
+// LoadTableLayout persist it as JSON.
+type TableLayout struct {
+	Order  []string       `json:"order,omitempty"`  // Column titles in display order
+	Hidden []string       `json:"hidden,omitempty"` // Titles of hidden columns
+	Widths map[string]int `json:"widths,omitempty"` // Column widths set by the user, by title
+
+	// SortColumn is the title of the column the rows are sorted by, if any
+	SortColumn     string `json:"sort_column,omitempty"`
+	SortDescending bool   `json:"sort_descending,omitempty"`
+
+	// From the last render of a ColumnPicker
+	cursor int
+}
+
+// LoadTableLayout reads a layout saved with Save. A missing file gives an
+// empty layout, which shows every column in its original order.
+func LoadTableLayout(path string) (*TableLayout, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &TableLayout{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var l TableLayout
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("%s: parse table layout: %w", path, err)
+	}
+	return &l, nil
+}
+
+// Save writes the layout to path as JSON, creating its directory if needed.
+func (l *TableLayout) Save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Visible reports whether the column with the given title is shown.
+func (l *TableLayout) Visible(title string) bool {
+	return !slices.Contains(l.Hidden, title)
+}
+
+// SetVisible shows or hides the column with the given title.
+func (l *TableLayout) SetVisible(title string, visible bool) {
+	if visible {
+		l.Hidden = slices.DeleteFunc(l.Hidden, func(t string) bool { return t == title })
+	} else if l.Visible(title) {
+		l.Hidden = append(l.Hidden, title)
+	}
+}
+
+// Move moves the column with the given title by delta places in the
+// display order (negative moves it left). columns are the table's columns,
+// needed to place columns the layout doesn't order yet.
+func (l *TableLayout) Move(columns []TableColumn, title string, delta int) {
+	order := l.order(columns)
+	from := slices.IndexFunc(order, func(i int) bool { return columns[i].Title == title })
+	if from < 0 {
+		return
+	}
+	to := max(0, min(from+delta, len(order)-1))
+	col := order[from]
+	order = slices.Delete(order, from, from+1)
+	order = slices.Insert(order, to, col)
+
+	l.Order = l.Order[:0]
+	for _, i := range order {
+		l.Order = append(l.Order, columns[i].Title)
+	}
+}
+
+// Columns returns the indexes of the visible columns in display order.
+func (l *TableLayout) Columns(columns []TableColumn) []int {
+	return slices.DeleteFunc(l.order(columns), func(i int) bool {
+		return !l.Visible(columns[i].Title)
+	})
+}
+
+// order returns the indexes of all columns in display order: those named
+// in Order first, then the rest in their original order.
+func (l *TableLayout) order(columns []TableColumn) []int {
+	order := make([]int, 0, len(columns))
+	placed := make([]bool, len(columns))
+	for _, title := range l.Order {
+		for i, col := range columns {
+			if !placed[i] && col.Title == title {
+				order = append(order, i)
+				placed[i] = true
+				break
+			}
+		}
+	}
+	for i := range columns {
+		if !placed[i] {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// Layout applies a column layout to the table, showing only its visible
+// columns in its order. Row cells are matched to columns by position in
+// Rows, as without a layout, and OnEdit reports the original column index.
+func (t *tableView) Layout(l *TableLayout) *tableView {
+	t.layout = l
+	return t
+}
+
+// applyLayout narrows and reorders the columns and row cells to the
+// layout.
+func (t *tableView) applyLayout() {
+	if t.layout == nil || t.layoutColumns != nil {
+		return
+	}
+	t.layoutColumns = t.layout.Columns(t.columns)
+
+	columns := make([]TableColumn, len(t.layoutColumns))
+	for i, src := range t.layoutColumns {
+		columns[i] = t.columns[src]
+	}
+	t.columns = columns
+
+	rows := make([][]string, len(t.rows))
+	for r, row := range t.rows {
+		cells := make([]string, len(t.layoutColumns))
+		for i, src := range t.layoutColumns {
+			if src < len(row) {
+				cells[i] = row[src]
+			}
+		}
+		rows[r] = cells
+	}
+	t.rows = rows
+}
+
+// sourceColumn maps a displayed column index to its index in the columns
+// passed to Table.
+func (t *tableView) sourceColumn(col int) int {
+	if t.layoutColumns == nil {
+		return col
+	}
+	return t.layoutColumns[col]
+}
+
+// columnPickerView lets the user choose which table columns are shown and
+// in what order.
+type columnPickerView struct {
+	id       string
+	columns  []TableColumn
+	layout   *TableLayout
+	onChange func()
+	onClose  func()
+	bounds   image.Rectangle
+	focused  bool
+
+	style       Style
+	cursorStyle Style
+}
+
+// ColumnPicker creates a column chooser for a table's columns that edits
+// layout. It draws a bordered checklist, so it works as an overlay in a
+// ZStack over the table; focus it with the Focus command when it opens.
+//
+// Up and Down (or k and j) move the cursor, Space shows or hides the column
+// under it, Shift+Up and Shift+Down (or K and J) move the column, and Enter
+// or Escape calls OnClose. The last visible column can't be hidden.
+//
+// Example:
+//
+//	func (app *App) View() tui.View {
+//	    return tui.ZStack(
+//	        tui.Table(columns, &app.selected).Rows(app.rows).Layout(app.layout),
+//	        tui.If(app.picking, tui.ColumnPicker(columns, app.layout).
+//	            OnChange(func() { app.layout.Save(app.layoutPath) }).
+//	            OnClose(func() { app.picking = false })),
+//	    )
+//	}
+func ColumnPicker(columns []TableColumn, layout *TableLayout) *columnPickerView {
+	return &columnPickerView{
+		id:          "column-picker",
+		columns:     columns,
+		layout:      layout,
+		style:       NewStyle(),
+		cursorStyle: NewStyle().WithReverse(),
+	}
+}
+
+// ID sets the focus ID (default "column-picker").
+func (p *columnPickerView) ID(id string) *columnPickerView {
+	p.id = id
+	return p
+}
+
+// OnChange sets a callback invoked after the user changes the layout, for
+// example to save it.
+func (p *columnPickerView) OnChange(fn func()) *columnPickerView {
+	p.onChange = fn
+	return p
+}
+
+// OnClose sets a callback invoked when Enter or Escape is pressed.
+func (p *columnPickerView) OnClose(fn func()) *columnPickerView {
+	p.onClose = fn
+	return p
+}
+
+// Style sets the style of the column rows.
+func (p *columnPickerView) Style(s Style) *columnPickerView {
+	p.style = s
+	return p
+}
+
+// CursorStyle sets the style of the row under the cursor. The default is
+// reverse video.
+func (p *columnPickerView) CursorStyle(s Style) *columnPickerView {
+	p.cursorStyle = s
+	return p
+}
+
+// Focusable interface implementation
+func (p *columnPickerView) FocusID() string {
+	return p.id
+}
+
+func (p *columnPickerView) IsFocused() bool {
+	return p.focused
+}
+
+func (p *columnPickerView) SetFocused(focused bool) {
+	p.focused = focused
+}
+
+func (p *columnPickerView) FocusBounds() image.Rectangle {
+	return p.bounds
+}
+
+func (p *columnPickerView) HandleKeyEvent(event KeyEvent) bool {
+	order := p.layout.order(p.columns)
+	if len(order) == 0 {
+		return false
+	}
+	l := p.layout
+	l.cursor = max(0, min(l.cursor, len(order)-1))
+	title := p.columns[order[l.cursor]].Title
+
+	switch {
+	case event.Key == KeyEnter || event.Key == KeyEscape:
+		if p.onClose != nil {
+			p.onClose()
+		}
+	case (event.Key == KeyArrowUp && event.Shift) || event.Rune == 'K':
+		p.move(title, -1)
+	case (event.Key == KeyArrowDown && event.Shift) || event.Rune == 'J':
+		p.move(title, 1)
+	case event.Key == KeyArrowUp || event.Rune == 'k':
+		l.cursor = max(0, l.cursor-1)
+	case event.Key == KeyArrowDown || event.Rune == 'j':
+		l.cursor = min(len(order)-1, l.cursor+1)
+	case event.Rune == ' ':
+		p.toggle(title)
+	default:
+		return false
+	}
+	return true
+}
+
+// move moves the column under the cursor, keeping the cursor on it.
+func (p *columnPickerView) move(title string, delta int) {
+	l := p.layout
+	l.Move(p.columns, title, delta)
+	l.cursor = max(0, min(l.cursor+delta, len(p.columns)-1))
+	p.changed()
+}
+
+// toggle shows or hides a column, unless it is the last visible one.
+func (p *columnPickerView) toggle(title string) {
+	l := p.layout
+	visible := l.Visible(title)
+	if visible && len(l.Columns(p.columns)) == 1 {
+		return
+	}
+	l.SetVisible(title, !visible)
+	p.changed()
+}
+
+func (p *columnPickerView) changed() {
+	if p.onChange != nil {
+		p.onChange()
+	}
+}
+
+// rows returns the checklist lines in display order.
+func (p *columnPickerView) rows() []string {
+	order := p.layout.order(p.columns)
+	rows := make([]string, len(order))
+	for i, col := range order {
+		title := p.columns[col].Title
+		mark := "[ ] "
+		if p.layout.Visible(title) {
+			mark = "[x] "
+		}
+		rows[i] = mark + title
+	}
+	return rows
+}
+
+func (p *columnPickerView) size(maxWidth, maxHeight int) (int, int) {
+	w := len("Columns") + 4
+	for _, row := range p.rows() {
+		w = max(w, runewidth.StringWidth(row)+4)
+	}
+	h := len(p.columns) + 2
+	if maxWidth > 0 && w > maxWidth {
+		w = maxWidth
+	}
+	if maxHeight > 0 && h > maxHeight {
+		h = maxHeight
+	}
+	return w, h
+}
+
+func (p *columnPickerView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width < 3 || height < 3 {
+		return
+	}
+
+	p.bounds = ctx.AbsoluteBounds()
+	if fm := ctx.FocusManager(); fm != nil {
+		fm.Register(p)
+	}
+
+	rows := p.rows()
+	l := p.layout
+	l.cursor = max(0, min(l.cursor, len(rows)-1))
+
+	var lines []View
+	for i, row := range rows {
+		style := p.style
+		if i == l.cursor && p.focused {
+			style = p.cursorStyle
+		}
+		lines = append(lines, Text("%s", row).Style(style).FillBg())
+	}
+	view := Bordered(Stack(lines...)).Border(&RoundedBorder).Title("Columns")
+	view.size(width, height)
+	view.render(ctx)
+}
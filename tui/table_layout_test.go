@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+var layoutColumns = []TableColumn{{Title: "Name"}, {Title: "Size"}, {Title: "Owner"}}
+
+func TestTableLayout_Columns(t *testing.T) {
+	l := &TableLayout{}
+	assert.Equal(t, []int{0, 1, 2}, l.Columns(layoutColumns))
+
+	l.SetVisible("Size", false)
+	assert.False(t, l.Visible("Size"))
+	assert.Equal(t, []int{0, 2}, l.Columns(layoutColumns))
+
+	l.Move(layoutColumns, "Owner", -2)
+	assert.Equal(t, []string{"Owner", "Name", "Size"}, l.Order)
+	assert.Equal(t, []int{2, 0}, l.Columns(layoutColumns))
+
+	l.SetVisible("Size", true)
+	assert.Equal(t, []int{2, 0, 1}, l.Columns(layoutColumns))
+
+	// Unknown titles are ignored and new columns come last
+	l = &TableLayout{Order: []string{"Gone", "Owner"}}
+	assert.Equal(t, []int{2, 0, 1}, l.Columns(layoutColumns))
+}
+
+func TestTableLayout_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "layouts", "files.json")
+
+	l, err := LoadTableLayout(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, l.Columns(layoutColumns))
+
+	l.SetVisible("Owner", false)
+	l.Move(layoutColumns, "Size", -1)
+	assert.NoError(t, l.Save(path))
+
+	loaded, err := LoadTableLayout(path)
+	assert.NoError(t, err)
+	assert.Equal(t, l.Order, loaded.Order)
+	assert.Equal(t, l.Hidden, loaded.Hidden)
+}
+
+func TestTable_Layout(t *testing.T) {
+	defer resetTableEdits()
+
+	layout := &TableLayout{Order: []string{"Owner"}, Hidden: []string{"Size"}}
+	columns := []TableColumn{{Title: "Name"}, {Title: "Size"}, {Title: "Owner", Editable: true}}
+	var editedCol int
+	selected := 0
+	view := Table(columns, &selected).
+		Rows([][]string{{"a.txt", "12", "root"}}).
+		Layout(layout).
+		HeaderBottomBorder(false).
+		OnEdit(func(row, col int, value string) { editedCol = col })
+
+	screen := SprintScreen(view, PrintConfig{Width: 30, Height: 2})
+	assert.Equal(t, "Owner    Name", screen.Row(0))
+	assert.Equal(t, "root     a.txt", screen.Row(1))
+
+	// Edits report the column's index in the columns passed to Table
+	view.HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	view.HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, 2, editedCol)
+}
+
+func TestColumnPicker(t *testing.T) {
+	layout := &TableLayout{}
+	changes := 0
+	closed := false
+	picker := ColumnPicker(layoutColumns, layout).
+		OnChange(func() { changes++ }).
+		OnClose(func() { closed = true })
+
+	screen := SprintScreen(picker, PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, "│[x] Name          │", screen.Row(1))
+	assert.Equal(t, "│[x] Owner         │", screen.Row(3))
+
+	// Hide Size and move it to the top
+	picker.HandleKeyEvent(KeyEvent{Rune: 'j'})
+	picker.HandleKeyEvent(KeyEvent{Rune: ' '})
+	picker.HandleKeyEvent(KeyEvent{Key: KeyArrowUp, Shift: true})
+	assert.Equal(t, []string{"Size"}, layout.Hidden)
+	assert.Equal(t, []string{"Size", "Name", "Owner"}, layout.Order)
+	assert.Equal(t, 2, changes)
+
+	screen = SprintScreen(picker, PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, "│[ ] Size          │", screen.Row(1))
+
+	// The last visible column can't be hidden
+	layout.SetVisible("Owner", false)
+	picker.HandleKeyEvent(KeyEvent{Rune: 'j'})
+	picker.HandleKeyEvent(KeyEvent{Rune: ' '})
+	assert.Equal(t, []int{0}, layout.Columns(layoutColumns))
+
+	picker.HandleKeyEvent(KeyEvent{Key: KeyEscape})
+	assert.True(t, closed)
+}
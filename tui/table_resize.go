@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"image"
+	"slices"
+)
+
+// SetWidth sets the width of the column with the given title, as when the
+// user drags its edge. A width of 0 gives the column back its own width.
+func (l *TableLayout) SetWidth(title string, width int) {
+	if width <= 0 {
+		delete(l.Widths, title)
+		return
+	}
+	if l.Widths == nil {
+		l.Widths = make(map[string]int)
+	}
+	l.Widths[title] = width
+}
+
+// OnResize sets a callback invoked when the user finishes resizing a
+// column, for example to save the table's layout.
+func (t *tableView) OnResize(fn func(title string, width int)) *tableView {
+	t.onResize = fn
+	return t
+}
+
+// applyWidths gives columns the widths the user chose, which take the
+// place of their Width.
+func (t *tableView) applyWidths() {
+	l := t.userLayout()
+	if l == nil || len(l.Widths) == 0 {
+		return
+	}
+	columns := slices.Clone(t.columns)
+	for i, col := range columns {
+		if w := l.Widths[col.Title]; w > 0 {
+			columns[i].Width = max(w, col.MinWidth)
+		}
+	}
+	t.columns = columns
+}
+
+// registerResizeHandle lets the user resize the column at pos by dragging
+// its right edge in the header row y: the gap after it, or its last cell
+// when there is no gap.
+func (t *tableView) registerResizeHandle(ctx *RenderContext, pos tableColumnPos, y int) {
+	l := t.userLayout()
+	w := t.columnWidths[pos.col]
+	x0, x1 := pos.x+w, pos.x+w+t.columnGap
+	if t.columnGap == 0 {
+		x0, x1 = pos.x+w-1, pos.x+w
+	}
+	bounds := ctx.AbsoluteBounds()
+	handle := image.Rect(bounds.Min.X+x0, bounds.Min.Y+y, bounds.Min.X+x1, bounds.Min.Y+y+1).Intersect(bounds)
+	if handle.Empty() {
+		return
+	}
+
+	col := t.columns[pos.col]
+	interactiveRegistry.RegisterDrag(handle, newDragTracker(handle, func(e DragEvent) {
+		dx, _ := e.Delta()
+		width := max(1, col.MinWidth, w+dx)
+		l.SetWidth(col.Title, width)
+		if e.Phase == DragEnd && t.onResize != nil {
+			t.onResize(col.Title, width)
+		}
+	}))
+}
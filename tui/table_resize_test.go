@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestTable_ResizeByDrag(t *testing.T) {
+	defer resetTableLayouts()
+	interactiveRegistry.Clear()
+	defer interactiveRegistry.Clear()
+
+	selected := 0
+	var resized []int
+	columns := []TableColumn{{Title: "Name", Width: 6, Resizable: true}, {Title: "Size"}}
+	table := func() View {
+		return Table(columns, &selected).
+			Rows([][]string{{"a.txt", "12"}}).
+			HeaderBottomBorder(false).
+			OnResize(func(title string, width int) { resized = append(resized, width) })
+	}
+	screen := SprintScreen(table(), PrintConfig{Width: 20, Height: 2})
+	assert.Equal(t, "Name    Size", screen.Row(0))
+
+	// Dragging the gap after a title resizes its column
+	renderToTerminal(t, table(), 20, 2)
+	interactiveRegistry.HandleDrag(MouseEvent{Type: MousePress, Button: MouseButtonLeft, X: 6, Y: 0})
+	interactiveRegistry.HandleDrag(MouseEvent{Type: MouseDrag, Button: MouseButtonLeft, X: 8, Y: 0})
+	interactiveRegistry.HandleDrag(MouseEvent{Type: MouseDrag, Button: MouseButtonLeft, X: 10, Y: 0})
+	assert.Empty(t, resized)
+	interactiveRegistry.HandleDrag(MouseEvent{Type: MouseRelease, Button: MouseButtonLeft, X: 10, Y: 0})
+	assert.Equal(t, []int{10}, resized)
+
+	screen = SprintScreen(table(), PrintConfig{Width: 20, Height: 2})
+	assert.Equal(t, "Name        Size", screen.Row(0))
+	assert.Equal(t, "a.txt       12", screen.Row(1))
+
+	// Columns don't shrink below one cell or their MinWidth
+	interactiveRegistry.Clear()
+	renderToTerminal(t, table(), 20, 2)
+	interactiveRegistry.HandleDrag(MouseEvent{Type: MousePress, Button: MouseButtonLeft, X: 10, Y: 0})
+	interactiveRegistry.HandleDrag(MouseEvent{Type: MouseDrag, Button: MouseButtonLeft, X: 0, Y: 0})
+	interactiveRegistry.HandleDrag(MouseEvent{Type: MouseRelease, Button: MouseButtonLeft, X: 0, Y: 0})
+	assert.Equal(t, []int{10, 1}, resized)
+}
+
+func TestTableLayout_Widths(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "files.json")
+	l := &TableLayout{}
+	l.SetWidth("Name", 12)
+	l.SortBy("Size", true)
+	assert.NoError(t, l.Save(path))
+
+	loaded, err := LoadTableLayout(path)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"Name": 12}, loaded.Widths)
+	assert.Equal(t, "Size", loaded.SortColumn)
+	assert.True(t, loaded.SortDescending)
+
+	// The layout's width replaces the column's own
+	selected := 0
+	columns := []TableColumn{{Title: "Name", Width: 4}, {Title: "Size"}}
+	screen := SprintScreen(Table(columns, &selected).Layout(loaded).ShowHeader(false).Rows([][]string{{"a", "1"}}), PrintConfig{Width: 30, Height: 1})
+	assert.Equal(t, "a             1", screen.Row(0))
+
+	loaded.SetWidth("Name", 0)
+	assert.Empty(t, loaded.Widths)
+}
@@ -0,0 +1,191 @@
+package tui
+
+import "sync"
+
+// tableScrollRegistry keeps the horizontal scroll offset of tables, keyed
+// by table ID, since table views are rebuilt every frame.
+var tableScrollRegistry = &tableScrollRegistryImpl{
+	offsets: make(map[string]int),
+	active:  make(map[string]bool),
+}
+
+type tableScrollRegistryImpl struct {
+	mu      sync.Mutex
+	offsets map[string]int
+	active  map[string]bool // tracks which IDs were accessed this frame
+}
+
+// Clear marks all entries as inactive. Called at the start of each frame.
+func (r *tableScrollRegistryImpl) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.active = make(map[string]bool)
+}
+
+// Prune removes entries that weren't accessed since the last Clear().
+func (r *tableScrollRegistryImpl) Prune() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id := range r.offsets {
+		if !r.active[id] {
+			delete(r.offsets, id)
+		}
+	}
+}
+
+func (r *tableScrollRegistryImpl) Get(id string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.active[id] = true
+	return r.offsets[id]
+}
+
+func (r *tableScrollRegistryImpl) Set(id string, offset int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.active[id] = true
+	r.offsets[id] = offset
+}
+
+// tableColumnPos is a column drawn at x.
+type tableColumnPos struct {
+	col int
+	x   int
+}
+
+// HorizontalScroll lets a table wider than its container scroll sideways
+// instead of shrinking its columns. Left and Right scroll by a column, and
+// arrows at the edges of the first row show that more columns are hidden
+// on that side. In editable or grouped tables Left and Right keep their
+// meaning, and the table scrolls to keep the cell cursor in view.
+//
+// Example:
+//
+//	tui.Table(columns, &app.selected).Rows(app.rows).
+//	    HorizontalScroll(true).FrozenColumns(1)
+func (t *tableView) HorizontalScroll(enabled bool) *tableView {
+	t.hscroll = enabled
+	return t
+}
+
+// FrozenColumns keeps the first n columns in place while the others scroll
+// horizontally, so row labels stay visible.
+func (t *tableView) FrozenColumns(n int) *tableView {
+	t.frozenColumns = max(0, n)
+	return t
+}
+
+// handleScrollKey scrolls the table horizontally. It returns false for keys
+// the table should process normally.
+func (t *tableView) handleScrollKey(event KeyEvent) bool {
+	if !t.hscroll || event.Shift || event.Ctrl || event.Alt {
+		return false
+	}
+	offset := tableScrollRegistry.Get(t.id)
+	switch {
+	case event.Key == KeyArrowRight && t.hiddenRight:
+		tableScrollRegistry.Set(t.id, offset+1)
+	case event.Key == KeyArrowLeft && offset > 0:
+		tableScrollRegistry.Set(t.id, offset-1)
+	default:
+		return false
+	}
+	return true
+}
+
+// columnsWidth returns the width of the given columns and the gaps
+// between them.
+func (t *tableView) columnsWidth(cols []int) int {
+	w := 0
+	for _, col := range cols {
+		w += t.columnWidths[col]
+	}
+	if len(cols) > 1 {
+		w += (len(cols) - 1) * t.columnGap
+	}
+	return w
+}
+
+// visibleColumns sets the column widths for width and returns the columns
+// to draw with their positions. Without horizontal scrolling, or when the
+// table fits, every column is drawn. Otherwise the frozen columns are
+// followed by as many scrolled columns as fit, starting at the scroll
+// offset, adjusted so that column show (or -1) is in view.
+func (t *tableView) visibleColumns(width, show int) []tableColumnPos {
+	t.calculateColumnWidths()
+	all := make([]int, len(t.columns))
+	for i := range all {
+		all[i] = i
+	}
+	t.hiddenLeft, t.hiddenRight = false, false
+	if !t.hscroll || t.columnsWidth(all) <= width {
+		t.fitColumnWidths(width) // Shrink columns to fit container
+		return t.placeColumns(all)
+	}
+
+	frozen := min(t.frozenColumns, len(t.columns)-1)
+	frozenWidth := 0
+	if frozen > 0 {
+		frozenWidth = t.columnsWidth(all[:frozen]) + t.columnGap
+	}
+	// fits returns the number of scrolled columns that fit from offset
+	fits := func(offset int) int {
+		x, n := frozenWidth, 0
+		for col := frozen + offset; col < len(t.columns); col++ {
+			if n > 0 && x+t.columnWidths[col] > width {
+				break
+			}
+			x += t.columnWidths[col] + t.columnGap
+			n++
+		}
+		return n
+	}
+
+	scrolled := len(t.columns) - frozen
+	maxOffset := scrolled - 1
+	for maxOffset > 0 && fits(maxOffset-1) == scrolled-(maxOffset-1) {
+		maxOffset--
+	}
+	offset := max(0, min(tableScrollRegistry.Get(t.id), maxOffset))
+	if show >= frozen {
+		offset = min(offset, show-frozen)
+		for offset < maxOffset && frozen+offset+fits(offset) <= show {
+			offset++
+		}
+	}
+	tableScrollRegistry.Set(t.id, offset)
+
+	n := fits(offset)
+	t.hiddenLeft = offset > 0
+	t.hiddenRight = frozen+offset+n < len(t.columns)
+	cols := append(all[:frozen:frozen], all[frozen+offset:frozen+offset+n]...)
+	return t.placeColumns(cols)
+}
+
+// placeColumns positions cols left to right, separated by the column gap.
+func (t *tableView) placeColumns(cols []int) []tableColumnPos {
+	positions := make([]tableColumnPos, len(cols))
+	x := 0
+	for i, col := range cols {
+		positions[i] = tableColumnPos{col: col, x: x}
+		x += t.columnWidths[col] + t.columnGap
+	}
+	return positions
+}
+
+// renderScrollIndicators draws arrows on row y where columns are hidden.
+func (t *tableView) renderScrollIndicators(ctx *RenderContext, y int, cols []tableColumnPos) {
+	width, _ := ctx.Size()
+	style := t.headerStyle.WithForeground(ColorBrightBlack)
+	if t.hiddenLeft {
+		frozen := min(t.frozenColumns, len(cols)-1)
+		x := 0
+		if frozen < len(cols) {
+			x = max(0, cols[frozen].x-1)
+		}
+		ctx.PrintStyled(x, y, "‹", style)
+	}
+	if t.hiddenRight {
+		ctx.PrintStyled(width-1, y, "›", style)
+	}
+}
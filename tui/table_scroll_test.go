@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func resetTableScroll() {
+	tableScrollRegistry.Clear()
+	tableScrollRegistry.Prune()
+}
+
+var scrollColumns = []TableColumn{
+	{Title: "Host", Width: 6}, {Title: "A", Width: 5}, {Title: "B", Width: 5}, {Title: "C", Width: 5},
+}
+
+func TestTable_HorizontalScroll(t *testing.T) {
+	defer resetTableScroll()
+
+	selected := 0
+	table := func() *tableView {
+		return Table(scrollColumns, &selected).
+			Rows([][]string{{"web", "1", "2", "3"}}).
+			HeaderBottomBorder(false).
+			ColumnGap(1).
+			HorizontalScroll(true).
+			FrozenColumns(1)
+	}
+
+	view := table()
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 2})
+	assert.Equal(t, "Host   A     B     ›", screen.Row(0))
+	assert.Equal(t, "web    1     2", screen.Row(1))
+
+	// Right scrolls past A; the frozen Host column stays
+	assert.True(t, view.HandleKeyEvent(KeyEvent{Key: KeyArrowRight}))
+	view = table()
+	screen = SprintScreen(view, PrintConfig{Width: 20, Height: 2})
+	assert.Equal(t, "Host  ‹B     C", screen.Row(0))
+	assert.Equal(t, "web    2     3", screen.Row(1))
+
+	// The last column is in view, so Right isn't used
+	assert.False(t, view.HandleKeyEvent(KeyEvent{Key: KeyArrowRight}))
+	assert.True(t, view.HandleKeyEvent(KeyEvent{Key: KeyArrowLeft}))
+	screen = SprintScreen(table(), PrintConfig{Width: 20, Height: 2})
+	assert.Equal(t, "Host   A     B     ›", screen.Row(0))
+
+	// Tables that fit are drawn as before
+	screen = SprintScreen(table(), PrintConfig{Width: 30, Height: 2})
+	assert.Equal(t, "Host   A     B     C", screen.Row(0))
+}
+
+func TestTable_HorizontalScrollFollowsEditCursor(t *testing.T) {
+	defer resetTableScroll()
+	defer resetTableEdits()
+
+	selected := 0
+	columns := append([]TableColumn(nil), scrollColumns...)
+	columns[3].Editable = true
+	view := Table(columns, &selected).
+		Rows([][]string{{"web", "1", "2", "3"}}).
+		HeaderBottomBorder(false).
+		ColumnGap(1).
+		HorizontalScroll(true).
+		FrozenColumns(1)
+
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 2})
+	assert.Equal(t, "Host  ‹B     C", screen.Row(0))
+}
@@ -0,0 +1,258 @@
+package tui
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// tableLayoutRegistry keeps the sort order and column widths the user
+// chose in tables without a Layout, keyed by table ID, since table views
+// are rebuilt every frame.
+var tableLayoutRegistry = &tableLayoutRegistryImpl{
+	layouts: make(map[string]*TableLayout),
+	active:  make(map[string]bool),
+}
+
+type tableLayoutRegistryImpl struct {
+	mu      sync.Mutex
+	layouts map[string]*TableLayout
+	active  map[string]bool // tracks which IDs were accessed this frame
+}
+
+// Clear marks all entries as inactive. Called at the start of each frame.
+func (r *tableLayoutRegistryImpl) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.active = make(map[string]bool)
+}
+
+// Prune removes entries that weren't accessed since the last Clear().
+func (r *tableLayoutRegistryImpl) Prune() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id := range r.layouts {
+		if !r.active[id] {
+			delete(r.layouts, id)
+		}
+	}
+}
+
+func (r *tableLayoutRegistryImpl) Get(id string) *TableLayout {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.active[id] = true
+	if l, exists := r.layouts[id]; exists {
+		return l
+	}
+	l := &TableLayout{}
+	r.layouts[id] = l
+	return l
+}
+
+// SortBy sorts the table by the column with the given title. An empty
+// title leaves the rows in their original order.
+func (l *TableLayout) SortBy(title string, descending bool) {
+	l.SortColumn, l.SortDescending = title, descending
+}
+
+// ToggleSort sorts by the column with the given title, ascending, or in
+// the other direction if the table is already sorted by it.
+func (l *TableLayout) ToggleSort(title string) {
+	if l.SortColumn == title {
+		l.SortDescending = !l.SortDescending
+		return
+	}
+	l.SortBy(title, false)
+}
+
+// OnSort sets a callback invoked after the user sorts the table, for
+// example to save its layout or to sort the data on a server. title is
+// empty when the rows are back in their original order.
+func (t *tableView) OnSort(fn func(title string, descending bool)) *tableView {
+	t.onSort = fn
+	return t
+}
+
+// Collator sets how sortable columns without a Compare function order
+// text, such as LocaleCollator("de"). Numbers still compare by value.
+func (t *tableView) Collator(c Collator) *tableView {
+	t.collator = c
+	return t
+}
+
+// userLayout returns the layout holding the user's sort order and column
+// widths: the table's Layout or, in a table with sortable or resizable
+// columns and no Layout, one kept by the table's ID. It returns nil
+// otherwise.
+func (t *tableView) userLayout() *TableLayout {
+	if t.layout != nil {
+		return t.layout
+	}
+	if t.state == nil && slices.ContainsFunc(t.columns, func(c TableColumn) bool { return c.Sortable || c.Resizable }) {
+		t.state = tableLayoutRegistry.Get(t.id)
+	}
+	return t.state
+}
+
+// sortColumn returns the column the rows are sorted by and the direction,
+// or -1 if they aren't sorted.
+func (t *tableView) sortColumn() (int, bool) {
+	l := t.userLayout()
+	if l == nil || l.SortColumn == "" {
+		return -1, false
+	}
+	col := slices.IndexFunc(t.columns, func(c TableColumn) bool { return c.Title == l.SortColumn })
+	return col, l.SortDescending
+}
+
+// sortOrder returns the indexes of n rows in the order they are shown,
+// given the cells of each row.
+func (t *tableView) sortOrder(n int, cells func(i int) []string) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	col, descending := t.sortColumn()
+	if col < 0 {
+		return order
+	}
+	compare := t.columns[col].Compare
+	if compare == nil {
+		compare = func(a, b string) int { return compareTableCells(a, b, t.collator) }
+	}
+	value := func(i int) string {
+		if row := cells(i); col < len(row) {
+			return row[col]
+		}
+		return ""
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		if descending {
+			return compare(value(b), value(a))
+		}
+		return compare(value(a), value(b))
+	})
+	return order
+}
+
+// sortRows sorts plain rows by the sort column, remembering the index of
+// each in Rows. Grouped and tree tables sort as they are flattened.
+func (t *tableView) sortRows() {
+	t.unsorted = t.rows
+	if col, _ := t.sortColumn(); col < 0 || t.groups != nil || t.tree != nil {
+		return
+	}
+	t.rowOrder = t.sortOrder(len(t.rows), func(i int) []string { return t.rows[i] })
+	rows := make([][]string, len(t.rows))
+	for i, src := range t.rowOrder {
+		rows[i] = t.rows[src]
+	}
+	t.rows = rows
+}
+
+// sourceRow maps a displayed row index to its index in Rows.
+func (t *tableView) sourceRow(row int) int {
+	if row < 0 || row >= len(t.rowOrder) {
+		return row
+	}
+	return t.rowOrder[row]
+}
+
+// changeSort applies a change to the sort order, keeping the selection on
+// the same row of a plain table.
+func (t *tableView) changeSort(change func(l *TableLayout)) {
+	l := t.userLayout()
+	if l == nil {
+		return
+	}
+	src := -1
+	if t.selected != nil && t.groups == nil && t.tree == nil && *t.selected >= 0 && *t.selected < len(t.rows) {
+		src = t.sourceRow(*t.selected)
+	}
+	change(l)
+	if src >= 0 {
+		order := t.sortOrder(len(t.unsorted), func(i int) []string { return t.unsorted[i] })
+		*t.selected = max(0, slices.Index(order, src))
+	}
+	if t.onSort != nil {
+		t.onSort(l.SortColumn, l.SortDescending)
+	}
+}
+
+// handleSortKey sorts by the next sortable column with s, unsorting after
+// the last, and reverses the order with S. It returns false for keys the
+// table should process normally.
+func (t *tableView) handleSortKey(event KeyEvent) bool {
+	var sortable []string
+	for _, col := range t.columns {
+		if col.Sortable {
+			sortable = append(sortable, col.Title)
+		}
+	}
+	if len(sortable) == 0 || event.Ctrl || event.Alt {
+		return false
+	}
+	switch event.Rune {
+	case 's':
+		t.changeSort(func(l *TableLayout) {
+			next := slices.Index(sortable, l.SortColumn) + 1
+			if next < len(sortable) {
+				l.SortBy(sortable[next], false)
+			} else {
+				l.SortBy("", false)
+			}
+		})
+	case 'S':
+		t.changeSort(func(l *TableLayout) {
+			if l.SortColumn != "" {
+				l.SortDescending = !l.SortDescending
+			}
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+// sortIndicator returns the arrow shown after the title of column col, or
+// "" if the rows aren't sorted by it.
+func (t *tableView) sortIndicator(col int) string {
+	sorted, descending := t.sortColumn()
+	switch {
+	case sorted != col:
+		return ""
+	case descending:
+		return "▼"
+	}
+	return "▲"
+}
+
+// compareTableCells orders cells of sortable columns without a Compare
+// function: numbers by value and before text, and text by collator, or
+// ignoring case without one.
+func compareTableCells(a, b string, collator Collator) int {
+	na, aNum := parseTableNumber(a)
+	nb, bNum := parseTableNumber(b)
+	switch {
+	case aNum && bNum:
+		return cmp.Compare(na, nb)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	case collator != nil:
+		return collator(a, b)
+	}
+	return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// parseTableNumber parses a numeric cell, ignoring surrounding spaces and
+// thousands separators.
+func parseTableNumber(s string) (float64, bool) {
+	n, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(s), ",", ""), 64)
+	return n, err == nil
+}
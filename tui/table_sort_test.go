@@ -0,0 +1,206 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func resetTableLayouts() {
+	tableLayoutRegistry.Clear()
+	tableLayoutRegistry.Prune()
+}
+
+var sortColumns = []TableColumn{
+	{Title: "Name", Sortable: true},
+	{Title: "Size", Sortable: true, Align: AlignRight},
+}
+
+var sortRows = [][]string{{"b.txt", "900"}, {"C.txt", "1,200"}, {"a.txt", "85"}}
+
+func TestTable_SortByKey(t *testing.T) {
+	defer resetTableLayouts()
+
+	selected := 0
+	var sorts []string
+	var picked int
+	table := func() *tableView {
+		return Table(sortColumns, &selected).
+			Rows(sortRows).
+			HeaderBottomBorder(false).
+			OnSelect(func(row int) { picked = row }).
+			OnSort(func(title string, descending bool) {
+				if descending {
+					title += " desc"
+				}
+				sorts = append(sorts, title)
+			})
+	}
+
+	screen := SprintScreen(table(), PrintConfig{Width: 20, Height: 4})
+	assert.Equal(t, "Name        Size", screen.Row(0))
+	assert.Equal(t, "b.txt        900", screen.Row(1))
+
+	// s sorts by the first sortable column, ignoring case, and the
+	// selection stays on its row
+	assert.True(t, table().HandleKeyEvent(KeyEvent{Rune: 's'}))
+	screen = SprintScreen(table(), PrintConfig{Width: 20, Height: 4})
+	assert.Equal(t, "Name ▲      Size", screen.Row(0))
+	assert.Equal(t, "a.txt         85", screen.Row(1))
+	assert.Equal(t, "b.txt        900", screen.Row(2))
+	assert.Equal(t, "C.txt      1,200", screen.Row(3))
+	assert.Equal(t, 1, selected)
+
+	// Selection reports the row's index in Rows
+	table().HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, 0, picked)
+
+	// Numbers sort by value, and S reverses the order
+	table().HandleKeyEvent(KeyEvent{Rune: 's'})
+	table().HandleKeyEvent(KeyEvent{Rune: 'S'})
+	screen = SprintScreen(table(), PrintConfig{Width: 20, Height: 4})
+	assert.Equal(t, "Name      Size ▼", screen.Row(0))
+	assert.Equal(t, "C.txt      1,200", screen.Row(1))
+	assert.Equal(t, "a.txt         85", screen.Row(3))
+
+	// After the last sortable column the rows are back in their order
+	table().HandleKeyEvent(KeyEvent{Rune: 's'})
+	screen = SprintScreen(table(), PrintConfig{Width: 20, Height: 4})
+	assert.Equal(t, "b.txt        900", screen.Row(1))
+	assert.Equal(t, []string{"Name", "Size", "Size desc", ""}, sorts)
+}
+
+func TestTable_SortByClick(t *testing.T) {
+	defer resetTableLayouts()
+	interactiveRegistry.Clear()
+	defer interactiveRegistry.Clear()
+
+	selected := 0
+	layout := &TableLayout{}
+	table := func() View {
+		return Table(sortColumns, &selected).Rows(sortRows).HeaderBottomBorder(false).Layout(layout)
+	}
+	renderToTerminal(t, table(), 20, 4)
+
+	// Clicking a title sorts by it, and clicking again reverses the order
+	assert.True(t, interactiveRegistry.HandleClick(12, 0))
+	assert.Equal(t, "Size", layout.SortColumn)
+	assert.False(t, layout.SortDescending)
+	assert.True(t, interactiveRegistry.HandleClick(12, 0))
+	assert.True(t, layout.SortDescending)
+
+	interactiveRegistry.Clear()
+	renderToTerminal(t, table(), 20, 4)
+	assert.True(t, interactiveRegistry.HandleClick(1, 0))
+	assert.Equal(t, "Name", layout.SortColumn)
+	assert.False(t, layout.SortDescending)
+}
+
+func TestTable_SortGroupsAndTrees(t *testing.T) {
+	defer resetTableLayouts()
+
+	selected := 0
+	layout := &TableLayout{SortColumn: "Size"}
+	group := &TableRowGroup{Title: "docs", Rows: sortRows}
+	var picked int
+	view := Table(sortColumns, &selected).
+		Groups([]*TableRowGroup{group}).
+		Layout(layout).
+		HeaderBottomBorder(false).
+		OnSelectGroupRow(func(g *TableRowGroup, row int) { picked = row })
+
+	// Rows are sorted within their group
+	screen := SprintScreen(view, PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, "  a.txt         85", screen.Row(2))
+	assert.Equal(t, "  C.txt      1,200", screen.Row(4))
+	selected = 1
+	view.HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, 2, picked)
+
+	// and nodes among their siblings
+	root := &TableNode{Cells: []string{"src"}, Expanded: true, Children: []*TableNode{
+		{Cells: []string{"b.go"}}, {Cells: []string{"a.go"}},
+	}}
+	layout.SortBy("Name", false)
+	screen = SprintScreen(Table(sortColumns, &selected).Tree([]*TableNode{root}).Layout(layout), PrintConfig{Width: 20, Height: 5})
+	assert.Equal(t, "    a.go", screen.Row(3))
+	assert.Equal(t, "    b.go", screen.Row(4))
+}
+
+func TestTable_SortCompare(t *testing.T) {
+	defer resetTableLayouts()
+
+	priority := map[string]int{"high": 0, "medium": 1, "low": 2}
+	columns := []TableColumn{{Title: "Priority", Sortable: true, Compare: func(a, b string) int {
+		return priority[a] - priority[b]
+	}}}
+	layout := &TableLayout{SortColumn: "Priority"}
+	selected := 0
+	view := Table(columns, &selected).
+		Rows([][]string{{"low"}, {"high"}, {"medium"}}).
+		Layout(layout).
+		ShowHeader(false)
+
+	screen := SprintScreen(view, PrintConfig{Width: 10, Height: 3})
+	assert.Equal(t, "high", screen.Row(0))
+	assert.Equal(t, "medium", screen.Row(1))
+	assert.Equal(t, "low", screen.Row(2))
+}
+
+func TestTable_CellStyle(t *testing.T) {
+	selected := 1
+	columns := []TableColumn{
+		{Title: "Name"},
+		{Title: "Change", Align: AlignRight, CellStyle: func(row int, value string) Style {
+			if value[0] == '-' {
+				return NewStyle().WithForeground(ColorRed)
+			}
+			return NewStyle()
+		}},
+	}
+	view := Table(columns, &selected).
+		Rows([][]string{{"abc", "-1.5"}, {"xyz", "-2"}, {"def", "+3"}}).
+		ShowHeader(false).
+		ColumnGap(1)
+	terminal := renderToTerminal(t, view, 20, 3)
+
+	// Cells are aligned within their column
+	assert.Equal(t, '-', terminal.GetCell(11, 0).Char)
+	assert.Equal(t, '-', terminal.GetCell(13, 1).Char)
+
+	assert.Equal(t, ColorRed, terminal.GetCell(11, 0).Style.Foreground)
+	assert.Equal(t, ColorDefault, terminal.GetCell(0, 0).Style.Foreground)
+	assert.Equal(t, ColorDefault, terminal.GetCell(13, 2).Style.Foreground)
+	// The selected row keeps its highlight
+	assert.Equal(t, ColorRed, terminal.GetCell(13, 1).Style.Foreground)
+	assert.True(t, terminal.GetCell(13, 1).Style.Reverse)
+}
+
+func TestTable_CellCallbacksGetSourceRow(t *testing.T) {
+	selected := 0
+	rows := [][]string{{"b", "stale"}, {"a", "fresh"}}
+	var rendered []int
+	columns := []TableColumn{
+		{Title: "Name", CellStyle: func(row int, value string) Style {
+			if rows[row][1] == "stale" {
+				return NewStyle().WithForeground(ColorRed)
+			}
+			return NewStyle()
+		}},
+		{Title: "State", Width: 6, Render: func(row int, value string) View {
+			rendered = append(rendered, row)
+			return Text("%s", value)
+		}},
+	}
+	view := Table(columns, &selected).
+		Rows(rows).
+		Layout(&TableLayout{SortColumn: "Name"}).
+		ShowHeader(false)
+	terminal := renderToTerminal(t, view, 20, 2)
+
+	// Sorted, "a" comes first but keeps its index in Rows
+	assert.Equal(t, 'a', terminal.GetCell(0, 0).Char)
+	assert.Equal(t, ColorDefault, terminal.GetCell(0, 0).Style.Foreground)
+	assert.Equal(t, ColorRed, terminal.GetCell(0, 1).Style.Foreground)
+	assert.Equal(t, []int{1, 0}, rendered)
+}
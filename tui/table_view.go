@@ -13,6 +13,45 @@ type TableColumn struct {
 	Title    string
 	Width    int // If 0, auto-calculated based on content
 	MinWidth int // Minimum width (won't shrink below this)
+
+	// Align places the title and cells in the column, such as AlignRight
+	// for numbers. The default is AlignLeft.
+	Align Alignment
+
+	// Sortable lets the user sort the rows by this column by clicking its
+	// title or pressing s. See tableView.OnSort.
+	Sortable bool
+	// Compare orders two cells when sorting by this column. The default
+	// compares numbers by value and text with the table's Collator, or
+	// ignoring case.
+	Compare func(a, b string) int
+
+	// Resizable lets the user resize the column by dragging the right edge
+	// of its title. See tableView.OnResize.
+	Resizable bool
+
+	// Editable lets the user edit cells in this column. See tableView.OnEdit.
+	Editable bool
+	// Validate checks an edited value before it is committed. Its error is
+	// shown next to the cell and the edit stays open.
+	Validate func(value string) error
+
+	// Render draws each cell as a view instead of text, such as a Progress
+	// bar or a colored Text badge. It gets the row's index in Rows, like
+	// OnSelect, and the cell's text, and the view is clipped to the
+	// one-line cell. The text still sizes an auto-width column, so set
+	// Width for view columns.
+	Render func(row int, value string) View
+
+	// CellStyle styles each cell from the row's index in Rows, like
+	// OnSelect, and the cell's text, such as red for negative numbers. The
+	// style is merged over the row's, so the selected row keeps its
+	// highlight.
+	CellStyle func(row int, value string) Style
+
+	// Aggregate summarizes the column's values on group header rows and on
+	// tree nodes with children. See AggregateCount and AggregateSum.
+	Aggregate func(values []string) string
 }
 
 // tableView displays a scrollable data table as a declarative view.
@@ -36,10 +75,41 @@ type tableView struct {
 	headerBottomBorder   bool
 	columnGap            int  // gap between columns (default 2)
 	fillWidth            bool // expand to fill container width
+	loading              bool
+	onEdit               func(row, col int, value string)
+	editCursorStyle      Style
+	edit                 *tableEditState // nil unless a column is editable
+	layout               *TableLayout
+	layoutColumns        []int        // source column of each displayed column, once the layout is applied
+	state                *TableLayout // sort order and widths of a table without a layout
+	unsorted             [][]string   // rows before sorting
+	rowOrder             []int        // index in Rows of each displayed row, once sorted
+	onSort               func(title string, descending bool)
+	onResize             func(title string, width int)
+	collator             Collator
+	detail               func(row int) View
+	detailHeight         int
+	detailRow            int  // displayed row of detailView
+	detailView           View // detail of the expanded row, once built
+	groups               []*TableRowGroup
+	tree                 []*TableNode
+	rowInfo              []tableRowInfo // grouping of each displayed row, for grouped and tree tables
+	onSelectGroupRow     func(group *TableRowGroup, row int)
+	onSelectNode         func(node *TableNode)
+	groupStyle           Style
+	prepared             bool
+	hscroll              bool
+	frozenColumns        int
+	hiddenLeft           bool // columns are scrolled out of view on the left, as of the last render
+	hiddenRight          bool // columns are hidden on the right, as of the last render
 	bounds               image.Rectangle
 	focused              bool
 }
 
+// tableLoadingRows is the number of skeleton rows a loading table shows
+// when it has no fixed height.
+const tableLoadingRows = 3
+
 // Table creates a new table view with the given columns.
 // selected should be a pointer to the currently selected row index.
 //
@@ -59,10 +129,12 @@ func Table(columns []TableColumn, selected *int) *tableView {
 		selected:           selected,
 		style:              NewStyle(),
 		headerStyle:        NewStyle().WithBold(),
+		groupStyle:         NewStyle().WithBold(),
 		selectedStyle:      NewStyle().WithReverse(),
 		showHeader:         true,
 		headerBottomBorder: true,
 		columnGap:          2,
+		editCursorStyle:    NewStyle().WithForeground(ColorBlack).WithBackground(ColorCyan),
 	}
 }
 
@@ -102,9 +174,16 @@ func (t *tableView) FocusBounds() image.Rectangle {
 }
 
 func (t *tableView) HandleKeyEvent(event KeyEvent) bool {
+	t.prepare()
 	if len(t.rows) == 0 {
 		return false
 	}
+	if edit := t.editState(); edit != nil && t.handleEditKey(edit, event) {
+		return true
+	}
+	if t.handleGroupKey(event) || t.handleDetailKey(event) || t.handleScrollKey(event) || t.handleSortKey(event) {
+		return true
+	}
 
 	// Calculate visible height (excluding header if shown)
 	visibleHeight := t.height
@@ -141,9 +220,7 @@ func (t *tableView) HandleKeyEvent(event KeyEvent) bool {
 	case KeyEnter:
 		// Enter selects the current row
 		if t.selected != nil && *t.selected >= 0 && *t.selected < len(t.rows) {
-			if t.onSelect != nil {
-				t.onSelect(*t.selected)
-			}
+			t.activate(*t.selected)
 			return true
 		}
 	}
@@ -265,6 +342,13 @@ func (t *tableView) FillWidth() *tableView {
 	return t
 }
 
+// Loading shows animated skeleton rows in place of the data while it loads.
+// The header is still drawn.
+func (t *tableView) Loading(loading bool) *tableView {
+	t.loading = loading
+	return t
+}
+
 // calculateColumnWidths computes the actual width for each column.
 func (t *tableView) calculateColumnWidths() {
 	if len(t.columns) == 0 {
@@ -437,6 +521,7 @@ func (t *tableView) fitColumnWidths(availableWidth int) {
 			shrinkAmount = shrinkableWidth
 		}
 
+		shrunk := 0
 		for i := range t.columnWidths {
 			minW := t.effectiveMinWidth(i)
 			canShrink := t.columnWidths[i] - minW
@@ -448,8 +533,21 @@ func (t *tableView) fitColumnWidths(availableWidth int) {
 				}
 				t.columnWidths[i] -= share
 				excess -= share
+				shrunk += share
 			}
 		}
+
+		// Shares round down, so take the last cells from the widest column
+		if shrunk == 0 {
+			widest := -1
+			for i, w := range t.columnWidths {
+				if w > t.effectiveMinWidth(i) && (widest < 0 || w > t.columnWidths[widest]) {
+					widest = i
+				}
+			}
+			t.columnWidths[widest]--
+			excess--
+		}
 	}
 
 	// Distribute any remaining space to the largest column
@@ -469,6 +567,7 @@ func (t *tableView) fitColumnWidths(availableWidth int) {
 }
 
 func (t *tableView) size(maxWidth, maxHeight int) (int, int) {
+	t.prepare()
 	t.calculateColumnWidths()
 
 	// Calculate total width including gaps
@@ -486,13 +585,24 @@ func (t *tableView) size(maxWidth, maxHeight int) (int, int) {
 	// Calculate height
 	h := t.height
 	if h == 0 {
-		h = len(t.rows)
+		header := 0
 		if t.showHeader {
-			h++ // header row
+			header++ // header row
 			if t.headerBottomBorder {
-				h++ // header border row
+				header++ // header border row
 			}
 		}
+		h = len(t.rows) + header
+		if t.loading && len(t.rows) == 0 {
+			h = tableLoadingRows + header
+		}
+		if !t.loading {
+			detailWidth := w
+			if maxWidth > 0 {
+				detailWidth = min(w, maxWidth)
+			}
+			h += t.detailLines(detailWidth, max(0, maxHeight-header-1))
+		}
 	}
 
 	if maxWidth > 0 && w > maxWidth {
@@ -505,6 +615,7 @@ func (t *tableView) size(maxWidth, maxHeight int) (int, int) {
 }
 
 func (t *tableView) render(ctx *RenderContext) {
+	t.prepare()
 	width, height := ctx.Size()
 	if width == 0 || height == 0 || len(t.columns) == 0 {
 		return
@@ -516,51 +627,52 @@ func (t *tableView) render(ctx *RenderContext) {
 		fm.Register(t)
 	}
 
-	t.calculateColumnWidths()
-	t.fitColumnWidths(width) // Shrink columns to fit container
+	edit := t.editState()
+	show := -1
+	if edit != nil {
+		show = edit.col
+	}
+	cols := t.visibleColumns(width, show)
 
 	currentY := 0
 
 	// Draw header
 	if t.showHeader {
-		currentX := 0
-		for i, col := range t.columns {
-			w := t.columnWidths[i]
-			title := col.Title
+		for _, pos := range cols {
+			w := t.columnWidths[pos.col]
+			column := t.columns[pos.col]
+			title := column.Title
 
 			// Apply uppercase if enabled
 			if t.uppercaseHeaders {
 				title = strings.ToUpper(title)
 			}
-
-			// Truncate if needed
-			if runewidth.StringWidth(title) > w {
-				title = runewidth.Truncate(title, w, "…")
-			}
-			// Pad
-			padding := w - runewidth.StringWidth(title)
-			if padding > 0 {
-				title += repeatStr(" ", padding)
+			if arrow := t.sortIndicator(pos.col); arrow != "" {
+				title = truncateCell(title, w-2) + " " + arrow
 			}
 
-			ctx.PrintStyled(currentX, currentY, title, t.headerStyle)
-			currentX += w
-			// Add gap after column (except last)
-			if i < len(t.columns)-1 {
-				currentX += t.columnGap
+			ctx.PrintStyled(pos.x, currentY, alignCell(title, w, column.Align), t.headerStyle)
+
+			if column.Sortable {
+				bounds := ctx.AbsoluteBounds()
+				cell := image.Rect(pos.x, currentY, pos.x+w, currentY+1).Add(bounds.Min).Intersect(bounds)
+				interactiveRegistry.RegisterRegion(cell, func() {
+					t.changeSort(func(l *TableLayout) { l.ToggleSort(column.Title) })
+				})
+			}
+			if column.Resizable {
+				t.registerResizeHandle(ctx, pos, currentY)
 			}
 		}
 		currentY++
 
 		// Draw header bottom border if enabled
 		if t.headerBottomBorder {
-			// Use actual table width (sum of columns + gaps)
+			// Use actual table width (sum of drawn columns + gaps)
 			tableWidth := 0
-			for _, cw := range t.columnWidths {
-				tableWidth += cw
-			}
-			if len(t.columnWidths) > 1 {
-				tableWidth += (len(t.columnWidths) - 1) * t.columnGap
+			if len(cols) > 0 {
+				last := cols[len(cols)-1]
+				tableWidth = last.x + t.columnWidths[last.col]
 			}
 			if tableWidth > width {
 				tableWidth = width
@@ -582,6 +694,14 @@ func (t *tableView) render(ctx *RenderContext) {
 	if availableHeight <= 0 {
 		return
 	}
+	if t.loading {
+		t.renderSkeletonRows(ctx, currentY, availableHeight)
+		return
+	}
+
+	if edit != nil && edit.editing && edit.row >= len(t.rows) {
+		edit.editing = false
+	}
 
 	// Get selected row
 	selectedRow := 0
@@ -589,6 +709,32 @@ func (t *tableView) render(ctx *RenderContext) {
 		selectedRow = *t.selected
 	}
 
+	// The cell cursor follows the selection unless an edit is open
+	cursorRow := selectedRow
+	if edit != nil && edit.editing {
+		cursorRow = edit.row
+	}
+
+	// The expanded row's detail takes lines below it, leaving room for the
+	// row itself
+	detailRow, detailView := t.expandedRow()
+	detailLines := 0
+	if availableHeight > 1 {
+		detailLines = t.detailLines(width, availableHeight-1)
+	}
+	if detailLines == 0 {
+		detailRow = -1
+	}
+	// linesFrom counts the lines from the top of row from to the bottom of
+	// row to, including the detail if it is among them
+	linesFrom := func(from, to int) int {
+		n := to - from + 1
+		if detailRow >= from && detailRow <= to {
+			n += detailLines
+		}
+		return n
+	}
+
 	// Adjust scrollY to ensure selected row is visible
 	if selectedRow < t.scrollY {
 		t.scrollY = selectedRow
@@ -596,6 +742,9 @@ func (t *tableView) render(ctx *RenderContext) {
 	if selectedRow >= t.scrollY+availableHeight {
 		t.scrollY = selectedRow - availableHeight + 1
 	}
+	for t.scrollY < selectedRow && linesFrom(t.scrollY, selectedRow) > availableHeight {
+		t.scrollY++
+	}
 
 	// Clamp scrollY
 	if t.scrollY < 0 {
@@ -605,19 +754,23 @@ func (t *tableView) render(ctx *RenderContext) {
 	if maxScroll < 0 {
 		maxScroll = 0
 	}
+	for maxScroll < len(t.rows)-1 && linesFrom(maxScroll, len(t.rows)-1) > availableHeight {
+		maxScroll++
+	}
 	if t.scrollY > maxScroll {
 		t.scrollY = maxScroll
 	}
 
 	// Draw rows
-	for i := 0; i < availableHeight; i++ {
-		rowIndex := t.scrollY + i
-		if rowIndex >= len(t.rows) {
-			break
-		}
+	y := currentY
+	for rowIndex := t.scrollY; rowIndex < len(t.rows) && y < currentY+availableHeight; rowIndex++ {
 
 		row := t.rows[rowIndex]
 		style := t.style
+		info, grouped := t.rowInfoAt(rowIndex)
+		if grouped && info.group != nil && info.groupRow < 0 {
+			style = t.groupStyle
+		}
 		if rowIndex == selectedRow {
 			style = t.selectedStyle
 			// Apply color inversion if enabled
@@ -626,50 +779,121 @@ func (t *tableView) render(ctx *RenderContext) {
 			}
 		}
 
-		currentX := 0
-		for colIdx, cell := range row {
-			if colIdx >= len(t.columnWidths) {
+		editX := -1
+		for n, pos := range cols {
+			colIdx := pos.col
+			if colIdx >= len(row) {
 				break
 			}
+			cell := row[colIdx]
 			w := t.columnWidths[colIdx]
-
-			// Truncate/Pad
-			if runewidth.StringWidth(cell) > w {
-				cell = runewidth.Truncate(cell, w, "…")
+			column := t.columns[colIdx]
+			cellStyle := style
+			if column.CellStyle != nil {
+				cellStyle = cellStyle.Merge(column.CellStyle(t.sourceRow(rowIndex), cell))
 			}
-			padding := w - runewidth.StringWidth(cell)
-			paddedCell := cell
-			if padding > 0 {
-				paddedCell += repeatStr(" ", padding)
+			if edit != nil && rowIndex == cursorRow && colIdx == edit.col {
+				editX = pos.x
+				if t.focused && !edit.editing {
+					cellStyle = t.editCursorStyle
+				}
 			}
 
-			ctx.PrintStyled(currentX, currentY+i, paddedCell, style)
-			currentX += w
+			if column.Render != nil {
+				ctx.PrintStyled(pos.x, y, repeatStr(" ", w), cellStyle)
+				t.renderCell(ctx, column.Render(t.sourceRow(rowIndex), cell), pos.x, y, w)
+			} else {
+				ctx.PrintStyled(pos.x, y, alignCell(cell, w, column.Align), cellStyle)
+			}
 			// Add gap after column (except last), filled with row style
-			if colIdx < len(t.columnWidths)-1 && t.columnGap > 0 {
-				ctx.PrintStyled(currentX, currentY+i, repeatStr(" ", t.columnGap), style)
-				currentX += t.columnGap
+			if n < len(cols)-1 && t.columnGap > 0 {
+				ctx.PrintStyled(pos.x+w, y, repeatStr(" ", t.columnGap), style)
 			}
 		}
 
+		if edit != nil && edit.editing && edit.row == rowIndex && editX >= 0 {
+			t.renderEditor(ctx, edit, editX, y)
+		}
+
 		// Register clickable region for this row
 		bounds := ctx.AbsoluteBounds()
 		rowBounds := image.Rect(
 			bounds.Min.X,
-			bounds.Min.Y+currentY+i,
+			bounds.Min.Y+y,
 			bounds.Max.X,
-			bounds.Min.Y+currentY+i+1,
+			bounds.Min.Y+y+1,
 		)
 		idx := rowIndex // capture for closure
 		interactiveRegistry.RegisterButton(rowBounds, func() {
 			if t.selected != nil {
 				*t.selected = idx
 			}
-			if t.onSelect != nil {
-				t.onSelect(idx)
+			if grouped && info.expandable() {
+				info.setExpanded(!info.expanded())
+				return
+			}
+			if t.detail != nil {
+				t.toggleDetail(idx)
+				if expanded, _ := t.expandedRow(); expanded != idx {
+					return
+				}
 			}
+			t.activate(idx)
 		})
+		y++
+
+		if rowIndex == detailRow {
+			lines := min(detailLines, currentY+availableHeight-y)
+			t.renderDetail(ctx, detailView, y, lines)
+			y += lines
+		}
 	}
+	t.renderScrollIndicators(ctx, 0, cols)
+}
+
+// renderCell draws a view from a column's Render function in the cell at
+// (x, y), clipped to the cell.
+func (t *tableView) renderCell(ctx *RenderContext, view View, x, y, w int) {
+	if view == nil {
+		return
+	}
+	cellCtx := ctx.SubContext(image.Rect(x, y, x+w, y+1))
+	cellW, cellH := cellCtx.Size()
+	if cellW == 0 || cellH == 0 {
+		return
+	}
+	view.size(cellW, 1)
+	view.render(cellCtx)
+}
+
+// renderSkeletonRows draws placeholder bars under each column.
+func (t *tableView) renderSkeletonRows(ctx *RenderContext, y, rows int) {
+	style := defaultSkeletonStyle()
+	for i := 0; i < rows; i++ {
+		x := 0
+		for col, w := range t.columnWidths {
+			barWidth := w * defaultSkeletonWidths[(i+col)%len(defaultSkeletonWidths)] / 100
+			if barWidth < 1 {
+				barWidth = 1
+			}
+			style.drawBar(ctx, x, y+i, barWidth)
+			x += w + t.columnGap
+		}
+	}
+}
+
+// truncateCell shortens s to width w with an ellipsis.
+func truncateCell(s string, w int) string {
+	if runewidth.StringWidth(s) > w {
+		return runewidth.Truncate(s, max(0, w), "…")
+	}
+	return s
+}
+
+// alignCell truncates s to width w and pads it to fill the width, placed
+// by align.
+func alignCell(s string, w int, align Alignment) string {
+	return AlignText(truncateCell(s, w), w, align)
 }
 
 // repeatStr repeats a string n times.
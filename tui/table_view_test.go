@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/deepnoodle-ai/wonton/assert"
+	"github.com/deepnoodle-ai/wonton/termtest"
 )
 
 func TestTableUppercaseHeaders(t *testing.T) {
@@ -169,3 +170,33 @@ func TestTableExplicitColumnWidthNotAffectedByMax(t *testing.T) {
 	// Explicit width should be respected, then limited by max
 	assert.Equal(t, 20, table.columnWidths[0])
 }
+
+func TestTableColumnRender(t *testing.T) {
+	columns := []TableColumn{
+		{Title: "Name", Width: 6},
+		{Title: "CPU", Width: 6, Render: func(row int, value string) View {
+			// Taller and wider than the cell; only the cell is drawn
+			return Stack(Text("%s%%%d=========", value, row).Bg(ColorGreen), Text("second line"))
+		}},
+		{Title: "State", Width: 5},
+	}
+	selected := 0
+	view := Table(columns, &selected).
+		Rows([][]string{{"init", "3", "ok"}, {"sshd", "40", "ok"}}).
+		HeaderBottomBorder(false)
+
+	screen := SprintScreen(view, PrintConfig{Width: 30, Height: 3})
+	assert.Equal(t, "init    3%0===  ok", screen.Row(1))
+	assert.Equal(t, "sshd    40%1==  ok", screen.Row(2))
+	assert.Equal(t, uint8(ColorGreen), screen.Cell(8, 2).Style.Background.Value)
+	assert.Equal(t, termtest.ColorDefault, screen.Cell(14, 2).Style.Background.Type)
+}
+
+func TestTableShrinksByLessThanOneCellPerColumn(t *testing.T) {
+	// Each column's share of the one cell to lose rounds down to zero
+	columns := []TableColumn{{Title: "Name"}, {Title: "Change"}}
+	view := Table(columns, nil).Rows([][]string{{"abc", "-1.5"}}).ColumnGap(1)
+	screen := SprintScreen(view, PrintConfig{Width: 14, Height: 3})
+	assert.Equal(t, "Name   Change", screen.Row(0))
+	assert.Equal(t, "abc    -1.5", screen.Row(2))
+}
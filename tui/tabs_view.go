@@ -0,0 +1,211 @@
+package tui
+
+import "image"
+
+// Tab is a page of a Tabs container.
+type Tab struct {
+	Title string
+	// Badge is short text shown after the title, like an unread count.
+	Badge string
+	// Content builds the tab's view. It is only called while the tab is
+	// active, so inactive tabs cost nothing to render.
+	Content func() View
+}
+
+// TabKeys configures the keys that switch tabs in a Tabs container. The keys
+// only apply when the focused element doesn't use them, so typing digits
+// into an input doesn't switch tabs.
+type TabKeys struct {
+	Numbers bool       // 1-9 activate the first nine tabs
+	Next    []KeyEvent // Keys that activate the next tab, wrapping around
+	Prev    []KeyEvent // Keys that activate the previous tab, wrapping around
+}
+
+// DefaultTabKeys returns the default tab keys: 1-9, Ctrl+Tab for the next
+// tab, and Ctrl+Shift+Tab for the previous one. Ctrl+Tab only reaches
+// applications in terminals that report it, so apps that need to work
+// everywhere can add keys such as ']' and '['.
+func DefaultTabKeys() TabKeys {
+	return TabKeys{
+		Numbers: true,
+		Next:    []KeyEvent{{Key: KeyTab, Ctrl: true}},
+		Prev:    []KeyEvent{{Key: KeyTab, Ctrl: true, Shift: true}},
+	}
+}
+
+// matchKey reports whether event is the key described by binding, ignoring
+// its timestamp.
+func matchKey(event, binding KeyEvent) bool {
+	return event.Key == binding.Key && event.Rune == binding.Rune &&
+		event.Ctrl == binding.Ctrl && event.Alt == binding.Alt && event.Shift == binding.Shift &&
+		event.Super == binding.Super
+}
+
+// tabsView shows a tab bar above the content of the active tab.
+type tabsView struct {
+	tabs     []Tab
+	active   *int
+	keys     TabKeys
+	onChange func(index int)
+	strip    *tabStripView
+
+	content View // content of the active tab, built once per frame
+}
+
+// Tabs creates a container with a tab bar above the content of the active
+// tab. active holds the index of the active tab; keep it in your
+// application model. Clicking a tab or pressing its key (see TabKeys)
+// activates it.
+//
+// Example:
+//
+//	tui.Tabs([]tui.Tab{
+//	    {Title: "Commits", Content: app.commitsView},
+//	    {Title: "Branches", Badge: fmt.Sprint(len(app.branches)), Content: app.branchesView},
+//	}, &app.tab)
+func Tabs(tabs []Tab, active *int) *tabsView {
+	v := &tabsView{
+		tabs:   tabs,
+		active: active,
+		keys:   DefaultTabKeys(),
+	}
+	v.strip = newTabStrip(v.activate)
+	for _, tab := range tabs {
+		v.strip.items = append(v.strip.items, tabStripItem{title: tab.Title, badge: tab.Badge})
+	}
+	return v
+}
+
+// Keys sets the keys that switch tabs.
+func (v *tabsView) Keys(keys TabKeys) *tabsView {
+	v.keys = keys
+	return v
+}
+
+// OnChange sets a callback invoked when another tab is activated.
+func (v *tabsView) OnChange(fn func(index int)) *tabsView {
+	v.onChange = fn
+	return v
+}
+
+// ActiveStyle sets the style of the active tab.
+func (v *tabsView) ActiveStyle(s Style) *tabsView {
+	v.strip.activeStyle = s
+	return v
+}
+
+// InactiveStyle sets the style of inactive tabs.
+func (v *tabsView) InactiveStyle(s Style) *tabsView {
+	v.strip.inactiveStyle = s
+	return v
+}
+
+// BadgeStyle sets the style of tab badges, applied over the tab's style.
+func (v *tabsView) BadgeStyle(s Style) *tabsView {
+	v.strip.badgeStyle = s
+	return v
+}
+
+// Numbered prefixes each tab with its 1-based position, as a hint for the
+// number keys.
+func (v *tabsView) Numbered(numbered bool) *tabsView {
+	v.strip.numbered = numbered
+	return v
+}
+
+// index returns the active index, clamped to the tabs.
+func (v *tabsView) index() int {
+	if *v.active >= len(v.tabs) {
+		*v.active = len(v.tabs) - 1
+	}
+	if *v.active < 0 {
+		*v.active = 0
+	}
+	return *v.active
+}
+
+// activate makes the tab at index active.
+func (v *tabsView) activate(index int) {
+	if index < 0 || index >= len(v.tabs) || index == *v.active {
+		return
+	}
+	*v.active = index
+	if v.onChange != nil {
+		v.onChange(index)
+	}
+}
+
+// handleKey switches tabs for the configured keys.
+func (v *tabsView) handleKey(event KeyEvent) bool {
+	n := len(v.tabs)
+	if v.keys.Numbers && event.Key == KeyUnknown && !event.Ctrl && !event.Alt &&
+		event.Rune >= '1' && event.Rune <= '9' && int(event.Rune-'1') < n {
+		v.activate(int(event.Rune - '1'))
+		return true
+	}
+	for _, key := range v.keys.Next {
+		if matchKey(event, key) {
+			v.activate((v.index() + 1) % n)
+			return true
+		}
+	}
+	for _, key := range v.keys.Prev {
+		if matchKey(event, key) {
+			v.activate((v.index() - 1 + n) % n)
+			return true
+		}
+	}
+	return false
+}
+
+// activeContent builds the active tab's view for this frame.
+func (v *tabsView) activeContent() View {
+	if v.content == nil {
+		v.content = Empty()
+		if len(v.tabs) > 0 {
+			if build := v.tabs[v.index()].Content; build != nil {
+				if content := build(); content != nil {
+					v.content = content
+				}
+			}
+		}
+	}
+	return v.content
+}
+
+func (v *tabsView) flex() int {
+	return 1
+}
+
+func (v *tabsView) size(maxWidth, maxHeight int) (int, int) {
+	if len(v.tabs) == 0 {
+		return 0, 0
+	}
+	v.strip.active = v.index()
+	stripW, _ := v.strip.size(maxWidth, 1)
+	contentMaxH := maxHeight
+	if maxHeight > 0 {
+		contentMaxH = max(0, maxHeight-1)
+	}
+	w, h := v.activeContent().size(maxWidth, contentMaxH)
+	return max(w, stripW), h + 1
+}
+
+func (v *tabsView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 || len(v.tabs) == 0 {
+		return
+	}
+
+	v.strip.active = v.index()
+	v.strip.render(ctx.SubContext(image.Rect(0, 0, width, 1)))
+
+	content := v.activeContent()
+	v.content = nil // build the content again if the view is reused
+	if height > 1 {
+		content.size(width, height-1)
+		content.render(ctx.SubContext(image.Rect(0, 1, width, height)))
+	}
+
+	interactiveRegistry.RegisterKeys(v.handleKey)
+}
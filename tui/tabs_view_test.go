@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestTabs(t *testing.T) {
+	active := 0
+	built := map[string]int{}
+	tab := func(title, badge string) Tab {
+		return Tab{Title: title, Badge: badge, Content: func() View {
+			built[title]++
+			return Text("%s content", title)
+		}}
+	}
+	changes := []int{}
+	view := func() *tabsView {
+		return Tabs([]Tab{tab("One", ""), tab("Two", "3"), tab("Three", "")}, &active).
+			OnChange(func(i int) { changes = append(changes, i) })
+	}
+
+	screen := SprintScreen(view(), PrintConfig{Width: 30, Height: 3})
+	assert.Equal(t, " One │ Two 3 │ Three", screen.Row(0))
+	assert.Equal(t, "One content", screen.Row(1))
+	assert.Equal(t, map[string]int{"One": 1}, built)
+	assert.Equal(t, uint8(ColorYellow), screen.Cell(11, 0).Style.Foreground.Value)
+
+	// Number keys and Ctrl+Tab switch tabs through the registered shortcuts
+	interactiveRegistry.Clear()
+	defer interactiveRegistry.Clear()
+	v := view()
+	SprintScreen(v, PrintConfig{Width: 30, Height: 3})
+	assert.True(t, interactiveRegistry.HandleKey(KeyEvent{Rune: '3'}))
+	assert.Equal(t, 2, active)
+	assert.True(t, interactiveRegistry.HandleKey(KeyEvent{Key: KeyTab, Ctrl: true}))
+	assert.Equal(t, 0, active)
+	assert.True(t, interactiveRegistry.HandleKey(KeyEvent{Key: KeyTab, Ctrl: true, Shift: true}))
+	assert.Equal(t, 2, active)
+	assert.False(t, interactiveRegistry.HandleKey(KeyEvent{Rune: '7'}))
+	assert.Equal(t, []int{2, 0, 2}, changes)
+
+	screen = SprintScreen(view(), PrintConfig{Width: 30, Height: 3})
+	assert.Equal(t, "Three content", screen.Row(1))
+	assert.Equal(t, 0, built["Two"])
+}
+
+func TestTabs_CustomKeys(t *testing.T) {
+	active := 0
+	v := Tabs([]Tab{{Title: "A"}, {Title: "B"}}, &active).
+		Keys(TabKeys{Next: []KeyEvent{{Rune: ']'}}})
+	assert.False(t, v.handleKey(KeyEvent{Rune: '2'}))
+	assert.True(t, v.handleKey(KeyEvent{Rune: ']'}))
+	assert.Equal(t, 1, active)
+}
+
+func TestFocusManager_CtrlTabNotConsumed(t *testing.T) {
+	fm := NewFocusManager()
+	assert.False(t, fm.HandleKey(KeyEvent{Key: KeyTab, Ctrl: true}))
+}
@@ -104,6 +104,9 @@ type textAreaView struct {
 	hasCurrentLineStyle  bool
 	cursorLine           *int // pointer to external cursor line position
 	internalCursorLine   int  // internal cursor line if cursorLine is nil
+
+	// Diagnostics
+	diagnostics []Diagnostic
 }
 
 // TextArea creates a scrollable text display component.
@@ -285,6 +288,23 @@ func (t *textAreaView) CursorLine(line *int) *textAreaView {
 	return t
 }
 
+// Diagnostics attaches diagnostics to the content. Their ranges are
+// underlined, their lines get a gutter marker, and while the text area is
+// focused with HighlightCurrentLine, the messages for the current line show
+// in a popover below it.
+//
+// Example:
+//
+//	TextArea(&app.draft).
+//	    HighlightCurrentLine(true).
+//	    Diagnostics([]tui.Diagnostic{
+//	        {Line: 2, StartCol: 4, EndCol: 9, Severity: tui.SeverityWarning, Message: "unknown word", Source: "spell"},
+//	    })
+func (t *textAreaView) Diagnostics(diags []Diagnostic) *textAreaView {
+	t.diagnostics = diags
+	return t
+}
+
 func (t *textAreaView) getContent() string {
 	if t.binding != nil {
 		return *t.binding
@@ -396,6 +416,19 @@ func (t *textAreaView) render(ctx *RenderContext) {
 			currentLineStyle = NewStyle().WithBackground(ColorBrightBlack)
 		}
 
+		byLine := diagnosticsByLine(t.diagnostics)
+		identity := func(col int) int { return col }
+
+		// lineText builds the text of a line, underlining its diagnostics
+		lineText := func(i int, line string, style Style) View {
+			diags := byLine[i]
+			if len(diags) == 0 {
+				return Text("%s", line).Style(style)
+			}
+			segments := []StyledSegment{{Text: line, Style: style}}
+			return segmentsView(underlineDiagnostics(segments, diags, identity))
+		}
+
 		for i, line := range lines {
 			var lineView View
 
@@ -409,12 +442,12 @@ func (t *textAreaView) render(ctx *RenderContext) {
 				if line == "" {
 					textView = Text(" ") // preserve empty lines
 				} else {
-					textView = Text("%s", line).Style(t.textStyle)
+					textView = lineText(i, line, t.textStyle)
 				}
 
 				// Apply current line highlighting if enabled
 				if t.highlightCurrentLine && i == cursorLine {
-					textView = Text("%s", line).Style(t.textStyle.Merge(currentLineStyle))
+					textView = lineText(i, line, t.textStyle.Merge(currentLineStyle))
 					lineNumView = Text("%s", lineNumText).Style(lnStyle.Merge(currentLineStyle))
 				}
 
@@ -429,10 +462,14 @@ func (t *textAreaView) render(ctx *RenderContext) {
 					if t.highlightCurrentLine && i == cursorLine {
 						lineStyle = lineStyle.Merge(currentLineStyle)
 					}
-					lineView = Text("%s", line).Style(lineStyle)
+					lineView = lineText(i, line, lineStyle)
 				}
 			}
 
+			if byLine != nil {
+				marker, markerStyle := diagnosticMarker(byLine[i])
+				lineView = Group(Text("%s", marker).Style(markerStyle), lineView)
+			}
 			lineViews[i] = lineView
 		}
 		contentView = Stack(lineViews...)
@@ -466,7 +503,7 @@ func (t *textAreaView) render(ctx *RenderContext) {
 		}
 
 		// Render bordered view manually for focus-aware styling
-		t.renderBordered(ctx, w, h, scrollContent, &scrollY, borderStyle, titleStyle)
+		t.renderBordered(ctx, w, h, scrollContent, &scrollY, borderStyle, titleStyle, isFocused)
 	} else {
 		// No border, just render the scroll content
 		scrollContent.render(ctx)
@@ -475,6 +512,10 @@ func (t *textAreaView) render(ctx *RenderContext) {
 	// Update scroll position
 	t.setScrollY(scrollY)
 
+	if isFocused && t.highlightCurrentLine && content != "" {
+		t.renderPopover(ctx, w, h, scrollY)
+	}
+
 	// Register as focusable for Tab navigation (if focus manager available)
 	bounds := ctx.AbsoluteBounds()
 	if fm != nil {
@@ -486,8 +527,38 @@ func (t *textAreaView) render(ctx *RenderContext) {
 	}
 }
 
-func (t *textAreaView) renderBordered(ctx *RenderContext, w, h int, content *scrollView, scrollY *int, borderStyle, titleStyle Style) {
-	border := t.border
+// renderPopover shows the diagnostics of the current line in a popover
+// below it, if the line is visible.
+func (t *textAreaView) renderPopover(ctx *RenderContext, w, h, scrollY int) {
+	cursorLine := t.getCursorLine()
+	diags := diagnosticsByLine(t.diagnostics)[cursorLine]
+	if len(diags) == 0 {
+		return
+	}
+
+	// Offset of the content within the text area
+	x, y := 0, 0
+	if t.bordered && t.border != nil {
+		x = 1
+		if !t.leftBorderOnly {
+			y = 1
+			h -= 2
+		}
+	}
+	row := cursorLine - scrollY
+	if row < 0 || row >= h {
+		return
+	}
+	x += diagnosticWidth + t.lineNumberWidth()
+	renderDiagnosticPopover(ctx, x, y+row, diags)
+}
+
+func (t *textAreaView) renderBordered(ctx *RenderContext, w, h int, content *scrollView, scrollY *int, borderStyle, titleStyle Style, focused bool) {
+	// With the theme's focus indicators, focus also shows without color
+	border, title := t.border, t.title
+	if focused {
+		border, title = focusedBorder(ctx, border), focusedTitle(ctx, title)
+	}
 
 	if t.leftBorderOnly {
 		// Only draw the left border
@@ -509,10 +580,10 @@ func (t *textAreaView) renderBordered(ctx *RenderContext, w, h int, content *scr
 	ctx.PrintTruncated(0, 0, border.TopLeft, borderStyle)
 	bx := 1
 
-	if t.title != "" && w > 4 {
+	if title != "" && w > 4 {
 		ctx.PrintTruncated(bx, 0, border.Horizontal, borderStyle)
 		bx++
-		titleText := " " + t.title + " "
+		titleText := " " + title + " "
 		titleW, _ := MeasureText(titleText)
 		maxTitleW := w - 4
 		if titleW > maxTitleW {
@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"image"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// textFrame is an offscreen RenderFrame that keeps only the characters
+// drawn to it, for views that need to know the text another view draws.
+type textFrame struct {
+	grid   *textGrid
+	bounds image.Rectangle // in grid coordinates
+}
+
+// textGrid holds the characters of a textFrame. Cells covered by the second
+// column of a wide character hold 0.
+type textGrid struct {
+	rows [][]rune
+}
+
+func newTextFrame(width, height int) *textFrame {
+	rows := make([][]rune, height)
+	for i := range rows {
+		rows[i] = []rune(strings.Repeat(" ", width))
+	}
+	return &textFrame{grid: &textGrid{rows: rows}, bounds: image.Rect(0, 0, width, height)}
+}
+
+// lines returns the text of each row, with wide characters counted once.
+func (f *textFrame) lines() []string {
+	lines := make([]string, len(f.grid.rows))
+	for i, row := range f.grid.rows {
+		var b strings.Builder
+		for _, r := range row {
+			if r != 0 {
+				b.WriteRune(r)
+			}
+		}
+		lines[i] = strings.TrimRight(b.String(), " ")
+	}
+	return lines
+}
+
+// cell returns the character at column x of row y, or 0 for the second
+// column of a wide character and for cells outside the frame.
+func (f *textFrame) cell(x, y int) rune {
+	if y < 0 || y >= len(f.grid.rows) || x < 0 || x >= len(f.grid.rows[y]) {
+		return 0
+	}
+	return f.grid.rows[y][x]
+}
+
+// put stores r at x, y relative to the frame, clipped to its bounds.
+func (f *textFrame) put(x, y int, r rune) {
+	p := image.Pt(x, y).Add(f.bounds.Min)
+	if !p.In(f.bounds) {
+		return
+	}
+	row := f.grid.rows[p.Y]
+	row[p.X] = r
+	if runewidth.RuneWidth(r) == 2 && p.X+1 < f.bounds.Max.X {
+		row[p.X+1] = 0
+	}
+}
+
+func (f *textFrame) print(x, y int, text string, wrap bool) {
+	width := f.bounds.Dx()
+	for _, r := range text {
+		if r == '\n' {
+			x = 0
+			y++
+			continue
+		}
+		w := runewidth.RuneWidth(r)
+		if w == 0 {
+			continue
+		}
+		if x+w > width {
+			if !wrap {
+				continue
+			}
+			x = 0
+			y++
+		}
+		if y >= f.bounds.Dy() {
+			return
+		}
+		f.put(x, y, r)
+		x += w
+	}
+}
+
+func (f *textFrame) SetCell(x, y int, char rune, style Style) error {
+	f.put(x, y, char)
+	return nil
+}
+
+func (f *textFrame) PrintStyled(x, y int, text string, style Style) error {
+	f.print(x, y, text, true)
+	return nil
+}
+
+func (f *textFrame) PrintTruncated(x, y int, text string, style Style) error {
+	f.print(x, y, text, false)
+	return nil
+}
+
+func (f *textFrame) FillStyled(x, y, width, height int, char rune, style Style) error {
+	for row := y; row < y+height; row++ {
+		for col := x; col < x+width; col++ {
+			f.put(col, row, char)
+		}
+	}
+	return nil
+}
+
+func (f *textFrame) Fill(char rune, style Style) error {
+	return f.FillStyled(0, 0, f.bounds.Dx(), f.bounds.Dy(), char, style)
+}
+
+func (f *textFrame) Size() (width, height int) {
+	return f.bounds.Dx(), f.bounds.Dy()
+}
+
+func (f *textFrame) GetBounds() image.Rectangle {
+	return f.bounds
+}
+
+func (f *textFrame) SubFrame(rect image.Rectangle) RenderFrame {
+	return &textFrame{grid: f.grid, bounds: rect.Add(f.bounds.Min).Intersect(f.bounds)}
+}
+
+func (f *textFrame) PrintHyperlink(x, y int, link Hyperlink) error {
+	return f.PrintStyled(x, y, link.Text, link.Style)
+}
+
+func (f *textFrame) PrintHyperlinkFallback(x, y int, link Hyperlink) error {
+	return f.PrintStyled(x, y, link.Text+" ("+link.URL+")", link.Style)
+}
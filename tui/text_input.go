@@ -20,6 +20,11 @@ const (
 	InputCursorUnderline
 	// InputCursorBar renders a vertical bar/beam cursor
 	InputCursorBar
+	// InputCursorTerminal uses the terminal's own cursor, which keeps the
+	// shape and blinking the user configured. Views place it with
+	// RenderContext.SetCursor; where that isn't supported they draw a block
+	// cursor instead.
+	InputCursorTerminal
 )
 
 // inputSegment represents a portion of input text
@@ -67,9 +72,34 @@ type TextInput struct {
 	CursorShape         InputCursorStyle // Shape of the cursor (block, underline, bar)
 	CursorColor         *Color           // Custom cursor color (nil = use default style)
 
+	// History of submitted values, recalled with Up/Down (nil = none)
+	History *InputHistory
+
+	// KeyHandler, if set, sees each key before the input's own editing
+	// keys, for alternative editing modes such as ViMode (nil = none)
+	KeyHandler InputKeyHandler
+
+	// Style merged into the selected text (see SetSelection)
+	SelectionStyle Style
+
+	// Editing helpers for code, such as bracket pairing (see CodeEditing)
+	CodeEditing CodeEditing
+
 	// Internal
-	focused  bool
-	segments []inputSegment // Segments of typed text and paste placeholders
+	focused    bool
+	segments   []inputSegment // Segments of typed text and paste placeholders
+	killBuffer string         // Text removed by the last kill, inserted by Ctrl+Y
+	cursorAt   *image.Point   // Frame position of an InputCursorTerminal cursor from the last Draw
+	selection  [2]int         // Selected range of display text; empty when equal
+	cursors    []inputCursor  // Every cursor, in order, while there are several (see AddCursor)
+	column     *columnSelection
+}
+
+// InputKeyHandler handles keys for a TextInput before its own editing keys,
+// to change how it edits. HandleInputKey returns true if it handled the key;
+// otherwise the input handles it as usual.
+type InputKeyHandler interface {
+	HandleInputKey(input *TextInput, event KeyEvent) bool
 }
 
 // NewTextInput creates a new text input widget
@@ -82,6 +112,7 @@ func NewTextInput() *TextInput {
 		CursorStyle:      NewStyle().WithBackground(ColorWhite).WithForeground(ColorBlack),
 		PasteStyle:       NewStyle().WithForeground(ColorBrightBlack).WithItalic(),
 		OverflowStyle:    NewStyle().WithForeground(ColorBrightBlack),
+		SelectionStyle:   NewStyle().WithReverse(),
 		segments:         []inputSegment{},
 		SubmitOnEnter:    true,
 	}
@@ -106,6 +137,7 @@ func (t *TextInput) SetValue(value string) {
 		t.segments = []inputSegment{{display: value, actual: value, isPaste: false}}
 	}
 	t.CursorPos = len(value)
+	t.cursors, t.column = nil, nil
 	t.MarkDirty()
 }
 
@@ -199,6 +231,21 @@ func (t *TextInput) WithCursorColor(color Color) *TextInput {
 	return t
 }
 
+// WithHistory enables recalling previously submitted values with Up and
+// Down. In multiline mode, Up and Down only recall history from the first
+// and last lines.
+func (t *TextInput) WithHistory(history *InputHistory) *TextInput {
+	t.History = history
+	return t
+}
+
+// WithKeyHandler sets a handler that sees keys before the input's own
+// editing keys, such as a ViMode.
+func (t *TextInput) WithKeyHandler(h InputKeyHandler) *TextInput {
+	t.KeyHandler = h
+	return t
+}
+
 // WithMaxHeight sets the maximum visible height in lines.
 // When content exceeds this, the input becomes scrollable with overflow indicators.
 func (t *TextInput) WithMaxHeight(lines int) *TextInput {
@@ -275,15 +322,20 @@ func (t *TextInput) Draw(frame RenderFrame) {
 		// Draw segments with appropriate styles, handling newlines and scrolling
 		x := drawX
 		visualLine := 0
+		offset := 0 // byte offset in the display text
 
 		for _, seg := range t.segments {
-			style := t.Style
+			segStyle := t.Style
 			if seg.isPaste {
-				style = t.PasteStyle
+				segStyle = t.PasteStyle
 			}
 
 			// Handle segment character by character to deal with newlines and wrapping
-			for _, r := range seg.display {
+			for i, r := range seg.display {
+				style := segStyle
+				if t.selected(offset + i) {
+					style = style.Merge(t.SelectionStyle)
+				}
 				if r == '\n' {
 					// Move to next line
 					visualLine++
@@ -305,6 +357,7 @@ func (t *TextInput) Draw(frame RenderFrame) {
 				}
 				x += charWidth
 			}
+			offset += len(seg.display)
 		}
 
 		// Draw overflow indicators
@@ -322,6 +375,15 @@ func (t *TextInput) Draw(frame RenderFrame) {
 	}
 
 	// Draw cursor if focused
+	t.cursorAt = nil
+	if t.focused && t.CursorShape == InputCursorTerminal {
+		cursorLine := t.getCursorLine(width)
+		screenLine := cursorLine - t.ScrollOffset
+		cursorX := t.getCursorXInLine(width)
+		if screenLine >= 0 && screenLine < height && cursorX < width {
+			t.cursorAt = &image.Point{X: drawX + cursorX, Y: drawY + screenLine}
+		}
+	}
 	if t.focused {
 		// Check if cursor should be visible (blinking logic)
 		cursorVisible := true
@@ -336,68 +398,91 @@ func (t *TextInput) Draw(frame RenderFrame) {
 		}
 
 		if cursorVisible {
-			// Calculate cursor position accounting for newlines and scroll offset
-			cursorLine := t.getCursorLine(width)
-			cursorX := t.getCursorXInLine(width)
-
-			// Only draw cursor if it's in the visible range
-			screenLine := cursorLine - t.ScrollOffset
-			if screenLine >= 0 && screenLine < height {
-				cursorScreenX := drawX + cursorX
-				cursorScreenY := drawY + screenLine
-
-				if cursorScreenX < drawX+width {
-					// Determine cursor style and character
-					cursorStyle := t.CursorStyle
-					if t.CursorColor != nil {
-						// Override with custom cursor color
-						cursorStyle = cursorStyle.WithBackground(*t.CursorColor)
-					}
+			// The terminal shows the main cursor itself; other cursors are drawn
+			if t.CursorShape != InputCursorTerminal {
+				t.drawCursor(frame, t.CursorPos, drawX, drawY, width, height, displayText, showingPlaceholder)
+			}
+			for _, c := range t.cursors {
+				if c.pos != t.CursorPos {
+					t.drawCursor(frame, c.pos, drawX, drawY, width, height, displayText, showingPlaceholder)
+				}
+			}
+		}
+	}
+}
 
-					charUnderCursor := " "
-					if showingPlaceholder {
-						// Show first char of placeholder under cursor
-						r, _ := utf8.DecodeRuneInString(t.Placeholder)
-						charUnderCursor = string(r)
-					} else if t.CursorPos < len(displayText) {
-						r, _ := utf8.DecodeRuneInString(displayText[t.CursorPos:])
-						if r != '\n' {
-							if t.MaskChar != 0 {
-								charUnderCursor = string(t.MaskChar)
-							} else {
-								charUnderCursor = string(r)
-							}
-						}
-					}
+// drawCursor draws a cursor at byte offset pos of the display text, if it
+// is scrolled into view.
+func (t *TextInput) drawCursor(frame RenderFrame, pos, drawX, drawY, width, height int, displayText string, showingPlaceholder bool) {
+	// Calculate cursor position accounting for newlines and scroll offset
+	cursorX, cursorLine := t.visualPosition(pos, width)
 
-					// Render based on cursor shape
-					switch t.CursorShape {
-					case InputCursorBlock:
-						// Default block cursor - inverted character
-						frame.PrintStyled(cursorScreenX, cursorScreenY, charUnderCursor, cursorStyle)
-
-					case InputCursorUnderline:
-						// Underline cursor - show character with underline
-						underlineStyle := t.Style
-						if t.CursorColor != nil {
-							underlineStyle = underlineStyle.WithForeground(*t.CursorColor).WithUnderline()
-						} else {
-							underlineStyle = underlineStyle.WithUnderline()
-						}
-						frame.PrintStyled(cursorScreenX, cursorScreenY, charUnderCursor, underlineStyle)
+	// Only draw cursor if it's in the visible range
+	screenLine := cursorLine - t.ScrollOffset
+	if screenLine < 0 || screenLine >= height || cursorX >= width {
+		return
+	}
+	cursorScreenX := drawX + cursorX
+	cursorScreenY := drawY + screenLine
+
+	// Determine cursor style and character
+	cursorStyle := t.CursorStyle
+	if t.CursorColor != nil {
+		// Override with custom cursor color
+		cursorStyle = cursorStyle.WithBackground(*t.CursorColor)
+	}
 
-					case InputCursorBar:
-						// Bar/beam cursor - vertical line character
-						barStyle := t.Style
-						if t.CursorColor != nil {
-							barStyle = barStyle.WithForeground(*t.CursorColor)
-						}
-						frame.PrintStyled(cursorScreenX, cursorScreenY, "│", barStyle)
-					}
-				}
+	charUnderCursor := " "
+	if showingPlaceholder {
+		// Show first char of placeholder under cursor
+		r, _ := utf8.DecodeRuneInString(t.Placeholder)
+		charUnderCursor = string(r)
+	} else if pos < len(displayText) {
+		r, _ := utf8.DecodeRuneInString(displayText[pos:])
+		if r != '\n' {
+			if t.MaskChar != 0 {
+				charUnderCursor = string(t.MaskChar)
+			} else {
+				charUnderCursor = string(r)
 			}
 		}
 	}
+
+	// Render based on cursor shape
+	switch t.CursorShape {
+	case InputCursorBlock, InputCursorTerminal:
+		// Default block cursor - inverted character
+		frame.PrintStyled(cursorScreenX, cursorScreenY, charUnderCursor, cursorStyle)
+
+	case InputCursorUnderline:
+		// Underline cursor - show character with underline
+		underlineStyle := t.Style
+		if t.CursorColor != nil {
+			underlineStyle = underlineStyle.WithForeground(*t.CursorColor).WithUnderline()
+		} else {
+			underlineStyle = underlineStyle.WithUnderline()
+		}
+		frame.PrintStyled(cursorScreenX, cursorScreenY, charUnderCursor, underlineStyle)
+
+	case InputCursorBar:
+		// Bar/beam cursor - vertical line character
+		barStyle := t.Style
+		if t.CursorColor != nil {
+			barStyle = barStyle.WithForeground(*t.CursorColor)
+		}
+		frame.PrintStyled(cursorScreenX, cursorScreenY, "│", barStyle)
+	}
+}
+
+// CursorPosition returns where the terminal cursor belongs, in the
+// coordinates of the frame last passed to Draw, when CursorShape is
+// InputCursorTerminal. ok is false if the input isn't focused or the cursor
+// is scrolled out of view.
+func (t *TextInput) CursorPosition() (x, y int, ok bool) {
+	if t.cursorAt == nil {
+		return 0, 0, false
+	}
+	return t.cursorAt.X, t.cursorAt.Y, true
 }
 
 // getCursorXY calculates the visual x,y position of the cursor
@@ -457,57 +542,42 @@ func (t *TextInput) countVisualLines(width int) int {
 
 // getCursorLine returns which visual line (0-indexed) the cursor is on
 func (t *TextInput) getCursorLine(width int) int {
-	if width <= 0 {
-		return 0
-	}
-	displayText := t.DisplayText()
-
-	line := 0
-	x := 0
-	for i, r := range displayText {
-		if i >= t.CursorPos {
-			break
-		}
-		if r == '\n' {
-			line++
-			x = 0
-		} else {
-			charWidth := runewidth.RuneWidth(r)
-			if x+charWidth > width {
-				line++
-				x = charWidth
-			} else {
-				x += charWidth
-			}
-		}
-	}
+	_, line := t.visualPosition(t.CursorPos, width)
 	return line
 }
 
 // getCursorXInLine returns the x position of the cursor within its current line
 func (t *TextInput) getCursorXInLine(width int) int {
+	x, _ := t.visualPosition(t.CursorPos, width)
+	return x
+}
+
+// visualPosition returns the x position and visual line (accounting for
+// wrapping) of byte offset pos in the display text.
+func (t *TextInput) visualPosition(pos, width int) (x, line int) {
 	if width <= 0 {
-		return 0
+		return 0, 0
 	}
 	displayText := t.DisplayText()
 
-	x := 0
 	for i, r := range displayText {
-		if i >= t.CursorPos {
+		if i >= pos {
 			break
 		}
 		if r == '\n' {
+			line++
 			x = 0
 		} else {
 			charWidth := runewidth.RuneWidth(r)
 			if x+charWidth > width {
+				line++
 				x = charWidth
 			} else {
 				x += charWidth
 			}
 		}
 	}
-	return x
+	return x, line
 }
 
 // lineRange represents the byte range of a visual line.
@@ -854,6 +924,107 @@ func (t *TextInput) deleteWordBackward() {
 	}
 }
 
+// deleteWordForward deletes from the cursor to the end of the next word
+func (t *TextInput) deleteWordForward() {
+	target := wordRight(t.DisplayText(), t.CursorPos)
+	remove := target - t.CursorPos
+	startLen := t.displayLen()
+	for startLen-t.displayLen() < remove && t.deleteForward() {
+	}
+}
+
+// kill runs a deletion and saves the removed text for yanking with Ctrl+Y.
+func (t *TextInput) kill(deleteFn func()) {
+	before := t.Value()
+	deleteFn()
+	if removed := removedText(before, t.Value()); removed != "" {
+		t.killBuffer = removed
+	}
+}
+
+// removedText returns the contiguous text that was removed from before to
+// produce after.
+func removedText(before, after string) string {
+	prefix := 0
+	for prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(after)-prefix && before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+	return before[prefix : len(before)-suffix]
+}
+
+// wordLeft returns the position of the start of the word before pos.
+func wordLeft(text string, pos int) int {
+	for pos > 0 {
+		r, w := utf8.DecodeLastRuneInString(text[:pos])
+		if isWordChar(r) {
+			break
+		}
+		pos -= w
+	}
+	for pos > 0 {
+		r, w := utf8.DecodeLastRuneInString(text[:pos])
+		if !isWordChar(r) {
+			break
+		}
+		pos -= w
+	}
+	return pos
+}
+
+// wordRight returns the position of the end of the word after pos.
+func wordRight(text string, pos int) int {
+	for pos < len(text) {
+		r, w := utf8.DecodeRuneInString(text[pos:])
+		if isWordChar(r) {
+			break
+		}
+		pos += w
+	}
+	for pos < len(text) {
+		r, w := utf8.DecodeRuneInString(text[pos:])
+		if !isWordChar(r) {
+			break
+		}
+		pos += w
+	}
+	return pos
+}
+
+// recallHistory replaces the value with the previous (up) or next history
+// entry. It returns false if there is no history or no entry in that
+// direction.
+func (t *TextInput) recallHistory(up bool) bool {
+	if t.History == nil {
+		return false
+	}
+	var entry string
+	var ok bool
+	if up {
+		entry, ok = t.History.Prev(t.Value())
+	} else {
+		entry, ok = t.History.Next()
+	}
+	if !ok {
+		return false
+	}
+	t.SetValue(entry)
+	if t.OnChange != nil {
+		t.OnChange(t.Value())
+	}
+	return true
+}
+
+// recordSubmit adds a submitted value to the history.
+func (t *TextInput) recordSubmit(value string) {
+	if t.History != nil {
+		t.History.Add(value)
+	}
+}
+
 // isWordChar returns true if r is a word character (alphanumeric or underscore)
 func isWordChar(r rune) bool {
 	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_'
@@ -887,9 +1058,45 @@ func (t *TextInput) HandleKey(event KeyEvent) bool {
 	if !t.focused {
 		return false
 	}
+	if t.KeyHandler != nil && t.KeyHandler.HandleInputKey(t, event) {
+		return true
+	}
+	if t.handleCodeKey(event) || t.handleCursorKey(event) {
+		return true
+	}
 
 	displayText := t.DisplayText()
 
+	// Word-wise movement and editing: Ctrl+Left/Right, Alt+b/f, Alt+d
+	switch {
+	case event.Key == KeyArrowLeft && event.Ctrl, event.Alt && event.Rune == 'b':
+		t.CursorPos = wordLeft(displayText, t.CursorPos)
+		t.MarkDirty()
+		return true
+	case event.Key == KeyArrowRight && event.Ctrl, event.Alt && event.Rune == 'f':
+		t.CursorPos = wordRight(displayText, t.CursorPos)
+		t.MarkDirty()
+		return true
+	case event.Alt && event.Rune == 'd':
+		if t.CursorPos < len(displayText) {
+			t.kill(t.deleteWordForward)
+			if t.OnChange != nil {
+				t.OnChange(t.Value())
+			}
+			t.MarkDirty()
+		}
+		return true
+	case event.Key == KeyBackspace && (event.Alt || event.Ctrl):
+		if t.CursorPos > 0 {
+			t.kill(t.deleteWordBackward)
+			if t.OnChange != nil {
+				t.OnChange(t.Value())
+			}
+			t.MarkDirty()
+		}
+		return true
+	}
+
 	switch event.Key {
 	case KeyArrowLeft:
 		if t.CursorPos > 0 {
@@ -911,20 +1118,26 @@ func (t *TextInput) HandleKey(event KeyEvent) bool {
 			if newPos != t.CursorPos {
 				t.CursorPos = newPos
 				t.MarkDirty()
+				return true
 			}
+		}
+		if t.recallHistory(true) {
 			return true
 		}
-		return false // Let app handle if not multiline
+		return t.MultilineMode || t.History != nil // Let app handle if not multiline
 	case KeyArrowDown:
 		if t.MultilineMode {
 			newPos := t.cursorDown(displayText)
 			if newPos != t.CursorPos {
 				t.CursorPos = newPos
 				t.MarkDirty()
+				return true
 			}
+		}
+		if t.recallHistory(false) {
 			return true
 		}
-		return false // Let app handle if not multiline
+		return t.MultilineMode || t.History != nil // Let app handle if not multiline
 	case KeyBackspace:
 		if t.deleteBackward() {
 			if t.OnChange != nil {
@@ -979,11 +1192,13 @@ func (t *TextInput) HandleKey(event KeyEvent) bool {
 					// Delete from cursor to just after the newline
 					runesToDelete = utf8.RuneCountInString(textBeforeCursor[lastNewline+1:])
 				}
-				for i := 0; i < runesToDelete && t.CursorPos > 0; i++ {
-					t.deleteBackward()
-				}
+				t.kill(func() {
+					for i := 0; i < runesToDelete && t.CursorPos > 0; i++ {
+						t.deleteBackward()
+					}
+				})
 			} else {
-				t.deleteToBeginning()
+				t.kill(t.deleteToBeginning)
 			}
 			if t.OnChange != nil {
 				t.OnChange(t.Value())
@@ -996,19 +1211,21 @@ func (t *TextInput) HandleKey(event KeyEvent) bool {
 		if t.CursorPos < t.displayLen() {
 			if t.MultilineMode {
 				// Delete forward until we hit a newline or end of text
-				for t.CursorPos < t.displayLen() {
-					dt := t.DisplayText()
-					if t.CursorPos < len(dt) {
-						// Check if char at cursor is newline
-						r, _ := utf8.DecodeRuneInString(dt[t.CursorPos:])
-						if r == '\n' {
-							break
+				t.kill(func() {
+					for t.CursorPos < t.displayLen() {
+						dt := t.DisplayText()
+						if t.CursorPos < len(dt) {
+							// Check if char at cursor is newline
+							r, _ := utf8.DecodeRuneInString(dt[t.CursorPos:])
+							if r == '\n' {
+								break
+							}
 						}
+						t.deleteForward()
 					}
-					t.deleteForward()
-				}
+				})
 			} else {
-				t.deleteToEnd()
+				t.kill(t.deleteToEnd)
 			}
 			if t.OnChange != nil {
 				t.OnChange(t.Value())
@@ -1019,7 +1236,7 @@ func (t *TextInput) HandleKey(event KeyEvent) bool {
 	case KeyCtrlW:
 		// Delete word backward
 		if t.CursorPos > 0 {
-			t.deleteWordBackward()
+			t.kill(t.deleteWordBackward)
 			if t.OnChange != nil {
 				t.OnChange(t.Value())
 			}
@@ -1027,9 +1244,9 @@ func (t *TextInput) HandleKey(event KeyEvent) bool {
 		}
 		return true
 	case KeyEnter:
-		if event.Shift && t.MultilineMode {
-			// Shift+Enter in multiline mode: insert newline
-			t.insertNewline()
+		if event.IsModifiedEnter() && t.MultilineMode {
+			// Shift/Alt/Ctrl+Enter in multiline mode: insert newline
+			t.insertLineBreak()
 			if t.OnChange != nil {
 				t.OnChange(t.Value())
 			}
@@ -1037,9 +1254,20 @@ func (t *TextInput) HandleKey(event KeyEvent) bool {
 			return true
 		}
 		if t.SubmitOnEnter && t.OnSubmit != nil {
+			t.recordSubmit(t.Value())
 			t.OnSubmit(t.Value())
 		}
 		return true
+	case KeyCtrlY:
+		// Yank the last killed text
+		if t.killBuffer != "" {
+			t.insertAtCursor(t.killBuffer)
+			if t.OnChange != nil {
+				t.OnChange(t.Value())
+			}
+			t.MarkDirty()
+		}
+		return true
 	}
 
 	if event.Rune != 0 && event.Rune >= 32 { // Printable characters
@@ -1084,6 +1312,26 @@ func (t *TextInput) HandleMouse(event MouseEvent) bool {
 // HandlePaste handles pasted content, using placeholder mode if enabled for multi-line pastes.
 // Returns true if the paste was handled.
 func (t *TextInput) HandlePaste(content string) bool {
+	if t.cursors != nil {
+		t.pasteAtCursors(content)
+		return true
+	}
+
+	// A single-line input can't show line breaks, and a typed newline would
+	// submit, so the lines are joined with spaces. Placeholder mode keeps
+	// them, since the placeholder stands in for the text.
+	if !t.MultilineMode && !t.PastePlaceholderMode {
+		content = strings.ReplaceAll(strings.TrimRight(content, "\n"), "\n", " ")
+	}
+	if t.MaxLength > 0 {
+		room := t.MaxLength - utf8.RuneCountInString(t.Value())
+		if room <= 0 {
+			return false
+		}
+		if utf8.RuneCountInString(content) > room {
+			content = string([]rune(content)[:room])
+		}
+	}
 	if content == "" {
 		return false
 	}
@@ -1158,5 +1406,46 @@ func (t *TextInput) HandlePaste(content string) bool {
 func (t *TextInput) Clear() {
 	t.segments = []inputSegment{}
 	t.CursorPos = 0
+	t.cursors, t.column = nil, nil
+	t.MarkDirty()
+}
+
+// SetSelection highlights the display text from byte offset start up to
+// end with SelectionStyle. The input doesn't edit the selection itself; it
+// is for key handlers such as ViMode.
+func (t *TextInput) SetSelection(start, end int) {
+	if start > end {
+		start, end = end, start
+	}
+	t.selection = [2]int{start, end}
+	t.MarkDirty()
+}
+
+// ClearSelection removes the selection highlight.
+func (t *TextInput) ClearSelection() {
+	t.selection = [2]int{}
 	t.MarkDirty()
 }
+
+// expandPastes replaces paste placeholders with the text they stand for,
+// so the display text is the value, keeping the cursor on the same text.
+func (t *TextInput) expandPastes() {
+	pos, offset := 0, 0
+	expanded := false
+	for _, seg := range t.segments {
+		switch {
+		case t.CursorPos >= offset+len(seg.display):
+			pos += len(seg.actual)
+		case t.CursorPos > offset && seg.isPaste:
+			pos += len(seg.actual)
+		case t.CursorPos > offset:
+			pos += t.CursorPos - offset
+		}
+		offset += len(seg.display)
+		expanded = expanded || seg.isPaste
+	}
+	if expanded {
+		t.SetValue(t.Value())
+		t.CursorPos = pos
+	}
+}
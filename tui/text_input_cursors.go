@@ -0,0 +1,383 @@
+package tui
+
+import (
+	"image"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// inputCursor is one of a TextInput's cursors: a byte offset into the
+// display text, and the other end of its selection, equal to pos when
+// nothing is selected.
+type inputCursor struct {
+	pos, anchor int
+}
+
+// span returns the cursor's selected range.
+func (c inputCursor) span() (start, end int) {
+	return min(c.pos, c.anchor), max(c.pos, c.anchor)
+}
+
+// columnSelection is a rectangle of text being selected with Shift+Alt and
+// the arrow keys. X is a column in cells and Y a line, counted by newlines.
+type columnSelection struct {
+	anchor, head image.Point
+}
+
+// AddCursor adds a cursor at byte offset pos of the display text, to edit
+// in several places at once. While there is more than one cursor, typing,
+// Backspace, Delete, Shift+Enter, pasting, and Left, Right, Home, and End
+// act at every cursor, and Escape goes back to the single cursor at
+// CursorPos. Other keys also go back to one cursor before they are handled.
+//
+// In multiline mode the user adds cursors with Ctrl+Alt+Up and Down, and
+// selects a column of text, with a cursor on each line, with Shift+Alt and
+// the arrow keys.
+func (t *TextInput) AddCursor(pos int) {
+	pos = max(0, min(pos, t.displayLen()))
+	if t.cursors == nil {
+		t.cursors = []inputCursor{{pos: t.CursorPos, anchor: t.CursorPos}}
+	}
+	t.cursors = append(t.cursors, inputCursor{pos: pos, anchor: pos})
+	t.normalizeCursors()
+	t.MarkDirty()
+}
+
+// Cursors returns the positions of the cursors in the display text, in
+// order. With a single cursor it returns CursorPos.
+func (t *TextInput) Cursors() []int {
+	if t.cursors == nil {
+		return []int{t.CursorPos}
+	}
+	positions := make([]int, len(t.cursors))
+	for i, c := range t.cursors {
+		positions[i] = c.pos
+	}
+	return positions
+}
+
+// ClearCursors removes every cursor but the one at CursorPos, and the
+// column selection.
+func (t *TextInput) ClearCursors() {
+	t.cursors = nil
+	t.column = nil
+	t.MarkDirty()
+}
+
+// SelectColumn selects the rectangle of text from fromCol on line fromLine
+// to toCol on line toLine, with a cursor at toCol on each line. Lines are
+// counted from 0 by newlines and columns in cells; lines shorter than a
+// column are selected to their end. Typing then replaces the selected text
+// on every line.
+func (t *TextInput) SelectColumn(fromLine, fromCol, toLine, toCol int) {
+	t.column = &columnSelection{
+		anchor: image.Pt(max(0, fromCol), max(0, fromLine)),
+		head:   image.Pt(max(0, toCol), max(0, toLine)),
+	}
+	t.selectColumn()
+}
+
+// selectColumn puts a cursor on each line of the column selection.
+func (t *TextInput) selectColumn() {
+	text := t.DisplayText()
+	starts := lineStarts(text)
+	sel := t.column
+	sel.anchor.Y = min(sel.anchor.Y, len(starts)-1)
+	sel.head.Y = min(sel.head.Y, len(starts)-1)
+
+	top, bottom := min(sel.anchor.Y, sel.head.Y), max(sel.anchor.Y, sel.head.Y)
+	t.cursors = t.cursors[:0]
+	for line := top; line <= bottom; line++ {
+		c := inputCursor{
+			pos:    posAtColumn(text, starts, line, sel.head.X),
+			anchor: posAtColumn(text, starts, line, sel.anchor.X),
+		}
+		if line == sel.head.Y {
+			t.CursorPos = c.pos
+		}
+		t.cursors = append(t.cursors, c)
+	}
+	t.normalizeCursors()
+	t.MarkDirty()
+}
+
+// normalizeCursors sorts the cursors and merges any at the same position,
+// keeping CursorPos on the main cursor. It goes back to a single cursor
+// when only one is left and it has nothing selected.
+func (t *TextInput) normalizeCursors() {
+	sort.SliceStable(t.cursors, func(i, j int) bool {
+		return t.cursors[i].pos < t.cursors[j].pos
+	})
+	merged := t.cursors[:0]
+	for _, c := range t.cursors {
+		if n := len(merged); n > 0 && merged[n-1].pos == c.pos {
+			continue
+		}
+		merged = append(merged, c)
+	}
+	t.cursors = merged
+	if len(t.cursors) == 1 && t.cursors[0].pos == t.cursors[0].anchor {
+		t.CursorPos = t.cursors[0].pos
+		t.cursors = nil
+	}
+}
+
+// selected reports whether byte offset pos of the display text is
+// highlighted, by SetSelection or a cursor's selection.
+func (t *TextInput) selected(pos int) bool {
+	if pos >= t.selection[0] && pos < t.selection[1] {
+		return true
+	}
+	for _, c := range t.cursors {
+		if start, end := c.span(); pos >= start && pos < end {
+			return true
+		}
+	}
+	return false
+}
+
+// anySelected reports whether any cursor has text selected.
+func (t *TextInput) anySelected() bool {
+	for _, c := range t.cursors {
+		if c.pos != c.anchor {
+			return true
+		}
+	}
+	return false
+}
+
+// editCursors replaces each cursor's selection with edit, which edits at
+// CursorPos and is told whether text was selected there. The cursors are
+// edited from last to first, so the edits don't move the cursors still to
+// come, and those already edited are shifted by each change in length.
+func (t *TextInput) editCursors(edit func(i int, selected bool)) {
+	main := len(t.cursors) - 1
+	for i, c := range t.cursors {
+		if c.pos == t.CursorPos {
+			main = i
+		}
+	}
+	for i := len(t.cursors) - 1; i >= 0; i-- {
+		before := t.displayLen()
+		start, end := t.cursors[i].span()
+		t.CursorPos = end
+		for t.CursorPos > start && t.deleteBackward() {
+		}
+		edit(i, end > start)
+
+		t.cursors[i] = inputCursor{pos: t.CursorPos, anchor: t.CursorPos}
+		delta := t.displayLen() - before
+		for j := i + 1; j < len(t.cursors); j++ {
+			t.cursors[j].pos += delta
+			t.cursors[j].anchor += delta
+		}
+	}
+	t.CursorPos = t.cursors[main].pos
+	t.normalizeCursors()
+	if t.OnChange != nil {
+		t.OnChange(t.Value())
+	}
+	t.MarkDirty()
+}
+
+// pasteAtCursors pastes content at every cursor. If it has a line for each
+// cursor, as when a column is copied, each cursor gets one line.
+func (t *TextInput) pasteAtCursors(content string) {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	t.editCursors(func(i int, _ bool) {
+		if len(lines) == len(t.cursors) {
+			t.insertAtCursor(lines[i])
+		} else {
+			t.insertAtCursor(content)
+		}
+	})
+}
+
+// moveCursors moves every cursor to move's result and clears their
+// selections.
+func (t *TextInput) moveCursors(move func(c inputCursor) int) {
+	for i, c := range t.cursors {
+		pos := move(c)
+		if c.pos == t.CursorPos {
+			t.CursorPos = pos
+		}
+		t.cursors[i] = inputCursor{pos: pos, anchor: pos}
+	}
+	t.normalizeCursors()
+	t.MarkDirty()
+}
+
+// handleCursorKey handles the keys for adding cursors and selecting
+// columns, and the editing keys while there are several cursors. It
+// returns false for other keys, leaving a single cursor.
+func (t *TextInput) handleCursorKey(event KeyEvent) bool {
+	if !t.MultilineMode {
+		return false
+	}
+	arrow := event.Key == KeyArrowUp || event.Key == KeyArrowDown ||
+		event.Key == KeyArrowLeft || event.Key == KeyArrowRight
+	switch {
+	case arrow && event.Shift && event.Alt:
+		t.extendColumn(event.Key)
+		return true
+	case (event.Key == KeyArrowUp || event.Key == KeyArrowDown) && event.Ctrl && event.Alt:
+		t.column = nil
+		t.addCursorOnLine(event.Key == KeyArrowDown)
+		return true
+	}
+	t.column = nil
+	if t.cursors == nil {
+		return false
+	}
+
+	text := t.DisplayText()
+	switch {
+	case event.Key == KeyEscape:
+		t.ClearCursors()
+	case event.Key == KeyBackspace && !event.Ctrl && !event.Alt:
+		// With text selected, only the selections are deleted, so lines
+		// too short to reach a column selection are left alone
+		anySelected := t.anySelected()
+		t.editCursors(func(int, bool) {
+			if !anySelected {
+				t.deleteBackward()
+			}
+		})
+	case event.Key == KeyDelete:
+		anySelected := t.anySelected()
+		t.editCursors(func(int, bool) {
+			if !anySelected {
+				t.deleteForward()
+			}
+		})
+	case event.Key == KeyEnter && event.IsModifiedEnter():
+		t.editCursors(func(int, bool) { t.insertLineBreak() })
+	case event.Key == KeyArrowLeft && !event.Ctrl:
+		t.moveCursors(func(c inputCursor) int {
+			if start, end := c.span(); start < end {
+				return start
+			}
+			_, w := utf8.DecodeLastRuneInString(text[:c.pos])
+			return c.pos - w
+		})
+	case event.Key == KeyArrowRight && !event.Ctrl:
+		t.moveCursors(func(c inputCursor) int {
+			if start, end := c.span(); start < end {
+				return end
+			}
+			_, w := utf8.DecodeRuneInString(text[c.pos:])
+			return c.pos + w
+		})
+	case event.Key == KeyHome, event.Key == KeyCtrlA:
+		starts := lineStarts(text)
+		t.moveCursors(func(c inputCursor) int {
+			return starts[lineOf(starts, c.pos)]
+		})
+	case event.Key == KeyEnd, event.Key == KeyCtrlE:
+		starts := lineStarts(text)
+		t.moveCursors(func(c inputCursor) int {
+			return lineEnd(text, starts, lineOf(starts, c.pos))
+		})
+	case event.Rune >= 32 && !event.Ctrl && !event.Alt:
+		if t.MaxLength > 0 && utf8.RuneCountInString(t.Value())+len(t.cursors) > t.MaxLength {
+			return true // Consumed but ignored
+		}
+		t.editCursors(func(int, bool) { t.insertAtCursor(string(event.Rune)) })
+	default:
+		t.ClearCursors()
+		return false
+	}
+	return true
+}
+
+// addCursorOnLine adds a cursor on the line below the last cursor, or above
+// the first, in the same column.
+func (t *TextInput) addCursorOnLine(down bool) {
+	text := t.DisplayText()
+	starts := lineStarts(text)
+	positions := t.Cursors()
+	from := positions[0]
+	if down {
+		from = positions[len(positions)-1]
+	}
+	line := lineOf(starts, from)
+	col := runewidth.StringWidth(text[starts[line]:from])
+	if down {
+		line++
+	} else {
+		line--
+	}
+	if line < 0 || line >= len(starts) {
+		return
+	}
+	t.AddCursor(posAtColumn(text, starts, line, col))
+}
+
+// extendColumn moves the corner of the column selection in the direction
+// of an arrow key, starting a selection at the cursor if there isn't one.
+func (t *TextInput) extendColumn(key Key) {
+	text := t.DisplayText()
+	starts := lineStarts(text)
+	if t.column == nil {
+		line := lineOf(starts, t.CursorPos)
+		at := image.Pt(runewidth.StringWidth(text[starts[line]:t.CursorPos]), line)
+		t.column = &columnSelection{anchor: at, head: at}
+	}
+	head := &t.column.head
+	switch key {
+	case KeyArrowUp:
+		head.Y = max(0, head.Y-1)
+	case KeyArrowDown:
+		head.Y = min(len(starts)-1, head.Y+1)
+	case KeyArrowLeft:
+		head.X = max(0, head.X-1)
+	case KeyArrowRight:
+		widest := 0
+		for line := range starts {
+			widest = max(widest, runewidth.StringWidth(text[starts[line]:lineEnd(text, starts, line)]))
+		}
+		head.X = min(widest, head.X+1)
+	}
+	t.selectColumn()
+}
+
+// lineStarts returns the byte offset of the start of each line of text.
+func lineStarts(text string) []int {
+	starts := []int{0}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// lineOf returns the line that byte offset pos is on.
+func lineOf(starts []int, pos int) int {
+	return sort.SearchInts(starts, pos+1) - 1
+}
+
+// lineEnd returns the byte offset of the end of line, before its newline.
+func lineEnd(text string, starts []int, line int) int {
+	if line+1 < len(starts) {
+		return starts[line+1] - 1
+	}
+	return len(text)
+}
+
+// posAtColumn returns the byte offset of column col, in cells, on line, or
+// the end of the line if it is shorter.
+func posAtColumn(text string, starts []int, line, col int) int {
+	start, end := starts[line], lineEnd(text, starts, line)
+	x := 0
+	for i, r := range text[start:end] {
+		if x >= col {
+			return start + i
+		}
+		x += runewidth.RuneWidth(r)
+	}
+	return end
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func newMultilineInput(value string) *TextInput {
+	input := NewTextInput().WithMultilineMode(true)
+	input.SetFocused(true)
+	input.SetValue(value)
+	return input
+}
+
+func TestTextInput_AddCursorsOnLines(t *testing.T) {
+	input := newMultilineInput("a = 1\nbb = 2\nc = 3")
+	input.CursorPos = 1
+
+	input.HandleKey(KeyEvent{Key: KeyArrowDown, Ctrl: true, Alt: true})
+	input.HandleKey(KeyEvent{Key: KeyArrowDown, Ctrl: true, Alt: true})
+	assert.Equal(t, []int{1, 7, 14}, input.Cursors())
+
+	input.HandleKey(KeyEvent{Rune: '_'})
+	assert.Equal(t, "a_ = 1\nb_b = 2\nc_ = 3", input.Value())
+	input.HandleKey(KeyEvent{Key: KeyBackspace})
+	input.HandleKey(KeyEvent{Key: KeyEnd})
+	input.HandleKey(KeyEvent{Rune: ';'})
+	assert.Equal(t, "a = 1;\nbb = 2;\nc = 3;", input.Value())
+	assert.Equal(t, 6, input.CursorPos)
+
+	input.HandleKey(KeyEvent{Key: KeyEscape})
+	assert.Equal(t, []int{6}, input.Cursors())
+	input.HandleKey(KeyEvent{Rune: '!'})
+	assert.Equal(t, "a = 1;!\nbb = 2;\nc = 3;", input.Value())
+}
+
+func TestTextInput_ColumnSelection(t *testing.T) {
+	input := newMultilineInput("x: 10\ny: 20\nz: 30")
+	input.CursorPos = 3
+
+	for _, key := range []Key{KeyArrowDown, KeyArrowDown, KeyArrowRight, KeyArrowRight} {
+		input.HandleKey(KeyEvent{Key: key, Shift: true, Alt: true})
+	}
+	assert.Equal(t, []int{5, 11, 17}, input.Cursors())
+	assert.True(t, input.selected(3))
+	assert.False(t, input.selected(2))
+
+	input.HandleKey(KeyEvent{Rune: '0'})
+	assert.Equal(t, "x: 0\ny: 0\nz: 0", input.Value())
+
+	// A pasted line for each cursor goes one to each line
+	input.HandlePaste("1\n2\n3\n")
+	assert.Equal(t, "x: 01\ny: 02\nz: 03", input.Value())
+}
+
+func TestTextInput_SelectColumnClampsShortLines(t *testing.T) {
+	input := newMultilineInput("long line\nab\nlonger line")
+	input.SelectColumn(0, 4, 2, 9)
+	input.HandleKey(KeyEvent{Key: KeyDelete})
+	assert.Equal(t, "long\nab\nlongne", input.Value())
+	assert.Equal(t, []int{4, 7, 12}, input.Cursors())
+
+	// Other keys go back to the cursor on the line the selection was
+	// extended to, and are handled as usual
+	input.HandleKey(KeyEvent{Key: KeyArrowUp})
+	assert.Equal(t, []int{7}, input.Cursors())
+}
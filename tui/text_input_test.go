@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestTextInput_WordMotion(t *testing.T) {
+	input := NewTextInput()
+	input.SetFocused(true)
+	input.SetValue("git commit --amend")
+
+	input.HandleKey(KeyEvent{Key: KeyArrowLeft, Ctrl: true})
+	assert.Equal(t, 13, input.CursorPos)
+	input.HandleKey(KeyEvent{Rune: 'b', Alt: true})
+	assert.Equal(t, 4, input.CursorPos)
+	input.HandleKey(KeyEvent{Rune: 'f', Alt: true})
+	assert.Equal(t, 10, input.CursorPos)
+	input.HandleKey(KeyEvent{Key: KeyArrowRight, Ctrl: true})
+	assert.Equal(t, 18, input.CursorPos)
+	assert.Equal(t, "git commit --amend", input.Value())
+}
+
+func TestTextInput_KillYank(t *testing.T) {
+	var changes int
+	input := NewTextInput()
+	input.SetFocused(true)
+	input.OnChange = func(string) { changes++ }
+	input.SetValue("hello brave world")
+
+	input.HandleKey(KeyEvent{Key: KeyBackspace, Alt: true})
+	assert.Equal(t, "hello brave ", input.Value())
+	input.HandleKey(KeyEvent{Key: KeyHome})
+	input.HandleKey(KeyEvent{Key: KeyCtrlY})
+	assert.Equal(t, "worldhello brave ", input.Value())
+
+	input.HandleKey(KeyEvent{Rune: 'd', Alt: true})
+	assert.Equal(t, "world brave ", input.Value())
+	input.HandleKey(KeyEvent{Key: KeyCtrlK})
+	assert.Equal(t, "world", input.Value())
+	input.HandleKey(KeyEvent{Key: KeyEnd})
+	input.HandleKey(KeyEvent{Key: KeyCtrlY})
+	assert.Equal(t, "world brave ", input.Value())
+	assert.Equal(t, 5, changes)
+}
+
+func TestTextInput_History(t *testing.T) {
+	var submitted []string
+	input := NewTextInput().WithSubmitOnEnter(true).WithHistory(NewInputHistory(0))
+	input.SetFocused(true)
+	input.OnSubmit = func(value string) {
+		submitted = append(submitted, value)
+		input.Clear()
+	}
+
+	input.SetValue("first")
+	input.HandleKey(KeyEvent{Key: KeyEnter})
+	input.SetValue("second")
+	input.HandleKey(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, []string{"first", "second"}, submitted)
+
+	input.SetValue("dra")
+	assert.True(t, input.HandleKey(KeyEvent{Key: KeyArrowUp}))
+	assert.Equal(t, "second", input.Value())
+	input.HandleKey(KeyEvent{Key: KeyArrowUp})
+	assert.Equal(t, "first", input.Value())
+	input.HandleKey(KeyEvent{Key: KeyArrowDown})
+	input.HandleKey(KeyEvent{Key: KeyArrowDown})
+	assert.Equal(t, "dra", input.Value())
+
+	// Without history, Up and Down are left to the application
+	plain := NewTextInput()
+	plain.SetFocused(true)
+	assert.False(t, plain.HandleKey(KeyEvent{Key: KeyArrowUp}))
+}
+
+func TestTextInput_Paste(t *testing.T) {
+	var changes int
+	input := NewTextInput()
+	input.OnChange = func(string) { changes++ }
+	input.SetValue("ab")
+	input.CursorPos = 1
+
+	// A paste is inserted at the cursor in one change
+	assert.True(t, input.HandlePaste("one\ntwo\n"))
+	assert.Equal(t, "aone twob", input.Value())
+	assert.Equal(t, 8, input.CursorPos)
+	assert.Equal(t, 1, changes)
+
+	multiline := NewTextInput().WithMultilineMode(true)
+	multiline.HandlePaste("one\ntwo")
+	assert.Equal(t, "one\ntwo", multiline.Value())
+
+	limited := NewTextInput().WithMaxLength(5)
+	limited.SetValue("ab")
+	assert.True(t, limited.HandlePaste("cdefg"))
+	assert.Equal(t, "abcde", limited.Value())
+	assert.False(t, limited.HandlePaste("h"))
+}
@@ -11,7 +11,13 @@ type textView struct {
 	wrap       bool
 	align      Alignment
 	fillBg     bool
+	markup     bool
+	selectable bool
 	flexFactor int
+	fgRole     Role // theme colors applied over style when drawn
+	bgRole     Role
+	fgAdaptive *AdaptiveColor // colors for the terminal background, or nil
+	bgAdaptive *AdaptiveColor
 }
 
 // Text creates a text view with optional Printf-style formatting.
@@ -110,6 +116,20 @@ func (t *textView) Style(s Style) *textView {
 	return t
 }
 
+// Role colors the text with the current theme's color for role, such as
+// RolePrimary or RoleError, so it follows theme switches.
+func (t *textView) Role(role Role) *textView {
+	t.fgRole = role
+	return t
+}
+
+// BgRole sets the background to the current theme's color for role, such
+// as RoleSurface.
+func (t *textView) BgRole(role Role) *textView {
+	t.bgRole = role
+	return t
+}
+
 // Semantic styling methods for common text patterns
 
 // Success returns text styled for success messages (green, bold).
@@ -180,12 +200,33 @@ func (t *textView) Right() *textView {
 	return t
 }
 
+// Markup enables inline style tags in the text, such as
+// "[red]error[/] occurred" or "[bold fg=#ffaa00]warning[/]". Tags are
+// ParseStyle descriptions layered over the text's own style; see
+// ParseMarkup for the syntax. Escape untrusted values with EscapeMarkup.
+//
+// Example:
+//
+//	Text("[green]✓[/] %s [dim](%s)[/]", tui.EscapeMarkup(name), elapsed).Markup()
+func (t *textView) Markup() *textView {
+	t.markup = true
+	return t
+}
+
 // FillBg fills the entire background with the background color.
 func (t *textView) FillBg() *textView {
 	t.fillBg = true
 	return t
 }
 
+// Selectable lets the user select the text by dragging the mouse over it.
+// The application receives a SelectionEvent with the selected text when the
+// drag ends. Requires mouse tracking (WithMouseTracking).
+func (t *textView) Selectable() *textView {
+	t.selectable = true
+	return t
+}
+
 // Flex sets the flex factor for this view in flex layouts.
 // A higher value means this view gets more of the available space.
 // Set to 0 to make the view non-flexible (fixed size).
@@ -215,29 +256,47 @@ func (t *textView) flex() int {
 //	Text("Bright pulse").Animate(Pulse(green, 10).Brightness(0.5, 1.0))
 func (t *textView) Animate(animation TextAnimation) *animatedTextView {
 	return &animatedTextView{
-		text:      t.content,
+		text:      t.plainContent(),
 		animation: animation,
 		style:     t.style,
 	}
 }
 
 func (t *textView) render(ctx *RenderContext) {
+	if t.selectable {
+		width, height := ctx.Size()
+		renderSelectable(ctx, ctx.RenderFrame(), width, height, 0, func(frame RenderFrame) {
+			t.draw(ctx.WithFrame(frame))
+		})
+		return
+	}
+	t.draw(ctx)
+}
+
+func (t *textView) draw(ctx *RenderContext) {
 	width, height := ctx.Size()
 	if width == 0 || height == 0 {
 		return
 	}
+	style := ctx.Theme().Apply(ctx.InheritStyle(t.style), t.fgRole, t.bgRole)
+	style = adaptStyle(ctx, style, t.fgAdaptive, t.bgAdaptive)
 
 	// Fill background if requested
 	if t.fillBg {
 		for y := 0; y < height; y++ {
 			for x := 0; x < width; x++ {
-				ctx.SetCell(x, y, ' ', t.style)
+				ctx.SetCell(x, y, ' ', style)
 			}
 		}
 	}
 
 	// Process text
 	displayText := t.content
+	var styled styledRunes
+	if t.markup {
+		styled = newStyledRunes(ParseMarkup(t.content, style))
+		displayText = string(styled.runes)
+	}
 	if t.wrap && width > 0 {
 		displayText = WrapText(displayText, width)
 	}
@@ -248,21 +307,40 @@ func (t *textView) render(ctx *RenderContext) {
 	}
 
 	// Render
-	if t.wrap {
+	if t.markup {
+		runes, styles := styled.restyle(displayText, style)
+		y, start := 0, 0
+		for i := 0; i <= len(runes) && y < height; i++ {
+			if i == len(runes) || runes[i] == '\n' {
+				printStyledRunes(ctx, y, runes[start:i], styles[start:i])
+				y++
+				start = i + 1
+			}
+		}
+	} else if t.wrap {
 		lines := splitLinesSimple(displayText)
 		for y, line := range lines {
 			if y >= height {
 				break
 			}
-			ctx.PrintStyled(0, y, line, t.style)
+			ctx.PrintStyled(0, y, line, style)
 		}
 	} else {
-		ctx.PrintTruncated(0, 0, displayText, t.style)
+		ctx.PrintTruncated(0, 0, displayText, style)
+	}
+}
+
+// plainContent returns the displayed text, without markup tags.
+func (t *textView) plainContent() string {
+	if t.markup {
+		return StripMarkup(t.content)
 	}
+	return t.content
 }
 
 func (t *textView) size(maxWidth, maxHeight int) (int, int) {
-	w, h := MeasureText(t.content)
+	content := t.plainContent()
+	w, h := MeasureText(content)
 
 	// For wrapped text, expand to fill available width
 	if t.wrap && maxWidth > 0 && w > maxWidth {
@@ -271,7 +349,7 @@ func (t *textView) size(maxWidth, maxHeight int) (int, int) {
 
 	// Calculate height based on wrapped lines
 	if t.wrap && maxWidth > 0 {
-		wrapped := WrapText(t.content, maxWidth)
+		wrapped := WrapText(content, maxWidth)
 		lines := splitLinesSimple(wrapped)
 		h = len(lines)
 	}
@@ -0,0 +1,293 @@
+package tui
+
+import "time"
+
+// Role is the part a color plays in an interface, such as the primary color
+// or the color of errors. Views given a role take their color from the
+// current Theme when they are drawn, so switching themes recolors them.
+type Role int
+
+const (
+	RoleNone       Role = iota // No role; the view's own colors apply
+	RolePrimary                // Main brand or action color
+	RoleAccent                 // Secondary highlight color
+	RoleText                   // Body text
+	RoleMuted                  // Secondary text, hints, and disabled items
+	RoleSurface                // Background of panels, cards, and popups
+	RoleBackground             // Background of the whole screen
+	RoleBorder                 // Borders and separators
+	RoleSuccess                // Success messages
+	RoleWarning                // Warnings
+	RoleError                  // Errors
+	RoleInfo                   // Informational messages
+)
+
+// String returns the role's name.
+func (r Role) String() string {
+	switch r {
+	case RolePrimary:
+		return "primary"
+	case RoleAccent:
+		return "accent"
+	case RoleText:
+		return "text"
+	case RoleMuted:
+		return "muted"
+	case RoleSurface:
+		return "surface"
+	case RoleBackground:
+		return "background"
+	case RoleBorder:
+		return "border"
+	case RoleSuccess:
+		return "success"
+	case RoleWarning:
+		return "warning"
+	case RoleError:
+		return "error"
+	case RoleInfo:
+		return "info"
+	}
+	return "none"
+}
+
+// Theme is a set of colors for semantic roles. Views given a Role, such as
+// with Text(...).Role(RolePrimary), look their color up in the theme being
+// rendered with, which the Runtime sets for the whole view tree (see
+// WithTheme and SwitchTheme) and Themed sets for part of it.
+//
+// Custom views can read the theme with RenderContext.Theme.
+type Theme struct {
+	Name       string
+	Primary    RGB
+	Accent     RGB
+	Text       RGB
+	Muted      RGB
+	Surface    RGB
+	Background RGB
+	Border     RGB
+	Success    RGB
+	Warning    RGB
+	Error      RGB
+	Info       RGB
+
+	// FocusIndicators marks focus with shapes as well as color, so it shows
+	// on monochrome terminals and to users who can't tell the colors apart:
+	// the borders of focused views turn heavy, with a "▶" before their
+	// title, and focused buttons and fields whose focus style only changes
+	// colors are drawn reversed. HighContrastTheme sets it.
+	FocusIndicators bool
+}
+
+// Built-in themes. When none is set, DarkTheme is used, or LightTheme if
+// the terminal's background is light.
+var (
+	DarkTheme = Theme{
+		Name:       "dark",
+		Primary:    NewRGB(97, 175, 239),
+		Accent:     NewRGB(198, 120, 221),
+		Text:       NewRGB(220, 223, 228),
+		Muted:      NewRGB(127, 132, 142),
+		Surface:    NewRGB(40, 44, 52),
+		Background: NewRGB(30, 33, 39),
+		Border:     NewRGB(92, 99, 112),
+		Success:    NewRGB(152, 195, 121),
+		Warning:    NewRGB(229, 192, 123),
+		Error:      NewRGB(224, 108, 117),
+		Info:       NewRGB(86, 182, 194),
+	}
+
+	LightTheme = Theme{
+		Name:       "light",
+		Primary:    NewRGB(1, 112, 201),
+		Accent:     NewRGB(166, 38, 164),
+		Text:       NewRGB(56, 58, 66),
+		Muted:      NewRGB(110, 113, 122),
+		Surface:    NewRGB(236, 237, 240),
+		Background: NewRGB(250, 250, 250),
+		Border:     NewRGB(192, 194, 200),
+		Success:    NewRGB(64, 145, 63),
+		Warning:    NewRGB(176, 118, 0),
+		Error:      NewRGB(208, 62, 50),
+		Info:       NewRGB(1, 128, 156),
+	}
+
+	// HighContrastTheme uses the most distinct colors, for low vision and
+	// poor displays.
+	HighContrastTheme = Theme{
+		Name:       "high-contrast",
+		Primary:    NewRGB(255, 255, 0),
+		Accent:     NewRGB(0, 255, 255),
+		Text:       NewRGB(255, 255, 255),
+		Muted:      NewRGB(208, 208, 208),
+		Surface:    NewRGB(0, 0, 0),
+		Background: NewRGB(0, 0, 0),
+		Border:     NewRGB(255, 255, 255),
+		Success:    NewRGB(0, 255, 0),
+		Warning:    NewRGB(255, 176, 0),
+		Error:      NewRGB(255, 96, 96),
+		Info:       NewRGB(96, 208, 255),
+
+		FocusIndicators: true,
+	}
+)
+
+// Color returns the theme's color for role, and false for RoleNone.
+func (t Theme) Color(role Role) (RGB, bool) {
+	switch role {
+	case RolePrimary:
+		return t.Primary, true
+	case RoleAccent:
+		return t.Accent, true
+	case RoleText:
+		return t.Text, true
+	case RoleMuted:
+		return t.Muted, true
+	case RoleSurface:
+		return t.Surface, true
+	case RoleBackground:
+		return t.Background, true
+	case RoleBorder:
+		return t.Border, true
+	case RoleSuccess:
+		return t.Success, true
+	case RoleWarning:
+		return t.Warning, true
+	case RoleError:
+		return t.Error, true
+	case RoleInfo:
+		return t.Info, true
+	}
+	return RGB{}, false
+}
+
+// Style returns a style with the color of role as its foreground.
+func (t Theme) Style(role Role) Style {
+	return t.Apply(NewStyle(), role, RoleNone)
+}
+
+// Apply returns style with its foreground and background set to the colors
+// of the roles fg and bg. RoleNone leaves that color as it is.
+func (t Theme) Apply(style Style, fg, bg Role) Style {
+	if c, ok := t.Color(fg); ok {
+		style = style.WithFgRGB(c)
+	}
+	if c, ok := t.Color(bg); ok {
+		style = style.WithBgRGB(c)
+	}
+	return style
+}
+
+// ThemeKey is the environment key of the theme views are drawn with. Set
+// it for a subtree with Themed, and read it with RenderContext.Theme, which
+// picks a theme for the terminal's background where none is set.
+var ThemeKey = NewEnvKey("theme", DarkTheme)
+
+// Theme returns the theme views in this context are drawn with.
+func (c *RenderContext) Theme() Theme {
+	if theme, ok := lookupEnv(c, ThemeKey); ok {
+		return theme
+	}
+	if !c.DarkBackground() {
+		return LightTheme
+	}
+	return DarkTheme
+}
+
+// WithTheme returns a new context that draws with theme.
+func (c *RenderContext) WithTheme(theme Theme) *RenderContext {
+	return WithEnv(c, ThemeKey, theme)
+}
+
+// Themed draws inner with theme instead of the application's theme, for
+// parts of the interface that keep their look, such as a preview of each
+// theme in a theme picker. It is short for Env(ThemeKey, theme, inner).
+func Themed(theme Theme, inner View) View {
+	return Env(ThemeKey, theme, inner)
+}
+
+// focusMarker is drawn before the title of a focused border when the theme
+// has FocusIndicators.
+const focusMarker = "▶"
+
+// asciiFocusBorder is the heavy form of ASCIIBorder.
+var asciiFocusBorder = BorderStyle{
+	TopLeft: "#", TopRight: "#", BottomLeft: "#", BottomRight: "#",
+	Horizontal: "=", Vertical: "#",
+	Cross: "#", TopJoin: "#", BottomJoin: "#", LeftJoin: "#", RightJoin: "#",
+}
+
+// focusedBorder returns the border a focused view draws: with the theme's
+// FocusIndicators, a heavier one than border.
+func focusedBorder(ctx *RenderContext, border *BorderStyle) *BorderStyle {
+	if border == nil || !ctx.Theme().FocusIndicators {
+		return border
+	}
+	switch *border {
+	case ThickBorder:
+		return &DoubleBorder
+	case ASCIIBorder:
+		return &asciiFocusBorder
+	}
+	return &ThickBorder
+}
+
+// focusedTitle returns the border title a focused view draws: with the
+// theme's FocusIndicators, title after the focus marker, which shows even
+// when title is empty.
+func focusedTitle(ctx *RenderContext, title string) string {
+	if !ctx.Theme().FocusIndicators {
+		return title
+	}
+	if title == "" {
+		return focusMarker
+	}
+	return focusMarker + " " + title
+}
+
+// focusedStyle returns the style a focused view draws with in place of
+// normal: focus, reversed if the theme has FocusIndicators and focus only
+// changes the colors of normal.
+func focusedStyle(ctx *RenderContext, normal, focus Style) Style {
+	if !ctx.Theme().FocusIndicators {
+		return focus
+	}
+	attrs := func(s Style) Style {
+		s.Foreground, s.Background, s.FgRGB, s.BgRGB = ColorDefault, ColorDefault, nil, nil
+		return s
+	}
+	if attrs(normal) == attrs(focus) {
+		focus = focus.WithReverse()
+	}
+	return focus
+}
+
+// ThemeEvent switches the application's theme. It is created by
+// SwitchTheme, and the application sees it after the switch, for example to
+// save the user's choice.
+type ThemeEvent struct {
+	Theme Theme
+	Time  time.Time
+}
+
+// Timestamp implements Event.
+func (e ThemeEvent) Timestamp() time.Time { return e.Time }
+
+// SwitchTheme returns a command that switches the application's theme,
+// recoloring every view that uses roles on the next frame.
+//
+// Example:
+//
+//	case tui.KeyEvent:
+//	    if e.Rune == 't' {
+//	        app.light = !app.light
+//	        if app.light {
+//	            return []tui.Cmd{tui.SwitchTheme(tui.LightTheme)}
+//	        }
+//	        return []tui.Cmd{tui.SwitchTheme(tui.DarkTheme)}
+//	    }
+func SwitchTheme(theme Theme) Cmd {
+	return func() Event {
+		return ThemeEvent{Theme: theme, Time: time.Now()}
+	}
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestTheme_Color(t *testing.T) {
+	c, ok := LightTheme.Color(RoleError)
+	assert.True(t, ok)
+	assert.Equal(t, LightTheme.Error, c)
+	_, ok = LightTheme.Color(RoleNone)
+	assert.False(t, ok)
+
+	style := DarkTheme.Apply(NewStyle().WithBold(), RoleNone, RoleSurface)
+	assert.True(t, style.Bold)
+	assert.Equal(t, &DarkTheme.Surface, style.BgRGB)
+	assert.Equal(t, "primary", RolePrimary.String())
+}
+
+func TestTheme_SwitchAtRuntime(t *testing.T) {
+	var seen []string
+	terminal := NewTestTerminal(10, 2, &bytes.Buffer{})
+	app := &simpleApp{
+		renderFunc: func() View {
+			return Stack(
+				Text("Hi").Role(RolePrimary),
+				Themed(HighContrastTheme, Text("Hi").Role(RolePrimary)),
+			)
+		},
+		handleFunc: func(event Event) []Cmd {
+			if e, ok := event.(ThemeEvent); ok {
+				seen = append(seen, e.Theme.Name)
+			}
+			return nil
+		},
+	}
+	runtime := NewRuntime(terminal, app, 30)
+	runtime.render()
+	assert.Equal(t, &DarkTheme.Primary, terminal.GetCell(0, 0).Style.FgRGB)
+	assert.Equal(t, &HighContrastTheme.Primary, terminal.GetCell(0, 1).Style.FgRGB)
+
+	runtime.processEvent(SwitchTheme(LightTheme)())
+	runtime.render()
+	assert.Equal(t, &LightTheme.Primary, terminal.GetCell(0, 0).Style.FgRGB)
+	assert.Equal(t, &HighContrastTheme.Primary, terminal.GetCell(0, 1).Style.FgRGB)
+	assert.Equal(t, []string{"light"}, seen)
+}
+
+func TestTheme_FocusIndicators(t *testing.T) {
+	buttonRegistry.Clear()
+	defer buttonRegistry.Clear()
+
+	render := func(theme Theme, focus string) *Terminal {
+		terminal := NewTestTerminal(12, 4, &bytes.Buffer{})
+		fm := NewFocusManager()
+		fm.Register(&mockFocusable{id: "name"})
+		view := Themed(theme, Stack(
+			Bordered(Text("xxxxxxxxxx")).Border(&RoundedBorder).Title("Name").FocusID("name"),
+			Button("Go", func() {}).ID("go").Fg(ColorGreen).FocusStyle(NewStyle().WithForeground(ColorYellow)),
+		))
+		// The button registers for focus when it is first drawn
+		for range 2 {
+			frame, err := terminal.BeginFrame()
+			assert.NoError(t, err)
+			view.size(12, 4)
+			view.render(NewRenderContext(frame, 0).WithFocusManager(fm))
+			terminal.EndFrame(frame)
+			fm.SetFocus(focus)
+		}
+		return terminal
+	}
+
+	// Without focus indicators, focus only changes colors
+	terminal := render(DarkTheme, "name")
+	assert.Equal(t, '╭', terminal.GetCell(0, 0).Char)
+	assert.Equal(t, 'N', terminal.GetCell(2, 0).Char)
+	terminal = render(DarkTheme, "go")
+	assert.False(t, terminal.GetCell(0, 3).Style.Reverse)
+
+	// With them, the focused border turns heavy and its title gets a marker
+	terminal = render(HighContrastTheme, "name")
+	assert.Equal(t, '┏', terminal.GetCell(0, 0).Char)
+	assert.Equal(t, '▶', terminal.GetCell(2, 0).Char)
+	assert.Equal(t, 'N', terminal.GetCell(4, 0).Char)
+	// and a focused button whose focus style is only a color is reversed
+	terminal = render(HighContrastTheme, "go")
+	assert.Equal(t, '╭', terminal.GetCell(0, 0).Char)
+	assert.True(t, terminal.GetCell(0, 3).Style.Reverse)
+}
+
+func TestTheme_FocusedStyle(t *testing.T) {
+	ctx := WithEnv(&RenderContext{}, ThemeKey, DarkTheme)
+	plain := NewStyle().WithForeground(ColorGreen)
+	colorOnly := NewStyle().WithForeground(ColorYellow)
+	bold := NewStyle().WithBold()
+
+	assert.Equal(t, colorOnly, focusedStyle(ctx, plain, colorOnly))
+
+	ctx = WithEnv(ctx, ThemeKey, HighContrastTheme)
+	// A focus style that only changes colors is reversed
+	assert.Equal(t, colorOnly.WithReverse(), focusedStyle(ctx, plain, colorOnly))
+	// One that changes attributes already shows without color
+	assert.Equal(t, bold, focusedStyle(ctx, plain, bold))
+}
@@ -0,0 +1,171 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/yuin/goldmark"
+)
+
+// ChatMessage is one message of a chat transcript.
+type ChatMessage struct {
+	Role    string    // Who sent it, like "user" or "assistant"
+	Content string    // Markdown text
+	Time    time.Time // When it was sent; zero to leave it out
+}
+
+// TranscriptFormat is a file format for ExportTranscript.
+type TranscriptFormat int
+
+const (
+	TranscriptMarkdown TranscriptFormat = iota // Markdown, with the messages as written
+	TranscriptHTML                             // A standalone HTML page
+)
+
+// Extension returns the file extension for the format, with its dot.
+func (f TranscriptFormat) Extension() string {
+	if f == TranscriptHTML {
+		return ".html"
+	}
+	return ".md"
+}
+
+// ExportTranscript writes messages as a transcript, each under a header
+// with its role and time. Markdown keeps the messages' text as written, so
+// fenced code blocks stay intact; a block left open is closed so it doesn't
+// swallow the messages after it. HTML renders each message's Markdown, with
+// code blocks as <pre><code class="language-..."> and any raw HTML in the
+// messages escaped.
+//
+// Example:
+//
+//	md := tui.ExportTranscript(app.messages, tui.TranscriptMarkdown)
+func ExportTranscript(messages []ChatMessage, format TranscriptFormat) string {
+	if format == TranscriptHTML {
+		return exportTranscriptHTML(messages)
+	}
+	var sb strings.Builder
+	sb.WriteString("# Transcript\n")
+	for _, m := range messages {
+		fmt.Fprintf(&sb, "\n## %s\n\n", transcriptHeader(m))
+		content := strings.TrimRight(m.Content, "\n")
+		sb.WriteString(content)
+		sb.WriteString("\n")
+		if fence := openFence(content); fence != "" {
+			sb.WriteString(fence + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// transcriptHeader returns a message's role, capitalized, and its time.
+func transcriptHeader(m ChatMessage) string {
+	role := m.Role
+	if role != "" {
+		role = strings.ToUpper(role[:1]) + role[1:]
+	}
+	if m.Time.IsZero() {
+		return role
+	}
+	return role + " · " + m.Time.Format("2006-01-02 15:04")
+}
+
+// openFence returns the fence of a code block content leaves open, or "".
+func openFence(content string) string {
+	fence := ""
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if len(line)-len(trimmed) > 3 {
+			continue // Indented code, not a fence
+		}
+		marker := strings.TrimRight(trimmed, " \t")
+		n := 0
+		for n < len(marker) && (marker[n] == '`' || marker[n] == '~') && marker[n] == marker[0] {
+			n++
+		}
+		switch {
+		case n < 3:
+		case fence == "":
+			fence = marker[:n]
+		case n == len(marker) && n >= len(fence) && marker[0] == fence[0]:
+			// A closing fence is at least as long and has no info string
+			fence = ""
+		}
+	}
+	return fence
+}
+
+// exportTranscriptHTML writes messages as an HTML page.
+func exportTranscriptHTML(messages []ChatMessage) string {
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Transcript</title>\n")
+	sb.WriteString("<style>\n" +
+		"body { font-family: sans-serif; max-width: 50em; margin: 2em auto; line-height: 1.5; }\n" +
+		".message { margin-bottom: 2em; }\n" +
+		".message h2 { font-size: 1em; border-bottom: 1px solid #ccc; }\n" +
+		".message time { color: #777; font-weight: normal; margin-left: 0.5em; }\n" +
+		"pre { background: #f4f4f4; padding: 0.75em; overflow-x: auto; }\n" +
+		"</style>\n</head>\n<body>\n<h1>Transcript</h1>\n")
+	md := goldmark.New()
+	for _, m := range messages {
+		fmt.Fprintf(&sb, "<section class=\"message %s\">\n<h2>%s", html.EscapeString(m.Role),
+			html.EscapeString(transcriptHeader(ChatMessage{Role: m.Role})))
+		if !m.Time.IsZero() {
+			fmt.Fprintf(&sb, "<time datetime=\"%s\">%s</time>", m.Time.Format(time.RFC3339),
+				m.Time.Format("2006-01-02 15:04"))
+		}
+		sb.WriteString("</h2>\n")
+		var body bytes.Buffer
+		if err := md.Convert([]byte(m.Content), &body); err != nil {
+			fmt.Fprintf(&body, "<pre>%s</pre>\n", html.EscapeString(m.Content))
+		}
+		sb.Write(body.Bytes())
+		sb.WriteString("</section>\n")
+	}
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
+
+// TranscriptSavedEvent reports the result of SaveTranscript.
+type TranscriptSavedEvent struct {
+	Path string
+	Err  error
+	Time time.Time
+}
+
+// Timestamp implements Event.
+func (e TranscriptSavedEvent) Timestamp() time.Time { return e.Time }
+
+// SaveTranscript returns a command that exports messages in format and
+// writes them to path, replacing the file if it exists. It delivers a
+// TranscriptSavedEvent, for example to show the path in a toast. An empty
+// path names the file transcript-<date>-<time> in the current directory,
+// with the format's extension.
+//
+// Example:
+//
+//	case tui.KeyEvent:
+//	    if e.Key == tui.KeyCtrlS {
+//	        return []tui.Cmd{tui.SaveTranscript("", app.messages, tui.TranscriptMarkdown)}
+//	    }
+//	case tui.TranscriptSavedEvent:
+//	    if e.Err != nil {
+//	        app.notes.Error("Export failed", e.Err.Error())
+//	    } else {
+//	        app.notes.Info("Transcript saved", e.Path)
+//	    }
+func SaveTranscript(path string, messages []ChatMessage, format TranscriptFormat) Cmd {
+	// Export now, so later changes to messages don't show up in the file
+	content := ExportTranscript(messages, format)
+	return func() Event {
+		if path == "" {
+			path = "transcript-" + time.Now().Format("20060102-150405") + format.Extension()
+		}
+		err := os.WriteFile(path, []byte(content), 0o644)
+		return TranscriptSavedEvent{Path: path, Err: err, Time: time.Now()}
+	}
+}
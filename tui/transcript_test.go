@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+var transcriptMessages = []ChatMessage{
+	{Role: "user", Content: "Sort <this> slice", Time: time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC)},
+	{Role: "assistant", Content: "Use sort.Ints:\n\n```go\nsort.Ints(xs)\n```\n"},
+}
+
+func TestExportTranscript_Markdown(t *testing.T) {
+	md := ExportTranscript(transcriptMessages, TranscriptMarkdown)
+	assert.Equal(t, "# Transcript\n"+
+		"\n## User · 2026-03-04 09:30\n\nSort <this> slice\n"+
+		"\n## Assistant\n\nUse sort.Ints:\n\n```go\nsort.Ints(xs)\n```\n", md)
+
+	// A code block left open is closed before the next message
+	md = ExportTranscript([]ChatMessage{
+		{Role: "assistant", Content: "````sh\nls\n```\n"},
+		{Role: "user", Content: "thanks"},
+	}, TranscriptMarkdown)
+	assert.Contains(t, md, "ls\n```\n````\n\n## User")
+}
+
+func TestExportTranscript_HTML(t *testing.T) {
+	page := ExportTranscript(transcriptMessages, TranscriptHTML)
+	assert.Contains(t, page, `<h2>User<time datetime="2026-03-04T09:30:00Z">2026-03-04 09:30</time></h2>`)
+	assert.Contains(t, page, `<pre><code class="language-go">sort.Ints(xs)`)
+	assert.NotContains(t, page, "<this>")
+}
+
+func TestSaveTranscript(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chat.md")
+	event := SaveTranscript(path, transcriptMessages, TranscriptMarkdown)().(TranscriptSavedEvent)
+	assert.NoError(t, event.Err)
+	assert.Equal(t, path, event.Path)
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, ExportTranscript(transcriptMessages, TranscriptMarkdown), string(data))
+}
@@ -0,0 +1,443 @@
+package tui
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sync"
+)
+
+// TransitionEffect is how a Transition animates from one view to another.
+type TransitionEffect int
+
+const (
+	// TransitionFade fades the old view out and then the new view in.
+	TransitionFade TransitionEffect = iota
+	// TransitionSlide slides the old view out and the new view in after it.
+	TransitionSlide
+	// TransitionExpand grows or shrinks the view's height to the new view's,
+	// like an accordion, showing the new view (or, when it is removed, the
+	// old one) cut off at the changing height.
+	TransitionExpand
+)
+
+// String returns the effect name.
+func (e TransitionEffect) String() string {
+	switch e {
+	case TransitionFade:
+		return "fade"
+	case TransitionSlide:
+		return "slide"
+	case TransitionExpand:
+		return "expand"
+	}
+	return fmt.Sprintf("TransitionEffect(%d)", int(e))
+}
+
+// SlideDirection is the direction views move in a sliding Transition.
+type SlideDirection int
+
+const (
+	SlideLeft  SlideDirection = iota // The new view comes in from the right
+	SlideRight                       // The new view comes in from the left
+	SlideUp                          // The new view comes in from below
+	SlideDown                        // The new view comes in from above
+)
+
+// String returns the direction name.
+func (d SlideDirection) String() string {
+	switch d {
+	case SlideLeft:
+		return "left"
+	case SlideRight:
+		return "right"
+	case SlideUp:
+		return "up"
+	case SlideDown:
+		return "down"
+	}
+	return fmt.Sprintf("SlideDirection(%d)", int(d))
+}
+
+// transitionRegistry keeps each transition's progress, keyed by ID, since
+// views are rebuilt every frame.
+var transitionRegistry = &transitionRegistryImpl{
+	states: make(map[string]*transitionState),
+	active: make(map[string]bool),
+}
+
+type transitionRegistryImpl struct {
+	mu     sync.Mutex
+	states map[string]*transitionState
+	active map[string]bool // tracks which IDs were accessed this frame
+}
+
+type transitionState struct {
+	key     string // key of the view shown
+	present bool   // whether a view is shown
+	view    View   // the view shown, as of the last frame
+	from    View   // the view being transitioned away from, or nil
+	running bool
+	started bool   // whether start has been set by a render
+	start   uint64 // animation frame the transition started on
+	frame   uint64 // animation frame last rendered
+}
+
+// Clear marks all entries as inactive. Called at the start of each frame.
+func (r *transitionRegistryImpl) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.active = make(map[string]bool)
+}
+
+// Prune removes entries that weren't accessed since the last Clear().
+func (r *transitionRegistryImpl) Prune() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id := range r.states {
+		if !r.active[id] {
+			delete(r.states, id)
+		}
+	}
+}
+
+func (r *transitionRegistryImpl) Get(id string) *transitionState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.active[id] = true
+	if state, exists := r.states[id]; exists {
+		return state
+	}
+	state := &transitionState{}
+	r.states[id] = state
+	return state
+}
+
+// transitionView animates changes to the view it shows.
+type transitionView struct {
+	view      View
+	id        string
+	key       string
+	effect    TransitionEffect
+	direction SlideDirection
+	duration  int
+	easing    Easing
+}
+
+// Transition animates its view appearing, disappearing, and being replaced.
+// When the Transition is first rendered, its view animates in; when view is
+// nil, the last view shown animates out; and when the Key changes, the old
+// view animates into the new one. Use it for wizard steps, mode switches,
+// and panels that open and close.
+//
+// The transition plays over a number of animation frames, so it needs tick
+// events (Run's default of 30 frames per second, or WithFPS). Its progress
+// is kept by ID; set ID when there is more than one Transition on screen.
+//
+// Example:
+//
+//	tui.Transition(app.stepView()).
+//	    Key(strconv.Itoa(app.step)).
+//	    Slide(tui.SlideLeft)
+//
+//	// A panel that slides down into place and back up when closed
+//	var panel tui.View
+//	if app.showDetails {
+//	    panel = app.details()
+//	}
+//	tui.Transition(panel).ID("details").Expand()
+func Transition(view View) *transitionView {
+	return &transitionView{
+		view:     view,
+		id:       "transition",
+		effect:   TransitionFade,
+		duration: 10,
+		easing:   EaseInOutQuad,
+	}
+}
+
+// ID sets the ID the transition's progress is kept under.
+func (t *transitionView) ID(id string) *transitionView {
+	t.id = id
+	return t
+}
+
+// Key identifies the view shown. Changing it starts a transition from the
+// view shown before to the new one.
+func (t *transitionView) Key(key string) *transitionView {
+	t.key = key
+	return t
+}
+
+// Fade makes the old view fade out and then the new view fade in (the
+// default).
+func (t *transitionView) Fade() *transitionView {
+	t.effect = TransitionFade
+	return t
+}
+
+// Slide makes the old view slide out in the given direction as the new view
+// slides in behind it.
+func (t *transitionView) Slide(direction SlideDirection) *transitionView {
+	t.effect = TransitionSlide
+	t.direction = direction
+	return t
+}
+
+// Expand makes the view grow or shrink to its new height.
+func (t *transitionView) Expand() *transitionView {
+	t.effect = TransitionExpand
+	return t
+}
+
+// Effect sets the transition effect.
+func (t *transitionView) Effect(effect TransitionEffect) *transitionView {
+	t.effect = effect
+	return t
+}
+
+// Duration sets how many animation frames a transition takes (default 10).
+func (t *transitionView) Duration(frames int) *transitionView {
+	t.duration = frames
+	return t
+}
+
+// Easing sets the easing function of the transition (default EaseInOutQuad).
+func (t *transitionView) Easing(easing Easing) *transitionView {
+	t.easing = easing
+	return t
+}
+
+// sync starts a transition if the view shown has changed, and returns the
+// transition's state.
+func (t *transitionView) sync() *transitionState {
+	state := transitionRegistry.Get(t.id)
+	if state.running && state.started && t.progress(state, state.frame) >= 1 {
+		// The last frame has been drawn
+		state.running = false
+		state.from = nil
+	}
+	present := t.view != nil
+	if t.key != state.key || present != state.present {
+		state.from = state.view
+		state.running = present || state.from != nil
+		state.started = false
+		state.key, state.present = t.key, present
+	}
+	state.view = t.view
+	return state
+}
+
+// progress returns how far the transition has eased along at frame, from 0
+// to 1.
+func (t *transitionView) progress(state *transitionState, frame uint64) float64 {
+	if !state.running {
+		return 1
+	}
+	if !state.started {
+		return 0
+	}
+	if t.duration <= 0 || frame-state.start >= uint64(t.duration) {
+		return 1
+	}
+	p := float64(frame-state.start) / float64(t.duration)
+	if t.easing != nil {
+		p = t.easing(p)
+	}
+	return p
+}
+
+// viewSize returns the size of view, which may be nil.
+func viewSize(view View, maxWidth, maxHeight int) (int, int) {
+	if view == nil {
+		return 0, 0
+	}
+	return view.size(maxWidth, maxHeight)
+}
+
+func (t *transitionView) size(maxWidth, maxHeight int) (int, int) {
+	state := t.sync()
+	// The size is worked out before render learns this frame's number, so
+	// it follows the frame last rendered
+	p := t.progress(state, state.frame)
+	toW, toH := viewSize(t.view, maxWidth, maxHeight)
+	if !state.running {
+		return toW, toH
+	}
+	fromW, fromH := viewSize(state.from, maxWidth, maxHeight)
+	if t.effect == TransitionExpand {
+		w := toW
+		if t.view == nil {
+			w = fromW
+		}
+		return w, fromH + int(math.Round(float64(toH-fromH)*p))
+	}
+	return max(fromW, toW), max(fromH, toH)
+}
+
+// flex implements the Flexible interface by delegating to the view shown.
+func (t *transitionView) flex() int {
+	if flex, ok := t.view.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (t *transitionView) render(ctx *RenderContext) {
+	state := t.sync()
+	if state.running && !state.started {
+		state.start, state.started = ctx.Frame(), true
+	}
+	state.frame = ctx.Frame()
+	p := t.progress(state, state.frame)
+	if !state.running {
+		if t.view != nil {
+			t.view.render(ctx)
+		}
+		return
+	}
+
+	w, h := ctx.Size()
+	// The old view is only drawn, so it doesn't take focus
+	fromCtx := ctx.WithFocusManager(nil)
+	switch t.effect {
+	case TransitionFade:
+		if p < 0.5 {
+			renderShifted(fromCtx, Opacity(1-2*p, orEmpty(state.from)), 0, 0)
+		} else {
+			renderShifted(ctx, Opacity(2*p-1, orEmpty(t.view)), 0, 0)
+		}
+	case TransitionSlide:
+		var dx, dy int
+		switch t.direction {
+		case SlideLeft:
+			dx = -int(math.Round(float64(w) * p))
+		case SlideRight:
+			dx = int(math.Round(float64(w) * p))
+		case SlideUp:
+			dy = -int(math.Round(float64(h) * p))
+		case SlideDown:
+			dy = int(math.Round(float64(h) * p))
+		}
+		if state.from != nil {
+			renderShifted(fromCtx, state.from, dx, dy)
+		}
+		if t.view != nil {
+			switch t.direction {
+			case SlideLeft:
+				dx += w
+			case SlideRight:
+				dx -= w
+			case SlideUp:
+				dy += h
+			case SlideDown:
+				dy -= h
+			}
+			renderShifted(ctx, t.view, dx, dy)
+		}
+	case TransitionExpand:
+		if t.view != nil {
+			renderShifted(ctx, t.view, 0, 0)
+		} else if state.from != nil {
+			renderShifted(fromCtx, state.from, 0, 0)
+		}
+	}
+}
+
+// orEmpty returns view, or an empty view if it is nil.
+func orEmpty(view View) View {
+	if view == nil {
+		return Empty()
+	}
+	return view
+}
+
+// renderShifted renders view at its natural size, limited to the context's
+// width, offset by (dx, dy) and cut off at the context's edges.
+func renderShifted(ctx *RenderContext, view View, dx, dy int) {
+	w, h := ctx.Size()
+	vw, vh := view.size(w, 0)
+	vw = max(vw, w)
+	frame := &offsetRenderFrame{inner: ctx.RenderFrame(), dx: dx, dy: dy, width: vw, height: max(vh, h)}
+	view.size(vw, frame.height)
+	view.render(ctx.WithFrame(frame))
+}
+
+// offsetRenderFrame is a frame of the given size whose origin is at (dx, dy)
+// in an inner frame, which clips what is drawn to it. The offset may be
+// negative, for content partly off the left or top edge.
+type offsetRenderFrame struct {
+	inner         RenderFrame
+	dx, dy        int
+	width, height int
+}
+
+func (f *offsetRenderFrame) SetCell(x, y int, char rune, style Style) error {
+	if x < 0 || y < 0 || x >= f.width || y >= f.height {
+		return nil
+	}
+	innerW, innerH := f.inner.Size()
+	if x+f.dx < 0 || y+f.dy < 0 || x+f.dx >= innerW || y+f.dy >= innerH {
+		return nil
+	}
+	return f.inner.SetCell(x+f.dx, y+f.dy, char, style)
+}
+
+func (f *offsetRenderFrame) PrintStyled(x, y int, text string, style Style) error {
+	return f.inner.PrintStyled(x+f.dx, y+f.dy, text, style)
+}
+
+func (f *offsetRenderFrame) PrintTruncated(x, y int, text string, style Style) error {
+	return f.inner.PrintTruncated(x+f.dx, y+f.dy, text, style)
+}
+
+func (f *offsetRenderFrame) FillStyled(x, y, width, height int, char rune, style Style) error {
+	rect := image.Rect(x, y, x+width, y+height).Intersect(image.Rect(0, 0, f.width, f.height))
+	if rect.Empty() {
+		return nil
+	}
+	rect = rect.Add(image.Pt(f.dx, f.dy))
+	return f.inner.FillStyled(rect.Min.X, rect.Min.Y, rect.Dx(), rect.Dy(), char, style)
+}
+
+func (f *offsetRenderFrame) Fill(char rune, style Style) error {
+	return f.FillStyled(0, 0, f.width, f.height, char, style)
+}
+
+func (f *offsetRenderFrame) Size() (width, height int) {
+	return f.width, f.height
+}
+
+func (f *offsetRenderFrame) GetBounds() image.Rectangle {
+	origin := f.inner.GetBounds().Min.Add(image.Pt(f.dx, f.dy))
+	return image.Rect(0, 0, f.width, f.height).Add(origin)
+}
+
+// SubFrame returns the part of rect inside the inner frame as a frame of the
+// inner frame, offset by however much of rect lies beyond its left and top
+// edges, so that drawing to it is clipped to rect as well.
+func (f *offsetRenderFrame) SubFrame(rect image.Rectangle) RenderFrame {
+	rect = rect.Intersect(image.Rect(0, 0, f.width, f.height))
+	shifted := rect.Add(image.Pt(f.dx, f.dy))
+	innerW, innerH := f.inner.Size()
+	clipped := shifted.Intersect(image.Rect(0, 0, innerW, innerH))
+	if clipped.Empty() {
+		clipped = image.Rectangle{}
+	}
+	return &offsetRenderFrame{
+		inner:  f.inner.SubFrame(clipped),
+		dx:     shifted.Min.X - clipped.Min.X,
+		dy:     shifted.Min.Y - clipped.Min.Y,
+		width:  rect.Dx(),
+		height: rect.Dy(),
+	}
+}
+
+func (f *offsetRenderFrame) PrintHyperlink(x, y int, link Hyperlink) error {
+	return f.inner.PrintHyperlink(x+f.dx, y+f.dy, link)
+}
+
+func (f *offsetRenderFrame) PrintHyperlinkFallback(x, y int, link Hyperlink) error {
+	return f.inner.PrintHyperlinkFallback(x+f.dx, y+f.dy, link)
+}
@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+// renderAt renders the runtime's app as of animation frame n.
+func renderAt(runtime *Runtime, n uint64) {
+	runtime.frame = n
+	runtime.render()
+}
+
+func TestTransition_Slide(t *testing.T) {
+	terminal := NewTestTerminal(8, 1, &bytes.Buffer{})
+	key := "a"
+	app := &simpleApp{
+		renderFunc: func() View {
+			text := map[string]string{"a": "AAAA", "b": "BBBB"}[key]
+			return Transition(Text("%s", text)).Key(key).Slide(SlideLeft).Duration(4).Easing(EaseLinear)
+		},
+		handleFunc: func(Event) []Cmd { return nil },
+	}
+	runtime := NewRuntime(terminal, app, 30)
+
+	// The view slides in from the right when it first appears
+	renderAt(runtime, 0)
+	assert.Equal(t, screenRow(terminal, 0), "")
+	renderAt(runtime, 2)
+	assert.Equal(t, screenRow(terminal, 0), "    AAAA")
+	renderAt(runtime, 4)
+	assert.Equal(t, screenRow(terminal, 0), "AAAA")
+
+	key = "b"
+	renderAt(runtime, 10)
+	assert.Equal(t, screenRow(terminal, 0), "AAAA")
+	renderAt(runtime, 11)
+	assert.Equal(t, screenRow(terminal, 0), "AA    BB")
+	renderAt(runtime, 12)
+	assert.Equal(t, screenRow(terminal, 0), "    BBBB")
+	renderAt(runtime, 14)
+	assert.Equal(t, screenRow(terminal, 0), "BBBB")
+
+	// Rendering the same key again doesn't restart the transition
+	renderAt(runtime, 20)
+	assert.Equal(t, screenRow(terminal, 0), "BBBB")
+}
+
+func TestTransition_Fade(t *testing.T) {
+	terminal := NewTestTerminal(8, 1, &bytes.Buffer{})
+	key := "a"
+	app := &simpleApp{
+		renderFunc: func() View {
+			text := map[string]string{"a": "AAAA", "b": "BBBB"}[key]
+			return Transition(Text("%s", text)).Key(key).Duration(4).Easing(EaseLinear)
+		},
+		handleFunc: func(Event) []Cmd { return nil },
+	}
+	runtime := NewRuntime(terminal, app, 30)
+	renderAt(runtime, 0)
+	renderAt(runtime, 4)
+
+	key = "b"
+	renderAt(runtime, 10)
+	renderAt(runtime, 11)
+	assert.Equal(t, screenRow(terminal, 0), "AAAA")
+	assert.True(t, terminal.GetCell(0, 0).Style.Dim)
+	renderAt(runtime, 13)
+	assert.Equal(t, screenRow(terminal, 0), "BBBB")
+	assert.True(t, terminal.GetCell(0, 0).Style.Dim)
+	renderAt(runtime, 14)
+	assert.Equal(t, screenRow(terminal, 0), "BBBB")
+	assert.False(t, terminal.GetCell(0, 0).Style.Dim)
+}
+
+func TestTransition_ExpandRemoval(t *testing.T) {
+	terminal := NewTestTerminal(8, 3, &bytes.Buffer{})
+	show := true
+	app := &simpleApp{
+		renderFunc: func() View {
+			var panel View
+			if show {
+				panel = Stack(Text("one"), Text("two"))
+			}
+			return Stack(
+				Transition(panel).ID("panel").Expand().Duration(2).Easing(EaseLinear),
+				Text("end"),
+			)
+		},
+		handleFunc: func(Event) []Cmd { return nil },
+	}
+	runtime := NewRuntime(terminal, app, 30)
+	for n := uint64(0); n <= 3; n++ {
+		renderAt(runtime, n)
+	}
+	assert.Equal(t, screenText(terminal), "one\ntwo\nend")
+
+	show = false
+	renderAt(runtime, 10)
+	assert.Equal(t, screenText(terminal), "one\ntwo\nend")
+	renderAt(runtime, 11)
+	renderAt(runtime, 12)
+	assert.Equal(t, screenText(terminal), "one\nend\n")
+	renderAt(runtime, 13)
+	assert.Equal(t, screenText(terminal), "end\n\n")
+}
+
+func TestOffsetRenderFrame_ClipsSubFrames(t *testing.T) {
+	terminal := NewTestTerminal(6, 2, &bytes.Buffer{})
+	frame, err := terminal.BeginFrame()
+	assert.NoError(t, err)
+	ctx := NewRenderContext(frame, 0)
+	renderShifted(ctx, Stack(Group(Text("abc"), Text("de")), Text("fgh")), -1, 0)
+	terminal.EndFrame(frame)
+
+	assert.Equal(t, screenRow(terminal, 0), "bcde")
+	assert.Equal(t, screenRow(terminal, 1), "gh")
+}
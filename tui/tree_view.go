@@ -20,6 +20,20 @@ type TreeNode struct {
 
 	// Data holds arbitrary user data associated with this node.
 	Data any
+
+	// Icon is shown before the label, such as a file type glyph. The tree's
+	// Icons function, if set, takes precedence.
+	Icon string
+
+	// Lazy marks a node whose children haven't been loaded yet. The tree
+	// calls its LoadChildren function the first time the node is expanded,
+	// then clears Lazy. Set it again to reload the children on the next
+	// expand.
+	Lazy bool
+
+	// LoadErr is the error from the last attempt to load the children of a
+	// lazy node, shown after its label.
+	LoadErr error
 }
 
 // NewTreeNode creates a new tree node with the given label.
@@ -51,9 +65,21 @@ func (n *TreeNode) SetExpanded(expanded bool) *TreeNode {
 	return n
 }
 
-// IsLeaf returns true if this node has no children.
+// SetIcon sets the icon shown before the label.
+func (n *TreeNode) SetIcon(icon string) *TreeNode {
+	n.Icon = icon
+	return n
+}
+
+// SetLazy marks the node's children as loaded on first expand.
+func (n *TreeNode) SetLazy(lazy bool) *TreeNode {
+	n.Lazy = lazy
+	return n
+}
+
+// IsLeaf returns true if this node has no children and none left to load.
 func (n *TreeNode) IsLeaf() bool {
-	return len(n.Children) == 0
+	return len(n.Children) == 0 && !n.Lazy
 }
 
 // ExpandAll expands this node and all descendants.
@@ -77,10 +103,13 @@ type treeView struct {
 	id           string
 	root         *TreeNode
 	selected     *TreeNode
+	selection    **TreeNode
 	scrollY      *int
 	width        int
 	height       int
 	onSelect     func(*TreeNode)
+	loadChildren func(*TreeNode) ([]*TreeNode, error)
+	icons        func(*TreeNode) string
 	style        Style
 	selectedSty  Style
 	expandedChar string
@@ -121,8 +150,19 @@ func ASCIITreeBranchChars() TreeBranchChars {
 
 // Tree creates a tree view with the given root node.
 //
-// The component handles keyboard navigation (arrow keys) and expand/collapse (Enter/Space)
-// automatically when focused. Use Tab to focus the tree.
+// The component handles keyboard navigation and expand/collapse automatically
+// when focused. Use Tab to focus the tree.
+//
+// Keys:
+//   - Up/Down or k/j: Move the selection
+//   - Home/End: Select the first/last visible node
+//   - Right or l: Expand the node, or move to its first child if expanded
+//   - Left or h: Collapse the node, or move to its parent if collapsed
+//   - Enter: Toggle expand/collapse
+//   - Space: Toggle expand/collapse and call OnSelect
+//
+// Bind the selection with Selection so it survives re-renders; the view
+// itself is rebuilt on every frame.
 //
 // Example:
 //
@@ -156,6 +196,81 @@ func (t *treeView) Selected(node *TreeNode) *treeView {
 	return t
 }
 
+// Selection binds the selected node to *sel. The tree reads the selection
+// from it when rendering and writes it back as the user moves around.
+func (t *treeView) Selection(sel **TreeNode) *treeView {
+	t.selection = sel
+	if sel != nil {
+		t.selected = *sel
+	}
+	return t
+}
+
+// LoadChildren sets the function that loads the children of lazy nodes,
+// called the first time such a node is expanded. It runs on the event loop,
+// so it should be quick, like reading a directory. If it returns an error,
+// the node is left collapsed with the error shown after its label, and the
+// next expand tries again.
+//
+// Example:
+//
+//	tui.Tree(app.root).LoadChildren(func(n *tui.TreeNode) ([]*tui.TreeNode, error) {
+//	    entries, err := os.ReadDir(n.Data.(string))
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    var children []*tui.TreeNode
+//	    for _, e := range entries {
+//	        path := filepath.Join(n.Data.(string), e.Name())
+//	        children = append(children, tui.NewTreeNode(e.Name()).SetData(path).SetLazy(e.IsDir()))
+//	    }
+//	    return children, nil
+//	})
+func (t *treeView) LoadChildren(fn func(node *TreeNode) ([]*TreeNode, error)) *treeView {
+	t.loadChildren = fn
+	return t
+}
+
+// Icons sets a function that picks the icon for each node, overriding
+// TreeNode.Icon. It can vary the icon with the node's state, such as an
+// open folder for an expanded directory. Return "" for no icon.
+func (t *treeView) Icons(fn func(node *TreeNode) string) *treeView {
+	t.icons = fn
+	return t
+}
+
+// setSelected changes the selected node and writes it to the bound selection.
+func (t *treeView) setSelected(node *TreeNode) {
+	t.selected = node
+	if t.selection != nil {
+		*t.selection = node
+	}
+}
+
+// setExpanded expands or collapses node, loading the children of a lazy
+// node first.
+func (t *treeView) setExpanded(node *TreeNode, expanded bool) {
+	if expanded && node.Lazy && t.loadChildren != nil {
+		children, err := t.loadChildren(node)
+		node.LoadErr = err
+		if err != nil {
+			node.Expanded = false
+			return
+		}
+		node.Children = children
+		node.Lazy = false
+	}
+	node.Expanded = expanded
+}
+
+// icon returns the icon shown for node, or "".
+func (t *treeView) icon(node *TreeNode) string {
+	if t.icons != nil {
+		return t.icons(node)
+	}
+	return node.Icon
+}
+
 // OnSelect sets a callback when a node is selected/clicked.
 func (t *treeView) OnSelect(fn func(*TreeNode)) *treeView {
 	t.onSelect = fn
@@ -191,15 +306,15 @@ func (t *treeView) HandleKeyEvent(event KeyEvent) bool {
 	}
 
 	// Get all visible (flattened) nodes
-	visibleNodes := t.flattenVisibleNodes(t.root, 0)
+	visibleNodes := t.flatten()
 	if len(visibleNodes) == 0 {
 		return false
 	}
 
 	// Find current selection index
 	currentIdx := -1
-	for i, node := range visibleNodes {
-		if node == t.selected {
+	for i, fn := range visibleNodes {
+		if fn.node == t.selected {
 			currentIdx = i
 			break
 		}
@@ -207,43 +322,66 @@ func (t *treeView) HandleKeyEvent(event KeyEvent) bool {
 
 	// If nothing selected, select first node
 	if currentIdx == -1 {
-		t.selected = visibleNodes[0]
+		t.setSelected(visibleNodes[0].node)
 		currentIdx = 0
 	}
+	moveTo := func(idx int) bool {
+		t.setSelected(visibleNodes[idx].node)
+		t.adjustScroll(idx)
+		return true
+	}
+	current := visibleNodes[currentIdx]
+	node := current.node
 
-	// Handle arrow keys for navigation
-	switch event.Key {
-	case KeyArrowUp:
+	switch {
+	case event.Key == KeyArrowUp || event.Rune == 'k':
 		if currentIdx > 0 {
-			t.selected = visibleNodes[currentIdx-1]
-			t.adjustScroll(currentIdx - 1)
-			return true
+			return moveTo(currentIdx - 1)
 		}
-	case KeyArrowDown:
+	case event.Key == KeyArrowDown || event.Rune == 'j':
 		if currentIdx < len(visibleNodes)-1 {
-			t.selected = visibleNodes[currentIdx+1]
-			t.adjustScroll(currentIdx + 1)
+			return moveTo(currentIdx + 1)
+		}
+	case event.Key == KeyHome:
+		return moveTo(0)
+	case event.Key == KeyEnd:
+		return moveTo(len(visibleNodes) - 1)
+	case event.Key == KeyArrowRight || event.Rune == 'l':
+		if node.IsLeaf() {
+			return false
+		}
+		if !node.Expanded {
+			t.setExpanded(node, true)
 			return true
 		}
-	case KeyEnter:
-		// Toggle expand/collapse on Enter
-		if t.selected != nil && !t.selected.IsLeaf() {
-			t.selected.Expanded = !t.selected.Expanded
+		if currentIdx+1 < len(visibleNodes) && visibleNodes[currentIdx+1].depth > current.depth {
+			return moveTo(currentIdx + 1)
+		}
+	case event.Key == KeyArrowLeft || event.Rune == 'h':
+		if !node.IsLeaf() && node.Expanded {
+			t.setExpanded(node, false)
 			return true
 		}
-	}
-
-	// Handle space to select/toggle
-	if event.Rune == ' ' {
-		if t.selected != nil {
-			if !t.selected.IsLeaf() {
-				t.selected.Expanded = !t.selected.Expanded
-			}
-			if t.onSelect != nil {
-				t.onSelect(t.selected)
+		for i := currentIdx - 1; i >= 0; i-- {
+			if visibleNodes[i].depth < current.depth {
+				return moveTo(i)
 			}
+		}
+	case event.Key == KeyEnter:
+		// Toggle expand/collapse on Enter
+		if !node.IsLeaf() {
+			t.setExpanded(node, !node.Expanded)
 			return true
 		}
+	case event.Rune == ' ':
+		// Space toggles and selects
+		if !node.IsLeaf() {
+			t.setExpanded(node, !node.Expanded)
+		}
+		if t.onSelect != nil {
+			t.onSelect(node)
+		}
+		return true
 	}
 
 	return false
@@ -271,21 +409,6 @@ func (t *treeView) adjustScroll(selectedIdx int) {
 	}
 }
 
-// flattenVisibleNodes returns all visible nodes in traversal order
-func (t *treeView) flattenVisibleNodes(node *TreeNode, depth int) []*TreeNode {
-	if node == nil {
-		return nil
-	}
-
-	result := []*TreeNode{node}
-	if node.Expanded {
-		for _, child := range node.Children {
-			result = append(result, t.flattenVisibleNodes(child, depth+1)...)
-		}
-	}
-	return result
-}
-
 // ScrollY sets the scroll position pointer.
 func (t *treeView) ScrollY(scrollY *int) *treeView {
 	t.scrollY = scrollY
@@ -406,10 +529,16 @@ func (t *treeView) size(maxWidth, maxHeight int) (int, int) {
 	w := t.width
 	if w == 0 {
 		for _, fn := range nodes {
-			// indent + expand char + space + label
+			// indent + expand char + space + icon + label
 			indent := fn.depth * 2
 			expandWidth := runewidth.StringWidth(t.expandedChar)
 			labelWidth := runewidth.StringWidth(fn.node.Label)
+			if icon := t.icon(fn.node); icon != "" {
+				labelWidth += runewidth.StringWidth(icon) + 1
+			}
+			if fn.node.LoadErr != nil {
+				labelWidth += runewidth.StringWidth(fn.node.LoadErr.Error()) + 2
+			}
 			nodeW := indent + expandWidth + 1 + labelWidth
 			if nodeW > w {
 				w = nodeW
@@ -520,13 +649,23 @@ func (t *treeView) render(ctx *RenderContext) {
 		ctx.PrintStyled(x, y, indicator+" ", style)
 		x += runewidth.StringWidth(indicator) + 1
 
-		// Draw label
+		// Draw icon and label
 		label := node.Label
+		if icon := t.icon(node); icon != "" {
+			label = icon + " " + label
+		}
 		labelWidth := runewidth.StringWidth(label)
 		if x+labelWidth > width {
 			label = truncateToWidth(label, width-x)
+			labelWidth = runewidth.StringWidth(label)
 		}
 		ctx.PrintStyled(x, y, label, style)
+		x += labelWidth
+
+		// Show why a lazy node's children didn't load
+		if node.LoadErr != nil && x+2 < width {
+			ctx.PrintStyled(x, y, truncateToWidth("  "+node.LoadErr.Error(), width-x), NewStyle().WithForeground(ColorRed))
+		}
 
 		// Register clickable region
 		bounds := ctx.AbsoluteBounds()
@@ -540,9 +679,9 @@ func (t *treeView) render(ctx *RenderContext) {
 		interactiveRegistry.RegisterButton(clickBounds, func() {
 			// Toggle expand/collapse for non-leaf nodes
 			if !nodeCopy.IsLeaf() {
-				nodeCopy.Expanded = !nodeCopy.Expanded
+				t.setExpanded(nodeCopy, !nodeCopy.Expanded)
 			}
-			t.selected = nodeCopy
+			t.setSelected(nodeCopy)
 			if t.onSelect != nil {
 				t.onSelect(nodeCopy)
 			}
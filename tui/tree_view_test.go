@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/deepnoodle-ai/wonton/assert"
@@ -162,3 +163,99 @@ func TestTreeBranchChars(t *testing.T) {
 	assert.Equal(t, "|", ascii.Vertical)
 	assert.Equal(t, "`", ascii.Corner)
 }
+
+func TestTreeViewKeyboard(t *testing.T) {
+	root := NewTreeNode("root").SetExpanded(true).AddChildren(
+		NewTreeNode("dir").AddChildren(
+			NewTreeNode("file"),
+		),
+		NewTreeNode("last"),
+	)
+	var selected *TreeNode
+	key := func(r rune) {
+		Tree(root).Selection(&selected).HandleKeyEvent(KeyEvent{Rune: r})
+	}
+
+	key('j') // nothing selected moves from the first node
+	assert.Equal(t, "dir", selected.Label)
+
+	// l expands, then moves into the children
+	key('l')
+	assert.True(t, selected.Expanded)
+	key('l')
+	assert.Equal(t, "file", selected.Label)
+
+	// h on a leaf moves to its parent, then collapses it
+	key('h')
+	assert.Equal(t, "dir", selected.Label)
+	key('h')
+	assert.False(t, selected.Expanded)
+
+	key('j')
+	assert.Equal(t, "last", selected.Label)
+	key('k')
+	assert.Equal(t, "dir", selected.Label)
+
+	Tree(root).Selection(&selected).HandleKeyEvent(KeyEvent{Key: KeyHome})
+	assert.Equal(t, "root", selected.Label)
+	Tree(root).Selection(&selected).HandleKeyEvent(KeyEvent{Key: KeyEnd})
+	assert.Equal(t, "last", selected.Label)
+}
+
+func TestTreeViewLazyChildren(t *testing.T) {
+	dir := NewTreeNode("dir").SetLazy(true)
+	root := NewTreeNode("root").SetExpanded(true).AddChildren(dir)
+	assert.False(t, dir.IsLeaf())
+
+	loads := 0
+	var loadErr error
+	view := func() *treeView {
+		return Tree(root).Selected(dir).LoadChildren(func(n *TreeNode) ([]*TreeNode, error) {
+			loads++
+			if loadErr != nil {
+				return nil, loadErr
+			}
+			return []*TreeNode{NewTreeNode(n.Label + "/a"), NewTreeNode(n.Label + "/b")}, nil
+		})
+	}
+
+	// A failed load leaves the node collapsed and shows the error
+	loadErr = errors.New("permission denied")
+	view().HandleKeyEvent(KeyEvent{Key: KeyArrowRight})
+	assert.False(t, dir.Expanded)
+	assert.True(t, dir.Lazy)
+	screen := SprintScreen(view(), PrintConfig{Width: 40, Height: 2})
+	assert.Equal(t, "└─▶ dir  permission denied", screen.Row(1))
+
+	// Children load on the first expand only
+	loadErr = nil
+	view().HandleKeyEvent(KeyEvent{Key: KeyArrowRight})
+	assert.True(t, dir.Expanded)
+	assert.False(t, dir.Lazy)
+	assert.Nil(t, dir.LoadErr)
+	assert.Len(t, dir.Children, 2)
+	view().HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	view().HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	assert.True(t, dir.Expanded)
+	assert.Equal(t, 2, loads)
+}
+
+func TestTreeViewIcons(t *testing.T) {
+	root := NewTreeNode("src").SetExpanded(true).AddChildren(
+		NewTreeNode("main.go").SetIcon("◆"),
+	)
+	screen := SprintScreen(Tree(root), PrintConfig{Width: 14, Height: 2})
+	assert.Equal(t, "▼ src", screen.Row(0))
+	assert.Equal(t, "└─  ◆ main.go", screen.Row(1))
+
+	// An icon function overrides node icons
+	view := Tree(root).Icons(func(n *TreeNode) string {
+		if n.IsLeaf() {
+			return "f"
+		}
+		return "d"
+	})
+	screen = SprintScreen(view, PrintConfig{Width: 14, Height: 2})
+	assert.Equal(t, "▼ d src", screen.Row(0))
+	assert.Equal(t, "└─  f main.go", screen.Row(1))
+}
@@ -0,0 +1,237 @@
+package tui
+
+import (
+	"image"
+	"math"
+	"sort"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// TreeMapItem is a weighted item in a TreeMap.
+type TreeMapItem struct {
+	Label string
+	Value float64 // Area of the item relative to the others; items <= 0 are hidden
+
+	// Style overrides the palette color for this item.
+	Style *Style
+
+	// Data holds arbitrary user data associated with this item.
+	Data any
+}
+
+// treeMapView draws items as rectangles with areas proportional to their
+// values.
+type treeMapView struct {
+	items     []TreeMapItem
+	palette   []Style
+	highlight int
+	onSelect  func(index int)
+}
+
+// TreeMap creates a squarified treemap: each item is a rectangle whose area
+// is proportional to its value, laid out to keep rectangles close to
+// square. It fills the space it is given, which makes it a compact overview
+// of sizes, such as the contents of a directory.
+//
+// Example:
+//
+//	items := make([]tui.TreeMapItem, len(dir.Children))
+//	for i, e := range dir.Children {
+//	    items[i] = tui.TreeMapItem{Label: e.Name, Value: float64(e.Usage)}
+//	}
+//	tui.TreeMap(items).Highlight(app.selected).OnSelect(app.open)
+func TreeMap(items []TreeMapItem) *treeMapView {
+	black := NewStyle().WithForeground(ColorBlack)
+	return &treeMapView{
+		items:     items,
+		highlight: -1,
+		palette: []Style{
+			black.WithBackground(ColorBlue),
+			black.WithBackground(ColorGreen),
+			black.WithBackground(ColorMagenta),
+			black.WithBackground(ColorCyan),
+			black.WithBackground(ColorYellow),
+			black.WithBackground(ColorRed),
+		},
+	}
+}
+
+// Palette sets the styles that items cycle through, in order of size.
+func (v *treeMapView) Palette(styles ...Style) *treeMapView {
+	if len(styles) > 0 {
+		v.palette = styles
+	}
+	return v
+}
+
+// Highlight marks the item at index, for example the one selected in a list
+// next to the treemap. Use -1 for none.
+func (v *treeMapView) Highlight(index int) *treeMapView {
+	v.highlight = index
+	return v
+}
+
+// OnSelect sets a callback invoked with the index of a clicked item.
+func (v *treeMapView) OnSelect(fn func(index int)) *treeMapView {
+	v.onSelect = fn
+	return v
+}
+
+func (v *treeMapView) flex() int {
+	return 1
+}
+
+func (v *treeMapView) size(maxWidth, maxHeight int) (int, int) {
+	w, h := maxWidth, maxHeight
+	if w <= 0 {
+		w = 40
+	}
+	if h <= 0 {
+		h = 10
+	}
+	return w, h
+}
+
+func (v *treeMapView) render(ctx *RenderContext) {
+	width, height := ctx.Size()
+	if width == 0 || height == 0 {
+		return
+	}
+	origin := ctx.AbsoluteBounds().Min
+	for rank, tile := range v.layout(width, height) {
+		item := v.items[tile.index]
+		style := v.palette[rank%len(v.palette)]
+		if item.Style != nil {
+			style = *item.Style
+		}
+		if tile.index == v.highlight {
+			style = style.WithReverse().WithBold()
+		}
+		r := tile.rect
+		ctx.FillStyled(r.Min.X, r.Min.Y, r.Dx(), r.Dy(), ' ', style)
+		if r.Dx() > 1 {
+			label := runewidth.Truncate(item.Label, r.Dx()-1, "…")
+			ctx.PrintTruncated(r.Min.X+1, r.Min.Y, label, style)
+		}
+
+		if v.onSelect != nil {
+			index, onSelect := tile.index, v.onSelect
+			interactiveRegistry.RegisterButton(r.Add(origin), func() { onSelect(index) })
+		}
+	}
+}
+
+// treeMapTile is the cell rectangle of an item.
+type treeMapTile struct {
+	index int
+	rect  image.Rectangle
+}
+
+// treeMapRect is a rectangle in layout units, where a unit of height is as
+// long as two cells of width, so that squares look square.
+type treeMapRect struct {
+	x, y, w, h float64
+}
+
+// layout places the items with positive values in a width x height area,
+// largest first, using the squarified treemap algorithm.
+func (v *treeMapView) layout(width, height int) []treeMapTile {
+	var order []int
+	total := 0.0
+	for i, item := range v.items {
+		if item.Value > 0 {
+			order = append(order, i)
+			total += item.Value
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return v.items[order[a]].Value > v.items[order[b]].Value
+	})
+
+	free := treeMapRect{w: float64(width) / 2, h: float64(height)}
+	scale := free.w * free.h / total
+	areas := make([]float64, len(order))
+	for i, idx := range order {
+		areas[i] = v.items[idx].Value * scale
+	}
+
+	rects := make([]treeMapRect, 0, len(areas))
+	for start := 0; start < len(areas); {
+		side := math.Min(free.w, free.h)
+		end := start + 1
+		for end < len(areas) && worstRatio(areas[start:end+1], side) <= worstRatio(areas[start:end], side) {
+			end++
+		}
+		var placed []treeMapRect
+		placed, free = layoutRow(areas[start:end], free)
+		rects = append(rects, placed...)
+		start = end
+	}
+
+	tiles := make([]treeMapTile, 0, len(rects))
+	for i, r := range rects {
+		rect := image.Rect(
+			int(math.Round(r.x*2)), int(math.Round(r.y)),
+			int(math.Round((r.x+r.w)*2)), int(math.Round(r.y+r.h)),
+		)
+		if rect.Empty() {
+			continue
+		}
+		tiles = append(tiles, treeMapTile{index: order[i], rect: rect})
+	}
+	return tiles
+}
+
+// worstRatio returns the largest aspect ratio of a row of areas laid along
+// a side of the given length.
+func worstRatio(row []float64, side float64) float64 {
+	sum, lo, hi := 0.0, math.Inf(1), 0.0
+	for _, a := range row {
+		sum += a
+		lo = math.Min(lo, a)
+		hi = math.Max(hi, a)
+	}
+	if sum == 0 || lo == 0 {
+		return math.Inf(1)
+	}
+	s2, side2 := sum*sum, side*side
+	return math.Max(side2*hi/s2, s2/(side2*lo))
+}
+
+// layoutRow places a row of areas along the shorter side of free and
+// returns their rectangles and the space left.
+func layoutRow(row []float64, free treeMapRect) ([]treeMapRect, treeMapRect) {
+	sum := 0.0
+	for _, a := range row {
+		sum += a
+	}
+	rects := make([]treeMapRect, len(row))
+	if free.w >= free.h {
+		// A column on the left
+		colW := sum / free.h
+		y := free.y
+		for i, a := range row {
+			h := a / colW
+			rects[i] = treeMapRect{x: free.x, y: y, w: colW, h: h}
+			y += h
+		}
+		free.x += colW
+		free.w -= colW
+	} else {
+		// A row along the top
+		rowH := sum / free.w
+		x := free.x
+		for i, a := range row {
+			w := a / rowH
+			rects[i] = treeMapRect{x: x, y: free.y, w: w, h: rowH}
+			x += w
+		}
+		free.y += rowH
+		free.h -= rowH
+	}
+	return rects, free
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"image"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestTreeMap_Layout(t *testing.T) {
+	view := TreeMap([]TreeMapItem{
+		{Label: "B", Value: 1},
+		{Label: "A", Value: 2},
+		{Label: "hidden", Value: 0},
+		{Label: "C", Value: 1},
+	})
+	assert.Equal(t, []treeMapTile{
+		{index: 1, rect: image.Rect(0, 0, 4, 2)},
+		{index: 0, rect: image.Rect(4, 0, 8, 1)},
+		{index: 3, rect: image.Rect(4, 1, 8, 2)},
+	}, view.layout(8, 2))
+
+	// Tiles cover the whole area without overlapping
+	view = TreeMap([]TreeMapItem{{Value: 5}, {Value: 3}, {Value: 3}, {Value: 2}, {Value: 1}, {Value: 1}})
+	area := 0
+	tiles := view.layout(37, 11)
+	for i, tile := range tiles {
+		area += tile.rect.Dx() * tile.rect.Dy()
+		for _, other := range tiles[i+1:] {
+			assert.True(t, tile.rect.Intersect(other.rect).Empty())
+		}
+	}
+	assert.Equal(t, 37*11, area)
+
+	assert.Equal(t, 0, len(TreeMap(nil).layout(10, 10)))
+}
+
+func TestTreeMap_Render(t *testing.T) {
+	view := TreeMap([]TreeMapItem{{Label: "Apple", Value: 2}, {Label: "B", Value: 1}, {Label: "C", Value: 1}}).
+		Highlight(2)
+	screen := SprintScreen(view, PrintConfig{Width: 8, Height: 2})
+	assert.Equal(t, " Ap… B", screen.Row(0))
+	assert.Equal(t, "     C", screen.Row(1))
+	assert.Equal(t, uint8(ColorBlue), screen.Cell(0, 1).Style.Background.Value)
+	assert.Equal(t, uint8(ColorGreen), screen.Cell(7, 0).Style.Background.Value)
+	assert.True(t, screen.Cell(7, 1).Style.Reverse)
+}
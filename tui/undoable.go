@@ -0,0 +1,65 @@
+package tui
+
+import "time"
+
+// UndoableAction is a change the user can take back for a while after
+// making it, such as deleting an item from a list. The change is made at
+// once, and a toast offers to undo it until the toast goes.
+type UndoableAction struct {
+	// Title is the toast's title, such as "Deleted 3 tasks"
+	Title   string
+	Message string
+
+	// Do makes the change. It runs at once, before Perform returns.
+	Do func()
+
+	// Undo takes the change back. It runs if the user clicks the toast's
+	// Undo button.
+	Undo func()
+
+	// Commit, if set, makes the change final once it can no longer be
+	// undone: when the toast times out or is dismissed, or the
+	// notification leaves the store. Use it for work that can't be taken
+	// back, such as deleting a file, while Do only hides it.
+	Commit func()
+
+	// Timeout is how long the change can be undone. Zero uses the store's
+	// Timeout.
+	Timeout time.Duration
+
+	// UndoLabel is the label of the toast's button. Empty uses "Undo".
+	UndoLabel string
+}
+
+// Perform makes an undoable change: it runs the action's Do, then shows a
+// toast with an Undo button that runs Undo. If the toast goes without the
+// user undoing the change, Commit runs. It returns the notification's ID.
+//
+// Example:
+//
+//	app.notes.Perform(tui.UndoableAction{
+//	    Title: "Deleted " + task.Title,
+//	    Do:    func() { app.tasks = slices.Delete(app.tasks, i, i+1) },
+//	    Undo:  func() { app.tasks = slices.Insert(app.tasks, i, task) },
+//	})
+func (s *NotificationStore) Perform(action UndoableAction) int {
+	if action.Do != nil {
+		action.Do()
+	}
+	label := action.UndoLabel
+	if label == "" {
+		label = "Undo"
+	}
+	commit := action.Commit
+	if commit == nil {
+		commit = func() {} // still ends the Undo when the toast goes
+	}
+	return s.Add(Notification{
+		Severity: SeverityInfo,
+		Title:    action.Title,
+		Message:  action.Message,
+		Timeout:  action.Timeout,
+		Actions:  []NotificationAction{{Label: label, Run: action.Undo}},
+		commit:   commit,
+	})
+}
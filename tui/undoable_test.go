@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestPerform_Undo(t *testing.T) {
+	s, _ := newTestStore()
+	items := []string{"a", "b"}
+	committed := false
+	id := s.Perform(UndoableAction{
+		Title:  "Deleted b",
+		Do:     func() { items = items[:1] },
+		Undo:   func() { items = append(items, "b") },
+		Commit: func() { committed = true },
+	})
+	assert.Equal(t, []string{"a"}, items)
+
+	toasts := s.Toasts()
+	assert.Len(t, toasts, 1)
+	assert.Equal(t, "Undo", toasts[0].Actions[0].Label)
+
+	assert.True(t, s.RunAction(id, 0))
+	assert.Equal(t, []string{"a", "b"}, items)
+	// Undone changes are not committed, even once the toast is gone
+	s.Dismiss(id)
+	assert.False(t, committed)
+}
+
+func TestPerform_CommitsWhenToastGoes(t *testing.T) {
+	s, now := newTestStore()
+	commits := 0
+	commit := func() { commits++ }
+
+	timedOut := s.Perform(UndoableAction{Title: "one", Timeout: 2 * time.Second, Commit: commit})
+	*now = now.Add(time.Second)
+	s.Toasts()
+	assert.Equal(t, 0, commits)
+	*now = now.Add(time.Second)
+	s.Toasts()
+	assert.Equal(t, 1, commits)
+	// Once final, the change can't be undone from the center
+	n, _ := s.Get(timedOut)
+	assert.Len(t, n.Actions, 0)
+	assert.False(t, s.RunAction(timedOut, 0))
+
+	dismissed := s.Perform(UndoableAction{Title: "two", Commit: commit})
+	s.Dismiss(dismissed)
+	s.Dismiss(dismissed)
+	assert.Equal(t, 2, commits)
+
+	s.Perform(UndoableAction{Title: "three", Commit: commit})
+	s.SetOpen(true)
+	assert.Equal(t, 3, commits)
+
+	s.Perform(UndoableAction{Title: "four", Commit: commit})
+	s.Clear()
+	assert.Equal(t, 4, commits)
+}
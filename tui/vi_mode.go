@@ -0,0 +1,820 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ViState is the editing state of a ViMode.
+type ViState int
+
+const (
+	// ViInsert types text as usual; Escape switches to ViNormal.
+	ViInsert ViState = iota
+	// ViNormal runs motions and commands; i, a, o and others switch to
+	// ViInsert.
+	ViNormal
+	// ViVisual selects text from where v was pressed to the cursor.
+	ViVisual
+)
+
+// String returns "INSERT", "NORMAL", or "VISUAL", for mode indicators.
+func (s ViState) String() string {
+	switch s {
+	case ViNormal:
+		return "NORMAL"
+	case ViVisual:
+		return "VISUAL"
+	}
+	return "INSERT"
+}
+
+// viMotionKind says which text a motion covers when an operator applies it.
+type viMotionKind int
+
+const (
+	viExclusive viMotionKind = iota // up to the new position
+	viInclusive                     // up to and including the new position
+	viLinewise                      // every line from the old to the new position
+)
+
+// viSnapshot is a value and cursor (a byte offset) to undo to.
+type viSnapshot struct {
+	text   string
+	cursor int
+}
+
+// ViMode adds vi-style modal editing to a text input: an insert mode that
+// types as usual, a normal mode with motions and operators, and a visual
+// mode for selecting text. Attach it with InputField.KeyHandler or
+// TextInput.WithKeyHandler. It starts in insert mode, like the vi mode of
+// shells, and Escape switches to normal mode.
+//
+// Normal mode supports counts and these keys:
+//
+//	h l j k, arrows      left, right, down, up
+//	w b e                next word, previous word, end of word
+//	0 ^ $                start of line, first non-blank, end of line
+//	gg G                 first line, last line (or line N with a count)
+//	f F t T ; ,          find a character on the line, and repeat
+//	d c y                delete, change, yank over a motion; dd cc yy for lines
+//	x X s D C Y          dl, dh, cl, d$, c$, yy
+//	p P                  put after, before the cursor
+//	i a I A o O          insert before, after, at line start, at line end, on a new line
+//	r ~ J                replace a character, toggle case, join lines
+//	u Ctrl+R             undo, redo
+//	v                    visual mode; d, c, y, x, s and ~ act on the selection
+//
+// In single-line inputs, j and k recall history like the arrow keys. Enter
+// submits in any mode. Each ViMode tracks one input; don't share it.
+//
+// Example:
+//
+//	app.vi = tui.NewViMode()
+//
+//	tui.InputField(&app.message).
+//	    Multiline(true).
+//	    KeyHandler(app.vi).
+//	    Label(app.vi.State().String())
+type ViMode struct {
+	state ViState
+
+	// Pending command: counts typed before the operator and before the
+	// motion, the operator, and a key waiting for its argument (g, r, or
+	// a find)
+	count, opCount int
+	op             rune
+	pending        rune
+
+	anchor   int // start of the visual selection, in runes
+	register string
+	linewise bool // the register holds whole lines
+
+	lastFind, lastFindChar rune // for ; and ,
+
+	undo, redo []viSnapshot
+	insertFrom viSnapshot // state before the current insert, for undo
+}
+
+// NewViMode creates a vi mode in insert mode.
+func NewViMode() *ViMode {
+	return &ViMode{}
+}
+
+// State returns the current state, for showing a mode indicator.
+func (v *ViMode) State() ViState {
+	return v.state
+}
+
+// HandleInputKey implements InputKeyHandler.
+func (v *ViMode) HandleInputKey(t *TextInput, event KeyEvent) bool {
+	if v.state == ViInsert {
+		if event.Key == KeyEscape {
+			v.leaveInsert(t)
+			return true
+		}
+		return false
+	}
+	if event.Paste != "" {
+		return false
+	}
+	t.expandPastes()
+
+	switch {
+	case event.Key == KeyEscape:
+		// Cancel a pending command or the selection; otherwise leave
+		// Escape to the application
+		handled := v.count != 0 || v.op != 0 || v.pending != 0 || v.state == ViVisual
+		v.reset()
+		if v.state == ViVisual {
+			v.state = ViNormal
+			t.ClearSelection()
+		}
+		return handled
+	case event.Key == KeyCtrlR:
+		for n := max(1, v.count); n > 0 && len(v.redo) > 0; n-- {
+			v.undo = append(v.undo, v.snapshot(t))
+			v.restore(t, &v.redo)
+		}
+		v.reset()
+		return true
+	}
+	k := viKey(event)
+	if k == 0 {
+		// Leave other keys, such as Enter, to the input
+		v.reset()
+		return false
+	}
+
+	display := t.DisplayText()
+	text := []rune(display)
+	cur := utf8.RuneCountInString(display[:min(t.CursorPos, len(display))])
+
+	if v.pending != 0 {
+		p := v.pending
+		v.pending = 0
+		switch p {
+		case 'r':
+			v.replaceChars(t, text, cur, k)
+			v.reset()
+		case 'g':
+			if k == 'g' {
+				v.motion(t, text, cur, 'g', 0)
+			} else {
+				v.reset()
+			}
+		default:
+			v.lastFind, v.lastFindChar = p, k
+			v.motion(t, text, cur, p, k)
+		}
+		v.updateSelection(t)
+		return true
+	}
+
+	if k >= '1' && k <= '9' || k == '0' && v.count > 0 {
+		v.count = v.count*10 + int(k-'0')
+		return true
+	}
+
+	if v.state == ViVisual {
+		v.visualKey(t, text, cur, k)
+	} else {
+		v.normalKey(t, text, cur, k)
+	}
+	v.updateSelection(t)
+	return true
+}
+
+// viKey maps a key event to the vi key it stands for, or 0.
+func viKey(event KeyEvent) rune {
+	switch event.Key {
+	case KeyArrowLeft, KeyBackspace:
+		return 'h'
+	case KeyArrowRight:
+		return 'l'
+	case KeyArrowUp:
+		return 'k'
+	case KeyArrowDown:
+		return 'j'
+	case KeyHome:
+		return '0'
+	case KeyEnd:
+		return '$'
+	case KeyDelete:
+		return 'x'
+	}
+	if event.Rune >= ' ' && !event.Ctrl && !event.Alt {
+		return event.Rune
+	}
+	return 0
+}
+
+// reset cancels a pending count, operator, or argument.
+func (v *ViMode) reset() {
+	v.count, v.opCount, v.op, v.pending = 0, 0, 0, 0
+}
+
+// counts returns the total count of the pending command, at least 1, and
+// whether one was typed.
+func (v *ViMode) counts() (int, bool) {
+	return max(1, v.count) * max(1, v.opCount), v.count > 0 || v.opCount > 0
+}
+
+// viShorthands maps normal mode commands to the operator and motion they
+// stand for.
+var viShorthands = map[rune]string{
+	'x': "dl", 'X': "dh", 's': "cl", 'D': "d$", 'C': "c$", 'Y': "yy",
+}
+
+func (v *ViMode) normalKey(t *TextInput, text []rune, cur int, k rune) {
+	switch k {
+	case 'd', 'c', 'y':
+		switch v.op {
+		case 0:
+			v.op, v.opCount, v.count = k, v.count, 0
+		case k:
+			// dd, cc, yy: the line and count-1 more below it
+			n, _ := v.counts()
+			end := cur
+			for i := 1; i < n; i++ {
+				if next := viLineEnd(text, end); next < len(text) {
+					end = next + 1
+				}
+			}
+			v.apply(t, text, k, cur, end, viLinewise)
+			v.reset()
+		default:
+			v.reset()
+		}
+		return
+	case 'g', 'r', 'f', 'F', 't', 'T':
+		v.pending = k
+		return
+	}
+	if v.op != 0 {
+		v.motion(t, text, cur, k, 0)
+		return
+	}
+
+	n, _ := v.counts()
+	switch k {
+	case 'i':
+		v.startInsert(t, v.snapshot(t), cur)
+	case 'a':
+		v.startInsert(t, v.snapshot(t), min(cur+1, viLineEnd(text, cur)))
+	case 'I':
+		v.startInsert(t, v.snapshot(t), viFirstNonBlank(text, cur))
+	case 'A':
+		v.startInsert(t, v.snapshot(t), viLineEnd(text, cur))
+	case 'o', 'O':
+		snap := v.snapshot(t)
+		at := viLineEnd(text, cur)
+		if k == 'O' {
+			at = viLineStart(text, cur)
+		}
+		text = viSplice(text, at, at, []rune{'\n'})
+		if k == 'o' {
+			at++
+		}
+		v.set(t, text, at)
+		v.startInsert(t, snap, at)
+	case 'x', 'X', 's', 'D', 'C', 'Y':
+		// Shorthands for an operator and a motion
+		short := []rune(viShorthands[k])
+		v.op, v.opCount, v.count = short[0], v.count, 0
+		if short[1] == 'y' {
+			v.normalKey(t, text, cur, 'y')
+		} else {
+			v.motion(t, text, cur, short[1], 0)
+		}
+	case 'p', 'P':
+		v.put(t, text, cur, k == 'P', n)
+	case '~':
+		end := min(cur+n, viLineEnd(text, cur))
+		v.save(t)
+		v.set(t, viToggleCase(text, cur, end), v.clamp(text, end))
+	case 'J':
+		v.join(t, text, cur, max(1, n-1))
+	case 'u':
+		for ; n > 0 && len(v.undo) > 0; n-- {
+			v.redo = append(v.redo, v.snapshot(t))
+			v.restore(t, &v.undo)
+		}
+	case 'v':
+		v.state, v.anchor = ViVisual, cur
+	default:
+		v.motion(t, text, cur, k, 0)
+		return
+	}
+	v.reset()
+}
+
+func (v *ViMode) visualKey(t *TextInput, text []rune, cur int, k rune) {
+	switch k {
+	case 'd', 'x', 'c', 's', 'y', '~':
+		op := k
+		switch k {
+		case 'x':
+			op = 'd'
+		case 's':
+			op = 'c'
+		}
+		v.state = ViNormal
+		t.ClearSelection()
+		if op == '~' {
+			start, end := min(v.anchor, cur), min(max(v.anchor, cur)+1, len(text))
+			v.save(t)
+			v.set(t, viToggleCase(text, start, end), start)
+		} else {
+			v.apply(t, text, op, v.anchor, cur, viInclusive)
+		}
+		v.reset()
+	case 'o':
+		v.anchor, cur = cur, v.anchor
+		v.setCursor(t, text, cur)
+		v.reset()
+	case 'v':
+		v.state = ViNormal
+		t.ClearSelection()
+		v.reset()
+	case 'g', 'f', 'F', 't', 'T':
+		v.pending = k
+	default:
+		v.motion(t, text, cur, k, 0)
+	}
+}
+
+// motion runs motion key k (with the argument char for finds) with the
+// pending count: it applies the pending operator, or moves the cursor.
+func (v *ViMode) motion(t *TextInput, text []rune, cur int, k, char rune) {
+	n, counted := v.counts()
+	op := v.op
+	if k == ';' || k == ',' {
+		// Repeat the last find, in the opposite direction for ,
+		reverse := k == ','
+		k, char = v.lastFind, v.lastFindChar
+		if reverse {
+			k = map[rune]rune{'f': 'F', 'F': 'f', 't': 'T', 'T': 't'}[k]
+		}
+	}
+	pos, kind, ok := viMove(text, cur, k, char, n, counted, op)
+
+	if (k == 'j' || k == 'k') && !ok && op == 0 && v.state == ViNormal && !t.MultilineMode {
+		// Like the arrow keys, j and k browse history in single-line inputs
+		if t.recallHistory(k == 'k') {
+			text = []rune(t.DisplayText())
+			v.setCursor(t, text, len(text))
+		}
+	}
+
+	if ok {
+		if op != 0 {
+			v.apply(t, text, op, cur, pos, kind)
+		} else {
+			v.setCursor(t, text, pos)
+		}
+	}
+	v.reset()
+}
+
+// viMove returns where motion k moves the cursor from cur, n times, and the
+// text it covers for an operator. ok is false if the motion fails.
+func viMove(text []rune, cur int, k, char rune, n int, counted bool, op rune) (pos int, kind viMotionKind, ok bool) {
+	lineStart, lineEnd := viLineStart(text, cur), viLineEnd(text, cur)
+	switch k {
+	case 'h':
+		return max(lineStart, cur-n), viExclusive, cur > lineStart
+	case 'l':
+		limit := lineEnd
+		if op == 0 {
+			limit = max(lineStart, lineEnd-1)
+		}
+		return min(limit, cur+n), viExclusive, cur < limit
+	case '0':
+		return lineStart, viExclusive, true
+	case '^':
+		return viFirstNonBlank(text, cur), viExclusive, true
+	case '$':
+		pos = cur
+		for i := 1; i < n && viLineEnd(text, pos) < len(text); i++ {
+			pos = viLineEnd(text, pos) + 1
+		}
+		return viLineEnd(text, pos), viExclusive, true
+	case 'w':
+		pos = cur
+		for i := 0; i < n; i++ {
+			pos = viWordForward(text, pos)
+		}
+		if op == 'c' && cur < len(text) && viClass(text[cur]) != 0 {
+			// cw changes to the end of the word, like ce
+			return viMove(text, cur, 'e', char, n, counted, op)
+		}
+		if op != 0 && pos > lineEnd {
+			// Don't join lines when deleting the last word
+			pos = lineEnd
+		}
+		return pos, viExclusive, pos != cur
+	case 'b':
+		pos = cur
+		for i := 0; i < n; i++ {
+			pos = viWordBackward(text, pos)
+		}
+		return pos, viExclusive, pos != cur
+	case 'e':
+		pos = cur
+		for i := 0; i < n; i++ {
+			pos = viWordEnd(text, pos)
+		}
+		return pos, viInclusive, pos != cur
+	case 'j', 'k':
+		col := cur - lineStart
+		pos = lineStart
+		for i := 0; i < n; i++ {
+			if k == 'j' {
+				end := viLineEnd(text, pos)
+				if end == len(text) {
+					return cur, viLinewise, false
+				}
+				pos = end + 1
+			} else {
+				if pos == 0 {
+					return cur, viLinewise, false
+				}
+				pos = viLineStart(text, pos-1)
+			}
+		}
+		return min(pos+col, max(pos, viLineEnd(text, pos)-1)), viLinewise, true
+	case 'G', 'g':
+		line := strings.Count(string(text), "\n")
+		if counted {
+			line = n - 1
+		} else if k == 'g' {
+			line = 0
+		}
+		pos = 0
+		for i := 0; i < line && viLineEnd(text, pos) < len(text); i++ {
+			pos = viLineEnd(text, pos) + 1
+		}
+		return viFirstNonBlank(text, pos), viLinewise, true
+	case 'f', 't':
+		pos = cur
+		for i := 0; i < n; i++ {
+			next := pos + 1
+			found := false
+			for ; next < lineEnd; next++ {
+				if text[next] == char {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return cur, viInclusive, false
+			}
+			pos = next
+		}
+		if k == 't' {
+			pos--
+		}
+		return pos, viInclusive, pos != cur
+	case 'F', 'T':
+		pos = cur
+		for i := 0; i < n; i++ {
+			prev := pos - 1
+			found := false
+			for ; prev >= lineStart; prev-- {
+				if text[prev] == char {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return cur, viExclusive, false
+			}
+			pos = prev
+		}
+		if k == 'T' {
+			pos++
+		}
+		return pos, viExclusive, pos != cur
+	}
+	return cur, viExclusive, false
+}
+
+// apply runs operator op on the text between from and to.
+func (v *ViMode) apply(t *TextInput, text []rune, op rune, from, to int, kind viMotionKind) {
+	start, end := min(from, to), max(from, to)
+	switch kind {
+	case viInclusive:
+		end = min(end+1, len(text))
+	case viLinewise:
+		start, end = viLineStart(text, start), viLineEnd(text, end)
+	}
+	v.register, v.linewise = string(text[start:end]), kind == viLinewise
+
+	switch op {
+	case 'y':
+		if kind != viLinewise {
+			v.setCursor(t, text, start)
+		}
+	case 'd':
+		if kind == viLinewise {
+			// Take a line break with the lines
+			if end < len(text) {
+				end++
+			} else if start > 0 {
+				start--
+			}
+		}
+		v.save(t)
+		text = viSplice(text, start, end, nil)
+		if kind == viLinewise {
+			start = viFirstNonBlank(text, min(start, len(text)))
+		}
+		v.set(t, text, v.clamp(text, start))
+	case 'c':
+		snap := v.snapshot(t)
+		text = viSplice(text, start, end, nil)
+		v.set(t, text, start)
+		v.startInsert(t, snap, start)
+	}
+}
+
+// put inserts the register n times before or after the cursor.
+func (v *ViMode) put(t *TextInput, text []rune, cur int, before bool, n int) {
+	if v.register == "" {
+		return
+	}
+	reg := []rune(strings.Repeat(v.register, n))
+	if v.linewise {
+		reg = []rune(strings.Repeat(v.register+"\n", n))
+		reg = reg[:len(reg)-1]
+		var at int
+		if before {
+			at = viLineStart(text, cur)
+			reg = append(reg, '\n')
+		} else {
+			at = viLineEnd(text, cur)
+			reg = append([]rune{'\n'}, reg...)
+		}
+		v.save(t)
+		text = viSplice(text, at, at, reg)
+		if !before {
+			at++
+		}
+		v.set(t, text, viFirstNonBlank(text, at))
+		return
+	}
+	at := cur
+	if !before && cur < viLineEnd(text, cur) {
+		at++
+	}
+	v.save(t)
+	text = viSplice(text, at, at, reg)
+	v.set(t, text, at+len(reg)-1)
+}
+
+// join joins n lines below the cursor's line onto it, separated by spaces.
+func (v *ViMode) join(t *TextInput, text []rune, cur int, n int) {
+	changed := false
+	pos := cur
+	for i := 0; i < n; i++ {
+		end := viLineEnd(text, cur)
+		if end == len(text) {
+			break
+		}
+		if !changed {
+			v.save(t)
+			changed = true
+		}
+		next := end + 1
+		for next < len(text) && (text[next] == ' ' || text[next] == '\t') {
+			next++
+		}
+		sep := []rune{' '}
+		if end == viLineStart(text, cur) || next == len(text) || text[next] == '\n' {
+			sep = nil
+		}
+		text = viSplice(text, end, next, sep)
+		pos = end
+	}
+	if changed {
+		v.set(t, text, v.clamp(text, pos))
+	}
+}
+
+// replaceChars replaces the count characters at the cursor with char.
+func (v *ViMode) replaceChars(t *TextInput, text []rune, cur int, char rune) {
+	n, _ := v.counts()
+	if cur+n > viLineEnd(text, cur) {
+		return
+	}
+	v.save(t)
+	text = viSplice(text, cur, cur+n, []rune(strings.Repeat(string(char), n)))
+	v.set(t, text, cur+n-1)
+}
+
+// startInsert switches to insert mode at pos. snap is the state to return
+// to if the insert is undone.
+func (v *ViMode) startInsert(t *TextInput, snap viSnapshot, pos int) {
+	v.state = ViInsert
+	v.insertFrom = snap
+	t.ClearSelection()
+	v.setCursor(t, []rune(t.DisplayText()), pos)
+}
+
+// leaveInsert switches to normal mode, recording the insert for undo and
+// moving the cursor onto the last character inserted, as vi does.
+func (v *ViMode) leaveInsert(t *TextInput) {
+	v.state = ViNormal
+	v.reset()
+	t.expandPastes()
+	if t.Value() != v.insertFrom.text {
+		v.undo = append(v.undo, v.insertFrom)
+		v.redo = nil
+	}
+	text := []rune(t.DisplayText())
+	cur := utf8.RuneCountInString(t.DisplayText()[:t.CursorPos])
+	if cur > viLineStart(text, cur) {
+		cur--
+	}
+	v.setCursor(t, text, cur)
+}
+
+// updateSelection highlights the visual selection, which includes the
+// characters at both ends.
+func (v *ViMode) updateSelection(t *TextInput) {
+	if v.state != ViVisual {
+		return
+	}
+	display := t.DisplayText()
+	text := []rune(display)
+	cur := utf8.RuneCountInString(display[:t.CursorPos])
+	start, end := min(v.anchor, cur), min(max(v.anchor, cur)+1, len(text))
+	t.SetSelection(len(string(text[:start])), len(string(text[:end])))
+}
+
+func (v *ViMode) snapshot(t *TextInput) viSnapshot {
+	return viSnapshot{text: t.Value(), cursor: t.CursorPos}
+}
+
+// save records the current state for undo before a change.
+func (v *ViMode) save(t *TextInput) {
+	v.undo = append(v.undo, v.snapshot(t))
+	v.redo = nil
+}
+
+// restore returns to the last snapshot on stack, removing it.
+func (v *ViMode) restore(t *TextInput, stack *[]viSnapshot) {
+	snap := (*stack)[len(*stack)-1]
+	*stack = (*stack)[:len(*stack)-1]
+	t.SetValue(snap.text)
+	cur := utf8.RuneCountInString(snap.text[:min(snap.cursor, len(snap.text))])
+	v.setCursor(t, []rune(snap.text), cur)
+	if t.OnChange != nil {
+		t.OnChange(t.Value())
+	}
+}
+
+// set replaces the input's value with text and puts the cursor at rune
+// index cur.
+func (v *ViMode) set(t *TextInput, text []rune, cur int) {
+	value := string(text)
+	if t.MaxLength > 0 && len(text) > t.MaxLength {
+		return
+	}
+	changed := value != t.Value()
+	t.SetValue(value)
+	t.CursorPos = len(string(text[:cur]))
+	if changed && t.OnChange != nil {
+		t.OnChange(value)
+	}
+}
+
+// setCursor moves the cursor to rune index cur, keeping it on a character
+// outside insert mode.
+func (v *ViMode) setCursor(t *TextInput, text []rune, cur int) {
+	if v.state != ViInsert {
+		cur = v.clamp(text, cur)
+	}
+	t.CursorPos = len(string(text[:cur]))
+	t.MarkDirty()
+}
+
+// clamp keeps the cursor off line breaks and the end of the text, as in
+// normal mode, unless the line is empty.
+func (v *ViMode) clamp(text []rune, cur int) int {
+	cur = max(0, min(cur, len(text)))
+	if (cur == len(text) || text[cur] == '\n') && cur > viLineStart(text, cur) {
+		cur--
+	}
+	return cur
+}
+
+// viSplice replaces text[start:end] with insert.
+func viSplice(text []rune, start, end int, insert []rune) []rune {
+	out := make([]rune, 0, len(text)-(end-start)+len(insert))
+	out = append(out, text[:start]...)
+	out = append(out, insert...)
+	return append(out, text[end:]...)
+}
+
+// viToggleCase switches the case of text[start:end].
+func viToggleCase(text []rune, start, end int) []rune {
+	out := append([]rune(nil), text...)
+	for i := start; i < end; i++ {
+		if unicode.IsUpper(out[i]) {
+			out[i] = unicode.ToLower(out[i])
+		} else {
+			out[i] = unicode.ToUpper(out[i])
+		}
+	}
+	return out
+}
+
+// viLineStart returns the index of the start of the line holding i.
+func viLineStart(text []rune, i int) int {
+	for i > 0 && text[i-1] != '\n' {
+		i--
+	}
+	return i
+}
+
+// viLineEnd returns the index of the line break ending the line holding i,
+// or len(text).
+func viLineEnd(text []rune, i int) int {
+	for i < len(text) && text[i] != '\n' {
+		i++
+	}
+	return i
+}
+
+// viFirstNonBlank returns the index of the first non-blank character of
+// the line holding i.
+func viFirstNonBlank(text []rune, i int) int {
+	i = viLineStart(text, i)
+	for i < len(text) && (text[i] == ' ' || text[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+// viClass classifies characters for word motions: 0 for blanks, 1 for word
+// characters, 2 for other characters. Words are runs of one class.
+func viClass(r rune) int {
+	switch {
+	case unicode.IsSpace(r):
+		return 0
+	case isWordChar(r) || unicode.IsLetter(r) || unicode.IsDigit(r):
+		return 1
+	}
+	return 2
+}
+
+// viWordForward returns the start of the next word after i.
+func viWordForward(text []rune, i int) int {
+	if i >= len(text) {
+		return i
+	}
+	if c := viClass(text[i]); c != 0 {
+		for i < len(text) && viClass(text[i]) == c {
+			i++
+		}
+	}
+	for i < len(text) && viClass(text[i]) == 0 {
+		i++
+	}
+	return i
+}
+
+// viWordBackward returns the start of the word before i.
+func viWordBackward(text []rune, i int) int {
+	i--
+	for i > 0 && viClass(text[i]) == 0 {
+		i--
+	}
+	if i <= 0 {
+		return 0
+	}
+	c := viClass(text[i])
+	for i > 0 && viClass(text[i-1]) == c {
+		i--
+	}
+	return i
+}
+
+// viWordEnd returns the end of the word after i.
+func viWordEnd(text []rune, i int) int {
+	i++
+	for i < len(text) && viClass(text[i]) == 0 {
+		i++
+	}
+	if i >= len(text) {
+		return max(0, len(text)-1)
+	}
+	c := viClass(text[i])
+	for i+1 < len(text) && viClass(text[i+1]) == c {
+		i++
+	}
+	return i
+}
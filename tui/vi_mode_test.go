@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+// viInput returns a focused input holding value in vi normal mode, with the
+// cursor at the start.
+func viInput(value string) (*TextInput, *ViMode) {
+	vi := NewViMode()
+	input := NewTextInput().WithKeyHandler(vi)
+	input.SetFocused(true)
+	input.SetValue(value)
+	input.HandleKey(KeyEvent{Key: KeyEscape})
+	input.CursorPos = 0
+	return input, vi
+}
+
+// viType sends each rune of keys to input, with Escape for '\x1b'.
+func viType(input *TextInput, keys string) {
+	for _, r := range keys {
+		if r == '\x1b' {
+			input.HandleKey(KeyEvent{Key: KeyEscape})
+		} else {
+			input.HandleKey(KeyEvent{Rune: r})
+		}
+	}
+}
+
+func TestViMode_Modes(t *testing.T) {
+	vi := NewViMode()
+	input := NewTextInput().WithKeyHandler(vi)
+	input.SetFocused(true)
+
+	viType(input, "hello")
+	assert.Equal(t, ViInsert, vi.State())
+	assert.Equal(t, "hello", input.Value())
+
+	viType(input, "\x1b")
+	assert.Equal(t, ViNormal, vi.State())
+	assert.Equal(t, 4, input.CursorPos)
+
+	// Letters are commands in normal mode
+	viType(input, "0x")
+	assert.Equal(t, "ello", input.Value())
+	viType(input, "A!\x1b")
+	assert.Equal(t, "ello!", input.Value())
+	assert.Equal(t, "NORMAL", vi.State().String())
+
+	// Escape with nothing pending is left to the application
+	assert.False(t, input.HandleKey(KeyEvent{Key: KeyEscape}))
+}
+
+func TestViMode_Motions(t *testing.T) {
+	input, _ := viInput("foo.bar baz qux")
+
+	viType(input, "w")
+	assert.Equal(t, 3, input.CursorPos)
+	viType(input, "2w")
+	assert.Equal(t, 8, input.CursorPos)
+	viType(input, "e")
+	assert.Equal(t, 10, input.CursorPos)
+	viType(input, "b")
+	assert.Equal(t, 8, input.CursorPos)
+	viType(input, "$")
+	assert.Equal(t, 14, input.CursorPos)
+	viType(input, "0fb")
+	assert.Equal(t, 4, input.CursorPos)
+	viType(input, ";")
+	assert.Equal(t, 8, input.CursorPos)
+	viType(input, ",")
+	assert.Equal(t, 4, input.CursorPos)
+	viType(input, "tz")
+	assert.Equal(t, 9, input.CursorPos)
+}
+
+func TestViMode_Operators(t *testing.T) {
+	input, _ := viInput("one two three four")
+
+	viType(input, "dw")
+	assert.Equal(t, "two three four", input.Value())
+	viType(input, "2dw")
+	assert.Equal(t, "four", input.Value())
+
+	input.SetValue("one two three")
+	viType(input, "\x1b0cwuno\x1b")
+	assert.Equal(t, "uno two three", input.Value())
+	viType(input, "wyep")
+	assert.Equal(t, "uno ttwowo three", input.Value())
+
+	viType(input, "u")
+	assert.Equal(t, "uno two three", input.Value())
+	viType(input, "u")
+	assert.Equal(t, "one two three", input.Value())
+	input.HandleKey(KeyEvent{Key: KeyCtrlR})
+	assert.Equal(t, "uno two three", input.Value())
+
+	viType(input, "$D")
+	assert.Equal(t, "uno two thre", input.Value())
+	viType(input, "0dt ")
+	assert.Equal(t, " two thre", input.Value())
+	viType(input, "x~")
+	assert.Equal(t, "Two thre", input.Value())
+	viType(input, "rW")
+	assert.Equal(t, "TWo thre", input.Value())
+	viType(input, "03rx")
+	assert.Equal(t, "xxx thre", input.Value())
+}
+
+func TestViMode_Lines(t *testing.T) {
+	input, _ := viInput("alpha\nbeta\ngamma")
+	input.WithMultilineMode(true)
+
+	viType(input, "jdd")
+	assert.Equal(t, "alpha\ngamma", input.Value())
+	viType(input, "P")
+	assert.Equal(t, "alpha\nbeta\ngamma", input.Value())
+	viType(input, "Gyyggp")
+	assert.Equal(t, "alpha\ngamma\nbeta\ngamma", input.Value())
+
+	viType(input, "ggJ")
+	assert.Equal(t, "alpha gamma\nbeta\ngamma", input.Value())
+	viType(input, "onew\x1b")
+	assert.Equal(t, "alpha gamma\nnew\nbeta\ngamma", input.Value())
+	viType(input, "2ddu")
+	assert.Equal(t, "alpha gamma\nnew\nbeta\ngamma", input.Value())
+	viType(input, "Gdk")
+	assert.Equal(t, "alpha gamma\nnew", input.Value())
+}
+
+func TestViMode_Visual(t *testing.T) {
+	input, vi := viInput("hello brave world")
+
+	viType(input, "wve")
+	assert.Equal(t, ViVisual, vi.State())
+	assert.Equal(t, [2]int{6, 11}, input.selection)
+
+	viType(input, "d")
+	assert.Equal(t, ViNormal, vi.State())
+	assert.Equal(t, "hello  world", input.Value())
+	assert.Equal(t, [2]int{}, input.selection)
+
+	viType(input, "0vly$p")
+	assert.Equal(t, "hello  worldhe", input.Value())
+	viType(input, "0v2ls__\x1b")
+	assert.Equal(t, "__lo  worldhe", input.Value())
+}
+
+func TestViMode_InputField(t *testing.T) {
+	var value, submitted string
+	vi := NewViMode()
+	screen := SprintScreen(InputField(&value).KeyHandler(vi).OnSubmit(func(s string) { submitted = s }), PrintConfig{Width: 20, Height: 1})
+	assert.Equal(t, "", screen.Row(0))
+
+	state := inputRegistry.inputs[generateInputID(&value)]
+	state.SetFocused(true)
+	for _, r := range "hi there" {
+		state.HandleKeyEvent(KeyEvent{Rune: r})
+	}
+	state.HandleKeyEvent(KeyEvent{Key: KeyEscape})
+	state.HandleKeyEvent(KeyEvent{Rune: 'b'})
+	state.HandleKeyEvent(KeyEvent{Rune: 'D'})
+	assert.Equal(t, "hi ", value)
+
+	state.HandleKeyEvent(KeyEvent{Key: KeyEnter})
+	assert.Equal(t, "hi ", submitted)
+}
@@ -0,0 +1,395 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// ViewTemplate is a view tree described as data, so dashboards and status
+// bars can be customized without recompiling. Templates are written in JSON
+// (convert YAML to JSON first if you prefer to author in YAML):
+//
+//	{
+//	  "type": "stack", "border": "rounded", "title": "System",
+//	  "children": [
+//	    {"type": "text", "text": "Host: {{.host}}", "style": "bold"},
+//	    {"type": "progress", "value": "cpu", "total": 100, "label": "CPU"},
+//	    {"type": "text", "text": "[red]{{.alerts}} alerts[/]", "markup": true, "if": "alerts"},
+//	    {"type": "spacer"},
+//	    {"type": "statusbar", "text": "updated {{.updated}}"}
+//	  ]
+//	}
+//
+// Text, Title, and Label are Go text/template strings executed against the
+// state map passed to Build, so {{.name}} inserts a state value and
+// {{printf "%.1f" .load}} formats one. Referencing a missing state value is
+// an error.
+//
+// Node types are stack, group, zstack, text, spacer, divider, progress,
+// header, and statusbar. The remaining fields apply to any node where they
+// make sense; see the field comments.
+type ViewTemplate struct {
+	Type     string          `json:"type"`
+	Children []*ViewTemplate `json:"children,omitempty"` // stack, group, zstack
+
+	Text   string `json:"text,omitempty"`   // text, header, statusbar (template)
+	Style  string `json:"style,omitempty"`  // ParseStyle description
+	Markup bool   `json:"markup,omitempty"` // text: parse inline style tags
+	Wrap   bool   `json:"wrap,omitempty"`   // text: wrap instead of truncating
+
+	Value string  `json:"value,omitempty"` // progress: name of the state value
+	Total float64 `json:"total,omitempty"` // progress: value at 100% (default 100)
+	Label string  `json:"label,omitempty"` // progress label (template)
+
+	Gap   int    `json:"gap,omitempty"`   // stack, group: spacing between children
+	Align string `json:"align,omitempty"` // stack, group, zstack, text: left, center, or right
+	Flex  int    `json:"flex,omitempty"`  // stack, group, text: flex factor
+
+	Border  string `json:"border,omitempty"`  // single, rounded, double, thick, or ascii
+	Title   string `json:"title,omitempty"`   // border or divider title (template)
+	Padding int    `json:"padding,omitempty"` // padding on all sides
+	Width   int    `json:"width,omitempty"`   // fixed width
+	Height  int    `json:"height,omitempty"`  // fixed height
+
+	If     string `json:"if,omitempty"`     // include only when this state value is truthy
+	Hidden string `json:"hidden,omitempty"` // keep space but draw nothing when this state value is truthy
+
+	style              Style
+	text, title, label *template.Template
+	alignment          Alignment
+	border             *BorderStyle
+	compiled           bool
+}
+
+var templateBorders = map[string]*BorderStyle{
+	"single":  &SingleBorder,
+	"rounded": &RoundedBorder,
+	"double":  &DoubleBorder,
+	"thick":   &ThickBorder,
+	"ascii":   &ASCIIBorder,
+}
+
+// ParseViewTemplate parses and validates a JSON view template.
+func ParseViewTemplate(data []byte) (*ViewTemplate, error) {
+	var t ViewTemplate
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("parse view template: %w", err)
+	}
+	if err := t.compile(t.Type); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// LoadViewTemplate reads and parses a JSON view template file.
+func LoadViewTemplate(path string) (*ViewTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	t, err := ParseViewTemplate(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+// compile validates the node and its children and prepares styles and
+// text templates. path identifies the node in error messages.
+func (t *ViewTemplate) compile(path string) error {
+	fail := func(format string, args ...any) error {
+		return fmt.Errorf("view template %s: %s", path, fmt.Sprintf(format, args...))
+	}
+
+	switch t.Type {
+	case "stack", "group", "zstack":
+	case "text", "spacer", "divider", "progress", "header", "statusbar":
+		if len(t.Children) > 0 {
+			return fail("%s cannot have children", t.Type)
+		}
+	case "":
+		return fail("missing type")
+	default:
+		return fail("unknown type %q", t.Type)
+	}
+	if t.Type == "progress" && t.Value == "" {
+		return fail("progress requires a value")
+	}
+
+	var err error
+	if t.style, err = ParseStyle(t.Style); err != nil {
+		return fail("%v", err)
+	}
+
+	switch strings.ToLower(t.Align) {
+	case "", "left":
+		t.alignment = AlignLeft
+	case "center":
+		t.alignment = AlignCenter
+	case "right":
+		t.alignment = AlignRight
+	default:
+		return fail("unknown align %q", t.Align)
+	}
+
+	if t.Border != "" {
+		border, ok := templateBorders[strings.ToLower(t.Border)]
+		if !ok {
+			return fail("unknown border %q", t.Border)
+		}
+		t.border = border
+	}
+
+	for _, s := range []struct {
+		name string
+		src  string
+		dst  **template.Template
+	}{
+		{"text", t.Text, &t.text},
+		{"title", t.Title, &t.title},
+		{"label", t.Label, &t.label},
+	} {
+		if s.src == "" {
+			continue
+		}
+		tmpl, err := template.New(s.name).Option("missingkey=error").Parse(s.src)
+		if err != nil {
+			return fail("%v", err)
+		}
+		*s.dst = tmpl
+	}
+
+	for i, child := range t.Children {
+		if child == nil {
+			return fail("children[%d] is null", i)
+		}
+		if err := child.compile(fmt.Sprintf("%s.children[%d](%s)", path, i, child.Type)); err != nil {
+			return err
+		}
+	}
+	t.compiled = true
+	return nil
+}
+
+// Build creates the view tree using the given state. Call it from View() on
+// every frame so the view reflects the current state.
+func (t *ViewTemplate) Build(state map[string]any) (View, error) {
+	if !t.compiled {
+		if err := t.compile(t.Type); err != nil {
+			return nil, err
+		}
+	}
+	if t.If != "" && !truthy(state[t.If]) {
+		return Empty(), nil
+	}
+
+	view, err := t.buildNode(state)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Padding > 0 {
+		view = Padding(t.Padding, view)
+	}
+	if t.border != nil {
+		title, err := execTemplate(t.title, state)
+		if err != nil {
+			return nil, err
+		}
+		bordered := Bordered(view).Border(t.border)
+		if title != "" && t.Type != "divider" { // a divider draws its own title
+			bordered = bordered.Title(title)
+		}
+		view = bordered
+	}
+	switch {
+	case t.Width > 0 && t.Height > 0:
+		view = Size(t.Width, t.Height, view)
+	case t.Width > 0:
+		view = Width(t.Width, view)
+	case t.Height > 0:
+		view = Height(t.Height, view)
+	}
+	if t.Hidden != "" {
+		view = Hidden(truthy(state[t.Hidden]), view)
+	}
+	return view, nil
+}
+
+func (t *ViewTemplate) buildChildren(state map[string]any) ([]View, error) {
+	children := make([]View, 0, len(t.Children))
+	for _, child := range t.Children {
+		view, err := child.Build(state)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, view)
+	}
+	return children, nil
+}
+
+func (t *ViewTemplate) buildNode(state map[string]any) (View, error) {
+	text, err := execTemplate(t.text, state)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.Type {
+	case "stack":
+		children, err := t.buildChildren(state)
+		if err != nil {
+			return nil, err
+		}
+		return Stack(children...).Gap(t.Gap).Align(t.alignment).Flex(t.Flex), nil
+	case "group":
+		children, err := t.buildChildren(state)
+		if err != nil {
+			return nil, err
+		}
+		return Group(children...).Gap(t.Gap).Align(t.alignment).Flex(t.Flex), nil
+	case "zstack":
+		children, err := t.buildChildren(state)
+		if err != nil {
+			return nil, err
+		}
+		return ZStack(children...).Align(t.alignment), nil
+	case "text":
+		view := Text("%s", text).Style(t.style).Align(t.alignment).Flex(t.Flex)
+		if t.Markup {
+			view = view.Markup()
+		}
+		if t.Wrap {
+			view = view.Wrap()
+		}
+		return view, nil
+	case "spacer":
+		return Spacer(), nil
+	case "divider":
+		title, err := execTemplate(t.title, state)
+		if err != nil {
+			return nil, err
+		}
+		view := Divider().Title(title)
+		if t.Style != "" {
+			view = view.Style(t.style)
+		}
+		return view, nil
+	case "header":
+		view := HeaderBar(text)
+		if t.Style != "" {
+			view = view.Style(t.style)
+		}
+		return view, nil
+	case "statusbar":
+		view := StatusBar(text)
+		if t.Style != "" {
+			view = view.Style(t.style)
+		}
+		return view, nil
+	case "progress":
+		value, ok := toFloat(state[t.Value])
+		if !ok {
+			return nil, fmt.Errorf("view template: progress value %q is not a number", t.Value)
+		}
+		total := t.Total
+		if total <= 0 {
+			total = 100
+		}
+		// Progress works in whole units; scale so fractional values show
+		const scale = 1000
+		view := Progress(int(value/total*scale), scale)
+		if t.Style != "" {
+			view = view.Style(t.style)
+		}
+		label, err := execTemplate(t.label, state)
+		if err != nil {
+			return nil, err
+		}
+		if label != "" {
+			view = view.Label(label)
+		}
+		if t.Width > 0 {
+			view = view.Width(t.Width)
+		}
+		return view, nil
+	}
+	return nil, fmt.Errorf("view template: unknown type %q", t.Type)
+}
+
+// TemplateView builds a template with the given state, showing the error
+// in place of the view if it fails.
+//
+// Example:
+//
+//	func (app *App) View() tui.View {
+//	    return tui.TemplateView(app.dashboard, map[string]any{"cpu": app.cpu})
+//	}
+func TemplateView(t *ViewTemplate, state map[string]any) View {
+	view, err := t.Build(state)
+	if err != nil {
+		return Text("%s", err.Error()).Error().Wrap()
+	}
+	return view
+}
+
+func execTemplate(tmpl *template.Template, state map[string]any) (string, error) {
+	if tmpl == nil {
+		return "", nil
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, state); err != nil {
+		return "", fmt.Errorf("view template: %w", err)
+	}
+	return b.String(), nil
+}
+
+// truthy reports whether a state value counts as true for "if" and
+// "hidden": true, non-zero numbers, and non-empty strings and collections.
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != "" && v != "false" && v != "0"
+	case []any:
+		return len(v) > 0
+	case map[string]any:
+		return len(v) > 0
+	}
+	if f, ok := toFloat(v); ok {
+		return f != 0
+	}
+	return true
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+const dashboardTemplate = `{
+  "type": "stack",
+  "children": [
+    {"type": "text", "text": "Host: {{.host}}", "style": "bold"},
+    {"type": "group", "gap": 1, "children": [
+      {"type": "text", "text": "Load"},
+      {"type": "text", "text": "{{printf \"%.2f\" .load}}"}
+    ]},
+    {"type": "text", "text": "[red]{{.alerts}} alerts[/]", "markup": true, "if": "alerts"},
+    {"type": "text", "text": "secret", "hidden": "locked"},
+    {"type": "text", "text": "last"}
+  ]
+}`
+
+func TestViewTemplate_Build(t *testing.T) {
+	tmpl, err := ParseViewTemplate([]byte(dashboardTemplate))
+	assert.NoError(t, err)
+
+	view, err := tmpl.Build(map[string]any{"host": "web-1", "load": 0.5, "alerts": 3, "locked": false})
+	assert.NoError(t, err)
+	screen := SprintScreen(view, PrintConfig{Width: 40})
+	assert.Equal(t, "Host: web-1", screen.Row(0))
+	assert.True(t, screen.Cell(0, 0).Style.Bold)
+	assert.Equal(t, "Load 0.50", screen.Row(1))
+	assert.Equal(t, "3 alerts", screen.Row(2))
+	assert.Equal(t, "secret", screen.Row(3))
+	assert.Equal(t, "last", screen.Row(4))
+
+	// "if" removes the node; "hidden" keeps its row
+	view, err = tmpl.Build(map[string]any{"host": "web-1", "load": 1.0, "alerts": 0, "locked": true})
+	assert.NoError(t, err)
+	screen = SprintScreen(view, PrintConfig{Width: 40})
+	assert.Equal(t, "Load 1.00", screen.Row(1))
+	assert.Equal(t, "", screen.Row(2))
+	assert.Equal(t, "last", screen.Row(3))
+}
+
+func TestViewTemplate_BorderAndProgress(t *testing.T) {
+	tmpl, err := ParseViewTemplate([]byte(`{
+		"type": "stack", "border": "rounded", "title": "{{.name}}",
+		"children": [{"type": "progress", "value": "cpu", "total": 200, "width": 10}]
+	}`))
+	assert.NoError(t, err)
+
+	view, err := tmpl.Build(map[string]any{"name": "CPU", "cpu": 100})
+	assert.NoError(t, err)
+	screen := SprintScreen(view, PrintConfig{Width: 40})
+	assert.Contains(t, screen.Row(0), "╭")
+	assert.Contains(t, screen.Row(0), "CPU")
+	assert.Contains(t, screen.Row(1), "50%")
+}
+
+func TestViewTemplate_MissingState(t *testing.T) {
+	tmpl, err := ParseViewTemplate([]byte(`{"type": "text", "text": "{{.missing}}"}`))
+	assert.NoError(t, err)
+	_, err = tmpl.Build(map[string]any{})
+	assert.Error(t, err)
+
+	screen := SprintScreen(TemplateView(tmpl, nil), PrintConfig{Width: 80})
+	assert.Contains(t, screen.Text(), "missing")
+
+	tmpl, err = ParseViewTemplate([]byte(`{"type": "progress", "value": "cpu"}`))
+	assert.NoError(t, err)
+	_, err = tmpl.Build(map[string]any{"cpu": "high"})
+	assert.Error(t, err)
+}
+
+func TestViewTemplate_Validation(t *testing.T) {
+	tests := []struct {
+		json string
+		want string
+	}{
+		{`{"type": "table"}`, `unknown type "table"`},
+		{`{}`, "missing type"},
+		{`{"type": "text", "style": "sparkly"}`, "sparkly"},
+		{`{"type": "stack", "align": "middle"}`, `unknown align "middle"`},
+		{`{"type": "stack", "border": "wavy"}`, `unknown border "wavy"`},
+		{`{"type": "text", "children": [{"type": "text"}]}`, "cannot have children"},
+		{`{"type": "progress"}`, "requires a value"},
+		{`{"type": "text", "text": "{{.x"}`, "unclosed action"},
+		{`{"type": "stack", "children": [{"type": "text"}, {"type": "nope"}]}`, "stack.children[1](nope)"},
+		{`not json`, "parse view template"},
+	}
+	for _, tt := range tests {
+		_, err := ParseViewTemplate([]byte(tt.json))
+		assert.Error(t, err, tt.json)
+		assert.True(t, strings.Contains(err.Error(), tt.want), err.Error())
+	}
+}
+
+func TestLoadViewTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "status.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"type": "statusbar", "text": "{{.mode}}"}`), 0o644))
+
+	tmpl, err := LoadViewTemplate(path)
+	assert.NoError(t, err)
+	view, err := tmpl.Build(map[string]any{"mode": "NORMAL"})
+	assert.NoError(t, err)
+	assert.Contains(t, SprintScreen(view, PrintConfig{Width: 20}).Row(0), "NORMAL")
+
+	_, err = LoadViewTemplate(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestTruthy(t *testing.T) {
+	for _, v := range []any{true, 1, 2.5, "yes", []any{1}, struct{}{}} {
+		assert.True(t, truthy(v), v)
+	}
+	for _, v := range []any{nil, false, 0, 0.0, "", "false", "0", []any{}} {
+		assert.False(t, truthy(v), v)
+	}
+}
@@ -0,0 +1,161 @@
+package tui
+
+import "image"
+
+// hiddenView reserves its inner view's space without drawing it.
+type hiddenView struct {
+	inner  View
+	hidden bool
+}
+
+// Hidden keeps the inner view's allocated space but renders blanks when
+// hidden is true. Unlike If, which removes the view from the layout,
+// toggling Hidden doesn't move the surrounding views. A hidden view draws
+// nothing and registers no click handlers or focusable elements.
+//
+// Example:
+//
+//	Group(
+//	    fileList,
+//	    Hidden(!app.showDetails, detailsPane),
+//	)
+func Hidden(hidden bool, inner View) View {
+	return &hiddenView{inner: inner, hidden: hidden}
+}
+
+func (h *hiddenView) size(maxWidth, maxHeight int) (int, int) {
+	return h.inner.size(maxWidth, maxHeight)
+}
+
+// flex implements the Flexible interface by delegating to the inner view,
+// so a hidden flexible view keeps claiming its share of space.
+func (h *hiddenView) flex() int {
+	if flex, ok := h.inner.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (h *hiddenView) render(ctx *RenderContext) {
+	if !h.hidden {
+		h.inner.render(ctx)
+	}
+}
+
+// opacityView renders its inner view with faded colors.
+type opacityView struct {
+	inner   View
+	opacity float64
+}
+
+// Opacity renders the inner view faded by the given amount, from 0
+// (invisible, like Hidden) to 1 (unchanged). Use it to de-emphasize inactive
+// panes or disabled controls without changing the layout.
+//
+// Terminals have no real transparency: RGB foreground colors drawn on an
+// RGB background are blended toward the background, and all other text is
+// rendered with the dim attribute.
+//
+// Example:
+//
+//	Opacity(0.5, Text("Disabled"))
+func Opacity(opacity float64, inner View) View {
+	if opacity < 0 {
+		opacity = 0
+	}
+	if opacity > 1 {
+		opacity = 1
+	}
+	return &opacityView{inner: inner, opacity: opacity}
+}
+
+func (o *opacityView) size(maxWidth, maxHeight int) (int, int) {
+	return o.inner.size(maxWidth, maxHeight)
+}
+
+// flex implements the Flexible interface by delegating to the inner view.
+func (o *opacityView) flex() int {
+	if flex, ok := o.inner.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (o *opacityView) render(ctx *RenderContext) {
+	switch {
+	case o.opacity <= 0:
+		return
+	case o.opacity >= 1:
+		o.inner.render(ctx)
+		return
+	}
+	frame := &styleRenderFrame{
+		RenderFrame: ctx.RenderFrame(),
+		transform:   func(s Style) Style { return fadeStyle(s, o.opacity) },
+	}
+	o.inner.render(ctx.WithFrame(frame))
+}
+
+// fadeStyle applies opacity to a style. See Opacity.
+func fadeStyle(s Style, opacity float64) Style {
+	if s.FgRGB != nil && s.BgRGB != nil {
+		fg := blendRGB(*s.BgRGB, *s.FgRGB, opacity)
+		s.FgRGB = &fg
+		return s
+	}
+	s.Dim = true
+	s.Bold = false // many terminals can't show bold and dim together
+	return s
+}
+
+// blendRGB mixes from and to, returning from at t=0 and to at t=1.
+func blendRGB(from, to RGB, t float64) RGB {
+	mix := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+	}
+	return NewRGB(mix(from.R, to.R), mix(from.G, to.G), mix(from.B, to.B))
+}
+
+// styleRenderFrame wraps a RenderFrame and rewrites the style of everything
+// drawn through it.
+type styleRenderFrame struct {
+	RenderFrame
+	transform func(Style) Style
+}
+
+func (f *styleRenderFrame) SetCell(x, y int, char rune, style Style) error {
+	return f.RenderFrame.SetCell(x, y, char, f.transform(style))
+}
+
+func (f *styleRenderFrame) PrintStyled(x, y int, text string, style Style) error {
+	return f.RenderFrame.PrintStyled(x, y, text, f.transform(style))
+}
+
+func (f *styleRenderFrame) PrintTruncated(x, y int, text string, style Style) error {
+	return f.RenderFrame.PrintTruncated(x, y, text, f.transform(style))
+}
+
+func (f *styleRenderFrame) FillStyled(x, y, width, height int, char rune, style Style) error {
+	return f.RenderFrame.FillStyled(x, y, width, height, char, f.transform(style))
+}
+
+func (f *styleRenderFrame) Fill(char rune, style Style) error {
+	return f.RenderFrame.Fill(char, f.transform(style))
+}
+
+func (f *styleRenderFrame) PrintHyperlink(x, y int, link Hyperlink) error {
+	link.Style = f.transform(link.Style)
+	return f.RenderFrame.PrintHyperlink(x, y, link)
+}
+
+func (f *styleRenderFrame) PrintHyperlinkFallback(x, y int, link Hyperlink) error {
+	link.Style = f.transform(link.Style)
+	return f.RenderFrame.PrintHyperlinkFallback(x, y, link)
+}
+
+func (f *styleRenderFrame) SubFrame(rect image.Rectangle) RenderFrame {
+	return &styleRenderFrame{
+		RenderFrame: f.RenderFrame.SubFrame(rect),
+		transform:   f.transform,
+	}
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestHidden_PreservesLayout(t *testing.T) {
+	shown := SprintScreen(Stack(Text("top"), Hidden(false, Text("middle")), Text("bottom")), PrintConfig{Width: 20})
+	assert.Equal(t, "middle", shown.Row(1))
+	assert.Equal(t, "bottom", shown.Row(2))
+
+	hidden := SprintScreen(Stack(Text("top"), Hidden(true, Text("middle")), Text("bottom")), PrintConfig{Width: 20})
+	assert.Equal(t, "", hidden.Row(1))
+	assert.Equal(t, "bottom", hidden.Row(2))
+
+	// If removes the view, so the rows below move up
+	removed := SprintScreen(Stack(Text("top"), If(false, Text("middle")), Text("bottom")), PrintConfig{Width: 20})
+	assert.Equal(t, "bottom", removed.Row(1))
+}
+
+func TestHidden_Modifiers(t *testing.T) {
+	screen := SprintScreen(Group(Text("a"), Group(Text("bcd")).Hidden(true), Text("e")), PrintConfig{Width: 20})
+	assert.Equal(t, "a   e", screen.Row(0))
+
+	w, h := Stack(Text("one"), Text("three")).Hidden(true).size(20, 10)
+	assert.Equal(t, 5, w)
+	assert.Equal(t, 2, h)
+}
+
+func TestHidden_KeepsFlex(t *testing.T) {
+	assert.Equal(t, 1, Hidden(true, Spacer()).(Flexible).flex())
+	assert.Equal(t, 0, Hidden(true, Text("x")).(Flexible).flex())
+}
+
+func TestHidden_RegistersNoClicks(t *testing.T) {
+	interactiveRegistry.Clear()
+	clicked := false
+	SprintScreen(Hidden(true, Clickable("click", func() { clicked = true })), PrintConfig{Width: 20})
+	assert.False(t, interactiveRegistry.HandleClick(0, 0))
+	assert.False(t, clicked)
+}
+
+func TestOpacity_Dims(t *testing.T) {
+	screen := SprintScreen(Group(Text("ab").Bold(), Opacity(0.5, Text("cd").Bold())), PrintConfig{Width: 20})
+	assert.False(t, screen.Cell(0, 0).Style.Dim)
+	assert.True(t, screen.Cell(0, 0).Style.Bold)
+	assert.True(t, screen.Cell(2, 0).Style.Dim)
+	assert.False(t, screen.Cell(2, 0).Style.Bold)
+	assert.Equal(t, "abcd", screen.Row(0))
+}
+
+func TestOpacity_Extremes(t *testing.T) {
+	screen := SprintScreen(Stack(Opacity(0, Text("gone")), Opacity(1, Text("kept"))), PrintConfig{Width: 20})
+	assert.Equal(t, "", screen.Row(0))
+	assert.Equal(t, "kept", screen.Row(1))
+	assert.False(t, screen.Cell(0, 1).Style.Dim)
+}
+
+func TestFadeStyle_BlendsRGB(t *testing.T) {
+	style := NewStyle().WithFgRGB(NewRGB(200, 100, 0)).WithBgRGB(NewRGB(0, 0, 0))
+	faded := fadeStyle(style, 0.5)
+	assert.Equal(t, NewRGB(100, 50, 0), *faded.FgRGB)
+	assert.False(t, faded.Dim)
+	// The original style is not modified
+	assert.Equal(t, NewRGB(200, 100, 0), *style.FgRGB)
+}
@@ -0,0 +1,143 @@
+package tui
+
+import "time"
+
+// zoomState carries the zoom target through a render pass. The Zoomable
+// view with the matching ID records its content instead of drawing it.
+type zoomState struct {
+	id   string
+	view View
+}
+
+// zoomableView marks a view that can be zoomed to fill the screen.
+type zoomableView struct {
+	id    string
+	inner View
+}
+
+// Zoomable marks a view that can be temporarily zoomed to fill the whole
+// screen with the Zoom and ToggleZoom commands, for example to maximize a
+// diff or log. While zoomed, the rest of the view tree is not drawn, but the
+// application and its state are untouched; unzooming restores the normal
+// layout.
+//
+// Example:
+//
+//	func (app *App) HandleEvent(e tui.Event) []tui.Cmd {
+//	    if key, ok := e.(tui.KeyEvent); ok && key.Rune == 'z' {
+//	        return []tui.Cmd{tui.ToggleZoom("diff")}
+//	    }
+//	    return nil
+//	}
+//
+//	func (app *App) View() tui.View {
+//	    return tui.Group(
+//	        app.fileList(),
+//	        tui.Zoomable("diff", tui.DiffView(app.diff, "go", &app.scrollY)),
+//	    )
+//	}
+//
+// Zooming applies to full-screen applications run with Run; inline
+// applications ignore it.
+func Zoomable(id string, inner View) View {
+	return &zoomableView{id: id, inner: inner}
+}
+
+func (z *zoomableView) size(maxWidth, maxHeight int) (int, int) {
+	return z.inner.size(maxWidth, maxHeight)
+}
+
+func (z *zoomableView) flex() int {
+	if flex, ok := z.inner.(Flexible); ok {
+		return flex.flex()
+	}
+	return 0
+}
+
+func (z *zoomableView) render(ctx *RenderContext) {
+	if ctx.zoom != nil && ctx.zoom.id == z.id {
+		// Drawn full-screen in a second pass; see renderWithZoom
+		ctx.zoom.view = z.inner
+		return
+	}
+	z.inner.render(ctx)
+}
+
+// renderWithZoom measures and renders view to fill ctx. If zoomID names a
+// Zoomable in the tree, that view's content is then redrawn alone over the
+// whole area. Registries filled by the first pass are cleared so only the
+// zoomed content is clickable and focusable.
+func renderWithZoom(ctx *RenderContext, view View, zoomID string) {
+	width, height := ctx.Size()
+	view.size(width, height)
+	if zoomID == "" {
+		view.render(ctx)
+		return
+	}
+
+	zoom := &zoomState{id: zoomID}
+	view.render(ctx.withZoom(zoom))
+	if zoom.view == nil {
+		// The zoomed view isn't in the tree this frame
+		return
+	}
+
+	if fm := ctx.FocusManager(); fm != nil {
+		fm.Clear()
+	}
+	buttonRegistry.Clear()
+	interactiveRegistry.Clear()
+	inputRegistry.Clear()
+	if ctx.overlays != nil {
+		ctx.overlays.draws = nil
+	}
+	if ctx.cursor != nil {
+		// A hidden input may have placed the cursor
+		*ctx.cursor = cursorRequest{}
+	}
+	ctx.Fill(' ', NewStyle())
+	zoom.view.size(width, height)
+	zoom.view.render(ctx)
+}
+
+// ZoomEvent is produced by the Zoom, ToggleZoom, and Unzoom commands and
+// processed by the Runtime to change which Zoomable view fills the screen.
+type ZoomEvent struct {
+	ID     string    // The Zoomable to zoom, or "" to restore the layout
+	Toggle bool      // Restore the layout if ID is already zoomed
+	Time   time.Time // When the event was created
+}
+
+// Timestamp implements Event.
+func (e ZoomEvent) Timestamp() time.Time { return e.Time }
+
+// Zoom returns a command that zooms the Zoomable view with the given ID to
+// fill the screen.
+func Zoom(id string) Cmd {
+	return func() Event {
+		return ZoomEvent{ID: id, Time: time.Now()}
+	}
+}
+
+// ToggleZoom returns a command that zooms the Zoomable view with the given
+// ID, or restores the normal layout if it is already zoomed.
+func ToggleZoom(id string) Cmd {
+	return func() Event {
+		return ZoomEvent{ID: id, Toggle: true, Time: time.Now()}
+	}
+}
+
+// Unzoom returns a command that restores the normal layout.
+func Unzoom() Cmd {
+	return func() Event {
+		return ZoomEvent{Time: time.Now()}
+	}
+}
+
+// nextZoom returns the zoomed ID after applying e to the current one.
+func (e ZoomEvent) nextZoom(current string) string {
+	if e.Toggle && e.ID == current {
+		return ""
+	}
+	return e.ID
+}
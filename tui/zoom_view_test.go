@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+// sizeProbe records the area it was rendered into.
+type sizeProbe struct {
+	rendered image.Rectangle
+	calls    int
+}
+
+func (p *sizeProbe) size(maxWidth, maxHeight int) (int, int) { return maxWidth, maxHeight }
+func (p *sizeProbe) flex() int                               { return 1 }
+func (p *sizeProbe) render(ctx *RenderContext) {
+	p.rendered = ctx.AbsoluteBounds()
+	p.calls++
+}
+
+func renderZoomTest(t *testing.T, view View, zoomID string) {
+	var buf bytes.Buffer
+	terminal := NewTestTerminal(40, 10, &buf)
+	frame, err := terminal.BeginFrame()
+	assert.NoError(t, err)
+	defer terminal.EndFrame(frame)
+
+	interactiveRegistry.Clear()
+	renderWithZoom(NewRenderContext(frame, 0).WithFocusManager(NewFocusManager()), view, zoomID)
+}
+
+func TestZoomable_RendersFullScreen(t *testing.T) {
+	defer interactiveRegistry.Clear()
+	files, diff := &sizeProbe{}, &sizeProbe{}
+	clicked := false
+	view := Stack(
+		Text("header"),
+		Group(
+			Clickable("files", func() { clicked = true }),
+			Width(10, files),
+			Zoomable("diff", diff),
+		),
+	)
+
+	renderZoomTest(t, view, "")
+	assert.Equal(t, image.Rect(15, 1, 40, 10), diff.rendered)
+	assert.Equal(t, 1, files.calls)
+
+	renderZoomTest(t, view, "diff")
+	assert.Equal(t, image.Rect(0, 0, 40, 10), diff.rendered)
+	assert.Equal(t, 2, diff.calls)
+
+	// Only the zoomed content is clickable
+	assert.False(t, interactiveRegistry.HandleClick(1, 1))
+	assert.False(t, clicked)
+}
+
+func TestZoomable_HidesCursorOfHiddenInput(t *testing.T) {
+	var buf bytes.Buffer
+	terminal := NewTestTerminal(40, 10, &buf)
+	frame, err := terminal.BeginFrame()
+	assert.NoError(t, err)
+	defer terminal.EndFrame(frame)
+
+	name := "wonton"
+	view := Stack(
+		InputField(&name).ID("zoom-name").CursorShape(InputCursorTerminal),
+		Zoomable("diff", &sizeProbe{}),
+	)
+	render := func(zoomID string) cursorRequest {
+		var cursor cursorRequest
+		ctx := NewRenderContext(frame, 0).WithFocusManager(NewFocusManager()).withCursor(&cursor)
+		renderWithZoom(ctx, view, zoomID)
+		return cursor
+	}
+
+	assert.True(t, render("").visible)
+	assert.False(t, inputRegistry.inputs["zoom-name"].FocusBounds().Empty())
+	assert.Equal(t, cursorRequest{}, render("diff"))
+
+	// The hidden input can't be hit
+	assert.True(t, inputRegistry.inputs["zoom-name"].FocusBounds().Empty())
+}
+
+func TestZoomable_MissingTarget(t *testing.T) {
+	diff := &sizeProbe{}
+	view := Stack(Text("header"), Zoomable("diff", diff))
+
+	renderZoomTest(t, view, "log")
+	assert.Equal(t, image.Rect(0, 1, 40, 10), diff.rendered)
+}
+
+func TestZoomEvent_NextZoom(t *testing.T) {
+	assert.Equal(t, "diff", ZoomEvent{ID: "diff", Toggle: true}.nextZoom(""))
+	assert.Equal(t, "", ZoomEvent{ID: "diff", Toggle: true}.nextZoom("diff"))
+	assert.Equal(t, "log", ZoomEvent{ID: "log", Toggle: true}.nextZoom("diff"))
+	assert.Equal(t, "diff", ZoomEvent{ID: "diff"}.nextZoom("diff"))
+	assert.Equal(t, "", ZoomEvent{}.nextZoom("diff"))
+
+	event := Zoom("diff")()
+	assert.Equal(t, "diff", event.(ZoomEvent).ID)
+	assert.True(t, ToggleZoom("diff")().(ZoomEvent).Toggle)
+	assert.Equal(t, "", Unzoom()().(ZoomEvent).ID)
+}
@@ -47,6 +47,15 @@
 //	        fmt.Printf("Removed from line %d: %s\n", line.OldLineNum, line.Content)
 //	    }
 //	}
+//
+// # Three-Way Merge
+//
+// Merge3 merges two versions of a text with their common ancestor, reporting
+// the regions that conflict:
+//
+//	m := unidiff.Merge3(base, ours, theirs)
+//	fmt.Printf("%d conflicts\n", m.Conflicts())
+//	fmt.Print(m.Text())
 package unidiff
 
 import (
@@ -246,6 +255,12 @@ func Parse(diffText string) (*Diff, error) {
 	for scanner.Scan() {
 		line := scanner.Text()
 
+		// Removed and added lines can themselves start with "--- " or
+		// "+++ ", so file headers are only recognized between hunks
+		inHunk := currentHunk != nil &&
+			(oldLineNum < currentHunk.OldStart+currentHunk.OldCount ||
+				newLineNum < currentHunk.NewStart+currentHunk.NewCount)
+
 		if strings.HasPrefix(line, "diff --git") {
 			flushFile()
 			currentFile = &File{}
@@ -258,13 +273,17 @@ func Parse(diffText string) (*Diff, error) {
 				currentFile.OldPath = strings.TrimPrefix(parts[2], "a/")
 				currentFile.NewPath = strings.TrimPrefix(parts[3], "b/")
 			}
-		} else if strings.HasPrefix(line, "--- ") {
-			if currentFile != nil {
-				path := strings.TrimPrefix(line, "--- ")
-				path = strings.TrimPrefix(path, "a/")
-				currentFile.OldPath = path
+		} else if strings.HasPrefix(line, "--- ") && !inHunk {
+			// Plain diff -u output has no "diff --git" line, so a header
+			// outside a file, or after a file's hunks, starts a new file
+			if currentFile == nil || currentHunk != nil || len(currentFile.Hunks) > 0 {
+				flushFile()
+				currentFile = &File{}
 			}
-		} else if strings.HasPrefix(line, "+++ ") {
+			path := strings.TrimPrefix(line, "--- ")
+			path = strings.TrimPrefix(path, "a/")
+			currentFile.OldPath = path
+		} else if strings.HasPrefix(line, "+++ ") && !inHunk {
 			if currentFile != nil {
 				path := strings.TrimPrefix(line, "+++ ")
 				path = strings.TrimPrefix(path, "b/")
@@ -188,6 +188,36 @@ func TestParse_PathWithoutPrefix(t *testing.T) {
 	assert.Equal(t, "file.go", diff.Files[0].NewPath)
 }
 
+func TestParse_PlainUnifiedDiff(t *testing.T) {
+	// diff -u output has no "diff --git" lines
+	diffText := `--- a/one.txt
++++ b/one.txt
+@@ -1,2 +1,2 @@
+-old
++new
+ same
+--- a/two.sql
++++ b/two.sql
+@@ -1,2 +1,1 @@
+--- a comment
++++ counter
+`
+
+	diff, err := Parse(diffText)
+	assert.NoError(t, err)
+	assert.Len(t, diff.Files, 2)
+	assert.Equal(t, "one.txt", diff.Files[0].OldPath)
+	assert.Equal(t, "two.sql", diff.Files[1].NewPath)
+
+	// Lines that look like headers inside a hunk are hunk lines
+	lines := diff.Files[1].Hunks[0].Lines
+	assert.Len(t, lines, 2)
+	assert.Equal(t, LineRemoved, lines[0].Type)
+	assert.Equal(t, "-- a comment", lines[0].Content)
+	assert.Equal(t, LineAdded, lines[1].Type)
+	assert.Equal(t, "++ counter", lines[1].Content)
+}
+
 func TestDiff_Stats(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,295 @@
+package unidiff
+
+import "strings"
+
+// ChunkKind describes how a chunk of a three-way merge changed.
+type ChunkKind int
+
+const (
+	// ChunkUnchanged is a run of lines identical in all three versions.
+	ChunkUnchanged ChunkKind = iota
+
+	// ChunkOurs is a change made only on our side; the merge takes it.
+	ChunkOurs
+
+	// ChunkTheirs is a change made only on their side; the merge takes it.
+	ChunkTheirs
+
+	// ChunkBoth is the same change made on both sides.
+	ChunkBoth
+
+	// ChunkConflict is a region changed differently on each side, which
+	// has to be resolved by hand.
+	ChunkConflict
+)
+
+// String returns "unchanged", "ours", "theirs", "both", or "conflict".
+func (k ChunkKind) String() string {
+	switch k {
+	case ChunkUnchanged:
+		return "unchanged"
+	case ChunkOurs:
+		return "ours"
+	case ChunkTheirs:
+		return "theirs"
+	case ChunkBoth:
+		return "both"
+	case ChunkConflict:
+		return "conflict"
+	default:
+		return "unknown"
+	}
+}
+
+// MergeChunk is a region of a three-way merge, with the lines each version
+// has there. Line slices don't include line endings.
+type MergeChunk struct {
+	Kind   ChunkKind
+	Base   []string
+	Ours   []string
+	Theirs []string
+
+	// BaseStart, OursStart, and TheirsStart are the 0-based indexes of the
+	// chunk's first line in each version.
+	BaseStart   int
+	OursStart   int
+	TheirsStart int
+}
+
+// Merged returns the lines the merge takes for the chunk, or nil for a
+// conflict.
+func (c MergeChunk) Merged() []string {
+	switch c.Kind {
+	case ChunkUnchanged:
+		return c.Base
+	case ChunkOurs, ChunkBoth:
+		return c.Ours
+	case ChunkTheirs:
+		return c.Theirs
+	default:
+		return nil
+	}
+}
+
+// Merge is the result of a three-way merge of two versions of a text with
+// their common ancestor.
+type Merge struct {
+	// Chunks covers all three versions in order.
+	Chunks []MergeChunk
+
+	// Newline reports whether the merged text ends with a newline.
+	Newline bool
+}
+
+// Merge3 merges ours and theirs, two versions of a text changed from base,
+// line by line in the manner of diff3. Changes made on only one side are
+// taken, and regions changed differently on each side are conflicts.
+//
+// Example:
+//
+//	m := unidiff.Merge3(base, ours, theirs)
+//	if m.Conflicts() == 0 {
+//	    os.WriteFile(path, []byte(m.Text()), 0644)
+//	}
+func Merge3(base, ours, theirs string) *Merge {
+	b, o, t := splitLines(base), splitLines(ours), splitLines(theirs)
+	m := &Merge{Newline: strings.HasSuffix(ours, "\n") || (ours == "" && strings.HasSuffix(theirs, "\n"))}
+
+	toOurs, toTheirs := matchLines(b, o), matchLines(b, t)
+	i, j, k := 0, 0, 0
+	for i < len(b) || j < len(o) || k < len(t) {
+		// Lines that are the same in all three versions
+		start := i
+		for i < len(b) && toOurs[i] == j && toTheirs[i] == k {
+			i, j, k = i+1, j+1, k+1
+		}
+		if i > start {
+			m.Chunks = append(m.Chunks, MergeChunk{
+				Kind:      ChunkUnchanged,
+				Base:      b[start:i],
+				Ours:      o[j-(i-start) : j],
+				Theirs:    t[k-(i-start) : k],
+				BaseStart: start, OursStart: j - (i - start), TheirsStart: k - (i - start),
+			})
+			continue
+		}
+
+		// Up to the next base line that both sides kept
+		next := i
+		for next < len(b) && (toOurs[next] < 0 || toTheirs[next] < 0) {
+			next++
+		}
+		endOurs, endTheirs := len(o), len(t)
+		if next < len(b) {
+			endOurs, endTheirs = toOurs[next], toTheirs[next]
+		}
+		chunk := MergeChunk{
+			Base:      b[i:next],
+			Ours:      o[j:endOurs],
+			Theirs:    t[k:endTheirs],
+			BaseStart: i, OursStart: j, TheirsStart: k,
+		}
+		switch {
+		case equalLines(chunk.Ours, chunk.Base):
+			chunk.Kind = ChunkTheirs
+		case equalLines(chunk.Theirs, chunk.Base):
+			chunk.Kind = ChunkOurs
+		case equalLines(chunk.Ours, chunk.Theirs):
+			chunk.Kind = ChunkBoth
+		default:
+			chunk.Kind = ChunkConflict
+		}
+		m.Chunks = append(m.Chunks, chunk)
+		i, j, k = next, endOurs, endTheirs
+	}
+	return m
+}
+
+// Conflicts returns the number of conflicting chunks.
+func (m *Merge) Conflicts() int {
+	n := 0
+	for _, c := range m.Chunks {
+		if c.Kind == ChunkConflict {
+			n++
+		}
+	}
+	return n
+}
+
+// Text returns the merged text. Conflicts are written with diff3-style
+// markers, as git does with merge.conflictStyle set to diff3.
+func (m *Merge) Text() string {
+	var b strings.Builder
+	for _, c := range m.Chunks {
+		if c.Kind == ChunkConflict {
+			b.WriteString(c.Markers("ours", "base", "theirs"))
+			continue
+		}
+		for _, line := range c.Merged() {
+			b.WriteString(line)
+			b.WriteByte('\n')
+		}
+	}
+	text := b.String()
+	if !m.Newline {
+		text = strings.TrimSuffix(text, "\n")
+	}
+	return text
+}
+
+// Markers returns the chunk's lines between diff3-style conflict markers
+// labeled with the given names, each line ending with a newline.
+func (c MergeChunk) Markers(ours, base, theirs string) string {
+	var b strings.Builder
+	section := func(marker string, lines []string) {
+		b.WriteString(marker)
+		b.WriteByte('\n')
+		for _, line := range lines {
+			b.WriteString(line)
+			b.WriteByte('\n')
+		}
+	}
+	section("<<<<<<< "+ours, c.Ours)
+	section("||||||| "+base, c.Base)
+	section("=======", c.Theirs)
+	b.WriteString(">>>>>>> " + theirs + "\n")
+	return b.String()
+}
+
+// splitLines splits text into lines without their line endings. A final
+// newline doesn't start another line.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+// equalLines reports whether a and b hold the same lines.
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// matchLines finds a longest common subsequence of a and b, using Myers'
+// algorithm, and returns the index in b that each line of a is matched
+// with, or -1 for lines only in a.
+func matchLines(a, b []string) []int {
+	match := make([]int, len(a))
+	for i := range match {
+		match[i] = -1
+	}
+
+	// Lines before and after every difference are matched directly
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		match[prefix] = prefix
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		match[len(a)-1-suffix] = len(b) - 1 - suffix
+		suffix++
+	}
+	a, b = a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return match
+	}
+
+	// v[offset+k] is the furthest x reached on diagonal k; trace keeps v
+	// as it was before each edit distance d, for walking back
+	offset := n + m
+	v := make([]int, 2*offset+2)
+	var trace [][]int
+	x, y := 0, 0
+search:
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y = x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x, y = x-1, y-1
+			match[prefix+x] = prefix + y
+		}
+		x, y = prevX, prevY
+	}
+	return match
+}
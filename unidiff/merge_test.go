@@ -0,0 +1,79 @@
+package unidiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestMatchLines(t *testing.T) {
+	a := strings.Split("a b c a b b a", " ")
+	b := strings.Split("c b a b a c", " ")
+	match := matchLines(a, b)
+
+	// Matches are increasing and pair equal lines
+	count, last := 0, -1
+	for i, j := range match {
+		if j < 0 {
+			continue
+		}
+		assert.Equal(t, a[i], b[j])
+		assert.True(t, j > last)
+		last = j
+		count++
+	}
+	assert.Equal(t, 4, count)
+
+	assert.Equal(t, []int{-1, -1}, matchLines([]string{"x", "y"}, nil))
+	assert.Equal(t, []int{0, -1, 1}, matchLines([]string{"x", "y", "z"}, []string{"x", "z"}))
+}
+
+func TestMerge3(t *testing.T) {
+	base := "one\ntwo\nthree\nfour\nfive\n"
+	ours := "one\nTWO\nthree\nfour\nfive\nsix\n"
+	theirs := "zero\none\ntwo\nthree\nFOUR\nfive\n"
+
+	m := Merge3(base, ours, theirs)
+	assert.Equal(t, 0, m.Conflicts())
+	assert.Equal(t, "zero\none\nTWO\nthree\nFOUR\nfive\nsix\n", m.Text())
+
+	var kinds []ChunkKind
+	for _, c := range m.Chunks {
+		kinds = append(kinds, c.Kind)
+	}
+	assert.Equal(t, []ChunkKind{
+		ChunkTheirs, ChunkUnchanged, ChunkOurs, ChunkUnchanged,
+		ChunkTheirs, ChunkUnchanged, ChunkOurs,
+	}, kinds)
+
+	// Changes to neighboring lines overlap, so they conflict
+	m = Merge3(base, ours, "one\ntwo\nthree\nfour\nFIVE\n")
+	assert.Equal(t, 1, m.Conflicts())
+	assert.Equal(t, []string{"five", "six"}, m.Chunks[len(m.Chunks)-1].Ours)
+}
+
+func TestMerge3_Conflict(t *testing.T) {
+	m := Merge3("a\nb\nc\n", "a\nB1\nc\n", "a\nB2\nc\n")
+	assert.Equal(t, 1, m.Conflicts())
+
+	c := m.Chunks[1]
+	assert.Equal(t, ChunkConflict, c.Kind)
+	assert.Equal(t, []string{"b"}, c.Base)
+	assert.Equal(t, []string{"B1"}, c.Ours)
+	assert.Equal(t, []string{"B2"}, c.Theirs)
+	assert.Equal(t, 1, c.OursStart)
+	assert.Nil(t, c.Merged())
+
+	assert.Equal(t, "a\n<<<<<<< ours\nB1\n||||||| base\nb\n=======\nB2\n>>>>>>> theirs\nc\n", m.Text())
+}
+
+func TestMerge3_SameChange(t *testing.T) {
+	m := Merge3("a\nb", "a\nc", "a\nc")
+	assert.Equal(t, 0, m.Conflicts())
+	assert.Equal(t, ChunkBoth, m.Chunks[1].Kind)
+	assert.Equal(t, "a\nc", m.Text())
+
+	assert.Equal(t, "ours\n", Merge3("", "ours\n", "").Text())
+	assert.Equal(t, 0, len(Merge3("", "", "").Chunks))
+}
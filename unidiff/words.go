@@ -0,0 +1,106 @@
+package unidiff
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Span is a byte range [Start, End) within a line.
+type Span struct {
+	Start int
+	End   int
+}
+
+// WordDiff compares a removed line with the added line that replaced it and
+// returns the spans of each that changed, for highlighting within the lines.
+// Lines are compared word by word: runs of letters, digits, and underscores,
+// runs of whitespace, and single punctuation characters. Adjacent changed
+// words, and changed words separated only by whitespace, are merged into one
+// span.
+//
+// When the lines share no words, both results are nil: the whole line
+// changed, and highlighting every word would add nothing.
+//
+// Example:
+//
+//	removed, added := unidiff.WordDiff(`fmt.Println("Hello")`, `log.Println("Hello")`)
+//	// removed = [{0 3}], added = [{0 3}]
+func WordDiff(old, new string) (removed, added []Span) {
+	a, aOffsets := splitWords(old)
+	b, bOffsets := splitWords(new)
+	match := matchLines(a, b)
+
+	matchedB := make([]bool, len(b))
+	common := false
+	for i, j := range match {
+		if j >= 0 {
+			matchedB[j] = true
+			if !isSpace(a[i]) {
+				common = true
+			}
+		}
+	}
+	if !common {
+		return nil, nil
+	}
+
+	changed := func(words []string, offsets []int, matched func(int) bool) []Span {
+		var spans []Span
+		for i, w := range words {
+			// Whitespace between two changed words joins them
+			between := isSpace(w) && i > 0 && i+1 < len(words) && !matched(i-1) && !matched(i+1)
+			if matched(i) && !between {
+				continue
+			}
+			start, end := offsets[i], offsets[i]+len(w)
+			if n := len(spans); n > 0 && spans[n-1].End == start {
+				spans[n-1].End = end
+			} else {
+				spans = append(spans, Span{start, end})
+			}
+		}
+		return spans
+	}
+	removed = changed(a, aOffsets, func(i int) bool { return match[i] >= 0 })
+	added = changed(b, bOffsets, func(i int) bool { return matchedB[i] })
+	return removed, added
+}
+
+// splitWords splits s into words for WordDiff and returns each word's byte
+// offset.
+func splitWords(s string) ([]string, []int) {
+	var words []string
+	var offsets []int
+	class := func(r rune) int {
+		switch {
+		case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			return 1
+		case unicode.IsSpace(r):
+			return 2
+		default:
+			return 0
+		}
+	}
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		end := i + size
+		if c := class(r); c != 0 {
+			for end < len(s) {
+				next, n := utf8.DecodeRuneInString(s[end:])
+				if class(next) != c {
+					break
+				}
+				end += n
+			}
+		}
+		words = append(words, s[i:end])
+		offsets = append(offsets, i)
+		i = end
+	}
+	return words, offsets
+}
+
+func isSpace(word string) bool {
+	r, _ := utf8.DecodeRuneInString(word)
+	return unicode.IsSpace(r)
+}
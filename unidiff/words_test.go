@@ -0,0 +1,35 @@
+package unidiff
+
+import (
+	"testing"
+
+	"github.com/deepnoodle-ai/wonton/assert"
+)
+
+func TestWordDiff(t *testing.T) {
+	removed, added := WordDiff(`	fmt.Println("Hello")`, `	log.Println("Hello, World!")`)
+	assert.Equal(t, []Span{{1, 4}}, removed)
+	assert.Equal(t, []Span{{1, 4}, {19, 27}}, added)
+}
+
+func TestWordDiff_MergesAdjacentWords(t *testing.T) {
+	removed, added := WordDiff("x := a + b", "x := c - d")
+	assert.Equal(t, []Span{{5, 10}}, removed)
+	assert.Equal(t, []Span{{5, 10}}, added)
+}
+
+func TestWordDiff_Unicode(t *testing.T) {
+	removed, added := WordDiff("naïve café", "naïve thé")
+	assert.Equal(t, []Span{{7, 12}}, removed)
+	assert.Equal(t, []Span{{7, 11}}, added)
+}
+
+func TestWordDiff_NothingInCommon(t *testing.T) {
+	removed, added := WordDiff("return nil", "panic(err)")
+	assert.Nil(t, removed)
+	assert.Nil(t, added)
+
+	removed, added = WordDiff("same", "same")
+	assert.Nil(t, removed)
+	assert.Nil(t, added)
+}